@@ -0,0 +1,56 @@
+package main
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics/statsd"
+	"github.com/Azure/ARO-RP/pkg/util/encryption"
+)
+
+// backfillClusterLookups writes the ClusterLookups for existing clusters.
+// Once it has completed successfully, set CLUSTER_LOOKUP_FALLBACK=false on
+// the RP to stop it from falling back to cross-partition queries.
+func backfillClusterLookups(ctx context.Context, log *logrus.Entry) error {
+	if !env.IsLocalDevelopmentMode() {
+		if err := env.ValidateVars("MDM_ACCOUNT", "MDM_NAMESPACE"); err != nil {
+			return err
+		}
+	}
+
+	_env, err := env.NewCore(ctx, log, env.COMPONENT_BACKFILL_LOOKUPS)
+	if err != nil {
+		return err
+	}
+
+	m := statsd.New(ctx, log.WithField("component", "backfill-cluster-lookups"), _env, os.Getenv("MDM_ACCOUNT"), os.Getenv("MDM_NAMESPACE"), os.Getenv("MDM_STATSD_SOCKET"))
+
+	aead, err := encryption.NewAEADWithCore(ctx, _env, env.EncryptionSecretV2Name, env.EncryptionSecretName)
+	if err != nil {
+		return err
+	}
+
+	dbc, err := database.NewDatabaseClientFromEnv(ctx, _env, log, m, aead)
+	if err != nil {
+		return err
+	}
+
+	dbName, err := env.DBName(_env)
+	if err != nil {
+		return err
+	}
+
+	dbOpenShiftClusters, err := database.NewOpenShiftClusters(ctx, dbc, dbName)
+	if err != nil {
+		return err
+	}
+
+	return database.BackfillClusterLookups(ctx, log, dbOpenShiftClusters)
+}
@@ -5,16 +5,20 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/env"
 	pkggateway "github.com/Azure/ARO-RP/pkg/gateway"
-	"github.com/Azure/ARO-RP/pkg/metrics/statsd"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/metrics/sinks"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd/golang"
 	utilnet "github.com/Azure/ARO-RP/pkg/util/net"
 )
@@ -25,7 +29,17 @@ func gateway(ctx context.Context, log *logrus.Entry) error {
 		return err
 	}
 
-	m := statsd.New(ctx, log.WithField("component", "gateway"), _env, os.Getenv("MDM_ACCOUNT"), os.Getenv("MDM_NAMESPACE"), os.Getenv("MDM_STATSD_SOCKET"))
+	m, err := sinks.New(ctx, log.WithField("component", "gateway"), _env, &sinks.Options{
+		MDMAccount:   os.Getenv("MDM_ACCOUNT"),
+		MDMNamespace: os.Getenv("MDM_NAMESPACE"),
+	})
+	if err != nil {
+		return err
+	}
+
+	// egress, revocation and unknown link ID metrics are deliberately
+	// dimensioned per cluster
+	m = metrics.NewCardinalityGuard(log.WithField("component", "gateway"), m, metrics.DefaultMaxDimensionValues, "gateway.cluster.", "gateway.connections.revoked", "gateway.nohost")
 
 	g, err := golang.NewMetrics(log.WithField("component", "gateway"), m)
 	if err != nil {
@@ -64,9 +78,33 @@ func gateway(ctx context.Context, log *logrus.Entry) error {
 		return err
 	}
 
+	var upstreamKeepAlive time.Duration
+	if v := os.Getenv("GATEWAY_UPSTREAM_KEEPALIVE"); v != "" {
+		upstreamKeepAlive, err = time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid GATEWAY_UPSTREAM_KEEPALIVE: %w", err)
+		}
+	}
+
+	var limits pkggateway.Limits
+	for _, l := range []struct {
+		env   string
+		value *int64
+	}{
+		{env: "GATEWAY_CLUSTER_MAX_CONNECTIONS", value: &limits.MaxConnections},
+		{env: "GATEWAY_CLUSTER_MAX_BANDWIDTH", value: &limits.MaxBandwidth},
+	} {
+		if v := os.Getenv(l.env); v != "" {
+			*l.value, err = strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", l.env, err)
+			}
+		}
+	}
+
 	log.Print("listening")
 
-	p, err := pkggateway.NewGateway(ctx, _env, log.WithField("component", "gateway"), log.WithField("component", "gateway-access"), dbGateway, httpsl, httpl, healthListener, os.Getenv("ACR_RESOURCE_ID"), os.Getenv("GATEWAY_DOMAINS"), m)
+	p, err := pkggateway.NewGateway(ctx, _env, log.WithField("component", "gateway"), log.WithField("component", "gateway-access"), dbGateway, httpsl, httpl, healthListener, os.Getenv("ACR_RESOURCE_ID"), os.Getenv("GATEWAY_DOMAINS"), os.Getenv("GATEWAY_PROXY_PROTOCOL_DESTINATIONS"), os.Getenv("GATEWAY_ACCESS_LOG_SAMPLING"), upstreamKeepAlive, limits, m)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,113 @@
+package main
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/hive"
+	"github.com/Azure/ARO-RP/pkg/hive/backfill"
+	"github.com/Azure/ARO-RP/pkg/metrics/statsd"
+	"github.com/Azure/ARO-RP/pkg/util/encryption"
+)
+
+// hiveBackfill adopts existing clusters into Hive.  HIVE_BACKFILL_SHARD sets
+// the shard to adopt clusters onto (default 1), HIVE_BACKFILL_INTERVAL sets
+// the minimum interval between adoptions (default 1m), and
+// HIVE_BACKFILL_CONTINUATION resumes an interrupted run from the last
+// continuation token it logged.
+func hiveBackfill(ctx context.Context, log *logrus.Entry) error {
+	if !env.IsLocalDevelopmentMode() {
+		if err := env.ValidateVars("MDM_ACCOUNT", "MDM_NAMESPACE"); err != nil {
+			return err
+		}
+	}
+
+	interval := time.Minute
+	if v := os.Getenv("HIVE_BACKFILL_INTERVAL"); v != "" {
+		var err error
+		interval, err = time.ParseDuration(v)
+		if err != nil || interval <= 0 {
+			return fmt.Errorf("invalid HIVE_BACKFILL_INTERVAL %q", v)
+		}
+	}
+
+	hiveShard := hive.DefaultShard
+	if v := os.Getenv("HIVE_BACKFILL_SHARD"); v != "" {
+		var err error
+		hiveShard, err = strconv.Atoi(v)
+		if err != nil || hiveShard < 1 {
+			return fmt.Errorf("invalid HIVE_BACKFILL_SHARD %q", v)
+		}
+	}
+
+	_env, err := env.NewCore(ctx, log, env.COMPONENT_HIVE_BACKFILL)
+	if err != nil {
+		return err
+	}
+
+	liveConfig, err := _env.NewLiveConfigManager(ctx)
+	if err != nil {
+		return err
+	}
+
+	adoptByHive, err := liveConfig.AdoptByHive(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !adoptByHive {
+		return errors.New("adoption by hive is disabled in this region")
+	}
+
+	hiveRestConfig, err := liveConfig.HiveRestConfig(ctx, hiveShard)
+	if err != nil {
+		return fmt.Errorf("failed getting RESTConfig for Hive shard %d: %w", hiveShard, err)
+	}
+
+	hiveClusterManager, err := hive.NewFromConfig(log, _env, hiveRestConfig)
+	if err != nil {
+		return err
+	}
+
+	m := statsd.New(ctx, log.WithField("component", "hive-backfill"), _env, os.Getenv("MDM_ACCOUNT"), os.Getenv("MDM_NAMESPACE"), os.Getenv("MDM_STATSD_SOCKET"))
+
+	aead, err := encryption.NewAEADWithCore(ctx, _env, env.EncryptionSecretV2Name, env.EncryptionSecretName)
+	if err != nil {
+		return err
+	}
+
+	dbc, err := database.NewDatabaseClientFromEnv(ctx, _env, log, m, aead)
+	if err != nil {
+		return err
+	}
+
+	dbName, err := env.DBName(_env)
+	if err != nil {
+		return err
+	}
+
+	dbOpenShiftClusters, err := database.NewOpenShiftClusters(ctx, dbc, dbName)
+	if err != nil {
+		return err
+	}
+
+	dbSubscriptions, err := database.NewSubscriptions(ctx, dbc, dbName)
+	if err != nil {
+		return err
+	}
+
+	b := backfill.New(log.WithField("component", "hive-backfill"), dbOpenShiftClusters, dbSubscriptions, hiveClusterManager, hiveShard, interval)
+
+	return b.Run(ctx, os.Getenv("HIVE_BACKFILL_CONTINUATION"))
+}
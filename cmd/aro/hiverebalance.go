@@ -0,0 +1,112 @@
+package main
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/hive"
+	"github.com/Azure/ARO-RP/pkg/hive/rebalance"
+	"github.com/Azure/ARO-RP/pkg/metrics/statsd"
+	"github.com/Azure/ARO-RP/pkg/util/encryption"
+)
+
+// hiveRebalance moves adopted clusters between Hive shards according to the
+// shard weights in ARO_HIVE_SHARD_WEIGHTS.  HIVE_REBALANCE_INTERVAL sets the
+// minimum interval between moves (default 1m), and if HIVE_REBALANCE_DRY_RUN
+// is set, the planned moves are only logged.
+func hiveRebalance(ctx context.Context, log *logrus.Entry) error {
+	if !env.IsLocalDevelopmentMode() {
+		if err := env.ValidateVars("MDM_ACCOUNT", "MDM_NAMESPACE"); err != nil {
+			return err
+		}
+	}
+
+	interval := time.Minute
+	if v := os.Getenv("HIVE_REBALANCE_INTERVAL"); v != "" {
+		var err error
+		interval, err = time.ParseDuration(v)
+		if err != nil || interval <= 0 {
+			return fmt.Errorf("invalid HIVE_REBALANCE_INTERVAL %q", v)
+		}
+	}
+
+	dryRun := os.Getenv("HIVE_REBALANCE_DRY_RUN") != ""
+
+	_env, err := env.NewCore(ctx, log, env.COMPONENT_HIVE_REBALANCE)
+	if err != nil {
+		return err
+	}
+
+	liveConfig, err := _env.NewLiveConfigManager(ctx)
+	if err != nil {
+		return err
+	}
+
+	adoptByHive, err := liveConfig.AdoptByHive(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !adoptByHive {
+		return errors.New("adoption by hive is disabled in this region")
+	}
+
+	weights, err := liveConfig.HiveShardWeights(ctx)
+	if err != nil {
+		return err
+	}
+
+	hiveClusterManagers := map[int]hive.ClusterManager{}
+	for shard := range weights {
+		hiveRestConfig, err := liveConfig.HiveRestConfig(ctx, shard)
+		if err != nil {
+			return fmt.Errorf("failed getting RESTConfig for Hive shard %d: %w", shard, err)
+		}
+
+		hiveClusterManagers[shard], err = hive.NewFromConfig(log, _env, hiveRestConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	m := statsd.New(ctx, log.WithField("component", "hive-rebalance"), _env, os.Getenv("MDM_ACCOUNT"), os.Getenv("MDM_NAMESPACE"), os.Getenv("MDM_STATSD_SOCKET"))
+
+	aead, err := encryption.NewAEADWithCore(ctx, _env, env.EncryptionSecretV2Name, env.EncryptionSecretName)
+	if err != nil {
+		return err
+	}
+
+	dbc, err := database.NewDatabaseClientFromEnv(ctx, _env, log, m, aead)
+	if err != nil {
+		return err
+	}
+
+	dbName, err := env.DBName(_env)
+	if err != nil {
+		return err
+	}
+
+	dbOpenShiftClusters, err := database.NewOpenShiftClusters(ctx, dbc, dbName)
+	if err != nil {
+		return err
+	}
+
+	dbSubscriptions, err := database.NewSubscriptions(ctx, dbc, dbName)
+	if err != nil {
+		return err
+	}
+
+	r := rebalance.New(log.WithField("component", "hive-rebalance"), dbOpenShiftClusters, dbSubscriptions, hiveClusterManagers, weights, interval, dryRun)
+
+	return r.Run(ctx)
+}
@@ -29,6 +29,11 @@ func usage() {
 	fmt.Fprintf(flag.CommandLine.Output(), "  %s update-versions\n", os.Args[0])
 	fmt.Fprintf(flag.CommandLine.Output(), "  %s update-role-sets\n", os.Args[0])
 	fmt.Fprintf(flag.CommandLine.Output(), "  %s mimo-actuator\n", os.Args[0])
+	fmt.Fprintf(flag.CommandLine.Output(), "  %s reencrypt-documents\n", os.Args[0])
+	fmt.Fprintf(flag.CommandLine.Output(), "  %s hive-backfill\n", os.Args[0])
+	fmt.Fprintf(flag.CommandLine.Output(), "  %s hive-rebalance\n", os.Args[0])
+	fmt.Fprintf(flag.CommandLine.Output(), "  %s backfill-cluster-lookups\n", os.Args[0])
+	fmt.Fprintf(flag.CommandLine.Output(), "  %s rotate-certificates config.yaml location\n", os.Args[0])
 	flag.PrintDefaults()
 }
 
@@ -78,6 +83,21 @@ func main() {
 	case "mimo-actuator":
 		checkArgs(1)
 		err = mimoActuator(ctx, log)
+	case "reencrypt-documents":
+		checkArgs(1)
+		err = reencryptDocuments(ctx, log)
+	case "hive-backfill":
+		checkArgs(1)
+		err = hiveBackfill(ctx, log)
+	case "hive-rebalance":
+		checkArgs(1)
+		err = hiveRebalance(ctx, log)
+	case "backfill-cluster-lookups":
+		checkArgs(1)
+		err = backfillClusterLookups(ctx, log)
+	case "rotate-certificates":
+		checkArgs(3)
+		err = rotateCertificates(ctx, log)
 	default:
 		usage()
 		os.Exit(2)
@@ -7,9 +7,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/containers/image/v5/types"
@@ -38,6 +40,169 @@ func getAuth(key string) (*types.DockerAuthConfig, error) {
 	}, nil
 }
 
+// getMirrorOptions returns the default mirror options, overridden by
+// MIRROR_WORKERS and MIRROR_RETRIES if they are set.  If MIRROR_SCANNER is
+// set, images are scanned before they are mirrored, accepting the risks listed
+// in MIRROR_SCAN_ALLOWLIST.  Vulnerable images are quarantined in
+// quarantineRepo if it is not empty.  If MIRROR_RELEASE_KEYRING is set,
+// release payloads are verified against the signatures in
+// MIRROR_SIGNATURE_STORE, and if MIRROR_COSIGN_KEY is set, mirrored images are
+// signed with it.
+func getMirrorOptions(quarantineRepo string) (pkgmirror.Options, error) {
+	options := pkgmirror.DefaultOptions
+
+	if v := os.Getenv("MIRROR_WORKERS"); v != "" {
+		workers, err := strconv.Atoi(v)
+		if err != nil || workers < 1 {
+			return pkgmirror.Options{}, fmt.Errorf("invalid MIRROR_WORKERS %q", v)
+		}
+		options.Workers = workers
+	}
+
+	if v := os.Getenv("MIRROR_RETRIES"); v != "" {
+		retries, err := strconv.Atoi(v)
+		if err != nil || retries < 0 {
+			return pkgmirror.Options{}, fmt.Errorf("invalid MIRROR_RETRIES %q", v)
+		}
+		options.Retries = retries
+	}
+
+	switch v := os.Getenv("MIRROR_SCANNER"); v {
+	case "":
+	case "trivy":
+		options.ScanPolicy = &pkgmirror.ScanPolicy{
+			Scanner:        pkgmirror.NewTrivyScanner(),
+			QuarantineRepo: quarantineRepo,
+		}
+
+		if path := os.Getenv("MIRROR_SCAN_ALLOWLIST"); path != "" {
+			allowlist, err := pkgmirror.ReadAllowlist(path)
+			if err != nil {
+				return pkgmirror.Options{}, err
+			}
+			options.ScanPolicy.Allowlist = allowlist
+		}
+	default:
+		return pkgmirror.Options{}, fmt.Errorf("invalid MIRROR_SCANNER %q", v)
+	}
+
+	if path := os.Getenv("MIRROR_RELEASE_KEYRING"); path != "" {
+		keyring, err := os.ReadFile(path)
+		if err != nil {
+			return pkgmirror.Options{}, err
+		}
+
+		store := os.Getenv("MIRROR_SIGNATURE_STORE")
+		if store == "" {
+			store = pkgmirror.DefaultReleaseSignatureStore
+		}
+
+		options.ReleaseVerifier, err = pkgmirror.NewReleaseVerifier(keyring, store)
+		if err != nil {
+			return pkgmirror.Options{}, err
+		}
+	}
+
+	if key := os.Getenv("MIRROR_COSIGN_KEY"); key != "" {
+		options.Signer = pkgmirror.NewCosignSigner(key)
+	}
+
+	return options, nil
+}
+
+// getReleases returns the OCP releases given on the command line, or all
+// releases from 4.12 in the release graph if there are none.
+func getReleases(log *logrus.Entry) ([]pkgmirror.Node, error) {
+	var releases []pkgmirror.Node
+	var err error
+
+	if len(flag.Args()) == 1 {
+		log.Print("reading release graph")
+		releases, err = pkgmirror.AddFromGraph(version.NewVersion(4, 12))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, arg := range flag.Args()[1:] {
+			if strings.EqualFold(arg, "latest") {
+				releases = append(releases, pkgmirror.Node{
+					Version: version.DefaultInstallStream.Version.String(),
+					Payload: version.DefaultInstallStream.PullSpec,
+				})
+			} else {
+				vers, err := version.ParseVersion(arg)
+				if err != nil {
+					return nil, err
+				}
+
+				node, err := pkgmirror.VersionInfo(vers)
+				if err != nil {
+					return nil, err
+				}
+
+				releases = append(releases, pkgmirror.Node{
+					Version: node.Version,
+					Payload: node.Payload,
+				})
+			}
+		}
+	}
+
+	return releases, nil
+}
+
+// mirrorChannels mirrors the releases in the given upgrade channels which are
+// new since the last run.  The releases mirrored by each run are recorded in
+// the state file given by MIRROR_STATE_FILE.
+func mirrorChannels(ctx context.Context, log *logrus.Entry, dstrepo string, channels []string, dstAuth, srcAuth *types.DockerAuthConfig, options pkgmirror.Options) error {
+	err := env.ValidateVars("MIRROR_STATE_FILE")
+	if err != nil {
+		return err
+	}
+
+	path := os.Getenv("MIRROR_STATE_FILE")
+
+	state, err := pkgmirror.ReadMirrorState(path)
+	if err != nil {
+		return err
+	}
+
+	err = pkgmirror.MirrorChannels(ctx, log, dstrepo, channels, state, dstAuth, srcAuth, options)
+
+	return errors.Join(err, pkgmirror.WriteMirrorState(path, state))
+}
+
+// diffReleases reports the images of the releases which are missing from the
+// destination or differ from their source, without pushing anything.  It
+// returns an error if there are any.
+func diffReleases(ctx context.Context, log *logrus.Entry, dstrepo string, releases []pkgmirror.Node, dstAuth, srcAuth *types.DockerAuthConfig, options pkgmirror.Options) error {
+	var diffs []string
+
+	for _, release := range releases {
+		if _, ok := doNotMirrorTags[release.Version]; ok {
+			continue
+		}
+
+		log.Printf("comparing release %s", release.Version)
+		imageDiffs, err := pkgmirror.Diff(ctx, log, dstrepo, release.Payload, dstAuth, srcAuth, options)
+		if err != nil {
+			return err
+		}
+
+		for _, d := range imageDiffs {
+			diffs = append(diffs, fmt.Sprintf("%s: %s", release.Version, d))
+		}
+	}
+
+	log.Print("done")
+
+	if diffs != nil {
+		return fmt.Errorf("%d image(s) missing or mismatched\n%s", len(diffs), strings.Join(diffs, "\n"))
+	}
+
+	return nil
+}
+
 func mirror(ctx context.Context, log *logrus.Entry) error {
 	err := env.ValidateVars(
 		"DST_AUTH",
@@ -73,6 +238,32 @@ func mirror(ctx context.Context, log *logrus.Entry) error {
 		return err
 	}
 
+	var quarantineRepo string
+	if v := os.Getenv("MIRROR_QUARANTINE_REPO"); v != "" {
+		quarantineRepo = dstAcr + acrDomainSuffix + "/" + v
+	}
+
+	mirrorOptions, err := getMirrorOptions(quarantineRepo)
+	if err != nil {
+		return err
+	}
+
+	if os.Getenv("MIRROR_DRY_RUN") != "" {
+		releases, err := getReleases(log)
+		if err != nil {
+			return err
+		}
+
+		return diffReleases(ctx, log, dstAcr+acrDomainSuffix, releases, dstAuth, srcAuthQuay, mirrorOptions)
+	}
+
+	srcAuthFor := func(ref string) *types.DockerAuthConfig {
+		if strings.Index(ref, "quay.io") == 0 {
+			return srcAuthQuay
+		}
+		return srcAuthRedhat
+	}
+
 	// We can lose visibility of early image mirroring errors because logs are trimmed in the output of Ev2 pipelines.
 	// If images fail to mirror, those errors need to be returned together and logged at the end of the execution.
 	var imageMirroringErrors []string
@@ -119,60 +310,50 @@ func mirror(ctx context.Context, log *logrus.Entry) error {
 	} {
 		log.Printf("mirroring %s -> %s", ref, pkgmirror.Dest(dstAcr+acrDomainSuffix, ref))
 
-		srcAuth := srcAuthRedhat
-		if strings.Index(ref, "quay.io") == 0 {
-			srcAuth = srcAuthQuay
-		}
-
-		err = pkgmirror.Copy(ctx, pkgmirror.Dest(dstAcr+acrDomainSuffix, ref), ref, dstAuth, srcAuth)
+		err = pkgmirror.Copy(ctx, pkgmirror.Dest(dstAcr+acrDomainSuffix, ref), ref, dstAuth, srcAuthFor(ref))
 		if err != nil {
 			imageMirroringErrors = append(imageMirroringErrors, fmt.Sprintf("%s: %s\n", ref, err))
 		}
 	}
 
 	// OCP release mirroring
-	var releases []pkgmirror.Node
-	if len(flag.Args()) == 1 {
-		log.Print("reading release graph")
-		releases, err = pkgmirror.AddFromGraph(version.NewVersion(4, 12))
+	if channels := os.Getenv("MIRROR_CHANNELS"); channels != "" {
+		err = mirrorChannels(ctx, log, dstAcr+acrDomainSuffix, strings.Split(channels, ","), dstAuth, srcAuthQuay, mirrorOptions)
 		if err != nil {
-			return err
+			imageMirroringErrors = append(imageMirroringErrors, fmt.Sprintf("%s: %s\n", channels, err))
 		}
 	} else {
-		for _, arg := range flag.Args()[1:] {
-			if strings.EqualFold(arg, "latest") {
-				releases = append(releases, pkgmirror.Node{
-					Version: version.DefaultInstallStream.Version.String(),
-					Payload: version.DefaultInstallStream.PullSpec,
-				})
-			} else {
-				vers, err := version.ParseVersion(arg)
-				if err != nil {
-					return err
-				}
-
-				node, err := pkgmirror.VersionInfo(vers)
-				if err != nil {
-					return err
-				}
+		releases, err := getReleases(log)
+		if err != nil {
+			return err
+		}
 
-				releases = append(releases, pkgmirror.Node{
-					Version: node.Version,
-					Payload: node.Payload,
-				})
+		for _, release := range releases {
+			if _, ok := doNotMirrorTags[release.Version]; ok {
+				log.Printf("skipping mirror of release %s", release.Version)
+				continue
+			}
+			log.Printf("mirroring release %s", release.Version)
+			err = pkgmirror.Mirror(ctx, log, dstAcr+acrDomainSuffix, release.Payload, dstAuth, srcAuthQuay, mirrorOptions)
+			if err != nil {
+				imageMirroringErrors = append(imageMirroringErrors, fmt.Sprintf("%s: %s\n", release, err))
 			}
 		}
 	}
 
-	for _, release := range releases {
-		if _, ok := doNotMirrorTags[release.Version]; ok {
-			log.Printf("skipping mirror of release %s", release.Version)
-			continue
-		}
-		log.Printf("mirroring release %s", release.Version)
-		err = pkgmirror.Mirror(ctx, log, dstAcr+acrDomainSuffix, release.Payload, dstAuth, srcAuthQuay)
+	// OLM operator catalog mirroring
+	if path := os.Getenv("MIRROR_OPERATOR_CATALOGS"); path != "" {
+		catalogConfig, err := pkgmirror.ReadCatalogConfig(path)
 		if err != nil {
-			imageMirroringErrors = append(imageMirroringErrors, fmt.Sprintf("%s: %s\n", release, err))
+			return err
+		}
+
+		for i, catalog := range catalogConfig.Catalogs {
+			log.Printf("mirroring catalog %s", catalog.Index)
+			err = pkgmirror.MirrorCatalog(ctx, log, dstAcr+acrDomainSuffix, &catalogConfig.Catalogs[i], dstAuth, srcAuthFor, mirrorOptions)
+			if err != nil {
+				imageMirroringErrors = append(imageMirroringErrors, fmt.Sprintf("%s: %s\n", catalog.Index, err))
+			}
 		}
 	}
 
@@ -13,13 +13,16 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics"
 	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	"github.com/Azure/ARO-RP/pkg/metrics/sinks"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd/azure"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd/golang"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd/k8s"
 	pkgmonitor "github.com/Azure/ARO-RP/pkg/monitor"
 	"github.com/Azure/ARO-RP/pkg/proxy"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
 	"github.com/Azure/ARO-RP/pkg/util/encryption"
 )
 
@@ -41,7 +44,16 @@ func monitor(ctx context.Context, log *logrus.Entry) error {
 		}
 	}
 
-	m := statsd.New(ctx, log.WithField("component", "metrics"), _env, os.Getenv("MDM_ACCOUNT"), os.Getenv("MDM_NAMESPACE"), os.Getenv("MDM_STATSD_SOCKET"))
+	m, err := sinks.New(ctx, log.WithField("component", "metrics"), _env, &sinks.Options{
+		MDMAccount:   os.Getenv("MDM_ACCOUNT"),
+		MDMNamespace: os.Getenv("MDM_NAMESPACE"),
+	})
+	if err != nil {
+		return err
+	}
+
+	// failed and timed out cluster monitors are reported per cluster
+	m = metrics.NewCardinalityGuard(log.WithField("component", "metrics"), m, metrics.DefaultMaxDimensionValues, "monitor.cluster.", "monitor.main.")
 
 	g, err := golang.NewMetrics(log.WithField("component", "metrics"), m)
 	if err != nil {
@@ -51,6 +63,7 @@ func monitor(ctx context.Context, log *logrus.Entry) error {
 	go g.Run()
 
 	tracing.Register(azure.New(m))
+	azureclient.RegisterThrottlingMetrics(m)
 	kmetrics.Register(kmetrics.RegisterOpts{
 		RequestResult:  k8s.NewResult(m),
 		RequestLatency: k8s.NewLatency(m),
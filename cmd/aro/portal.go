@@ -6,17 +6,23 @@ package main
 import (
 	"context"
 	"crypto/x509"
+	"fmt"
 	"net"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/containers/image/v5/docker/reference"
 	"github.com/sirupsen/logrus"
 
 	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/hive"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd/golang"
 	pkgportal "github.com/Azure/ARO-RP/pkg/portal"
+	"github.com/Azure/ARO-RP/pkg/portal/middleware"
+	"github.com/Azure/ARO-RP/pkg/portal/ssh"
 	"github.com/Azure/ARO-RP/pkg/proxy"
 	"github.com/Azure/ARO-RP/pkg/util/encryption"
 	"github.com/Azure/ARO-RP/pkg/util/keyvault"
@@ -34,6 +40,7 @@ func portal(ctx context.Context, log *logrus.Entry, audit *logrus.Entry) error {
 		err := env.ValidateVars(
 			"MDM_ACCOUNT",
 			"MDM_NAMESPACE",
+			"PORTAL_DEBUG_POD_IMAGE",
 			"PORTAL_HOSTNAME")
 
 		if err != nil {
@@ -60,6 +67,16 @@ func portal(ctx context.Context, log *logrus.Entry, audit *logrus.Entry) error {
 		return err
 	}
 
+	stepUp, err := middleware.NewStepUp(os.Getenv("AZURE_PORTAL_STEPUP_AUTH_CONTEXT"), os.Getenv("AZURE_PORTAL_STEPUP_MAX_AGE"))
+	if err != nil {
+		return err
+	}
+
+	debugPodImage, err := parseDebugPodImage(os.Getenv("PORTAL_DEBUG_POD_IMAGE"), _env.IsLocalDevelopmentMode())
+	if err != nil {
+		return err
+	}
+
 	m := statsd.New(ctx, log.WithField("component", "portal"), _env, os.Getenv("MDM_ACCOUNT"), os.Getenv("MDM_NAMESPACE"), os.Getenv("MDM_STATSD_SOCKET"))
 
 	g, err := golang.NewMetrics(log.WithField("component", "portal"), m)
@@ -94,7 +111,13 @@ func portal(ctx context.Context, log *logrus.Entry, audit *logrus.Entry) error {
 		return err
 	}
 
+	dbAsyncOperations, err := database.NewAsyncOperations(ctx, _env.IsLocalDevelopmentMode(), dbc, dbName)
+	if err != nil {
+		return err
+	}
+
 	dbGroup := database.NewDBGroup().
+		WithAsyncOperations(dbAsyncOperations).
 		WithOpenShiftClusters(dbOpenShiftClusters).
 		WithPortal(dbPortal)
 
@@ -132,11 +155,24 @@ func portal(ctx context.Context, log *logrus.Entry, audit *logrus.Entry) error {
 		return err
 	}
 
+	sshSessionRetention := ssh.DefaultSessionRetention
+	if v, found := os.LookupEnv("SSH_SESSION_RETENTION"); found {
+		sshSessionRetention, err = time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid SSH_SESSION_RETENTION: %w", err)
+		}
+	}
+
 	dialer, err := proxy.NewDialer(_env.IsLocalDevelopmentMode())
 	if err != nil {
 		return err
 	}
 
+	hiveClusterManager, err := newPortalHiveClusterManager(ctx, log, _env)
+	if err != nil {
+		return err
+	}
+
 	clientID := os.Getenv("AZURE_PORTAL_CLIENT_ID")
 	verifier, err := oidc.NewVerifier(ctx, _env.Environment().ActiveDirectoryEndpoint+_env.TenantID()+"/v2.0", clientID)
 	if err != nil {
@@ -170,11 +206,37 @@ func portal(ctx context.Context, log *logrus.Entry, audit *logrus.Entry) error {
 
 	log.Printf("listening %s", address)
 
-	p := pkgportal.NewPortal(_env, audit, log.WithField("component", "portal"), log.WithField("component", "portal-access"), l, sshl, verifier, hostname, servingKey, servingCerts, clientID, clientKey, clientCerts, sessionKey, sshKey, groupIDs, elevatedGroupIDs, dbGroup, dialer, m)
+	p := pkgportal.NewPortal(_env, audit, log.WithField("component", "portal"), log.WithField("component", "portal-access"), l, sshl, verifier, hostname, servingKey, servingCerts, clientID, clientKey, clientCerts, sessionKey, sshKey, sshSessionRetention, debugPodImage, groupIDs, elevatedGroupIDs, stepUp, dbGroup, dialer, hiveClusterManager, m)
 
 	return p.Run(ctx)
 }
 
+// newPortalHiveClusterManager returns a nil ClusterManager in regions without
+// Hive, in which case the portal does not show Hive state.
+func newPortalHiveClusterManager(ctx context.Context, log *logrus.Entry, _env env.Core) (hive.ClusterManager, error) {
+	liveConfig, err := _env.NewLiveConfigManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	adoptByHive, err := liveConfig.AdoptByHive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	installViaHive, err := liveConfig.InstallViaHive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !adoptByHive && !installViaHive {
+		log.Info("hive is disabled, skipping creation of ClusterManager")
+		return nil, nil
+	}
+
+	return hive.NewSharded(log, _env, liveConfig), nil
+}
+
 func parseGroupIDs(_groupIDs string) ([]string, error) {
 	groupIDs := strings.Split(_groupIDs, ",")
 	for _, groupID := range groupIDs {
@@ -185,3 +247,24 @@ func parseGroupIDs(_groupIDs string) ([]string, error) {
 	}
 	return groupIDs, nil
 }
+
+// parseDebugPodImage checks that the node debug pod image is a fully qualified
+// pullspec pinned by digest, so that SREs always get the image which was
+// mirrored into the ACR.  In local development it defaults to the upstream
+// image.
+func parseDebugPodImage(image string, isLocalDevelopmentMode bool) (string, error) {
+	if image == "" && isLocalDevelopmentMode {
+		return "registry.access.redhat.com/ubi8/ubi-minimal:latest", nil
+	}
+
+	ref, err := reference.ParseNamed(image)
+	if err != nil {
+		return "", fmt.Errorf("invalid PORTAL_DEBUG_POD_IMAGE %q: %w", image, err)
+	}
+
+	if _, ok := ref.(reference.Canonical); !ok {
+		return "", fmt.Errorf("invalid PORTAL_DEBUG_POD_IMAGE %q: must be pinned by digest", image)
+	}
+
+	return image, nil
+}
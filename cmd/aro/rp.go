@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/Azure/go-autorest/tracing"
 	"github.com/sirupsen/logrus"
@@ -26,23 +28,35 @@ import (
 	_ "github.com/Azure/ARO-RP/pkg/api/v20230904"
 	_ "github.com/Azure/ARO-RP/pkg/api/v20231122"
 	_ "github.com/Azure/ARO-RP/pkg/api/v20240812preview"
+	_ "github.com/Azure/ARO-RP/pkg/api/v20250725"
 	"github.com/Azure/ARO-RP/pkg/backend"
 	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/frontend"
 	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
 	"github.com/Azure/ARO-RP/pkg/hive"
+	pkgmetrics "github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/metrics/otel"
+	"github.com/Azure/ARO-RP/pkg/metrics/sinks"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd/azure"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd/golang"
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd/k8s"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
 	"github.com/Azure/ARO-RP/pkg/util/clusterdata"
 	"github.com/Azure/ARO-RP/pkg/util/encryption"
+	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
 func rp(ctx context.Context, log, audit *logrus.Entry) error {
 	stop := make(chan struct{})
 
+	configSources, err := env.LoadConfig()
+	if err != nil {
+		return err
+	}
+	configSources.Log(log)
+
 	_env, err := env.NewEnv(ctx, log, env.COMPONENT_RP)
 	if err != nil {
 		return err
@@ -86,7 +100,23 @@ func rp(ctx context.Context, log, audit *logrus.Entry) error {
 		return err
 	}
 
-	metrics := statsd.New(ctx, log.WithField("component", "metrics"), _env, os.Getenv("MDM_ACCOUNT"), os.Getenv("MDM_NAMESPACE"), os.Getenv("MDM_STATSD_SOCKET"))
+	otelProvider, err := otel.New(ctx, log.WithField("component", "otel"), _env, "rp")
+	if err != nil {
+		return err
+	}
+
+	metrics, err := sinks.New(ctx, log.WithField("component", "metrics"), _env, &sinks.Options{
+		MDMAccount:   os.Getenv("MDM_ACCOUNT"),
+		MDMNamespace: os.Getenv("MDM_NAMESPACE"),
+		OTel:         otelProvider,
+	})
+	if err != nil {
+		return err
+	}
+
+	// the backend operation metrics are deliberately dimensioned per cluster
+	// and per operation
+	metrics = pkgmetrics.NewCardinalityGuard(log.WithField("component", "metrics"), metrics, pkgmetrics.DefaultMaxDimensionValues, "backend.openshiftcluster.")
 
 	g, err := golang.NewMetrics(log.WithField("component", "metrics"), metrics)
 	if err != nil {
@@ -95,7 +125,8 @@ func rp(ctx context.Context, log, audit *logrus.Entry) error {
 
 	go g.Run()
 
-	tracing.Register(azure.New(metrics))
+	tracing.Register(otelProvider.AzureTracer(azure.New(metrics)))
+	azureclient.RegisterThrottlingMetrics(metrics)
 	kmetrics.Register(kmetrics.RegisterOpts{
 		RequestResult:  k8s.NewResult(metrics),
 		RequestLatency: k8s.NewLatency(metrics),
@@ -122,6 +153,7 @@ func rp(ctx context.Context, log, audit *logrus.Entry) error {
 	if err != nil {
 		return err
 	}
+	dbAsyncOperations = database.NewInstrumentedAsyncOperations(dbAsyncOperations, metrics)
 
 	dbBilling, err := database.NewBilling(ctx, dbc, dbName)
 	if err != nil {
@@ -137,24 +169,69 @@ func rp(ctx context.Context, log, audit *logrus.Entry) error {
 	if err != nil {
 		return err
 	}
+	if v, found := os.LookupEnv("CLUSTER_LOOKUP_FALLBACK"); found {
+		lookupFallback, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid CLUSTER_LOOKUP_FALLBACK: %w", err)
+		}
+		if !lookupFallback {
+			dbOpenShiftClusters.DisableLookupFallback()
+		}
+	}
+	dbOpenShiftClusters = database.NewInstrumentedOpenShiftClusters(dbOpenShiftClusters, metrics)
+
+	tombstoneRetention := database.DefaultOpenShiftClusterTombstoneRetention
+	if v, found := os.LookupEnv("CLUSTER_TOMBSTONE_RETENTION"); found {
+		tombstoneRetention, err = time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid CLUSTER_TOMBSTONE_RETENTION: %w", err)
+		}
+		if tombstoneRetention < time.Second {
+			return fmt.Errorf("invalid CLUSTER_TOMBSTONE_RETENTION: %q must be at least 1s", v)
+		}
+	}
+
+	dbOpenShiftClusterTombstones, err := database.NewOpenShiftClusterTombstones(ctx, dbc, dbName, tombstoneRetention)
+	if err != nil {
+		return err
+	}
 
 	dbSubscriptions, err := database.NewSubscriptions(ctx, dbc, dbName)
 	if err != nil {
 		return err
 	}
+	dbSubscriptions = database.NewInstrumentedSubscriptions(dbSubscriptions, metrics)
 
 	dbOpenShiftVersions, err := database.NewOpenShiftVersions(ctx, dbc, dbName)
 	if err != nil {
 		return err
 	}
 
+	err = database.EnsureDefaultOpenShiftVersion(ctx, dbOpenShiftVersions, &api.OpenShiftVersion{
+		Properties: api.OpenShiftVersionProperties{
+			Version:           version.DefaultInstallStream.Version.String(),
+			OpenShiftPullspec: version.DefaultInstallStream.PullSpec,
+			InstallerPullspec: fmt.Sprintf("%s/aro-installer:release-%s", _env.ACRDomain(), version.DefaultInstallStream.Version.MinorVersion()),
+			Enabled:           true,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
 	// Note: When handling DB operations don't delete records but set TTL on them otherwise if we're leveraging change feeds, it will break.
 	dbPlatformWorkloadIdentityRoleSets, err := database.NewPlatformWorkloadIdentityRoleSets(ctx, dbc, dbName)
 	if err != nil {
 		return err
 	}
 
+	dbSupportedVMSizes, err := database.NewSupportedVMSizes(ctx, dbc, dbName)
+	if err != nil {
+		return err
+	}
+
 	go database.EmitOpenShiftClustersMetrics(ctx, log, dbOpenShiftClusters, metrics)
+	go database.MigrateOpenShiftClusters(ctx, log.WithField("component", "migrations"), dbOpenShiftClusters, time.Hour)
 
 	feAead, err := encryption.NewMulti(ctx, _env.ServiceKeyvault(), env.FrontendEncryptionSecretV2Name, env.FrontendEncryptionSecretName)
 	if err != nil {
@@ -170,6 +247,7 @@ func rp(ctx context.Context, log, audit *logrus.Entry) error {
 		WithOpenShiftClusters(dbOpenShiftClusters).
 		WithOpenShiftVersions(dbOpenShiftVersions).
 		WithPlatformWorkloadIdentityRoleSets(dbPlatformWorkloadIdentityRoleSets).
+		WithSupportedVMSizes(dbSupportedVMSizes).
 		WithSubscriptions(dbSubscriptions)
 
 	// MIMO only activated in development for now
@@ -186,7 +264,7 @@ func rp(ctx context.Context, log, audit *logrus.Entry) error {
 		return err
 	}
 
-	b, err := backend.NewBackend(log.WithField("component", "backend"), _env, dbAsyncOperations, dbBilling, dbGateway, dbOpenShiftClusters, dbSubscriptions, dbOpenShiftVersions, dbPlatformWorkloadIdentityRoleSets, aead, metrics)
+	b, err := backend.NewBackend(log.WithField("component", "backend"), _env, dbAsyncOperations, dbBilling, dbGateway, dbOpenShiftClusters, dbOpenShiftClusterTombstones, dbSubscriptions, dbOpenShiftVersions, dbPlatformWorkloadIdentityRoleSets, dbSupportedVMSizes, aead, metrics)
 	if err != nil {
 		return err
 	}
@@ -211,5 +289,5 @@ func rp(ctx context.Context, log, audit *logrus.Entry) error {
 	<-doneB
 	<-doneF
 
-	return nil
+	return otelProvider.Shutdown(ctx)
 }
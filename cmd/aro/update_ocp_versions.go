@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 
@@ -23,6 +24,16 @@ import (
 type OpenShiftVersions struct {
 	DefaultStream  map[string]string
 	InstallStreams map[string]string
+
+	// RegionOverrides, keyed by location, replace the DefaultStream and/or
+	// the InstallStreams in individual regions, so that a new default
+	// version can be rolled out region by region.
+	RegionOverrides map[string]OpenShiftVersionsOverride
+}
+
+type OpenShiftVersionsOverride struct {
+	DefaultStream  map[string]string
+	InstallStreams map[string]string
 }
 
 func getEnvironmentData(envKey string, envData any) error {
@@ -47,7 +58,7 @@ func getEnvironmentData(envKey string, envData any) error {
 	return nil
 }
 
-func getOpenShiftVersions() (*OpenShiftVersions, error) {
+func getOpenShiftVersions(log *logrus.Entry, location string) (*OpenShiftVersions, error) {
 	const envKey = envOpenShiftVersions
 	var openShiftVersions OpenShiftVersions
 
@@ -55,6 +66,20 @@ func getOpenShiftVersions() (*OpenShiftVersions, error) {
 		return nil, err
 	}
 
+	for region, override := range openShiftVersions.RegionOverrides {
+		if !strings.EqualFold(region, location) {
+			continue
+		}
+
+		log.Printf("Applying OpenShift version overrides for %s", location)
+		if override.DefaultStream != nil {
+			openShiftVersions.DefaultStream = override.DefaultStream
+		}
+		if override.InstallStreams != nil {
+			openShiftVersions.InstallStreams = override.InstallStreams
+		}
+	}
+
 	// The DefaultStream map must have exactly one entry.
 	numDefaultStreams := len(openShiftVersions.DefaultStream)
 	if numDefaultStreams != 1 {
@@ -116,7 +141,7 @@ func getLatestOCPVersions(ctx context.Context, log *logrus.Entry) ([]api.OpenShi
 	acrDomainSuffix := "." + env.Environment().ContainerRegistryDNSSuffix
 	installerImageName := dstAcr + acrDomainSuffix + "/aro-installer"
 
-	openShiftVersions, err := getOpenShiftVersions()
+	openShiftVersions, err := getOpenShiftVersions(log, env.Location())
 	if err != nil {
 		return nil, err
 	}
@@ -198,6 +223,11 @@ func updateOpenShiftVersions(ctx context.Context, dbOpenShiftVersions database.O
 		if found {
 			log.Printf("Found Version %q, patching", existing.Properties.Version)
 			_, err := dbOpenShiftVersions.Patch(ctx, doc.ID, func(inFlightDoc *api.OpenShiftVersionDocument) error {
+				// availability is managed via the admin API once a version
+				// exists, but the default version must always be enabled
+				existing.Properties.Enabled = existing.Properties.Default || inFlightDoc.OpenShiftVersion.Properties.Enabled
+				existing.Properties.DisabledLocations = inFlightDoc.OpenShiftVersion.Properties.DisabledLocations
+				existing.Properties.RequiredFeature = inFlightDoc.OpenShiftVersion.Properties.RequiredFeature
 				inFlightDoc.OpenShiftVersion = &existing
 				return nil
 			})
@@ -22,11 +22,12 @@ import (
 )
 
 const (
-	SessionName        = "session"
-	SessionKeyExpires  = "expires"
-	SessionKeyUsername = "user_name"
-	SessionKeyGroups   = "groups"
-	KeyVaultPrefix     = "KEYVAULT_PREFIX"
+	SessionName          = "session"
+	SessionKeyExpires    = "expires"
+	SessionKeyUsername   = "user_name"
+	SessionKeyGroups     = "groups"
+	SessionKeyStepUpTime = "stepup_time"
+	KeyVaultPrefix       = "KEYVAULT_PREFIX"
 )
 
 func run(ctx context.Context, log *logrus.Entry) error {
@@ -71,6 +72,7 @@ func run(ctx context.Context, log *logrus.Entry) error {
 	session.Values[SessionKeyUsername] = username
 	session.Values[SessionKeyGroups] = strings.Split(*groups, ",")
 	session.Values[SessionKeyExpires] = time.Now().Add(time.Hour).Unix()
+	session.Values[SessionKeyStepUpTime] = time.Now().Unix()
 
 	encoded, err := securecookie.EncodeMulti(session.Name(), session.Values,
 		store.Codecs...)
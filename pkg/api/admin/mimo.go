@@ -31,6 +31,10 @@ type MaintenanceManifest struct {
 	RunAfter int `json:"runAfter,omitempty"`
 	// RunBefore defines the latest that this manifest should start running
 	RunBefore int `json:"runBefore,omitempty"`
+
+	// Windows optionally restricts the weekly windows, in UTC, in which this
+	// manifest may start running
+	Windows []MaintenanceWindow `json:"windows,omitempty"`
 }
 
 // MaintenanceManifestList represents a list of MaintenanceManifests.
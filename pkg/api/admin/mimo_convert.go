@@ -14,6 +14,18 @@ func (m maintenanceManifestConverter) ToExternal(d *api.MaintenanceManifestDocum
 	if !clusterNamespaced {
 		clusterResourceID = d.ClusterResourceID
 	}
+	var windows []MaintenanceWindow
+	if d.MaintenanceManifest.Windows != nil {
+		windows = make([]MaintenanceWindow, 0, len(d.MaintenanceManifest.Windows))
+		for _, w := range d.MaintenanceManifest.Windows {
+			windows = append(windows, MaintenanceWindow{
+				DayOfWeek:     w.DayOfWeek,
+				StartHour:     w.StartHour,
+				DurationHours: w.DurationHours,
+			})
+		}
+	}
+
 	return &MaintenanceManifest{
 		ID: d.ID,
 
@@ -27,6 +39,7 @@ func (m maintenanceManifestConverter) ToExternal(d *api.MaintenanceManifestDocum
 
 		RunAfter:  d.MaintenanceManifest.RunAfter,
 		RunBefore: d.MaintenanceManifest.RunBefore,
+		Windows:   windows,
 	}
 }
 
@@ -53,4 +66,16 @@ func (m maintenanceManifestConverter) ToInternal(_i interface{}, out *api.Mainte
 	out.MaintenanceManifest.RunBefore = i.RunBefore
 	out.MaintenanceManifest.State = api.MaintenanceManifestState(i.State)
 	out.MaintenanceManifest.StatusText = i.StatusText
+
+	out.MaintenanceManifest.Windows = nil
+	if i.Windows != nil {
+		out.MaintenanceManifest.Windows = make([]api.MaintenanceWindow, len(i.Windows))
+		for j, w := range i.Windows {
+			out.MaintenanceManifest.Windows[j] = api.MaintenanceWindow{
+				DayOfWeek:     w.DayOfWeek,
+				StartHour:     w.StartHour,
+				DurationHours: w.DurationHours,
+			}
+		}
+	}
 }
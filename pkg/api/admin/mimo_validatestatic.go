@@ -4,6 +4,7 @@ package admin
 // Licensed under the Apache License 2.0.
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/Azure/ARO-RP/pkg/api"
@@ -46,6 +47,23 @@ func (sv maintenanceManifestStaticValidator) validate(new *MaintenanceManifest)
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "runBefore", "Must be provided")
 	}
 
+	if new.RunBefore < new.RunAfter {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "runBefore", "Must not be before runAfter")
+	}
+
+	for i, w := range new.Windows {
+		path := fmt.Sprintf("windows[%d]", i)
+		if _, ok := api.ParseDayOfWeek(w.DayOfWeek); !ok {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".dayOfWeek", "The provided day of week '%s' is invalid.", w.DayOfWeek)
+		}
+		if w.StartHour < 0 || w.StartHour > 23 {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".startHour", "The provided start hour '%d' is invalid.", w.StartHour)
+		}
+		if w.DurationHours < 1 || w.DurationHours > 24 {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".durationHours", "The provided duration '%d' is invalid.", w.DurationHours)
+		}
+	}
+
 	return nil
 }
 
@@ -53,16 +53,19 @@ type OpenShiftClusterProperties struct {
 	// WorkerProfiles is used to store the worker profile data that was sent in the api request
 	WorkerProfiles []WorkerProfile `json:"workerProfiles,omitempty"`
 	// WorkerProfilesStatus is used to store the enriched worker profile data
-	WorkerProfilesStatus            []WorkerProfile   `json:"workerProfilesStatus,omitempty" swagger:"readOnly"`
-	APIServerProfile                APIServerProfile  `json:"apiserverProfile,omitempty"`
-	IngressProfiles                 []IngressProfile  `json:"ingressProfiles,omitempty"`
-	Install                         *Install          `json:"install,omitempty"`
-	StorageSuffix                   string            `json:"storageSuffix,omitempty"`
-	RegistryProfiles                []RegistryProfile `json:"registryProfiles,omitempty"`
-	ImageRegistryStorageAccountName string            `json:"imageRegistryStorageAccountName,omitempty"`
-	InfraID                         string            `json:"infraId,omitempty"`
-	HiveProfile                     HiveProfile       `json:"hiveProfile,omitempty"`
-	MaintenanceState                MaintenanceState  `json:"maintenanceState,omitempty"`
+	WorkerProfilesStatus            []WorkerProfile           `json:"workerProfilesStatus,omitempty" swagger:"readOnly"`
+	APIServerProfile                APIServerProfile          `json:"apiserverProfile,omitempty"`
+	IngressProfiles                 []IngressProfile          `json:"ingressProfiles,omitempty"`
+	Install                         *Install                  `json:"install,omitempty"`
+	StorageSuffix                   string                    `json:"storageSuffix,omitempty"`
+	RegistryProfiles                []RegistryProfile         `json:"registryProfiles,omitempty"`
+	ImageRegistryStorageAccountName string                    `json:"imageRegistryStorageAccountName,omitempty"`
+	InfraID                         string                    `json:"infraId,omitempty"`
+	HiveProfile                     HiveProfile               `json:"hiveProfile,omitempty"`
+	MaintenanceState                MaintenanceState          `json:"maintenanceState,omitempty"`
+	MaintenanceHistory              []MaintenanceHistoryEntry `json:"maintenanceHistory,omitempty"`
+	ScheduledMaintenance            *ScheduledMaintenance     `json:"scheduledMaintenance,omitempty"`
+	UpgradeProfile                  *UpgradeProfile           `json:"upgradeProfile,omitempty" mutable:"true"`
 }
 
 // ProvisioningState represents a provisioning state.
@@ -113,6 +116,7 @@ const (
 	MaintenanceTaskOperator          MaintenanceTask = "OperatorUpdate"
 	MaintenanceTaskRenewCerts        MaintenanceTask = "CertificatesRenewal"
 	MaintenanceTaskSyncClusterObject MaintenanceTask = "SyncClusterObject"
+	MaintenanceTaskRotateGraphKey    MaintenanceTask = "GraphKeyRotation"
 
 	//
 	// Maintenance tasks for updating customer maintenance signals
@@ -205,7 +209,12 @@ type LoadBalancerProfile struct {
 }
 
 // EffectiveOutboundIP represents an effective outbound IP resource of the cluster public load balancer.
-type EffectiveOutboundIP ResourceReference
+type EffectiveOutboundIP struct {
+	// The fully qualified Azure resource id of an IP address resource.
+	ID string `json:"id,omitempty"`
+	// The public IP address of the IP address resource.
+	IPAddress string `json:"ipAddress,omitempty" swagger:"readOnly"`
+}
 
 // ManagedOutboundIPs represents the desired managed outbound IPs for the cluster public load balancer.
 type ManagedOutboundIPs struct {
@@ -254,12 +263,35 @@ const (
 	EncryptionAtHostDisabled EncryptionAtHost = "Disabled"
 )
 
+// SecurityType represents the security type of the cluster VMs.
+type SecurityType string
+
+// SecurityType constants
+const (
+	SecurityTypeStandard       SecurityType = "Standard"
+	SecurityTypeTrustedLaunch  SecurityType = "TrustedLaunch"
+	SecurityTypeConfidentialVM SecurityType = "ConfidentialVM"
+)
+
+// SecurityEncryptionType represents what a confidential VM encrypts with its
+// confidential key.
+type SecurityEncryptionType string
+
+// SecurityEncryptionType constants
+const (
+	SecurityEncryptionTypeVMGuestStateOnly     SecurityEncryptionType = "VMGuestStateOnly"
+	SecurityEncryptionTypeDiskWithVMGuestState SecurityEncryptionType = "DiskWithVMGuestState"
+)
+
 // MasterProfile represents a master profile.
 type MasterProfile struct {
 	VMSize              VMSize           `json:"vmSize,omitempty"`
 	SubnetID            string           `json:"subnetId,omitempty"`
 	EncryptionAtHost    EncryptionAtHost `json:"encryptionAtHost,omitempty"`
 	DiskEncryptionSetID string           `json:"diskEncryptionSetId,omitempty"`
+	SecurityType        SecurityType     `json:"securityType,omitempty"`
+	IPAddresses         []string         `json:"ipAddresses,omitempty"`
+	NetworkInterfaceIDs []string         `json:"networkInterfaceIds,omitempty"`
 }
 
 // VMSize represents a VM size.
@@ -307,6 +339,31 @@ const (
 	VMSizeStandardD64dsV5 VMSize = "Standard_D64ds_v5"
 	VMSizeStandardD96dsV5 VMSize = "Standard_D96ds_v5"
 
+	// Arm64 VMs
+	VMSizeStandardD4psV5  VMSize = "Standard_D4ps_v5"
+	VMSizeStandardD8psV5  VMSize = "Standard_D8ps_v5"
+	VMSizeStandardD16psV5 VMSize = "Standard_D16ps_v5"
+	VMSizeStandardD32psV5 VMSize = "Standard_D32ps_v5"
+	VMSizeStandardD48psV5 VMSize = "Standard_D48ps_v5"
+	VMSizeStandardD64psV5 VMSize = "Standard_D64ps_v5"
+
+	// Confidential VMs
+	VMSizeStandardDC4asV5  VMSize = "Standard_DC4as_v5"
+	VMSizeStandardDC8asV5  VMSize = "Standard_DC8as_v5"
+	VMSizeStandardDC16asV5 VMSize = "Standard_DC16as_v5"
+	VMSizeStandardDC32asV5 VMSize = "Standard_DC32as_v5"
+	VMSizeStandardDC48asV5 VMSize = "Standard_DC48as_v5"
+	VMSizeStandardDC64asV5 VMSize = "Standard_DC64as_v5"
+	VMSizeStandardDC96asV5 VMSize = "Standard_DC96as_v5"
+	VMSizeStandardEC4asV5  VMSize = "Standard_EC4as_v5"
+	VMSizeStandardEC8asV5  VMSize = "Standard_EC8as_v5"
+	VMSizeStandardEC16asV5 VMSize = "Standard_EC16as_v5"
+	VMSizeStandardEC20asV5 VMSize = "Standard_EC20as_v5"
+	VMSizeStandardEC32asV5 VMSize = "Standard_EC32as_v5"
+	VMSizeStandardEC48asV5 VMSize = "Standard_EC48as_v5"
+	VMSizeStandardEC64asV5 VMSize = "Standard_EC64as_v5"
+	VMSizeStandardEC96asV5 VMSize = "Standard_EC96as_v5"
+
 	VMSizeStandardE4sV3  VMSize = "Standard_E4s_v3"
 	VMSizeStandardE8sV3  VMSize = "Standard_E8s_v3"
 	VMSizeStandardE16sV3 VMSize = "Standard_E16s_v3"
@@ -394,13 +451,15 @@ const (
 
 // WorkerProfile represents a worker profile.
 type WorkerProfile struct {
-	Name                string           `json:"name,omitempty"`
-	VMSize              VMSize           `json:"vmSize,omitempty"`
-	DiskSizeGB          int              `json:"diskSizeGB,omitempty"`
-	SubnetID            string           `json:"subnetId,omitempty"`
-	Count               int              `json:"count,omitempty"`
-	EncryptionAtHost    EncryptionAtHost `json:"encryptionAtHost,omitempty"`
-	DiskEncryptionSetID string           `json:"diskEncryptionSetId,omitempty"`
+	Name                   string                 `json:"name,omitempty"`
+	VMSize                 VMSize                 `json:"vmSize,omitempty"`
+	DiskSizeGB             int                    `json:"diskSizeGB,omitempty"`
+	SubnetID               string                 `json:"subnetId,omitempty"`
+	Count                  int                    `json:"count,omitempty"`
+	EncryptionAtHost       EncryptionAtHost       `json:"encryptionAtHost,omitempty"`
+	DiskEncryptionSetID    string                 `json:"diskEncryptionSetId,omitempty"`
+	SecurityType           SecurityType           `json:"securityType,omitempty"`
+	SecurityEncryptionType SecurityEncryptionType `json:"securityEncryptionType,omitempty"`
 }
 
 // APIServerProfile represents an API server profile.
@@ -427,6 +486,61 @@ type IngressProfile struct {
 	IP         string     `json:"ip,omitempty"`
 }
 
+// UpgradePolicy represents how the cluster is upgraded.
+type UpgradePolicy string
+
+// UpgradePolicy constants.
+const (
+	UpgradePolicyManual         UpgradePolicy = "Manual"
+	UpgradePolicyAutomaticPatch UpgradePolicy = "AutomaticPatch"
+)
+
+// UpgradeProfile represents the upgrade policy of a cluster.
+type UpgradeProfile struct {
+	Policy             UpgradePolicy       `json:"policy,omitempty"`
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	// AutomaticUpgradesPausedReason is set when an automatic upgrade fails.
+	// Clearing it resumes automatic upgrades.
+	AutomaticUpgradesPausedReason string `json:"automaticUpgradesPausedReason,omitempty"`
+}
+
+// MaintenanceWindow represents a weekly window, in UTC, in which automatic
+// upgrades may start.
+type MaintenanceWindow struct {
+	DayOfWeek     string `json:"dayOfWeek,omitempty"`
+	StartHour     int    `json:"startHour,omitempty"`
+	DurationHours int    `json:"durationHours,omitempty"`
+}
+
+// MaintenanceHistoryEntry records a single maintenance task performed on the
+// cluster by an admin update or by MIMO.
+type MaintenanceHistoryEntry struct {
+	Type        string                    `json:"type,omitempty"`
+	StartedAt   time.Time                 `json:"startedAt,omitempty"`
+	CompletedAt time.Time                 `json:"completedAt,omitempty"`
+	Outcome     MaintenanceHistoryOutcome `json:"outcome,omitempty"`
+	Error       string                    `json:"error,omitempty"`
+}
+
+// ScheduledMaintenance describes impactful maintenance which is planned on the
+// cluster and published to the customer.
+type ScheduledMaintenance struct {
+	ManifestID string    `json:"manifestID,omitempty"`
+	StartTime  time.Time `json:"startTime,omitempty"`
+	EndTime    time.Time `json:"endTime,omitempty"`
+	Impact     string    `json:"impact,omitempty"`
+}
+
+// MaintenanceHistoryOutcome represents the outcome of a maintenance task.
+type MaintenanceHistoryOutcome string
+
+// MaintenanceHistoryOutcome constants.
+const (
+	MaintenanceHistoryOutcomeSucceeded MaintenanceHistoryOutcome = "Succeeded"
+	MaintenanceHistoryOutcomeFailed    MaintenanceHistoryOutcome = "Failed"
+	MaintenanceHistoryOutcomeRetrying  MaintenanceHistoryOutcome = "Retrying"
+)
+
 // PlatformWorkloadIdentityProfile encapsulates all information that is specific to workload identity clusters.
 type PlatformWorkloadIdentityProfile struct {
 	UpgradeableTo              *UpgradeableTo                      `json:"upgradeableTo,omitempty"`
@@ -485,8 +599,9 @@ type ManagedServiceIdentity struct {
 
 // Install represents an install process.
 type Install struct {
-	Now   time.Time    `json:"now,omitempty"`
-	Phase InstallPhase `json:"phase"`
+	Now            time.Time    `json:"now,omitempty"`
+	Phase          InstallPhase `json:"phase"`
+	RetryBootstrap bool         `json:"retryBootstrap,omitempty"`
 }
 
 // InstallPhase represents an install phase.
@@ -542,4 +657,9 @@ type HiveProfile struct {
 	// of clusters that were created by Hive to avoid deleting existing
 	// ClusterDeployments.
 	CreatedByHive bool `json:"createdByHive,omitempty"`
+
+	// Shard is the Hive shard which the cluster is registered with.  Clusters
+	// registered before shards were assigned have a zero Shard and are on
+	// shard 1.
+	Shard int `json:"shard,omitempty"`
 }
@@ -61,6 +61,9 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 				SubnetID:            oc.Properties.MasterProfile.SubnetID,
 				EncryptionAtHost:    EncryptionAtHost(oc.Properties.MasterProfile.EncryptionAtHost),
 				DiskEncryptionSetID: oc.Properties.MasterProfile.DiskEncryptionSetID,
+				SecurityType:        SecurityType(oc.Properties.MasterProfile.SecurityType),
+				IPAddresses:         append([]string(nil), oc.Properties.MasterProfile.IPAddresses...),
+				NetworkInterfaceIDs: append([]string(nil), oc.Properties.MasterProfile.NetworkInterfaceIDs...),
 			},
 			APIServerProfile: APIServerProfile{
 				Visibility: Visibility(oc.Properties.APIServerProfile.Visibility),
@@ -99,7 +102,8 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 			out.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs = make([]EffectiveOutboundIP, 0, len(oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs))
 			for _, effectiveOutboundIP := range oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs {
 				out.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs = append(out.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs, EffectiveOutboundIP{
-					ID: effectiveOutboundIP.ID,
+					ID:        effectiveOutboundIP.ID,
+					IPAddress: effectiveOutboundIP.IPAddress,
 				})
 			}
 		}
@@ -127,13 +131,15 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		out.Properties.WorkerProfiles = make([]WorkerProfile, 0, len(oc.Properties.WorkerProfiles))
 		for _, p := range oc.Properties.WorkerProfiles {
 			out.Properties.WorkerProfiles = append(out.Properties.WorkerProfiles, WorkerProfile{
-				Name:                p.Name,
-				VMSize:              VMSize(p.VMSize),
-				DiskSizeGB:          p.DiskSizeGB,
-				SubnetID:            p.SubnetID,
-				Count:               p.Count,
-				EncryptionAtHost:    EncryptionAtHost(p.EncryptionAtHost),
-				DiskEncryptionSetID: p.DiskEncryptionSetID,
+				Name:                   p.Name,
+				VMSize:                 VMSize(p.VMSize),
+				DiskSizeGB:             p.DiskSizeGB,
+				SubnetID:               p.SubnetID,
+				Count:                  p.Count,
+				EncryptionAtHost:       EncryptionAtHost(p.EncryptionAtHost),
+				DiskEncryptionSetID:    p.DiskEncryptionSetID,
+				SecurityType:           SecurityType(p.SecurityType),
+				SecurityEncryptionType: SecurityEncryptionType(p.SecurityEncryptionType),
 			})
 		}
 	}
@@ -142,13 +148,15 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		out.Properties.WorkerProfilesStatus = make([]WorkerProfile, 0, len(oc.Properties.WorkerProfilesStatus))
 		for _, p := range oc.Properties.WorkerProfilesStatus {
 			out.Properties.WorkerProfilesStatus = append(out.Properties.WorkerProfilesStatus, WorkerProfile{
-				Name:                p.Name,
-				VMSize:              VMSize(p.VMSize),
-				DiskSizeGB:          p.DiskSizeGB,
-				SubnetID:            p.SubnetID,
-				Count:               p.Count,
-				EncryptionAtHost:    EncryptionAtHost(p.EncryptionAtHost),
-				DiskEncryptionSetID: p.DiskEncryptionSetID,
+				Name:                   p.Name,
+				VMSize:                 VMSize(p.VMSize),
+				DiskSizeGB:             p.DiskSizeGB,
+				SubnetID:               p.SubnetID,
+				Count:                  p.Count,
+				EncryptionAtHost:       EncryptionAtHost(p.EncryptionAtHost),
+				DiskEncryptionSetID:    p.DiskEncryptionSetID,
+				SecurityType:           SecurityType(p.SecurityType),
+				SecurityEncryptionType: SecurityEncryptionType(p.SecurityEncryptionType),
 			})
 		}
 	}
@@ -166,8 +174,9 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 
 	if oc.Properties.Install != nil {
 		out.Properties.Install = &Install{
-			Now:   oc.Properties.Install.Now,
-			Phase: InstallPhase(oc.Properties.Install.Phase),
+			Now:            oc.Properties.Install.Now,
+			Phase:          InstallPhase(oc.Properties.Install.Phase),
+			RetryBootstrap: oc.Properties.Install.RetryBootstrap,
 		}
 	}
 
@@ -221,6 +230,47 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 	out.Properties.HiveProfile = HiveProfile{
 		Namespace:     oc.Properties.HiveProfile.Namespace,
 		CreatedByHive: oc.Properties.HiveProfile.CreatedByHive,
+		Shard:         oc.Properties.HiveProfile.Shard,
+	}
+
+	if oc.Properties.UpgradeProfile != nil {
+		out.Properties.UpgradeProfile = &UpgradeProfile{
+			Policy:                        UpgradePolicy(oc.Properties.UpgradeProfile.Policy),
+			AutomaticUpgradesPausedReason: oc.Properties.UpgradeProfile.AutomaticUpgradesPausedReason,
+		}
+
+		if oc.Properties.UpgradeProfile.MaintenanceWindows != nil {
+			out.Properties.UpgradeProfile.MaintenanceWindows = make([]MaintenanceWindow, 0, len(oc.Properties.UpgradeProfile.MaintenanceWindows))
+			for _, w := range oc.Properties.UpgradeProfile.MaintenanceWindows {
+				out.Properties.UpgradeProfile.MaintenanceWindows = append(out.Properties.UpgradeProfile.MaintenanceWindows, MaintenanceWindow{
+					DayOfWeek:     w.DayOfWeek,
+					StartHour:     w.StartHour,
+					DurationHours: w.DurationHours,
+				})
+			}
+		}
+	}
+
+	if oc.Properties.MaintenanceHistory != nil {
+		out.Properties.MaintenanceHistory = make([]MaintenanceHistoryEntry, 0, len(oc.Properties.MaintenanceHistory))
+		for _, e := range oc.Properties.MaintenanceHistory {
+			out.Properties.MaintenanceHistory = append(out.Properties.MaintenanceHistory, MaintenanceHistoryEntry{
+				Type:        e.Type,
+				StartedAt:   e.StartedAt,
+				CompletedAt: e.CompletedAt,
+				Outcome:     MaintenanceHistoryOutcome(e.Outcome),
+				Error:       e.Error,
+			})
+		}
+	}
+
+	if oc.Properties.ScheduledMaintenance != nil {
+		out.Properties.ScheduledMaintenance = &ScheduledMaintenance{
+			ManifestID: oc.Properties.ScheduledMaintenance.ManifestID,
+			StartTime:  oc.Properties.ScheduledMaintenance.StartTime,
+			EndTime:    oc.Properties.ScheduledMaintenance.EndTime,
+			Impact:     oc.Properties.ScheduledMaintenance.Impact,
+		}
 	}
 
 	return out
@@ -275,6 +325,7 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 	out.Properties.InfraID = oc.Properties.InfraID
 	out.Properties.HiveProfile.Namespace = oc.Properties.HiveProfile.Namespace
 	out.Properties.HiveProfile.CreatedByHive = oc.Properties.HiveProfile.CreatedByHive
+	out.Properties.HiveProfile.Shard = oc.Properties.HiveProfile.Shard
 	out.Properties.ProvisioningState = api.ProvisioningState(oc.Properties.ProvisioningState)
 	out.Properties.LastProvisioningState = api.ProvisioningState(oc.Properties.LastProvisioningState)
 	out.Properties.FailedProvisioningState = api.ProvisioningState(oc.Properties.FailedProvisioningState)
@@ -285,6 +336,26 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 	out.Properties.CreatedBy = oc.Properties.CreatedBy
 	out.Properties.ProvisionedBy = oc.Properties.ProvisionedBy
 	out.Properties.MaintenanceState = api.MaintenanceState(oc.Properties.MaintenanceState)
+	out.Properties.MaintenanceHistory = nil
+	if oc.Properties.MaintenanceHistory != nil {
+		out.Properties.MaintenanceHistory = make([]api.MaintenanceHistoryEntry, len(oc.Properties.MaintenanceHistory))
+		for i, e := range oc.Properties.MaintenanceHistory {
+			out.Properties.MaintenanceHistory[i].Type = e.Type
+			out.Properties.MaintenanceHistory[i].StartedAt = e.StartedAt
+			out.Properties.MaintenanceHistory[i].CompletedAt = e.CompletedAt
+			out.Properties.MaintenanceHistory[i].Outcome = api.MaintenanceHistoryOutcome(e.Outcome)
+			out.Properties.MaintenanceHistory[i].Error = e.Error
+		}
+	}
+	out.Properties.ScheduledMaintenance = nil
+	if oc.Properties.ScheduledMaintenance != nil {
+		out.Properties.ScheduledMaintenance = &api.ScheduledMaintenance{
+			ManifestID: oc.Properties.ScheduledMaintenance.ManifestID,
+			StartTime:  oc.Properties.ScheduledMaintenance.StartTime,
+			EndTime:    oc.Properties.ScheduledMaintenance.EndTime,
+			Impact:     oc.Properties.ScheduledMaintenance.Impact,
+		}
+	}
 	out.Properties.ClusterProfile.Domain = oc.Properties.ClusterProfile.Domain
 	out.Properties.ClusterProfile.FipsValidatedModules = api.FipsValidatedModules(oc.Properties.ClusterProfile.FipsValidatedModules)
 	out.Properties.ClusterProfile.Version = oc.Properties.ClusterProfile.Version
@@ -361,6 +432,7 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			out.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs = make([]api.EffectiveOutboundIP, len(oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs))
 			for i := range oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs {
 				out.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs[i].ID = oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs[i].ID
+				out.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs[i].IPAddress = oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs[i].IPAddress
 			}
 		}
 	}
@@ -369,6 +441,9 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 	out.Properties.MasterProfile.SubnetID = oc.Properties.MasterProfile.SubnetID
 	out.Properties.MasterProfile.EncryptionAtHost = api.EncryptionAtHost(oc.Properties.MasterProfile.EncryptionAtHost)
 	out.Properties.MasterProfile.DiskEncryptionSetID = oc.Properties.MasterProfile.DiskEncryptionSetID
+	out.Properties.MasterProfile.SecurityType = api.SecurityType(oc.Properties.MasterProfile.SecurityType)
+	out.Properties.MasterProfile.IPAddresses = append([]string(nil), oc.Properties.MasterProfile.IPAddresses...)
+	out.Properties.MasterProfile.NetworkInterfaceIDs = append([]string(nil), oc.Properties.MasterProfile.NetworkInterfaceIDs...)
 	out.Properties.StorageSuffix = oc.Properties.StorageSuffix
 	out.Properties.ImageRegistryStorageAccountName = oc.Properties.ImageRegistryStorageAccountName
 	out.Properties.WorkerProfiles = nil
@@ -382,6 +457,8 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			out.Properties.WorkerProfiles[i].Count = oc.Properties.WorkerProfiles[i].Count
 			out.Properties.WorkerProfiles[i].EncryptionAtHost = api.EncryptionAtHost(oc.Properties.WorkerProfiles[i].EncryptionAtHost)
 			out.Properties.WorkerProfiles[i].DiskEncryptionSetID = oc.Properties.WorkerProfiles[i].DiskEncryptionSetID
+			out.Properties.WorkerProfiles[i].SecurityType = api.SecurityType(oc.Properties.WorkerProfiles[i].SecurityType)
+			out.Properties.WorkerProfiles[i].SecurityEncryptionType = api.SecurityEncryptionType(oc.Properties.WorkerProfiles[i].SecurityEncryptionType)
 		}
 	}
 	out.Properties.WorkerProfilesStatus = nil
@@ -395,6 +472,8 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			out.Properties.WorkerProfilesStatus[i].Count = oc.Properties.WorkerProfilesStatus[i].Count
 			out.Properties.WorkerProfilesStatus[i].EncryptionAtHost = api.EncryptionAtHost(oc.Properties.WorkerProfilesStatus[i].EncryptionAtHost)
 			out.Properties.WorkerProfilesStatus[i].DiskEncryptionSetID = oc.Properties.WorkerProfilesStatus[i].DiskEncryptionSetID
+			out.Properties.WorkerProfilesStatus[i].SecurityType = api.SecurityType(oc.Properties.WorkerProfilesStatus[i].SecurityType)
+			out.Properties.WorkerProfilesStatus[i].SecurityEncryptionType = api.SecurityEncryptionType(oc.Properties.WorkerProfilesStatus[i].SecurityEncryptionType)
 		}
 	}
 	out.Properties.APIServerProfile.Visibility = api.Visibility(oc.Properties.APIServerProfile.Visibility)
@@ -414,8 +493,25 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 	out.Properties.Install = nil
 	if oc.Properties.Install != nil {
 		out.Properties.Install = &api.Install{
-			Now:   oc.Properties.Install.Now,
-			Phase: api.InstallPhase(oc.Properties.Install.Phase),
+			Now:            oc.Properties.Install.Now,
+			Phase:          api.InstallPhase(oc.Properties.Install.Phase),
+			RetryBootstrap: oc.Properties.Install.RetryBootstrap,
+		}
+	}
+
+	out.Properties.UpgradeProfile = nil
+	if oc.Properties.UpgradeProfile != nil {
+		out.Properties.UpgradeProfile = &api.UpgradeProfile{
+			Policy:                        api.UpgradePolicy(oc.Properties.UpgradeProfile.Policy),
+			AutomaticUpgradesPausedReason: oc.Properties.UpgradeProfile.AutomaticUpgradesPausedReason,
+		}
+		if oc.Properties.UpgradeProfile.MaintenanceWindows != nil {
+			out.Properties.UpgradeProfile.MaintenanceWindows = make([]api.MaintenanceWindow, len(oc.Properties.UpgradeProfile.MaintenanceWindows))
+			for i, w := range oc.Properties.UpgradeProfile.MaintenanceWindows {
+				out.Properties.UpgradeProfile.MaintenanceWindows[i].DayOfWeek = w.DayOfWeek
+				out.Properties.UpgradeProfile.MaintenanceWindows[i].StartHour = w.StartHour
+				out.Properties.UpgradeProfile.MaintenanceWindows[i].DurationHours = w.DurationHours
+			}
 		}
 	}
 
@@ -13,7 +13,7 @@ import (
 type openShiftClusterStaticValidator struct{}
 
 // Validate validates an OpenShift cluster
-func (sv openShiftClusterStaticValidator) Static(_oc interface{}, _current *api.OpenShiftCluster, location, domain string, requireD2sV3Workers bool, resourceID string) error {
+func (sv openShiftClusterStaticValidator) Static(_oc interface{}, _current *api.OpenShiftCluster, location, domain string, requireD2sV3Workers bool, additionalVMSizes []*api.SupportedVMSize, resourceID string) error {
 	if _current == nil {
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeRequestNotAllowed, "", "Admin API does not allow cluster creation.")
 	}
@@ -40,6 +40,7 @@ func validateMaintenanceTask(task MaintenanceTask) error {
 		task == MaintenanceTaskPending ||
 		task == MaintenanceTaskNone ||
 		task == MaintenanceTaskSyncClusterObject ||
+		task == MaintenanceTaskRotateGraphKey ||
 		task == MaintenanceTaskCustomerActionNeeded) {
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.maintenanceTask", "Invalid enum parameter.")
 	}
@@ -745,6 +745,22 @@ func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 			},
 			wantErr: "400: InvalidParameter: properties.maintenanceTask: Invalid enum parameter.",
 		},
+		{
+			name: "upgradeProfile automaticUpgradesPausedReason clear is allowed",
+			oc: func() *OpenShiftCluster {
+				return &OpenShiftCluster{
+					Properties: OpenShiftClusterProperties{
+						UpgradeProfile: &UpgradeProfile{
+							Policy:                        UpgradePolicyAutomaticPatch,
+							AutomaticUpgradesPausedReason: "upgrade failed",
+						},
+					},
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile.AutomaticUpgradesPausedReason = ""
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -758,7 +774,7 @@ func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 			(&openShiftClusterConverter{}).ToInternal(tt.oc(), current)
 
 			v := &openShiftClusterStaticValidator{}
-			err := v.Static(oc, current, "", "", true, "")
+			err := v.Static(oc, current, "", "", true, nil, "")
 			if err == nil {
 				if tt.wantErr != "" {
 					t.Error(err)
@@ -3,10 +3,17 @@ package admin
 // Copyright (c) Microsoft Corporation.
 // Licensed under the Apache License 2.0.
 
+import (
+	"time"
+)
+
 // OpenShiftVersionList represents a list of OpenShift versions that can be
 // installed.
 type OpenShiftVersionList struct {
 	OpenShiftVersions []*OpenShiftVersion `json:"value"`
+
+	// The link used to get the next page of operations.
+	NextLink string `json:"nextLink,omitempty"`
 }
 
 type OpenShiftVersion struct {
@@ -27,4 +34,26 @@ type OpenShiftVersionProperties struct {
 	OpenShiftPullspec string `json:"openShiftPullspec,omitempty" mutable:"true"`
 	InstallerPullspec string `json:"installerPullspec,omitempty" mutable:"true"`
 	Enabled           bool   `json:"enabled" mutable:"true"`
+
+	// MultiArchPullspec, if set, is the multi-architecture release payload of
+	// the version, required to install clusters with Arm64 workers
+	MultiArchPullspec string `json:"multiArchPullspec,omitempty" mutable:"true"`
+
+	// DisabledLocations lists the regions in which an enabled version cannot
+	// be installed
+	DisabledLocations []string `json:"disabledLocations,omitempty" mutable:"true"`
+
+	// EnabledBy and EnabledAt are set by the RP when the version is enabled
+	EnabledBy string     `json:"enabledBy,omitempty" mutable:"true"`
+	EnabledAt *time.Time `json:"enabledAt,omitempty" mutable:"true"`
+
+	// GADate, EndOfSupportDate and EndOfLifeDate describe the support
+	// lifecycle of the version, in YYYY-MM-DD format
+	GADate           string `json:"gaDate,omitempty" mutable:"true"`
+	EndOfSupportDate string `json:"endOfSupportDate,omitempty" mutable:"true"`
+	EndOfLifeDate    string `json:"endOfLifeDate,omitempty" mutable:"true"`
+
+	// RequiredFeature, if set, is the ARM feature which a subscription must
+	// have registered to install the version
+	RequiredFeature string `json:"requiredFeature,omitempty" mutable:"true"`
 }
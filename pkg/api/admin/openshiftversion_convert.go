@@ -4,6 +4,8 @@ package admin
 // Licensed under the Apache License 2.0.
 
 import (
+	"slices"
+
 	"github.com/Azure/ARO-RP/pkg/api"
 )
 
@@ -21,17 +23,30 @@ func (openShiftVersionConverter) ToExternal(v *api.OpenShiftVersion) interface{}
 			OpenShiftPullspec: v.Properties.OpenShiftPullspec,
 			InstallerPullspec: v.Properties.InstallerPullspec,
 			Enabled:           v.Properties.Enabled,
+			MultiArchPullspec: v.Properties.MultiArchPullspec,
+			DisabledLocations: slices.Clone(v.Properties.DisabledLocations),
+			EnabledBy:         v.Properties.EnabledBy,
+			GADate:            v.Properties.GADate,
+			EndOfSupportDate:  v.Properties.EndOfSupportDate,
+			EndOfLifeDate:     v.Properties.EndOfLifeDate,
+			RequiredFeature:   v.Properties.RequiredFeature,
 		},
 	}
 
+	if v.Properties.EnabledAt != nil {
+		enabledAt := *v.Properties.EnabledAt
+		out.Properties.EnabledAt = &enabledAt
+	}
+
 	return out
 }
 
 // ToExternalList returns a slice of external representations of the internal
 // objects
-func (c openShiftVersionConverter) ToExternalList(vers []*api.OpenShiftVersion) interface{} {
+func (c openShiftVersionConverter) ToExternalList(vers []*api.OpenShiftVersion, nextLink string) interface{} {
 	l := &OpenShiftVersionList{
 		OpenShiftVersions: make([]*OpenShiftVersion, 0, len(vers)),
+		NextLink:          nextLink,
 	}
 
 	for _, ver := range vers {
@@ -44,12 +59,18 @@ func (c openShiftVersionConverter) ToExternalList(vers []*api.OpenShiftVersion)
 // ToInternal overwrites in place a pre-existing internal object, setting (only)
 // all mapped fields from the external representation. ToInternal modifies its
 // argument; there is no pointer aliasing between the passed and returned
-// objects
+// objects.  EnabledBy and EnabledAt are managed by the RP and are not mapped.
 func (c openShiftVersionConverter) ToInternal(_new interface{}, out *api.OpenShiftVersion) {
 	new := _new.(*OpenShiftVersion)
 
 	out.Properties.Enabled = new.Properties.Enabled
+	out.Properties.DisabledLocations = slices.Clone(new.Properties.DisabledLocations)
 	out.Properties.InstallerPullspec = new.Properties.InstallerPullspec
 	out.Properties.OpenShiftPullspec = new.Properties.OpenShiftPullspec
+	out.Properties.MultiArchPullspec = new.Properties.MultiArchPullspec
 	out.Properties.Version = new.Properties.Version
+	out.Properties.GADate = new.Properties.GADate
+	out.Properties.EndOfSupportDate = new.Properties.EndOfSupportDate
+	out.Properties.EndOfLifeDate = new.Properties.EndOfLifeDate
+	out.Properties.RequiredFeature = new.Properties.RequiredFeature
 }
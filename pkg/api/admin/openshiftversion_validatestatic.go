@@ -4,7 +4,10 @@ package admin
 // Licensed under the Apache License 2.0.
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/api/util/immutable"
@@ -45,6 +48,47 @@ func (sv openShiftVersionStaticValidator) validate(new *OpenShiftVersion) error
 	if new.Properties.OpenShiftPullspec == "" {
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.openShiftPullspec", "Must be provided")
 	}
+
+	for i, location := range new.Properties.DisabledLocations {
+		if location == "" || location != strings.ToLower(location) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("properties.disabledLocations[%d]", i), "Must be a lowercase location name")
+		}
+	}
+
+	if new.Properties.RequiredFeature != "" && !strings.HasPrefix(new.Properties.RequiredFeature, "Microsoft.RedHatOpenShift/") {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.requiredFeature", "Must be a Microsoft.RedHatOpenShift feature")
+	}
+
+	return sv.validateLifecycle(new)
+}
+
+// validateLifecycle validates that the lifecycle dates which are set are
+// dates, and are in order
+func (sv openShiftVersionStaticValidator) validateLifecycle(new *OpenShiftVersion) error {
+	var previous time.Time
+	for _, d := range []struct {
+		path  string
+		value string
+	}{
+		{path: "properties.gaDate", value: new.Properties.GADate},
+		{path: "properties.endOfSupportDate", value: new.Properties.EndOfSupportDate},
+		{path: "properties.endOfLifeDate", value: new.Properties.EndOfLifeDate},
+	} {
+		if d.value == "" {
+			continue
+		}
+
+		date, err := time.Parse(time.DateOnly, d.value)
+		if err != nil {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, d.path, "Must be a date in YYYY-MM-DD format")
+		}
+
+		if date.Before(previous) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, d.path, "Must not be before the previous lifecycle date")
+		}
+		previous = date
+	}
+
 	return nil
 }
 
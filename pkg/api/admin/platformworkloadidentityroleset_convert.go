@@ -42,9 +42,10 @@ func (c platformWorkloadIdentityRoleSetConverter) ToExternal(s *api.PlatformWork
 
 // ToExternalList returns a slice of external representations of the internal
 // objects
-func (c platformWorkloadIdentityRoleSetConverter) ToExternalList(sets []*api.PlatformWorkloadIdentityRoleSet) interface{} {
+func (c platformWorkloadIdentityRoleSetConverter) ToExternalList(sets []*api.PlatformWorkloadIdentityRoleSet, nextLink string) interface{} {
 	l := &PlatformWorkloadIdentityRoleSetList{
 		PlatformWorkloadIdentityRoleSets: make([]*PlatformWorkloadIdentityRoleSet, 0, len(sets)),
+		NextLink:                         nextLink,
 	}
 
 	for _, set := range sets {
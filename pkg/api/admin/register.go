@@ -18,6 +18,8 @@ func init() {
 		OpenShiftVersionStaticValidator:                openShiftVersionStaticValidator{},
 		PlatformWorkloadIdentityRoleSetConverter:       platformWorkloadIdentityRoleSetConverter{},
 		PlatformWorkloadIdentityRoleSetStaticValidator: platformWorkloadIdentityRoleSetStaticValidator{},
+		SupportedVMSizeConverter:                       supportedVMSizeConverter{},
+		SupportedVMSizeStaticValidator:                 supportedVMSizeStaticValidator{},
 		MaintenanceManifestConverter:                   maintenanceManifestConverter{},
 		MaintenanceManifestStaticValidator:             maintenanceManifestStaticValidator{},
 	}
@@ -0,0 +1,35 @@
+package admin
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// SupportedVMSizeList represents a list of VM sizes which are supported in
+// addition to the VM sizes built into the RP.
+type SupportedVMSizeList struct {
+	SupportedVMSizes []*SupportedVMSize `json:"value"`
+}
+
+type SupportedVMSize struct {
+	// The ID for the resource.
+	ID string `json:"id,omitempty"`
+
+	// Name of the resource.
+	Name string `json:"name,omitempty"`
+
+	// The properties for the SupportedVMSize resource.
+	Properties SupportedVMSizeProperties `json:"properties,omitempty"`
+}
+
+// SupportedVMSizeProperties represents the properties of a SupportedVMSize.
+type SupportedVMSizeProperties struct {
+	// VMSize is the name of the VM size, e.g. Standard_D8s_v5
+	VMSize VMSize `json:"vmSize,omitempty"`
+
+	// CoreCount and Family are used to validate the quota of the VM size
+	CoreCount int    `json:"coreCount,omitempty" mutable:"true"`
+	Family    string `json:"family,omitempty" mutable:"true"`
+
+	// Roles lists the node roles, master and/or worker, which may use the VM
+	// size
+	Roles []string `json:"roles,omitempty" mutable:"true"`
+}
@@ -0,0 +1,55 @@
+package admin
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"slices"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+type supportedVMSizeConverter struct{}
+
+// supportedVMSizeConverter.ToExternal returns a new external representation
+// of the internal object, reading from the subset of the internal object's
+// fields that appear in the external representation.  ToExternal does not
+// modify its argument; there is no pointer aliasing between the passed and
+// returned objects.
+func (supportedVMSizeConverter) ToExternal(s *api.SupportedVMSize) interface{} {
+	return &SupportedVMSize{
+		Properties: SupportedVMSizeProperties{
+			VMSize:    VMSize(s.Properties.VMSize),
+			CoreCount: s.Properties.CoreCount,
+			Family:    s.Properties.Family,
+			Roles:     slices.Clone(s.Properties.Roles),
+		},
+	}
+}
+
+// ToExternalList returns a slice of external representations of the internal
+// objects
+func (c supportedVMSizeConverter) ToExternalList(sizes []*api.SupportedVMSize) interface{} {
+	l := &SupportedVMSizeList{
+		SupportedVMSizes: make([]*SupportedVMSize, 0, len(sizes)),
+	}
+
+	for _, size := range sizes {
+		l.SupportedVMSizes = append(l.SupportedVMSizes, c.ToExternal(size).(*SupportedVMSize))
+	}
+
+	return l
+}
+
+// ToInternal overwrites in place a pre-existing internal object, setting (only)
+// all mapped fields from the external representation. ToInternal modifies its
+// argument; there is no pointer aliasing between the passed and returned
+// objects.
+func (c supportedVMSizeConverter) ToInternal(_new interface{}, out *api.SupportedVMSize) {
+	new := _new.(*SupportedVMSize)
+
+	out.Properties.VMSize = api.VMSize(new.Properties.VMSize)
+	out.Properties.CoreCount = new.Properties.CoreCount
+	out.Properties.Family = new.Properties.Family
+	out.Properties.Roles = slices.Clone(new.Properties.Roles)
+}
@@ -0,0 +1,78 @@
+package admin
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/util/immutable"
+	"github.com/Azure/ARO-RP/pkg/api/validate"
+)
+
+var rxVMSize = regexp.MustCompile(`^Standard_[A-Za-z0-9_]+$`)
+
+type supportedVMSizeStaticValidator struct{}
+
+// Validate validates a supported VM size
+func (sv supportedVMSizeStaticValidator) Static(_new interface{}, _current *api.SupportedVMSize) error {
+	new := _new.(*SupportedVMSize)
+
+	var current *SupportedVMSize
+	if _current != nil {
+		current = (&supportedVMSizeConverter{}).ToExternal(_current).(*SupportedVMSize)
+	}
+
+	err := sv.validate(new)
+	if err != nil {
+		return err
+	}
+
+	if current == nil {
+		return nil
+	}
+
+	return sv.validateDelta(new, current)
+}
+
+func (sv supportedVMSizeStaticValidator) validate(new *SupportedVMSize) error {
+	if !rxVMSize.MatchString(string(new.Properties.VMSize)) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.vmSize", "Must be a VM size name, e.g. Standard_D8s_v5")
+	}
+
+	if new.Properties.CoreCount <= 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.coreCount", "Must be greater than zero")
+	}
+
+	if new.Properties.Family == "" {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.family", "Must be provided")
+	}
+
+	if len(new.Properties.Roles) == 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.roles", "Must be provided")
+	}
+
+	for i, role := range new.Properties.Roles {
+		if role != validate.VMRoleMaster && role != validate.VMRoleWorker {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("properties.roles[%d]", i), "Must be either '%s' or '%s'", validate.VMRoleMaster, validate.VMRoleWorker)
+		}
+		if slices.Contains(new.Properties.Roles[:i], role) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("properties.roles[%d]", i), "Must not be duplicated")
+		}
+	}
+
+	return nil
+}
+
+func (sv supportedVMSizeStaticValidator) validateDelta(new, current *SupportedVMSize) error {
+	err := immutable.Validate("", new, current)
+	if err != nil {
+		err := err.(*immutable.ValidationError)
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodePropertyChangeNotAllowed, err.Target, err.Message)
+	}
+	return nil
+}
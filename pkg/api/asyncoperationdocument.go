@@ -33,6 +33,8 @@ type AsyncOperationDocument struct {
 
 	OpenShiftClusterKey string            `json:"openShiftClusterKey,omitempty"`
 	OpenShiftCluster    *OpenShiftCluster `json:"openShiftCluster,omitempty"`
+
+	CorrelationData *CorrelationData `json:"correlationData,omitempty" deep:"-"`
 }
 
 func (c *AsyncOperationDocument) String() string {
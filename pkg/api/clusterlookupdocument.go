@@ -0,0 +1,51 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// ClusterLookupKind identifies which OpenShiftClusterDocument field a
+// ClusterLookupDocument indexes.
+type ClusterLookupKind string
+
+const (
+	ClusterLookupKindClientID             ClusterLookupKind = "ClientID"
+	ClusterLookupKindClusterResourceGroup ClusterLookupKind = "ClusterResourceGroup"
+)
+
+// ClusterLookupDocuments represents cluster lookup documents.
+// pkg/database/cosmosdb requires its definition.
+type ClusterLookupDocuments struct {
+	Count                  int                      `json:"_count,omitempty"`
+	ResourceID             string                   `json:"_rid,omitempty"`
+	ClusterLookupDocuments []*ClusterLookupDocument `json:"Documents,omitempty"`
+}
+
+func (c *ClusterLookupDocuments) String() string {
+	return encodeJSON(c)
+}
+
+// ClusterLookupDocument represents a cluster lookup document.  It maps a
+// lookup value (e.g. a service principal client ID or a cluster resource group
+// ID) to the key of the OpenShiftClusterDocument which owns it.
+// pkg/database/cosmosdb requires its definition.
+type ClusterLookupDocument struct {
+	MissingFields
+
+	// ID is derived from Kind and Value; see database.ClusterLookupID
+	ID          string                 `json:"id,omitempty"`
+	ResourceID  string                 `json:"_rid,omitempty"`
+	Timestamp   int                    `json:"_ts,omitempty"`
+	Self        string                 `json:"_self,omitempty"`
+	ETag        string                 `json:"_etag,omitempty"`
+	Attachments string                 `json:"_attachments,omitempty"`
+	LSN         int                    `json:"_lsn,omitempty"`
+	Metadata    map[string]interface{} `json:"_metadata,omitempty"`
+
+	Kind       ClusterLookupKind `json:"kind,omitempty"`
+	Value      string            `json:"value,omitempty"`
+	ClusterKey string            `json:"clusterKey,omitempty"`
+}
+
+func (c *ClusterLookupDocument) String() string {
+	return encodeJSON(c)
+}
@@ -103,6 +103,7 @@ const (
 	CloudErrorCodeInUseSubnetCannotBeDeleted                                 = "InUseSubnetCannotBeDeleted"
 	CloudErrorCodeScopeLocked                                                = "ScopeLocked"
 	CloudErrorCodeRequestDisallowedByPolicy                                  = "RequestDisallowedByPolicy"
+	CloudErrorCodeRequestDisallowedByDenyAssignment                          = "RequestDisallowedByDenyAssignment"
 	CloudErrorCodeInvalidNetworkAddress                                      = "InvalidNetworkAddress"
 	CloudErrorCodeThrottlingLimitExceeded                                    = "ThrottlingLimitExceeded"
 	CloudErrorCodeInvalidCIDRRange                                           = "InvalidCIDRRange"
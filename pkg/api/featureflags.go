@@ -9,4 +9,11 @@ const (
 	// Unit (MTU) on Azure virtual networks, which as of late 2021 is 3900 bytes.
 	// Otherwise cluster nodes will use the DHCP-provided MTU of 1500 bytes.
 	FeatureFlagMTU3900 = "Microsoft.RedHatOpenShift/MTU3900"
+
+	// FeatureFlagTrustedLaunch is the feature in the subscription that causes
+	// new OpenShift cluster VMs which do not request a security type to boot
+	// from Hyper-V generation 2 images with Trusted Launch (secure boot and a
+	// virtual TPM).  Otherwise they default to the Standard security type.
+	// Arm64 VMs always default to Standard, as they do not support it.
+	FeatureFlagTrustedLaunch = "Microsoft.RedHatOpenShift/TrustedLaunch"
 )
@@ -3,6 +3,8 @@ package api
 // Copyright (c) Microsoft Corporation.
 // Licensed under the Apache License 2.0.
 
+import "time"
+
 type MaintenanceManifestState string
 
 const (
@@ -30,4 +32,24 @@ type MaintenanceManifest struct {
 	RunAfter int `json:"runAfter,omitempty"`
 	// RunBefore defines the latest that this manifest should start running
 	RunBefore int `json:"runBefore,omitempty"`
+
+	// Windows optionally restricts the weekly windows in which this manifest
+	// may start running.  If none are set, it may start at any time between
+	// RunAfter and RunBefore.
+	Windows []MaintenanceWindow `json:"windows,omitempty"`
+}
+
+// CanStartAt returns true if the manifest may start running at t, i.e. t is
+// not before RunAfter and falls in one of the manifest's windows, if any
+func (m *MaintenanceManifest) CanStartAt(t time.Time) bool {
+	if t.Before(time.Unix(int64(m.RunAfter), 0)) {
+		return false
+	}
+
+	if len(m.Windows) == 0 {
+		return true
+	}
+
+	_, ok := activeMaintenanceWindowEnd(m.Windows, t)
+	return ok
 }
@@ -0,0 +1,81 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMaintenanceManifestCanStartAt(t *testing.T) {
+	// 2024-06-01 is a Saturday
+	saturday := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	windows := []MaintenanceWindow{
+		{
+			DayOfWeek:     "Saturday",
+			StartHour:     2,
+			DurationHours: 4,
+		},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		manifest *MaintenanceManifest
+		t        time.Time
+		want     bool
+	}{
+		{
+			name:     "no windows",
+			manifest: &MaintenanceManifest{},
+			t:        saturday,
+			want:     true,
+		},
+		{
+			name: "before runAfter",
+			manifest: &MaintenanceManifest{
+				RunAfter: int(saturday.Add(time.Hour).Unix()),
+			},
+			t: saturday,
+		},
+		{
+			name: "at runAfter",
+			manifest: &MaintenanceManifest{
+				RunAfter: int(saturday.Unix()),
+			},
+			t:    saturday,
+			want: true,
+		},
+		{
+			name: "outside of windows",
+			manifest: &MaintenanceManifest{
+				Windows: windows,
+			},
+			t: saturday.Add(time.Hour),
+		},
+		{
+			name: "in window",
+			manifest: &MaintenanceManifest{
+				Windows: windows,
+			},
+			t:    saturday.Add(3 * time.Hour),
+			want: true,
+		},
+		{
+			name: "in window but before runAfter",
+			manifest: &MaintenanceManifest{
+				RunAfter: int(saturday.AddDate(0, 0, 1).Unix()),
+				Windows:  windows,
+			},
+			t: saturday.Add(3 * time.Hour),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.manifest.CanStartAt(tt.t)
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// MoveResourcesRequest is the request body ARM sends to validateMoveResources
+// and moveResources when resources are moved out of a resource group
+type MoveResourcesRequest struct {
+	Resources           []string `json:"resources"`
+	TargetResourceGroup string   `json:"targetResourceGroup"`
+}
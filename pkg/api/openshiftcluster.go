@@ -5,6 +5,7 @@ package api
 
 import (
 	"errors"
+	"strings"
 	"sync"
 	"time"
 
@@ -149,6 +150,11 @@ type OpenShiftClusterProperties struct {
 	// admin updated this cluster
 	ProvisionedBy string `json:"provisionedBy,omitempty"`
 
+	// MovedFromResourceID is the resource ID the cluster had before it was
+	// last moved to another resource group.  The cluster resource group is
+	// still managed by this ID until the post-move admin update adopts it.
+	MovedFromResourceID string `json:"movedFromResourceId,omitempty"`
+
 	ClusterProfile ClusterProfile `json:"clusterProfile,omitempty"`
 
 	FeatureProfile FeatureProfile `json:"featureProfile,omitempty"`
@@ -179,6 +185,11 @@ type OpenShiftClusterProperties struct {
 	StorageSuffix                   string `json:"storageSuffix,omitempty"`
 	ImageRegistryStorageAccountName string `json:"imageRegistryStorageAccountName,omitempty"`
 
+	// GraphKeyVersion is the version of the cluster graph key which the
+	// persisted installer graph was last encrypted with by the RP.  It is
+	// empty until the graph is first re-encrypted after install.
+	GraphKeyVersion string `json:"graphKeyVersion,omitempty"`
+
 	InfraID string      `json:"infraId,omitempty"`
 	SSHKey  SecureBytes `json:"sshKey,omitempty"`
 
@@ -200,6 +211,19 @@ type OpenShiftClusterProperties struct {
 	HiveProfile HiveProfile `json:"hiveProfile,omitempty"`
 
 	MaintenanceState MaintenanceState `json:"maintenanceState,omitempty"`
+
+	// MaintenanceHistory records the most recent maintenance tasks performed
+	// on the cluster by the RP, oldest first
+	MaintenanceHistory []MaintenanceHistoryEntry `json:"maintenanceHistory,omitempty"`
+
+	// ScheduledMaintenance is published to the customer by MIMO while
+	// impactful maintenance is queued for the cluster, and cleared once it is
+	// complete
+	ScheduledMaintenance *ScheduledMaintenance `json:"scheduledMaintenance,omitempty"`
+
+	UpgradeProfile *UpgradeProfile `json:"upgradeProfile,omitempty"`
+
+	GuardrailsProfile *GuardrailsProfile `json:"guardrailsProfile,omitempty"`
 }
 
 // ProvisioningState represents a provisioning state
@@ -230,6 +254,172 @@ const (
 	MaintenanceStateCustomerActionNeeded MaintenanceState = "CustomerActionNeeded"
 )
 
+// MaxMaintenanceHistoryEntries is the number of maintenance history entries
+// retained on a cluster document
+const MaxMaintenanceHistoryEntries = 50
+
+// MaintenanceHistoryEntry records a single maintenance task performed on a
+// cluster, either by an admin update or by MIMO
+type MaintenanceHistoryEntry struct {
+	MissingFields
+
+	// Type is the admin update maintenance task or the MIMO task ID
+	Type        string                    `json:"type,omitempty"`
+	StartedAt   time.Time                 `json:"startedAt,omitempty"`
+	CompletedAt time.Time                 `json:"completedAt,omitempty"`
+	Outcome     MaintenanceHistoryOutcome `json:"outcome,omitempty"`
+	Error       string                    `json:"error,omitempty"`
+}
+
+// MaintenanceHistoryOutcome represents the outcome of a maintenance task
+type MaintenanceHistoryOutcome string
+
+const (
+	MaintenanceHistoryOutcomeSucceeded MaintenanceHistoryOutcome = "Succeeded"
+	MaintenanceHistoryOutcomeFailed    MaintenanceHistoryOutcome = "Failed"
+	// Retrying means the task failed with a transient error and will be run
+	// again
+	MaintenanceHistoryOutcomeRetrying MaintenanceHistoryOutcome = "Retrying"
+)
+
+// AppendMaintenanceHistory adds entries to the maintenance history, dropping
+// the oldest entries beyond MaxMaintenanceHistoryEntries
+func (p *OpenShiftClusterProperties) AppendMaintenanceHistory(entries ...MaintenanceHistoryEntry) {
+	p.MaintenanceHistory = append(p.MaintenanceHistory, entries...)
+	if len(p.MaintenanceHistory) > MaxMaintenanceHistoryEntries {
+		p.MaintenanceHistory = p.MaintenanceHistory[len(p.MaintenanceHistory)-MaxMaintenanceHistoryEntries:]
+	}
+}
+
+// ScheduledMaintenance describes impactful maintenance which is planned on a
+// cluster
+type ScheduledMaintenance struct {
+	MissingFields
+
+	// ManifestID is the ID of the MIMO maintenance manifest which performs the
+	// maintenance
+	ManifestID string    `json:"manifestID,omitempty"`
+	StartTime  time.Time `json:"startTime,omitempty"`
+	EndTime    time.Time `json:"endTime,omitempty"`
+	Impact     string    `json:"impact,omitempty"`
+}
+
+// GuardrailsState represents whether the managed Gatekeeper policies are
+// deployed on a cluster
+type GuardrailsState string
+
+const (
+	GuardrailsStateEnabled  GuardrailsState = "Enabled"
+	GuardrailsStateDisabled GuardrailsState = "Disabled"
+)
+
+// GuardrailsEnforcementAction represents the Gatekeeper enforcement action of
+// the managed policies
+type GuardrailsEnforcementAction string
+
+const (
+	GuardrailsEnforcementActionDryRun GuardrailsEnforcementAction = "DryRun"
+	GuardrailsEnforcementActionWarn   GuardrailsEnforcementAction = "Warn"
+	GuardrailsEnforcementActionDeny   GuardrailsEnforcementAction = "Deny"
+)
+
+// GuardrailsProfile represents the configuration of the managed Gatekeeper
+// policies which the ARO operator deploys on a cluster
+type GuardrailsProfile struct {
+	MissingFields
+
+	State             GuardrailsState             `json:"state,omitempty"`
+	EnforcementAction GuardrailsEnforcementAction `json:"enforcementAction,omitempty"`
+}
+
+// UpgradePolicy represents how a cluster is upgraded
+type UpgradePolicy string
+
+const (
+	// UpgradePolicyManual clusters are only upgraded by the customer
+	UpgradePolicyManual UpgradePolicy = "Manual"
+
+	// UpgradePolicyAutomaticPatch clusters are upgraded by the RP to the latest
+	// patch release of their minor version within their maintenance windows
+	UpgradePolicyAutomaticPatch UpgradePolicy = "AutomaticPatch"
+)
+
+// UpgradeProfile represents the upgrade policy of a cluster
+type UpgradeProfile struct {
+	MissingFields
+
+	Policy             UpgradePolicy       `json:"policy,omitempty"`
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// AutomaticUpgradesPausedReason is set by the RP when an automatic
+	// upgrade fails.  No automatic upgrades are scheduled while it is set.
+	AutomaticUpgradesPausedReason string `json:"automaticUpgradesPausedReason,omitempty"`
+}
+
+// MaintenanceWindow represents a weekly window, in UTC, in which the RP may
+// start an automatic upgrade of the cluster
+type MaintenanceWindow struct {
+	MissingFields
+
+	DayOfWeek     string `json:"dayOfWeek,omitempty"`
+	StartHour     int    `json:"startHour,omitempty"`
+	DurationHours int    `json:"durationHours,omitempty"`
+}
+
+// ParseDayOfWeek returns the weekday with the given name, e.g. Saturday
+func ParseDayOfWeek(s string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), s) {
+			return d, true
+		}
+	}
+
+	return 0, false
+}
+
+// AutomaticUpgradesEnabled returns true if the RP should automatically upgrade
+// the cluster
+func (p *UpgradeProfile) AutomaticUpgradesEnabled() bool {
+	return p != nil && p.Policy == UpgradePolicyAutomaticPatch && p.AutomaticUpgradesPausedReason == ""
+}
+
+// ActiveMaintenanceWindowEnd returns the end of the maintenance window which t
+// falls in, or false if t does not fall in any maintenance window
+func (p *UpgradeProfile) ActiveMaintenanceWindowEnd(t time.Time) (time.Time, bool) {
+	if p == nil {
+		return time.Time{}, false
+	}
+
+	return activeMaintenanceWindowEnd(p.MaintenanceWindows, t)
+}
+
+func activeMaintenanceWindowEnd(windows []MaintenanceWindow, t time.Time) (time.Time, bool) {
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	var end time.Time
+	for _, w := range windows {
+		day, ok := ParseDayOfWeek(w.DayOfWeek)
+		if !ok {
+			continue
+		}
+
+		// find the most recent start of the window
+		daysAgo := (int(t.Weekday()) - int(day) + 7) % 7
+		start := midnight.AddDate(0, 0, -daysAgo).Add(time.Duration(w.StartHour) * time.Hour)
+		if start.After(t) {
+			start = start.AddDate(0, 0, -7)
+		}
+
+		windowEnd := start.Add(time.Duration(w.DurationHours) * time.Hour)
+		if t.Before(windowEnd) && windowEnd.After(end) {
+			end = windowEnd
+		}
+	}
+
+	return end, !end.IsZero()
+}
+
 type MaintenanceTask string
 
 const (
@@ -241,6 +431,7 @@ const (
 	MaintenanceTaskOperator          MaintenanceTask = "OperatorUpdate"
 	MaintenanceTaskRenewCerts        MaintenanceTask = "CertificatesRenewal"
 	MaintenanceTaskSyncClusterObject MaintenanceTask = "SyncClusterObject"
+	MaintenanceTaskRotateGraphKey    MaintenanceTask = "GraphKeyRotation"
 
 	//
 	// Maintenance tasks for updating customer maintenance signals
@@ -263,6 +454,7 @@ func (t MaintenanceTask) IsMaintenanceOngoingTask() bool {
 		(t == MaintenanceTaskOperator) ||
 		(t == MaintenanceTaskRenewCerts) ||
 		(t == MaintenanceTaskSyncClusterObject) ||
+		(t == MaintenanceTaskRotateGraphKey) ||
 		(t == "")
 	return result
 }
@@ -302,6 +494,10 @@ type ClusterProfile struct {
 	FipsValidatedModules          FipsValidatedModules `json:"fipsValidatedModules,omitempty"`
 	OIDCIssuer                    *OIDCIssuer          `json:"oidcIssuer,omitempty"`
 	BoundServiceAccountSigningKey *SecureString        `json:"boundServiceAccountSigningKey,omitempty"`
+
+	// AdditionalTrustBundle is a PEM bundle of additional CA certificates
+	// which the cluster's nodes trust, e.g. those of a TLS-intercepting proxy
+	AdditionalTrustBundle string `json:"additionalTrustBundle,omitempty"`
 }
 
 // FeatureProfile represents a feature profile.
@@ -374,7 +570,13 @@ type LoadBalancerProfile struct {
 }
 
 // EffectiveOutboundIP represents an effective outbound IP resource of the cluster public load balancer.
-type EffectiveOutboundIP ResourceReference
+type EffectiveOutboundIP struct {
+	// The fully qualified Azure resource id of an IP address resource.
+	ID string `json:"id,omitempty"`
+	// IPAddress is the public IP address of the resource, populated by the
+	// RP when it reconciles the load balancer.
+	IPAddress string `json:"ipAddress,omitempty"`
+}
 
 // ManagedOutboundIPs represents the desired managed outbound IPs for the cluster public load balancer.
 type ManagedOutboundIPs struct {
@@ -432,6 +634,32 @@ const (
 	EncryptionAtHostDisabled EncryptionAtHost = "Disabled"
 )
 
+// SecurityType represents the security type of the cluster VMs.
+type SecurityType string
+
+// SecurityType constants
+const (
+	// SecurityTypeStandard VMs boot from Hyper-V generation 1 images.
+	SecurityTypeStandard SecurityType = "Standard"
+	// SecurityTypeTrustedLaunch VMs boot from Hyper-V generation 2 images
+	// with secure boot and a virtual TPM enabled.
+	SecurityTypeTrustedLaunch SecurityType = "TrustedLaunch"
+	// SecurityTypeConfidentialVM VMs additionally run with their memory
+	// encrypted by the hardware; only confidential VM sizes support it, and
+	// only for workers.
+	SecurityTypeConfidentialVM SecurityType = "ConfidentialVM"
+)
+
+// SecurityEncryptionType represents what a confidential VM encrypts with its
+// confidential key.
+type SecurityEncryptionType string
+
+// SecurityEncryptionType constants
+const (
+	SecurityEncryptionTypeVMGuestStateOnly     SecurityEncryptionType = "VMGuestStateOnly"
+	SecurityEncryptionTypeDiskWithVMGuestState SecurityEncryptionType = "DiskWithVMGuestState"
+)
+
 // MasterProfile represents a master profile
 type MasterProfile struct {
 	MissingFields
@@ -440,6 +668,12 @@ type MasterProfile struct {
 	SubnetID            string           `json:"subnetId,omitempty"`
 	EncryptionAtHost    EncryptionAtHost `json:"encryptionAtHost,omitempty"`
 	DiskEncryptionSetID string           `json:"diskEncryptionSetId,omitempty"`
+	SecurityType        SecurityType     `json:"securityType,omitempty"`
+
+	// IPAddresses and NetworkInterfaceIDs are mutually exclusive; when set,
+	// they hold one entry per master, in master index order
+	IPAddresses         []string `json:"ipAddresses,omitempty"`
+	NetworkInterfaceIDs []string `json:"networkInterfaceIds,omitempty"`
 }
 
 // VMSize represents a VM size
@@ -488,6 +722,31 @@ const (
 	VMSizeStandardD64dsV5 VMSize = "Standard_D64ds_v5"
 	VMSizeStandardD96dsV5 VMSize = "Standard_D96ds_v5"
 
+	// Arm64 VMs
+	VMSizeStandardD4psV5  VMSize = "Standard_D4ps_v5"
+	VMSizeStandardD8psV5  VMSize = "Standard_D8ps_v5"
+	VMSizeStandardD16psV5 VMSize = "Standard_D16ps_v5"
+	VMSizeStandardD32psV5 VMSize = "Standard_D32ps_v5"
+	VMSizeStandardD48psV5 VMSize = "Standard_D48ps_v5"
+	VMSizeStandardD64psV5 VMSize = "Standard_D64ps_v5"
+
+	// Confidential VMs
+	VMSizeStandardDC4asV5  VMSize = "Standard_DC4as_v5"
+	VMSizeStandardDC8asV5  VMSize = "Standard_DC8as_v5"
+	VMSizeStandardDC16asV5 VMSize = "Standard_DC16as_v5"
+	VMSizeStandardDC32asV5 VMSize = "Standard_DC32as_v5"
+	VMSizeStandardDC48asV5 VMSize = "Standard_DC48as_v5"
+	VMSizeStandardDC64asV5 VMSize = "Standard_DC64as_v5"
+	VMSizeStandardDC96asV5 VMSize = "Standard_DC96as_v5"
+	VMSizeStandardEC4asV5  VMSize = "Standard_EC4as_v5"
+	VMSizeStandardEC8asV5  VMSize = "Standard_EC8as_v5"
+	VMSizeStandardEC16asV5 VMSize = "Standard_EC16as_v5"
+	VMSizeStandardEC20asV5 VMSize = "Standard_EC20as_v5"
+	VMSizeStandardEC32asV5 VMSize = "Standard_EC32as_v5"
+	VMSizeStandardEC48asV5 VMSize = "Standard_EC48as_v5"
+	VMSizeStandardEC64asV5 VMSize = "Standard_EC64as_v5"
+	VMSizeStandardEC96asV5 VMSize = "Standard_EC96as_v5"
+
 	VMSizeStandardE4sV3  VMSize = "Standard_E4s_v3"
 	VMSizeStandardE8sV3  VMSize = "Standard_E8s_v3"
 	VMSizeStandardE16sV3 VMSize = "Standard_E16s_v3"
@@ -619,6 +878,30 @@ var (
 	VMSizeStandardD64dsV5Struct = VMSizeStruct{CoreCount: 64, Family: standardDDSv5}
 	VMSizeStandardD96dsV5Struct = VMSizeStruct{CoreCount: 96, Family: standardDDSv5}
 
+	VMSizeStandardD4psV5Struct  = VMSizeStruct{CoreCount: 4, Family: standardDPSv5}
+	VMSizeStandardD8psV5Struct  = VMSizeStruct{CoreCount: 8, Family: standardDPSv5}
+	VMSizeStandardD16psV5Struct = VMSizeStruct{CoreCount: 16, Family: standardDPSv5}
+	VMSizeStandardD32psV5Struct = VMSizeStruct{CoreCount: 32, Family: standardDPSv5}
+	VMSizeStandardD48psV5Struct = VMSizeStruct{CoreCount: 48, Family: standardDPSv5}
+	VMSizeStandardD64psV5Struct = VMSizeStruct{CoreCount: 64, Family: standardDPSv5}
+
+	VMSizeStandardDC4asV5Struct  = VMSizeStruct{CoreCount: 4, Family: standardDCASv5}
+	VMSizeStandardDC8asV5Struct  = VMSizeStruct{CoreCount: 8, Family: standardDCASv5}
+	VMSizeStandardDC16asV5Struct = VMSizeStruct{CoreCount: 16, Family: standardDCASv5}
+	VMSizeStandardDC32asV5Struct = VMSizeStruct{CoreCount: 32, Family: standardDCASv5}
+	VMSizeStandardDC48asV5Struct = VMSizeStruct{CoreCount: 48, Family: standardDCASv5}
+	VMSizeStandardDC64asV5Struct = VMSizeStruct{CoreCount: 64, Family: standardDCASv5}
+	VMSizeStandardDC96asV5Struct = VMSizeStruct{CoreCount: 96, Family: standardDCASv5}
+
+	VMSizeStandardEC4asV5Struct  = VMSizeStruct{CoreCount: 4, Family: standardECASv5}
+	VMSizeStandardEC8asV5Struct  = VMSizeStruct{CoreCount: 8, Family: standardECASv5}
+	VMSizeStandardEC16asV5Struct = VMSizeStruct{CoreCount: 16, Family: standardECASv5}
+	VMSizeStandardEC20asV5Struct = VMSizeStruct{CoreCount: 20, Family: standardECASv5}
+	VMSizeStandardEC32asV5Struct = VMSizeStruct{CoreCount: 32, Family: standardECASv5}
+	VMSizeStandardEC48asV5Struct = VMSizeStruct{CoreCount: 48, Family: standardECASv5}
+	VMSizeStandardEC64asV5Struct = VMSizeStruct{CoreCount: 64, Family: standardECASv5}
+	VMSizeStandardEC96asV5Struct = VMSizeStruct{CoreCount: 96, Family: standardECASv5}
+
 	VMSizeStandardE4sV3Struct  = VMSizeStruct{CoreCount: 4, Family: standardESv3}
 	VMSizeStandardE8sV3Struct  = VMSizeStruct{CoreCount: 8, Family: standardESv3}
 	VMSizeStandardE16sV3Struct = VMSizeStruct{CoreCount: 16, Family: standardESv3}
@@ -713,6 +996,9 @@ const (
 	standardDASv4  = "standardDASv4Family"
 	standardDASv5  = "standardDASv5Family"
 	standardDDSv5  = "standardDDSv5Family"
+	standardDPSv5  = "standardDPSv5Family"
+	standardDCASv5 = "standardDCASv5Family"
+	standardECASv5 = "standardECASv5Family"
 	standardESv3   = "standardESv3Family"
 	standardESv4   = "standardESv4Family"
 	standardESv5   = "standardESv5Family"
@@ -743,6 +1029,10 @@ type WorkerProfile struct {
 	Count               int              `json:"count,omitempty"`
 	EncryptionAtHost    EncryptionAtHost `json:"encryptionAtHost,omitempty"`
 	DiskEncryptionSetID string           `json:"diskEncryptionSetId,omitempty"`
+	SecurityType        SecurityType     `json:"securityType,omitempty"`
+
+	// SecurityEncryptionType is only set for ConfidentialVM workers
+	SecurityEncryptionType SecurityEncryptionType `json:"securityEncryptionType,omitempty"`
 }
 
 // GetEnrichedWorkerProfiles returns WorkerProfilesStatus if not nil, otherwise WorkerProfiles
@@ -799,6 +1089,11 @@ type Install struct {
 
 	Now   time.Time    `json:"now,omitempty"`
 	Phase InstallPhase `json:"phase"`
+
+	// RetryBootstrap is set when a failed bootstrap phase has been requeued
+	// via the admin API.  The bootstrap resources are then re-deployed while
+	// the existing control plane resources and graph are reused.
+	RetryBootstrap bool `json:"retryBootstrap,omitempty"`
 }
 
 // InstallPhase represents an install phase
@@ -831,6 +1126,11 @@ type HiveProfile struct {
 	// of clusters that were created by Hive to avoid deleting existing
 	// ClusterDeployments.
 	CreatedByHive bool `json:"createdByHive,omitempty"`
+
+	// Shard is the Hive shard which the cluster is registered with.  Clusters
+	// registered before shards were assigned have a zero Shard and are on
+	// shard 1.
+	Shard int `json:"shard,omitempty"`
 }
 
 // PlatformWorkloadIdentityProfile encapsulates all information that is specific to workload identity clusters.
@@ -856,6 +1156,20 @@ type PlatformWorkloadIdentity struct {
 
 	// The ObjectID of the PlatformWorkloadIdentity resource
 	ObjectID string `json:"objectId,omitempty" swagger:"readOnly"`
+
+	// The federated identity credentials the RP created on the PlatformWorkloadIdentity resource
+	FederatedIdentityCredentials []FederatedIdentityCredential `json:"federatedIdentityCredentials,omitempty"`
+}
+
+// FederatedIdentityCredential represents a federated identity credential
+// created by the RP on a platform workload identity.
+type FederatedIdentityCredential struct {
+	MissingFields
+
+	Name      string   `json:"name,omitempty"`
+	Issuer    string   `json:"issuer,omitempty"`
+	Subject   string   `json:"subject,omitempty"`
+	Audiences []string `json:"audiences,omitempty"`
 }
 
 // UserAssignedIdentity stores information about a user-assigned managed identity in a predefined format required by Microsoft's Managed Identity team.
@@ -5,7 +5,9 @@ package api
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
 	"go.uber.org/mock/gomock"
 
@@ -248,3 +250,164 @@ func TestHasUserAssignedIdentities(t *testing.T) {
 		})
 	}
 }
+
+func TestActiveMaintenanceWindowEnd(t *testing.T) {
+	// 2024-06-01 is a Saturday
+	saturday := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	profile := &UpgradeProfile{
+		MaintenanceWindows: []MaintenanceWindow{
+			{
+				DayOfWeek:     "Saturday",
+				StartHour:     2,
+				DurationHours: 4,
+			},
+			{
+				DayOfWeek:     "sunday",
+				StartHour:     22,
+				DurationHours: 6,
+			},
+			{
+				DayOfWeek:     "Caturday",
+				StartHour:     0,
+				DurationHours: 24,
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name    string
+		profile *UpgradeProfile
+		t       time.Time
+		wantEnd time.Time
+	}{
+		{
+			name:    "no profile",
+			t:       saturday.Add(3 * time.Hour),
+			profile: nil,
+		},
+		{
+			name:    "before window",
+			profile: profile,
+			t:       saturday.Add(time.Hour),
+		},
+		{
+			name:    "start of window",
+			profile: profile,
+			t:       saturday.Add(2 * time.Hour),
+			wantEnd: saturday.Add(6 * time.Hour),
+		},
+		{
+			name:    "in window",
+			profile: profile,
+			t:       saturday.Add(5 * time.Hour),
+			wantEnd: saturday.Add(6 * time.Hour),
+		},
+		{
+			name:    "end of window",
+			profile: profile,
+			t:       saturday.Add(6 * time.Hour),
+		},
+		{
+			name:    "in window spanning midnight",
+			profile: profile,
+			t:       saturday.AddDate(0, 0, 2).Add(time.Hour),
+			wantEnd: saturday.AddDate(0, 0, 2).Add(4 * time.Hour),
+		},
+		{
+			name:    "in window in another time zone",
+			profile: profile,
+			t:       saturday.Add(3 * time.Hour).In(time.FixedZone("UTC-8", -8*60*60)),
+			wantEnd: saturday.Add(6 * time.Hour),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			end, ok := tt.profile.ActiveMaintenanceWindowEnd(tt.t)
+			if ok != !tt.wantEnd.IsZero() {
+				t.Fatal(ok)
+			}
+			if !end.Equal(tt.wantEnd) {
+				t.Error(end)
+			}
+		})
+	}
+}
+
+func TestAutomaticUpgradesEnabled(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		profile *UpgradeProfile
+		want    bool
+	}{
+		{
+			name: "no profile",
+		},
+		{
+			name:    "manual",
+			profile: &UpgradeProfile{Policy: UpgradePolicyManual},
+		},
+		{
+			name:    "automatic patch",
+			profile: &UpgradeProfile{Policy: UpgradePolicyAutomaticPatch},
+			want:    true,
+		},
+		{
+			name: "automatic patch paused",
+			profile: &UpgradeProfile{
+				Policy:                        UpgradePolicyAutomaticPatch,
+				AutomaticUpgradesPausedReason: "upgrade failed",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.profile.AutomaticUpgradesEnabled(); got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestAppendMaintenanceHistory(t *testing.T) {
+	entries := func(first, last int) []MaintenanceHistoryEntry {
+		var e []MaintenanceHistoryEntry
+		for i := first; i <= last; i++ {
+			e = append(e, MaintenanceHistoryEntry{Type: fmt.Sprintf("task%d", i)})
+		}
+		return e
+	}
+
+	for _, tt := range []struct {
+		name     string
+		existing []MaintenanceHistoryEntry
+		append   []MaintenanceHistoryEntry
+		want     []MaintenanceHistoryEntry
+	}{
+		{
+			name:   "empty history",
+			append: entries(1, 1),
+			want:   entries(1, 1),
+		},
+		{
+			name:     "appends in order",
+			existing: entries(1, 2),
+			append:   entries(3, 4),
+			want:     entries(1, 4),
+		},
+		{
+			name:     "drops oldest entries",
+			existing: entries(1, MaxMaintenanceHistoryEntries),
+			append:   entries(MaxMaintenanceHistoryEntries+1, MaxMaintenanceHistoryEntries+2),
+			want:     entries(3, MaxMaintenanceHistoryEntries+2),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &OpenShiftClusterProperties{MaintenanceHistory: tt.existing}
+
+			p.AppendMaintenanceHistory(tt.append...)
+
+			if !reflect.DeepEqual(p.MaintenanceHistory, tt.want) {
+				t.Error(p.MaintenanceHistory)
+			}
+		})
+	}
+}
@@ -29,6 +29,10 @@ type OpenShiftClusterDocument struct {
 	LSN         int                    `json:"_lsn,omitempty"`
 	Metadata    map[string]interface{} `json:"_metadata,omitempty"`
 
+	// SchemaVersion is the version of the document schema last written; see
+	// database.OpenShiftClusterDocumentSchemaVersion
+	SchemaVersion int `json:"schemaVersion,omitempty" deep:"-"`
+
 	Key                       string `json:"key,omitempty"`
 	PartitionKey              string `json:"partitionKey,omitempty" deep:"-"`
 	ClusterResourceGroupIDKey string `json:"clusterResourceGroupIdKey,omitempty"`
@@ -45,6 +49,10 @@ type OpenShiftClusterDocument struct {
 	OpenShiftCluster *OpenShiftCluster `json:"openShiftCluster,omitempty"`
 
 	CorrelationData *CorrelationData `json:"correlationData,omitempty" deep:"-"`
+
+	// TTL is only set on tombstones of deleted clusters; see
+	// database.OpenShiftClusterTombstones
+	TTL int `json:"ttl,omitempty" deep:"-"`
 }
 
 func (c *OpenShiftClusterDocument) String() string {
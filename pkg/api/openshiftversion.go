@@ -3,6 +3,12 @@ package api
 // Copyright (c) Microsoft Corporation.
 // Licensed under the Apache License 2.0.
 
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
 // OpenShiftVersion represents an OpenShift version that can be installed
 type OpenShiftVersion struct {
 	MissingFields
@@ -26,4 +32,32 @@ type OpenShiftVersionProperties struct {
 	InstallerPullspec string `json:"installerPullspec,omitempty"`
 	Enabled           bool   `json:"enabled,omitempty"`
 	Default           bool   `json:"default,omitempty"`
+
+	// MultiArchPullspec, if set, is the multi-architecture release payload of
+	// the version, from which clusters with Arm64 workers are installed
+	MultiArchPullspec string `json:"multiArchPullspec,omitempty"`
+
+	// DisabledLocations lists the regions in which an enabled version cannot
+	// be installed
+	DisabledLocations []string `json:"disabledLocations,omitempty"`
+
+	// EnabledBy and EnabledAt record who enabled the version, or last changed
+	// the release payload of an enabled version, and when
+	EnabledBy string     `json:"enabledBy,omitempty"`
+	EnabledAt *time.Time `json:"enabledAt,omitempty"`
+
+	// GADate, EndOfSupportDate and EndOfLifeDate describe the support
+	// lifecycle of the version, in YYYY-MM-DD format
+	GADate           string `json:"gaDate,omitempty"`
+	EndOfSupportDate string `json:"endOfSupportDate,omitempty"`
+	EndOfLifeDate    string `json:"endOfLifeDate,omitempty"`
+
+	// RequiredFeature, if set, is the ARM feature which a subscription must
+	// have registered to install the version, e.g. for a private preview
+	RequiredFeature string `json:"requiredFeature,omitempty"`
+}
+
+// IsEnabledIn returns true if the version can be installed in location
+func (v *OpenShiftVersion) IsEnabledIn(location string) bool {
+	return v.Properties.Enabled && !slices.Contains(v.Properties.DisabledLocations, strings.ToLower(location))
 }
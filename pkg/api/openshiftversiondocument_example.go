@@ -16,6 +16,9 @@ func ExampleOpenShiftVersionDocument() *OpenShiftVersionDocument {
 				OpenShiftPullspec: "ab:c",
 				InstallerPullspec: "de:f",
 				Enabled:           true,
+				GADate:            "2022-03-10",
+				EndOfSupportDate:  "2023-07-17",
+				EndOfLifeDate:     "2023-12-21",
 			},
 		},
 	}
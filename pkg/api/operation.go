@@ -120,6 +120,16 @@ var OperationOpenShiftClusterListAdminCredentials = Operation{
 	Origin: "user,system",
 }
 
+var OperationOpenShiftClusterGetFederatedIdentityCredentials = Operation{
+	Name: "Microsoft.RedHatOpenShift/openShiftClusters/federatedIdentityCredentials/read",
+	Display: Display{
+		Provider:  "Azure Red Hat OpenShift",
+		Resource:  "openShiftClusters",
+		Operation: "Get federated identity credentials of an OpenShift cluster",
+	},
+	Origin: "user,system",
+}
+
 var OperationOpenShiftClusterGetDetectors = Operation{
 	Name: "Microsoft.RedHatOpenShift/openShiftClusters/detectors/read",
 	Display: Display{
@@ -12,8 +12,10 @@ type Portal struct {
 	// ID is the resourceID of the cluster being accessed by the SRE
 	ID string `json:"id,omitempty"`
 
-	SSH        *SSH        `json:"ssh,omitempty"`
-	Kubeconfig *Kubeconfig `json:"kubeconfig,omitempty"`
+	SSH             *SSH             `json:"ssh,omitempty"`
+	SSHSession      *SSHSession      `json:"sshSession,omitempty"`
+	Kubeconfig      *Kubeconfig      `json:"kubeconfig,omitempty"`
+	PrometheusQuery *PrometheusQuery `json:"prometheusQuery,omitempty"`
 }
 
 type SSH struct {
@@ -23,8 +25,29 @@ type SSH struct {
 	Authenticated bool `json:"authenticated,omitempty"`
 }
 
+// SSHSession records the metadata of an SRE's SSH session to a cluster node.
+// The commands run during the session are sent to the audit log.
+type SSHSession struct {
+	MissingFields
+
+	Master     int    `json:"master"`
+	RemoteAddr string `json:"remoteAddr,omitempty"`
+	StartTime  int64  `json:"startTime,omitempty"`
+	EndTime    int64  `json:"endTime,omitempty"`
+	Commands   int    `json:"commands,omitempty"`
+}
+
 type Kubeconfig struct {
 	MissingFields
 
 	Elevated bool `json:"elevated,omitempty"`
 }
+
+// PrometheusQuery is a PromQL query saved by an SRE for reuse against any
+// cluster.  Username is the SRE who saved it.
+type PrometheusQuery struct {
+	MissingFields
+
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
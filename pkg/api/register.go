@@ -12,7 +12,7 @@ type OpenShiftClusterConverter interface {
 }
 
 type OpenShiftClusterStaticValidator interface {
-	Static(interface{}, *OpenShiftCluster, string, string, bool, string) error
+	Static(interface{}, *OpenShiftCluster, string, string, bool, []*SupportedVMSize, string) error
 }
 
 type ClusterManagerStaticValidator interface {
@@ -27,9 +27,17 @@ type OpenShiftClusterAdminKubeconfigConverter interface {
 	ToExternal(*OpenShiftCluster) interface{}
 }
 
+type OpenShiftClusterExecCredentialsConverter interface {
+	ToExternal([]byte) interface{}
+}
+
+type OpenShiftClusterFederatedIdentityCredentialsConverter interface {
+	ToExternal(*OpenShiftCluster) interface{}
+}
+
 type OpenShiftVersionConverter interface {
 	ToExternal(*OpenShiftVersion) interface{}
-	ToExternalList([]*OpenShiftVersion) interface{}
+	ToExternalList([]*OpenShiftVersion, string) interface{}
 	ToInternal(interface{}, *OpenShiftVersion)
 }
 
@@ -39,7 +47,7 @@ type OpenShiftVersionStaticValidator interface {
 
 type PlatformWorkloadIdentityRoleSetConverter interface {
 	ToExternal(*PlatformWorkloadIdentityRoleSet) interface{}
-	ToExternalList([]*PlatformWorkloadIdentityRoleSet) interface{}
+	ToExternalList([]*PlatformWorkloadIdentityRoleSet, string) interface{}
 	ToInternal(interface{}, *PlatformWorkloadIdentityRoleSet)
 }
 
@@ -47,6 +55,16 @@ type PlatformWorkloadIdentityRoleSetStaticValidator interface {
 	Static(interface{}, *PlatformWorkloadIdentityRoleSet) error
 }
 
+type SupportedVMSizeConverter interface {
+	ToExternal(*SupportedVMSize) interface{}
+	ToExternalList([]*SupportedVMSize) interface{}
+	ToInternal(interface{}, *SupportedVMSize)
+}
+
+type SupportedVMSizeStaticValidator interface {
+	Static(interface{}, *SupportedVMSize) error
+}
+
 type SyncSetConverter interface {
 	ToExternal(*SyncSet) interface{}
 	ToExternalList([]*SyncSet) interface{}
@@ -83,22 +101,26 @@ type MaintenanceManifestStaticValidator interface {
 
 // Version is a set of endpoints implemented by each API version
 type Version struct {
-	OpenShiftClusterConverter                      OpenShiftClusterConverter
-	OpenShiftClusterStaticValidator                OpenShiftClusterStaticValidator
-	OpenShiftClusterCredentialsConverter           OpenShiftClusterCredentialsConverter
-	OpenShiftClusterAdminKubeconfigConverter       OpenShiftClusterAdminKubeconfigConverter
-	OpenShiftVersionConverter                      OpenShiftVersionConverter
-	OpenShiftVersionStaticValidator                OpenShiftVersionStaticValidator
-	PlatformWorkloadIdentityRoleSetConverter       PlatformWorkloadIdentityRoleSetConverter
-	PlatformWorkloadIdentityRoleSetStaticValidator PlatformWorkloadIdentityRoleSetStaticValidator
-	OperationList                                  OperationList
-	SyncSetConverter                               SyncSetConverter
-	MachinePoolConverter                           MachinePoolConverter
-	SyncIdentityProviderConverter                  SyncIdentityProviderConverter
-	SecretConverter                                SecretConverter
-	ClusterManagerStaticValidator                  ClusterManagerStaticValidator
-	MaintenanceManifestConverter                   MaintenanceManifestConverter
-	MaintenanceManifestStaticValidator             MaintenanceManifestStaticValidator
+	OpenShiftClusterConverter                             OpenShiftClusterConverter
+	OpenShiftClusterStaticValidator                       OpenShiftClusterStaticValidator
+	OpenShiftClusterCredentialsConverter                  OpenShiftClusterCredentialsConverter
+	OpenShiftClusterAdminKubeconfigConverter              OpenShiftClusterAdminKubeconfigConverter
+	OpenShiftClusterExecCredentialsConverter              OpenShiftClusterExecCredentialsConverter
+	OpenShiftClusterFederatedIdentityCredentialsConverter OpenShiftClusterFederatedIdentityCredentialsConverter
+	OpenShiftVersionConverter                             OpenShiftVersionConverter
+	OpenShiftVersionStaticValidator                       OpenShiftVersionStaticValidator
+	PlatformWorkloadIdentityRoleSetConverter              PlatformWorkloadIdentityRoleSetConverter
+	PlatformWorkloadIdentityRoleSetStaticValidator        PlatformWorkloadIdentityRoleSetStaticValidator
+	SupportedVMSizeConverter                              SupportedVMSizeConverter
+	SupportedVMSizeStaticValidator                        SupportedVMSizeStaticValidator
+	OperationList                                         OperationList
+	SyncSetConverter                                      SyncSetConverter
+	MachinePoolConverter                                  MachinePoolConverter
+	SyncIdentityProviderConverter                         SyncIdentityProviderConverter
+	SecretConverter                                       SecretConverter
+	ClusterManagerStaticValidator                         ClusterManagerStaticValidator
+	MaintenanceManifestConverter                          MaintenanceManifestConverter
+	MaintenanceManifestStaticValidator                    MaintenanceManifestStaticValidator
 }
 
 // APIs is the map of registered API versions
@@ -29,6 +29,7 @@ type SubscriptionProperties struct {
 	MissingFields
 
 	TenantID           string                     `json:"tenantId,omitempty"`
+	QuotaID            string                     `json:"quotaId,omitempty"`
 	AccountOwner       *AccountOwnerProfile       `json:"accountOwner,omitempty"`
 	RegisteredFeatures []RegisteredFeatureProfile `json:"registeredFeatures,omitempty"`
 }
@@ -0,0 +1,43 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"slices"
+)
+
+// SupportedVMSize represents a VM size which clusters may use in addition to
+// the VM sizes built into the RP, e.g. a newly available SKU
+type SupportedVMSize struct {
+	MissingFields
+
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Deleting bool   `json:"deleting,omitempty"` // https://docs.microsoft.com/en-us/azure/cosmos-db/change-feed-design-patterns#deletes
+
+	// The properties for the SupportedVMSize resource.
+	Properties SupportedVMSizeProperties `json:"properties,omitempty"`
+}
+
+// SupportedVMSizeProperties represents the properties of a SupportedVMSize.
+type SupportedVMSizeProperties struct {
+	MissingFields
+
+	// VMSize is the name of the VM size, e.g. Standard_D8s_v5
+	VMSize VMSize `json:"vmSize,omitempty"`
+
+	// CoreCount and Family are used to validate the quota of the VM size
+	CoreCount int    `json:"coreCount,omitempty"`
+	Family    string `json:"family,omitempty"`
+
+	// Roles lists the node roles, master and/or worker, which may use the VM
+	// size
+	Roles []string `json:"roles,omitempty"`
+}
+
+// IsSupportedAs returns true if the VM size may be used for nodes of role
+func (s *SupportedVMSize) IsSupportedAs(role string) bool {
+	return slices.Contains(s.Properties.Roles, role)
+}
@@ -0,0 +1,38 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// SupportedVMSizeDocuments represents supported VM size documents.
+// pkg/database/cosmosdb requires its definition.
+type SupportedVMSizeDocuments struct {
+	Count                    int                        `json:"_count,omitempty"`
+	ResourceID               string                     `json:"_rid,omitempty"`
+	SupportedVMSizeDocuments []*SupportedVMSizeDocument `json:"Documents,omitempty"`
+}
+
+func (c *SupportedVMSizeDocuments) String() string {
+	return encodeJSON(c)
+}
+
+// SupportedVMSizeDocument represents a supported VM size document.
+// pkg/database/cosmosdb requires its definition.
+type SupportedVMSizeDocument struct {
+	MissingFields
+
+	ID          string                 `json:"id,omitempty"`
+	ResourceID  string                 `json:"_rid,omitempty"`
+	Timestamp   int                    `json:"_ts,omitempty"`
+	Self        string                 `json:"_self,omitempty"`
+	ETag        string                 `json:"_etag,omitempty" deep:"-"`
+	Attachments string                 `json:"_attachments,omitempty"`
+	TTL         int                    `json:"ttl,omitempty"`
+	LSN         int                    `json:"_lsn,omitempty"`
+	Metadata    map[string]interface{} `json:"_metadata,omitempty"`
+
+	SupportedVMSize *SupportedVMSize `json:"supportedVMSize,omitempty"`
+}
+
+func (c *SupportedVMSizeDocument) String() string {
+	return encodeJSON(c)
+}
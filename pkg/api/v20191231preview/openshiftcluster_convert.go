@@ -91,6 +91,15 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		}
 	}
 
+	out.SystemData = &SystemData{
+		CreatedBy:          oc.SystemData.CreatedBy,
+		CreatedAt:          oc.SystemData.CreatedAt,
+		CreatedByType:      CreatedByType(oc.SystemData.CreatedByType),
+		LastModifiedBy:     oc.SystemData.LastModifiedBy,
+		LastModifiedAt:     oc.SystemData.LastModifiedAt,
+		LastModifiedByType: CreatedByType(oc.SystemData.LastModifiedByType),
+	}
+
 	return out
 }
 
@@ -166,9 +175,21 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			out.Properties.IngressProfiles[i].IP = oc.Properties.IngressProfiles[i].IP
 		}
 	}
+
+	if oc.SystemData != nil {
+		out.SystemData = api.SystemData{
+			CreatedBy:          oc.SystemData.CreatedBy,
+			CreatedAt:          oc.SystemData.CreatedAt,
+			CreatedByType:      api.CreatedByType(oc.SystemData.CreatedByType),
+			LastModifiedBy:     oc.SystemData.LastModifiedBy,
+			LastModifiedAt:     oc.SystemData.LastModifiedAt,
+			LastModifiedByType: api.CreatedByType(oc.SystemData.LastModifiedByType),
+		}
+	}
 }
 
 // ExternalNoReadOnly removes all read-only fields from the external representation.
 func (c openShiftClusterConverter) ExternalNoReadOnly(_oc interface{}) {
-	// This version of the API has no read-only fields.
+	oc := _oc.(*OpenShiftCluster)
+	oc.SystemData = nil
 }
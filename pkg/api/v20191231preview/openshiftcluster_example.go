@@ -35,6 +35,7 @@ func ExampleOpenShiftClusterPutParameter() *OpenShiftCluster {
 	oc.Properties.APIServerProfile.URL = ""
 	oc.Properties.APIServerProfile.IP = ""
 	oc.Properties.IngressProfiles[0].IP = ""
+	oc.SystemData = nil
 
 	return oc
 }
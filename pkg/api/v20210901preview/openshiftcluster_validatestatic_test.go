@@ -10,8 +10,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/Azure/go-autorest/autorest/azure"
-
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/util/uuid"
 	"github.com/Azure/ARO-RP/test/validate"
@@ -116,19 +114,7 @@ func runTests(t *testing.T, mode testMode, tests []*validateTest) {
 	t.Run(string(mode), func(t *testing.T) {
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				v := &openShiftClusterStaticValidator{
-					location:            "location",
-					domain:              "location.aroapp.io",
-					requireD2sV3Workers: tt.requireD2sV3Workers,
-					resourceID:          id,
-					r: azure.Resource{
-						SubscriptionID: subscriptionID,
-						ResourceGroup:  "resourceGroup",
-						Provider:       "Microsoft.RedHatOpenShift",
-						ResourceType:   "openshiftClusters",
-						ResourceName:   "resourceName",
-					},
-				}
+				v := &openShiftClusterStaticValidator{}
 
 				validOCForTest := func() *OpenShiftCluster {
 					oc := validOpenShiftCluster()
@@ -149,7 +135,7 @@ func runTests(t *testing.T, mode testMode, tests []*validateTest) {
 					(&openShiftClusterConverter{}).ToInternal(validOCForTest(), current)
 				}
 
-				err := v.Static(oc, current, v.location, v.domain, tt.requireD2sV3Workers, v.resourceID)
+				err := v.Static(oc, current, "location", "location.aroapp.io", tt.requireD2sV3Workers, nil, id)
 				if err == nil {
 					if tt.wantErr != "" {
 						t.Error(err)
@@ -1008,3 +994,62 @@ func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 
 	runTests(t, testModeUpdate, tests)
 }
+
+func TestOpenShiftClusterStaticValidateEmptyProfiles(t *testing.T) {
+	createTests := []*validateTest{
+		{
+			name: "empty workerProfiles invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles = []WorkerProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles: There should be exactly one worker profile.",
+		},
+		{
+			name: "empty servicePrincipalProfile invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ServicePrincipalProfile = &ServicePrincipalProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.servicePrincipalProfile.clientId: The provided client ID '' is invalid.",
+		},
+		{
+			name: "nil ingressProfiles invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles = nil
+			},
+			wantErr: "400: InvalidParameter: properties.ingressProfiles: There should be exactly one ingress profile.",
+		},
+		{
+			name: "empty ingressProfiles invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles = []IngressProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.ingressProfiles: There should be exactly one ingress profile.",
+		},
+	}
+	updateTests := []*validateTest{
+		{
+			name: "empty servicePrincipalProfile invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ServicePrincipalProfile = &ServicePrincipalProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.servicePrincipalProfile.clientId: The provided client ID '' is invalid.",
+		},
+		{
+			name: "nil ingressProfiles invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles = nil
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.ingressProfiles: Changing property 'properties.ingressProfiles' is not allowed.",
+		},
+		{
+			name: "empty ingressProfiles invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles = []IngressProfile{}
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.ingressProfiles: Changing property 'properties.ingressProfiles' is not allowed.",
+		},
+	}
+
+	runTests(t, testModeCreate, createTests)
+	runTests(t, testModeUpdate, updateTests)
+}
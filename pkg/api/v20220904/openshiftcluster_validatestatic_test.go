@@ -10,7 +10,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/to"
 
 	"github.com/Azure/ARO-RP/pkg/api"
@@ -131,19 +130,7 @@ func runTests(t *testing.T, mode testMode, tests []*validateTest) {
 					tt.clusterName = to.StringPtr("resourceName")
 				}
 
-				v := &openShiftClusterStaticValidator{
-					location:            *tt.location,
-					domain:              "location.aroapp.io",
-					requireD2sV3Workers: tt.requireD2sV3Workers,
-					resourceID:          getResourceID(*tt.clusterName),
-					r: azure.Resource{
-						SubscriptionID: subscriptionID,
-						ResourceGroup:  "resourceGroup",
-						Provider:       "Microsoft.RedHatOpenShift",
-						ResourceType:   "openshiftClusters",
-						ResourceName:   *tt.clusterName,
-					},
-				}
+				v := &openShiftClusterStaticValidator{}
 
 				validOCForTest := func() *OpenShiftCluster {
 					oc := validOpenShiftCluster(*tt.clusterName, *tt.location)
@@ -164,7 +151,7 @@ func runTests(t *testing.T, mode testMode, tests []*validateTest) {
 					(&openShiftClusterConverter{}).ToInternal(validOCForTest(), current)
 				}
 
-				err := v.Static(oc, current, v.location, v.domain, tt.requireD2sV3Workers, v.resourceID)
+				err := v.Static(oc, current, *tt.location, "location.aroapp.io", tt.requireD2sV3Workers, nil, getResourceID(*tt.clusterName))
 				if err == nil {
 					if tt.wantErr != "" {
 						t.Error(err)
@@ -1006,3 +993,62 @@ func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 
 	runTests(t, testModeUpdate, tests)
 }
+
+func TestOpenShiftClusterStaticValidateEmptyProfiles(t *testing.T) {
+	createTests := []*validateTest{
+		{
+			name: "empty workerProfiles invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles = []WorkerProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles: There should be exactly one worker profile.",
+		},
+		{
+			name: "empty servicePrincipalProfile invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ServicePrincipalProfile = &ServicePrincipalProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.servicePrincipalProfile.clientId: The provided client ID '' is invalid.",
+		},
+		{
+			name: "nil ingressProfiles invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles = nil
+			},
+			wantErr: "400: InvalidParameter: properties.ingressProfiles: There should be exactly one ingress profile.",
+		},
+		{
+			name: "empty ingressProfiles invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles = []IngressProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.ingressProfiles: There should be exactly one ingress profile.",
+		},
+	}
+	updateTests := []*validateTest{
+		{
+			name: "empty servicePrincipalProfile invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ServicePrincipalProfile = &ServicePrincipalProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.servicePrincipalProfile.clientId: The provided client ID '' is invalid.",
+		},
+		{
+			name: "nil ingressProfiles invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles = nil
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.ingressProfiles: Changing property 'properties.ingressProfiles' is not allowed.",
+		},
+		{
+			name: "empty ingressProfiles invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles = []IngressProfile{}
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.ingressProfiles: Changing property 'properties.ingressProfiles' is not allowed.",
+		},
+	}
+
+	runTests(t, testModeCreate, createTests)
+	runTests(t, testModeUpdate, updateTests)
+}
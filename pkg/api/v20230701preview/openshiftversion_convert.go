@@ -28,9 +28,10 @@ func (openShiftVersionConverter) ToExternal(v *api.OpenShiftVersion) interface{}
 
 // ToExternalList returns a slice of external representations of the internal
 // objects
-func (c openShiftVersionConverter) ToExternalList(vers []*api.OpenShiftVersion) interface{} {
+func (c openShiftVersionConverter) ToExternalList(vers []*api.OpenShiftVersion, nextLink string) interface{} {
 	l := &OpenShiftVersionList{
 		OpenShiftVersions: make([]*OpenShiftVersion, 0, len(vers)),
+		NextLink:          nextLink,
 	}
 
 	for _, ver := range vers {
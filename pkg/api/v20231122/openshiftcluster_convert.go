@@ -273,7 +273,7 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			CreatedByType:      api.CreatedByType(oc.SystemData.CreatedByType),
 			LastModifiedBy:     oc.SystemData.LastModifiedBy,
 			LastModifiedAt:     oc.SystemData.LastModifiedAt,
-			LastModifiedByType: api.CreatedByType(oc.SystemData.CreatedByType),
+			LastModifiedByType: api.CreatedByType(oc.SystemData.LastModifiedByType),
 		}
 	}
 }
@@ -83,8 +83,21 @@ type OpenShiftClusterProperties struct {
 
 	// The cluster ingress profiles.
 	IngressProfiles []IngressProfile `json:"ingressProfiles,omitempty"`
+
+	// The cluster upgrade profile.
+	UpgradeProfile *UpgradeProfile `json:"upgradeProfile,omitempty" mutable:"true"`
+
+	// The customer-configurable ARO operator feature flags.
+	OperatorFlags OperatorFlags `json:"operatorFlags,omitempty" mutable:"true"`
+
+	// The configuration of the managed Gatekeeper policies.
+	GuardrailsProfile *GuardrailsProfile `json:"guardrailsProfile,omitempty" mutable:"true"`
 }
 
+// OperatorFlags represents the ARO operator feature flags which customers may
+// set on a cluster.
+type OperatorFlags map[string]string
+
 // ProvisioningState represents a provisioning state.
 type ProvisioningState string
 
@@ -131,6 +144,70 @@ type ClusterProfile struct {
 
 	// The URL of the managed OIDC issuer in a workload identity cluster.
 	OIDCIssuer *OIDCIssuer `json:"oidcIssuer,omitempty"`
+
+	// A PEM bundle of additional CA certificates trusted by the cluster, e.g. those of a TLS-intercepting proxy.
+	AdditionalTrustBundle string `json:"additionalTrustBundle,omitempty"`
+}
+
+// UpgradePolicy represents how the cluster is upgraded.
+type UpgradePolicy string
+
+// UpgradePolicy constants.
+const (
+	UpgradePolicyManual         UpgradePolicy = "Manual"
+	UpgradePolicyAutomaticPatch UpgradePolicy = "AutomaticPatch"
+)
+
+// UpgradeProfile represents the upgrade policy of a cluster.
+type UpgradeProfile struct {
+	// The upgrade policy.  AutomaticPatch clusters are upgraded to the latest patch release of their minor version within their maintenance windows.
+	Policy UpgradePolicy `json:"policy,omitempty"`
+
+	// The weekly windows in which automatic upgrades may start.
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// The reason automatic upgrades are paused, e.g. because an automatic upgrade failed.
+	AutomaticUpgradesPausedReason string `json:"automaticUpgradesPausedReason,omitempty" swagger:"readOnly"`
+}
+
+// GuardrailsState represents whether the managed Gatekeeper policies are deployed.
+type GuardrailsState string
+
+// GuardrailsState constants.
+const (
+	GuardrailsStateEnabled  GuardrailsState = "Enabled"
+	GuardrailsStateDisabled GuardrailsState = "Disabled"
+)
+
+// GuardrailsEnforcementAction represents the Gatekeeper enforcement action of the managed policies.
+type GuardrailsEnforcementAction string
+
+// GuardrailsEnforcementAction constants.
+const (
+	GuardrailsEnforcementActionDryRun GuardrailsEnforcementAction = "DryRun"
+	GuardrailsEnforcementActionWarn   GuardrailsEnforcementAction = "Warn"
+	GuardrailsEnforcementActionDeny   GuardrailsEnforcementAction = "Deny"
+)
+
+// GuardrailsProfile represents the configuration of the managed Gatekeeper policies deployed by the ARO operator.
+type GuardrailsProfile struct {
+	// Whether the managed Gatekeeper policies are deployed.
+	State GuardrailsState `json:"state,omitempty"`
+
+	// The enforcement action of the managed Gatekeeper policies.  Defaults to DryRun.  May only be set when the state is Enabled.
+	EnforcementAction GuardrailsEnforcementAction `json:"enforcementAction,omitempty"`
+}
+
+// MaintenanceWindow represents a weekly window, in UTC, in which automatic upgrades may start.
+type MaintenanceWindow struct {
+	// The day of the week on which the window starts, e.g. Saturday.
+	DayOfWeek string `json:"dayOfWeek,omitempty"`
+
+	// The hour, in UTC, at which the window starts.  Allowed values are in the range of 0 - 23.
+	StartHour int `json:"startHour,omitempty"`
+
+	// The duration of the window in hours.  Allowed values are in the range of 1 - 24.
+	DurationHours int `json:"durationHours,omitempty"`
 }
 
 // ConsoleProfile represents a console profile.
@@ -172,7 +249,12 @@ type LoadBalancerProfile struct {
 }
 
 // EffectiveOutboundIP represents an effective outbound IP resource of the cluster public load balancer.
-type EffectiveOutboundIP ResourceReference
+type EffectiveOutboundIP struct {
+	// The fully qualified Azure resource id of an IP address resource.
+	ID string `json:"id,omitempty"`
+	// The public IP address of the IP address resource.
+	IPAddress string `json:"ipAddress,omitempty" swagger:"readOnly"`
+}
 
 // ManagedOutboundIPs represents the desired managed outbound IPs for the cluster public load balancer.
 type ManagedOutboundIPs struct {
@@ -216,6 +298,26 @@ const (
 	EncryptionAtHostDisabled EncryptionAtHost = "Disabled"
 )
 
+// SecurityType represents the security type of the cluster VMs.
+type SecurityType string
+
+// SecurityType constants
+const (
+	SecurityTypeStandard       SecurityType = "Standard"
+	SecurityTypeTrustedLaunch  SecurityType = "TrustedLaunch"
+	SecurityTypeConfidentialVM SecurityType = "ConfidentialVM"
+)
+
+// SecurityEncryptionType represents what a confidential VM encrypts with its
+// confidential key.
+type SecurityEncryptionType string
+
+// SecurityEncryptionType constants
+const (
+	SecurityEncryptionTypeVMGuestStateOnly     SecurityEncryptionType = "VMGuestStateOnly"
+	SecurityEncryptionTypeDiskWithVMGuestState SecurityEncryptionType = "DiskWithVMGuestState"
+)
+
 // MasterProfile represents a master profile.
 type MasterProfile struct {
 	// The size of the master VMs.
@@ -229,6 +331,15 @@ type MasterProfile struct {
 
 	// The resource ID of an associated DiskEncryptionSet, if applicable.
 	DiskEncryptionSetID string `json:"diskEncryptionSetId,omitempty"`
+
+	// The security type of the master VMs. TrustedLaunch VMs boot from Hyper-V generation 2 images with secure boot and a virtual TPM enabled.
+	SecurityType SecurityType `json:"securityType,omitempty"`
+
+	// Static private IP addresses to assign to the master VMs, one per master.
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+
+	// The Azure resource IDs of pre-created network interfaces to attach to the master VMs, one per master.
+	NetworkInterfaceIDs []string `json:"networkInterfaceIds,omitempty"`
 }
 
 // VM size availability varies by region.
@@ -258,6 +369,12 @@ type WorkerProfile struct {
 
 	// The resource ID of an associated DiskEncryptionSet, if applicable.
 	DiskEncryptionSetID string `json:"diskEncryptionSetId,omitempty"`
+
+	// The security type of the worker VMs. TrustedLaunch VMs boot from Hyper-V generation 2 images with secure boot and a virtual TPM enabled. ConfidentialVM VMs additionally encrypt their memory, and require a confidential VM size.
+	SecurityType SecurityType `json:"securityType,omitempty"`
+
+	// What the confidential key of ConfidentialVM worker VMs encrypts: the VM guest state only, or also the OS disk.
+	SecurityEncryptionType SecurityEncryptionType `json:"securityEncryptionType,omitempty"`
 }
 
 // APIServerProfile represents an API server profile.
@@ -4,7 +4,10 @@ package v20240812preview
 // Licensed under the Apache License 2.0.
 
 import (
+	"strings"
+
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/operator"
 	"github.com/Azure/ARO-RP/pkg/util/pointerutils"
 )
 
@@ -23,11 +26,12 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		Properties: OpenShiftClusterProperties{
 			ProvisioningState: ProvisioningState(oc.Properties.ProvisioningState),
 			ClusterProfile: ClusterProfile{
-				PullSecret:           string(oc.Properties.ClusterProfile.PullSecret),
-				Domain:               oc.Properties.ClusterProfile.Domain,
-				Version:              oc.Properties.ClusterProfile.Version,
-				ResourceGroupID:      oc.Properties.ClusterProfile.ResourceGroupID,
-				FipsValidatedModules: FipsValidatedModules(oc.Properties.ClusterProfile.FipsValidatedModules),
+				PullSecret:            string(oc.Properties.ClusterProfile.PullSecret),
+				Domain:                oc.Properties.ClusterProfile.Domain,
+				Version:               oc.Properties.ClusterProfile.Version,
+				ResourceGroupID:       oc.Properties.ClusterProfile.ResourceGroupID,
+				FipsValidatedModules:  FipsValidatedModules(oc.Properties.ClusterProfile.FipsValidatedModules),
+				AdditionalTrustBundle: oc.Properties.ClusterProfile.AdditionalTrustBundle,
 			},
 			ConsoleProfile: ConsoleProfile{
 				URL: oc.Properties.ConsoleProfile.URL,
@@ -43,6 +47,9 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 				SubnetID:            oc.Properties.MasterProfile.SubnetID,
 				EncryptionAtHost:    EncryptionAtHost(oc.Properties.MasterProfile.EncryptionAtHost),
 				DiskEncryptionSetID: oc.Properties.MasterProfile.DiskEncryptionSetID,
+				SecurityType:        SecurityType(oc.Properties.MasterProfile.SecurityType),
+				IPAddresses:         append([]string(nil), oc.Properties.MasterProfile.IPAddresses...),
+				NetworkInterfaceIDs: append([]string(nil), oc.Properties.MasterProfile.NetworkInterfaceIDs...),
 			},
 			APIServerProfile: APIServerProfile{
 				Visibility: Visibility(oc.Properties.APIServerProfile.Visibility),
@@ -72,7 +79,8 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 			out.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs = make([]EffectiveOutboundIP, 0, len(oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs))
 			for _, effectiveOutboundIP := range oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs {
 				out.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs = append(out.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs, EffectiveOutboundIP{
-					ID: effectiveOutboundIP.ID,
+					ID:        effectiveOutboundIP.ID,
+					IPAddress: effectiveOutboundIP.IPAddress,
 				})
 			}
 		}
@@ -83,13 +91,15 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		out.Properties.WorkerProfiles = make([]WorkerProfile, 0, len(workerProfiles))
 		for _, p := range workerProfiles {
 			out.Properties.WorkerProfiles = append(out.Properties.WorkerProfiles, WorkerProfile{
-				Name:                p.Name,
-				VMSize:              VMSize(p.VMSize),
-				DiskSizeGB:          p.DiskSizeGB,
-				SubnetID:            p.SubnetID,
-				Count:               p.Count,
-				EncryptionAtHost:    EncryptionAtHost(p.EncryptionAtHost),
-				DiskEncryptionSetID: p.DiskEncryptionSetID,
+				Name:                   p.Name,
+				VMSize:                 VMSize(p.VMSize),
+				DiskSizeGB:             p.DiskSizeGB,
+				SubnetID:               p.SubnetID,
+				Count:                  p.Count,
+				EncryptionAtHost:       EncryptionAtHost(p.EncryptionAtHost),
+				DiskEncryptionSetID:    p.DiskEncryptionSetID,
+				SecurityType:           SecurityType(p.SecurityType),
+				SecurityEncryptionType: SecurityEncryptionType(p.SecurityEncryptionType),
 			})
 		}
 	}
@@ -99,13 +109,15 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		out.Properties.WorkerProfilesStatus = make([]WorkerProfile, 0, len(workerProfiles))
 		for _, p := range workerProfiles {
 			out.Properties.WorkerProfilesStatus = append(out.Properties.WorkerProfilesStatus, WorkerProfile{
-				Name:                p.Name,
-				VMSize:              VMSize(p.VMSize),
-				DiskSizeGB:          p.DiskSizeGB,
-				SubnetID:            p.SubnetID,
-				Count:               p.Count,
-				EncryptionAtHost:    EncryptionAtHost(p.EncryptionAtHost),
-				DiskEncryptionSetID: p.DiskEncryptionSetID,
+				Name:                   p.Name,
+				VMSize:                 VMSize(p.VMSize),
+				DiskSizeGB:             p.DiskSizeGB,
+				SubnetID:               p.SubnetID,
+				Count:                  p.Count,
+				EncryptionAtHost:       EncryptionAtHost(p.EncryptionAtHost),
+				DiskEncryptionSetID:    p.DiskEncryptionSetID,
+				SecurityType:           SecurityType(p.SecurityType),
+				SecurityEncryptionType: SecurityEncryptionType(p.SecurityEncryptionType),
 			})
 		}
 	}
@@ -167,6 +179,40 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		out.Properties.ClusterProfile.OIDCIssuer = pointerutils.ToPtr(OIDCIssuer(*oc.Properties.ClusterProfile.OIDCIssuer))
 	}
 
+	if oc.Properties.UpgradeProfile != nil {
+		out.Properties.UpgradeProfile = &UpgradeProfile{
+			Policy:                        UpgradePolicy(oc.Properties.UpgradeProfile.Policy),
+			AutomaticUpgradesPausedReason: oc.Properties.UpgradeProfile.AutomaticUpgradesPausedReason,
+		}
+
+		if oc.Properties.UpgradeProfile.MaintenanceWindows != nil {
+			out.Properties.UpgradeProfile.MaintenanceWindows = make([]MaintenanceWindow, 0, len(oc.Properties.UpgradeProfile.MaintenanceWindows))
+			for _, w := range oc.Properties.UpgradeProfile.MaintenanceWindows {
+				out.Properties.UpgradeProfile.MaintenanceWindows = append(out.Properties.UpgradeProfile.MaintenanceWindows, MaintenanceWindow{
+					DayOfWeek:     w.DayOfWeek,
+					StartHour:     w.StartHour,
+					DurationHours: w.DurationHours,
+				})
+			}
+		}
+	}
+
+	if oc.Properties.GuardrailsProfile != nil {
+		out.Properties.GuardrailsProfile = &GuardrailsProfile{
+			State:             GuardrailsState(oc.Properties.GuardrailsProfile.State),
+			EnforcementAction: GuardrailsEnforcementAction(oc.Properties.GuardrailsProfile.EnforcementAction),
+		}
+	}
+
+	for _, k := range operator.CustomerOperatorFlags() {
+		if v, ok := oc.Properties.OperatorFlags[k]; ok {
+			if out.Properties.OperatorFlags == nil {
+				out.Properties.OperatorFlags = OperatorFlags{}
+			}
+			out.Properties.OperatorFlags[k] = v
+		}
+	}
+
 	out.SystemData = &SystemData{
 		CreatedBy:          oc.SystemData.CreatedBy,
 		CreatedAt:          oc.SystemData.CreatedAt,
@@ -238,13 +284,14 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 		out.Properties.ConsoleProfile.URL = oc.Properties.ConsoleProfile.URL
 	}
 	out.Properties.ClusterProfile.FipsValidatedModules = api.FipsValidatedModules(oc.Properties.ClusterProfile.FipsValidatedModules)
+	out.Properties.ClusterProfile.AdditionalTrustBundle = oc.Properties.ClusterProfile.AdditionalTrustBundle
 	if oc.Properties.ServicePrincipalProfile != nil {
 		out.Properties.ServicePrincipalProfile = &api.ServicePrincipalProfile{
 			ClientID:     oc.Properties.ServicePrincipalProfile.ClientID,
 			ClientSecret: api.SecureString(oc.Properties.ServicePrincipalProfile.ClientSecret),
 		}
 	}
-	if oc.Properties.PlatformWorkloadIdentityProfile != nil && oc.Properties.PlatformWorkloadIdentityProfile.PlatformWorkloadIdentities != nil {
+	if oc.Properties.PlatformWorkloadIdentityProfile != nil {
 		if out.Properties.PlatformWorkloadIdentityProfile == nil {
 			out.Properties.PlatformWorkloadIdentityProfile = &api.PlatformWorkloadIdentityProfile{}
 		}
@@ -296,6 +343,7 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			out.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs = make([]api.EffectiveOutboundIP, len(oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs))
 			for i := range oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs {
 				out.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs[i].ID = oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs[i].ID
+				out.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs[i].IPAddress = oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs[i].IPAddress
 			}
 		}
 	}
@@ -304,6 +352,9 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 	out.Properties.MasterProfile.SubnetID = oc.Properties.MasterProfile.SubnetID
 	out.Properties.MasterProfile.EncryptionAtHost = api.EncryptionAtHost(oc.Properties.MasterProfile.EncryptionAtHost)
 	out.Properties.MasterProfile.DiskEncryptionSetID = oc.Properties.MasterProfile.DiskEncryptionSetID
+	out.Properties.MasterProfile.SecurityType = api.SecurityType(oc.Properties.MasterProfile.SecurityType)
+	out.Properties.MasterProfile.IPAddresses = append([]string(nil), oc.Properties.MasterProfile.IPAddresses...)
+	out.Properties.MasterProfile.NetworkInterfaceIDs = append([]string(nil), oc.Properties.MasterProfile.NetworkInterfaceIDs...)
 	out.Properties.WorkerProfiles = nil
 	if oc.Properties.WorkerProfiles != nil {
 		out.Properties.WorkerProfiles = make([]api.WorkerProfile, len(oc.Properties.WorkerProfiles))
@@ -315,6 +366,8 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			out.Properties.WorkerProfiles[i].Count = oc.Properties.WorkerProfiles[i].Count
 			out.Properties.WorkerProfiles[i].EncryptionAtHost = api.EncryptionAtHost(oc.Properties.WorkerProfiles[i].EncryptionAtHost)
 			out.Properties.WorkerProfiles[i].DiskEncryptionSetID = oc.Properties.WorkerProfiles[i].DiskEncryptionSetID
+			out.Properties.WorkerProfiles[i].SecurityType = api.SecurityType(oc.Properties.WorkerProfiles[i].SecurityType)
+			out.Properties.WorkerProfiles[i].SecurityEncryptionType = api.SecurityEncryptionType(oc.Properties.WorkerProfiles[i].SecurityEncryptionType)
 		}
 	}
 	out.Properties.WorkerProfilesStatus = nil
@@ -328,6 +381,8 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			out.Properties.WorkerProfilesStatus[i].Count = oc.Properties.WorkerProfilesStatus[i].Count
 			out.Properties.WorkerProfilesStatus[i].EncryptionAtHost = api.EncryptionAtHost(oc.Properties.WorkerProfilesStatus[i].EncryptionAtHost)
 			out.Properties.WorkerProfilesStatus[i].DiskEncryptionSetID = oc.Properties.WorkerProfilesStatus[i].DiskEncryptionSetID
+			out.Properties.WorkerProfilesStatus[i].SecurityType = api.SecurityType(oc.Properties.WorkerProfilesStatus[i].SecurityType)
+			out.Properties.WorkerProfilesStatus[i].SecurityEncryptionType = api.SecurityEncryptionType(oc.Properties.WorkerProfilesStatus[i].SecurityEncryptionType)
 		}
 	}
 	out.Properties.APIServerProfile.Visibility = api.Visibility(oc.Properties.APIServerProfile.Visibility)
@@ -349,6 +404,71 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 		}
 	}
 
+	if oc.Properties.UpgradeProfile != nil {
+		upgradeProfile := api.UpgradeProfile{
+			Policy: api.UpgradePolicy(oc.Properties.UpgradeProfile.Policy),
+		}
+
+		// AutomaticUpgradesPausedReason is a read-only field, so it will never
+		// be present in requests.  Preserve it from the pre-existing internal
+		// object.
+		if out.Properties.UpgradeProfile != nil {
+			upgradeProfile.AutomaticUpgradesPausedReason = out.Properties.UpgradeProfile.AutomaticUpgradesPausedReason
+		}
+
+		if oc.Properties.UpgradeProfile.MaintenanceWindows != nil {
+			upgradeProfile.MaintenanceWindows = make([]api.MaintenanceWindow, len(oc.Properties.UpgradeProfile.MaintenanceWindows))
+			for i, w := range oc.Properties.UpgradeProfile.MaintenanceWindows {
+				upgradeProfile.MaintenanceWindows[i].DayOfWeek = w.DayOfWeek
+				upgradeProfile.MaintenanceWindows[i].StartHour = w.StartHour
+				upgradeProfile.MaintenanceWindows[i].DurationHours = w.DurationHours
+			}
+		}
+
+		out.Properties.UpgradeProfile = &upgradeProfile
+	}
+
+	// Only the customer-configurable operator flags appear in the external
+	// representation, so lay them on top of the cluster's existing flags.
+	if len(oc.Properties.OperatorFlags) > 0 {
+		if out.Properties.OperatorFlags == nil {
+			out.Properties.OperatorFlags = api.OperatorFlags(operator.DefaultOperatorFlags())
+		}
+		for k, v := range oc.Properties.OperatorFlags {
+			out.Properties.OperatorFlags[k] = v
+		}
+	}
+
+	// The guardrails profile is reconciled by the operator through its
+	// guardrails flags.
+	if oc.Properties.GuardrailsProfile != nil {
+		out.Properties.GuardrailsProfile = &api.GuardrailsProfile{
+			State:             api.GuardrailsState(oc.Properties.GuardrailsProfile.State),
+			EnforcementAction: api.GuardrailsEnforcementAction(oc.Properties.GuardrailsProfile.EnforcementAction),
+		}
+
+		if out.Properties.OperatorFlags == nil {
+			out.Properties.OperatorFlags = api.OperatorFlags(operator.DefaultOperatorFlags())
+		}
+		out.Properties.OperatorFlags[operator.GuardrailsEnabled] = operator.FlagTrue
+
+		switch oc.Properties.GuardrailsProfile.State {
+		case GuardrailsStateEnabled:
+			enforcementAction := oc.Properties.GuardrailsProfile.EnforcementAction
+			if enforcementAction == "" {
+				enforcementAction = GuardrailsEnforcementActionDryRun
+			}
+
+			out.Properties.OperatorFlags[operator.GuardrailsDeployManaged] = operator.FlagTrue
+			out.Properties.OperatorFlags[operator.GuardrailsPoliciesManaged] = operator.FlagTrue
+			out.Properties.OperatorFlags[operator.GuardrailsPoliciesEnforcement] = strings.ToLower(string(enforcementAction))
+		case GuardrailsStateDisabled:
+			out.Properties.OperatorFlags[operator.GuardrailsDeployManaged] = operator.FlagFalse
+			out.Properties.OperatorFlags[operator.GuardrailsPoliciesManaged] = operator.FlagFalse
+			delete(out.Properties.OperatorFlags, operator.GuardrailsPoliciesEnforcement)
+		}
+	}
+
 	if oc.SystemData != nil {
 		out.SystemData = api.SystemData{
 			CreatedBy:          oc.SystemData.CreatedBy,
@@ -356,7 +476,7 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			CreatedByType:      api.CreatedByType(oc.SystemData.CreatedByType),
 			LastModifiedBy:     oc.SystemData.LastModifiedBy,
 			LastModifiedAt:     oc.SystemData.LastModifiedAt,
-			LastModifiedByType: api.CreatedByType(oc.SystemData.CreatedByType),
+			LastModifiedByType: api.CreatedByType(oc.SystemData.LastModifiedByType),
 		}
 	}
 }
@@ -369,13 +489,16 @@ func (c openShiftClusterConverter) ExternalNoReadOnly(_oc interface{}) {
 		oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs = nil
 	}
 	oc.SystemData = nil
+	if oc.Properties.UpgradeProfile != nil {
+		oc.Properties.UpgradeProfile.AutomaticUpgradesPausedReason = ""
+	}
 	oc.Properties.ConsoleProfile.URL = ""
 	oc.Properties.APIServerProfile.URL = ""
 	oc.Properties.APIServerProfile.IP = ""
 	for i := range oc.Properties.IngressProfiles {
 		oc.Properties.IngressProfiles[i].IP = ""
 	}
-	if oc.Properties.PlatformWorkloadIdentityProfile != nil {
+	if oc.Properties.PlatformWorkloadIdentityProfile != nil && oc.Properties.PlatformWorkloadIdentityProfile.PlatformWorkloadIdentities != nil {
 		for i := range oc.Properties.PlatformWorkloadIdentityProfile.PlatformWorkloadIdentities {
 			if entry, ok := oc.Properties.PlatformWorkloadIdentityProfile.PlatformWorkloadIdentities[i]; ok {
 				entry.ClientID = ""
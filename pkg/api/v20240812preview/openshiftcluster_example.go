@@ -89,7 +89,8 @@ func ExampleOpenShiftClusterGetResponse() interface{} {
 	oc.Properties.NetworkProfile.LoadBalancerProfile = &LoadBalancerProfile{
 		EffectiveOutboundIPs: []EffectiveOutboundIP{
 			{
-				ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/clusterResourceGroup/providers/Microsoft.Network/publicIPAddresses/publicIPAddressName",
+				ID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/clusterResourceGroup/providers/Microsoft.Network/publicIPAddresses/publicIPAddressName",
+				IPAddress: "1.2.3.4",
 			},
 		},
 		ManagedOutboundIPs: &ManagedOutboundIPs{
@@ -5,7 +5,11 @@ package v20240812preview
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/operator"
 )
 
 func TestIsWorkloadIdentity(t *testing.T) {
@@ -65,3 +69,122 @@ func TestIsWorkloadIdentity(t *testing.T) {
 		})
 	}
 }
+
+func TestGuardrailsProfileToInternal(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		profile   *GuardrailsProfile
+		wantFlags map[string]string
+	}{
+		{
+			name: "enabled defaults to dryrun",
+			profile: &GuardrailsProfile{
+				State: GuardrailsStateEnabled,
+			},
+			wantFlags: map[string]string{
+				operator.GuardrailsEnabled:             operator.FlagTrue,
+				operator.GuardrailsDeployManaged:       operator.FlagTrue,
+				operator.GuardrailsPoliciesManaged:     operator.FlagTrue,
+				operator.GuardrailsPoliciesEnforcement: "dryrun",
+			},
+		},
+		{
+			name: "enabled with deny",
+			profile: &GuardrailsProfile{
+				State:             GuardrailsStateEnabled,
+				EnforcementAction: GuardrailsEnforcementActionDeny,
+			},
+			wantFlags: map[string]string{
+				operator.GuardrailsEnabled:             operator.FlagTrue,
+				operator.GuardrailsDeployManaged:       operator.FlagTrue,
+				operator.GuardrailsPoliciesManaged:     operator.FlagTrue,
+				operator.GuardrailsPoliciesEnforcement: "deny",
+			},
+		},
+		{
+			name: "disabled",
+			profile: &GuardrailsProfile{
+				State: GuardrailsStateDisabled,
+			},
+			wantFlags: map[string]string{
+				operator.GuardrailsEnabled:         operator.FlagTrue,
+				operator.GuardrailsDeployManaged:   operator.FlagFalse,
+				operator.GuardrailsPoliciesManaged: operator.FlagFalse,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			oc := &OpenShiftCluster{
+				Properties: OpenShiftClusterProperties{
+					GuardrailsProfile: tt.profile,
+				},
+			}
+
+			out := &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					OperatorFlags: api.OperatorFlags{
+						operator.GuardrailsEnabled:             operator.FlagFalse,
+						operator.GuardrailsPoliciesEnforcement: "warn",
+					},
+				},
+			}
+			(&openShiftClusterConverter{}).ToInternal(oc, out)
+
+			if !reflect.DeepEqual(map[string]string(out.Properties.OperatorFlags), tt.wantFlags) {
+				t.Errorf("got flags %v, wanted %v", out.Properties.OperatorFlags, tt.wantFlags)
+			}
+
+			ext := (&openShiftClusterConverter{}).ToExternal(out).(*OpenShiftCluster)
+			if !reflect.DeepEqual(ext.Properties.GuardrailsProfile, tt.profile) {
+				t.Errorf("got profile %v, wanted %v", ext.Properties.GuardrailsProfile, tt.profile)
+			}
+		})
+	}
+}
+
+func TestPlatformWorkloadIdentityProfileToInternal(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		profile *PlatformWorkloadIdentityProfile
+		want    *api.PlatformWorkloadIdentityProfile
+	}{
+		{
+			name: "nil profile",
+		},
+		{
+			name:    "empty profile",
+			profile: &PlatformWorkloadIdentityProfile{},
+			want: &api.PlatformWorkloadIdentityProfile{
+				PlatformWorkloadIdentities: map[string]api.PlatformWorkloadIdentity{},
+			},
+		},
+		{
+			name: "profile with identities",
+			profile: &PlatformWorkloadIdentityProfile{
+				PlatformWorkloadIdentities: map[string]PlatformWorkloadIdentity{
+					"operator1": {ResourceID: "resourceID"},
+				},
+			},
+			want: &api.PlatformWorkloadIdentityProfile{
+				PlatformWorkloadIdentities: map[string]api.PlatformWorkloadIdentity{
+					"operator1": {ResourceID: "resourceID"},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			oc := &OpenShiftCluster{
+				Properties: OpenShiftClusterProperties{
+					PlatformWorkloadIdentityProfile: tt.profile,
+				},
+			}
+
+			out := &api.OpenShiftCluster{}
+			(&openShiftClusterConverter{}).ToInternal(oc, out)
+
+			if !reflect.DeepEqual(out.Properties.PlatformWorkloadIdentityProfile, tt.want) {
+				t.Errorf("got profile %v, wanted %v", out.Properties.PlatformWorkloadIdentityProfile, tt.want)
+			}
+		})
+	}
+}
@@ -10,10 +10,11 @@ import (
 	"testing"
 	"time"
 
-	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/to"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
+	utiltls "github.com/Azure/ARO-RP/pkg/util/tls"
 	"github.com/Azure/ARO-RP/pkg/util/uuid"
 	"github.com/Azure/ARO-RP/pkg/util/version"
 	"github.com/Azure/ARO-RP/test/validate"
@@ -158,19 +159,7 @@ func runTests(t *testing.T, mode testMode, tests []*validateTest) {
 					tt.clusterName = to.StringPtr("resourceName")
 				}
 
-				v := &openShiftClusterStaticValidator{
-					location:            *tt.location,
-					domain:              "location.aroapp.io",
-					requireD2sV3Workers: tt.requireD2sV3Workers,
-					resourceID:          getResourceID(*tt.clusterName),
-					r: azure.Resource{
-						SubscriptionID: subscriptionID,
-						ResourceGroup:  "resourceGroup",
-						Provider:       "Microsoft.RedHatOpenShift",
-						ResourceType:   "openshiftClusters",
-						ResourceName:   *tt.clusterName,
-					},
-				}
+				v := &openShiftClusterStaticValidator{}
 
 				validOCForTest := func() *OpenShiftCluster {
 					oc := validOpenShiftCluster(*tt.clusterName, *tt.location)
@@ -200,7 +189,7 @@ func runTests(t *testing.T, mode testMode, tests []*validateTest) {
 					(&openShiftClusterConverter{}).ToInternal(ext, current)
 				}
 
-				err := v.Static(oc, current, v.location, v.domain, tt.requireD2sV3Workers, v.resourceID)
+				err := v.Static(oc, current, *tt.location, "location.aroapp.io", tt.requireD2sV3Workers, nil, getResourceID(*tt.clusterName))
 				if err == nil {
 					if tt.wantErr != "" {
 						t.Errorf("Expected error %s, got nil", tt.wantErr)
@@ -317,6 +306,24 @@ func TestOpenShiftClusterStaticValidateProperties(t *testing.T) {
 }
 
 func TestOpenShiftClusterStaticValidateClusterProfile(t *testing.T) {
+	caKey, caCerts, err := utiltls.GenerateKeyAndCertificate("proxy-ca", nil, nil, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caBundle, err := utilpem.Encode(caCerts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, leafCerts, err := utiltls.GenerateKeyAndCertificate("proxy", caKey, caCerts[0], false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafBundle, err := utilpem.Encode(leafCerts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	commonTests := []*validateTest{
 		{
 			name: "valid",
@@ -405,6 +412,37 @@ func TestOpenShiftClusterStaticValidateClusterProfile(t *testing.T) {
 			},
 			wantErr: "400: InvalidParameter: properties.clusterProfile.fipsValidatedModules: The provided value '' is invalid.",
 		},
+		{
+			name: "additional trust bundle valid",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.AdditionalTrustBundle = string(caBundle)
+			},
+		},
+		{
+			name: "additional trust bundle not PEM",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.AdditionalTrustBundle = "invalid"
+			},
+			wantErr: "400: InvalidParameter: properties.clusterProfile.additionalTrustBundle: The provided additional trust bundle is invalid: it must contain only PEM-encoded certificates.",
+		},
+		{
+			name: "additional trust bundle contains a private key",
+			modify: func(oc *OpenShiftCluster) {
+				keyBundle, err := utilpem.Encode(caKey)
+				if err != nil {
+					t.Fatal(err)
+				}
+				oc.Properties.ClusterProfile.AdditionalTrustBundle = string(caBundle) + string(keyBundle)
+			},
+			wantErr: "400: InvalidParameter: properties.clusterProfile.additionalTrustBundle: The provided additional trust bundle is invalid: it must contain only PEM-encoded certificates.",
+		},
+		{
+			name: "additional trust bundle contains a non-CA certificate",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.AdditionalTrustBundle = string(leafBundle)
+			},
+			wantErr: "400: InvalidParameter: properties.clusterProfile.additionalTrustBundle: The provided additional trust bundle is invalid: certificate 'CN=proxy' is not a CA certificate.",
+		},
 	}
 
 	createTests := []*validateTest{
@@ -788,9 +826,22 @@ func TestOpenShiftClusterStaticValidateMasterProfile(t *testing.T) {
 			},
 			wantErr: "400: InvalidParameter: properties.masterProfile.encryptionAtHost: The provided value '' is invalid.",
 		},
+		{
+			name: "security type invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MasterProfile.SecurityType = "ConfidentialVM"
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.securityType: The provided value 'ConfidentialVM' is invalid.",
+		},
 	}
 
 	createTests := []*validateTest{
+		{
+			name: "security type trusted launch",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MasterProfile.SecurityType = SecurityTypeTrustedLaunch
+			},
+		},
 		{
 			name: "disk encryption set is valid",
 			modify: func(oc *OpenShiftCluster) {
@@ -799,6 +850,77 @@ func TestOpenShiftClusterStaticValidateMasterProfile(t *testing.T) {
 				oc.Properties.WorkerProfiles[0].DiskEncryptionSetID = desID
 			},
 		},
+		{
+			name: "static IP addresses are valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MasterProfile.IPAddresses = []string{"10.0.0.10", "10.0.0.11", "10.0.0.12"}
+			},
+		},
+		{
+			name: "wrong number of static IP addresses",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MasterProfile.IPAddresses = []string{"10.0.0.10", "10.0.0.11"}
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.ipAddresses: There should be exactly three master IP addresses.",
+		},
+		{
+			name: "static IP address is not IPv4",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MasterProfile.IPAddresses = []string{"10.0.0.10", "fd00::1", "10.0.0.12"}
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.ipAddresses[1]: The provided master IP address 'fd00::1' is invalid: must be IPv4.",
+		},
+		{
+			name: "static IP address is duplicated",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MasterProfile.IPAddresses = []string{"10.0.0.10", "10.0.0.11", "10.0.0.10"}
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.ipAddresses[2]: The provided master IP address '10.0.0.10' is duplicated.",
+		},
+		{
+			name: "pre-created network interfaces are valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MasterProfile.NetworkInterfaceIDs = []string{
+					fmt.Sprintf("/subscriptions/%s/resourceGroups/vnet/providers/Microsoft.Network/networkInterfaces/master-0", subscriptionID),
+					fmt.Sprintf("/subscriptions/%s/resourceGroups/vnet/providers/Microsoft.Network/networkInterfaces/master-1", subscriptionID),
+					fmt.Sprintf("/subscriptions/%s/resourceGroups/vnet/providers/Microsoft.Network/networkInterfaces/master-2", subscriptionID),
+				}
+			},
+		},
+		{
+			name: "static IP addresses and pre-created network interfaces are mutually exclusive",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MasterProfile.IPAddresses = []string{"10.0.0.10", "10.0.0.11", "10.0.0.12"}
+				oc.Properties.MasterProfile.NetworkInterfaceIDs = []string{
+					fmt.Sprintf("/subscriptions/%s/resourceGroups/vnet/providers/Microsoft.Network/networkInterfaces/master-0", subscriptionID),
+					fmt.Sprintf("/subscriptions/%s/resourceGroups/vnet/providers/Microsoft.Network/networkInterfaces/master-1", subscriptionID),
+					fmt.Sprintf("/subscriptions/%s/resourceGroups/vnet/providers/Microsoft.Network/networkInterfaces/master-2", subscriptionID),
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.networkInterfaceIds: The fields ipAddresses and networkInterfaceIds are mutually exclusive.",
+		},
+		{
+			name: "pre-created network interface is invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MasterProfile.NetworkInterfaceIDs = []string{
+					fmt.Sprintf("/subscriptions/%s/resourceGroups/vnet/providers/Microsoft.Network/networkInterfaces/master-0", subscriptionID),
+					"invalid",
+					fmt.Sprintf("/subscriptions/%s/resourceGroups/vnet/providers/Microsoft.Network/networkInterfaces/master-2", subscriptionID),
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.networkInterfaceIds[1]: The provided master network interface 'invalid' is invalid.",
+		},
+		{
+			name: "pre-created network interface not matching cluster subscriptionId",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MasterProfile.NetworkInterfaceIDs = []string{
+					"/subscriptions/7a3036d1-60a1-4605-8a41-44955e050804/resourceGroups/vnet/providers/Microsoft.Network/networkInterfaces/master-0",
+					fmt.Sprintf("/subscriptions/%s/resourceGroups/vnet/providers/Microsoft.Network/networkInterfaces/master-1", subscriptionID),
+					fmt.Sprintf("/subscriptions/%s/resourceGroups/vnet/providers/Microsoft.Network/networkInterfaces/master-2", subscriptionID),
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.networkInterfaceIds[0]: The provided master network interface '/subscriptions/7a3036d1-60a1-4605-8a41-44955e050804/resourceGroups/vnet/providers/Microsoft.Network/networkInterfaces/master-0' is invalid: must be in same subscription as cluster.",
+		},
 	}
 
 	runTests(t, testModeCreate, createTests)
@@ -904,6 +1026,81 @@ func TestOpenShiftClusterStaticValidateWorkerProfile(t *testing.T) {
 			},
 			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].encryptionAtHost: The provided value '' is invalid.",
 		},
+		{
+			name: "security type trusted launch",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].SecurityType = SecurityTypeTrustedLaunch
+			},
+		},
+		{
+			name: "security type invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].SecurityType = "Banana"
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].securityType: The provided value 'Banana' is invalid.",
+		},
+		{
+			name: "security type trusted launch with arm64 vmSize",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].VMSize = "Standard_D4ps_v5"
+				oc.Properties.WorkerProfiles[0].SecurityType = SecurityTypeTrustedLaunch
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].securityType: The provided security type 'TrustedLaunch' is invalid: it is not supported by worker VM size 'Standard_D4ps_v5'.",
+		},
+		{
+			name: "confidential vm",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].VMSize = "Standard_DC4as_v5"
+				oc.Properties.WorkerProfiles[0].SecurityType = SecurityTypeConfidentialVM
+				oc.Properties.WorkerProfiles[0].SecurityEncryptionType = SecurityEncryptionTypeDiskWithVMGuestState
+			},
+		},
+		{
+			name: "confidential vm size with security type defaulted",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].VMSize = "Standard_EC8as_v5"
+			},
+		},
+		{
+			name: "confidential vm with non-confidential vmSize",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].SecurityType = SecurityTypeConfidentialVM
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].securityType: The provided security type 'ConfidentialVM' is invalid: it is not supported by worker VM size 'Standard_D4s_v3'.",
+		},
+		{
+			name: "confidential vmSize with trusted launch",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].VMSize = "Standard_DC4as_v5"
+				oc.Properties.WorkerProfiles[0].SecurityType = SecurityTypeTrustedLaunch
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].securityType: The provided security type 'TrustedLaunch' is invalid: it is not supported by worker VM size 'Standard_DC4as_v5'.",
+		},
+		{
+			name: "security encryption type with non-confidential vmSize",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].SecurityEncryptionType = SecurityEncryptionTypeVMGuestStateOnly
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].securityEncryptionType: The provided security encryption type 'VMGuestStateOnly' is invalid: it is only supported by confidential VMs.",
+		},
+		{
+			name: "security encryption type invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].VMSize = "Standard_DC4as_v5"
+				oc.Properties.WorkerProfiles[0].SecurityType = SecurityTypeConfidentialVM
+				oc.Properties.WorkerProfiles[0].SecurityEncryptionType = "Banana"
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].securityEncryptionType: The provided value 'Banana' is invalid.",
+		},
+		{
+			name: "confidential vm with encryption at host",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].VMSize = "Standard_DC4as_v5"
+				oc.Properties.WorkerProfiles[0].SecurityType = SecurityTypeConfidentialVM
+				oc.Properties.WorkerProfiles[0].EncryptionAtHost = EncryptionAtHostEnabled
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].encryptionAtHost: Encryption at host is not supported by confidential worker VM size 'Standard_DC4as_v5'.",
+		},
 	}
 
 	// We do not perform this validation on update
@@ -1011,7 +1208,216 @@ func TestOpenShiftClusterStaticValidateIngressProfile(t *testing.T) {
 	runTests(t, testModeCreate, tests)
 }
 
+func TestOpenShiftClusterStaticValidateUpgradeProfile(t *testing.T) {
+	tests := []*validateTest{
+		{
+			name: "valid",
+		},
+		{
+			name: "manual policy valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					Policy: UpgradePolicyManual,
+				}
+			},
+		},
+		{
+			name: "automatic patch policy valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					Policy: UpgradePolicyAutomaticPatch,
+					MaintenanceWindows: []MaintenanceWindow{
+						{
+							DayOfWeek:     "Saturday",
+							StartHour:     22,
+							DurationHours: 6,
+						},
+					},
+				}
+			},
+		},
+		{
+			name: "policy invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					Policy: "invalid",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.upgradeProfile.policy: The provided upgrade policy 'invalid' is invalid.",
+		},
+		{
+			name: "automatic patch policy without maintenance windows",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					Policy: UpgradePolicyAutomaticPatch,
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.upgradeProfile.maintenanceWindows: At least one maintenance window must be provided for automatic upgrades.",
+		},
+		{
+			name: "day of week invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					Policy: UpgradePolicyAutomaticPatch,
+					MaintenanceWindows: []MaintenanceWindow{
+						{
+							DayOfWeek:     "Caturday",
+							DurationHours: 4,
+						},
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.upgradeProfile.maintenanceWindows[0].dayOfWeek: The provided day of week 'Caturday' is invalid.",
+		},
+		{
+			name: "start hour invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					Policy: UpgradePolicyAutomaticPatch,
+					MaintenanceWindows: []MaintenanceWindow{
+						{
+							DayOfWeek:     "Sunday",
+							StartHour:     24,
+							DurationHours: 4,
+						},
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.upgradeProfile.maintenanceWindows[0].startHour: The provided start hour '24' is invalid.",
+		},
+		{
+			name: "duration invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					Policy: UpgradePolicyAutomaticPatch,
+					MaintenanceWindows: []MaintenanceWindow{
+						{
+							DayOfWeek: "Sunday",
+						},
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.upgradeProfile.maintenanceWindows[0].durationHours: The provided duration '0' is invalid.",
+		},
+	}
+
+	runTests(t, testModeCreate, tests)
+	runTests(t, testModeUpdate, tests)
+}
+
+func TestOpenShiftClusterStaticValidateOperatorFlags(t *testing.T) {
+	tests := []*validateTest{
+		{
+			name: "valid",
+		},
+		{
+			name: "customer flags valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.OperatorFlags = OperatorFlags{
+					"aro.autosizednodes.enabled": "true",
+					"aro.banner.enabled":         "false",
+				}
+			},
+		},
+		{
+			name: "unsupported flag",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.OperatorFlags = OperatorFlags{
+					"aro.imageconfig.enabled": "false",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.operatorFlags: The provided operator flag 'aro.imageconfig.enabled' is not supported.",
+		},
+		{
+			name: "invalid value",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.OperatorFlags = OperatorFlags{
+					"aro.checker.enabled": "yes",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.operatorFlags: The provided value 'yes' for operator flag 'aro.checker.enabled' is invalid.",
+		},
+	}
+
+	runTests(t, testModeCreate, tests)
+	runTests(t, testModeUpdate, tests)
+}
+
+func TestOpenShiftClusterStaticValidateGuardrailsProfile(t *testing.T) {
+	tests := []*validateTest{
+		{
+			name: "valid",
+		},
+		{
+			name: "enabled valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.GuardrailsProfile = &GuardrailsProfile{
+					State:             GuardrailsStateEnabled,
+					EnforcementAction: GuardrailsEnforcementActionDeny,
+				}
+			},
+		},
+		{
+			name: "enabled without enforcement action valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.GuardrailsProfile = &GuardrailsProfile{
+					State: GuardrailsStateEnabled,
+				}
+			},
+		},
+		{
+			name: "disabled valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.GuardrailsProfile = &GuardrailsProfile{
+					State: GuardrailsStateDisabled,
+				}
+			},
+		},
+		{
+			name: "state invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.GuardrailsProfile = &GuardrailsProfile{
+					State: "invalid",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.guardrailsProfile.state: The provided guardrails state 'invalid' is invalid.",
+		},
+		{
+			name: "enforcement action invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.GuardrailsProfile = &GuardrailsProfile{
+					State:             GuardrailsStateEnabled,
+					EnforcementAction: "invalid",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.guardrailsProfile.enforcementAction: The provided enforcement action 'invalid' is invalid.",
+		},
+		{
+			name: "enforcement action set when disabled",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.GuardrailsProfile = &GuardrailsProfile{
+					State:             GuardrailsStateDisabled,
+					EnforcementAction: GuardrailsEnforcementActionWarn,
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.guardrailsProfile.enforcementAction: The enforcement action may only be set when guardrails are enabled.",
+		},
+	}
+
+	runTests(t, testModeCreate, tests)
+	runTests(t, testModeUpdate, tests)
+}
+
 func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
+	_, caCerts, err := utiltls.GenerateKeyAndCertificate("proxy-ca", nil, nil, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caBundle, err := utilpem.Encode(caCerts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	tests := []*validateTest{
 		{
 			name: "valid",
@@ -1062,6 +1468,11 @@ func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 			modify:  func(oc *OpenShiftCluster) { oc.Properties.ClusterProfile.Version = "4.3.999" },
 			wantErr: "400: PropertyChangeNotAllowed: properties.clusterProfile.version: Changing property 'properties.clusterProfile.version' is not allowed.",
 		},
+		{
+			name:    "additional trust bundle change",
+			modify:  func(oc *OpenShiftCluster) { oc.Properties.ClusterProfile.AdditionalTrustBundle = string(caBundle) },
+			wantErr: "400: PropertyChangeNotAllowed: properties.clusterProfile.additionalTrustBundle: Changing property 'properties.clusterProfile.additionalTrustBundle' is not allowed.",
+		},
 		{
 			name: "resource group change",
 			modify: func(oc *OpenShiftCluster) {
@@ -1132,6 +1543,20 @@ func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 			},
 			wantErr: "400: PropertyChangeNotAllowed: properties.masterProfile.subnetId: Changing property 'properties.masterProfile.subnetId' is not allowed.",
 		},
+		{
+			name: "master ipAddresses change",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MasterProfile.IPAddresses = []string{"10.0.0.10", "10.0.0.11", "10.0.0.12"}
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.masterProfile.ipAddresses: Changing property 'properties.masterProfile.ipAddresses' is not allowed.",
+		},
+		{
+			name: "master securityType change",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MasterProfile.SecurityType = SecurityTypeTrustedLaunch
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.masterProfile.securityType: Changing property 'properties.masterProfile.securityType' is not allowed.",
+		},
 		{
 			name:    "worker name change",
 			modify:  func(oc *OpenShiftCluster) { oc.Properties.WorkerProfiles[0].Name = "new-name" },
@@ -1662,3 +2087,146 @@ func TestOpenShiftClusterStaticValidatePlatformWorkloadIdentityProfile(t *testin
 	runTests(t, testModeCreate, createTests)
 	runTests(t, testModeUpdate, updateTests)
 }
+
+func TestOpenShiftClusterStaticValidateEmptyProfiles(t *testing.T) {
+	createTests := []*validateTest{
+		{
+			name: "empty workerProfiles invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles = []WorkerProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles: There should be exactly one worker profile.",
+		},
+		{
+			name: "empty platformWorkloadIdentityProfile invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.PlatformWorkloadIdentityProfile = &PlatformWorkloadIdentityProfile{}
+				oc.Identity = &ManagedServiceIdentity{
+					UserAssignedIdentities: map[string]UserAssignedIdentity{
+						"first": clusterIdentity1,
+					},
+				}
+				oc.Properties.ServicePrincipalProfile = nil
+			},
+			wantErr: "400: InvalidParameter: properties.platformWorkloadIdentityProfile.platformWorkloadIdentities: The set of platform workload identities cannot be empty.",
+		},
+		{
+			name: "empty identity invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.PlatformWorkloadIdentityProfile = &PlatformWorkloadIdentityProfile{
+					PlatformWorkloadIdentities: map[string]PlatformWorkloadIdentity{
+						"operator1": platformIdentity1,
+					},
+				}
+				oc.Identity = &ManagedServiceIdentity{}
+				oc.Properties.ServicePrincipalProfile = nil
+			},
+			wantErr: "400: InvalidParameter: identity: The provided cluster identity is invalid; there should be exactly one.",
+		},
+		{
+			name: "empty servicePrincipalProfile invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ServicePrincipalProfile = &ServicePrincipalProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.servicePrincipalProfile.clientId: The provided client ID '' is invalid.",
+		},
+		{
+			name: "nil ingressProfiles invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles = nil
+			},
+			wantErr: "400: InvalidParameter: properties.ingressProfiles: There should be exactly one ingress profile.",
+		},
+		{
+			name: "empty ingressProfiles invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles = []IngressProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.ingressProfiles: There should be exactly one ingress profile.",
+		},
+		{
+			name: "empty loadBalancerProfile valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.LoadBalancerProfile = &LoadBalancerProfile{}
+			},
+		},
+		{
+			name: "empty managedOutboundIps invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.LoadBalancerProfile = &LoadBalancerProfile{
+					ManagedOutboundIPs: &ManagedOutboundIPs{},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.loadBalancerProfile.managedOutboundIps.count: The provided managedOutboundIps.count 0 is invalid: managedOutboundIps.count must be in the range of 1 to 20 (inclusive).",
+		},
+		{
+			name: "empty upgradeProfile invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.upgradeProfile.policy: The provided upgrade policy '' is invalid.",
+		},
+		{
+			name: "empty guardrailsProfile invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.GuardrailsProfile = &GuardrailsProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.guardrailsProfile.state: The provided guardrails state '' is invalid.",
+		},
+	}
+	updateTests := []*validateTest{
+		{
+			name: "empty servicePrincipalProfile invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ServicePrincipalProfile = &ServicePrincipalProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.servicePrincipalProfile.clientId: The provided client ID '' is invalid.",
+		},
+		{
+			name: "nil ingressProfiles invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles = nil
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.ingressProfiles: Changing property 'properties.ingressProfiles' is not allowed.",
+		},
+		{
+			name: "empty ingressProfiles invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles = []IngressProfile{}
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.ingressProfiles: Changing property 'properties.ingressProfiles' is not allowed.",
+		},
+		{
+			name: "empty loadBalancerProfile valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.LoadBalancerProfile = &LoadBalancerProfile{}
+			},
+		},
+		{
+			name: "empty managedOutboundIps invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.LoadBalancerProfile = &LoadBalancerProfile{
+					ManagedOutboundIPs: &ManagedOutboundIPs{},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.loadBalancerProfile.managedOutboundIps.count: The provided managedOutboundIps.count 0 is invalid: managedOutboundIps.count must be in the range of 1 to 20 (inclusive).",
+		},
+		{
+			name: "empty upgradeProfile invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.upgradeProfile.policy: The provided upgrade policy '' is invalid.",
+		},
+		{
+			name: "empty guardrailsProfile invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.GuardrailsProfile = &GuardrailsProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.guardrailsProfile.state: The provided guardrails state '' is invalid.",
+		},
+	}
+
+	runTests(t, testModeCreate, createTests)
+	runTests(t, testModeUpdate, updateTests)
+}
@@ -33,4 +33,16 @@ type OpenShiftVersion struct {
 type OpenShiftVersionProperties struct {
 	// Version represents the version to create the cluster at.
 	Version string `json:"version,omitempty"`
+
+	// The date the version became generally available, in YYYY-MM-DD format.
+	GADate string `json:"gaDate,omitempty" swagger:"readOnly"`
+
+	// The date after which the version is no longer supported, in YYYY-MM-DD format.
+	EndOfSupportDate string `json:"endOfSupportDate,omitempty" swagger:"readOnly"`
+
+	// The date after which the version no longer receives any fixes, in YYYY-MM-DD format.
+	EndOfLifeDate string `json:"endOfLifeDate,omitempty" swagger:"readOnly"`
+
+	// Whether clusters with Arm64 worker VM sizes can be installed at this version.
+	MultiArch bool `json:"multiArch,omitempty" swagger:"readOnly"`
 }
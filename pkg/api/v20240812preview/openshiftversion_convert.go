@@ -19,7 +19,11 @@ func (openShiftVersionConverter) ToExternal(v *api.OpenShiftVersion) interface{}
 		ID:            v.ID,
 		proxyResource: true,
 		Properties: OpenShiftVersionProperties{
-			Version: v.Properties.Version,
+			Version:          v.Properties.Version,
+			GADate:           v.Properties.GADate,
+			EndOfSupportDate: v.Properties.EndOfSupportDate,
+			EndOfLifeDate:    v.Properties.EndOfLifeDate,
+			MultiArch:        v.Properties.MultiArchPullspec != "",
 		},
 	}
 
@@ -28,9 +32,10 @@ func (openShiftVersionConverter) ToExternal(v *api.OpenShiftVersion) interface{}
 
 // ToExternalList returns a slice of external representations of the internal
 // objects
-func (c openShiftVersionConverter) ToExternalList(vers []*api.OpenShiftVersion) interface{} {
+func (c openShiftVersionConverter) ToExternalList(vers []*api.OpenShiftVersion, nextLink string) interface{} {
 	l := &OpenShiftVersionList{
 		OpenShiftVersions: make([]*OpenShiftVersion, 0, len(vers)),
+		NextLink:          nextLink,
 	}
 
 	for _, ver := range vers {
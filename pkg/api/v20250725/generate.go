@@ -0,0 +1,6 @@
+package v20250725
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+//go:generate go run ../../../hack/swagger github.com/Azure/ARO-RP/pkg/api/v20250725 ../../../swagger/redhatopenshift/resource-manager/Microsoft.RedHatOpenShift/openshiftclusters/stable/2025-07-25
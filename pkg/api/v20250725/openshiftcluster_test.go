@@ -0,0 +1,117 @@
+package v20250725
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+func TestIsWorkloadIdentity(t *testing.T) {
+	tests := []*struct {
+		name string
+		oc   OpenShiftCluster
+		want bool
+	}{
+		{
+			name: "Cluster is Workload Identity",
+			oc: OpenShiftCluster{
+				Properties: OpenShiftClusterProperties{
+					PlatformWorkloadIdentityProfile: &PlatformWorkloadIdentityProfile{},
+					ServicePrincipalProfile:         nil,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Cluster is Service Principal",
+			oc: OpenShiftCluster{
+				Properties: OpenShiftClusterProperties{
+					PlatformWorkloadIdentityProfile: nil,
+					ServicePrincipalProfile:         &ServicePrincipalProfile{},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Cluster is Service Principal",
+			oc: OpenShiftCluster{
+				Properties: OpenShiftClusterProperties{
+					PlatformWorkloadIdentityProfile: nil,
+					ServicePrincipalProfile:         nil,
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Cluster is Service Principal",
+			oc: OpenShiftCluster{
+				Properties: OpenShiftClusterProperties{
+					PlatformWorkloadIdentityProfile: &PlatformWorkloadIdentityProfile{},
+					ServicePrincipalProfile:         &ServicePrincipalProfile{},
+				},
+			},
+			want: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.oc.UsesWorkloadIdentity()
+			if got != test.want {
+				t.Error(fmt.Errorf("got != want: %v != %v", got, test.want))
+			}
+		})
+	}
+}
+
+func TestPlatformWorkloadIdentityProfileToInternal(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		profile *PlatformWorkloadIdentityProfile
+		want    *api.PlatformWorkloadIdentityProfile
+	}{
+		{
+			name: "nil profile",
+		},
+		{
+			name:    "empty profile",
+			profile: &PlatformWorkloadIdentityProfile{},
+			want: &api.PlatformWorkloadIdentityProfile{
+				PlatformWorkloadIdentities: map[string]api.PlatformWorkloadIdentity{},
+			},
+		},
+		{
+			name: "profile with identities",
+			profile: &PlatformWorkloadIdentityProfile{
+				PlatformWorkloadIdentities: map[string]PlatformWorkloadIdentity{
+					"operator1": {ResourceID: "resourceID"},
+				},
+			},
+			want: &api.PlatformWorkloadIdentityProfile{
+				PlatformWorkloadIdentities: map[string]api.PlatformWorkloadIdentity{
+					"operator1": {ResourceID: "resourceID"},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			oc := &OpenShiftCluster{
+				Properties: OpenShiftClusterProperties{
+					PlatformWorkloadIdentityProfile: tt.profile,
+				},
+			}
+
+			out := &api.OpenShiftCluster{}
+			(&openShiftClusterConverter{}).ToInternal(oc, out)
+
+			if !reflect.DeepEqual(out.Properties.PlatformWorkloadIdentityProfile, tt.want) {
+				t.Errorf("got profile %v, wanted %v", out.Properties.PlatformWorkloadIdentityProfile, tt.want)
+			}
+		})
+	}
+}
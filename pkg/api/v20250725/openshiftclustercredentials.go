@@ -0,0 +1,18 @@
+package v20250725
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// OpenShiftClusterCredentials represents an OpenShift cluster's credentials.
+type OpenShiftClusterCredentials struct {
+	// The username for the kubeadmin user.
+	KubeadminUsername string `json:"kubeadminUsername,omitempty"`
+
+	// The password for the kubeadmin user.
+	KubeadminPassword string `json:"kubeadminPassword,omitempty"`
+
+	// The base64-encoded kubeconfig file which obtains short-lived Microsoft
+	// Entra tokens through an exec credential plugin.  Only returned when the
+	// exec format is requested.
+	Kubeconfig []byte `json:"kubeconfig,omitempty"`
+}
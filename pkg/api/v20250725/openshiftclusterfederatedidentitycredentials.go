@@ -0,0 +1,33 @@
+package v20250725
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// FederatedIdentityCredentialList represents the federated identity
+// credentials created on an OpenShift cluster's platform workload identities.
+type FederatedIdentityCredentialList struct {
+	// The list of federated identity credentials.
+	FederatedIdentityCredentials []*FederatedIdentityCredential `json:"value"`
+}
+
+// FederatedIdentityCredential represents a federated identity credential
+// created on a platform workload identity.
+type FederatedIdentityCredential struct {
+	// The name of the federated identity credential.
+	Name string `json:"name,omitempty" swagger:"readOnly"`
+
+	// The name of the operator using the platform workload identity.
+	OperatorName string `json:"operatorName,omitempty" swagger:"readOnly"`
+
+	// The resource ID of the platform workload identity the federated identity credential was created on.
+	IdentityResourceID string `json:"identityResourceId,omitempty" swagger:"readOnly"`
+
+	// The URL of the issuer trusted by the federated identity credential.
+	Issuer string `json:"issuer,omitempty" swagger:"readOnly"`
+
+	// The identifier of the external identity trusted by the federated identity credential.
+	Subject string `json:"subject,omitempty" swagger:"readOnly"`
+
+	// The audiences that can appear in the external token.
+	Audiences []string `json:"audiences,omitempty" swagger:"readOnly"`
+}
@@ -0,0 +1,49 @@
+package v20250725
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"sort"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+type openShiftClusterFederatedIdentityCredentialsConverter struct{}
+
+// OpenShiftClusterFederatedIdentityCredentialsToExternal returns a new
+// external representation of the federated identity credentials recorded on
+// the internal object's platform workload identities.  ToExternal does not
+// modify its argument; there is no pointer aliasing between the passed and
+// returned objects.
+func (openShiftClusterFederatedIdentityCredentialsConverter) ToExternal(oc *api.OpenShiftCluster) interface{} {
+	out := &FederatedIdentityCredentialList{
+		FederatedIdentityCredentials: []*FederatedIdentityCredential{},
+	}
+
+	if oc.Properties.PlatformWorkloadIdentityProfile == nil {
+		return out
+	}
+
+	for operatorName, identity := range oc.Properties.PlatformWorkloadIdentityProfile.PlatformWorkloadIdentities {
+		for _, fic := range identity.FederatedIdentityCredentials {
+			out.FederatedIdentityCredentials = append(out.FederatedIdentityCredentials, &FederatedIdentityCredential{
+				Name:               fic.Name,
+				OperatorName:       operatorName,
+				IdentityResourceID: identity.ResourceID,
+				Issuer:             fic.Issuer,
+				Subject:            fic.Subject,
+				Audiences:          append([]string(nil), fic.Audiences...),
+			})
+		}
+	}
+
+	sort.Slice(out.FederatedIdentityCredentials, func(i, j int) bool {
+		if out.FederatedIdentityCredentials[i].OperatorName != out.FederatedIdentityCredentials[j].OperatorName {
+			return out.FederatedIdentityCredentials[i].OperatorName < out.FederatedIdentityCredentials[j].OperatorName
+		}
+		return out.FederatedIdentityCredentials[i].Name < out.FederatedIdentityCredentials[j].Name
+	})
+
+	return out
+}
@@ -0,0 +1,22 @@
+package v20250725
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// ExampleFederatedIdentityCredentialListResponse returns an example
+// FederatedIdentityCredentialList object that the RP might return to an
+// end-user
+func ExampleFederatedIdentityCredentialListResponse() interface{} {
+	return &FederatedIdentityCredentialList{
+		FederatedIdentityCredentials: []*FederatedIdentityCredential{
+			{
+				Name:               "federatedIdentityCredentialName",
+				OperatorName:       "CloudControllerManager",
+				IdentityResourceID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroup/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identityName",
+				Issuer:             "https://issuer.example.com/00000000-0000-0000-0000-000000000000",
+				Subject:            "system:serviceaccount:openshift-cloud-controller-manager:cloud-controller-manager",
+				Audiences:          []string{"openshift"},
+			},
+		},
+	}
+}
@@ -0,0 +1,58 @@
+package v20250725
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// APIVersion contains a version string as it will be used by clients
+const APIVersion = "2025-07-25"
+
+const (
+	resourceProviderNamespace = "Microsoft.RedHatOpenShift"
+	resourceType              = "openShiftClusters"
+)
+
+func init() {
+	api.APIs[APIVersion] = &api.Version{
+		OpenShiftClusterConverter:                             openShiftClusterConverter{},
+		OpenShiftClusterStaticValidator:                       openShiftClusterStaticValidator{},
+		OpenShiftClusterCredentialsConverter:                  openShiftClusterCredentialsConverter{},
+		OpenShiftClusterAdminKubeconfigConverter:              openShiftClusterAdminKubeconfigConverter{},
+		OpenShiftClusterExecCredentialsConverter:              openShiftClusterExecCredentialsConverter{},
+		OpenShiftClusterFederatedIdentityCredentialsConverter: openShiftClusterFederatedIdentityCredentialsConverter{},
+		OpenShiftVersionConverter:                             openShiftVersionConverter{},
+		PlatformWorkloadIdentityRoleSetConverter:              platformWorkloadIdentityRoleSetConverter{},
+		OperationList: api.OperationList{
+			Operations: []api.Operation{
+				api.OperationResultsRead,
+				api.OperationStatusRead,
+				api.OperationRead,
+				api.OperationOpenShiftClusterRead,
+				api.OperationOpenShiftClusterWrite,
+				api.OperationOpenShiftClusterDelete,
+				api.OperationOpenShiftClusterListCredentials,
+				api.OperationOpenShiftClusterListAdminCredentials,
+				api.OperationOpenShiftClusterGetFederatedIdentityCredentials,
+				api.OperationListInstallVersions,
+				api.OperationSyncSetsRead,
+				api.OperationSyncSetsWrite,
+				api.OperationSyncSetsDelete,
+				api.OperationMachinePoolsRead,
+				api.OperationMachinePoolsWrite,
+				api.OperationMachinePoolsDelete,
+				api.OperationSyncIdentityProvidersRead,
+				api.OperationSyncIdentityProvidersWrite,
+				api.OperationSyncIdentityProvidersDelete,
+				api.OperationOpenShiftClusterGetDetectors,
+			},
+		},
+		SyncSetConverter:              syncSetConverter{},
+		MachinePoolConverter:          machinePoolConverter{},
+		SyncIdentityProviderConverter: syncIdentityProviderConverter{},
+		SecretConverter:               secretConverter{},
+		ClusterManagerStaticValidator: clusterManagerStaticValidator{},
+	}
+}
@@ -0,0 +1,864 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	azcorearm "github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/coreos/go-semver/semver"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	apisubnet "github.com/Azure/ARO-RP/pkg/api/util/subnet"
+	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
+	"github.com/Azure/ARO-RP/pkg/util/pullsecret"
+	"github.com/Azure/ARO-RP/pkg/util/uuid"
+)
+
+const openShiftClusterResourceType = "Microsoft.RedHatOpenShift/openShiftClusters"
+
+// canceledProvisioningStates maps the API versions which expose the canceled
+// provisioning state to its name in that API version
+var canceledProvisioningStates = map[string]api.ProvisioningState{
+	apiVersion20230701preview: "Cancelled",
+	apiVersion20231122:        api.ProvisioningStateCanceled,
+	apiVersion20240812preview: api.ProvisioningStateCanceled,
+	apiVersion20250725:        api.ProvisioningStateCanceled,
+}
+
+// OpenShiftClusterRules are the static validation rules of the OpenShift
+// cluster resource, run against the internal representation of the request.
+// The rules are run in order, so rules on a field which depend on another
+// field being valid must come after the rules validating that field.
+var OpenShiftClusterRules = Rules{
+	{
+		Path:     "id",
+		Validate: validateOpenShiftClusterResourceID,
+	},
+	{
+		Path:        "properties.servicePrincipalProfile",
+		APIVersions: apiVersionsSince(apiVersion20240812preview),
+		Validate:    validatePlatformIdentities,
+	},
+	{
+		Path:     "properties.provisioningState",
+		Validate: validateProvisioningState,
+	},
+	{
+		Path:     "properties.clusterProfile",
+		Validate: validateClusterProfile,
+	},
+	{
+		Path:        "properties.clusterProfile.fipsValidatedModules",
+		APIVersions: apiVersionsSince(apiVersion20220401),
+		Validate:    validateFipsValidatedModules,
+	},
+	{
+		Path:        "properties.clusterProfile.additionalTrustBundle",
+		APIVersions: []string{apiVersion20240812preview},
+		Validate:    validateAdditionalTrustBundle,
+	},
+	{
+		Path:     "properties.consoleProfile",
+		Validate: validateConsoleProfile,
+	},
+	{
+		Path:        "properties.servicePrincipalProfile",
+		APIVersions: apiVersionsBefore(apiVersion20240812preview),
+		Validate:    validateServicePrincipalProfilePresent,
+	},
+	{
+		Path:     "properties.servicePrincipalProfile",
+		Validate: validateServicePrincipalProfile,
+	},
+	{
+		Path:     "properties.networkProfile",
+		Validate: validateNetworkProfileCIDRs,
+	},
+	{
+		Path:        "properties.networkProfile",
+		APIVersions: []string{apiVersion20210901preview},
+		Validate:    validateSoftwareDefinedNetwork,
+	},
+	{
+		Path:        "properties.networkProfile",
+		APIVersions: apiVersionsSince(apiVersion20230401),
+		Validate:    validateOutboundType,
+	},
+	{
+		Path:        "properties.networkProfile",
+		APIVersions: []string{apiVersion20230701preview, apiVersion20231122, apiVersion20240812preview, apiVersion20250725},
+		Validate:    validateLoadBalancerProfileOutboundType,
+	},
+	{
+		Path:        "properties.networkProfile.loadBalancerProfile",
+		APIVersions: []string{apiVersion20230701preview, apiVersion20231122, apiVersion20240812preview, apiVersion20250725},
+		Validate:    validateLoadBalancerProfile,
+	},
+	{
+		Path:     "properties.masterProfile",
+		Validate: validateMasterProfile,
+	},
+	{
+		Path:        "properties.masterProfile",
+		APIVersions: apiVersionsSince(apiVersion20210901preview),
+		Validate:    validateMasterProfileEncryption,
+	},
+	{
+		Path:        "properties.masterProfile",
+		APIVersions: []string{apiVersion20240812preview},
+		Validate:    validateMasterProfileNetworkInterfaces,
+	},
+	{
+		Path:        "properties.masterProfile",
+		APIVersions: []string{apiVersion20240812preview},
+		Validate:    validateMasterProfileSecurityType,
+	},
+	{
+		Path:     "properties.apiserverProfile",
+		Validate: validateAPIServerProfile,
+	},
+	{
+		Path:        "properties.platformWorkloadIdentityProfile",
+		APIVersions: apiVersionsSince(apiVersion20240812preview),
+		Validate:    validatePlatformWorkloadIdentityProfile,
+	},
+	{
+		Path:        "properties.upgradeProfile",
+		APIVersions: apiVersionsSince(apiVersion20240812preview),
+		Validate:    validateUpgradeProfile,
+	},
+	{
+		Path:        "properties.guardrailsProfile",
+		APIVersions: []string{apiVersion20240812preview},
+		Validate:    validateGuardrailsProfile,
+	},
+	{
+		Path:        "properties.workerProfilesStatus",
+		APIVersions: apiVersionsSince(apiVersion20230904),
+		CreateOnly:  true,
+		Validate:    validateWorkerProfilesStatus,
+	},
+	{
+		Path:       "properties.workerProfiles",
+		CreateOnly: true,
+		Validate:   validateWorkerProfiles,
+	},
+	{
+		Path:        "properties.workerProfiles",
+		APIVersions: apiVersionsSince(apiVersion20210901preview),
+		CreateOnly:  true,
+		Validate:    validateWorkerProfileEncryption,
+	},
+	{
+		Path:        "properties.workerProfiles",
+		APIVersions: []string{apiVersion20240812preview},
+		CreateOnly:  true,
+		Validate:    validateWorkerProfileSecurityType,
+	},
+	{
+		Path:        "properties.ingressProfiles",
+		APIVersions: apiVersionsSince(apiVersion20200430),
+		CreateOnly:  true,
+		Validate:    validateIngressProfiles,
+	},
+	// ingress profiles can't be changed in 2019-12-31-preview, but are
+	// validated on update too
+	{
+		Path:        "properties.ingressProfiles",
+		APIVersions: []string{apiVersion20191231preview},
+		Validate:    validateIngressProfiles,
+	},
+}
+
+func validateOpenShiftClusterResourceID(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	if !strings.EqualFold(oc.ID, c.ResourceID) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeMismatchingResourceID, path, "The provided resource ID '%s' did not match the name in the Url '%s'.", oc.ID, c.ResourceID)
+	}
+	if !strings.EqualFold(oc.Name, c.r.ResourceName) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeMismatchingResourceName, "name", "The provided resource name '%s' did not match the name in the Url '%s'.", oc.Name, c.r.ResourceName)
+	}
+	if !strings.EqualFold(oc.Type, openShiftClusterResourceType) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeMismatchingResourceType, "type", "The provided resource type '%s' did not match the name in the Url '%s'.", oc.Type, openShiftClusterResourceType)
+	}
+	if !strings.EqualFold(oc.Location, c.Location) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "location", "The provided location '%s' is invalid.", oc.Location)
+	}
+
+	return nil
+}
+
+func validatePlatformIdentities(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	pwip := oc.Properties.PlatformWorkloadIdentityProfile
+	spp := oc.Properties.ServicePrincipalProfile
+
+	if pwip == nil && spp == nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "Must provide either an identity or service principal credentials.")
+	}
+
+	if pwip != nil && spp != nil && (spp.ClientID != "" || spp.ClientSecret != "") {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "Cannot use identities and service principal credentials at the same time.")
+	}
+
+	clusterIdentityPresent := oc.Identity != nil
+	operatorRolePresent := pwip != nil
+
+	if clusterIdentityPresent != operatorRolePresent {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "identity", "Cluster identity and platform workload identities require each other.")
+	}
+
+	if clusterIdentityPresent && len(oc.Identity.UserAssignedIdentities) != 1 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "identity", "The provided cluster identity is invalid; there should be exactly one.")
+	}
+
+	if operatorRolePresent && len(pwip.PlatformWorkloadIdentities) == 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.platformWorkloadIdentityProfile.platformWorkloadIdentities", "The set of platform workload identities cannot be empty.")
+	}
+
+	return nil
+}
+
+func validateProvisioningState(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	switch oc.Properties.ProvisioningState {
+	case api.ProvisioningStateCreating, api.ProvisioningStateUpdating,
+		api.ProvisioningStateAdminUpdating, api.ProvisioningStateDeleting,
+		api.ProvisioningStateSucceeded, api.ProvisioningStateFailed:
+		return nil
+	}
+
+	if canceled, ok := canceledProvisioningStates[c.APIVersion]; ok && oc.Properties.ProvisioningState == canceled {
+		return nil
+	}
+
+	return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided provisioning state '%s' is invalid.", oc.Properties.ProvisioningState)
+}
+
+func validateClusterProfile(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	cp := &oc.Properties.ClusterProfile
+
+	if pullsecret.Validate(string(cp.PullSecret)) != nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".pullSecret", "The provided pull secret is invalid.")
+	}
+	if c.IsCreate {
+		if !RxDomainName.MatchString(cp.Domain) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".domain", "The provided domain '%s' is invalid.", cp.Domain)
+		}
+	} else {
+		// We currently do not allow domains with a digit as a first charecter,
+		// for new clusters, but we already have some existing clusters with
+		// domains like this and we need to allow customers to update them.
+		if !RxDomainNameRFC1123.MatchString(cp.Domain) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".domain", "The provided domain '%s' is invalid.", cp.Domain)
+		}
+	}
+	// domain ends .aroapp.io, but doesn't end .<rp-location>.aroapp.io
+	if strings.HasSuffix(cp.Domain, "."+strings.SplitN(c.Domain, ".", 2)[1]) &&
+		!strings.HasSuffix(cp.Domain, "."+c.Domain) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".domain", "The provided domain '%s' is invalid.", cp.Domain)
+	}
+	// domain is of form multiple.names.<rp-location>.aroapp.io
+	if strings.HasSuffix(cp.Domain, "."+c.Domain) &&
+		strings.ContainsRune(strings.TrimSuffix(cp.Domain, "."+c.Domain), '.') {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".domain", "The provided domain '%s' is invalid.", cp.Domain)
+	}
+
+	if !RxResourceGroupID.MatchString(cp.ResourceGroupID) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".resourceGroupId", "The provided resource group '%s' is invalid.", cp.ResourceGroupID)
+	}
+	if strings.Split(cp.ResourceGroupID, "/")[2] != c.r.SubscriptionID {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".resourceGroupId", "The provided resource group '%s' is invalid: must be in same subscription as cluster.", cp.ResourceGroupID)
+	}
+	if strings.EqualFold(cp.ResourceGroupID, fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", c.r.SubscriptionID, c.r.ResourceGroup)) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".resourceGroupId", "The provided resource group '%s' is invalid: must be different from resourceGroup of the OpenShift cluster object.", cp.ResourceGroupID)
+	}
+
+	return nil
+}
+
+func validateAdditionalTrustBundle(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	bundle := oc.Properties.ClusterProfile.AdditionalTrustBundle
+	if bundle == "" {
+		return nil
+	}
+
+	key, certs, err := utilpem.Parse([]byte(bundle))
+	if err != nil || key != nil || len(certs) == 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided additional trust bundle is invalid: it must contain only PEM-encoded certificates.")
+	}
+
+	for _, cert := range certs {
+		if !cert.IsCA {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided additional trust bundle is invalid: certificate '%s' is not a CA certificate.", cert.Subject.String())
+		}
+	}
+
+	return nil
+}
+
+func validateFipsValidatedModules(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	switch oc.Properties.ClusterProfile.FipsValidatedModules {
+	case api.FipsValidatedModulesDisabled, api.FipsValidatedModulesEnabled:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided value '%s' is invalid.", oc.Properties.ClusterProfile.FipsValidatedModules)
+	}
+
+	return nil
+}
+
+func validateConsoleProfile(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	cp := &oc.Properties.ConsoleProfile
+
+	if cp.URL != "" {
+		if _, err := url.Parse(cp.URL); err != nil {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".url", "The provided console URL '%s' is invalid.", cp.URL)
+		}
+	}
+
+	return nil
+}
+
+func validateServicePrincipalProfilePresent(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	if oc.Properties.ServicePrincipalProfile == nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".servicePrincipalProfile", "ServicePrincipalProfile cannot be nil in this API version.")
+	}
+
+	return nil
+}
+
+func validateServicePrincipalProfile(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	spp := oc.Properties.ServicePrincipalProfile
+	if spp == nil {
+		return nil
+	}
+
+	if !uuid.IsValid(spp.ClientID) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".clientId", "The provided client ID '%s' is invalid.", spp.ClientID)
+	}
+	if spp.ClientSecret == "" {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".clientSecret", "The provided client secret is invalid.")
+	}
+
+	return nil
+}
+
+func validateNetworkProfileCIDRs(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	np := &oc.Properties.NetworkProfile
+
+	// 2021-09-01-preview allowed the join CIDR ranges to be used with
+	// OpenShiftSDN
+	checkJoinCIDRRange := c.APIVersion != apiVersion20210901preview ||
+		np.SoftwareDefinedNetwork == api.SoftwareDefinedNetworkOVNKubernetes
+
+	podIP, pod, err := net.ParseCIDR(np.PodCIDR)
+	if err != nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".podCidr", "The provided pod CIDR '%s' is invalid: '%s'.", np.PodCIDR, err)
+	}
+
+	if pod.IP.To4() == nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".podCidr", "The provided pod CIDR '%s' is invalid: must be IPv4.", np.PodCIDR)
+	}
+
+	if checkJoinCIDRRange {
+		if err := validateNotInJoinCIDRRange(path, np.PodCIDR, pod); err != nil {
+			return err
+		}
+	}
+
+	ones, _ := pod.Mask.Size()
+	if ones > 18 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".podCidr", "The provided vnet CIDR '%s' is invalid: must be /18 or larger.", np.PodCIDR)
+	}
+
+	nip := podIP.Mask(pod.Mask)
+
+	if nip.String() != podIP.String() {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidNetworkAddress, path+".podCidr", "The provided pod CIDR '%s' is invalid, expecting: '%s/%d'.", np.PodCIDR, nip.String(), ones)
+	}
+
+	serviceIP, service, err := net.ParseCIDR(np.ServiceCIDR)
+	if err != nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".serviceCidr", "The provided service CIDR '%s' is invalid: '%s'.", np.ServiceCIDR, err)
+	}
+
+	if service.IP.To4() == nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".serviceCidr", "The provided service CIDR '%s' is invalid: must be IPv4.", np.ServiceCIDR)
+	}
+
+	if checkJoinCIDRRange {
+		if err := validateNotInJoinCIDRRange(path, np.ServiceCIDR, service); err != nil {
+			return err
+		}
+	}
+
+	ones, _ = service.Mask.Size()
+	if ones > 22 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".serviceCidr", "The provided vnet CIDR '%s' is invalid: must be /22 or larger.", np.ServiceCIDR)
+	}
+
+	nip = serviceIP.Mask(service.Mask)
+
+	if nip.String() != serviceIP.String() {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidNetworkAddress, path+".serviceCidr", "The provided service CIDR '%s' is invalid, expecting: '%s/%d'.", np.ServiceCIDR, nip.String(), ones)
+	}
+
+	return nil
+}
+
+func validateNotInJoinCIDRRange(path, s string, ipnet *net.IPNet) error {
+	for _, r := range api.JoinCIDRRange {
+		_, cidr, _ := net.ParseCIDR(r)
+		if cidr.Contains(ipnet.IP) || ipnet.Contains(cidr.IP) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidCIDRRange, path, "Azure Red Hat OpenShift uses 100.64.0.0/16, 169.254.169.0/29, and 100.88.0.0/16 IP address ranges internally. Do not include this '%s' IP address range in any other CIDR definitions in your cluster.", s)
+		}
+	}
+
+	return nil
+}
+
+func validateSoftwareDefinedNetwork(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	switch oc.Properties.NetworkProfile.SoftwareDefinedNetwork {
+	case api.SoftwareDefinedNetworkOVNKubernetes, api.SoftwareDefinedNetworkOpenShiftSDN:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".SoftwareDefinedNetwork", "The provided SoftwareDefinedNetwork '%s' is invalid.", oc.Properties.NetworkProfile.SoftwareDefinedNetwork)
+	}
+
+	return nil
+}
+
+func validateOutboundType(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	np := &oc.Properties.NetworkProfile
+
+	if np.OutboundType == "" {
+		return nil
+	}
+
+	if np.OutboundType != api.OutboundTypeLoadbalancer && np.OutboundType != api.OutboundTypeUserDefinedRouting {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".outboundType", "The provided outboundType '%s' is invalid: must be UserDefinedRouting or Loadbalancer.", np.OutboundType)
+	}
+	if np.OutboundType == api.OutboundTypeUserDefinedRouting &&
+		(oc.Properties.APIServerProfile.Visibility != api.VisibilityPrivate || oc.Properties.IngressProfiles[0].Visibility != api.VisibilityPrivate) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".outboundType", "The provided outboundType '%s' is invalid: cannot use UserDefinedRouting if either API Server Visibility or Ingress Visibility is public.", np.OutboundType)
+	}
+
+	return nil
+}
+
+func validateLoadBalancerProfileOutboundType(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	np := &oc.Properties.NetworkProfile
+
+	if np.OutboundType == api.OutboundTypeUserDefinedRouting && np.LoadBalancerProfile != nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".loadBalancerProfile", "The provided loadBalancerProfile is invalid: cannot use a loadBalancerProfile if outboundType is UserDefinedRouting.")
+	}
+
+	return nil
+}
+
+func validateLoadBalancerProfile(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	lbp := oc.Properties.NetworkProfile.LoadBalancerProfile
+	if lbp == nil {
+		return nil
+	}
+
+	if lbp.ManagedOutboundIPs != nil {
+		if c.ArchitectureVersion == api.ArchitectureVersionV1 && lbp.ManagedOutboundIPs.Count > 1 {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".managedOutboundIps.count", "The provided managedOutboundIps.count %d is invalid: managedOutboundIps.count must be 1, multiple IPs are not supported for this cluster's network architecture.", lbp.ManagedOutboundIPs.Count)
+		}
+		if !(lbp.ManagedOutboundIPs.Count > 0 && lbp.ManagedOutboundIPs.Count <= 20) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".managedOutboundIps.count", "The provided managedOutboundIps.count %d is invalid: managedOutboundIps.count must be in the range of 1 to 20 (inclusive).", lbp.ManagedOutboundIPs.Count)
+		}
+	}
+
+	// Prevents EffectiveOutboundIPs from being set during create,
+	// during update validateDelta will prevent the field from being changed.
+	if lbp.EffectiveOutboundIPs != nil && c.IsCreate {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".effectiveOutboundIps", "The field effectiveOutboundIps is read only.")
+	}
+
+	return nil
+}
+
+func validateMasterProfile(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	mp := &oc.Properties.MasterProfile
+
+	if !VMSizeIsValid(mp.VMSize, c.RequireD2sV3Workers, true, c.AdditionalVMSizes) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".vmSize", "The provided master VM size '%s' is invalid.", mp.VMSize)
+	}
+	if !RxSubnetID.MatchString(mp.SubnetID) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".subnetId", "The provided master VM subnet '%s' is invalid.", mp.SubnetID)
+	}
+	sr, err := azure.ParseResourceID(mp.SubnetID)
+	if err != nil {
+		return err
+	}
+	if sr.SubscriptionID != c.r.SubscriptionID {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".subnetId", "The provided master VM subnet '%s' is invalid: must be in same subscription as cluster.", mp.SubnetID)
+	}
+
+	return nil
+}
+
+func validateMasterProfileEncryption(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	mp := &oc.Properties.MasterProfile
+
+	switch mp.EncryptionAtHost {
+	case api.EncryptionAtHostDisabled, api.EncryptionAtHostEnabled:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".encryptionAtHost", "The provided value '%s' is invalid.", mp.EncryptionAtHost)
+	}
+	if mp.DiskEncryptionSetID != "" {
+		if !RxDiskEncryptionSetID.MatchString(mp.DiskEncryptionSetID) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".diskEncryptionSetId", "The provided master disk encryption set '%s' is invalid.", mp.DiskEncryptionSetID)
+		}
+		desr, err := azure.ParseResourceID(mp.DiskEncryptionSetID)
+		if err != nil {
+			return err
+		}
+		if desr.SubscriptionID != c.r.SubscriptionID {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".diskEncryptionSetId", "The provided master disk encryption set '%s' is invalid: must be in same subscription as cluster.", mp.DiskEncryptionSetID)
+		}
+	}
+
+	return nil
+}
+
+// validateMasterProfileNetworkInterfaces validates the statically assigned IPs
+// or pre-created NICs for the masters.  Whether they fit the master subnet is
+// checked by dynamic validation.
+func validateMasterProfileNetworkInterfaces(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	mp := &oc.Properties.MasterProfile
+
+	if len(mp.IPAddresses) > 0 && len(mp.NetworkInterfaceIDs) > 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".networkInterfaceIds", "The fields ipAddresses and networkInterfaceIds are mutually exclusive.")
+	}
+
+	if mp.IPAddresses != nil {
+		if len(mp.IPAddresses) != 3 {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".ipAddresses", "There should be exactly three master IP addresses.")
+		}
+
+		seen := map[string]struct{}{}
+		for i, s := range mp.IPAddresses {
+			ipPath := fmt.Sprintf("%s.ipAddresses[%d]", path, i)
+
+			ip := net.ParseIP(s)
+			if ip == nil || ip.To4() == nil {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, ipPath, "The provided master IP address '%s' is invalid: must be IPv4.", s)
+			}
+			if _, ok := seen[ip.String()]; ok {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, ipPath, "The provided master IP address '%s' is duplicated.", s)
+			}
+			seen[ip.String()] = struct{}{}
+		}
+	}
+
+	if mp.NetworkInterfaceIDs != nil {
+		if len(mp.NetworkInterfaceIDs) != 3 {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".networkInterfaceIds", "There should be exactly three master network interfaces.")
+		}
+
+		seen := map[string]struct{}{}
+		for i, id := range mp.NetworkInterfaceIDs {
+			nicPath := fmt.Sprintf("%s.networkInterfaceIds[%d]", path, i)
+
+			if !RxNetworkInterfaceID.MatchString(id) {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, nicPath, "The provided master network interface '%s' is invalid.", id)
+			}
+			r, err := azure.ParseResourceID(id)
+			if err != nil {
+				return err
+			}
+			if r.SubscriptionID != c.r.SubscriptionID {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, nicPath, "The provided master network interface '%s' is invalid: must be in same subscription as cluster.", id)
+			}
+			if _, ok := seen[strings.ToLower(id)]; ok {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, nicPath, "The provided master network interface '%s' is duplicated.", id)
+			}
+			seen[strings.ToLower(id)] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// validateMasterProfileSecurityType validates the master security type.  When
+// it is empty, it is defaulted at install time.
+func validateMasterProfileSecurityType(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	mp := &oc.Properties.MasterProfile
+
+	switch mp.SecurityType {
+	case "", api.SecurityTypeStandard, api.SecurityTypeTrustedLaunch:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".securityType", "The provided value '%s' is invalid.", mp.SecurityType)
+	}
+
+	return nil
+}
+
+func validateAPIServerProfile(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	ap := &oc.Properties.APIServerProfile
+
+	switch ap.Visibility {
+	case api.VisibilityPublic, api.VisibilityPrivate:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".visibility", "The provided visibility '%s' is invalid.", ap.Visibility)
+	}
+	if ap.URL != "" {
+		if _, err := url.Parse(ap.URL); err != nil {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".url", "The provided URL '%s' is invalid.", ap.URL)
+		}
+	}
+
+	return validateIPv4(path, ap.IP)
+}
+
+func validatePlatformWorkloadIdentityProfile(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	// PlatformWorkloadIdentityProfile being empty is acceptable
+	pwip := oc.Properties.PlatformWorkloadIdentityProfile
+	if pwip == nil {
+		return nil
+	}
+
+	// Validate the PlatformWorkloadIdentities
+	foundIdentityResourceIDs := map[string]string{}
+
+	for name, p := range pwip.PlatformWorkloadIdentities {
+		if _, present := foundIdentityResourceIDs[strings.ToLower(p.ResourceID)]; present {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s.PlatformWorkloadIdentities", path), "ResourceID %s used by multiple identities.", strings.ToLower(p.ResourceID))
+		}
+		foundIdentityResourceIDs[strings.ToLower(p.ResourceID)] = ""
+
+		resource, err := azcorearm.ParseResourceID(p.ResourceID)
+		if err != nil {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s.PlatformWorkloadIdentities[%s].resourceID", path, name), "ResourceID %s formatted incorrectly.", p.ResourceID)
+		}
+
+		if name == "" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s.PlatformWorkloadIdentities[%s].resourceID", path, name), "Operator name is empty.")
+		}
+
+		if resource.ResourceType.Type != "userAssignedIdentities" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s.PlatformWorkloadIdentities[%s].resourceID", path, name), "Resource must be a user assigned identity.")
+		}
+	}
+
+	if pwip.UpgradeableTo != nil {
+		_, err := semver.NewVersion(string(*pwip.UpgradeableTo))
+		if err != nil {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s.UpgradeableTo[%v]", path, *pwip.UpgradeableTo), "UpgradeableTo must be a valid OpenShift version in the format 'x.y.z'.")
+		}
+	}
+
+	return nil
+}
+
+func validateUpgradeProfile(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	up := oc.Properties.UpgradeProfile
+	if up == nil {
+		return nil
+	}
+
+	switch up.Policy {
+	case api.UpgradePolicyManual:
+	case api.UpgradePolicyAutomaticPatch:
+		if len(up.MaintenanceWindows) == 0 {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".maintenanceWindows", "At least one maintenance window must be provided for automatic upgrades.")
+		}
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".policy", "The provided upgrade policy '%s' is invalid.", up.Policy)
+	}
+
+	for i, w := range up.MaintenanceWindows {
+		windowPath := fmt.Sprintf("%s.maintenanceWindows[%d]", path, i)
+		if _, ok := api.ParseDayOfWeek(w.DayOfWeek); !ok {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, windowPath+".dayOfWeek", "The provided day of week '%s' is invalid.", w.DayOfWeek)
+		}
+		if w.StartHour < 0 || w.StartHour > 23 {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, windowPath+".startHour", "The provided start hour '%d' is invalid.", w.StartHour)
+		}
+		if w.DurationHours < 1 || w.DurationHours > 24 {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, windowPath+".durationHours", "The provided duration '%d' is invalid.", w.DurationHours)
+		}
+	}
+
+	return nil
+}
+
+func validateGuardrailsProfile(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	gp := oc.Properties.GuardrailsProfile
+	if gp == nil {
+		return nil
+	}
+
+	switch gp.State {
+	case api.GuardrailsStateEnabled:
+	case api.GuardrailsStateDisabled:
+		if gp.EnforcementAction != "" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".enforcementAction", "The enforcement action may only be set when guardrails are enabled.")
+		}
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".state", "The provided guardrails state '%s' is invalid.", gp.State)
+	}
+
+	switch gp.EnforcementAction {
+	case "", api.GuardrailsEnforcementActionDryRun, api.GuardrailsEnforcementActionWarn, api.GuardrailsEnforcementActionDeny:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".enforcementAction", "The provided enforcement action '%s' is invalid.", gp.EnforcementAction)
+	}
+
+	return nil
+}
+
+func validateWorkerProfilesStatus(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	if len(oc.Properties.WorkerProfilesStatus) != 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "Worker Profile Status must be set to nil.")
+	}
+
+	return nil
+}
+
+func validateWorkerProfiles(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	if len(oc.Properties.WorkerProfiles) != 1 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "There should be exactly one worker profile.")
+	}
+
+	wp := &oc.Properties.WorkerProfiles[0]
+	mp := &oc.Properties.MasterProfile
+	path += "['" + wp.Name + "']"
+
+	if wp.Name != "worker" {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".name", "The provided worker name '%s' is invalid.", wp.Name)
+	}
+	if !VMSizeIsValid(wp.VMSize, c.RequireD2sV3Workers, false, c.AdditionalVMSizes) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".vmSize", "The provided worker VM size '%s' is invalid.", wp.VMSize)
+	}
+	if !DiskSizeIsValid(wp.DiskSizeGB) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".diskSizeGB", "The provided worker disk size '%d' is invalid.", wp.DiskSizeGB)
+	}
+	if !RxSubnetID.MatchString(wp.SubnetID) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".subnetId", "The provided worker VM subnet '%s' is invalid.", wp.SubnetID)
+	}
+	workerVnetID, _, err := apisubnet.Split(wp.SubnetID)
+	if err != nil {
+		return err
+	}
+	masterVnetID, _, err := apisubnet.Split(mp.SubnetID)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(masterVnetID, workerVnetID) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".subnetId", "The provided worker VM subnet '%s' is invalid: must be in the same vnet as master VM subnet '%s'.", wp.SubnetID, mp.SubnetID)
+	}
+	if strings.EqualFold(mp.SubnetID, wp.SubnetID) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".subnetId", "The provided worker VM subnet '%s' is invalid: must be different to master VM subnet '%s'.", wp.SubnetID, mp.SubnetID)
+	}
+	if wp.Count < 2 || wp.Count > 50 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".count", "The provided worker count '%d' is invalid.", wp.Count)
+	}
+
+	return nil
+}
+
+// validateWorkerProfileEncryption runs after validateWorkerProfiles, which
+// ensures that there is exactly one worker profile
+func validateWorkerProfileEncryption(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	wp := &oc.Properties.WorkerProfiles[0]
+	mp := &oc.Properties.MasterProfile
+	path += "['" + wp.Name + "']"
+
+	switch wp.EncryptionAtHost {
+	case api.EncryptionAtHostDisabled, api.EncryptionAtHostEnabled:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".encryptionAtHost", "The provided value '%s' is invalid.", wp.EncryptionAtHost)
+	}
+	if !strings.EqualFold(mp.DiskEncryptionSetID, wp.DiskEncryptionSetID) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".subnetId", "The provided worker disk encryption set '%s' is invalid: must be the same as master disk encryption set '%s'.", wp.DiskEncryptionSetID, mp.DiskEncryptionSetID)
+	}
+
+	return nil
+}
+
+// validateWorkerProfileSecurityType runs after validateWorkerProfiles and
+// validateWorkerProfileEncryption, which ensure that there is exactly one
+// worker profile with valid encryption settings.  When the security type is
+// empty, it is defaulted at install time.
+func validateWorkerProfileSecurityType(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	wp := &oc.Properties.WorkerProfiles[0]
+	path += "['" + wp.Name + "']"
+
+	switch wp.SecurityType {
+	case "", api.SecurityTypeStandard, api.SecurityTypeTrustedLaunch, api.SecurityTypeConfidentialVM:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".securityType", "The provided value '%s' is invalid.", wp.SecurityType)
+	}
+
+	// Trusted Launch is not available on Arm64 VM sizes, and confidential VM
+	// sizes only run as confidential VMs
+	switch {
+	case wp.SecurityType == api.SecurityTypeTrustedLaunch && VMSizeIsArm64(wp.VMSize),
+		wp.SecurityType == api.SecurityTypeConfidentialVM && !VMSizeIsConfidential(wp.VMSize),
+		wp.SecurityType != "" && wp.SecurityType != api.SecurityTypeConfidentialVM && VMSizeIsConfidential(wp.VMSize):
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".securityType", "The provided security type '%s' is invalid: it is not supported by worker VM size '%s'.", wp.SecurityType, wp.VMSize)
+	}
+
+	if !VMSizeIsConfidential(wp.VMSize) {
+		if wp.SecurityEncryptionType != "" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".securityEncryptionType", "The provided security encryption type '%s' is invalid: it is only supported by confidential VMs.", wp.SecurityEncryptionType)
+		}
+		return nil
+	}
+
+	switch wp.SecurityEncryptionType {
+	case "", api.SecurityEncryptionTypeVMGuestStateOnly, api.SecurityEncryptionTypeDiskWithVMGuestState:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".securityEncryptionType", "The provided value '%s' is invalid.", wp.SecurityEncryptionType)
+	}
+
+	// confidential VMs do not support encryption at host
+	if wp.EncryptionAtHost == api.EncryptionAtHostEnabled {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".encryptionAtHost", "Encryption at host is not supported by confidential worker VM size '%s'.", wp.VMSize)
+	}
+
+	return nil
+}
+
+func validateIngressProfiles(c *RuleContext, path string, oc *api.OpenShiftCluster) error {
+	if len(oc.Properties.IngressProfiles) != 1 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "There should be exactly one ingress profile.")
+	}
+
+	p := &oc.Properties.IngressProfiles[0]
+	path += "['" + p.Name + "']"
+
+	if p.Name != "default" {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".name", "The provided ingress name '%s' is invalid.", p.Name)
+	}
+	switch p.Visibility {
+	case api.VisibilityPublic, api.VisibilityPrivate:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".visibility", "The provided visibility '%s' is invalid.", p.Visibility)
+	}
+
+	return validateIPv4(path, p.IP)
+}
+
+func validateIPv4(path, s string) error {
+	if s == "" {
+		return nil
+	}
+
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".ip", "The provided IP '%s' is invalid.", s)
+	}
+	if ip.To4() == nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".ip", "The provided IP '%s' is invalid: must be IPv4.", s)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"github.com/Azure/go-autorest/autorest/azure"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// API versions known to the static validation rules.  A rule which only
+// applies to some API versions lists them explicitly, so a new API version must
+// be added here and to the rules it is subject to.
+const (
+	apiVersion20191231preview = "2019-12-31-preview"
+	apiVersion20200430        = "2020-04-30"
+	apiVersion20210901preview = "2021-09-01-preview"
+	apiVersion20220401        = "2022-04-01"
+	apiVersion20220904        = "2022-09-04"
+	apiVersion20230401        = "2023-04-01"
+	apiVersion20230701preview = "2023-07-01-preview"
+	apiVersion20230904        = "2023-09-04"
+	apiVersion20231122        = "2023-11-22"
+	apiVersion20240812preview = "2024-08-12-preview"
+	apiVersion20250725        = "2025-07-25"
+)
+
+// apiVersions lists the API versions in the order they were released
+var apiVersions = []string{
+	apiVersion20191231preview,
+	apiVersion20200430,
+	apiVersion20210901preview,
+	apiVersion20220401,
+	apiVersion20220904,
+	apiVersion20230401,
+	apiVersion20230701preview,
+	apiVersion20230904,
+	apiVersion20231122,
+	apiVersion20240812preview,
+	apiVersion20250725,
+}
+
+// apiVersionsSince returns the API versions released since (and including)
+// apiVersion
+func apiVersionsSince(apiVersion string) []string {
+	for i, v := range apiVersions {
+		if v == apiVersion {
+			return apiVersions[i:]
+		}
+	}
+
+	panic("unknown API version " + apiVersion)
+}
+
+// apiVersionsBefore returns the API versions released before apiVersion
+func apiVersionsBefore(apiVersion string) []string {
+	for i, v := range apiVersions {
+		if v == apiVersion {
+			return apiVersions[:i]
+		}
+	}
+
+	panic("unknown API version " + apiVersion)
+}
+
+// RuleContext holds the parameters of a static validation request
+type RuleContext struct {
+	APIVersion          string
+	Location            string
+	Domain              string
+	RequireD2sV3Workers bool
+	AdditionalVMSizes   []*api.SupportedVMSize
+	ResourceID          string
+	IsCreate            bool
+	ArchitectureVersion api.ArchitectureVersion
+
+	r azure.Resource
+}
+
+// Rule is a static validation rule for the field at Path of an OpenShift
+// cluster
+type Rule struct {
+	// Path is the path of the validated field, used as the target of the
+	// errors returned by Validate
+	Path string
+
+	// APIVersions lists the API versions the rule applies to.  A rule with
+	// no APIVersions applies to all API versions.
+	APIVersions []string
+
+	// CreateOnly rules are only applied when a cluster is created
+	CreateOnly bool
+
+	Validate func(c *RuleContext, path string, oc *api.OpenShiftCluster) error
+}
+
+func (r *Rule) appliesTo(c *RuleContext) bool {
+	if r.CreateOnly && !c.IsCreate {
+		return false
+	}
+
+	if r.APIVersions == nil {
+		return true
+	}
+
+	for _, v := range r.APIVersions {
+		if v == c.APIVersion {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Rules is an ordered list of static validation rules
+type Rules []Rule
+
+// Validate runs the rules which apply to the API version and operation of c
+// against oc in order, and returns the first error found
+func (rules Rules) Validate(c *RuleContext, oc *api.OpenShiftCluster) error {
+	var err error
+	c.r, err = azure.ParseResourceID(c.ResourceID)
+	if err != nil {
+		return err
+	}
+
+	for i := range rules {
+		if !rules[i].appliesTo(c) {
+			continue
+		}
+
+		err = rules[i].Validate(c, rules[i].Path, oc)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestRulesValidate(t *testing.T) {
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName"
+
+	for _, tt := range []struct {
+		name       string
+		apiVersion string
+		isCreate   bool
+		resourceID string
+		wantRan    []string
+		wantErr    string
+	}{
+		{
+			name:       "create in the newest API version",
+			apiVersion: apiVersion20250725,
+			isCreate:   true,
+			resourceID: resourceID,
+			wantRan:    []string{"all", "since 2023-04-01", "create"},
+		},
+		{
+			name:       "update in the newest API version",
+			apiVersion: apiVersion20250725,
+			resourceID: resourceID,
+			wantRan:    []string{"all", "since 2023-04-01"},
+		},
+		{
+			name:       "create in an old API version",
+			apiVersion: apiVersion20220904,
+			isCreate:   true,
+			resourceID: resourceID,
+			wantRan:    []string{"all", "2022-09-04 only", "create"},
+		},
+		{
+			name:       "rules stop at the first error",
+			apiVersion: apiVersion20191231preview,
+			resourceID: resourceID,
+			wantRan:    []string{"all", "fails in 2019-12-31-preview"},
+			wantErr:    "failed",
+		},
+		{
+			name:       "invalid resource ID",
+			apiVersion: apiVersion20250725,
+			resourceID: "invalid",
+			wantErr:    "parsing failed for invalid. Invalid resource Id format",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var ran []string
+			rule := func(name string, err error) func(*RuleContext, string, *api.OpenShiftCluster) error {
+				return func(*RuleContext, string, *api.OpenShiftCluster) error {
+					ran = append(ran, name)
+					return err
+				}
+			}
+
+			rules := Rules{
+				{Validate: rule("all", nil)},
+				{APIVersions: []string{apiVersion20220904}, Validate: rule("2022-09-04 only", nil)},
+				{APIVersions: apiVersionsSince(apiVersion20230401), Validate: rule("since 2023-04-01", nil)},
+				{APIVersions: apiVersionsBefore(apiVersion20200430), Validate: rule("fails in 2019-12-31-preview", errors.New("failed"))},
+				{CreateOnly: true, Validate: rule("create", nil)},
+			}
+
+			err := rules.Validate(&RuleContext{
+				APIVersion: tt.apiVersion,
+				IsCreate:   tt.isCreate,
+				ResourceID: tt.resourceID,
+			}, &api.OpenShiftCluster{})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(ran, tt.wantRan) {
+				t.Errorf("got %v, wanted %v", ran, tt.wantRan)
+			}
+		})
+	}
+}
+
+func TestOpenShiftClusterRulesAPIVersions(t *testing.T) {
+	known := map[string]bool{}
+	for _, v := range apiVersions {
+		known[v] = true
+	}
+
+	for _, r := range OpenShiftClusterRules {
+		for _, v := range r.APIVersions {
+			if !known[v] {
+				t.Errorf("rule for %s applies to unknown API version %s", r.Path, v)
+			}
+		}
+	}
+}
@@ -4,6 +4,9 @@ package validate
 // Licensed under the Apache License 2.0.
 
 import (
+	"maps"
+	"slices"
+
 	"github.com/Azure/ARO-RP/pkg/api"
 )
 
@@ -13,6 +16,10 @@ import (
 // To add new instance types, needs Project Management's involvement and instructions are below.,
 // https://github.com/Azure/ARO-RP/blob/master/docs/adding-new-instance-types.md
 
+// The VM sizes below are built into the RP.  Newly supported VM sizes can be
+// added without an RP release via the admin API; the RP and the ARO operator
+// pass these to the functions below as additionalVMSizes.
+
 const VMRoleMaster string = "master"
 const VMRoleWorker string = "worker"
 
@@ -21,11 +28,31 @@ var supportedVMSizesByRoleMap = map[string]map[api.VMSize]api.VMSizeStruct{
 	VMRoleWorker: supportedWorkerVmSizes,
 }
 
-func SupportedVMSizesByRole(vmRole string) map[api.VMSize]api.VMSizeStruct {
+// SupportedVMSizesByRole returns the VM sizes which are supported for vmRole:
+// the ones built into the RP and those of additionalVMSizes which are
+// supported for the role.
+func SupportedVMSizesByRole(vmRole string, additionalVMSizes []*api.SupportedVMSize) map[api.VMSize]api.VMSizeStruct {
 	supportedvmsizes, exists := supportedVMSizesByRoleMap[vmRole]
 	if !exists {
 		return nil
 	}
+
+	if len(additionalVMSizes) == 0 {
+		return supportedvmsizes
+	}
+
+	supportedvmsizes = maps.Clone(supportedvmsizes)
+	for _, vmSize := range additionalVMSizes {
+		if !slices.Contains(vmSize.Properties.Roles, vmRole) {
+			continue
+		}
+
+		supportedvmsizes[vmSize.Properties.VMSize] = api.VMSizeStruct{
+			CoreCount: vmSize.Properties.CoreCount,
+			Family:    vmSize.Properties.Family,
+		}
+	}
+
 	return supportedvmsizes
 }
 
@@ -235,15 +262,71 @@ var supportedWorkerVmSizes = map[api.VMSize]api.VMSizeStruct{
 	api.VMSizeStandardNC12sV3:  api.VMSizeStandardNC12sV3Struct,
 	api.VMSizeStandardNC24sV3:  api.VMSizeStandardNC24sV3Struct,
 	api.VMSizeStandardNC24rsV3: api.VMSizeStandardNC24rsV3Struct,
+
+	// Arm64 nodes
+	// these require the cluster to be installed from a multi-architecture
+	// release payload
+	api.VMSizeStandardD4psV5:  api.VMSizeStandardD4psV5Struct,
+	api.VMSizeStandardD8psV5:  api.VMSizeStandardD8psV5Struct,
+	api.VMSizeStandardD16psV5: api.VMSizeStandardD16psV5Struct,
+	api.VMSizeStandardD32psV5: api.VMSizeStandardD32psV5Struct,
+	api.VMSizeStandardD48psV5: api.VMSizeStandardD48psV5Struct,
+	api.VMSizeStandardD64psV5: api.VMSizeStandardD64psV5Struct,
+
+	// Confidential VM nodes
+	// these must use the ConfidentialVM security type
+	api.VMSizeStandardDC4asV5:  api.VMSizeStandardDC4asV5Struct,
+	api.VMSizeStandardDC8asV5:  api.VMSizeStandardDC8asV5Struct,
+	api.VMSizeStandardDC16asV5: api.VMSizeStandardDC16asV5Struct,
+	api.VMSizeStandardDC32asV5: api.VMSizeStandardDC32asV5Struct,
+	api.VMSizeStandardDC48asV5: api.VMSizeStandardDC48asV5Struct,
+	api.VMSizeStandardDC64asV5: api.VMSizeStandardDC64asV5Struct,
+	api.VMSizeStandardDC96asV5: api.VMSizeStandardDC96asV5Struct,
+
+	api.VMSizeStandardEC4asV5:  api.VMSizeStandardEC4asV5Struct,
+	api.VMSizeStandardEC8asV5:  api.VMSizeStandardEC8asV5Struct,
+	api.VMSizeStandardEC16asV5: api.VMSizeStandardEC16asV5Struct,
+	api.VMSizeStandardEC20asV5: api.VMSizeStandardEC20asV5Struct,
+	api.VMSizeStandardEC32asV5: api.VMSizeStandardEC32asV5Struct,
+	api.VMSizeStandardEC48asV5: api.VMSizeStandardEC48asV5Struct,
+	api.VMSizeStandardEC64asV5: api.VMSizeStandardEC64asV5Struct,
+	api.VMSizeStandardEC96asV5: api.VMSizeStandardEC96asV5Struct,
+}
+
+var arm64VMSizes = map[api.VMSize]struct{}{
+	api.VMSizeStandardD4psV5:  {},
+	api.VMSizeStandardD8psV5:  {},
+	api.VMSizeStandardD16psV5: {},
+	api.VMSizeStandardD32psV5: {},
+	api.VMSizeStandardD48psV5: {},
+	api.VMSizeStandardD64psV5: {},
+}
+
+var confidentialVMSizes = map[api.VMSize]struct{}{
+	api.VMSizeStandardDC4asV5:  {},
+	api.VMSizeStandardDC8asV5:  {},
+	api.VMSizeStandardDC16asV5: {},
+	api.VMSizeStandardDC32asV5: {},
+	api.VMSizeStandardDC48asV5: {},
+	api.VMSizeStandardDC64asV5: {},
+	api.VMSizeStandardDC96asV5: {},
+	api.VMSizeStandardEC4asV5:  {},
+	api.VMSizeStandardEC8asV5:  {},
+	api.VMSizeStandardEC16asV5: {},
+	api.VMSizeStandardEC20asV5: {},
+	api.VMSizeStandardEC32asV5: {},
+	api.VMSizeStandardEC48asV5: {},
+	api.VMSizeStandardEC64asV5: {},
+	api.VMSizeStandardEC96asV5: {},
 }
 
 func DiskSizeIsValid(sizeGB int) bool {
 	return sizeGB >= 128
 }
 
-func VMSizeIsValid(vmSize api.VMSize, requiredD2sV3Workers, isMaster bool) bool {
+func VMSizeIsValid(vmSize api.VMSize, requiredD2sV3Workers, isMaster bool, additionalVMSizes []*api.SupportedVMSize) bool {
 	if isMaster {
-		_, supportedAsMaster := SupportedVMSizesByRole(VMRoleMaster)[vmSize]
+		_, supportedAsMaster := SupportedVMSizesByRole(VMRoleMaster, additionalVMSizes)[vmSize]
 		return supportedAsMaster
 	}
 
@@ -251,7 +334,7 @@ func VMSizeIsValid(vmSize api.VMSize, requiredD2sV3Workers, isMaster bool) bool
 		return false
 	}
 
-	_, supportedAsWorker := SupportedVMSizesByRole(VMRoleWorker)[vmSize]
+	_, supportedAsWorker := SupportedVMSizesByRole(VMRoleWorker, additionalVMSizes)[vmSize]
 	if supportedAsWorker || (requiredD2sV3Workers && vmSize == api.VMSizeStandardD2sV3) {
 		return true
 	}
@@ -259,17 +342,42 @@ func VMSizeIsValid(vmSize api.VMSize, requiredD2sV3Workers, isMaster bool) bool
 	return false
 }
 
-func VMSizeFromName(vmSize api.VMSize) (api.VMSizeStruct, bool) {
+// VMSizeIsArm64 returns true if vmSize has an Arm64 processor
+func VMSizeIsArm64(vmSize api.VMSize) bool {
+	_, ok := arm64VMSizes[vmSize]
+	return ok
+}
+
+// VMSizeIsConfidential returns true if vmSize is a confidential VM size, which
+// only runs with the ConfidentialVM security type
+func VMSizeIsConfidential(vmSize api.VMSize) bool {
+	_, ok := confidentialVMSizes[vmSize]
+	return ok
+}
+
+// HasArm64Workers returns true if any worker profile of the cluster uses an
+// Arm64 VM size, in which case it must be installed from a multi-architecture
+// release payload
+func HasArm64Workers(oc *api.OpenShiftCluster) bool {
+	for _, wp := range oc.Properties.WorkerProfiles {
+		if VMSizeIsArm64(wp.VMSize) {
+			return true
+		}
+	}
+	return false
+}
+
+func VMSizeFromName(vmSize api.VMSize, additionalVMSizes []*api.SupportedVMSize) (api.VMSizeStruct, bool) {
 	//this is for development purposes only
 	if vmSize == api.VMSizeStandardD2sV3 {
 		return api.VMSizeStandardD2sV3Struct, true
 	}
 
-	if size, ok := SupportedVMSizesByRole(VMRoleWorker)[vmSize]; ok {
+	if size, ok := SupportedVMSizesByRole(VMRoleWorker, additionalVMSizes)[vmSize]; ok {
 		return size, true
 	}
 
-	if size, ok := SupportedVMSizesByRole(VMRoleMaster)[vmSize]; ok {
+	if size, ok := SupportedVMSizesByRole(VMRoleMaster, additionalVMSizes)[vmSize]; ok {
 		return size, true
 	}
 	return api.VMSizeStruct{}, false
@@ -79,6 +79,34 @@ func TestVMSizeIsValid(t *testing.T) {
 			isMaster:            false,
 			desiredResult:       false,
 		},
+		{
+			name:                "arm64 vmSize is supported for use in ARO as worker node",
+			vmSize:              api.VMSizeStandardD8psV5,
+			requireD2sV3Workers: false,
+			isMaster:            false,
+			desiredResult:       true,
+		},
+		{
+			name:                "arm64 vmSize is not supported for use in ARO as master node",
+			vmSize:              api.VMSizeStandardD8psV5,
+			requireD2sV3Workers: false,
+			isMaster:            true,
+			desiredResult:       false,
+		},
+		{
+			name:                "confidential vmSize is supported for use in ARO as worker node",
+			vmSize:              api.VMSizeStandardEC16asV5,
+			requireD2sV3Workers: false,
+			isMaster:            false,
+			desiredResult:       true,
+		},
+		{
+			name:                "confidential vmSize is not supported for use in ARO as master node",
+			vmSize:              api.VMSizeStandardEC16asV5,
+			requireD2sV3Workers: false,
+			isMaster:            true,
+			desiredResult:       false,
+		},
 		{
 			name:                "install requires Standard_D2s_v3 workers, worker vmSize is Standard_D2s_v3",
 			vmSize:              api.VMSizeStandardD2sV3,
@@ -95,7 +123,100 @@ func TestVMSizeIsValid(t *testing.T) {
 		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
-			result := VMSizeIsValid(tt.vmSize, tt.requireD2sV3Workers, tt.isMaster)
+			result := VMSizeIsValid(tt.vmSize, tt.requireD2sV3Workers, tt.isMaster, nil)
+
+			if result != tt.desiredResult {
+				t.Errorf("Want %v, got %v", tt.desiredResult, result)
+			}
+		})
+	}
+}
+
+func TestAdditionalVMSizes(t *testing.T) {
+	const vmSize = api.VMSize("Standard_D8s_v6")
+
+	additionalVMSizes := []*api.SupportedVMSize{
+		{
+			Properties: api.SupportedVMSizeProperties{
+				VMSize:    vmSize,
+				CoreCount: 8,
+				Family:    "standardDSv6Family",
+				Roles:     []string{VMRoleWorker},
+			},
+		},
+	}
+
+	if !VMSizeIsValid(vmSize, false, false, additionalVMSizes) {
+		t.Error("want additional vmSize to be valid as worker")
+	}
+	if VMSizeIsValid(vmSize, false, true, additionalVMSizes) {
+		t.Error("want additional vmSize to be invalid as master")
+	}
+	if _, ok := supportedWorkerVmSizes[vmSize]; ok {
+		t.Error("additional vmSize leaked into built-in vmSizes")
+	}
+
+	size, ok := VMSizeFromName(vmSize, additionalVMSizes)
+	if !ok || size.CoreCount != 8 || size.Family != "standardDSv6Family" {
+		t.Errorf("unexpected vmSize %v, %v", size, ok)
+	}
+
+	if VMSizeIsValid(vmSize, false, false, nil) {
+		t.Error("want vmSize to be invalid as worker without additional vmSizes")
+	}
+}
+
+func TestVMSizeIsConfidential(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		vmSize        api.VMSize
+		desiredResult bool
+	}{
+		{
+			name:          "confidential vmSize",
+			vmSize:        api.VMSizeStandardDC8asV5,
+			desiredResult: true,
+		},
+		{
+			name:          "non-confidential vmSize",
+			vmSize:        api.VMSizeStandardD8asV5,
+			desiredResult: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := VMSizeIsConfidential(tt.vmSize)
+
+			if result != tt.desiredResult {
+				t.Errorf("Want %v, got %v", tt.desiredResult, result)
+			}
+		})
+	}
+}
+
+func TestHasArm64Workers(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		vmSizes       []api.VMSize
+		desiredResult bool
+	}{
+		{
+			name:          "no arm64 workers",
+			vmSizes:       []api.VMSize{api.VMSizeStandardD4sV5},
+			desiredResult: false,
+		},
+		{
+			name:          "arm64 workers",
+			vmSizes:       []api.VMSize{api.VMSizeStandardD4sV5, api.VMSizeStandardD4psV5},
+			desiredResult: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			oc := &api.OpenShiftCluster{}
+			for _, vmSize := range tt.vmSizes {
+				oc.Properties.WorkerProfiles = append(oc.Properties.WorkerProfiles, api.WorkerProfile{VMSize: vmSize})
+			}
+
+			result := HasArm64Workers(oc)
 
 			if result != tt.desiredResult {
 				t.Errorf("Want %v, got %v", tt.desiredResult, result)
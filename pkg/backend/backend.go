@@ -13,6 +13,7 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/hive"
 	"github.com/Azure/ARO-RP/pkg/metrics"
 	"github.com/Azure/ARO-RP/pkg/util/billing"
 	"github.com/Azure/ARO-RP/pkg/util/encryption"
@@ -32,9 +33,11 @@ type backend struct {
 	dbBilling                          database.Billing
 	dbGateway                          database.Gateway
 	dbOpenShiftClusters                database.OpenShiftClusters
+	dbOpenShiftClusterTombstones       database.OpenShiftClusterTombstones
 	dbSubscriptions                    database.Subscriptions
 	dbOpenShiftVersions                database.OpenShiftVersions
 	dbPlatformWorkloadIdentityRoleSets database.PlatformWorkloadIdentityRoleSets
+	dbSupportedVMSizes                 database.SupportedVMSizes
 
 	aead    encryption.AEAD
 	m       metrics.Emitter
@@ -47,6 +50,8 @@ type backend struct {
 
 	ocb *openShiftClusterBackend
 	sb  *subscriptionBackend
+
+	hiveShards *hive.ShardHealth
 }
 
 // Runnable represents a runnable object
@@ -55,8 +60,8 @@ type Runnable interface {
 }
 
 // NewBackend returns a new runnable backend
-func NewBackend(log *logrus.Entry, env env.Interface, dbAsyncOperations database.AsyncOperations, dbBilling database.Billing, dbGateway database.Gateway, dbOpenShiftClusters database.OpenShiftClusters, dbSubscriptions database.Subscriptions, dbOpenShiftVersions database.OpenShiftVersions, dbPlatformWorkloadIdentityRoleSets database.PlatformWorkloadIdentityRoleSets, aead encryption.AEAD, m metrics.Emitter) (Runnable, error) {
-	b, err := newBackend(log, env, dbAsyncOperations, dbBilling, dbGateway, dbOpenShiftClusters, dbSubscriptions, dbOpenShiftVersions, dbPlatformWorkloadIdentityRoleSets, aead, m)
+func NewBackend(log *logrus.Entry, env env.Interface, dbAsyncOperations database.AsyncOperations, dbBilling database.Billing, dbGateway database.Gateway, dbOpenShiftClusters database.OpenShiftClusters, dbOpenShiftClusterTombstones database.OpenShiftClusterTombstones, dbSubscriptions database.Subscriptions, dbOpenShiftVersions database.OpenShiftVersions, dbPlatformWorkloadIdentityRoleSets database.PlatformWorkloadIdentityRoleSets, dbSupportedVMSizes database.SupportedVMSizes, aead encryption.AEAD, m metrics.Emitter) (Runnable, error) {
+	b, err := newBackend(log, env, dbAsyncOperations, dbBilling, dbGateway, dbOpenShiftClusters, dbOpenShiftClusterTombstones, dbSubscriptions, dbOpenShiftVersions, dbPlatformWorkloadIdentityRoleSets, dbSupportedVMSizes, aead, m)
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +71,7 @@ func NewBackend(log *logrus.Entry, env env.Interface, dbAsyncOperations database
 	return b, nil
 }
 
-func newBackend(log *logrus.Entry, env env.Interface, dbAsyncOperations database.AsyncOperations, dbBilling database.Billing, dbGateway database.Gateway, dbOpenShiftClusters database.OpenShiftClusters, dbSubscriptions database.Subscriptions, dbOpenShiftVersions database.OpenShiftVersions, dbPlatformWorkloadIdentityRoleSets database.PlatformWorkloadIdentityRoleSets, aead encryption.AEAD, m metrics.Emitter) (*backend, error) {
+func newBackend(log *logrus.Entry, env env.Interface, dbAsyncOperations database.AsyncOperations, dbBilling database.Billing, dbGateway database.Gateway, dbOpenShiftClusters database.OpenShiftClusters, dbOpenShiftClusterTombstones database.OpenShiftClusterTombstones, dbSubscriptions database.Subscriptions, dbOpenShiftVersions database.OpenShiftVersions, dbPlatformWorkloadIdentityRoleSets database.PlatformWorkloadIdentityRoleSets, dbSupportedVMSizes database.SupportedVMSizes, aead encryption.AEAD, m metrics.Emitter) (*backend, error) {
 	billing, err := billing.NewManager(env, dbBilling, dbSubscriptions, log)
 	if err != nil {
 		return nil, err
@@ -80,13 +85,17 @@ func newBackend(log *logrus.Entry, env env.Interface, dbAsyncOperations database
 		dbBilling:                          dbBilling,
 		dbGateway:                          dbGateway,
 		dbOpenShiftClusters:                dbOpenShiftClusters,
+		dbOpenShiftClusterTombstones:       dbOpenShiftClusterTombstones,
 		dbSubscriptions:                    dbSubscriptions,
 		dbOpenShiftVersions:                dbOpenShiftVersions,
 		dbPlatformWorkloadIdentityRoleSets: dbPlatformWorkloadIdentityRoleSets,
+		dbSupportedVMSizes:                 dbSupportedVMSizes,
 
 		billing: billing,
 		aead:    aead,
 		m:       m,
+
+		hiveShards: hive.NewShardHealth(log.WithField("component", "hive-shard-health"), env, m),
 	}
 	b.cond = sync.NewCond(&b.mu)
 	b.stopping.Store(false)
@@ -110,6 +119,8 @@ func (b *backend) Run(ctx context.Context, stop <-chan struct{}, done chan<- str
 		}()
 	}
 
+	go b.hiveShards.Run(ctx, stop)
+
 	for {
 		b.mu.Lock()
 		for atomic.LoadInt32(&b.workers) >= maxWorkers && !b.stopping.Load().(bool) {
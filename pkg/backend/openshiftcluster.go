@@ -30,13 +30,16 @@ import (
 type openShiftClusterBackend struct {
 	*backend
 
-	newManager func(context.Context, *logrus.Entry, env.Interface, database.OpenShiftClusters, database.Gateway, database.OpenShiftVersions, database.PlatformWorkloadIdentityRoleSets, encryption.AEAD, billing.Manager, *api.OpenShiftClusterDocument, *api.SubscriptionDocument, hive.ClusterManager, metrics.Emitter) (cluster.Interface, error)
+	newManager func(context.Context, *logrus.Entry, env.Interface, database.OpenShiftClusters, database.Gateway, database.OpenShiftVersions, database.PlatformWorkloadIdentityRoleSets, database.SupportedVMSizes, encryption.AEAD, billing.Manager, *api.OpenShiftClusterDocument, *api.SubscriptionDocument, hive.ClusterManager, bool, metrics.Emitter) (cluster.Interface, error)
+
+	now func() time.Time
 }
 
 func newOpenShiftClusterBackend(b *backend) *openShiftClusterBackend {
 	return &openShiftClusterBackend{
 		backend:    b,
 		newManager: cluster.New,
+		now:        time.Now,
 	}
 }
 
@@ -78,7 +81,14 @@ func (ocb *openShiftClusterBackend) try(ctx context.Context, monitorDeleteWaitTi
 			log.WithField("duration", time.Since(t).Seconds()).Print("done")
 		}()
 
-		err := ocb.handle(context.Background(), log, doc, monitorDeleteWaitTimeSec)
+		// outgoing requests carry the correlation ID of the ARM request which
+		// queued the operation
+		ctx := context.Background()
+		if doc.CorrelationData != nil {
+			ctx = api.CtxWithCorrelationData(ctx, doc.CorrelationData)
+		}
+
+		err := ocb.handle(ctx, log, doc, monitorDeleteWaitTimeSec)
 		if err != nil {
 			log.Error(err)
 		}
@@ -116,9 +126,15 @@ func (ocb *openShiftClusterBackend) handle(ctx context.Context, log *logrus.Entr
 		return err
 	}
 
+	hiveShard := hive.DefaultShard
 	var hr hive.ClusterManager
 	if installViaHive || adoptViaHive {
-		hiveShard := 1
+		doc, err = ocb.assignHiveShard(ctx, log, doc)
+		if err != nil {
+			return err
+		}
+
+		hiveShard = hive.Shard(doc)
 		hiveRestConfig, err := ocb.env.LiveConfig().HiveRestConfig(ctx, hiveShard)
 		if err != nil {
 			return fmt.Errorf("failed getting RESTConfig for Hive shard %d: %w", hiveShard, err)
@@ -129,7 +145,7 @@ func (ocb *openShiftClusterBackend) handle(ctx context.Context, log *logrus.Entr
 		}
 	}
 
-	m, err := ocb.newManager(ctx, log, ocb.env, ocb.dbOpenShiftClusters, ocb.dbGateway, ocb.dbOpenShiftVersions, ocb.dbPlatformWorkloadIdentityRoleSets, ocb.aead, ocb.billing, doc, subscriptionDoc, hr, ocb.m)
+	m, err := ocb.newManager(ctx, log, ocb.env, ocb.dbOpenShiftClusters, ocb.dbGateway, ocb.dbOpenShiftVersions, ocb.dbPlatformWorkloadIdentityRoleSets, ocb.dbSupportedVMSizes, ocb.aead, ocb.billing, doc, subscriptionDoc, hr, ocb.hiveShards.IsSchedulable(hiveShard), ocb.m)
 	if err != nil {
 		return ocb.endLease(ctx, log, stop, doc, api.ProvisioningStateFailed, api.ProvisioningStateFailed, err)
 	}
@@ -157,8 +173,10 @@ func (ocb *openShiftClusterBackend) handle(ctx context.Context, log *logrus.Entr
 
 	case api.ProvisioningStateAdminUpdating:
 		log.Printf("admin updating (type: %s)", doc.OpenShiftCluster.Properties.MaintenanceTask)
+		startedAt := ocb.now()
 
 		err = m.AdminUpdate(ctx)
+		ocb.recordMaintenanceHistory(ctx, log, doc, startedAt, err)
 		if err != nil {
 			// Customer will continue to see the cluster in an ongoing maintenance state
 			return ocb.endLease(ctx, log, stop, doc, api.ProvisioningStateAdminUpdating, api.ProvisioningStateFailed, err)
@@ -200,12 +218,41 @@ func (ocb *openShiftClusterBackend) handle(ctx context.Context, log *logrus.Entr
 		// and stop monitoring the cluster.
 		// TODO: Provide better communication between RP and Monitor
 		time.Sleep(time.Until(t.Add(time.Second * time.Duration(monitorDeleteWaitTimeSec))))
+
+		_, err = ocb.dbOpenShiftClusterTombstones.Create(ctx, doc)
+		if err != nil {
+			return err
+		}
+
 		return ocb.dbOpenShiftClusters.Delete(ctx, doc)
 	}
 
 	return fmt.Errorf("unexpected provisioningState %q", doc.OpenShiftCluster.Properties.ProvisioningState)
 }
 
+// assignHiveShard allocates a Hive shard to a cluster which is not yet
+// registered with Hive, and records it in the cluster document.  Clusters
+// keep their shard once they have a namespace in Hive.
+func (ocb *openShiftClusterBackend) assignHiveShard(ctx context.Context, log *logrus.Entry, doc *api.OpenShiftClusterDocument) (*api.OpenShiftClusterDocument, error) {
+	if doc.OpenShiftCluster.Properties.HiveProfile.Namespace != "" ||
+		doc.OpenShiftCluster.Properties.HiveProfile.Shard != 0 ||
+		doc.OpenShiftCluster.Properties.ProvisioningState == api.ProvisioningStateDeleting {
+		return doc, nil
+	}
+
+	shard, _, err := ocb.hiveShards.Allocate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("assigning hive shard %d", shard)
+
+	return ocb.dbOpenShiftClusters.PatchWithLease(ctx, doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.HiveProfile.Shard = shard
+		return nil
+	})
+}
+
 func (ocb *openShiftClusterBackend) heartbeat(ctx context.Context, cancel context.CancelFunc, log *logrus.Entry, doc *api.OpenShiftClusterDocument) func() {
 	var stopped bool
 	stop, done := make(chan struct{}), make(chan struct{})
@@ -368,6 +415,35 @@ func (ocb *openShiftClusterBackend) asyncOperationResultLog(log *logrus.Entry, i
 	log.Info("long running operation failed")
 }
 
+// recordMaintenanceHistory appends the outcome of an admin update to the
+// cluster's maintenance history.  Failing to record it is logged but does not
+// fail the admin update.
+func (ocb *openShiftClusterBackend) recordMaintenanceHistory(ctx context.Context, log *logrus.Entry, doc *api.OpenShiftClusterDocument, startedAt time.Time, adminUpdateErr error) {
+	task := doc.OpenShiftCluster.Properties.MaintenanceTask
+	if task == "" {
+		task = api.MaintenanceTaskEverything
+	}
+
+	entry := api.MaintenanceHistoryEntry{
+		Type:        string(task),
+		StartedAt:   startedAt,
+		CompletedAt: ocb.now(),
+		Outcome:     api.MaintenanceHistoryOutcomeSucceeded,
+	}
+	if adminUpdateErr != nil {
+		entry.Outcome = api.MaintenanceHistoryOutcomeFailed
+		entry.Error = adminUpdateErr.Error()
+	}
+
+	_, err := ocb.dbOpenShiftClusters.Patch(ctx, doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.AppendMaintenanceHistory(entry)
+		return nil
+	})
+	if err != nil {
+		log.Error(fmt.Errorf("failed recording maintenance history: %w", err))
+	}
+}
+
 func (ocb *openShiftClusterBackend) setNoMaintenanceState(ctx context.Context, doc *api.OpenShiftClusterDocument) (*api.OpenShiftClusterDocument, error) {
 	return ocb.dbOpenShiftClusters.Patch(ctx, doc.Key, func(doc *api.OpenShiftClusterDocument) error {
 		doc.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStateNone
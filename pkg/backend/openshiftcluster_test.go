@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
@@ -35,15 +36,17 @@ import (
 )
 
 type backendTestStruct struct {
-	name    string
-	mocks   func(*mock_cluster.MockInterface, database.OpenShiftClusters)
-	fixture func(*testdatabase.Fixture)
-	checker func(*testdatabase.Checker)
+	name           string
+	mocks          func(*mock_cluster.MockInterface, database.OpenShiftClusters)
+	fixture        func(*testdatabase.Fixture)
+	checker        func(*testdatabase.Checker)
+	wantTombstones int
 }
 
 func TestBackendTry(t *testing.T) {
 	mockSubID := "00000000-0000-0000-0000-000000000000"
 	resourceID := fmt.Sprintf("/subscriptions/%s/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName", mockSubID)
+	now := time.Unix(1700000000, 0).UTC()
 
 	for _, tt := range []backendTestStruct{
 		{
@@ -292,6 +295,14 @@ func TestBackendTry(t *testing.T) {
 						Properties: api.OpenShiftClusterProperties{
 							ProvisioningState: api.ProvisioningStateSucceeded,
 							MaintenanceState:  api.MaintenanceStateNone,
+							MaintenanceHistory: []api.MaintenanceHistoryEntry{
+								{
+									Type:        string(api.MaintenanceTaskEverything),
+									StartedAt:   now,
+									CompletedAt: now,
+									Outcome:     api.MaintenanceHistoryOutcomeSucceeded,
+								},
+							},
 							NetworkProfile: api.NetworkProfile{
 								PodCIDR:          "10.128.0.0/14",
 								ServiceCIDR:      "172.30.0.0/16",
@@ -358,6 +369,15 @@ func TestBackendTry(t *testing.T) {
 							FailedProvisioningState: api.ProvisioningStateUpdating,
 							LastAdminUpdateError:    "oh no!",
 							MaintenanceState:        api.MaintenanceStateUnplanned,
+							MaintenanceHistory: []api.MaintenanceHistoryEntry{
+								{
+									Type:        string(api.MaintenanceTaskEverything),
+									StartedAt:   now,
+									CompletedAt: now,
+									Outcome:     api.MaintenanceHistoryOutcomeFailed,
+									Error:       "oh no!",
+								},
+							},
 							NetworkProfile: api.NetworkProfile{
 								PodCIDR:          "10.128.0.0/14",
 								ServiceCIDR:      "172.30.0.0/16",
@@ -378,7 +398,7 @@ func TestBackendTry(t *testing.T) {
 			},
 		},
 		{
-			name: "StateDeleting success deletes the document",
+			name: "StateDeleting success deletes the document and leaves a tombstone",
 			fixture: func(f *testdatabase.Fixture) {
 				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
 					Key: strings.ToLower(resourceID),
@@ -411,6 +431,7 @@ func TestBackendTry(t *testing.T) {
 			mocks: func(manager *mock_cluster.MockInterface, dbOpenShiftClusters database.OpenShiftClusters) {
 				manager.EXPECT().Delete(gomock.Any()).Return(nil)
 			},
+			wantTombstones: 1,
 		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
@@ -426,6 +447,7 @@ func TestBackendTry(t *testing.T) {
 			_env.EXPECT().SubscriptionID().AnyTimes().Return(mockSubID)
 
 			dbOpenShiftClusters, clientOpenShiftClusters := testdatabase.NewFakeOpenShiftClusters()
+			dbOpenShiftClusterTombstones, clientOpenShiftClusterTombstones := testdatabase.NewFakeOpenShiftClusterTombstones()
 			dbSubscriptions, _ := testdatabase.NewFakeSubscriptions()
 			uuidGen := deterministicuuid.NewTestUUIDGenerator(deterministicuuid.OPENSHIFT_VERSIONS)
 			dbOpenShiftVersions, _ := testdatabase.NewFakeOpenShiftVersions(uuidGen)
@@ -439,11 +461,11 @@ func TestBackendTry(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			createManager := func(context.Context, *logrus.Entry, env.Interface, database.OpenShiftClusters, database.Gateway, database.OpenShiftVersions, database.PlatformWorkloadIdentityRoleSets, encryption.AEAD, billing.Manager, *api.OpenShiftClusterDocument, *api.SubscriptionDocument, hive.ClusterManager, metrics.Emitter) (cluster.Interface, error) {
+			createManager := func(context.Context, *logrus.Entry, env.Interface, database.OpenShiftClusters, database.Gateway, database.OpenShiftVersions, database.PlatformWorkloadIdentityRoleSets, database.SupportedVMSizes, encryption.AEAD, billing.Manager, *api.OpenShiftClusterDocument, *api.SubscriptionDocument, hive.ClusterManager, bool, metrics.Emitter) (cluster.Interface, error) {
 				return manager, nil
 			}
 
-			b, err := newBackend(log, _env, nil, nil, nil, dbOpenShiftClusters, dbSubscriptions, dbOpenShiftVersions, dbPlatformWorkloadIdentityRoleSets, nil, &noop.Noop{})
+			b, err := newBackend(log, _env, nil, nil, nil, dbOpenShiftClusters, dbOpenShiftClusterTombstones, dbSubscriptions, dbOpenShiftVersions, dbPlatformWorkloadIdentityRoleSets, nil, nil, &noop.Noop{})
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -451,6 +473,7 @@ func TestBackendTry(t *testing.T) {
 			b.ocb = &openShiftClusterBackend{
 				backend:    b,
 				newManager: createManager,
+				now:        func() time.Time { return now },
 			}
 
 			worked, err := b.ocb.try(ctx, 0)
@@ -471,6 +494,14 @@ func TestBackendTry(t *testing.T) {
 			for _, err := range errs {
 				t.Error(err)
 			}
+
+			tombstones, err := clientOpenShiftClusterTombstones.ListAll(ctx, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tombstones.OpenShiftClusterDocuments) != tt.wantTombstones {
+				t.Errorf("got %d tombstones, expected %d", len(tombstones.OpenShiftClusterDocuments), tt.wantTombstones)
+			}
 		})
 	}
 }
@@ -552,3 +583,86 @@ func TestAsyncOperationResultLog(t *testing.T) {
 		})
 	}
 }
+
+func TestAssignHiveShard(t *testing.T) {
+	ctx := context.Background()
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName"
+
+	for _, tt := range []struct {
+		name        string
+		state       api.ProvisioningState
+		hiveProfile api.HiveProfile
+		wantShard   int
+	}{
+		{
+			name:      "assigns a shard to a new cluster",
+			state:     api.ProvisioningStateCreating,
+			wantShard: 1,
+		},
+		{
+			name:        "keeps the shard of a registered cluster",
+			state:       api.ProvisioningStateUpdating,
+			hiveProfile: api.HiveProfile{Namespace: "aro-namespace"},
+		},
+		{
+			name:        "keeps an assigned shard",
+			state:       api.ProvisioningStateCreating,
+			hiveProfile: api.HiveProfile{Shard: 2},
+			wantShard:   2,
+		},
+		{
+			name:  "does not assign a shard to a deleting cluster",
+			state: api.ProvisioningStateDeleting,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			log := logrus.NewEntry(logrus.StandardLogger())
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			_env := mock_env.NewMockInterface(controller)
+			_env.EXPECT().LiveConfig().AnyTimes().Return(testliveconfig.NewTestLiveConfig(true, true))
+
+			dbOpenShiftClusters, _ := testdatabase.NewFakeOpenShiftClusters()
+			f := testdatabase.NewFixture().WithOpenShiftClusters(dbOpenShiftClusters)
+			f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(resourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: tt.state,
+						HiveProfile:       tt.hiveProfile,
+					},
+				},
+			})
+			err := f.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc, err := dbOpenShiftClusters.Dequeue(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ocb := &openShiftClusterBackend{
+				backend: &backend{
+					baseLog:             log,
+					env:                 _env,
+					dbOpenShiftClusters: dbOpenShiftClusters,
+					hiveShards:          hive.NewShardHealth(log, _env, &noop.Noop{}),
+				},
+			}
+
+			doc, err = ocb.assignHiveShard(ctx, log, doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if doc.OpenShiftCluster.Properties.HiveProfile.Shard != tt.wantShard {
+				t.Error(doc.OpenShiftCluster.Properties.HiveProfile.Shard)
+			}
+		})
+	}
+}
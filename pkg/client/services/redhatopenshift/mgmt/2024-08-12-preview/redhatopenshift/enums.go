@@ -159,6 +159,21 @@ func PossibleSkuTierValues() []SkuTier {
 	return []SkuTier{Basic, Free, Premium, Standard}
 }
 
+// UpgradePolicy enumerates the values for upgrade policy.
+type UpgradePolicy string
+
+const (
+	// AutomaticPatch ...
+	AutomaticPatch UpgradePolicy = "AutomaticPatch"
+	// Manual ...
+	Manual UpgradePolicy = "Manual"
+)
+
+// PossibleUpgradePolicyValues returns an array of possible values for the UpgradePolicy const type.
+func PossibleUpgradePolicyValues() []UpgradePolicy {
+	return []UpgradePolicy{AutomaticPatch, Manual}
+}
+
 // Visibility enumerates the values for visibility.
 type Visibility string
 
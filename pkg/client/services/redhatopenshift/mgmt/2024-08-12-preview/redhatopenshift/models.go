@@ -463,6 +463,17 @@ func (mpu *MachinePoolUpdate) UnmarshalJSON(body []byte) error {
 	return nil
 }
 
+// MaintenanceWindow maintenanceWindow represents a weekly window, in UTC, in which automatic upgrades may
+// start.
+type MaintenanceWindow struct {
+	// DayOfWeek - The day of the week on which the window starts, e.g. Saturday.
+	DayOfWeek *string `json:"dayOfWeek,omitempty"`
+	// StartHour - The hour, in UTC, at which the window starts.  Allowed values are in the range of 0 - 23.
+	StartHour *int32 `json:"startHour,omitempty"`
+	// DurationHours - The duration of the window in hours.  Allowed values are in the range of 1 - 24.
+	DurationHours *int32 `json:"durationHours,omitempty"`
+}
+
 // ManagedOutboundIPs managedOutboundIPs represents the desired managed outbound IPs for the cluster public
 // load balancer.
 type ManagedOutboundIPs struct {
@@ -846,6 +857,8 @@ type OpenShiftClusterProperties struct {
 	ApiserverProfile *APIServerProfile `json:"apiserverProfile,omitempty"`
 	// IngressProfiles - The cluster ingress profiles.
 	IngressProfiles *[]IngressProfile `json:"ingressProfiles,omitempty"`
+	// UpgradeProfile - The cluster upgrade profile.
+	UpgradeProfile *UpgradeProfile `json:"upgradeProfile,omitempty"`
 }
 
 // MarshalJSON is the custom marshaler for OpenShiftClusterProperties.
@@ -881,6 +894,9 @@ func (oscp OpenShiftClusterProperties) MarshalJSON() ([]byte, error) {
 	if oscp.IngressProfiles != nil {
 		objectMap["ingressProfiles"] = oscp.IngressProfiles
 	}
+	if oscp.UpgradeProfile != nil {
+		objectMap["upgradeProfile"] = oscp.UpgradeProfile
+	}
 	return json.Marshal(objectMap)
 }
 
@@ -1320,6 +1336,21 @@ func NewOpenShiftVersionListPage(cur OpenShiftVersionList, getNextPage func(cont
 type OpenShiftVersionProperties struct {
 	// Version - Version represents the version to create the cluster at.
 	Version *string `json:"version,omitempty"`
+	// GaDate - READ-ONLY; The date the version became generally available, in YYYY-MM-DD format.
+	GaDate *string `json:"gaDate,omitempty"`
+	// EndOfSupportDate - READ-ONLY; The date after which the version is no longer supported, in YYYY-MM-DD format.
+	EndOfSupportDate *string `json:"endOfSupportDate,omitempty"`
+	// EndOfLifeDate - READ-ONLY; The date after which the version no longer receives any fixes, in YYYY-MM-DD format.
+	EndOfLifeDate *string `json:"endOfLifeDate,omitempty"`
+}
+
+// MarshalJSON is the custom marshaler for OpenShiftVersionProperties.
+func (osvp OpenShiftVersionProperties) MarshalJSON() ([]byte, error) {
+	objectMap := make(map[string]interface{})
+	if osvp.Version != nil {
+		objectMap["version"] = osvp.Version
+	}
+	return json.Marshal(objectMap)
 }
 
 // Operation operation represents an RP operation.
@@ -2830,6 +2861,28 @@ func (tr TrackedResource) MarshalJSON() ([]byte, error) {
 	return json.Marshal(objectMap)
 }
 
+// UpgradeProfile upgradeProfile represents the upgrade policy of a cluster.
+type UpgradeProfile struct {
+	// Policy - The upgrade policy.  AutomaticPatch clusters are upgraded to the latest patch release of their minor version within their maintenance windows. Possible values include: 'AutomaticPatch', 'Manual'
+	Policy UpgradePolicy `json:"policy,omitempty"`
+	// MaintenanceWindows - The weekly windows in which automatic upgrades may start.
+	MaintenanceWindows *[]MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	// AutomaticUpgradesPausedReason - READ-ONLY; The reason automatic upgrades are paused, e.g. because an automatic upgrade failed.
+	AutomaticUpgradesPausedReason *string `json:"automaticUpgradesPausedReason,omitempty"`
+}
+
+// MarshalJSON is the custom marshaler for UpgradeProfile.
+func (up UpgradeProfile) MarshalJSON() ([]byte, error) {
+	objectMap := make(map[string]interface{})
+	if up.Policy != "" {
+		objectMap["policy"] = up.Policy
+	}
+	if up.MaintenanceWindows != nil {
+		objectMap["maintenanceWindows"] = up.MaintenanceWindows
+	}
+	return json.Marshal(objectMap)
+}
+
 // UserAssignedIdentity user assigned identity properties
 type UserAssignedIdentity struct {
 	// PrincipalID - READ-ONLY; The principal ID of the assigned identity.
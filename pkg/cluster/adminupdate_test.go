@@ -37,7 +37,6 @@ func TestAdminUpdateSteps(t *testing.T) {
 		"[Action ensureBillingRecord]",
 		"[Action ensureDefaults]",
 		"[Action fixupClusterSPObjectID]",
-		"[Action fixInfraID]",
 	}
 
 	generalFixesSteps := []string{
@@ -47,6 +46,7 @@ func TestAdminUpdateSteps(t *testing.T) {
 		"[Action populateRegistryStorageAccountName]",
 		"[Action migrateStorageAccounts]",
 		"[Action fixSSH]",
+		"[Action enableBootDiagnostics]",
 		"[Action startVMs]",
 		"[Condition apiServersReady, timeout 30m0s]",
 		"[Action fixSREKubeconfig]",
@@ -208,6 +208,16 @@ func TestAdminUpdateSteps(t *testing.T) {
 			},
 			shouldRunSteps: utilgenerics.ConcatMultipleSlices(zerothSteps, syncClusterObjectSteps),
 		},
+		{
+			name: "Rotate persisted graph encryption key",
+			fixture: func() (*api.OpenShiftClusterDocument, bool) {
+				doc := baseClusterDoc()
+				doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateAdminUpdating
+				doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskRotateGraphKey
+				return doc, true
+			},
+			shouldRunSteps: utilgenerics.ConcatMultipleSlices(zerothSteps, []string{"[Action rotateGraphEncryption]"}),
+		},
 		{
 			name: "adminUpdate() does not adopt Hive-created clusters",
 			fixture: func() (*api.OpenShiftClusterDocument, bool) {
@@ -33,6 +33,10 @@ func (m *manager) ensureAROOperator(ctx context.Context) error {
 		return nil
 	}
 
+	if m.shouldManageOperatorViaHive() {
+		return m.hiveEnsureAROOperatorSyncSet(ctx)
+	}
+
 	err := m.aroOperatorDeployer.Update(ctx)
 	if err != nil {
 		m.log.Error(fmt.Errorf("cannot ensureAROOperator.Update: %w", err))
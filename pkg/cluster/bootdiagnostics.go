@@ -0,0 +1,51 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+// enableBootDiagnostics turns on boot diagnostics for every VM in the cluster
+// resource group. No storage URI is set, so Azure uses managed storage and
+// the serial log and console screenshot are retrievable via the admin API.
+func (m *manager) enableBootDiagnostics(ctx context.Context) error {
+	resourceGroupName := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+	vms, err := m.virtualMachines.List(ctx, resourceGroupName)
+	if err != nil {
+		return err
+	}
+
+	for _, vm := range vms {
+		if vm.VirtualMachineProperties == nil || bootDiagnosticsEnabled(&vm) {
+			continue
+		}
+
+		m.log.Printf("enabling boot diagnostics on %s", *vm.Name)
+		vm.DiagnosticsProfile = &mgmtcompute.DiagnosticsProfile{
+			BootDiagnostics: &mgmtcompute.BootDiagnostics{
+				Enabled: to.BoolPtr(true),
+			},
+		}
+
+		err = m.virtualMachines.CreateOrUpdateAndWait(ctx, resourceGroupName, *vm.Name, vm)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func bootDiagnosticsEnabled(vm *mgmtcompute.VirtualMachine) bool {
+	dp := vm.DiagnosticsProfile
+	return dp != nil && dp.BootDiagnostics != nil &&
+		dp.BootDiagnostics.Enabled != nil && *dp.BootDiagnostics.Enabled &&
+		dp.BootDiagnostics.StorageURI == nil
+}
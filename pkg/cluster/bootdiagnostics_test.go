@@ -0,0 +1,131 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_compute "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/compute"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestEnableBootDiagnostics(t *testing.T) {
+	ctx := context.Background()
+	clusterRGName := "test-cluster"
+
+	managed := &mgmtcompute.DiagnosticsProfile{
+		BootDiagnostics: &mgmtcompute.BootDiagnostics{
+			Enabled: to.BoolPtr(true),
+		},
+	}
+
+	for _, tt := range []struct {
+		name    string
+		mock    func(vmClient *mock_compute.MockVirtualMachinesClient)
+		wantErr string
+	}{
+		{
+			name: "enable managed boot diagnostics where missing",
+			mock: func(vmClient *mock_compute.MockVirtualMachinesClient) {
+				vms := []mgmtcompute.VirtualMachine{
+					{
+						Name: to.StringPtr("enabled-vm"),
+						VirtualMachineProperties: &mgmtcompute.VirtualMachineProperties{
+							DiagnosticsProfile: managed,
+						},
+					},
+					{
+						Name:                     to.StringPtr("disabled-vm"),
+						VirtualMachineProperties: &mgmtcompute.VirtualMachineProperties{},
+					},
+					{
+						Name: to.StringPtr("storage-account-vm"),
+						VirtualMachineProperties: &mgmtcompute.VirtualMachineProperties{
+							DiagnosticsProfile: &mgmtcompute.DiagnosticsProfile{
+								BootDiagnostics: &mgmtcompute.BootDiagnostics{
+									Enabled:    to.BoolPtr(true),
+									StorageURI: to.StringPtr("https://cluster.blob.core.windows.net/"),
+								},
+							},
+						},
+					},
+					{
+						Name: to.StringPtr("nil-virtualmachineproperties"),
+					},
+				}
+
+				vmClient.EXPECT().List(gomock.Any(), clusterRGName).Return(vms, nil)
+				for _, name := range []string{"disabled-vm", "storage-account-vm"} {
+					vmClient.EXPECT().
+						CreateOrUpdateAndWait(gomock.Any(), clusterRGName, name, mgmtcompute.VirtualMachine{
+							Name: to.StringPtr(name),
+							VirtualMachineProperties: &mgmtcompute.VirtualMachineProperties{
+								DiagnosticsProfile: managed,
+							},
+						}).
+						Return(nil)
+				}
+			},
+		},
+		{
+			name: "failed to list VMs",
+			mock: func(vmClient *mock_compute.MockVirtualMachinesClient) {
+				vmClient.EXPECT().List(gomock.Any(), clusterRGName).Return(nil, errors.New("random error"))
+			},
+			wantErr: "random error",
+		},
+		{
+			name: "failed to update VM",
+			mock: func(vmClient *mock_compute.MockVirtualMachinesClient) {
+				vms := []mgmtcompute.VirtualMachine{
+					{
+						Name:                     to.StringPtr("vm1"),
+						VirtualMachineProperties: &mgmtcompute.VirtualMachineProperties{},
+					},
+				}
+
+				vmClient.EXPECT().List(gomock.Any(), clusterRGName).Return(vms, nil)
+				vmClient.EXPECT().
+					CreateOrUpdateAndWait(gomock.Any(), clusterRGName, "vm1", gomock.Any()).
+					Return(errors.New("random error"))
+			},
+			wantErr: "random error",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			vmClient := mock_compute.NewMockVirtualMachinesClient(controller)
+
+			tt.mock(vmClient)
+
+			m := &manager{
+				log:             logrus.NewEntry(logrus.StandardLogger()),
+				virtualMachines: vmClient,
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							ClusterProfile: api.ClusterProfile{
+								ResourceGroupID: fmt.Sprintf("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/%s", clusterRGName),
+							},
+						},
+					},
+				},
+			}
+
+			err := m.enableBootDiagnostics(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
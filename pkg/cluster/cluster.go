@@ -69,6 +69,7 @@ type manager struct {
 	db                  database.OpenShiftClusters
 	dbGateway           database.Gateway
 	dbOpenShiftVersions database.OpenShiftVersions
+	dbSupportedVMSizes  database.SupportedVMSizes
 
 	billing           billing.Manager
 	doc               *api.OpenShiftClusterDocument
@@ -123,6 +124,8 @@ type manager struct {
 
 	installViaHive       bool
 	adoptViaHive         bool
+	deprovisionViaHive   bool
+	operatorViaHive      bool
 	hiveClusterManager   hive.ClusterManager
 	fpServicePrincipalID string
 
@@ -140,8 +143,8 @@ type manager struct {
 }
 
 // New returns a cluster manager
-func New(ctx context.Context, log *logrus.Entry, _env env.Interface, db database.OpenShiftClusters, dbGateway database.Gateway, dbOpenShiftVersions database.OpenShiftVersions, dbPlatformWorkloadIdentityRoleSets database.PlatformWorkloadIdentityRoleSets, aead encryption.AEAD,
-	billing billing.Manager, doc *api.OpenShiftClusterDocument, subscriptionDoc *api.SubscriptionDocument, hiveClusterManager hive.ClusterManager, metricsEmitter metrics.Emitter,
+func New(ctx context.Context, log *logrus.Entry, _env env.Interface, db database.OpenShiftClusters, dbGateway database.Gateway, dbOpenShiftVersions database.OpenShiftVersions, dbPlatformWorkloadIdentityRoleSets database.PlatformWorkloadIdentityRoleSets, dbSupportedVMSizes database.SupportedVMSizes, aead encryption.AEAD,
+	billing billing.Manager, doc *api.OpenShiftClusterDocument, subscriptionDoc *api.SubscriptionDocument, hiveClusterManager hive.ClusterManager, hiveShardSchedulable bool, metricsEmitter metrics.Emitter,
 ) (Interface, error) {
 	r, err := azure.ParseResourceID(doc.OpenShiftCluster.ID)
 	if err != nil {
@@ -188,12 +191,23 @@ func New(ctx context.Context, log *logrus.Entry, _env env.Interface, db database
 	if err != nil {
 		return nil, err
 	}
+	installViaHive = shouldInstallViaHive(doc, installViaHive, hiveShardSchedulable)
 
 	adoptByHive, err := _env.LiveConfig().AdoptByHive(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	deprovisionViaHive, err := _env.LiveConfig().DeprovisionViaHive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	operatorViaHive, err := _env.LiveConfig().OperatorViaHive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	clientOptions := _env.Environment().ArmClientOptions()
 
 	armInterfacesClient, err := armnetwork.NewInterfacesClient(r.SubscriptionID, fpCredClusterTenant, clientOptions)
@@ -259,6 +273,7 @@ func New(ctx context.Context, log *logrus.Entry, _env env.Interface, db database
 		db:                       db,
 		dbGateway:                dbGateway,
 		dbOpenShiftVersions:      dbOpenShiftVersions,
+		dbSupportedVMSizes:       dbSupportedVMSizes,
 		billing:                  billing,
 		doc:                      doc,
 		subscriptionDoc:          subscriptionDoc,
@@ -295,6 +310,8 @@ func New(ctx context.Context, log *logrus.Entry, _env env.Interface, db database
 		rpBlob:                                 rpBlob,
 		installViaHive:                         installViaHive,
 		adoptViaHive:                           adoptByHive,
+		deprovisionViaHive:                     deprovisionViaHive,
+		operatorViaHive:                        operatorViaHive,
 		hiveClusterManager:                     hiveClusterManager,
 		now:                                    func() time.Time { return time.Now() },
 		openShiftClusterDocumentVersioner:      new(openShiftClusterDocumentVersionerService),
@@ -357,3 +374,20 @@ func (m *manager) APICertName() string {
 func (m *manager) IngressCertName() string {
 	return m.doc.ID + "-ingress"
 }
+
+// shouldInstallViaHive returns whether the cluster is installed via Hive.  An
+// install must finish with the installer it started with, so installs which
+// have not started via Hive use the RP's own installer if the Hive shard is
+// unschedulable when they start.
+func shouldInstallViaHive(doc *api.OpenShiftClusterDocument, installViaHive, hiveShardSchedulable bool) bool {
+	if !installViaHive ||
+		doc.OpenShiftCluster.Properties.ProvisioningState != api.ProvisioningStateCreating ||
+		doc.OpenShiftCluster.Properties.HiveProfile.CreatedByHive {
+		return installViaHive
+	}
+
+	installStarted := doc.OpenShiftCluster.Properties.Install != nil &&
+		doc.OpenShiftCluster.Properties.Install.Phase != api.InstallPhaseBootstrap
+
+	return !installStarted && hiveShardSchedulable
+}
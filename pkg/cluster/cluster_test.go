@@ -64,3 +64,72 @@ func TestIngressCertName(t *testing.T) {
 		})
 	}
 }
+
+func TestShouldInstallViaHive(t *testing.T) {
+	for _, tt := range []struct {
+		name                 string
+		provisioningState    api.ProvisioningState
+		install              *api.Install
+		createdByHive        bool
+		installViaHive       bool
+		hiveShardSchedulable bool
+		want                 bool
+	}{
+		{
+			name:                 "install via hive disabled",
+			provisioningState:    api.ProvisioningStateCreating,
+			hiveShardSchedulable: true,
+		},
+		{
+			name:                 "new install, shard schedulable",
+			provisioningState:    api.ProvisioningStateCreating,
+			installViaHive:       true,
+			hiveShardSchedulable: true,
+			want:                 true,
+		},
+		{
+			name:              "new install, shard unschedulable",
+			provisioningState: api.ProvisioningStateCreating,
+			installViaHive:    true,
+		},
+		{
+			name:              "install started via hive, shard unschedulable",
+			provisioningState: api.ProvisioningStateCreating,
+			install:           &api.Install{Phase: api.InstallPhaseRemoveBootstrap},
+			createdByHive:     true,
+			installViaHive:    true,
+			want:              true,
+		},
+		{
+			name:                 "install started by the RP, shard schedulable",
+			provisioningState:    api.ProvisioningStateCreating,
+			install:              &api.Install{Phase: api.InstallPhaseRemoveBootstrap},
+			installViaHive:       true,
+			hiveShardSchedulable: true,
+		},
+		{
+			name:              "not installing",
+			provisioningState: api.ProvisioningStateDeleting,
+			installViaHive:    true,
+			want:              true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &api.OpenShiftClusterDocument{
+				OpenShiftCluster: &api.OpenShiftCluster{
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: tt.provisioningState,
+						Install:           tt.install,
+						HiveProfile: api.HiveProfile{
+							CreatedByHive: tt.createdByHive,
+						},
+					},
+				},
+			}
+
+			if got := shouldInstallViaHive(doc, tt.installViaHive, tt.hiveShardSchedulable); got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
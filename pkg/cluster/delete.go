@@ -524,6 +524,15 @@ func (m *manager) Delete(ctx context.Context) error {
 		}
 	}
 
+	// Hive deprovisions the cluster with the cluster service principal, so
+	// this must happen before its role assignments are deleted
+	if m.shouldDeprovisionViaHive() {
+		err = m.hiveDeprovisionResources(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	m.log.Printf("deleting role assignments")
 	err = m.deleteRoleAssignments(ctx)
 	if err != nil {
@@ -546,6 +555,8 @@ func (m *manager) Delete(ctx context.Context) error {
 		return err
 	}
 
+	// when Hive has deprovisioned the cluster, this only cleans up anything
+	// left behind
 	err = m.deleteResourcesAndResourceGroup(ctx)
 	if err != nil {
 		return err
@@ -120,6 +120,13 @@ func (m *manager) ensureResourceGroup(ctx context.Context) (err error) {
 		},
 	}
 
+	// After the cluster has been moved to another resource group, the cluster
+	// resource group is still managed by its previous resource ID; adopt it
+	if group.ManagedBy != nil && m.doc.OpenShiftCluster.Properties.MovedFromResourceID != "" &&
+		strings.EqualFold(*group.ManagedBy, m.doc.OpenShiftCluster.Properties.MovedFromResourceID) {
+		group.ManagedBy = &m.doc.OpenShiftCluster.ID
+	}
+
 	// If managedBy or location don't match, return an error that RG must not already exist
 	if group.Location == nil || !strings.EqualFold(*group.Location, m.doc.OpenShiftCluster.Location) {
 		return resourceGroupAlreadyExistsError
@@ -271,7 +278,7 @@ func (m *manager) newPublicLoadBalancer(ctx context.Context, resources *[]*arm.R
 			outboundIPs = append(outboundIPs, api.ResourceReference{ID: m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID + "/providers/Microsoft.Network/publicIPAddresses/" + ipName})
 		}
 	}
-	m.patchEffectiveOutboundIPs(ctx, outboundIPs)
+	m.patchEffectiveOutboundIPs(ctx, outboundIPs, nil)
 
 	*resources = append(*resources,
 		m.networkPublicLoadBalancer(azureRegion, outboundIPs),
@@ -477,6 +484,7 @@ func (m *manager) federateIdentityCredentials(ctx context.Context) error {
 
 	platformWIRolesByRoleName := m.platformWorkloadIdentityRolesByVersion.GetPlatformWorkloadIdentityRolesByRoleName()
 	platformWorkloadIdentities := m.doc.OpenShiftCluster.Properties.PlatformWorkloadIdentityProfile.PlatformWorkloadIdentities
+	federatedIdentityCredentials := make(map[string][]api.FederatedIdentityCredential, len(platformWorkloadIdentities))
 
 	for name, identity := range platformWorkloadIdentities {
 		identityResourceId, err := azure.ParseResourceID(identity.ResourceID)
@@ -513,9 +521,26 @@ func (m *manager) federateIdentityCredentials(ctx context.Context) error {
 			if err != nil {
 				return err
 			}
+
+			federatedIdentityCredentials[name] = append(federatedIdentityCredentials[name], api.FederatedIdentityCredential{
+				Name:      federatedIdentityCredentialResourceName,
+				Issuer:    *issuer,
+				Subject:   sa,
+				Audiences: []string{"openshift"},
+			})
 		}
 	}
-	return nil
+
+	var err error
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		for name, identity := range doc.OpenShiftCluster.Properties.PlatformWorkloadIdentityProfile.PlatformWorkloadIdentities {
+			identity.FederatedIdentityCredentials = federatedIdentityCredentials[name]
+			doc.OpenShiftCluster.Properties.PlatformWorkloadIdentityProfile.PlatformWorkloadIdentities[name] = identity
+		}
+		return nil
+	})
+
+	return err
 }
 
 // generateInfraID take base and returns a ID that
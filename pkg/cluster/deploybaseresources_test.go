@@ -74,10 +74,11 @@ func TestEnsureResourceGroup(t *testing.T) {
 	}, "", "", &http.Response{StatusCode: http.StatusNotFound}, "")
 
 	for _, tt := range []struct {
-		name              string
-		provisioningState api.ProvisioningState
-		mocks             func(*mock_features.MockResourceGroupsClient, *mock_env.MockInterface)
-		wantErr           string
+		name                string
+		provisioningState   api.ProvisioningState
+		movedFromResourceID string
+		mocks               func(*mock_features.MockResourceGroupsClient, *mock_env.MockInterface)
+		wantErr             string
 	}{
 		{
 			name:              "success - rg doesn't exist",
@@ -146,6 +147,30 @@ func TestEnsureResourceGroup(t *testing.T) {
 					Return(nil)
 			},
 		},
+		{
+			name:                "success - rg managed by the cluster's previous resource ID is adopted after a move",
+			provisioningState:   api.ProvisioningStateAdminUpdating,
+			movedFromResourceID: "previous-test-cluster",
+			mocks: func(rg *mock_features.MockResourceGroupsClient, env *mock_env.MockInterface) {
+				movedGroup := group
+				movedGroup.ManagedBy = to.StringPtr("previous-test-cluster")
+				rg.EXPECT().
+					Get(gomock.Any(), resourceGroupName).
+					Return(movedGroup, nil)
+
+				rg.EXPECT().
+					CreateOrUpdate(gomock.Any(), resourceGroupName, group).
+					Return(group, nil)
+
+				env.EXPECT().
+					IsLocalDevelopmentMode().
+					Return(false)
+
+				env.EXPECT().
+					EnsureARMResourceGroupRoleAssignment(gomock.Any(), resourceGroupName).
+					Return(nil)
+			},
+		},
 		{
 			name:              "fail - get rg returns generic error",
 			provisioningState: api.ProvisioningStateAdminUpdating,
@@ -236,7 +261,8 @@ func TestEnsureResourceGroup(t *testing.T) {
 							ClusterProfile: api.ClusterProfile{
 								ResourceGroupID: resourceGroup,
 							},
-							ProvisioningState: tt.provisioningState,
+							ProvisioningState:   tt.provisioningState,
+							MovedFromResourceID: tt.movedFromResourceID,
 						},
 						Location: location,
 						ID:       clusterID,
@@ -1686,11 +1712,18 @@ func TestGenerateFederatedIdentityCredentials(t *testing.T) {
 	OIDCIssuer := pointerutils.ToPtr(api.OIDCIssuer(fmt.Sprintf("https://%s/%s", afdEndpoint, oidcbuilder.GetBlobName(tenantId, docID))))
 	fakeClint, _ := utilmsi.NewTestFederatedIdentityCredentialsClient(subID)
 
+	federatedCredName := func(identityName, serviceAccount string) string {
+		clusterResource, _ := azure.ParseResourceID(clusterResourceID)
+		identityResource, _ := azure.ParseResourceID(fmt.Sprintf("%s/%s", resourceID, identityName))
+		return platformworkloadidentity.GetPlatformWorkloadIdentityFederatedCredName(clusterResource, identityResource, serviceAccount)
+	}
+
 	for _, tt := range []struct {
-		name    string
-		oc      *api.OpenShiftClusterDocument
-		fixture func(f *testdatabase.Fixture)
-		wantErr string
+		name                             string
+		oc                               *api.OpenShiftClusterDocument
+		fixture                          func(f *testdatabase.Fixture)
+		wantFederatedIdentityCredentials map[string][]api.FederatedIdentityCredential
+		wantErr                          string
 	}{
 		{
 			name: "Success - Exit generateFederatedIdentityCredentials for non MIWI clusters that has ServicePrincipalProfile",
@@ -1785,6 +1818,24 @@ func TestGenerateFederatedIdentityCredentials(t *testing.T) {
 					},
 				)
 			},
+			wantFederatedIdentityCredentials: map[string][]api.FederatedIdentityCredential{
+				"CloudControllerManager": {
+					{
+						Name:      federatedCredName("ccm", "openshift-cloud-controller-manager:cloud-controller-manager"),
+						Issuer:    string(*OIDCIssuer),
+						Subject:   "openshift-cloud-controller-manager:cloud-controller-manager",
+						Audiences: []string{"openshift"},
+					},
+				},
+				"ClusterIngressOperator": {
+					{
+						Name:      federatedCredName("cio", "openshift-ingress-operator:ingress-operator"),
+						Issuer:    string(*OIDCIssuer),
+						Subject:   "openshift-ingress-operator:ingress-operator",
+						Audiences: []string{"openshift"},
+					},
+				},
+			},
 			wantErr: "",
 		},
 		{
@@ -1879,15 +1930,33 @@ func TestGenerateFederatedIdentityCredentials(t *testing.T) {
 		}
 
 		t.Run(tt.name, func(t *testing.T) {
+			tt.oc.Key = strings.ToLower(clusterResourceID)
+
+			openShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(openShiftClustersDatabase)
+			fixture.AddOpenShiftClusterDocuments(tt.oc)
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
 			m := &manager{
 				log:                                    logrus.NewEntry(logrus.StandardLogger()),
 				doc:                                    tt.oc,
+				db:                                     openShiftClustersDatabase,
 				platformWorkloadIdentityRolesByVersion: pir,
 				clusterMsiFederatedIdentityCredentials: fakeClint,
 			}
 
-			err := m.federateIdentityCredentials(ctx)
+			err = m.federateIdentityCredentials(ctx)
 			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			for name, want := range tt.wantFederatedIdentityCredentials {
+				got := m.doc.OpenShiftCluster.Properties.PlatformWorkloadIdentityProfile.PlatformWorkloadIdentities[name].FederatedIdentityCredentials
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("%s: got federated identity credentials %#v, want %#v", name, got, want)
+				}
+			}
 		})
 	}
 }
@@ -1,25 +0,0 @@
-package cluster
-
-// Copyright (c) Microsoft Corporation.
-// Licensed under the Apache License 2.0.
-
-import (
-	"context"
-
-	"github.com/Azure/ARO-RP/pkg/api"
-)
-
-func (m *manager) fixInfraID(ctx context.Context) error {
-	infraID := m.doc.OpenShiftCluster.Properties.InfraID
-	if infraID != "" {
-		return nil
-	}
-	infraID = "aro"
-
-	var err error
-	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
-		doc.OpenShiftCluster.Properties.InfraID = infraID
-		return nil
-	})
-	return err
-}
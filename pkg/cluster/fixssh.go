@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/to"
 
 	"github.com/Azure/ARO-RP/pkg/api"
@@ -66,42 +67,12 @@ func (m *manager) checkAndUpdateLB(ctx context.Context, resourceGroup string, lb
 
 func (m *manager) checkandUpdateNIC(ctx context.Context, resourceGroup string, infraID string, lb mgmtnetwork.LoadBalancer) (err error) {
 	for i := 0; i < 3; i++ {
-		// NIC names might be different if customer re-created master nodes
-		// see https://bugzilla.redhat.com/show_bug.cgi?id=1882490 for more details
-		// installer naming  - <foo>-master{0,1,2}-nic
-		// machineAPI naming - <foo>-master-{0,1,2}-nic
-		nicNameInstaller := fmt.Sprintf("%s-master%d-nic", infraID, i)
-		nicNameMachineAPI := fmt.Sprintf("%s-master-%d-nic", infraID, i)
-
-		var nic mgmtnetwork.Interface
-		nicName := nicNameInstaller
-		fallbackNIC := false
-
-		nic, err = m.interfaces.Get(ctx, resourceGroup, nicName, "")
+		nic, nicResourceGroup, nicName, err := m.getMasterNIC(ctx, resourceGroup, infraID, i)
 		if err != nil {
-			m.log.Warnf("Fetching details for NIC %s has failed with err %s", nicName, err)
-			fallbackNIC = true
-		} else if nic.InterfacePropertiesFormat != nil && nic.InterfacePropertiesFormat.VirtualMachine == nil {
-			err = m.removeBackendPoolsFromNIC(ctx, resourceGroup, nicName, &nic)
-			if err != nil {
-				m.log.Warnf("Removing BackendPools from NIC %s has failed with err %s", nicName, err)
-				return err
-			}
-			m.log.Warnf("Installer provisioned NIC %s has no VM attached", nicName)
-			fallbackNIC = true
-		}
-
-		if fallbackNIC {
-			nicName = nicNameMachineAPI
-			m.log.Warnf("Fallback to check MachineAPI Nic name format for %s", nicName)
-			nic, err = m.interfaces.Get(ctx, resourceGroup, nicName, "")
-			if err != nil {
-				m.log.Warnf("Fallback failed with err %s", err)
-				return err
-			}
+			return err
 		}
 
-		err = m.updateILBAddressPool(ctx, &nic, nicName, &lb, i, resourceGroup, infraID)
+		err = m.updateILBAddressPool(ctx, &nic, nicName, &lb, i, nicResourceGroup, infraID)
 		if err != nil {
 			return err
 		}
@@ -124,7 +95,7 @@ func (m *manager) checkandUpdateNIC(ctx context.Context, resourceGroup string, i
 			return err
 		}
 
-		err = m.updateELBAddressPool(ctx, &nic, nicName, &elb, resourceGroup, infraID)
+		err = m.updateELBAddressPool(ctx, &nic, nicName, &elb, nicResourceGroup, infraID)
 		if err != nil {
 			return err
 		}
@@ -133,6 +104,60 @@ func (m *manager) checkandUpdateNIC(ctx context.Context, resourceGroup string, i
 	return nil
 }
 
+// getMasterNIC returns the NIC of master i together with its resource group
+// and name.  Pre-created NICs specified in the master profile are used as-is;
+// otherwise the NIC is looked up in the cluster resource group by name.
+func (m *manager) getMasterNIC(ctx context.Context, resourceGroup string, infraID string, i int) (nic mgmtnetwork.Interface, nicResourceGroup string, nicName string, err error) {
+	if nicIDs := m.doc.OpenShiftCluster.Properties.MasterProfile.NetworkInterfaceIDs; len(nicIDs) > i {
+		r, err := azure.ParseResourceID(nicIDs[i])
+		if err != nil {
+			return nic, "", "", err
+		}
+
+		nic, err = m.interfaces.Get(ctx, r.ResourceGroup, r.ResourceName, "")
+		if err != nil {
+			m.log.Warnf("Fetching details for NIC %s has failed with err %s", nicIDs[i], err)
+		}
+		return nic, r.ResourceGroup, r.ResourceName, err
+	}
+
+	// NIC names might be different if customer re-created master nodes
+	// see https://bugzilla.redhat.com/show_bug.cgi?id=1882490 for more details
+	// installer naming  - <foo>-master{0,1,2}-nic
+	// machineAPI naming - <foo>-master-{0,1,2}-nic
+	nicNameInstaller := fmt.Sprintf("%s-master%d-nic", infraID, i)
+	nicNameMachineAPI := fmt.Sprintf("%s-master-%d-nic", infraID, i)
+
+	nicName = nicNameInstaller
+	fallbackNIC := false
+
+	nic, err = m.interfaces.Get(ctx, resourceGroup, nicName, "")
+	if err != nil {
+		m.log.Warnf("Fetching details for NIC %s has failed with err %s", nicName, err)
+		fallbackNIC = true
+	} else if nic.InterfacePropertiesFormat != nil && nic.InterfacePropertiesFormat.VirtualMachine == nil {
+		err = m.removeBackendPoolsFromNIC(ctx, resourceGroup, nicName, &nic)
+		if err != nil {
+			m.log.Warnf("Removing BackendPools from NIC %s has failed with err %s", nicName, err)
+			return nic, "", "", err
+		}
+		m.log.Warnf("Installer provisioned NIC %s has no VM attached", nicName)
+		fallbackNIC = true
+	}
+
+	if fallbackNIC {
+		nicName = nicNameMachineAPI
+		m.log.Warnf("Fallback to check MachineAPI Nic name format for %s", nicName)
+		nic, err = m.interfaces.Get(ctx, resourceGroup, nicName, "")
+		if err != nil {
+			m.log.Warnf("Fallback failed with err %s", err)
+			return nic, "", "", err
+		}
+	}
+
+	return nic, resourceGroup, nicName, nil
+}
+
 func (m *manager) removeBackendPoolsFromNIC(ctx context.Context, resourceGroup, nicName string, nic *mgmtnetwork.Interface) error {
 	if nic.InterfacePropertiesFormat.IPConfigurations == nil || len(*nic.InterfacePropertiesFormat.IPConfigurations) == 0 {
 		return fmt.Errorf("unable to remove Backend Address Pools from NIC as there are no IP configurations for %s in resource group %s", nicName, resourceGroup)
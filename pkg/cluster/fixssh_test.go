@@ -429,3 +429,47 @@ func TestFixSSH(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMasterNICPreCreated(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nicID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/customer-rg/providers/Microsoft.Network/networkInterfaces/custom-nic-1"
+	want := mgmtnetwork.Interface{ID: to.StringPtr(nicID)}
+
+	interfaces := mock_network.NewMockInterfacesClient(ctrl)
+	interfaces.EXPECT().Get(gomock.Any(), "customer-rg", "custom-nic-1", "").Return(want, nil)
+
+	m := &manager{
+		log: logrus.NewEntry(logrus.StandardLogger()),
+		doc: &api.OpenShiftClusterDocument{
+			OpenShiftCluster: &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					MasterProfile: api.MasterProfile{
+						NetworkInterfaceIDs: []string{
+							"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/customer-rg/providers/Microsoft.Network/networkInterfaces/custom-nic-0",
+							nicID,
+							"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/customer-rg/providers/Microsoft.Network/networkInterfaces/custom-nic-2",
+						},
+					},
+				},
+			},
+		},
+		interfaces: interfaces,
+	}
+
+	nic, nicResourceGroup, nicName, err := m.getMasterNIC(ctx, resourceGroup, infraID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *nic.ID != nicID {
+		t.Errorf("got NIC %s, wanted %s", *nic.ID, nicID)
+	}
+	if nicResourceGroup != "customer-rg" {
+		t.Errorf("got resource group %s, wanted customer-rg", nicResourceGroup)
+	}
+	if nicName != "custom-nic-1" {
+		t.Errorf("got NIC name %s, wanted custom-nic-1", nicName)
+	}
+}
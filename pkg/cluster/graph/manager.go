@@ -5,8 +5,10 @@ package graph
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"io"
+	"path"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
@@ -26,6 +28,7 @@ const (
 type Manager interface {
 	Exists(ctx context.Context, resourceGroup, account string) (bool, error)
 	LoadPersisted(ctx context.Context, resourceGroup, account string) (PersistedGraph, error)
+	RotateEncryption(ctx context.Context, resourceGroup, account, keyVersion string) (string, error)
 }
 
 type manager struct {
@@ -108,5 +111,72 @@ func (m *manager) loadPersisted(ctx context.Context, resourceGroup, account stri
 	return pg, nil
 }
 
+// RotateEncryption re-encrypts the persisted graph with the current version of
+// the cluster graph key and returns that version.  keyVersion is the version
+// the graph is known to be encrypted with; if it is already current, the graph
+// is left untouched.
+func (m *manager) RotateEncryption(ctx context.Context, resourceGroup, account, keyVersion string) (string, error) {
+	bundle, err := m.env.ServiceKeyvault().GetSecret(ctx, env.EncryptionSecretV2Name)
+	if err != nil {
+		return "", err
+	}
+
+	currentVersion := path.Base(*bundle.ID)
+	if currentVersion == keyVersion {
+		m.log.Printf("graph is already encrypted with key version %s", currentVersion)
+		return currentVersion, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(*bundle.Value)
+	if err != nil {
+		return "", err
+	}
+
+	sealer, err := encryption.NewAES256SHA512(ctx, key)
+	if err != nil {
+		return "", err
+	}
+
+	blobService, err := m.storage.BlobService(ctx, resourceGroup, account, armstorage.Permissions("rw"), armstorage.SignedResourceTypesO)
+	if err != nil {
+		return "", err
+	}
+
+	rc, err := blobService.DownloadStream(ctx, GraphContainer, GraphBlob, nil)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Body.Close()
+
+	b, err := io.ReadAll(rc.Body)
+	if err != nil {
+		return "", err
+	}
+
+	// the key version which the graph is sealed with may be newer than our
+	// AEAD's keys
+	if err = m.reloadAead(ctx); err != nil {
+		return "", err
+	}
+
+	b, err = m.aead.Open(b)
+	if err != nil {
+		return "", err
+	}
+
+	b, err = sealer.Seal(b)
+	if err != nil {
+		return "", err
+	}
+
+	m.log.Printf("re-encrypting graph with key version %s", currentVersion)
+	_, err = blobService.UploadBuffer(ctx, GraphContainer, GraphBlob, b, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return currentVersion, nil
+}
+
 // SavePersistedGraph could be implemented and used with care if needed, but
 // currently we don't need it (and it's better that way)
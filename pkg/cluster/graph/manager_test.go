@@ -5,15 +5,18 @@ package graph
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"testing"
 
+	azkeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
 	"github.com/sirupsen/logrus"
 	"go.uber.org/mock/gomock"
 
 	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
 	mock_keyvault "github.com/Azure/ARO-RP/pkg/util/mocks/keyvault"
 	mock_storage "github.com/Azure/ARO-RP/pkg/util/mocks/storage"
+	"github.com/Azure/ARO-RP/pkg/util/pointerutils"
 	utilerror "github.com/Azure/ARO-RP/test/util/error"
 )
 
@@ -64,3 +67,72 @@ func TestLoadPersisted(t *testing.T) {
 		})
 	}
 }
+
+func TestRotateEncryption(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name        string
+		keyVersion  string
+		mocks       func(*mock_storage.MockManager, *mock_env.MockInterface, *mock_keyvault.MockManager)
+		wantVersion string
+		wantErr     string
+	}{
+		{
+			name:       "graph already encrypted with the current key version",
+			keyVersion: "v2",
+			mocks: func(storage *mock_storage.MockManager, env *mock_env.MockInterface, kv *mock_keyvault.MockManager) {
+				env.EXPECT().ServiceKeyvault().Return(kv)
+				kv.EXPECT().GetSecret(gomock.Any(), gomock.Any()).Return(azkeyvault.SecretBundle{
+					ID:    pointerutils.ToPtr("https://kv.vault.azure.net/secrets/encryption-key-v2/v2"),
+					Value: pointerutils.ToPtr(""),
+				}, nil)
+			},
+			wantVersion: "v2",
+		},
+		{
+			name:       "getting the current key returns an error",
+			keyVersion: "v1",
+			mocks: func(storage *mock_storage.MockManager, env *mock_env.MockInterface, kv *mock_keyvault.MockManager) {
+				env.EXPECT().ServiceKeyvault().Return(kv)
+				kv.EXPECT().GetSecret(gomock.Any(), gomock.Any()).Return(azkeyvault.SecretBundle{}, errors.New("random error"))
+			},
+			wantErr: "random error",
+		},
+		{
+			name:       "getting the blob service returns an error",
+			keyVersion: "v1",
+			mocks: func(storage *mock_storage.MockManager, env *mock_env.MockInterface, kv *mock_keyvault.MockManager) {
+				env.EXPECT().ServiceKeyvault().Return(kv)
+				kv.EXPECT().GetSecret(gomock.Any(), gomock.Any()).Return(azkeyvault.SecretBundle{
+					ID:    pointerutils.ToPtr("https://kv.vault.azure.net/secrets/encryption-key-v2/v2"),
+					Value: pointerutils.ToPtr(base64.StdEncoding.EncodeToString(make([]byte, 64))),
+				}, nil)
+				storage.EXPECT().BlobService(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("general error"))
+			},
+			wantErr: "general error",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			env := mock_env.NewMockInterface(ctrl)
+			storage := mock_storage.NewMockManager(ctrl)
+			kv := mock_keyvault.NewMockManager(ctrl)
+
+			tt.mocks(storage, env, kv)
+
+			m := &manager{
+				log:     logrus.NewEntry(logrus.StandardLogger()),
+				storage: storage,
+				env:     env,
+			}
+
+			version, err := m.RotateEncryption(ctx, "test-rg", "TEST-ACCOUNT", tt.keyVersion)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			if version != tt.wantVersion {
+				t.Errorf("got version %q, wanted %q", version, tt.wantVersion)
+			}
+		})
+	}
+}
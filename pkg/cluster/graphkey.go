@@ -0,0 +1,33 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+// rotateGraphEncryption re-encrypts the persisted installer graph with the
+// current cluster graph key and records the key version in the document
+func (m *manager) rotateGraphEncryption(ctx context.Context) error {
+	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+	account := "cluster" + m.doc.OpenShiftCluster.Properties.StorageSuffix
+
+	keyVersion, err := m.graph.RotateEncryption(ctx, resourceGroup, account, m.doc.OpenShiftCluster.Properties.GraphKeyVersion)
+	if err != nil {
+		return err
+	}
+
+	if keyVersion == m.doc.OpenShiftCluster.Properties.GraphKeyVersion {
+		return nil
+	}
+
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.GraphKeyVersion = keyVersion
+		return nil
+	})
+	return err
+}
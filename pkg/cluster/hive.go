@@ -5,8 +5,12 @@ package cluster
 
 import (
 	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/hive"
 )
 
 func (m *manager) hiveCreateNamespace(ctx context.Context) error {
@@ -49,6 +53,60 @@ func (m *manager) hiveResetCorrelationData(ctx context.Context) error {
 	return m.hiveClusterManager.ResetCorrelationData(ctx, m.doc)
 }
 
+// shouldDeprovisionViaHive returns true if the deletion of the cluster's Azure
+// resources should be delegated to Hive.  Only adopted clusters with a
+// service principal can be deprovisioned by Hive, as Hive needs credentials
+// for the cluster's managed resource group.
+func (m *manager) shouldDeprovisionViaHive() bool {
+	return m.deprovisionViaHive &&
+		m.hiveClusterManager != nil &&
+		m.doc.OpenShiftCluster.Properties.HiveProfile.Namespace != "" &&
+		!m.doc.OpenShiftCluster.UsesWorkloadIdentity()
+}
+
+// shouldManageOperatorViaHive returns true if the ARO operator's resources
+// should be applied by a Hive SyncSet rather than by the RP.  This requires
+// the cluster to have a ClusterDeployment in Hive.
+func (m *manager) shouldManageOperatorViaHive() bool {
+	return m.operatorViaHive &&
+		m.hiveClusterManager != nil &&
+		m.doc.OpenShiftCluster.Properties.HiveProfile.Namespace != ""
+}
+
+// hiveEnsureAROOperatorSyncSet updates the ARO operator's SyncSet in Hive.
+// The operator's secrets are still applied directly, so that they are not
+// stored in Hive in plain text.
+func (m *manager) hiveEnsureAROOperatorSyncSet(ctx context.Context) error {
+	m.log.Info("ensuring aro operator syncset in hive")
+	err := m.aroOperatorDeployer.UpdateSecrets(ctx)
+	if err != nil {
+		return err
+	}
+
+	resources, err := m.aroOperatorDeployer.Resources(ctx)
+	if err != nil {
+		return err
+	}
+
+	return m.hiveClusterManager.EnsureSyncSet(ctx, m.doc, hive.OperatorSyncSetName, resources)
+}
+
+func (m *manager) hiveDeprovisionResources(ctx context.Context) error {
+	m.log.Info("deprovisioning cluster resources with hive")
+	err := m.hiveClusterManager.Deprovision(ctx, m.doc)
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Hour)
+	defer cancel()
+
+	m.log.Info("waiting for cluster deprovision to complete")
+	return wait.PollImmediateUntil(15*time.Second, func() (bool, error) {
+		return m.hiveClusterManager.IsClusterDeprovisionComplete(timeoutCtx, m.doc)
+	}, timeoutCtx.Done())
+}
+
 func (m *manager) hiveDeleteResources(ctx context.Context) error {
 	m.log.Info("deregistering cluster with hive")
 	namespace := m.doc.OpenShiftCluster.Properties.HiveProfile.Namespace
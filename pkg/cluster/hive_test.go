@@ -13,9 +13,12 @@ import (
 	"github.com/sirupsen/logrus"
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/hive"
 	mock_hive "github.com/Azure/ARO-RP/pkg/util/mocks/hive"
+	mock_deploy "github.com/Azure/ARO-RP/pkg/util/mocks/operator/deploy"
 	testdatabase "github.com/Azure/ARO-RP/test/database"
 	utilerror "github.com/Azure/ARO-RP/test/util/error"
 )
@@ -311,3 +314,181 @@ func TestHiveDeleteResources(t *testing.T) {
 		})
 	}
 }
+
+func TestHiveDeprovisionResources(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		testName           string
+		clusterManagerMock func(mockCtrl *gomock.Controller, doc *api.OpenShiftClusterDocument) *mock_hive.MockClusterManager
+		wantErr            string
+	}{
+		{
+			testName: "waits for the deprovision to complete",
+			clusterManagerMock: func(mockCtrl *gomock.Controller, doc *api.OpenShiftClusterDocument) *mock_hive.MockClusterManager {
+				mockClusterManager := mock_hive.NewMockClusterManager(mockCtrl)
+				mockClusterManager.EXPECT().Deprovision(ctx, doc).Return(nil)
+				mockClusterManager.EXPECT().IsClusterDeprovisionComplete(gomock.Any(), doc).Return(true, nil)
+				return mockClusterManager
+			},
+		},
+		{
+			testName: "returns error if the deprovision cannot be created",
+			clusterManagerMock: func(mockCtrl *gomock.Controller, doc *api.OpenShiftClusterDocument) *mock_hive.MockClusterManager {
+				mockClusterManager := mock_hive.NewMockClusterManager(mockCtrl)
+				mockClusterManager.EXPECT().Deprovision(ctx, doc).Return(errors.New("cluster manager error"))
+				return mockClusterManager
+			},
+			wantErr: "cluster manager error",
+		},
+		{
+			testName: "returns error if the deprovision fails",
+			clusterManagerMock: func(mockCtrl *gomock.Controller, doc *api.OpenShiftClusterDocument) *mock_hive.MockClusterManager {
+				mockClusterManager := mock_hive.NewMockClusterManager(mockCtrl)
+				mockClusterManager.EXPECT().Deprovision(ctx, doc).Return(nil)
+				mockClusterManager.EXPECT().IsClusterDeprovisionComplete(gomock.Any(), doc).Return(false, errors.New("cluster deprovision failed"))
+				return mockClusterManager
+			},
+			wantErr: "cluster deprovision failed",
+		},
+	} {
+		t.Run(tt.testName, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := createManagerForTests(t, "existing-namespace")
+			m.hiveClusterManager = tt.clusterManagerMock(controller, m.doc)
+
+			err := m.hiveDeprovisionResources(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestShouldDeprovisionViaHive(t *testing.T) {
+	for _, tt := range []struct {
+		testName           string
+		deprovisionViaHive bool
+		namespace          string
+		workloadIdentity   bool
+		want               bool
+	}{
+		{
+			testName:           "adopted service principal cluster",
+			deprovisionViaHive: true,
+			namespace:          "existing-namespace",
+			want:               true,
+		},
+		{
+			testName:  "deprovisioning via hive disabled",
+			namespace: "existing-namespace",
+		},
+		{
+			testName:           "cluster not adopted",
+			deprovisionViaHive: true,
+		},
+		{
+			testName:           "workload identity cluster",
+			deprovisionViaHive: true,
+			namespace:          "existing-namespace",
+			workloadIdentity:   true,
+		},
+	} {
+		t.Run(tt.testName, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := createManagerForTests(t, tt.namespace)
+			m.deprovisionViaHive = tt.deprovisionViaHive
+			m.hiveClusterManager = mock_hive.NewMockClusterManager(controller)
+			if tt.workloadIdentity {
+				m.doc.OpenShiftCluster.Properties.PlatformWorkloadIdentityProfile = &api.PlatformWorkloadIdentityProfile{}
+			}
+
+			if got := m.shouldDeprovisionViaHive(); got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestHiveEnsureAROOperatorSyncSet(t *testing.T) {
+	ctx := context.Background()
+	resources := []kruntime.Object{&corev1.ConfigMap{}}
+
+	for _, tt := range []struct {
+		name    string
+		mocks   func(*mock_deploy.MockOperator, *mock_hive.MockClusterManager)
+		wantErr string
+	}{
+		{
+			name: "applies secrets and ensures the syncset",
+			mocks: func(deployer *mock_deploy.MockOperator, hiveMock *mock_hive.MockClusterManager) {
+				gomock.InOrder(
+					deployer.EXPECT().UpdateSecrets(gomock.Any()).Return(nil),
+					deployer.EXPECT().Resources(gomock.Any()).Return(resources, nil),
+					hiveMock.EXPECT().EnsureSyncSet(gomock.Any(), gomock.Any(), hive.OperatorSyncSetName, resources).Return(nil),
+				)
+			},
+		},
+		{
+			name: "fails to apply secrets",
+			mocks: func(deployer *mock_deploy.MockOperator, hiveMock *mock_hive.MockClusterManager) {
+				deployer.EXPECT().UpdateSecrets(gomock.Any()).Return(errors.New("fake error"))
+			},
+			wantErr: "fake error",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			deployer := mock_deploy.NewMockOperator(controller)
+			hiveMock := mock_hive.NewMockClusterManager(controller)
+			tt.mocks(deployer, hiveMock)
+
+			m := createManagerForTests(t, "existing-namespace")
+			m.aroOperatorDeployer = deployer
+			m.hiveClusterManager = hiveMock
+
+			err := m.hiveEnsureAROOperatorSyncSet(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestShouldManageOperatorViaHive(t *testing.T) {
+	for _, tt := range []struct {
+		testName        string
+		operatorViaHive bool
+		namespace       string
+		want            bool
+	}{
+		{
+			testName:        "adopted cluster",
+			operatorViaHive: true,
+			namespace:       "existing-namespace",
+			want:            true,
+		},
+		{
+			testName:  "operator via hive disabled",
+			namespace: "existing-namespace",
+		},
+		{
+			testName:        "cluster not adopted",
+			operatorViaHive: true,
+		},
+	} {
+		t.Run(tt.testName, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := createManagerForTests(t, tt.namespace)
+			m.operatorViaHive = tt.operatorViaHive
+			m.hiveClusterManager = mock_hive.NewMockClusterManager(controller)
+
+			if got := m.shouldManageOperatorViaHive(); got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
@@ -51,6 +51,7 @@ func (m *manager) adminUpdate() []steps.Step {
 	isOperator := task == api.MaintenanceTaskOperator
 	isRenewCerts := task == api.MaintenanceTaskRenewCerts
 	isSyncClusterObject := task == api.MaintenanceTaskSyncClusterObject
+	isRotateGraphKey := task == api.MaintenanceTaskRotateGraphKey
 
 	stepsToRun := m.getZerothSteps()
 	if isEverything {
@@ -71,6 +72,8 @@ func (m *manager) adminUpdate() []steps.Step {
 		stepsToRun = append(stepsToRun, m.getCertificateRenewalSteps()...)
 	} else if isSyncClusterObject {
 		stepsToRun = append(stepsToRun, m.getSyncClusterObjectSteps()...)
+	} else if isRotateGraphKey {
+		stepsToRun = append(stepsToRun, steps.Action(m.rotateGraphEncryption))
 	}
 
 	// We don't run this on an operator-only deploy as PUCM scripts then cannot
@@ -84,19 +87,12 @@ func (m *manager) adminUpdate() []steps.Step {
 }
 
 func (m *manager) getZerothSteps() []steps.Step {
-	bootstrap := []steps.Step{
+	return []steps.Step{
 		steps.Action(m.initializeKubernetesClients), // must be first
 		steps.Action(m.ensureBillingRecord),         // belt and braces
 		steps.Action(m.ensureDefaults),
 		steps.Action(m.fixupClusterSPObjectID),
 	}
-
-	// Generic fix-up actions that are fairly safe to always take, and don't require a running cluster
-	step0 := []steps.Step{
-		steps.Action(m.fixInfraID), // Old clusters lacks infraID in the database. Which makes code prone to errors.
-	}
-
-	return append(bootstrap, step0...)
 }
 
 func (m *manager) getEnsureAPIServerReadySteps() []steps.Step {
@@ -114,6 +110,7 @@ func (m *manager) getGeneralFixesSteps() []steps.Step {
 		steps.Action(m.populateRegistryStorageAccountName), // must go before migrateStorageAccounts
 		steps.Action(m.migrateStorageAccounts),
 		steps.Action(m.fixSSH),
+		steps.Action(m.enableBootDiagnostics),
 		// steps.Action(m.removePrivateDNSZone), // TODO(mj): re-enable once we communicate this out
 
 	}
@@ -256,6 +253,7 @@ func (m *manager) Update(ctx context.Context) error {
 		steps.Action(m.fixUserAdminKubeconfig),
 		steps.Action(m.reconcileLoadBalancerProfile),
 		steps.Action(m.reconcileSoftwareDefinedNetwork),
+		steps.Action(m.syncClusterObject), // picks up customer-set operator flags
 	)
 
 	if m.doc.OpenShiftCluster.UsesWorkloadIdentity() {
@@ -357,37 +355,17 @@ func (m *manager) bootstrap() []steps.Step {
 		)
 	}
 
-	s = append(s,
-		steps.AuthorizationRetryingAction(m.fpAuthorizer, m.validateResources),
-		steps.Action(m.ensurePreconfiguredNSG),
-		steps.Action(m.ensureACRToken),
-		steps.Action(m.ensureInfraID),
-		steps.Action(m.ensureSSHKey),
-		steps.Action(m.ensureStorageSuffix),
-		steps.Action(m.populateMTUSize),
-		steps.Action(m.createDNS),
-		steps.Action(m.createOIDC),
-		steps.Action(m.ensureResourceGroup),
-		steps.Action(m.ensureServiceEndpoints),
-		steps.Action(m.setMasterSubnetPolicies),
-		steps.AuthorizationRetryingAction(m.fpAuthorizer, m.deployBaseResourceTemplate),
-	)
-
-	if m.doc.OpenShiftCluster.UsesWorkloadIdentity() {
+	if m.isBootstrapRetry() {
+		// The control plane resources, graph and certificates were created by
+		// the failed attempt, so only the bootstrap resources are torn down
+		// here for the installer to re-deploy.
 		s = append(s,
-			steps.Action(m.federateIdentityCredentials),
+			steps.Action(m.removeBootstrap),
 		)
+	} else {
+		s = append(s, m.bootstrapPrepare()...)
 	}
 
-	s = append(s,
-		steps.AuthorizationRetryingAction(m.fpAuthorizer, m.attachNSGs),
-		steps.Action(m.updateAPIIPEarly),
-		steps.Action(m.createOrUpdateRouterIPEarly),
-		steps.Action(m.ensureGatewayCreate),
-		steps.Action(m.createAPIServerPrivateEndpoint),
-		steps.Action(m.createCertificates),
-	)
-
 	if m.adoptViaHive || m.installViaHive {
 		// We will always need a Hive namespace, whether we are installing
 		// via Hive or adopting
@@ -436,6 +414,51 @@ func (m *manager) bootstrap() []steps.Step {
 	return s
 }
 
+// bootstrapPrepare returns the steps which create the control plane
+// resources ahead of running the installer
+func (m *manager) bootstrapPrepare() []steps.Step {
+	s := []steps.Step{
+		steps.AuthorizationRetryingAction(m.fpAuthorizer, m.validateResources),
+		steps.Action(m.ensurePreconfiguredNSG),
+		steps.Action(m.ensureACRToken),
+		steps.Action(m.ensureInfraID),
+		steps.Action(m.ensureSSHKey),
+		steps.Action(m.ensureStorageSuffix),
+		steps.Action(m.populateMTUSize),
+		steps.Action(m.populateSecurityType),
+		steps.Action(m.createDNS),
+		steps.Action(m.createOIDC),
+		steps.Action(m.ensureResourceGroup),
+		steps.Action(m.ensureServiceEndpoints),
+		steps.Action(m.setMasterSubnetPolicies),
+		steps.AuthorizationRetryingAction(m.fpAuthorizer, m.deployBaseResourceTemplate),
+	}
+
+	if m.doc.OpenShiftCluster.UsesWorkloadIdentity() {
+		s = append(s,
+			steps.Action(m.federateIdentityCredentials),
+		)
+	}
+
+	s = append(s,
+		steps.AuthorizationRetryingAction(m.fpAuthorizer, m.attachNSGs),
+		steps.Action(m.updateAPIIPEarly),
+		steps.Action(m.createOrUpdateRouterIPEarly),
+		steps.Action(m.ensureGatewayCreate),
+		steps.Action(m.createAPIServerPrivateEndpoint),
+		steps.Action(m.createCertificates),
+	)
+
+	return s
+}
+
+// isBootstrapRetry returns true if a failed bootstrap phase has been requeued
+// via the admin API
+func (m *manager) isBootstrapRetry() bool {
+	install := m.doc.OpenShiftCluster.Properties.Install
+	return install != nil && install.Phase == api.InstallPhaseBootstrap && install.RetryBootstrap
+}
+
 // Install installs an ARO cluster
 func (m *manager) Install(ctx context.Context) error {
 	steps := map[api.InstallPhase][]steps.Step{
@@ -451,6 +474,7 @@ func (m *manager) Install(ctx context.Context) error {
 			steps.Action(m.configureAPIServerCertificate),
 			steps.Condition(m.apiServersReady, 30*time.Minute, true),
 			steps.Condition(m.minimumWorkerNodesReady, 30*time.Minute, true),
+			steps.Action(m.enableBootDiagnostics),
 			steps.Condition(m.operatorConsoleExists, 30*time.Minute, true),
 			steps.Action(m.updateConsoleBranding),
 			steps.Condition(m.operatorConsoleReady, 20*time.Minute, true),
@@ -487,15 +511,21 @@ func (m *manager) runSteps(ctx context.Context, s []steps.Step, metricsTopic str
 		var stepsTimeRun map[string]int64
 		stepsTimeRun, err = steps.Run(ctx, m.log, 10*time.Second, s, m.now)
 		if err == nil {
+			dimensions := m.correlationMetricsDimensions()
+
 			var totalInstallTime int64
 			for stepName, duration := range stepsTimeRun {
 				metricName := fmt.Sprintf("backend.openshiftcluster.%s.%s.duration.seconds", metricsTopic, stepName)
-				m.metricsEmitter.EmitGauge(metricName, duration, nil)
+				m.metricsEmitter.EmitGauge(metricName, duration, dimensions)
 				totalInstallTime += duration
 			}
 
 			metricName := fmt.Sprintf("backend.openshiftcluster.%s.duration.total.seconds", metricsTopic)
-			m.metricsEmitter.EmitGauge(metricName, totalInstallTime, nil)
+			m.metricsEmitter.EmitGauge(metricName, totalInstallTime, dimensions)
+
+			if metricsTopic == "install" {
+				m.emitInstallPhaseMetrics(stepsTimeRun)
+			}
 		}
 	} else {
 		_, err = steps.Run(ctx, m.log, 10*time.Second, s, nil)
@@ -618,7 +648,21 @@ func (m *manager) initializeKubernetesClients(ctx context.Context) error {
 // initializeKubernetesClients initializes clients which are used
 // once the cluster is up later on in the install process.
 func (m *manager) initializeOperatorDeployer(ctx context.Context) (err error) {
-	m.aroOperatorDeployer, err = deploy.New(m.log, m.env, m.doc.OpenShiftCluster, m.subscriptionDoc, m.arocli, m.ch, m.extensionscli, m.kubernetescli, m.operatorcli)
+	// the operator validates the cluster's machines against the VM sizes
+	// which the RP supports in addition to its built-in ones
+	docs, err := m.dbSupportedVMSizes.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	var supportedVMSizes []*api.SupportedVMSize
+	for _, doc := range docs.SupportedVMSizeDocuments {
+		if !doc.SupportedVMSize.Deleting {
+			supportedVMSizes = append(supportedVMSizes, doc.SupportedVMSize)
+		}
+	}
+
+	m.aroOperatorDeployer, err = deploy.New(m.log, m.env, m.doc.OpenShiftCluster, m.subscriptionDoc, supportedVMSizes, m.arocli, m.ch, m.extensionscli, m.kubernetescli, m.operatorcli)
 	return
 }
 
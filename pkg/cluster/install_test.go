@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-test/deep"
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
 	configv1 "github.com/openshift/api/config/v1"
@@ -23,6 +24,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	utilgenerics "github.com/Azure/ARO-RP/pkg/util/generics"
 	mock_hive "github.com/Azure/ARO-RP/pkg/util/mocks/hive"
 	"github.com/Azure/ARO-RP/pkg/util/steps"
 	"github.com/Azure/ARO-RP/pkg/util/version"
@@ -237,6 +239,93 @@ func TestStepRunnerWithInstaller(t *testing.T) {
 	}
 }
 
+func TestBootstrapSteps(t *testing.T) {
+	credentialSteps := []string{
+		"[Action initializeClusterSPClients]",
+		"[AuthorizationRetryingAction clusterSPObjectID]",
+	}
+
+	prepareSteps := []string{
+		"[AuthorizationRetryingAction validateResources]",
+		"[Action ensurePreconfiguredNSG]",
+		"[Action ensureACRToken]",
+		"[Action ensureInfraID]",
+		"[Action ensureSSHKey]",
+		"[Action ensureStorageSuffix]",
+		"[Action populateMTUSize]",
+		"[Action populateSecurityType]",
+		"[Action createDNS]",
+		"[Action createOIDC]",
+		"[Action ensureResourceGroup]",
+		"[Action ensureServiceEndpoints]",
+		"[Action setMasterSubnetPolicies]",
+		"[AuthorizationRetryingAction deployBaseResourceTemplate]",
+		"[AuthorizationRetryingAction attachNSGs]",
+		"[Action updateAPIIPEarly]",
+		"[Action createOrUpdateRouterIPEarly]",
+		"[Action ensureGatewayCreate]",
+		"[Action createAPIServerPrivateEndpoint]",
+		"[Action createCertificates]",
+	}
+
+	installSteps := []string{
+		"[Action runPodmanInstaller]",
+		"[Action generateKubeconfigs]",
+		"[Action ensureBillingRecord]",
+		"[Action initializeKubernetesClients]",
+		"[Action initializeOperatorDeployer]",
+		"[Condition apiServersReady, timeout 30m0s]",
+		"[Action installAROOperator]",
+		"[Action enableOperatorReconciliation]",
+		"[Action incrInstallPhase]",
+	}
+
+	for _, tt := range []struct {
+		name           string
+		install        *api.Install
+		shouldRunSteps []string
+	}{
+		{
+			name:           "new installation",
+			shouldRunSteps: utilgenerics.ConcatMultipleSlices(credentialSteps, prepareSteps, installSteps),
+		},
+		{
+			name:           "bootstrap phase",
+			install:        &api.Install{Phase: api.InstallPhaseBootstrap},
+			shouldRunSteps: utilgenerics.ConcatMultipleSlices(credentialSteps, prepareSteps, installSteps),
+		},
+		{
+			name:    "bootstrap phase retry",
+			install: &api.Install{Phase: api.InstallPhaseBootstrap, RetryBootstrap: true},
+			shouldRunSteps: utilgenerics.ConcatMultipleSlices(
+				credentialSteps, []string{"[Action removeBootstrap]"}, installSteps,
+			),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &manager{
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							Install: tt.install,
+						},
+					},
+				},
+			}
+
+			var stepsToRun []string
+			for _, s := range m.bootstrap() {
+				o := strings.Replace(s.String(), "pkg/cluster.(*manager).", "", -1)
+				stepsToRun = append(stepsToRun, o)
+			}
+
+			for _, d := range deep.Equal(stepsToRun, tt.shouldRunSteps) {
+				t.Error(d)
+			}
+		})
+	}
+}
+
 func TestUpdateProvisionedBy(t *testing.T) {
 	ctx := context.Background()
 	key := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName1"
@@ -312,6 +401,7 @@ func TestInstallationTimeMetrics(t *testing.T) {
 				"backend.openshiftcluster.install.duration.total.seconds":                             4,
 				"backend.openshiftcluster.install.action.successfulActionStep.duration.seconds":       2,
 				"backend.openshiftcluster.install.condition.successfulConditionStep.duration.seconds": 2,
+				"backend.openshiftcluster.install.phase.duration.seconds":                             4,
 			},
 		},
 		{
@@ -336,6 +426,9 @@ func TestInstallationTimeMetrics(t *testing.T) {
 				log:            log,
 				metricsEmitter: fm,
 				now:            func() time.Time { return time.Now().Add(time.Duration(tt.timePerStep) * time.Second) },
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{},
+				},
 			}
 
 			err := m.runSteps(ctx, tt.steps, tt.metricsTopic)
@@ -68,6 +68,160 @@ func TestGetOpenShiftVersionFromVersion(t *testing.T) {
 			wantErrString: "400: InvalidParameter: properties.clusterProfile.version: The requested OpenShift version '4.11.5' is not supported.",
 			want:          nil,
 		},
+		{
+			name: "select version disabled in the cluster's location",
+			f: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftVersionDocuments(
+					&api.OpenShiftVersionDocument{
+						OpenShiftVersion: &api.OpenShiftVersion{
+							Properties: api.OpenShiftVersionProperties{
+								Version:           "4.10.20",
+								Enabled:           true,
+								DisabledLocations: []string{"eastus"},
+							},
+						},
+					},
+				)
+			},
+			m: manager{
+				doc: &api.OpenShiftClusterDocument{
+					Key: strings.ToLower(key),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       key,
+						Location: "eastus",
+						Properties: api.OpenShiftClusterProperties{
+							ClusterProfile: api.ClusterProfile{
+								Version: "4.10.20",
+							},
+						},
+					},
+				},
+				openShiftClusterDocumentVersioner: new(openShiftClusterDocumentVersionerService),
+			},
+			wantErrString: "400: InvalidParameter: properties.clusterProfile.version: The requested OpenShift version '4.10.20' is not supported.",
+			want:          nil,
+		},
+		{
+			name: "select version enabled in the cluster's location",
+			f: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftVersionDocuments(
+					&api.OpenShiftVersionDocument{
+						OpenShiftVersion: &api.OpenShiftVersion{
+							Properties: api.OpenShiftVersionProperties{
+								Version:           "4.10.20",
+								Enabled:           true,
+								OpenShiftPullspec: "quay.io/openshift-release-dev/ocp-release@sha256:abc",
+								InstallerPullspec: "arointsvc.azurecr.io/aro-installer:release-4.10",
+								DisabledLocations: []string{"westus"},
+							},
+						},
+					},
+				)
+			},
+			m: manager{
+				doc: &api.OpenShiftClusterDocument{
+					Key: strings.ToLower(key),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       key,
+						Location: "eastus",
+						Properties: api.OpenShiftClusterProperties{
+							ClusterProfile: api.ClusterProfile{
+								Version: "4.10.20",
+							},
+						},
+					},
+				},
+				openShiftClusterDocumentVersioner: new(openShiftClusterDocumentVersionerService),
+			},
+			want: &api.OpenShiftVersion{
+				Properties: api.OpenShiftVersionProperties{
+					Version:           "4.10.20",
+					OpenShiftPullspec: "quay.io/openshift-release-dev/ocp-release@sha256:abc",
+					InstallerPullspec: "arointsvc.azurecr.io/aro-installer:release-4.10",
+				},
+			},
+		},
+		{
+			name: "select multi-architecture release for cluster with arm64 workers",
+			f: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftVersionDocuments(
+					&api.OpenShiftVersionDocument{
+						OpenShiftVersion: &api.OpenShiftVersion{
+							Properties: api.OpenShiftVersionProperties{
+								Version:           "4.15.27",
+								Enabled:           true,
+								OpenShiftPullspec: "quay.io/openshift-release-dev/ocp-release@sha256:abc",
+								MultiArchPullspec: "quay.io/openshift-release-dev/ocp-release@sha256:def",
+								InstallerPullspec: "arointsvc.azurecr.io/aro-installer:release-4.15",
+							},
+						},
+					},
+				)
+			},
+			m: manager{
+				doc: &api.OpenShiftClusterDocument{
+					Key: strings.ToLower(key),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: key,
+						Properties: api.OpenShiftClusterProperties{
+							ClusterProfile: api.ClusterProfile{
+								Version: "4.15.27",
+							},
+							WorkerProfiles: []api.WorkerProfile{
+								{
+									VMSize: api.VMSizeStandardD8psV5,
+								},
+							},
+						},
+					},
+				},
+				openShiftClusterDocumentVersioner: new(openShiftClusterDocumentVersionerService),
+			},
+			want: &api.OpenShiftVersion{
+				Properties: api.OpenShiftVersionProperties{
+					Version:           "4.15.27",
+					OpenShiftPullspec: "quay.io/openshift-release-dev/ocp-release@sha256:def",
+					InstallerPullspec: "arointsvc.azurecr.io/aro-installer:release-4.15",
+				},
+			},
+		},
+		{
+			name: "select version without multi-architecture release for cluster with arm64 workers",
+			f: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftVersionDocuments(
+					&api.OpenShiftVersionDocument{
+						OpenShiftVersion: &api.OpenShiftVersion{
+							Properties: api.OpenShiftVersionProperties{
+								Version:           "4.15.27",
+								Enabled:           true,
+								OpenShiftPullspec: "quay.io/openshift-release-dev/ocp-release@sha256:abc",
+								InstallerPullspec: "arointsvc.azurecr.io/aro-installer:release-4.15",
+							},
+						},
+					},
+				)
+			},
+			m: manager{
+				doc: &api.OpenShiftClusterDocument{
+					Key: strings.ToLower(key),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: key,
+						Properties: api.OpenShiftClusterProperties{
+							ClusterProfile: api.ClusterProfile{
+								Version: "4.15.27",
+							},
+							WorkerProfiles: []api.WorkerProfile{
+								{
+									VMSize: api.VMSizeStandardD8psV5,
+								},
+							},
+						},
+					},
+				},
+				openShiftClusterDocumentVersioner: new(openShiftClusterDocumentVersionerService),
+			},
+			wantErrString: "400: InvalidParameter: properties.clusterProfile.version: The requested OpenShift version '4.15.27' is not supported.",
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
@@ -0,0 +1,100 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"sort"
+	"strings"
+)
+
+const installPhaseOther = "other"
+
+// installPhases groups install steps, by function name, into the phases whose
+// durations are emitted for each install, so that install latency can be
+// compared fleet-wide between OpenShift versions.  Steps which are not listed
+// are counted in the "other" phase.
+var installPhases = map[string]string{
+	"clusterSPObjectID":           "validate",
+	"clusterIdentityIDs":          "validate",
+	"platformWorkloadIdentityIDs": "validate",
+	"validateResources":           "validate",
+
+	"ensurePreconfiguredNSG":         "deployARM",
+	"createDNS":                      "deployARM",
+	"createOIDC":                     "deployARM",
+	"ensureResourceGroup":            "deployARM",
+	"ensureServiceEndpoints":         "deployARM",
+	"setMasterSubnetPolicies":        "deployARM",
+	"deployBaseResourceTemplate":     "deployARM",
+	"federateIdentityCredentials":    "deployARM",
+	"attachNSGs":                     "deployARM",
+	"updateAPIIPEarly":               "deployARM",
+	"createOrUpdateRouterIPEarly":    "deployARM",
+	"ensureGatewayCreate":            "deployARM",
+	"createAPIServerPrivateEndpoint": "deployARM",
+
+	"runPodmanInstaller":              "bootstrap",
+	"runHiveInstaller":                "bootstrap",
+	"hiveClusterInstallationComplete": "bootstrap",
+	"hiveClusterDeploymentReady":      "bootstrap",
+
+	"removeBootstrap":         "removeBootstrap",
+	"removeBootstrapIgnition": "removeBootstrap",
+
+	"apiServersReady":         "nodesReady",
+	"minimumWorkerNodesReady": "nodesReady",
+
+	"operatorConsoleExists":  "operatorsAvailable",
+	"operatorConsoleReady":   "operatorsAvailable",
+	"clusterVersionReady":    "operatorsAvailable",
+	"aroDeploymentReady":     "operatorsAvailable",
+	"ingressControllerReady": "operatorsAvailable",
+}
+
+// installPhase returns the phase of a step, given its metrics name, e.g.
+// action.deployBaseResourceTemplate
+func installPhase(stepName string) string {
+	_, name, _ := strings.Cut(stepName, ".")
+	if phase, found := installPhases[name]; found {
+		return phase
+	}
+
+	return installPhaseOther
+}
+
+// emitInstallPhaseMetrics emits the total duration of each install phase in a
+// run of install steps, dimensioned by the cluster's location and version
+func (m *manager) emitInstallPhaseMetrics(stepsTimeRun map[string]int64) {
+	durations := map[string]int64{}
+	for stepName, duration := range stepsTimeRun {
+		durations[installPhase(stepName)] += duration
+	}
+
+	phases := make([]string, 0, len(durations))
+	for phase := range durations {
+		phases = append(phases, phase)
+	}
+	sort.Strings(phases)
+
+	for _, phase := range phases {
+		m.metricsEmitter.EmitGauge("backend.openshiftcluster.install.phase.duration.seconds", durations[phase], map[string]string{
+			"phase":      phase,
+			"location":   m.doc.OpenShiftCluster.Location,
+			"ocpVersion": m.doc.OpenShiftCluster.Properties.ClusterProfile.Version,
+		})
+	}
+}
+
+// correlationMetricsDimensions returns the dimensions which tie the step
+// metrics of an operation to the ARM request which queued it.  The dimension
+// matches the one on the backend's operation metrics.
+func (m *manager) correlationMetricsDimensions() map[string]string {
+	if m.doc == nil || m.doc.CorrelationData == nil {
+		return nil
+	}
+
+	return map[string]string{
+		"correlationdata.correlationid": m.doc.CorrelationData.CorrelationID,
+	}
+}
@@ -0,0 +1,92 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestEmitInstallPhaseMetrics(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	emitter := mock_metrics.NewMockEmitter(controller)
+
+	for phase, duration := range map[string]int64{
+		"deployARM":          300,
+		"bootstrap":          1500,
+		"operatorsAvailable": 600,
+		"other":              5,
+	} {
+		emitter.EXPECT().EmitGauge("backend.openshiftcluster.install.phase.duration.seconds", duration, map[string]string{
+			"phase":      phase,
+			"location":   "eastus",
+			"ocpVersion": "4.15.27",
+		})
+	}
+
+	m := &manager{
+		metricsEmitter: emitter,
+		doc: &api.OpenShiftClusterDocument{
+			OpenShiftCluster: &api.OpenShiftCluster{
+				Location: "eastus",
+				Properties: api.OpenShiftClusterProperties{
+					ClusterProfile: api.ClusterProfile{
+						Version: "4.15.27",
+					},
+				},
+			},
+		},
+	}
+
+	m.emitInstallPhaseMetrics(map[string]int64{
+		"authorizationretryingaction.deployBaseResourceTemplate": 240,
+		"authorizationretryingaction.attachNSGs":                 60,
+		"action.runPodmanInstaller":                              1500,
+		"condition.clusterVersionReady":                          400,
+		"condition.ingressControllerReady":                       200,
+		"action.ensureBillingRecord":                             5,
+	})
+}
+
+func TestCorrelationMetricsDimensions(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		doc  *api.OpenShiftClusterDocument
+		want map[string]string
+	}{
+		{
+			name: "no correlation data",
+			doc:  &api.OpenShiftClusterDocument{},
+		},
+		{
+			name: "correlation data",
+			doc: &api.OpenShiftClusterDocument{
+				CorrelationData: &api.CorrelationData{
+					CorrelationID: "correlation-id",
+				},
+			},
+			want: map[string]string{
+				"correlationdata.correlationid": "correlation-id",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &manager{
+				doc: tt.doc,
+			}
+
+			got := m.correlationMetricsDimensions()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(got)
+			}
+		})
+	}
+}
@@ -74,7 +74,12 @@ func (m *manager) reconcileOutboundRuleV4IPsInner(ctx context.Context, lb sdknet
 	originalOutboundIPs := getOutboundIPsFromLB(lb)
 
 	if needsEffectiveOutboundIPsPatched(m.doc.OpenShiftCluster.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs, originalOutboundIPs) {
-		err := m.patchEffectiveOutboundIPs(ctx, originalOutboundIPs)
+		ipAddresses, err := m.getPublicIPAddresses(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = m.patchEffectiveOutboundIPs(ctx, originalOutboundIPs, ipAddresses)
 		if err != nil {
 			return err
 		}
@@ -99,7 +104,12 @@ func (m *manager) reconcileOutboundRuleV4IPsInner(ctx context.Context, lb sdknet
 	}
 
 	// update database with new effective outbound IPs
-	err = m.patchEffectiveOutboundIPs(ctx, desiredOutboundIPs)
+	ipAddresses, err := m.getPublicIPAddresses(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = m.patchEffectiveOutboundIPs(ctx, desiredOutboundIPs, ipAddresses)
 	if err != nil {
 		return err
 	}
@@ -404,11 +414,37 @@ func getOutboundIPsFromLB(lb sdknetwork.LoadBalancer) []api.ResourceReference {
 	return outboundIPs
 }
 
-func (m *manager) patchEffectiveOutboundIPs(ctx context.Context, outboundIPs []api.ResourceReference) error {
+// getPublicIPAddresses returns the IP addresses of the public IP address
+// resources in the cluster resource group, keyed by lower case resource ID.
+func (m *manager) getPublicIPAddresses(ctx context.Context) (map[string]string, error) {
+	resourceGroupName := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	result, err := m.armPublicIPAddresses.List(ctx, resourceGroupName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ipAddresses := make(map[string]string, len(result))
+	for _, ip := range result {
+		if ip.ID != nil && ip.Properties != nil && ip.Properties.IPAddress != nil {
+			ipAddresses[strings.ToLower(*ip.ID)] = *ip.Properties.IPAddress
+		}
+	}
+
+	return ipAddresses, nil
+}
+
+// patchEffectiveOutboundIPs records the outbound IPs of the load balancer on
+// the cluster document.  ipAddresses maps lower case resource IDs to their IP
+// addresses; it is nil before the IP address resources have been deployed.
+func (m *manager) patchEffectiveOutboundIPs(ctx context.Context, outboundIPs []api.ResourceReference, ipAddresses map[string]string) error {
 	m.log.Info("patching effectiveOutboundIPs")
 	effectiveOutboundIPs := make([]api.EffectiveOutboundIP, 0, len(outboundIPs))
 	for _, obIP := range outboundIPs {
-		effectiveOutboundIPs = append(effectiveOutboundIPs, api.EffectiveOutboundIP(obIP))
+		effectiveOutboundIPs = append(effectiveOutboundIPs, api.EffectiveOutboundIP{
+			ID:        obIP.ID,
+			IPAddress: ipAddresses[strings.ToLower(obIP.ID)],
+		})
 	}
 	var err error
 	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
@@ -461,7 +497,12 @@ func needsEffectiveOutboundIPsPatched(cosmosEffectiveOutboundIPs []api.Effective
 	}
 	effectiveIPResources := make([]api.ResourceReference, 0, len(cosmosEffectiveOutboundIPs))
 	for _, ip := range cosmosEffectiveOutboundIPs {
-		effectiveIPResources = append(effectiveIPResources, api.ResourceReference(ip))
+		// IP addresses are not known until the IP address resources are
+		// deployed, so fill them in on the first reconcile after install
+		if ip.IPAddress == "" {
+			return true
+		}
+		effectiveIPResources = append(effectiveIPResources, api.ResourceReference{ID: ip.ID})
 	}
 	return !areResourceRefsEqual(effectiveIPResources, lbEffectiveIPs)
 }
@@ -559,6 +559,7 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 	clusterRGName := "clusterRG"
 	defaultOutboundIPName := infraID + "-pip-v4"
 	defaultOutboundIPID := clusterRGID + "/providers/Microsoft.Network/publicIPAddresses/" + defaultOutboundIPName
+	defaultOutboundIPAddress := "20.0.0.1"
 	// Define the DB instance we will use to run the PatchWithLease function
 	key := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName"
 
@@ -649,6 +650,9 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 				publicIPAddressClient.EXPECT().
 					List(gomock.Any(), clusterRGName, nil).
 					Return(getFakePublicIPList(0), nil)
+				publicIPAddressClient.EXPECT().
+					List(gomock.Any(), clusterRGName, nil).
+					Return(getFakePublicIPList(0), nil)
 				loadBalancersClient.EXPECT().
 					Get(gomock.Any(), clusterRGName, infraID, nil).
 					Return(sdknetwork.LoadBalancersClientGetResponse{LoadBalancer: fakeLoadBalancersGet(0, api.VisibilityPublic)}, nil)
@@ -659,7 +663,70 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 				},
 				EffectiveOutboundIPs: []api.EffectiveOutboundIP{
 					{
-						ID: defaultOutboundIPID,
+						ID:        defaultOutboundIPID,
+						IPAddress: defaultOutboundIPAddress,
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "effectiveOutboundIPs IP addresses are patched when missing",
+			uuids: []string{},
+			m: manager{
+				doc: &api.OpenShiftClusterDocument{
+					Key: strings.ToLower(key),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       key,
+						Location: location,
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:   api.ProvisioningStateUpdating,
+							ArchitectureVersion: api.ArchitectureVersionV2,
+							ClusterProfile: api.ClusterProfile{
+								ResourceGroupID: clusterRGID,
+							},
+							InfraID: infraID,
+							APIServerProfile: api.APIServerProfile{
+								Visibility: api.VisibilityPublic,
+							},
+							NetworkProfile: api.NetworkProfile{
+								OutboundType: api.OutboundTypeLoadbalancer,
+								LoadBalancerProfile: &api.LoadBalancerProfile{
+									ManagedOutboundIPs: &api.ManagedOutboundIPs{
+										Count: 1,
+									},
+									EffectiveOutboundIPs: []api.EffectiveOutboundIP{
+										{
+											ID: defaultOutboundIPID,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			mocks: func(
+				loadBalancersClient *mock_armnetwork.MockLoadBalancersClient,
+				publicIPAddressClient *mock_armnetwork.MockPublicIPAddressesClient,
+				ctx context.Context) {
+				publicIPAddressClient.EXPECT().
+					List(gomock.Any(), clusterRGName, nil).
+					Return(getFakePublicIPList(0), nil).
+					Times(3)
+				loadBalancersClient.EXPECT().
+					Get(gomock.Any(), clusterRGName, infraID, nil).
+					Return(sdknetwork.LoadBalancersClientGetResponse{LoadBalancer: fakeLoadBalancersGet(0, api.VisibilityPublic)}, nil).
+					Times(2)
+			},
+			expectedLoadBalancerProfile: &api.LoadBalancerProfile{
+				ManagedOutboundIPs: &api.ManagedOutboundIPs{
+					Count: 1,
+				},
+				EffectiveOutboundIPs: []api.EffectiveOutboundIP{
+					{
+						ID:        defaultOutboundIPID,
+						IPAddress: defaultOutboundIPAddress,
 					},
 				},
 			},
@@ -692,7 +759,8 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 									},
 									EffectiveOutboundIPs: []api.EffectiveOutboundIP{
 										{
-											ID: defaultOutboundIPID,
+											ID:        defaultOutboundIPID,
+											IPAddress: defaultOutboundIPAddress,
 										},
 									},
 								},
@@ -714,6 +782,9 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 				publicIPAddressClient.EXPECT().
 					List(gomock.Any(), clusterRGName, nil).
 					Return(getFakePublicIPList(1), nil)
+				publicIPAddressClient.EXPECT().
+					List(gomock.Any(), clusterRGName, nil).
+					Return(getFakePublicIPList(1), nil)
 				loadBalancersClient.EXPECT().
 					Get(gomock.Any(), clusterRGName, infraID, nil).
 					Return(sdknetwork.LoadBalancersClientGetResponse{LoadBalancer: fakeLoadBalancersGet(1, api.VisibilityPublic)}, nil)
@@ -724,10 +795,12 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 				},
 				EffectiveOutboundIPs: []api.EffectiveOutboundIP{
 					{
-						ID: defaultOutboundIPID,
+						ID:        defaultOutboundIPID,
+						IPAddress: defaultOutboundIPAddress,
 					},
 					{
-						ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/uuid1-outbound-pip-v4",
+						ID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/uuid1-outbound-pip-v4",
+						IPAddress: "20.0.1.1",
 					},
 				},
 			},
@@ -760,7 +833,8 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 									},
 									EffectiveOutboundIPs: []api.EffectiveOutboundIP{
 										{
-											ID: defaultOutboundIPID,
+											ID:        defaultOutboundIPID,
+											IPAddress: defaultOutboundIPAddress,
 										},
 									},
 								},
@@ -786,6 +860,9 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 				publicIPAddressClient.EXPECT().
 					List(gomock.Any(), clusterRGName, nil).
 					Return(getFakePublicIPList(1), nil)
+				publicIPAddressClient.EXPECT().
+					List(gomock.Any(), clusterRGName, nil).
+					Return(getFakePublicIPList(1), nil)
 				loadBalancersClient.EXPECT().
 					Get(gomock.Any(), clusterRGName, infraID, nil).
 					Return(sdknetwork.LoadBalancersClientGetResponse{LoadBalancer: fakeLoadBalancersGet(1, api.VisibilityPublic)}, nil)
@@ -797,10 +874,12 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 				EffectiveOutboundIPs: []api.EffectiveOutboundIP{
 					{
 
-						ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/infraID-pip-v4",
+						ID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/infraID-pip-v4",
+						IPAddress: defaultOutboundIPAddress,
 					},
 					{
-						ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/uuid1-outbound-pip-v4",
+						ID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/uuid1-outbound-pip-v4",
+						IPAddress: "20.0.1.1",
 					},
 				},
 			},
@@ -833,10 +912,12 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 									},
 									EffectiveOutboundIPs: []api.EffectiveOutboundIP{
 										{
-											ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/infraID-pip-v4",
+											ID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/infraID-pip-v4",
+											IPAddress: defaultOutboundIPAddress,
 										},
 										{
-											ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/uuid1-outbound-pip-v4",
+											ID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/uuid1-outbound-pip-v4",
+											IPAddress: "20.0.1.1",
 										},
 									},
 								},
@@ -860,6 +941,9 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 				publicIPAddressClient.EXPECT().
 					List(gomock.Any(), clusterRGName, nil).
 					Return(getFakePublicIPList(1), nil)
+				publicIPAddressClient.EXPECT().
+					List(gomock.Any(), clusterRGName, nil).
+					Return(getFakePublicIPList(1), nil)
 				loadBalancersClient.EXPECT().
 					Get(gomock.Any(), clusterRGName, infraID, nil).
 					Return(sdknetwork.LoadBalancersClientGetResponse{LoadBalancer: fakeLoadBalancersGet(0, api.VisibilityPublic)}, nil)
@@ -872,7 +956,8 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 				},
 				EffectiveOutboundIPs: []api.EffectiveOutboundIP{
 					{
-						ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/infraID-pip-v4",
+						ID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/infraID-pip-v4",
+						IPAddress: defaultOutboundIPAddress,
 					},
 				},
 			},
@@ -905,7 +990,8 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 									},
 									EffectiveOutboundIPs: []api.EffectiveOutboundIP{
 										{
-											ID: defaultOutboundIPID,
+											ID:        defaultOutboundIPID,
+											IPAddress: defaultOutboundIPAddress,
 										},
 									},
 								},
@@ -942,7 +1028,8 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 				},
 				EffectiveOutboundIPs: []api.EffectiveOutboundIP{
 					{
-						ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/infraID-pip-v4",
+						ID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/infraID-pip-v4",
+						IPAddress: defaultOutboundIPAddress,
 					},
 				},
 			},
@@ -975,13 +1062,16 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 									},
 									EffectiveOutboundIPs: []api.EffectiveOutboundIP{
 										{
-											ID: defaultOutboundIPID,
+											ID:        defaultOutboundIPID,
+											IPAddress: defaultOutboundIPAddress,
 										},
 										{
-											ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/uuid1-outbound-pip-v4",
+											ID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/uuid1-outbound-pip-v4",
+											IPAddress: "20.0.1.1",
 										},
 										{
-											ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/uuid2-outbound-pip-v4",
+											ID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/uuid2-outbound-pip-v4",
+											IPAddress: "20.0.1.2",
 										},
 									},
 								},
@@ -1005,6 +1095,9 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 				publicIPAddressClient.EXPECT().
 					List(gomock.Any(), clusterRGName, nil).
 					Return(getFakePublicIPList(2), nil)
+				publicIPAddressClient.EXPECT().
+					List(gomock.Any(), clusterRGName, nil).
+					Return(getFakePublicIPList(2), nil)
 				loadBalancersClient.EXPECT().
 					Get(gomock.Any(), clusterRGName, infraID, nil).
 					Return(sdknetwork.LoadBalancersClientGetResponse{LoadBalancer: fakeLoadBalancersGet(0, api.VisibilityPublic)}, nil)
@@ -1017,7 +1110,8 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 				},
 				EffectiveOutboundIPs: []api.EffectiveOutboundIP{
 					{
-						ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/infraID-pip-v4",
+						ID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/infraID-pip-v4",
+						IPAddress: defaultOutboundIPAddress,
 					},
 				},
 			},
@@ -1050,7 +1144,8 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 									},
 									EffectiveOutboundIPs: []api.EffectiveOutboundIP{
 										{
-											ID: defaultOutboundIPID,
+											ID:        defaultOutboundIPID,
+											IPAddress: defaultOutboundIPAddress,
 										},
 									},
 								},
@@ -1087,7 +1182,8 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 				},
 				EffectiveOutboundIPs: []api.EffectiveOutboundIP{
 					{
-						ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/infraID-pip-v4",
+						ID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/infraID-pip-v4",
+						IPAddress: defaultOutboundIPAddress,
 					},
 				},
 			},
@@ -1260,15 +1356,22 @@ func getFakePublicIPList(managedCount int) []*sdknetwork.PublicIPAddress {
 		{
 			ID:   &defaultOutboundIPID,
 			Name: &defaultOutboundIPName,
+			Properties: &sdknetwork.PublicIPAddressPropertiesFormat{
+				IPAddress: ptr.To("20.0.0.1"),
+			},
 		},
 		{
 			ID:   ptr.To(clusterRGID + "/providers/Microsoft.Network/publicIPAddresses/infraID-default-v4"),
 			Name: ptr.To("infraID-default-v4"),
+			Properties: &sdknetwork.PublicIPAddressPropertiesFormat{
+				IPAddress: ptr.To("20.0.0.2"),
+			},
 		},
 	}
 	for i := 0; i < managedCount; i++ {
 		ipName := fmt.Sprintf("uuid%d-outbound-pip-v4", i+1)
 		ip := getFakePublicIPAddress(ipName, "eastus")
+		ip.Properties.IPAddress = ptr.To(fmt.Sprintf("20.0.1.%d", i+1))
 		ips = append(ips, &ip)
 	}
 	return ips
@@ -11,6 +11,7 @@ import (
 	apisubnet "github.com/Azure/ARO-RP/pkg/api/util/subnet"
 	"github.com/Azure/ARO-RP/pkg/util/arm"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/networkrules"
 )
 
 func (m *manager) clusterNSG(infraID, location string) *arm.Resource {
@@ -23,19 +24,7 @@ func (m *manager) clusterNSG(infraID, location string) *arm.Resource {
 
 	if m.doc.OpenShiftCluster.Properties.APIServerProfile.Visibility == api.VisibilityPublic {
 		nsg.SecurityRules = &[]mgmtnetwork.SecurityRule{
-			{
-				SecurityRulePropertiesFormat: &mgmtnetwork.SecurityRulePropertiesFormat{
-					Protocol:                 mgmtnetwork.SecurityRuleProtocolTCP,
-					SourcePortRange:          to.StringPtr("*"),
-					DestinationPortRange:     to.StringPtr("6443"),
-					SourceAddressPrefix:      to.StringPtr("*"),
-					DestinationAddressPrefix: to.StringPtr("*"),
-					Access:                   mgmtnetwork.SecurityRuleAccessAllow,
-					Priority:                 to.Int32Ptr(120),
-					Direction:                mgmtnetwork.SecurityRuleDirectionInbound,
-				},
-				Name: to.StringPtr("apiserver_in"),
-			},
+			networkrules.ClusterInboundAPIServer.SecurityRule(),
 		}
 	}
 
@@ -0,0 +1,51 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/validate"
+	"github.com/Azure/ARO-RP/pkg/util/feature"
+)
+
+// populateSecurityType ensures that every new cluster object has the
+// SecurityType field defined on its master and worker profiles
+func (m *manager) populateSecurityType(ctx context.Context) error {
+	securityType := api.SecurityTypeStandard
+	subProperties := m.subscriptionDoc.Subscription.Properties
+	if feature.IsRegisteredForFeature(subProperties, api.FeatureFlagTrustedLaunch) {
+		securityType = api.SecurityTypeTrustedLaunch
+	}
+
+	var err error
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		mp := &doc.OpenShiftCluster.Properties.MasterProfile
+		if mp.SecurityType == "" {
+			mp.SecurityType = securityType
+		}
+
+		for i := range doc.OpenShiftCluster.Properties.WorkerProfiles {
+			wp := &doc.OpenShiftCluster.Properties.WorkerProfiles[i]
+			if wp.SecurityType == "" {
+				switch {
+				case validate.VMSizeIsConfidential(wp.VMSize):
+					wp.SecurityType = api.SecurityTypeConfidentialVM
+				case validate.VMSizeIsArm64(wp.VMSize):
+					wp.SecurityType = api.SecurityTypeStandard
+				default:
+					wp.SecurityType = securityType
+				}
+			}
+
+			if wp.SecurityType == api.SecurityTypeConfidentialVM && wp.SecurityEncryptionType == "" {
+				wp.SecurityEncryptionType = api.SecurityEncryptionTypeVMGuestStateOnly
+			}
+		}
+
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,150 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestPopulateSecurityType(t *testing.T) {
+	ctx := context.Background()
+
+	key := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName"
+
+	trustedLaunchFeature := []api.RegisteredFeatureProfile{
+		{
+			Name:  api.FeatureFlagTrustedLaunch,
+			State: "Registered",
+		},
+	}
+
+	for _, tt := range []struct {
+		name               string
+		features           []api.RegisteredFeatureProfile
+		masterSecurityType api.SecurityType
+		workerVMSize       api.VMSize
+		workerSecurityType api.SecurityType
+		workerEncryption   api.SecurityEncryptionType
+		wantMaster         api.SecurityType
+		wantWorker         api.SecurityType
+		wantEncryption     api.SecurityEncryptionType
+	}{
+		{
+			name:         "not registered for feature",
+			workerVMSize: api.VMSizeStandardD4sV3,
+			wantMaster:   api.SecurityTypeStandard,
+			wantWorker:   api.SecurityTypeStandard,
+		},
+		{
+			name:         "registered for feature",
+			features:     trustedLaunchFeature,
+			workerVMSize: api.VMSizeStandardD4sV3,
+			wantMaster:   api.SecurityTypeTrustedLaunch,
+			wantWorker:   api.SecurityTypeTrustedLaunch,
+		},
+		{
+			name:         "registered for feature, arm64 workers",
+			features:     trustedLaunchFeature,
+			workerVMSize: api.VMSizeStandardD4psV5,
+			wantMaster:   api.SecurityTypeTrustedLaunch,
+			wantWorker:   api.SecurityTypeStandard,
+		},
+		{
+			name:               "registered for feature, security types requested",
+			features:           trustedLaunchFeature,
+			masterSecurityType: api.SecurityTypeStandard,
+			workerVMSize:       api.VMSizeStandardD4sV3,
+			workerSecurityType: api.SecurityTypeStandard,
+			wantMaster:         api.SecurityTypeStandard,
+			wantWorker:         api.SecurityTypeStandard,
+		},
+		{
+			name:               "not registered for feature, security types requested",
+			masterSecurityType: api.SecurityTypeTrustedLaunch,
+			workerVMSize:       api.VMSizeStandardD4sV3,
+			workerSecurityType: api.SecurityTypeTrustedLaunch,
+			wantMaster:         api.SecurityTypeTrustedLaunch,
+			wantWorker:         api.SecurityTypeTrustedLaunch,
+		},
+		{
+			name:           "confidential workers",
+			features:       trustedLaunchFeature,
+			workerVMSize:   api.VMSizeStandardDC4asV5,
+			wantMaster:     api.SecurityTypeTrustedLaunch,
+			wantWorker:     api.SecurityTypeConfidentialVM,
+			wantEncryption: api.SecurityEncryptionTypeVMGuestStateOnly,
+		},
+		{
+			name:               "confidential workers, encryption type requested",
+			workerVMSize:       api.VMSizeStandardEC4asV5,
+			workerSecurityType: api.SecurityTypeConfidentialVM,
+			workerEncryption:   api.SecurityEncryptionTypeDiskWithVMGuestState,
+			wantMaster:         api.SecurityTypeStandard,
+			wantWorker:         api.SecurityTypeConfidentialVM,
+			wantEncryption:     api.SecurityEncryptionTypeDiskWithVMGuestState,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &manager{
+				doc: &api.OpenShiftClusterDocument{
+					Key: strings.ToLower(key),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: key,
+						Properties: api.OpenShiftClusterProperties{
+							MasterProfile: api.MasterProfile{
+								SecurityType: tt.masterSecurityType,
+							},
+							WorkerProfiles: []api.WorkerProfile{
+								{
+									Name:                   "worker",
+									VMSize:                 tt.workerVMSize,
+									SecurityType:           tt.workerSecurityType,
+									SecurityEncryptionType: tt.workerEncryption,
+								},
+							},
+						},
+					},
+				},
+				subscriptionDoc: &api.SubscriptionDocument{
+					Subscription: &api.Subscription{
+						Properties: &api.SubscriptionProperties{
+							RegisteredFeatures: tt.features,
+						},
+					},
+				},
+			}
+
+			openShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(openShiftClustersDatabase)
+			fixture.AddOpenShiftClusterDocuments(m.doc)
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+			m.db = openShiftClustersDatabase
+
+			err = m.populateSecurityType(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, err := range deep.Equal(m.doc.OpenShiftCluster.Properties.MasterProfile.SecurityType, tt.wantMaster) {
+				t.Error(err)
+			}
+			for _, err := range deep.Equal(m.doc.OpenShiftCluster.Properties.WorkerProfiles[0].SecurityType, tt.wantWorker) {
+				t.Error(err)
+			}
+			for _, err := range deep.Equal(m.doc.OpenShiftCluster.Properties.WorkerProfiles[0].SecurityEncryptionType, tt.wantEncryption) {
+				t.Error(err)
+			}
+		})
+	}
+}
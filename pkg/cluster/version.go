@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/validate"
 	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/env"
 )
@@ -25,6 +26,7 @@ type openShiftClusterDocumentVersionerService struct{}
 
 func (service *openShiftClusterDocumentVersionerService) Get(ctx context.Context, doc *api.OpenShiftClusterDocument, dbOpenShiftVersions database.OpenShiftVersions, env env.Interface, installViaHive bool) (*api.OpenShiftVersion, error) {
 	requestedInstallVersion := doc.OpenShiftCluster.Properties.ClusterProfile.Version
+	location := doc.OpenShiftCluster.Location
 
 	// TODO: Refactor to use changefeeds rather than querying the database every time
 	// should also leverage shared changefeed or shared logic
@@ -35,7 +37,7 @@ func (service *openShiftClusterDocumentVersionerService) Get(ctx context.Context
 
 	activeOpenShiftVersions := make([]*api.OpenShiftVersion, 0)
 	for _, doc := range docs.OpenShiftVersionDocuments {
-		if doc.OpenShiftVersion.Properties.Enabled {
+		if doc.OpenShiftVersion.IsEnabledIn(location) {
 			activeOpenShiftVersions = append(activeOpenShiftVersions, doc.OpenShiftVersion)
 		}
 	}
@@ -44,6 +46,14 @@ func (service *openShiftClusterDocumentVersionerService) Get(ctx context.Context
 
 	for _, active := range activeOpenShiftVersions {
 		if requestedInstallVersion == active.Properties.Version {
+			// Arm64 workers need the multi-architecture release payload
+			if validate.HasArm64Workers(doc.OpenShiftCluster) {
+				if active.Properties.MultiArchPullspec == "" {
+					return nil, errUnsupportedVersion
+				}
+				active.Properties.OpenShiftPullspec = active.Properties.MultiArchPullspec
+			}
+
 			if installViaHive {
 				active.Properties.OpenShiftPullspec = strings.Replace(active.Properties.OpenShiftPullspec, "quay.io", env.ACRDomain(), 1)
 			}
@@ -105,9 +105,25 @@ func (m *manager) startContainer(ctx context.Context, version *api.OpenShiftVers
 	})
 	s.WorkDir = "/.azure"
 	s.Entrypoint = []string{"/bin/bash", "-c", "/bin/openshift-install create manifests && /bin/openshift-install create cluster"}
+	s.ResourceLimits = m.resourceLimits
 
 	_, err := runContainer(m.conn, m.log, s)
-	return err
+	if err != nil {
+		return err
+	}
+
+	// stream the installer's logs to the RP log as the install runs, rather
+	// than only dumping them once it has failed
+	m.logsDone = make(chan struct{})
+	go func() {
+		defer close(m.logsDone)
+		err := followContainerLogs(m.conn, m.log.WithField("container", s.Name), s.Name)
+		if err != nil {
+			m.log.Warnf("unable to stream container logs: %v", err)
+		}
+	}()
+
+	return nil
 }
 
 func (m *manager) containerFinished(context.Context) (bool, error) {
@@ -119,7 +135,6 @@ func (m *manager) containerFinished(context.Context) (bool, error) {
 
 	if inspectData.State.Status == "exited" || inspectData.State.Status == "stopped" {
 		if inspectData.State.ExitCode != 0 {
-			getContainerLogs(m.conn, m.log, containerName)
 			return true, fmt.Errorf("container exited with %d", inspectData.State.ExitCode)
 		}
 		m.success = true
@@ -197,7 +212,15 @@ func (m *manager) cleanupContainers(ctx context.Context) error {
 
 	if !m.success {
 		m.log.Infof("cleaning up failed container %s", containerName)
-		getContainerLogs(m.conn, m.log, containerName)
+	}
+
+	// let the log stream drain before the container is removed
+	if m.logsDone != nil {
+		select {
+		case <-m.logsDone:
+		case <-time.After(30 * time.Second):
+			m.log.Warn("timed out waiting for container logs")
+		}
 	}
 
 	_, err := containers.Remove(
@@ -127,6 +127,25 @@ var _ = Describe("Podman", Ordered, func() {
 	})
 })
 
+var _ = DescribeTable("installerResourceLimits",
+	func(cpuLimit, memoryLimit string, wantQuota, wantMemory int64, wantErr string) {
+		limits, err := installerResourceLimits(cpuLimit, memoryLimit)
+		if wantErr != "" {
+			Expect(err).To(MatchError(wantErr))
+			return
+		}
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*limits.CPU.Period).To(BeEquivalentTo(100000))
+		Expect(*limits.CPU.Quota).To(Equal(wantQuota))
+		Expect(*limits.Memory.Limit).To(Equal(wantMemory))
+	},
+	Entry("defaults", "", "", int64(400000), int64(4<<30), ""),
+	Entry("whole CPUs", "2", "6Gi", int64(200000), int64(6<<30), ""),
+	Entry("millicores", "500m", "512Mi", int64(50000), int64(512<<20), ""),
+	Entry("invalid CPU limit", "lots", "", int64(0), int64(0), `invalid installer CPU limit "lots"`),
+	Entry("zero memory limit", "", "0", int64(0), int64(0), `invalid installer memory limit "0"`),
+)
+
 func TestContainerInstall(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "ContainerInstall Suite")
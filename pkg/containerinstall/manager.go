@@ -6,9 +6,12 @@ package containerinstall
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 
+	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/env"
@@ -24,12 +27,25 @@ type manager struct {
 	log  *logrus.Entry
 	env  env.Interface
 
-	clusterUUID string
-	pullSecret  *pullsecret.UserPass
+	clusterUUID    string
+	pullSecret     *pullsecret.UserPass
+	resourceLimits *specs.LinuxResources
+
+	// logsDone is closed once the installer's logs have been fully streamed
+	logsDone chan struct{}
 
 	success bool
 }
 
+const (
+	defaultInstallerCPULimit    = "4"
+	defaultInstallerMemoryLimit = "4Gi"
+
+	// cpuPeriod is the CFS period, in microseconds, which the installer's CPU
+	// quota is expressed against
+	cpuPeriod = 100000
+)
+
 func New(ctx context.Context, log *logrus.Entry, env env.Interface, clusterUUID string) (ContainerInstaller, error) {
 	isDevelopment := env.IsLocalDevelopmentMode()
 	if !isDevelopment {
@@ -41,6 +57,11 @@ func New(ctx context.Context, log *logrus.Entry, env env.Interface, clusterUUID
 		return nil, err
 	}
 
+	resourceLimits, err := installerResourceLimits(os.Getenv("ARO_INSTALLER_CPU_LIMIT"), os.Getenv("ARO_INSTALLER_MEMORY_LIMIT"))
+	if err != nil {
+		return nil, err
+	}
+
 	conn, err := getConnection(ctx)
 	if err != nil {
 		return nil, err
@@ -51,7 +72,44 @@ func New(ctx context.Context, log *logrus.Entry, env env.Interface, clusterUUID
 		log:  log,
 		env:  env,
 
-		clusterUUID: clusterUUID,
-		pullSecret:  pullSecret,
+		clusterUUID:    clusterUUID,
+		pullSecret:     pullSecret,
+		resourceLimits: resourceLimits,
+	}, nil
+}
+
+// installerResourceLimits returns the CPU and memory limits of the installer
+// container.  Limits are given as Kubernetes quantities, e.g. "2" or "500m"
+// CPUs and "4Gi" of memory; empty limits fall back to the defaults.
+func installerResourceLimits(cpuLimit, memoryLimit string) (*specs.LinuxResources, error) {
+	if cpuLimit == "" {
+		cpuLimit = defaultInstallerCPULimit
+	}
+	if memoryLimit == "" {
+		memoryLimit = defaultInstallerMemoryLimit
+	}
+
+	cpu, err := resource.ParseQuantity(cpuLimit)
+	if err != nil || cpu.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid installer CPU limit %q", cpuLimit)
+	}
+
+	memory, err := resource.ParseQuantity(memoryLimit)
+	if err != nil || memory.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid installer memory limit %q", memoryLimit)
+	}
+
+	quota := cpu.MilliValue() * cpuPeriod / 1000
+	period := uint64(cpuPeriod)
+	limit := memory.Value()
+
+	return &specs.LinuxResources{
+		CPU: &specs.LinuxCPU{
+			Quota:  &quota,
+			Period: &period,
+		},
+		Memory: &specs.LinuxMemory{
+			Limit: &limit,
+		},
 	}, nil
 }
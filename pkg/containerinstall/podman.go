@@ -6,6 +6,8 @@ package containerinstall
 import (
 	"context"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/containers/podman/v5/pkg/bindings"
 	"github.com/containers/podman/v5/pkg/bindings/containers"
@@ -13,6 +15,9 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// getConnection connects to the podman socket in ARO_PODMAN_SOCKET, falling
+// back to the user's local socket.  Remote ssh:// sockets authenticate with
+// the private key in ARO_PODMAN_IDENTITY.
 func getConnection(ctx context.Context) (context.Context, error) {
 	socket := os.Getenv("ARO_PODMAN_SOCKET")
 
@@ -21,29 +26,49 @@ func getConnection(ctx context.Context) (context.Context, error) {
 		socket = "unix:" + sock_dir + "/podman/podman.sock"
 	}
 
+	if strings.HasPrefix(socket, "ssh://") {
+		return bindings.NewConnectionWithIdentity(ctx, socket, os.Getenv("ARO_PODMAN_IDENTITY"), false)
+	}
+
 	return bindings.NewConnection(ctx, socket)
 }
 
+// getContainerLogs logs the output of the container so far
 func getContainerLogs(ctx context.Context, log *logrus.Entry, containerName string) error {
+	return streamContainerLogs(ctx, log, containerName, (&containers.LogOptions{}).WithStderr(true).WithStdout(true))
+}
+
+// followContainerLogs logs the output of the container as it is written,
+// returning once the container exits
+func followContainerLogs(ctx context.Context, log *logrus.Entry, containerName string) error {
+	return streamContainerLogs(ctx, log, containerName, (&containers.LogOptions{}).WithStderr(true).WithStdout(true).WithFollow(true))
+}
+
+func streamContainerLogs(ctx context.Context, log *logrus.Entry, containerName string, options *containers.LogOptions) error {
 	stdout, stderr := make(chan string, 1024), make(chan string, 1024)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
 	go func() {
+		defer wg.Done()
 		for v := range stdout {
 			log.Infof("stdout: %s", v)
 		}
 	}()
 
 	go func() {
+		defer wg.Done()
 		for v := range stderr {
 			log.Errorf("stderr: %s", v)
 		}
 	}()
-	err := containers.Logs(
-		ctx,
-		containerName,
-		(&containers.LogOptions{}).WithStderr(true).WithStdout(true),
-		stdout,
-		stderr,
-	)
+
+	err := containers.Logs(ctx, containerName, options, stdout, stderr)
+
+	close(stdout)
+	close(stderr)
+	wg.Wait()
+
 	return err
 }
 
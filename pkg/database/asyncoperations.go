@@ -14,6 +14,10 @@ import (
 	"github.com/Azure/ARO-RP/pkg/util/uuid"
 )
 
+const (
+	AsyncOperationsForClusterQuery = `SELECT * FROM AsyncOperations doc WHERE doc.openShiftClusterKey = @key`
+)
+
 type asyncOperations struct {
 	c             cosmosdb.AsyncOperationDocumentClient
 	uuidGenerator uuid.Generator
@@ -24,6 +28,7 @@ type AsyncOperations interface {
 	Create(context.Context, *api.AsyncOperationDocument) (*api.AsyncOperationDocument, error)
 	Get(context.Context, string) (*api.AsyncOperationDocument, error)
 	Patch(context.Context, string, func(*api.AsyncOperationDocument) error) (*api.AsyncOperationDocument, error)
+	ListByClusterKey(context.Context, string) (*api.AsyncOperationDocuments, error)
 	NewUUID() string
 }
 
@@ -70,7 +75,7 @@ func (c *asyncOperations) Get(ctx context.Context, id string) (*api.AsyncOperati
 func (c *asyncOperations) Patch(ctx context.Context, id string, f func(*api.AsyncOperationDocument) error) (*api.AsyncOperationDocument, error) {
 	var doc *api.AsyncOperationDocument
 
-	err := cosmosdb.RetryOnPreconditionFailed(func() (err error) {
+	err := RetryOnPreconditionFailed(ctx, func() (err error) {
 		doc, err = c.Get(ctx, id)
 		if err != nil {
 			return
@@ -87,3 +92,21 @@ func (c *asyncOperations) Patch(ctx context.Context, id string, f func(*api.Asyn
 
 	return doc, err
 }
+
+// ListByClusterKey returns the retained asynchronous operations on the cluster
+// with the given key
+func (c *asyncOperations) ListByClusterKey(ctx context.Context, key string) (*api.AsyncOperationDocuments, error) {
+	if key != strings.ToLower(key) {
+		return nil, fmt.Errorf("key %q is not lower case", key)
+	}
+
+	return c.c.QueryAll(ctx, "", &cosmosdb.Query{
+		Query: AsyncOperationsForClusterQuery,
+		Parameters: []cosmosdb.Parameter{
+			{
+				Name:  "@key",
+				Value: key,
+			},
+		},
+	}, nil)
+}
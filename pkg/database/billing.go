@@ -61,7 +61,7 @@ func (c *billing) Get(ctx context.Context, id string) (*api.BillingDocument, err
 func (c *billing) patch(ctx context.Context, id string, f func(*api.BillingDocument) error, options *cosmosdb.Options) (*api.BillingDocument, error) {
 	var doc *api.BillingDocument
 
-	err := cosmosdb.RetryOnPreconditionFailed(func() (err error) {
+	err := RetryOnPreconditionFailed(ctx, func() (err error) {
 		doc, err = c.Get(ctx, id)
 		if err != nil {
 			return
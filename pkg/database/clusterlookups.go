@@ -0,0 +1,347 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+)
+
+// ClusterLookupID returns the ID of the ClusterLookupDocument indexing value.
+// Lookup values are resource IDs or client IDs which may contain characters
+// that are not permitted in Cosmos DB document IDs, so they are hashed.
+func ClusterLookupID(kind api.ClusterLookupKind, value string) string {
+	h := sha256.Sum256([]byte(value))
+	return strings.ToLower(string(kind)) + "-" + hex.EncodeToString(h[:])
+}
+
+// clusterLookupValues returns the lookup values maintained for doc, keyed by
+// kind.  Empty values are omitted.
+func clusterLookupValues(doc *api.OpenShiftClusterDocument) map[api.ClusterLookupKind]string {
+	values := map[api.ClusterLookupKind]string{}
+
+	if doc.ClientIDKey != "" {
+		values[api.ClusterLookupKindClientID] = doc.ClientIDKey
+	}
+	if doc.ClusterResourceGroupIDKey != "" {
+		values[api.ClusterLookupKindClusterResourceGroup] = doc.ClusterResourceGroupIDKey
+	}
+
+	return values
+}
+
+// putLookups points the lookups for doc's ClientIDKey and
+// ClusterResourceGroupIDKey at doc.  It is called before the cluster document
+// is written: a lookup pointing at a missing or mismatched cluster is treated
+// as stale by lookup(), so a failed cluster write leaves nothing to clean up.
+func (c *openShiftClusters) putLookups(ctx context.Context, doc *api.OpenShiftClusterDocument) error {
+	for kind, value := range clusterLookupValues(doc) {
+		err := c.putLookup(ctx, kind, value, doc.Key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *openShiftClusters) putLookup(ctx context.Context, kind api.ClusterLookupKind, value, clusterKey string) error {
+	id := ClusterLookupID(kind, value)
+
+	_, err := c.lookups.Create(ctx, id, &api.ClusterLookupDocument{
+		ID:         id,
+		Kind:       kind,
+		Value:      value,
+		ClusterKey: clusterKey,
+	}, nil)
+	if !cosmosdb.IsErrorStatusCode(err, http.StatusConflict) {
+		return err
+	}
+
+	return RetryOnPreconditionFailed(ctx, func() error {
+		lookup, err := c.lookups.Get(ctx, id, id, nil)
+		if err != nil {
+			return err
+		}
+
+		if lookup.ClusterKey == clusterKey {
+			return nil
+		}
+
+		lookup.ClusterKey = clusterKey
+		_, err = c.lookups.Replace(ctx, id, lookup, nil)
+		return err
+	})
+}
+
+// putChangedLookups points the lookups for the values in newValues which
+// differ from oldValues at the cluster.  Like putLookups, it is called before
+// the cluster document is written.
+func (c *openShiftClusters) putChangedLookups(ctx context.Context, clusterKey string, oldValues, newValues map[api.ClusterLookupKind]string) error {
+	for kind, value := range newValues {
+		if oldValues[kind] == value {
+			continue
+		}
+
+		err := c.putLookup(ctx, kind, value, clusterKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteChangedLookups removes the lookups for the values in oldValues which
+// are no longer in newValues.  It is called after the cluster document is
+// written.
+func (c *openShiftClusters) deleteChangedLookups(ctx context.Context, clusterKey string, oldValues, newValues map[api.ClusterLookupKind]string) error {
+	for kind, value := range oldValues {
+		if newValues[kind] == value {
+			continue
+		}
+
+		err := c.deleteLookup(ctx, kind, value, clusterKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteLookups removes the lookups which point at doc.
+func (c *openShiftClusters) deleteLookups(ctx context.Context, doc *api.OpenShiftClusterDocument) error {
+	for kind, value := range clusterLookupValues(doc) {
+		err := c.deleteLookup(ctx, kind, value, doc.Key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteLookup removes the lookup for value if it points at clusterKey.
+// Lookups which have since been repointed at a different cluster are left
+// alone.
+func (c *openShiftClusters) deleteLookup(ctx context.Context, kind api.ClusterLookupKind, value, clusterKey string) error {
+	id := ClusterLookupID(kind, value)
+
+	lookup, err := c.lookups.Get(ctx, id, id, nil)
+	if cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if lookup.ClusterKey != clusterKey {
+		return nil
+	}
+
+	err = c.lookups.Delete(ctx, id, lookup, &cosmosdb.Options{NoETag: true})
+	if err != nil && !cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// BackfillLookups writes the lookups for the cluster key if they are missing
+// or stale.  It re-reads the cluster so that a sweep working from an old
+// listing does not index values which have since changed.
+func (c *openShiftClusters) BackfillLookups(ctx context.Context, key string) error {
+	doc, err := c.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	for kind, value := range clusterLookupValues(doc) {
+		err := c.backfillLookup(ctx, kind, value, doc.Key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// backfillLookup creates the lookup for value, or repoints it at clusterKey if
+// it is stale.  Unlike putLookup, it leaves alone a lookup pointing at another
+// cluster which still holds value, so that it cannot clobber a lookup written
+// by the RP since the cluster was read.
+func (c *openShiftClusters) backfillLookup(ctx context.Context, kind api.ClusterLookupKind, value, clusterKey string) error {
+	id := ClusterLookupID(kind, value)
+
+	_, err := c.lookups.Create(ctx, id, &api.ClusterLookupDocument{
+		ID:         id,
+		Kind:       kind,
+		Value:      value,
+		ClusterKey: clusterKey,
+	}, nil)
+	if !cosmosdb.IsErrorStatusCode(err, http.StatusConflict) {
+		return err
+	}
+
+	return RetryOnPreconditionFailed(ctx, func() error {
+		lookup, err := c.lookups.Get(ctx, id, id, nil)
+		if err != nil {
+			return err
+		}
+
+		if lookup.ClusterKey == clusterKey {
+			return nil
+		}
+
+		doc, err := c.Get(ctx, lookup.ClusterKey)
+		if err == nil && clusterLookupValues(doc)[kind] == value {
+			return nil
+		}
+		if err != nil && !cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+			return err
+		}
+
+		lookup.ClusterKey = clusterKey
+		_, err = c.lookups.Replace(ctx, id, lookup, nil)
+		return err
+	})
+}
+
+// BackfillClusterLookups writes the lookups for every cluster which predates
+// the ClusterLookups collection, or whose lookups are otherwise missing or
+// stale.  Once it has completed successfully, the cross-partition fallback in
+// LookupByClientID and LookupByClusterResourceGroupID can be disabled with
+// DisableLookupFallback.  It is safe to run it again.
+func BackfillClusterLookups(ctx context.Context, log *logrus.Entry, dbOpenShiftClusters OpenShiftClusters) error {
+	var failed int
+
+	i := dbOpenShiftClusters.List("")
+	for {
+		docs, err := i.Next(ctx, -1)
+		if err != nil {
+			return err
+		}
+		if docs == nil {
+			break
+		}
+
+		for _, doc := range docs.OpenShiftClusterDocuments {
+			err = dbOpenShiftClusters.BackfillLookups(ctx, doc.Key)
+			if cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+				// the cluster was deleted since it was listed
+				continue
+			}
+			if err != nil {
+				log.Errorf("backfilling lookups for %s: %s", doc.Key, err)
+				failed++
+				continue
+			}
+
+			log.Infof("backfilled lookups for %s", doc.Key)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to backfill lookups for %d documents", failed)
+	}
+
+	return nil
+}
+
+// DisableLookupFallback stops LookupByClientID and
+// LookupByClusterResourceGroupID from falling back to a cross-partition query
+// when a lookup is missing or stale: they return not found instead.  It must
+// only be called once BackfillClusterLookups has completed, and before c is
+// used.
+func (c *openShiftClusters) DisableLookupFallback() {
+	c.noLookupFallback = true
+}
+
+// LookupByClientID returns the cluster whose ClientIDKey is clientID, using a
+// point read on the ClusterLookups collection where possible.
+func (c *openShiftClusters) LookupByClientID(ctx context.Context, clientID string) (*api.OpenShiftClusterDocument, error) {
+	return c.lookup(ctx, api.ClusterLookupKindClientID, clientID, &cosmosdb.Query{
+		Query: OpenshiftClustersClientIdQuery,
+		Parameters: []cosmosdb.Parameter{
+			{
+				Name:  "@clientID",
+				Value: clientID,
+			},
+		},
+	})
+}
+
+// LookupByClusterResourceGroupID returns the cluster whose
+// ClusterResourceGroupIDKey is resourceGroupID, using a point read on the
+// ClusterLookups collection where possible.
+func (c *openShiftClusters) LookupByClusterResourceGroupID(ctx context.Context, resourceGroupID string) (*api.OpenShiftClusterDocument, error) {
+	return c.lookup(ctx, api.ClusterLookupKindClusterResourceGroup, resourceGroupID, &cosmosdb.Query{
+		Query: OpenshiftClustersResourceGroupQuery,
+		Parameters: []cosmosdb.Parameter{
+			{
+				Name:  "@resourceGroupID",
+				Value: resourceGroupID,
+			},
+		},
+	})
+}
+
+// lookup resolves value via its ClusterLookupDocument.  If the lookup is
+// missing (e.g. the cluster predates the ClusterLookups collection) or stale,
+// it falls back to a cross-partition query and repairs the lookup, unless the
+// fallback has been disabled.
+func (c *openShiftClusters) lookup(ctx context.Context, kind api.ClusterLookupKind, value string, fallback *cosmosdb.Query) (*api.OpenShiftClusterDocument, error) {
+	if value != strings.ToLower(value) {
+		return nil, fmt.Errorf("lookup value %q is not lower case", value)
+	}
+
+	id := ClusterLookupID(kind, value)
+
+	lookup, err := c.lookups.Get(ctx, id, id, nil)
+	switch {
+	case err == nil:
+		doc, err := c.Get(ctx, lookup.ClusterKey)
+		if err == nil && clusterLookupValues(doc)[kind] == value {
+			return doc, nil
+		}
+		if err != nil && !cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+			return nil, err
+		}
+	case !cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, err
+	}
+
+	if c.noLookupFallback {
+		return nil, &cosmosdb.Error{StatusCode: http.StatusNotFound}
+	}
+
+	docs, err := c.c.QueryAll(ctx, "", fallback, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(docs.OpenShiftClusterDocuments) > 1:
+		return nil, fmt.Errorf("read %d documents, expected <= 1", len(docs.OpenShiftClusterDocuments))
+	case len(docs.OpenShiftClusterDocuments) == 1:
+		doc := docs.OpenShiftClusterDocuments[0]
+		_, err = MigrateOpenShiftClusterDocument(doc)
+		if err != nil {
+			return nil, err
+		}
+		return doc, c.putLookup(ctx, kind, value, doc.Key)
+	default:
+		return nil, &cosmosdb.Error{StatusCode: http.StatusNotFound}
+	}
+}
@@ -0,0 +1,249 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/util/uuid"
+)
+
+func fakeClusterMatchQuery(client cosmosdb.OpenShiftClusterDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.OpenShiftClusterDocumentRawIterator {
+	all, err := client.ListAll(context.Background(), nil)
+	if err != nil {
+		return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+	}
+
+	var docs []*api.OpenShiftClusterDocument
+	for _, doc := range all.OpenShiftClusterDocuments {
+		var value string
+		switch query.Parameters[0].Name {
+		case "@key":
+			value = doc.Key
+		case "@clientID":
+			value = doc.ClientIDKey
+		case "@resourceGroupID":
+			value = doc.ClusterResourceGroupIDKey
+		}
+		if value == query.Parameters[0].Value {
+			docs = append(docs, doc)
+		}
+	}
+
+	return cosmosdb.NewFakeOpenShiftClusterDocumentIterator(docs, 0)
+}
+
+func TestClusterLookups(t *testing.T) {
+	ctx := context.Background()
+
+	key := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroup/providers/microsoft.redhatopenshift/openshiftclusters/cluster"
+	clientID := "11111111-1111-1111-1111-111111111111"
+	resourceGroupID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterresourcegroup"
+
+	newDoc := func() *api.OpenShiftClusterDocument {
+		return &api.OpenShiftClusterDocument{
+			ID:                        "id",
+			Key:                       key,
+			ClientIDKey:               clientID,
+			ClusterResourceGroupIDKey: resourceGroupID,
+		}
+	}
+
+	log := logrus.NewEntry(logrus.StandardLogger())
+
+	for _, tt := range []struct {
+		name            string
+		fixture         func(context.Context, OpenShiftClusters, *cosmosdb.FakeOpenShiftClusterDocumentClient, *cosmosdb.FakeClusterLookupDocumentClient) error
+		disableFallback bool
+		wantKey         string
+		wantNotFound    bool
+		wantLookups     int
+	}{
+		{
+			name: "lookups are written on create",
+			fixture: func(ctx context.Context, c OpenShiftClusters, _ *cosmosdb.FakeOpenShiftClusterDocumentClient, _ *cosmosdb.FakeClusterLookupDocumentClient) error {
+				_, err := c.Create(ctx, newDoc())
+				return err
+			},
+			wantKey:     key,
+			wantLookups: 2,
+		},
+		{
+			name: "missing lookups are repaired from the cluster document",
+			fixture: func(ctx context.Context, _ OpenShiftClusters, client *cosmosdb.FakeOpenShiftClusterDocumentClient, _ *cosmosdb.FakeClusterLookupDocumentClient) error {
+				doc := newDoc()
+				_, err := client.Create(ctx, "00000000-0000-0000-0000-000000000000", doc, nil)
+				return err
+			},
+			wantKey:     key,
+			wantLookups: 1,
+		},
+		{
+			name: "stale lookups are ignored",
+			fixture: func(ctx context.Context, _ OpenShiftClusters, _ *cosmosdb.FakeOpenShiftClusterDocumentClient, lookups *cosmosdb.FakeClusterLookupDocumentClient) error {
+				id := ClusterLookupID(api.ClusterLookupKindClientID, clientID)
+				_, err := lookups.Create(ctx, id, &api.ClusterLookupDocument{
+					ID:         id,
+					Kind:       api.ClusterLookupKindClientID,
+					Value:      clientID,
+					ClusterKey: key,
+				}, nil)
+				return err
+			},
+			wantNotFound: true,
+			wantLookups:  1,
+		},
+		{
+			name: "lookups are replaced on update",
+			fixture: func(ctx context.Context, c OpenShiftClusters, _ *cosmosdb.FakeOpenShiftClusterDocumentClient, _ *cosmosdb.FakeClusterLookupDocumentClient) error {
+				_, err := c.Create(ctx, newDoc())
+				if err != nil {
+					return err
+				}
+				_, err = c.Patch(ctx, key, func(doc *api.OpenShiftClusterDocument) error {
+					doc.ClientIDKey = "22222222-2222-2222-2222-222222222222"
+					return nil
+				})
+				return err
+			},
+			wantNotFound: true,
+			wantLookups:  2,
+		},
+		{
+			name: "lookups are removed on delete",
+			fixture: func(ctx context.Context, c OpenShiftClusters, _ *cosmosdb.FakeOpenShiftClusterDocumentClient, _ *cosmosdb.FakeClusterLookupDocumentClient) error {
+				doc, err := c.Create(ctx, newDoc())
+				if err != nil {
+					return err
+				}
+				return c.Delete(ctx, doc)
+			},
+			wantNotFound: true,
+		},
+		{
+			name: "missing lookups are not found without the fallback",
+			fixture: func(ctx context.Context, _ OpenShiftClusters, client *cosmosdb.FakeOpenShiftClusterDocumentClient, _ *cosmosdb.FakeClusterLookupDocumentClient) error {
+				_, err := client.Create(ctx, "00000000-0000-0000-0000-000000000000", newDoc(), nil)
+				return err
+			},
+			disableFallback: true,
+			wantNotFound:    true,
+		},
+		{
+			name: "backfilled lookups are found without the fallback",
+			fixture: func(ctx context.Context, c OpenShiftClusters, client *cosmosdb.FakeOpenShiftClusterDocumentClient, _ *cosmosdb.FakeClusterLookupDocumentClient) error {
+				_, err := client.Create(ctx, "00000000-0000-0000-0000-000000000000", newDoc(), nil)
+				if err != nil {
+					return err
+				}
+				return BackfillClusterLookups(ctx, log, c)
+			},
+			disableFallback: true,
+			wantKey:         key,
+			wantLookups:     2,
+		},
+		{
+			name: "stale lookups are repointed by the backfill",
+			fixture: func(ctx context.Context, c OpenShiftClusters, client *cosmosdb.FakeOpenShiftClusterDocumentClient, lookups *cosmosdb.FakeClusterLookupDocumentClient) error {
+				id := ClusterLookupID(api.ClusterLookupKindClientID, clientID)
+				_, err := lookups.Create(ctx, id, &api.ClusterLookupDocument{
+					ID:         id,
+					Kind:       api.ClusterLookupKindClientID,
+					Value:      clientID,
+					ClusterKey: key + "-deleted",
+				}, nil)
+				if err != nil {
+					return err
+				}
+				_, err = client.Create(ctx, "00000000-0000-0000-0000-000000000000", newDoc(), nil)
+				if err != nil {
+					return err
+				}
+				return BackfillClusterLookups(ctx, log, c)
+			},
+			disableFallback: true,
+			wantKey:         key,
+			wantLookups:     2,
+		},
+		{
+			name: "backfill leaves lookups pointing at another cluster holding the value",
+			fixture: func(ctx context.Context, c OpenShiftClusters, client *cosmosdb.FakeOpenShiftClusterDocumentClient, _ *cosmosdb.FakeClusterLookupDocumentClient) error {
+				_, err := c.Create(ctx, newDoc())
+				if err != nil {
+					return err
+				}
+				other := newDoc()
+				other.ID = "other"
+				other.Key = key + "-other"
+				_, err = client.Create(ctx, "00000000-0000-0000-0000-000000000000", other, nil)
+				if err != nil {
+					return err
+				}
+				return BackfillClusterLookups(ctx, log, c)
+			},
+			disableFallback: true,
+			wantKey:         key,
+			wantLookups:     2,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := NewJSONHandle(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := cosmosdb.NewFakeOpenShiftClusterDocumentClient(h)
+			client.SetQueryHandler(OpenShiftClustersGetQuery, fakeClusterMatchQuery)
+			client.SetQueryHandler(OpenshiftClustersClientIdQuery, fakeClusterMatchQuery)
+			client.SetQueryHandler(OpenshiftClustersResourceGroupQuery, fakeClusterMatchQuery)
+			lookups := cosmosdb.NewFakeClusterLookupDocumentClient(h)
+
+			c := NewOpenShiftClustersWithProvidedClient(client, lookups, nil, "", uuid.DefaultGenerator)
+
+			err = tt.fixture(ctx, c, client, lookups)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.disableFallback {
+				c.DisableLookupFallback()
+
+				unexpectedQuery := func(_ cosmosdb.OpenShiftClusterDocumentClient, query *cosmosdb.Query, _ *cosmosdb.Options) cosmosdb.OpenShiftClusterDocumentRawIterator {
+					t.Errorf("unexpected query %q", query.Query)
+					return cosmosdb.NewFakeOpenShiftClusterDocumentIterator(nil, 0)
+				}
+				client.SetQueryHandler(OpenshiftClustersClientIdQuery, unexpectedQuery)
+				client.SetQueryHandler(OpenshiftClustersResourceGroupQuery, unexpectedQuery)
+			}
+
+			doc, err := c.LookupByClientID(ctx, clientID)
+			if tt.wantNotFound {
+				if !cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+					t.Fatalf("expected not found, got %v", err)
+				}
+			} else {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if doc.Key != tt.wantKey {
+					t.Error(doc.Key)
+				}
+			}
+
+			all, err := lookups.ListAll(ctx, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(all.ClusterLookupDocuments) != tt.wantLookups {
+				t.Errorf("got %d lookups, expected %d", len(all.ClusterLookupDocuments), tt.wantLookups)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// ConsistencyLevel is a Cosmos DB consistency level requested for individual
+// read operations.  Cosmos DB only allows a request to relax the account's
+// default consistency (Strong), never to strengthen it.  Reads at
+// BoundedStaleness or stronger cost twice the request units of weaker levels.
+type ConsistencyLevel string
+
+const (
+	ConsistencyLevelBoundedStaleness ConsistencyLevel = "BoundedStaleness"
+	ConsistencyLevelSession          ConsistencyLevel = "Session"
+	ConsistencyLevelEventual         ConsistencyLevel = "Eventual"
+)
+
+type consistencyLevelContextKey struct{}
+
+// WithConsistencyLevel returns a context which causes database reads made
+// with it to use the given consistency level instead of the account default.
+// It should only be used on read paths which tolerate stale data, e.g. list
+// endpoints and monitoring; frontend and backend critical sections must keep
+// using the default.
+func WithConsistencyLevel(ctx context.Context, level ConsistencyLevel) context.Context {
+	return context.WithValue(ctx, consistencyLevelContextKey{}, level)
+}
+
+func consistencyLevelFromContext(ctx context.Context) (ConsistencyLevel, bool) {
+	level, ok := ctx.Value(consistencyLevelContextKey{}).(ConsistencyLevel)
+	return level, ok
+}
+
+var _ http.RoundTripper = (*consistencyRoundTripper)(nil)
+
+// consistencyRoundTripper sets x-ms-consistency-level on read requests whose
+// context carries a ConsistencyLevel.
+type consistencyRoundTripper struct {
+	tr http.RoundTripper
+}
+
+func (t *consistencyRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	level, ok := consistencyLevelFromContext(req.Context())
+	if ok && isRead(req) {
+		req = req.Clone(req.Context())
+		req.Header.Set("x-ms-consistency-level", string(level))
+	}
+
+	return t.tr.RoundTrip(req)
+}
+
+func isRead(req *http.Request) bool {
+	return req.Method == http.MethodGet ||
+		req.Method == http.MethodPost && strings.EqualFold(req.Header.Get("x-ms-documentdb-isquery"), "true")
+}
@@ -0,0 +1,82 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+type headerRecordingRoundTripper struct {
+	header http.Header
+}
+
+func (rt *headerRecordingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.header = req.Header
+	return &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func TestConsistencyRoundTripper(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		method    string
+		header    http.Header
+		level     ConsistencyLevel
+		wantLevel string
+	}{
+		{
+			name:   "no level requested",
+			method: http.MethodGet,
+		},
+		{
+			name:      "read",
+			method:    http.MethodGet,
+			level:     ConsistencyLevelSession,
+			wantLevel: "Session",
+		},
+		{
+			name:   "query",
+			method: http.MethodPost,
+			header: http.Header{
+				"X-Ms-Documentdb-Isquery": []string{"True"},
+			},
+			level:     ConsistencyLevelEventual,
+			wantLevel: "Eventual",
+		},
+		{
+			name:   "writes are not affected",
+			method: http.MethodPut,
+			level:  ConsistencyLevelSession,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.level != "" {
+				ctx = WithConsistencyLevel(ctx, tt.level)
+			}
+
+			req, err := http.NewRequestWithContext(ctx, tt.method, "https://localhost/dbs/ARO/colls/OpenShiftClusters/docs", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.header != nil {
+				req.Header = tt.header
+			}
+
+			rt := &headerRecordingRoundTripper{}
+			_, err = (&consistencyRoundTripper{tr: rt}).RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := rt.header.Get("x-ms-consistency-level"); got != tt.wantLevel {
+				t.Errorf("got %q, wanted %q", got, tt.wantLevel)
+			}
+			if req.Header.Get("x-ms-consistency-level") != "" {
+				t.Error("original request was modified")
+			}
+		})
+	}
+}
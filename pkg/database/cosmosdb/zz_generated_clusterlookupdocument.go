@@ -0,0 +1,313 @@
+// Code generated by github.com/jim-minter/go-cosmosdb, DO NOT EDIT.
+
+package cosmosdb
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	pkg "github.com/Azure/ARO-RP/pkg/api"
+)
+
+type clusterLookupDocumentClient struct {
+	*databaseClient
+	path string
+}
+
+// ClusterLookupDocumentClient is a clusterLookupDocument client
+type ClusterLookupDocumentClient interface {
+	Create(context.Context, string, *pkg.ClusterLookupDocument, *Options) (*pkg.ClusterLookupDocument, error)
+	List(*Options) ClusterLookupDocumentIterator
+	ListAll(context.Context, *Options) (*pkg.ClusterLookupDocuments, error)
+	Get(context.Context, string, string, *Options) (*pkg.ClusterLookupDocument, error)
+	Replace(context.Context, string, *pkg.ClusterLookupDocument, *Options) (*pkg.ClusterLookupDocument, error)
+	Delete(context.Context, string, *pkg.ClusterLookupDocument, *Options) error
+	Query(string, *Query, *Options) ClusterLookupDocumentRawIterator
+	QueryAll(context.Context, string, *Query, *Options) (*pkg.ClusterLookupDocuments, error)
+	ChangeFeed(*Options) ClusterLookupDocumentIterator
+}
+
+type clusterLookupDocumentChangeFeedIterator struct {
+	*clusterLookupDocumentClient
+	continuation string
+	options      *Options
+}
+
+type clusterLookupDocumentListIterator struct {
+	*clusterLookupDocumentClient
+	continuation string
+	done         bool
+	options      *Options
+}
+
+type clusterLookupDocumentQueryIterator struct {
+	*clusterLookupDocumentClient
+	partitionkey string
+	query        *Query
+	continuation string
+	done         bool
+	options      *Options
+}
+
+// ClusterLookupDocumentIterator is a clusterLookupDocument iterator
+type ClusterLookupDocumentIterator interface {
+	Next(context.Context, int) (*pkg.ClusterLookupDocuments, error)
+	Continuation() string
+}
+
+// ClusterLookupDocumentRawIterator is a clusterLookupDocument raw iterator
+type ClusterLookupDocumentRawIterator interface {
+	ClusterLookupDocumentIterator
+	NextRaw(context.Context, int, interface{}) error
+}
+
+// NewClusterLookupDocumentClient returns a new clusterLookupDocument client
+func NewClusterLookupDocumentClient(collc CollectionClient, collid string) ClusterLookupDocumentClient {
+	return &clusterLookupDocumentClient{
+		databaseClient: collc.(*collectionClient).databaseClient,
+		path:           collc.(*collectionClient).path + "/colls/" + collid,
+	}
+}
+
+func (c *clusterLookupDocumentClient) all(ctx context.Context, i ClusterLookupDocumentIterator) (*pkg.ClusterLookupDocuments, error) {
+	allclusterLookupDocuments := &pkg.ClusterLookupDocuments{}
+
+	for {
+		clusterLookupDocuments, err := i.Next(ctx, -1)
+		if err != nil {
+			return nil, err
+		}
+		if clusterLookupDocuments == nil {
+			break
+		}
+
+		allclusterLookupDocuments.Count += clusterLookupDocuments.Count
+		allclusterLookupDocuments.ResourceID = clusterLookupDocuments.ResourceID
+		allclusterLookupDocuments.ClusterLookupDocuments = append(allclusterLookupDocuments.ClusterLookupDocuments, clusterLookupDocuments.ClusterLookupDocuments...)
+	}
+
+	return allclusterLookupDocuments, nil
+}
+
+func (c *clusterLookupDocumentClient) Create(ctx context.Context, partitionkey string, newclusterLookupDocument *pkg.ClusterLookupDocument, options *Options) (clusterLookupDocument *pkg.ClusterLookupDocument, err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	if options == nil {
+		options = &Options{}
+	}
+	options.NoETag = true
+
+	err = c.setOptions(options, newclusterLookupDocument, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodPost, c.path+"/docs", "docs", c.path, http.StatusCreated, &newclusterLookupDocument, &clusterLookupDocument, headers)
+	return
+}
+
+func (c *clusterLookupDocumentClient) List(options *Options) ClusterLookupDocumentIterator {
+	continuation := ""
+	if options != nil {
+		continuation = options.Continuation
+	}
+
+	return &clusterLookupDocumentListIterator{clusterLookupDocumentClient: c, options: options, continuation: continuation}
+}
+
+func (c *clusterLookupDocumentClient) ListAll(ctx context.Context, options *Options) (*pkg.ClusterLookupDocuments, error) {
+	return c.all(ctx, c.List(options))
+}
+
+func (c *clusterLookupDocumentClient) Get(ctx context.Context, partitionkey, clusterLookupDocumentid string, options *Options) (clusterLookupDocument *pkg.ClusterLookupDocument, err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	err = c.setOptions(options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodGet, c.path+"/docs/"+clusterLookupDocumentid, "docs", c.path+"/docs/"+clusterLookupDocumentid, http.StatusOK, nil, &clusterLookupDocument, headers)
+	return
+}
+
+func (c *clusterLookupDocumentClient) Replace(ctx context.Context, partitionkey string, newclusterLookupDocument *pkg.ClusterLookupDocument, options *Options) (clusterLookupDocument *pkg.ClusterLookupDocument, err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	err = c.setOptions(options, newclusterLookupDocument, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodPut, c.path+"/docs/"+newclusterLookupDocument.ID, "docs", c.path+"/docs/"+newclusterLookupDocument.ID, http.StatusOK, &newclusterLookupDocument, &clusterLookupDocument, headers)
+	return
+}
+
+func (c *clusterLookupDocumentClient) Delete(ctx context.Context, partitionkey string, clusterLookupDocument *pkg.ClusterLookupDocument, options *Options) (err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	err = c.setOptions(options, clusterLookupDocument, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodDelete, c.path+"/docs/"+clusterLookupDocument.ID, "docs", c.path+"/docs/"+clusterLookupDocument.ID, http.StatusNoContent, nil, nil, headers)
+	return
+}
+
+func (c *clusterLookupDocumentClient) Query(partitionkey string, query *Query, options *Options) ClusterLookupDocumentRawIterator {
+	continuation := ""
+	if options != nil {
+		continuation = options.Continuation
+	}
+
+	return &clusterLookupDocumentQueryIterator{clusterLookupDocumentClient: c, partitionkey: partitionkey, query: query, options: options, continuation: continuation}
+}
+
+func (c *clusterLookupDocumentClient) QueryAll(ctx context.Context, partitionkey string, query *Query, options *Options) (*pkg.ClusterLookupDocuments, error) {
+	return c.all(ctx, c.Query(partitionkey, query, options))
+}
+
+func (c *clusterLookupDocumentClient) ChangeFeed(options *Options) ClusterLookupDocumentIterator {
+	continuation := ""
+	if options != nil {
+		continuation = options.Continuation
+	}
+
+	return &clusterLookupDocumentChangeFeedIterator{clusterLookupDocumentClient: c, options: options, continuation: continuation}
+}
+
+func (c *clusterLookupDocumentClient) setOptions(options *Options, clusterLookupDocument *pkg.ClusterLookupDocument, headers http.Header) error {
+	if options == nil {
+		return nil
+	}
+
+	if clusterLookupDocument != nil && !options.NoETag {
+		if clusterLookupDocument.ETag == "" {
+			return ErrETagRequired
+		}
+		headers.Set("If-Match", clusterLookupDocument.ETag)
+	}
+	if len(options.PreTriggers) > 0 {
+		headers.Set("X-Ms-Documentdb-Pre-Trigger-Include", strings.Join(options.PreTriggers, ","))
+	}
+	if len(options.PostTriggers) > 0 {
+		headers.Set("X-Ms-Documentdb-Post-Trigger-Include", strings.Join(options.PostTriggers, ","))
+	}
+	if len(options.PartitionKeyRangeID) > 0 {
+		headers.Set("X-Ms-Documentdb-PartitionKeyRangeID", options.PartitionKeyRangeID)
+	}
+
+	return nil
+}
+
+func (i *clusterLookupDocumentChangeFeedIterator) Next(ctx context.Context, maxItemCount int) (clusterLookupDocuments *pkg.ClusterLookupDocuments, err error) {
+	headers := http.Header{}
+	headers.Set("A-IM", "Incremental feed")
+
+	headers.Set("X-Ms-Max-Item-Count", strconv.Itoa(maxItemCount))
+	if i.continuation != "" {
+		headers.Set("If-None-Match", i.continuation)
+	}
+
+	err = i.setOptions(i.options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = i.do(ctx, http.MethodGet, i.path+"/docs", "docs", i.path, http.StatusOK, nil, &clusterLookupDocuments, headers)
+	if IsErrorStatusCode(err, http.StatusNotModified) {
+		err = nil
+	}
+	if err != nil {
+		return
+	}
+
+	i.continuation = headers.Get("Etag")
+
+	return
+}
+
+func (i *clusterLookupDocumentChangeFeedIterator) Continuation() string {
+	return i.continuation
+}
+
+func (i *clusterLookupDocumentListIterator) Next(ctx context.Context, maxItemCount int) (clusterLookupDocuments *pkg.ClusterLookupDocuments, err error) {
+	if i.done {
+		return
+	}
+
+	headers := http.Header{}
+	headers.Set("X-Ms-Max-Item-Count", strconv.Itoa(maxItemCount))
+	if i.continuation != "" {
+		headers.Set("X-Ms-Continuation", i.continuation)
+	}
+
+	err = i.setOptions(i.options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = i.do(ctx, http.MethodGet, i.path+"/docs", "docs", i.path, http.StatusOK, nil, &clusterLookupDocuments, headers)
+	if err != nil {
+		return
+	}
+
+	i.continuation = headers.Get("X-Ms-Continuation")
+	i.done = i.continuation == ""
+
+	return
+}
+
+func (i *clusterLookupDocumentListIterator) Continuation() string {
+	return i.continuation
+}
+
+func (i *clusterLookupDocumentQueryIterator) Next(ctx context.Context, maxItemCount int) (clusterLookupDocuments *pkg.ClusterLookupDocuments, err error) {
+	err = i.NextRaw(ctx, maxItemCount, &clusterLookupDocuments)
+	return
+}
+
+func (i *clusterLookupDocumentQueryIterator) NextRaw(ctx context.Context, maxItemCount int, raw interface{}) (err error) {
+	if i.done {
+		return
+	}
+
+	headers := http.Header{}
+	headers.Set("X-Ms-Max-Item-Count", strconv.Itoa(maxItemCount))
+	headers.Set("X-Ms-Documentdb-Isquery", "True")
+	headers.Set("Content-Type", "application/query+json")
+	if i.partitionkey != "" {
+		headers.Set("X-Ms-Documentdb-Partitionkey", `["`+i.partitionkey+`"]`)
+	} else {
+		headers.Set("X-Ms-Documentdb-Query-Enablecrosspartition", "True")
+	}
+	if i.continuation != "" {
+		headers.Set("X-Ms-Continuation", i.continuation)
+	}
+
+	err = i.setOptions(i.options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = i.do(ctx, http.MethodPost, i.path+"/docs", "docs", i.path, http.StatusOK, &i.query, &raw, headers)
+	if err != nil {
+		return
+	}
+
+	i.continuation = headers.Get("X-Ms-Continuation")
+	i.done = i.continuation == ""
+
+	return
+}
+
+func (i *clusterLookupDocumentQueryIterator) Continuation() string {
+	return i.continuation
+}
@@ -0,0 +1,360 @@
+// Code generated by github.com/jim-minter/go-cosmosdb, DO NOT EDIT.
+
+package cosmosdb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ugorji/go/codec"
+
+	pkg "github.com/Azure/ARO-RP/pkg/api"
+)
+
+type fakeClusterLookupDocumentTriggerHandler func(context.Context, *pkg.ClusterLookupDocument) error
+type fakeClusterLookupDocumentQueryHandler func(ClusterLookupDocumentClient, *Query, *Options) ClusterLookupDocumentRawIterator
+
+var _ ClusterLookupDocumentClient = &FakeClusterLookupDocumentClient{}
+
+// NewFakeClusterLookupDocumentClient returns a FakeClusterLookupDocumentClient
+func NewFakeClusterLookupDocumentClient(h *codec.JsonHandle) *FakeClusterLookupDocumentClient {
+	return &FakeClusterLookupDocumentClient{
+		clusterLookupDocuments: make(map[string][]byte),
+		triggerHandlers:        make(map[string]fakeClusterLookupDocumentTriggerHandler),
+		queryHandlers:          make(map[string]fakeClusterLookupDocumentQueryHandler),
+		jsonHandle:             h,
+		lock:                   &sync.RWMutex{},
+	}
+}
+
+// FakeClusterLookupDocumentClient is a FakeClusterLookupDocumentClient
+type FakeClusterLookupDocumentClient struct {
+	clusterLookupDocuments map[string][]byte
+	jsonHandle             *codec.JsonHandle
+	lock                   *sync.RWMutex
+	triggerHandlers        map[string]fakeClusterLookupDocumentTriggerHandler
+	queryHandlers          map[string]fakeClusterLookupDocumentQueryHandler
+	sorter                 func([]*pkg.ClusterLookupDocument)
+
+	// returns true if documents conflict
+	conflictChecker func(*pkg.ClusterLookupDocument, *pkg.ClusterLookupDocument) bool
+
+	// err, if not nil, is an error to return when attempting to communicate
+	// with this Client
+	err error
+}
+
+func (c *FakeClusterLookupDocumentClient) decodeClusterLookupDocument(s []byte) (clusterLookupDocument *pkg.ClusterLookupDocument, err error) {
+	err = codec.NewDecoderBytes(s, c.jsonHandle).Decode(&clusterLookupDocument)
+	return
+}
+
+func (c *FakeClusterLookupDocumentClient) encodeClusterLookupDocument(clusterLookupDocument *pkg.ClusterLookupDocument) (b []byte, err error) {
+	err = codec.NewEncoderBytes(&b, c.jsonHandle).Encode(clusterLookupDocument)
+	return
+}
+
+// SetError sets or unsets an error that will be returned on any
+// FakeClusterLookupDocumentClient method invocation
+func (c *FakeClusterLookupDocumentClient) SetError(err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.err = err
+}
+
+// SetSorter sets or unsets a sorter function which will be used to sort values
+// returned by List() for test stability
+func (c *FakeClusterLookupDocumentClient) SetSorter(sorter func([]*pkg.ClusterLookupDocument)) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.sorter = sorter
+}
+
+// SetConflictChecker sets or unsets a function which can be used to validate
+// additional unique keys in a ClusterLookupDocument
+func (c *FakeClusterLookupDocumentClient) SetConflictChecker(conflictChecker func(*pkg.ClusterLookupDocument, *pkg.ClusterLookupDocument) bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.conflictChecker = conflictChecker
+}
+
+// SetTriggerHandler sets or unsets a trigger handler
+func (c *FakeClusterLookupDocumentClient) SetTriggerHandler(triggerName string, trigger fakeClusterLookupDocumentTriggerHandler) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.triggerHandlers[triggerName] = trigger
+}
+
+// SetQueryHandler sets or unsets a query handler
+func (c *FakeClusterLookupDocumentClient) SetQueryHandler(queryName string, query fakeClusterLookupDocumentQueryHandler) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.queryHandlers[queryName] = query
+}
+
+func (c *FakeClusterLookupDocumentClient) deepCopy(clusterLookupDocument *pkg.ClusterLookupDocument) (*pkg.ClusterLookupDocument, error) {
+	b, err := c.encodeClusterLookupDocument(clusterLookupDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.decodeClusterLookupDocument(b)
+}
+
+func (c *FakeClusterLookupDocumentClient) apply(ctx context.Context, partitionkey string, clusterLookupDocument *pkg.ClusterLookupDocument, options *Options, isCreate bool) (*pkg.ClusterLookupDocument, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	clusterLookupDocument, err := c.deepCopy(clusterLookupDocument) // copy now because pretriggers can mutate clusterLookupDocument
+	if err != nil {
+		return nil, err
+	}
+
+	if options != nil {
+		err := c.processPreTriggers(ctx, clusterLookupDocument, options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, exists := c.clusterLookupDocuments[clusterLookupDocument.ID]
+	if isCreate && exists {
+		return nil, &Error{
+			StatusCode: http.StatusConflict,
+			Message:    "Entity with the specified id already exists in the system",
+		}
+	}
+	if !isCreate && !exists {
+		return nil, &Error{StatusCode: http.StatusNotFound}
+	}
+
+	if c.conflictChecker != nil {
+		for id := range c.clusterLookupDocuments {
+			clusterLookupDocumentToCheck, err := c.decodeClusterLookupDocument(c.clusterLookupDocuments[id])
+			if err != nil {
+				return nil, err
+			}
+
+			if c.conflictChecker(clusterLookupDocumentToCheck, clusterLookupDocument) {
+				return nil, &Error{
+					StatusCode: http.StatusConflict,
+					Message:    "Entity with the specified id already exists in the system",
+				}
+			}
+		}
+	}
+
+	b, err := c.encodeClusterLookupDocument(clusterLookupDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	c.clusterLookupDocuments[clusterLookupDocument.ID] = b
+
+	return clusterLookupDocument, nil
+}
+
+// Create creates a ClusterLookupDocument in the database
+func (c *FakeClusterLookupDocumentClient) Create(ctx context.Context, partitionkey string, clusterLookupDocument *pkg.ClusterLookupDocument, options *Options) (*pkg.ClusterLookupDocument, error) {
+	return c.apply(ctx, partitionkey, clusterLookupDocument, options, true)
+}
+
+// Replace replaces a ClusterLookupDocument in the database
+func (c *FakeClusterLookupDocumentClient) Replace(ctx context.Context, partitionkey string, clusterLookupDocument *pkg.ClusterLookupDocument, options *Options) (*pkg.ClusterLookupDocument, error) {
+	return c.apply(ctx, partitionkey, clusterLookupDocument, options, false)
+}
+
+// List returns a ClusterLookupDocumentIterator to list all ClusterLookupDocuments in the database
+func (c *FakeClusterLookupDocumentClient) List(*Options) ClusterLookupDocumentIterator {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return NewFakeClusterLookupDocumentErroringRawIterator(c.err)
+	}
+
+	clusterLookupDocuments := make([]*pkg.ClusterLookupDocument, 0, len(c.clusterLookupDocuments))
+	for _, d := range c.clusterLookupDocuments {
+		r, err := c.decodeClusterLookupDocument(d)
+		if err != nil {
+			return NewFakeClusterLookupDocumentErroringRawIterator(err)
+		}
+		clusterLookupDocuments = append(clusterLookupDocuments, r)
+	}
+
+	if c.sorter != nil {
+		c.sorter(clusterLookupDocuments)
+	}
+
+	return NewFakeClusterLookupDocumentIterator(clusterLookupDocuments, 0)
+}
+
+// ListAll lists all ClusterLookupDocuments in the database
+func (c *FakeClusterLookupDocumentClient) ListAll(ctx context.Context, options *Options) (*pkg.ClusterLookupDocuments, error) {
+	iter := c.List(options)
+	return iter.Next(ctx, -1)
+}
+
+// Get gets a ClusterLookupDocument from the database
+func (c *FakeClusterLookupDocumentClient) Get(ctx context.Context, partitionkey string, id string, options *Options) (*pkg.ClusterLookupDocument, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	clusterLookupDocument, exists := c.clusterLookupDocuments[id]
+	if !exists {
+		return nil, &Error{StatusCode: http.StatusNotFound}
+	}
+
+	return c.decodeClusterLookupDocument(clusterLookupDocument)
+}
+
+// Delete deletes a ClusterLookupDocument from the database
+func (c *FakeClusterLookupDocumentClient) Delete(ctx context.Context, partitionKey string, clusterLookupDocument *pkg.ClusterLookupDocument, options *Options) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.err != nil {
+		return c.err
+	}
+
+	_, exists := c.clusterLookupDocuments[clusterLookupDocument.ID]
+	if !exists {
+		return &Error{StatusCode: http.StatusNotFound}
+	}
+
+	delete(c.clusterLookupDocuments, clusterLookupDocument.ID)
+	return nil
+}
+
+// ChangeFeed is unimplemented
+func (c *FakeClusterLookupDocumentClient) ChangeFeed(*Options) ClusterLookupDocumentIterator {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return NewFakeClusterLookupDocumentErroringRawIterator(c.err)
+	}
+
+	return NewFakeClusterLookupDocumentErroringRawIterator(ErrNotImplemented)
+}
+
+func (c *FakeClusterLookupDocumentClient) processPreTriggers(ctx context.Context, clusterLookupDocument *pkg.ClusterLookupDocument, options *Options) error {
+	for _, triggerName := range options.PreTriggers {
+		if triggerHandler := c.triggerHandlers[triggerName]; triggerHandler != nil {
+			err := triggerHandler(ctx, clusterLookupDocument)
+			if err != nil {
+				return err
+			}
+		} else {
+			return ErrNotImplemented
+		}
+	}
+
+	return nil
+}
+
+// Query calls a query handler to implement database querying
+func (c *FakeClusterLookupDocumentClient) Query(name string, query *Query, options *Options) ClusterLookupDocumentRawIterator {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return NewFakeClusterLookupDocumentErroringRawIterator(c.err)
+	}
+
+	if queryHandler := c.queryHandlers[query.Query]; queryHandler != nil {
+		return queryHandler(c, query, options)
+	}
+
+	return NewFakeClusterLookupDocumentErroringRawIterator(ErrNotImplemented)
+}
+
+// QueryAll calls a query handler to implement database querying
+func (c *FakeClusterLookupDocumentClient) QueryAll(ctx context.Context, partitionkey string, query *Query, options *Options) (*pkg.ClusterLookupDocuments, error) {
+	iter := c.Query("", query, options)
+	return iter.Next(ctx, -1)
+}
+
+func NewFakeClusterLookupDocumentIterator(clusterLookupDocuments []*pkg.ClusterLookupDocument, continuation int) ClusterLookupDocumentRawIterator {
+	return &fakeClusterLookupDocumentIterator{clusterLookupDocuments: clusterLookupDocuments, continuation: continuation}
+}
+
+type fakeClusterLookupDocumentIterator struct {
+	clusterLookupDocuments []*pkg.ClusterLookupDocument
+	continuation           int
+	done                   bool
+}
+
+func (i *fakeClusterLookupDocumentIterator) NextRaw(ctx context.Context, maxItemCount int, out interface{}) error {
+	return ErrNotImplemented
+}
+
+func (i *fakeClusterLookupDocumentIterator) Next(ctx context.Context, maxItemCount int) (*pkg.ClusterLookupDocuments, error) {
+	if i.done {
+		return nil, nil
+	}
+
+	var clusterLookupDocuments []*pkg.ClusterLookupDocument
+	if maxItemCount == -1 {
+		clusterLookupDocuments = i.clusterLookupDocuments[i.continuation:]
+		i.continuation = len(i.clusterLookupDocuments)
+		i.done = true
+	} else {
+		max := i.continuation + maxItemCount
+		if max > len(i.clusterLookupDocuments) {
+			max = len(i.clusterLookupDocuments)
+		}
+		clusterLookupDocuments = i.clusterLookupDocuments[i.continuation:max]
+		i.continuation += max
+		i.done = i.Continuation() == ""
+	}
+
+	return &pkg.ClusterLookupDocuments{
+		ClusterLookupDocuments: clusterLookupDocuments,
+		Count:                  len(clusterLookupDocuments),
+	}, nil
+}
+
+func (i *fakeClusterLookupDocumentIterator) Continuation() string {
+	if i.continuation >= len(i.clusterLookupDocuments) {
+		return ""
+	}
+	return fmt.Sprintf("%d", i.continuation)
+}
+
+// NewFakeClusterLookupDocumentErroringRawIterator returns a ClusterLookupDocumentRawIterator which
+// whose methods return the given error
+func NewFakeClusterLookupDocumentErroringRawIterator(err error) ClusterLookupDocumentRawIterator {
+	return &fakeClusterLookupDocumentErroringRawIterator{err: err}
+}
+
+type fakeClusterLookupDocumentErroringRawIterator struct {
+	err error
+}
+
+func (i *fakeClusterLookupDocumentErroringRawIterator) Next(ctx context.Context, maxItemCount int) (*pkg.ClusterLookupDocuments, error) {
+	return nil, i.err
+}
+
+func (i *fakeClusterLookupDocumentErroringRawIterator) NextRaw(context.Context, int, interface{}) error {
+	return i.err
+}
+
+func (i *fakeClusterLookupDocumentErroringRawIterator) Continuation() string {
+	return ""
+}
@@ -0,0 +1,313 @@
+// Code generated by github.com/jewzaam/go-cosmosdb, DO NOT EDIT.
+
+package cosmosdb
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	pkg "github.com/Azure/ARO-RP/pkg/api"
+)
+
+type supportedVMSizeDocumentClient struct {
+	*databaseClient
+	path string
+}
+
+// SupportedVMSizeDocumentClient is a supportedVMSizeDocument client
+type SupportedVMSizeDocumentClient interface {
+	Create(context.Context, string, *pkg.SupportedVMSizeDocument, *Options) (*pkg.SupportedVMSizeDocument, error)
+	List(*Options) SupportedVMSizeDocumentIterator
+	ListAll(context.Context, *Options) (*pkg.SupportedVMSizeDocuments, error)
+	Get(context.Context, string, string, *Options) (*pkg.SupportedVMSizeDocument, error)
+	Replace(context.Context, string, *pkg.SupportedVMSizeDocument, *Options) (*pkg.SupportedVMSizeDocument, error)
+	Delete(context.Context, string, *pkg.SupportedVMSizeDocument, *Options) error
+	Query(string, *Query, *Options) SupportedVMSizeDocumentRawIterator
+	QueryAll(context.Context, string, *Query, *Options) (*pkg.SupportedVMSizeDocuments, error)
+	ChangeFeed(*Options) SupportedVMSizeDocumentIterator
+}
+
+type supportedVMSizeDocumentChangeFeedIterator struct {
+	*supportedVMSizeDocumentClient
+	continuation string
+	options      *Options
+}
+
+type supportedVMSizeDocumentListIterator struct {
+	*supportedVMSizeDocumentClient
+	continuation string
+	done         bool
+	options      *Options
+}
+
+type supportedVMSizeDocumentQueryIterator struct {
+	*supportedVMSizeDocumentClient
+	partitionkey string
+	query        *Query
+	continuation string
+	done         bool
+	options      *Options
+}
+
+// SupportedVMSizeDocumentIterator is a supportedVMSizeDocument iterator
+type SupportedVMSizeDocumentIterator interface {
+	Next(context.Context, int) (*pkg.SupportedVMSizeDocuments, error)
+	Continuation() string
+}
+
+// SupportedVMSizeDocumentRawIterator is a supportedVMSizeDocument raw iterator
+type SupportedVMSizeDocumentRawIterator interface {
+	SupportedVMSizeDocumentIterator
+	NextRaw(context.Context, int, interface{}) error
+}
+
+// NewSupportedVMSizeDocumentClient returns a new supportedVMSizeDocument client
+func NewSupportedVMSizeDocumentClient(collc CollectionClient, collid string) SupportedVMSizeDocumentClient {
+	return &supportedVMSizeDocumentClient{
+		databaseClient: collc.(*collectionClient).databaseClient,
+		path:           collc.(*collectionClient).path + "/colls/" + collid,
+	}
+}
+
+func (c *supportedVMSizeDocumentClient) all(ctx context.Context, i SupportedVMSizeDocumentIterator) (*pkg.SupportedVMSizeDocuments, error) {
+	allsupportedVMSizeDocuments := &pkg.SupportedVMSizeDocuments{}
+
+	for {
+		supportedVMSizeDocuments, err := i.Next(ctx, -1)
+		if err != nil {
+			return nil, err
+		}
+		if supportedVMSizeDocuments == nil {
+			break
+		}
+
+		allsupportedVMSizeDocuments.Count += supportedVMSizeDocuments.Count
+		allsupportedVMSizeDocuments.ResourceID = supportedVMSizeDocuments.ResourceID
+		allsupportedVMSizeDocuments.SupportedVMSizeDocuments = append(allsupportedVMSizeDocuments.SupportedVMSizeDocuments, supportedVMSizeDocuments.SupportedVMSizeDocuments...)
+	}
+
+	return allsupportedVMSizeDocuments, nil
+}
+
+func (c *supportedVMSizeDocumentClient) Create(ctx context.Context, partitionkey string, newsupportedVMSizeDocument *pkg.SupportedVMSizeDocument, options *Options) (supportedVMSizeDocument *pkg.SupportedVMSizeDocument, err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	if options == nil {
+		options = &Options{}
+	}
+	options.NoETag = true
+
+	err = c.setOptions(options, newsupportedVMSizeDocument, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodPost, c.path+"/docs", "docs", c.path, http.StatusCreated, &newsupportedVMSizeDocument, &supportedVMSizeDocument, headers)
+	return
+}
+
+func (c *supportedVMSizeDocumentClient) List(options *Options) SupportedVMSizeDocumentIterator {
+	continuation := ""
+	if options != nil {
+		continuation = options.Continuation
+	}
+
+	return &supportedVMSizeDocumentListIterator{supportedVMSizeDocumentClient: c, options: options, continuation: continuation}
+}
+
+func (c *supportedVMSizeDocumentClient) ListAll(ctx context.Context, options *Options) (*pkg.SupportedVMSizeDocuments, error) {
+	return c.all(ctx, c.List(options))
+}
+
+func (c *supportedVMSizeDocumentClient) Get(ctx context.Context, partitionkey, supportedVMSizeDocumentid string, options *Options) (supportedVMSizeDocument *pkg.SupportedVMSizeDocument, err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	err = c.setOptions(options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodGet, c.path+"/docs/"+supportedVMSizeDocumentid, "docs", c.path+"/docs/"+supportedVMSizeDocumentid, http.StatusOK, nil, &supportedVMSizeDocument, headers)
+	return
+}
+
+func (c *supportedVMSizeDocumentClient) Replace(ctx context.Context, partitionkey string, newsupportedVMSizeDocument *pkg.SupportedVMSizeDocument, options *Options) (supportedVMSizeDocument *pkg.SupportedVMSizeDocument, err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	err = c.setOptions(options, newsupportedVMSizeDocument, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodPut, c.path+"/docs/"+newsupportedVMSizeDocument.ID, "docs", c.path+"/docs/"+newsupportedVMSizeDocument.ID, http.StatusOK, &newsupportedVMSizeDocument, &supportedVMSizeDocument, headers)
+	return
+}
+
+func (c *supportedVMSizeDocumentClient) Delete(ctx context.Context, partitionkey string, supportedVMSizeDocument *pkg.SupportedVMSizeDocument, options *Options) (err error) {
+	headers := http.Header{}
+	headers.Set("X-Ms-Documentdb-Partitionkey", `["`+partitionkey+`"]`)
+
+	err = c.setOptions(options, supportedVMSizeDocument, headers)
+	if err != nil {
+		return
+	}
+
+	err = c.do(ctx, http.MethodDelete, c.path+"/docs/"+supportedVMSizeDocument.ID, "docs", c.path+"/docs/"+supportedVMSizeDocument.ID, http.StatusNoContent, nil, nil, headers)
+	return
+}
+
+func (c *supportedVMSizeDocumentClient) Query(partitionkey string, query *Query, options *Options) SupportedVMSizeDocumentRawIterator {
+	continuation := ""
+	if options != nil {
+		continuation = options.Continuation
+	}
+
+	return &supportedVMSizeDocumentQueryIterator{supportedVMSizeDocumentClient: c, partitionkey: partitionkey, query: query, options: options, continuation: continuation}
+}
+
+func (c *supportedVMSizeDocumentClient) QueryAll(ctx context.Context, partitionkey string, query *Query, options *Options) (*pkg.SupportedVMSizeDocuments, error) {
+	return c.all(ctx, c.Query(partitionkey, query, options))
+}
+
+func (c *supportedVMSizeDocumentClient) ChangeFeed(options *Options) SupportedVMSizeDocumentIterator {
+	continuation := ""
+	if options != nil {
+		continuation = options.Continuation
+	}
+
+	return &supportedVMSizeDocumentChangeFeedIterator{supportedVMSizeDocumentClient: c, options: options, continuation: continuation}
+}
+
+func (c *supportedVMSizeDocumentClient) setOptions(options *Options, supportedVMSizeDocument *pkg.SupportedVMSizeDocument, headers http.Header) error {
+	if options == nil {
+		return nil
+	}
+
+	if supportedVMSizeDocument != nil && !options.NoETag {
+		if supportedVMSizeDocument.ETag == "" {
+			return ErrETagRequired
+		}
+		headers.Set("If-Match", supportedVMSizeDocument.ETag)
+	}
+	if len(options.PreTriggers) > 0 {
+		headers.Set("X-Ms-Documentdb-Pre-Trigger-Include", strings.Join(options.PreTriggers, ","))
+	}
+	if len(options.PostTriggers) > 0 {
+		headers.Set("X-Ms-Documentdb-Post-Trigger-Include", strings.Join(options.PostTriggers, ","))
+	}
+	if len(options.PartitionKeyRangeID) > 0 {
+		headers.Set("X-Ms-Documentdb-PartitionKeyRangeID", options.PartitionKeyRangeID)
+	}
+
+	return nil
+}
+
+func (i *supportedVMSizeDocumentChangeFeedIterator) Next(ctx context.Context, maxItemCount int) (supportedVMSizeDocuments *pkg.SupportedVMSizeDocuments, err error) {
+	headers := http.Header{}
+	headers.Set("A-IM", "Incremental feed")
+
+	headers.Set("X-Ms-Max-Item-Count", strconv.Itoa(maxItemCount))
+	if i.continuation != "" {
+		headers.Set("If-None-Match", i.continuation)
+	}
+
+	err = i.setOptions(i.options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = i.do(ctx, http.MethodGet, i.path+"/docs", "docs", i.path, http.StatusOK, nil, &supportedVMSizeDocuments, headers)
+	if IsErrorStatusCode(err, http.StatusNotModified) {
+		err = nil
+	}
+	if err != nil {
+		return
+	}
+
+	i.continuation = headers.Get("Etag")
+
+	return
+}
+
+func (i *supportedVMSizeDocumentChangeFeedIterator) Continuation() string {
+	return i.continuation
+}
+
+func (i *supportedVMSizeDocumentListIterator) Next(ctx context.Context, maxItemCount int) (supportedVMSizeDocuments *pkg.SupportedVMSizeDocuments, err error) {
+	if i.done {
+		return
+	}
+
+	headers := http.Header{}
+	headers.Set("X-Ms-Max-Item-Count", strconv.Itoa(maxItemCount))
+	if i.continuation != "" {
+		headers.Set("X-Ms-Continuation", i.continuation)
+	}
+
+	err = i.setOptions(i.options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = i.do(ctx, http.MethodGet, i.path+"/docs", "docs", i.path, http.StatusOK, nil, &supportedVMSizeDocuments, headers)
+	if err != nil {
+		return
+	}
+
+	i.continuation = headers.Get("X-Ms-Continuation")
+	i.done = i.continuation == ""
+
+	return
+}
+
+func (i *supportedVMSizeDocumentListIterator) Continuation() string {
+	return i.continuation
+}
+
+func (i *supportedVMSizeDocumentQueryIterator) Next(ctx context.Context, maxItemCount int) (supportedVMSizeDocuments *pkg.SupportedVMSizeDocuments, err error) {
+	err = i.NextRaw(ctx, maxItemCount, &supportedVMSizeDocuments)
+	return
+}
+
+func (i *supportedVMSizeDocumentQueryIterator) NextRaw(ctx context.Context, maxItemCount int, raw interface{}) (err error) {
+	if i.done {
+		return
+	}
+
+	headers := http.Header{}
+	headers.Set("X-Ms-Max-Item-Count", strconv.Itoa(maxItemCount))
+	headers.Set("X-Ms-Documentdb-Isquery", "True")
+	headers.Set("Content-Type", "application/query+json")
+	if i.partitionkey != "" {
+		headers.Set("X-Ms-Documentdb-Partitionkey", `["`+i.partitionkey+`"]`)
+	} else {
+		headers.Set("X-Ms-Documentdb-Query-Enablecrosspartition", "True")
+	}
+	if i.continuation != "" {
+		headers.Set("X-Ms-Continuation", i.continuation)
+	}
+
+	err = i.setOptions(i.options, nil, headers)
+	if err != nil {
+		return
+	}
+
+	err = i.do(ctx, http.MethodPost, i.path+"/docs", "docs", i.path, http.StatusOK, &i.query, &raw, headers)
+	if err != nil {
+		return
+	}
+
+	i.continuation = headers.Get("X-Ms-Continuation")
+	i.done = i.continuation == ""
+
+	return
+}
+
+func (i *supportedVMSizeDocumentQueryIterator) Continuation() string {
+	return i.continuation
+}
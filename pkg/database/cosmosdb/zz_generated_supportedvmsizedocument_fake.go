@@ -0,0 +1,361 @@
+// Code generated by github.com/jewzaam/go-cosmosdb, DO NOT EDIT.
+
+package cosmosdb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ugorji/go/codec"
+
+	pkg "github.com/Azure/ARO-RP/pkg/api"
+)
+
+type fakeSupportedVMSizeDocumentTriggerHandler func(context.Context, *pkg.SupportedVMSizeDocument) error
+type fakeSupportedVMSizeDocumentQueryHandler func(SupportedVMSizeDocumentClient, *Query, *Options) SupportedVMSizeDocumentRawIterator
+
+var _ SupportedVMSizeDocumentClient = &FakeSupportedVMSizeDocumentClient{}
+
+// NewFakeSupportedVMSizeDocumentClient returns a FakeSupportedVMSizeDocumentClient
+func NewFakeSupportedVMSizeDocumentClient(h *codec.JsonHandle) *FakeSupportedVMSizeDocumentClient {
+	return &FakeSupportedVMSizeDocumentClient{
+		jsonHandle:               h,
+		supportedVMSizeDocuments: make(map[string]*pkg.SupportedVMSizeDocument),
+		triggerHandlers:          make(map[string]fakeSupportedVMSizeDocumentTriggerHandler),
+		queryHandlers:            make(map[string]fakeSupportedVMSizeDocumentQueryHandler),
+	}
+}
+
+// FakeSupportedVMSizeDocumentClient is a FakeSupportedVMSizeDocumentClient
+type FakeSupportedVMSizeDocumentClient struct {
+	lock                     sync.RWMutex
+	jsonHandle               *codec.JsonHandle
+	supportedVMSizeDocuments map[string]*pkg.SupportedVMSizeDocument
+	triggerHandlers          map[string]fakeSupportedVMSizeDocumentTriggerHandler
+	queryHandlers            map[string]fakeSupportedVMSizeDocumentQueryHandler
+	sorter                   func([]*pkg.SupportedVMSizeDocument)
+	etag                     int
+
+	// returns true if documents conflict
+	conflictChecker func(*pkg.SupportedVMSizeDocument, *pkg.SupportedVMSizeDocument) bool
+
+	// err, if not nil, is an error to return when attempting to communicate
+	// with this Client
+	err error
+}
+
+// SetError sets or unsets an error that will be returned on any
+// FakeSupportedVMSizeDocumentClient method invocation
+func (c *FakeSupportedVMSizeDocumentClient) SetError(err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.err = err
+}
+
+// SetSorter sets or unsets a sorter function which will be used to sort values
+// returned by List() for test stability
+func (c *FakeSupportedVMSizeDocumentClient) SetSorter(sorter func([]*pkg.SupportedVMSizeDocument)) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.sorter = sorter
+}
+
+// SetConflictChecker sets or unsets a function which can be used to validate
+// additional unique keys in a SupportedVMSizeDocument
+func (c *FakeSupportedVMSizeDocumentClient) SetConflictChecker(conflictChecker func(*pkg.SupportedVMSizeDocument, *pkg.SupportedVMSizeDocument) bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.conflictChecker = conflictChecker
+}
+
+// SetTriggerHandler sets or unsets a trigger handler
+func (c *FakeSupportedVMSizeDocumentClient) SetTriggerHandler(triggerName string, trigger fakeSupportedVMSizeDocumentTriggerHandler) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.triggerHandlers[triggerName] = trigger
+}
+
+// SetQueryHandler sets or unsets a query handler
+func (c *FakeSupportedVMSizeDocumentClient) SetQueryHandler(queryName string, query fakeSupportedVMSizeDocumentQueryHandler) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.queryHandlers[queryName] = query
+}
+
+func (c *FakeSupportedVMSizeDocumentClient) deepCopy(supportedVMSizeDocument *pkg.SupportedVMSizeDocument) (*pkg.SupportedVMSizeDocument, error) {
+	var b []byte
+	err := codec.NewEncoderBytes(&b, c.jsonHandle).Encode(supportedVMSizeDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	supportedVMSizeDocument = nil
+	err = codec.NewDecoderBytes(b, c.jsonHandle).Decode(&supportedVMSizeDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	return supportedVMSizeDocument, nil
+}
+
+func (c *FakeSupportedVMSizeDocumentClient) apply(ctx context.Context, partitionkey string, supportedVMSizeDocument *pkg.SupportedVMSizeDocument, options *Options, isCreate bool) (*pkg.SupportedVMSizeDocument, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	supportedVMSizeDocument, err := c.deepCopy(supportedVMSizeDocument) // copy now because pretriggers can mutate supportedVMSizeDocument
+	if err != nil {
+		return nil, err
+	}
+
+	if options != nil {
+		err := c.processPreTriggers(ctx, supportedVMSizeDocument, options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	existingSupportedVMSizeDocument, exists := c.supportedVMSizeDocuments[supportedVMSizeDocument.ID]
+	if isCreate && exists {
+		return nil, &Error{
+			StatusCode: http.StatusConflict,
+			Message:    "Entity with the specified id already exists in the system",
+		}
+	}
+	if !isCreate {
+		if !exists {
+			return nil, &Error{StatusCode: http.StatusNotFound}
+		}
+
+		if supportedVMSizeDocument.ETag != existingSupportedVMSizeDocument.ETag {
+			return nil, &Error{StatusCode: http.StatusPreconditionFailed}
+		}
+	}
+
+	if c.conflictChecker != nil {
+		for _, supportedVMSizeDocumentToCheck := range c.supportedVMSizeDocuments {
+			if c.conflictChecker(supportedVMSizeDocumentToCheck, supportedVMSizeDocument) {
+				return nil, &Error{
+					StatusCode: http.StatusConflict,
+					Message:    "Entity with the specified id already exists in the system",
+				}
+			}
+		}
+	}
+
+	supportedVMSizeDocument.ETag = fmt.Sprint(c.etag)
+	c.etag++
+
+	c.supportedVMSizeDocuments[supportedVMSizeDocument.ID] = supportedVMSizeDocument
+
+	return c.deepCopy(supportedVMSizeDocument)
+}
+
+// Create creates a SupportedVMSizeDocument in the database
+func (c *FakeSupportedVMSizeDocumentClient) Create(ctx context.Context, partitionkey string, supportedVMSizeDocument *pkg.SupportedVMSizeDocument, options *Options) (*pkg.SupportedVMSizeDocument, error) {
+	return c.apply(ctx, partitionkey, supportedVMSizeDocument, options, true)
+}
+
+// Replace replaces a SupportedVMSizeDocument in the database
+func (c *FakeSupportedVMSizeDocumentClient) Replace(ctx context.Context, partitionkey string, supportedVMSizeDocument *pkg.SupportedVMSizeDocument, options *Options) (*pkg.SupportedVMSizeDocument, error) {
+	return c.apply(ctx, partitionkey, supportedVMSizeDocument, options, false)
+}
+
+// List returns a SupportedVMSizeDocumentIterator to list all SupportedVMSizeDocuments in the database
+func (c *FakeSupportedVMSizeDocumentClient) List(*Options) SupportedVMSizeDocumentIterator {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return NewFakeSupportedVMSizeDocumentErroringRawIterator(c.err)
+	}
+
+	supportedVMSizeDocuments := make([]*pkg.SupportedVMSizeDocument, 0, len(c.supportedVMSizeDocuments))
+	for _, supportedVMSizeDocument := range c.supportedVMSizeDocuments {
+		supportedVMSizeDocument, err := c.deepCopy(supportedVMSizeDocument)
+		if err != nil {
+			return NewFakeSupportedVMSizeDocumentErroringRawIterator(err)
+		}
+		supportedVMSizeDocuments = append(supportedVMSizeDocuments, supportedVMSizeDocument)
+	}
+
+	if c.sorter != nil {
+		c.sorter(supportedVMSizeDocuments)
+	}
+
+	return NewFakeSupportedVMSizeDocumentIterator(supportedVMSizeDocuments, 0)
+}
+
+// ListAll lists all SupportedVMSizeDocuments in the database
+func (c *FakeSupportedVMSizeDocumentClient) ListAll(ctx context.Context, options *Options) (*pkg.SupportedVMSizeDocuments, error) {
+	iter := c.List(options)
+	return iter.Next(ctx, -1)
+}
+
+// Get gets a SupportedVMSizeDocument from the database
+func (c *FakeSupportedVMSizeDocumentClient) Get(ctx context.Context, partitionkey string, id string, options *Options) (*pkg.SupportedVMSizeDocument, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	supportedVMSizeDocument, exists := c.supportedVMSizeDocuments[id]
+	if !exists {
+		return nil, &Error{StatusCode: http.StatusNotFound}
+	}
+
+	return c.deepCopy(supportedVMSizeDocument)
+}
+
+// Delete deletes a SupportedVMSizeDocument from the database
+func (c *FakeSupportedVMSizeDocumentClient) Delete(ctx context.Context, partitionKey string, supportedVMSizeDocument *pkg.SupportedVMSizeDocument, options *Options) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.err != nil {
+		return c.err
+	}
+
+	_, exists := c.supportedVMSizeDocuments[supportedVMSizeDocument.ID]
+	if !exists {
+		return &Error{StatusCode: http.StatusNotFound}
+	}
+
+	delete(c.supportedVMSizeDocuments, supportedVMSizeDocument.ID)
+	return nil
+}
+
+// ChangeFeed is unimplemented
+func (c *FakeSupportedVMSizeDocumentClient) ChangeFeed(*Options) SupportedVMSizeDocumentIterator {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return NewFakeSupportedVMSizeDocumentErroringRawIterator(c.err)
+	}
+
+	return NewFakeSupportedVMSizeDocumentErroringRawIterator(ErrNotImplemented)
+}
+
+func (c *FakeSupportedVMSizeDocumentClient) processPreTriggers(ctx context.Context, supportedVMSizeDocument *pkg.SupportedVMSizeDocument, options *Options) error {
+	for _, triggerName := range options.PreTriggers {
+		if triggerHandler := c.triggerHandlers[triggerName]; triggerHandler != nil {
+			c.lock.Unlock()
+			err := triggerHandler(ctx, supportedVMSizeDocument)
+			c.lock.Lock()
+			if err != nil {
+				return err
+			}
+		} else {
+			return ErrNotImplemented
+		}
+	}
+
+	return nil
+}
+
+// Query calls a query handler to implement database querying
+func (c *FakeSupportedVMSizeDocumentClient) Query(name string, query *Query, options *Options) SupportedVMSizeDocumentRawIterator {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.err != nil {
+		return NewFakeSupportedVMSizeDocumentErroringRawIterator(c.err)
+	}
+
+	if queryHandler := c.queryHandlers[query.Query]; queryHandler != nil {
+		c.lock.RUnlock()
+		i := queryHandler(c, query, options)
+		c.lock.RLock()
+		return i
+	}
+
+	return NewFakeSupportedVMSizeDocumentErroringRawIterator(ErrNotImplemented)
+}
+
+// QueryAll calls a query handler to implement database querying
+func (c *FakeSupportedVMSizeDocumentClient) QueryAll(ctx context.Context, partitionkey string, query *Query, options *Options) (*pkg.SupportedVMSizeDocuments, error) {
+	iter := c.Query("", query, options)
+	return iter.Next(ctx, -1)
+}
+
+func NewFakeSupportedVMSizeDocumentIterator(supportedVMSizeDocuments []*pkg.SupportedVMSizeDocument, continuation int) SupportedVMSizeDocumentRawIterator {
+	return &fakeSupportedVMSizeDocumentIterator{supportedVMSizeDocuments: supportedVMSizeDocuments, continuation: continuation}
+}
+
+type fakeSupportedVMSizeDocumentIterator struct {
+	supportedVMSizeDocuments []*pkg.SupportedVMSizeDocument
+	continuation             int
+	done                     bool
+}
+
+func (i *fakeSupportedVMSizeDocumentIterator) NextRaw(ctx context.Context, maxItemCount int, out interface{}) error {
+	return ErrNotImplemented
+}
+
+func (i *fakeSupportedVMSizeDocumentIterator) Next(ctx context.Context, maxItemCount int) (*pkg.SupportedVMSizeDocuments, error) {
+	if i.done {
+		return nil, nil
+	}
+
+	var supportedVMSizeDocuments []*pkg.SupportedVMSizeDocument
+	if maxItemCount == -1 {
+		supportedVMSizeDocuments = i.supportedVMSizeDocuments[i.continuation:]
+		i.continuation = len(i.supportedVMSizeDocuments)
+		i.done = true
+	} else {
+		max := i.continuation + maxItemCount
+		if max > len(i.supportedVMSizeDocuments) {
+			max = len(i.supportedVMSizeDocuments)
+		}
+		supportedVMSizeDocuments = i.supportedVMSizeDocuments[i.continuation:max]
+		i.continuation += max
+		i.done = i.Continuation() == ""
+	}
+
+	return &pkg.SupportedVMSizeDocuments{
+		SupportedVMSizeDocuments: supportedVMSizeDocuments,
+		Count:                    len(supportedVMSizeDocuments),
+	}, nil
+}
+
+func (i *fakeSupportedVMSizeDocumentIterator) Continuation() string {
+	if i.continuation >= len(i.supportedVMSizeDocuments) {
+		return ""
+	}
+	return fmt.Sprintf("%d", i.continuation)
+}
+
+// NewFakeSupportedVMSizeDocumentErroringRawIterator returns a SupportedVMSizeDocumentRawIterator which
+// whose methods return the given error
+func NewFakeSupportedVMSizeDocumentErroringRawIterator(err error) SupportedVMSizeDocumentRawIterator {
+	return &fakeSupportedVMSizeDocumentErroringRawIterator{err: err}
+}
+
+type fakeSupportedVMSizeDocumentErroringRawIterator struct {
+	err error
+}
+
+func (i *fakeSupportedVMSizeDocumentErroringRawIterator) Next(ctx context.Context, maxItemCount int) (*pkg.SupportedVMSizeDocuments, error) {
+	return nil, i.err
+}
+
+func (i *fakeSupportedVMSizeDocumentErroringRawIterator) NextRaw(context.Context, int, interface{}) error {
+	return i.err
+}
+
+func (i *fakeSupportedVMSizeDocumentErroringRawIterator) Continuation() string {
+	return ""
+}
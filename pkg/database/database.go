@@ -14,6 +14,7 @@ import (
 	sdkcosmos "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cosmos/armcosmos/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/ugorji/go/codec"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
@@ -27,14 +28,17 @@ import (
 const (
 	collAsyncOperations                 = "AsyncOperations"
 	collBilling                         = "Billing"
+	collClusterLookups                  = "ClusterLookups"
 	collClusterManager                  = "ClusterManagerConfigurations"
 	collGateway                         = "Gateway"
 	collMonitors                        = "Monitors"
 	collOpenShiftClusters               = "OpenShiftClusters"
+	collOpenShiftClusterTombstones      = "OpenShiftClusterTombstones"
 	collOpenShiftVersion                = "OpenShiftVersions"
 	collPlatformWorkloadIdentityRoleSet = "PlatformWorkloadIdentityRoleSets"
 	collPortal                          = "Portal"
 	collSubscriptions                   = "Subscriptions"
+	collSupportedVMSizes                = "SupportedVMSizes"
 	collMaintenanceManifests            = "MaintenanceManifests"
 )
 
@@ -44,16 +48,25 @@ func NewDatabaseClient(log *logrus.Entry, _env env.Core, authorizer cosmosdb.Aut
 		return nil, err
 	}
 
-	c := &http.Client{
-		Transport: dbmetrics.New(log, &http.Transport{
-			// disable HTTP/2 for now: https://github.com/golang/go/issues/36026
-			TLSNextProto:        map[string]func(string, *tls.Conn) http.RoundTripper{},
-			MaxIdleConnsPerHost: 20,
-		}, m),
+	return cosmosdb.NewDatabaseClient(log, newHTTPClient(log, m, nil), h, databaseAccountName+"."+_env.Environment().CosmosDBDNSSuffix, authorizer), nil
+}
+
+func newHTTPClient(log *logrus.Entry, m metrics.Emitter, tlsConfig *tls.Config) *http.Client {
+	return &http.Client{
+		Transport: &consistencyRoundTripper{
+			tr: newThrottlingRoundTripper(&operationRoundTripper{
+				tr: otelhttp.NewTransport(dbmetrics.New(log, &http.Transport{
+					// disable HTTP/2 for now: https://github.com/golang/go/issues/36026
+					TLSNextProto:        map[string]func(string, *tls.Conn) http.RoundTripper{},
+					TLSClientConfig:     tlsConfig,
+					MaxIdleConnsPerHost: 20,
+				}, m), otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+					return "cosmosdb " + r.Method
+				})),
+			}, m),
+		},
 		Timeout: 30 * time.Second,
 	}
-
-	return cosmosdb.NewDatabaseClient(log, c, h, databaseAccountName+"."+_env.Environment().CosmosDBDNSSuffix, authorizer), nil
 }
 
 func NewTokenAuthorizer(ctx context.Context, log *logrus.Entry, cred azcore.TokenCredential, databaseAccountName string, scopes []string) (cosmosdb.Authorizer, error) {
@@ -25,6 +25,10 @@ type DatabaseGroupWithPlatformWorkloadIdentityRoleSets interface {
 	PlatformWorkloadIdentityRoleSets() (PlatformWorkloadIdentityRoleSets, error)
 }
 
+type DatabaseGroupWithSupportedVMSizes interface {
+	SupportedVMSizes() (SupportedVMSizes, error)
+}
+
 type DatabaseGroupWithAsyncOperations interface {
 	AsyncOperations() (AsyncOperations, error)
 }
@@ -47,6 +51,7 @@ type DatabaseGroup interface {
 	DatabaseGroupWithMonitors
 	DatabaseGroupWithOpenShiftVersions
 	DatabaseGroupWithPlatformWorkloadIdentityRoleSets
+	DatabaseGroupWithSupportedVMSizes
 	DatabaseGroupWithAsyncOperations
 	DatabaseGroupWithBilling
 	DatabaseGroupWithPortal
@@ -57,6 +62,7 @@ type DatabaseGroup interface {
 	WithMonitors(db Monitors) DatabaseGroup
 	WithOpenShiftVersions(db OpenShiftVersions) DatabaseGroup
 	WithPlatformWorkloadIdentityRoleSets(db PlatformWorkloadIdentityRoleSets) DatabaseGroup
+	WithSupportedVMSizes(db SupportedVMSizes) DatabaseGroup
 	WithAsyncOperations(db AsyncOperations) DatabaseGroup
 	WithBilling(db Billing) DatabaseGroup
 	WithPortal(db Portal) DatabaseGroup
@@ -69,6 +75,7 @@ type dbGroup struct {
 	monitors                         Monitors
 	platformWorkloadIdentityRoleSets PlatformWorkloadIdentityRoleSets
 	openShiftVersions                OpenShiftVersions
+	supportedVMSizes                 SupportedVMSizes
 	asyncOperations                  AsyncOperations
 	billing                          Billing
 	portal                           Portal
@@ -135,6 +142,18 @@ func (d *dbGroup) WithPlatformWorkloadIdentityRoleSets(db PlatformWorkloadIdenti
 	return d
 }
 
+func (d *dbGroup) SupportedVMSizes() (SupportedVMSizes, error) {
+	if d.supportedVMSizes == nil {
+		return nil, errors.New("no SupportedVMSizes defined")
+	}
+	return d.supportedVMSizes, nil
+}
+
+func (d *dbGroup) WithSupportedVMSizes(db SupportedVMSizes) DatabaseGroup {
+	d.supportedVMSizes = db
+	return d
+}
+
 func (d *dbGroup) AsyncOperations() (AsyncOperations, error) {
 	if d.asyncOperations == nil {
 		return nil, errors.New("no AsyncOperations defined")
@@ -0,0 +1,172 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/util/encryption"
+)
+
+// emulatorMasterKey is the fixed, publicly documented master key of the
+// Cosmos DB emulator.  It is not a secret.
+const emulatorMasterKey = "C2y6yDjf5/R+ob0N8A7Cgv30VRDJIWEHLM+4QDU5DE2nQ9nDuVTqobD4b8mGGyPMbIZnqyMsEcaGQy67XIw/Jw=="
+
+type emulatorCollection struct {
+	id           string
+	partitionKey string
+	uniqueKeys   []string
+	triggers     []emulatorTrigger
+}
+
+type emulatorTrigger struct {
+	id        string
+	body      string
+	operation cosmosdb.TriggerOperation
+}
+
+// emulatorCollections mirrors the containers and triggers which
+// pkg/deploy/generator deploys for a real database account.  Default TTLs are
+// not set as the collection API does not support them; documents simply do not
+// expire in the emulator.
+var emulatorCollections = []emulatorCollection{
+	{id: collAsyncOperations, partitionKey: "/id"},
+	{
+		id:           collBilling,
+		partitionKey: "/id",
+		triggers: []emulatorTrigger{
+			{"setCreationBillingTimeStamp", SetCreationBillingTimeStampTriggerFunction, cosmosdb.TriggerOperationCreate},
+			{"setDeletionBillingTimeStamp", SetDeletionBillingTimeStampTriggerFunction, cosmosdb.TriggerOperationReplace},
+		},
+	},
+	{id: collClusterLookups, partitionKey: "/id"},
+	{id: collClusterManager, partitionKey: "/partitionKey"},
+	{id: collGateway, partitionKey: "/id"},
+	{
+		id:           collMaintenanceManifests,
+		partitionKey: "/clusterResourceID",
+		triggers: []emulatorTrigger{
+			{"renewLease", RenewLeaseTriggerFunction, cosmosdb.TriggerOperationAll},
+		},
+	},
+	{
+		id:           collMonitors,
+		partitionKey: "/id",
+		triggers: []emulatorTrigger{
+			{"renewLease", RenewLeaseTriggerFunction, cosmosdb.TriggerOperationAll},
+		},
+	},
+	{
+		id:           collOpenShiftClusters,
+		partitionKey: "/partitionKey",
+		uniqueKeys:   []string{"/key", "/clusterResourceGroupIdKey", "/clientIdKey"},
+		triggers: []emulatorTrigger{
+			{"renewLease", RenewLeaseTriggerFunction, cosmosdb.TriggerOperationAll},
+		},
+	},
+	{id: collOpenShiftClusterTombstones, partitionKey: "/partitionKey"},
+	{id: collOpenShiftVersion, partitionKey: "/id"},
+	{id: collPlatformWorkloadIdentityRoleSet, partitionKey: "/id"},
+	{id: collPortal, partitionKey: "/id"},
+	{
+		id:           collSubscriptions,
+		partitionKey: "/id",
+		triggers: []emulatorTrigger{
+			{"renewLease", RenewLeaseTriggerFunction, cosmosdb.TriggerOperationAll},
+			{"retryLater", RetryLaterTriggerFunction, cosmosdb.TriggerOperationAll},
+		},
+	},
+	{id: collSupportedVMSizes, partitionKey: "/id"},
+}
+
+// newEmulatorDatabaseClient returns a database client for a local Cosmos DB
+// emulator and makes sure that the development database and its collections
+// exist, so that no real database account is needed for local development.
+func newEmulatorDatabaseClient(ctx context.Context, _env env.Core, log *logrus.Entry, m metrics.Emitter, aead encryption.AEAD, host string) (cosmosdb.DatabaseClient, error) {
+	dbName, err := env.DBName(_env)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := NewJSONHandle(aead)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizer, err := cosmosdb.NewMasterKeyAuthorizer(emulatorMasterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// the emulator serves a self-signed certificate
+	c := newHTTPClient(log, m, &tls.Config{
+		InsecureSkipVerify: true, // #nosec G402
+	})
+
+	dbc := cosmosdb.NewDatabaseClient(log, c, h, host, authorizer)
+
+	log.Printf("using Cosmos DB emulator at %s", host)
+
+	err = ensureEmulatorDatabase(ctx, dbc, dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbc, nil
+}
+
+func ensureEmulatorDatabase(ctx context.Context, dbc cosmosdb.DatabaseClient, dbName string) error {
+	_, err := dbc.Create(ctx, &cosmosdb.Database{ID: dbName})
+	if err != nil && !cosmosdb.IsErrorStatusCode(err, http.StatusConflict) {
+		return err
+	}
+
+	collc := cosmosdb.NewCollectionClient(dbc, dbName)
+
+	for _, coll := range emulatorCollections {
+		newcoll := &cosmosdb.Collection{
+			ID: coll.id,
+			PartitionKey: &cosmosdb.PartitionKey{
+				Paths: []string{coll.partitionKey},
+				Kind:  cosmosdb.PartitionKeyKindHash,
+			},
+		}
+
+		if coll.uniqueKeys != nil {
+			newcoll.UniqueKeyPolicy = &cosmosdb.UniqueKeyPolicy{}
+			for _, path := range coll.uniqueKeys {
+				newcoll.UniqueKeyPolicy.UniqueKeys = append(newcoll.UniqueKeyPolicy.UniqueKeys, cosmosdb.UniqueKey{
+					Paths: []string{path},
+				})
+			}
+		}
+
+		_, err = collc.Create(ctx, newcoll)
+		if err != nil && !cosmosdb.IsErrorStatusCode(err, http.StatusConflict) {
+			return err
+		}
+
+		triggerc := cosmosdb.NewTriggerClient(collc, coll.id)
+		for _, trigger := range coll.triggers {
+			_, err = triggerc.Create(ctx, &cosmosdb.Trigger{
+				ID:               trigger.id,
+				Body:             trigger.body,
+				TriggerOperation: trigger.operation,
+				TriggerType:      cosmosdb.TriggerTypePre,
+			})
+			if err != nil && !cosmosdb.IsErrorStatusCode(err, http.StatusConflict) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
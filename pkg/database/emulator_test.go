@@ -0,0 +1,87 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+)
+
+func TestEnsureEmulatorDatabase(t *testing.T) {
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	created := map[string]bool{}
+
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var body struct {
+			ID string `json:"id"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		key := strings.Trim(r.URL.Path, "/") + "/" + body.ID
+		if created[key] {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		created[key] = true
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer s.Close()
+
+	authorizer, err := cosmosdb.NewMasterKeyAuthorizer(emulatorMasterKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := NewJSONHandle(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dbc := cosmosdb.NewDatabaseClient(logrus.NewEntry(logrus.StandardLogger()), s.Client(), h, strings.TrimPrefix(s.URL, "https://"), authorizer)
+
+	// the second run must tolerate everything already existing
+	for i := 0; i < 2; i++ {
+		err = ensureEmulatorDatabase(ctx, dbc, "test")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, key := range []string{
+		"dbs/test",
+		"dbs/test/colls/OpenShiftClusters",
+		"dbs/test/colls/OpenShiftClusters/triggers/renewLease",
+		"dbs/test/colls/Billing/triggers/setCreationBillingTimeStamp",
+		"dbs/test/colls/Subscriptions/triggers/retryLater",
+	} {
+		if !created[key] {
+			t.Errorf("%s was not created", key)
+		}
+	}
+}
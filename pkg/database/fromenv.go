@@ -6,6 +6,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/sirupsen/logrus"
 
@@ -17,6 +18,10 @@ import (
 
 // NewDatabaseClient creates a CosmosDB database client from the environment configuration.
 func NewDatabaseClientFromEnv(ctx context.Context, _env env.Core, log *logrus.Entry, m metrics.Emitter, aead encryption.AEAD) (cosmosdb.DatabaseClient, error) {
+	if host, found := os.LookupEnv(env.EnvDatabaseEmulatorHost); found && _env.IsLocalDevelopmentMode() {
+		return newEmulatorDatabaseClient(ctx, _env, log.WithField("component", "database"), m, aead, host)
+	}
+
 	dbAccountName, err := env.DBAccountName()
 	if err != nil {
 		return nil, err
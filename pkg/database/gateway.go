@@ -76,7 +76,7 @@ func (c *gateway) Get(ctx context.Context, id string) (*api.GatewayDocument, err
 func (c *gateway) Patch(ctx context.Context, id string, f func(*api.GatewayDocument) error) (*api.GatewayDocument, error) {
 	var doc *api.GatewayDocument
 
-	err := cosmosdb.RetryOnPreconditionFailed(func() (err error) {
+	err := RetryOnPreconditionFailed(ctx, func() (err error) {
 		doc, err = c.Get(ctx, id)
 		if err != nil {
 			return
@@ -0,0 +1,293 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+)
+
+// operationStats accumulates the requests made to Cosmos DB on behalf of one
+// instrumented database operation
+type operationStats struct {
+	mu            sync.Mutex
+	retries       int64
+	requestCharge float64
+}
+
+type operationStatsContextKey struct{}
+
+func withOperationStats(ctx context.Context) (context.Context, *operationStats) {
+	stats := &operationStats{}
+	return context.WithValue(ctx, operationStatsContextKey{}, stats), stats
+}
+
+// countRetry counts a retry against the instrumented database operation in
+// ctx, if any.  It is called where requests are actually retried: by the
+// throttling round tripper and by RetryOnPreconditionFailed.
+func countRetry(ctx context.Context) {
+	stats, ok := ctx.Value(operationStatsContextKey{}).(*operationStats)
+	if !ok {
+		return
+	}
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.retries++
+}
+
+var _ http.RoundTripper = (*operationRoundTripper)(nil)
+
+// operationRoundTripper adds the request charge of each request to the
+// operationStats in its context, if any
+type operationRoundTripper struct {
+	tr http.RoundTripper
+}
+
+func (t *operationRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.tr.RoundTrip(req)
+
+	stats, ok := req.Context().Value(operationStatsContextKey{}).(*operationStats)
+	if !ok || resp == nil {
+		return resp, err
+	}
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	if ru, err := strconv.ParseFloat(strings.Trim(resp.Header.Get("x-ms-request-charge"), `"`), 64); err == nil {
+		stats.requestCharge += ru
+	}
+
+	return resp, err
+}
+
+// instrument runs a database operation and emits its latency, the number of
+// times its requests were retried and its total request charge, dimensioned
+// by collection and operation
+func instrument[T any](ctx context.Context, m metrics.Emitter, collection, operation string, f func(context.Context) (T, error)) (T, error) {
+	ctx, stats := withOperationStats(ctx)
+	start := time.Now()
+
+	result, err := f(ctx)
+
+	dims := map[string]string{
+		"collection": collection,
+		"operation":  operation,
+	}
+
+	m.EmitGauge("database.operation.duration", time.Since(start).Milliseconds(), dims)
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	if stats.retries > 0 {
+		m.EmitGauge("database.operation.retries", stats.retries, dims)
+	}
+	m.EmitFloat("database.operation.requestunits", stats.requestCharge, dims)
+
+	return result, err
+}
+
+// instrumentErr is instrument for operations which only return an error
+func instrumentErr(ctx context.Context, m metrics.Emitter, collection, operation string, f func(context.Context) error) error {
+	_, err := instrument(ctx, m, collection, operation, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, f(ctx)
+	})
+	return err
+}
+
+type instrumentedOpenShiftClusters struct {
+	OpenShiftClusters
+	m metrics.Emitter
+}
+
+// NewInstrumentedOpenShiftClusters returns an OpenShiftClusters which emits
+// metrics for each operation on db which takes a context
+func NewInstrumentedOpenShiftClusters(db OpenShiftClusters, m metrics.Emitter) OpenShiftClusters {
+	return &instrumentedOpenShiftClusters{
+		OpenShiftClusters: db,
+		m:                 m,
+	}
+}
+
+func (c *instrumentedOpenShiftClusters) Create(ctx context.Context, doc *api.OpenShiftClusterDocument) (*api.OpenShiftClusterDocument, error) {
+	return instrument(ctx, c.m, collOpenShiftClusters, "Create", func(ctx context.Context) (*api.OpenShiftClusterDocument, error) {
+		return c.OpenShiftClusters.Create(ctx, doc)
+	})
+}
+
+func (c *instrumentedOpenShiftClusters) Get(ctx context.Context, key string) (*api.OpenShiftClusterDocument, error) {
+	return instrument(ctx, c.m, collOpenShiftClusters, "Get", func(ctx context.Context) (*api.OpenShiftClusterDocument, error) {
+		return c.OpenShiftClusters.Get(ctx, key)
+	})
+}
+
+func (c *instrumentedOpenShiftClusters) QueueLength(ctx context.Context, collid string) (int, error) {
+	return instrument(ctx, c.m, collOpenShiftClusters, "QueueLength", func(ctx context.Context) (int, error) {
+		return c.OpenShiftClusters.QueueLength(ctx, collid)
+	})
+}
+
+func (c *instrumentedOpenShiftClusters) Patch(ctx context.Context, key string, f OpenShiftClusterDocumentMutator) (*api.OpenShiftClusterDocument, error) {
+	return instrument(ctx, c.m, collOpenShiftClusters, "Patch", func(ctx context.Context) (*api.OpenShiftClusterDocument, error) {
+		return c.OpenShiftClusters.Patch(ctx, key, f)
+	})
+}
+
+func (c *instrumentedOpenShiftClusters) PatchWithLease(ctx context.Context, key string, f OpenShiftClusterDocumentMutator) (*api.OpenShiftClusterDocument, error) {
+	return instrument(ctx, c.m, collOpenShiftClusters, "PatchWithLease", func(ctx context.Context) (*api.OpenShiftClusterDocument, error) {
+		return c.OpenShiftClusters.PatchWithLease(ctx, key, f)
+	})
+}
+
+func (c *instrumentedOpenShiftClusters) Update(ctx context.Context, doc *api.OpenShiftClusterDocument) (*api.OpenShiftClusterDocument, error) {
+	return instrument(ctx, c.m, collOpenShiftClusters, "Update", func(ctx context.Context) (*api.OpenShiftClusterDocument, error) {
+		return c.OpenShiftClusters.Update(ctx, doc)
+	})
+}
+
+func (c *instrumentedOpenShiftClusters) Delete(ctx context.Context, doc *api.OpenShiftClusterDocument) error {
+	return instrumentErr(ctx, c.m, collOpenShiftClusters, "Delete", func(ctx context.Context) error {
+		return c.OpenShiftClusters.Delete(ctx, doc)
+	})
+}
+
+func (c *instrumentedOpenShiftClusters) ListAll(ctx context.Context) (*api.OpenShiftClusterDocuments, error) {
+	return instrument(ctx, c.m, collOpenShiftClusters, "ListAll", func(ctx context.Context) (*api.OpenShiftClusterDocuments, error) {
+		return c.OpenShiftClusters.ListAll(ctx)
+	})
+}
+
+func (c *instrumentedOpenShiftClusters) Dequeue(ctx context.Context) (*api.OpenShiftClusterDocument, error) {
+	return instrument(ctx, c.m, collOpenShiftClusters, "Dequeue", func(ctx context.Context) (*api.OpenShiftClusterDocument, error) {
+		return c.OpenShiftClusters.Dequeue(ctx)
+	})
+}
+
+func (c *instrumentedOpenShiftClusters) DoDequeue(ctx context.Context, doc *api.OpenShiftClusterDocument) (*api.OpenShiftClusterDocument, error) {
+	return instrument(ctx, c.m, collOpenShiftClusters, "DoDequeue", func(ctx context.Context) (*api.OpenShiftClusterDocument, error) {
+		return c.OpenShiftClusters.DoDequeue(ctx, doc)
+	})
+}
+
+func (c *instrumentedOpenShiftClusters) Lease(ctx context.Context, key string) (*api.OpenShiftClusterDocument, error) {
+	return instrument(ctx, c.m, collOpenShiftClusters, "Lease", func(ctx context.Context) (*api.OpenShiftClusterDocument, error) {
+		return c.OpenShiftClusters.Lease(ctx, key)
+	})
+}
+
+func (c *instrumentedOpenShiftClusters) EndLease(ctx context.Context, key string, provisioningState, failedProvisioningState api.ProvisioningState, adminUpdateError *string) (*api.OpenShiftClusterDocument, error) {
+	return instrument(ctx, c.m, collOpenShiftClusters, "EndLease", func(ctx context.Context) (*api.OpenShiftClusterDocument, error) {
+		return c.OpenShiftClusters.EndLease(ctx, key, provisioningState, failedProvisioningState, adminUpdateError)
+	})
+}
+
+func (c *instrumentedOpenShiftClusters) LookupByClientID(ctx context.Context, clientID string) (*api.OpenShiftClusterDocument, error) {
+	return instrument(ctx, c.m, collOpenShiftClusters, "LookupByClientID", func(ctx context.Context) (*api.OpenShiftClusterDocument, error) {
+		return c.OpenShiftClusters.LookupByClientID(ctx, clientID)
+	})
+}
+
+func (c *instrumentedOpenShiftClusters) LookupByClusterResourceGroupID(ctx context.Context, resourceGroupID string) (*api.OpenShiftClusterDocument, error) {
+	return instrument(ctx, c.m, collOpenShiftClusters, "LookupByClusterResourceGroupID", func(ctx context.Context) (*api.OpenShiftClusterDocument, error) {
+		return c.OpenShiftClusters.LookupByClusterResourceGroupID(ctx, resourceGroupID)
+	})
+}
+
+type instrumentedSubscriptions struct {
+	Subscriptions
+	m metrics.Emitter
+}
+
+// NewInstrumentedSubscriptions returns a Subscriptions which emits metrics for
+// each operation on db which takes a context
+func NewInstrumentedSubscriptions(db Subscriptions, m metrics.Emitter) Subscriptions {
+	return &instrumentedSubscriptions{
+		Subscriptions: db,
+		m:             m,
+	}
+}
+
+func (c *instrumentedSubscriptions) Create(ctx context.Context, doc *api.SubscriptionDocument) (*api.SubscriptionDocument, error) {
+	return instrument(ctx, c.m, collSubscriptions, "Create", func(ctx context.Context) (*api.SubscriptionDocument, error) {
+		return c.Subscriptions.Create(ctx, doc)
+	})
+}
+
+func (c *instrumentedSubscriptions) Get(ctx context.Context, key string) (*api.SubscriptionDocument, error) {
+	return instrument(ctx, c.m, collSubscriptions, "Get", func(ctx context.Context) (*api.SubscriptionDocument, error) {
+		return c.Subscriptions.Get(ctx, key)
+	})
+}
+
+func (c *instrumentedSubscriptions) Update(ctx context.Context, doc *api.SubscriptionDocument) (*api.SubscriptionDocument, error) {
+	return instrument(ctx, c.m, collSubscriptions, "Update", func(ctx context.Context) (*api.SubscriptionDocument, error) {
+		return c.Subscriptions.Update(ctx, doc)
+	})
+}
+
+func (c *instrumentedSubscriptions) Dequeue(ctx context.Context) (*api.SubscriptionDocument, error) {
+	return instrument(ctx, c.m, collSubscriptions, "Dequeue", func(ctx context.Context) (*api.SubscriptionDocument, error) {
+		return c.Subscriptions.Dequeue(ctx)
+	})
+}
+
+func (c *instrumentedSubscriptions) Lease(ctx context.Context, key string) (*api.SubscriptionDocument, error) {
+	return instrument(ctx, c.m, collSubscriptions, "Lease", func(ctx context.Context) (*api.SubscriptionDocument, error) {
+		return c.Subscriptions.Lease(ctx, key)
+	})
+}
+
+func (c *instrumentedSubscriptions) EndLease(ctx context.Context, key string, done, retryLater bool) (*api.SubscriptionDocument, error) {
+	return instrument(ctx, c.m, collSubscriptions, "EndLease", func(ctx context.Context) (*api.SubscriptionDocument, error) {
+		return c.Subscriptions.EndLease(ctx, key, done, retryLater)
+	})
+}
+
+type instrumentedAsyncOperations struct {
+	AsyncOperations
+	m metrics.Emitter
+}
+
+// NewInstrumentedAsyncOperations returns an AsyncOperations which emits
+// metrics for each operation on db which takes a context
+func NewInstrumentedAsyncOperations(db AsyncOperations, m metrics.Emitter) AsyncOperations {
+	return &instrumentedAsyncOperations{
+		AsyncOperations: db,
+		m:               m,
+	}
+}
+
+func (c *instrumentedAsyncOperations) Create(ctx context.Context, doc *api.AsyncOperationDocument) (*api.AsyncOperationDocument, error) {
+	return instrument(ctx, c.m, collAsyncOperations, "Create", func(ctx context.Context) (*api.AsyncOperationDocument, error) {
+		return c.AsyncOperations.Create(ctx, doc)
+	})
+}
+
+func (c *instrumentedAsyncOperations) Get(ctx context.Context, id string) (*api.AsyncOperationDocument, error) {
+	return instrument(ctx, c.m, collAsyncOperations, "Get", func(ctx context.Context) (*api.AsyncOperationDocument, error) {
+		return c.AsyncOperations.Get(ctx, id)
+	})
+}
+
+func (c *instrumentedAsyncOperations) Patch(ctx context.Context, id string, f func(*api.AsyncOperationDocument) error) (*api.AsyncOperationDocument, error) {
+	return instrument(ctx, c.m, collAsyncOperations, "Patch", func(ctx context.Context) (*api.AsyncOperationDocument, error) {
+		return c.AsyncOperations.Patch(ctx, id, f)
+	})
+}
+
+func (c *instrumentedAsyncOperations) ListByClusterKey(ctx context.Context, clusterKey string) (*api.AsyncOperationDocuments, error) {
+	return instrument(ctx, c.m, collAsyncOperations, "ListByClusterKey", func(ctx context.Context) (*api.AsyncOperationDocuments, error) {
+		return c.AsyncOperations.ListByClusterKey(ctx, clusterKey)
+	})
+}
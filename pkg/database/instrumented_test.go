@@ -0,0 +1,187 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+type chargedRoundTripper struct {
+	statusCodes []int
+	charge      string
+}
+
+func (rt *chargedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	statusCode := rt.statusCodes[0]
+	rt.statusCodes = rt.statusCodes[1:]
+
+	return &http.Response{
+		StatusCode: statusCode,
+		Header: http.Header{
+			"X-Ms-Request-Charge": []string{rt.charge},
+			"X-Ms-Retry-After-Ms": []string{"1"},
+		},
+		Body: io.NopCloser(strings.NewReader("")),
+	}, nil
+}
+
+type fakeOpenShiftClusters struct {
+	OpenShiftClusters
+	hc *http.Client
+}
+
+func (c *fakeOpenShiftClusters) do(ctx context.Context, method string) error {
+	req, err := http.NewRequestWithContext(ctx, method, "https://localhost/dbs/db/colls/OpenShiftClusters/docs/id", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.hc.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return &cosmosdb.Error{StatusCode: resp.StatusCode}
+	}
+
+	return nil
+}
+
+// Patch retries its request if its precondition fails, like openShiftClusters
+func (c *fakeOpenShiftClusters) Patch(ctx context.Context, key string, f OpenShiftClusterDocumentMutator) (*api.OpenShiftClusterDocument, error) {
+	err := RetryOnPreconditionFailed(ctx, func() error {
+		return c.do(ctx, http.MethodPut)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.OpenShiftClusterDocument{Key: key}, nil
+}
+
+// Update returns a precondition failure to its caller without retrying
+func (c *fakeOpenShiftClusters) Update(ctx context.Context, doc *api.OpenShiftClusterDocument) (*api.OpenShiftClusterDocument, error) {
+	err := c.do(ctx, http.MethodPut)
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func TestInstrumentedOpenShiftClusters(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		operation   string
+		statusCodes []int
+		charge      string
+		wantRetries int64
+		wantRU      float64
+		wantErr     string
+	}{
+		{
+			name:        "success",
+			operation:   "Patch",
+			statusCodes: []int{http.StatusOK},
+			charge:      "1.5",
+			wantRU:      1.5,
+		},
+		{
+			name:        "retried on precondition failure",
+			operation:   "Patch",
+			statusCodes: []int{http.StatusPreconditionFailed, http.StatusPreconditionFailed, http.StatusOK},
+			charge:      `"2"`,
+			wantRetries: 2,
+			wantRU:      6,
+		},
+		{
+			name:        "retried when throttled",
+			operation:   "Update",
+			statusCodes: []int{http.StatusTooManyRequests, http.StatusOK},
+			charge:      "1",
+			wantRetries: 1,
+			wantRU:      2,
+		},
+		{
+			name:        "precondition failure which is not retried",
+			operation:   "Update",
+			statusCodes: []int{http.StatusPreconditionFailed},
+			charge:      "1",
+			wantRU:      1,
+			wantErr:     "412 : ",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			dims := map[string]string{
+				"collection": "OpenShiftClusters",
+				"operation":  tt.operation,
+			}
+
+			m := mock_metrics.NewMockEmitter(controller)
+			m.EXPECT().EmitGauge("client.cosmosdb.throttled", int64(1), gomock.Any()).AnyTimes()
+			m.EXPECT().EmitGauge("database.operation.duration", gomock.Any(), dims)
+			if tt.wantRetries > 0 {
+				m.EXPECT().EmitGauge("database.operation.retries", tt.wantRetries, dims)
+			}
+			m.EXPECT().EmitFloat("database.operation.requestunits", tt.wantRU, dims)
+
+			db := NewInstrumentedOpenShiftClusters(&fakeOpenShiftClusters{
+				hc: &http.Client{
+					Transport: newThrottlingRoundTripper(&operationRoundTripper{
+						tr: &chargedRoundTripper{
+							statusCodes: tt.statusCodes,
+							charge:      tt.charge,
+						},
+					}, m),
+				},
+			}, m)
+
+			var err error
+			switch tt.operation {
+			case "Patch":
+				_, err = db.Patch(context.Background(), "key", nil)
+			case "Update":
+				_, err = db.Update(context.Background(), &api.OpenShiftClusterDocument{})
+			}
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestOperationRoundTripperWithoutStats(t *testing.T) {
+	hc := &http.Client{
+		Transport: &operationRoundTripper{
+			tr: &chargedRoundTripper{
+				statusCodes: []int{http.StatusOK},
+				charge:      "1",
+			},
+		},
+	}
+
+	resp, err := hc.Get("https://localhost/dbs/db/colls/OpenShiftClusters/docs/id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Error(resp.StatusCode)
+	}
+}
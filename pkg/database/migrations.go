@@ -0,0 +1,118 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/util/recover"
+)
+
+// openShiftClusterDocumentMigrations[i] upgrades an OpenShiftClusterDocument
+// from schema version i to i+1.  Migrations must be idempotent and may only
+// depend on the document itself: fixups which need to talk to the cluster or
+// to Azure still belong in pkg/cluster.  Append new migrations to the end of
+// the list; never reorder or remove existing ones.
+var openShiftClusterDocumentMigrations = []func(*api.OpenShiftClusterDocument) error{
+	migrateInfraID,
+}
+
+// OpenShiftClusterDocumentSchemaVersion is the schema version of documents
+// written by this code.
+var OpenShiftClusterDocumentSchemaVersion = len(openShiftClusterDocumentMigrations)
+
+// MigrateOpenShiftClusterDocument upgrades doc in place to the current schema
+// version.  It returns true if doc was changed.
+func MigrateOpenShiftClusterDocument(doc *api.OpenShiftClusterDocument) (bool, error) {
+	if doc.SchemaVersion >= OpenShiftClusterDocumentSchemaVersion {
+		return false, nil
+	}
+
+	for _, migrate := range openShiftClusterDocumentMigrations[doc.SchemaVersion:] {
+		err := migrate(doc)
+		if err != nil {
+			return false, err
+		}
+		doc.SchemaVersion++
+	}
+
+	return true, nil
+}
+
+// migrateInfraID defaults InfraID for old clusters which predate it being
+// stored in the database.  Clusters which are still being created are
+// skipped: their InfraID is generated during install.
+func migrateInfraID(doc *api.OpenShiftClusterDocument) error {
+	if doc.OpenShiftCluster == nil ||
+		doc.OpenShiftCluster.Properties.ProvisioningState == api.ProvisioningStateCreating {
+		return nil
+	}
+
+	if doc.OpenShiftCluster.Properties.InfraID == "" {
+		doc.OpenShiftCluster.Properties.InfraID = "aro"
+	}
+
+	return nil
+}
+
+// MigrateOpenShiftClusters periodically walks all OpenShiftClusterDocuments
+// and persists any which are not at the current schema version.  Documents
+// are also migrated lazily on read, so this only exists to bound how long old
+// schema versions linger in the database.
+func MigrateOpenShiftClusters(ctx context.Context, log *logrus.Entry, dbOpenShiftClusters OpenShiftClusters, interval time.Duration) {
+	defer recover.Panic(log)
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		err := migrateOpenShiftClusters(ctx, log, dbOpenShiftClusters)
+		if err != nil {
+			log.Error(err)
+		}
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func migrateOpenShiftClusters(ctx context.Context, log *logrus.Entry, dbOpenShiftClusters OpenShiftClusters) error {
+	_, err := rewriteOpenShiftClusters(ctx, log, dbOpenShiftClusters, dbOpenShiftClusters.ListOutdated(""))
+	return err
+}
+
+// rewriteOpenShiftClusters rewrites the OpenShiftClusterDocuments returned by
+// i.  Patch migrates the document on read, and the database codec re-seals
+// secure fields with the current encryption key and stamps the current schema
+// version on write, so an empty patch is enough.  Failures to rewrite
+// individual documents are logged and counted rather than aborting the walk.
+func rewriteOpenShiftClusters(ctx context.Context, log *logrus.Entry, dbOpenShiftClusters OpenShiftClusters, i cosmosdb.OpenShiftClusterDocumentIterator) (failed int, err error) {
+	for {
+		docs, err := i.Next(ctx, -1)
+		if err != nil {
+			return failed, err
+		}
+		if docs == nil {
+			return failed, nil
+		}
+
+		for _, doc := range docs.OpenShiftClusterDocuments {
+			_, err = dbOpenShiftClusters.Patch(ctx, doc.Key, func(*api.OpenShiftClusterDocument) error { return nil })
+			if err != nil {
+				log.Errorf("rewriting %s: %s", doc.Key, err)
+				failed++
+				continue
+			}
+
+			log.Infof("rewrote %s", doc.Key)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/util/uuid"
+)
+
+func TestMigrateOpenShiftClusterDocument(t *testing.T) {
+	for _, tt := range []struct {
+		name              string
+		doc               *api.OpenShiftClusterDocument
+		wantChanged       bool
+		wantInfraID       string
+		wantSchemaVersion int
+	}{
+		{
+			name: "no infra id",
+			doc: &api.OpenShiftClusterDocument{
+				OpenShiftCluster: &api.OpenShiftCluster{
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateAdminUpdating,
+					},
+				},
+			},
+			wantChanged:       true,
+			wantInfraID:       "aro",
+			wantSchemaVersion: OpenShiftClusterDocumentSchemaVersion,
+		},
+		{
+			name: "unique random infra id",
+			doc: &api.OpenShiftClusterDocument{
+				OpenShiftCluster: &api.OpenShiftCluster{
+					Properties: api.OpenShiftClusterProperties{
+						InfraID:           "cluster-abc",
+						ProvisioningState: api.ProvisioningStateSucceeded,
+					},
+				},
+			},
+			wantChanged:       true,
+			wantInfraID:       "cluster-abc",
+			wantSchemaVersion: OpenShiftClusterDocumentSchemaVersion,
+		},
+		{
+			name: "creating clusters keep an empty infra id",
+			doc: &api.OpenShiftClusterDocument{
+				OpenShiftCluster: &api.OpenShiftCluster{
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateCreating,
+					},
+				},
+			},
+			wantChanged:       true,
+			wantSchemaVersion: OpenShiftClusterDocumentSchemaVersion,
+		},
+		{
+			name: "current documents are untouched",
+			doc: &api.OpenShiftClusterDocument{
+				SchemaVersion: OpenShiftClusterDocumentSchemaVersion,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateSucceeded,
+					},
+				},
+			},
+			wantSchemaVersion: OpenShiftClusterDocumentSchemaVersion,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			changed, err := MigrateOpenShiftClusterDocument(tt.doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if changed != tt.wantChanged {
+				t.Error(changed)
+			}
+			if tt.doc.OpenShiftCluster.Properties.InfraID != tt.wantInfraID {
+				t.Error(tt.doc.OpenShiftCluster.Properties.InfraID)
+			}
+			if tt.doc.SchemaVersion != tt.wantSchemaVersion {
+				t.Error(tt.doc.SchemaVersion)
+			}
+		})
+	}
+}
+
+func TestMigrateOpenShiftClusters(t *testing.T) {
+	ctx := context.Background()
+	key := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroup/providers/microsoft.redhatopenshift/openshiftclusters/cluster"
+
+	h, err := NewJSONHandle(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := cosmosdb.NewFakeOpenShiftClusterDocumentClient(h)
+	client.SetQueryHandler(OpenShiftClustersGetQuery, fakeClusterMatchQuery)
+	client.SetQueryHandler(OpenShiftClustersOutdatedQuery, func(client cosmosdb.OpenShiftClusterDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.OpenShiftClusterDocumentRawIterator {
+		all, err := client.ListAll(ctx, nil)
+		if err != nil {
+			return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+		}
+
+		schemaVersion, err := strconv.Atoi(query.Parameters[0].Value)
+		if err != nil {
+			return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+		}
+
+		var docs []*api.OpenShiftClusterDocument
+		for _, doc := range all.OpenShiftClusterDocuments {
+			if doc.SchemaVersion < schemaVersion {
+				docs = append(docs, doc)
+			}
+		}
+
+		return cosmosdb.NewFakeOpenShiftClusterDocumentIterator(docs, 0)
+	})
+	c := NewOpenShiftClustersWithProvidedClient(client, cosmosdb.NewFakeClusterLookupDocumentClient(h), nil, "", uuid.DefaultGenerator)
+
+	// written by older code, so bypass Create which would stamp the version
+	_, err = client.Create(ctx, "00000000-0000-0000-0000-000000000000", &api.OpenShiftClusterDocument{
+		ID:  "id",
+		Key: key,
+		OpenShiftCluster: &api.OpenShiftCluster{
+			Properties: api.OpenShiftClusterProperties{
+				ProvisioningState: api.ProvisioningStateSucceeded,
+			},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// iterators return documents migrated before they are rewritten
+	docs, err := c.List("").Next(ctx, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if docs.OpenShiftClusterDocuments[0].OpenShiftCluster.Properties.InfraID != "aro" {
+		t.Error(docs.OpenShiftClusterDocuments[0].OpenShiftCluster.Properties.InfraID)
+	}
+
+	err = migrateOpenShiftClusters(ctx, logrus.NewEntry(logrus.StandardLogger()), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := client.Get(ctx, "00000000-0000-0000-0000-000000000000", "id", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.SchemaVersion != OpenShiftClusterDocumentSchemaVersion {
+		t.Error(doc.SchemaVersion)
+	}
+	if doc.OpenShiftCluster.Properties.InfraID != "aro" {
+		t.Error(doc.OpenShiftCluster.Properties.InfraID)
+	}
+}
@@ -135,7 +135,7 @@ func (c *maintenanceManifests) Patch(ctx context.Context, clusterResourceID stri
 func (c *maintenanceManifests) patch(ctx context.Context, clusterResourceID string, id string, f MaintenanceManifestDocumentMutator, options *cosmosdb.Options) (*api.MaintenanceManifestDocument, error) {
 	var doc *api.MaintenanceManifestDocument
 
-	err := cosmosdb.RetryOnPreconditionFailed(func() (err error) {
+	err := RetryOnPreconditionFailed(ctx, func() (err error) {
 		doc, err = c.Get(ctx, clusterResourceID, id)
 		if err != nil {
 			return
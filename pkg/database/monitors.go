@@ -64,7 +64,7 @@ func (c *monitors) get(ctx context.Context, id string) (*api.MonitorDocument, er
 func (c *monitors) patch(ctx context.Context, id string, f func(*api.MonitorDocument) error, options *cosmosdb.Options) (*api.MonitorDocument, error) {
 	var doc *api.MonitorDocument
 
-	err := cosmosdb.RetryOnPreconditionFailed(func() (err error) {
+	err := RetryOnPreconditionFailed(ctx, func() (err error) {
 		doc, err = c.get(ctx, id)
 		if err != nil {
 			return
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/Azure/go-autorest/autorest/azure"
@@ -24,15 +25,38 @@ const (
 	OpenshiftClustersClientIdQuery              = `SELECT * FROM OpenShiftClusters doc WHERE doc.clientIdKey = @clientID`
 	OpenshiftClustersResourceGroupQuery         = `SELECT * FROM OpenShiftClusters doc WHERE doc.clusterResourceGroupIdKey = @resourceGroupID`
 	OpenshiftClustersClusterResourceIDOnlyQuery = `SELECT doc.id, doc.key FROM OpenShiftClusters doc WHERE doc.openShiftCluster.properties.provisioningState NOT IN ("Creating", "Deleting")`
+	OpenShiftClustersOutdatedQuery              = `SELECT * FROM OpenShiftClusters doc WHERE (doc.schemaVersion ?? 0) < StringToNumber(@schemaVersion)`
+	OpenShiftClustersSearchQuery                = `SELECT * FROM OpenShiftClusters doc WHERE (@version = "" OR STARTSWITH(doc.openShiftCluster.properties.clusterProfile.version, @version)) AND (@location = "" OR doc.openShiftCluster.location = @location) AND (@provisioningState = "" OR doc.openShiftCluster.properties.provisioningState = @provisioningState) AND (@lastError = "" OR CONTAINS(doc.openShiftCluster.properties.lastAdminUpdateError, @lastError, true))`
 )
 
+// OpenShiftClusterSearch holds the criteria of a search across all clusters.
+// Empty fields match any cluster.
+type OpenShiftClusterSearch struct {
+	// Version matches clusters whose version starts with it, so "4.12"
+	// matches all 4.12.z clusters
+	Version string
+
+	// Location matches clusters in the given region
+	Location string
+
+	// ProvisioningState matches clusters in the given provisioning state
+	ProvisioningState api.ProvisioningState
+
+	// LastError matches clusters whose last admin update error contains it,
+	// case-insensitively
+	LastError string
+}
+
 type OpenShiftClusterDocumentMutator func(*api.OpenShiftClusterDocument) error
 
 type openShiftClusters struct {
 	c             cosmosdb.OpenShiftClusterDocumentClient
+	lookups       cosmosdb.ClusterLookupDocumentClient
 	collc         cosmosdb.CollectionClient
 	uuid          string
 	uuidGenerator uuid.Generator
+
+	noLookupFallback bool
 }
 
 // OpenShiftClusters is the database interface for OpenShiftClusterDocuments
@@ -47,13 +71,17 @@ type OpenShiftClusters interface {
 	ChangeFeed() cosmosdb.OpenShiftClusterDocumentIterator
 	List(string) cosmosdb.OpenShiftClusterDocumentIterator
 	ListAll(context.Context) (*api.OpenShiftClusterDocuments, error)
+	ListOutdated(string) cosmosdb.OpenShiftClusterDocumentIterator
 	ListByPrefix(string, string, string) (cosmosdb.OpenShiftClusterDocumentIterator, error)
 	Dequeue(context.Context) (*api.OpenShiftClusterDocument, error)
 	Lease(context.Context, string) (*api.OpenShiftClusterDocument, error)
 	EndLease(context.Context, string, api.ProvisioningState, api.ProvisioningState, *string) (*api.OpenShiftClusterDocument, error)
-	GetByClientID(ctx context.Context, partitionKey, clientID string) (*api.OpenShiftClusterDocuments, error)
-	GetByClusterResourceGroupID(ctx context.Context, partitionKey, resourceGroupID string) (*api.OpenShiftClusterDocuments, error)
+	LookupByClientID(ctx context.Context, clientID string) (*api.OpenShiftClusterDocument, error)
+	LookupByClusterResourceGroupID(ctx context.Context, resourceGroupID string) (*api.OpenShiftClusterDocument, error)
+	BackfillLookups(ctx context.Context, key string) error
+	DisableLookupFallback()
 	GetAllResourceIDs(ctx context.Context, continuation string) (cosmosdb.OpenShiftClusterDocumentIterator, error)
+	Search(search *OpenShiftClusterSearch, continuation string) (cosmosdb.OpenShiftClusterDocumentIterator, error)
 	DoDequeue(ctx context.Context, doc *api.OpenShiftClusterDocument) (*api.OpenShiftClusterDocument, error)
 	NewUUID() string
 }
@@ -63,12 +91,14 @@ func NewOpenShiftClusters(ctx context.Context, dbc cosmosdb.DatabaseClient, dbNa
 	collc := cosmosdb.NewCollectionClient(dbc, dbName)
 
 	documentClient := cosmosdb.NewOpenShiftClusterDocumentClient(collc, collOpenShiftClusters)
-	return NewOpenShiftClustersWithProvidedClient(documentClient, collc, uuid.DefaultGenerator.Generate(), uuid.DefaultGenerator), nil
+	lookupClient := cosmosdb.NewClusterLookupDocumentClient(collc, collClusterLookups)
+	return NewOpenShiftClustersWithProvidedClient(documentClient, lookupClient, collc, uuid.DefaultGenerator.Generate(), uuid.DefaultGenerator), nil
 }
 
-func NewOpenShiftClustersWithProvidedClient(client cosmosdb.OpenShiftClusterDocumentClient, collectionClient cosmosdb.CollectionClient, uuid string, uuidGenerator uuid.Generator) OpenShiftClusters {
+func NewOpenShiftClustersWithProvidedClient(client cosmosdb.OpenShiftClusterDocumentClient, lookupClient cosmosdb.ClusterLookupDocumentClient, collectionClient cosmosdb.CollectionClient, uuid string, uuidGenerator uuid.Generator) OpenShiftClusters {
 	return &openShiftClusters{
 		c:             client,
+		lookups:       lookupClient,
 		collc:         collectionClient,
 		uuid:          uuid,
 		uuidGenerator: uuidGenerator,
@@ -90,6 +120,15 @@ func (c *openShiftClusters) Create(ctx context.Context, doc *api.OpenShiftCluste
 		return nil, err
 	}
 
+	// documents are created at the current schema version: migrations only
+	// apply to documents written by older code
+	doc.SchemaVersion = OpenShiftClusterDocumentSchemaVersion
+
+	err = c.putLookups(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+
 	doc, err = c.c.Create(ctx, doc.PartitionKey, doc, nil)
 
 	if err, ok := err.(*cosmosdb.Error); ok && err.StatusCode == http.StatusConflict {
@@ -126,6 +165,10 @@ func (c *openShiftClusters) Get(ctx context.Context, key string) (*api.OpenShift
 	case len(docs.OpenShiftClusterDocuments) > 1:
 		return nil, fmt.Errorf("read %d documents, expected <= 1", len(docs.OpenShiftClusterDocuments))
 	case len(docs.OpenShiftClusterDocuments) == 1:
+		_, err = MigrateOpenShiftClusterDocument(docs.OpenShiftClusterDocuments[0])
+		if err != nil {
+			return nil, err
+		}
 		return docs.OpenShiftClusterDocuments[0], nil
 	default:
 		return nil, &cosmosdb.Error{StatusCode: http.StatusNotFound}
@@ -170,18 +213,20 @@ func (c *openShiftClusters) Patch(ctx context.Context, key string, f OpenShiftCl
 func (c *openShiftClusters) patch(ctx context.Context, key string, f OpenShiftClusterDocumentMutator, options *cosmosdb.Options) (*api.OpenShiftClusterDocument, error) {
 	var doc *api.OpenShiftClusterDocument
 
-	err := cosmosdb.RetryOnPreconditionFailed(func() (err error) {
+	err := RetryOnPreconditionFailed(ctx, func() (err error) {
 		doc, err = c.Get(ctx, key)
 		if err != nil {
 			return
 		}
 
+		lookupValues := clusterLookupValues(doc)
+
 		err = f(doc)
 		if err != nil {
 			return
 		}
 
-		doc, err = c.update(ctx, doc, options)
+		doc, err = c.update(ctx, doc, lookupValues, options)
 		return
 	})
 
@@ -203,15 +248,45 @@ func (c *openShiftClusters) patchWithLease(ctx context.Context, key string, f Op
 }
 
 func (c *openShiftClusters) Update(ctx context.Context, doc *api.OpenShiftClusterDocument) (*api.OpenShiftClusterDocument, error) {
-	return c.update(ctx, doc, nil)
+	if doc.Key != strings.ToLower(doc.Key) {
+		return nil, fmt.Errorf("key %q is not lower case", doc.Key)
+	}
+
+	// the caller's document may already have been changed, so the lookups
+	// to replace are taken from the stored document
+	current, err := c.Get(ctx, doc.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.update(ctx, doc, clusterLookupValues(current), nil)
 }
 
-func (c *openShiftClusters) update(ctx context.Context, doc *api.OpenShiftClusterDocument, options *cosmosdb.Options) (*api.OpenShiftClusterDocument, error) {
+// update replaces doc.  lookupValues are the lookup values of the stored
+// document, whose lookups are replaced if they have changed.
+func (c *openShiftClusters) update(ctx context.Context, doc *api.OpenShiftClusterDocument, lookupValues map[api.ClusterLookupKind]string, options *cosmosdb.Options) (*api.OpenShiftClusterDocument, error) {
 	if doc.Key != strings.ToLower(doc.Key) {
 		return nil, fmt.Errorf("key %q is not lower case", doc.Key)
 	}
 
-	return c.c.Replace(ctx, doc.PartitionKey, doc, options)
+	_, err := MigrateOpenShiftClusterDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	newLookupValues := clusterLookupValues(doc)
+
+	err = c.putChangedLookups(ctx, doc.Key, lookupValues, newLookupValues)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err = c.c.Replace(ctx, doc.PartitionKey, doc, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, c.deleteChangedLookups(ctx, doc.Key, lookupValues, newLookupValues)
 }
 
 func (c *openShiftClusters) Delete(ctx context.Context, doc *api.OpenShiftClusterDocument) error {
@@ -219,19 +294,48 @@ func (c *openShiftClusters) Delete(ctx context.Context, doc *api.OpenShiftCluste
 		return fmt.Errorf("key %q is not lower case", doc.Key)
 	}
 
+	err := c.deleteLookups(ctx, doc)
+	if err != nil {
+		return err
+	}
+
 	return c.c.Delete(ctx, doc.PartitionKey, doc, &cosmosdb.Options{NoETag: true})
 }
 
 func (c *openShiftClusters) ChangeFeed() cosmosdb.OpenShiftClusterDocumentIterator {
-	return c.c.ChangeFeed(nil)
+	return &migratingOpenShiftClusterDocumentIterator{c.c.ChangeFeed(nil)}
 }
 
 func (c *openShiftClusters) List(continuation string) cosmosdb.OpenShiftClusterDocumentIterator {
-	return c.c.List(&cosmosdb.Options{Continuation: continuation})
+	return &migratingOpenShiftClusterDocumentIterator{c.c.List(&cosmosdb.Options{Continuation: continuation})}
 }
 
 func (c *openShiftClusters) ListAll(ctx context.Context) (*api.OpenShiftClusterDocuments, error) {
-	return c.c.ListAll(ctx, nil)
+	docs, err := c.c.ListAll(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return docs, migrateOpenShiftClusterDocuments(docs)
+}
+
+// ListOutdated returns the documents which are stored at an older schema
+// version.  Like the other iterators, it returns them migrated to the current
+// schema version.
+func (c *openShiftClusters) ListOutdated(continuation string) cosmosdb.OpenShiftClusterDocumentIterator {
+	return &migratingOpenShiftClusterDocumentIterator{c.c.Query(
+		"",
+		&cosmosdb.Query{
+			Query: OpenShiftClustersOutdatedQuery,
+			Parameters: []cosmosdb.Parameter{
+				{
+					Name:  "@schemaVersion",
+					Value: strconv.Itoa(OpenShiftClusterDocumentSchemaVersion),
+				},
+			},
+		},
+		&cosmosdb.Options{Continuation: continuation},
+	)}
 }
 
 func (c *openShiftClusters) ListByPrefix(subscriptionID, prefix, continuation string) (cosmosdb.OpenShiftClusterDocumentIterator, error) {
@@ -239,7 +343,7 @@ func (c *openShiftClusters) ListByPrefix(subscriptionID, prefix, continuation st
 		return nil, fmt.Errorf("prefix %q is not lower case", prefix)
 	}
 
-	return c.c.Query(
+	return &migratingOpenShiftClusterDocumentIterator{c.c.Query(
 		subscriptionID,
 		&cosmosdb.Query{
 			Query: OpenshiftClustersPrefixQuery,
@@ -251,13 +355,13 @@ func (c *openShiftClusters) ListByPrefix(subscriptionID, prefix, continuation st
 			},
 		},
 		&cosmosdb.Options{Continuation: continuation},
-	), nil
+	)}, nil
 }
 
 func (c *openShiftClusters) Dequeue(ctx context.Context) (*api.OpenShiftClusterDocument, error) {
-	i := c.c.Query("", &cosmosdb.Query{
+	i := &migratingOpenShiftClusterDocumentIterator{c.c.Query("", &cosmosdb.Query{
 		Query: OpenShiftClustersDequeueQuery,
-	}, nil)
+	}, nil)}
 
 	for {
 		docs, err := i.Next(ctx, -1)
@@ -281,7 +385,7 @@ func (c *openShiftClusters) Dequeue(ctx context.Context) (*api.OpenShiftClusterD
 func (c *openShiftClusters) DoDequeue(ctx context.Context, doc *api.OpenShiftClusterDocument) (*api.OpenShiftClusterDocument, error) {
 	doc.LeaseOwner = c.uuid
 	doc.Dequeues++
-	return c.update(ctx, doc, &cosmosdb.Options{PreTriggers: []string{"renewLease"}})
+	return c.update(ctx, doc, clusterLookupValues(doc), &cosmosdb.Options{PreTriggers: []string{"renewLease"}})
 }
 
 func (c *openShiftClusters) Lease(ctx context.Context, key string) (*api.OpenShiftClusterDocument, error) {
@@ -323,44 +427,77 @@ func (c *openShiftClusters) partitionKey(key string) (string, error) {
 	return r.SubscriptionID, err
 }
 
-func (c *openShiftClusters) GetByClientID(ctx context.Context, partitionKey, clientID string) (*api.OpenShiftClusterDocuments, error) {
-	docs, err := c.c.QueryAll(ctx, partitionKey, &cosmosdb.Query{
-		Query: OpenshiftClustersClientIdQuery,
-		Parameters: []cosmosdb.Parameter{
-			{
-				Name:  "@clientID",
-				Value: clientID,
-			},
+func (c *openShiftClusters) GetAllResourceIDs(ctx context.Context, continuation string) (cosmosdb.OpenShiftClusterDocumentIterator, error) {
+	return c.c.Query(
+		"",
+		&cosmosdb.Query{
+			Query: OpenshiftClustersClusterResourceIDOnlyQuery,
 		},
-	}, nil)
-	if err != nil {
-		return nil, err
-	}
-	return docs, nil
+		&cosmosdb.Options{Continuation: continuation},
+	), nil
 }
 
-func (c *openShiftClusters) GetByClusterResourceGroupID(ctx context.Context, partitionKey, resourceGroupID string) (*api.OpenShiftClusterDocuments, error) {
-	docs, err := c.c.QueryAll(ctx, partitionKey, &cosmosdb.Query{
-		Query: OpenshiftClustersResourceGroupQuery,
-		Parameters: []cosmosdb.Parameter{
-			{
-				Name:  "@resourceGroupID",
-				Value: resourceGroupID,
+// Search returns the clusters matching the given criteria across all
+// partitions.  The version, location and provisioning state criteria are
+// served by the container's range indexes; the last error criterion is only
+// evaluated against the documents which match the others.
+func (c *openShiftClusters) Search(search *OpenShiftClusterSearch, continuation string) (cosmosdb.OpenShiftClusterDocumentIterator, error) {
+	if search.Location != strings.ToLower(search.Location) {
+		return nil, fmt.Errorf("location %q is not lower case", search.Location)
+	}
+
+	return &migratingOpenShiftClusterDocumentIterator{c.c.Query(
+		"",
+		&cosmosdb.Query{
+			Query: OpenShiftClustersSearchQuery,
+			Parameters: []cosmosdb.Parameter{
+				{
+					Name:  "@version",
+					Value: search.Version,
+				},
+				{
+					Name:  "@location",
+					Value: search.Location,
+				},
+				{
+					Name:  "@provisioningState",
+					Value: string(search.ProvisioningState),
+				},
+				{
+					Name:  "@lastError",
+					Value: search.LastError,
+				},
 			},
 		},
-	}, nil)
+		&cosmosdb.Options{Continuation: continuation},
+	)}, nil
+}
+
+// migratingOpenShiftClusterDocumentIterator migrates the documents returned
+// by an iterator to the current schema version.
+type migratingOpenShiftClusterDocumentIterator struct {
+	cosmosdb.OpenShiftClusterDocumentIterator
+}
+
+func (i *migratingOpenShiftClusterDocumentIterator) Next(ctx context.Context, maxItemCount int) (*api.OpenShiftClusterDocuments, error) {
+	docs, err := i.OpenShiftClusterDocumentIterator.Next(ctx, maxItemCount)
 	if err != nil {
 		return nil, err
 	}
-	return docs, nil
+
+	return docs, migrateOpenShiftClusterDocuments(docs)
 }
 
-func (c *openShiftClusters) GetAllResourceIDs(ctx context.Context, continuation string) (cosmosdb.OpenShiftClusterDocumentIterator, error) {
-	return c.c.Query(
-		"",
-		&cosmosdb.Query{
-			Query: OpenshiftClustersClusterResourceIDOnlyQuery,
-		},
-		&cosmosdb.Options{Continuation: continuation},
-	), nil
+func migrateOpenShiftClusterDocuments(docs *api.OpenShiftClusterDocuments) error {
+	if docs == nil {
+		return nil
+	}
+
+	for _, doc := range docs.OpenShiftClusterDocuments {
+		_, err := MigrateOpenShiftClusterDocument(doc)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
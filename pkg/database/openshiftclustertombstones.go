@@ -0,0 +1,172 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+)
+
+const (
+	OpenShiftClusterTombstonesGetQuery = `SELECT * FROM OpenShiftClusterTombstones doc WHERE doc.key = @key`
+
+	// DefaultOpenShiftClusterTombstoneRetention is how long tombstones of
+	// deleted clusters are kept unless configured otherwise.
+	DefaultOpenShiftClusterTombstoneRetention = 90 * 24 * time.Hour
+)
+
+type openShiftClusterTombstones struct {
+	c         cosmosdb.OpenShiftClusterDocumentClient
+	retention time.Duration
+}
+
+// OpenShiftClusterTombstones is the database interface for tombstones of
+// deleted clusters.  When a cluster deletion completes, its
+// OpenShiftClusterDocument is copied into the OpenShiftClusterTombstones
+// collection before being removed from OpenShiftClusters.  Tombstones are
+// kept for a limited retention period (enforced by Cosmos DB TTL) so that
+// support can investigate deleted clusters and billing reconciliation has an
+// authoritative record of what existed.
+//
+// Tombstones live in their own collection so that they do not collide with
+// the unique keys of OpenShiftClusters (a cluster may be recreated with the
+// same name) and so that no existing query or change feed consumer has to
+// learn to skip them.
+type OpenShiftClusterTombstones interface {
+	Create(context.Context, *api.OpenShiftClusterDocument) (*api.OpenShiftClusterDocument, error)
+	ListByKey(context.Context, string) (*api.OpenShiftClusterDocuments, error)
+	List(string) cosmosdb.OpenShiftClusterDocumentIterator
+}
+
+// NewOpenShiftClusterTombstones returns a new OpenShiftClusterTombstones
+func NewOpenShiftClusterTombstones(ctx context.Context, dbc cosmosdb.DatabaseClient, dbName string, retention time.Duration) (OpenShiftClusterTombstones, error) {
+	collc := cosmosdb.NewCollectionClient(dbc, dbName)
+
+	documentClient := cosmosdb.NewOpenShiftClusterDocumentClient(collc, collOpenShiftClusterTombstones)
+	return NewOpenShiftClusterTombstonesWithProvidedClient(documentClient, retention), nil
+}
+
+// NewOpenShiftClusterTombstonesWithProvidedClient returns a new
+// OpenShiftClusterTombstones.  A retention of 0 means the default: a TTL of 0
+// would otherwise keep tombstones forever.
+func NewOpenShiftClusterTombstonesWithProvidedClient(client cosmosdb.OpenShiftClusterDocumentClient, retention time.Duration) OpenShiftClusterTombstones {
+	if retention == 0 {
+		retention = DefaultOpenShiftClusterTombstoneRetention
+	}
+
+	return &openShiftClusterTombstones{
+		c:         client,
+		retention: retention,
+	}
+}
+
+// Create stores a tombstone of doc.  Secrets are removed from the tombstone:
+// they are of no use once the cluster is gone.  The tombstone shares its ID
+// with the original document, so creating it again (e.g. if a previous
+// attempt to delete the original document failed) is not an error.
+func (c *openShiftClusterTombstones) Create(ctx context.Context, doc *api.OpenShiftClusterDocument) (*api.OpenShiftClusterDocument, error) {
+	if doc.Key != strings.ToLower(doc.Key) {
+		return nil, fmt.Errorf("key %q is not lower case", doc.Key)
+	}
+
+	tombstone := &api.OpenShiftClusterDocument{
+		ID:                        doc.ID,
+		SchemaVersion:             doc.SchemaVersion,
+		Key:                       doc.Key,
+		PartitionKey:              doc.PartitionKey,
+		ClusterResourceGroupIDKey: doc.ClusterResourceGroupIDKey,
+		ClientIDKey:               doc.ClientIDKey,
+		Bucket:                    doc.Bucket,
+		CorrelationData:           doc.CorrelationData,
+		TTL:                       int(c.retention / time.Second),
+	}
+
+	if doc.OpenShiftCluster != nil {
+		tombstone.OpenShiftCluster = &api.OpenShiftCluster{
+			MissingFields: doc.OpenShiftCluster.MissingFields,
+			ID:            doc.OpenShiftCluster.ID,
+			Name:          doc.OpenShiftCluster.Name,
+			Type:          doc.OpenShiftCluster.Type,
+			Location:      doc.OpenShiftCluster.Location,
+			SystemData:    doc.OpenShiftCluster.SystemData,
+			Tags:          doc.OpenShiftCluster.Tags,
+			Properties:    doc.OpenShiftCluster.Properties,
+			Identity:      doc.OpenShiftCluster.Identity,
+		}
+		removeSecrets(&tombstone.OpenShiftCluster.Properties)
+	}
+
+	tombstone, err := c.c.Create(ctx, tombstone.PartitionKey, tombstone, nil)
+	if cosmosdb.IsErrorStatusCode(err, http.StatusConflict) {
+		return c.c.Get(ctx, doc.PartitionKey, doc.ID, nil)
+	}
+
+	return tombstone, err
+}
+
+// ListByKey returns all tombstones of clusters with the given resource ID.
+// There may be more than one if a cluster was recreated with the same name.
+func (c *openShiftClusterTombstones) ListByKey(ctx context.Context, key string) (*api.OpenShiftClusterDocuments, error) {
+	if key != strings.ToLower(key) {
+		return nil, fmt.Errorf("key %q is not lower case", key)
+	}
+
+	r, err := azure.ParseResourceID(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.c.QueryAll(ctx, r.SubscriptionID, &cosmosdb.Query{
+		Query: OpenShiftClusterTombstonesGetQuery,
+		Parameters: []cosmosdb.Parameter{
+			{
+				Name:  "@key",
+				Value: key,
+			},
+		},
+	}, nil)
+}
+
+func (c *openShiftClusterTombstones) List(continuation string) cosmosdb.OpenShiftClusterDocumentIterator {
+	return c.c.List(&cosmosdb.Options{Continuation: continuation})
+}
+
+// removeSecrets clears all secure fields of p.  Nested structs holding
+// secrets are copied rather than modified in place, so that the caller's
+// document is untouched.
+func removeSecrets(p *api.OpenShiftClusterProperties) {
+	p.ClusterProfile.PullSecret = ""
+	p.ClusterProfile.BoundServiceAccountSigningKey = nil
+
+	if p.ServicePrincipalProfile != nil {
+		spp := *p.ServicePrincipalProfile
+		spp.ClientSecret = ""
+		p.ServicePrincipalProfile = &spp
+	}
+
+	p.SSHKey = nil
+	p.AdminKubeconfig = nil
+	p.AROServiceKubeconfig = nil
+	p.AROSREKubeconfig = nil
+	p.KubeadminPassword = ""
+	p.UserAdminKubeconfig = nil
+
+	if p.RegistryProfiles != nil {
+		registryProfiles := make([]*api.RegistryProfile, 0, len(p.RegistryProfiles))
+		for _, rp := range p.RegistryProfiles {
+			rp := *rp
+			rp.Password = ""
+			registryProfiles = append(registryProfiles, &rp)
+		}
+		p.RegistryProfiles = registryProfiles
+	}
+}
@@ -0,0 +1,119 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+)
+
+func TestOpenShiftClusterTombstones(t *testing.T) {
+	ctx := context.Background()
+	key := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroup/providers/microsoft.redhatopenshift/openshiftclusters/cluster"
+
+	h, err := NewJSONHandle(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := cosmosdb.NewFakeOpenShiftClusterDocumentClient(h)
+	client.SetQueryHandler(OpenShiftClusterTombstonesGetQuery, fakeClusterMatchQuery)
+	c := NewOpenShiftClusterTombstonesWithProvidedClient(client, time.Hour)
+
+	doc := &api.OpenShiftClusterDocument{
+		ID:           "id",
+		Key:          key,
+		PartitionKey: "00000000-0000-0000-0000-000000000000",
+		LeaseOwner:   "owner",
+		OpenShiftCluster: &api.OpenShiftCluster{
+			Properties: api.OpenShiftClusterProperties{
+				ProvisioningState: api.ProvisioningStateDeleting,
+				ClusterProfile: api.ClusterProfile{
+					PullSecret: "pullsecret",
+					Domain:     "domain",
+				},
+				ServicePrincipalProfile: &api.ServicePrincipalProfile{
+					ClientID:     "clientid",
+					ClientSecret: "clientsecret",
+				},
+				AdminKubeconfig: api.SecureBytes("kubeconfig"),
+				RegistryProfiles: []*api.RegistryProfile{
+					{
+						Name:     "registry",
+						Password: "password",
+					},
+				},
+			},
+		},
+	}
+
+	tombstone, err := c.Create(ctx, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tombstone.TTL != 3600 {
+		t.Error(tombstone.TTL)
+	}
+	if tombstone.LeaseOwner != "" {
+		t.Error(tombstone.LeaseOwner)
+	}
+
+	p := tombstone.OpenShiftCluster.Properties
+	if p.ClusterProfile.PullSecret != "" ||
+		p.ServicePrincipalProfile.ClientSecret != "" ||
+		p.AdminKubeconfig != nil ||
+		p.RegistryProfiles[0].Password != "" {
+		t.Error("tombstone contains secrets")
+	}
+	if p.ClusterProfile.Domain != "domain" || p.ServicePrincipalProfile.ClientID != "clientid" {
+		t.Error("tombstone is missing non-secret fields")
+	}
+
+	// the original document must not be modified
+	if doc.OpenShiftCluster.Properties.ServicePrincipalProfile.ClientSecret != "clientsecret" ||
+		doc.OpenShiftCluster.Properties.RegistryProfiles[0].Password != "password" {
+		t.Error("original document was modified")
+	}
+
+	// creating the tombstone again is not an error
+	_, err = c.Create(ctx, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := c.ListByKey(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs.OpenShiftClusterDocuments) != 1 {
+		t.Errorf("got %d tombstones, expected 1", len(docs.OpenShiftClusterDocuments))
+	}
+}
+
+func TestOpenShiftClusterTombstonesDefaultRetention(t *testing.T) {
+	ctx := context.Background()
+
+	h, err := NewJSONHandle(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewOpenShiftClusterTombstonesWithProvidedClient(cosmosdb.NewFakeOpenShiftClusterDocumentClient(h), 0)
+
+	tombstone, err := c.Create(ctx, &api.OpenShiftClusterDocument{
+		ID:  "id",
+		Key: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroup/providers/microsoft.redhatopenshift/openshiftclusters/cluster",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tombstone.TTL != int(DefaultOpenShiftClusterTombstoneRetention/time.Second) {
+		t.Error(tombstone.TTL)
+	}
+}
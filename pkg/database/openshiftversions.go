@@ -74,7 +74,7 @@ func (c *openShiftVersions) Get(ctx context.Context, id string) (*api.OpenShiftV
 func (c *openShiftVersions) Patch(ctx context.Context, id string, f func(*api.OpenShiftVersionDocument) error) (*api.OpenShiftVersionDocument, error) {
 	var doc *api.OpenShiftVersionDocument
 
-	err := cosmosdb.RetryOnPreconditionFailed(func() (err error) {
+	err := RetryOnPreconditionFailed(ctx, func() (err error) {
 		doc, err = c.Get(ctx, id)
 		if err != nil {
 			return
@@ -111,3 +111,25 @@ func (c *openShiftVersions) ListAll(ctx context.Context) (*api.OpenShiftVersionD
 func (c *openShiftVersions) NewUUID() string {
 	return c.uuid.Generate()
 }
+
+// EnsureDefaultOpenShiftVersion creates v as the default version if there are
+// no OpenShiftVersion documents, so that a new environment can install
+// clusters before its versions are managed via the admin API
+func EnsureDefaultOpenShiftVersion(ctx context.Context, db OpenShiftVersions, v *api.OpenShiftVersion) error {
+	docs, err := db.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	if docs != nil && len(docs.OpenShiftVersionDocuments) > 0 {
+		return nil
+	}
+
+	v.Properties.Default = true
+
+	_, err = db.Create(ctx, &api.OpenShiftVersionDocument{
+		ID:               db.NewUUID(),
+		OpenShiftVersion: v,
+	})
+	return err
+}
@@ -0,0 +1,87 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	uuidfake "github.com/Azure/ARO-RP/pkg/util/uuid/fake"
+)
+
+func TestEnsureDefaultOpenShiftVersion(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name         string
+		existing     []string
+		wantVersions []string
+		wantDefault  string
+	}{
+		{
+			name:         "empty database is seeded",
+			wantVersions: []string{"4.13.40"},
+			wantDefault:  "4.13.40",
+		},
+		{
+			name:         "existing versions are kept",
+			existing:     []string{"4.14.16"},
+			wantVersions: []string{"4.14.16"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := NewJSONHandle(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := cosmosdb.NewFakeOpenShiftVersionDocumentClient(h)
+			db := NewOpenShiftVersionsWithProvidedClient(client, uuidfake.NewGenerator([]string{"00000000-0000-0000-0000-000000000001"}))
+
+			for _, v := range tt.existing {
+				_, err := client.Create(ctx, "", &api.OpenShiftVersionDocument{
+					ID: v,
+					OpenShiftVersion: &api.OpenShiftVersion{
+						Properties: api.OpenShiftVersionProperties{
+							Version: v,
+							Enabled: true,
+						},
+					},
+				}, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err = EnsureDefaultOpenShiftVersion(ctx, db, &api.OpenShiftVersion{
+				Properties: api.OpenShiftVersionProperties{
+					Version: "4.13.40",
+					Enabled: true,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			docs, err := db.ListAll(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(docs.OpenShiftVersionDocuments) != len(tt.wantVersions) {
+				t.Fatal(len(docs.OpenShiftVersionDocuments))
+			}
+			for i, doc := range docs.OpenShiftVersionDocuments {
+				if doc.OpenShiftVersion.Properties.Version != tt.wantVersions[i] {
+					t.Error(doc.OpenShiftVersion.Properties.Version)
+				}
+				if doc.OpenShiftVersion.Properties.Default != (doc.OpenShiftVersion.Properties.Version == tt.wantDefault) {
+					t.Error(doc.OpenShiftVersion.Properties.Default)
+				}
+			}
+		})
+	}
+}
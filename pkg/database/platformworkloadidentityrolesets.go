@@ -74,7 +74,7 @@ func (c *platformWorkloadIdentityRoleSets) Get(ctx context.Context, id string) (
 func (c *platformWorkloadIdentityRoleSets) Patch(ctx context.Context, id string, f func(*api.PlatformWorkloadIdentityRoleSetDocument) error) (*api.PlatformWorkloadIdentityRoleSetDocument, error) {
 	var doc *api.PlatformWorkloadIdentityRoleSetDocument
 
-	err := cosmosdb.RetryOnPreconditionFailed(func() (err error) {
+	err := RetryOnPreconditionFailed(ctx, func() (err error) {
 		doc, err = c.Get(ctx, id)
 		if err != nil {
 			return
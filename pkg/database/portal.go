@@ -14,6 +14,11 @@ import (
 	"github.com/Azure/ARO-RP/pkg/util/uuid"
 )
 
+const (
+	PortalSSHSessionsQuery       = `SELECT * FROM Portal doc WHERE doc.portal.id = @id AND IS_DEFINED(doc.portal.sshSession)`
+	PortalPrometheusQueriesQuery = `SELECT * FROM Portal doc WHERE IS_DEFINED(doc.portal.prometheusQuery)`
+)
+
 type portals struct {
 	c             cosmosdb.PortalDocumentClient
 	uuidGenerator uuid.Generator
@@ -24,6 +29,9 @@ type Portal interface {
 	Create(context.Context, *api.PortalDocument) (*api.PortalDocument, error)
 	Get(context.Context, string) (*api.PortalDocument, error)
 	Patch(context.Context, string, func(*api.PortalDocument) error) (*api.PortalDocument, error)
+	Delete(context.Context, *api.PortalDocument) error
+	ListSSHSessions(context.Context, string) (*api.PortalDocuments, error)
+	ListPrometheusQueries(context.Context) (*api.PortalDocuments, error)
 	NewUUID() string
 }
 
@@ -71,7 +79,7 @@ func (c *portals) Get(ctx context.Context, id string) (*api.PortalDocument, erro
 func (c *portals) Patch(ctx context.Context, id string, f func(*api.PortalDocument) error) (*api.PortalDocument, error) {
 	var doc *api.PortalDocument
 
-	err := cosmosdb.RetryOnPreconditionFailed(func() (err error) {
+	err := RetryOnPreconditionFailed(ctx, func() (err error) {
 		doc, err = c.Get(ctx, id)
 		if err != nil {
 			return
@@ -88,3 +96,33 @@ func (c *portals) Patch(ctx context.Context, id string, f func(*api.PortalDocume
 
 	return doc, err
 }
+
+func (c *portals) Delete(ctx context.Context, doc *api.PortalDocument) error {
+	if doc.ID != strings.ToLower(doc.ID) {
+		return fmt.Errorf("id %q is not lower case", doc.ID)
+	}
+
+	return c.c.Delete(ctx, doc.ID, doc, &cosmosdb.Options{NoETag: true})
+}
+
+// ListSSHSessions returns the recorded SSH sessions to the given cluster
+func (c *portals) ListSSHSessions(ctx context.Context, resourceID string) (*api.PortalDocuments, error) {
+	if resourceID != strings.ToLower(resourceID) {
+		return nil, fmt.Errorf("resourceID %q is not lower case", resourceID)
+	}
+
+	return c.c.QueryAll(ctx, "", &cosmosdb.Query{
+		Query: PortalSSHSessionsQuery,
+		Parameters: []cosmosdb.Parameter{
+			{
+				Name:  "@id",
+				Value: resourceID,
+			},
+		},
+	}, nil)
+}
+
+// ListPrometheusQueries returns the saved Prometheus queries
+func (c *portals) ListPrometheusQueries(ctx context.Context) (*api.PortalDocuments, error) {
+	return c.c.QueryAll(ctx, "", &cosmosdb.Query{Query: PortalPrometheusQueriesQuery}, nil)
+}
@@ -0,0 +1,34 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ReencryptOpenShiftClusters rewrites every OpenShiftClusterDocument so that
+// its secure fields are sealed with the current version of the document
+// encryption key.
+//
+// The database AEAD opens with every enabled version of the key and always
+// seals with the latest one, so documents are re-encrypted opportunistically
+// whenever they are written.  Documents which are rarely written (e.g. stable
+// clusters which are never updated) would keep depending on old key versions
+// indefinitely; this sweep finishes the rotation so that old key versions
+// can be disabled.  See docs/keyvaults.md for the full procedure.
+func ReencryptOpenShiftClusters(ctx context.Context, log *logrus.Entry, dbOpenShiftClusters OpenShiftClusters) error {
+	failed, err := rewriteOpenShiftClusters(ctx, log, dbOpenShiftClusters, dbOpenShiftClusters.List(""))
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to re-encrypt %d documents", failed)
+	}
+
+	return nil
+}
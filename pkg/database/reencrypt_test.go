@@ -0,0 +1,81 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/util/uuid"
+)
+
+// versionedAEAD is a toy AEAD which prefixes sealed data with the current key
+// version and opens data sealed with any known version, mimicking the
+// multi-version AEAD used in production.
+type versionedAEAD struct {
+	version byte
+}
+
+func (a *versionedAEAD) Open(input []byte) ([]byte, error) {
+	if len(input) == 0 || input[0] > a.version {
+		return nil, errors.New("unknown key version")
+	}
+	return input[1:], nil
+}
+
+func (a *versionedAEAD) Seal(input []byte) ([]byte, error) {
+	return append([]byte{a.version}, input...), nil
+}
+
+func TestReencryptOpenShiftClusters(t *testing.T) {
+	ctx := context.Background()
+	key := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroup/providers/microsoft.redhatopenshift/openshiftclusters/cluster"
+
+	aead := &versionedAEAD{version: 1}
+	h, err := NewJSONHandle(aead)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := cosmosdb.NewFakeOpenShiftClusterDocumentClient(h)
+	client.SetQueryHandler(OpenShiftClustersGetQuery, fakeClusterMatchQuery)
+	c := NewOpenShiftClustersWithProvidedClient(client, cosmosdb.NewFakeClusterLookupDocumentClient(h), nil, "", uuid.DefaultGenerator)
+
+	created, err := c.Create(ctx, &api.OpenShiftClusterDocument{
+		Key: key,
+		OpenShiftCluster: &api.OpenShiftCluster{
+			Properties: api.OpenShiftClusterProperties{
+				AdminKubeconfig: api.SecureBytes("kubeconfig"),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// rotate the key
+	aead.version = 2
+
+	err = ReencryptOpenShiftClusters(ctx, logrus.NewEntry(logrus.StandardLogger()), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := c.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.ETag == created.ETag {
+		t.Error("document was not rewritten")
+	}
+	if !bytes.Equal(doc.OpenShiftCluster.Properties.AdminKubeconfig, []byte("kubeconfig")) {
+		t.Error(string(doc.OpenShiftCluster.Properties.AdminKubeconfig))
+	}
+}
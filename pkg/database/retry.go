@@ -0,0 +1,25 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+)
+
+// RetryOnPreconditionFailed is cosmosdb.RetryOnPreconditionFailed, which also
+// counts each retry against the instrumented database operation in ctx, if
+// any
+func RetryOnPreconditionFailed(ctx context.Context, f func() error) error {
+	var attempted bool
+	return cosmosdb.RetryOnPreconditionFailed(func() error {
+		if attempted {
+			countRetry(ctx)
+		}
+		attempted = true
+
+		return f()
+	})
+}
@@ -0,0 +1,65 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestRetryOnPreconditionFailed(t *testing.T) {
+	preconditionFailed := &cosmosdb.Error{StatusCode: http.StatusPreconditionFailed}
+
+	for _, tt := range []struct {
+		name         string
+		errs         []error
+		wantErr      string
+		wantAttempts int
+		wantRetries  int64
+	}{
+		{
+			name:         "succeeds first time",
+			errs:         []error{nil},
+			wantAttempts: 1,
+		},
+		{
+			name:         "other errors are not retried",
+			errs:         []error{errors.New("failed")},
+			wantErr:      "failed",
+			wantAttempts: 1,
+		},
+		{
+			name:         "succeeds after precondition failure",
+			errs:         []error{preconditionFailed, nil},
+			wantAttempts: 2,
+			wantRetries:  1,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, stats := withOperationStats(context.Background())
+
+			var attempts int
+			err := RetryOnPreconditionFailed(ctx, func() error {
+				err := tt.errs[attempts]
+				attempts++
+				return err
+			})
+
+			if attempts != tt.wantAttempts {
+				t.Errorf("got %d attempts, expected %d", attempts, tt.wantAttempts)
+			}
+
+			if stats.retries != tt.wantRetries {
+				t.Errorf("got %d retries, expected %d", stats.retries, tt.wantRetries)
+			}
+
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
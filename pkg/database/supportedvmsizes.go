@@ -0,0 +1,113 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/util/uuid"
+)
+
+type supportedVMSizes struct {
+	c    cosmosdb.SupportedVMSizeDocumentClient
+	uuid uuid.Generator
+}
+
+type SupportedVMSizes interface {
+	ChangeFeed() cosmosdb.SupportedVMSizeDocumentIterator
+	Create(context.Context, *api.SupportedVMSizeDocument) (*api.SupportedVMSizeDocument, error)
+	Delete(context.Context, *api.SupportedVMSizeDocument) error
+	Get(context.Context, string) (*api.SupportedVMSizeDocument, error)
+	Update(context.Context, *api.SupportedVMSizeDocument) (*api.SupportedVMSizeDocument, error)
+	Patch(context.Context, string, func(*api.SupportedVMSizeDocument) error) (*api.SupportedVMSizeDocument, error)
+	ListAll(context.Context) (*api.SupportedVMSizeDocuments, error)
+	NewUUID() string
+}
+
+func NewSupportedVMSizes(ctx context.Context, dbc cosmosdb.DatabaseClient, dbName string) (SupportedVMSizes, error) {
+	collc := cosmosdb.NewCollectionClient(dbc, dbName)
+
+	documentClient := cosmosdb.NewSupportedVMSizeDocumentClient(collc, collSupportedVMSizes)
+	return NewSupportedVMSizesWithProvidedClient(documentClient, uuid.DefaultGenerator), nil
+}
+
+func NewSupportedVMSizesWithProvidedClient(client cosmosdb.SupportedVMSizeDocumentClient, uuid uuid.Generator) SupportedVMSizes {
+	return &supportedVMSizes{
+		c:    client,
+		uuid: uuid,
+	}
+}
+
+func (c *supportedVMSizes) ChangeFeed() cosmosdb.SupportedVMSizeDocumentIterator {
+	return c.c.ChangeFeed(nil)
+}
+
+func (c *supportedVMSizes) Create(ctx context.Context, doc *api.SupportedVMSizeDocument) (*api.SupportedVMSizeDocument, error) {
+	if doc.ID != strings.ToLower(doc.ID) {
+		return nil, fmt.Errorf("id %q is not lower case", doc.ID)
+	}
+
+	return c.c.Create(ctx, doc.ID, doc, nil)
+}
+
+func (c *supportedVMSizes) Delete(ctx context.Context, doc *api.SupportedVMSizeDocument) error {
+	if doc.ID != strings.ToLower(doc.ID) {
+		return fmt.Errorf("id %q is not lower case", doc.ID)
+	}
+
+	return c.c.Delete(ctx, doc.ID, doc, &cosmosdb.Options{NoETag: true})
+}
+
+func (c *supportedVMSizes) Get(ctx context.Context, id string) (*api.SupportedVMSizeDocument, error) {
+	if id != strings.ToLower(id) {
+		return nil, fmt.Errorf("id %q is not lower case", id)
+	}
+
+	return c.c.Get(ctx, id, id, nil)
+}
+
+func (c *supportedVMSizes) Patch(ctx context.Context, id string, f func(*api.SupportedVMSizeDocument) error) (*api.SupportedVMSizeDocument, error) {
+	var doc *api.SupportedVMSizeDocument
+
+	err := RetryOnPreconditionFailed(ctx, func() (err error) {
+		doc, err = c.Get(ctx, id)
+		if err != nil {
+			return
+		}
+
+		err = f(doc)
+		if err != nil {
+			return
+		}
+
+		doc, err = c.update(ctx, doc)
+		return
+	})
+
+	return doc, err
+}
+
+func (c *supportedVMSizes) Update(ctx context.Context, doc *api.SupportedVMSizeDocument) (*api.SupportedVMSizeDocument, error) {
+	return c.update(ctx, doc)
+}
+
+func (c *supportedVMSizes) update(ctx context.Context, doc *api.SupportedVMSizeDocument) (*api.SupportedVMSizeDocument, error) {
+	if doc.ID != strings.ToLower(doc.ID) {
+		return nil, fmt.Errorf("id %q is not lower case", doc.ID)
+	}
+
+	return c.c.Replace(ctx, doc.ID, doc, nil)
+}
+
+func (c *supportedVMSizes) ListAll(ctx context.Context) (*api.SupportedVMSizeDocuments, error) {
+	return c.c.ListAll(ctx, nil)
+}
+
+func (c *supportedVMSizes) NewUUID() string {
+	return c.uuid.Generate()
+}
@@ -0,0 +1,153 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/metrics"
+)
+
+const (
+	// throttlingMaxWait bounds the total time a single request may spend
+	// waiting for Cosmos DB to stop throttling it.
+	throttlingMaxWait = 10 * time.Second
+
+	// throttlingMaxWaiters bounds the number of requests which may be
+	// waiting on throttling at once.  When Cosmos DB is throttling hard,
+	// queuing more requests only adds to the backlog, so further throttled
+	// requests fail fast instead.
+	throttlingMaxWaiters = 100
+
+	// throttlingDefaultRetryAfter is used when a 429 response does not carry
+	// a parseable x-ms-retry-after-ms header.
+	throttlingDefaultRetryAfter = 100 * time.Millisecond
+)
+
+// ThrottledError is returned when a request is still being throttled by
+// Cosmos DB after the client has waited as long as it is prepared to.
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("request throttled by Cosmos DB, retry after %s", e.RetryAfter)
+}
+
+// IsThrottled returns true and the suggested retry interval if err is, or
+// wraps, a ThrottledError.
+func IsThrottled(err error) (time.Duration, bool) {
+	var throttledErr *ThrottledError
+	if errors.As(err, &throttledErr) {
+		return throttledErr.RetryAfter, true
+	}
+	return 0, false
+}
+
+var _ http.RoundTripper = (*throttlingRoundTripper)(nil)
+
+// throttlingRoundTripper retries requests which Cosmos DB throttles (429),
+// honouring x-ms-retry-after-ms.  Unlike the retry loop in the generated
+// client, it respects the request context, bounds the total wait and the
+// number of concurrently waiting requests, and reports requests it gives up
+// on as a ThrottledError so that callers can distinguish throttling from
+// other failures.
+type throttlingRoundTripper struct {
+	tr      http.RoundTripper
+	m       metrics.Emitter
+	waiters chan struct{}
+	maxWait time.Duration
+}
+
+func newThrottlingRoundTripper(tr http.RoundTripper, m metrics.Emitter) *throttlingRoundTripper {
+	return &throttlingRoundTripper{
+		tr:      tr,
+		m:       m,
+		waiters: make(chan struct{}, throttlingMaxWaiters),
+		maxWait: throttlingMaxWait,
+	}
+}
+
+func (t *throttlingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// the request body must be replayable to retry it
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var waited time.Duration
+	for {
+		if body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err := t.tr.RoundTrip(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+			return resp, err
+		}
+
+		retryAfter := throttlingDefaultRetryAfter
+		if ms, err := strconv.ParseInt(resp.Header.Get("x-ms-retry-after-ms"), 10, 64); err == nil {
+			retryAfter = time.Duration(ms) * time.Millisecond
+		}
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if waited+retryAfter > t.maxWait {
+			t.emitThrottled(req, "exhausted")
+			return nil, &ThrottledError{RetryAfter: retryAfter}
+		}
+
+		select {
+		case t.waiters <- struct{}{}:
+		default:
+			t.emitThrottled(req, "rejected")
+			return nil, &ThrottledError{RetryAfter: retryAfter}
+		}
+
+		t.emitThrottled(req, "retried")
+		countRetry(req.Context())
+
+		timer := time.NewTimer(retryAfter)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			<-t.waiters
+			return nil, req.Context().Err()
+		}
+		<-t.waiters
+
+		waited += retryAfter
+	}
+}
+
+func (t *throttlingRoundTripper) emitThrottled(req *http.Request, outcome string) {
+	var collection string
+	parts := strings.Split(req.URL.Path, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == "colls" {
+			collection = parts[i+1]
+		}
+	}
+
+	t.m.EmitGauge("client.cosmosdb.throttled", 1, map[string]string{
+		"verb":       req.Method,
+		"collection": collection,
+		"outcome":    outcome,
+	})
+}
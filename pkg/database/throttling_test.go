@@ -0,0 +1,138 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+// scriptedRoundTripper returns the given status codes in order and records
+// the request bodies it receives.
+type scriptedRoundTripper struct {
+	statusCodes []int
+	bodies      []string
+}
+
+func (rt *scriptedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	b, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	rt.bodies = append(rt.bodies, string(b))
+
+	statusCode := rt.statusCodes[0]
+	rt.statusCodes = rt.statusCodes[1:]
+
+	return &http.Response{
+		StatusCode: statusCode,
+		Header: http.Header{
+			"X-Ms-Retry-After-Ms": []string{"1"},
+		},
+		Body: io.NopCloser(&bytes.Buffer{}),
+	}, nil
+}
+
+func TestThrottlingRoundTripper(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		statusCodes    []int
+		maxWait        time.Duration
+		fullQueue      bool
+		wantOutcomes   []string
+		wantStatusCode int
+		wantThrottled  bool
+		wantAttempts   int
+	}{
+		{
+			name:           "not throttled",
+			statusCodes:    []int{http.StatusCreated},
+			maxWait:        time.Second,
+			wantStatusCode: http.StatusCreated,
+			wantAttempts:   1,
+		},
+		{
+			name:           "throttled then succeeds",
+			statusCodes:    []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusCreated},
+			maxWait:        time.Second,
+			wantOutcomes:   []string{"retried", "retried"},
+			wantStatusCode: http.StatusCreated,
+			wantAttempts:   3,
+		},
+		{
+			name:          "gives up after max wait",
+			statusCodes:   []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests},
+			maxWait:       2 * time.Millisecond,
+			wantOutcomes:  []string{"retried", "retried", "exhausted"},
+			wantThrottled: true,
+			wantAttempts:  3,
+		},
+		{
+			name:          "fails fast when too many requests are waiting",
+			statusCodes:   []int{http.StatusTooManyRequests},
+			maxWait:       time.Second,
+			fullQueue:     true,
+			wantOutcomes:  []string{"rejected"},
+			wantThrottled: true,
+			wantAttempts:  1,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := mock_metrics.NewMockEmitter(controller)
+			for _, outcome := range tt.wantOutcomes {
+				m.EXPECT().EmitGauge("client.cosmosdb.throttled", int64(1), map[string]string{
+					"verb":       http.MethodPost,
+					"collection": "OpenShiftClusters",
+					"outcome":    outcome,
+				})
+			}
+
+			rt := &scriptedRoundTripper{statusCodes: tt.statusCodes}
+			trt := newThrottlingRoundTripper(rt, m)
+			trt.maxWait = tt.maxWait
+			if tt.fullQueue {
+				trt.waiters = make(chan struct{})
+			}
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://localhost/dbs/ARO/colls/OpenShiftClusters/docs", strings.NewReader("body"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := trt.RoundTrip(req)
+			if _, throttled := IsThrottled(err); throttled != tt.wantThrottled {
+				t.Fatal(err)
+			}
+			if !tt.wantThrottled {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if resp.StatusCode != tt.wantStatusCode {
+					t.Error(resp.StatusCode)
+				}
+			}
+
+			if len(rt.bodies) != tt.wantAttempts {
+				t.Errorf("got %d attempts, expected %d", len(rt.bodies), tt.wantAttempts)
+			}
+			for _, body := range rt.bodies {
+				if body != "body" {
+					t.Errorf("body was not replayed: %q", body)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package deploy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+)
+
+// rpCanaryCapacity returns the number of instances of a canary scaleset which
+// serve approximately trafficPercentage of the traffic alongside oldCapacity
+// old instances.  The RP load balancer spreads traffic evenly over the healthy
+// instances of all scalesets, so traffic is split by instance count, rounded
+// up to at least one canary instance.
+func rpCanaryCapacity(oldCapacity int64, trafficPercentage int) int64 {
+	p := int64(trafficPercentage)
+	return (oldCapacity*p + 100 - p - 1) / (100 - p)
+}
+
+// rpOldCapacity returns the total capacity of the RP scalesets other than the
+// one being deployed.
+func (d *deployer) rpOldCapacity(ctx context.Context) (int64, error) {
+	scalesets, err := d.vmss.List(ctx, d.config.RPResourceGroupName)
+	if err != nil {
+		return 0, err
+	}
+
+	var capacity int64
+	for _, vmss := range scalesets {
+		if *vmss.Name == rpVMSSPrefix+d.version || vmss.Sku == nil || vmss.Sku.Capacity == nil {
+			continue
+		}
+
+		capacity += *vmss.Sku.Capacity
+	}
+
+	return capacity, nil
+}
+
+// rpDeployCapacity returns the capacity with which to deploy the new RP
+// scaleset, or 0 to use the configured capacity.  If canary deployments are
+// enabled and there are old scalesets, the new scaleset is deployed as a
+// canary.
+func (d *deployer) rpDeployCapacity(ctx context.Context) (int64, error) {
+	if d.config.Configuration.RPCanary == nil {
+		return 0, nil
+	}
+
+	oldCapacity, err := d.rpOldCapacity(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if oldCapacity == 0 {
+		return 0, nil
+	}
+
+	capacity := rpCanaryCapacity(oldCapacity, d.config.Configuration.RPCanary.TrafficPercentage)
+	d.log.Printf("deploying %s as a canary with %d instance(s) alongside %d old instance(s)", rpVMSSPrefix+d.version, capacity, oldCapacity)
+
+	return capacity, nil
+}
+
+// rpUpgradeCanary watches the health of the canary scaleset for the bake
+// duration, then scales it up to the full capacity.  If the canary becomes
+// unhealthy or fails to scale up, it is rolled back.
+func (d *deployer) rpUpgradeCanary(ctx context.Context, oldCapacity int64) error {
+	vmssName := rpVMSSPrefix + d.version
+	canary := d.config.Configuration.RPCanary
+
+	bakeDuration, err := time.ParseDuration(canary.BakeDuration)
+	if err != nil {
+		return err
+	}
+
+	err = d.watchHealth(ctx, d.config.RPResourceGroupName, vmssName, bakeDuration, canary.UnhealthyThreshold)
+	if err != nil {
+		return d.rollback(ctx, vmssName, err, d.rpRemoveOldScaleset)
+	}
+
+	capacity := oldCapacity
+	if d.config.Configuration.RPVMSSCapacity != nil {
+		capacity = int64(*d.config.Configuration.RPVMSSCapacity)
+	}
+
+	err = d.rpPromoteCanary(ctx, vmssName, capacity)
+	if err != nil {
+		return d.rollback(ctx, vmssName, err, d.rpRemoveOldScaleset)
+	}
+
+	return nil
+}
+
+// rpPromoteCanary scales the canary scaleset up to the full capacity and waits
+// for all its instances to be healthy.
+func (d *deployer) rpPromoteCanary(ctx context.Context, vmssName string, capacity int64) error {
+	scalesets, err := d.vmss.List(ctx, d.config.RPResourceGroupName)
+	if err != nil {
+		return err
+	}
+
+	var sku *mgmtcompute.Sku
+	var zones []string
+	for _, vmss := range scalesets {
+		if *vmss.Name == vmssName {
+			sku = vmss.Sku
+			if vmss.Zones != nil {
+				zones = *vmss.Zones
+			}
+		}
+	}
+	if sku == nil {
+		return fmt.Errorf("scaleset %s not found", vmssName)
+	}
+
+	// a canary placed in zones is promoted to the configured instances per zone
+	if d.config.Configuration.Zones != nil && d.config.Configuration.Zones.RPInstancesPerZone > 0 && len(zones) > 0 {
+		capacity = int64(d.config.Configuration.Zones.RPInstancesPerZone * len(zones))
+	}
+
+	d.log.Printf("promoting canary scaleset %s to %d instance(s)", vmssName, capacity)
+
+	err = d.vmss.UpdateAndWait(ctx, d.config.RPResourceGroupName, vmssName, mgmtcompute.VirtualMachineScaleSetUpdate{
+		Sku: &mgmtcompute.Sku{
+			Name:     sku.Name,
+			Tier:     sku.Tier,
+			Capacity: to.Int64Ptr(capacity),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Hour)
+	defer cancel()
+
+	return d.rpWaitForReadiness(timeoutCtx, vmssName)
+}
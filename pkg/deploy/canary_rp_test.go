@@ -0,0 +1,166 @@
+package deploy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+
+	mock_compute "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/compute"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestRPCanaryCapacity(t *testing.T) {
+	for _, tt := range []struct {
+		oldCapacity       int64
+		trafficPercentage int
+		want              int64
+	}{
+		{oldCapacity: 3, trafficPercentage: 10, want: 1},
+		{oldCapacity: 3, trafficPercentage: 50, want: 3},
+		{oldCapacity: 9, trafficPercentage: 25, want: 3},
+		{oldCapacity: 10, trafficPercentage: 20, want: 3},
+		{oldCapacity: 1, trafficPercentage: 99, want: 99},
+	} {
+		got := rpCanaryCapacity(tt.oldCapacity, tt.trafficPercentage)
+		if got != tt.want {
+			t.Errorf("rpCanaryCapacity(%d, %d) = %d, want %d", tt.oldCapacity, tt.trafficPercentage, got, tt.want)
+		}
+	}
+}
+
+func TestRPCanaryConfigurationValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		canary  RPCanaryConfiguration
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			canary: RPCanaryConfiguration{TrafficPercentage: 10, BakeDuration: "30m"},
+		},
+		{
+			name:    "invalid traffic percentage",
+			canary:  RPCanaryConfiguration{TrafficPercentage: 100, BakeDuration: "30m"},
+			wantErr: "rpCanary.trafficPercentage 100 must be between 1 and 99",
+		},
+		{
+			name:    "invalid bake duration",
+			canary:  RPCanaryConfiguration{TrafficPercentage: 10},
+			wantErr: `invalid rpCanary.bakeDuration ""`,
+		},
+		{
+			name:    "invalid unhealthy threshold",
+			canary:  RPCanaryConfiguration{TrafficPercentage: 10, BakeDuration: "30m", UnhealthyThreshold: -1},
+			wantErr: "rpCanary.unhealthyThreshold -1 must not be negative",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.canary.validate()
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRPUpgradeCanary(t *testing.T) {
+	ctx := context.Background()
+
+	canaryVMSSName := rpVMSSPrefix + "test"
+	sku := &mgmtcompute.Sku{Name: to.StringPtr("Standard_D2s_v3"), Tier: to.StringPtr("Standard")}
+
+	scalesets := []mgmtcompute.VirtualMachineScaleSet{
+		{Name: to.StringPtr(rpVMSSPrefix + "old"), Sku: &mgmtcompute.Sku{Capacity: to.Int64Ptr(3)}},
+		{Name: to.StringPtr(canaryVMSSName), Sku: sku},
+	}
+
+	defer func(interval time.Duration) {
+		healthCheckInterval = interval
+	}(healthCheckInterval)
+	healthCheckInterval = time.Millisecond
+
+	removeScaleset := func(vmss *mock_compute.MockVirtualMachineScaleSetsClient, vmssvms *mock_compute.MockVirtualMachineScaleSetVMsClient, vmssName string) {
+		vmssvms.EXPECT().List(gomock.Any(), rgName, vmssName, "", "", "").Return(vms, nil)
+		vmssvms.EXPECT().RunCommandAndWait(gomock.Any(), rgName, vmssName, instanceID, gomock.Any()).Return(nil)
+		vmss.EXPECT().DeleteAndWait(gomock.Any(), rgName, vmssName).Return(nil)
+	}
+
+	for _, tt := range []struct {
+		name    string
+		mocks   func(*mock_compute.MockVirtualMachineScaleSetsClient, *mock_compute.MockVirtualMachineScaleSetVMsClient)
+		wantErr string
+	}{
+		{
+			name: "healthy canary is promoted",
+			mocks: func(vmss *mock_compute.MockVirtualMachineScaleSetsClient, vmssvms *mock_compute.MockVirtualMachineScaleSetVMsClient) {
+				vmssvms.EXPECT().List(gomock.Any(), rgName, canaryVMSSName, "", "", "").Return(vms, nil).MinTimes(1)
+				vmssvms.EXPECT().GetInstanceView(gomock.Any(), rgName, canaryVMSSName, instanceID).Return(healthyVMSS, nil).MinTimes(1)
+
+				vmss.EXPECT().List(gomock.Any(), rgName).Return(scalesets, nil)
+				vmss.EXPECT().UpdateAndWait(gomock.Any(), rgName, canaryVMSSName, mgmtcompute.VirtualMachineScaleSetUpdate{
+					Sku: &mgmtcompute.Sku{Name: sku.Name, Tier: sku.Tier, Capacity: to.Int64Ptr(3)},
+				}).Return(nil)
+			},
+		},
+		{
+			name: "unhealthy canary is rolled back",
+			mocks: func(vmss *mock_compute.MockVirtualMachineScaleSetsClient, vmssvms *mock_compute.MockVirtualMachineScaleSetVMsClient) {
+				vmssvms.EXPECT().List(gomock.Any(), rgName, canaryVMSSName, "", "", "").Return(vms, nil).Times(3)
+				vmssvms.EXPECT().GetInstanceView(gomock.Any(), rgName, canaryVMSSName, instanceID).Return(unhealthyVMSS, nil).Times(3)
+
+				removeScaleset(vmss, vmssvms, canaryVMSSName)
+			},
+			wantErr: "rolled back scaleset rp-vmss-test: scaleset rp-vmss-test was unhealthy on 3 consecutive checks",
+		},
+		{
+			name: "canary which fails to scale up is rolled back",
+			mocks: func(vmss *mock_compute.MockVirtualMachineScaleSetsClient, vmssvms *mock_compute.MockVirtualMachineScaleSetVMsClient) {
+				vmssvms.EXPECT().List(gomock.Any(), rgName, canaryVMSSName, "", "", "").Return(vms, nil).MinTimes(1)
+				vmssvms.EXPECT().GetInstanceView(gomock.Any(), rgName, canaryVMSSName, instanceID).Return(healthyVMSS, nil).MinTimes(1)
+
+				vmss.EXPECT().List(gomock.Any(), rgName).Return(scalesets, nil)
+				vmss.EXPECT().UpdateAndWait(gomock.Any(), rgName, canaryVMSSName, gomock.Any()).Return(errGeneric)
+
+				// the canary's instances are listed by the health checks too
+				vmssvms.EXPECT().RunCommandAndWait(gomock.Any(), rgName, canaryVMSSName, instanceID, gomock.Any()).Return(nil)
+				vmss.EXPECT().DeleteAndWait(gomock.Any(), rgName, canaryVMSSName).Return(nil)
+			},
+			wantErr: "rolled back scaleset rp-vmss-test: generic error",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockVMSS := mock_compute.NewMockVirtualMachineScaleSetsClient(controller)
+			mockVMSSVMs := mock_compute.NewMockVirtualMachineScaleSetVMsClient(controller)
+
+			tt.mocks(mockVMSS, mockVMSSVMs)
+
+			d := deployer{
+				log:     logrus.NewEntry(logrus.StandardLogger()),
+				vmss:    mockVMSS,
+				vmssvms: mockVMSSVMs,
+				config: &RPConfig{
+					RPResourceGroupName: rgName,
+					Configuration: &Configuration{
+						RPCanary: &RPCanaryConfiguration{
+							TrafficPercentage: 10,
+							BakeDuration:      "50ms",
+						},
+					},
+				},
+				version: "test",
+			}
+
+			err := d.rpUpgradeCanary(ctx, 3)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
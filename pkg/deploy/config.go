@@ -10,6 +10,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest/to"
 	"golang.org/x/crypto/ssh"
@@ -81,6 +82,7 @@ type Configuration struct {
 	NonZonalRegions                    []string               `json:"nonZonalRegions,omitempty"`
 	PortalAccessGroupIDs               []string               `json:"portalAccessGroupIds,omitempty" value:"required"`
 	PortalClientID                     *string                `json:"portalClientId,omitempty" value:"required"`
+	PortalDebugPodImage                *string                `json:"portalDebugPodImage,omitempty" value:"required"`
 	PortalElevatedGroupIDs             []string               `json:"portalElevatedGroupIds,omitempty" value:"required"`
 	RPFeatures                         []string               `json:"rpFeatures,omitempty"`
 	RPImagePrefix                      *string                `json:"rpImagePrefix,omitempty" value:"required"`
@@ -89,6 +91,7 @@ type Configuration struct {
 	RPMDSDConfigVersion                *string                `json:"rpMdsdConfigVersion,omitempty" value:"required"`
 	RPMDSDNamespace                    *string                `json:"rpMdsdNamespace,omitempty" value:"required"`
 	RPNSGPortalSourceAddressPrefixes   []string               `json:"rpNsgPortalSourceAddressPrefixes,omitempty"`
+	RPCanary                           *RPCanaryConfiguration `json:"rpCanary,omitempty"`
 	RPParentDomainName                 *string                `json:"rpParentDomainName,omitempty" value:"required"`
 	RPVMSSCapacity                     *int                   `json:"rpVmssCapacity,omitempty"`
 	SSHPublicKey                       *string                `json:"sshPublicKey,omitempty"`
@@ -96,9 +99,13 @@ type Configuration struct {
 	SubscriptionResourceGroupLocation  *string                `json:"subscriptionResourceGroupLocation,omitempty" value:"required"`
 	VMSize                             *string                `json:"vmSize,omitempty" value:"required"`
 	VMSSCleanupEnabled                 *bool                  `json:"vmssCleanupEnabled,omitempty"`
+	WhatIf                             *WhatIfConfiguration   `json:"whatIf,omitempty"`
+	Zones                              *ZonesConfiguration    `json:"zones,omitempty"`
 	OIDCStorageAccountName             *string                `json:"oidcStorageAccountName,omitempty" value:"required"`
 	MsiRpEndpoint                      *string                `json:"msiRpEndpoint,omitempty" value:"required"`
 
+	HealthGate *HealthGateConfiguration `json:"healthGate,omitempty"`
+
 	// TODO: Replace with Live Service Configuration in KeyVault
 	InstallViaHive           *string `json:"clustersInstallViaHive,omitempty"`
 	DefaultInstallerPullspec *string `json:"clusterDefaultInstallerPullspec,omitempty"`
@@ -112,6 +119,119 @@ type CosmosDBConfiguration struct {
 	GatewayProvisionedThroughput  int `json:"gatewayProvisionedThroughput,omitempty"`
 }
 
+// RPCanaryConfiguration configures deploying a new RP scaleset as a canary
+// alongside the old ones, serving a share of the traffic while its health is
+// watched, before it is promoted or rolled back.
+type RPCanaryConfiguration struct {
+	// TrafficPercentage is the approximate percentage of traffic served by
+	// the canary, between 1 and 99.
+	TrafficPercentage int `json:"trafficPercentage,omitempty"`
+
+	// BakeDuration is how long the canary's health is watched before it is
+	// promoted, such as "30m".
+	BakeDuration string `json:"bakeDuration,omitempty"`
+
+	// UnhealthyThreshold is the number of consecutive failed health checks
+	// of the canary after which it is rolled back.  It defaults to 3.
+	UnhealthyThreshold int `json:"unhealthyThreshold,omitempty"`
+}
+
+func (c *RPCanaryConfiguration) validate() error {
+	if c.TrafficPercentage < 1 || c.TrafficPercentage > 99 {
+		return fmt.Errorf("rpCanary.trafficPercentage %d must be between 1 and 99", c.TrafficPercentage)
+	}
+
+	d, err := time.ParseDuration(c.BakeDuration)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid rpCanary.bakeDuration %q", c.BakeDuration)
+	}
+
+	if c.UnhealthyThreshold < 0 {
+		return fmt.Errorf("rpCanary.unhealthyThreshold %d must not be negative", c.UnhealthyThreshold)
+	}
+
+	return nil
+}
+
+// HealthGateConfiguration configures watching the health of new RP and
+// gateway scalesets before the old ones are removed.
+type HealthGateConfiguration struct {
+	// BakeWindow is how long the health of a new scaleset is watched, such as
+	// "15m".
+	BakeWindow string `json:"bakeWindow,omitempty"`
+
+	// UnhealthyThreshold is the number of consecutive failed health checks
+	// of a new scaleset after which it is rolled back.  It defaults to 3.
+	UnhealthyThreshold int `json:"unhealthyThreshold,omitempty"`
+}
+
+func (c *HealthGateConfiguration) validate() error {
+	d, err := time.ParseDuration(c.BakeWindow)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid healthGate.bakeWindow %q", c.BakeWindow)
+	}
+
+	if c.UnhealthyThreshold < 0 {
+		return fmt.Errorf("healthGate.unhealthyThreshold %d must not be negative", c.UnhealthyThreshold)
+	}
+
+	return nil
+}
+
+// WhatIfConfiguration configures running ARM what-if on the predeploy
+// templates before they are deployed.
+type WhatIfConfiguration struct {
+	// Mode is "warn" to log the destructive changes predicted by what-if, or
+	// "fail" to fail the deployment if there are any.
+	Mode string `json:"mode,omitempty"`
+
+	// ReportPath, if set, is the file to which a JSON report of the predicted
+	// changes is written.
+	ReportPath string `json:"reportPath,omitempty"`
+}
+
+func (c *WhatIfConfiguration) validate() error {
+	switch c.Mode {
+	case whatIfModeWarn, whatIfModeFail:
+		return nil
+	}
+
+	return fmt.Errorf("invalid whatIf.mode %q", c.Mode)
+}
+
+// ZonesConfiguration configures placing the RP and gateway scalesets
+// explicitly across the availability zones of a region.
+type ZonesConfiguration struct {
+	// Zones lists the availability zones to use, such as ["1", "2", "3"].  If
+	// empty, the zones in which the scalesets' VM sizes are available are
+	// detected.
+	Zones []string `json:"zones,omitempty"`
+
+	// RPInstancesPerZone and GatewayInstancesPerZone, if set, are the number
+	// of instances of each scaleset in each zone.  In zonal regions they
+	// replace rpVmssCapacity and gatewayVmssCapacity.
+	RPInstancesPerZone      int `json:"rpInstancesPerZone,omitempty"`
+	GatewayInstancesPerZone int `json:"gatewayInstancesPerZone,omitempty"`
+}
+
+func (c *ZonesConfiguration) validate() error {
+	for _, zone := range c.Zones {
+		if zone == "" {
+			return fmt.Errorf("zones.zones must not contain empty zones")
+		}
+	}
+
+	if c.RPInstancesPerZone < 0 {
+		return fmt.Errorf("zones.rpInstancesPerZone %d must not be negative", c.RPInstancesPerZone)
+	}
+
+	if c.GatewayInstancesPerZone < 0 {
+		return fmt.Errorf("zones.gatewayInstancesPerZone %d must not be negative", c.GatewayInstancesPerZone)
+	}
+
+	return nil
+}
+
 // GetConfig return RP configuration from the file
 func GetConfig(path, location string) (*RPConfig, error) {
 	data, err := os.ReadFile(path)
@@ -175,6 +295,34 @@ func (conf *RPConfig) validate() error {
 		conf.Configuration.SSHPublicKey = to.StringPtr(string(publicKeyBytes))
 	}
 
+	if conf.Configuration.RPCanary != nil {
+		err := conf.Configuration.RPCanary.validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if conf.Configuration.HealthGate != nil {
+		err := conf.Configuration.HealthGate.validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if conf.Configuration.WhatIf != nil {
+		err := conf.Configuration.WhatIf.validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if conf.Configuration.Zones != nil {
+		err := conf.Configuration.Zones.validate()
+		if err != nil {
+			return err
+		}
+	}
+
 	for i := 0; i < v.NumField(); i++ {
 		required := v.Type().Field(i).Tag.Get("value") == "required"
 
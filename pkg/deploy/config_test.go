@@ -44,7 +44,8 @@ func TestConfigurationFieldParity(t *testing.T) {
 		for name := range params.Parameters {
 			switch name {
 			case "deployNSGs", "gatewayResourceGroupName", "gatewayServicePrincipalId",
-				"rpImage", "rpServicePrincipalId", "vmssCleanupEnabled", "vmssName", "ipRules", "globalDevopsServicePrincipalId":
+				"rpImage", "rpServicePrincipalId", "vmssCleanupEnabled", "vmssName", "ipRules", "globalDevopsServicePrincipalId",
+				"rpVmssZones", "gatewayVmssZones":
 			default:
 				if _, found := m[name]; !found {
 					t.Errorf("field %s not found in config.Configuration but exists in templates", name)
@@ -37,6 +37,7 @@ type Deployer interface {
 	UpgradeRP(context.Context) error
 	UpgradeGateway(context.Context) error
 	SaveVersion(context.Context) error
+	RotateCertificates(context.Context, int) error
 }
 
 type deployer struct {
@@ -59,12 +60,14 @@ type deployer struct {
 	vmssvms                      compute.VirtualMachineScaleSetVMsClient
 	zones                        dns.ZonesClient
 	clusterKeyvault              keyvault.Manager
+	gatewayKeyvault              keyvault.Manager
 	portalKeyvault               keyvault.Manager
 	serviceKeyvault              keyvault.Manager
 
-	config      *RPConfig
-	version     string
-	vmssCleaner vmsscleaner.Interface
+	config       *RPConfig
+	version      string
+	vmssCleaner  vmsscleaner.Interface
+	whatIfReport whatIfReport
 }
 
 // KnownDeploymentErrorType represents a type of error we encounter during an
@@ -110,6 +113,7 @@ func New(ctx context.Context, log *logrus.Entry, _env env.Core, config *RPConfig
 		vmssvms:                      compute.NewVirtualMachineScaleSetVMsClient(_env.Environment(), config.SubscriptionID, authorizer),
 		zones:                        dns.NewZonesClient(_env.Environment(), config.SubscriptionID, authorizer),
 		clusterKeyvault:              keyvault.NewManager(kvAuthorizer, "https://"+*config.Configuration.KeyvaultPrefix+env.ClusterKeyvaultSuffix+"."+_env.Environment().KeyVaultDNSSuffix+"/"),
+		gatewayKeyvault:              keyvault.NewManager(kvAuthorizer, "https://"+*config.Configuration.KeyvaultPrefix+env.GatewayKeyvaultSuffix+"."+_env.Environment().KeyVaultDNSSuffix+"/"),
 		portalKeyvault:               keyvault.NewManager(kvAuthorizer, "https://"+*config.Configuration.KeyvaultPrefix+env.PortalKeyvaultSuffix+"."+_env.Environment().KeyVaultDNSSuffix+"/"),
 		serviceKeyvault:              keyvault.NewManager(kvAuthorizer, "https://"+*config.Configuration.KeyvaultPrefix+env.ServiceKeyvaultSuffix+"."+_env.Environment().KeyVaultDNSSuffix+"/"),
 
@@ -58,6 +58,18 @@ func (d *deployer) DeployGateway(ctx context.Context) error {
 	parameters.Parameters["azureCloudName"] = &arm.ParametersParameter{
 		Value: d.env.Environment().ActualCloudName,
 	}
+	if d.config.Configuration.Zones != nil {
+		// the template defaults gatewayVmSize to Standard_D4s_v3
+		vmSize := "Standard_D4s_v3"
+		if d.config.Configuration.GatewayVMSize != nil {
+			vmSize = *d.config.Configuration.GatewayVMSize
+		}
+
+		err = d.setZoneParameters(ctx, parameters, vmSize, "gatewayVmssZones", "gatewayVmssCapacity", d.config.Configuration.Zones.GatewayInstancesPerZone)
+		if err != nil {
+			return err
+		}
+	}
 
 	return d.deploy(ctx, d.config.GatewayResourceGroupName, deploymentName, gatewayVMSSPrefix+d.version,
 		mgmtfeatures.Deployment{
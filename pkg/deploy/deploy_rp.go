@@ -85,6 +85,21 @@ func (d *deployer) DeployRP(ctx context.Context) error {
 	parameters.Parameters["globalDevopsServicePrincipalId"] = &arm.ParametersParameter{
 		Value: globalDevopsMSI.PrincipalID.String(),
 	}
+	if d.config.Configuration.Zones != nil {
+		err = d.setZoneParameters(ctx, parameters, *d.config.Configuration.VMSize, "rpVmssZones", "rpVmssCapacity", d.config.Configuration.Zones.RPInstancesPerZone)
+		if err != nil {
+			return err
+		}
+	}
+	capacity, err := d.rpDeployCapacity(ctx)
+	if err != nil {
+		return err
+	}
+	if capacity > 0 {
+		parameters.Parameters["rpVmssCapacity"] = &arm.ParametersParameter{
+			Value: capacity,
+		}
+	}
 	if d.config.Configuration.CosmosDB != nil {
 		parameters.Parameters["cosmosDB"] = &arm.ParametersParameter{
 			Value: map[string]int{
@@ -175,7 +175,8 @@ func DevConfig(_env env.Core) (*Config, error) {
 			PortalAccessGroupIDs: []string{
 				os.Getenv("AZURE_PORTAL_ACCESS_GROUP_IDS"),
 			},
-			PortalClientID: ptr.To(os.Getenv("AZURE_PORTAL_CLIENT_ID")),
+			PortalClientID:      ptr.To(os.Getenv("AZURE_PORTAL_CLIENT_ID")),
+			PortalDebugPodImage: ptr.To(os.Getenv("PORTAL_DEBUG_POD_IMAGE")),
 			PortalElevatedGroupIDs: []string{
 				os.Getenv("AZURE_PORTAL_ELEVATED_GROUP_IDS"),
 			},
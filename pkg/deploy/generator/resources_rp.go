@@ -22,6 +22,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/util/arm"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/networkrules"
 	"github.com/Azure/ARO-RP/pkg/util/rbac"
 	"github.com/Azure/ARO-RP/pkg/util/version"
 )
@@ -38,81 +39,26 @@ func (g *generator) rpManagedIdentity() *arm.Resource {
 }
 
 func (g *generator) rpSecurityGroupForPortalSourceAddressPrefixes() *arm.Resource {
-	return g.securityRules("rp-nsg/portal_in", &mgmtnetwork.SecurityRulePropertiesFormat{
-		Protocol:                 mgmtnetwork.SecurityRuleProtocolTCP,
-		SourcePortRange:          to.StringPtr("*"),
-		DestinationPortRange:     to.StringPtr("444"),
-		SourceAddressPrefixes:    &[]string{},
-		DestinationAddressPrefix: to.StringPtr("*"),
-		Access:                   mgmtnetwork.SecurityRuleAccessAllow,
-		Priority:                 to.Int32Ptr(142),
-		Direction:                mgmtnetwork.SecurityRuleDirectionInbound,
-	}, "[not(empty(parameters('rpNsgPortalSourceAddressPrefixes')))]")
+	properties := networkrules.RPInboundPortal.SecurityRulePropertiesFormat()
+	properties.SourceAddressPrefix = nil
+	properties.SourceAddressPrefixes = &[]string{}
+
+	return g.securityRules("rp-nsg/"+networkrules.RPInboundPortal.Name, properties, "[not(empty(parameters('rpNsgPortalSourceAddressPrefixes')))]")
 }
 
 func (g *generator) rpSecurityGroup() *arm.Resource {
 	rules := []mgmtnetwork.SecurityRule{
-		{
-			SecurityRulePropertiesFormat: &mgmtnetwork.SecurityRulePropertiesFormat{
-				Protocol:                 mgmtnetwork.SecurityRuleProtocolTCP,
-				SourcePortRange:          to.StringPtr("*"),
-				DestinationPortRange:     to.StringPtr("443"),
-				SourceAddressPrefix:      to.StringPtr("AzureResourceManager"),
-				DestinationAddressPrefix: to.StringPtr("*"),
-				Access:                   mgmtnetwork.SecurityRuleAccessAllow,
-				Priority:                 to.Int32Ptr(120),
-				Direction:                mgmtnetwork.SecurityRuleDirectionInbound,
-			},
-			Name: to.StringPtr("rp_in_arm"),
-		},
-		{
-			SecurityRulePropertiesFormat: &mgmtnetwork.SecurityRulePropertiesFormat{
-				Protocol:                 mgmtnetwork.SecurityRuleProtocolTCP,
-				SourcePortRange:          to.StringPtr("*"),
-				DestinationPortRange:     to.StringPtr("443"),
-				SourceAddressPrefix:      to.StringPtr("GenevaActions"),
-				DestinationAddressPrefix: to.StringPtr("*"),
-				Access:                   mgmtnetwork.SecurityRuleAccessAllow,
-				Priority:                 to.Int32Ptr(130),
-				Direction:                mgmtnetwork.SecurityRuleDirectionInbound,
-			},
-			Name: to.StringPtr("rp_in_geneva"),
-		},
+		networkrules.RPInboundARM.SecurityRule(),
+		networkrules.RPInboundGeneva.SecurityRule(),
 	}
 
 	if !g.production {
 		// override production ARM flag for more open configuration in development
 		rules[0].SecurityRulePropertiesFormat.SourceAddressPrefix = to.StringPtr("*")
 
-		rules = append(rules, mgmtnetwork.SecurityRule{
-			SecurityRulePropertiesFormat: &mgmtnetwork.SecurityRulePropertiesFormat{
-				Protocol:                 mgmtnetwork.SecurityRuleProtocolTCP,
-				SourcePortRange:          to.StringPtr("*"),
-				DestinationPortRange:     to.StringPtr("22"),
-				SourceAddressPrefix:      to.StringPtr("*"),
-				DestinationAddressPrefix: to.StringPtr("*"),
-				Access:                   mgmtnetwork.SecurityRuleAccessAllow,
-				Priority:                 to.Int32Ptr(125),
-				Direction:                mgmtnetwork.SecurityRuleDirectionInbound,
-			},
-			Name: to.StringPtr("ssh_in"),
-		})
+		rules = append(rules, networkrules.RPInboundSSH.SecurityRule())
 	} else {
-		rules = append(rules,
-			mgmtnetwork.SecurityRule{
-				SecurityRulePropertiesFormat: &mgmtnetwork.SecurityRulePropertiesFormat{
-					Protocol:                 mgmtnetwork.SecurityRuleProtocolTCP,
-					SourcePortRange:          to.StringPtr("*"),
-					DestinationPortRange:     to.StringPtr("*"),
-					SourceAddressPrefix:      to.StringPtr("10.0.8.0/24"),
-					DestinationAddressPrefix: to.StringPtr("*"),
-					Access:                   mgmtnetwork.SecurityRuleAccessDeny,
-					Priority:                 to.Int32Ptr(145),
-					Direction:                mgmtnetwork.SecurityRuleDirectionInbound,
-				},
-				Name: to.StringPtr("deny_in_gateway"),
-			},
-		)
+		rules = append(rules, networkrules.RPDenyInboundGateway.SecurityRule())
 	}
 
 	return g.securityGroup("rp-nsg", &rules, g.conditionStanza("deployNSGs"))
@@ -387,6 +333,7 @@ func (g *generator) rpVMSS() *arm.Resource {
 		"msiRpEndpoint",
 		"portalAccessGroupIds",
 		"portalClientId",
+		"portalDebugPodImage",
 		"portalElevatedGroupIds",
 		"rpFeatures",
 		"rpImage",
@@ -461,7 +408,8 @@ func (g *generator) rpVMSS() *arm.Resource {
 				Tier:     to.StringPtr("Standard"),
 				Capacity: to.Int64Ptr(1338),
 			},
-			Tags: map[string]*string{},
+			Zones: &[]string{"rpVmssZones"},
+			Tags:  map[string]*string{},
 			VirtualMachineScaleSetProperties: &mgmtcompute.VirtualMachineScaleSetProperties{
 				// Reference: https://learn.microsoft.com/en-us/azure/virtual-machine-scale-sets/virtual-machine-scale-sets-automatic-upgrade#arm-templates
 				UpgradePolicy: &mgmtcompute.UpgradePolicy{
@@ -1142,6 +1090,31 @@ func (g *generator) database(databaseName string, addDependsOn bool) []*arm.Reso
 			},
 			Type: "Microsoft.DocumentDB/databaseAccounts/sqlDatabases",
 		},
+		{
+			Resource: &sdkcosmos.SQLContainerCreateUpdateParameters{
+				Properties: &sdkcosmos.SQLContainerCreateUpdateProperties{
+					Resource: &sdkcosmos.SQLContainerResource{
+						ID: to.StringPtr("SupportedVMSizes"),
+						PartitionKey: &sdkcosmos.ContainerPartitionKey{
+							Paths: []*string{
+								to.StringPtr("/id"),
+							},
+							Kind: &hashPartitionKey,
+						},
+						DefaultTTL: to.Int32Ptr(-1),
+					},
+					Options: &sdkcosmos.CreateUpdateOptions{},
+				},
+				Name:     to.StringPtr("[concat(parameters('databaseAccountName'), '/', " + databaseName + ", '/SupportedVMSizes')]"),
+				Type:     to.StringPtr("Microsoft.DocumentDB/databaseAccounts/sqlDatabases/containers"),
+				Location: to.StringPtr("[resourceGroup().location]"),
+			},
+			APIVersion: azureclient.APIVersion("Microsoft.DocumentDB"),
+			DependsOn: []string{
+				"[resourceId('Microsoft.DocumentDB/databaseAccounts/sqlDatabases', parameters('databaseAccountName'), " + databaseName + ")]",
+			},
+			Type: "Microsoft.DocumentDB/databaseAccounts/sqlDatabases",
+		},
 		{
 			Resource: &sdkcosmos.SQLContainerCreateUpdateParameters{
 				Properties: &sdkcosmos.SQLContainerCreateUpdateProperties{
@@ -1190,6 +1163,30 @@ func (g *generator) database(databaseName string, addDependsOn bool) []*arm.Reso
 			},
 			Type: "Microsoft.DocumentDB/databaseAccounts/sqlDatabases",
 		},
+		{
+			Resource: &sdkcosmos.SQLContainerCreateUpdateParameters{
+				Properties: &sdkcosmos.SQLContainerCreateUpdateProperties{
+					Resource: &sdkcosmos.SQLContainerResource{
+						ID: to.StringPtr("ClusterLookups"),
+						PartitionKey: &sdkcosmos.ContainerPartitionKey{
+							Paths: []*string{
+								to.StringPtr("/id"),
+							},
+							Kind: &hashPartitionKey,
+						},
+					},
+					Options: &sdkcosmos.CreateUpdateOptions{},
+				},
+				Name:     to.StringPtr("[concat(parameters('databaseAccountName'), '/', " + databaseName + ", '/ClusterLookups')]"),
+				Type:     to.StringPtr("Microsoft.DocumentDB/databaseAccounts/sqlDatabases/containers"),
+				Location: to.StringPtr("[resourceGroup().location]"),
+			},
+			APIVersion: azureclient.APIVersion("Microsoft.DocumentDB"),
+			DependsOn: []string{
+				"[resourceId('Microsoft.DocumentDB/databaseAccounts/sqlDatabases', parameters('databaseAccountName'), " + databaseName + ")]",
+			},
+			Type: "Microsoft.DocumentDB/databaseAccounts/sqlDatabases",
+		},
 		gateway,
 		{
 			Resource: &sdkcosmos.SQLContainerCreateUpdateParameters{
@@ -1259,6 +1256,31 @@ func (g *generator) database(databaseName string, addDependsOn bool) []*arm.Reso
 			},
 			Type: "Microsoft.DocumentDB/databaseAccounts/sqlDatabases",
 		},
+		{
+			Resource: &sdkcosmos.SQLContainerCreateUpdateParameters{
+				Properties: &sdkcosmos.SQLContainerCreateUpdateProperties{
+					Resource: &sdkcosmos.SQLContainerResource{
+						ID: to.StringPtr("OpenShiftClusterTombstones"),
+						PartitionKey: &sdkcosmos.ContainerPartitionKey{
+							Paths: []*string{
+								to.StringPtr("/partitionKey"),
+							},
+							Kind: &hashPartitionKey,
+						},
+						DefaultTTL: to.Int32Ptr(-1),
+					},
+					Options: &sdkcosmos.CreateUpdateOptions{},
+				},
+				Name:     to.StringPtr("[concat(parameters('databaseAccountName'), '/', " + databaseName + ", '/OpenShiftClusterTombstones')]"),
+				Type:     to.StringPtr("Microsoft.DocumentDB/databaseAccounts/sqlDatabases/containers"),
+				Location: to.StringPtr("[resourceGroup().location]"),
+			},
+			APIVersion: azureclient.APIVersion("Microsoft.DocumentDB"),
+			DependsOn: []string{
+				"[resourceId('Microsoft.DocumentDB/databaseAccounts/sqlDatabases', parameters('databaseAccountName'), " + databaseName + ")]",
+			},
+			Type: "Microsoft.DocumentDB/databaseAccounts/sqlDatabases",
+		},
 		portal,
 		{
 			Resource: &sdkcosmos.SQLContainerCreateUpdateParameters{
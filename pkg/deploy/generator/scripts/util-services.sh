@@ -247,6 +247,7 @@ DATABASE_ACCOUNT_NAME='$DATABASEACCOUNTNAME'
 KEYVAULT_PREFIX='$KEYVAULTPREFIX'
 MDM_ACCOUNT='$RPMDMACCOUNT'
 MDM_NAMESPACE=Portal
+PORTAL_DEBUG_POD_IMAGE='$PORTALDEBUGPODIMAGE'
 PORTAL_HOSTNAME='$LOCATION.admin.$RPPARENTDOMAINNAME'
 RPIMAGE='$image'"
 
@@ -275,6 +276,7 @@ ExecStart=/usr/bin/podman run \
   -e KEYVAULT_PREFIX \
   -e MDM_ACCOUNT \
   -e MDM_NAMESPACE \
+  -e PORTAL_DEBUG_POD_IMAGE \
   -e PORTAL_HOSTNAME \
   -m 2g \
   -p 444:8444 \
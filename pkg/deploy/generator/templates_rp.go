@@ -63,6 +63,7 @@ func (g *generator) rpTemplate() *arm.Template {
 			"nonZonalRegions",
 			"portalAccessGroupIds",
 			"portalClientId",
+			"portalDebugPodImage",
 			"portalElevatedGroupIds",
 			"rpFeatures",
 			"rpImage",
@@ -72,6 +73,7 @@ func (g *generator) rpTemplate() *arm.Template {
 			"rpMdsdNamespace",
 			"rpParentDomainName",
 			"rpVmssCapacity",
+			"rpVmssZones",
 			"sshPublicKey",
 			"subscriptionResourceGroupName",
 			"vmSize",
@@ -116,6 +118,9 @@ func (g *generator) rpTemplate() *arm.Template {
 		case "rpVmssCapacity":
 			p.Type = "int"
 			p.DefaultValue = 3
+		case "rpVmssZones":
+			p.Type = "array"
+			p.DefaultValue = []string{}
 		case "nonZonalRegions":
 			p.Type = "array"
 			p.DefaultValue = []string{
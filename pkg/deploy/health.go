@@ -0,0 +1,80 @@
+package deploy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const defaultUnhealthyThreshold = 3
+
+var healthCheckInterval = 30 * time.Second
+
+// watchHealth checks the health of a scaleset's instances until duration has
+// passed, and fails if they are unhealthy on threshold consecutive checks.  An
+// instance is healthy if the load balancer's probe of its /healthz/ready
+// endpoint succeeds.  A threshold of 0 means the default.
+func (d *deployer) watchHealth(ctx context.Context, resourceGroupName, vmssName string, duration time.Duration, threshold int) error {
+	if threshold == 0 {
+		threshold = defaultUnhealthyThreshold
+	}
+
+	d.log.Printf("watching the health of scaleset %s for %s", vmssName, duration)
+
+	watchCtx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	var unhealthy int
+	err := wait.PollImmediateUntil(healthCheckInterval, func() (bool, error) {
+		if d.scalesetHealthy(ctx, resourceGroupName, vmssName) {
+			unhealthy = 0
+			return false, nil
+		}
+
+		unhealthy++
+		if unhealthy >= threshold {
+			return false, fmt.Errorf("scaleset %s was unhealthy on %d consecutive checks", vmssName, unhealthy)
+		}
+
+		return false, nil
+	}, watchCtx.Done())
+
+	// the duration passing without the scaleset failing is success
+	if errors.Is(err, wait.ErrWaitTimeout) && ctx.Err() == nil {
+		return nil
+	}
+
+	return err
+}
+
+// scalesetHealthy returns true if all the instances of a scaleset are healthy.
+func (d *deployer) scalesetHealthy(ctx context.Context, resourceGroupName, vmssName string) bool {
+	scalesetVMs, err := d.vmssvms.List(ctx, resourceGroupName, vmssName, "", "", "")
+	if err != nil {
+		d.log.Print(err)
+		return false
+	}
+
+	healthy := true
+	for _, vm := range scalesetVMs {
+		if !d.isVMInstanceHealthy(ctx, resourceGroupName, vmssName, *vm.InstanceID) {
+			healthy = false
+		}
+	}
+
+	return healthy
+}
+
+// rollback removes a new scaleset, leaving the old scalesets serving all
+// traffic, and returns the reason for the rollback.
+func (d *deployer) rollback(ctx context.Context, vmssName string, reason error, remove func(context.Context, string) error) error {
+	d.log.Printf("rolling back scaleset %s: %s", vmssName, reason)
+
+	return errors.Join(fmt.Errorf("rolled back scaleset %s: %w", vmssName, reason), remove(ctx, vmssName))
+}
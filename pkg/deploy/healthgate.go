@@ -0,0 +1,31 @@
+package deploy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"time"
+)
+
+// healthGate watches the health of a new scaleset for the configured bake
+// window before the old scalesets are removed.  If the health gate fails, the
+// new scaleset is removed with remove, leaving the old scalesets in service.
+func (d *deployer) healthGate(ctx context.Context, resourceGroupName, vmssName string, remove func(context.Context, string) error) error {
+	gate := d.config.Configuration.HealthGate
+	if gate == nil {
+		return nil
+	}
+
+	bakeWindow, err := time.ParseDuration(gate.BakeWindow)
+	if err != nil {
+		return err
+	}
+
+	err = d.watchHealth(ctx, resourceGroupName, vmssName, bakeWindow, gate.UnhealthyThreshold)
+	if err != nil {
+		return d.rollback(ctx, vmssName, err, remove)
+	}
+
+	return nil
+}
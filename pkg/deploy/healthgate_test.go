@@ -0,0 +1,132 @@
+package deploy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+
+	mock_compute "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/compute"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestHealthGate(t *testing.T) {
+	ctx := context.Background()
+
+	defer func(interval time.Duration) {
+		healthCheckInterval = interval
+	}(healthCheckInterval)
+	healthCheckInterval = time.Millisecond
+
+	for _, tt := range []struct {
+		name        string
+		healthGate  *HealthGateConfiguration
+		instances   []mgmtcompute.VirtualMachineScaleSetVMInstanceView
+		removeErr   error
+		wantRemoved bool
+		wantErr     string
+	}{
+		{
+			name: "skipped if not configured",
+		},
+		{
+			name:       "passes if the scaleset stays healthy",
+			healthGate: &HealthGateConfiguration{BakeWindow: "20ms"},
+			instances:  []mgmtcompute.VirtualMachineScaleSetVMInstanceView{healthyVMSS},
+		},
+		{
+			name:       "passes if the scaleset recovers before the threshold",
+			healthGate: &HealthGateConfiguration{BakeWindow: "20ms", UnhealthyThreshold: 3},
+			instances:  []mgmtcompute.VirtualMachineScaleSetVMInstanceView{unhealthyVMSS, unhealthyVMSS, healthyVMSS},
+		},
+		{
+			name:        "rolls back if the scaleset becomes unhealthy",
+			healthGate:  &HealthGateConfiguration{BakeWindow: "1m", UnhealthyThreshold: 2},
+			instances:   []mgmtcompute.VirtualMachineScaleSetVMInstanceView{healthyVMSS, unhealthyVMSS, unhealthyVMSS},
+			wantRemoved: true,
+			wantErr:     "rolled back scaleset " + vmssName + ": scaleset " + vmssName + " was unhealthy on 2 consecutive checks",
+		},
+		{
+			name:        "reports failure to roll back",
+			healthGate:  &HealthGateConfiguration{BakeWindow: "1m", UnhealthyThreshold: 1},
+			instances:   []mgmtcompute.VirtualMachineScaleSetVMInstanceView{unhealthyVMSS},
+			removeErr:   errGeneric,
+			wantRemoved: true,
+			wantErr:     "rolled back scaleset " + vmssName + ": scaleset " + vmssName + " was unhealthy on 1 consecutive checks\ngeneric error",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockVMSSVMs := mock_compute.NewMockVirtualMachineScaleSetVMsClient(controller)
+			mockVMSSVMs.EXPECT().List(gomock.Any(), rgName, vmssName, "", "", "").Return(vms, nil).AnyTimes()
+
+			// the last instance view is returned once the others have been
+			var i int
+			mockVMSSVMs.EXPECT().GetInstanceView(gomock.Any(), rgName, vmssName, instanceID).DoAndReturn(
+				func(ctx context.Context, resourceGroupName, vmScaleSetName, instanceID string) (mgmtcompute.VirtualMachineScaleSetVMInstanceView, error) {
+					instance := tt.instances[i]
+					if i < len(tt.instances)-1 {
+						i++
+					}
+					return instance, nil
+				}).AnyTimes()
+
+			d := deployer{
+				log:     logrus.NewEntry(logrus.StandardLogger()),
+				vmssvms: mockVMSSVMs,
+				config: &RPConfig{
+					Configuration: &Configuration{
+						HealthGate: tt.healthGate,
+					},
+				},
+			}
+
+			var removed bool
+			err := d.healthGate(ctx, rgName, vmssName, func(ctx context.Context, name string) error {
+				removed = name == vmssName
+				return tt.removeErr
+			})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if removed != tt.wantRemoved {
+				t.Error(removed)
+			}
+		})
+	}
+}
+
+func TestHealthGateConfigurationValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		healthGate HealthGateConfiguration
+		wantErr    string
+	}{
+		{
+			name:       "valid",
+			healthGate: HealthGateConfiguration{BakeWindow: "15m"},
+		},
+		{
+			name:       "invalid bake window",
+			healthGate: HealthGateConfiguration{BakeWindow: "15"},
+			wantErr:    `invalid healthGate.bakeWindow "15"`,
+		},
+		{
+			name:       "invalid unhealthy threshold",
+			healthGate: HealthGateConfiguration{BakeWindow: "15m", UnhealthyThreshold: -1},
+			wantErr:    "healthGate.unhealthyThreshold -1 must not be negative",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.healthGate.validate()
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
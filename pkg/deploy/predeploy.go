@@ -349,13 +349,20 @@ func (d *deployer) deployPreDeploy(ctx context.Context, resourceGroupName, deplo
 		Value: spID,
 	}
 
+	properties := &mgmtfeatures.DeploymentProperties{
+		Template:   template,
+		Mode:       mgmtfeatures.Incremental,
+		Parameters: parameters.Parameters,
+	}
+
+	err = d.validateWhatIf(ctx, resourceGroupName, deploymentName, properties, isCreate)
+	if err != nil {
+		return err
+	}
+
 	d.log.Infof("deploying %s", deploymentName)
 	return d.deployments.CreateOrUpdateAndWait(ctx, resourceGroupName, deploymentName, mgmtfeatures.Deployment{
-		Properties: &mgmtfeatures.DeploymentProperties{
-			Template:   template,
-			Mode:       mgmtfeatures.Incremental,
-			Parameters: parameters.Parameters,
-		},
+		Properties: properties,
 	})
 }
 
@@ -514,16 +521,22 @@ func (d *deployer) restartOldScaleset(ctx context.Context, vmssName string, lbHe
 		}
 	}
 
-	scalesetVMs, err := d.vmssvms.List(ctx, d.config.RPResourceGroupName, vmssName, "", "", "")
+	return d.rollScaleset(ctx, d.config.RPResourceGroupName, vmssName, rpRestartScript, lbHealthcheckWaitTimeSec)
+}
+
+// rollScaleset runs script on each instance of a scaleset in turn, waiting for
+// each instance to become healthy again before moving on to the next one.
+func (d *deployer) rollScaleset(ctx context.Context, resourceGroupName, vmssName, script string, lbHealthcheckWaitTimeSec int) error {
+	scalesetVMs, err := d.vmssvms.List(ctx, resourceGroupName, vmssName, "", "", "")
 	if err != nil {
 		return err
 	}
 
 	for _, vm := range scalesetVMs {
-		d.log.Printf("waiting for restart script to complete on older rp vmss %s, instance %s", vmssName, *vm.InstanceID)
-		err = d.vmssvms.RunCommandAndWait(ctx, d.config.RPResourceGroupName, vmssName, *vm.InstanceID, mgmtcompute.RunCommandInput{
+		d.log.Printf("waiting for script to complete on vmss %s, instance %s", vmssName, *vm.InstanceID)
+		err = d.vmssvms.RunCommandAndWait(ctx, resourceGroupName, vmssName, *vm.InstanceID, mgmtcompute.RunCommandInput{
 			CommandID: to.StringPtr("RunShellScript"),
-			Script:    &[]string{rpRestartScript},
+			Script:    &[]string{script},
 		})
 
 		if err != nil {
@@ -534,7 +547,7 @@ func (d *deployer) restartOldScaleset(ctx context.Context, vmssName string, lbHe
 		time.Sleep(time.Duration(lbHealthcheckWaitTimeSec) * time.Second)
 		timeoutCtx, cancel := context.WithTimeout(ctx, time.Hour)
 		defer cancel()
-		err = d.waitForReadiness(timeoutCtx, vmssName, *vm.InstanceID)
+		err = d.waitForReadiness(timeoutCtx, resourceGroupName, vmssName, *vm.InstanceID)
 		if err != nil {
 			return err
 		}
@@ -543,9 +556,9 @@ func (d *deployer) restartOldScaleset(ctx context.Context, vmssName string, lbHe
 	return nil
 }
 
-func (d *deployer) waitForReadiness(ctx context.Context, vmssName string, vmInstanceID string) error {
+func (d *deployer) waitForReadiness(ctx context.Context, resourceGroupName, vmssName string, vmInstanceID string) error {
 	return wait.PollImmediateUntil(10*time.Second, func() (bool, error) {
-		return d.isVMInstanceHealthy(ctx, d.config.RPResourceGroupName, vmssName, vmInstanceID), nil
+		return d.isVMInstanceHealthy(ctx, resourceGroupName, vmssName, vmInstanceID), nil
 	}, ctx.Done())
 }
 
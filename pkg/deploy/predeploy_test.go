@@ -1637,7 +1637,7 @@ func TestWaitForReadiness(t *testing.T) {
 			}
 
 			defer tt.testParams.cancel()
-			err := d.waitForReadiness(tt.testParams.ctx, tt.testParams.vmssName, tt.testParams.vmInstanceID)
+			err := d.waitForReadiness(tt.testParams.ctx, tt.testParams.resourceGroup, tt.testParams.vmssName, tt.testParams.vmInstanceID)
 			utilerror.AssertErrorMessage(t, err, tt.wantErr)
 		})
 	}
@@ -0,0 +1,154 @@
+package deploy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/keyvault"
+)
+
+const (
+	rpMDMCertificateName       = "rp-mdm"
+	rpMDSDCertificateName      = "rp-mdsd"
+	gatewayMDMCertificateName  = "gwy-mdm"
+	gatewayMDSDCertificateName = "gwy-mdsd"
+
+	// the download-*-credentials units write the Geneva certificates to disk,
+	// where MDM and MDSD pick them up.  The RP, monitor and portal read their
+	// certificates from key vault at startup.
+	refreshCredentialsScript = "systemctl start download-mdsd-credentials.service download-mdm-credentials.service"
+	rpRotateScript           = refreshCredentialsScript + "; " + rpRestartScript
+
+	// the load balancer spreads connections across the instances, so a
+	// number of connections are checked when verifying a served certificate
+	servedCertificateChecks = 10
+)
+
+type rotatedCertificate struct {
+	kv   keyvault.Manager
+	name string
+}
+
+// RotateCertificates renews the RP, portal and gateway TLS and Geneva
+// certificates in the service key vaults, rolls them out to the RP and gateway
+// scalesets one instance at a time, and then verifies that the RP and portal
+// are serving the new certificates.
+func (d *deployer) RotateCertificates(ctx context.Context, lbHealthcheckWaitTimeSec int) error {
+	for _, c := range []rotatedCertificate{
+		{d.serviceKeyvault, env.RPServerSecretName},
+		{d.serviceKeyvault, rpMDMCertificateName},
+		{d.serviceKeyvault, rpMDSDCertificateName},
+		{d.portalKeyvault, env.PortalServerSecretName},
+		{d.gatewayKeyvault, gatewayMDMCertificateName},
+		{d.gatewayKeyvault, gatewayMDSDCertificateName},
+	} {
+		d.log.Printf("renewing certificate %s", c.name)
+		err := c.kv.RenewCertificate(ctx, c.name)
+		if err != nil {
+			return err
+		}
+
+		err = c.kv.WaitForCertificateOperation(ctx, c.name)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, s := range []struct {
+		resourceGroupName string
+		vmssPrefix        string
+		script            string
+	}{
+		{d.config.RPResourceGroupName, rpVMSSPrefix, rpRotateScript},
+		{d.config.GatewayResourceGroupName, gatewayVMSSPrefix, refreshCredentialsScript},
+	} {
+		scalesets, err := d.vmss.List(ctx, s.resourceGroupName)
+		if err != nil {
+			return err
+		}
+
+		for _, vmss := range scalesets {
+			if !strings.HasPrefix(*vmss.Name, s.vmssPrefix) {
+				continue
+			}
+
+			err = d.rollScaleset(ctx, s.resourceGroupName, *vmss.Name, s.script, lbHealthcheckWaitTimeSec)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	err := d.verifyServedCertificate(timeoutCtx, d.serviceKeyvault, env.RPServerSecretName, "rp."+d.config.Location+"."+*d.config.Configuration.RPParentDomainName+":443")
+	if err != nil {
+		return err
+	}
+
+	return d.verifyServedCertificate(timeoutCtx, d.portalKeyvault, env.PortalServerSecretName, d.config.Location+".admin."+*d.config.Configuration.RPParentDomainName+":443")
+}
+
+// verifyServedCertificate waits until address consistently serves the current
+// version of a key vault certificate.
+func (d *deployer) verifyServedCertificate(ctx context.Context, kv keyvault.Manager, certificateName, address string) error {
+	bundle, err := kv.GetCertificate(ctx, certificateName)
+	if err != nil {
+		return err
+	}
+
+	if bundle.Cer == nil {
+		return errors.New("certificate " + certificateName + " has no content")
+	}
+
+	d.log.Printf("waiting for %s to serve the new %s certificate", address, certificateName)
+	return wait.PollImmediateUntil(10*time.Second, func() (bool, error) {
+		for i := 0; i < servedCertificateChecks; i++ {
+			cert, err := servedCertificate(ctx, address)
+			if err != nil {
+				d.log.Info(err)
+				return false, nil
+			}
+
+			if !bytes.Equal(cert.Raw, *bundle.Cer) {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	}, ctx.Done())
+}
+
+func servedCertificate(ctx context.Context, address string) (*x509.Certificate, error) {
+	dialer := &tls.Dialer{
+		Config: &tls.Config{
+			// the served certificate is compared with the one in key vault
+			InsecureSkipVerify: true, // #nosec G402
+		},
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, errors.New(address + " served no certificate")
+	}
+
+	return certs[0], nil
+}
@@ -0,0 +1,178 @@
+package deploy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	azkeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/env"
+	mock_compute "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/compute"
+	mock_keyvault "github.com/Azure/ARO-RP/pkg/util/mocks/keyvault"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestRotateCertificates(t *testing.T) {
+	ctx := context.Background()
+	gatewayRGName := "testGatewayRG"
+	gatewayVMSSName := gatewayVMSSPrefix + "test"
+
+	renewMock := func(kv *mock_keyvault.MockManager, name string) {
+		kv.EXPECT().RenewCertificate(ctx, name).Return(nil)
+		kv.EXPECT().WaitForCertificateOperation(ctx, name).Return(nil)
+	}
+	renewAllMock := func(service, portal, gateway *mock_keyvault.MockManager) {
+		renewMock(service, env.RPServerSecretName)
+		renewMock(service, rpMDMCertificateName)
+		renewMock(service, rpMDSDCertificateName)
+		renewMock(portal, env.PortalServerSecretName)
+		renewMock(gateway, gatewayMDMCertificateName)
+		renewMock(gateway, gatewayMDSDCertificateName)
+	}
+	rollMock := func(vmssvms *mock_compute.MockVirtualMachineScaleSetVMsClient, resourceGroupName, vmssName, script string) {
+		vmssvms.EXPECT().List(ctx, resourceGroupName, vmssName, "", "", "").Return(vms, nil)
+		vmssvms.EXPECT().RunCommandAndWait(ctx, resourceGroupName, vmssName, instanceID, mgmtcompute.RunCommandInput{
+			CommandID: to.StringPtr("RunShellScript"),
+			Script:    &[]string{script},
+		}).Return(nil)
+		vmssvms.EXPECT().GetInstanceView(gomock.Any(), resourceGroupName, vmssName, instanceID).Return(healthyVMSS, nil)
+	}
+
+	for _, tt := range []struct {
+		name    string
+		mocks   func(service, portal, gateway *mock_keyvault.MockManager, vmss *mock_compute.MockVirtualMachineScaleSetsClient, vmssvms *mock_compute.MockVirtualMachineScaleSetVMsClient)
+		wantErr string
+	}{
+		{
+			name: "Don't continue if renewing a certificate fails",
+			mocks: func(service, portal, gateway *mock_keyvault.MockManager, vmss *mock_compute.MockVirtualMachineScaleSetsClient, vmssvms *mock_compute.MockVirtualMachineScaleSetVMsClient) {
+				service.EXPECT().RenewCertificate(ctx, env.RPServerSecretName).Return(errGeneric)
+			},
+			wantErr: "generic error",
+		},
+		{
+			name: "Don't continue if the certificate operation fails",
+			mocks: func(service, portal, gateway *mock_keyvault.MockManager, vmss *mock_compute.MockVirtualMachineScaleSetsClient, vmssvms *mock_compute.MockVirtualMachineScaleSetVMsClient) {
+				service.EXPECT().RenewCertificate(ctx, env.RPServerSecretName).Return(nil)
+				service.EXPECT().WaitForCertificateOperation(ctx, env.RPServerSecretName).Return(errGeneric)
+			},
+			wantErr: "generic error",
+		},
+		{
+			name: "Don't continue if vmss list fails",
+			mocks: func(service, portal, gateway *mock_keyvault.MockManager, vmss *mock_compute.MockVirtualMachineScaleSetsClient, vmssvms *mock_compute.MockVirtualMachineScaleSetVMsClient) {
+				renewAllMock(service, portal, gateway)
+				vmss.EXPECT().List(ctx, rgName).Return(nil, errGeneric)
+			},
+			wantErr: "generic error",
+		},
+		{
+			name: "Roll RP and gateway scalesets, then verify the served certificate",
+			mocks: func(service, portal, gateway *mock_keyvault.MockManager, vmss *mock_compute.MockVirtualMachineScaleSetsClient, vmssvms *mock_compute.MockVirtualMachineScaleSetVMsClient) {
+				renewAllMock(service, portal, gateway)
+				vmss.EXPECT().List(ctx, rgName).Return(append(vmsss, invalidVMSSs...), nil)
+				rollMock(vmssvms, rgName, vmssName, rpRotateScript)
+				vmss.EXPECT().List(ctx, gatewayRGName).Return([]mgmtcompute.VirtualMachineScaleSet{{Name: &gatewayVMSSName}}, nil)
+				rollMock(vmssvms, gatewayRGName, gatewayVMSSName, refreshCredentialsScript)
+				service.EXPECT().GetCertificate(gomock.Any(), env.RPServerSecretName).Return(azkeyvault.CertificateBundle{}, errGeneric)
+			},
+			wantErr: "generic error",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockServiceKV := mock_keyvault.NewMockManager(controller)
+			mockPortalKV := mock_keyvault.NewMockManager(controller)
+			mockGatewayKV := mock_keyvault.NewMockManager(controller)
+			mockVMSS := mock_compute.NewMockVirtualMachineScaleSetsClient(controller)
+			mockVMSSVM := mock_compute.NewMockVirtualMachineScaleSetVMsClient(controller)
+
+			tt.mocks(mockServiceKV, mockPortalKV, mockGatewayKV, mockVMSS, mockVMSSVM)
+
+			d := deployer{
+				log:             logrus.NewEntry(logrus.StandardLogger()),
+				vmss:            mockVMSS,
+				vmssvms:         mockVMSSVM,
+				serviceKeyvault: mockServiceKV,
+				portalKeyvault:  mockPortalKV,
+				gatewayKeyvault: mockGatewayKV,
+				config: &RPConfig{
+					Location:                 location,
+					RPResourceGroupName:      rgName,
+					GatewayResourceGroupName: gatewayRGName,
+					Configuration: &Configuration{
+						RPParentDomainName: to.StringPtr("example.com"),
+					},
+				},
+			}
+
+			err := d.RotateCertificates(ctx, 0)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestVerifyServedCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	address := strings.TrimPrefix(server.URL, "https://")
+	served := server.Certificate().Raw
+
+	for _, tt := range []struct {
+		name    string
+		bundle  azkeyvault.CertificateBundle
+		timeout bool
+		wantErr string
+	}{
+		{
+			name:   "new certificate is served",
+			bundle: azkeyvault.CertificateBundle{Cer: &served},
+		},
+		{
+			name:    "old certificate is still served",
+			bundle:  azkeyvault.CertificateBundle{Cer: &[]byte{}},
+			timeout: true,
+			wantErr: "timed out waiting for the condition",
+		},
+		{
+			name:    "certificate has no content",
+			wantErr: "certificate rp-server has no content",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			kv := mock_keyvault.NewMockManager(controller)
+			kv.EXPECT().GetCertificate(ctx, env.RPServerSecretName).DoAndReturn(func(context.Context, string) (azkeyvault.CertificateBundle, error) {
+				if tt.timeout {
+					cancel()
+				}
+				return tt.bundle, nil
+			})
+
+			d := deployer{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+			}
+
+			err := d.verifyServedCertificate(ctx, kv, env.RPServerSecretName, address)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
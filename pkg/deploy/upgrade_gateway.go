@@ -28,6 +28,11 @@ func (d *deployer) UpgradeGateway(ctx context.Context) error {
 		return err
 	}
 
+	err = d.healthGate(ctx, d.config.GatewayResourceGroupName, gatewayVMSSPrefix+d.version, d.gatewayRemoveOldScaleset)
+	if err != nil {
+		return err
+	}
+
 	return d.gatewayRemoveOldScalesets(ctx)
 }
 
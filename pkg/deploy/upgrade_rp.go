@@ -28,6 +28,25 @@ func (d *deployer) UpgradeRP(ctx context.Context) error {
 		return err
 	}
 
+	if d.config.Configuration.RPCanary != nil {
+		oldCapacity, err := d.rpOldCapacity(ctx)
+		if err != nil {
+			return err
+		}
+
+		if oldCapacity > 0 {
+			err = d.rpUpgradeCanary(ctx, oldCapacity)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = d.healthGate(ctx, d.config.RPResourceGroupName, rpVMSSPrefix+d.version, d.rpRemoveOldScaleset)
+	if err != nil {
+		return err
+	}
+
 	return d.rpRemoveOldScalesets(ctx)
 }
 
@@ -0,0 +1,175 @@
+package deploy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	"github.com/Azure/go-autorest/autorest/to"
+)
+
+const (
+	whatIfModeWarn = "warn"
+	whatIfModeFail = "fail"
+)
+
+// whatIfReport is the machine-readable report of the changes predicted by ARM
+// what-if for each deployment validated by a run.
+type whatIfReport struct {
+	Deployments []whatIfDeployment `json:"deployments"`
+}
+
+type whatIfDeployment struct {
+	ResourceGroup string         `json:"resourceGroup"`
+	Deployment    string         `json:"deployment"`
+	Changes       []whatIfChange `json:"changes"`
+}
+
+type whatIfChange struct {
+	ResourceID string `json:"resourceId"`
+	ChangeType string `json:"changeType"`
+
+	// Destructive lists the reasons why the change is destructive, if it is.
+	Destructive []string `json:"destructive,omitempty"`
+}
+
+// validateWhatIf runs ARM what-if on a deployment before it is made, records
+// the predicted changes in the report, and warns about or fails on those which
+// are destructive.  isCreate is true if the resources are being deployed for
+// the first time.
+func (d *deployer) validateWhatIf(ctx context.Context, resourceGroupName, deploymentName string, properties *mgmtfeatures.DeploymentProperties, isCreate bool) error {
+	whatIf := d.config.Configuration.WhatIf
+	if whatIf == nil {
+		return nil
+	}
+
+	d.log.Infof("running what-if on %s", deploymentName)
+	result, err := d.deployments.WhatIfAndWait(ctx, resourceGroupName, deploymentName, mgmtfeatures.DeploymentWhatIf{
+		Properties: &mgmtfeatures.DeploymentWhatIfProperties{
+			Template:   properties.Template,
+			Parameters: properties.Parameters,
+			Mode:       properties.Mode,
+			WhatIfSettings: &mgmtfeatures.DeploymentWhatIfSettings{
+				ResultFormat: mgmtfeatures.FullResourcePayloads,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.Error != nil {
+		return fmt.Errorf("what-if failed for %s: %s: %s", deploymentName, to.String(result.Error.Code), to.String(result.Error.Message))
+	}
+
+	deployment := whatIfDeployment{
+		ResourceGroup: resourceGroupName,
+		Deployment:    deploymentName,
+		Changes:       []whatIfChange{},
+	}
+
+	var destructive []string
+	if result.WhatIfOperationProperties != nil && result.Changes != nil {
+		for _, change := range *result.Changes {
+			c := whatIfChange{
+				ResourceID:  to.String(change.ResourceID),
+				ChangeType:  string(change.ChangeType),
+				Destructive: destructiveChanges(change, isCreate),
+			}
+			deployment.Changes = append(deployment.Changes, c)
+
+			for _, reason := range c.Destructive {
+				destructive = append(destructive, c.ResourceID+": "+reason)
+			}
+		}
+	}
+
+	d.whatIfReport.Deployments = append(d.whatIfReport.Deployments, deployment)
+
+	if whatIf.ReportPath != "" {
+		b, err := json.MarshalIndent(&d.whatIfReport, "", "    ")
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(whatIf.ReportPath, append(b, '\n'), 0o644)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(destructive) == 0 {
+		return nil
+	}
+
+	if whatIf.Mode == whatIfModeFail {
+		return fmt.Errorf("what-if predicts %d destructive change(s) by %s:\n%s", len(destructive), deploymentName, strings.Join(destructive, "\n"))
+	}
+
+	for _, change := range destructive {
+		d.log.Warnf("what-if predicts destructive change by %s: %s", deploymentName, change)
+	}
+
+	return nil
+}
+
+// destructiveChanges returns the reasons why a change predicted by what-if is
+// destructive: deleting a resource, recreating a key vault, which loses its
+// secrets, or removing NSG rules.
+func destructiveChanges(change mgmtfeatures.WhatIfChange, isCreate bool) []string {
+	resourceID := strings.ToLower(to.String(change.ResourceID))
+
+	var reasons []string
+
+	switch {
+	case change.ChangeType == mgmtfeatures.Delete:
+		reasons = append(reasons, "resource would be deleted")
+
+	case change.ChangeType == mgmtfeatures.Create && !isCreate &&
+		strings.Contains(resourceID, "/providers/microsoft.keyvault/vaults/"):
+		reasons = append(reasons, "key vault would be recreated")
+
+	case change.ChangeType == mgmtfeatures.Modify && change.Delta != nil &&
+		strings.Contains(resourceID, "/providers/microsoft.network/networksecuritygroups/"):
+		for _, delta := range *change.Delta {
+			if !strings.EqualFold(to.String(delta.Path), "properties.securityRules") {
+				continue
+			}
+
+			switch delta.PropertyChangeType {
+			case mgmtfeatures.PropertyChangeTypeDelete:
+				reasons = append(reasons, "security rules would be removed")
+
+			case mgmtfeatures.PropertyChangeTypeArray:
+				if delta.Children == nil {
+					continue
+				}
+
+				for _, child := range *delta.Children {
+					if child.PropertyChangeType == mgmtfeatures.PropertyChangeTypeDelete {
+						reasons = append(reasons, fmt.Sprintf("security rule %s would be removed", securityRuleName(child.Before)))
+					}
+				}
+			}
+		}
+	}
+
+	return reasons
+}
+
+// securityRuleName returns the name of a security rule in a what-if snapshot.
+func securityRuleName(rule interface{}) string {
+	if m, ok := rule.(map[string]interface{}); ok {
+		if name, ok := m["name"].(string); ok {
+			return name
+		}
+	}
+
+	return "(unknown)"
+}
@@ -0,0 +1,218 @@
+package deploy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+
+	mock_features "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/features"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+const (
+	testNSGID      = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/networkSecurityGroups/rp-nsg"
+	testKeyvaultID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/kv-svc"
+)
+
+func TestDestructiveChanges(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		change   mgmtfeatures.WhatIfChange
+		isCreate bool
+		want     []string
+	}{
+		{
+			name: "deleted resource",
+			change: mgmtfeatures.WhatIfChange{
+				ResourceID: to.StringPtr(testNSGID),
+				ChangeType: mgmtfeatures.Delete,
+			},
+			want: []string{"resource would be deleted"},
+		},
+		{
+			name: "recreated key vault",
+			change: mgmtfeatures.WhatIfChange{
+				ResourceID: to.StringPtr(testKeyvaultID),
+				ChangeType: mgmtfeatures.Create,
+			},
+			want: []string{"key vault would be recreated"},
+		},
+		{
+			name: "key vault created on first deployment",
+			change: mgmtfeatures.WhatIfChange{
+				ResourceID: to.StringPtr(testKeyvaultID),
+				ChangeType: mgmtfeatures.Create,
+			},
+			isCreate: true,
+		},
+		{
+			name: "removed NSG rule",
+			change: mgmtfeatures.WhatIfChange{
+				ResourceID: to.StringPtr(testNSGID),
+				ChangeType: mgmtfeatures.Modify,
+				Delta: &[]mgmtfeatures.WhatIfPropertyChange{
+					{
+						Path:               to.StringPtr("properties.securityRules"),
+						PropertyChangeType: mgmtfeatures.PropertyChangeTypeArray,
+						Children: &[]mgmtfeatures.WhatIfPropertyChange{
+							{
+								Path:               to.StringPtr("0"),
+								PropertyChangeType: mgmtfeatures.PropertyChangeTypeDelete,
+								Before:             map[string]interface{}{"name": "rp_in_arm"},
+							},
+							{
+								Path:               to.StringPtr("1"),
+								PropertyChangeType: mgmtfeatures.PropertyChangeTypeCreate,
+								After:              map[string]interface{}{"name": "rp_in_geneva"},
+							},
+						},
+					},
+				},
+			},
+			want: []string{"security rule rp_in_arm would be removed"},
+		},
+		{
+			name: "added NSG rule",
+			change: mgmtfeatures.WhatIfChange{
+				ResourceID: to.StringPtr(testNSGID),
+				ChangeType: mgmtfeatures.Modify,
+				Delta: &[]mgmtfeatures.WhatIfPropertyChange{
+					{
+						Path:               to.StringPtr("properties.securityRules"),
+						PropertyChangeType: mgmtfeatures.PropertyChangeTypeArray,
+						Children: &[]mgmtfeatures.WhatIfPropertyChange{
+							{
+								Path:               to.StringPtr("1"),
+								PropertyChangeType: mgmtfeatures.PropertyChangeTypeCreate,
+							},
+						},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := destructiveChanges(tt.change, tt.isCreate)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestValidateWhatIf(t *testing.T) {
+	ctx := context.Background()
+
+	result := mgmtfeatures.WhatIfOperationResult{
+		WhatIfOperationProperties: &mgmtfeatures.WhatIfOperationProperties{
+			Changes: &[]mgmtfeatures.WhatIfChange{
+				{ResourceID: to.StringPtr(testNSGID), ChangeType: mgmtfeatures.NoChange},
+				{ResourceID: to.StringPtr(testKeyvaultID), ChangeType: mgmtfeatures.Create},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name    string
+		whatIf  *WhatIfConfiguration
+		mocks   func(*mock_features.MockDeploymentsClient)
+		wantErr string
+	}{
+		{
+			name: "skipped if not configured",
+		},
+		{
+			name:   "warns on destructive changes",
+			whatIf: &WhatIfConfiguration{Mode: whatIfModeWarn},
+			mocks: func(d *mock_features.MockDeploymentsClient) {
+				d.EXPECT().WhatIfAndWait(ctx, rgName, "rp-production-predeploy", gomock.Any()).Return(result, nil)
+			},
+		},
+		{
+			name:   "fails on destructive changes",
+			whatIf: &WhatIfConfiguration{Mode: whatIfModeFail},
+			mocks: func(d *mock_features.MockDeploymentsClient) {
+				d.EXPECT().WhatIfAndWait(ctx, rgName, "rp-production-predeploy", gomock.Any()).Return(result, nil)
+			},
+			wantErr: "what-if predicts 1 destructive change(s) by rp-production-predeploy:\n" + testKeyvaultID + ": key vault would be recreated",
+		},
+		{
+			name:   "fails if what-if fails",
+			whatIf: &WhatIfConfiguration{Mode: whatIfModeWarn},
+			mocks: func(d *mock_features.MockDeploymentsClient) {
+				d.EXPECT().WhatIfAndWait(ctx, rgName, "rp-production-predeploy", gomock.Any()).Return(mgmtfeatures.WhatIfOperationResult{}, errGeneric)
+			},
+			wantErr: "generic error",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockDeployments := mock_features.NewMockDeploymentsClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(mockDeployments)
+			}
+
+			reportPath := filepath.Join(t.TempDir(), "whatif.json")
+			if tt.whatIf != nil {
+				tt.whatIf.ReportPath = reportPath
+			}
+
+			d := deployer{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				config: &RPConfig{
+					Configuration: &Configuration{
+						WhatIf: tt.whatIf,
+					},
+				},
+				deployments: mockDeployments,
+			}
+
+			err := d.validateWhatIf(ctx, rgName, "rp-production-predeploy", &mgmtfeatures.DeploymentProperties{}, false)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if tt.whatIf == nil || tt.wantErr == "generic error" {
+				return
+			}
+
+			b, err := os.ReadFile(reportPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var report whatIfReport
+			err = json.Unmarshal(b, &report)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := whatIfReport{
+				Deployments: []whatIfDeployment{
+					{
+						ResourceGroup: rgName,
+						Deployment:    "rp-production-predeploy",
+						Changes: []whatIfChange{
+							{ResourceID: testNSGID, ChangeType: "NoChange"},
+							{ResourceID: testKeyvaultID, ChangeType: "Create", Destructive: []string{"key vault would be recreated"}},
+						},
+					},
+				},
+			}
+			if !reflect.DeepEqual(report, want) {
+				t.Error(string(b))
+			}
+		})
+	}
+}
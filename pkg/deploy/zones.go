@@ -0,0 +1,110 @@
+package deploy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+
+	"github.com/Azure/ARO-RP/pkg/util/arm"
+	"github.com/Azure/ARO-RP/pkg/util/computeskus"
+)
+
+// vmssZones returns the availability zones in which to place a scaleset of
+// VMs of size vmSize, or nil if the region is not zonal.  The zones are taken
+// from the configuration, or else detected from the zones in which vmSize is
+// available to the subscription.  Regions listed in nonZonalRegions are never
+// zonal.
+func (d *deployer) vmssZones(ctx context.Context, vmSize string) ([]string, error) {
+	for _, region := range d.config.Configuration.NonZonalRegions {
+		if strings.EqualFold(region, d.config.Location) {
+			return nil, nil
+		}
+	}
+
+	if len(d.config.Configuration.Zones.Zones) > 0 {
+		return d.config.Configuration.Zones.Zones, nil
+	}
+
+	skus, err := d.resourceskus.List(ctx, fmt.Sprintf("location eq '%s'", d.config.Location))
+	if err != nil {
+		return nil, err
+	}
+
+	sku, found := computeskus.FilterVMSizes(skus, d.config.Location)[vmSize]
+	if !found {
+		return nil, fmt.Errorf("VM size %s is not available in %s", vmSize, d.config.Location)
+	}
+
+	restricted := map[string]bool{}
+	if sku.Restrictions != nil {
+		for _, restriction := range *sku.Restrictions {
+			if restriction.Type != mgmtcompute.Zone || restriction.RestrictionInfo == nil || restriction.RestrictionInfo.Zones == nil {
+				continue
+			}
+
+			for _, zone := range *restriction.RestrictionInfo.Zones {
+				restricted[zone] = true
+			}
+		}
+	}
+
+	var zones []string
+	for _, zone := range computeskus.Zones(sku) {
+		if !restricted[zone] {
+			zones = append(zones, zone)
+		}
+	}
+	sort.Strings(zones)
+
+	return zones, nil
+}
+
+// setZoneParameters places a scaleset explicitly in the availability zones of
+// the region by setting its zones template parameter and, if instancesPerZone
+// is set, its capacity template parameter.  If the region is not zonal, it is
+// added to the nonZonalRegions template parameter so that the load balancers
+// and public IPs are not zonal either.
+func (d *deployer) setZoneParameters(ctx context.Context, parameters *arm.Parameters, vmSize, zonesParameter, capacityParameter string, instancesPerZone int) error {
+	zones, err := d.vmssZones(ctx, vmSize)
+	if err != nil {
+		return err
+	}
+
+	if len(zones) == 0 {
+		d.log.Printf("%s is not zonal, not placing scaleset in zones", d.config.Location)
+
+		nonZonalRegions := []string{strings.ToLower(d.config.Location)}
+		for _, region := range d.config.Configuration.NonZonalRegions {
+			if !strings.EqualFold(region, d.config.Location) {
+				nonZonalRegions = append(nonZonalRegions, region)
+			}
+		}
+
+		parameters.Parameters["nonZonalRegions"] = &arm.ParametersParameter{
+			Value: nonZonalRegions,
+		}
+		parameters.Parameters[zonesParameter] = &arm.ParametersParameter{
+			Value: []string{},
+		}
+		return nil
+	}
+
+	d.log.Printf("placing scaleset in zones %s", strings.Join(zones, ","))
+	parameters.Parameters[zonesParameter] = &arm.ParametersParameter{
+		Value: zones,
+	}
+
+	if instancesPerZone > 0 {
+		parameters.Parameters[capacityParameter] = &arm.ParametersParameter{
+			Value: instancesPerZone * len(zones),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package deploy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/util/arm"
+	mock_compute "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/compute"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestSetZoneParameters(t *testing.T) {
+	ctx := context.Background()
+	location := "eastus"
+
+	sku := func(zones []string, restrictedZones []string) []mgmtcompute.ResourceSku {
+		restrictions := []mgmtcompute.ResourceSkuRestrictions{}
+		if restrictedZones != nil {
+			restrictions = append(restrictions, mgmtcompute.ResourceSkuRestrictions{
+				Type: mgmtcompute.Zone,
+				RestrictionInfo: &mgmtcompute.ResourceSkuRestrictionInfo{
+					Zones: &restrictedZones,
+				},
+			})
+		}
+
+		return []mgmtcompute.ResourceSku{
+			{
+				Name:         to.StringPtr("Standard_D2s_v3"),
+				ResourceType: to.StringPtr("virtualMachines"),
+				Locations:    &[]string{location},
+				LocationInfo: &[]mgmtcompute.ResourceSkuLocationInfo{
+					{Zones: &zones},
+				},
+				Restrictions: &restrictions,
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name             string
+		zones            ZonesConfiguration
+		nonZonalRegions  []string
+		mocks            func(*mock_compute.MockResourceSkusClient)
+		wantParameters   map[string]interface{}
+		wantErr          string
+		instancesPerZone int
+	}{
+		{
+			name:  "configured zones",
+			zones: ZonesConfiguration{Zones: []string{"1", "2"}},
+			wantParameters: map[string]interface{}{
+				"rpVmssZones": []string{"1", "2"},
+			},
+		},
+		{
+			name:             "configured zones with instances per zone",
+			zones:            ZonesConfiguration{Zones: []string{"1", "2", "3"}},
+			instancesPerZone: 2,
+			wantParameters: map[string]interface{}{
+				"rpVmssZones":    []string{"1", "2", "3"},
+				"rpVmssCapacity": 6,
+			},
+		},
+		{
+			name: "detected zones",
+			mocks: func(skus *mock_compute.MockResourceSkusClient) {
+				skus.EXPECT().List(ctx, "location eq 'eastus'").Return(sku([]string{"3", "1", "2"}, nil), nil)
+			},
+			instancesPerZone: 1,
+			wantParameters: map[string]interface{}{
+				"rpVmssZones":    []string{"1", "2", "3"},
+				"rpVmssCapacity": 3,
+			},
+		},
+		{
+			name: "restricted zones are not used",
+			mocks: func(skus *mock_compute.MockResourceSkusClient) {
+				skus.EXPECT().List(ctx, "location eq 'eastus'").Return(sku([]string{"1", "2", "3"}, []string{"2"}), nil)
+			},
+			wantParameters: map[string]interface{}{
+				"rpVmssZones": []string{"1", "3"},
+			},
+		},
+		{
+			name: "detected non-zonal region",
+			mocks: func(skus *mock_compute.MockResourceSkusClient) {
+				skus.EXPECT().List(ctx, "location eq 'eastus'").Return(sku([]string{}, nil), nil)
+			},
+			instancesPerZone: 2,
+			wantParameters: map[string]interface{}{
+				"rpVmssZones":     []string{},
+				"nonZonalRegions": []string{"eastus"},
+			},
+		},
+		{
+			name:            "configured non-zonal region",
+			zones:           ZonesConfiguration{Zones: []string{"1", "2", "3"}},
+			nonZonalRegions: []string{"westus", "eastus"},
+			wantParameters: map[string]interface{}{
+				"rpVmssZones":     []string{},
+				"nonZonalRegions": []string{"eastus", "westus"},
+			},
+		},
+		{
+			name: "unavailable VM size",
+			mocks: func(skus *mock_compute.MockResourceSkusClient) {
+				skus.EXPECT().List(ctx, "location eq 'eastus'").Return([]mgmtcompute.ResourceSku{}, nil)
+			},
+			wantErr: "VM size Standard_D2s_v3 is not available in eastus",
+		},
+		{
+			name: "error listing SKUs",
+			mocks: func(skus *mock_compute.MockResourceSkusClient) {
+				skus.EXPECT().List(ctx, "location eq 'eastus'").Return(nil, errGeneric)
+			},
+			wantErr: "generic error",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mockResourceSkus := mock_compute.NewMockResourceSkusClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(mockResourceSkus)
+			}
+
+			d := deployer{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				config: &RPConfig{
+					Location: location,
+					Configuration: &Configuration{
+						NonZonalRegions: tt.nonZonalRegions,
+						Zones:           &tt.zones,
+					},
+				},
+				resourceskus: mockResourceSkus,
+			}
+
+			parameters := &arm.Parameters{
+				Parameters: map[string]*arm.ParametersParameter{},
+			}
+
+			err := d.setZoneParameters(ctx, parameters, "Standard_D2s_v3", "rpVmssZones", "rpVmssCapacity", tt.instancesPerZone)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if tt.wantErr != "" {
+				return
+			}
+
+			got := map[string]interface{}{}
+			for k, v := range parameters.Parameters {
+				got[k] = v.Value
+			}
+
+			if !reflect.DeepEqual(got, tt.wantParameters) {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestZonesConfigurationValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		zones   ZonesConfiguration
+		wantErr string
+	}{
+		{
+			name:  "valid",
+			zones: ZonesConfiguration{Zones: []string{"1", "2", "3"}, RPInstancesPerZone: 1, GatewayInstancesPerZone: 2},
+		},
+		{
+			name:    "empty zone",
+			zones:   ZonesConfiguration{Zones: []string{"1", ""}},
+			wantErr: "zones.zones must not contain empty zones",
+		},
+		{
+			name:    "invalid RP instances per zone",
+			zones:   ZonesConfiguration{RPInstancesPerZone: -1},
+			wantErr: "zones.rpInstancesPerZone -1 must not be negative",
+		},
+		{
+			name:    "invalid gateway instances per zone",
+			zones:   ZonesConfiguration{GatewayInstancesPerZone: -1},
+			wantErr: "zones.gatewayInstancesPerZone -1 must not be negative",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.zones.validate()
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
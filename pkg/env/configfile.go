@@ -0,0 +1,244 @@
+package env
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+// RPConfigFile is the environment variable naming an optional YAML or JSON
+// file of RP configuration.  Environment variables take precedence over the
+// values in the file.
+const RPConfigFile = "RP_CONFIG_FILE"
+
+const configFileAPIVersion = "v1"
+
+// configFile is the schema of the RP configuration file.
+type configFile struct {
+	// APIVersion is the version of the configuration file format.
+	APIVersion string `json:"apiVersion"`
+
+	// Env holds the values of the RP's environment variables.
+	Env map[string]string `json:"env"`
+}
+
+// configVar describes an environment variable which configures the RP.
+type configVar struct {
+	name     string
+	secret   bool
+	validate func(string) error
+}
+
+// rpConfigVars are the environment variables which may be set in the RP
+// configuration file, and which are listed in the startup diagnostics.
+var rpConfigVars = []configVar{
+	{name: "ACR_RESOURCE_ID", validate: validateResourceID},
+	{name: "ADMIN_API_CLIENT_CERT_COMMON_NAME"},
+	{name: "ARM_API_CLIENT_CERT_COMMON_NAME"},
+	{name: "ARO_ADOPT_BY_HIVE"},
+	{name: "ARO_HIVE_DEFAULT_INSTALLER_PULLSPEC"},
+	{name: "ARO_INSTALL_VIA_HIVE"},
+	{name: "AZURE_ARM_CLIENT_ID", validate: validateUUID},
+	{name: "AZURE_ENVIRONMENT"},
+	{name: "AZURE_FP_CLIENT_ID", validate: validateUUID},
+	{name: "CLUSTER_LOOKUP_FALLBACK", validate: validateBool},
+	{name: "CLUSTER_MDM_ACCOUNT"},
+	{name: "CLUSTER_MDM_NAMESPACE"},
+	{name: "CLUSTER_MDSD_ACCOUNT"},
+	{name: "CLUSTER_MDSD_CONFIG_VERSION"},
+	{name: "CLUSTER_MDSD_NAMESPACE"},
+	{name: "CLUSTER_TOMBSTONE_RETENTION", validate: validatePositiveDuration},
+	{name: EnvDatabaseAccountName},
+	{name: EnvDatabaseName},
+	{name: "DOMAIN_NAME"},
+	{name: "GATEWAY_DOMAINS"},
+	{name: "GATEWAY_RESOURCEGROUP"},
+	{name: KeyvaultPrefix},
+	{name: "MDM_ACCOUNT"},
+	{name: "MDM_NAMESPACE"},
+	{name: "MDSD_ENVIRONMENT"},
+	{name: "METRICS_LOG_FILTER"},
+	{name: "METRICS_MDM_FILTER"},
+	{name: "METRICS_OTLP_FILTER"},
+	{name: "METRICS_PROMETHEUS_FILTER"},
+	{name: "METRICS_SINKS"},
+	{name: "MSI_RP_ENDPOINT"},
+	{name: OIDCAFDEndpoint},
+	{name: OIDCStorageAccountName},
+	{name: "OTEL_EXPORTER_OTLP_ENDPOINT"},
+	{name: "PROMETHEUS_LISTEN_ADDRESS"},
+	{name: "PULL_SECRET", secret: true},
+	{name: "RP_FEATURES", validate: validateFeatures},
+	{name: "RP_MODE", validate: validateRPMode},
+}
+
+// ConfigSources records where the value of each RP configuration environment
+// variable which is set came from.
+type ConfigSources map[string]string
+
+// LoadConfig loads the RP configuration file named by RP_CONFIG_FILE, if set,
+// into the environment, and returns the sources of the configuration.  The
+// file is validated against the schema before any of it is applied.
+func LoadConfig() (ConfigSources, error) {
+	sources := ConfigSources{}
+	for _, v := range rpConfigVars {
+		if _, found := os.LookupEnv(v.name); found {
+			sources[v.name] = "environment"
+		}
+	}
+
+	path := os.Getenv(RPConfigFile)
+	if path == "" {
+		return sources, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := parseConfigFile(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %s: %w", RPConfigFile, path, err)
+	}
+
+	for name, value := range config.Env {
+		if _, found := sources[name]; found {
+			sources[name] = "environment, overriding " + path
+			continue
+		}
+
+		err = os.Setenv(name, value)
+		if err != nil {
+			return nil, err
+		}
+
+		sources[name] = path
+	}
+
+	return sources, nil
+}
+
+// parseConfigFile parses and validates an RP configuration file.
+func parseConfigFile(b []byte) (*configFile, error) {
+	var config *configFile
+	err := yaml.UnmarshalStrict(b, &config)
+	if err != nil {
+		return nil, err
+	}
+
+	if config == nil {
+		return nil, errors.New("file is empty")
+	}
+
+	if config.APIVersion != configFileAPIVersion {
+		return nil, fmt.Errorf("unsupported apiVersion %q", config.APIVersion)
+	}
+
+	vars := map[string]configVar{}
+	for _, v := range rpConfigVars {
+		vars[v.name] = v
+	}
+
+	var errs []error
+	for _, name := range sortedKeys(config.Env) {
+		v, found := vars[name]
+		switch {
+		case !found:
+			errs = append(errs, fmt.Errorf("unknown variable %q", name))
+		case v.validate != nil:
+			if err := v.validate(config.Env[name]); err != nil {
+				errs = append(errs, fmt.Errorf("invalid variable %q: %w", name, err))
+			}
+		}
+	}
+
+	return config, errors.Join(errs...)
+}
+
+// Log logs the effective value and source of each RP configuration
+// environment variable.  Secret values are redacted.
+func (s ConfigSources) Log(log *logrus.Entry) {
+	for _, v := range rpConfigVars {
+		source, found := s[v.name]
+		if !found {
+			log.Infof("configuration: %s unset", v.name)
+			continue
+		}
+
+		value := os.Getenv(v.name)
+		if v.secret {
+			value = "<redacted>"
+		}
+
+		log.Infof("configuration: %s=%q (from %s)", v.name, value, source)
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func validateBool(value string) error {
+	_, err := strconv.ParseBool(value)
+	return err
+}
+
+func validateDuration(value string) error {
+	_, err := time.ParseDuration(value)
+	return err
+}
+
+func validatePositiveDuration(value string) error {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+
+	if d < time.Second {
+		return fmt.Errorf("duration %q must be at least 1s", value)
+	}
+
+	return nil
+}
+
+func validateFeatures(value string) error {
+	_, err := parseFeatures(value)
+	return err
+}
+
+func validateResourceID(value string) error {
+	_, err := azure.ParseResourceID(value)
+	return err
+}
+
+func validateRPMode(value string) error {
+	if value != "" && !strings.EqualFold(value, "development") {
+		return fmt.Errorf(`must be empty or "development"`)
+	}
+
+	return nil
+}
+
+func validateUUID(value string) error {
+	_, err := uuid.FromString(value)
+	return err
+}
@@ -0,0 +1,145 @@
+package env
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestParseConfigFile(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		config  string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name: "valid YAML",
+			config: `apiVersion: v1
+env:
+  DOMAIN_NAME: eastus.aroapp.io
+  RP_FEATURES: DisableDenyAssignments
+`,
+			want: map[string]string{
+				"DOMAIN_NAME": "eastus.aroapp.io",
+				"RP_FEATURES": "DisableDenyAssignments",
+			},
+		},
+		{
+			name:   "valid JSON",
+			config: `{"apiVersion": "v1", "env": {"AZURE_FP_CLIENT_ID": "00000000-0000-0000-0000-000000000000"}}`,
+			want: map[string]string{
+				"AZURE_FP_CLIENT_ID": "00000000-0000-0000-0000-000000000000",
+			},
+		},
+		{
+			name:    "empty",
+			wantErr: "file is empty",
+		},
+		{
+			name:    "unsupported version",
+			config:  "apiVersion: v2\n",
+			wantErr: `unsupported apiVersion "v2"`,
+		},
+		{
+			name:    "unknown field",
+			config:  "apiVersion: v1\nenvironment: {}\n",
+			wantErr: `error unmarshaling JSON: while decoding JSON: json: unknown field "environment"`,
+		},
+		{
+			name: "unknown and invalid variables",
+			config: `apiVersion: v1
+env:
+  CLUSTER_TOMBSTONE_RETENTION: 1 week
+  DOMAIN_NAMES: eastus.aroapp.io
+  RP_MODE: production
+`,
+			wantErr: `invalid variable "CLUSTER_TOMBSTONE_RETENTION": time: unknown unit " week" in duration "1 week"` + "\n" +
+				`unknown variable "DOMAIN_NAMES"` + "\n" +
+				`invalid variable "RP_MODE": must be empty or "development"`,
+		},
+		{
+			name: "zero tombstone retention",
+			config: `apiVersion: v1
+env:
+  CLUSTER_TOMBSTONE_RETENTION: 0s
+`,
+			wantErr: `invalid variable "CLUSTER_TOMBSTONE_RETENTION": duration "0s" must be at least 1s`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := parseConfigFile([]byte(tt.config))
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if tt.wantErr == "" && !reflect.DeepEqual(config.Env, tt.want) {
+				t.Error(config.Env)
+			}
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rp.yaml")
+	err := os.WriteFile(path, []byte(`apiVersion: v1
+env:
+  DOMAIN_NAME: eastus.aroapp.io
+  MDM_ACCOUNT: file-account
+  PULL_SECRET: secret
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(RPConfigFile, path)
+	t.Setenv("MDM_ACCOUNT", "environment-account")
+	for _, name := range []string{"DOMAIN_NAME", "PULL_SECRET"} {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+
+	sources, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[string]string{
+		"DOMAIN_NAME": "eastus.aroapp.io",
+		"MDM_ACCOUNT": "environment-account",
+		"PULL_SECRET": "secret",
+	} {
+		if got := os.Getenv(name); got != want {
+			t.Errorf("%s: got %q", name, got)
+		}
+	}
+
+	if sources["DOMAIN_NAME"] != path || sources["MDM_ACCOUNT"] != "environment, overriding "+path {
+		t.Error(sources)
+	}
+
+	logger, hook := test.NewNullLogger()
+	sources.Log(logrus.NewEntry(logger))
+
+	messages := map[string]bool{}
+	for _, entry := range hook.AllEntries() {
+		messages[entry.Message] = true
+	}
+
+	for _, want := range []string{
+		`configuration: DOMAIN_NAME="eastus.aroapp.io" (from ` + path + `)`,
+		`configuration: MDM_ACCOUNT="environment-account" (from environment, overriding ` + path + `)`,
+		`configuration: PULL_SECRET="<redacted>" (from ` + path + `)`,
+	} {
+		if !messages[want] {
+			t.Errorf("missing log message %s", want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ package env
 
 import (
 	"context"
+	"os"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
@@ -13,6 +14,7 @@ import (
 
 	utilcontainerservice "github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/armcontainerservice"
 	"github.com/Azure/ARO-RP/pkg/util/instancemetadata"
+	"github.com/Azure/ARO-RP/pkg/util/keyvault"
 	"github.com/Azure/ARO-RP/pkg/util/liveconfig"
 )
 
@@ -31,6 +33,10 @@ const (
 	COMPONENT_TOOLING             ServiceComponent = "TOOLING"
 	COMPONENT_MIMO_SCHEDULER      ServiceComponent = "MIMO_SCHEDULER"
 	COMPONENT_MIMO_ACTUATOR       ServiceComponent = "MIMO_ACTUATOR"
+	COMPONENT_REENCRYPT_DOCUMENTS ServiceComponent = "REENCRYPT_DOCUMENTS"
+	COMPONENT_HIVE_BACKFILL       ServiceComponent = "HIVE_BACKFILL"
+	COMPONENT_HIVE_REBALANCE      ServiceComponent = "HIVE_REBALANCE"
+	COMPONENT_BACKFILL_LOOKUPS    ServiceComponent = "BACKFILL_LOOKUPS"
 )
 
 // Core collects basic configuration information which is expected to be
@@ -87,11 +93,23 @@ func (c *core) NewLiveConfigManager(ctx context.Context) (liveconfig.Manager, er
 		return nil, err
 	}
 
+	// the sidecar images are read from the service key vault, which only
+	// services which set KEYVAULT_PREFIX have access to
+	var serviceKeyvault keyvault.Manager
+	if keyVaultPrefix := os.Getenv(KeyvaultPrefix); keyVaultPrefix != "" {
+		msiKVAuthorizer, err := c.NewMSIAuthorizer(c.Environment().KeyVaultScope)
+		if err != nil {
+			return nil, err
+		}
+
+		serviceKeyvault = keyvault.NewManager(msiKVAuthorizer, keyvault.URI(c, ServiceKeyvaultSuffix, keyVaultPrefix))
+	}
+
 	if c.isLocalDevelopmentMode {
-		return liveconfig.NewDev(c.Location(), mcc), nil
+		return liveconfig.NewDev(c.Location(), mcc, serviceKeyvault), nil
 	}
 
-	return liveconfig.NewProd(c.Location(), mcc), nil
+	return liveconfig.NewProd(c.Location(), mcc, serviceKeyvault), nil
 }
 
 func NewCore(ctx context.Context, log *logrus.Entry, component ServiceComponent) (Core, error) {
@@ -43,7 +43,7 @@ func newDev(ctx context.Context, log *logrus.Entry, component ServiceComponent)
 		FeatureRequireOIDCStorageWebEndpoint,
 		FeatureUseMockMsiRp,
 	} {
-		d.features[feature] = true
+		d.features.set(feature)
 	}
 
 	d.prod.clusterGenevaLoggingAccount = version.DevClusterGenevaLoggingAccount
@@ -39,11 +39,13 @@ const (
 	FeatureEnableOCMEndpoints
 	FeatureRequireOIDCStorageWebEndpoint
 	FeatureUseMockMsiRp
+	FeatureBlockSharedSubnets
 )
 
 const (
 	RPDevARMSecretName               = "dev-arm"
 	RPFirstPartySecretName           = "rp-firstparty"
+	RPFeaturesSecretName             = "rp-features"
 	RPServerSecretName               = "rp-server"
 	ClusterLoggingSecretName         = "cluster-mdsd"
 	EncryptionSecretName             = "encryption-key"
@@ -0,0 +1,164 @@
+package env
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureerrors"
+	"github.com/Azure/ARO-RP/pkg/util/keyvault"
+)
+
+// featureFlags holds the RP feature flags.  The flags set at process start by
+// RP_FEATURES (and development mode) can be overridden fleet-wide without a
+// restart by the RPFeaturesSecretName secret in the service key vault, which
+// is polled for changes.
+type featureFlags struct {
+	lock      sync.RWMutex
+	static    map[Feature]bool
+	overrides map[Feature]bool
+
+	logger     *logrus.Entry
+	kv         keyvault.Manager
+	secretName string
+	newTicker  func() (tick <-chan time.Time, stop func())
+}
+
+func newFeatureFlags() *featureFlags {
+	return &featureFlags{
+		static:    map[Feature]bool{},
+		overrides: map[Feature]bool{},
+	}
+}
+
+// parseFeatures parses a comma-delimited list of feature names without their
+// Feature prefix, such as the value of RP_FEATURES.  A name prefixed with "-"
+// unsets the feature.
+func parseFeatures(features string) (map[Feature]bool, error) {
+	m := map[Feature]bool{}
+	if features == "" {
+		return m, nil
+	}
+
+	for _, feature := range strings.Split(features, ",") {
+		feature = strings.TrimSpace(feature)
+		value := !strings.HasPrefix(feature, "-")
+
+		f, err := FeatureString("Feature" + strings.TrimPrefix(feature, "-"))
+		if err != nil {
+			return nil, err
+		}
+
+		m[f] = value
+	}
+
+	return m, nil
+}
+
+// set sets a feature at process start.
+func (f *featureFlags) set(feature Feature) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.static[feature] = true
+}
+
+// IsSet returns whether a feature is set, safe to use concurrently.
+func (f *featureFlags) IsSet(feature Feature) bool {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	if value, found := f.overrides[feature]; found {
+		return value
+	}
+
+	return f.static[feature]
+}
+
+// Start fetches the overrides and starts polling them for changes every
+// interval.
+func (f *featureFlags) Start(ctx context.Context, logger *logrus.Entry, interval time.Duration, kv keyvault.Manager, secretName string) error {
+	f.logger = logger
+	f.kv = kv
+	f.secretName = secretName
+	if f.newTicker == nil {
+		f.newTicker = func() (tick <-chan time.Time, stop func()) {
+			ticker := time.NewTicker(interval)
+			return ticker.C, func() { ticker.Stop() }
+		}
+	}
+
+	err := f.fetchOverridesOnce(ctx)
+	if err != nil {
+		return err
+	}
+
+	f.fetchOverrides(ctx)
+
+	return nil
+}
+
+// fetchOverridesOnce reads the overrides from key vault.  If the secret does
+// not exist, there are no overrides.  In case of failure the old overrides are
+// left in place.
+func (f *featureFlags) fetchOverridesOnce(ctx context.Context) error {
+	var value string
+
+	bundle, err := f.kv.GetSecret(ctx, f.secretName)
+	switch {
+	case azureerrors.IsNotFoundError(err):
+	case err != nil:
+		return err
+	case bundle.Value != nil:
+		value = *bundle.Value
+	}
+
+	overrides, err := parseFeatures(value)
+	if err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for feature, value := range overrides {
+		if old, found := f.overrides[feature]; !found || old != value {
+			f.logger.Infof("feature %s overridden to %t", feature, value)
+		}
+	}
+	for feature := range f.overrides {
+		if _, found := overrides[feature]; !found {
+			f.logger.Infof("feature %s no longer overridden", feature)
+		}
+	}
+
+	f.overrides = overrides
+
+	return nil
+}
+
+// fetchOverrides starts goroutine to poll the overrides
+func (f *featureFlags) fetchOverrides(ctx context.Context) {
+	tick, stop := f.newTicker()
+
+	go func() {
+		defer stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick:
+				err := f.fetchOverridesOnce(ctx)
+				if err != nil {
+					f.logger.Errorf("cannot pull feature overrides leaving old ones, %s", err.Error())
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,172 @@
+package env
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	azkeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+
+	mock_keyvault "github.com/Azure/ARO-RP/pkg/util/mocks/keyvault"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestParseFeatures(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		features string
+		want     map[Feature]bool
+		wantErr  string
+	}{
+		{
+			name: "empty",
+			want: map[Feature]bool{},
+		},
+		{
+			name:     "set and unset features",
+			features: "DisableDenyAssignments, -UseMockMsiRp",
+			want: map[Feature]bool{
+				FeatureDisableDenyAssignments: true,
+				FeatureUseMockMsiRp:           false,
+			},
+		},
+		{
+			name:     "unknown feature",
+			features: "DisableDenyAssignments,Unknown",
+			wantErr:  "FeatureUnknown does not belong to Feature values",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFeatures(tt.features)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestFeatureFlags(t *testing.T) {
+	ctx := context.Background()
+
+	notFound := autorest.DetailedError{StatusCode: http.StatusNotFound}
+	secret := func(value string) azkeyvault.SecretBundle {
+		return azkeyvault.SecretBundle{Value: to.StringPtr(value)}
+	}
+
+	for _, tt := range []struct {
+		name    string
+		mocks   func(*mock_keyvault.MockManager)
+		refresh bool
+		want    map[Feature]bool
+		wantErr string
+	}{
+		{
+			name: "no overrides if the secret does not exist",
+			mocks: func(kv *mock_keyvault.MockManager) {
+				kv.EXPECT().GetSecret(gomock.Any(), RPFeaturesSecretName).Return(azkeyvault.SecretBundle{}, notFound)
+			},
+			want: map[Feature]bool{
+				FeatureDisableDenyAssignments: true,
+				FeatureUseMockMsiRp:           true,
+			},
+		},
+		{
+			name: "overrides are applied",
+			mocks: func(kv *mock_keyvault.MockManager) {
+				kv.EXPECT().GetSecret(gomock.Any(), RPFeaturesSecretName).Return(secret("EnableOCMEndpoints,-UseMockMsiRp"), nil)
+			},
+			want: map[Feature]bool{
+				FeatureDisableDenyAssignments: true,
+				FeatureEnableOCMEndpoints:     true,
+			},
+		},
+		{
+			name:    "overrides are refreshed",
+			refresh: true,
+			mocks: func(kv *mock_keyvault.MockManager) {
+				gomock.InOrder(
+					kv.EXPECT().GetSecret(gomock.Any(), RPFeaturesSecretName).Return(secret("-UseMockMsiRp"), nil),
+					kv.EXPECT().GetSecret(gomock.Any(), RPFeaturesSecretName).Return(secret("EnableOCMEndpoints"), nil),
+				)
+			},
+			want: map[Feature]bool{
+				FeatureDisableDenyAssignments: true,
+				FeatureEnableOCMEndpoints:     true,
+				FeatureUseMockMsiRp:           true,
+			},
+		},
+		{
+			name:    "old overrides are kept if refreshing fails",
+			refresh: true,
+			mocks: func(kv *mock_keyvault.MockManager) {
+				gomock.InOrder(
+					kv.EXPECT().GetSecret(gomock.Any(), RPFeaturesSecretName).Return(secret("-UseMockMsiRp"), nil),
+					kv.EXPECT().GetSecret(gomock.Any(), RPFeaturesSecretName).Return(secret("Unknown"), nil),
+				)
+			},
+			want: map[Feature]bool{
+				FeatureDisableDenyAssignments: true,
+			},
+		},
+		{
+			name: "error on start",
+			mocks: func(kv *mock_keyvault.MockManager) {
+				kv.EXPECT().GetSecret(gomock.Any(), RPFeaturesSecretName).Return(azkeyvault.SecretBundle{}, errors.New("failed"))
+			},
+			wantErr: "failed",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			kv := mock_keyvault.NewMockManager(controller)
+			tt.mocks(kv)
+
+			tick := make(chan time.Time)
+			stopped := make(chan struct{})
+
+			f := newFeatureFlags()
+			f.set(FeatureDisableDenyAssignments)
+			f.set(FeatureUseMockMsiRp)
+			f.newTicker = func() (<-chan time.Time, func()) {
+				return tick, func() { close(stopped) }
+			}
+
+			ctx, cancel := context.WithCancel(ctx)
+
+			err := f.Start(ctx, logrus.NewEntry(logrus.StandardLogger()), time.Minute, kv, RPFeaturesSecretName)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			if err != nil {
+				cancel()
+				return
+			}
+
+			if tt.refresh {
+				tick <- time.Time{}
+			}
+
+			// the poller stops once it has handled the tick
+			cancel()
+			<-stopped
+
+			for _, feature := range FeatureValues() {
+				if f.IsSet(feature) != tt.want[feature] {
+					t.Errorf("%s: got %t", feature, f.IsSet(feature))
+				}
+			}
+		})
+	}
+}
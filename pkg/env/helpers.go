@@ -11,6 +11,10 @@ import (
 const (
 	EnvDatabaseName        = "DATABASE_NAME"
 	EnvDatabaseAccountName = "DATABASE_ACCOUNT_NAME"
+
+	// EnvDatabaseEmulatorHost, if set in development mode, points the RP at
+	// a Cosmos DB emulator (e.g. "localhost:8081") instead of a real account
+	EnvDatabaseEmulatorHost = "DATABASE_EMULATOR_HOST"
 )
 
 // Fetch the database account name from the environment.
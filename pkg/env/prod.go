@@ -71,7 +71,7 @@ type prod struct {
 
 	log *logrus.Entry
 
-	features map[Feature]bool
+	features *featureFlags
 }
 
 func newProd(ctx context.Context, log *logrus.Entry, component ServiceComponent) (*prod, error) {
@@ -116,19 +116,12 @@ func newProd(ctx context.Context, log *logrus.Entry, component ServiceComponent)
 
 		log: log,
 
-		features: map[Feature]bool{},
+		features: newFeatureFlags(),
 	}
 
-	features := os.Getenv("RP_FEATURES")
-	if features != "" {
-		for _, feature := range strings.Split(features, ",") {
-			f, err := FeatureString("Feature" + feature)
-			if err != nil {
-				return nil, err
-			}
-
-			p.features[f] = true
-		}
+	p.features.static, err = parseFeatures(os.Getenv("RP_FEATURES"))
+	if err != nil {
+		return nil, err
 	}
 
 	msiAuthorizer, err := p.NewMSIAuthorizer(p.Environment().ResourceManagerScope)
@@ -160,6 +153,11 @@ func newProd(ctx context.Context, log *logrus.Entry, component ServiceComponent)
 		return nil, err
 	}
 
+	err = p.features.Start(ctx, log, 5*time.Minute, p.serviceKeyvault, RPFeaturesSecretName)
+	if err != nil {
+		return nil, err
+	}
+
 	localFPKVAuthorizer, err := p.FPAuthorizer(p.TenantID(), nil, p.Environment().KeyVaultScope)
 	if err != nil {
 		return nil, err
@@ -334,7 +332,7 @@ func (p *prod) Domain() string {
 }
 
 func (p *prod) FeatureIsSet(f Feature) bool {
-	return p.features[f]
+	return p.features.IsSet(f)
 }
 
 // TODO: Delete FPAuthorizer once the replace from track1 to track2 is done.
@@ -0,0 +1,136 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/hive"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	mock_hive "github.com/Azure/ARO-RP/pkg/util/mocks/hive"
+)
+
+func Test_getAdminHiveProvisionLogs(t *testing.T) {
+	fakeUUID := "00000000-0000-0000-0000-000000000000"
+	ctx := context.Background()
+	provisionLogs := &hive.ProvisionLogs{
+		Provisions: []hive.ProvisionAttempt{
+			{
+				Name:          "cluster-0-aaaaa",
+				Stage:         "failed",
+				FailureReason: "AzureQuotaExceeded",
+			},
+		},
+	}
+	type test struct {
+		name           string
+		resourceID     string
+		properties     api.OpenShiftClusterProperties
+		hiveEnabled    bool
+		mocks          func(*mock_hive.MockClusterManager)
+		wantStatusCode int
+		wantResponse   []byte
+		wantError      string
+	}
+
+	for _, tt := range []*test{
+		{
+			name:        "cluster has hive profile with namespace",
+			resourceID:  fmt.Sprintf("/subscriptions/%s/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/hive", fakeUUID),
+			properties:  api.OpenShiftClusterProperties{HiveProfile: api.HiveProfile{Namespace: fmt.Sprintf("aro-%s", fakeUUID)}},
+			hiveEnabled: true,
+			mocks: func(clusterManager *mock_hive.MockClusterManager) {
+				clusterManager.EXPECT().GetProvisionLogs(gomock.Any(), gomock.Any()).Return(provisionLogs, nil)
+			},
+			wantResponse: []byte(`{"provisions":[{"name":"cluster-0-aaaaa","attempt":0,"stage":"failed","failureReason":"AzureQuotaExceeded"}]}`),
+		},
+		{
+			name:        "hive shard is unreachable",
+			resourceID:  fmt.Sprintf("/subscriptions/%s/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/hive", fakeUUID),
+			properties:  api.OpenShiftClusterProperties{HiveProfile: api.HiveProfile{Namespace: fmt.Sprintf("aro-%s", fakeUUID)}},
+			hiveEnabled: true,
+			mocks: func(clusterManager *mock_hive.MockClusterManager) {
+				clusterManager.EXPECT().GetProvisionLogs(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      "500: InternalServerError: : connection refused",
+		},
+		{
+			name:           "cluster does not have hive profile with namespace",
+			resourceID:     fmt.Sprintf("/subscriptions/%s/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/nonHive", fakeUUID),
+			hiveEnabled:    true,
+			wantStatusCode: http.StatusNoContent,
+			wantError:      "204: ResourceNotFound: : cluster is not managed by hive",
+		},
+		{
+			name:           "hive is not enabled",
+			resourceID:     fmt.Sprintf("/subscriptions/%s/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/nonHive", fakeUUID),
+			hiveEnabled:    false,
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      "500: InternalServerError: : hive is not enabled",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			controller := gomock.NewController(t)
+			defer ti.done()
+			defer controller.Finish()
+
+			ti.fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(tt.resourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID:         tt.resourceID,
+					Name:       "hive",
+					Type:       "Microsoft.RedHatOpenShift/openshiftClusters",
+					Properties: tt.properties,
+				},
+			})
+
+			err := ti.buildFixtures(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_env := ti.env.(*mock_env.MockInterface)
+			var f *frontend
+			if tt.hiveEnabled {
+				clusterManager := mock_hive.NewMockClusterManager(controller)
+				if tt.mocks != nil {
+					tt.mocks(clusterManager)
+				}
+				f, err = NewFrontend(ctx, ti.audit, ti.log, _env, ti.dbGroup, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, clusterManager, nil, nil, nil, nil)
+			} else {
+				f, err = NewFrontend(ctx, ti.audit, ti.log, _env, ti.dbGroup, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil, nil)
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := f._getAdminHiveProvisionLogs(ctx, strings.ToLower(tt.resourceID))
+			cloudErr, isCloudErr := err.(*api.CloudError)
+			if tt.wantError != "" {
+				if !isCloudErr || tt.wantError != cloudErr.Error() {
+					t.Fatalf("got %v but wanted %q", err, tt.wantError)
+				}
+				if tt.wantStatusCode != cloudErr.StatusCode {
+					t.Fatalf("got status %d but wanted %d", cloudErr.StatusCode, tt.wantStatusCode)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(b) != string(tt.wantResponse) {
+				t.Fatalf("got %q and expected %q", b, tt.wantResponse)
+			}
+		})
+	}
+}
@@ -74,6 +74,31 @@ func TestMIMOCreateManifest(t *testing.T) {
 			wantError:      "400: InvalidParameter: maintenanceTaskID: Must be provided",
 			wantStatusCode: http.StatusBadRequest,
 		},
+		{
+			name: "invalid window",
+			fixtures: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   resourceID,
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+					},
+				})
+			},
+			body: &admin.MaintenanceManifest{
+				MaintenanceTaskID: "exampletask",
+				Windows: []admin.MaintenanceWindow{
+					{
+						DayOfWeek:     "Caturday",
+						StartHour:     2,
+						DurationHours: 4,
+					},
+				},
+			},
+			wantError:      "400: InvalidParameter: windows[0].dayOfWeek: The provided day of week 'Caturday' is invalid.",
+			wantStatusCode: http.StatusBadRequest,
+		},
 
 		{
 			name: "good",
@@ -152,6 +177,65 @@ func TestMIMOCreateManifest(t *testing.T) {
 			},
 			wantStatusCode: http.StatusCreated,
 		},
+		{
+			name: "with windows",
+			fixtures: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   resourceID,
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+					},
+				})
+			},
+			body: &admin.MaintenanceManifest{
+				MaintenanceTaskID: "exampletask",
+				RunAfter:          1,
+				RunBefore:         1,
+				Windows: []admin.MaintenanceWindow{
+					{
+						DayOfWeek:     "Saturday",
+						StartHour:     2,
+						DurationHours: 4,
+					},
+				},
+			},
+			wantResult: func(c *testdatabase.Checker) {
+				c.AddMaintenanceManifestDocuments(&api.MaintenanceManifestDocument{
+					ID:                "07070707-0707-0707-0707-070707070001",
+					ClusterResourceID: strings.ToLower(resourceID),
+					MaintenanceManifest: api.MaintenanceManifest{
+						MaintenanceTaskID: "exampletask",
+						State:             api.MaintenanceManifestStatePending,
+						RunAfter:          1,
+						RunBefore:         1,
+						Windows: []api.MaintenanceWindow{
+							{
+								DayOfWeek:     "Saturday",
+								StartHour:     2,
+								DurationHours: 4,
+							},
+						},
+					},
+				})
+			},
+			wantResponse: &admin.MaintenanceManifest{
+				ID:                "07070707-0707-0707-0707-070707070001",
+				MaintenanceTaskID: "exampletask",
+				State:             admin.MaintenanceManifestStatePending,
+				RunAfter:          1,
+				RunBefore:         1,
+				Windows: []admin.MaintenanceWindow{
+					{
+						DayOfWeek:     "Saturday",
+						StartHour:     2,
+						DurationHours: 4,
+					},
+				},
+			},
+			wantStatusCode: http.StatusCreated,
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			now := func() time.Time { return time.Unix(1000, 0) }
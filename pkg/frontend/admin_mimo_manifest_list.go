@@ -7,7 +7,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"math"
 	"net/http"
 	"strconv"
 
@@ -35,8 +34,8 @@ func (f *frontend) getAdminMaintManifests(w http.ResponseWriter, r *http.Request
 func (f *frontend) _getAdminMaintManifests(ctx context.Context, r *http.Request, resourceID string) ([]byte, error) {
 	limitstr := r.URL.Query().Get("limit")
 	limit, err := strconv.Atoi(limitstr)
-	if err != nil {
-		limit = 100
+	if err != nil || limit <= 0 || limit > maxPageSize {
+		limit = maxPageSize
 	}
 
 	converter := f.apis[admin.APIVersion].MaintenanceManifestConverter
@@ -72,7 +71,7 @@ func (f *frontend) _getAdminMaintManifests(ctx context.Context, r *http.Request,
 
 	docList := make([]*api.MaintenanceManifestDocument, 0)
 	for {
-		docs, err := i.Next(ctx, int(math.Min(float64(limit), 10)))
+		docs, err := i.Next(ctx, limit-len(docList))
 		if err != nil {
 			return nil, api.NewCloudError(http.StatusInternalServerError, api.CloudErrorCodeInternalServerError, "", fmt.Errorf("failed reading next manifest document: %w", err).Error())
 		}
@@ -7,7 +7,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"math"
 	"net/http"
 	"strconv"
 
@@ -34,8 +33,8 @@ func (f *frontend) getAdminQueuedMaintManifests(w http.ResponseWriter, r *http.R
 func (f *frontend) _getAdminQueuedMaintManifests(ctx context.Context, r *http.Request) ([]byte, error) {
 	limitstr := r.URL.Query().Get("limit")
 	limit, err := strconv.Atoi(limitstr)
-	if err != nil {
-		limit = 100
+	if err != nil || limit <= 0 || limit > maxPageSize {
+		limit = maxPageSize
 	}
 
 	converter := f.apis[admin.APIVersion].MaintenanceManifestConverter
@@ -57,7 +56,7 @@ func (f *frontend) _getAdminQueuedMaintManifests(ctx context.Context, r *http.Re
 
 	docList := make([]*api.MaintenanceManifestDocument, 0)
 	for {
-		docs, err := i.Next(ctx, int(math.Min(float64(limit), 10)))
+		docs, err := i.Next(ctx, limit-len(docList))
 		if err != nil {
 			return nil, api.NewCloudError(http.StatusInternalServerError, api.CloudErrorCodeInternalServerError, "", fmt.Errorf("failed reading next manifest document: %w", err).Error())
 		}
@@ -0,0 +1,161 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	mock_adminactions "github.com/Azure/ARO-RP/pkg/util/mocks/adminactions"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminControlPlaneUpgrade(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	mockTenantID := "00000000-0000-0000-0000-000000000000"
+
+	ctx := context.Background()
+
+	fixture := func(f *testdatabase.Fixture) {
+		f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+			Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+				Properties: api.OpenShiftClusterProperties{
+					ClusterProfile: api.ClusterProfile{
+						ResourceGroupID: fmt.Sprintf("/subscriptions/%s/resourceGroups/test-cluster", mockSubID),
+					},
+				},
+			},
+		})
+
+		f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+			ID: mockSubID,
+			Subscription: &api.Subscription{
+				State: api.SubscriptionStateRegistered,
+				Properties: &api.SubscriptionProperties{
+					TenantID: mockTenantID,
+				},
+			},
+		})
+	}
+
+	type test struct {
+		name           string
+		resourceID     string
+		action         string
+		fixture        func(*testdatabase.Fixture)
+		mocks          func(*mock_adminactions.MockKubeActions)
+		wantStatusCode int
+		wantResponse   []byte
+		wantError      string
+	}
+
+	for _, tt := range []*test{
+		{
+			name:       "control plane upgrade",
+			resourceID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			action:     "controlplaneupgrade?version=4.15.20",
+			fixture:    fixture,
+			mocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().UpgradeControlPlane(gomock.Any(), "4.15.20").Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "control plane upgrade with invalid version",
+			resourceID:     testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			action:         "controlplaneupgrade?version=latest",
+			fixture:        fixture,
+			mocks:          func(k *mock_adminactions.MockKubeActions) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: version: The provided version 'latest' is invalid.",
+		},
+		{
+			name:       "control plane upgrade rejected by cluster",
+			resourceID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			action:     "controlplaneupgrade?version=4.16.1",
+			fixture:    fixture,
+			mocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().UpgradeControlPlane(gomock.Any(), "4.16.1").
+					Return(api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "version", "Version '4.16.1' is not an available update."))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: version: Version '4.16.1' is not an available update.",
+		},
+		{
+			name:       "control plane upgrade on missing cluster",
+			resourceID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			action:     "controlplaneupgrade?version=4.15.20",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+						Properties: &api.SubscriptionProperties{
+							TenantID: mockTenantID,
+						},
+					},
+				})
+			},
+			mocks:          func(k *mock_adminactions.MockKubeActions) {},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: ResourceNotFound: : The Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was not found.",
+		},
+		{
+			name:       "resume worker rollout",
+			resourceID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			action:     "resumeworkerrollout",
+			fixture:    fixture,
+			mocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().ResumeWorkerRollout(gomock.Any()).Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			defer ti.done()
+
+			k := mock_adminactions.NewMockKubeActions(ti.controller)
+			tt.mocks(k)
+
+			err := ti.buildFixtures(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.dbGroup, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, func(*logrus.Entry, env.Interface, *api.OpenShiftCluster) (adminactions.KubeActions, error) {
+				return k, nil
+			}, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodPost,
+				fmt.Sprintf("https://server/admin%s/%s", tt.resourceID, tt.action),
+				nil, nil)
+			if err != nil {
+				t.Error(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
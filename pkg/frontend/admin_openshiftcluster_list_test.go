@@ -5,8 +5,10 @@ package frontend
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strings"
 	"testing"
@@ -26,6 +28,7 @@ func TestAdminListOpenShiftCluster(t *testing.T) {
 
 	type test struct {
 		name           string
+		top            string
 		wantEnriched   []string
 		throwsError    error
 		fixture        func(*testdatabase.Fixture)
@@ -95,6 +98,34 @@ func TestAdminListOpenShiftCluster(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "clusters are paged with $top",
+			fixture: func(f *testdatabase.Fixture) {
+				for _, resourceName := range []string{"resourceName1", "resourceName2"} {
+					f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+						Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, resourceName)),
+						OpenShiftCluster: &api.OpenShiftCluster{
+							ID:   testdatabase.GetResourcePath(mockSubID, resourceName),
+							Name: resourceName,
+							Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+						},
+					})
+				}
+			},
+			top:            "1",
+			wantEnriched:   []string{testdatabase.GetResourcePath(mockSubID, "resourceName1")},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.OpenShiftClusterList{
+				OpenShiftClusters: []*admin.OpenShiftCluster{
+					{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName1"),
+						Name: "resourceName1",
+						Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+					},
+				},
+				NextLink: "https://mockrefererhost/?%24skipToken=" + url.QueryEscape(base64.StdEncoding.EncodeToString([]byte("FAKE1"))),
+			},
+		},
 		{
 			name:           "no clusters found in db",
 			wantStatusCode: http.StatusOK,
@@ -132,7 +163,7 @@ func TestAdminListOpenShiftCluster(t *testing.T) {
 			go f.Run(ctx, nil, nil)
 
 			resp, b, err := ti.request(http.MethodGet,
-				"https://server/admin/providers/Microsoft.RedHatOpenShift/openShiftClusters",
+				"https://server/admin/providers/Microsoft.RedHatOpenShift/openShiftClusters?%24top="+tt.top,
 				http.Header{
 					"Referer": []string{"https://mockrefererhost/"},
 				}, nil)
@@ -0,0 +1,89 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+)
+
+func (f *frontend) postAdminOpenShiftClusterRetryBootstrap(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	err := f._postAdminOpenShiftClusterRetryBootstrap(ctx, r, log)
+
+	adminReply(log, w, nil, nil, err)
+}
+
+// _postAdminOpenShiftClusterRetryBootstrap requeues a cluster whose
+// installation failed during the bootstrap phase.  The backend then re-deploys
+// the bootstrap resources only, reusing the existing control plane resources
+// and graph instead of requiring the cluster to be deleted and recreated.
+func (f *frontend) _postAdminOpenShiftClusterRetryBootstrap(ctx context.Context, r *http.Request, log *logrus.Entry) error {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	dbOpenShiftClusters, err := f.dbGroup.OpenShiftClusters()
+	if err != nil {
+		return err
+	}
+
+	doc, err := dbOpenShiftClusters.Get(ctx, resourceID)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "", "The Resource '%s/%s' under resource group '%s' was not found.", resType, resName, resGroupName)
+	case err != nil:
+		return err
+	}
+
+	_, err = dbOpenShiftClusters.Patch(ctx, doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		err := validateRetryBootstrap(doc.OpenShiftCluster)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("requeueing bootstrap of cluster %s", doc.OpenShiftCluster.ID)
+
+		doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateCreating
+		doc.OpenShiftCluster.Properties.FailedProvisioningState = ""
+		// refresh the install time, which is used for the SAS token with
+		// which the bootstrap node retrieves its ignition payload
+		doc.OpenShiftCluster.Properties.Install.Now = f.now().UTC()
+		doc.OpenShiftCluster.Properties.Install.RetryBootstrap = true
+		doc.Dequeues = 0
+
+		return nil
+	})
+
+	return err
+}
+
+func validateRetryBootstrap(oc *api.OpenShiftCluster) error {
+	if oc.Properties.ProvisioningState != api.ProvisioningStateFailed ||
+		oc.Properties.FailedProvisioningState != api.ProvisioningStateCreating {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeRequestNotAllowed, "", "Retrying bootstrap is only allowed on a cluster whose creation failed.")
+	}
+
+	if oc.Properties.Install == nil || oc.Properties.Install.Phase != api.InstallPhaseBootstrap {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeRequestNotAllowed, "", "Retrying bootstrap is only allowed on a cluster whose creation failed during the bootstrap phase.")
+	}
+
+	if oc.Properties.HiveProfile.CreatedByHive {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeRequestNotAllowed, "", "Retrying bootstrap is not supported on clusters installed via Hive.")
+	}
+
+	return nil
+}
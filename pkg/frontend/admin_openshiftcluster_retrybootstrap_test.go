@@ -0,0 +1,141 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminRetryBootstrap(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+	failedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	ctx := context.Background()
+
+	clusterDoc := func(provisioningState, failedProvisioningState api.ProvisioningState, install *api.Install, dequeues int) *api.OpenShiftClusterDocument {
+		return &api.OpenShiftClusterDocument{
+			Key:      strings.ToLower(resourceID),
+			Dequeues: dequeues,
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID: resourceID,
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState:       provisioningState,
+					FailedProvisioningState: failedProvisioningState,
+					Install:                 install,
+				},
+			},
+		}
+	}
+
+	type test struct {
+		name           string
+		fixture        func(*testdatabase.Fixture)
+		wantDocuments  func(*testdatabase.Checker)
+		wantStatusCode int
+		wantError      string
+	}
+
+	for _, tt := range []*test{
+		{
+			name: "requeues bootstrap of a cluster which failed during the bootstrap phase",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateFailed, api.ProvisioningStateCreating, &api.Install{Now: failedAt, Phase: api.InstallPhaseBootstrap}, 3))
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateCreating, "", &api.Install{Now: now, Phase: api.InstallPhaseBootstrap, RetryBootstrap: true}, 0))
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "rejects a cluster which failed after the bootstrap phase",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateFailed, api.ProvisioningStateCreating, &api.Install{Now: failedAt, Phase: api.InstallPhaseRemoveBootstrap}, 3))
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateFailed, api.ProvisioningStateCreating, &api.Install{Now: failedAt, Phase: api.InstallPhaseRemoveBootstrap}, 3))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: RequestNotAllowed: : Retrying bootstrap is only allowed on a cluster whose creation failed during the bootstrap phase.",
+		},
+		{
+			name: "rejects a cluster whose creation did not fail",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateCreating, "", &api.Install{Now: failedAt, Phase: api.InstallPhaseBootstrap}, 0))
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(clusterDoc(api.ProvisioningStateCreating, "", &api.Install{Now: failedAt, Phase: api.InstallPhaseBootstrap}, 0))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: RequestNotAllowed: : Retrying bootstrap is only allowed on a cluster whose creation failed.",
+		},
+		{
+			name: "rejects a cluster installed via Hive",
+			fixture: func(f *testdatabase.Fixture) {
+				doc := clusterDoc(api.ProvisioningStateFailed, api.ProvisioningStateCreating, &api.Install{Now: failedAt, Phase: api.InstallPhaseBootstrap}, 3)
+				doc.OpenShiftCluster.Properties.HiveProfile.CreatedByHive = true
+				f.AddOpenShiftClusterDocuments(doc)
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				doc := clusterDoc(api.ProvisioningStateFailed, api.ProvisioningStateCreating, &api.Install{Now: failedAt, Phase: api.InstallPhaseBootstrap}, 3)
+				doc.OpenShiftCluster.Properties.HiveProfile.CreatedByHive = true
+				c.AddOpenShiftClusterDocuments(doc)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: RequestNotAllowed: : Retrying bootstrap is not supported on clusters installed via Hive.",
+		},
+		{
+			name:           "cluster not found",
+			fixture:        func(f *testdatabase.Fixture) {},
+			wantDocuments:  func(c *testdatabase.Checker) {},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: ResourceNotFound: : The Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was not found.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			defer ti.done()
+
+			err := ti.buildFixtures(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.dbGroup, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.now = func() time.Time { return now }
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodPost,
+				fmt.Sprintf("https://server/admin%s/retrybootstrap", resourceID),
+				nil, nil)
+			if err != nil {
+				t.Error(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, nil)
+			if err != nil {
+				t.Error(err)
+			}
+
+			tt.wantDocuments(ti.checker)
+			for _, err := range ti.checker.CheckOpenShiftClusters(ti.openShiftClustersClient) {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+)
+
+func (f *frontend) postAdminOpenShiftVersionEnable(w http.ResponseWriter, r *http.Request) {
+	f.postAdminOpenShiftVersionEnabled(w, r, true)
+}
+
+func (f *frontend) postAdminOpenShiftVersionDisable(w http.ResponseWriter, r *http.Request) {
+	f.postAdminOpenShiftVersionEnabled(w, r, false)
+}
+
+func (f *frontend) postAdminOpenShiftVersionEnabled(w http.ResponseWriter, r *http.Request, enabled bool) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+
+	b, err := f._postAdminOpenShiftVersionEnabled(ctx, r, enabled)
+
+	adminReply(log, w, nil, b, err)
+}
+
+// _postAdminOpenShiftVersionEnabled enables or disables the version given by
+// the version query parameter.  If a location is given, the version is
+// enabled or disabled in that region only; it must also be enabled globally
+// to be installable there.  Enabling a version globally verifies its release
+// payload as a PUT does.
+func (f *frontend) _postAdminOpenShiftVersionEnabled(ctx context.Context, r *http.Request, enabled bool) ([]byte, error) {
+	version := r.URL.Query().Get("version")
+	location := strings.ToLower(r.URL.Query().Get("location"))
+
+	if version == "" {
+		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "version", "Must be provided")
+	}
+
+	dbOpenShiftVersions, err := f.dbGroup.OpenShiftVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := dbOpenShiftVersions.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var id string
+	for _, doc := range docs.OpenShiftVersionDocuments {
+		if doc.OpenShiftVersion.Properties.Version == version {
+			id = doc.ID
+			break
+		}
+	}
+	if id == "" {
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeNotFound, "version", "The OpenShift version '%s' was not found.", version)
+	}
+
+	doc, err := dbOpenShiftVersions.Patch(ctx, id, func(doc *api.OpenShiftVersionDocument) error {
+		props := &doc.OpenShiftVersion.Properties
+
+		// prevent disabling of the default installation version
+		if props.Default && !enabled {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "version", "You cannot disable the default installation version.")
+		}
+
+		switch {
+		case location == "":
+			current := *props
+			props.Enabled = enabled
+
+			err := f.onboardOpenShiftVersion(ctx, &current, doc.OpenShiftVersion)
+			if err != nil {
+				return err
+			}
+		case enabled:
+			props.DisabledLocations = slices.DeleteFunc(props.DisabledLocations, func(l string) bool { return l == location })
+			if len(props.DisabledLocations) == 0 {
+				props.DisabledLocations = nil
+			}
+		case !slices.Contains(props.DisabledLocations, location):
+			props.DisabledLocations = append(props.DisabledLocations, location)
+			slices.Sort(props.DisabledLocations)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(f.apis[admin.APIVersion].OpenShiftVersionConverter.ToExternal(doc.OpenShiftVersion), "", "    ")
+}
@@ -0,0 +1,206 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestOpenShiftVersionEnable(t *testing.T) {
+	ctx := context.Background()
+
+	fixture := func(f *testdatabase.Fixture) {
+		f.AddOpenShiftVersionDocuments(
+			&api.OpenShiftVersionDocument{
+				OpenShiftVersion: &api.OpenShiftVersion{
+					Properties: api.OpenShiftVersionProperties{
+						Version:           "4.10.0",
+						Enabled:           true,
+						Default:           true,
+						OpenShiftPullspec: "a:a/b",
+						InstallerPullspec: "b:b/c",
+					},
+				},
+			},
+			&api.OpenShiftVersionDocument{
+				OpenShiftVersion: &api.OpenShiftVersion{
+					Properties: api.OpenShiftVersionProperties{
+						Version:           "4.11.0",
+						Enabled:           true,
+						OpenShiftPullspec: "c:c/d",
+						InstallerPullspec: "d:d/e",
+						DisabledLocations: []string{"westus"},
+					},
+				},
+			},
+		)
+	}
+
+	defaultVersion := &api.OpenShiftVersionDocument{
+		ID: "07070707-0707-0707-0707-070707070001",
+		OpenShiftVersion: &api.OpenShiftVersion{
+			Properties: api.OpenShiftVersionProperties{
+				Version:           "4.10.0",
+				Enabled:           true,
+				Default:           true,
+				OpenShiftPullspec: "a:a/b",
+				InstallerPullspec: "b:b/c",
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name           string
+		url            string
+		wantStatusCode int
+		wantResponse   *admin.OpenShiftVersion
+		wantError      string
+		wantDocuments  []*api.OpenShiftVersionDocument
+	}{
+		{
+			name:           "disable version",
+			url:            "https://server/admin/versions/disable?version=4.11.0",
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.11.0",
+					Enabled:           false,
+					OpenShiftPullspec: "c:c/d",
+					InstallerPullspec: "d:d/e",
+					DisabledLocations: []string{"westus"},
+				},
+			},
+			wantDocuments: []*api.OpenShiftVersionDocument{
+				defaultVersion,
+				{
+					ID: "07070707-0707-0707-0707-070707070002",
+					OpenShiftVersion: &api.OpenShiftVersion{
+						Properties: api.OpenShiftVersionProperties{
+							Version:           "4.11.0",
+							Enabled:           false,
+							OpenShiftPullspec: "c:c/d",
+							InstallerPullspec: "d:d/e",
+							DisabledLocations: []string{"westus"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:           "disable version in a location",
+			url:            "https://server/admin/versions/disable?version=4.11.0&location=EastUS",
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.11.0",
+					Enabled:           true,
+					OpenShiftPullspec: "c:c/d",
+					InstallerPullspec: "d:d/e",
+					DisabledLocations: []string{"eastus", "westus"},
+				},
+			},
+			wantDocuments: []*api.OpenShiftVersionDocument{
+				defaultVersion,
+				{
+					ID: "07070707-0707-0707-0707-070707070002",
+					OpenShiftVersion: &api.OpenShiftVersion{
+						Properties: api.OpenShiftVersionProperties{
+							Version:           "4.11.0",
+							Enabled:           true,
+							OpenShiftPullspec: "c:c/d",
+							InstallerPullspec: "d:d/e",
+							DisabledLocations: []string{"eastus", "westus"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:           "enable version in a location",
+			url:            "https://server/admin/versions/enable?version=4.11.0&location=westus",
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.11.0",
+					Enabled:           true,
+					OpenShiftPullspec: "c:c/d",
+					InstallerPullspec: "d:d/e",
+				},
+			},
+			wantDocuments: []*api.OpenShiftVersionDocument{
+				defaultVersion,
+				{
+					ID: "07070707-0707-0707-0707-070707070002",
+					OpenShiftVersion: &api.OpenShiftVersion{
+						Properties: api.OpenShiftVersionProperties{
+							Version:           "4.11.0",
+							Enabled:           true,
+							OpenShiftPullspec: "c:c/d",
+							InstallerPullspec: "d:d/e",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:           "can not disable default install version",
+			url:            "https://server/admin/versions/disable?version=4.10.0&location=eastus",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: version: You cannot disable the default installation version.",
+		},
+		{
+			name:           "unknown version",
+			url:            "https://server/admin/versions/enable?version=4.12.0",
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: NotFound: version: The OpenShift version '4.12.0' was not found.",
+		},
+		{
+			name:           "version must be provided",
+			url:            "https://server/admin/versions/enable",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: version: Must be provided",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftVersions()
+			defer ti.done()
+
+			err := ti.buildFixtures(fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.dbGroup, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodPost, tt.url, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if tt.wantDocuments != nil {
+				ti.checker.AddOpenShiftVersionDocuments(tt.wantDocuments...)
+				for _, err := range ti.checker.CheckOpenShiftVersions(ti.openShiftVersionsClient) {
+					t.Error(err)
+				}
+			}
+		})
+	}
+}
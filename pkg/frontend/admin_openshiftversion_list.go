@@ -48,6 +48,12 @@ func (f *frontend) getAdminOpenShiftVersions(w http.ResponseWriter, r *http.Requ
 		return semver.New(vers[i].Properties.Version).LessThan(*semver.New(vers[j].Properties.Version))
 	})
 
-	b, err := json.MarshalIndent(converter.ToExternalList(vers), "", "    ")
+	vers, nextLink, err := page(f, r, vers)
+	if err != nil {
+		adminReply(log, w, nil, nil, err)
+		return
+	}
+
+	b, err := json.MarshalIndent(converter.ToExternalList(vers, nextLink), "", "    ")
 	adminReply(log, w, nil, b, err)
 }
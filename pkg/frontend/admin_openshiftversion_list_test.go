@@ -5,7 +5,10 @@ package frontend
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
 	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/Azure/ARO-RP/pkg/api"
@@ -100,6 +103,36 @@ func TestOpenShiftVersionList(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "the first page of versions is returned without $top",
+			fixture: func(f *testdatabase.Fixture) {
+				for i := 0; i < defaultPageSize+2; i++ {
+					f.AddOpenShiftVersionDocuments(&api.OpenShiftVersionDocument{
+						OpenShiftVersion: &api.OpenShiftVersion{
+							Properties: api.OpenShiftVersionProperties{
+								Version: fmt.Sprintf("4.10.%d", i),
+								Enabled: true,
+							},
+						},
+					})
+				}
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: func() *admin.OpenShiftVersionList {
+				l := &admin.OpenShiftVersionList{
+					NextLink: "https://localhost:8443/admin/versions?%24skipToken=" + url.QueryEscape(base64.StdEncoding.EncodeToString([]byte("FAKE10"))),
+				}
+				for i := 0; i < defaultPageSize; i++ {
+					l.OpenShiftVersions = append(l.OpenShiftVersions, &admin.OpenShiftVersion{
+						Properties: admin.OpenShiftVersionProperties{
+							Version: fmt.Sprintf("4.10.%d", i),
+							Enabled: true,
+						},
+					})
+				}
+				return l
+			}(),
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			ti := newTestInfra(t).WithOpenShiftVersions()
@@ -110,7 +143,7 @@ func TestOpenShiftVersionList(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.dbGroup, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil, nil)
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.dbGroup, api.APIs, &noop.Noop{}, &noop.Noop{}, testdatabase.NewFakeAEAD(), nil, nil, nil, nil, nil)
 
 			if err != nil {
 				t.Fatal(err)
@@ -63,6 +63,7 @@ func (f *frontend) putAdminOpenShiftVersion(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
+	var current *api.OpenShiftVersionProperties
 	isCreate := versionDoc == nil
 	if isCreate {
 		err = staticValidator.Static(ext, nil)
@@ -71,6 +72,8 @@ func (f *frontend) putAdminOpenShiftVersion(w http.ResponseWriter, r *http.Reque
 			OpenShiftVersion: &api.OpenShiftVersion{},
 		}
 	} else {
+		props := versionDoc.OpenShiftVersion.Properties
+		current = &props
 		err = staticValidator.Static(ext, versionDoc.OpenShiftVersion)
 	}
 	if err != nil {
@@ -80,6 +83,12 @@ func (f *frontend) putAdminOpenShiftVersion(w http.ResponseWriter, r *http.Reque
 
 	converter.ToInternal(ext, versionDoc.OpenShiftVersion)
 
+	err = f.onboardOpenShiftVersion(ctx, current, versionDoc.OpenShiftVersion)
+	if err != nil {
+		adminReply(log, w, nil, []byte{}, err)
+		return
+	}
+
 	if isCreate {
 		versionDoc, err = dbOpenShiftVersions.Create(ctx, versionDoc)
 		if err != nil {
@@ -5,8 +5,10 @@ package frontend
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/api/admin"
@@ -14,12 +16,28 @@ import (
 	testdatabase "github.com/Azure/ARO-RP/test/database"
 )
 
+type fakeReleaseVerifier struct {
+	digest string
+	err    error
+}
+
+func (v *fakeReleaseVerifier) Verify(ctx context.Context, digest string) error {
+	v.digest = digest
+	return v.err
+}
+
 func TestOpenShiftVersionPut(t *testing.T) {
 	ctx := context.Background()
 
+	enabledAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	previouslyEnabledAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
 	type test struct {
 		name           string
 		fixture        func(f *testdatabase.Fixture)
+		verifier       *fakeReleaseVerifier
+		wantDigest     string
 		body           *admin.OpenShiftVersion
 		wantStatusCode int
 		wantResponse   *admin.OpenShiftVersion
@@ -104,6 +122,8 @@ func TestOpenShiftVersionPut(t *testing.T) {
 					Enabled:           true,
 					OpenShiftPullspec: "f:f/g",
 					InstallerPullspec: "g:g/h",
+					EnabledBy:         "admin@example.com",
+					EnabledAt:         &enabledAt,
 				},
 			},
 			wantDocuments: []*api.OpenShiftVersionDocument{
@@ -125,11 +145,250 @@ func TestOpenShiftVersionPut(t *testing.T) {
 							Enabled:           true,
 							OpenShiftPullspec: "f:f/g",
 							InstallerPullspec: "g:g/h",
+							EnabledBy:         "admin@example.com",
+							EnabledAt:         &enabledAt,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "creating new version verifies the release signature",
+			fixture:  func(f *testdatabase.Fixture) {},
+			verifier: &fakeReleaseVerifier{},
+			body: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.10.1",
+					Enabled:           true,
+					OpenShiftPullspec: "quay.io/openshift-release-dev/ocp-release@" + digest,
+					InstallerPullspec: "g:g/h",
+				},
+			},
+			wantDigest:     digest,
+			wantStatusCode: http.StatusCreated,
+			wantResponse: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.10.1",
+					Enabled:           true,
+					OpenShiftPullspec: "quay.io/openshift-release-dev/ocp-release@" + digest,
+					InstallerPullspec: "g:g/h",
+					EnabledBy:         "admin@example.com",
+					EnabledAt:         &enabledAt,
+				},
+			},
+			wantDocuments: []*api.OpenShiftVersionDocument{
+				{
+					ID: "07070707-0707-0707-0707-070707070001",
+					OpenShiftVersion: &api.OpenShiftVersion{
+						Properties: api.OpenShiftVersionProperties{
+							Version:           "4.10.1",
+							Enabled:           true,
+							OpenShiftPullspec: "quay.io/openshift-release-dev/ocp-release@" + digest,
+							InstallerPullspec: "g:g/h",
+							EnabledBy:         "admin@example.com",
+							EnabledAt:         &enabledAt,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "enabled release image must be referenced by digest",
+			fixture:  func(f *testdatabase.Fixture) {},
+			verifier: &fakeReleaseVerifier{},
+			body: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.10.1",
+					Enabled:           true,
+					OpenShiftPullspec: "quay.io/openshift-release-dev/ocp-release:4.10.1-x86_64",
+					InstallerPullspec: "g:g/h",
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: properties.openShiftPullspec: The release image must be referenced by digest.",
+			wantDocuments:  []*api.OpenShiftVersionDocument{},
+		},
+		{
+			name:     "enabled multi-architecture release image must be referenced by digest",
+			fixture:  func(f *testdatabase.Fixture) {},
+			verifier: &fakeReleaseVerifier{},
+			body: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.10.1",
+					Enabled:           true,
+					OpenShiftPullspec: "quay.io/openshift-release-dev/ocp-release@" + digest,
+					MultiArchPullspec: "quay.io/openshift-release-dev/ocp-release:4.10.1-multi",
+					InstallerPullspec: "g:g/h",
+				},
+			},
+			wantDigest:     digest,
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: properties.multiArchPullspec: The release image must be referenced by digest.",
+			wantDocuments:  []*api.OpenShiftVersionDocument{},
+		},
+		{
+			name:     "enabled release image must have a valid signature",
+			fixture:  func(f *testdatabase.Fixture) {},
+			verifier: &fakeReleaseVerifier{err: errors.New("no valid signature found for " + digest)},
+			body: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.10.1",
+					Enabled:           true,
+					OpenShiftPullspec: "quay.io/openshift-release-dev/ocp-release@" + digest,
+					InstallerPullspec: "g:g/h",
+				},
+			},
+			wantDigest:     digest,
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: properties.openShiftPullspec: The release image signature could not be verified: no valid signature found for " + digest + ".",
+			wantDocuments:  []*api.OpenShiftVersionDocument{},
+		},
+		{
+			name: "updating enabled version without changing its release keeps its enablement",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftVersionDocuments(
+					&api.OpenShiftVersionDocument{
+						OpenShiftVersion: &api.OpenShiftVersion{
+							Properties: api.OpenShiftVersionProperties{
+								Version:           "4.10.0",
+								Enabled:           true,
+								OpenShiftPullspec: "a:a/b",
+								InstallerPullspec: "b:b/c",
+								EnabledBy:         "someone@example.com",
+								EnabledAt:         &previouslyEnabledAt,
+							},
+						},
+					},
+				)
+			},
+			verifier: &fakeReleaseVerifier{},
+			body: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.10.0",
+					Enabled:           true,
+					OpenShiftPullspec: "a:a/b",
+					InstallerPullspec: "d:d/e",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.10.0",
+					Enabled:           true,
+					OpenShiftPullspec: "a:a/b",
+					InstallerPullspec: "d:d/e",
+					EnabledBy:         "someone@example.com",
+					EnabledAt:         &previouslyEnabledAt,
+				},
+			},
+			wantDocuments: []*api.OpenShiftVersionDocument{
+				{
+					ID: "07070707-0707-0707-0707-070707070001",
+					OpenShiftVersion: &api.OpenShiftVersion{
+						Properties: api.OpenShiftVersionProperties{
+							Version:           "4.10.0",
+							Enabled:           true,
+							OpenShiftPullspec: "a:a/b",
+							InstallerPullspec: "d:d/e",
+							EnabledBy:         "someone@example.com",
+							EnabledAt:         &previouslyEnabledAt,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "updating known version sets its lifecycle dates",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftVersionDocuments(
+					&api.OpenShiftVersionDocument{
+						OpenShiftVersion: &api.OpenShiftVersion{
+							Properties: api.OpenShiftVersionProperties{
+								Version:           "4.10.0",
+								OpenShiftPullspec: "a:a/b",
+								InstallerPullspec: "d:d/e",
+							},
+						},
+					},
+				)
+			},
+			body: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.10.0",
+					OpenShiftPullspec: "a:a/b",
+					InstallerPullspec: "d:d/e",
+					GADate:            "2022-03-10",
+					EndOfSupportDate:  "2023-09-10",
+					EndOfLifeDate:     "2023-09-10",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.10.0",
+					OpenShiftPullspec: "a:a/b",
+					InstallerPullspec: "d:d/e",
+					GADate:            "2022-03-10",
+					EndOfSupportDate:  "2023-09-10",
+					EndOfLifeDate:     "2023-09-10",
+				},
+			},
+			wantDocuments: []*api.OpenShiftVersionDocument{
+				{
+					ID: "07070707-0707-0707-0707-070707070001",
+					OpenShiftVersion: &api.OpenShiftVersion{
+						Properties: api.OpenShiftVersionProperties{
+							Version:           "4.10.0",
+							OpenShiftPullspec: "a:a/b",
+							InstallerPullspec: "d:d/e",
+							GADate:            "2022-03-10",
+							EndOfSupportDate:  "2023-09-10",
+							EndOfLifeDate:     "2023-09-10",
 						},
 					},
 				},
 			},
 		},
+		{
+			name: "lifecycle dates must be dates",
+			body: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.10.0",
+					OpenShiftPullspec: "a:a/b",
+					InstallerPullspec: "d:d/e",
+					GADate:            "10/03/2022",
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: properties.gaDate: Must be a date in YYYY-MM-DD format",
+		},
+		{
+			name: "required feature must be a Microsoft.RedHatOpenShift feature",
+			body: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.10.0",
+					OpenShiftPullspec: "a:a/b",
+					InstallerPullspec: "d:d/e",
+					RequiredFeature:   "PreviewVersions",
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: properties.requiredFeature: Must be a Microsoft.RedHatOpenShift feature",
+		},
+		{
+			name: "lifecycle dates must be in order",
+			body: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.10.0",
+					OpenShiftPullspec: "a:a/b",
+					InstallerPullspec: "d:d/e",
+					GADate:            "2022-03-10",
+					EndOfLifeDate:     "2021-03-10",
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: properties.endOfLifeDate: Must not be before the previous lifecycle date",
+		},
 		{
 			name: "updating known version requires installer pullspec",
 			fixture: func(f *testdatabase.Fixture) {
@@ -270,12 +529,17 @@ func TestOpenShiftVersionPut(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
+			f.now = func() time.Time { return enabledAt }
+			if tt.verifier != nil {
+				f.releaseVerifier = tt.verifier
+			}
 
 			go f.Run(ctx, nil, nil)
 
 			resp, b, err := ti.request(http.MethodPut, "https://server/admin/versions",
 				http.Header{
-					"Content-Type": []string{"application/json"},
+					"Content-Type":               []string{"application/json"},
+					"X-Ms-Client-Principal-Name": []string{"admin@example.com"},
 				}, tt.body)
 			if err != nil {
 				t.Fatal(err)
@@ -286,6 +550,10 @@ func TestOpenShiftVersionPut(t *testing.T) {
 				t.Error(err)
 			}
 
+			if tt.verifier != nil && tt.verifier.digest != tt.wantDigest {
+				t.Error(tt.verifier.digest)
+			}
+
 			if tt.wantDocuments != nil {
 				ti.checker.AddOpenShiftVersionDocuments(tt.wantDocuments...)
 				for _, err := range ti.checker.CheckOpenShiftVersions(ti.openShiftVersionsClient) {
@@ -48,6 +48,12 @@ func (f *frontend) getAdminPlatformWorkloadIdentityRoleSets(w http.ResponseWrite
 		return version.CreateSemverFromMinorVersionString(roleSets[i].Properties.OpenShiftVersion).LessThan(*version.CreateSemverFromMinorVersionString(roleSets[j].Properties.OpenShiftVersion))
 	})
 
-	b, err := json.MarshalIndent(converter.ToExternalList(roleSets), "", "    ")
+	roleSets, nextLink, err := page(f, r, roleSets)
+	if err != nil {
+		adminReply(log, w, nil, nil, err)
+		return
+	}
+
+	b, err := json.MarshalIndent(converter.ToExternalList(roleSets, nextLink), "", "    ")
 	adminReply(log, w, nil, b, err)
 }
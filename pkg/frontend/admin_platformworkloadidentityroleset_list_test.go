@@ -5,8 +5,11 @@ package frontend
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"sort"
 	"testing"
 
@@ -232,6 +235,34 @@ func TestPlatformWorkloadIdentityRoleSetList(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "GET request without $top returns the first page of role sets",
+			fixture: func(f *testdatabase.Fixture) {
+				for i := 0; i < defaultPageSize+2; i++ {
+					f.AddPlatformWorkloadIdentityRoleSetDocuments(&api.PlatformWorkloadIdentityRoleSetDocument{
+						PlatformWorkloadIdentityRoleSet: &api.PlatformWorkloadIdentityRoleSet{
+							Properties: api.PlatformWorkloadIdentityRoleSetProperties{
+								OpenShiftVersion: fmt.Sprintf("4.%d", i),
+							},
+						},
+					})
+				}
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: func() *admin.PlatformWorkloadIdentityRoleSetList {
+				l := &admin.PlatformWorkloadIdentityRoleSetList{
+					NextLink: "https://localhost:8443/admin/platformworkloadidentityrolesets?%24skipToken=" + url.QueryEscape(base64.StdEncoding.EncodeToString([]byte("FAKE10"))),
+				}
+				for i := 0; i < defaultPageSize; i++ {
+					l.PlatformWorkloadIdentityRoleSets = append(l.PlatformWorkloadIdentityRoleSets, &admin.PlatformWorkloadIdentityRoleSet{
+						Properties: admin.PlatformWorkloadIdentityRoleSetProperties{
+							OpenShiftVersion: fmt.Sprintf("4.%d", i),
+						},
+					})
+				}
+				return l
+			}(),
+		},
 		{
 			name:    "GET request results in StatusInternalServerError due to issues with Cosmos DB",
 			fixture: func(f *testdatabase.Fixture) {},
@@ -258,7 +289,7 @@ func TestPlatformWorkloadIdentityRoleSetList(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.dbGroup, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil, nil)
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.dbGroup, api.APIs, &noop.Noop{}, &noop.Noop{}, testdatabase.NewFakeAEAD(), nil, nil, nil, nil, nil)
 
 			if err != nil {
 				t.Fatal(err)
@@ -0,0 +1,98 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+)
+
+// putAdminSupportedVMSize adds or updates a VM size which clusters may use in
+// addition to the VM sizes built into the RP, so that newly supported SKUs
+// don't need an RP release
+func (f *frontend) putAdminSupportedVMSize(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	converter := f.apis[admin.APIVersion].SupportedVMSizeConverter
+	staticValidator := f.apis[admin.APIVersion].SupportedVMSizeStaticValidator
+
+	body := r.Context().Value(middleware.ContextKeyBody).([]byte)
+	if len(body) == 0 || !json.Valid(body) {
+		api.WriteError(w, http.StatusBadRequest, api.CloudErrorCodeInvalidRequestContent, "", "The request content was invalid and could not be deserialized.")
+		return
+	}
+
+	var ext *admin.SupportedVMSize
+	err := json.Unmarshal(body, &ext)
+	if err != nil {
+		api.WriteError(w, http.StatusBadRequest, api.CloudErrorCodeInvalidRequestContent, "", "The request content could not be deserialized: "+err.Error())
+		return
+	}
+
+	dbSupportedVMSizes, err := f.dbGroup.SupportedVMSizes()
+	if err != nil {
+		api.WriteError(w, http.StatusInternalServerError, api.CloudErrorCodeInternalServerError, "", err.Error())
+		return
+	}
+
+	docs, err := dbSupportedVMSizes.ListAll(ctx)
+	if err != nil {
+		api.WriteError(w, http.StatusInternalServerError, api.CloudErrorCodeInternalServerError, "", "Internal server error.")
+		return
+	}
+
+	var vmSizeDoc *api.SupportedVMSizeDocument
+	if docs != nil {
+		for _, doc := range docs.SupportedVMSizeDocuments {
+			if doc.SupportedVMSize.Properties.VMSize == api.VMSize(ext.Properties.VMSize) {
+				vmSizeDoc = doc
+				break
+			}
+		}
+	}
+
+	isCreate := vmSizeDoc == nil
+	if isCreate {
+		err = staticValidator.Static(ext, nil)
+		vmSizeDoc = &api.SupportedVMSizeDocument{
+			ID:              dbSupportedVMSizes.NewUUID(),
+			SupportedVMSize: &api.SupportedVMSize{},
+		}
+	} else {
+		err = staticValidator.Static(ext, vmSizeDoc.SupportedVMSize)
+	}
+	if err != nil {
+		adminReply(log, w, nil, []byte{}, err)
+		return
+	}
+
+	converter.ToInternal(ext, vmSizeDoc.SupportedVMSize)
+
+	if isCreate {
+		vmSizeDoc, err = dbSupportedVMSizes.Create(ctx, vmSizeDoc)
+	} else {
+		vmSizeDoc, err = dbSupportedVMSizes.Update(ctx, vmSizeDoc)
+	}
+	if err != nil {
+		api.WriteError(w, http.StatusInternalServerError, api.CloudErrorCodeInternalServerError, "", "Internal server error.")
+		return
+	}
+
+	b, err := json.MarshalIndent(converter.ToExternal(vmSizeDoc.SupportedVMSize), "", "    ")
+	if err == nil {
+		if isCreate {
+			err = statusCodeError(http.StatusCreated)
+		}
+	}
+	adminReply(log, w, nil, b, err)
+}
@@ -0,0 +1,175 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestSupportedVMSizePut(t *testing.T) {
+	ctx := context.Background()
+
+	type test struct {
+		name           string
+		fixture        func(f *testdatabase.Fixture)
+		body           *admin.SupportedVMSize
+		wantStatusCode int
+		wantResponse   *admin.SupportedVMSize
+		wantError      string
+		wantDocuments  []*api.SupportedVMSizeDocument
+	}
+
+	for _, tt := range []*test{
+		{
+			name: "PUT to create a new entry results in StatusCreated",
+			body: &admin.SupportedVMSize{
+				Properties: admin.SupportedVMSizeProperties{
+					VMSize:    "Standard_D8s_v6",
+					CoreCount: 8,
+					Family:    "standardDSv6Family",
+					Roles:     []string{"worker"},
+				},
+			},
+			wantStatusCode: http.StatusCreated,
+			wantResponse: &admin.SupportedVMSize{
+				Properties: admin.SupportedVMSizeProperties{
+					VMSize:    "Standard_D8s_v6",
+					CoreCount: 8,
+					Family:    "standardDSv6Family",
+					Roles:     []string{"worker"},
+				},
+			},
+			wantDocuments: []*api.SupportedVMSizeDocument{
+				{
+					ID: "09090909-0909-0909-0909-090909090001",
+					SupportedVMSize: &api.SupportedVMSize{
+						Properties: api.SupportedVMSizeProperties{
+							VMSize:    "Standard_D8s_v6",
+							CoreCount: 8,
+							Family:    "standardDSv6Family",
+							Roles:     []string{"worker"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PUT to update an existing entry updates it in-place and results in StatusOK",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSupportedVMSizeDocuments(
+					&api.SupportedVMSizeDocument{
+						SupportedVMSize: &api.SupportedVMSize{
+							Properties: api.SupportedVMSizeProperties{
+								VMSize:    "Standard_D8s_v6",
+								CoreCount: 8,
+								Family:    "standardDSv6Family",
+								Roles:     []string{"worker"},
+							},
+						},
+					},
+				)
+			},
+			body: &admin.SupportedVMSize{
+				Properties: admin.SupportedVMSizeProperties{
+					VMSize:    "Standard_D8s_v6",
+					CoreCount: 8,
+					Family:    "standardDSv6Family",
+					Roles:     []string{"master", "worker"},
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.SupportedVMSize{
+				Properties: admin.SupportedVMSizeProperties{
+					VMSize:    "Standard_D8s_v6",
+					CoreCount: 8,
+					Family:    "standardDSv6Family",
+					Roles:     []string{"master", "worker"},
+				},
+			},
+			wantDocuments: []*api.SupportedVMSizeDocument{
+				{
+					ID: "09090909-0909-0909-0909-090909090001",
+					SupportedVMSize: &api.SupportedVMSize{
+						Properties: api.SupportedVMSizeProperties{
+							VMSize:    "Standard_D8s_v6",
+							CoreCount: 8,
+							Family:    "standardDSv6Family",
+							Roles:     []string{"master", "worker"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PUT with an invalid role results in StatusBadRequest",
+			body: &admin.SupportedVMSize{
+				Properties: admin.SupportedVMSizeProperties{
+					VMSize:    "Standard_D8s_v6",
+					CoreCount: 8,
+					Family:    "standardDSv6Family",
+					Roles:     []string{"infra"},
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: properties.roles[0]: Must be either 'master' or 'worker'",
+		},
+		{
+			name: "PUT without a core count results in StatusBadRequest",
+			body: &admin.SupportedVMSize{
+				Properties: admin.SupportedVMSizeProperties{
+					VMSize: "Standard_D8s_v6",
+					Family: "standardDSv6Family",
+					Roles:  []string{"worker"},
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: properties.coreCount: Must be greater than zero",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithSupportedVMSizes()
+
+			defer ti.done()
+
+			err := ti.buildFixtures(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.dbGroup, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodPut, "https://server/admin/supportedvmsizes",
+				http.Header{
+					"Content-Type": []string{"application/json"},
+				}, tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if tt.wantDocuments != nil {
+				ti.checker.AddSupportedVMSizeDocuments(tt.wantDocuments...)
+				for _, err := range ti.checker.CheckSupportedVMSizes(ti.supportedVMSizesClient) {
+					t.Error(err)
+				}
+			}
+		})
+	}
+}
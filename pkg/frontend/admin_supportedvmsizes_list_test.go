@@ -15,8 +15,8 @@ import (
 )
 
 func TestSupportedvmsizes(t *testing.T) {
-	mastervmsizes := validate.SupportedVMSizesByRole(validate.VMRoleMaster)
-	workervmsizes := validate.SupportedVMSizesByRole(validate.VMRoleWorker)
+	mastervmsizes := validate.SupportedVMSizesByRole(validate.VMRoleMaster, nil)
+	workervmsizes := validate.SupportedVMSizesByRole(validate.VMRoleWorker, nil)
 
 	type test struct {
 		name         string
@@ -26,7 +26,7 @@ func (f *frontend) supportedVMSizesForRole(vmRole string) ([]byte, error) {
 	if vmRole != validate.VMRoleMaster && vmRole != validate.VMRoleWorker {
 		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "", "The provided vmRole '%s' is invalid. vmRole can only be master or worker", vmRole)
 	}
-	vmsizes := validate.SupportedVMSizesByRole(vmRole)
+	vmsizes := validate.SupportedVMSizesByRole(vmRole, f.getAdditionalVMSizes())
 	b, err := json.MarshalIndent(vmsizes, "", "    ")
 	if err != nil {
 		return b, err
@@ -8,6 +8,8 @@ import (
 	"net/http"
 
 	"github.com/Azure/go-autorest/autorest/to"
+	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	mcoclient "github.com/openshift/machine-config-operator/pkg/generated/clientset/versioned"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -37,6 +39,8 @@ type KubeActions interface {
 	ApproveCsr(ctx context.Context, csrName string) error
 	ApproveAllCsrs(ctx context.Context) error
 	KubeGetPodLogs(ctx context.Context, namespace, name, containerName string) ([]byte, error)
+	UpgradeControlPlane(ctx context.Context, version string) error
+	ResumeWorkerRollout(ctx context.Context) error
 	// kubeWatch returns a watch object for the provided label selector key
 	KubeWatch(ctx context.Context, o *unstructured.Unstructured, label string) (watch.Interface, error)
 }
@@ -47,8 +51,10 @@ type kubeActions struct {
 
 	mapper meta.RESTMapper
 
-	dyn     dynamic.Interface
-	kubecli kubernetes.Interface
+	dyn       dynamic.Interface
+	kubecli   kubernetes.Interface
+	configcli configclient.Interface
+	mcocli    mcoclient.Interface
 }
 
 // NewKubeActions returns a kubeActions
@@ -73,14 +79,26 @@ func NewKubeActions(log *logrus.Entry, env env.Interface, oc *api.OpenShiftClust
 		return nil, err
 	}
 
+	configcli, err := configclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	mcocli, err := mcoclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	return &kubeActions{
 		log: log,
 		oc:  oc,
 
 		mapper: mapper,
 
-		dyn:     dyn,
-		kubecli: kubecli,
+		dyn:       dyn,
+		kubecli:   kubecli,
+		configcli: configcli,
+		mcocli:    mcocli,
 	}, nil
 }
 
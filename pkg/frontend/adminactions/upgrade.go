@@ -0,0 +1,106 @@
+package adminactions
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+
+	configv1 "github.com/openshift/api/config/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utilversion "github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+const masterMachineConfigPool = "master"
+
+// UpgradeControlPlane pauses every worker MachineConfigPool and then starts an
+// upgrade to the given version, so that only the control plane is upgraded
+// until ResumeWorkerRollout is called.  This matches the EUS-to-EUS upgrade
+// procedure.
+func (k *kubeActions) UpgradeControlPlane(ctx context.Context, version string) error {
+	cv, err := k.configcli.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if utilversion.IsClusterUpgrading(cv) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeRequestNotAllowed, "", "An upgrade is already in progress.")
+	}
+
+	var target *configv1.Release
+	for i := range cv.Status.AvailableUpdates {
+		if cv.Status.AvailableUpdates[i].Version == version {
+			target = &cv.Status.AvailableUpdates[i]
+			break
+		}
+	}
+
+	if target == nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "version", "Version '%s' is not an available update.", version)
+	}
+
+	err = k.setWorkerMachineConfigPoolsPaused(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cv, err := k.configcli.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		cv.Spec.DesiredUpdate = &configv1.Update{
+			Version: target.Version,
+			Image:   target.Image,
+		}
+
+		_, err = k.configcli.ConfigV1().ClusterVersions().Update(ctx, cv, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// ResumeWorkerRollout unpauses the worker MachineConfigPools paused by
+// UpgradeControlPlane, letting the workers pick up the new release.
+func (k *kubeActions) ResumeWorkerRollout(ctx context.Context) error {
+	return k.setWorkerMachineConfigPoolsPaused(ctx, false)
+}
+
+func (k *kubeActions) setWorkerMachineConfigPoolsPaused(ctx context.Context, paused bool) error {
+	mcps, err := k.mcocli.MachineconfigurationV1().MachineConfigPools().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	for _, mcp := range mcps.Items {
+		if mcp.Name == masterMachineConfigPool {
+			continue
+		}
+
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			mcp, err := k.mcocli.MachineconfigurationV1().MachineConfigPools().Get(ctx, mcp.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+
+			if mcp.Spec.Paused == paused {
+				return nil
+			}
+
+			k.log.Infof("setting MachineConfigPool %s paused=%t", mcp.Name, paused)
+			mcp.Spec.Paused = paused
+
+			_, err = k.mcocli.MachineconfigurationV1().MachineConfigPools().Update(ctx, mcp, metav1.UpdateOptions{})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
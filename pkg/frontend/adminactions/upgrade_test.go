@@ -0,0 +1,160 @@
+package adminactions
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	configfake "github.com/openshift/client-go/config/clientset/versioned/fake"
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	mcofake "github.com/openshift/machine-config-operator/pkg/generated/clientset/versioned/fake"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestUpgradeControlPlane(t *testing.T) {
+	ctx := context.Background()
+
+	clusterVersion := func(progressing configv1.ConditionStatus) *configv1.ClusterVersion {
+		return &configv1.ClusterVersion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "version",
+			},
+			Status: configv1.ClusterVersionStatus{
+				Conditions: []configv1.ClusterOperatorStatusCondition{
+					{
+						Type:   configv1.OperatorProgressing,
+						Status: progressing,
+					},
+				},
+				AvailableUpdates: []configv1.Release{
+					{
+						Version: "4.15.20",
+						Image:   "quay.io/openshift-release-dev/ocp-release@sha256:4.15.20",
+					},
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name        string
+		cv          *configv1.ClusterVersion
+		version     string
+		wantDesired *configv1.Update
+		wantPaused  map[string]bool
+		wantErr     string
+	}{
+		{
+			name:    "pauses worker pools and upgrades control plane",
+			cv:      clusterVersion(configv1.ConditionFalse),
+			version: "4.15.20",
+			wantDesired: &configv1.Update{
+				Version: "4.15.20",
+				Image:   "quay.io/openshift-release-dev/ocp-release@sha256:4.15.20",
+			},
+			wantPaused: map[string]bool{
+				"master": false,
+				"worker": true,
+				"infra":  true,
+			},
+		},
+		{
+			name:    "version not available",
+			cv:      clusterVersion(configv1.ConditionFalse),
+			version: "4.16.1",
+			wantPaused: map[string]bool{
+				"master": false,
+				"worker": false,
+				"infra":  false,
+			},
+			wantErr: "400: InvalidParameter: version: Version '4.16.1' is not an available update.",
+		},
+		{
+			name:    "upgrade in progress",
+			cv:      clusterVersion(configv1.ConditionTrue),
+			version: "4.15.20",
+			wantPaused: map[string]bool{
+				"master": false,
+				"worker": false,
+				"infra":  false,
+			},
+			wantErr: "400: RequestNotAllowed: : An upgrade is already in progress.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &kubeActions{
+				log:       logrus.NewEntry(logrus.StandardLogger()),
+				configcli: configfake.NewSimpleClientset(tt.cv),
+				mcocli: mcofake.NewSimpleClientset(
+					&mcv1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "master"}},
+					&mcv1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}},
+					&mcv1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "infra"}},
+				),
+			}
+
+			err := k.UpgradeControlPlane(ctx, tt.version)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			cv, err := k.configcli.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(cv.Spec.DesiredUpdate, tt.wantDesired) {
+				t.Errorf("got desired update %v, wanted %v", cv.Spec.DesiredUpdate, tt.wantDesired)
+			}
+
+			mcps, err := k.mcocli.MachineconfigurationV1().MachineConfigPools().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, mcp := range mcps.Items {
+				if mcp.Spec.Paused != tt.wantPaused[mcp.Name] {
+					t.Errorf("MachineConfigPool %s: got paused %t, wanted %t", mcp.Name, mcp.Spec.Paused, tt.wantPaused[mcp.Name])
+				}
+			}
+		})
+	}
+}
+
+func TestResumeWorkerRollout(t *testing.T) {
+	ctx := context.Background()
+
+	k := &kubeActions{
+		log: logrus.NewEntry(logrus.StandardLogger()),
+		mcocli: mcofake.NewSimpleClientset(
+			&mcv1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "master"}, Spec: mcv1.MachineConfigPoolSpec{Paused: true}},
+			&mcv1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}, Spec: mcv1.MachineConfigPoolSpec{Paused: true}},
+		),
+	}
+
+	err := k.ResumeWorkerRollout(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the master pool is left alone
+	wantPaused := map[string]bool{
+		"master": true,
+		"worker": false,
+	}
+
+	mcps, err := k.mcocli.MachineconfigurationV1().MachineConfigPools().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mcp := range mcps.Items {
+		if mcp.Spec.Paused != wantPaused[mcp.Name] {
+			t.Errorf("MachineConfigPool %s: got paused %t, wanted %t", mcp.Name, mcp.Spec.Paused, wantPaused[mcp.Name])
+		}
+	}
+}
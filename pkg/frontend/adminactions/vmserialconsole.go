@@ -18,3 +18,10 @@ func (a *azureActions) VMSerialConsole(ctx context.Context,
 
 	return a.virtualMachines.GetSerialConsoleForVM(ctx, clusterRGName, vmName, target)
 }
+
+func (a *azureActions) VMScreenshot(ctx context.Context,
+	log *logrus.Entry, vmName string, target io.Writer) error {
+	clusterRGName := stringutils.LastTokenByte(a.oc.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	return a.virtualMachines.GetScreenshotForVM(ctx, clusterRGName, vmName, target)
+}
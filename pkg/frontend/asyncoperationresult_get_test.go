@@ -66,9 +66,10 @@ func TestGetAsyncOperationResult(t *testing.T) {
 			},
 			wantStatusCode: http.StatusOK,
 			wantResponse: &v20200430.OpenShiftCluster{
-				ID:   testdatabase.GetResourcePath(mockSubID, "fakeClusterID"),
-				Name: "resourceName",
-				Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+				ID:         testdatabase.GetResourcePath(mockSubID, "fakeClusterID"),
+				Name:       "resourceName",
+				Type:       "Microsoft.RedHatOpenShift/openshiftClusters",
+				SystemData: &v20200430.SystemData{},
 				Properties: v20200430.OpenShiftClusterProperties{
 					ServicePrincipalProfile: &v20200430.ServicePrincipalProfile{},
 				},
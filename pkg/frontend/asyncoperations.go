@@ -28,6 +28,7 @@ func (f *frontend) newAsyncOperation(ctx context.Context, subId, resourceProvide
 			ProvisioningState:        doc.OpenShiftCluster.Properties.ProvisioningState,
 			StartTime:                time.Now().UTC(),
 		},
+		CorrelationData: api.GetCorrelationDataFromCtx(ctx),
 	})
 	if err != nil {
 		return "", err
@@ -45,6 +45,22 @@ func (f *frontend) changefeedRoleSets(ctx context.Context) {
 	f.updateFromIteratorRoleSets(ctx, t, roleSetsIterator)
 }
 
+func (f *frontend) changefeedSupportedVMSizes(ctx context.Context) {
+	defer recover.Panic(f.baseLog)
+
+	dbSupportedVMSizes, err := f.dbGroup.SupportedVMSizes()
+	if err != nil {
+		return
+	}
+
+	supportedVMSizesIterator := dbSupportedVMSizes.ChangeFeed()
+
+	t := time.NewTicker(10 * time.Second)
+	defer t.Stop()
+
+	f.updateFromIteratorSupportedVMSizes(ctx, t, supportedVMSizesIterator)
+}
+
 func (f *frontend) updateFromIteratorOcpVersions(ctx context.Context, ticker *time.Ticker, frontendIterator cosmosdb.OpenShiftVersionDocumentIterator) {
 	for {
 		successful := true
@@ -75,13 +91,14 @@ func (f *frontend) updateFromIteratorOcpVersions(ctx context.Context, ticker *ti
 	}
 }
 
-// updateOcpVersions adds enabled versions to the frontend cache
+// updateOcpVersions adds versions enabled in the RP's location to the frontend
+// cache
 func (f *frontend) updateOcpVersions(docs []*api.OpenShiftVersionDocument) {
 	f.ocpVersionsMu.Lock()
 	defer f.ocpVersionsMu.Unlock()
 
 	for _, doc := range docs {
-		if doc.OpenShiftVersion.Deleting || !doc.OpenShiftVersion.Properties.Enabled {
+		if doc.OpenShiftVersion.Deleting || !doc.OpenShiftVersion.IsEnabledIn(f.location) {
 			// https://docs.microsoft.com/en-us/azure/cosmos-db/change-feed-design-patterns#deletes
 			delete(f.enabledOcpVersions, doc.OpenShiftVersion.Properties.Version)
 		} else {
@@ -136,3 +153,63 @@ func (f *frontend) updatePlatformWorkloadIdentityRoleSets(docs []*api.PlatformWo
 		}
 	}
 }
+
+func (f *frontend) updateFromIteratorSupportedVMSizes(ctx context.Context, ticker *time.Ticker, frontendIterator cosmosdb.SupportedVMSizeDocumentIterator) {
+	for {
+		successful := true
+
+		for {
+			docs, err := frontendIterator.Next(ctx, -1)
+			if err != nil {
+				successful = false
+				f.baseLog.Error(err)
+				break
+			}
+			if docs == nil {
+				break
+			}
+
+			f.updateSupportedVMSizes(docs.SupportedVMSizeDocuments)
+		}
+
+		if successful {
+			f.lastSupportedVMSizesChangefeed.Store(time.Now())
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// updateSupportedVMSizes updates the frontend cache of VM sizes supported in
+// addition to the ones built into the RP
+func (f *frontend) updateSupportedVMSizes(docs []*api.SupportedVMSizeDocument) {
+	f.supportedVMSizesMu.Lock()
+	defer f.supportedVMSizesMu.Unlock()
+
+	for _, doc := range docs {
+		if doc.SupportedVMSize.Deleting {
+			// https://docs.microsoft.com/en-us/azure/cosmos-db/change-feed-design-patterns#deletes
+			delete(f.supportedVMSizes, doc.SupportedVMSize.Properties.VMSize)
+		} else {
+			f.supportedVMSizes[doc.SupportedVMSize.Properties.VMSize] = doc.SupportedVMSize
+		}
+	}
+}
+
+// getAdditionalVMSizes returns the VM sizes which are supported in addition to
+// the ones built into the RP, for validation
+func (f *frontend) getAdditionalVMSizes() []*api.SupportedVMSize {
+	f.supportedVMSizesMu.RLock()
+	defer f.supportedVMSizesMu.RUnlock()
+
+	vmSizes := make([]*api.SupportedVMSize, 0, len(f.supportedVMSizes))
+	for _, vmSize := range f.supportedVMSizes {
+		vmSizes = append(vmSizes, vmSize)
+	}
+
+	return vmSizes
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/validate"
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	"github.com/Azure/ARO-RP/pkg/util/cmp"
 )
@@ -17,6 +18,7 @@ import (
 func TestUpdateFromIteratorOcpVersions(t *testing.T) {
 	for _, tt := range []struct {
 		name           string
+		location       string
 		docsInIterator []*api.OpenShiftVersionDocument
 		versions       map[string]*api.OpenShiftVersion
 		wantVersions   map[string]*api.OpenShiftVersion
@@ -173,12 +175,54 @@ func TestUpdateFromIteratorOcpVersions(t *testing.T) {
 			},
 			wantVersions: map[string]*api.OpenShiftVersion{},
 		},
+		{
+			name:     "A doc present in the frontend cache is disabled in the RP's location - remove it from the cache",
+			location: "eastus",
+			docsInIterator: []*api.OpenShiftVersionDocument{
+				{
+					OpenShiftVersion: &api.OpenShiftVersion{
+						Properties: api.OpenShiftVersionProperties{
+							Version:           "4.5.6",
+							Enabled:           true,
+							DisabledLocations: []string{"eastus"},
+						},
+					},
+				},
+				{
+					OpenShiftVersion: &api.OpenShiftVersion{
+						Properties: api.OpenShiftVersionProperties{
+							Version:           "4.5.7",
+							Enabled:           true,
+							DisabledLocations: []string{"westus"},
+						},
+					},
+				},
+			},
+			versions: map[string]*api.OpenShiftVersion{
+				"4.5.6": {
+					Properties: api.OpenShiftVersionProperties{
+						Version: "4.5.6",
+						Enabled: true,
+					},
+				},
+			},
+			wantVersions: map[string]*api.OpenShiftVersion{
+				"4.5.7": {
+					Properties: api.OpenShiftVersionProperties{
+						Version:           "4.5.7",
+						Enabled:           true,
+						DisabledLocations: []string{"westus"},
+					},
+				},
+			},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			ticker := time.NewTicker(20 * time.Millisecond)
 			ctx, cancel := context.WithCancel(context.TODO())
 
 			frontend := frontend{
+				location:           tt.location,
 				enabledOcpVersions: tt.versions,
 			}
 
@@ -536,3 +580,74 @@ func TestUpdateFromIteratorRoleSets(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateFromIteratorSupportedVMSizes(t *testing.T) {
+	d8sv6 := &api.SupportedVMSize{
+		Properties: api.SupportedVMSizeProperties{
+			VMSize:    "Standard_D8s_v6",
+			CoreCount: 8,
+			Family:    "standardDSv6Family",
+			Roles:     []string{validate.VMRoleMaster, validate.VMRoleWorker},
+		},
+	}
+
+	for _, tt := range []struct {
+		name                  string
+		docsInIterator        []*api.SupportedVMSizeDocument
+		vmSizes               map[api.VMSize]*api.SupportedVMSize
+		wantVMSizes           map[api.VMSize]*api.SupportedVMSize
+		wantSupportedAsWorker bool
+	}{
+		{
+			name: "add to empty",
+			docsInIterator: []*api.SupportedVMSizeDocument{
+				{
+					SupportedVMSize: d8sv6,
+				},
+			},
+			vmSizes: map[api.VMSize]*api.SupportedVMSize{},
+			wantVMSizes: map[api.VMSize]*api.SupportedVMSize{
+				"Standard_D8s_v6": d8sv6,
+			},
+			wantSupportedAsWorker: true,
+		},
+		{
+			name: "delete",
+			docsInIterator: []*api.SupportedVMSizeDocument{
+				{
+					SupportedVMSize: &api.SupportedVMSize{
+						Deleting:   true,
+						Properties: d8sv6.Properties,
+					},
+				},
+			},
+			vmSizes: map[api.VMSize]*api.SupportedVMSize{
+				"Standard_D8s_v6": d8sv6,
+			},
+			wantVMSizes: map[api.VMSize]*api.SupportedVMSize{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ticker := time.NewTicker(20 * time.Millisecond)
+			ctx, cancel := context.WithCancel(context.TODO())
+
+			frontend := frontend{
+				supportedVMSizes: tt.vmSizes,
+			}
+
+			fakeIterator := cosmosdb.NewFakeSupportedVMSizeDocumentIterator(tt.docsInIterator, 0)
+
+			go frontend.updateFromIteratorSupportedVMSizes(ctx, ticker, fakeIterator)
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+
+			if !reflect.DeepEqual(frontend.supportedVMSizes, tt.wantVMSizes) {
+				t.Error(cmp.Diff(frontend.supportedVMSizes, tt.wantVMSizes))
+			}
+
+			if _, ok := validate.SupportedVMSizesByRole(validate.VMRoleWorker, frontend.getAdditionalVMSizes())["Standard_D8s_v6"]; ok != tt.wantSupportedAsWorker {
+				t.Errorf("got supported as worker %v, want %v", ok, tt.wantSupportedAsWorker)
+			}
+		})
+	}
+}
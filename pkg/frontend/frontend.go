@@ -8,8 +8,10 @@ import (
 	"crypto/tls"
 	"fmt"
 	"log"
+	"math"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -50,6 +52,7 @@ type frontendDBs interface {
 	database.DatabaseGroupWithAsyncOperations
 	database.DatabaseGroupWithSubscriptions
 	database.DatabaseGroupWithPlatformWorkloadIdentityRoleSets
+	database.DatabaseGroupWithSupportedVMSizes
 	database.DatabaseGroupWithMaintenanceManifests
 }
 
@@ -72,15 +75,19 @@ type frontend struct {
 
 	dbGroup frontendDBs
 
+	location                                  string // the region this RP serves, lowercase
 	defaultOcpVersion                         string // always enabled
 	enabledOcpVersions                        map[string]*api.OpenShiftVersion
 	availablePlatformWorkloadIdentityRoleSets map[string]*api.PlatformWorkloadIdentityRoleSet
+	supportedVMSizes                          map[api.VMSize]*api.SupportedVMSize
 	apis                                      map[string]*api.Version
 
 	lastOcpVersionsChangefeed                      atomic.Value //time.Time
 	lastPlatformWorkloadIdentityRoleSetsChangefeed atomic.Value
+	lastSupportedVMSizesChangefeed                 atomic.Value
 	ocpVersionsMu                                  sync.RWMutex
 	platformWorkloadIdentityRoleSetsMu             sync.RWMutex
+	supportedVMSizesMu                             sync.RWMutex
 
 	aead encryption.AEAD
 
@@ -95,6 +102,8 @@ type frontend struct {
 
 	clusterEnricher clusterdata.BestEffortEnricher
 
+	releaseVerifier releaseVerifier
+
 	l net.Listener
 	s *http.Server
 
@@ -170,8 +179,10 @@ func NewFrontend(ctx context.Context,
 
 		clusterEnricher: enricher,
 
-		enabledOcpVersions:                        map[string]*api.OpenShiftVersion{},
+		location:           strings.ToLower(_env.Location()),
+		enabledOcpVersions: map[string]*api.OpenShiftVersion{},
 		availablePlatformWorkloadIdentityRoleSets: map[string]*api.PlatformWorkloadIdentityRoleSet{},
+		supportedVMSizes: map[api.VMSize]*api.SupportedVMSize{},
 
 		bucketAllocator: &bucket.Random{},
 
@@ -183,6 +194,12 @@ func NewFrontend(ctx context.Context,
 		streamResponder: defaultResponder{},
 	}
 
+	var err error
+	f.releaseVerifier, err = newReleaseVerifier()
+	if err != nil {
+		return nil, err
+	}
+
 	l, err := f.env.Listen()
 	if err != nil {
 		return nil, err
@@ -246,6 +263,8 @@ func (f *frontend) chiAuthenticatedRoutes(router chi.Router) {
 					r.Post("/listcredentials", f.postOpenShiftClusterCredentials)
 
 					r.Post("/listadmincredentials", f.postOpenShiftClusterKubeConfigCredentials)
+
+					r.Get("/federatedidentitycredentials", f.getOpenShiftClusterFederatedIdentityCredentials)
 				})
 
 				r.Get("/detectors", f.listAppLensDetectors)
@@ -254,6 +273,10 @@ func (f *frontend) chiAuthenticatedRoutes(router chi.Router) {
 			})
 		})
 
+		r.Post("/resourcegroups/{resourceGroupName}/validatemoveresources", f.postValidateMoveResources)
+
+		r.Post("/resourcegroups/{resourceGroupName}/moveresources", f.postMoveResources)
+
 		r.Route("/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/deployments/{deploymentName}/preflight", func(r chi.Router) {
 			r.Use(f.apiVersionMiddleware.ValidatePreflightAPIVersion)
 			r.Post("/", f.preflightValidation)
@@ -282,12 +305,15 @@ func (f *frontend) chiAuthenticatedRoutes(router chi.Router) {
 		r.Route("/versions", func(r chi.Router) {
 			r.Get("/", f.getAdminOpenShiftVersions)
 			r.Put("/", f.putAdminOpenShiftVersion)
+			r.Post("/enable", f.postAdminOpenShiftVersionEnable)
+			r.Post("/disable", f.postAdminOpenShiftVersionDisable)
 		})
 		r.Route("/platformworkloadidentityrolesets", func(r chi.Router) {
 			r.Get("/", f.getAdminPlatformWorkloadIdentityRoleSets)
 			r.Put("/", f.putAdminPlatformWorkloadIdentityRoleSet)
 		})
 		r.Get("/supportedvmsizes", f.supportedvmsizes)
+		r.Put("/supportedvmsizes", f.putAdminSupportedVMSize)
 
 		r.Route("/maintenancemanifests", func(r chi.Router) {
 			r.Get("/queued", f.getAdminQueuedMaintManifests)
@@ -312,7 +338,10 @@ func (f *frontend) chiAuthenticatedRoutes(router chi.Router) {
 
 				r.Get("/serialconsole", f.getAdminOpenShiftClusterSerialConsole)
 
+				r.Get("/screenshot", f.getAdminOpenShiftClusterScreenshot)
+
 				r.Get("/clusterdeployment", f.getAdminHiveClusterDeployment)
+				r.Get("/hiveprovisionlogs", f.getAdminHiveProvisionLogs)
 
 				r.With(f.maintenanceMiddleware.UnplannedMaintenanceSignal).Post("/redeployvm", f.postAdminOpenShiftClusterRedeployVM)
 
@@ -334,6 +363,11 @@ func (f *frontend) chiAuthenticatedRoutes(router chi.Router) {
 				r.With(f.maintenanceMiddleware.UnplannedMaintenanceSignal).Post("/etcdcertificaterenew", f.postAdminOpenShiftClusterEtcdCertificateRenew)
 				r.With(f.maintenanceMiddleware.UnplannedMaintenanceSignal).Post("/deletemanagedresource", f.postAdminOpenShiftDeleteManagedResource)
 
+				r.With(f.maintenanceMiddleware.UnplannedMaintenanceSignal).Post("/controlplaneupgrade", f.postAdminOpenShiftClusterControlPlaneUpgrade)
+				r.With(f.maintenanceMiddleware.UnplannedMaintenanceSignal).Post("/resumeworkerrollout", f.postAdminOpenShiftClusterResumeWorkerRollout)
+
+				r.Post("/retrybootstrap", f.postAdminOpenShiftClusterRetryBootstrap)
+
 				// MIMO
 				r.Route("/maintenancemanifests", func(r chi.Router) {
 					r.Get("/", f.getAdminMaintManifests)
@@ -371,6 +405,7 @@ func (f *frontend) setupRouter() chi.Router {
 	chiRouter.NotFound(f.authMiddleware.Authenticate(http.HandlerFunc(notFound)).ServeHTTP)
 	registered := chiRouter.With(
 		chiMiddlewares.CleanPath,
+		middleware.Tracing,
 		f.logMiddleware.Log,
 		f.m.Metrics,
 		middleware.Panic,
@@ -388,6 +423,7 @@ func (f *frontend) Run(ctx context.Context, stop <-chan struct{}, done chan<- st
 	defer recover.Panic(f.baseLog)
 	go f.changefeedOcpVersions(ctx)
 	go f.changefeedRoleSets(ctx)
+	go f.changefeedSupportedVMSizes(ctx)
 
 	if stop != nil {
 		go func() {
@@ -465,6 +501,13 @@ func reply(log *logrus.Entry, w http.ResponseWriter, header http.Header, b []byt
 		case statusCodeError:
 			w.WriteHeader(int(err))
 		default:
+			if retryAfter, ok := database.IsThrottled(err); ok {
+				log.Warn(err)
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				api.WriteError(w, http.StatusTooManyRequests, api.CloudErrorCodeThrottlingLimitExceeded, "", "The request is being throttled, please retry later.")
+				return
+			}
+
 			log.Error(err)
 			api.WriteError(w, http.StatusInternalServerError, api.CloudErrorCodeInternalServerError, "", "Internal server error.")
 			return
@@ -7,12 +7,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/onsi/gomega"
@@ -23,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/env"
 	utillog "github.com/Azure/ARO-RP/pkg/util/log"
 	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
@@ -115,6 +118,28 @@ func TestAdminReply(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "throttled error",
+			// reply sets Retry-After itself; it is listed here because the
+			// test compares the full response header
+			header: http.Header{
+				"Retry-After": []string{"2"},
+			},
+			err:            fmt.Errorf("wrapped: %w", &database.ThrottledError{RetryAfter: 1500 * time.Millisecond}),
+			wantStatusCode: http.StatusTooManyRequests,
+			wantBody: map[string]interface{}{
+				"error": map[string]interface{}{
+					"code":    api.CloudErrorCodeThrottlingLimitExceeded,
+					"message": "The request is being throttled, please retry later.",
+				},
+			},
+			wantEntries: []map[string]types.GomegaMatcher{
+				{
+					"level": gomega.Equal(logrus.WarnLevel),
+					"msg":   gomega.Equal(`wrapped: request throttled by Cosmos DB, retry after 1.5s`),
+				},
+			},
+		},
 		{
 			name: "normal output",
 			header: http.Header{
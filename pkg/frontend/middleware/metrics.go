@@ -4,6 +4,7 @@ package middleware
 // Licensed under the Apache License 2.0.
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 	"strings"
@@ -15,10 +16,31 @@ import (
 	"github.com/Azure/ARO-RP/pkg/metrics"
 )
 
+// operationPrefixes are stripped from route patterns to give the operation
+// which a request is for, e.g. PUT /{resourceType}/{resourceName}
+var operationPrefixes = []string{
+	"/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}",
+	"/subscriptions/{subscriptionId}/providers/{resourceProviderNamespace}",
+}
+
 type MetricsMiddleware struct {
 	metrics.Emitter
 }
 
+// metricsDimensions holds dimensions which are only known once a request has
+// been handled
+type metricsDimensions struct {
+	subscriptionTier string
+}
+
+// SetSubscriptionTier records the tier of the subscription which a request is
+// for, so that the request's metrics can be dimensioned by it
+func SetSubscriptionTier(ctx context.Context, tier string) {
+	if d, ok := ctx.Value(ContextKeyMetricsDimensions).(*metricsDimensions); ok {
+		d.subscriptionTier = tier
+	}
+}
+
 // Metric records request metrics for tracking
 func (mm MetricsMiddleware) Metrics(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -27,23 +49,79 @@ func (mm MetricsMiddleware) Metrics(h http.Handler) http.Handler {
 
 		w = &logResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
+		d := &metricsDimensions{subscriptionTier: "Unknown"}
+		r = r.WithContext(context.WithValue(r.Context(), ContextKeyMetricsDimensions, d))
+
 		h.ServeHTTP(w, r)
 
+		duration := time.Since(t).Milliseconds()
+		statusCode := w.(*logResponseWriter).statusCode
+
 		//get the route pattern that matched
 		rctx := chi.RouteContext(r.Context())
 		routePattern := strings.Join(rctx.RoutePatterns, "")
 		mm.EmitGauge("frontend.count", 1, map[string]string{
 			"verb":        r.Method,
 			"api-version": apiVersion,
-			"code":        strconv.Itoa(w.(*logResponseWriter).statusCode),
+			"code":        strconv.Itoa(statusCode),
 			"route":       routePattern,
 		})
 
-		mm.EmitGauge("frontend.duration", time.Since(t).Milliseconds(), map[string]string{
+		mm.EmitGauge("frontend.duration", duration, map[string]string{
 			"verb":        r.Method,
 			"api-version": apiVersion,
-			"code":        strconv.Itoa(w.(*logResponseWriter).statusCode),
+			"code":        strconv.Itoa(statusCode),
 			"route":       routePattern,
 		})
+
+		dimensions := map[string]string{
+			"api-version":      apiVersion,
+			"operation":        operation(r.Method, rctx.RoutePattern()),
+			"subscriptionTier": d.subscriptionTier,
+		}
+
+		mm.EmitGauge("frontend.api.latency", duration, dimensions)
+
+		if errorClass := errorClass(statusCode); errorClass != "" {
+			dimensions["errorClass"] = errorClass
+			mm.EmitGauge("frontend.api.errors", 1, dimensions)
+		}
 	})
 }
+
+// operation returns the method and the route pattern of a request relative to
+// the resource provider, so that the same operation at resource group and
+// subscription scope is counted together
+func operation(method, routePattern string) string {
+	for _, prefix := range operationPrefixes {
+		if strings.HasPrefix(routePattern, prefix) {
+			routePattern = strings.TrimPrefix(routePattern, prefix)
+			break
+		}
+		if strings.HasPrefix(routePattern, "/admin"+prefix) {
+			routePattern = "/admin" + strings.TrimPrefix(routePattern, "/admin"+prefix)
+			break
+		}
+	}
+
+	if routePattern == "" {
+		return method
+	}
+
+	return method + " " + routePattern
+}
+
+// errorClass classifies failed requests by their status code.  It returns ""
+// for requests which succeeded.
+func errorClass(statusCode int) string {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return "Throttled"
+	case statusCode >= 500:
+		return "ServerError"
+	case statusCode >= 400:
+		return "ClientError"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,134 @@
+package middleware
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/mock/gomock"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestMetrics(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		statusCode     int
+		tier           string
+		wantTier       string
+		wantErrorClass string
+	}{
+		{
+			name:       "successful request",
+			statusCode: http.StatusOK,
+			tier:       "PayAsYouGo",
+			wantTier:   "PayAsYouGo",
+		},
+		{
+			name:           "failed request",
+			statusCode:     http.StatusInternalServerError,
+			wantTier:       "Unknown",
+			wantErrorClass: "ServerError",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := mock_metrics.NewMockEmitter(controller)
+
+			m.EXPECT().EmitGauge("frontend.count", int64(1), gomock.Any())
+			m.EXPECT().EmitGauge("frontend.duration", gomock.Any(), gomock.Any())
+
+			dimensions := map[string]string{
+				"api-version":      "2023-11-22",
+				"operation":        "PUT /{resourceType}/{resourceName}",
+				"subscriptionTier": tt.wantTier,
+			}
+			m.EXPECT().EmitGauge("frontend.api.latency", gomock.Any(), dimensions)
+			if tt.wantErrorClass != "" {
+				m.EXPECT().EmitGauge("frontend.api.errors", int64(1), map[string]string{
+					"api-version":      "2023-11-22",
+					"operation":        "PUT /{resourceType}/{resourceName}",
+					"subscriptionTier": tt.wantTier,
+					"errorClass":       tt.wantErrorClass,
+				})
+			}
+
+			router := chi.NewRouter()
+			router.Use(MetricsMiddleware{Emitter: m}.Metrics)
+			router.Put("/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}", func(w http.ResponseWriter, r *http.Request) {
+				if tt.tier != "" {
+					SetSubscriptionTier(r.Context(), tt.tier)
+				}
+				w.WriteHeader(tt.statusCode)
+			})
+
+			r := httptest.NewRequest(http.MethodPut, "/subscriptions/sub/resourcegroups/rg/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster?api-version=2023-11-22", nil)
+			router.ServeHTTP(httptest.NewRecorder(), r)
+		})
+	}
+}
+
+func TestOperation(t *testing.T) {
+	for _, tt := range []struct {
+		method       string
+		routePattern string
+		want         string
+	}{
+		{
+			method:       http.MethodGet,
+			routePattern: "/subscriptions/{subscriptionId}/providers/{resourceProviderNamespace}/{resourceType}",
+			want:         "GET /{resourceType}",
+		},
+		{
+			method:       http.MethodPost,
+			routePattern: "/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/listcredentials",
+			want:         "POST /{resourceType}/{resourceName}/listcredentials",
+		},
+		{
+			method:       http.MethodPost,
+			routePattern: "/admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/redeployvm",
+			want:         "POST /admin/{resourceType}/{resourceName}/redeployvm",
+		},
+		{
+			method:       http.MethodPut,
+			routePattern: "/subscriptions/{subscriptionId}",
+			want:         "PUT /subscriptions/{subscriptionId}",
+		},
+		{
+			method: http.MethodGet,
+			want:   "GET",
+		},
+	} {
+		t.Run(tt.want, func(t *testing.T) {
+			got := operation(tt.method, tt.routePattern)
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestErrorClass(t *testing.T) {
+	for _, tt := range []struct {
+		statusCode int
+		want       string
+	}{
+		{statusCode: http.StatusCreated},
+		{statusCode: http.StatusNotFound, want: "ClientError"},
+		{statusCode: http.StatusTooManyRequests, want: "Throttled"},
+		{statusCode: http.StatusServiceUnavailable, want: "ServerError"},
+	} {
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			got := errorClass(tt.statusCode)
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing records an OpenTelemetry span for each request, named after the
+// route that matched so that span names do not contain resource IDs
+func Tracing(h http.Handler) http.Handler {
+	return otelhttp.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r)
+
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			trace.SpanFromContext(r.Context()).SetName(r.Method + " " + strings.Join(rctx.RoutePatterns, ""))
+		}
+	}), "frontend")
+}
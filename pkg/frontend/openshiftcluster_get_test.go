@@ -59,9 +59,10 @@ func TestGetOpenShiftCluster(t *testing.T) {
 			wantStatusCode: http.StatusOK,
 			wantResponse: func(tt *test) *v20200430.OpenShiftCluster {
 				return &v20200430.OpenShiftCluster{
-					ID:   tt.resourceID,
-					Name: "resourceName",
-					Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+					ID:         tt.resourceID,
+					Name:       "resourceName",
+					Type:       "Microsoft.RedHatOpenShift/openshiftClusters",
+					SystemData: &v20200430.SystemData{},
 					Properties: v20200430.OpenShiftClusterProperties{
 						ServicePrincipalProfile: &v20200430.ServicePrincipalProfile{},
 					},
@@ -9,16 +9,28 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/sirupsen/logrus"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
 )
 
+const (
+	// defaultPageSize is the number of items returned per page of a list
+	// response when the caller does not specify $top.
+	defaultPageSize = 10
+
+	// maxPageSize is the largest number of items returned per page of a list
+	// response; larger $top values are capped to it.
+	maxPageSize = 100
+)
+
 func (f *frontend) getOpenShiftClusters(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
@@ -42,6 +54,14 @@ func (f *frontend) getOpenShiftClusters(w http.ResponseWriter, r *http.Request)
 }
 
 func (f *frontend) _getOpenShiftClusters(ctx context.Context, log *logrus.Entry, r *http.Request, converter api.OpenShiftClusterConverter, lister func(string) (cosmosdb.OpenShiftClusterDocumentIterator, error)) ([]byte, error) {
+	// list results are not used to make decisions, so relax consistency
+	ctx = database.WithConsistencyLevel(ctx, database.ConsistencyLevelSession)
+
+	top, err := parseTop(r.URL.String())
+	if err != nil {
+		return nil, err
+	}
+
 	skipToken, err := f.parseSkipToken(r.URL.String())
 	if err != nil {
 		return nil, err
@@ -52,7 +72,7 @@ func (f *frontend) _getOpenShiftClusters(ctx context.Context, log *logrus.Entry,
 		return nil, err
 	}
 
-	docs, err := i.Next(ctx, 10)
+	docs, err := i.Next(ctx, top)
 	if err != nil {
 		return nil, err
 	}
@@ -101,17 +121,80 @@ func (f *frontend) parseSkipToken(originalURL string) (string, error) {
 
 	b, err := base64.StdEncoding.DecodeString(skipToken)
 	if err != nil {
-		return "", err
+		return "", api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "$skipToken", "The provided $skipToken is invalid.")
 	}
 
 	output, err := f.aead.Open(b)
 	if err != nil {
-		return "", err
+		return "", api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "$skipToken", "The provided $skipToken is invalid.")
 	}
 
 	return string(output), nil
 }
 
+// parseTop parses originalURL and retrieves the requested page size.
+// Returns defaultPageSize if there is no $top parameter in originalURL, and
+// caps the page size at maxPageSize.
+func parseTop(originalURL string) (int, error) {
+	u, err := url.Parse(originalURL)
+	if err != nil {
+		return 0, err
+	}
+
+	topstr := u.Query().Get("$top")
+	if topstr == "" {
+		return defaultPageSize, nil
+	}
+
+	top, err := strconv.Atoi(topstr)
+	if err != nil || top <= 0 {
+		return 0, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "$top", "The provided $top '%s' is invalid.", topstr)
+	}
+
+	if top > maxPageSize {
+		top = maxPageSize
+	}
+
+	return top, nil
+}
+
+// page returns the page of items selected by the $top and $skipToken
+// parameters of r, and the link to the following page, if any.  It is used
+// to page lists which are served from memory rather than from a database
+// iterator; the skipToken is the offset of the next page.
+func page[T any](f *frontend, r *http.Request, items []T) ([]T, string, error) {
+	top, err := parseTop(r.URL.String())
+	if err != nil {
+		return nil, "", err
+	}
+
+	skipToken, err := f.parseSkipToken(r.URL.String())
+	if err != nil {
+		return nil, "", err
+	}
+
+	var offset int
+	if skipToken != "" {
+		offset, err = strconv.Atoi(skipToken)
+		if err != nil || offset < 0 {
+			return nil, "", api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "$skipToken", "The provided $skipToken is invalid.")
+		}
+	}
+
+	offset = min(offset, len(items))
+	end := min(offset+top, len(items))
+
+	var nextLink string
+	if end < len(items) {
+		nextLink, err = f.buildNextLink(r.Header.Get("Referer"), strconv.Itoa(end))
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	return items[offset:end], nextLink, nil
+}
+
 // buildNextLink adds $skipToken parameter into baseURL.
 // Returns an empty string without an error, if skipToken is empty.
 func (f *frontend) buildNextLink(baseURL, skipToken string) (string, error) {
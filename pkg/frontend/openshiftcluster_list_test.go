@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 
@@ -50,6 +51,7 @@ func TestListOpenShiftCluster(t *testing.T) {
 		fixture        func(*testdatabase.Fixture)
 		dbError        error
 		skipToken      string
+		top            string
 		wantEnriched   []string
 		wantStatusCode int
 		wantResponse   func() *v20200430.OpenShiftClusterList
@@ -75,17 +77,19 @@ func TestListOpenShiftCluster(t *testing.T) {
 				return &v20200430.OpenShiftClusterList{
 					OpenShiftClusters: []*v20200430.OpenShiftCluster{
 						{
-							ID:   fmt.Sprintf("/subscriptions/%s/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName01", mockSubID),
-							Name: "resourceName01",
-							Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+							ID:         fmt.Sprintf("/subscriptions/%s/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName01", mockSubID),
+							Name:       "resourceName01",
+							Type:       "Microsoft.RedHatOpenShift/openShiftClusters",
+							SystemData: &v20200430.SystemData{},
 							Properties: v20200430.OpenShiftClusterProperties{
 								ServicePrincipalProfile: &v20200430.ServicePrincipalProfile{},
 							},
 						},
 						{
-							ID:   testdatabase.GetResourcePath(mockSubID, "resourceName02"),
-							Name: "resourceName02",
-							Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+							ID:         testdatabase.GetResourcePath(mockSubID, "resourceName02"),
+							Name:       "resourceName02",
+							Type:       "Microsoft.RedHatOpenShift/openShiftClusters",
+							SystemData: &v20200430.SystemData{},
 							Properties: v20200430.OpenShiftClusterProperties{
 								ServicePrincipalProfile: &v20200430.ServicePrincipalProfile{},
 							},
@@ -120,9 +124,10 @@ func TestListOpenShiftCluster(t *testing.T) {
 				var docs []*v20200430.OpenShiftCluster
 				for i := 1; i < 11; i++ {
 					docs = append(docs, &v20200430.OpenShiftCluster{
-						ID:   testdatabase.GetResourcePath(mockSubID, fmt.Sprintf("resourceName%02d", i)),
-						Name: fmt.Sprintf("resourceName%02d", i),
-						Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+						ID:         testdatabase.GetResourcePath(mockSubID, fmt.Sprintf("resourceName%02d", i)),
+						Name:       fmt.Sprintf("resourceName%02d", i),
+						Type:       "Microsoft.RedHatOpenShift/openShiftClusters",
+						SystemData: &v20200430.SystemData{},
 						Properties: v20200430.OpenShiftClusterProperties{
 							ServicePrincipalProfile: &v20200430.ServicePrincipalProfile{},
 						},
@@ -151,9 +156,10 @@ func TestListOpenShiftCluster(t *testing.T) {
 				return &v20200430.OpenShiftClusterList{
 					OpenShiftClusters: []*v20200430.OpenShiftCluster{
 						{
-							ID:   testdatabase.GetResourcePath(mockSubID, "resourceName11"),
-							Name: "resourceName11",
-							Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+							ID:         testdatabase.GetResourcePath(mockSubID, "resourceName11"),
+							Name:       "resourceName11",
+							Type:       "Microsoft.RedHatOpenShift/openShiftClusters",
+							SystemData: &v20200430.SystemData{},
 							Properties: v20200430.OpenShiftClusterProperties{
 								ServicePrincipalProfile: &v20200430.ServicePrincipalProfile{},
 							},
@@ -162,6 +168,53 @@ func TestListOpenShiftCluster(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "request has page size",
+			fixture: func(f *testdatabase.Fixture) {
+				var docs []*api.OpenShiftClusterDocument
+				for i := 1; i <= 3; i++ {
+					docs = append(docs, makeDoc(i))
+				}
+				f.AddOpenShiftClusterDocuments(docs...)
+			},
+			top: "2",
+			wantEnriched: []string{
+				testdatabase.GetResourcePath(mockSubID, "resourceName01"),
+				testdatabase.GetResourcePath(mockSubID, "resourceName02"),
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: func() *v20200430.OpenShiftClusterList {
+				var docs []*v20200430.OpenShiftCluster
+				for i := 1; i <= 2; i++ {
+					docs = append(docs, &v20200430.OpenShiftCluster{
+						ID:         testdatabase.GetResourcePath(mockSubID, fmt.Sprintf("resourceName%02d", i)),
+						Name:       fmt.Sprintf("resourceName%02d", i),
+						Type:       "Microsoft.RedHatOpenShift/openShiftClusters",
+						SystemData: &v20200430.SystemData{},
+						Properties: v20200430.OpenShiftClusterProperties{
+							ServicePrincipalProfile: &v20200430.ServicePrincipalProfile{},
+						},
+					})
+				}
+
+				return &v20200430.OpenShiftClusterList{
+					OpenShiftClusters: docs,
+					NextLink:          "https://mockrefererhost/?%24skipToken=" + url.QueryEscape(base64.StdEncoding.EncodeToString([]byte("FAKE2"))),
+				}
+			},
+		},
+		{
+			name:           "request has invalid page size",
+			top:            "0",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      `400: InvalidParameter: $top: The provided $top '0' is invalid.`,
+		},
+		{
+			name:           "request has invalid pagination token",
+			skipToken:      "invalid",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      `400: InvalidParameter: $skipToken: The provided $skipToken is invalid.`,
+		},
 		{
 			name:           "no clusters found in db",
 			wantStatusCode: http.StatusOK,
@@ -212,7 +265,7 @@ func TestListOpenShiftCluster(t *testing.T) {
 					go f.Run(ctx, nil, nil)
 
 					resp, b, err := ti.request(http.MethodGet,
-						fmt.Sprintf("https://server%sproviders/Microsoft.RedHatOpenShift/openShiftClusters?api-version=2020-04-30&%%24skipToken=%s", listPrefix, tt.skipToken),
+						fmt.Sprintf("https://server%sproviders/Microsoft.RedHatOpenShift/openShiftClusters?api-version=2020-04-30&%%24skipToken=%s&%%24top=%s", listPrefix, tt.skipToken, tt.top),
 						http.Header{
 							"Referer": []string{"https://mockrefererhost/"},
 						}, nil)
@@ -0,0 +1,158 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+)
+
+// clusterMove describes a single cluster being moved between resource groups
+type clusterMove struct {
+	sourceID string
+	targetID string
+}
+
+// postValidateMoveResources validates that the clusters in the request can be
+// moved to the target resource group.  Moves between subscriptions are denied.
+// /subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/validatemoveresources?api-version={api-version}
+func (f *frontend) postValidateMoveResources(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+
+	_, err := f.validateMoveResources(ctx, r)
+	if err == nil {
+		err = statusCodeError(http.StatusNoContent)
+	}
+
+	reply(log, w, nil, nil, err)
+}
+
+// postMoveResources re-keys the cluster documents of the moved clusters under
+// their new resource IDs and queues an admin update on each of them, which
+// adopts the cluster resource group and re-creates the RP role assignments.
+// /subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/moveresources?api-version={api-version}
+func (f *frontend) postMoveResources(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+
+	err := f._postMoveResources(ctx, log, r)
+	if err == nil {
+		err = statusCodeError(http.StatusNoContent)
+	}
+
+	frontendOperationResultLog(log, r.Method, err)
+	reply(log, w, nil, nil, err)
+}
+
+func (f *frontend) _postMoveResources(ctx context.Context, log *logrus.Entry, r *http.Request) error {
+	moves, err := f.validateMoveResources(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	dbOpenShiftClusters, err := f.dbGroup.OpenShiftClusters()
+	if err != nil {
+		return err
+	}
+
+	for _, move := range moves {
+		log.Infof("moving cluster %s to %s", move.sourceID, move.targetID)
+
+		_, err = dbOpenShiftClusters.Patch(ctx, strings.ToLower(move.sourceID), func(doc *api.OpenShiftClusterDocument) error {
+			doc.Key = strings.ToLower(move.targetID)
+			doc.OpenShiftCluster.Properties.MovedFromResourceID = doc.OpenShiftCluster.ID
+			doc.OpenShiftCluster.ID = move.targetID
+
+			doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskEverything
+			adminUpdateProvisioningState(doc)
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *frontend) validateMoveResources(ctx context.Context, r *http.Request) ([]clusterMove, error) {
+	subId, resourceGroupName := chi.URLParam(r, "subscriptionId"), chi.URLParam(r, "resourceGroupName")
+
+	var req api.MoveResourcesRequest
+	body := r.Context().Value(middleware.ContextKeyBody).([]byte)
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidRequestContent, "", "The request content was invalid and could not be deserialized: %q.", err)
+	}
+
+	target, err := arm.ParseResourceID(req.TargetResourceGroup)
+	if err != nil || !strings.EqualFold(target.ResourceType.String(), arm.ResourceGroupResourceType.String()) {
+		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "targetResourceGroup", "The provided target resource group '%s' is invalid.", req.TargetResourceGroup)
+	}
+
+	if !strings.EqualFold(target.SubscriptionID, subId) {
+		return nil, api.NewCloudError(http.StatusConflict, api.CloudErrorCodeRequestNotAllowed, "targetResourceGroup", "Moving clusters to a different subscription is not supported.")
+	}
+
+	dbOpenShiftClusters, err := f.dbGroup.OpenShiftClusters()
+	if err != nil {
+		return nil, err
+	}
+
+	moves := make([]clusterMove, 0, len(req.Resources))
+	for _, resourceID := range req.Resources {
+		source, err := arm.ParseResourceID(resourceID)
+		if err != nil ||
+			!strings.EqualFold(source.SubscriptionID, subId) ||
+			!strings.EqualFold(source.ResourceGroupName, resourceGroupName) ||
+			!strings.EqualFold(source.ResourceType.String(), "Microsoft.RedHatOpenShift/openShiftClusters") {
+			return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "resources", "The provided resource '%s' is invalid.", resourceID)
+		}
+
+		_, err = f.validateSubscriptionState(ctx, resourceID, api.SubscriptionStateRegistered)
+		if err != nil {
+			return nil, err
+		}
+
+		doc, err := dbOpenShiftClusters.Get(ctx, strings.ToLower(resourceID))
+		switch {
+		case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+			return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "", "The Resource '%s/%s' under resource group '%s' was not found.", source.ResourceType.Type, source.Name, source.ResourceGroupName)
+		case err != nil:
+			return nil, err
+		}
+
+		if doc.OpenShiftCluster.Properties.ProvisioningState != api.ProvisioningStateSucceeded {
+			return nil, api.NewCloudError(http.StatusConflict, api.CloudErrorCodeRequestNotAllowed, "", "Request is not allowed in provisioningState '%s'.", doc.OpenShiftCluster.Properties.ProvisioningState)
+		}
+
+		targetID := target.String() + "/providers/" + source.ResourceType.String() + "/" + source.Name
+
+		_, err = dbOpenShiftClusters.Get(ctx, strings.ToLower(targetID))
+		switch {
+		case err == nil:
+			return nil, api.NewCloudError(http.StatusConflict, api.CloudErrorCodeRequestNotAllowed, "", "The Resource '%s/%s' already exists under resource group '%s'.", source.ResourceType.Type, source.Name, target.ResourceGroupName)
+		case !cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+			return nil, err
+		}
+
+		moves = append(moves, clusterMove{
+			sourceID: doc.OpenShiftCluster.ID,
+			targetID: targetID,
+		})
+	}
+
+	return moves, nil
+}
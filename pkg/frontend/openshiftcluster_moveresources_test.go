@@ -0,0 +1,267 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestMoveResources(t *testing.T) {
+	ctx := context.Background()
+
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	sourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+	targetResourceGroup := fmt.Sprintf("/subscriptions/%s/resourceGroups/targetResourceGroup", mockSubID)
+	targetID := targetResourceGroup + "/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName"
+
+	subscriptionFixture := func(f *testdatabase.Fixture) {
+		f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+			ID: mockSubID,
+			Subscription: &api.Subscription{
+				State: api.SubscriptionStateRegistered,
+				Properties: &api.SubscriptionProperties{
+					TenantID: "11111111-1111-1111-1111-111111111111",
+				},
+			},
+		})
+	}
+
+	clusterDocument := func(key, id string, provisioningState api.ProvisioningState) *api.OpenShiftClusterDocument {
+		return &api.OpenShiftClusterDocument{
+			Key: strings.ToLower(key),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID:   id,
+				Name: "resourceName",
+				Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState: provisioningState,
+				},
+			},
+		}
+	}
+
+	type test struct {
+		name           string
+		action         string
+		body           *api.MoveResourcesRequest
+		fixture        func(*testdatabase.Fixture)
+		dbError        error
+		wantDocuments  func(*testdatabase.Checker)
+		wantStatusCode int
+		wantError      string
+	}
+
+	for _, tt := range []*test{
+		{
+			name:   "validate - cluster can be moved",
+			action: "validateMoveResources",
+			body: &api.MoveResourcesRequest{
+				Resources:           []string{sourceID},
+				TargetResourceGroup: targetResourceGroup,
+			},
+			fixture: func(f *testdatabase.Fixture) {
+				subscriptionFixture(f)
+				f.AddOpenShiftClusterDocuments(clusterDocument(sourceID, sourceID, api.ProvisioningStateSucceeded))
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(clusterDocument(sourceID, sourceID, api.ProvisioningStateSucceeded))
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:   "validate - move to a different subscription is denied",
+			action: "validateMoveResources",
+			body: &api.MoveResourcesRequest{
+				Resources:           []string{sourceID},
+				TargetResourceGroup: "/subscriptions/22222222-2222-2222-2222-222222222222/resourceGroups/targetResourceGroup",
+			},
+			fixture: func(f *testdatabase.Fixture) {
+				subscriptionFixture(f)
+				f.AddOpenShiftClusterDocuments(clusterDocument(sourceID, sourceID, api.ProvisioningStateSucceeded))
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(clusterDocument(sourceID, sourceID, api.ProvisioningStateSucceeded))
+			},
+			wantStatusCode: http.StatusConflict,
+			wantError:      "409: RequestNotAllowed: targetResourceGroup: Moving clusters to a different subscription is not supported.",
+		},
+		{
+			name:   "validate - invalid target resource group",
+			action: "validateMoveResources",
+			body: &api.MoveResourcesRequest{
+				Resources:           []string{sourceID},
+				TargetResourceGroup: "invalid",
+			},
+			fixture:        subscriptionFixture,
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: targetResourceGroup: The provided target resource group 'invalid' is invalid.",
+		},
+		{
+			name:   "validate - resource from another resource group",
+			action: "validateMoveResources",
+			body: &api.MoveResourcesRequest{
+				Resources:           []string{targetID},
+				TargetResourceGroup: targetResourceGroup,
+			},
+			fixture:        subscriptionFixture,
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      fmt.Sprintf("400: InvalidParameter: resources: The provided resource '%s' is invalid.", targetID),
+		},
+		{
+			name:   "validate - cluster not found",
+			action: "validateMoveResources",
+			body: &api.MoveResourcesRequest{
+				Resources:           []string{sourceID},
+				TargetResourceGroup: targetResourceGroup,
+			},
+			fixture:        subscriptionFixture,
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: ResourceNotFound: : The Resource 'openShiftClusters/resourceName' under resource group 'resourceGroup' was not found.",
+		},
+		{
+			name:   "validate - cluster is not in a terminal state",
+			action: "validateMoveResources",
+			body: &api.MoveResourcesRequest{
+				Resources:           []string{sourceID},
+				TargetResourceGroup: targetResourceGroup,
+			},
+			fixture: func(f *testdatabase.Fixture) {
+				subscriptionFixture(f)
+				f.AddOpenShiftClusterDocuments(clusterDocument(sourceID, sourceID, api.ProvisioningStateUpdating))
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(clusterDocument(sourceID, sourceID, api.ProvisioningStateUpdating))
+			},
+			wantStatusCode: http.StatusConflict,
+			wantError:      "409: RequestNotAllowed: : Request is not allowed in provisioningState 'Updating'.",
+		},
+		{
+			name:   "validate - cluster already exists in the target resource group",
+			action: "validateMoveResources",
+			body: &api.MoveResourcesRequest{
+				Resources:           []string{sourceID},
+				TargetResourceGroup: targetResourceGroup,
+			},
+			fixture: func(f *testdatabase.Fixture) {
+				subscriptionFixture(f)
+				f.AddOpenShiftClusterDocuments(
+					clusterDocument(sourceID, sourceID, api.ProvisioningStateSucceeded),
+					clusterDocument(targetID, targetID, api.ProvisioningStateSucceeded),
+				)
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(
+					clusterDocument(sourceID, sourceID, api.ProvisioningStateSucceeded),
+					clusterDocument(targetID, targetID, api.ProvisioningStateSucceeded),
+				)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantError:      "409: RequestNotAllowed: : The Resource 'openShiftClusters/resourceName' already exists under resource group 'targetResourceGroup'.",
+		},
+		{
+			name:   "move - cluster document is re-keyed and an admin update is queued",
+			action: "moveResources",
+			body: &api.MoveResourcesRequest{
+				Resources:           []string{sourceID},
+				TargetResourceGroup: targetResourceGroup,
+			},
+			fixture: func(f *testdatabase.Fixture) {
+				subscriptionFixture(f)
+				f.AddOpenShiftClusterDocuments(clusterDocument(sourceID, sourceID, api.ProvisioningStateSucceeded))
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				doc := clusterDocument(targetID, targetID, api.ProvisioningStateAdminUpdating)
+				doc.OpenShiftCluster.Properties.LastProvisioningState = api.ProvisioningStateSucceeded
+				doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskEverything
+				doc.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStateUnplanned
+				doc.OpenShiftCluster.Properties.MovedFromResourceID = sourceID
+				c.AddOpenShiftClusterDocuments(doc)
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:   "move - move to a different subscription is denied",
+			action: "moveResources",
+			body: &api.MoveResourcesRequest{
+				Resources:           []string{sourceID},
+				TargetResourceGroup: "/subscriptions/22222222-2222-2222-2222-222222222222/resourceGroups/targetResourceGroup",
+			},
+			fixture: func(f *testdatabase.Fixture) {
+				subscriptionFixture(f)
+				f.AddOpenShiftClusterDocuments(clusterDocument(sourceID, sourceID, api.ProvisioningStateSucceeded))
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(clusterDocument(sourceID, sourceID, api.ProvisioningStateSucceeded))
+			},
+			wantStatusCode: http.StatusConflict,
+			wantError:      "409: RequestNotAllowed: targetResourceGroup: Moving clusters to a different subscription is not supported.",
+		},
+		{
+			name:   "move - internal error",
+			action: "moveResources",
+			body: &api.MoveResourcesRequest{
+				Resources:           []string{sourceID},
+				TargetResourceGroup: targetResourceGroup,
+			},
+			dbError:        &cosmosdb.Error{Code: "500", Message: "oh no!"},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      "500: InternalServerError: : Internal server error.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			defer ti.done()
+
+			err := ti.buildFixtures(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.dbError != nil {
+				ti.subscriptionsClient.SetError(tt.dbError)
+				ti.openShiftClustersClient.SetError(tt.dbError)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.dbGroup, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodPost,
+				fmt.Sprintf("https://server/subscriptions/%s/resourceGroups/resourceGroup/%s?api-version=2025-07-25", mockSubID, tt.action),
+				http.Header{
+					"Content-Type": []string{"application/json"},
+				}, tt.body)
+			if err != nil {
+				t.Error(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, nil)
+			if err != nil {
+				t.Error(err)
+			}
+
+			ti.subscriptionsClient.SetError(nil)
+			ti.openShiftClustersClient.SetError(nil)
+			if tt.wantDocuments != nil {
+				tt.wantDocuments(ti.checker)
+			}
+			errs := ti.checker.CheckOpenShiftClusters(ti.openShiftClustersClient)
+			for _, i := range errs {
+				t.Error(i)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/platformworkloadidentity"
 )
 
 var validationSuccess = api.ValidationResult{
@@ -101,15 +102,39 @@ func (f *frontend) _preflightValidation(ctx context.Context, log *logrus.Entry,
 	}
 	// unmarshal raw to OpenShiftCluster type
 	oc := &api.OpenShiftCluster{}
-	oc.Properties.ProvisioningState = api.ProvisioningStateSucceeded
+	if isCreate {
+		oc.Properties.ProvisioningState = api.ProvisioningStateSucceeded
 
-	if !f.env.IsLocalDevelopmentMode() /* not local dev or CI */ {
-		oc.Properties.FeatureProfile.GatewayEnabled = true
+		if !f.env.IsLocalDevelopmentMode() /* not local dev or CI */ {
+			oc.Properties.FeatureProfile.GatewayEnabled = true
+		}
+	} else {
+		// validate the update the same way as a PUT onto the existing cluster
+		if err := validateTerminalProvisioningState(doc.OpenShiftCluster.Properties.ProvisioningState); err != nil {
+			return api.ValidationResult{
+				Status: api.ValidationStatusFailed,
+				Error: &api.CloudErrorBody{
+					Message: err.Error(),
+				},
+			}
+		}
+		if err := validateFailedProvisioningState(doc.OpenShiftCluster); err != nil {
+			return api.ValidationResult{
+				Status: api.ValidationStatusFailed,
+				Error: &api.CloudErrorBody{
+					Message: err.Error(),
+				},
+			}
+		}
+		oc = putBaseOpenShiftCluster(doc.OpenShiftCluster)
 	}
 
 	converter := f.apis[apiVersion].OpenShiftClusterConverter
 	staticValidator := f.apis[apiVersion].OpenShiftClusterStaticValidator
 	ext := converter.ToExternal(oc)
+	if !isCreate {
+		converter.ExternalNoReadOnly(ext)
+	}
 	if err = json.Unmarshal(raw, &ext); err != nil {
 		log.Warning(err.Error())
 		return api.ValidationResult{
@@ -121,7 +146,17 @@ func (f *frontend) _preflightValidation(ctx context.Context, log *logrus.Entry,
 	}
 	if isCreate {
 		converter.ToInternal(ext, oc)
-		if err = staticValidator.Static(ext, nil, f.env.Location(), f.env.Domain(), f.env.FeatureIsSet(env.FeatureRequireD2sV3Workers), resourceID); err != nil {
+		if err = staticValidator.Static(ext, nil, f.env.Location(), f.env.Domain(), f.env.FeatureIsSet(env.FeatureRequireD2sV3Workers), f.getAdditionalVMSizes(), resourceID); err != nil {
+			return api.ValidationResult{
+				Status: api.ValidationStatusFailed,
+				Error: &api.CloudErrorBody{
+					Message: err.Error(),
+				},
+			}
+		}
+		subscription, err := f.getSubscriptionDocument(ctx, resourceID)
+		if err != nil {
+			log.Warning(err.Error())
 			return api.ValidationResult{
 				Status: api.ValidationStatusFailed,
 				Error: &api.CloudErrorBody{
@@ -129,7 +164,7 @@ func (f *frontend) _preflightValidation(ctx context.Context, log *logrus.Entry,
 				},
 			}
 		}
-		if err := f.validateInstallVersion(ctx, oc); err != nil {
+		if err := f.validateInstallVersion(ctx, oc, subscription.Subscription.Properties); err != nil {
 			return api.ValidationResult{
 				Status: api.ValidationStatusFailed,
 				Error: &api.CloudErrorBody{
@@ -139,7 +174,7 @@ func (f *frontend) _preflightValidation(ctx context.Context, log *logrus.Entry,
 			}
 		}
 	} else {
-		if err := staticValidator.Static(ext, doc.OpenShiftCluster, f.env.Location(), f.env.Domain(), f.env.FeatureIsSet(env.FeatureRequireD2sV3Workers), resourceID); err != nil {
+		if err := staticValidator.Static(ext, doc.OpenShiftCluster, f.env.Location(), f.env.Domain(), f.env.FeatureIsSet(env.FeatureRequireD2sV3Workers), f.getAdditionalVMSizes(), resourceID); err != nil {
 			return api.ValidationResult{
 				Status: api.ValidationStatusFailed,
 				Error: &api.CloudErrorBody{
@@ -147,10 +182,54 @@ func (f *frontend) _preflightValidation(ctx context.Context, log *logrus.Entry,
 				},
 			}
 		}
+		if doc.OpenShiftCluster.UsesWorkloadIdentity() {
+			// the document is not persisted, so the update can be applied
+			// to it directly
+			converter.ToInternal(ext, doc.OpenShiftCluster)
+			if err := f.validatePlatformWorkloadIdentitiesUpdate(ctx, doc.OpenShiftCluster); err != nil {
+				log.Warning(err.Error())
+				return api.ValidationResult{
+					Status: api.ValidationStatusFailed,
+					Error: &api.CloudErrorBody{
+						Message: err.Error(),
+					},
+				}
+			}
+		}
 	}
 	return validationSuccess
 }
 
+// validatePlatformWorkloadIdentitiesUpdate checks that the platform workload
+// identities of the updated cluster oc match the platform workload identity
+// roles of its current and upgradeable OpenShift versions
+func (f *frontend) validatePlatformWorkloadIdentitiesUpdate(ctx context.Context, oc *api.OpenShiftCluster) error {
+	dbPlatformWorkloadIdentityRoleSets, err := f.dbGroup.PlatformWorkloadIdentityRoleSets()
+	if err != nil {
+		return err
+	}
+
+	rolesByVersion := platformworkloadidentity.NewPlatformWorkloadIdentityRolesByVersionService()
+	err = rolesByVersion.PopulatePlatformWorkloadIdentityRolesByVersion(ctx, oc, dbPlatformWorkloadIdentityRoleSets)
+	if err != nil {
+		return err
+	}
+
+	rolesByRoleName := rolesByVersion.GetPlatformWorkloadIdentityRolesByRoleName()
+	identities := oc.Properties.PlatformWorkloadIdentityProfile.PlatformWorkloadIdentities
+	if len(identities) != len(rolesByRoleName) {
+		return platformworkloadidentity.GetPlatformWorkloadIdentityMismatchError(oc, rolesByRoleName)
+	}
+
+	for name := range identities {
+		if _, exists := rolesByRoleName[name]; !exists {
+			return platformworkloadidentity.GetPlatformWorkloadIdentityMismatchError(oc, rolesByRoleName)
+		}
+	}
+
+	return nil
+}
+
 func unmarshalRequest(body []byte) (*api.PreflightRequest, error) {
 	preflightRequest := &api.PreflightRequest{}
 	if err := json.Unmarshal(body, preflightRequest); err != nil {
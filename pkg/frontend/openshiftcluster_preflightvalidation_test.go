@@ -103,6 +103,111 @@ func TestPreflightValidation(t *testing.T) {
 		},
 	}
 
+	existingCluster := func(provisioningState, failedProvisioningState api.ProvisioningState) *api.OpenShiftClusterDocument {
+		return &api.OpenShiftClusterDocument{
+			Key: strings.ToLower(testdatabase.GetResourcePath(api.ExampleOpenShiftClusterDocument().ID, api.ExampleOpenShiftClusterDocument().OpenShiftCluster.Name)),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID:       testdatabase.GetResourcePath(api.ExampleOpenShiftClusterDocument().ID, api.ExampleOpenShiftClusterDocument().OpenShiftCluster.Name),
+				Name:     api.ExampleOpenShiftClusterDocument().OpenShiftCluster.Name,
+				Type:     api.ExampleOpenShiftClusterDocument().OpenShiftCluster.Type,
+				Location: location,
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState:       provisioningState,
+					FailedProvisioningState: failedProvisioningState,
+				},
+			},
+		}
+	}
+
+	workloadIdentityAPIVersion := "2024-08-12-preview"
+	clusterMSI := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroup/providers/Microsoft.ManagedIdentity/userAssignedIdentities/cluster"
+
+	// workloadIdentityCluster returns a workload identity cluster with an
+	// identity for each of operatorNames
+	workloadIdentityCluster := func(operatorNames ...string) *api.OpenShiftCluster {
+		oc := &api.OpenShiftCluster{
+			ID:       testdatabase.GetResourcePath(api.ExampleOpenShiftClusterDocument().ID, api.ExampleOpenShiftClusterDocument().OpenShiftCluster.Name),
+			Name:     api.ExampleOpenShiftClusterDocument().OpenShiftCluster.Name,
+			Type:     api.ExampleOpenShiftClusterDocument().OpenShiftCluster.Type,
+			Location: location,
+			Identity: &api.ManagedServiceIdentity{
+				Type: api.ManagedServiceIdentityUserAssigned,
+				UserAssignedIdentities: map[string]api.UserAssignedIdentity{
+					clusterMSI: {},
+				},
+			},
+			Properties: api.OpenShiftClusterProperties{
+				ProvisioningState: api.ProvisioningStateSucceeded,
+				ClusterProfile: api.ClusterProfile{
+					Domain:               defaultProfile,
+					FipsValidatedModules: api.FipsValidatedModulesEnabled,
+					ResourceGroupID:      resourceGroup,
+					Version:              version.DefaultInstallStream.Version.String(),
+				},
+				PlatformWorkloadIdentityProfile: &api.PlatformWorkloadIdentityProfile{
+					PlatformWorkloadIdentities: map[string]api.PlatformWorkloadIdentity{},
+				},
+				NetworkProfile: api.NetworkProfile{
+					PodCIDR:     netProfile,
+					ServiceCIDR: netProfile,
+				},
+				MasterProfile: api.MasterProfile{
+					VMSize:              api.VMSizeStandardD32sV3,
+					SubnetID:            masterSub,
+					DiskEncryptionSetID: encryptionSet,
+					EncryptionAtHost:    api.EncryptionAtHostEnabled,
+				},
+				APIServerProfile: api.APIServerProfile{
+					Visibility: api.VisibilityPublic,
+				},
+			},
+		}
+		for _, name := range operatorNames {
+			oc.Properties.PlatformWorkloadIdentityProfile.PlatformWorkloadIdentities[name] = api.PlatformWorkloadIdentity{
+				ResourceID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroup/providers/Microsoft.ManagedIdentity/userAssignedIdentities/" + name,
+			}
+		}
+		return oc
+	}
+
+	// workloadIdentityPayload returns the preflight payload of a PUT of oc
+	workloadIdentityPayload := func(oc *api.OpenShiftCluster) json.RawMessage {
+		b, err := json.Marshal(api.APIs[workloadIdentityAPIVersion].OpenShiftClusterConverter.ToExternal(oc))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(b, &m); err != nil {
+			t.Fatal(err)
+		}
+		m["apiVersion"] = workloadIdentityAPIVersion
+		delete(m, "systemData")
+		b, err = json.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	workloadIdentityFixture := func(f *testdatabase.Fixture) {
+		f.AddSubscriptionDocuments(api.ExampleSubscriptionDocument())
+		f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+			Key:              strings.ToLower(testdatabase.GetResourcePath(api.ExampleOpenShiftClusterDocument().ID, api.ExampleOpenShiftClusterDocument().OpenShiftCluster.Name)),
+			OpenShiftCluster: workloadIdentityCluster("CloudControllerManager", "ClusterIngressOperator"),
+		})
+		f.AddPlatformWorkloadIdentityRoleSetDocuments(&api.PlatformWorkloadIdentityRoleSetDocument{
+			PlatformWorkloadIdentityRoleSet: &api.PlatformWorkloadIdentityRoleSet{
+				Properties: api.PlatformWorkloadIdentityRoleSetProperties{
+					OpenShiftVersion: version.DefaultInstallStream.Version.MinorVersion(),
+					PlatformWorkloadIdentityRoles: []api.PlatformWorkloadIdentityRole{
+						{OperatorName: "CloudControllerManager"},
+						{OperatorName: "ClusterIngressOperator"},
+					},
+				},
+			},
+		})
+	}
+
 	type test struct {
 		name             string
 		preflightRequest func() *api.PreflightRequest
@@ -386,11 +491,108 @@ func TestPreflightValidation(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Failed Preflight Update Non-Terminal Provisioning State",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(api.ExampleSubscriptionDocument())
+				f.AddOpenShiftClusterDocuments(existingCluster(api.ProvisioningStateUpdating, ""))
+			},
+			preflightRequest: func() *api.PreflightRequest {
+				return &api.PreflightRequest{
+					Resources: []json.RawMessage{
+						preflightPayload,
+					},
+				}
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &api.ValidationResult{
+				Status: api.ValidationStatusFailed,
+				Error: &api.CloudErrorBody{
+					Message: "400: RequestNotAllowed: : Request is not allowed in provisioningState 'Updating'.",
+				},
+			},
+		},
+		{
+			name: "Failed Preflight Update Failed Creation",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(api.ExampleSubscriptionDocument())
+				f.AddOpenShiftClusterDocuments(existingCluster(api.ProvisioningStateFailed, api.ProvisioningStateCreating))
+			},
+			preflightRequest: func() *api.PreflightRequest {
+				return &api.PreflightRequest{
+					Resources: []json.RawMessage{
+						preflightPayload,
+					},
+				}
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &api.ValidationResult{
+				Status: api.ValidationStatusFailed,
+				Error: &api.CloudErrorBody{
+					Message: "400: RequestNotAllowed: : Request is not allowed on cluster whose creation failed. Delete the cluster.",
+				},
+			},
+		},
+		{
+			name:    "Successful Preflight Update Workload Identity",
+			fixture: workloadIdentityFixture,
+			preflightRequest: func() *api.PreflightRequest {
+				return &api.PreflightRequest{
+					Resources: []json.RawMessage{
+						workloadIdentityPayload(workloadIdentityCluster("CloudControllerManager", "ClusterIngressOperator")),
+					},
+				}
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &api.ValidationResult{
+				Status: api.ValidationStatusSucceeded,
+			},
+		},
+		{
+			name:    "Failed Preflight Update Workload Identity Not Required",
+			fixture: workloadIdentityFixture,
+			preflightRequest: func() *api.PreflightRequest {
+				return &api.PreflightRequest{
+					Resources: []json.RawMessage{
+						workloadIdentityPayload(workloadIdentityCluster("CloudControllerManager", "ClusterIngressOperator", "Unknown")),
+					},
+				}
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &api.ValidationResult{
+				Status: api.ValidationStatusFailed,
+				Error: &api.CloudErrorBody{
+					Message: fmt.Sprintf("400: PlatformWorkloadIdentityMismatch: properties.PlatformWorkloadIdentityProfile.PlatformWorkloadIdentities: There's a mismatch between the required and expected set of platform workload identities for the requested OpenShift minor version '%s'. The required platform workload identities are '[CloudControllerManager ClusterIngressOperator]'", version.DefaultInstallStream.Version.MinorVersion()),
+				},
+			},
+		},
+		{
+			name:    "Failed Preflight Update Workload Identity Upgradeable To Unknown Version",
+			fixture: workloadIdentityFixture,
+			preflightRequest: func() *api.PreflightRequest {
+				oc := workloadIdentityCluster("CloudControllerManager", "ClusterIngressOperator")
+				upgradeableTo := api.UpgradeableTo("4.99.0")
+				oc.Properties.PlatformWorkloadIdentityProfile.UpgradeableTo = &upgradeableTo
+				return &api.PreflightRequest{
+					Resources: []json.RawMessage{
+						workloadIdentityPayload(oc),
+					},
+				}
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &api.ValidationResult{
+				Status: api.ValidationStatusFailed,
+				Error: &api.CloudErrorBody{
+					Message: "400: InvalidParameter: : No PlatformWorkloadIdentityRoleSet found for the requested or upgradeable OpenShift minor version '4.99'. Please retry with different OpenShift version, and if the issue persists, raise an Azure support ticket",
+				},
+			},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			ti := newTestInfra(t).
 				WithSubscriptions().
-				WithOpenShiftClusters()
+				WithOpenShiftClusters().
+				WithPlatformWorkloadIdentityRoleSets()
 			defer ti.done()
 
 			err := ti.buildFixtures(tt.fixture)
@@ -18,6 +18,7 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
@@ -82,7 +83,7 @@ func (f *frontend) putOrPatchOpenShiftCluster(w http.ResponseWriter, r *http.Req
 		identityURL,
 		identityTenantID,
 	}
-	err := cosmosdb.RetryOnPreconditionFailed(func() error {
+	err := database.RetryOnPreconditionFailed(ctx, func() error {
 		var err error
 		b, err = f._putOrPatchOpenShiftCluster(ctx, log, putOrPatchClusterParameters)
 		return err
@@ -144,18 +145,9 @@ func (f *frontend) _putOrPatchOpenShiftCluster(ctx context.Context, log *logrus.
 		return nil, err
 	}
 
-	if doc.OpenShiftCluster.Properties.ProvisioningState == api.ProvisioningStateFailed {
-		switch doc.OpenShiftCluster.Properties.FailedProvisioningState {
-		case api.ProvisioningStateCreating:
-			return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeRequestNotAllowed, "", "Request is not allowed on cluster whose creation failed. Delete the cluster.")
-		case api.ProvisioningStateUpdating:
-			// allow: a previous failure to update should not prevent a new
-			// operation.
-		case api.ProvisioningStateDeleting:
-			return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeRequestNotAllowed, "", "Request is not allowed on cluster whose deletion failed. Delete the cluster.")
-		default:
-			return nil, fmt.Errorf("unexpected failedProvisioningState %q", doc.OpenShiftCluster.Properties.FailedProvisioningState)
-		}
+	err = validateFailedProvisioningState(doc.OpenShiftCluster)
+	if err != nil {
+		return nil, err
 	}
 
 	// If Put or Patch is executed we will enrich document with cluster data.
@@ -172,26 +164,7 @@ func (f *frontend) _putOrPatchOpenShiftCluster(ctx context.Context, log *logrus.
 	// Our base structure for unmarshal is skeleton document with values we
 	// think is required. We expect payload to have everything else required.
 	case http.MethodPut:
-		document := &api.OpenShiftCluster{
-			ID:   doc.OpenShiftCluster.ID,
-			Name: doc.OpenShiftCluster.Name,
-			Type: doc.OpenShiftCluster.Type,
-			Properties: api.OpenShiftClusterProperties{
-				ProvisioningState: doc.OpenShiftCluster.Properties.ProvisioningState,
-				ClusterProfile: api.ClusterProfile{
-					PullSecret: doc.OpenShiftCluster.Properties.ClusterProfile.PullSecret,
-					Version:    doc.OpenShiftCluster.Properties.ClusterProfile.Version,
-				},
-			},
-			SystemData: doc.OpenShiftCluster.SystemData,
-		}
-
-		if doc.OpenShiftCluster.Properties.ServicePrincipalProfile != nil {
-			document.Properties.ServicePrincipalProfile = &api.ServicePrincipalProfile{}
-			document.Properties.ServicePrincipalProfile.ClientSecret = doc.OpenShiftCluster.Properties.ServicePrincipalProfile.ClientSecret
-		}
-
-		ext = putOrPatchClusterParameters.converter.ToExternal(document)
+		ext = putOrPatchClusterParameters.converter.ToExternal(putBaseOpenShiftCluster(doc.OpenShiftCluster))
 
 	// In case of PATCH we take current cluster document, which is enriched
 	// from the cluster and use it as base for unmarshal. So customer can
@@ -225,7 +198,7 @@ func (f *frontend) _putOrPatchOpenShiftCluster(ctx context.Context, log *logrus.
 			return nil, err
 		}
 	} else {
-		err = putOrPatchClusterParameters.staticValidator.Static(ext, doc.OpenShiftCluster, f.env.Location(), f.env.Domain(), f.env.FeatureIsSet(env.FeatureRequireD2sV3Workers), putOrPatchClusterParameters.path)
+		err = putOrPatchClusterParameters.staticValidator.Static(ext, doc.OpenShiftCluster, f.env.Location(), f.env.Domain(), f.env.FeatureIsSet(env.FeatureRequireD2sV3Workers), f.getAdditionalVMSizes(), putOrPatchClusterParameters.path)
 		if err != nil {
 			return nil, err
 		}
@@ -240,7 +213,7 @@ func (f *frontend) _putOrPatchOpenShiftCluster(ctx context.Context, log *logrus.
 	f.systemDataClusterDocEnricher(doc, putOrPatchClusterParameters.systemData)
 
 	if isCreate {
-		err = f.validateInstallVersion(ctx, doc.OpenShiftCluster)
+		err = f.validateInstallVersion(ctx, doc.OpenShiftCluster, subscription.Subscription.Properties)
 		if err != nil {
 			return nil, err
 		}
@@ -346,6 +319,31 @@ func (f *frontend) _putOrPatchOpenShiftCluster(ctx context.Context, log *logrus.
 	return b, err
 }
 
+// putBaseOpenShiftCluster returns the skeleton cluster which the body of a PUT
+// request to update oc is unmarshalled onto
+func putBaseOpenShiftCluster(oc *api.OpenShiftCluster) *api.OpenShiftCluster {
+	base := &api.OpenShiftCluster{
+		ID:   oc.ID,
+		Name: oc.Name,
+		Type: oc.Type,
+		Properties: api.OpenShiftClusterProperties{
+			ProvisioningState: oc.Properties.ProvisioningState,
+			ClusterProfile: api.ClusterProfile{
+				PullSecret: oc.Properties.ClusterProfile.PullSecret,
+				Version:    oc.Properties.ClusterProfile.Version,
+			},
+		},
+		SystemData: oc.SystemData,
+	}
+
+	if oc.Properties.ServicePrincipalProfile != nil {
+		base.Properties.ServicePrincipalProfile = &api.ServicePrincipalProfile{}
+		base.Properties.ServicePrincipalProfile.ClientSecret = oc.Properties.ServicePrincipalProfile.ClientSecret
+	}
+
+	return base
+}
+
 // enrichClusterSystemData will selectively overwrite systemData fields based on
 // arm inputs
 func enrichClusterSystemData(doc *api.OpenShiftClusterDocument, systemData *api.SystemData) {
@@ -393,7 +391,7 @@ func validateIdentityTenantID(cluster *api.OpenShiftCluster, identityTenantID st
 }
 
 func (f *frontend) ValidateNewCluster(ctx context.Context, subscription *api.SubscriptionDocument, cluster *api.OpenShiftCluster, staticValidator api.OpenShiftClusterStaticValidator, ext interface{}, path string) error {
-	err := staticValidator.Static(ext, nil, f.env.Location(), f.env.Domain(), f.env.FeatureIsSet(env.FeatureRequireD2sV3Workers), path)
+	err := staticValidator.Static(ext, nil, f.env.Location(), f.env.Domain(), f.env.FeatureIsSet(env.FeatureRequireD2sV3Workers), f.getAdditionalVMSizes(), path)
 	if err != nil {
 		return err
 	}
@@ -403,7 +401,7 @@ func (f *frontend) ValidateNewCluster(ctx context.Context, subscription *api.Sub
 		return err
 	}
 
-	err = f.quotaValidator.ValidateQuota(ctx, f.env.Environment(), f.env, subscription.ID, subscription.Subscription.Properties.TenantID, cluster)
+	err = f.quotaValidator.ValidateQuota(ctx, f.env.Environment(), f.env, subscription.ID, subscription.Subscription.Properties.TenantID, cluster, f.getAdditionalVMSizes())
 	if err != nil {
 		return err
 	}
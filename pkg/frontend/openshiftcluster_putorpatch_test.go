@@ -33,7 +33,7 @@ import (
 
 type dummyOpenShiftClusterValidator struct{}
 
-func (*dummyOpenShiftClusterValidator) Static(interface{}, *api.OpenShiftCluster, string, string, bool, string) error {
+func (*dummyOpenShiftClusterValidator) Static(interface{}, *api.OpenShiftCluster, string, string, bool, []*api.SupportedVMSize, string) error {
 	return nil
 }
 
@@ -2107,6 +2107,11 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 							},
 						},
 					},
+					OperatorFlags: v20240812preview.OperatorFlags{
+						operator.AutosizedNodesEnabled: operator.FlagTrue,
+						operator.BannerEnabled:         operator.FlagFalse,
+						operator.CheckerEnabled:        operator.FlagTrue,
+					},
 				},
 			},
 		},
@@ -2257,6 +2262,11 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 							"disk-csi-driver":          {ResourceID: mockMiResourceId},
 						},
 					},
+					OperatorFlags: v20240812preview.OperatorFlags{
+						operator.AutosizedNodesEnabled: operator.FlagTrue,
+						operator.BannerEnabled:         operator.FlagFalse,
+						operator.CheckerEnabled:        operator.FlagTrue,
+					},
 				},
 			},
 		},
@@ -3561,7 +3571,7 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 			defer controller.Finish()
 
 			mockQuotaValidator := mock_frontend.NewMockQuotaValidator(controller)
-			mockQuotaValidator.EXPECT().ValidateQuota(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(tt.quotaValidatorError).AnyTimes()
+			mockQuotaValidator.EXPECT().ValidateQuota(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(tt.quotaValidatorError).AnyTimes()
 
 			mockSkuValidator := mock_frontend.NewMockSkuValidator(controller)
 
@@ -11,12 +11,18 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/sirupsen/logrus"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"sigs.k8s.io/yaml"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
 )
 
+// credentialsFormatExec requests a kubeconfig which obtains short-lived
+// Microsoft Entra tokens through kubelogin instead of the kubeadmin password.
+const credentialsFormatExec = "exec"
+
 func (f *frontend) postOpenShiftClusterCredentials(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
@@ -35,17 +41,23 @@ func (f *frontend) postOpenShiftClusterCredentials(w http.ResponseWriter, r *htt
 		return
 	}
 
+	format := r.URL.Query().Get("format")
+	if format != "" && (format != credentialsFormatExec || f.apis[apiVersion].OpenShiftClusterExecCredentialsConverter == nil) {
+		api.WriteError(w, http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "format", "The provided format '%s' is not supported for api version '%s'.", format, apiVersion)
+		return
+	}
+
 	r.URL.Path = filepath.Dir(r.URL.Path)
 
-	b, err := f._postOpenShiftClusterCredentials(ctx, r, f.apis[apiVersion].OpenShiftClusterCredentialsConverter)
+	b, err := f._postOpenShiftClusterCredentials(ctx, r, f.apis[apiVersion].OpenShiftClusterCredentialsConverter, f.apis[apiVersion].OpenShiftClusterExecCredentialsConverter, format)
 
 	reply(log, w, nil, b, err)
 }
 
-func (f *frontend) _postOpenShiftClusterCredentials(ctx context.Context, r *http.Request, converter api.OpenShiftClusterCredentialsConverter) ([]byte, error) {
+func (f *frontend) _postOpenShiftClusterCredentials(ctx context.Context, r *http.Request, converter api.OpenShiftClusterCredentialsConverter, execConverter api.OpenShiftClusterExecCredentialsConverter, format string) ([]byte, error) {
 	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
 
-	_, err := f.validateSubscriptionState(ctx, r.URL.Path, api.SubscriptionStateRegistered)
+	subscription, err := f.validateSubscriptionState(ctx, r.URL.Path, api.SubscriptionStateRegistered)
 	if err != nil {
 		return nil, err
 	}
@@ -70,6 +82,15 @@ func (f *frontend) _postOpenShiftClusterCredentials(ctx context.Context, r *http
 		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeRequestNotAllowed, "", "Request is not allowed in provisioningState '%s'.", doc.OpenShiftCluster.Properties.ProvisioningState)
 	}
 
+	if format == credentialsFormatExec {
+		kubeconfig, err := f.makeExecKubeconfig(doc.OpenShiftCluster, subscription.Subscription.Properties.TenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.MarshalIndent(execConverter.ToExternal(kubeconfig), "", "    ")
+	}
+
 	doc.OpenShiftCluster.Properties.ClusterProfile.PullSecret = ""
 
 	if doc.OpenShiftCluster.Properties.ServicePrincipalProfile != nil {
@@ -79,3 +100,53 @@ func (f *frontend) _postOpenShiftClusterCredentials(ctx context.Context, r *http
 
 	return json.MarshalIndent(converter.ToExternal(doc.OpenShiftCluster), "", "    ")
 }
+
+// makeExecKubeconfig returns a kubeconfig for the cluster's public API server
+// which authenticates using short-lived Microsoft Entra tokens obtained by
+// kubelogin, rather than a long-lived client certificate or password.
+func (f *frontend) makeExecKubeconfig(oc *api.OpenShiftCluster, tenantID string) ([]byte, error) {
+	if oc.Properties.APIServerProfile.URL == "" {
+		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeRequestNotAllowed, "", "The API server URL of the cluster is not yet known.")
+	}
+
+	return yaml.Marshal(&clientcmdv1.Config{
+		Clusters: []clientcmdv1.NamedCluster{
+			{
+				Name: oc.Name,
+				Cluster: clientcmdv1.Cluster{
+					Server: oc.Properties.APIServerProfile.URL,
+				},
+			},
+		},
+		AuthInfos: []clientcmdv1.NamedAuthInfo{
+			{
+				Name: oc.Name,
+				AuthInfo: clientcmdv1.AuthInfo{
+					Exec: &clientcmdv1.ExecConfig{
+						APIVersion: "client.authentication.k8s.io/v1beta1",
+						Command:    "kubelogin",
+						Args: []string{
+							"get-token",
+							"--login", "azurecli",
+							"--environment", f.env.Environment().Name,
+							"--tenant-id", tenantID,
+							"--server-id", f.env.FPClientID(),
+						},
+						InstallHint:     "kubelogin is required to use this kubeconfig; see https://azure.github.io/kubelogin/install.html for installation instructions.",
+						InteractiveMode: clientcmdv1.IfAvailableExecInteractiveMode,
+					},
+				},
+			},
+		},
+		Contexts: []clientcmdv1.NamedContext{
+			{
+				Name: oc.Name,
+				Context: clientcmdv1.Context{
+					Cluster:  oc.Name,
+					AuthInfo: oc.Name,
+				},
+			},
+		},
+		CurrentContext: oc.Name,
+	})
+}
@@ -10,8 +10,12 @@ import (
 	"strings"
 	"testing"
 
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"sigs.k8s.io/yaml"
+
 	"github.com/Azure/ARO-RP/pkg/api"
 	v20200430 "github.com/Azure/ARO-RP/pkg/api/v20200430"
+	v20250725 "github.com/Azure/ARO-RP/pkg/api/v20250725"
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	"github.com/Azure/ARO-RP/pkg/metrics/noop"
 	testdatabase "github.com/Azure/ARO-RP/test/database"
@@ -298,3 +302,159 @@ func TestPostOpenShiftClusterCredentials(t *testing.T) {
 		})
 	}
 }
+
+func TestPostOpenShiftClusterExecCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	resourceID := fmt.Sprintf("/subscriptions/%s/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName", mockSubID)
+
+	type test struct {
+		name           string
+		apiVersion     string
+		format         string
+		apiServerURL   string
+		wantStatusCode int
+		wantResponse   func() *v20250725.OpenShiftClusterCredentials
+		wantError      string
+	}
+
+	for _, tt := range []*test{
+		{
+			name:           "exec credentials are returned",
+			apiVersion:     "2025-07-25",
+			format:         "exec",
+			apiServerURL:   "https://api.example.com:6443/",
+			wantStatusCode: http.StatusOK,
+			wantResponse: func() *v20250725.OpenShiftClusterCredentials {
+				kubeconfig, err := yaml.Marshal(&clientcmdv1.Config{
+					Clusters: []clientcmdv1.NamedCluster{
+						{
+							Name: "resourceName",
+							Cluster: clientcmdv1.Cluster{
+								Server: "https://api.example.com:6443/",
+							},
+						},
+					},
+					AuthInfos: []clientcmdv1.NamedAuthInfo{
+						{
+							Name: "resourceName",
+							AuthInfo: clientcmdv1.AuthInfo{
+								Exec: &clientcmdv1.ExecConfig{
+									APIVersion: "client.authentication.k8s.io/v1beta1",
+									Command:    "kubelogin",
+									Args: []string{
+										"get-token",
+										"--login", "azurecli",
+										"--environment", "AzurePublicCloud",
+										"--tenant-id", "11111111-1111-1111-1111-111111111111",
+										"--server-id", "00000000-0000-0000-0000-000000000001",
+									},
+									InstallHint:     "kubelogin is required to use this kubeconfig; see https://azure.github.io/kubelogin/install.html for installation instructions.",
+									InteractiveMode: clientcmdv1.IfAvailableExecInteractiveMode,
+								},
+							},
+						},
+					},
+					Contexts: []clientcmdv1.NamedContext{
+						{
+							Name: "resourceName",
+							Context: clientcmdv1.Context{
+								Cluster:  "resourceName",
+								AuthInfo: "resourceName",
+							},
+						},
+					},
+					CurrentContext: "resourceName",
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return &v20250725.OpenShiftClusterCredentials{
+					Kubeconfig: kubeconfig,
+				}
+			},
+		},
+		{
+			name:           "api server URL is not yet known",
+			apiVersion:     "2025-07-25",
+			format:         "exec",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      `400: RequestNotAllowed: : The API server URL of the cluster is not yet known.`,
+		},
+		{
+			name:           "unknown format",
+			apiVersion:     "2025-07-25",
+			format:         "invalid",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      `400: InvalidParameter: format: The provided format 'invalid' is not supported for api version '2025-07-25'.`,
+		},
+		{
+			name:           "exec format is not supported in the API version",
+			apiVersion:     "2020-04-30",
+			format:         "exec",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      `400: InvalidParameter: format: The provided format 'exec' is not supported for api version '2020-04-30'.`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			defer ti.done()
+
+			err := ti.buildFixtures(func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+							APIServerProfile: api.APIServerProfile{
+								URL: tt.apiServerURL,
+							},
+							KubeadminPassword: "password",
+						},
+					},
+				})
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+						Properties: &api.SubscriptionProperties{
+							TenantID: "11111111-1111-1111-1111-111111111111",
+						},
+					},
+				})
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.dbGroup, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodPost,
+				fmt.Sprintf("https://server%s/listcredentials?api-version=%s&format=%s", resourceID, tt.apiVersion, tt.format),
+				nil, nil)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var wantResponse interface{}
+			if tt.wantResponse != nil {
+				wantResponse = tt.wantResponse()
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	v20250725 "github.com/Azure/ARO-RP/pkg/api/v20250725"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestGetOpenShiftClusterFederatedIdentityCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	apis := map[string]*api.Version{
+		v20250725.APIVersion: api.APIs[v20250725.APIVersion],
+		"no-federated-identity-credentials": {
+			OpenShiftClusterConverter:       api.APIs[v20250725.APIVersion].OpenShiftClusterConverter,
+			OpenShiftClusterStaticValidator: api.APIs[v20250725.APIVersion].OpenShiftClusterStaticValidator,
+		},
+	}
+
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	resourceID := fmt.Sprintf("/subscriptions/%s/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName", mockSubID)
+	identityResourceID := fmt.Sprintf("/subscriptions/%s/resourcegroups/resourceGroup/providers/Microsoft.ManagedIdentity/userAssignedIdentities/", mockSubID)
+
+	type test struct {
+		name           string
+		resourceID     string
+		apiVersion     string
+		fixture        func(*testdatabase.Fixture)
+		dbError        error
+		wantStatusCode int
+		wantResponse   *v20250725.FederatedIdentityCredentialList
+		wantError      string
+	}
+
+	for _, tt := range []*test{
+		{
+			name:       "cluster exists in db",
+			resourceID: resourceID,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+							PlatformWorkloadIdentityProfile: &api.PlatformWorkloadIdentityProfile{
+								PlatformWorkloadIdentities: map[string]api.PlatformWorkloadIdentity{
+									"ClusterIngressOperator": {
+										ResourceID: identityResourceID + "cio",
+										FederatedIdentityCredentials: []api.FederatedIdentityCredential{
+											{
+												Name:      "cio-fic",
+												Issuer:    "https://issuer",
+												Subject:   "system:serviceaccount:openshift-ingress-operator:ingress-operator",
+												Audiences: []string{"openshift"},
+											},
+										},
+									},
+									"CloudControllerManager": {
+										ResourceID: identityResourceID + "ccm",
+										FederatedIdentityCredentials: []api.FederatedIdentityCredential{
+											{
+												Name:      "ccm-fic",
+												Issuer:    "https://issuer",
+												Subject:   "system:serviceaccount:openshift-cloud-controller-manager:cloud-controller-manager",
+												Audiences: []string{"openshift"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				})
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &v20250725.FederatedIdentityCredentialList{
+				FederatedIdentityCredentials: []*v20250725.FederatedIdentityCredential{
+					{
+						Name:               "ccm-fic",
+						OperatorName:       "CloudControllerManager",
+						IdentityResourceID: identityResourceID + "ccm",
+						Issuer:             "https://issuer",
+						Subject:            "system:serviceaccount:openshift-cloud-controller-manager:cloud-controller-manager",
+						Audiences:          []string{"openshift"},
+					},
+					{
+						Name:               "cio-fic",
+						OperatorName:       "ClusterIngressOperator",
+						IdentityResourceID: identityResourceID + "cio",
+						Issuer:             "https://issuer",
+						Subject:            "system:serviceaccount:openshift-ingress-operator:ingress-operator",
+						Audiences:          []string{"openshift"},
+					},
+				},
+			},
+		},
+		{
+			name:       "cluster uses a service principal",
+			resourceID: resourceID,
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+							ServicePrincipalProfile: &api.ServicePrincipalProfile{
+								ClientSecret: "clientSecret",
+							},
+						},
+					},
+				})
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      `400: RequestNotAllowed: : Federated identity credentials are only available for clusters using platform workload identities.`,
+		},
+		{
+			name:           "federated identity credentials request is not allowed in the API version",
+			resourceID:     resourceID,
+			apiVersion:     "no-federated-identity-credentials",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      `400: InvalidResourceType: : The resource type 'openshiftclusters' could not be found in the namespace 'microsoft.redhatopenshift' for api version 'no-federated-identity-credentials'.`,
+		},
+		{
+			name:           "cluster not found in db",
+			resourceID:     resourceID,
+			wantStatusCode: http.StatusNotFound,
+			wantError:      `404: ResourceNotFound: : The Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was not found.`,
+		},
+		{
+			name:           "internal error",
+			resourceID:     resourceID,
+			dbError:        &cosmosdb.Error{Code: "500", Message: "oh no!"},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      `500: InternalServerError: : Internal server error.`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters()
+			defer ti.done()
+
+			if tt.dbError != nil {
+				ti.openShiftClustersClient.SetError(tt.dbError)
+			}
+
+			err := ti.buildFixtures(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.dbGroup, apis, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			reqAPIVersion := v20250725.APIVersion
+			if tt.apiVersion != "" {
+				reqAPIVersion = tt.apiVersion
+			}
+
+			resp, b, err := ti.request(http.MethodGet,
+				fmt.Sprintf("https://server%s/federatedidentitycredentials?api-version=%s", tt.resourceID, reqAPIVersion),
+				nil, nil)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var wantResponse interface{}
+			if tt.wantResponse != nil {
+				wantResponse = tt.wantResponse
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
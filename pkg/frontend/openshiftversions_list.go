@@ -7,11 +7,14 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"sort"
 
+	"github.com/coreos/go-semver/semver"
 	"github.com/go-chi/chi/v5"
 	"github.com/sirupsen/logrus"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
 )
 
@@ -25,21 +28,62 @@ func (f *frontend) listInstallVersions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	versions := f.getEnabledInstallVersions(ctx)
+	sub, err := f.getSubscriptionProperties(ctx, chi.URLParam(r, "subscriptionId"))
+	if err != nil {
+		log.Error(err)
+		api.WriteError(w, http.StatusInternalServerError, api.CloudErrorCodeInternalServerError, "", "Internal server error.")
+		return
+	}
+
+	versions := f.getEnabledInstallVersions(ctx, sub)
 	converter := f.apis[apiVersion].OpenShiftVersionConverter
 
-	b, err := json.MarshalIndent(converter.ToExternalList(versions), "", "    ")
+	versions, nextLink, err := page(f, r, versions)
+	if err != nil {
+		reply(log, w, nil, nil, err)
+		return
+	}
+
+	b, err := json.MarshalIndent(converter.ToExternalList(versions, nextLink), "", "    ")
 	reply(log, w, nil, b, err)
 }
 
-func (f *frontend) getEnabledInstallVersions(ctx context.Context) []*api.OpenShiftVersion {
+// getSubscriptionProperties returns the properties of the subscription, or nil
+// if the subscription is not registered
+func (f *frontend) getSubscriptionProperties(ctx context.Context, subscriptionID string) (*api.SubscriptionProperties, error) {
+	dbSubscriptions, err := f.dbGroup.Subscriptions()
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := dbSubscriptions.Get(ctx, subscriptionID)
+	if cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return doc.Subscription.Properties, nil
+}
+
+// getEnabledInstallVersions returns the enabled install versions which are
+// available to the subscription
+func (f *frontend) getEnabledInstallVersions(ctx context.Context, sub *api.SubscriptionProperties) []*api.OpenShiftVersion {
 	versions := make([]*api.OpenShiftVersion, 0)
 
 	f.ocpVersionsMu.RLock()
 	for _, v := range f.enabledOcpVersions {
-		versions = append(versions, v)
+		if isInstallVersionAvailable(v, sub) {
+			versions = append(versions, v)
+		}
 	}
 	f.ocpVersionsMu.RUnlock()
 
+	// sort the versions so that pages are stable
+	sort.Slice(versions, func(i, j int) bool {
+		return semver.New(versions[i].Properties.Version).LessThan(*semver.New(versions[j].Properties.Version))
+	})
+
 	return versions
 }
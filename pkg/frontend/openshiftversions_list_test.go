@@ -5,9 +5,11 @@ package frontend
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"sort"
 	"testing"
 
@@ -15,7 +17,9 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	v20220904 "github.com/Azure/ARO-RP/pkg/api/v20220904"
+	v20240812preview "github.com/Azure/ARO-RP/pkg/api/v20240812preview"
 	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
 )
 
 func TestListInstallVersions(t *testing.T) {
@@ -27,8 +31,10 @@ func TestListInstallVersions(t *testing.T) {
 		name           string
 		changeFeed     map[string]*api.OpenShiftVersion
 		apiVersion     string
+		top            string
+		skipToken      string
 		wantStatusCode int
-		wantResponse   v20220904.OpenShiftVersionList
+		wantResponse   interface{}
 		wantError      string
 	}
 
@@ -67,6 +73,190 @@ func TestListInstallVersions(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "hide versions requiring a feature the subscription has not registered",
+			changeFeed: map[string]*api.OpenShiftVersion{
+				"4.11.0": {
+					Properties: api.OpenShiftVersionProperties{
+						Version: "4.11.0",
+						Enabled: true,
+					},
+				},
+				"4.12.0": {
+					Properties: api.OpenShiftVersionProperties{
+						Version:         "4.12.0",
+						Enabled:         true,
+						RequiredFeature: "Microsoft.RedHatOpenShift/PreviewVersions",
+					},
+				},
+			},
+			apiVersion:     "2022-09-04",
+			wantStatusCode: http.StatusOK,
+			wantResponse: v20220904.OpenShiftVersionList{
+				OpenShiftVersions: []*v20220904.OpenShiftVersion{
+					{
+						Properties: v20220904.OpenShiftVersionProperties{
+							Version: "4.11.0",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "return lifecycle dates",
+			changeFeed: map[string]*api.OpenShiftVersion{
+				"4.14.16": {
+					Properties: api.OpenShiftVersionProperties{
+						Version:          "4.14.16",
+						Enabled:          true,
+						GADate:           "2023-10-31",
+						EndOfSupportDate: "2025-05-01",
+						EndOfLifeDate:    "2025-10-31",
+					},
+				},
+			},
+			apiVersion:     "2024-08-12-preview",
+			wantStatusCode: http.StatusOK,
+			wantResponse: v20240812preview.OpenShiftVersionList{
+				OpenShiftVersions: []*v20240812preview.OpenShiftVersion{
+					{
+						Properties: v20240812preview.OpenShiftVersionProperties{
+							Version:          "4.14.16",
+							GADate:           "2023-10-31",
+							EndOfSupportDate: "2025-05-01",
+							EndOfLifeDate:    "2025-10-31",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "return multi-architecture support",
+			changeFeed: map[string]*api.OpenShiftVersion{
+				"4.14.16": {
+					Properties: api.OpenShiftVersionProperties{
+						Version:           "4.14.16",
+						Enabled:           true,
+						MultiArchPullspec: "example.com/ocp-release:4.14.16-multi",
+					},
+				},
+			},
+			apiVersion:     "2024-08-12-preview",
+			wantStatusCode: http.StatusOK,
+			wantResponse: v20240812preview.OpenShiftVersionList{
+				OpenShiftVersions: []*v20240812preview.OpenShiftVersion{
+					{
+						Properties: v20240812preview.OpenShiftVersionProperties{
+							Version:   "4.14.16",
+							MultiArch: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:           "return the first page of versions without $top",
+			changeFeed:     manyInstallVersions(defaultPageSize + 2),
+			apiVersion:     "2022-09-04",
+			wantStatusCode: http.StatusOK,
+			wantResponse: v20220904.OpenShiftVersionList{
+				OpenShiftVersions: manyExternalInstallVersions(defaultPageSize),
+				NextLink:          "https://mockrefererhost/?%24skipToken=" + url.QueryEscape(base64.StdEncoding.EncodeToString([]byte("FAKE10"))),
+			},
+		},
+		{
+			name: "return the first page of versions",
+			changeFeed: map[string]*api.OpenShiftVersion{
+				"4.11.0": {
+					Properties: api.OpenShiftVersionProperties{
+						Version: "4.11.0",
+						Enabled: true,
+					},
+				},
+				"4.11.5": {
+					Properties: api.OpenShiftVersionProperties{
+						Version: "4.11.5",
+						Enabled: true,
+					},
+				},
+				"4.12.0": {
+					Properties: api.OpenShiftVersionProperties{
+						Version: "4.12.0",
+						Enabled: true,
+						Default: true,
+					},
+				},
+			},
+			apiVersion:     "2022-09-04",
+			top:            "2",
+			wantStatusCode: http.StatusOK,
+			wantResponse: v20220904.OpenShiftVersionList{
+				OpenShiftVersions: []*v20220904.OpenShiftVersion{
+					{
+						Properties: v20220904.OpenShiftVersionProperties{
+							Version: "4.11.0",
+						},
+					},
+					{
+						Properties: v20220904.OpenShiftVersionProperties{
+							Version: "4.11.5",
+						},
+					},
+				},
+				NextLink: "https://mockrefererhost/?%24skipToken=" + url.QueryEscape(base64.StdEncoding.EncodeToString([]byte("FAKE2"))),
+			},
+		},
+		{
+			name: "return the last page of versions",
+			changeFeed: map[string]*api.OpenShiftVersion{
+				"4.11.0": {
+					Properties: api.OpenShiftVersionProperties{
+						Version: "4.11.0",
+						Enabled: true,
+					},
+				},
+				"4.11.5": {
+					Properties: api.OpenShiftVersionProperties{
+						Version: "4.11.5",
+						Enabled: true,
+					},
+				},
+				"4.12.0": {
+					Properties: api.OpenShiftVersionProperties{
+						Version: "4.12.0",
+						Enabled: true,
+						Default: true,
+					},
+				},
+			},
+			apiVersion:     "2022-09-04",
+			top:            "2",
+			skipToken:      base64.StdEncoding.EncodeToString([]byte("FAKE2")),
+			wantStatusCode: http.StatusOK,
+			wantResponse: v20220904.OpenShiftVersionList{
+				OpenShiftVersions: []*v20220904.OpenShiftVersion{
+					{
+						Properties: v20220904.OpenShiftVersionProperties{
+							Version: "4.12.0",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:           "invalid $top",
+			apiVersion:     "2022-09-04",
+			top:            "0",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      `400: InvalidParameter: $top: The provided $top '0' is invalid.`,
+		},
+		{
+			name:           "invalid $skipToken",
+			apiVersion:     "2022-09-04",
+			skipToken:      base64.StdEncoding.EncodeToString([]byte("FAKEinvalid")),
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      `400: InvalidParameter: $skipToken: The provided $skipToken is invalid.`,
+		},
 		{
 			name:           "api does not exist",
 			apiVersion:     "invalid",
@@ -78,7 +268,7 @@ func TestListInstallVersions(t *testing.T) {
 			ti := newTestInfra(t).WithSubscriptions().WithOpenShiftVersions()
 			defer ti.done()
 
-			frontend, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.dbGroup, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil, nil)
+			frontend, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.dbGroup, api.APIs, &noop.Noop{}, &noop.Noop{}, testdatabase.NewFakeAEAD(), nil, nil, nil, nil, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -95,15 +285,17 @@ func TestListInstallVersions(t *testing.T) {
 			frontend.ocpVersionsMu.Unlock()
 
 			resp, b, err := ti.request(method,
-				fmt.Sprintf("https://server/subscriptions/%s/providers/Microsoft.RedHatOpenShift/locations/%s/openshiftversions?api-version=%s", mockSubID, ti.env.Location(), tt.apiVersion),
-				nil, nil)
+				fmt.Sprintf("https://server/subscriptions/%s/providers/Microsoft.RedHatOpenShift/locations/%s/openshiftversions?api-version=%s&%%24top=%s&%%24skipToken=%s", mockSubID, ti.env.Location(), tt.apiVersion, tt.top, tt.skipToken),
+				http.Header{
+					"Referer": []string{"https://mockrefererhost/"},
+				}, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
 
 			// sort the response as the version order might be changed
 			if b != nil && resp.StatusCode == http.StatusOK {
-				var v v20220904.OpenShiftVersionList
+				var v v20240812preview.OpenShiftVersionList
 				if err = json.Unmarshal(b, &v); err != nil {
 					t.Error(err)
 				}
@@ -132,3 +324,35 @@ func TestListInstallVersions(t *testing.T) {
 		})
 	}
 }
+
+// manyInstallVersions returns a change feed of n enabled versions 4.11.0 to
+// 4.11.(n-1)
+func manyInstallVersions(n int) map[string]*api.OpenShiftVersion {
+	vers := map[string]*api.OpenShiftVersion{}
+	for i := 0; i < n; i++ {
+		v := fmt.Sprintf("4.11.%d", i)
+		vers[v] = &api.OpenShiftVersion{
+			Properties: api.OpenShiftVersionProperties{
+				Version: v,
+				Enabled: true,
+			},
+		}
+	}
+
+	return vers
+}
+
+// manyExternalInstallVersions returns the external representation of the
+// versions returned by manyInstallVersions, in order
+func manyExternalInstallVersions(n int) []*v20220904.OpenShiftVersion {
+	vers := make([]*v20220904.OpenShiftVersion, 0, n)
+	for i := 0; i < n; i++ {
+		vers = append(vers, &v20220904.OpenShiftVersion{
+			Properties: v20220904.OpenShiftVersionProperties{
+				Version: fmt.Sprintf("4.11.%d", i),
+			},
+		})
+	}
+
+	return vers
+}
@@ -7,12 +7,14 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"sort"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/sirupsen/logrus"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
 func (f *frontend) listPlatformWorkloadIdentityRoleSets(w http.ResponseWriter, r *http.Request) {
@@ -28,7 +30,13 @@ func (f *frontend) listPlatformWorkloadIdentityRoleSets(w http.ResponseWriter, r
 	roleSets := f.getAvailablePlatformWorkloadIdentityRoleSets(ctx)
 	converter := f.apis[apiVersion].PlatformWorkloadIdentityRoleSetConverter
 
-	b, err := json.MarshalIndent(converter.ToExternalList(roleSets), "", "    ")
+	roleSets, nextLink, err := page(f, r, roleSets)
+	if err != nil {
+		reply(log, w, nil, nil, err)
+		return
+	}
+
+	b, err := json.MarshalIndent(converter.ToExternalList(roleSets, nextLink), "", "    ")
 	reply(log, w, nil, b, err)
 }
 
@@ -41,5 +49,10 @@ func (f *frontend) getAvailablePlatformWorkloadIdentityRoleSets(ctx context.Cont
 	}
 	f.platformWorkloadIdentityRoleSetsMu.RUnlock()
 
+	// sort the role sets so that pages are stable
+	sort.Slice(roleSets, func(i, j int) bool {
+		return version.CreateSemverFromMinorVersionString(roleSets[i].Properties.OpenShiftVersion).LessThan(*version.CreateSemverFromMinorVersionString(roleSets[j].Properties.OpenShiftVersion))
+	})
+
 	return roleSets
 }
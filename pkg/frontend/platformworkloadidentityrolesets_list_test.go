@@ -2,9 +2,11 @@ package frontend
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"sort"
 	"testing"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/api/v20240812preview"
 	"github.com/Azure/ARO-RP/pkg/metrics/noop"
 	"github.com/Azure/ARO-RP/pkg/util/version"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
 )
 
 // Copyright (c) Microsoft Corporation.
@@ -26,6 +29,7 @@ func TestListPlatformWorkloadIdentityRoleSets(t *testing.T) {
 		name           string
 		changeFeed     map[string]*api.PlatformWorkloadIdentityRoleSet
 		apiVersion     string
+		top            string
 		wantStatusCode int
 		wantResponse   v20240812preview.PlatformWorkloadIdentityRoleSetList
 		wantError      string
@@ -108,6 +112,44 @@ func TestListPlatformWorkloadIdentityRoleSets(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:           "GET request without $top returns the first page of role sets",
+			changeFeed:     manyRoleSets(defaultPageSize + 2),
+			apiVersion:     "2024-08-12-preview",
+			wantStatusCode: http.StatusOK,
+			wantResponse: v20240812preview.PlatformWorkloadIdentityRoleSetList{
+				PlatformWorkloadIdentityRoleSets: manyExternalRoleSets(defaultPageSize),
+				NextLink:                         "https://mockrefererhost/?%24skipToken=" + url.QueryEscape(base64.StdEncoding.EncodeToString([]byte("FAKE10"))),
+			},
+		},
+		{
+			name: "GET request with $top returns a page of role sets",
+			changeFeed: map[string]*api.PlatformWorkloadIdentityRoleSet{
+				"4.14": {
+					Properties: api.PlatformWorkloadIdentityRoleSetProperties{
+						OpenShiftVersion: "4.14",
+					},
+				},
+				"4.15": {
+					Properties: api.PlatformWorkloadIdentityRoleSetProperties{
+						OpenShiftVersion: "4.15",
+					},
+				},
+			},
+			apiVersion:     "2024-08-12-preview",
+			top:            "1",
+			wantStatusCode: http.StatusOK,
+			wantResponse: v20240812preview.PlatformWorkloadIdentityRoleSetList{
+				PlatformWorkloadIdentityRoleSets: []*v20240812preview.PlatformWorkloadIdentityRoleSet{
+					{
+						Properties: v20240812preview.PlatformWorkloadIdentityRoleSetProperties{
+							OpenShiftVersion: "4.14",
+						},
+					},
+				},
+				NextLink: "https://mockrefererhost/?%24skipToken=" + url.QueryEscape(base64.StdEncoding.EncodeToString([]byte("FAKE1"))),
+			},
+		},
 		{
 			name:           "GET request with non-existent API version results in StatusBadRequest",
 			apiVersion:     "invalid",
@@ -125,7 +167,7 @@ func TestListPlatformWorkloadIdentityRoleSets(t *testing.T) {
 			ti := newTestInfra(t).WithSubscriptions().WithPlatformWorkloadIdentityRoleSets()
 			defer ti.done()
 
-			frontend, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.dbGroup, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil, nil)
+			frontend, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.dbGroup, api.APIs, &noop.Noop{}, &noop.Noop{}, testdatabase.NewFakeAEAD(), nil, nil, nil, nil, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -137,8 +179,10 @@ func TestListPlatformWorkloadIdentityRoleSets(t *testing.T) {
 			frontend.platformWorkloadIdentityRoleSetsMu.Unlock()
 
 			resp, b, err := ti.request(method,
-				fmt.Sprintf("https://server/subscriptions/%s/providers/Microsoft.RedHatOpenShift/locations/%s/platformworkloadidentityrolesets?api-version=%s", mockSubID, ti.env.Location(), tt.apiVersion),
-				nil, nil)
+				fmt.Sprintf("https://server/subscriptions/%s/providers/Microsoft.RedHatOpenShift/locations/%s/platformworkloadidentityrolesets?api-version=%s&%%24top=%s", mockSubID, ti.env.Location(), tt.apiVersion, tt.top),
+				http.Header{
+					"Referer": []string{"https://mockrefererhost/"},
+				}, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -174,3 +218,34 @@ func TestListPlatformWorkloadIdentityRoleSets(t *testing.T) {
 		})
 	}
 }
+
+// manyRoleSets returns a change feed of n role sets for OpenShift versions 4.0
+// to 4.(n-1)
+func manyRoleSets(n int) map[string]*api.PlatformWorkloadIdentityRoleSet {
+	roleSets := map[string]*api.PlatformWorkloadIdentityRoleSet{}
+	for i := 0; i < n; i++ {
+		v := fmt.Sprintf("4.%d", i)
+		roleSets[v] = &api.PlatformWorkloadIdentityRoleSet{
+			Properties: api.PlatformWorkloadIdentityRoleSetProperties{
+				OpenShiftVersion: v,
+			},
+		}
+	}
+
+	return roleSets
+}
+
+// manyExternalRoleSets returns the external representation of the role sets
+// returned by manyRoleSets, in order
+func manyExternalRoleSets(n int) []*v20240812preview.PlatformWorkloadIdentityRoleSet {
+	roleSets := make([]*v20240812preview.PlatformWorkloadIdentityRoleSet, 0, n)
+	for i := 0; i < n; i++ {
+		roleSets = append(roleSets, &v20240812preview.PlatformWorkloadIdentityRoleSet{
+			Properties: v20240812preview.PlatformWorkloadIdentityRoleSetProperties{
+				OpenShiftVersion: fmt.Sprintf("4.%d", i),
+			},
+		})
+	}
+
+	return roleSets
+}
@@ -77,7 +77,7 @@ func TestValidateQuota(t *testing.T) {
 		},
 		{
 			name:    "not enough cores",
-			wantErr: "400: ResourceQuotaExceeded: : Resource quota of cores exceeded. Maximum allowed: 212, Current in use: 101, Additional requested: 112.",
+			wantErr: "400: ResourceQuotaExceeded: cores: Resource quota of cores exceeded. Maximum allowed: 212, Current in use: 101, Available: 111, Additional requested: 112.",
 			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_armnetwork.MockUsagesClient) {
 				cuc.EXPECT().
 					List(ctx, "ocLocation").
@@ -90,11 +90,14 @@ func TestValidateQuota(t *testing.T) {
 							Limit:        to.Int64Ptr(212),
 						},
 					}, nil)
+				nuc.EXPECT().
+					List(ctx, "ocLocation", nil).
+					Return([]*sdknetwork.Usage{}, nil)
 			},
 		},
 		{
 			name:    "not enough virtualMachines",
-			wantErr: "400: ResourceQuotaExceeded: : Resource quota of virtualMachines exceeded. Maximum allowed: 114, Current in use: 101, Additional requested: 14.",
+			wantErr: "400: ResourceQuotaExceeded: virtualMachines: Resource quota of virtualMachines exceeded. Maximum allowed: 114, Current in use: 101, Available: 13, Additional requested: 14.",
 			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_armnetwork.MockUsagesClient) {
 				cuc.EXPECT().
 					List(ctx, "ocLocation").
@@ -107,11 +110,14 @@ func TestValidateQuota(t *testing.T) {
 							Limit:        to.Int64Ptr(114),
 						},
 					}, nil)
+				nuc.EXPECT().
+					List(ctx, "ocLocation", nil).
+					Return([]*sdknetwork.Usage{}, nil)
 			},
 		},
 		{
 			name:    "not enough standardDSv3Family",
-			wantErr: "400: ResourceQuotaExceeded: : Resource quota of standardDSv3Family exceeded. Maximum allowed: 212, Current in use: 101, Additional requested: 112.",
+			wantErr: "400: ResourceQuotaExceeded: standardDSv3Family: Resource quota of standardDSv3Family exceeded. Maximum allowed: 212, Current in use: 101, Available: 111, Additional requested: 112. Required by: properties.masterProfile.vmSize (4 x Standard_D8s_v3, 8 cores each), properties.workerProfiles[0].vmSize (10 x Standard_D8s_v3, 8 cores each).",
 			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_armnetwork.MockUsagesClient) {
 				cuc.EXPECT().
 					List(ctx, "ocLocation").
@@ -124,11 +130,14 @@ func TestValidateQuota(t *testing.T) {
 							Limit:        to.Int64Ptr(212),
 						},
 					}, nil)
+				nuc.EXPECT().
+					List(ctx, "ocLocation", nil).
+					Return([]*sdknetwork.Usage{}, nil)
 			},
 		},
 		{
 			name:    "not enough premium disks",
-			wantErr: "400: ResourceQuotaExceeded: : Resource quota of PremiumDiskCount exceeded. Maximum allowed: 114, Current in use: 101, Additional requested: 14.",
+			wantErr: "400: ResourceQuotaExceeded: PremiumDiskCount: Resource quota of PremiumDiskCount exceeded. Maximum allowed: 114, Current in use: 101, Available: 13, Additional requested: 14.",
 			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_armnetwork.MockUsagesClient) {
 				cuc.EXPECT().
 					List(ctx, "ocLocation").
@@ -141,11 +150,14 @@ func TestValidateQuota(t *testing.T) {
 							Limit:        to.Int64Ptr(114),
 						},
 					}, nil)
+				nuc.EXPECT().
+					List(ctx, "ocLocation", nil).
+					Return([]*sdknetwork.Usage{}, nil)
 			},
 		},
 		{
 			name:    "not enough public ip addresses",
-			wantErr: "400: ResourceQuotaExceeded: : Resource quota of PublicIPAddresses exceeded. Maximum allowed: 6, Current in use: 4, Additional requested: 3.",
+			wantErr: "400: ResourceQuotaExceeded: PublicIPAddresses: Resource quota of PublicIPAddresses exceeded. Maximum allowed: 6, Current in use: 4, Available: 2, Additional requested: 3.",
 			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_armnetwork.MockUsagesClient) {
 				cuc.EXPECT().
 					List(ctx, "ocLocation").
@@ -163,6 +175,36 @@ func TestValidateQuota(t *testing.T) {
 					}, nil)
 			},
 		},
+		{
+			name: "multiple quotas exceeded",
+			wantErr: "400: ResourceQuotaExceeded: : Resource quotas of standardDSv3Family, PublicIPAddresses exceeded. Details: " +
+				"ResourceQuotaExceeded: standardDSv3Family: Resource quota of standardDSv3Family exceeded. Maximum allowed: 100, Current in use: 0, Available: 100, Additional requested: 112. Required by: properties.masterProfile.vmSize (4 x Standard_D8s_v3, 8 cores each), properties.workerProfiles[0].vmSize (10 x Standard_D8s_v3, 8 cores each)., " +
+				"ResourceQuotaExceeded: PublicIPAddresses: Resource quota of PublicIPAddresses exceeded. Maximum allowed: 10, Current in use: 12, Available: 0, Additional requested: 3.",
+			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_armnetwork.MockUsagesClient) {
+				cuc.EXPECT().
+					List(ctx, "ocLocation").
+					Return([]mgmtcompute.Usage{
+						{
+							Name: &mgmtcompute.UsageName{
+								Value: to.StringPtr("standardDSv3Family"),
+							},
+							CurrentValue: to.Int32Ptr(0),
+							Limit:        to.Int64Ptr(100),
+						},
+					}, nil)
+				nuc.EXPECT().
+					List(ctx, "ocLocation", nil).
+					Return([]*sdknetwork.Usage{
+						{
+							Name: &sdknetwork.UsageName{
+								Value: to.StringPtr("PublicIPAddresses"),
+							},
+							CurrentValue: to.Int64Ptr(12),
+							Limit:        to.Int64Ptr(10),
+						},
+					}, nil)
+			},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			controller := gomock.NewController(t)
@@ -192,7 +234,7 @@ func TestValidateQuota(t *testing.T) {
 				},
 			}
 
-			err := validateQuota(ctx, oc, networkUsageClient, computeUsageClient)
+			err := validateQuota(ctx, oc, nil, networkUsageClient, computeUsageClient)
 			utilerror.AssertErrorMessage(t, err, tt.wantErr)
 		})
 	}
@@ -5,7 +5,9 @@ package frontend
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/api/validate"
@@ -16,29 +18,64 @@ import (
 )
 
 type QuotaValidator interface {
-	ValidateQuota(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string, oc *api.OpenShiftCluster) error
+	ValidateQuota(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string, oc *api.OpenShiftCluster, additionalVMSizes []*api.SupportedVMSize) error
 }
 
 type quotaValidator struct{}
 
-func addRequiredResources(requiredResources map[string]int, vmSize api.VMSize, count int) error {
-	vm, ok := validate.VMSizeFromName(vmSize)
+// quotaRequirements tracks the quantity of each quota required by the cluster
+// and, for VM family quotas, which profiles require it
+type quotaRequirements struct {
+	additionalVMSizes []*api.SupportedVMSize
+	required          map[string]int
+	requiredBy        map[string][]string
+}
+
+func (r *quotaRequirements) add(path string, vmSize api.VMSize, count int) error {
+	vm, ok := validate.VMSizeFromName(vmSize, r.additionalVMSizes)
 	if !ok {
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "", "The provided VM SKU %s is not supported.", vmSize)
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided VM SKU %s is not supported.", vmSize)
 	}
 
-	requiredResources["virtualMachines"] += count
-	requiredResources["PremiumDiskCount"] += count
+	r.required["virtualMachines"] += count
+	r.required["PremiumDiskCount"] += count
+
+	r.required[vm.Family] += vm.CoreCount * count
+	r.required["cores"] += vm.CoreCount * count
 
-	requiredResources[vm.Family] += vm.CoreCount * count
-	requiredResources["cores"] += vm.CoreCount * count
+	r.requiredBy[vm.Family] = append(r.requiredBy[vm.Family], fmt.Sprintf("%s (%d x %s, %d cores each)", path, count, vmSize, vm.CoreCount))
 	return nil
 }
 
+// exceeded returns the error body for the named quota if the required
+// quantity exceeds the available quota, or nil otherwise
+func (r *quotaRequirements) exceeded(name string, limit, currentValue int64) *api.CloudErrorBody {
+	required, present := r.required[name]
+	if !present || int64(required) <= limit-currentValue {
+		return nil
+	}
+
+	available := limit - currentValue
+	if available < 0 {
+		available = 0
+	}
+
+	message := fmt.Sprintf("Resource quota of %s exceeded. Maximum allowed: %d, Current in use: %d, Available: %d, Additional requested: %d.", name, limit, currentValue, available, required)
+	if len(r.requiredBy[name]) > 0 {
+		message += fmt.Sprintf(" Required by: %s.", strings.Join(r.requiredBy[name], ", "))
+	}
+
+	return &api.CloudErrorBody{
+		Code:    api.CloudErrorCodeResourceQuotaExceeded,
+		Target:  name,
+		Message: message,
+	}
+}
+
 // ValidateQuota checks usage quotas vs. resources required by cluster before cluster
 // creation
 // It is a method on struct so we can make use of interfaces.
-func (q quotaValidator) ValidateQuota(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string, oc *api.OpenShiftCluster) error {
+func (q quotaValidator) ValidateQuota(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string, oc *api.OpenShiftCluster, additionalVMSizes []*api.SupportedVMSize) error {
 	fpAuthorizer, err := environment.FPAuthorizer(tenantID, nil, environment.Environment().ResourceManagerScope)
 	if err != nil {
 		return err
@@ -56,29 +93,33 @@ func (q quotaValidator) ValidateQuota(ctx context.Context, azEnv *azureclient.AR
 		return err
 	}
 
-	return validateQuota(ctx, oc, spNetworkUsage, spComputeUsage)
+	return validateQuota(ctx, oc, additionalVMSizes, spNetworkUsage, spComputeUsage)
 }
 
-func validateQuota(ctx context.Context, oc *api.OpenShiftCluster, spNetworkUsage armnetwork.UsagesClient, spComputeUsage compute.UsageClient) error {
+func validateQuota(ctx context.Context, oc *api.OpenShiftCluster, additionalVMSizes []*api.SupportedVMSize, spNetworkUsage armnetwork.UsagesClient, spComputeUsage compute.UsageClient) error {
 	// If ValidateQuota runs outside install process, we should skip quota validation
-	requiredResources := map[string]int{}
+	requiredResources := &quotaRequirements{
+		additionalVMSizes: additionalVMSizes,
+		required:          map[string]int{},
+		requiredBy:        map[string][]string{},
+	}
 
-	err := addRequiredResources(requiredResources, oc.Properties.MasterProfile.VMSize, 4)
+	err := requiredResources.add("properties.masterProfile.vmSize", oc.Properties.MasterProfile.VMSize, 4)
 	if err != nil {
 		return err
 	}
 
-	workerProfiles, _ := api.GetEnrichedWorkerProfiles(oc.Properties)
+	workerProfiles, propertyName := api.GetEnrichedWorkerProfiles(oc.Properties)
 	//worker node resource calculation
-	for _, w := range workerProfiles {
-		err := addRequiredResources(requiredResources, w.VMSize, w.Count)
+	for i, w := range workerProfiles {
+		err := requiredResources.add(fmt.Sprintf("properties.%s[%d].vmSize", propertyName, i), w.VMSize, w.Count)
 		if err != nil {
 			return err
 		}
 	}
 
 	//Public IP Addresses minimum requirement: 2 for ARM template deployment and 1 for kube-controller-manager
-	requiredResources["PublicIPAddresses"] = 3
+	requiredResources.required["PublicIPAddresses"] = 3
 
 	//check requirements vs. usage
 
@@ -91,10 +132,10 @@ func validateQuota(ctx context.Context, oc *api.OpenShiftCluster, spNetworkUsage
 		return err
 	}
 
+	exceeded := []api.CloudErrorBody{}
 	for _, usage := range computeUsages {
-		required, present := requiredResources[*usage.Name.Value]
-		if present && int64(required) > (*usage.Limit-int64(*usage.CurrentValue)) {
-			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeResourceQuotaExceeded, "", "Resource quota of %s exceeded. Maximum allowed: %d, Current in use: %d, Additional requested: %d.", *usage.Name.Value, *usage.Limit, *usage.CurrentValue, required)
+		if body := requiredResources.exceeded(*usage.Name.Value, *usage.Limit, int64(*usage.CurrentValue)); body != nil {
+			exceeded = append(exceeded, *body)
 		}
 	}
 
@@ -104,11 +145,32 @@ func validateQuota(ctx context.Context, oc *api.OpenShiftCluster, spNetworkUsage
 	}
 
 	for _, netUsage := range netUsages {
-		required, present := requiredResources[*netUsage.Name.Value]
-		if present && int64(required) > (*netUsage.Limit-*netUsage.CurrentValue) {
-			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeResourceQuotaExceeded, "", "Resource quota of %s exceeded. Maximum allowed: %d, Current in use: %d, Additional requested: %d.", *netUsage.Name.Value, *netUsage.Limit, *netUsage.CurrentValue, required)
+		if body := requiredResources.exceeded(*netUsage.Name.Value, *netUsage.Limit, *netUsage.CurrentValue); body != nil {
+			exceeded = append(exceeded, *body)
 		}
 	}
 
-	return nil
+	switch len(exceeded) {
+	case 0:
+		return nil
+	case 1:
+		return &api.CloudError{
+			StatusCode:     http.StatusBadRequest,
+			CloudErrorBody: &exceeded[0],
+		}
+	}
+
+	names := make([]string, 0, len(exceeded))
+	for _, body := range exceeded {
+		names = append(names, body.Target)
+	}
+
+	return &api.CloudError{
+		StatusCode: http.StatusBadRequest,
+		CloudErrorBody: &api.CloudErrorBody{
+			Code:    api.CloudErrorCodeResourceQuotaExceeded,
+			Message: fmt.Sprintf("Resource quotas of %s exceeded.", strings.Join(names, ", ")),
+			Details: exceeded,
+		},
+	}
 }
@@ -23,8 +23,9 @@ func (f *frontend) checkReady() bool {
 
 	_, okOcpVersions := f.lastOcpVersionsChangefeed.Load().(time.Time)
 	_, okPlatformWorkloadIdentityRoleSets := f.lastPlatformWorkloadIdentityRoleSetsChangefeed.Load().(time.Time)
+	_, okSupportedVMSizes := f.lastSupportedVMSizesChangefeed.Load().(time.Time)
 
-	return okOcpVersions && okPlatformWorkloadIdentityRoleSets &&
+	return okOcpVersions && okPlatformWorkloadIdentityRoleSets && okSupportedVMSizes &&
 		f.ready.Load().(bool) &&
 		f.env.ArmClientAuthorizer().IsReady() &&
 		f.env.AdminClientAuthorizer().IsReady()
@@ -0,0 +1,98 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"regexp"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/mirror"
+)
+
+var rxReleaseDigest = regexp.MustCompile(`@(sha256:[0-9a-f]{64})$`)
+
+// releaseVerifier verifies that a signature store has a valid signature for
+// the release payload with the given digest
+type releaseVerifier interface {
+	Verify(ctx context.Context, digest string) error
+}
+
+// newReleaseVerifier returns a releaseVerifier which trusts the keyring at
+// RELEASE_KEYRING and fetches signatures from RELEASE_SIGNATURE_STORE, or the
+// Red Hat signature store if that is unset.  If RELEASE_KEYRING is unset,
+// release payloads are not verified.
+func newReleaseVerifier() (releaseVerifier, error) {
+	path := os.Getenv("RELEASE_KEYRING")
+	if path == "" {
+		return nil, nil
+	}
+
+	keyring, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	store := os.Getenv("RELEASE_SIGNATURE_STORE")
+	if store == "" {
+		store = mirror.DefaultReleaseSignatureStore
+	}
+
+	v, err := mirror.NewReleaseVerifier(keyring, store)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// onboardOpenShiftVersion verifies the release payload of a version which is
+// being enabled, or whose payload is changing while it is enabled, and
+// records who enabled it and when.  current is nil if the version is new.
+func (f *frontend) onboardOpenShiftVersion(ctx context.Context, current *api.OpenShiftVersionProperties, v *api.OpenShiftVersion) error {
+	if !v.Properties.Enabled {
+		return nil
+	}
+
+	if current != nil && current.Enabled &&
+		current.OpenShiftPullspec == v.Properties.OpenShiftPullspec &&
+		current.MultiArchPullspec == v.Properties.MultiArchPullspec {
+		return nil
+	}
+
+	if f.releaseVerifier != nil {
+		for _, release := range []struct {
+			path     string
+			pullspec string
+		}{
+			{path: "properties.openShiftPullspec", pullspec: v.Properties.OpenShiftPullspec},
+			{path: "properties.multiArchPullspec", pullspec: v.Properties.MultiArchPullspec},
+		} {
+			if release.pullspec == "" {
+				continue
+			}
+
+			m := rxReleaseDigest.FindStringSubmatch(release.pullspec)
+			if m == nil {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, release.path, "The release image must be referenced by digest.")
+			}
+
+			err := f.releaseVerifier.Verify(ctx, m[1])
+			if err != nil {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, release.path, "The release image signature could not be verified: %s.", err)
+			}
+		}
+	}
+
+	now := f.now().UTC()
+	v.Properties.EnabledAt = &now
+	v.Properties.EnabledBy = ""
+	if correlationData := api.GetCorrelationDataFromCtx(ctx); correlationData != nil {
+		v.Properties.EnabledBy = correlationData.ClientPrincipalName
+	}
+
+	return nil
+}
@@ -87,6 +87,7 @@ func TestSecurity(t *testing.T) {
 	f.startTime = time.Time{}
 	f.lastOcpVersionsChangefeed.Store(time.Time{})
 	f.lastPlatformWorkloadIdentityRoleSetsChangefeed.Store(time.Time{})
+	f.lastSupportedVMSizesChangefeed.Store(time.Time{})
 
 	go f.Run(ctx, nil, nil)
 
@@ -84,6 +84,8 @@ type testInfra struct {
 	openShiftVersionsDatabase                database.OpenShiftVersions
 	platformWorkloadIdentityRoleSetsClient   *cosmosdb.FakePlatformWorkloadIdentityRoleSetDocumentClient
 	platformWorkloadIdentityRoleSetsDatabase database.PlatformWorkloadIdentityRoleSets
+	supportedVMSizesClient                   *cosmosdb.FakeSupportedVMSizeDocumentClient
+	supportedVMSizesDatabase                 database.SupportedVMSizes
 	maintenanceManifestsClient               *cosmosdb.FakeMaintenanceManifestDocumentClient
 	maintenanceManifestsDatabase             database.MaintenanceManifests
 }
@@ -112,6 +114,7 @@ func newTestInfraWithFeatures(t *testing.T, features map[env.Feature]bool) *test
 	_env.EXPECT().ArmClientAuthorizer().AnyTimes().Return(clientauthorizer.NewOne(clientcerts[0].Raw))
 	_env.EXPECT().AdminClientAuthorizer().AnyTimes().Return(clientauthorizer.NewOne(clientcerts[0].Raw))
 	_env.EXPECT().Domain().AnyTimes().Return("aro.example")
+	_env.EXPECT().FPClientID().AnyTimes().Return("00000000-0000-0000-0000-000000000001")
 	_env.EXPECT().Listen().AnyTimes().Return(l, nil)
 	for f, val := range features {
 		_env.EXPECT().FeatureIsSet(f).AnyTimes().Return(val)
@@ -201,6 +204,14 @@ func (ti *testInfra) WithPlatformWorkloadIdentityRoleSets() *testInfra {
 	return ti
 }
 
+func (ti *testInfra) WithSupportedVMSizes() *testInfra {
+	uuid := deterministicuuid.NewTestUUIDGenerator(9)
+	ti.supportedVMSizesDatabase, ti.supportedVMSizesClient = testdatabase.NewFakeSupportedVMSizes(uuid)
+	ti.fixture.WithSupportedVMSizes(ti.supportedVMSizesDatabase, uuid)
+	ti.dbGroup.WithSupportedVMSizes(ti.supportedVMSizesDatabase)
+	return ti
+}
+
 func (ti *testInfra) WithClusterManagerConfigurations() *testInfra {
 	ti.clusterManagerDatabase, ti.clusterManagerClient = testdatabase.NewFakeClusterManager()
 	ti.fixture.WithClusterManagerConfigurations(ti.clusterManagerDatabase)
@@ -30,6 +30,13 @@ func TestValidateVMSku(t *testing.T) {
 		availableSku          string
 		availableSku2         string
 		restrictedSku         string
+		availableSkuZones     []string
+		noPremiumIO           bool
+		noEncryptionAtHost    bool
+		encryptionAtHost      bool
+		noTrustedLaunch       bool
+		trustedLaunch         bool
+		confidentialVM        bool
 		resourceSkusClientErr error
 		wpStatus              bool
 		wantErr               string
@@ -146,11 +153,142 @@ func TestValidateVMSku(t *testing.T) {
 			restrictedSku:     "Standard_L80",
 			wantErr:           "400: InvalidParameter: properties.masterProfile.VMSize: The selected SKU 'Standard_L80' is restricted in region 'eastus' for selected subscription",
 		},
+		{
+			name:              "sku is valid in a region without availability zones",
+			workerProfile1Sku: "Standard_D4s_v2",
+			workerProfile2Sku: "Standard_D4s_v2",
+			masterProfileSku:  "Standard_D4s_v2",
+			availableSku:      "Standard_D4s_v2",
+			availableSkuZones: []string{},
+		},
+		{
+			name:              "master sku is only available in some availability zones",
+			workerProfile1Sku: "Standard_D4s_v2",
+			workerProfile2Sku: "Standard_D4s_v2",
+			masterProfileSku:  "Standard_D4s_v2",
+			availableSku:      "Standard_D4s_v2",
+			availableSkuZones: []string{"1", "2"},
+			wantErr:           "400: InvalidParameter: properties.masterProfile.VMSize: The selected SKU 'Standard_D4s_v2' is only available in availability zones [1 2] in region 'eastus', but 3 are required",
+		},
+		{
+			name:              "master sku does not support premium storage",
+			workerProfile1Sku: "Standard_D4s_v2",
+			workerProfile2Sku: "Standard_D4s_v2",
+			masterProfileSku:  "Standard_D4s_v2",
+			availableSku:      "Standard_D4s_v2",
+			noPremiumIO:       true,
+			wantErr:           "400: InvalidParameter: properties.masterProfile.VMSize: The selected SKU 'Standard_D4s_v2' does not support premium storage in region 'eastus'",
+		},
+		{
+			name:              "worker sku does not need premium storage",
+			workerProfile1Sku: "Standard_D4_v2",
+			workerProfile2Sku: "Standard_D4_v2",
+			masterProfileSku:  "Standard_D4s_v2",
+			availableSku:      "Standard_D4_v2",
+			availableSku2:     "Standard_D4s_v2",
+			noPremiumIO:       true,
+		},
+		{
+			name:              "skus support encryption at host",
+			workerProfile1Sku: "Standard_D4s_v2",
+			workerProfile2Sku: "Standard_D4s_v2",
+			masterProfileSku:  "Standard_D4s_v2",
+			availableSku:      "Standard_D4s_v2",
+			encryptionAtHost:  true,
+		},
+		{
+			name:               "worker sku does not support encryption at host",
+			workerProfile1Sku:  "Standard_D4_v2",
+			workerProfile2Sku:  "Standard_D4_v2",
+			masterProfileSku:   "Standard_D4s_v2",
+			availableSku:       "Standard_D4_v2",
+			availableSku2:      "Standard_D4s_v2",
+			noPremiumIO:        true,
+			noEncryptionAtHost: true,
+			encryptionAtHost:   true,
+			wantErr:            "400: InvalidParameter: properties.workerProfiles[0].encryptionAtHost: VM SKU 'Standard_D4_v2' does not support encryption at host.",
+		},
+		{
+			name:              "skus support trusted launch",
+			workerProfile1Sku: "Standard_D4s_v2",
+			workerProfile2Sku: "Standard_D4s_v2",
+			masterProfileSku:  "Standard_D4s_v2",
+			availableSku:      "Standard_D4s_v2",
+			trustedLaunch:     true,
+		},
+		{
+			name:              "master sku does not support trusted launch",
+			workerProfile1Sku: "Standard_D4s_v2",
+			workerProfile2Sku: "Standard_D4s_v2",
+			masterProfileSku:  "Standard_D4s_v2",
+			availableSku:      "Standard_D4s_v2",
+			noTrustedLaunch:   true,
+			trustedLaunch:     true,
+			wantErr:           "400: InvalidParameter: properties.masterProfile.securityType: VM SKU 'Standard_D4s_v2' does not support Trusted Launch.",
+		},
+		{
+			name:              "worker sku does not support trusted launch",
+			workerProfile1Sku: "Standard_D4_v2",
+			workerProfile2Sku: "Standard_D4_v2",
+			masterProfileSku:  "Standard_D4s_v2",
+			availableSku:      "Standard_D4_v2",
+			availableSku2:     "Standard_D4s_v2",
+			noPremiumIO:       true,
+			noTrustedLaunch:   true,
+			trustedLaunch:     true,
+			wantErr:           "400: InvalidParameter: properties.workerProfiles[0].securityType: VM SKU 'Standard_D4_v2' does not support Trusted Launch.",
+		},
+		{
+			name:              "worker sku supports confidential vms",
+			workerProfile1Sku: "Standard_DC4as_v5",
+			workerProfile2Sku: "Standard_DC4as_v5",
+			masterProfileSku:  "Standard_D4s_v2",
+			availableSku:      "Standard_DC4as_v5",
+			availableSku2:     "Standard_D4s_v2",
+			confidentialVM:    true,
+		},
+		{
+			name:              "worker sku does not support confidential vms in region",
+			workerProfile1Sku: "Standard_DC4as_v5",
+			workerProfile2Sku: "Standard_DC4as_v5",
+			masterProfileSku:  "Standard_D4s_v2",
+			availableSku:      "Standard_DC4as_v5",
+			availableSku2:     "Standard_D4s_v2",
+			wantErr:           "400: InvalidParameter: properties.workerProfiles[0].securityType: VM SKU 'Standard_DC4as_v5' does not support confidential VMs in region 'eastus'.",
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.restrictedZones == nil {
 				tt.restrictedZones = []string{"1", "2", "3"}
 			}
+			if tt.availableSkuZones == nil {
+				tt.availableSkuZones = []string{"1", "2", "3"}
+			}
+
+			capabilities := func(premiumIO, encryptionAtHost, trustedLaunch, confidentialVM bool) *[]mgmtcompute.ResourceSkuCapabilities {
+				value := map[bool]string{true: "True", false: "False"}
+				hyperVGenerations := map[bool]string{true: "V1,V2", false: "V1"}
+				c := []mgmtcompute.ResourceSkuCapabilities{
+					{Name: to.StringPtr("PremiumIO"), Value: to.StringPtr(value[premiumIO])},
+					{Name: to.StringPtr("EncryptionAtHostSupported"), Value: to.StringPtr(value[encryptionAtHost])},
+					{Name: to.StringPtr("HyperVGenerations"), Value: to.StringPtr(hyperVGenerations[trustedLaunch])},
+					{Name: to.StringPtr("TrustedLaunchDisabled"), Value: to.StringPtr(value[!trustedLaunch])},
+				}
+				if confidentialVM {
+					c = append(c, mgmtcompute.ResourceSkuCapabilities{Name: to.StringPtr("ConfidentialComputingType"), Value: to.StringPtr("SNP")})
+				}
+				return &c
+			}
+
+			encryptionAtHost := api.EncryptionAtHostDisabled
+			if tt.encryptionAtHost {
+				encryptionAtHost = api.EncryptionAtHostEnabled
+			}
+
+			securityType := api.SecurityTypeStandard
+			if tt.trustedLaunch {
+				securityType = api.SecurityTypeTrustedLaunch
+			}
 
 			controller := gomock.NewController(t)
 			defer controller.Finish()
@@ -160,14 +298,20 @@ func TestValidateVMSku(t *testing.T) {
 				Properties: api.OpenShiftClusterProperties{
 					WorkerProfiles: []api.WorkerProfile{
 						{
-							VMSize: api.VMSize(tt.workerProfile1Sku),
+							VMSize:           api.VMSize(tt.workerProfile1Sku),
+							EncryptionAtHost: encryptionAtHost,
+							SecurityType:     securityType,
 						},
 						{
-							VMSize: api.VMSize(tt.workerProfile2Sku),
+							VMSize:           api.VMSize(tt.workerProfile2Sku),
+							EncryptionAtHost: encryptionAtHost,
+							SecurityType:     securityType,
 						},
 					},
 					MasterProfile: api.MasterProfile{
-						VMSize: api.VMSize(tt.masterProfileSku),
+						VMSize:           api.VMSize(tt.masterProfileSku),
+						EncryptionAtHost: encryptionAtHost,
+						SecurityType:     securityType,
 					},
 				},
 			}
@@ -177,20 +321,20 @@ func TestValidateVMSku(t *testing.T) {
 					Name:      &tt.availableSku,
 					Locations: &[]string{"eastus"},
 					LocationInfo: &[]mgmtcompute.ResourceSkuLocationInfo{
-						{Zones: &[]string{"1, 2, 3"}},
+						{Zones: &tt.availableSkuZones},
 					},
 					Restrictions: &[]mgmtcompute.ResourceSkuRestrictions{},
-					Capabilities: &[]mgmtcompute.ResourceSkuCapabilities{},
+					Capabilities: capabilities(!tt.noPremiumIO, !tt.noEncryptionAtHost, !tt.noTrustedLaunch, tt.confidentialVM),
 					ResourceType: to.StringPtr("virtualMachines"),
 				},
 				{
 					Name:      &tt.availableSku2,
 					Locations: &[]string{"eastus"},
 					LocationInfo: &[]mgmtcompute.ResourceSkuLocationInfo{
-						{Zones: &[]string{"1, 2, 3"}},
+						{Zones: &[]string{"1", "2", "3"}},
 					},
 					Restrictions: &[]mgmtcompute.ResourceSkuRestrictions{},
-					Capabilities: &[]mgmtcompute.ResourceSkuCapabilities{},
+					Capabilities: capabilities(true, true, true, false),
 					ResourceType: to.StringPtr("virtualMachines"),
 				},
 				{
@@ -11,12 +11,23 @@ import (
 	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/validate"
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
 	"github.com/Azure/ARO-RP/pkg/util/computeskus"
 )
 
+const (
+	premiumIOCapability             = "PremiumIO"
+	encryptionAtHostCapability      = "EncryptionAtHostSupported"
+	trustedLaunchDisabledCapability = "TrustedLaunchDisabled"
+
+	// masterCount is the number of master nodes, which are spread across
+	// availability zones where the region has them
+	masterCount = 3
+)
+
 type SkuValidator interface {
 	ValidateVMSku(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string, oc *api.OpenShiftCluster) error
 }
@@ -33,7 +44,9 @@ func (s skuValidator) ValidateVMSku(ctx context.Context, azEnv *azureclient.AROE
 	return validateVMSku(ctx, oc, resourceSkusClient)
 }
 
-// validateVMSku uses resourceSkusClient to ensure that the VM sizes listed in the cluster document are available for use in the target region.
+// validateVMSku uses resourceSkusClient to ensure that the VM sizes listed in
+// the cluster document are available for use in the target region, and have
+// the capabilities the cluster requires of them there.
 func validateVMSku(ctx context.Context, oc *api.OpenShiftCluster, resourceSkusClient compute.ResourceSkusClient) error {
 	// Get a list of available worker SKUs, filtering by location. We initialized a new resourceSkusClient
 	// so that we can determine SKU availability within target cluster subscription instead of within RP subscription.
@@ -52,6 +65,25 @@ func validateVMSku(ctx context.Context, oc *api.OpenShiftCluster, resourceSkusCl
 		return err
 	}
 
+	err = checkMasterSKUCapabilities(filteredSkus[masterProfileSku], location, "properties.masterProfile", masterProfileSku)
+	if err != nil {
+		return err
+	}
+
+	if oc.Properties.MasterProfile.EncryptionAtHost == api.EncryptionAtHostEnabled {
+		err = checkSKUEncryptionAtHost(filteredSkus[masterProfileSku], "properties.masterProfile.encryptionAtHost", masterProfileSku)
+		if err != nil {
+			return err
+		}
+	}
+
+	if oc.Properties.MasterProfile.SecurityType == api.SecurityTypeTrustedLaunch {
+		err = checkSKUTrustedLaunch(filteredSkus[masterProfileSku], "properties.masterProfile.securityType", masterProfileSku)
+		if err != nil {
+			return err
+		}
+	}
+
 	workerProfiles, _ := api.GetEnrichedWorkerProfiles(oc.Properties)
 
 	// In case there are multiple WorkerProfiles listed in the cluster document (such as post-install),
@@ -63,6 +95,28 @@ func validateVMSku(ctx context.Context, oc *api.OpenShiftCluster, resourceSkusCl
 		if err != nil {
 			return err
 		}
+
+		if workerprofile.EncryptionAtHost == api.EncryptionAtHostEnabled {
+			err = checkSKUEncryptionAtHost(filteredSkus[workerProfileSku], fmt.Sprintf("properties.workerProfiles[%d].encryptionAtHost", i), workerProfileSku)
+			if err != nil {
+				return err
+			}
+		}
+
+		if workerprofile.SecurityType == api.SecurityTypeTrustedLaunch {
+			err = checkSKUTrustedLaunch(filteredSkus[workerProfileSku], fmt.Sprintf("properties.workerProfiles[%d].securityType", i), workerProfileSku)
+			if err != nil {
+				return err
+			}
+		}
+
+		// confidential VM sizes always run as confidential VMs
+		if validate.VMSizeIsConfidential(workerprofile.VMSize) {
+			err = checkSKUConfidentialVM(filteredSkus[workerProfileSku], location, fmt.Sprintf("properties.workerProfiles[%d].securityType", i), workerProfileSku)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -83,3 +137,47 @@ func checkSKUAvailability(skus map[string]*mgmtcompute.ResourceSku, location, pa
 
 	return nil
 }
+
+// checkMasterSKUCapabilities ensures that the master VM size supports premium
+// storage, which the master OS disks use, and that in regions with availability
+// zones it is offered in enough zones to spread the master nodes across them.
+func checkMasterSKUCapabilities(sku *mgmtcompute.ResourceSku, location, path, vmsize string) error {
+	if !computeskus.HasCapability(sku, premiumIOCapability) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".VMSize", "The selected SKU '%v' does not support premium storage in region '%v'", vmsize, location)
+	}
+
+	zones := computeskus.Zones(sku)
+	if len(zones) > 0 && len(zones) < masterCount {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".VMSize", "The selected SKU '%v' is only available in availability zones %v in region '%v', but %d are required", vmsize, zones, location, masterCount)
+	}
+
+	return nil
+}
+
+func checkSKUEncryptionAtHost(sku *mgmtcompute.ResourceSku, path, vmsize string) error {
+	if !computeskus.HasCapability(sku, encryptionAtHostCapability) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "VM SKU '%s' does not support encryption at host.", vmsize)
+	}
+
+	return nil
+}
+
+// checkSKUTrustedLaunch ensures that the VM size can boot a Hyper-V generation
+// 2 image, which Trusted Launch requires, and is not excluded from it.
+func checkSKUTrustedLaunch(sku *mgmtcompute.ResourceSku, path, vmsize string) error {
+	if !computeskus.SupportsHyperVGeneration(sku, "V2") || computeskus.HasCapability(sku, trustedLaunchDisabledCapability) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "VM SKU '%s' does not support Trusted Launch.", vmsize)
+	}
+
+	return nil
+}
+
+// checkSKUConfidentialVM ensures that the VM size runs as a confidential VM in
+// the region; confidential computing hardware is not available everywhere.
+func checkSKUConfidentialVM(sku *mgmtcompute.ResourceSku, location, path, vmsize string) error {
+	if !computeskus.SupportsConfidentialComputing(sku) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "VM SKU '%s' does not support confidential VMs in region '%s'.", vmsize, location)
+	}
+
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ugorji/go/codec"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
 )
@@ -21,7 +22,7 @@ func (f *frontend) putSubscription(w http.ResponseWriter, r *http.Request) {
 	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
 
 	var b []byte
-	err := cosmosdb.RetryOnPreconditionFailed(func() error {
+	err := database.RetryOnPreconditionFailed(ctx, func() error {
 		var err error
 		b, err = f._putSubscription(ctx, r)
 		return err
@@ -65,6 +66,8 @@ func (f *frontend) _putSubscription(ctx context.Context, r *http.Request) ([]byt
 		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidRequestContent, "", "The request content was invalid and could not be deserialized: %q.", err)
 	}
 
+	middleware.SetSubscriptionTier(ctx, subscriptionTier(doc))
+
 	switch doc.Subscription.State {
 	case api.SubscriptionStateRegistered, api.SubscriptionStateUnregistered,
 		api.SubscriptionStateWarned, api.SubscriptionStateSuspended:
@@ -5,6 +5,7 @@ package frontend
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"regexp"
 	"strings"
@@ -16,7 +17,10 @@ import (
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/api/validate"
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/feature"
 	utilnamespace "github.com/Azure/ARO-RP/pkg/util/namespace"
+	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
 func validateTerminalProvisioningState(state api.ProvisioningState) error {
@@ -27,6 +31,25 @@ func validateTerminalProvisioningState(state api.ProvisioningState) error {
 	return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeRequestNotAllowed, "", "Request is not allowed in provisioningState '%s'.", state)
 }
 
+func validateFailedProvisioningState(oc *api.OpenShiftCluster) error {
+	if oc.Properties.ProvisioningState != api.ProvisioningStateFailed {
+		return nil
+	}
+
+	switch oc.Properties.FailedProvisioningState {
+	case api.ProvisioningStateCreating:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeRequestNotAllowed, "", "Request is not allowed on cluster whose creation failed. Delete the cluster.")
+	case api.ProvisioningStateUpdating:
+		// allow: a previous failure to update should not prevent a new
+		// operation.
+		return nil
+	case api.ProvisioningStateDeleting:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeRequestNotAllowed, "", "Request is not allowed on cluster whose deletion failed. Delete the cluster.")
+	default:
+		return fmt.Errorf("unexpected failedProvisioningState %q", oc.Properties.FailedProvisioningState)
+	}
+}
+
 func (f *frontend) getSubscriptionDocument(ctx context.Context, key string) (*api.SubscriptionDocument, error) {
 	r, err := azure.ParseResourceID(key)
 	if err != nil {
@@ -42,8 +65,27 @@ func (f *frontend) getSubscriptionDocument(ctx context.Context, key string) (*ap
 	if cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
 		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidSubscriptionState, "", "Request is not allowed in unregistered subscription '%s'.", r.SubscriptionID)
 	}
+	if err != nil {
+		return nil, err
+	}
 
-	return doc, err
+	middleware.SetSubscriptionTier(ctx, subscriptionTier(doc))
+
+	return doc, nil
+}
+
+// subscriptionTier returns the offer category of a subscription, e.g.
+// Internal, PayAsYouGo or EnterpriseAgreement, from the quota ID which ARM
+// sends when the subscription is registered, e.g. PayAsYouGo_2014-09-01
+func subscriptionTier(doc *api.SubscriptionDocument) string {
+	if doc.Subscription == nil ||
+		doc.Subscription.Properties == nil ||
+		doc.Subscription.Properties.QuotaID == "" {
+		return "Unknown"
+	}
+
+	tier, _, _ := strings.Cut(doc.Subscription.Properties.QuotaID, "_")
+	return tier
 }
 
 func (f *frontend) validateSubscriptionState(ctx context.Context, path string, allowedStates ...api.SubscriptionState) (*api.SubscriptionDocument, error) {
@@ -68,11 +110,11 @@ func (f *frontend) validateOpenShiftUniqueKey(ctx context.Context, doc *api.Open
 		return err
 	}
 
-	docs, err := dbOpenShiftClusters.GetByClientID(ctx, doc.PartitionKey, doc.ClientIDKey)
-	if err != nil {
+	_, err = dbOpenShiftClusters.LookupByClientID(ctx, doc.ClientIDKey)
+	if err != nil && !cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
 		return err
 	}
-	if docs.Count != 0 {
+	if err == nil {
 		clientIdOrMsi := ""
 		value := ""
 		if doc.OpenShiftCluster.UsesWorkloadIdentity() {
@@ -89,11 +131,11 @@ func (f *frontend) validateOpenShiftUniqueKey(ctx context.Context, doc *api.Open
 		}
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeDuplicateClientID, "", "The provided %s '%s' is already in use by a cluster.", clientIdOrMsi, value)
 	}
-	docs, err = dbOpenShiftClusters.GetByClusterResourceGroupID(ctx, doc.PartitionKey, doc.ClusterResourceGroupIDKey)
-	if err != nil {
+	_, err = dbOpenShiftClusters.LookupByClusterResourceGroupID(ctx, doc.ClusterResourceGroupIDKey)
+	if err != nil && !cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
 		return err
 	}
-	if docs.Count != 0 {
+	if err == nil {
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeDuplicateResourceGroup, "", "The provided resource group '%s' already contains a cluster.", doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID)
 	}
 	return api.NewCloudError(http.StatusInternalServerError, api.CloudErrorCodeInternalServerError, "", "Internal server error.")
@@ -214,9 +256,9 @@ func validateNetworkInterfaceName(nicName string) error {
 	return nil
 }
 
-func validateAdminMasterVMSize(vmSize string) error {
+func validateAdminMasterVMSize(vmSize string, additionalVMSizes []*api.SupportedVMSize) error {
 	// check to ensure that the target size is supported as a master size
-	for k := range validate.SupportedVMSizesByRole(validate.VMRoleMaster) {
+	for k := range validate.SupportedVMSizesByRole(validate.VMRoleMaster, additionalVMSizes) {
 		if strings.EqualFold(string(k), vmSize) {
 			return nil
 		}
@@ -225,16 +267,30 @@ func validateAdminMasterVMSize(vmSize string) error {
 	return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "", "The provided vmSize '%s' is unsupported for master.", vmSize)
 }
 
+var rxMinorVersion = regexp.MustCompile(`^\d+\.\d+$`)
+
 // validateInstallVersion validates the install version set in the clusterprofile.version
 // TODO convert this into static validation instead of this receiver function in the validation for frontend.
-func (f *frontend) validateInstallVersion(ctx context.Context, oc *api.OpenShiftCluster) error {
+func (f *frontend) validateInstallVersion(ctx context.Context, oc *api.OpenShiftCluster, sub *api.SubscriptionProperties) error {
 	f.ocpVersionsMu.RLock()
 	// If this request is from an older API or the user did not specify
 	// the version to install, use the default version.
 	if oc.Properties.ClusterProfile.Version == "" {
 		oc.Properties.ClusterProfile.Version = f.defaultOcpVersion
 	}
-	_, ok := f.enabledOcpVersions[oc.Properties.ClusterProfile.Version]
+	// If the user specified a minor version, e.g. 4.14, install the latest
+	// version of it which is enabled
+	if rxMinorVersion.MatchString(oc.Properties.ClusterProfile.Version) {
+		if latest := f.latestEnabledOcpVersion(oc.Properties.ClusterProfile.Version, sub); latest != "" {
+			oc.Properties.ClusterProfile.Version = latest
+		}
+	}
+	v, ok := f.enabledOcpVersions[oc.Properties.ClusterProfile.Version]
+	var multiArch bool
+	if ok {
+		ok = isInstallVersionAvailable(v, sub)
+		multiArch = v.Properties.MultiArchPullspec != ""
+	}
 	f.ocpVersionsMu.RUnlock()
 
 	_, err := semver.NewVersion(oc.Properties.ClusterProfile.Version)
@@ -243,5 +299,48 @@ func (f *frontend) validateInstallVersion(ctx context.Context, oc *api.OpenShift
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.clusterProfile.version", "The requested OpenShift version '%s' is invalid.", oc.Properties.ClusterProfile.Version)
 	}
 
+	// Arm64 workers can only join a cluster installed from a
+	// multi-architecture release payload
+	if validate.HasArm64Workers(oc) && !multiArch {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.workerProfiles", "The requested OpenShift version '%s' does not support Arm64 worker VM sizes.", oc.Properties.ClusterProfile.Version)
+	}
+
 	return nil
 }
+
+// latestEnabledOcpVersion returns the latest enabled version of minorVersion
+// which is available to the subscription, ignoring prereleases, or "" if there
+// is none.  Caller must hold f.ocpVersionsMu.
+func (f *frontend) latestEnabledOcpVersion(minorVersion string, sub *api.SubscriptionProperties) string {
+	var latest *version.Version
+	for v, doc := range f.enabledOcpVersions {
+		if !isInstallVersionAvailable(doc, sub) {
+			continue
+		}
+
+		parsed, err := version.ParseVersion(v)
+		if err != nil || parsed.Suffix != "" || parsed.MinorVersion() != minorVersion {
+			continue
+		}
+
+		if latest == nil || latest.Lt(parsed) {
+			latest = parsed
+		}
+	}
+
+	if latest == nil {
+		return ""
+	}
+
+	return latest.String()
+}
+
+// isInstallVersionAvailable returns true if the version does not require a
+// feature, or if the subscription has registered the feature it requires
+func isInstallVersionAvailable(v *api.OpenShiftVersion, sub *api.SubscriptionProperties) bool {
+	if v.Properties.RequiredFeature == "" {
+		return true
+	}
+
+	return sub != nil && feature.IsRegisteredForFeature(sub, v.Properties.RequiredFeature)
+}
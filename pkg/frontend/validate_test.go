@@ -259,7 +259,7 @@ func TestValidateAdminMasterVMSize(t *testing.T) {
 		},
 	} {
 		t.Run(tt.test, func(t *testing.T) {
-			err := validateAdminMasterVMSize(tt.vmSize)
+			err := validateAdminMasterVMSize(tt.vmSize, nil)
 			if err != nil && err.Error() != tt.wantErr ||
 				err == nil && tt.wantErr != "" {
 				t.Error(err)
@@ -272,11 +272,15 @@ func TestValidateInstallVersion(t *testing.T) {
 	defaultOcpVersion := "4.12.25"
 
 	for _, tt := range []struct {
-		test              string
-		version           string
-		availableVersions []string
-		wantVersion       string
-		wantErr           string
+		test               string
+		version            string
+		availableVersions  []string
+		gatedVersions      []string
+		multiArchVersions  []string
+		registeredFeatures []string
+		workerVMSize       api.VMSize
+		wantVersion        string
+		wantErr            string
 	}{
 		{
 			test:              "Valid and available OCP version specified returns no error",
@@ -304,6 +308,54 @@ func TestValidateInstallVersion(t *testing.T) {
 			version:           "4.14.16+installerref-abcdef",
 			availableVersions: []string{"4.12.25", "4.13.40", "4.14.16", "4.14.16+installerref-abcdef"},
 		},
+		{
+			test:              "Minor version selects its latest available version",
+			version:           "4.14",
+			availableVersions: []string{"4.13.40", "4.14.9", "4.14.16", "4.14.17-0.nightly-2024-01-01-000000", "4.15.27"},
+			wantVersion:       "4.14.16",
+		},
+		{
+			test:              "Minor version without an available version returns error",
+			version:           "4.15",
+			availableVersions: []string{"4.13.40", "4.14.16"},
+			wantErr:           "400: InvalidParameter: properties.clusterProfile.version: The requested OpenShift version '4.15' is invalid.",
+		},
+		{
+			test:               "Gated version available to registered subscription returns no error",
+			version:            "4.15.27",
+			availableVersions:  []string{"4.14.16", "4.15.27"},
+			gatedVersions:      []string{"4.15.27"},
+			registeredFeatures: []string{"Microsoft.RedHatOpenShift/PreviewVersions"},
+		},
+		{
+			test:              "Gated version unavailable to unregistered subscription returns error",
+			version:           "4.15.27",
+			availableVersions: []string{"4.14.16", "4.15.27"},
+			gatedVersions:     []string{"4.15.27"},
+			wantErr:           "400: InvalidParameter: properties.clusterProfile.version: The requested OpenShift version '4.15.27' is invalid.",
+		},
+		{
+			test:              "Minor version skips gated versions unavailable to the subscription",
+			version:           "4.14",
+			availableVersions: []string{"4.14.16", "4.14.17"},
+			gatedVersions:     []string{"4.14.17"},
+			wantVersion:       "4.14.16",
+		},
+		{
+			test:              "Arm64 workers with a multi-architecture version returns no error",
+			version:           "4.15.27",
+			availableVersions: []string{"4.14.16", "4.15.27"},
+			multiArchVersions: []string{"4.15.27"},
+			workerVMSize:      api.VMSizeStandardD8psV5,
+		},
+		{
+			test:              "Arm64 workers without a multi-architecture version returns error",
+			version:           "4.14.16",
+			availableVersions: []string{"4.14.16", "4.15.27"},
+			multiArchVersions: []string{"4.15.27"},
+			workerVMSize:      api.VMSizeStandardD8psV5,
+			wantErr:           "400: InvalidParameter: properties.workerProfiles: The requested OpenShift version '4.14.16' does not support Arm64 worker VM sizes.",
+		},
 	} {
 		t.Run(tt.test, func(t *testing.T) {
 			ctx := context.Background()
@@ -312,6 +364,20 @@ func TestValidateInstallVersion(t *testing.T) {
 			for _, av := range tt.availableVersions {
 				enabledOcpVersions[av] = &api.OpenShiftVersion{}
 			}
+			for _, gv := range tt.gatedVersions {
+				enabledOcpVersions[gv].Properties.RequiredFeature = "Microsoft.RedHatOpenShift/PreviewVersions"
+			}
+			for _, mv := range tt.multiArchVersions {
+				enabledOcpVersions[mv].Properties.MultiArchPullspec = "quay.io/openshift-release-dev/ocp-release:" + mv + "-multi"
+			}
+
+			sub := &api.SubscriptionProperties{}
+			for _, rf := range tt.registeredFeatures {
+				sub.RegisteredFeatures = append(sub.RegisteredFeatures, api.RegisteredFeatureProfile{
+					Name:  rf,
+					State: "Registered",
+				})
+			}
 
 			f := frontend{
 				enabledOcpVersions: enabledOcpVersions,
@@ -325,8 +391,11 @@ func TestValidateInstallVersion(t *testing.T) {
 					},
 				},
 			}
+			if tt.workerVMSize != "" {
+				oc.Properties.WorkerProfiles = []api.WorkerProfile{{VMSize: tt.workerVMSize}}
+			}
 
-			err := f.validateInstallVersion(ctx, oc)
+			err := f.validateInstallVersion(ctx, oc, sub)
 			if tt.wantVersion != "" && oc.Properties.ClusterProfile.Version != tt.wantVersion {
 				t.Errorf("wanted clusterdoc updated with version %s but got %s", tt.wantVersion, oc.Properties.ClusterProfile.Version)
 			}
@@ -335,3 +404,35 @@ func TestValidateInstallVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestSubscriptionTier(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		doc  *api.SubscriptionDocument
+		want string
+	}{
+		{
+			name: "quota ID is set",
+			doc: &api.SubscriptionDocument{
+				Subscription: &api.Subscription{
+					Properties: &api.SubscriptionProperties{
+						QuotaID: "EnterpriseAgreement_2014-09-01",
+					},
+				},
+			},
+			want: "EnterpriseAgreement",
+		},
+		{
+			name: "quota ID is not set",
+			doc:  api.ExampleSubscriptionDocument(),
+			want: "Unknown",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := subscriptionTier(tt.doc)
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Destination classes, for access log sampling
+const (
+	destinationClassAllowList = "allowlist" // on the static allow list
+	destinationClassCluster   = "cluster"   // allowed for the cluster only
+	destinationClassDenied    = "denied"    // not allowed
+)
+
+// Access log verdicts
+const (
+	verdictAllowed = "allowed"
+	verdictDenied  = "denied"
+	verdictLimited = "limited"
+	verdictRevoked = "revoked"
+)
+
+// parseAccessLogSampling parses access log sampling rates, formatted as a
+// comma-separated list of class=rate pairs, e.g. "allowlist=0.01,cluster=0.1".
+// Classes which are not listed are always logged.
+func parseAccessLogSampling(s string) (map[string]float64, error) {
+	sampling := map[string]float64{
+		destinationClassAllowList: 1,
+		destinationClassCluster:   1,
+		destinationClassDenied:    1,
+	}
+
+	if s == "" {
+		return sampling, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		class, rate, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid access log sampling rate %q", pair)
+		}
+
+		if _, ok := sampling[class]; !ok {
+			return nil, fmt.Errorf("invalid access log destination class %q", class)
+		}
+
+		r, err := strconv.ParseFloat(rate, 64)
+		if err != nil || r < 0 || r > 1 {
+			return nil, fmt.Errorf("invalid access log sampling rate %q for destination class %q", rate, class)
+		}
+
+		sampling[class] = r
+	}
+
+	return sampling, nil
+}
+
+// destinationClass returns the class of a destination for which the access
+// decision has already been made
+func (g *gateway) destinationClass(host string, isAllowed bool) string {
+	if !isAllowed {
+		return destinationClassDenied
+	}
+
+	if _, found := g.allowList[strings.ToLower(host)]; found {
+		return destinationClassAllowList
+	}
+
+	return destinationClassCluster
+}
+
+// logAccess writes the access log record of a connection once it is finished
+// with, subject to the sampling rate of the destination class.  e is nil if
+// the connection was not proxied.
+func (g *gateway) logAccess(log *logrus.Entry, class, verdict string, start time.Time, e *connectionEgress) {
+	rate, ok := g.accessLogSampling[class]
+	if !ok {
+		rate = 1
+	}
+
+	if rate < 1 && rand.Float64() >= rate {
+		return
+	}
+
+	fields := logrus.Fields{
+		"destination_class": class,
+		"verdict":           verdict,
+		"duration":          time.Since(start).Seconds(),
+		"sample_rate":       rate,
+	}
+
+	if e != nil {
+		fields["sent_bytes"] = atomic.LoadInt64(&e.bytesOut)
+		fields["received_bytes"] = atomic.LoadInt64(&e.bytesIn)
+	}
+
+	log.WithFields(fields).Print("access")
+}
@@ -0,0 +1,110 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+
+	testlog "github.com/Azure/ARO-RP/test/util/log"
+)
+
+func TestParseAccessLogSampling(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		s       string
+		want    map[string]float64
+		wantErr string
+	}{
+		{
+			name: "default",
+			want: map[string]float64{
+				destinationClassAllowList: 1,
+				destinationClassCluster:   1,
+				destinationClassDenied:    1,
+			},
+		},
+		{
+			name: "rates",
+			s:    "allowlist=0.01, cluster=0.5",
+			want: map[string]float64{
+				destinationClassAllowList: 0.01,
+				destinationClassCluster:   0.5,
+				destinationClassDenied:    1,
+			},
+		},
+		{
+			name:    "missing rate",
+			s:       "allowlist",
+			wantErr: `invalid access log sampling rate "allowlist"`,
+		},
+		{
+			name:    "unknown class",
+			s:       "other=1",
+			wantErr: `invalid access log destination class "other"`,
+		},
+		{
+			name:    "rate out of range",
+			s:       "denied=2",
+			wantErr: `invalid access log sampling rate "2" for destination class "denied"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAccessLogSampling(tt.s)
+			if err != nil && err.Error() != tt.wantErr ||
+				err == nil && tt.wantErr != "" {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestLogAccess(t *testing.T) {
+	g := &gateway{
+		allowList: map[string]struct{}{
+			"allowed.example.com": {},
+		},
+		accessLogSampling: map[string]float64{
+			destinationClassAllowList: 0,
+			destinationClassCluster:   1,
+			destinationClassDenied:    1,
+		},
+	}
+
+	h, log := testlog.New()
+
+	e := &connectionEgress{bytesOut: 5, bytesIn: 6}
+
+	g.logAccess(log, g.destinationClass("Allowed.example.com", true), verdictAllowed, time.Now(), e)
+	g.logAccess(log, g.destinationClass("cluster.example.com", true), verdictRevoked, time.Now(), e)
+	g.logAccess(log, g.destinationClass("denied.example.com", false), verdictDenied, time.Now(), nil)
+
+	err := testlog.AssertLoggingOutput(h, []map[string]types.GomegaMatcher{
+		{
+			"msg":               gomega.Equal("access"),
+			"destination_class": gomega.Equal(destinationClassCluster),
+			"verdict":           gomega.Equal(verdictRevoked),
+			"sample_rate":       gomega.Equal(1.0),
+			"sent_bytes":        gomega.Equal(int64(5)),
+			"received_bytes":    gomega.Equal(int64(6)),
+		},
+		{
+			"msg":               gomega.Equal("access"),
+			"destination_class": gomega.Equal(destinationClassDenied),
+			"verdict":           gomega.Equal(verdictDenied),
+			"sent_bytes":        gomega.BeNil(),
+		},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
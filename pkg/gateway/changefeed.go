@@ -39,6 +39,7 @@ func (g *gateway) updateFromIterator(ctx context.Context, ticker *time.Ticker, g
 			}
 
 			g.updateGateways(docs.GatewayDocuments)
+			g.revokeConnections()
 		}
 
 		if successful {
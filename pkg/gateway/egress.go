@@ -0,0 +1,229 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Limits bound the traffic which a single cluster may proxy through a gateway
+// instance, so that one misbehaving cluster cannot saturate it.  Zero values
+// mean no limit.
+type Limits struct {
+	// MaxConnections is the maximum number of concurrent connections
+	MaxConnections int64
+
+	// MaxBandwidth is the maximum combined rate of both directions, in bytes
+	// per second
+	MaxBandwidth int64
+}
+
+// clusterEgress counts the traffic proxied for a single cluster, identified by
+// the private endpoint link ID its connections arrive on, since metrics were
+// last emitted.
+type clusterEgress struct {
+	resourceID string
+
+	// limiter is nil if the bandwidth is not limited
+	limiter *rate.Limiter
+
+	// open is protected by gateway.egressMu; the counters are updated
+	// atomically by the proxying goroutines
+	open        int64
+	connections int64
+	bytesOut    int64 // cluster -> destination
+	bytesIn     int64 // destination -> cluster
+	throttled   int64
+}
+
+// acquireEgress returns the egress counters of the cluster for a newly allowed
+// connection.  Callers must call releaseEgress once the connection is closed.
+// It returns false, and the connection must be refused, if the cluster already
+// has as many open connections as it is allowed.
+func (g *gateway) acquireEgress(linkID, resourceID string) (*clusterEgress, bool) {
+	g.egressMu.Lock()
+	defer g.egressMu.Unlock()
+
+	e := g.egress[linkID]
+	if e == nil {
+		e = &clusterEgress{}
+		if g.limits.MaxBandwidth > 0 {
+			burst := g.limits.MaxBandwidth
+			if burst < SocketSize {
+				burst = SocketSize
+			}
+			e.limiter = rate.NewLimiter(rate.Limit(g.limits.MaxBandwidth), int(burst))
+		}
+		g.egress[linkID] = e
+	}
+
+	e.resourceID = resourceID
+
+	if g.limits.MaxConnections > 0 && e.open >= g.limits.MaxConnections {
+		g.m.EmitGauge("gateway.cluster.limitexceeded", 1, map[string]string{
+			"linkid":     linkID,
+			"resourceId": resourceID,
+			"limit":      "connections",
+		})
+		return nil, false
+	}
+
+	e.open++
+	atomic.AddInt64(&e.connections, 1)
+
+	return e, true
+}
+
+func (g *gateway) releaseEgress(e *clusterEgress) {
+	g.egressMu.Lock()
+	defer g.egressMu.Unlock()
+
+	e.open--
+}
+
+// emitEgressMetrics emits the traffic of each cluster since the last call and
+// resets the counters.  Clusters without open connections are forgotten once
+// their counters have been emitted.
+func (g *gateway) emitEgressMetrics() {
+	g.egressMu.Lock()
+	defer g.egressMu.Unlock()
+
+	for linkID, e := range g.egress {
+		g.m.EmitGauge("gateway.cluster.connections", atomic.SwapInt64(&e.connections, 0), map[string]string{
+			"linkid":     linkID,
+			"resourceId": e.resourceID,
+		})
+
+		g.m.EmitGauge("gateway.cluster.bytes", atomic.SwapInt64(&e.bytesOut, 0), map[string]string{
+			"linkid":     linkID,
+			"resourceId": e.resourceID,
+			"direction":  "out",
+		})
+
+		g.m.EmitGauge("gateway.cluster.bytes", atomic.SwapInt64(&e.bytesIn, 0), map[string]string{
+			"linkid":     linkID,
+			"resourceId": e.resourceID,
+			"direction":  "in",
+		})
+
+		if throttled := atomic.SwapInt64(&e.throttled, 0); throttled > 0 {
+			g.m.EmitGauge("gateway.cluster.limitexceeded", throttled, map[string]string{
+				"linkid":     linkID,
+				"resourceId": e.resourceID,
+				"limit":      "bandwidth",
+			})
+		}
+
+		if e.open == 0 {
+			delete(g.egress, linkID)
+		}
+	}
+}
+
+// transferred counts n bytes transferred in one direction and, if the
+// cluster's bandwidth is limited, blocks until the limit allows them.
+func (e *clusterEgress) transferred(counter *int64, n int) {
+	atomic.AddInt64(counter, int64(n))
+
+	if e.limiter == nil {
+		return
+	}
+
+	for n > 0 {
+		chunk := n
+		if chunk > e.limiter.Burst() {
+			chunk = e.limiter.Burst()
+		}
+
+		if d := e.limiter.ReserveN(time.Now(), chunk).Delay(); d > 0 {
+			atomic.AddInt64(&e.throttled, 1)
+			time.Sleep(d)
+		}
+
+		n -= chunk
+	}
+}
+
+// connectionEgress counts the traffic of a single connection, as well as
+// that of its cluster
+type connectionEgress struct {
+	cluster  *clusterEgress
+	bytesOut int64
+	bytesIn  int64
+}
+
+func (c *connectionEgress) out(n int) {
+	atomic.AddInt64(&c.bytesOut, int64(n))
+	c.cluster.transferred(&c.cluster.bytesOut, n)
+}
+
+func (c *connectionEgress) in(n int) {
+	atomic.AddInt64(&c.bytesIn, int64(n))
+	c.cluster.transferred(&c.cluster.bytesIn, n)
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	io.Reader
+	count func(int)
+}
+
+func (r *countingReader) Read(b []byte) (int, error) {
+	n, err := r.Reader.Read(b)
+	r.count(n)
+	return n, err
+}
+
+// countingConn is a net.Conn which counts the bytes written to it
+type countingConn struct {
+	net.Conn
+	count func(int)
+}
+
+func (c *countingConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.count(n)
+	return n, err
+}
+
+func (c *countingConn) CloseWrite() error {
+	closeWriter, ok := c.Conn.(interface{ CloseWrite() error })
+	if !ok {
+		return errors.New("connection does not support CloseWrite")
+	}
+
+	return closeWriter.CloseWrite()
+}
+
+// countingResponseWriter counts the bytes proxied over a hijacked HTTP CONNECT
+// connection
+type countingResponseWriter struct {
+	http.ResponseWriter
+	e *connectionEgress
+}
+
+func (w *countingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("hijacking not supported")
+	}
+
+	c, buf, err := hijacker.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the proxy reads from buf, which may already hold bytes read from c
+	return &countingConn{Conn: c, count: w.e.in},
+		bufio.NewReadWriter(bufio.NewReader(&countingReader{Reader: buf.Reader, count: w.e.out}), buf.Writer),
+		nil
+}
@@ -0,0 +1,232 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestEmitEgressMetrics(t *testing.T) {
+	const resourceID = "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName"
+
+	for _, tt := range []struct {
+		name       string
+		release    bool
+		wantEgress bool
+	}{
+		{
+			name:       "open connection is kept",
+			wantEgress: true,
+		},
+		{
+			name:    "closed connection is forgotten",
+			release: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := mock_metrics.NewMockEmitter(controller)
+			m.EXPECT().EmitGauge("gateway.cluster.connections", int64(2), map[string]string{
+				"linkid":     "1",
+				"resourceId": resourceID,
+			})
+			m.EXPECT().EmitGauge("gateway.cluster.bytes", int64(10), map[string]string{
+				"linkid":     "1",
+				"resourceId": resourceID,
+				"direction":  "out",
+			})
+			m.EXPECT().EmitGauge("gateway.cluster.bytes", int64(20), map[string]string{
+				"linkid":     "1",
+				"resourceId": resourceID,
+				"direction":  "in",
+			})
+
+			g := &gateway{
+				m:      m,
+				egress: map[string]*clusterEgress{},
+			}
+
+			e, _ := g.acquireEgress("1", resourceID)
+			e2, _ := g.acquireEgress("1", resourceID)
+			g.releaseEgress(e2)
+			e.bytesOut += 10
+			e.bytesIn += 20
+
+			if tt.release {
+				g.releaseEgress(e)
+			}
+
+			g.emitEgressMetrics()
+
+			if _, ok := g.egress["1"]; ok != tt.wantEgress {
+				t.Error(ok)
+			}
+
+			if e.connections != 0 || e.bytesOut != 0 || e.bytesIn != 0 {
+				t.Error(e.connections, e.bytesOut, e.bytesIn)
+			}
+		})
+	}
+}
+
+func TestAcquireEgressConnectionLimit(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+	m.EXPECT().EmitGauge("gateway.cluster.limitexceeded", int64(1), map[string]string{
+		"linkid":     "1",
+		"resourceId": "resourceID",
+		"limit":      "connections",
+	})
+
+	g := &gateway{
+		m:      m,
+		egress: map[string]*clusterEgress{},
+		limits: Limits{MaxConnections: 2},
+	}
+
+	e, ok := g.acquireEgress("1", "resourceID")
+	if !ok {
+		t.Fatal(ok)
+	}
+
+	_, ok = g.acquireEgress("1", "resourceID")
+	if !ok {
+		t.Fatal(ok)
+	}
+
+	// the limit is per cluster
+	_, ok = g.acquireEgress("2", "otherResourceID")
+	if !ok {
+		t.Fatal(ok)
+	}
+
+	_, ok = g.acquireEgress("1", "resourceID")
+	if ok {
+		t.Fatal(ok)
+	}
+
+	g.releaseEgress(e)
+
+	_, ok = g.acquireEgress("1", "resourceID")
+	if !ok {
+		t.Fatal(ok)
+	}
+}
+
+func TestEgressBandwidthLimit(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+	m.EXPECT().EmitGauge("gateway.cluster.connections", gomock.Any(), gomock.Any())
+	m.EXPECT().EmitGauge("gateway.cluster.bytes", gomock.Any(), gomock.Any()).Times(2)
+	m.EXPECT().EmitGauge("gateway.cluster.limitexceeded", int64(1), map[string]string{
+		"linkid":     "1",
+		"resourceId": "resourceID",
+		"limit":      "bandwidth",
+	})
+
+	g := &gateway{
+		m:      m,
+		egress: map[string]*clusterEgress{},
+		limits: Limits{MaxBandwidth: 10 * SocketSize},
+	}
+
+	e, _ := g.acquireEgress("1", "resourceID")
+
+	// the first burst is free; the next tenth of it has to wait for the
+	// limiter to refill
+	start := time.Now()
+	e.transferred(&e.bytesOut, 10*SocketSize)
+	e.transferred(&e.bytesOut, SocketSize)
+
+	if d := time.Since(start); d < 50*time.Millisecond {
+		t.Error(d)
+	}
+
+	if e.bytesOut != 11*SocketSize {
+		t.Error(e.bytesOut)
+	}
+
+	g.emitEgressMetrics()
+}
+
+func TestCountingResponseWriter(t *testing.T) {
+	e := &connectionEgress{cluster: &clusterEgress{}}
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, buf, err := (&countingResponseWriter{ResponseWriter: w, e: e}).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer c.Close()
+
+		b := make([]byte, 5)
+		_, err = io.ReadFull(buf, b)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		_, err = c.Write([]byte("world!"))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer s.Close()
+
+	c, err := net.Dial("tcp", s.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	// send the request and the first proxied bytes together, so that some are
+	// already buffered by the HTTP server when the connection is hijacked
+	_, err = c.Write([]byte("CONNECT example.com:443 HTTP/1.1\r\nHost: example.com:443\r\n\r\nhello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := io.ReadAll(bufio.NewReader(c))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != "world!" {
+		t.Error(string(b))
+	}
+
+	if n := atomic.LoadInt64(&e.bytesOut); n != 5 {
+		t.Error(n)
+	}
+
+	if n := atomic.LoadInt64(&e.bytesIn); n != 6 {
+		t.Error(n)
+	}
+
+	if n := atomic.LoadInt64(&e.cluster.bytesOut); n != 5 {
+		t.Error(n)
+	}
+
+	if n := atomic.LoadInt64(&e.cluster.bytesIn); n != 6 {
+		t.Error(n)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/metrics"
 	"github.com/Azure/ARO-RP/pkg/portal/middleware"
 	"github.com/Azure/ARO-RP/pkg/util/heartbeat"
+	utilnet "github.com/Azure/ARO-RP/pkg/util/net"
 )
 
 type Runnable interface {
@@ -50,16 +51,20 @@ type Runnable interface {
 // HTTPS_PROXY in the ignition config instead.
 //
 // Important note: regardless of mode, TLS traffic is never decrypted/re-
-// encrypted by the gateway.
+// encrypted by the gateway.  This means that upstream connections cannot be
+// pooled or reused: each one carries the TLS session of a single cluster
+// connection.  Only the TCP keep-alive period of upstream connections is
+// tunable.
 type gateway struct {
 	env       env.Core
 	log       *logrus.Entry
 	accessLog *logrus.Entry
 
-	ready          atomic.Value
-	lastChangefeed atomic.Value //time.Time
-	mu             sync.RWMutex
-	gateways       map[string]*api.Gateway
+	ready             atomic.Value
+	upstreamUnhealthy atomic.Bool
+	lastChangefeed    atomic.Value //time.Time
+	mu                sync.RWMutex
+	gateways          map[string]*api.Gateway
 
 	dbGateway database.Gateway
 
@@ -70,10 +75,34 @@ type gateway struct {
 	healthServer *http.Server
 
 	allowList map[string]struct{}
+	resolver  *resolver
+
+	// accessLogSampling is the rate at which connections to each destination
+	// class are logged
+	accessLogSampling map[string]float64
+
+	// proxyProtocolDestinations are the destinations which are sent a PROXY
+	// protocol v2 header identifying the cluster
+	proxyProtocolDestinations map[string]struct{}
+
+	upstreamKeepAlive   time.Duration
+	upstreamDialContext func(context.Context, string, string) (net.Conn, error)
+	limits              Limits
+
+	// unhealthyUpstreams are the addresses of allowed destinations which
+	// failed their last health check
+	unhealthyUpstreamsMu sync.RWMutex
+	unhealthyUpstreams   map[string]struct{}
 
 	m                metrics.Emitter
 	httpConnections  int64
 	httpsConnections int64
+
+	egressMu sync.Mutex
+	egress   map[string]*clusterEgress // by linkID
+
+	connectionsMu sync.Mutex
+	connections   map[*connection]struct{}
 }
 
 type contextKey int
@@ -90,7 +119,7 @@ const SocketSize = 65536
 
 // TODO: may one day want to limit gateway readiness on # active connections
 
-func NewGateway(ctx context.Context, env env.Core, baseLog, accessLog *logrus.Entry, dbGateway database.Gateway, httpsl, httpl, httpHealthl net.Listener, acrResourceID, gatewayDomains string, m metrics.Emitter) (Runnable, error) {
+func NewGateway(ctx context.Context, env env.Core, baseLog, accessLog *logrus.Entry, dbGateway database.Gateway, httpsl, httpl, httpHealthl net.Listener, acrResourceID, gatewayDomains, proxyProtocolDestinations, accessLogSampling string, upstreamKeepAlive time.Duration, limits Limits, m metrics.Emitter) (Runnable, error) {
 	var domains []string
 	if gatewayDomains != "" {
 		domains = strings.Split(gatewayDomains, ",")
@@ -129,6 +158,23 @@ func NewGateway(ctx context.Context, env env.Core, baseLog, accessLog *logrus.En
 		allowList[strings.ToLower(domain)] = struct{}{}
 	}
 
+	ppDestinations := map[string]struct{}{}
+	if proxyProtocolDestinations != "" {
+		for _, domain := range strings.Split(proxyProtocolDestinations, ",") {
+			ppDestinations[strings.ToLower(domain)] = struct{}{}
+		}
+	}
+
+	sampling, err := parseAccessLogSampling(accessLogSampling)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver, err := newResolver("/etc/resolv.conf")
+	if err != nil {
+		return nil, err
+	}
+
 	g := &gateway{
 		env:       env,
 		log:       baseLog,
@@ -142,11 +188,16 @@ func NewGateway(ctx context.Context, env env.Core, baseLog, accessLog *logrus.En
 		// later pick out the private endpoint ID of the incoming connection via
 		// Azure's haproxy protocol support
 		// (https://docs.microsoft.com/en-us/azure/private-link/private-link-service-overview#getting-connection-information-using-tcp-proxy-v2).
+		// Connections without a valid PROXY protocol v2 header are dropped.
 		httpsl: &proxyproto.Listener{
-			Listener: httpsl,
+			Listener:       httpsl,
+			Policy:         requireProxyHeader,
+			ValidateHeader: validateProxyHeader,
 		},
 		httpl: &proxyproto.Listener{
-			Listener: httpl,
+			Listener:       httpl,
+			Policy:         requireProxyHeader,
+			ValidateHeader: validateProxyHeader,
 		},
 		httpHealthl: &proxyproto.Listener{
 			Listener: httpHealthl,
@@ -171,7 +222,19 @@ func NewGateway(ctx context.Context, env env.Core, baseLog, accessLog *logrus.En
 		},
 
 		allowList: allowList,
+		resolver:  resolver,
 		m:         m,
+
+		accessLogSampling: sampling,
+
+		proxyProtocolDestinations: ppDestinations,
+
+		upstreamKeepAlive:   upstreamKeepAlive,
+		upstreamDialContext: (&net.Dialer{}).DialContext,
+		limits:              limits,
+
+		egress:      map[string]*clusterEgress{},
+		connections: map[*connection]struct{}{},
 	}
 
 	panicMiddleware := middleware.Panic(baseLog)
@@ -192,6 +255,7 @@ func NewGateway(ctx context.Context, env env.Core, baseLog, accessLog *logrus.En
 
 func (g *gateway) Run(ctx context.Context, done chan<- struct{}) {
 	go g.changefeed(ctx)
+	go g.checkUpstreamHealth(ctx)
 
 	go g.emitMetrics()
 	go heartbeat.EmitHeartbeat(g.log, g.m, "gateway.heartbeat", nil, g.isReady)
@@ -232,3 +296,40 @@ func (g *gateway) Run(ctx context.Context, done chan<- struct{}) {
 
 	close(done)
 }
+
+// dialUpstream dials an upstream destination, on behalf of either an HTTPS
+// connection or an HTTP CONNECT request, with the gateway's socket buffer size
+// and upstream keep-alive period.  Destinations on the static allow list are
+// dialled at the addresses which the gateway itself resolves for them, so that
+// connections follow a destination whose addresses move as soon as its DNS TTL
+// allows.  The resolved addresses are only ever used to reach the hostname
+// which the cluster asked for: they do not allow connections to be made to them
+// directly.
+func (g *gateway) dialUpstream(network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, found := g.allowList[strings.ToLower(host)]; !found || g.resolver == nil {
+		return utilnet.DialKeepAlive(network, address, SocketSize, g.upstreamKeepAlive)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), resolverTimeout)
+	defer cancel()
+
+	ips, err := g.resolver.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ip := range g.sortByUpstreamHealth(ips) {
+		var c net.Conn
+		c, err = utilnet.DialKeepAlive(network, net.JoinHostPort(ip.String(), port), SocketSize, g.upstreamKeepAlive)
+		if err == nil {
+			return c, nil
+		}
+	}
+
+	return nil, err
+}
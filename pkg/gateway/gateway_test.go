@@ -5,6 +5,7 @@ package gateway
 
 import (
 	"context"
+	"net"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/pires/go-proxyproto"
 	"github.com/sirupsen/logrus"
 	"go.uber.org/mock/gomock"
+	"golang.org/x/net/dns/dnsmessage"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient"
@@ -107,7 +109,7 @@ func TestNewGateway(t *testing.T) {
 			env := mock_env.NewMockCore(controller)
 			tt.mocks(env)
 
-			gtwy, err := NewGateway(ctx, env, baseLog, baseLog, nil, httpsl, httpl, healthListener, tt.acrResourceID, tt.gatewayDomains, metrics)
+			gtwy, err := NewGateway(ctx, env, baseLog, baseLog, nil, httpsl, httpl, healthListener, tt.acrResourceID, tt.gatewayDomains, "", "", 0, Limits{}, metrics)
 
 			if tt.wantErr != "" {
 				if err == nil {
@@ -162,7 +164,7 @@ func TestNewGatewayDefaultConditions(t *testing.T) {
 	env.EXPECT().Environment().AnyTimes().Return(populatedEnv)
 	env.EXPECT().Location().AnyTimes().Return("location")
 
-	gtwy, _ := NewGateway(ctx, env, baseLog, baseLog, nil, httpsl, httpl, healthListener, acrResourceID, gatewayDomains, metrics)
+	gtwy, _ := NewGateway(ctx, env, baseLog, baseLog, nil, httpsl, httpl, healthListener, acrResourceID, gatewayDomains, "", "", 0, Limits{}, metrics)
 
 	gateway, _ := gtwy.(*gateway)
 
@@ -284,3 +286,70 @@ func TestUpdateGateway(t *testing.T) {
 		})
 	}
 }
+
+func TestDialUpstream(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			c.Close()
+		}
+	}()
+
+	_, port, err := net.SplitHostPort(l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name    string
+		host    string
+		wantErr bool
+	}{
+		{
+			name: "allow list destination is dialled at its resolved address",
+			host: "allowed.example",
+		},
+		{
+			name: "allow list destination is matched case-insensitively",
+			host: "Allowed.Example",
+		},
+		{
+			name:    "other destinations are not dialled at the resolved address",
+			host:    "other.invalid",
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &gateway{
+				allowList: map[string]struct{}{"allowed.example": {}},
+				resolver: &resolver{
+					now:   time.Now,
+					cache: map[string]*resolverEntry{},
+					exchange: func(ctx context.Context, q dnsmessage.Question) ([]net.IP, time.Duration, error) {
+						if q.Name.String() == "allowed.example." && q.Type == dnsmessage.TypeA {
+							return []net.IP{net.ParseIP("127.0.0.1")}, time.Minute, nil
+						}
+						return nil, time.Minute, nil
+					},
+				},
+			}
+
+			c, err := g.dialUpstream("tcp", net.JoinHostPort(tt.host, port))
+			if tt.wantErr != (err != nil) {
+				t.Fatal(err)
+			}
+			if c != nil {
+				c.Close()
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/pires/go-proxyproto"
+	"github.com/sirupsen/logrus"
 
 	"github.com/Azure/ARO-RP/pkg/proxy"
 	utillog "github.com/Azure/ARO-RP/pkg/util/log"
@@ -33,18 +34,25 @@ func (g *gateway) handleConnect(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	clusterResourceID, isAllowed, err := g.isAllowed(conn, host)
+	linkID, clusterResourceID, isAllowed, err := g.isAllowed(conn, host)
 	if err != nil {
 		g.log.Error(err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
+	start := time.Now()
 	log := utillog.EnrichWithResourceID(g.accessLog, clusterResourceID)
-	log = log.WithField("hostname", host)
+	log = log.WithFields(logrus.Fields{
+		"protocol": "http",
+		"hostname": host,
+		"linkid":   linkID,
+		"source":   conn.RemoteAddr().String(),
+	})
+	class := g.destinationClass(host, isAllowed && port == "443")
 
 	if !isAllowed || port != "443" {
-		log.Print("access denied")
+		g.logAccess(log, class, verdictDenied, start, nil)
 		g.m.EmitGauge("gateway.connections", 1, map[string]string{
 			"protocol": "http",
 			"action":   "denied",
@@ -53,7 +61,20 @@ func (g *gateway) handleConnect(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Print("access allowed")
+	ce, ok := g.acquireEgress(linkID, clusterResourceID)
+	if !ok {
+		g.logAccess(log, class, verdictLimited, start, nil)
+		http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+		return
+	}
+	defer g.releaseEgress(ce)
+
+	e := &connectionEgress{cluster: ce}
+	verdict := verdictAllowed
+	defer func() {
+		g.logAccess(log, class, verdict, start, e)
+	}()
+
 	g.m.EmitGauge("gateway.connections", 1, map[string]string{
 		"protocol": "http",
 		"action":   "allowed",
@@ -62,7 +83,19 @@ func (g *gateway) handleConnect(w http.ResponseWriter, r *http.Request) {
 	atomic.AddInt64(&g.httpConnections, 1)
 	defer atomic.AddInt64(&g.httpConnections, -1)
 
-	proxy.Proxy(g.log, w, r, SocketSize)
+	// closing the underlying connection also closes it once hijacked
+	tracked := g.trackConnection(linkID, host, func() {
+		conn.Close()
+	})
+	defer g.untrackConnection(tracked)
+
+	proxy.Proxy(g.log, &countingResponseWriter{ResponseWriter: w, e: e}, r, func(network, address string) (net.Conn, error) {
+		return g.dialUpstreamFrom(conn, network, address)
+	})
+
+	if tracked.revoked.Load() {
+		verdict = verdictRevoked
+	}
 }
 
 func (g *gateway) checkReady(w http.ResponseWriter, r *http.Request) {
@@ -74,5 +107,5 @@ func (g *gateway) checkReady(w http.ResponseWriter, r *http.Request) {
 
 func (g *gateway) isReady() bool {
 	_, ok := g.lastChangefeed.Load().(time.Time)
-	return ok && g.ready.Load().(bool)
+	return ok && g.ready.Load().(bool) && !g.upstreamUnhealthy.Load()
 }
@@ -10,11 +10,12 @@ import (
 	"io"
 	"net"
 	"sync/atomic"
+	"time"
 
 	"github.com/pires/go-proxyproto"
+	"github.com/sirupsen/logrus"
 
 	utillog "github.com/Azure/ARO-RP/pkg/util/log"
-	utilnet "github.com/Azure/ARO-RP/pkg/util/net"
 	"github.com/Azure/ARO-RP/pkg/util/recover"
 )
 
@@ -58,17 +59,24 @@ func (g *gateway) handleHTTPS(ctx context.Context, _c net.Conn) {
 	}
 
 	// 2. Determine if we allow the connection.
-	clusterResourceID, isAllowed, err := g.isAllowed(conn, serverName)
+	linkID, clusterResourceID, isAllowed, err := g.isAllowed(conn, serverName)
 	if err != nil {
 		g.log.Error(err)
 		return
 	}
 
+	start := time.Now()
 	log := utillog.EnrichWithResourceID(g.accessLog, clusterResourceID)
-	log = log.WithField("hostname", serverName)
+	log = log.WithFields(logrus.Fields{
+		"protocol": "https",
+		"hostname": serverName,
+		"linkid":   linkID,
+		"source":   conn.RemoteAddr().String(),
+	})
+	class := g.destinationClass(serverName, isAllowed)
 
 	if !isAllowed {
-		log.Print("access denied")
+		g.logAccess(log, class, verdictDenied, start, nil)
 		g.m.EmitGauge("gateway.connections", 1, map[string]string{
 			"protocol": "https",
 			"action":   "denied",
@@ -76,7 +84,19 @@ func (g *gateway) handleHTTPS(ctx context.Context, _c net.Conn) {
 		return
 	}
 
-	log.Print("access allowed")
+	ce, ok := g.acquireEgress(linkID, clusterResourceID)
+	if !ok {
+		g.logAccess(log, class, verdictLimited, start, nil)
+		return
+	}
+	defer g.releaseEgress(ce)
+
+	e := &connectionEgress{cluster: ce}
+	verdict := verdictAllowed
+	defer func() {
+		g.logAccess(log, class, verdict, start, e)
+	}()
+
 	g.m.EmitGauge("gateway.connections", 1, map[string]string{
 		"protocol": "https",
 		"action":   "allowed",
@@ -86,12 +106,19 @@ func (g *gateway) handleHTTPS(ctx context.Context, _c net.Conn) {
 	defer atomic.AddInt64(&g.httpsConnections, -1)
 
 	// 3. Dial the second leg of the connection (c2).
-	c2, err := utilnet.Dial("tcp", serverName+":443", SocketSize)
+	c2, err := g.dialUpstreamFrom(conn, "tcp", serverName+":443")
 	if err != nil {
 		return
 	}
 
 	defer c2.Close()
+
+	tracked := g.trackConnection(linkID, serverName, func() {
+		_c.Close()
+		c2.Close()
+	})
+	defer g.untrackConnection(tracked)
+
 	ch := make(chan struct{})
 
 	// 4. Proxy c1<->c2.
@@ -102,7 +129,7 @@ func (g *gateway) handleHTTPS(ctx context.Context, _c net.Conn) {
 			_ = conn.Raw().(*net.TCPConn).CloseWrite()
 		}()
 
-		_, _ = io.Copy(c1, c2)
+		_, _ = io.Copy(c1, &countingReader{Reader: c2, count: e.in})
 	}()
 
 	func() {
@@ -110,8 +137,12 @@ func (g *gateway) handleHTTPS(ctx context.Context, _c net.Conn) {
 			_ = c2.(*net.TCPConn).CloseWrite()
 		}()
 
-		_, _ = io.Copy(c2, c1)
+		_, _ = io.Copy(c2, &countingReader{Reader: c1, count: e.out})
 	}()
 
 	<-ch
+
+	if tracked.revoked.Load() {
+		verdict = verdictRevoked
+	}
 }
@@ -11,6 +11,8 @@ import (
 	"strings"
 
 	"github.com/pires/go-proxyproto"
+
+	"github.com/Azure/ARO-RP/pkg/api"
 )
 
 const (
@@ -23,15 +25,16 @@ const (
 // lookup of the gateway collection record in the in-memory cache (this is
 // populated by the Cosmos DB change feed).  It then makes a decision about
 // whether to allow the connection based on a static allow list and the
-// additional hostnames in the gateway record. It returns the cluster ID and
-// deny/allow decision.
-func (g *gateway) isAllowed(conn *proxyproto.Conn, host string) (string, bool, error) {
+// additional hostnames in the gateway record.  It returns the link ID, the
+// cluster ID and deny/allow decision.
+func (g *gateway) isAllowed(conn *proxyproto.Conn, host string) (string, string, bool, error) {
 	linkID, err := linkID(conn)
 	if err != nil {
-		return "", false, err
+		return "", "", false, err
 	}
 
-	return g.gatewayVerification(host, linkID)
+	clusterResourceID, isAllowed, err := g.gatewayVerification(host, linkID)
+	return linkID, clusterResourceID, isAllowed, err
 }
 
 func (g *gateway) gatewayVerification(host, linkID string) (string, bool, error) {
@@ -58,17 +61,32 @@ func (g *gateway) gatewayVerification(host, linkID string) (string, bool, error)
 		return gateway.ID, true, nil
 	}
 
-	return gateway.ID,
-		strings.EqualFold(host, gateway.ImageRegistryStorageAccountName+".blob."+g.env.Environment().StorageEndpointSuffix) ||
-			strings.EqualFold(host, "cluster"+gateway.StorageSuffix+".blob."+g.env.Environment().StorageEndpointSuffix),
-		nil
+	for _, clusterHost := range g.clusterHosts(gateway) {
+		if strings.EqualFold(host, clusterHost) {
+			return gateway.ID, true, nil
+		}
+	}
+
+	return gateway.ID, false, nil
+}
+
+// clusterHosts returns the destinations which are only allowed for the cluster
+// of the given gateway record
+func (g *gateway) clusterHosts(gateway *api.Gateway) []string {
+	return []string{
+		gateway.ImageRegistryStorageAccountName + ".blob." + g.env.Environment().StorageEndpointSuffix,
+		"cluster" + gateway.StorageSuffix + ".blob." + g.env.Environment().StorageEndpointSuffix,
+	}
 }
 
 // linkID retrieves the private endpoint link ID from the haproxy binary
 // protocol header injected on the front of the TCP stream by PLS.  See
 // https://docs.microsoft.com/en-us/azure/private-link/private-link-service-overview#getting-connection-information-using-tcp-proxy-v2
 func linkID(conn *proxyproto.Conn) (string, error) {
-	h := conn.ProxyHeader()
+	return headerLinkID(conn.ProxyHeader())
+}
+
+func headerLinkID(h *proxyproto.Header) (string, error) {
 	if h == nil {
 		return "", errors.New("nil ProxyHeader")
 	}
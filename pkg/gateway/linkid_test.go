@@ -4,10 +4,14 @@ package gateway
 // Licensed under the Apache License 2.0.
 
 import (
+	"context"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest/azure"
 	"go.uber.org/mock/gomock"
+	"golang.org/x/net/dns/dnsmessage"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient"
@@ -132,3 +136,62 @@ func TestGatewayVerification(t *testing.T) {
 		})
 	}
 }
+
+func TestGatewayVerificationIP(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		host string
+	}{
+		{
+			name: "address of allow list destination",
+			host: "10.0.0.1",
+		},
+		{
+			name: "other address",
+			host: "10.0.0.3",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			mockCore := mock_env.NewMockCore(mockController)
+			mockCore.
+				EXPECT().
+				Environment().
+				Return(&azureclient.AROEnvironment{Environment: azure.Environment{StorageEndpointSuffix: "storageEndpointSuffix"}}).
+				AnyTimes()
+
+			gateway := gateway{
+				gateways: map[string]*api.Gateway{
+					"1": {ID: "1", StorageSuffix: "suffix-1", ImageRegistryStorageAccountName: "account1"},
+				},
+				env:       mockCore,
+				allowList: map[string]struct{}{"redhat.com": {}},
+				resolver: &resolver{
+					now:   time.Now,
+					cache: map[string]*resolverEntry{},
+					exchange: func(ctx context.Context, q dnsmessage.Question) ([]net.IP, time.Duration, error) {
+						if q.Name.String() == "redhat.com." && q.Type == dnsmessage.TypeA {
+							return []net.IP{net.ParseIP("10.0.0.1")}, time.Minute, nil
+						}
+						return nil, time.Minute, nil
+					},
+				},
+			}
+
+			gatewayID, isAllowed, err := gateway.gatewayVerification(tt.host, "1")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if gatewayID != "1" {
+				t.Error(gatewayID)
+			}
+
+			if isAllowed {
+				t.Error(isAllowed)
+			}
+		})
+	}
+}
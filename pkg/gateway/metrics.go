@@ -33,4 +33,6 @@ func (g *gateway) _emitMetrics() {
 	if lastChangefeed, ok := g.lastChangefeed.Load().(time.Time); ok {
 		g.m.EmitGauge("gateway.lastchangefeed", lastChangefeed.Unix(), nil)
 	}
+
+	g.emitEgressMetrics()
 }
@@ -0,0 +1,84 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/pires/go-proxyproto"
+)
+
+// requireProxyHeader is the policy of the cluster-facing listeners.  Every
+// connection arrives through the private link service, which prepends a PROXY
+// protocol v2 header; connections without one cannot be attributed to a
+// cluster and are dropped as soon as they are read from.
+func requireProxyHeader(net.Addr) (proxyproto.Policy, error) {
+	return proxyproto.REQUIRE, nil
+}
+
+// validateProxyHeader accepts only PROXY protocol v2 headers which carry a
+// private endpoint link ID.
+func validateProxyHeader(h *proxyproto.Header) error {
+	if h.Version != 2 {
+		return fmt.Errorf("unsupported PROXY protocol version %d", h.Version)
+	}
+
+	_, err := headerLinkID(h)
+	return err
+}
+
+// upstreamProxyHeader returns the PROXY protocol v2 header to send to an
+// upstream destination which accepts one.  It carries the source address of
+// the cluster and the TLVs of the incoming header, including the private
+// endpoint link ID, so that the destination can identify the cluster too.
+func upstreamProxyHeader(conn *proxyproto.Conn, upstream net.Conn) (*proxyproto.Header, error) {
+	h := proxyproto.HeaderProxyFromAddrs(2, conn.RemoteAddr(), upstream.RemoteAddr())
+
+	tlvs, err := conn.ProxyHeader().TLVs()
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.SetTLVs(tlvs)
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// dialUpstreamFrom dials an upstream destination on behalf of conn.  If the
+// destination accepts a PROXY protocol header, the header is sent before the
+// connection is returned.
+func (g *gateway) dialUpstreamFrom(conn *proxyproto.Conn, network, address string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	c2, err := g.dialUpstream(network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, found := g.proxyProtocolDestinations[strings.ToLower(host)]; !found {
+		return c2, nil
+	}
+
+	h, err := upstreamProxyHeader(conn, c2)
+	if err != nil {
+		c2.Close()
+		return nil, err
+	}
+
+	_, err = h.WriteTo(c2)
+	if err != nil {
+		c2.Close()
+		return nil, err
+	}
+
+	return c2, nil
+}
@@ -0,0 +1,214 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"io"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/pires/go-proxyproto"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func testProxyHeader(t *testing.T, version byte, tlvs []proxyproto.TLV) *proxyproto.Header {
+	h := proxyproto.HeaderProxyFromAddrs(version,
+		&net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 12345},
+		&net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 443},
+	)
+
+	if tlvs != nil {
+		err := h.SetTLVs(tlvs)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return h
+}
+
+func TestValidateProxyHeader(t *testing.T) {
+	linkIDTLV := proxyproto.TLV{
+		Type:  pp2TypeAzure,
+		Value: []byte{pp2SubtypeAzurePrivateEndpointLinkID, 1, 0, 0, 0},
+	}
+
+	for _, tt := range []struct {
+		name    string
+		header  func(*testing.T) *proxyproto.Header
+		wantErr string
+	}{
+		{
+			name: "valid",
+			header: func(t *testing.T) *proxyproto.Header {
+				return testProxyHeader(t, 2, []proxyproto.TLV{linkIDTLV})
+			},
+		},
+		{
+			name: "version 1",
+			header: func(t *testing.T) *proxyproto.Header {
+				return testProxyHeader(t, 1, nil)
+			},
+			wantErr: "unsupported PROXY protocol version 1",
+		},
+		{
+			name: "no link id",
+			header: func(t *testing.T) *proxyproto.Header {
+				return testProxyHeader(t, 2, nil)
+			},
+			wantErr: "link id not found",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateProxyHeader(tt.header(t))
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestUpstreamProxyHeader(t *testing.T) {
+	tlvs := []proxyproto.TLV{
+		{
+			Type:  pp2TypeAzure,
+			Value: []byte{pp2SubtypeAzurePrivateEndpointLinkID, 1, 0, 0, 0},
+		},
+	}
+
+	// the incoming connection, carrying the header added by the private link
+	// service
+	c1, c2 := net.Pipe()
+	defer c1.Close()
+	defer c2.Close()
+
+	go func() {
+		_, _ = testProxyHeader(t, 2, tlvs).WriteTo(c2)
+	}()
+
+	conn := proxyproto.NewConn(c1)
+	if conn.ProxyHeader() == nil {
+		t.Fatal("no PROXY header")
+	}
+
+	// the upstream connection
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	upstream, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer upstream.Close()
+
+	h, err := upstreamProxyHeader(conn, upstream)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if h.Version != 2 || h.Command != proxyproto.PROXY {
+		t.Error(h.Version, h.Command)
+	}
+
+	if h.SourceAddr.String() != "10.0.0.1:12345" {
+		t.Error(h.SourceAddr)
+	}
+
+	if h.DestinationAddr.String() != l.Addr().String() {
+		t.Error(h.DestinationAddr)
+	}
+
+	gotTLVs, err := h.TLVs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(gotTLVs, tlvs) {
+		t.Error(gotTLVs)
+	}
+}
+
+func TestDialUpstreamFrom(t *testing.T) {
+	tlvs := []proxyproto.TLV{
+		{
+			Type:  pp2TypeAzure,
+			Value: []byte{pp2SubtypeAzurePrivateEndpointLinkID, 1, 0, 0, 0},
+		},
+	}
+
+	for _, tt := range []struct {
+		name                      string
+		proxyProtocolDestinations map[string]struct{}
+		wantHeader                bool
+	}{
+		{
+			name:                      "destination accepts a PROXY header",
+			proxyProtocolDestinations: map[string]struct{}{"127.0.0.1": {}},
+			wantHeader:                true,
+		},
+		{
+			name: "destination does not accept a PROXY header",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c1, c2 := net.Pipe()
+			defer c1.Close()
+			defer c2.Close()
+
+			go func() {
+				_, _ = testProxyHeader(t, 2, tlvs).WriteTo(c2)
+			}()
+
+			conn := proxyproto.NewConn(c1)
+			if conn.ProxyHeader() == nil {
+				t.Fatal("no PROXY header")
+			}
+
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer l.Close()
+
+			g := &gateway{
+				proxyProtocolDestinations: tt.proxyProtocolDestinations,
+			}
+
+			upstream, err := g.dialUpstreamFrom(conn, "tcp", l.Addr().String())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = upstream.Write([]byte("hello"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			upstream.Close()
+
+			accepted, err := l.Accept()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer accepted.Close()
+
+			received := proxyproto.NewConn(accepted)
+
+			b, err := io.ReadAll(received)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(b) != "hello" {
+				t.Error(string(b))
+			}
+
+			if (received.ProxyHeader() != nil) != tt.wantHeader {
+				t.Error(received.ProxyHeader())
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	// resolved addresses are cached for the DNS TTL, within these bounds
+	resolverMinTTL = 5 * time.Second
+	resolverMaxTTL = 5 * time.Minute
+
+	resolverTimeout = 5 * time.Second
+)
+
+// resolver resolves allowed destination hostnames on behalf of the gateway,
+// caching their addresses for as long as their DNS TTL allows.  It means that
+// the gateway's connections follow an allowed destination if its addresses
+// move, without the gateway resolving it afresh for every connection.
+type resolver struct {
+	now      func() time.Time
+	exchange func(context.Context, dnsmessage.Question) ([]net.IP, time.Duration, error)
+
+	servers []string
+
+	mu    sync.Mutex
+	cache map[string]*resolverEntry
+}
+
+type resolverEntry struct {
+	ips     []net.IP
+	expires time.Time
+}
+
+// newResolver returns a resolver which queries the nameservers in resolvConf,
+// or the local nameserver if there are none.
+func newResolver(resolvConf string) (*resolver, error) {
+	r := &resolver{
+		now:   time.Now,
+		cache: map[string]*resolverEntry{},
+	}
+	r.exchange = r.exchangeUDP
+
+	f, err := os.Open(resolvConf)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		defer f.Close()
+
+		s := bufio.NewScanner(f)
+		for s.Scan() {
+			fields := strings.Fields(s.Text())
+			if len(fields) == 2 && fields[0] == "nameserver" && net.ParseIP(fields[1]) != nil {
+				r.servers = append(r.servers, net.JoinHostPort(fields[1], "53"))
+			}
+		}
+		if err = s.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(r.servers) == 0 {
+		r.servers = []string{"127.0.0.1:53"}
+	}
+
+	return r, nil
+}
+
+// lookup returns the IPv4 and IPv6 addresses of host, from the cache if they
+// have not expired.
+func (r *resolver) lookup(ctx context.Context, host string) ([]net.IP, error) {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	r.mu.Lock()
+	e := r.cache[host]
+	r.mu.Unlock()
+
+	if e != nil && r.now().Before(e.expires) {
+		return e.ips, nil
+	}
+
+	name, err := dnsmessage.NewName(host + ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []net.IP
+	ttl := resolverMaxTTL
+
+	for _, t := range []dnsmessage.Type{dnsmessage.TypeA, dnsmessage.TypeAAAA} {
+		_ips, _ttl, err := r.exchange(ctx, dnsmessage.Question{
+			Name:  name,
+			Type:  t,
+			Class: dnsmessage.ClassINET,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		ips = append(ips, _ips...)
+		if len(_ips) > 0 && _ttl < ttl {
+			ttl = _ttl
+		}
+	}
+
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("no addresses found for %s", host)
+	}
+
+	if ttl < resolverMinTTL {
+		ttl = resolverMinTTL
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	for h, e := range r.cache {
+		if !now.Before(e.expires) {
+			delete(r.cache, h)
+		}
+	}
+
+	r.cache[host] = &resolverEntry{
+		ips:     ips,
+		expires: now.Add(ttl),
+	}
+
+	return ips, nil
+}
+
+// exchangeUDP sends the question to each nameserver in turn until one answers.
+// It returns the addresses in the answer and the lowest TTL of the answer's
+// records, including any CNAMEs.
+func (r *resolver) exchangeUDP(ctx context.Context, q dnsmessage.Question) (ips []net.IP, ttl time.Duration, err error) {
+	for _, server := range r.servers {
+		ips, ttl, err = exchangeUDP(ctx, server, q)
+		if err == nil {
+			return ips, ttl, nil
+		}
+	}
+
+	return nil, 0, err
+}
+
+func exchangeUDP(ctx context.Context, server string, q dnsmessage.Question) ([]net.IP, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, resolverTimeout)
+	defer cancel()
+
+	id := uint16(rand.Uint32())
+
+	b, err := (&dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:               id,
+			RecursionDesired: true,
+		},
+		Questions: []dnsmessage.Question{q},
+	}).Pack()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	c, err := (&net.Dialer{}).DialContext(ctx, "udp", server)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer c.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		err = c.SetDeadline(deadline)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	_, err = c.Write(b)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	b = make([]byte, 65535)
+	n, err := c.Read(b)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return parseAnswer(b[:n], id)
+}
+
+func parseAnswer(b []byte, id uint16) ([]net.IP, time.Duration, error) {
+	var p dnsmessage.Parser
+
+	h, err := p.Start(b)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch {
+	case h.ID != id:
+		return nil, 0, errors.New("DNS response ID mismatch")
+	case h.Truncated:
+		return nil, 0, errors.New("DNS response truncated")
+	case h.RCode != dnsmessage.RCodeSuccess:
+		return nil, 0, fmt.Errorf("DNS response code %s", h.RCode)
+	}
+
+	err = p.SkipAllQuestions()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var ips []net.IP
+	ttl := resolverMaxTTL
+
+	for {
+		ah, err := p.AnswerHeader()
+		if err == dnsmessage.ErrSectionDone {
+			break
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if d := time.Duration(ah.TTL) * time.Second; d < ttl {
+			ttl = d
+		}
+
+		switch ah.Type {
+		case dnsmessage.TypeA:
+			rr, err := p.AResource()
+			if err != nil {
+				return nil, 0, err
+			}
+			ips = append(ips, net.IP(rr.A[:]))
+
+		case dnsmessage.TypeAAAA:
+			rr, err := p.AAAAResource()
+			if err != nil {
+				return nil, 0, err
+			}
+			ips = append(ips, net.IP(rr.AAAA[:]))
+
+		default:
+			err = p.SkipAnswer()
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+
+	return ips, ttl, nil
+}
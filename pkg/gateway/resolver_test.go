@@ -0,0 +1,218 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func TestNewResolver(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, tt := range []struct {
+		name        string
+		resolvConf  string
+		wantServers []string
+	}{
+		{
+			name:        "nameservers",
+			resolvConf:  "search example.com\nnameserver 10.0.0.1\nnameserver fd00::1\nnameserver invalid\n",
+			wantServers: []string{"10.0.0.1:53", "[fd00::1]:53"},
+		},
+		{
+			name:        "no nameservers",
+			resolvConf:  "search example.com\n",
+			wantServers: []string{"127.0.0.1:53"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "resolv.conf")
+			err := os.WriteFile(path, []byte(tt.resolvConf), 0o666)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := newResolver(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(r.servers, tt.wantServers) {
+				t.Error(r.servers)
+			}
+		})
+	}
+}
+
+func TestResolverLookup(t *testing.T) {
+	now := time.Unix(0, 0)
+	queries := 0
+
+	r := &resolver{
+		now:   func() time.Time { return now },
+		cache: map[string]*resolverEntry{},
+		exchange: func(ctx context.Context, q dnsmessage.Question) ([]net.IP, time.Duration, error) {
+			queries++
+
+			switch {
+			case q.Name.String() == "failing.example.com.":
+				return nil, 0, errors.New("failed")
+			case q.Name.String() == "empty.example.com.":
+				return nil, 0, nil
+			case q.Type == dnsmessage.TypeA:
+				return []net.IP{net.ParseIP("10.0.0.1")}, time.Minute, nil
+			default:
+				return nil, resolverMaxTTL, nil
+			}
+		},
+	}
+
+	ctx := context.Background()
+
+	ips, err := r.lookup(ctx, "Allowed.example.com.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ips) != 1 || !ips[0].Equal(net.ParseIP("10.0.0.1")) {
+		t.Error(ips)
+	}
+	if queries != 2 {
+		t.Error(queries)
+	}
+
+	// cached until the TTL expires
+	now = now.Add(59 * time.Second)
+	_, err = r.lookup(ctx, "allowed.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queries != 2 {
+		t.Error(queries)
+	}
+
+	now = now.Add(time.Second)
+	_, err = r.lookup(ctx, "allowed.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queries != 4 {
+		t.Error(queries)
+	}
+
+	_, err = r.lookup(ctx, "failing.example.com")
+	if err == nil || err.Error() != "failed" {
+		t.Error(err)
+	}
+
+	_, err = r.lookup(ctx, "empty.example.com")
+	if err == nil || err.Error() != "no addresses found for empty.example.com" {
+		t.Error(err)
+	}
+}
+
+func TestParseAnswer(t *testing.T) {
+	name := dnsmessage.MustNewName("allowed.example.com.")
+	target := dnsmessage.MustNewName("target.example.com.")
+
+	for _, tt := range []struct {
+		name    string
+		header  dnsmessage.Header
+		answers func(*dnsmessage.Builder) error
+		wantIPs []net.IP
+		wantTTL time.Duration
+		wantErr string
+	}{
+		{
+			name:   "addresses behind a CNAME",
+			header: dnsmessage.Header{ID: 1, Response: true},
+			answers: func(b *dnsmessage.Builder) error {
+				err := b.CNAMEResource(dnsmessage.ResourceHeader{Name: name, Class: dnsmessage.ClassINET, TTL: 30}, dnsmessage.CNAMEResource{CNAME: target})
+				if err != nil {
+					return err
+				}
+				err = b.AResource(dnsmessage.ResourceHeader{Name: target, Class: dnsmessage.ClassINET, TTL: 60}, dnsmessage.AResource{A: [4]byte{10, 0, 0, 1}})
+				if err != nil {
+					return err
+				}
+				return b.AAAAResource(dnsmessage.ResourceHeader{Name: target, Class: dnsmessage.ClassINET, TTL: 60}, dnsmessage.AAAAResource{AAAA: [16]byte{0xfd, 15: 1}})
+			},
+			wantIPs: []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("fd00::1")},
+			wantTTL: 30 * time.Second,
+		},
+		{
+			name:    "no answers",
+			header:  dnsmessage.Header{ID: 1, Response: true},
+			wantTTL: resolverMaxTTL,
+		},
+		{
+			name:    "ID mismatch",
+			header:  dnsmessage.Header{ID: 2, Response: true},
+			wantErr: "DNS response ID mismatch",
+		},
+		{
+			name:    "truncated",
+			header:  dnsmessage.Header{ID: 1, Response: true, Truncated: true},
+			wantErr: "DNS response truncated",
+		},
+		{
+			name:    "NXDOMAIN",
+			header:  dnsmessage.Header{ID: 1, Response: true, RCode: dnsmessage.RCodeNameError},
+			wantErr: "DNS response code RCodeNameError",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			b := dnsmessage.NewBuilder(nil, tt.header)
+			err := b.StartQuestions()
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = b.Question(dnsmessage.Question{Name: name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = b.StartAnswers()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.answers != nil {
+				err = tt.answers(&b)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			msg, err := b.Finish()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ips, ttl, err := parseAnswer(msg, 1)
+			if err != nil && err.Error() != tt.wantErr ||
+				err == nil && tt.wantErr != "" {
+				t.Fatal(err)
+			}
+
+			if len(ips) != len(tt.wantIPs) {
+				t.Fatal(ips)
+			}
+			for i := range ips {
+				if !ips[i].Equal(tt.wantIPs[i]) {
+					t.Error(ips[i])
+				}
+			}
+
+			if tt.wantErr == "" && ttl != tt.wantTTL {
+				t.Error(ttl)
+			}
+		})
+	}
+}
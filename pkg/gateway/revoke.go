@@ -0,0 +1,64 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"sync/atomic"
+)
+
+// connection is an open proxied connection.  It is tracked so that it can be
+// closed if it stops being allowed while it is open, e.g. because the cluster's
+// gateway record was deleted.
+type connection struct {
+	linkID  string
+	host    string
+	close   func()
+	revoked atomic.Bool
+}
+
+func (g *gateway) trackConnection(linkID, host string, close func()) *connection {
+	c := &connection{
+		linkID: linkID,
+		host:   host,
+		close:  close,
+	}
+
+	g.connectionsMu.Lock()
+	defer g.connectionsMu.Unlock()
+
+	g.connections[c] = struct{}{}
+
+	return c
+}
+
+func (g *gateway) untrackConnection(c *connection) {
+	g.connectionsMu.Lock()
+	defer g.connectionsMu.Unlock()
+
+	delete(g.connections, c)
+}
+
+// revokeConnections re-checks every open connection against the current
+// gateway records and closes those which are no longer allowed.  It is called
+// whenever the change feed updates the gateway records, so that revocations
+// apply to existing connections as well as new ones.
+func (g *gateway) revokeConnections() {
+	g.connectionsMu.Lock()
+	defer g.connectionsMu.Unlock()
+
+	for c := range g.connections {
+		_, isAllowed, err := g.gatewayVerification(c.host, c.linkID)
+		if isAllowed && err == nil {
+			continue
+		}
+
+		c.revoked.Store(true)
+		g.m.EmitGauge("gateway.connections.revoked", 1, map[string]string{
+			"linkid": c.linkID,
+		})
+
+		c.close()
+		delete(g.connections, c)
+	}
+}
@@ -0,0 +1,69 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestRevokeConnections(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+
+	g := &gateway{
+		accessLog: logrus.NewEntry(logrus.StandardLogger()),
+		m:         m,
+		gateways: map[string]*api.Gateway{
+			"1": {ID: "1"},
+			"2": {ID: "2"},
+		},
+		allowList: map[string]struct{}{
+			"allowed.com": {},
+		},
+		connections: map[*connection]struct{}{},
+	}
+
+	closed := map[string]bool{}
+	for _, linkID := range []string{"1", "2", "3"} {
+		linkID := linkID
+		g.trackConnection(linkID, "allowed.com", func() { closed[linkID] = true })
+	}
+
+	// the change feed marks gateway 2 as deleting
+	g.updateGateways([]*api.GatewayDocument{
+		{ID: "2", Gateway: &api.Gateway{ID: "2", Deleting: true}},
+	})
+
+	m.EXPECT().EmitGauge("gateway.connections.revoked", int64(1), map[string]string{"linkid": "2"})
+	m.EXPECT().EmitGauge("gateway.connections.revoked", int64(1), map[string]string{"linkid": "3"})
+
+	g.revokeConnections()
+
+	if closed["1"] || !closed["2"] || !closed["3"] {
+		t.Error(closed)
+	}
+
+	if len(g.connections) != 1 {
+		t.Error(len(g.connections))
+	}
+
+	for c := range g.connections {
+		if c.linkID != "1" {
+			t.Error(c.linkID)
+		}
+		g.untrackConnection(c)
+	}
+
+	if len(g.connections) != 0 {
+		t.Error(len(g.connections))
+	}
+}
@@ -0,0 +1,150 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/util/recover"
+)
+
+const (
+	upstreamHealthInterval = 30 * time.Second
+	upstreamHealthTimeout  = 5 * time.Second
+)
+
+// checkUpstreamHealth periodically checks that the gateway can reach each
+// address of the destinations on its static allow list.
+//
+// Connections to an allowed destination try the addresses which passed their
+// last check first (see dialUpstream), so that an address which this instance
+// cannot reach is only tried once the others have failed.  If the instance
+// can reach none of the destinations at all, it has most likely lost its own
+// egress: it then fails its readiness probe, so that it is taken out of
+// rotation and clusters' connections go to the healthy instances instead.
+func (g *gateway) checkUpstreamHealth(ctx context.Context) {
+	defer recover.Panic(g.log)
+
+	t := time.NewTicker(upstreamHealthInterval)
+	defer t.Stop()
+
+	for {
+		g._checkUpstreamHealth(ctx)
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// _checkUpstreamHealth dials each address of each allowed destination.  A
+// destination is healthy if any of its addresses is reachable.  The instance
+// is only considered unhealthy if no destination is healthy: if only some
+// fail, the problem is much more likely to be with the destinations than with
+// the instance, and every instance would be affected alike.
+func (g *gateway) _checkUpstreamHealth(ctx context.Context) {
+	hosts := make([]string, 0, len(g.allowList))
+	for host := range g.allowList {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	unhealthyUpstreams := map[string]struct{}{}
+
+	var healthy int
+	for _, host := range hosts {
+		var value int64
+
+		addresses, err := g.upstreamHealthAddresses(ctx, host)
+		if err != nil {
+			g.log.Warnf("upstream health check of %s failed: %s", host, err)
+		}
+
+		for _, address := range addresses {
+			err := g.dialUpstreamHealth(ctx, address)
+			if err != nil {
+				g.log.Warnf("upstream health check of %s (%s) failed: %s", host, address, err)
+				unhealthyUpstreams[address] = struct{}{}
+				continue
+			}
+
+			value = 1
+		}
+
+		if value == 1 {
+			healthy++
+		}
+
+		g.m.EmitGauge("gateway.upstream.healthy", value, map[string]string{
+			"hostname": host,
+		})
+	}
+
+	g.unhealthyUpstreamsMu.Lock()
+	g.unhealthyUpstreams = unhealthyUpstreams
+	g.unhealthyUpstreamsMu.Unlock()
+
+	g.upstreamUnhealthy.Store(len(hosts) > 0 && healthy == 0)
+}
+
+// upstreamHealthAddresses returns the addresses of host to check, as
+// dialUpstream would resolve them.
+func (g *gateway) upstreamHealthAddresses(ctx context.Context, host string) ([]string, error) {
+	if g.resolver == nil {
+		return []string{host}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, resolverTimeout)
+	defer cancel()
+
+	ips, err := g.resolver.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		addresses = append(addresses, ip.String())
+	}
+
+	return addresses, nil
+}
+
+func (g *gateway) dialUpstreamHealth(ctx context.Context, address string) error {
+	ctx, cancel := context.WithTimeout(ctx, upstreamHealthTimeout)
+	defer cancel()
+
+	c, err := g.upstreamDialContext(ctx, "tcp", net.JoinHostPort(address, "443"))
+	if err != nil {
+		return err
+	}
+
+	return c.Close()
+}
+
+// sortByUpstreamHealth returns ips with the addresses which failed their last
+// health check moved to the end, otherwise preserving their order.  They are
+// still returned, so that a destination remains reachable if every one of
+// its addresses failed.
+func (g *gateway) sortByUpstreamHealth(ips []net.IP) []net.IP {
+	g.unhealthyUpstreamsMu.RLock()
+	defer g.unhealthyUpstreamsMu.RUnlock()
+
+	sorted := make([]net.IP, 0, len(ips))
+	var unhealthy []net.IP
+	for _, ip := range ips {
+		if _, found := g.unhealthyUpstreams[ip.String()]; found {
+			unhealthy = append(unhealthy, ip)
+			continue
+		}
+		sorted = append(sorted, ip)
+	}
+
+	return append(sorted, unhealthy...)
+}
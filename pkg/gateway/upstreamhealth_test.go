@@ -0,0 +1,141 @@
+package gateway
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/net/dns/dnsmessage"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestCheckUpstreamHealth(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		reachable map[string]bool
+		wantReady bool
+	}{
+		{
+			name: "all destinations reachable",
+			reachable: map[string]bool{
+				"login.microsoftonline.com:443": true,
+				"management.azure.com:443":      true,
+			},
+			wantReady: true,
+		},
+		{
+			name: "some destinations reachable",
+			reachable: map[string]bool{
+				"login.microsoftonline.com:443": true,
+			},
+			wantReady: true,
+		},
+		{
+			name: "no destinations reachable",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := mock_metrics.NewMockEmitter(controller)
+			for _, host := range []string{"login.microsoftonline.com", "management.azure.com"} {
+				var value int64
+				if tt.reachable[host+":443"] {
+					value = 1
+				}
+				m.EXPECT().EmitGauge("gateway.upstream.healthy", value, map[string]string{
+					"hostname": host,
+				})
+			}
+
+			g := &gateway{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				m:   m,
+				allowList: map[string]struct{}{
+					"login.microsoftonline.com": {},
+					"management.azure.com":      {},
+				},
+				upstreamDialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+					if !tt.reachable[address] {
+						return nil, errors.New("unreachable")
+					}
+					c1, c2 := net.Pipe()
+					c2.Close()
+					return c1, nil
+				},
+			}
+			g.ready.Store(true)
+			g.lastChangefeed.Store(time.Now())
+
+			g._checkUpstreamHealth(context.Background())
+
+			if g.isReady() != tt.wantReady {
+				t.Error(g.isReady())
+			}
+		})
+	}
+}
+
+func TestCheckUpstreamHealthRouting(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+	m.EXPECT().EmitGauge("gateway.upstream.healthy", int64(1), map[string]string{
+		"hostname": "management.azure.com",
+	})
+
+	g := &gateway{
+		log: logrus.NewEntry(logrus.StandardLogger()),
+		m:   m,
+		allowList: map[string]struct{}{
+			"management.azure.com": {},
+		},
+		resolver: &resolver{
+			now:   time.Now,
+			cache: map[string]*resolverEntry{},
+			exchange: func(ctx context.Context, q dnsmessage.Question) ([]net.IP, time.Duration, error) {
+				if q.Type != dnsmessage.TypeA {
+					return nil, resolverMaxTTL, nil
+				}
+				return []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2"), net.ParseIP("10.0.0.3")}, time.Minute, nil
+			},
+		},
+		upstreamDialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+			if address == "10.0.0.1:443" {
+				return nil, errors.New("unreachable")
+			}
+			c1, c2 := net.Pipe()
+			c2.Close()
+			return c1, nil
+		},
+	}
+	g.ready.Store(true)
+	g.lastChangefeed.Store(time.Now())
+
+	g._checkUpstreamHealth(context.Background())
+
+	if !g.isReady() {
+		t.Error("expected the instance to be ready when some addresses are reachable")
+	}
+
+	ips := g.sortByUpstreamHealth([]net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2"), net.ParseIP("10.0.0.3")})
+
+	var got []string
+	for _, ip := range ips {
+		got = append(got, ip.String())
+	}
+	if !reflect.DeepEqual(got, []string{"10.0.0.2", "10.0.0.3", "10.0.0.1"}) {
+		t.Error(got)
+	}
+}
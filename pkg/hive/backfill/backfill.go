@@ -0,0 +1,219 @@
+package backfill
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/hive"
+)
+
+var errNotAdoptable = errors.New("cluster is no longer adoptable")
+
+// Backfiller adopts existing clusters which are not yet managed by Hive.
+//
+// Clusters are otherwise only adopted when they are next updated, which
+// stable clusters may never be.  The backfill registers them in the same way
+// as the update does: it creates the cluster's namespace in Hive and records
+// it in the cluster document, creates the ClusterDeployment and its secrets,
+// then waits for the ClusterDeployment to become ready.
+//
+// Adoptions are rate limited so as not to overload Hive with new
+// ClusterDeployments.  The backfill is resumable: clusters which already have
+// a ClusterDeployment are skipped without counting towards the rate limit, and
+// the continuation token of each page of clusters is logged so that a long
+// run can be restarted part way through.
+//
+// A Backfiller adopts clusters onto a single shard.  Clusters which are
+// already assigned to another shard are skipped.
+type Backfiller struct {
+	log *logrus.Entry
+
+	dbOpenShiftClusters database.OpenShiftClusters
+	dbSubscriptions     database.Subscriptions
+	hiveClusterManager  hive.ClusterManager
+	shard               int
+
+	limiter *rate.Limiter
+
+	verifyInterval time.Duration
+	verifyTimeout  time.Duration
+}
+
+// New returns a Backfiller which adopts at most one cluster every interval
+// onto the given shard, using hiveClusterManager for that shard.
+func New(log *logrus.Entry, dbOpenShiftClusters database.OpenShiftClusters, dbSubscriptions database.Subscriptions, hiveClusterManager hive.ClusterManager, shard int, interval time.Duration) *Backfiller {
+	return &Backfiller{
+		log: log,
+
+		dbOpenShiftClusters: dbOpenShiftClusters,
+		dbSubscriptions:     dbSubscriptions,
+		hiveClusterManager:  hiveClusterManager,
+		shard:               shard,
+
+		limiter: rate.NewLimiter(rate.Every(interval), 1),
+
+		verifyInterval: 10 * time.Second,
+		verifyTimeout:  5 * time.Minute,
+	}
+}
+
+// Run adopts every adoptable cluster, starting from the given continuation
+// token ("" to start from the beginning).  It carries on past clusters which
+// fail to be adopted, and returns an error at the end if there were any.
+func (b *Backfiller) Run(ctx context.Context, continuation string) error {
+	var adopted, skipped, failed int
+
+	i := b.dbOpenShiftClusters.List(continuation)
+
+	for {
+		b.log.Infof("listing clusters, continuation token %q", i.Continuation())
+
+		docs, err := i.Next(ctx, -1)
+		if err != nil {
+			return err
+		}
+		if docs == nil {
+			break
+		}
+
+		for _, doc := range docs.OpenShiftClusterDocuments {
+			log := b.log.WithField("resource_id", doc.OpenShiftCluster.ID)
+
+			ok, err := b.adopt(ctx, log, doc)
+			switch {
+			case errors.Is(err, errNotAdoptable):
+				log.Info("skipping: cluster is no longer adoptable")
+				skipped++
+			case err != nil:
+				log.Errorf("adopting: %s", err)
+				failed++
+			case ok:
+				log.Info("adopted")
+				adopted++
+			default:
+				skipped++
+			}
+		}
+	}
+
+	b.log.Infof("adopted %d clusters, skipped %d, failed %d", adopted, skipped, failed)
+
+	if failed > 0 {
+		return fmt.Errorf("failed to adopt %d clusters", failed)
+	}
+
+	return nil
+}
+
+// adopt adopts a single cluster, returning false if there was nothing to do.
+func (b *Backfiller) adopt(ctx context.Context, log *logrus.Entry, doc *api.OpenShiftClusterDocument) (bool, error) {
+	if !b.isAdoptable(doc) {
+		return false, nil
+	}
+
+	if doc.OpenShiftCluster.Properties.HiveProfile.Namespace != "" {
+		_, err := b.hiveClusterManager.GetClusterDeployment(ctx, doc)
+		if err == nil {
+			return false, nil
+		}
+		if !kerrors.IsNotFound(err) {
+			return false, err
+		}
+	}
+
+	err := b.limiter.Wait(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if doc.OpenShiftCluster.Properties.HiveProfile.Namespace == "" {
+		doc, err = b.createNamespace(ctx, log, doc)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	r, err := azure.ParseResourceID(doc.OpenShiftCluster.ID)
+	if err != nil {
+		return false, err
+	}
+
+	sub, err := b.dbSubscriptions.Get(ctx, r.SubscriptionID)
+	if err != nil {
+		return false, err
+	}
+
+	log.Info("registering with hive")
+	err = b.hiveClusterManager.CreateOrUpdate(ctx, sub, doc)
+	if err != nil {
+		return false, err
+	}
+
+	log.Info("waiting for cluster deployment to become ready")
+	timeoutCtx, cancel := context.WithTimeout(ctx, b.verifyTimeout)
+	defer cancel()
+
+	err = wait.PollImmediateUntil(b.verifyInterval, func() (bool, error) {
+		return b.hiveClusterManager.IsClusterDeploymentReady(timeoutCtx, doc)
+	}, timeoutCtx.Done())
+	if err != nil {
+		return false, fmt.Errorf("cluster deployment not ready: %w", err)
+	}
+
+	return true, nil
+}
+
+// createNamespace creates the cluster's namespace in Hive and records it in
+// the cluster document.  The namespace name is derived from the document ID,
+// so a namespace left behind by an interrupted run is reused.
+func (b *Backfiller) createNamespace(ctx context.Context, log *logrus.Entry, doc *api.OpenShiftClusterDocument) (*api.OpenShiftClusterDocument, error) {
+	log.Info("creating a namespace in the hive cluster")
+
+	namespace := "aro-" + doc.ID
+
+	ns, err := b.hiveClusterManager.CreateNamespace(ctx, doc.ID)
+	switch {
+	case kerrors.IsAlreadyExists(err):
+	case err != nil:
+		return nil, err
+	default:
+		namespace = ns.Name
+	}
+
+	return b.dbOpenShiftClusters.Patch(ctx, doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		if !b.isAdoptable(doc) {
+			return errNotAdoptable
+		}
+
+		if doc.OpenShiftCluster.Properties.HiveProfile.Namespace == "" {
+			doc.OpenShiftCluster.Properties.HiveProfile.Namespace = namespace
+			doc.OpenShiftCluster.Properties.HiveProfile.Shard = b.shard
+		}
+
+		return nil
+	})
+}
+
+// isAdoptable returns true for clusters which are not in the middle of an
+// operation, were not installed by Hive and are not assigned to another
+// shard.  Clusters with an operation in flight are adopted by the backend as
+// part of that operation if need be.
+func (b *Backfiller) isAdoptable(doc *api.OpenShiftClusterDocument) bool {
+	return doc.OpenShiftCluster.Properties.ProvisioningState == api.ProvisioningStateSucceeded &&
+		!doc.OpenShiftCluster.Properties.HiveProfile.CreatedByHive &&
+		(doc.OpenShiftCluster.Properties.HiveProfile.Shard == 0 && doc.OpenShiftCluster.Properties.HiveProfile.Namespace == "" ||
+			hive.Shard(doc) == b.shard)
+}
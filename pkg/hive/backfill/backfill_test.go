@@ -0,0 +1,165 @@
+package backfill
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/hive"
+	mock_hive "github.com/Azure/ARO-RP/pkg/util/mocks/hive"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		subscriptionID = "00000000-0000-0000-0000-000000000000"
+		docID          = "00000000-0000-0000-0000-000000000001"
+	)
+	resourceID := "/subscriptions/" + subscriptionID + "/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName"
+	notFound := kerrors.NewNotFound(schema.GroupResource{Group: "hive.openshift.io", Resource: "clusterdeployments"}, "cluster")
+
+	for _, tt := range []struct {
+		name          string
+		state         api.ProvisioningState
+		hiveProfile   api.HiveProfile
+		mocks         func(*mock_hive.MockClusterManager)
+		wantNamespace string
+		wantErr       string
+	}{
+		{
+			name:  "adopts a cluster",
+			state: api.ProvisioningStateSucceeded,
+			mocks: func(hiveClusterManager *mock_hive.MockClusterManager) {
+				hiveClusterManager.EXPECT().CreateNamespace(gomock.Any(), docID).Return(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "aro-" + docID}}, nil)
+				hiveClusterManager.EXPECT().CreateOrUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				hiveClusterManager.EXPECT().IsClusterDeploymentReady(gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			wantNamespace: "aro-" + docID,
+		},
+		{
+			name:  "reuses a namespace left behind by an interrupted run",
+			state: api.ProvisioningStateSucceeded,
+			mocks: func(hiveClusterManager *mock_hive.MockClusterManager) {
+				hiveClusterManager.EXPECT().CreateNamespace(gomock.Any(), docID).Return(nil, kerrors.NewAlreadyExists(schema.GroupResource{Resource: "namespaces"}, "aro-"+docID))
+				hiveClusterManager.EXPECT().CreateOrUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				hiveClusterManager.EXPECT().IsClusterDeploymentReady(gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			wantNamespace: "aro-" + docID,
+		},
+		{
+			name:        "resumes a cluster without a ClusterDeployment",
+			state:       api.ProvisioningStateSucceeded,
+			hiveProfile: api.HiveProfile{Namespace: "aro-" + docID},
+			mocks: func(hiveClusterManager *mock_hive.MockClusterManager) {
+				hiveClusterManager.EXPECT().GetClusterDeployment(gomock.Any(), gomock.Any()).Return(nil, notFound)
+				hiveClusterManager.EXPECT().CreateOrUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				hiveClusterManager.EXPECT().IsClusterDeploymentReady(gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			wantNamespace: "aro-" + docID,
+		},
+		{
+			name:        "skips an adopted cluster",
+			state:       api.ProvisioningStateSucceeded,
+			hiveProfile: api.HiveProfile{Namespace: "aro-" + docID},
+			mocks: func(hiveClusterManager *mock_hive.MockClusterManager) {
+				hiveClusterManager.EXPECT().GetClusterDeployment(gomock.Any(), gomock.Any()).Return(&hivev1.ClusterDeployment{}, nil)
+			},
+			wantNamespace: "aro-" + docID,
+		},
+		{
+			name:        "skips a cluster assigned to another shard",
+			state:       api.ProvisioningStateSucceeded,
+			hiveProfile: api.HiveProfile{Shard: 2},
+		},
+		{
+			name:  "skips a cluster with an operation in flight",
+			state: api.ProvisioningStateUpdating,
+		},
+		{
+			name:          "skips a cluster installed by hive",
+			state:         api.ProvisioningStateSucceeded,
+			hiveProfile:   api.HiveProfile{Namespace: "aro-" + docID, CreatedByHive: true},
+			wantNamespace: "aro-" + docID,
+		},
+		{
+			name:  "fails if the ClusterDeployment does not become ready",
+			state: api.ProvisioningStateSucceeded,
+			mocks: func(hiveClusterManager *mock_hive.MockClusterManager) {
+				hiveClusterManager.EXPECT().CreateNamespace(gomock.Any(), docID).Return(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "aro-" + docID}}, nil)
+				hiveClusterManager.EXPECT().CreateOrUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				hiveClusterManager.EXPECT().IsClusterDeploymentReady(gomock.Any(), gomock.Any()).Return(false, errors.New("unreachable"))
+			},
+			wantNamespace: "aro-" + docID,
+			wantErr:       "failed to adopt 1 clusters",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			hiveClusterManager := mock_hive.NewMockClusterManager(controller)
+			if tt.mocks != nil {
+				tt.mocks(hiveClusterManager)
+			}
+
+			dbOpenShiftClusters, _ := testdatabase.NewFakeOpenShiftClusters()
+			dbSubscriptions, _ := testdatabase.NewFakeSubscriptions()
+
+			f := testdatabase.NewFixture().WithOpenShiftClusters(dbOpenShiftClusters).WithSubscriptions(dbSubscriptions)
+			f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+				ID: subscriptionID,
+				Subscription: &api.Subscription{
+					State: api.SubscriptionStateRegistered,
+				},
+			})
+			f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				ID:  docID,
+				Key: strings.ToLower(resourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: tt.state,
+						HiveProfile:       tt.hiveProfile,
+					},
+				},
+			})
+
+			err := f.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			b := New(logrus.NewEntry(logrus.StandardLogger()), dbOpenShiftClusters, dbSubscriptions, hiveClusterManager, hive.DefaultShard, time.Millisecond)
+			b.verifyInterval = time.Millisecond
+
+			err = b.Run(ctx, "")
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			doc, err := dbOpenShiftClusters.Get(ctx, strings.ToLower(resourceID))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if doc.OpenShiftCluster.Properties.HiveProfile.Namespace != tt.wantNamespace {
+				t.Error(doc.OpenShiftCluster.Properties.HiveProfile.Namespace)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package hive
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/pointerutils"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+func (hr *clusterManager) Deprovision(ctx context.Context, doc *api.OpenShiftClusterDocument) error {
+	cdp := clusterDeprovision(
+		doc.OpenShiftCluster.Properties.HiveProfile.Namespace,
+		doc.OpenShiftCluster.Name,
+		doc.ID,
+		doc.OpenShiftCluster.Properties.InfraID,
+		stringutils.LastTokenByte(doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/'),
+	)
+
+	err := hr.hiveClientset.Create(ctx, cdp)
+	if kerrors.IsAlreadyExists(err) {
+		return nil
+	}
+
+	return err
+}
+
+func (hr *clusterManager) IsClusterDeprovisionComplete(ctx context.Context, doc *api.OpenShiftClusterDocument) (bool, error) {
+	cdp := &hivev1.ClusterDeprovision{}
+	err := hr.hiveClientset.Get(ctx, client.ObjectKey{
+		Namespace: doc.OpenShiftCluster.Properties.HiveProfile.Namespace,
+		Name:      ClusterDeploymentName,
+	}, cdp)
+	if err != nil {
+		return false, hr.handleClusterDeploymentGetError(err)
+	}
+
+	if cdp.Status.Completed {
+		return true, nil
+	}
+
+	for _, cond := range cdp.Status.Conditions {
+		switch cond.Type {
+		case hivev1.AuthenticationFailureClusterDeprovisionCondition,
+			hivev1.DeprovisionFailedClusterDeprovisionCondition:
+			if cond.Status == corev1.ConditionTrue {
+				return false, fmt.Errorf("cluster deprovision failed: %s: %s", cond.Reason, cond.Message)
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// clusterDeprovision returns a ClusterDeprovision which deletes the cluster's
+// managed resource group using the credentials in the cluster's adopted
+// ClusterDeployment.  DNS is not managed by Hive for ARO clusters, so no base
+// domain is given.
+func clusterDeprovision(namespace, clusterName, clusterID, infraID, resourceGroupName string) *hivev1.ClusterDeprovision {
+	return &hivev1.ClusterDeprovision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ClusterDeploymentName,
+			Namespace: namespace,
+		},
+		Spec: hivev1.ClusterDeprovisionSpec{
+			InfraID:     infraID,
+			ClusterID:   clusterID,
+			ClusterName: clusterName,
+			Platform: hivev1.ClusterDeprovisionPlatform{
+				Azure: &hivev1.AzureClusterDeprovision{
+					CredentialsSecretRef: &corev1.LocalObjectReference{
+						Name: clusterServicePrincipalSecretName,
+					},
+					ResourceGroupName: pointerutils.ToPtr(resourceGroupName),
+				},
+			},
+		},
+	}
+}
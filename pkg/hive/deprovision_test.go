@@ -0,0 +1,158 @@
+package hive
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestDeprovision(t *testing.T) {
+	fakeNamespace := "aro-00000000-0000-0000-0000-000000000000"
+	doc := &api.OpenShiftClusterDocument{
+		ID: "00000000-0000-0000-0000-000000000000",
+		OpenShiftCluster: &api.OpenShiftCluster{
+			Name: "cluster",
+			Properties: api.OpenShiftClusterProperties{
+				InfraID: "infraid",
+				ClusterProfile: api.ClusterProfile{
+					ResourceGroupID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/aro-cluster",
+				},
+				HiveProfile: api.HiveProfile{
+					Namespace: fakeNamespace,
+				},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		existing bool
+	}{
+		{name: "creates the clusterdeprovision"},
+		{name: "clusterdeprovision already exists", existing: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClientBuilder := fake.NewClientBuilder()
+			if tt.existing {
+				fakeClientBuilder = fakeClientBuilder.WithRuntimeObjects(&hivev1.ClusterDeprovision{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      ClusterDeploymentName,
+						Namespace: fakeNamespace,
+					},
+				})
+			}
+			c := clusterManager{
+				hiveClientset: fakeClientBuilder.Build(),
+				log:           logrus.NewEntry(logrus.StandardLogger()),
+			}
+
+			err := c.Deprovision(context.Background(), doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.existing {
+				return
+			}
+
+			cdp := &hivev1.ClusterDeprovision{}
+			err = c.hiveClientset.Get(context.Background(), client.ObjectKey{Namespace: fakeNamespace, Name: ClusterDeploymentName}, cdp)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if cdp.Spec.InfraID != "infraid" || cdp.Spec.ClusterID != doc.ID || cdp.Spec.ClusterName != "cluster" {
+				t.Error(cdp.Spec)
+			}
+
+			azure := cdp.Spec.Platform.Azure
+			if azure == nil || azure.ResourceGroupName == nil || *azure.ResourceGroupName != "aro-cluster" ||
+				azure.CredentialsSecretRef == nil || azure.CredentialsSecretRef.Name != clusterServicePrincipalSecretName {
+				t.Error(azure)
+			}
+		})
+	}
+}
+
+func TestIsClusterDeprovisionComplete(t *testing.T) {
+	fakeNamespace := "aro-00000000-0000-0000-0000-000000000000"
+	doc := &api.OpenShiftClusterDocument{
+		OpenShiftCluster: &api.OpenShiftCluster{
+			Properties: api.OpenShiftClusterProperties{
+				HiveProfile: api.HiveProfile{
+					Namespace: fakeNamespace,
+				},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name       string
+		status     hivev1.ClusterDeprovisionStatus
+		wantResult bool
+		wantErr    string
+	}{
+		{
+			name:       "completed",
+			status:     hivev1.ClusterDeprovisionStatus{Completed: true},
+			wantResult: true,
+		},
+		{
+			name: "in progress",
+			status: hivev1.ClusterDeprovisionStatus{
+				Conditions: []hivev1.ClusterDeprovisionCondition{
+					{
+						Type:   hivev1.AuthenticationFailureClusterDeprovisionCondition,
+						Status: corev1.ConditionFalse,
+					},
+				},
+			},
+		},
+		{
+			name: "failed",
+			status: hivev1.ClusterDeprovisionStatus{
+				Conditions: []hivev1.ClusterDeprovisionCondition{
+					{
+						Type:    hivev1.AuthenticationFailureClusterDeprovisionCondition,
+						Status:  corev1.ConditionTrue,
+						Reason:  "AuthenticationFailed",
+						Message: "Credentials are invalid",
+					},
+				},
+			},
+			wantErr: "cluster deprovision failed: AuthenticationFailed: Credentials are invalid",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := clusterManager{
+				hiveClientset: fake.NewClientBuilder().WithRuntimeObjects(&hivev1.ClusterDeprovision{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      ClusterDeploymentName,
+						Namespace: fakeNamespace,
+					},
+					Status: tt.status,
+				}).Build(),
+				log: logrus.NewEntry(logrus.StandardLogger()),
+			}
+
+			result, err := c.IsClusterDeprovisionComplete(context.Background(), doc)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if result != tt.wantResult {
+				t.Error(result)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package hive
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/pointerutils"
+)
+
+const (
+	// hiveJobTypeLabel is set by Hive on its install and uninstall pods
+	hiveJobTypeLabel = "hive.openshift.io/job-type"
+
+	// podLogTailLines limits the log returned for each container
+	podLogTailLines = 1000
+)
+
+// ProvisionLogs holds what is needed to debug the installation or
+// deprovisioning of a cluster by Hive without access to the Hive shard.
+type ProvisionLogs struct {
+	Provisions  []ProvisionAttempt `json:"provisions,omitempty"`
+	Deprovision *DeprovisionStatus `json:"deprovision,omitempty"`
+	Pods        []PodLog           `json:"pods,omitempty"`
+}
+
+// ProvisionAttempt is the outcome of one of the cluster's ClusterProvisions.
+type ProvisionAttempt struct {
+	Name           string `json:"name"`
+	Attempt        int    `json:"attempt"`
+	Stage          string `json:"stage"`
+	FailureReason  string `json:"failureReason,omitempty"`
+	FailureMessage string `json:"failureMessage,omitempty"`
+	InstallLog     string `json:"installLog,omitempty"`
+}
+
+// DeprovisionStatus is the outcome of the cluster's ClusterDeprovision.
+type DeprovisionStatus struct {
+	Completed      bool   `json:"completed"`
+	FailureReason  string `json:"failureReason,omitempty"`
+	FailureMessage string `json:"failureMessage,omitempty"`
+}
+
+// PodLog is the tail of the log of a container in one of Hive's install or
+// uninstall pods.
+type PodLog struct {
+	Name      string `json:"name"`
+	JobType   string `json:"jobType"`
+	Container string `json:"container"`
+	Log       string `json:"log,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+func (hr *clusterManager) GetProvisionLogs(ctx context.Context, doc *api.OpenShiftClusterDocument) (*ProvisionLogs, error) {
+	namespace := doc.OpenShiftCluster.Properties.HiveProfile.Namespace
+	logs := &ProvisionLogs{}
+
+	provisions, err := hr.ListClusterProvisions(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cp := range provisions {
+		attempt := ProvisionAttempt{
+			Name:    cp.Name,
+			Attempt: cp.Spec.Attempt,
+			Stage:   string(cp.Spec.Stage),
+		}
+		if cp.Spec.InstallLog != nil {
+			attempt.InstallLog = *cp.Spec.InstallLog
+		}
+		for _, cond := range cp.Status.Conditions {
+			if cond.Type == hivev1.ClusterProvisionFailedCondition && cond.Status == corev1.ConditionTrue {
+				attempt.FailureReason = cond.Reason
+				attempt.FailureMessage = cond.Message
+			}
+		}
+		logs.Provisions = append(logs.Provisions, attempt)
+	}
+
+	cdp := &hivev1.ClusterDeprovision{}
+	err = hr.hiveClientset.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ClusterDeploymentName}, cdp)
+	switch {
+	case kerrors.IsNotFound(err):
+	case err != nil:
+		return nil, err
+	default:
+		logs.Deprovision = &DeprovisionStatus{Completed: cdp.Status.Completed}
+		for _, cond := range cdp.Status.Conditions {
+			switch cond.Type {
+			case hivev1.AuthenticationFailureClusterDeprovisionCondition,
+				hivev1.DeprovisionFailedClusterDeprovisionCondition:
+				if cond.Status == corev1.ConditionTrue {
+					logs.Deprovision.FailureReason = cond.Reason
+					logs.Deprovision.FailureMessage = cond.Message
+				}
+			}
+		}
+	}
+
+	pods, err := hr.kubernetescli.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: hiveJobTypeLabel,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			podLog := PodLog{
+				Name:      pod.Name,
+				JobType:   pod.Labels[hiveJobTypeLabel],
+				Container: container.Name,
+			}
+
+			b, err := hr.kubernetescli.CoreV1().Pods(namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+				Container: container.Name,
+				TailLines: pointerutils.ToPtr(int64(podLogTailLines)),
+			}).DoRaw(ctx)
+			if err != nil {
+				// the container may not have started yet
+				podLog.Error = err.Error()
+			} else {
+				podLog.Log = string(b)
+			}
+
+			logs.Pods = append(logs.Pods, podLog)
+		}
+	}
+
+	return logs, nil
+}
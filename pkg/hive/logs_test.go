@@ -0,0 +1,132 @@
+package hive
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/pointerutils"
+)
+
+func TestGetProvisionLogs(t *testing.T) {
+	fakeNamespace := "aro-00000000-0000-0000-0000-000000000000"
+	doc := &api.OpenShiftClusterDocument{
+		OpenShiftCluster: &api.OpenShiftCluster{
+			Properties: api.OpenShiftClusterProperties{
+				HiveProfile: api.HiveProfile{
+					Namespace: fakeNamespace,
+				},
+			},
+		},
+	}
+
+	provision := &hivev1.ClusterProvision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-0-aaaaa",
+			Namespace: fakeNamespace,
+			Labels: map[string]string{
+				"hive.openshift.io/cluster-deployment-name": ClusterDeploymentName,
+			},
+		},
+		Spec: hivev1.ClusterProvisionSpec{
+			Stage:      hivev1.ClusterProvisionStageFailed,
+			InstallLog: pointerutils.ToPtr("level=error msg=failed"),
+		},
+		Status: hivev1.ClusterProvisionStatus{
+			Conditions: []hivev1.ClusterProvisionCondition{
+				{
+					Type:    hivev1.ClusterProvisionFailedCondition,
+					Status:  corev1.ConditionTrue,
+					Reason:  "AzureQuotaExceeded",
+					Message: "quota exceeded",
+				},
+			},
+		},
+	}
+
+	deprovision := &hivev1.ClusterDeprovision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ClusterDeploymentName,
+			Namespace: fakeNamespace,
+		},
+		Status: hivev1.ClusterDeprovisionStatus{
+			Conditions: []hivev1.ClusterDeprovisionCondition{
+				{
+					Type:    hivev1.AuthenticationFailureClusterDeprovisionCondition,
+					Status:  corev1.ConditionTrue,
+					Reason:  "AuthenticationFailed",
+					Message: "invalid credentials",
+				},
+			},
+		},
+	}
+
+	pod := func(name, jobType string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: fakeNamespace,
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "installer"},
+				},
+			},
+		}
+		if jobType != "" {
+			pod.Labels = map[string]string{hiveJobTypeLabel: jobType}
+		}
+		return pod
+	}
+
+	c := clusterManager{
+		hiveClientset: fake.NewClientBuilder().WithRuntimeObjects(provision, deprovision).Build(),
+		kubernetescli: kubernetesfake.NewSimpleClientset(
+			pod("cluster-0-aaaaa-provision", "provision"),
+			pod("unrelated", ""),
+		),
+		log: logrus.NewEntry(logrus.StandardLogger()),
+	}
+
+	logs, err := c.GetProvisionLogs(context.Background(), doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, diff := range deep.Equal(logs, &ProvisionLogs{
+		Provisions: []ProvisionAttempt{
+			{
+				Name:           "cluster-0-aaaaa",
+				Stage:          "failed",
+				FailureReason:  "AzureQuotaExceeded",
+				FailureMessage: "quota exceeded",
+				InstallLog:     "level=error msg=failed",
+			},
+		},
+		Deprovision: &DeprovisionStatus{
+			FailureReason:  "AuthenticationFailed",
+			FailureMessage: "invalid credentials",
+		},
+		Pods: []PodLog{
+			{
+				Name:      "cluster-0-aaaaa-provision",
+				JobType:   "provision",
+				Container: "installer",
+				Log:       "fake logs",
+			},
+		},
+	}) {
+		t.Error(diff)
+	}
+}
@@ -6,11 +6,11 @@ package hive
 import (
 	"context"
 	"errors"
-	"fmt"
 	"sort"
 	"strings"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveinternalv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -35,6 +35,10 @@ type ClusterManager interface {
 	// existing cluster. This may adopt the cluster (Create) or amend the
 	// existing resources (Update).
 	CreateOrUpdate(ctx context.Context, sub *api.SubscriptionDocument, doc *api.OpenShiftClusterDocument) error
+	// Deprovision requests that Hive deletes the cluster's managed resource
+	// group.  The cluster must already have been adopted.
+	Deprovision(ctx context.Context, doc *api.OpenShiftClusterDocument) error
+	IsClusterDeprovisionComplete(ctx context.Context, doc *api.OpenShiftClusterDocument) (bool, error)
 	// Delete removes the cluster from Hive.
 	Delete(ctx context.Context, doc *api.OpenShiftClusterDocument) error
 	// Install creates a ClusterDocument and related secrets for a new cluster
@@ -43,6 +47,18 @@ type ClusterManager interface {
 	IsClusterDeploymentReady(ctx context.Context, doc *api.OpenShiftClusterDocument) (bool, error)
 	IsClusterInstallationComplete(ctx context.Context, doc *api.OpenShiftClusterDocument) (bool, error)
 	GetClusterDeployment(ctx context.Context, doc *api.OpenShiftClusterDocument) (*hivev1.ClusterDeployment, error)
+	// ListClusterProvisions returns the provision attempts of the cluster's
+	// ClusterDeployment, most recent first.
+	ListClusterProvisions(ctx context.Context, doc *api.OpenShiftClusterDocument) ([]hivev1.ClusterProvision, error)
+	// GetClusterSync returns the status of the SyncSets and SelectorSyncSets
+	// applied to the cluster.
+	GetClusterSync(ctx context.Context, doc *api.OpenShiftClusterDocument) (*hiveinternalv1alpha1.ClusterSync, error)
+	// GetProvisionLogs returns the cluster's provision attempts, the status of
+	// its deprovision, and the logs of Hive's install and uninstall pods.
+	GetProvisionLogs(ctx context.Context, doc *api.OpenShiftClusterDocument) (*ProvisionLogs, error)
+	// EnsureSyncSet creates or updates the named SyncSet, which applies
+	// resources to the cluster and reconciles any drift.
+	EnsureSyncSet(ctx context.Context, doc *api.OpenShiftClusterDocument, name string, resources []kruntime.Object) error
 	ResetCorrelationData(ctx context.Context, doc *api.OpenShiftClusterDocument) error
 }
 
@@ -60,6 +76,8 @@ type clusterManager struct {
 // and we do not want to restrict the frontend from starting up successfully.
 // It has the caveat of requiring a nil check on any operations performed with the returned ClusterManager
 // until this conditional return is removed (we have hive everywhere).
+//
+// The returned ClusterManager is sharded; see NewSharded.
 func NewFromEnv(ctx context.Context, log *logrus.Entry, env env.Interface) (ClusterManager, error) {
 	adoptByHive, err := env.LiveConfig().AdoptByHive(ctx)
 	if err != nil {
@@ -73,12 +91,8 @@ func NewFromEnv(ctx context.Context, log *logrus.Entry, env env.Interface) (Clus
 		log.Infof("hive is disabled, skipping creation of ClusterManager")
 		return nil, nil
 	}
-	hiveShard := 1
-	hiveRestConfig, err := env.LiveConfig().HiveRestConfig(ctx, hiveShard)
-	if err != nil {
-		return nil, fmt.Errorf("failed getting RESTConfig for Hive shard %d: %w", hiveShard, err)
-	}
-	return NewFromConfig(log, env, hiveRestConfig)
+
+	return NewSharded(log, env, env.LiveConfig()), nil
 }
 
 // NewFromConfig creates a ClusterManager.
@@ -224,6 +238,36 @@ func (hr *clusterManager) GetClusterDeployment(ctx context.Context, doc *api.Ope
 	return cd, nil
 }
 
+func (hr *clusterManager) ListClusterProvisions(ctx context.Context, doc *api.OpenShiftClusterDocument) ([]hivev1.ClusterProvision, error) {
+	provisionList := &hivev1.ClusterProvisionList{}
+	err := hr.hiveClientset.List(
+		ctx,
+		provisionList,
+		client.InNamespace(doc.OpenShiftCluster.Properties.HiveProfile.Namespace),
+		client.MatchingLabels(map[string]string{clusterDeploymentNameLabel: ClusterDeploymentName}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(provisionList.Items, func(i, j int) bool { return provisionList.Items[i].Spec.Attempt > provisionList.Items[j].Spec.Attempt })
+
+	return provisionList.Items, nil
+}
+
+func (hr *clusterManager) GetClusterSync(ctx context.Context, doc *api.OpenShiftClusterDocument) (*hiveinternalv1alpha1.ClusterSync, error) {
+	cs := &hiveinternalv1alpha1.ClusterSync{}
+	err := hr.hiveClientset.Get(ctx, client.ObjectKey{
+		Namespace: doc.OpenShiftCluster.Properties.HiveProfile.Namespace,
+		Name:      ClusterDeploymentName,
+	}, cs)
+	if err != nil {
+		return nil, err
+	}
+
+	return cs, nil
+}
+
 // handleClusterDeploymentGetError is intended to take in an error value returned by hr.GetClusterDeployment()
 // and apply some special handling: if we encounter a transient connection error, return nil so that the RP continues
 // polling the ClusterDeployment. Otherwise, return the error that was passed in.
@@ -260,7 +304,7 @@ func (hr *clusterManager) installLogsForLatestDeployment(ctx context.Context, cd
 		ctx,
 		provisionList,
 		client.InNamespace(cd.Namespace),
-		client.MatchingLabels(map[string]string{"hive.openshift.io/cluster-deployment-name": cd.Name}),
+		client.MatchingLabels(map[string]string{clusterDeploymentNameLabel: cd.Name}),
 	); err != nil {
 		hr.log.WithError(err).Warn("could not list provisions for clusterdeployment")
 		return nil, err
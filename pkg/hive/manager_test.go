@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveinternalv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
 	"github.com/sirupsen/logrus"
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
@@ -566,3 +567,103 @@ func TestGetClusterDeployment(t *testing.T) {
 		})
 	}
 }
+
+func TestListClusterProvisions(t *testing.T) {
+	fakeNamespace := "aro-00000000-0000-0000-0000-000000000000"
+	doc := &api.OpenShiftClusterDocument{
+		OpenShiftCluster: &api.OpenShiftCluster{
+			Properties: api.OpenShiftClusterProperties{
+				HiveProfile: api.HiveProfile{
+					Namespace: fakeNamespace,
+				},
+			},
+		},
+	}
+
+	provision := func(name, namespace string, attempt int) *hivev1.ClusterProvision {
+		return &hivev1.ClusterProvision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					"hive.openshift.io/cluster-deployment-name": ClusterDeploymentName,
+				},
+			},
+			Spec: hivev1.ClusterProvisionSpec{
+				Attempt: attempt,
+			},
+		}
+	}
+
+	c := clusterManager{
+		hiveClientset: fake.NewClientBuilder().WithRuntimeObjects(
+			provision("cluster-0-aaaaa", fakeNamespace, 0),
+			provision("cluster-1-bbbbb", fakeNamespace, 1),
+			provision("cluster-0-ccccc", "aro-11111111-1111-1111-1111-111111111111", 0),
+		).Build(),
+		log: logrus.NewEntry(logrus.StandardLogger()),
+	}
+
+	provisions, err := c.ListClusterProvisions(context.Background(), doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, p := range provisions {
+		names = append(names, p.Name)
+	}
+
+	if !reflect.DeepEqual(names, []string{"cluster-1-bbbbb", "cluster-0-aaaaa"}) {
+		t.Error(names)
+	}
+}
+
+func TestGetClusterSync(t *testing.T) {
+	fakeNamespace := "aro-00000000-0000-0000-0000-000000000000"
+	doc := &api.OpenShiftClusterDocument{
+		OpenShiftCluster: &api.OpenShiftCluster{
+			Properties: api.OpenShiftClusterProperties{
+				HiveProfile: api.HiveProfile{
+					Namespace: fakeNamespace,
+				},
+			},
+		},
+	}
+
+	cs := &hiveinternalv1alpha1.ClusterSync{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ClusterDeploymentName,
+			Namespace: fakeNamespace,
+		},
+	}
+
+	for _, tt := range []struct {
+		name    string
+		wantErr string
+	}{
+		{name: "clustersync exists and is returned"},
+		{name: "clustersync does not exist err returned", wantErr: `clustersyncs.hiveinternal.openshift.io "cluster" not found`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClientBuilder := fake.NewClientBuilder()
+			if tt.wantErr == "" {
+				fakeClientBuilder = fakeClientBuilder.WithRuntimeObjects(cs)
+			}
+			c := clusterManager{
+				hiveClientset: fakeClientBuilder.Build(),
+				log:           logrus.NewEntry(logrus.StandardLogger()),
+			}
+
+			result, err := c.GetClusterSync(context.Background(), doc)
+			if err != nil && err.Error() != tt.wantErr ||
+				err == nil && tt.wantErr != "" {
+				t.Fatal(err)
+			}
+
+			if result != nil && (result.Name != cs.Name || result.Namespace != cs.Namespace) {
+				t.Fatal("Unexpected cluster sync returned", result)
+			}
+		})
+	}
+}
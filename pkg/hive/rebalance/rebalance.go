@@ -0,0 +1,298 @@
+package rebalance
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/hive"
+)
+
+var errNotMovable = errors.New("cluster is no longer movable")
+
+// Rebalancer moves adopted clusters between Hive shards so that the number of
+// clusters on each shard is in proportion to the shard's weight.
+//
+// A cluster is moved by adopting it on the new shard, waiting for its
+// ClusterDeployment there to become ready, recording the new shard in the
+// cluster document and then removing the cluster from the old shard.
+// Adopted ClusterDeployments preserve the cluster on deletion, so removing
+// the cluster from the old shard does not affect it.  Clusters which were
+// installed by Hive or which have an operation in flight are not moved.
+//
+// Moves are rate limited so as not to overload Hive with new
+// ClusterDeployments.
+type Rebalancer struct {
+	log *logrus.Entry
+
+	dbOpenShiftClusters database.OpenShiftClusters
+	dbSubscriptions     database.Subscriptions
+	hiveClusterManagers map[int]hive.ClusterManager
+	weights             map[int]int
+	dryRun              bool
+
+	limiter *rate.Limiter
+
+	verifyInterval time.Duration
+	verifyTimeout  time.Duration
+}
+
+// move is a planned move of a cluster from one shard to another.
+type move struct {
+	doc  *api.OpenShiftClusterDocument
+	from int
+	to   int
+}
+
+// New returns a Rebalancer which moves at most one cluster every interval.
+// hiveClusterManagers must have a ClusterManager for every shard in weights.
+// If dryRun is set, the planned moves are only logged.
+func New(log *logrus.Entry, dbOpenShiftClusters database.OpenShiftClusters, dbSubscriptions database.Subscriptions, hiveClusterManagers map[int]hive.ClusterManager, weights map[int]int, interval time.Duration, dryRun bool) *Rebalancer {
+	return &Rebalancer{
+		log: log,
+
+		dbOpenShiftClusters: dbOpenShiftClusters,
+		dbSubscriptions:     dbSubscriptions,
+		hiveClusterManagers: hiveClusterManagers,
+		weights:             weights,
+		dryRun:              dryRun,
+
+		limiter: rate.NewLimiter(rate.Every(interval), 1),
+
+		verifyInterval: 10 * time.Second,
+		verifyTimeout:  5 * time.Minute,
+	}
+}
+
+// Run plans and carries out the moves needed to rebalance the shards.  It
+// carries on past clusters which fail to be moved, and returns an error at
+// the end if there were any.
+func (r *Rebalancer) Run(ctx context.Context) error {
+	moves, err := r.plan(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.log.Infof("planned %d moves", len(moves))
+
+	var moved, skipped, failed int
+
+	for _, mv := range moves {
+		log := r.log.WithField("resource_id", mv.doc.OpenShiftCluster.ID)
+
+		if r.dryRun {
+			log.Infof("would move from shard %d to shard %d", mv.from, mv.to)
+			continue
+		}
+
+		err := r.move(ctx, log, mv)
+		switch {
+		case errors.Is(err, errNotMovable):
+			log.Info("skipping: cluster is no longer movable")
+			skipped++
+		case err != nil:
+			log.Errorf("moving: %s", err)
+			failed++
+		default:
+			log.Infof("moved from shard %d to shard %d", mv.from, mv.to)
+			moved++
+		}
+	}
+
+	r.log.Infof("moved %d clusters, skipped %d, failed %d", moved, skipped, failed)
+
+	if failed > 0 {
+		return fmt.Errorf("failed to move %d clusters", failed)
+	}
+
+	return nil
+}
+
+// plan counts the clusters registered with each shard and returns the moves
+// which bring each shard as close as possible to its share of the clusters.
+func (r *Rebalancer) plan(ctx context.Context) ([]move, error) {
+	counts := map[int]int{}
+	movable := map[int][]*api.OpenShiftClusterDocument{}
+
+	i := r.dbOpenShiftClusters.List("")
+	for {
+		docs, err := i.Next(ctx, -1)
+		if err != nil {
+			return nil, err
+		}
+		if docs == nil {
+			break
+		}
+
+		for _, doc := range docs.OpenShiftClusterDocuments {
+			if doc.OpenShiftCluster.Properties.HiveProfile.Namespace == "" {
+				continue
+			}
+
+			shard := hive.Shard(doc)
+			counts[shard]++
+
+			if isMovable(doc, shard) {
+				movable[shard] = append(movable[shard], doc)
+			}
+		}
+	}
+
+	targets := targets(counts, r.weights)
+
+	var moves []move
+	for _, from := range sortedKeys(counts) {
+		for counts[from] > targets[from] && len(movable[from]) > 0 {
+			to := mostUnderTarget(counts, targets)
+			if to == 0 {
+				return moves, nil
+			}
+
+			moves = append(moves, move{doc: movable[from][0], from: from, to: to})
+			movable[from] = movable[from][1:]
+			counts[from]--
+			counts[to]++
+		}
+	}
+
+	return moves, nil
+}
+
+// move moves a single cluster to a new shard.
+func (r *Rebalancer) move(ctx context.Context, log *logrus.Entry, mv move) error {
+	from, to := r.hiveClusterManagers[mv.from], r.hiveClusterManagers[mv.to]
+	if from == nil || to == nil {
+		return fmt.Errorf("no cluster manager for shard %d or %d", mv.from, mv.to)
+	}
+
+	err := r.limiter.Wait(ctx)
+	if err != nil {
+		return err
+	}
+
+	doc := mv.doc
+
+	log.Infof("creating a namespace in hive shard %d", mv.to)
+	ns, err := to.CreateNamespace(ctx, doc.ID)
+	switch {
+	case kerrors.IsAlreadyExists(err):
+	case err != nil:
+		return err
+	case ns.Name != doc.OpenShiftCluster.Properties.HiveProfile.Namespace:
+		return fmt.Errorf("namespace %q does not match %q", ns.Name, doc.OpenShiftCluster.Properties.HiveProfile.Namespace)
+	}
+
+	res, err := azure.ParseResourceID(doc.OpenShiftCluster.ID)
+	if err != nil {
+		return err
+	}
+
+	sub, err := r.dbSubscriptions.Get(ctx, res.SubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("registering with hive shard %d", mv.to)
+	err = to.CreateOrUpdate(ctx, sub, doc)
+	if err != nil {
+		return err
+	}
+
+	log.Info("waiting for cluster deployment to become ready")
+	timeoutCtx, cancel := context.WithTimeout(ctx, r.verifyTimeout)
+	defer cancel()
+
+	err = wait.PollImmediateUntil(r.verifyInterval, func() (bool, error) {
+		return to.IsClusterDeploymentReady(timeoutCtx, doc)
+	}, timeoutCtx.Done())
+	if err != nil {
+		return fmt.Errorf("cluster deployment not ready: %w", err)
+	}
+
+	doc, err = r.dbOpenShiftClusters.Patch(ctx, doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		if !isMovable(doc, mv.from) {
+			return errNotMovable
+		}
+
+		doc.OpenShiftCluster.Properties.HiveProfile.Shard = mv.to
+		return nil
+	})
+	if errors.Is(err, errNotMovable) {
+		// leave the cluster where it was
+		return errors.Join(err, to.Delete(ctx, mv.doc))
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Infof("deregistering from hive shard %d", mv.from)
+	return from.Delete(ctx, doc)
+}
+
+// isMovable returns true for adopted clusters on the given shard which are not
+// in the middle of an operation and were not installed by Hive.
+func isMovable(doc *api.OpenShiftClusterDocument, shard int) bool {
+	return doc.OpenShiftCluster.Properties.ProvisioningState == api.ProvisioningStateSucceeded &&
+		doc.OpenShiftCluster.Properties.HiveProfile.Namespace != "" &&
+		!doc.OpenShiftCluster.Properties.HiveProfile.CreatedByHive &&
+		hive.Shard(doc) == shard
+}
+
+// targets returns each shard's share of the clusters in proportion to its
+// weight, rounded down.  Shards which are not weighted have a target of zero.
+func targets(counts map[int]int, weights map[int]int) map[int]int {
+	var total, totalWeight int
+	for _, count := range counts {
+		total += count
+	}
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+
+	targets := map[int]int{}
+	if totalWeight == 0 {
+		return targets
+	}
+
+	for shard, weight := range weights {
+		targets[shard] = total * weight / totalWeight
+	}
+
+	return targets
+}
+
+// mostUnderTarget returns the weighted shard which is furthest below its
+// target, or 0 if no shard is below its target.
+func mostUnderTarget(counts, targets map[int]int) int {
+	var best, bestDeficit int
+	for _, shard := range sortedKeys(targets) {
+		if deficit := targets[shard] - counts[shard]; deficit > bestDeficit {
+			best, bestDeficit = shard, deficit
+		}
+	}
+
+	return best
+}
+
+func sortedKeys(m map[int]int) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	return keys
+}
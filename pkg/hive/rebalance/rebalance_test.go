@@ -0,0 +1,166 @@
+package rebalance
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/hive"
+	mock_hive "github.com/Azure/ARO-RP/pkg/util/mocks/hive"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	const subscriptionID = "00000000-0000-0000-0000-000000000000"
+
+	for _, tt := range []struct {
+		name      string
+		weights   map[int]int
+		clusters  []api.HiveProfile
+		dryRun    bool
+		mocks     func(from, to *mock_hive.MockClusterManager)
+		wantShard []int
+		wantErr   string
+	}{
+		{
+			name:    "moves clusters to a new shard",
+			weights: map[int]int{1: 1, 2: 1},
+			clusters: []api.HiveProfile{
+				{Namespace: "aro-0"},
+				{Namespace: "aro-1", Shard: 1},
+			},
+			mocks: func(from, to *mock_hive.MockClusterManager) {
+				to.EXPECT().CreateNamespace(gomock.Any(), "0").Return(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "aro-0"}}, nil)
+				to.EXPECT().CreateOrUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				to.EXPECT().IsClusterDeploymentReady(gomock.Any(), gomock.Any()).Return(true, nil)
+				from.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantShard: []int{2, 1},
+		},
+		{
+			name:    "dry run",
+			weights: map[int]int{1: 1, 2: 1},
+			clusters: []api.HiveProfile{
+				{Namespace: "aro-0"},
+				{Namespace: "aro-1"},
+			},
+			dryRun:    true,
+			wantShard: []int{0, 0},
+		},
+		{
+			name:    "does not move clusters installed by hive",
+			weights: map[int]int{1: 1, 2: 1},
+			clusters: []api.HiveProfile{
+				{Namespace: "aro-0", CreatedByHive: true},
+				{Namespace: "aro-1", CreatedByHive: true},
+			},
+			wantShard: []int{0, 0},
+		},
+		{
+			name:    "drains a shard with no weight",
+			weights: map[int]int{1: 0, 2: 1},
+			clusters: []api.HiveProfile{
+				{Namespace: "aro-0"},
+			},
+			mocks: func(from, to *mock_hive.MockClusterManager) {
+				to.EXPECT().CreateNamespace(gomock.Any(), "0").Return(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "aro-0"}}, nil)
+				to.EXPECT().CreateOrUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				to.EXPECT().IsClusterDeploymentReady(gomock.Any(), gomock.Any()).Return(true, nil)
+				from.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantShard: []int{2},
+		},
+		{
+			name:    "leaves the cluster if the ClusterDeployment does not become ready",
+			weights: map[int]int{1: 0, 2: 1},
+			clusters: []api.HiveProfile{
+				{Namespace: "aro-0"},
+			},
+			mocks: func(from, to *mock_hive.MockClusterManager) {
+				to.EXPECT().CreateNamespace(gomock.Any(), "0").Return(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "aro-0"}}, nil)
+				to.EXPECT().CreateOrUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				to.EXPECT().IsClusterDeploymentReady(gomock.Any(), gomock.Any()).Return(false, errors.New("unreachable"))
+			},
+			wantShard: []int{0},
+			wantErr:   "failed to move 1 clusters",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			from := mock_hive.NewMockClusterManager(controller)
+			to := mock_hive.NewMockClusterManager(controller)
+			if tt.mocks != nil {
+				tt.mocks(from, to)
+			}
+
+			dbOpenShiftClusters, _ := testdatabase.NewFakeOpenShiftClusters()
+			dbSubscriptions, _ := testdatabase.NewFakeSubscriptions()
+
+			f := testdatabase.NewFixture().WithOpenShiftClusters(dbOpenShiftClusters).WithSubscriptions(dbSubscriptions)
+			f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+				ID: subscriptionID,
+				Subscription: &api.Subscription{
+					State: api.SubscriptionStateRegistered,
+				},
+			})
+
+			var keys []string
+			for i, hiveProfile := range tt.clusters {
+				resourceID := fmt.Sprintf("/subscriptions/%s/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName%d", subscriptionID, i)
+				keys = append(keys, strings.ToLower(resourceID))
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					ID:  fmt.Sprint(i),
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: resourceID,
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+							HiveProfile:       hiveProfile,
+						},
+					},
+				})
+			}
+
+			err := f.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			managers := map[int]hive.ClusterManager{1: from, 2: to}
+
+			r := New(logrus.NewEntry(logrus.StandardLogger()), dbOpenShiftClusters, dbSubscriptions, managers, tt.weights, time.Millisecond, tt.dryRun)
+			r.verifyInterval = time.Millisecond
+
+			err = r.Run(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			for i, key := range keys {
+				doc, err := dbOpenShiftClusters.Get(ctx, key)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if doc.OpenShiftCluster.Properties.HiveProfile.Shard != tt.wantShard[i] {
+					t.Errorf("cluster %d: shard %d", i, doc.OpenShiftCluster.Properties.HiveProfile.Shard)
+				}
+			}
+		})
+	}
+}
@@ -16,8 +16,10 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/validate"
 	utillog "github.com/Azure/ARO-RP/pkg/util/log"
 	"github.com/Azure/ARO-RP/pkg/util/pullsecret"
+	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
 // Changing values of these constants most likely would require
@@ -31,6 +33,7 @@ const (
 	boundServiceAccountSigningKeySecretKey  = "bound-service-account-signing-key.key"
 	hiveClusterPlatformLabel                = "hive.openshift.io/cluster-platform"
 	hiveClusterRegionLabel                  = "hive.openshift.io/cluster-region"
+	clusterDeploymentNameLabel              = "hive.openshift.io/cluster-deployment-name"
 	hiveInfraDisabledAnnotation             = "hive.openshift.io/infra-disabled"
 )
 
@@ -265,14 +268,68 @@ func pullsecretSecret(namespace string, oc *api.OpenShiftCluster) (*corev1.Secre
 	}, nil
 }
 
-func installConfigCM(namespace string, location string) *corev1.Secret {
+// machinePoolConfig renders an install-config machine pool; prefix is the
+// indentation of its first line, e.g. "- " for a list item
+func machinePoolConfig(prefix, name string, architecture version.Architecture, securityType api.SecurityType, securityEncryptionType api.SecurityEncryptionType) string {
+	s := fmt.Sprintf("%sname: %s\n  architecture: %s\n", prefix, name, architecture)
+
+	// Trusted Launch and confidential VMs boot from the Hyper-V generation 2
+	// image with secure boot and a virtual TPM; confidential VMs also encrypt
+	// their guest state, and optionally their OS disk, with a platform key
+	switch securityType {
+	case api.SecurityTypeTrustedLaunch:
+		s += "  platform:\n    azure:\n      settings:\n        securityType: TrustedLaunch\n" +
+			"        trustedLaunch:\n          uefiSettings:\n            secureBoot: Enabled\n            virtualizedTrustedPlatformModule: Enabled\n"
+	case api.SecurityTypeConfidentialVM:
+		s += "  platform:\n    azure:\n      settings:\n        securityType: ConfidentialVM\n" +
+			"        confidentialVM:\n          uefiSettings:\n            secureBoot: Enabled\n            virtualizedTrustedPlatformModule: Enabled\n" +
+			fmt.Sprintf("      osDisk:\n        securityProfile:\n          securityEncryptionType: %s\n", securityEncryptionType)
+	}
+
+	return s
+}
+
+func installConfigCM(namespace string, oc *api.OpenShiftCluster) *corev1.Secret {
+	installConfig := fmt.Sprintf(installConfigTemplate, oc.Location)
+
+	// the installer picks the boot image of each machine pool by its
+	// architecture and security type; masters are always amd64
+	workerArchitecture := version.ArchitectureAMD64
+	if validate.HasArm64Workers(oc) {
+		workerArchitecture = version.ArchitectureARM64
+	}
+	masterSecurityType := oc.Properties.MasterProfile.SecurityType
+	var workerSecurityType api.SecurityType
+	var workerSecurityEncryptionType api.SecurityEncryptionType
+	if len(oc.Properties.WorkerProfiles) > 0 {
+		workerSecurityType = oc.Properties.WorkerProfiles[0].SecurityType
+		workerSecurityEncryptionType = oc.Properties.WorkerProfiles[0].SecurityEncryptionType
+	}
+	if workerArchitecture != version.ArchitectureAMD64 ||
+		masterSecurityType == api.SecurityTypeTrustedLaunch ||
+		workerSecurityType == api.SecurityTypeTrustedLaunch ||
+		workerSecurityType == api.SecurityTypeConfidentialVM {
+		installConfig += "controlPlane:\n" + machinePoolConfig("  ", "master", version.ArchitectureAMD64, masterSecurityType, "")
+		installConfig += "compute:\n" + machinePoolConfig("- ", "worker", workerArchitecture, workerSecurityType, workerSecurityEncryptionType)
+	}
+
+	// the installer injects the additional trust bundle into the bootstrap
+	// and node ignition; policy Always makes it trusted even when no cluster
+	// proxy is configured, e.g. behind a transparent TLS-intercepting proxy
+	if additionalTrustBundle := oc.Properties.ClusterProfile.AdditionalTrustBundle; additionalTrustBundle != "" {
+		installConfig += "additionalTrustBundlePolicy: Always\nadditionalTrustBundle: |\n"
+		for _, line := range strings.Split(strings.TrimSpace(additionalTrustBundle), "\n") {
+			installConfig += "  " + line + "\n"
+		}
+	}
+
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
 			Name:      installConfigName,
 		},
 		StringData: map[string]string{
-			"install-config.yaml": fmt.Sprintf(installConfigTemplate, location),
+			"install-config.yaml": installConfig,
 		},
 	}
 }
@@ -18,12 +18,73 @@ import (
 )
 
 func TestInstallConfigMap(t *testing.T) {
-	var expected = map[string]string{"install-config.yaml": "apiVersion: v1\nplatform:\n  azure:\n    region: \"testLocation\"\n"}
+	for _, tt := range []struct {
+		name                  string
+		additionalTrustBundle string
+		workerVMSize          api.VMSize
+		masterSecurityType    api.SecurityType
+		workerSecurityType    api.SecurityType
+		workerEncryptionType  api.SecurityEncryptionType
+		expected              map[string]string
+	}{
+		{
+			name:     "no additional trust bundle",
+			expected: map[string]string{"install-config.yaml": "apiVersion: v1\nplatform:\n  azure:\n    region: \"testLocation\"\n"},
+		},
+		{
+			name:                  "additional trust bundle",
+			additionalTrustBundle: "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n",
+			expected:              map[string]string{"install-config.yaml": "apiVersion: v1\nplatform:\n  azure:\n    region: \"testLocation\"\nadditionalTrustBundlePolicy: Always\nadditionalTrustBundle: |\n  -----BEGIN CERTIFICATE-----\n  MIIB\n  -----END CERTIFICATE-----\n"},
+		},
+		{
+			name:         "arm64 workers",
+			workerVMSize: api.VMSizeStandardD4psV5,
+			expected:     map[string]string{"install-config.yaml": "apiVersion: v1\nplatform:\n  azure:\n    region: \"testLocation\"\ncontrolPlane:\n  name: master\n  architecture: amd64\ncompute:\n- name: worker\n  architecture: arm64\n"},
+		},
+		{
+			name:               "trusted launch masters",
+			masterSecurityType: api.SecurityTypeTrustedLaunch,
+			expected:           map[string]string{"install-config.yaml": "apiVersion: v1\nplatform:\n  azure:\n    region: \"testLocation\"\ncontrolPlane:\n  name: master\n  architecture: amd64\n  platform:\n    azure:\n      settings:\n        securityType: TrustedLaunch\n        trustedLaunch:\n          uefiSettings:\n            secureBoot: Enabled\n            virtualizedTrustedPlatformModule: Enabled\ncompute:\n- name: worker\n  architecture: amd64\n"},
+		},
+		{
+			name:                 "confidential workers",
+			workerVMSize:         api.VMSizeStandardDC4asV5,
+			workerSecurityType:   api.SecurityTypeConfidentialVM,
+			workerEncryptionType: api.SecurityEncryptionTypeDiskWithVMGuestState,
+			expected:             map[string]string{"install-config.yaml": "apiVersion: v1\nplatform:\n  azure:\n    region: \"testLocation\"\ncontrolPlane:\n  name: master\n  architecture: amd64\ncompute:\n- name: worker\n  architecture: amd64\n  platform:\n    azure:\n      settings:\n        securityType: ConfidentialVM\n        confidentialVM:\n          uefiSettings:\n            secureBoot: Enabled\n            virtualizedTrustedPlatformModule: Enabled\n      osDisk:\n        securityProfile:\n          securityEncryptionType: DiskWithVMGuestState\n"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			workerVMSize := api.VMSizeStandardD4sV3
+			if tt.workerVMSize != "" {
+				workerVMSize = tt.workerVMSize
+			}
+			oc := &api.OpenShiftCluster{
+				Location: "testLocation",
+				Properties: api.OpenShiftClusterProperties{
+					ClusterProfile: api.ClusterProfile{
+						AdditionalTrustBundle: tt.additionalTrustBundle,
+					},
+					MasterProfile: api.MasterProfile{
+						SecurityType: tt.masterSecurityType,
+					},
+					WorkerProfiles: []api.WorkerProfile{
+						{
+							Name:                   "worker",
+							VMSize:                 workerVMSize,
+							SecurityType:           tt.workerSecurityType,
+							SecurityEncryptionType: tt.workerEncryptionType,
+						},
+					},
+				},
+			}
 
-	r := installConfigCM("testNamespace", "testLocation")
+			r := installConfigCM("testNamespace", oc)
 
-	for _, err := range deep.Equal(r.StringData, expected) {
-		t.Error(err)
+			for _, err := range deep.Equal(r.StringData, tt.expected) {
+				t.Error(err)
+			}
+		})
 	}
 }
 
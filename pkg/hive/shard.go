@@ -0,0 +1,20 @@
+package hive
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// DefaultShard is the shard of clusters which were registered with Hive
+// before shards were assigned.
+const DefaultShard = 1
+
+// Shard returns the Hive shard which the cluster is registered with.
+func Shard(doc *api.OpenShiftClusterDocument) int {
+	if doc.OpenShiftCluster.Properties.HiveProfile.Shard != 0 {
+		return doc.OpenShiftCluster.Properties.HiveProfile.Shard
+	}
+	return DefaultShard
+}
@@ -0,0 +1,176 @@
+package hive
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveinternalv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/liveconfig"
+)
+
+// shardedClusterManager passes each call on to the ClusterManager of the
+// shard which the cluster is registered with, creating it on first use.  It
+// is used by components which look up clusters across all shards.
+type shardedClusterManager struct {
+	newManager func(context.Context, int) (ClusterManager, error)
+
+	mu       sync.Mutex
+	managers map[int]ClusterManager
+}
+
+// NewSharded returns a ClusterManager which passes each call on to the shard
+// which the cluster is registered with.  CreateNamespace is not supported.
+func NewSharded(log *logrus.Entry, _env env.Core, liveConfig liveconfig.Manager) ClusterManager {
+	return &shardedClusterManager{
+		newManager: func(ctx context.Context, shard int) (ClusterManager, error) {
+			hiveRestConfig, err := liveConfig.HiveRestConfig(ctx, shard)
+			if err != nil {
+				return nil, fmt.Errorf("failed getting RESTConfig for Hive shard %d: %w", shard, err)
+			}
+			return NewFromConfig(log, _env, hiveRestConfig)
+		},
+		managers: map[int]ClusterManager{},
+	}
+}
+
+func (s *shardedClusterManager) shard(ctx context.Context, doc *api.OpenShiftClusterDocument) (ClusterManager, error) {
+	shard := Shard(doc)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if m, found := s.managers[shard]; found {
+		return m, nil
+	}
+
+	m, err := s.newManager(ctx, shard)
+	if err != nil {
+		return nil, err
+	}
+
+	s.managers[shard] = m
+
+	return m, nil
+}
+
+// CreateNamespace is not supported, as the shard is not known until the
+// cluster is allocated to one.
+func (s *shardedClusterManager) CreateNamespace(ctx context.Context, docID string) (*corev1.Namespace, error) {
+	return nil, errors.New("cannot create a namespace without a shard")
+}
+
+func (s *shardedClusterManager) CreateOrUpdate(ctx context.Context, sub *api.SubscriptionDocument, doc *api.OpenShiftClusterDocument) error {
+	m, err := s.shard(ctx, doc)
+	if err != nil {
+		return err
+	}
+	return m.CreateOrUpdate(ctx, sub, doc)
+}
+
+func (s *shardedClusterManager) Deprovision(ctx context.Context, doc *api.OpenShiftClusterDocument) error {
+	m, err := s.shard(ctx, doc)
+	if err != nil {
+		return err
+	}
+	return m.Deprovision(ctx, doc)
+}
+
+func (s *shardedClusterManager) IsClusterDeprovisionComplete(ctx context.Context, doc *api.OpenShiftClusterDocument) (bool, error) {
+	m, err := s.shard(ctx, doc)
+	if err != nil {
+		return false, err
+	}
+	return m.IsClusterDeprovisionComplete(ctx, doc)
+}
+
+func (s *shardedClusterManager) Delete(ctx context.Context, doc *api.OpenShiftClusterDocument) error {
+	m, err := s.shard(ctx, doc)
+	if err != nil {
+		return err
+	}
+	return m.Delete(ctx, doc)
+}
+
+func (s *shardedClusterManager) Install(ctx context.Context, sub *api.SubscriptionDocument, doc *api.OpenShiftClusterDocument, version *api.OpenShiftVersion, customManifests map[string]kruntime.Object) error {
+	m, err := s.shard(ctx, doc)
+	if err != nil {
+		return err
+	}
+	return m.Install(ctx, sub, doc, version, customManifests)
+}
+
+func (s *shardedClusterManager) IsClusterDeploymentReady(ctx context.Context, doc *api.OpenShiftClusterDocument) (bool, error) {
+	m, err := s.shard(ctx, doc)
+	if err != nil {
+		return false, err
+	}
+	return m.IsClusterDeploymentReady(ctx, doc)
+}
+
+func (s *shardedClusterManager) IsClusterInstallationComplete(ctx context.Context, doc *api.OpenShiftClusterDocument) (bool, error) {
+	m, err := s.shard(ctx, doc)
+	if err != nil {
+		return false, err
+	}
+	return m.IsClusterInstallationComplete(ctx, doc)
+}
+
+func (s *shardedClusterManager) GetClusterDeployment(ctx context.Context, doc *api.OpenShiftClusterDocument) (*hivev1.ClusterDeployment, error) {
+	m, err := s.shard(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	return m.GetClusterDeployment(ctx, doc)
+}
+
+func (s *shardedClusterManager) ListClusterProvisions(ctx context.Context, doc *api.OpenShiftClusterDocument) ([]hivev1.ClusterProvision, error) {
+	m, err := s.shard(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	return m.ListClusterProvisions(ctx, doc)
+}
+
+func (s *shardedClusterManager) GetClusterSync(ctx context.Context, doc *api.OpenShiftClusterDocument) (*hiveinternalv1alpha1.ClusterSync, error) {
+	m, err := s.shard(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	return m.GetClusterSync(ctx, doc)
+}
+
+func (s *shardedClusterManager) GetProvisionLogs(ctx context.Context, doc *api.OpenShiftClusterDocument) (*ProvisionLogs, error) {
+	m, err := s.shard(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	return m.GetProvisionLogs(ctx, doc)
+}
+
+func (s *shardedClusterManager) EnsureSyncSet(ctx context.Context, doc *api.OpenShiftClusterDocument, name string, resources []kruntime.Object) error {
+	m, err := s.shard(ctx, doc)
+	if err != nil {
+		return err
+	}
+	return m.EnsureSyncSet(ctx, doc, name, resources)
+}
+
+func (s *shardedClusterManager) ResetCorrelationData(ctx context.Context, doc *api.OpenShiftClusterDocument) error {
+	m, err := s.shard(ctx, doc)
+	if err != nil {
+		return err
+	}
+	return m.ResetCorrelationData(ctx, doc)
+}
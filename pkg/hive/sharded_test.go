@@ -0,0 +1,78 @@
+package hive
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+func TestShardedClusterManager(t *testing.T) {
+	ctx := context.Background()
+	fakeNamespace := "aro-00000000-0000-0000-0000-000000000000"
+
+	var created []int
+	s := &shardedClusterManager{
+		newManager: func(ctx context.Context, shard int) (ClusterManager, error) {
+			created = append(created, shard)
+
+			// only shard 2 has the ClusterDeployment
+			fakeClientBuilder := fake.NewClientBuilder()
+			if shard == 2 {
+				fakeClientBuilder = fakeClientBuilder.WithRuntimeObjects(&hivev1.ClusterDeployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      ClusterDeploymentName,
+						Namespace: fakeNamespace,
+					},
+				})
+			}
+
+			return &clusterManager{
+				hiveClientset: fakeClientBuilder.Build(),
+				log:           logrus.NewEntry(logrus.StandardLogger()),
+			}, nil
+		},
+		managers: map[int]ClusterManager{},
+	}
+
+	doc := &api.OpenShiftClusterDocument{
+		OpenShiftCluster: &api.OpenShiftCluster{
+			Properties: api.OpenShiftClusterProperties{
+				HiveProfile: api.HiveProfile{
+					Namespace: fakeNamespace,
+					Shard:     2,
+				},
+			},
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := s.GetClusterDeployment(ctx, doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	doc.OpenShiftCluster.Properties.HiveProfile.Shard = 0
+	_, err := s.GetClusterDeployment(ctx, doc)
+	if err == nil {
+		t.Error("expected the ClusterDeployment not to be found on the default shard")
+	}
+
+	if len(created) != 2 || created[0] != 2 || created[1] != DefaultShard {
+		t.Error(created)
+	}
+
+	_, err = s.CreateNamespace(ctx, "00000000-0000-0000-0000-000000000000")
+	if err == nil {
+		t.Error("expected CreateNamespace to fail")
+	}
+}
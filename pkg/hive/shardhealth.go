@@ -0,0 +1,348 @@
+package hive
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveinternalv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/util/recover"
+)
+
+const (
+	// a shard with more ClusterDeployments than this still waiting to be
+	// installed is not given any more
+	shardMaxPendingClusterDeployments = 20
+
+	// a shard on which more than this percentage of ClusterSyncs are failing
+	// is not given any more ClusterDeployments
+	shardMaxClusterSyncFailurePercent = 20
+)
+
+// ShardHealth periodically checks each Hive shard's availability, number of
+// clusters, resource headroom, backlog of ClusterDeployments waiting to be
+// installed and rate of SyncSet failures, and emits them as metrics.  It marks
+// unhealthy shards unschedulable for new installs, and allocates clusters
+// which are not yet registered with Hive to shards.
+type ShardHealth struct {
+	log *logrus.Entry
+	env env.Interface
+	m   metrics.Emitter
+
+	newClient func(context.Context, int) (client.Client, error)
+
+	mu            sync.RWMutex
+	unschedulable map[int]bool
+	capacity      map[int]shardCapacity
+}
+
+// shardCapacity records the number of clusters on a shard and the fraction of
+// its nodes' allocatable CPU and memory which is not yet requested, whichever
+// is the lower.
+type shardCapacity struct {
+	clusters int
+	headroom float64
+}
+
+func NewShardHealth(log *logrus.Entry, _env env.Interface, m metrics.Emitter) *ShardHealth {
+	s := &ShardHealth{
+		log: log,
+		env: _env,
+		m:   m,
+
+		unschedulable: map[int]bool{},
+		capacity:      map[int]shardCapacity{},
+	}
+	s.newClient = s.newShardClient
+
+	return s
+}
+
+func (s *ShardHealth) newShardClient(ctx context.Context, shard int) (client.Client, error) {
+	restConfig, err := s.env.LiveConfig().HiveRestConfig(ctx, shard)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.New(restConfig, client.Options{})
+}
+
+// IsSchedulable returns false if new installs should not be given to the
+// shard.  Shards are schedulable until they are found to be unhealthy.
+func (s *ShardHealth) IsSchedulable(shard int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return !s.unschedulable[shard]
+}
+
+// Allocate chooses the shard for a cluster which is not yet registered with
+// Hive.  Schedulable shards are preferred, and between them the shard with
+// the most headroom per cluster relative to its weight is chosen.  If no
+// shard is schedulable, the best unschedulable shard is returned so that
+// adoption can continue, and schedulable is false.
+func (s *ShardHealth) Allocate(ctx context.Context) (shard int, schedulable bool, err error) {
+	weights, err := s.env.LiveConfig().HiveShardWeights(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return s.allocate(weights)
+}
+
+func (s *ShardHealth) allocate(weights map[int]int) (int, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var best int
+	var bestSchedulable bool
+	var bestScore float64
+
+	for _, shard := range sortedShards(weights) {
+		if weights[shard] == 0 {
+			continue
+		}
+
+		// shards which have not been checked yet are treated as empty
+		score := float64(weights[shard])
+		if c, found := s.capacity[shard]; found {
+			score *= c.headroom / float64(c.clusters+1)
+		}
+
+		schedulable := !s.unschedulable[shard]
+
+		if best == 0 ||
+			schedulable && !bestSchedulable ||
+			schedulable == bestSchedulable && score > bestScore {
+			best, bestSchedulable, bestScore = shard, schedulable, score
+		}
+	}
+
+	if best == 0 {
+		return 0, false, errors.New("no hive shard has a non-zero weight")
+	}
+
+	return best, bestSchedulable, nil
+}
+
+// Run checks the shards every minute until stop is closed.  It does nothing
+// in regions where Hive is disabled.
+func (s *ShardHealth) Run(ctx context.Context, stop <-chan struct{}) {
+	defer recover.Panic(s.log)
+
+	t := time.NewTicker(time.Minute)
+	defer t.Stop()
+
+	for {
+		installViaHive, err := s.env.LiveConfig().InstallViaHive(ctx)
+		if err != nil {
+			s.log.Error(err)
+		}
+
+		adoptByHive, err := s.env.LiveConfig().AdoptByHive(ctx)
+		if err != nil {
+			s.log.Error(err)
+		}
+
+		weights, err := s.env.LiveConfig().HiveShardWeights(ctx)
+		if err != nil {
+			s.log.Error(err)
+		}
+
+		if installViaHive || adoptByHive {
+			// shards with a zero weight are still checked, as they may
+			// host existing clusters
+			for _, shard := range sortedShards(weights) {
+				s.check(ctx, shard)
+			}
+		}
+
+		select {
+		case <-t.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (s *ShardHealth) check(ctx context.Context, shard int) {
+	log := s.log.WithField("shard", shard)
+	dims := map[string]string{
+		"shard": strconv.Itoa(shard),
+	}
+
+	c, pending, failedSyncs, totalSyncs, err := s.inspect(ctx, shard)
+	available := err == nil
+	if err != nil {
+		log.Error(err)
+	}
+
+	schedulable := available &&
+		pending <= shardMaxPendingClusterDeployments &&
+		(totalSyncs == 0 || failedSyncs*100 <= totalSyncs*shardMaxClusterSyncFailurePercent)
+
+	s.mu.Lock()
+	wasSchedulable := !s.unschedulable[shard]
+	s.unschedulable[shard] = !schedulable
+	if available {
+		s.capacity[shard] = c
+	}
+	s.mu.Unlock()
+
+	if schedulable != wasSchedulable {
+		log.Warnf("shard schedulable changed to %t (available %t, %d pending clusterdeployments, %d/%d clustersyncs failed)", schedulable, available, pending, failedSyncs, totalSyncs)
+	}
+
+	s.m.EmitGauge("hive.shard.available", boolToInt64(available), dims)
+	s.m.EmitGauge("hive.shard.schedulable", boolToInt64(schedulable), dims)
+	if available {
+		s.m.EmitGauge("hive.shard.clusters", int64(c.clusters), dims)
+		s.m.EmitGauge("hive.shard.headroom", int64(c.headroom*100), dims)
+		s.m.EmitGauge("hive.shard.clusterdeployments.pending", int64(pending), dims)
+		s.m.EmitGauge("hive.shard.clustersyncs.failed", int64(failedSyncs), dims)
+		s.m.EmitGauge("hive.shard.clustersyncs.count", int64(totalSyncs), dims)
+	}
+}
+
+// inspect returns the capacity of the shard, the number of ClusterDeployments
+// on it which are waiting to be installed, and the number of failed and total
+// ClusterSyncs.  An error means that the shard is unavailable.
+func (s *ShardHealth) inspect(ctx context.Context, shard int) (capacity shardCapacity, pending, failedSyncs, totalSyncs int, err error) {
+	c, err := s.newClient(ctx, shard)
+	if err != nil {
+		return shardCapacity{}, 0, 0, 0, err
+	}
+
+	cds := &hivev1.ClusterDeploymentList{}
+	err = c.List(ctx, cds)
+	if err != nil {
+		return shardCapacity{}, 0, 0, 0, err
+	}
+
+	for _, cd := range cds.Items {
+		if !cd.Spec.Installed {
+			pending++
+		}
+	}
+
+	css := &hiveinternalv1alpha1.ClusterSyncList{}
+	err = c.List(ctx, css)
+	if err != nil {
+		return shardCapacity{}, 0, 0, 0, err
+	}
+
+	for _, cs := range css.Items {
+		for _, cond := range cs.Status.Conditions {
+			if cond.Type == hiveinternalv1alpha1.ClusterSyncFailed && cond.Status == corev1.ConditionTrue {
+				failedSyncs++
+				break
+			}
+		}
+	}
+
+	headroom, err := s.headroom(ctx, c)
+	if err != nil {
+		return shardCapacity{}, 0, 0, 0, err
+	}
+
+	capacity = shardCapacity{
+		clusters: len(cds.Items),
+		headroom: headroom,
+	}
+
+	return capacity, pending, failedSyncs, len(css.Items), nil
+}
+
+// headroom returns the fraction of the shard's allocatable CPU or memory,
+// whichever is the lower, which is not requested by running pods.
+func (s *ShardHealth) headroom(ctx context.Context, c client.Client) (float64, error) {
+	nodes := &corev1.NodeList{}
+	err := c.List(ctx, nodes)
+	if err != nil {
+		return 0, err
+	}
+
+	allocatable := corev1.ResourceList{}
+	for _, node := range nodes.Items {
+		addResources(allocatable, node.Status.Allocatable)
+	}
+
+	pods := &corev1.PodList{}
+	err = c.List(ctx, pods)
+	if err != nil {
+		return 0, err
+	}
+
+	requested := corev1.ResourceList{}
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == "" ||
+			pod.Status.Phase == corev1.PodSucceeded ||
+			pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		for _, container := range pod.Spec.Containers {
+			addResources(requested, container.Resources.Requests)
+		}
+	}
+
+	headroom := 1.0
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		a := allocatable[name]
+		if a.IsZero() {
+			return 0, nil
+		}
+
+		r := requested[name]
+		free := 1 - float64(r.MilliValue())/float64(a.MilliValue())
+		if free < headroom {
+			headroom = free
+		}
+	}
+
+	if headroom < 0 {
+		headroom = 0
+	}
+
+	return headroom, nil
+}
+
+func addResources(total, add corev1.ResourceList) {
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		if q, found := add[name]; found {
+			sum := total[name]
+			sum.Add(q)
+			total[name] = sum
+		}
+	}
+}
+
+func sortedShards(weights map[int]int) []int {
+	shards := make([]int, 0, len(weights))
+	for shard := range weights {
+		shards = append(shards, shard)
+	}
+	sort.Ints(shards)
+
+	return shards
+}
+
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
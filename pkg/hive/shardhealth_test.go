@@ -0,0 +1,298 @@
+package hive
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveinternalv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestShardHealthCheck(t *testing.T) {
+	clusterDeployments := func(installed, pending int) (objects []kruntime.Object) {
+		for i := 0; i < installed+pending; i++ {
+			objects = append(objects, &hivev1.ClusterDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ClusterDeploymentName,
+					Namespace: fmt.Sprintf("aro-%d", i),
+				},
+				Spec: hivev1.ClusterDeploymentSpec{
+					Installed: i < installed,
+				},
+			})
+		}
+		return objects
+	}
+
+	clusterSyncs := func(succeeded, failed int) (objects []kruntime.Object) {
+		for i := 0; i < succeeded+failed; i++ {
+			status := corev1.ConditionFalse
+			if i >= succeeded {
+				status = corev1.ConditionTrue
+			}
+			objects = append(objects, &hiveinternalv1alpha1.ClusterSync{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ClusterDeploymentName,
+					Namespace: fmt.Sprintf("aro-%d", i),
+				},
+				Status: hiveinternalv1alpha1.ClusterSyncStatus{
+					Conditions: []hiveinternalv1alpha1.ClusterSyncCondition{
+						{
+							Type:   hiveinternalv1alpha1.ClusterSyncFailed,
+							Status: status,
+						},
+					},
+				},
+			})
+		}
+		return objects
+	}
+
+	// a node with 4 CPUs and 16Gi of memory, of which pods request 1 CPU and
+	// 8Gi
+	capacity := []kruntime.Object{
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node"},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("16Gi"),
+				},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "hive"},
+			Spec: corev1.PodSpec{
+				NodeName: "node",
+				Containers: []corev1.Container{
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("1"),
+								corev1.ResourceMemory: resource.MustParse("8Gi"),
+							},
+						},
+					},
+				},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "completed", Namespace: "hive"},
+			Spec: corev1.PodSpec{
+				NodeName: "node",
+				Containers: []corev1.Container{
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("8Gi"),
+							},
+						},
+					},
+				},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+		},
+	}
+
+	for _, tt := range []struct {
+		name            string
+		objects         []kruntime.Object
+		clientErr       error
+		wantAvailable   int64
+		wantSchedulable bool
+		wantClusters    int64
+		wantHeadroom    int64
+		wantPending     int64
+		wantFailed      int64
+		wantCount       int64
+	}{
+		{
+			name:            "healthy",
+			objects:         append(append(clusterDeployments(10, 2), clusterSyncs(10, 2)...), capacity...),
+			wantAvailable:   1,
+			wantSchedulable: true,
+			wantClusters:    12,
+			wantHeadroom:    50,
+			wantPending:     2,
+			wantFailed:      2,
+			wantCount:       12,
+		},
+		{
+			name:          "too many pending clusterdeployments",
+			objects:       clusterDeployments(0, shardMaxPendingClusterDeployments+1),
+			wantAvailable: 1,
+			wantClusters:  shardMaxPendingClusterDeployments + 1,
+			wantPending:   shardMaxPendingClusterDeployments + 1,
+		},
+		{
+			name:          "too many failing clustersyncs",
+			objects:       clusterSyncs(7, 3),
+			wantAvailable: 1,
+			wantFailed:    3,
+			wantCount:     10,
+		},
+		{
+			name:      "unavailable",
+			clientErr: errors.New("connection refused"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := mock_metrics.NewMockEmitter(controller)
+			dims := map[string]string{"shard": "1"}
+			m.EXPECT().EmitGauge("hive.shard.available", tt.wantAvailable, dims)
+			m.EXPECT().EmitGauge("hive.shard.schedulable", boolToInt64(tt.wantSchedulable), dims)
+			if tt.clientErr == nil {
+				m.EXPECT().EmitGauge("hive.shard.clusters", tt.wantClusters, dims)
+				m.EXPECT().EmitGauge("hive.shard.headroom", tt.wantHeadroom, dims)
+				m.EXPECT().EmitGauge("hive.shard.clusterdeployments.pending", tt.wantPending, dims)
+				m.EXPECT().EmitGauge("hive.shard.clustersyncs.failed", tt.wantFailed, dims)
+				m.EXPECT().EmitGauge("hive.shard.clustersyncs.count", tt.wantCount, dims)
+			}
+
+			s := &ShardHealth{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				m:   m,
+				newClient: func(context.Context, int) (client.Client, error) {
+					if tt.clientErr != nil {
+						return nil, tt.clientErr
+					}
+					return fake.NewClientBuilder().WithRuntimeObjects(tt.objects...).Build(), nil
+				},
+				unschedulable: map[int]bool{},
+				capacity:      map[int]shardCapacity{},
+			}
+
+			if !s.IsSchedulable(1) {
+				t.Error("shard should be schedulable until checked")
+			}
+
+			s.check(context.Background(), 1)
+
+			if s.IsSchedulable(1) != tt.wantSchedulable {
+				t.Error(s.IsSchedulable(1))
+			}
+		})
+	}
+}
+
+func TestShardHealthAllocate(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		weights         map[int]int
+		unschedulable   map[int]bool
+		capacity        map[int]shardCapacity
+		wantShard       int
+		wantSchedulable bool
+		wantErr         string
+	}{
+		{
+			name:            "single shard",
+			weights:         map[int]int{1: 1},
+			wantShard:       1,
+			wantSchedulable: true,
+		},
+		{
+			name:    "fewest clusters",
+			weights: map[int]int{1: 1, 2: 1},
+			capacity: map[int]shardCapacity{
+				1: {clusters: 10, headroom: 0.5},
+				2: {clusters: 5, headroom: 0.5},
+			},
+			wantShard:       2,
+			wantSchedulable: true,
+		},
+		{
+			name:    "most headroom",
+			weights: map[int]int{1: 1, 2: 1},
+			capacity: map[int]shardCapacity{
+				1: {clusters: 5, headroom: 0.5},
+				2: {clusters: 5, headroom: 0.1},
+			},
+			wantShard:       1,
+			wantSchedulable: true,
+		},
+		{
+			name:    "weighted",
+			weights: map[int]int{1: 1, 2: 3},
+			capacity: map[int]shardCapacity{
+				1: {clusters: 9, headroom: 0.5},
+				2: {clusters: 19, headroom: 0.5},
+			},
+			wantShard:       2,
+			wantSchedulable: true,
+		},
+		{
+			name:    "unchecked shard",
+			weights: map[int]int{1: 1, 2: 1},
+			capacity: map[int]shardCapacity{
+				1: {clusters: 0, headroom: 0.9},
+			},
+			wantShard:       2,
+			wantSchedulable: true,
+		},
+		{
+			name:          "prefers schedulable shards",
+			weights:       map[int]int{1: 1, 2: 1},
+			unschedulable: map[int]bool{1: true},
+			capacity: map[int]shardCapacity{
+				1: {clusters: 0, headroom: 1},
+				2: {clusters: 100, headroom: 0.1},
+			},
+			wantShard:       2,
+			wantSchedulable: true,
+		},
+		{
+			name:          "no schedulable shards",
+			weights:       map[int]int{1: 1},
+			unschedulable: map[int]bool{1: true},
+			wantShard:     1,
+		},
+		{
+			name:            "drained shard",
+			weights:         map[int]int{1: 0, 2: 1},
+			wantShard:       2,
+			wantSchedulable: true,
+		},
+		{
+			name:    "no weights",
+			weights: map[int]int{1: 0},
+			wantErr: "no hive shard has a non-zero weight",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &ShardHealth{
+				unschedulable: tt.unschedulable,
+				capacity:      tt.capacity,
+			}
+
+			shard, schedulable, err := s.allocate(tt.weights)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if shard != tt.wantShard {
+				t.Error(shard)
+			}
+			if schedulable != tt.wantSchedulable {
+				t.Error(schedulable)
+			}
+		})
+	}
+}
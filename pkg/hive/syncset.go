@@ -0,0 +1,88 @@
+package hive
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// OperatorSyncSetName is the name of the SyncSet which deploys the ARO
+// operator to Hive-managed clusters.
+const OperatorSyncSetName = "aro-operator"
+
+func (hr *clusterManager) EnsureSyncSet(ctx context.Context, doc *api.OpenShiftClusterDocument, name string, resources []kruntime.Object) error {
+	ss, err := syncSet(doc.OpenShiftCluster.Properties.HiveProfile.Namespace, name, resources)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing := &hivev1.SyncSet{}
+		err := hr.hiveClientset.Get(ctx, client.ObjectKeyFromObject(ss), existing)
+		if kerrors.IsNotFound(err) {
+			return hr.hiveClientset.Create(ctx, ss)
+		}
+		if err != nil {
+			return err
+		}
+
+		existing.Spec = ss.Spec
+		return hr.hiveClientset.Update(ctx, existing)
+	})
+}
+
+// syncSet returns a SyncSet which continuously applies resources to the
+// cluster's ClusterDeployment.  Resources which are removed from the SyncSet
+// are deleted from the cluster.  Secrets must not be passed in, as the
+// SyncSet would store them in plain text.
+func syncSet(namespace, name string, resources []kruntime.Object) (*hivev1.SyncSet, error) {
+	raw := make([]kruntime.RawExtension, 0, len(resources))
+	for _, resource := range resources {
+		gvk, err := apiutil.GVKForObject(resource, scheme.Scheme)
+		if err != nil {
+			return nil, err
+		}
+
+		resource = resource.DeepCopyObject()
+		resource.GetObjectKind().SetGroupVersionKind(gvk)
+
+		b, err := json.Marshal(resource)
+		if err != nil {
+			return nil, err
+		}
+
+		raw = append(raw, kruntime.RawExtension{Raw: b})
+	}
+
+	return &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: hivev1.SyncSetSpec{
+			SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
+				Resources:         raw,
+				ResourceApplyMode: hivev1.SyncResourceApplyMode,
+			},
+			ClusterDeploymentRefs: []corev1.LocalObjectReference{
+				{
+					Name: ClusterDeploymentName,
+				},
+			},
+		},
+	}, nil
+}
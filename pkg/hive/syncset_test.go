@@ -0,0 +1,105 @@
+package hive
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+func TestEnsureSyncSet(t *testing.T) {
+	ctx := context.Background()
+	fakeNamespace := "aro-00000000-0000-0000-0000-000000000000"
+	doc := &api.OpenShiftClusterDocument{
+		OpenShiftCluster: &api.OpenShiftCluster{
+			Properties: api.OpenShiftClusterProperties{
+				HiveProfile: api.HiveProfile{
+					Namespace: fakeNamespace,
+				},
+			},
+		},
+	}
+
+	resources := []kruntime.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "config",
+				Namespace: "openshift-azure-operator",
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		existing bool
+	}{
+		{name: "creates the syncset"},
+		{name: "updates the syncset", existing: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClientBuilder := fake.NewClientBuilder()
+			if tt.existing {
+				fakeClientBuilder = fakeClientBuilder.WithRuntimeObjects(&hivev1.SyncSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      OperatorSyncSetName,
+						Namespace: fakeNamespace,
+					},
+					Spec: hivev1.SyncSetSpec{
+						SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
+							ResourceApplyMode: hivev1.UpsertResourceApplyMode,
+						},
+					},
+				})
+			}
+			c := clusterManager{
+				hiveClientset: fakeClientBuilder.Build(),
+				log:           logrus.NewEntry(logrus.StandardLogger()),
+			}
+
+			err := c.EnsureSyncSet(ctx, doc, OperatorSyncSetName, resources)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ss := &hivev1.SyncSet{}
+			err = c.hiveClientset.Get(ctx, client.ObjectKey{Namespace: fakeNamespace, Name: OperatorSyncSetName}, ss)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ss.Spec.ResourceApplyMode != hivev1.SyncResourceApplyMode {
+				t.Error(ss.Spec.ResourceApplyMode)
+			}
+
+			if len(ss.Spec.ClusterDeploymentRefs) != 1 || ss.Spec.ClusterDeploymentRefs[0].Name != ClusterDeploymentName {
+				t.Error(ss.Spec.ClusterDeploymentRefs)
+			}
+
+			if len(ss.Spec.Resources) != 1 {
+				t.Fatal(len(ss.Spec.Resources))
+			}
+
+			var m metav1.PartialObjectMetadata
+			err = json.Unmarshal(ss.Spec.Resources[0].Raw, &m)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if m.APIVersion != "v1" || m.Kind != "ConfigMap" || m.Name != "config" {
+				t.Error(m)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package metrics
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultMaxDimensionValues is the number of distinct values of a
+	// dimension of a metric which are emitted before further values are
+	// aggregated
+	DefaultMaxDimensionValues = 500
+
+	// maxDimensionValueLength is the length at which dimension values are
+	// truncated
+	maxDimensionValueLength = 256
+
+	// OtherDimensionValue replaces dimension values which are suppressed
+	OtherDimensionValue = "_other"
+
+	suppressedMetricName = "metrics.cardinality.suppressed"
+)
+
+type dimensionKey struct {
+	metricName string
+	dimension  string
+}
+
+type cardinalityGuard struct {
+	log       *logrus.Entry
+	next      Emitter
+	maxValues int
+	exempt    []string
+
+	mu     sync.Mutex
+	values map[dimensionKey]map[string]struct{}
+}
+
+// NewCardinalityGuard returns an Emitter which emits to next, guarding against
+// dimensions with unexpectedly many distinct values, such as raw resource IDs,
+// which would blow out the number of series in the Geneva account.  Once
+// maxValues distinct values of a dimension of a metric have been emitted,
+// further values are replaced with OtherDimensionValue, and each replacement
+// is counted in the metrics.cardinality.suppressed metric.  Metrics whose names
+// start with one of the exempt prefixes are deliberately dimensioned per
+// resource or per operation and are not guarded.  Overlong dimension values of
+// all metrics are truncated.
+func NewCardinalityGuard(log *logrus.Entry, next Emitter, maxValues int, exempt ...string) Emitter {
+	return &cardinalityGuard{
+		log:       log,
+		next:      next,
+		maxValues: maxValues,
+		exempt:    exempt,
+		values:    map[dimensionKey]map[string]struct{}{},
+	}
+}
+
+func (g *cardinalityGuard) EmitFloat(metricName string, metricValue float64, dimensions map[string]string) {
+	g.next.EmitFloat(metricName, metricValue, g.guard(metricName, dimensions))
+}
+
+func (g *cardinalityGuard) EmitGauge(metricName string, metricValue int64, dimensions map[string]string) {
+	g.next.EmitGauge(metricName, metricValue, g.guard(metricName, dimensions))
+}
+
+// guard returns the dimensions to emit.  The caller's map is not modified.
+func (g *cardinalityGuard) guard(metricName string, dimensions map[string]string) map[string]string {
+	if len(dimensions) == 0 {
+		return dimensions
+	}
+
+	exempt := g.isExempt(metricName)
+
+	guarded := make(map[string]string, len(dimensions))
+	for k, v := range dimensions {
+		if len(v) > maxDimensionValueLength {
+			v = v[:maxDimensionValueLength]
+		}
+
+		if !exempt && !g.admit(dimensionKey{metricName: metricName, dimension: k}, v) {
+			v = OtherDimensionValue
+			g.next.EmitGauge(suppressedMetricName, 1, map[string]string{
+				"metric":    metricName,
+				"dimension": k,
+			})
+		}
+
+		guarded[k] = v
+	}
+
+	return guarded
+}
+
+// admit returns true if value has already been seen for key, or if it can be
+// recorded without exceeding maxValues
+func (g *cardinalityGuard) admit(key dimensionKey, value string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	values, found := g.values[key]
+	if !found {
+		values = map[string]struct{}{}
+		g.values[key] = values
+	}
+
+	if _, found := values[value]; found {
+		return true
+	}
+
+	if len(values) >= g.maxValues {
+		if len(values) == g.maxValues {
+			g.log.Warnf("dimension %s of metric %s has more than %d values, suppressing further values", key.dimension, key.metricName, g.maxValues)
+			// record the suppression marker so that the warning is logged once
+			values[OtherDimensionValue] = struct{}{}
+		}
+		return false
+	}
+
+	values[value] = struct{}{}
+	return true
+}
+
+func (g *cardinalityGuard) isExempt(metricName string) bool {
+	for _, prefix := range g.exempt {
+		if strings.HasPrefix(metricName, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,101 @@
+package metrics
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+type emitted struct {
+	metricName string
+	dimensions map[string]string
+}
+
+type fakeEmitter []emitted
+
+func (f *fakeEmitter) EmitFloat(metricName string, metricValue float64, dimensions map[string]string) {
+	*f = append(*f, emitted{metricName, dimensions})
+}
+
+func (f *fakeEmitter) EmitGauge(metricName string, metricValue int64, dimensions map[string]string) {
+	*f = append(*f, emitted{metricName, dimensions})
+}
+
+func TestCardinalityGuard(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		metricName string
+		values     []string
+		want       []emitted
+	}{
+		{
+			name:       "values within the limit are emitted",
+			metricName: "client.k8s.duration",
+			values:     []string{"/api", "/apis", "/api"},
+			want: []emitted{
+				{"client.k8s.duration", map[string]string{"path": "/api"}},
+				{"client.k8s.duration", map[string]string{"path": "/apis"}},
+				{"client.k8s.duration", map[string]string{"path": "/api"}},
+			},
+		},
+		{
+			name:       "values over the limit are suppressed and counted",
+			metricName: "client.k8s.duration",
+			values:     []string{"/api", "/apis", "/healthz", "/api"},
+			want: []emitted{
+				{"client.k8s.duration", map[string]string{"path": "/api"}},
+				{"client.k8s.duration", map[string]string{"path": "/apis"}},
+				{suppressedMetricName, map[string]string{"metric": "client.k8s.duration", "dimension": "path"}},
+				{"client.k8s.duration", map[string]string{"path": OtherDimensionValue}},
+				{"client.k8s.duration", map[string]string{"path": "/api"}},
+			},
+		},
+		{
+			name:       "exempt metrics are not suppressed",
+			metricName: "gateway.cluster.connections",
+			values:     []string{"a", "b", "c"},
+			want: []emitted{
+				{"gateway.cluster.connections", map[string]string{"path": "a"}},
+				{"gateway.cluster.connections", map[string]string{"path": "b"}},
+				{"gateway.cluster.connections", map[string]string{"path": "c"}},
+			},
+		},
+		{
+			name:       "overlong values are truncated",
+			metricName: "gateway.cluster.connections",
+			values:     []string{strings.Repeat("a", 300)},
+			want: []emitted{
+				{"gateway.cluster.connections", map[string]string{"path": strings.Repeat("a", maxDimensionValueLength)}},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeEmitter{}
+			g := NewCardinalityGuard(logrus.NewEntry(logrus.StandardLogger()), f, 2, "gateway.cluster.")
+
+			for _, v := range tt.values {
+				g.EmitGauge(tt.metricName, 1, map[string]string{"path": v})
+			}
+
+			if !reflect.DeepEqual([]emitted(*f), tt.want) {
+				t.Error(*f)
+			}
+		})
+	}
+}
+
+func TestCardinalityGuardDoesNotModifyDimensions(t *testing.T) {
+	g := NewCardinalityGuard(logrus.NewEntry(logrus.StandardLogger()), &fakeEmitter{}, 0)
+
+	dimensions := map[string]string{"path": "/api"}
+	g.EmitFloat("client.k8s.duration", 1, dimensions)
+
+	if dimensions["path"] != "/api" {
+		t.Error(dimensions)
+	}
+}
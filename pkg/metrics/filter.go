@@ -0,0 +1,42 @@
+package metrics
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import "strings"
+
+type filteredEmitter struct {
+	next     Emitter
+	prefixes []string
+}
+
+// NewFilteredEmitter returns an Emitter which emits to next only the metrics
+// whose names start with one of the given prefixes
+func NewFilteredEmitter(next Emitter, prefixes ...string) Emitter {
+	return &filteredEmitter{
+		next:     next,
+		prefixes: prefixes,
+	}
+}
+
+func (f *filteredEmitter) EmitFloat(metricName string, metricValue float64, dimensions map[string]string) {
+	if f.matches(metricName) {
+		f.next.EmitFloat(metricName, metricValue, dimensions)
+	}
+}
+
+func (f *filteredEmitter) EmitGauge(metricName string, metricValue int64, dimensions map[string]string) {
+	if f.matches(metricName) {
+		f.next.EmitGauge(metricName, metricValue, dimensions)
+	}
+}
+
+func (f *filteredEmitter) matches(metricName string) bool {
+	for _, prefix := range f.prefixes {
+		if strings.HasPrefix(metricName, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,26 @@
+package metrics
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilteredEmitter(t *testing.T) {
+	f := &fakeEmitter{}
+	e := NewFilteredEmitter(f, "frontend.", "gateway.")
+
+	e.EmitGauge("frontend.count", 1, nil)
+	e.EmitGauge("backend.openshiftcluster.count", 1, nil)
+	e.EmitFloat("gateway.cluster.bytes", 1, nil)
+
+	want := []emitted{
+		{"frontend.count", nil},
+		{"gateway.cluster.bytes", nil},
+	}
+	if !reflect.DeepEqual([]emitted(*f), want) {
+		t.Error(*f)
+	}
+}
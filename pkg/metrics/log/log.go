@@ -0,0 +1,40 @@
+package log
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/metrics"
+)
+
+type emitter struct {
+	log *logrus.Entry
+}
+
+// New returns a metrics.Emitter which writes each metric to log, for
+// environments without an MDM or OpenTelemetry collector
+func New(log *logrus.Entry) metrics.Emitter {
+	return &emitter{
+		log: log,
+	}
+}
+
+// EmitFloat logs float information
+func (e *emitter) EmitFloat(metricName string, metricValue float64, dimensions map[string]string) {
+	e.emit(metricName, metricValue, dimensions)
+}
+
+// EmitGauge logs gauge information
+func (e *emitter) EmitGauge(metricName string, metricValue int64, dimensions map[string]string) {
+	e.emit(metricName, metricValue, dimensions)
+}
+
+func (e *emitter) emit(metricName string, metricValue interface{}, dimensions map[string]string) {
+	e.log.WithFields(logrus.Fields{
+		"metric":     metricName,
+		"value":      metricValue,
+		"dimensions": dimensions,
+	}).Info("metric")
+}
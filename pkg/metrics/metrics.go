@@ -8,3 +8,23 @@ type Emitter interface {
 	EmitFloat(metricName string, metricValue float64, dimensions map[string]string)
 	EmitGauge(metricName string, metricValue int64, dimensions map[string]string)
 }
+
+type multiEmitter []Emitter
+
+// NewMultiEmitter returns an Emitter which emits each metric to all of the
+// given emitters
+func NewMultiEmitter(emitters ...Emitter) Emitter {
+	return multiEmitter(emitters)
+}
+
+func (m multiEmitter) EmitFloat(metricName string, metricValue float64, dimensions map[string]string) {
+	for _, e := range m {
+		e.EmitFloat(metricName, metricValue, dimensions)
+	}
+}
+
+func (m multiEmitter) EmitGauge(metricName string, metricValue int64, dimensions map[string]string) {
+	for _, e := range m {
+		e.EmitGauge(metricName, metricValue, dimensions)
+	}
+}
@@ -0,0 +1,58 @@
+package otel
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/Azure/ARO-RP/pkg/metrics/otel"
+
+var _ tracing.Tracer = (*azureTracer)(nil)
+
+type azureTracer struct {
+	tracer trace.Tracer
+	next   tracing.Tracer
+}
+
+// AzureTracer returns a go-autorest tracing.Tracer which, if the Provider is
+// enabled, records a span for each ARM call before passing it on to next.
+func (p *Provider) AzureTracer(next tracing.Tracer) tracing.Tracer {
+	if p.tp == nil {
+		return next
+	}
+
+	return &azureTracer{
+		tracer: otel.Tracer(tracerName),
+		next:   next,
+	}
+}
+
+func (t *azureTracer) NewTransport(base *http.Transport) http.RoundTripper {
+	return t.next.NewTransport(base)
+}
+
+func (t *azureTracer) StartSpan(ctx context.Context, name string) context.Context {
+	ctx, _ = t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+	return t.next.StartSpan(ctx, name)
+}
+
+func (t *azureTracer) EndSpan(ctx context.Context, httpStatusCode int, err error) {
+	t.next.EndSpan(ctx, httpStatusCode, err)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(semconv.HTTPResponseStatusCode(httpStatusCode))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,81 @@
+package otel
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/Azure/ARO-RP/pkg/metrics"
+)
+
+const meterName = "github.com/Azure/ARO-RP/pkg/metrics"
+
+type emitter struct {
+	meter metric.Meter
+
+	mu     sync.Mutex
+	floats map[string]metric.Float64Histogram
+	gauges map[string]metric.Int64Histogram
+}
+
+// NewEmitter returns a metrics.Emitter which records metrics with the given
+// OpenTelemetry meter provider.  OpenTelemetry has no synchronous gauge, so
+// each metric is recorded as a histogram; its sum and count give the totals and
+// averages that the MDM gauges are used for.
+func NewEmitter(mp metric.MeterProvider) metrics.Emitter {
+	return &emitter{
+		meter:  mp.Meter(meterName),
+		floats: map[string]metric.Float64Histogram{},
+		gauges: map[string]metric.Int64Histogram{},
+	}
+}
+
+// EmitFloat records float information
+func (e *emitter) EmitFloat(metricName string, metricValue float64, dimensions map[string]string) {
+	e.mu.Lock()
+	h, found := e.floats[metricName]
+	if !found {
+		var err error
+		h, err = e.meter.Float64Histogram(metricName)
+		if err != nil {
+			e.mu.Unlock()
+			return
+		}
+		e.floats[metricName] = h
+	}
+	e.mu.Unlock()
+
+	h.Record(context.Background(), metricValue, metric.WithAttributes(attributes(dimensions)...))
+}
+
+// EmitGauge records gauge information
+func (e *emitter) EmitGauge(metricName string, metricValue int64, dimensions map[string]string) {
+	e.mu.Lock()
+	h, found := e.gauges[metricName]
+	if !found {
+		var err error
+		h, err = e.meter.Int64Histogram(metricName)
+		if err != nil {
+			e.mu.Unlock()
+			return
+		}
+		e.gauges[metricName] = h
+	}
+	e.mu.Unlock()
+
+	h.Record(context.Background(), metricValue, metric.WithAttributes(attributes(dimensions)...))
+}
+
+func attributes(dimensions map[string]string) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(dimensions))
+	for k, v := range dimensions {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+
+	return attrs
+}
@@ -0,0 +1,105 @@
+package otel
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+// EndpointEnvVar is the standard OpenTelemetry environment variable naming the
+// OTLP/HTTP collector to export to.  The exporters also honour the other
+// OTEL_EXPORTER_OTLP_* variables, e.g. for headers and timeouts.
+const EndpointEnvVar = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+// Provider exports spans and metrics to an OTLP collector.
+type Provider struct {
+	tp *sdktrace.TracerProvider
+	mp *sdkmetric.MeterProvider
+}
+
+// New returns a Provider which exports spans and metrics over OTLP/HTTP to the
+// collector named by OTEL_EXPORTER_OTLP_ENDPOINT, and registers it as the
+// global OpenTelemetry provider.  If the variable is not set, the returned
+// Provider is disabled and tracing remains a no-op.
+func New(ctx context.Context, log *logrus.Entry, _env env.Core, component string) (*Provider, error) {
+	endpoint := os.Getenv(EndpointEnvVar)
+	if endpoint == "" {
+		return &Provider{}, nil
+	}
+
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceNamespace("aro"),
+		semconv.ServiceName(component),
+		semconv.ServiceVersion(version.GitCommit),
+		semconv.CloudRegion(_env.Location()),
+		semconv.DeploymentEnvironment(_env.Environment().Name),
+	)
+
+	traceExporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	metricExporter, err := otlpmetrichttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		tp: sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(traceExporter),
+			sdktrace.WithResource(res),
+		),
+		mp: sdkmetric.NewMeterProvider(
+			sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)),
+			sdkmetric.WithResource(res),
+		),
+	}
+
+	otel.SetTracerProvider(p.tp)
+	otel.SetMeterProvider(p.mp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		log.Warn(err)
+	}))
+
+	log.Printf("exporting OpenTelemetry spans and metrics to %s", endpoint)
+
+	return p, nil
+}
+
+// Emitter returns a metrics.Emitter which records metrics with OpenTelemetry,
+// or nil if the Provider is disabled.
+func (p *Provider) Emitter() metrics.Emitter {
+	if p.mp == nil {
+		return nil
+	}
+
+	return NewEmitter(p.mp)
+}
+
+// Shutdown flushes any buffered spans and metrics and stops exporting.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p.tp == nil {
+		return nil
+	}
+
+	return errors.Join(p.tp.Shutdown(ctx), p.mp.Shutdown(ctx))
+}
@@ -0,0 +1,108 @@
+package otel
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEmitter(t *testing.T) {
+	ctx := context.Background()
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	e := NewEmitter(mp)
+	e.EmitGauge("frontend.count", 1, map[string]string{"code": "200"})
+	e.EmitGauge("frontend.count", 1, map[string]string{"code": "200"})
+	e.EmitFloat("client.cosmosdb.requestunits", 2.5, nil)
+
+	var rm metricdata.ResourceMetrics
+	err := reader.Collect(ctx, &rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]interface{}{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Histogram[int64]:
+			got[m.Name] = data.DataPoints[0].Sum
+			if v, _ := data.DataPoints[0].Attributes.Value("code"); v != attribute.StringValue("200") {
+				t.Error(v)
+			}
+		case metricdata.Histogram[float64]:
+			got[m.Name] = data.DataPoints[0].Sum
+		}
+	}
+
+	if got["frontend.count"] != int64(2) || got["client.cosmosdb.requestunits"] != 2.5 {
+		t.Error(got)
+	}
+}
+
+func TestProviderDisabled(t *testing.T) {
+	p := &Provider{}
+
+	if p.Emitter() != nil {
+		t.Error("expected no emitter")
+	}
+
+	if p.Shutdown(context.Background()) != nil {
+		t.Error("expected shutdown to succeed")
+	}
+}
+
+type fakeTracer struct {
+	ended bool
+}
+
+func (t *fakeTracer) NewTransport(base *http.Transport) http.RoundTripper { return base }
+
+func (t *fakeTracer) StartSpan(ctx context.Context, name string) context.Context { return ctx }
+
+func (t *fakeTracer) EndSpan(ctx context.Context, httpStatusCode int, err error) { t.ended = true }
+
+func TestAzureTracer(t *testing.T) {
+	ctx := context.Background()
+
+	recorder := tracetest.NewSpanRecorder()
+	next := &fakeTracer{}
+
+	var tracer tracing.Tracer = &azureTracer{
+		tracer: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracerName),
+		next:   next,
+	}
+
+	ctx = tracer.StartSpan(ctx, "compute.VirtualMachinesClient#Get")
+	tracer.EndSpan(ctx, http.StatusNotFound, errors.New("not found"))
+
+	if !next.ended {
+		t.Error("expected next tracer to be called")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatal(len(spans))
+	}
+
+	if spans[0].Name() != "compute.VirtualMachinesClient#Get" {
+		t.Error(spans[0].Name())
+	}
+
+	if spans[0].Status().Code != codes.Error {
+		t.Error(spans[0].Status())
+	}
+}
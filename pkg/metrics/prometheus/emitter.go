@@ -0,0 +1,155 @@
+package prometheus
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "aro"
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+type series struct {
+	labels map[string]string
+	count  uint64
+	sum    float64
+	last   float64
+}
+
+type family struct {
+	labelNames map[string]struct{}
+	series     map[string]*series
+}
+
+// Emitter is a metrics.Emitter which keeps the metrics it is given in memory
+// and exposes them as a prometheus.Collector.  Each metric is exposed as a
+// summary, whose sum and count give the totals and rates that the MDM gauges
+// are used for, and as a gauge holding the last value emitted, for metrics
+// such as worker counts.
+type Emitter struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+// NewEmitter returns a new Emitter
+func NewEmitter() *Emitter {
+	return &Emitter{
+		families: map[string]*family{},
+	}
+}
+
+// EmitFloat records float information
+func (e *Emitter) EmitFloat(metricName string, metricValue float64, dimensions map[string]string) {
+	e.emit(metricName, metricValue, dimensions)
+}
+
+// EmitGauge records gauge information
+func (e *Emitter) EmitGauge(metricName string, metricValue int64, dimensions map[string]string) {
+	e.emit(metricName, float64(metricValue), dimensions)
+}
+
+func (e *Emitter) emit(metricName string, metricValue float64, dimensions map[string]string) {
+	labels := make(map[string]string, len(dimensions))
+	for k, v := range dimensions {
+		labels[sanitize(k)] = v
+	}
+
+	key := seriesKey(labels)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	f, found := e.families[metricName]
+	if !found {
+		f = &family{
+			labelNames: map[string]struct{}{},
+			series:     map[string]*series{},
+		}
+		e.families[metricName] = f
+	}
+
+	for k := range labels {
+		f.labelNames[k] = struct{}{}
+	}
+
+	s, found := f.series[key]
+	if !found {
+		s = &series{labels: labels}
+		f.series[key] = s
+	}
+
+	s.count++
+	s.sum += metricValue
+	s.last = metricValue
+}
+
+// Describe implements prometheus.Collector.  The metrics are not known in
+// advance, so the Emitter is an unchecked collector.
+func (e *Emitter) Describe(chan<- *prom.Desc) {}
+
+// Collect implements prometheus.Collector
+func (e *Emitter) Collect(ch chan<- prom.Metric) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for metricName, f := range e.families {
+		name := namespace + "_" + sanitize(metricName)
+
+		// dimensions may be omitted on some emits of a metric, but every
+		// series of a metric must have the same label names
+		labelNames := make([]string, 0, len(f.labelNames))
+		for k := range f.labelNames {
+			labelNames = append(labelNames, k)
+		}
+		sort.Strings(labelNames)
+
+		summaryDesc := prom.NewDesc(name, metricName, labelNames, nil)
+		lastDesc := prom.NewDesc(name+"_last", metricName+" (last value)", labelNames, nil)
+
+		for _, s := range f.series {
+			labelValues := make([]string, 0, len(labelNames))
+			for _, k := range labelNames {
+				labelValues = append(labelValues, s.labels[k])
+			}
+
+			ch <- prom.MustNewConstSummary(summaryDesc, s.count, s.sum, nil, labelValues...)
+			ch <- prom.MustNewConstMetric(lastDesc, prom.GaugeValue, s.last, labelValues...)
+		}
+	}
+}
+
+// sanitize converts a metric or dimension name into a valid Prometheus name,
+// e.g. frontend.count becomes frontend_count
+func sanitize(name string) string {
+	name = invalidNameChars.ReplaceAllString(name, "_")
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+
+	return name
+}
+
+func seriesKey(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, k := range keys {
+		sb.WriteString(k)
+		sb.WriteByte(0)
+		sb.WriteString(labels[k])
+		sb.WriteByte(0)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,82 @@
+package prometheus
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/util/recover"
+)
+
+// ListenAddressEnvVar names the address on which to serve /metrics.  Each
+// process needs its own address if several run on the same host.
+const ListenAddressEnvVar = "PROMETHEUS_LISTEN_ADDRESS"
+
+// New returns a metrics.Emitter whose metrics are served in Prometheus format
+// on /metrics at PROMETHEUS_LISTEN_ADDRESS until ctx is done.  If the variable
+// is not set, New returns nil.
+func New(ctx context.Context, log *logrus.Entry) (metrics.Emitter, error) {
+	address := os.Getenv(ListenAddressEnvVar)
+	if address == "" {
+		return nil, nil
+	}
+
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	e := NewEmitter()
+
+	registry := prom.NewRegistry()
+	registry.MustRegister(
+		e,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	go serve(ctx, log, l, Handler(registry))
+
+	log.Printf("serving Prometheus metrics on %s/metrics", l.Addr())
+
+	return e, nil
+}
+
+// Handler returns an http.Handler which serves the metrics gathered from g on
+// /metrics
+func Handler(g prom.Gatherer) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(g, promhttp.HandlerOpts{}))
+
+	return mux
+}
+
+func serve(ctx context.Context, log *logrus.Entry, l net.Listener, handler http.Handler) {
+	defer recover.Panic(log)
+
+	s := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		s.Close()
+	}()
+
+	err := s.Serve(l)
+	if err != http.ErrServerClosed {
+		log.Error(err)
+	}
+}
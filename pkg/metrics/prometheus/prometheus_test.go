@@ -0,0 +1,84 @@
+package prometheus
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+func TestEmitter(t *testing.T) {
+	e := NewEmitter()
+	e.EmitGauge("frontend.count", 1, map[string]string{"code": "200", "api-version": "2023-11-22"})
+	e.EmitGauge("frontend.count", 1, map[string]string{"code": "200", "api-version": "2023-11-22"})
+	e.EmitGauge("backend.openshiftcluster.workers.count", 3, nil)
+	e.EmitGauge("backend.openshiftcluster.workers.count", 2, nil)
+	e.EmitFloat("client.cosmosdb.requestunits", 2.5, map[string]string{"code": "200"})
+	e.EmitFloat("client.cosmosdb.requestunits", 1, nil)
+
+	registry := prom.NewRegistry()
+	registry.MustRegister(e)
+
+	server := httptest.NewServer(Handler(registry))
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		t.Fatal(resp.StatusCode)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`aro_frontend_count_count{api_version="2023-11-22",code="200"} 2`,
+		`aro_frontend_count_sum{api_version="2023-11-22",code="200"} 2`,
+		`aro_backend_openshiftcluster_workers_count_last 2`,
+		`aro_backend_openshiftcluster_workers_count_sum 5`,
+		`aro_client_cosmosdb_requestunits_sum{code="200"} 2.5`,
+		`aro_client_cosmosdb_requestunits_sum{code=""} 1`,
+	} {
+		if !strings.Contains(string(b), want+"\n") {
+			t.Errorf("missing %s", want)
+		}
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		want string
+	}{
+		{
+			name: "gateway.cluster.limitexceeded",
+			want: "gateway_cluster_limitexceeded",
+		},
+		{
+			name: "api-version",
+			want: "api_version",
+		},
+		{
+			name: "2xx",
+			want: "_2xx",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sanitize(tt.name)
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
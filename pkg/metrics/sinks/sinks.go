@@ -0,0 +1,109 @@
+package sinks
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	metricslog "github.com/Azure/ARO-RP/pkg/metrics/log"
+	"github.com/Azure/ARO-RP/pkg/metrics/otel"
+	"github.com/Azure/ARO-RP/pkg/metrics/prometheus"
+	"github.com/Azure/ARO-RP/pkg/metrics/statsd"
+)
+
+// EnvVar names the comma separated sinks to which a process emits metrics.
+// The filter for a sink is named by METRICS_<SINK>_FILTER, e.g.
+// METRICS_LOG_FILTER, and holds comma separated metric name prefixes.
+const EnvVar = "METRICS_SINKS"
+
+const (
+	// SinkMDM emits to Geneva MDM via the statsd socket
+	SinkMDM = "mdm"
+	// SinkOTLP emits to the OTLP collector, if one is configured
+	SinkOTLP = "otlp"
+	// SinkPrometheus serves /metrics, if a listen address is configured
+	SinkPrometheus = "prometheus"
+	// SinkLog writes each metric to the log
+	SinkLog = "log"
+)
+
+var defaultSinks = []string{SinkMDM, SinkOTLP, SinkPrometheus}
+
+// Options configures the sinks
+type Options struct {
+	// MDMAccount and MDMNamespace are the Geneva MDM account and namespace for
+	// the mdm sink
+	MDMAccount   string
+	MDMNamespace string
+
+	// OTel provides the otlp sink.  It may be nil if the process does not
+	// export to OpenTelemetry.
+	OTel *otel.Provider
+}
+
+// New returns a metrics.Emitter which emits to each of the sinks named in
+// METRICS_SINKS, or to the mdm, otlp and prometheus sinks if it is not set.
+// The otlp and prometheus sinks are skipped unless they are configured.  Each
+// sink only receives the metrics which match its filter, if it has one.
+func New(ctx context.Context, log *logrus.Entry, _env env.Core, o *Options) (metrics.Emitter, error) {
+	names := defaultSinks
+	if value := os.Getenv(EnvVar); value != "" {
+		names = strings.Split(value, ",")
+	}
+
+	emitters := make([]metrics.Emitter, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+
+		var e metrics.Emitter
+		var err error
+
+		switch name {
+		case SinkMDM:
+			e = statsd.New(ctx, log, _env, o.MDMAccount, o.MDMNamespace, os.Getenv("MDM_STATSD_SOCKET"))
+		case SinkOTLP:
+			if o.OTel != nil {
+				e = o.OTel.Emitter()
+			}
+		case SinkPrometheus:
+			e, err = prometheus.New(ctx, log)
+		case SinkLog:
+			e = metricslog.New(log)
+		default:
+			return nil, fmt.Errorf("unknown metrics sink %q in %s", name, EnvVar)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if e == nil {
+			continue
+		}
+
+		if filter := os.Getenv(FilterEnvVar(name)); filter != "" {
+			prefixes := strings.Split(filter, ",")
+			for i := range prefixes {
+				prefixes[i] = strings.TrimSpace(prefixes[i])
+			}
+			e = metrics.NewFilteredEmitter(e, prefixes...)
+		}
+
+		emitters = append(emitters, e)
+	}
+
+	return metrics.NewMultiEmitter(emitters...), nil
+}
+
+// FilterEnvVar returns the name of the environment variable which holds the
+// filter for a sink
+func FilterEnvVar(sink string) string {
+	return "METRICS_" + strings.ToUpper(sink) + "_FILTER"
+}
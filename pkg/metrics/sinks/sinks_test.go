@@ -0,0 +1,82 @@
+package sinks
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+	testlog "github.com/Azure/ARO-RP/test/util/log"
+)
+
+func TestNew(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		sinks     string
+		logFilter string
+		wantLogs  []map[string]types.GomegaMatcher
+		wantErr   string
+	}{
+		{
+			name:  "log sink",
+			sinks: "log",
+			wantLogs: []map[string]types.GomegaMatcher{
+				{
+					"msg":    gomega.Equal("metric"),
+					"metric": gomega.Equal("frontend.count"),
+				},
+				{
+					"msg":    gomega.Equal("metric"),
+					"metric": gomega.Equal("backend.openshiftcluster.count"),
+				},
+			},
+		},
+		{
+			name:      "filtered log sink",
+			sinks:     "log",
+			logFilter: "backend., gateway.",
+			wantLogs: []map[string]types.GomegaMatcher{
+				{
+					"msg":    gomega.Equal("metric"),
+					"metric": gomega.Equal("backend.openshiftcluster.count"),
+				},
+			},
+		},
+		{
+			name:  "unconfigured sinks are skipped",
+			sinks: "otlp, prometheus",
+		},
+		{
+			name:    "unknown sink",
+			sinks:   "log,geneva",
+			wantErr: `unknown metrics sink "geneva" in METRICS_SINKS`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvVar, tt.sinks)
+			t.Setenv(FilterEnvVar(SinkLog), tt.logFilter)
+			t.Setenv("PROMETHEUS_LISTEN_ADDRESS", "")
+
+			h, log := testlog.New()
+
+			m, err := New(context.Background(), log, nil, &Options{})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			if err != nil {
+				return
+			}
+
+			m.EmitGauge("frontend.count", 1, nil)
+			m.EmitFloat("backend.openshiftcluster.count", 1, nil)
+
+			err = testlog.AssertLoggingOutput(h, tt.wantLogs)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -35,6 +35,7 @@ func (t *tracerRoundTripper) RoundTrip(req *http.Request) (resp *http.Response,
 
 	defer func() {
 		parts := strings.Split(req.URL.Path, "/")
+		collection, operation := collectionAndOperation(req, parts)
 		if len(parts) >= 2 && parts[len(parts)-2] == "docs" {
 			parts[len(parts)-1] = "{id}"
 		}
@@ -45,24 +46,20 @@ func (t *tracerRoundTripper) RoundTrip(req *http.Request) (resp *http.Response,
 			statusCode = resp.StatusCode
 		}
 
-		t.m.EmitGauge("client.cosmosdb.count", 1, map[string]string{
-			"code": strconv.Itoa(statusCode),
-			"verb": req.Method,
-			"path": path,
-		})
+		dims := map[string]string{
+			"code":       strconv.Itoa(statusCode),
+			"verb":       req.Method,
+			"path":       path,
+			"collection": collection,
+			"operation":  operation,
+		}
+
+		t.m.EmitGauge("client.cosmosdb.count", 1, dims)
 
-		t.m.EmitGauge("client.cosmosdb.duration", time.Since(start).Milliseconds(), map[string]string{
-			"code": strconv.Itoa(statusCode),
-			"verb": req.Method,
-			"path": path,
-		})
+		t.m.EmitGauge("client.cosmosdb.duration", time.Since(start).Milliseconds(), dims)
 
 		if err != nil {
-			t.m.EmitGauge("client.cosmosdb.errors", 1, map[string]string{
-				"code": strconv.Itoa(statusCode),
-				"verb": req.Method,
-				"path": path,
-			})
+			t.m.EmitGauge("client.cosmosdb.errors", 1, dims)
 		}
 
 		if resp != nil {
@@ -71,14 +68,48 @@ func (t *tracerRoundTripper) RoundTrip(req *http.Request) (resp *http.Response,
 
 			ru, parseErr := strconv.ParseFloat(requestCharge, 64)
 			if parseErr == nil {
-				t.m.EmitFloat("client.cosmosdb.requestunits", ru, map[string]string{
-					"code": strconv.Itoa(statusCode),
-					"verb": req.Method,
-					"path": path,
-				})
+				t.m.EmitFloat("client.cosmosdb.requestunits", ru, dims)
 			}
 		}
 	}()
 
 	return t.tr.RoundTrip(req)
 }
+
+// collectionAndOperation returns the collection a Cosmos DB request targets
+// and the logical operation it performs, so that request charge and latency
+// can be attributed without parsing the raw path downstream.
+func collectionAndOperation(req *http.Request, parts []string) (collection, operation string) {
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == "colls" {
+			collection = parts[i+1]
+		}
+	}
+
+	switch {
+	case len(parts) >= 2 && parts[len(parts)-2] == "docs":
+		switch req.Method {
+		case http.MethodGet:
+			return collection, "read"
+		case http.MethodPut:
+			return collection, "replace"
+		case http.MethodDelete:
+			return collection, "delete"
+		}
+	case len(parts) >= 1 && parts[len(parts)-1] == "docs":
+		switch {
+		case req.Method == http.MethodPost && strings.EqualFold(req.Header.Get("X-Ms-Documentdb-Isquery"), "true"):
+			return collection, "query"
+		case req.Method == http.MethodPost:
+			return collection, "create"
+		case req.Method == http.MethodGet && req.Header.Get("A-IM") != "":
+			return collection, "changefeed"
+		case req.Method == http.MethodGet:
+			return collection, "list"
+		}
+	case len(parts) >= 1 && parts[len(parts)-1] == "pkranges":
+		return collection, "pkranges"
+	}
+
+	return collection, strings.ToLower(req.Method)
+}
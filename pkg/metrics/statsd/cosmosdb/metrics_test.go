@@ -27,7 +27,9 @@ func (rt *testRoundTripper) RoundTrip(req *http.Request) (*http.Response, error)
 func TestTracerRoundTripperRoundTrip(t *testing.T) {
 	for _, tt := range []struct {
 		name               string
+		method             string
 		url                string
+		header             http.Header
 		rt                 http.RoundTripper
 		mocks              func(*mock_metrics.MockEmitter)
 		wantErr            string
@@ -40,19 +42,25 @@ func TestTracerRoundTripperRoundTrip(t *testing.T) {
 			},
 			mocks: func(m *mock_metrics.MockEmitter) {
 				m.EXPECT().EmitGauge("client.cosmosdb.count", int64(1), map[string]string{
-					"verb": http.MethodGet,
-					"path": "/foo",
-					"code": "0",
+					"verb":       http.MethodGet,
+					"path":       "/foo",
+					"code":       "0",
+					"collection": "",
+					"operation":  "get",
 				})
 				m.EXPECT().EmitGauge("client.cosmosdb.duration", gomock.Any(), map[string]string{
-					"verb": http.MethodGet,
-					"path": "/foo",
-					"code": "0",
+					"verb":       http.MethodGet,
+					"path":       "/foo",
+					"code":       "0",
+					"collection": "",
+					"operation":  "get",
 				})
 				m.EXPECT().EmitGauge("client.cosmosdb.errors", int64(1), map[string]string{
-					"verb": http.MethodGet,
-					"path": "/foo",
-					"code": "0",
+					"verb":       http.MethodGet,
+					"path":       "/foo",
+					"code":       "0",
+					"collection": "",
+					"operation":  "get",
 				})
 			},
 			wantErr: "roundtrip failed",
@@ -69,14 +77,18 @@ func TestTracerRoundTripperRoundTrip(t *testing.T) {
 			},
 			mocks: func(m *mock_metrics.MockEmitter) {
 				m.EXPECT().EmitGauge("client.cosmosdb.count", int64(1), map[string]string{
-					"verb": http.MethodGet,
-					"path": "/foo",
-					"code": "401",
+					"verb":       http.MethodGet,
+					"path":       "/foo",
+					"code":       "401",
+					"collection": "",
+					"operation":  "get",
 				})
 				m.EXPECT().EmitGauge("client.cosmosdb.duration", gomock.Any(), map[string]string{
-					"verb": http.MethodGet,
-					"path": "/foo",
-					"code": "401",
+					"verb":       http.MethodGet,
+					"path":       "/foo",
+					"code":       "401",
+					"collection": "",
+					"operation":  "get",
 				})
 			},
 			wantRespStatusCode: http.StatusUnauthorized,
@@ -94,23 +106,58 @@ func TestTracerRoundTripperRoundTrip(t *testing.T) {
 			},
 			mocks: func(m *mock_metrics.MockEmitter) {
 				m.EXPECT().EmitGauge("client.cosmosdb.count", int64(1), map[string]string{
-					"verb": http.MethodGet,
-					"path": "/docs/{id}",
-					"code": "200",
+					"verb":       http.MethodGet,
+					"path":       "/docs/{id}",
+					"code":       "200",
+					"collection": "",
+					"operation":  "read",
 				})
 				m.EXPECT().EmitGauge("client.cosmosdb.duration", gomock.Any(), map[string]string{
-					"verb": http.MethodGet,
-					"path": "/docs/{id}",
-					"code": "200",
+					"verb":       http.MethodGet,
+					"path":       "/docs/{id}",
+					"code":       "200",
+					"collection": "",
+					"operation":  "read",
 				})
 				m.EXPECT().EmitFloat("client.cosmosdb.requestunits", 1.23, map[string]string{
-					"verb": http.MethodGet,
-					"path": "/docs/{id}",
-					"code": "200",
+					"verb":       http.MethodGet,
+					"path":       "/docs/{id}",
+					"code":       "200",
+					"collection": "",
+					"operation":  "read",
 				})
 			},
 			wantRespStatusCode: http.StatusOK,
 		},
+		{
+			name:   "query is attributed to its collection",
+			method: http.MethodPost,
+			url:    "http://example.com/dbs/ARO/colls/OpenShiftClusters/docs",
+			header: http.Header{
+				"X-Ms-Documentdb-Isquery": {"True"},
+			},
+			rt: &testRoundTripper{
+				resp: &http.Response{
+					StatusCode: http.StatusOK,
+					Header: http.Header{
+						"X-Ms-Request-Charge": {`"2.5"`},
+					},
+				},
+			},
+			mocks: func(m *mock_metrics.MockEmitter) {
+				dims := map[string]string{
+					"verb":       http.MethodPost,
+					"path":       "/dbs/ARO/colls/OpenShiftClusters/docs",
+					"code":       "200",
+					"collection": "OpenShiftClusters",
+					"operation":  "query",
+				}
+				m.EXPECT().EmitGauge("client.cosmosdb.count", int64(1), dims)
+				m.EXPECT().EmitGauge("client.cosmosdb.duration", gomock.Any(), dims)
+				m.EXPECT().EmitFloat("client.cosmosdb.requestunits", 2.5, dims)
+			},
+			wantRespStatusCode: http.StatusOK,
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			controller := gomock.NewController(t)
@@ -130,10 +177,18 @@ func TestTracerRoundTripperRoundTrip(t *testing.T) {
 				url = tt.url
 			}
 
-			req, err := http.NewRequest(http.MethodGet, url, nil)
+			method := http.MethodGet
+			if tt.method != "" {
+				method = tt.method
+			}
+
+			req, err := http.NewRequest(method, url, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
+			if tt.header != nil {
+				req.Header = tt.header
+			}
 
 			resp, err := tripper.RoundTrip(req)
 			utilerror.AssertErrorMessage(t, err, tt.wantErr)
@@ -21,6 +21,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	"github.com/Azure/ARO-RP/pkg/env"
+	mimo_const "github.com/Azure/ARO-RP/pkg/mimo"
 	"github.com/Azure/ARO-RP/pkg/mimo/tasks"
 	"github.com/Azure/ARO-RP/pkg/util/mimo"
 	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
@@ -157,6 +158,235 @@ var _ = Describe("MIMO Actuator", Ordered, func() {
 		})
 	})
 
+	When("manifests outside of their execution window", func() {
+		BeforeEach(func() {
+			fixtures.Clear()
+			fixtures.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(clusterResourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: clusterResourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateSucceeded,
+					},
+				},
+			})
+
+			notYetDue := &api.MaintenanceManifestDocument{
+				ID:                manifests.NewUUID(),
+				ClusterResourceID: strings.ToLower(clusterResourceID),
+				MaintenanceManifest: api.MaintenanceManifest{
+					State:             api.MaintenanceManifestStatePending,
+					MaintenanceTaskID: "0",
+					RunBefore:         600,
+					RunAfter:          300,
+				},
+			}
+			// the evaluation time is a Thursday
+			outsideWindows := &api.MaintenanceManifestDocument{
+				ID:                manifests.NewUUID(),
+				ClusterResourceID: strings.ToLower(clusterResourceID),
+				MaintenanceManifest: api.MaintenanceManifest{
+					State:             api.MaintenanceManifestStatePending,
+					MaintenanceTaskID: "0",
+					RunBefore:         600,
+					RunAfter:          0,
+					Windows: []api.MaintenanceWindow{
+						{
+							DayOfWeek:     "Saturday",
+							StartHour:     0,
+							DurationHours: 24,
+						},
+					},
+				},
+			}
+			fixtures.AddMaintenanceManifestDocuments(notYetDue, outsideWindows)
+
+			checker.Clear()
+			checker.AddMaintenanceManifestDocuments(notYetDue, outsideWindows)
+			checker.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(clusterResourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: clusterResourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateSucceeded,
+					},
+				},
+			})
+		})
+
+		It("defers them", func() {
+			a.AddMaintenanceTasks(map[string]tasks.MaintenanceTask{
+				"0": func(th mimo.TaskContext, mmd *api.MaintenanceManifestDocument, oscd *api.OpenShiftClusterDocument) error {
+					Fail("manifest should not have been run")
+					return nil
+				},
+			})
+
+			didWork, err := a.Process(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(didWork).To(BeFalse())
+
+			errs := checker.CheckMaintenanceManifests(manifestsClient)
+			Expect(errs).To(BeNil(), fmt.Sprintf("%v", errs))
+
+			errs = checker.CheckOpenShiftClusters(clustersClient)
+			Expect(errs).To(BeNil(), fmt.Sprintf("%v", errs))
+		})
+	})
+
+	When("impactful manifest not yet due", func() {
+		var manifest *api.MaintenanceManifestDocument
+
+		BeforeEach(func() {
+			fixtures.Clear()
+			fixtures.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(clusterResourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: clusterResourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateSucceeded,
+					},
+				},
+			})
+
+			manifest = &api.MaintenanceManifestDocument{
+				ID:                manifests.NewUUID(),
+				ClusterResourceID: strings.ToLower(clusterResourceID),
+				MaintenanceManifest: api.MaintenanceManifest{
+					State:             api.MaintenanceManifestStatePending,
+					MaintenanceTaskID: mimo_const.AUTOMATIC_PATCH_UPGRADE_ID,
+					RunBefore:         600,
+					RunAfter:          300,
+				},
+			}
+			fixtures.AddMaintenanceManifestDocuments(manifest)
+
+			checker.Clear()
+			checker.AddMaintenanceManifestDocuments(manifest)
+			checker.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(clusterResourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: clusterResourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateSucceeded,
+						ScheduledMaintenance: &api.ScheduledMaintenance{
+							ManifestID: manifest.ID,
+							StartTime:  time.Unix(300, 0).UTC(),
+							EndTime:    time.Unix(600, 0).UTC(),
+							Impact:     mimo_const.IMPACTFUL_TASKS[mimo_const.AUTOMATIC_PATCH_UPGRADE_ID],
+						},
+					},
+				},
+			})
+		})
+
+		It("publishes it to the customer", func() {
+			a.AddMaintenanceTasks(map[string]tasks.MaintenanceTask{
+				mimo_const.AUTOMATIC_PATCH_UPGRADE_ID: func(th mimo.TaskContext, mmd *api.MaintenanceManifestDocument, oscd *api.OpenShiftClusterDocument) error {
+					Fail("manifest should not have been run")
+					return nil
+				},
+			})
+
+			didWork, err := a.Process(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(didWork).To(BeFalse())
+
+			errs := checker.CheckMaintenanceManifests(manifestsClient)
+			Expect(errs).To(BeNil(), fmt.Sprintf("%v", errs))
+
+			errs = checker.CheckOpenShiftClusters(clustersClient)
+			Expect(errs).To(BeNil(), fmt.Sprintf("%v", errs))
+		})
+	})
+
+	When("impactful manifest is due", func() {
+		var manifestID string
+
+		BeforeEach(func() {
+			manifestID = manifests.NewUUID()
+
+			fixtures.Clear()
+			fixtures.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(clusterResourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: clusterResourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateSucceeded,
+						MaintenanceState:  api.MaintenanceStateNone,
+						ScheduledMaintenance: &api.ScheduledMaintenance{
+							ManifestID: manifestID,
+							StartTime:  time.Unix(0, 0).UTC(),
+							EndTime:    time.Unix(600, 0).UTC(),
+							Impact:     mimo_const.IMPACTFUL_TASKS[mimo_const.AUTOMATIC_PATCH_UPGRADE_ID],
+						},
+					},
+				},
+			})
+			fixtures.AddMaintenanceManifestDocuments(&api.MaintenanceManifestDocument{
+				ID:                manifestID,
+				ClusterResourceID: strings.ToLower(clusterResourceID),
+				MaintenanceManifest: api.MaintenanceManifest{
+					State:             api.MaintenanceManifestStatePending,
+					MaintenanceTaskID: mimo_const.AUTOMATIC_PATCH_UPGRADE_ID,
+					RunBefore:         600,
+					RunAfter:          0,
+				},
+			})
+
+			checker.Clear()
+			checker.AddMaintenanceManifestDocuments(&api.MaintenanceManifestDocument{
+				ID:                manifestID,
+				Dequeues:          1,
+				ClusterResourceID: strings.ToLower(clusterResourceID),
+				MaintenanceManifest: api.MaintenanceManifest{
+					State:             api.MaintenanceManifestStateCompleted,
+					MaintenanceTaskID: mimo_const.AUTOMATIC_PATCH_UPGRADE_ID,
+					StatusText:        "done",
+					RunBefore:         600,
+					RunAfter:          0,
+				},
+			})
+			checker.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(clusterResourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: clusterResourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateSucceeded,
+						MaintenanceState:  api.MaintenanceStateNone,
+						MaintenanceHistory: []api.MaintenanceHistoryEntry{
+							{
+								Type:        mimo_const.AUTOMATIC_PATCH_UPGRADE_ID,
+								StartedAt:   time.Unix(120, 0).UTC(),
+								CompletedAt: time.Unix(120, 0).UTC(),
+								Outcome:     api.MaintenanceHistoryOutcomeSucceeded,
+							},
+						},
+					},
+				},
+			})
+		})
+
+		It("clears the published maintenance once it has run", func() {
+			a.AddMaintenanceTasks(map[string]tasks.MaintenanceTask{
+				mimo_const.AUTOMATIC_PATCH_UPGRADE_ID: func(th mimo.TaskContext, mmd *api.MaintenanceManifestDocument, oscd *api.OpenShiftClusterDocument) error {
+					th.SetResultMessage("done")
+					return nil
+				},
+			})
+
+			didWork, err := a.Process(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(didWork).To(BeTrue())
+
+			errs := checker.CheckMaintenanceManifests(manifestsClient)
+			Expect(errs).To(BeNil(), fmt.Sprintf("%v", errs))
+
+			errs = checker.CheckOpenShiftClusters(clustersClient)
+			Expect(errs).To(BeNil(), fmt.Sprintf("%v", errs))
+		})
+	})
+
 	When("new manifest", func() {
 		var manifestID string
 
@@ -205,6 +435,14 @@ var _ = Describe("MIMO Actuator", Ordered, func() {
 					Properties: api.OpenShiftClusterProperties{
 						ProvisioningState: api.ProvisioningStateSucceeded,
 						MaintenanceState:  api.MaintenanceStateNone,
+						MaintenanceHistory: []api.MaintenanceHistoryEntry{
+							{
+								Type:        "0",
+								StartedAt:   time.Unix(120, 0).UTC(),
+								CompletedAt: time.Unix(120, 0).UTC(),
+								Outcome:     api.MaintenanceHistoryOutcomeSucceeded,
+							},
+						},
 					},
 				},
 			})
@@ -286,6 +524,18 @@ var _ = Describe("MIMO Actuator", Ordered, func() {
 
 			errs := checker.CheckMaintenanceManifests(manifestsClient)
 			Expect(errs).To(BeNil(), fmt.Sprintf("%v", errs))
+
+			oc, err := clusters.Get(ctx, strings.ToLower(clusterResourceID))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(oc.OpenShiftCluster.Properties.MaintenanceHistory).To(Equal([]api.MaintenanceHistoryEntry{
+				{
+					Type:        "0",
+					StartedAt:   time.Unix(120, 0).UTC(),
+					CompletedAt: time.Unix(120, 0).UTC(),
+					Outcome:     api.MaintenanceHistoryOutcomeFailed,
+					Error:       "TransientError: oh no",
+				},
+			}))
 		})
 	})
 
@@ -414,6 +664,115 @@ var _ = Describe("MIMO Actuator", Ordered, func() {
 		})
 	})
 
+	When("automatic upgrades are enabled", func() {
+		var upgradeProfile *api.UpgradeProfile
+
+		addCluster := func() {
+			fixtures.Clear()
+			fixtures.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(clusterResourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: clusterResourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateSucceeded,
+						UpgradeProfile:    upgradeProfile,
+					},
+				},
+			})
+		}
+
+		listManifests := func() []*api.MaintenanceManifestDocument {
+			all, err := manifestsClient.ListAll(ctx, nil)
+			Expect(err).ToNot(HaveOccurred())
+			return all.MaintenanceManifestDocuments
+		}
+
+		BeforeEach(func() {
+			// the actuator's clock is at 1970-01-01 00:02 UTC, a Thursday
+			upgradeProfile = &api.UpgradeProfile{
+				Policy: api.UpgradePolicyAutomaticPatch,
+				MaintenanceWindows: []api.MaintenanceWindow{
+					{
+						DayOfWeek:     "Thursday",
+						StartHour:     0,
+						DurationHours: 2,
+					},
+				},
+			}
+			addCluster()
+		})
+
+		It("queues one upgrade within the open maintenance window", func() {
+			didWork, err := a.ScheduleAutomaticUpgrades(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(didWork).To(BeTrue())
+
+			didWork, err = a.ScheduleAutomaticUpgrades(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(didWork).To(BeFalse())
+
+			docs := listManifests()
+			Expect(docs).To(HaveLen(1))
+			Expect(docs[0].ClusterResourceID).To(Equal(strings.ToLower(clusterResourceID)))
+			Expect(docs[0].MaintenanceManifest).To(Equal(api.MaintenanceManifest{
+				State:             api.MaintenanceManifestStatePending,
+				MaintenanceTaskID: mimo_const.AUTOMATIC_PATCH_UPGRADE_ID,
+				RunAfter:          120,
+				RunBefore:         7200,
+			}))
+		})
+
+		When("outside of the maintenance windows", func() {
+			BeforeEach(func() {
+				upgradeProfile.MaintenanceWindows[0].DayOfWeek = "Friday"
+				addCluster()
+			})
+
+			It("does not queue an upgrade", func() {
+				didWork, err := a.ScheduleAutomaticUpgrades(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(didWork).To(BeFalse())
+				Expect(listManifests()).To(BeEmpty())
+			})
+		})
+
+		When("automatic upgrades are paused", func() {
+			BeforeEach(func() {
+				upgradeProfile.AutomaticUpgradesPausedReason = "automatic upgrade failed: oh no"
+				addCluster()
+			})
+
+			It("does not queue an upgrade", func() {
+				didWork, err := a.ScheduleAutomaticUpgrades(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(didWork).To(BeFalse())
+				Expect(listManifests()).To(BeEmpty())
+			})
+		})
+
+		It("pauses automatic upgrades when the upgrade fails", func() {
+			_, err := a.ScheduleAutomaticUpgrades(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			a.AddMaintenanceTasks(map[string]tasks.MaintenanceTask{
+				mimo_const.AUTOMATIC_PATCH_UPGRADE_ID: func(th mimo.TaskContext, mmd *api.MaintenanceManifestDocument, oscd *api.OpenShiftClusterDocument) error {
+					return mimo.TerminalError(errors.New("oh no"))
+				},
+			})
+
+			didWork, err := a.Process(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(didWork).To(BeTrue())
+
+			docs := listManifests()
+			Expect(docs).To(HaveLen(1))
+			Expect(docs[0].MaintenanceManifest.State).To(Equal(api.MaintenanceManifestStateFailed))
+
+			oc, err := clusters.Get(ctx, strings.ToLower(clusterResourceID))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(oc.OpenShiftCluster.Properties.UpgradeProfile.AutomaticUpgradesPausedReason).To(Equal("automatic upgrade failed: TerminalError: oh no"))
+		})
+	})
 })
 
 func TestActuator(t *testing.T) {
@@ -0,0 +1,75 @@
+package actuator
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/mimo"
+)
+
+// ScheduleAutomaticUpgrades queues an automatic patch upgrade if the cluster
+// has opted in to them and is inside one of its maintenance windows.  At most
+// one upgrade is queued per window: the manifest must start before the window
+// ends, which is also how an already queued manifest is recognised.
+func (a *actuator) ScheduleAutomaticUpgrades(ctx context.Context) (bool, error) {
+	oc, err := a.oc.Get(ctx, a.clusterResourceID)
+	if err != nil {
+		return false, fmt.Errorf("failed getting cluster document: %w", err)
+	}
+
+	up := oc.OpenShiftCluster.Properties.UpgradeProfile
+	if !up.AutomaticUpgradesEnabled() {
+		return false, nil
+	}
+
+	evaluationTime := a.now()
+
+	windowEnd, ok := up.ActiveMaintenanceWindowEnd(evaluationTime)
+	if !ok {
+		return false, nil
+	}
+
+	i, err := a.mmf.GetByClusterResourceID(ctx, a.clusterResourceID, "")
+	if err != nil {
+		return false, fmt.Errorf("failed getting manifests: %w", err)
+	}
+
+	for {
+		docs, err := i.Next(ctx, -1)
+		if err != nil {
+			return false, fmt.Errorf("failed reading next manifest document: %w", err)
+		}
+		if docs == nil {
+			break
+		}
+
+		for _, doc := range docs.MaintenanceManifestDocuments {
+			if doc.MaintenanceManifest.MaintenanceTaskID == mimo.AUTOMATIC_PATCH_UPGRADE_ID &&
+				doc.MaintenanceManifest.RunBefore == int(windowEnd.Unix()) {
+				return false, nil
+			}
+		}
+	}
+
+	a.log.Infof("scheduling automatic patch upgrade to start before %s", windowEnd)
+
+	_, err = a.mmf.Create(ctx, &api.MaintenanceManifestDocument{
+		ID:                a.mmf.NewUUID(),
+		ClusterResourceID: a.clusterResourceID,
+		MaintenanceManifest: api.MaintenanceManifest{
+			State:             api.MaintenanceManifestStatePending,
+			MaintenanceTaskID: mimo.AUTOMATIC_PATCH_UPGRADE_ID,
+			RunAfter:          int(evaluationTime.Unix()),
+			RunBefore:         int(windowEnd.Unix()),
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed creating automatic upgrade manifest: %w", err)
+	}
+
+	return true, nil
+}
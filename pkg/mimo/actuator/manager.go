@@ -16,6 +16,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/mimo"
 	"github.com/Azure/ARO-RP/pkg/mimo/tasks"
 	utilmimo "github.com/Azure/ARO-RP/pkg/util/mimo"
 )
@@ -24,6 +25,7 @@ const maxDequeueCount = 5
 
 type Actuator interface {
 	Process(context.Context) (bool, error)
+	ScheduleAutomaticUpgrades(context.Context) (bool, error)
 	AddMaintenanceTasks(map[string]tasks.MaintenanceTask)
 }
 
@@ -91,6 +93,7 @@ func (a *actuator) Process(ctx context.Context) (bool, error) {
 	}
 
 	manifestsToAction := make([]*api.MaintenanceManifestDocument, 0)
+	manifestsQueued := make([]*api.MaintenanceManifestDocument, 0)
 
 	// Order manifests in order of RunAfter, and then Priority for ones with the
 	// same RunAfter.
@@ -118,12 +121,23 @@ func (a *actuator) Process(ctx context.Context) (bool, error) {
 			if err != nil {
 				a.log.Error(fmt.Errorf("failed to patch manifest %s with state TimedOut; will still attempt to process other manifests: %w", doc.ID, err))
 			}
+		} else if !doc.MaintenanceManifest.CanStartAt(evaluationTime) {
+			// not yet due or outside of its windows, leave it queued
+			a.log.Infof("deferring %v: outside of its execution window at %v", doc.ID, evaluationTime.UTC())
+			manifestsQueued = append(manifestsQueued, doc)
 		} else {
 			// not timed out, do something about it
 			manifestsToAction = append(manifestsToAction, doc)
+			manifestsQueued = append(manifestsQueued, doc)
 		}
 	}
 
+	// Let the customer know about impactful maintenance ahead of time
+	err = a.publishScheduledMaintenance(ctx, manifestsQueued)
+	if err != nil {
+		a.log.Error(fmt.Errorf("failed publishing scheduled maintenance, but continuing: %w", err))
+	}
+
 	// Nothing to do, don't dequeue
 	if len(manifestsToAction) == 0 {
 		return false, nil
@@ -164,6 +178,16 @@ func (a *actuator) Process(ctx context.Context) (bool, error) {
 
 	taskContext := newTaskContext(ctx, a.env, a.log, oc)
 
+	// Set if an automatic upgrade fails, to pause further automatic upgrades
+	var automaticUpgradesPausedReason string
+
+	// Outcomes of the executed manifests, recorded in the cluster's
+	// maintenance history
+	history := make([]api.MaintenanceHistoryEntry, 0, len(manifestsToAction))
+
+	// Manifests which will not be run again
+	finished := map[string]bool{}
+
 	// Execute on the manifests we want to action
 	for _, doc := range manifestsToAction {
 		taskLog := a.log.WithFields(logrus.Fields{
@@ -196,6 +220,7 @@ func (a *actuator) Process(ctx context.Context) (bool, error) {
 		var msg string
 
 		taskLog.Info("executing manifest")
+		startedAt := a.now()
 
 		// Perform the task with a timeout
 		err = taskContext.RunInTimeout(time.Minute*60, func() error {
@@ -231,6 +256,30 @@ func (a *actuator) Process(ctx context.Context) (bool, error) {
 			taskLog.Info("manifest executed successfully")
 		}
 
+		entry := api.MaintenanceHistoryEntry{
+			Type:        doc.MaintenanceManifest.MaintenanceTaskID,
+			StartedAt:   startedAt,
+			CompletedAt: a.now(),
+			Outcome:     api.MaintenanceHistoryOutcomeSucceeded,
+		}
+		if err != nil {
+			entry.Outcome = api.MaintenanceHistoryOutcomeFailed
+			if state == api.MaintenanceManifestStatePending {
+				entry.Outcome = api.MaintenanceHistoryOutcomeRetrying
+			}
+			entry.Error = err.Error()
+		}
+		history = append(history, entry)
+
+		if doc.MaintenanceManifest.MaintenanceTaskID == mimo.AUTOMATIC_PATCH_UPGRADE_ID &&
+			(state == api.MaintenanceManifestStateFailed || state == api.MaintenanceManifestStateRetriesExceeded) {
+			automaticUpgradesPausedReason = fmt.Sprintf("automatic upgrade failed: %s", err.Error())
+		}
+
+		if state != api.MaintenanceManifestStatePending {
+			finished[doc.ID] = true
+		}
+
 		_, err = a.mmf.EndLease(ctx, doc.ClusterResourceID, doc.ID, state, &msg)
 		if err != nil {
 			taskLog.Error(fmt.Errorf("failed ending lease on manifest: %w", err))
@@ -242,6 +291,15 @@ func (a *actuator) Process(ctx context.Context) (bool, error) {
 	a.log.Info("removing maintenance state on cluster")
 	oc, err = a.oc.PatchWithLease(ctx, a.clusterResourceID, func(oscd *api.OpenShiftClusterDocument) error {
 		oscd.OpenShiftCluster.Properties.MaintenanceState = api.MaintenanceStateNone
+		oscd.OpenShiftCluster.Properties.AppendMaintenanceHistory(history...)
+		// Clear the published maintenance once it is complete; any further
+		// impactful maintenance is published on the next pass
+		if sm := oscd.OpenShiftCluster.Properties.ScheduledMaintenance; sm != nil && finished[sm.ManifestID] {
+			oscd.OpenShiftCluster.Properties.ScheduledMaintenance = nil
+		}
+		if automaticUpgradesPausedReason != "" && oscd.OpenShiftCluster.Properties.UpgradeProfile != nil {
+			oscd.OpenShiftCluster.Properties.UpgradeProfile.AutomaticUpgradesPausedReason = automaticUpgradesPausedReason
+		}
 		return nil
 	})
 	if err != nil {
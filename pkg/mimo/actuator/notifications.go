@@ -0,0 +1,71 @@
+package actuator
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/mimo"
+)
+
+// publishScheduledMaintenance publishes the first impactful manifest queued
+// for the cluster to the customer via the cluster document, or clears the
+// published maintenance if there is none.  docs must be in the order in which
+// they will be run.
+func (a *actuator) publishScheduledMaintenance(ctx context.Context, docs []*api.MaintenanceManifestDocument) error {
+	var scheduled *api.ScheduledMaintenance
+	for _, doc := range docs {
+		impact, ok := mimo.IMPACTFUL_TASKS[doc.MaintenanceManifest.MaintenanceTaskID]
+		if !ok {
+			continue
+		}
+
+		scheduled = &api.ScheduledMaintenance{
+			ManifestID: doc.ID,
+			StartTime:  time.Unix(int64(doc.MaintenanceManifest.RunAfter), 0).UTC(),
+			EndTime:    time.Unix(int64(doc.MaintenanceManifest.RunBefore), 0).UTC(),
+			Impact:     impact,
+		}
+		break
+	}
+
+	oc, err := a.oc.Get(ctx, a.clusterResourceID)
+	if err != nil {
+		return fmt.Errorf("failed getting cluster document: %w", err)
+	}
+
+	if scheduledMaintenanceEqual(oc.OpenShiftCluster.Properties.ScheduledMaintenance, scheduled) {
+		return nil
+	}
+
+	if scheduled != nil {
+		a.log.Infof("publishing scheduled maintenance %s between %s and %s", scheduled.ManifestID, scheduled.StartTime, scheduled.EndTime)
+	} else {
+		a.log.Info("clearing scheduled maintenance")
+	}
+
+	_, err = a.oc.Patch(ctx, a.clusterResourceID, func(oscd *api.OpenShiftClusterDocument) error {
+		oscd.OpenShiftCluster.Properties.ScheduledMaintenance = scheduled
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed patching scheduled maintenance on cluster document: %w", err)
+	}
+
+	return nil
+}
+
+func scheduledMaintenanceEqual(a, b *api.ScheduledMaintenance) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.ManifestID == b.ManifestID &&
+		a.StartTime.Equal(b.StartTime) &&
+		a.EndTime.Equal(b.EndTime) &&
+		a.Impact == b.Impact
+}
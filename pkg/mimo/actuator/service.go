@@ -301,7 +301,12 @@ out:
 				s.m.EmitGauge("mimo.actuator.workers.active.count", int64(s.workers.Load()), nil)
 			}()
 
-			_, err := a.Process(context.Background())
+			_, err := a.ScheduleAutomaticUpgrades(context.Background())
+			if err != nil {
+				log.Error(err)
+			}
+
+			_, err = a.Process(context.Background())
 			if err != nil {
 				log.Error(err)
 			}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/cincinnati"
 	"github.com/Azure/ARO-RP/pkg/util/clienthelper"
 	"github.com/Azure/ARO-RP/pkg/util/mimo"
 	"github.com/Azure/ARO-RP/pkg/util/restconfig"
@@ -33,6 +34,10 @@ type th struct {
 	_ch clienthelper.Interface
 }
 
+// upgradeGraph is shared by all tasks so that the update graph of each channel
+// is fetched at most once an hour
+var upgradeGraph = cincinnati.NewClient("")
+
 // force interface checking
 var _ mimo.TaskContext = &th{}
 
@@ -141,3 +146,8 @@ func (t *th) LocalFpAuthorizer() (autorest.Authorizer, error) {
 func (t *th) GetOpenshiftClusterDocument() *api.OpenShiftClusterDocument {
 	return t.oc
 }
+
+// UpgradeGraph implements mimo.TaskContext.
+func (t *th) UpgradeGraph() cincinnati.Client {
+	return upgradeGraph
+}
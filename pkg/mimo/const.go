@@ -4,7 +4,15 @@ package mimo
 // Licensed under the Apache License 2.0.
 
 const (
-	TLS_CERT_ROTATION_ID     = "9b741734-6505-447f-8510-85eb0ae561a2"
-	OPERATOR_FLAGS_UPDATE_ID = "b41749fc-af26-4ab7-b5a1-e03f3ee4cba6"
-	ACR_TOKEN_CHECKER_ID     = "082978ce-3700-4972-835f-53d48658d291"
+	TLS_CERT_ROTATION_ID       = "9b741734-6505-447f-8510-85eb0ae561a2"
+	OPERATOR_FLAGS_UPDATE_ID   = "b41749fc-af26-4ab7-b5a1-e03f3ee4cba6"
+	ACR_TOKEN_CHECKER_ID       = "082978ce-3700-4972-835f-53d48658d291"
+	AUTOMATIC_PATCH_UPGRADE_ID = "478ad685-a0ea-4f3e-b27f-c0f83e0dc54b"
 )
+
+// IMPACTFUL_TASKS maps the IDs of tasks which may impact the customer's
+// workloads to the impact which is published to the customer while they are
+// scheduled
+var IMPACTFUL_TASKS = map[string]string{
+	AUTOMATIC_PATCH_UPGRADE_ID: "The cluster will be upgraded to the latest patch release of its minor version. Nodes are drained and restarted one at a time.",
+}
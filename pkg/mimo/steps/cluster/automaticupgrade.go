@@ -0,0 +1,170 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/Azure/ARO-RP/pkg/util/cincinnati"
+	"github.com/Azure/ARO-RP/pkg/util/mimo"
+	"github.com/Azure/ARO-RP/pkg/util/ready"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+const clusterVersionFailing configv1.ClusterStatusConditionType = "Failing"
+
+// EnsureClusterIsHealthyForUpgrade gates automatic upgrades on the health of
+// the cluster: the ClusterVersion must not be failing, every ClusterOperator
+// must be available and not degraded, and every node must be ready.
+func EnsureClusterIsHealthyForUpgrade(ctx context.Context) error {
+	th, err := mimo.GetTaskContext(ctx)
+	if err != nil {
+		return mimo.TerminalError(err)
+	}
+
+	ch, err := th.ClientHelper()
+	if err != nil {
+		return mimo.TerminalError(err)
+	}
+
+	cv := &configv1.ClusterVersion{}
+	err = ch.GetOne(ctx, types.NamespacedName{Name: "version"}, cv)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return mimo.TerminalError(err)
+		}
+		return mimo.TransientError(err)
+	}
+
+	for _, c := range cv.Status.Conditions {
+		if c.Type == clusterVersionFailing && c.Status == configv1.ConditionTrue {
+			return mimo.TransientError(fmt.Errorf("ClusterVersion is failing: %s", c.Message))
+		}
+	}
+
+	cos := &configv1.ClusterOperatorList{}
+	err = ch.List(ctx, cos)
+	if err != nil {
+		return mimo.TransientError(err)
+	}
+
+	for _, co := range cos.Items {
+		conditions := make(map[configv1.ClusterStatusConditionType]configv1.ConditionStatus, len(co.Status.Conditions))
+		for _, c := range co.Status.Conditions {
+			conditions[c.Type] = c.Status
+		}
+
+		if conditions[configv1.OperatorAvailable] != configv1.ConditionTrue ||
+			conditions[configv1.OperatorDegraded] == configv1.ConditionTrue {
+			return mimo.TransientError(fmt.Errorf("ClusterOperator %s is not healthy: %s=%s, %s=%s", co.Name,
+				configv1.OperatorAvailable, conditions[configv1.OperatorAvailable], configv1.OperatorDegraded, conditions[configv1.OperatorDegraded]))
+		}
+	}
+
+	nodes := &corev1.NodeList{}
+	err = ch.List(ctx, nodes)
+	if err != nil {
+		return mimo.TransientError(err)
+	}
+
+	for i := range nodes.Items {
+		if !ready.NodeIsReady(&nodes.Items[i]) {
+			return mimo.TransientError(fmt.Errorf("node %s is not ready", nodes.Items[i].Name))
+		}
+	}
+
+	return nil
+}
+
+// StartAutomaticPatchUpgrade requests an upgrade to the latest patch release of
+// the cluster's minor version which the ClusterVersion reports as available,
+// once the update graph confirms the upgrade is supported.  It does nothing if
+// an upgrade is already in progress or no newer patch release is available.
+func StartAutomaticPatchUpgrade(ctx context.Context) error {
+	th, err := mimo.GetTaskContext(ctx)
+	if err != nil {
+		return mimo.TerminalError(err)
+	}
+
+	ch, err := th.ClientHelper()
+	if err != nil {
+		return mimo.TerminalError(err)
+	}
+
+	cv := &configv1.ClusterVersion{}
+	err = ch.GetOne(ctx, types.NamespacedName{Name: "version"}, cv)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return mimo.TerminalError(err)
+		}
+		return mimo.TransientError(err)
+	}
+
+	if version.IsClusterUpgrading(cv) {
+		th.SetResultMessage("an upgrade is already in progress")
+		return nil
+	}
+
+	current, err := version.GetClusterVersion(cv)
+	if err != nil {
+		return mimo.TerminalError(err)
+	}
+
+	var target *configv1.Release
+	var targetVersion *version.Version
+	for i, u := range cv.Status.AvailableUpdates {
+		v, err := version.ParseVersion(u.Version)
+		if err != nil {
+			th.Log().Warnf("ignoring available update: %v", err)
+			continue
+		}
+
+		// only patch releases of the current minor version are considered
+		if v.V[0] != current.V[0] || v.V[1] != current.V[1] || !current.Lt(v) {
+			continue
+		}
+
+		if targetVersion == nil || targetVersion.Lt(v) {
+			target, targetVersion = &cv.Status.AvailableUpdates[i], v
+		}
+	}
+
+	if target == nil {
+		th.SetResultMessage(fmt.Sprintf("%s is the latest available patch release", current))
+		return nil
+	}
+
+	channel := cv.Spec.Channel
+	if channel == "" {
+		channel = "stable-" + current.MinorVersion()
+	}
+
+	err = th.UpgradeGraph().ValidateUpgrade(ctx, channel, current.String(), targetVersion.String())
+	var unsupported *cincinnati.UnsupportedUpgradeError
+	if errors.As(err, &unsupported) {
+		return mimo.TerminalError(err)
+	} else if err != nil {
+		return mimo.TransientError(err)
+	}
+
+	cv.Spec.DesiredUpdate = &configv1.Update{
+		Version: target.Version,
+		Image:   target.Image,
+	}
+
+	err = ch.Update(ctx, cv)
+	if err != nil {
+		return mimo.TransientError(err)
+	}
+
+	th.SetResultMessage(fmt.Sprintf("started upgrade from %s to %s", current, targetVersion))
+	return nil
+}
@@ -0,0 +1,296 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Azure/ARO-RP/pkg/util/cincinnati"
+	"github.com/Azure/ARO-RP/pkg/util/clienthelper"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	testtasks "github.com/Azure/ARO-RP/test/mimo/tasks"
+	testclienthelper "github.com/Azure/ARO-RP/test/util/clienthelper"
+	testlog "github.com/Azure/ARO-RP/test/util/log"
+)
+
+func TestEnsureClusterIsHealthyForUpgrade(t *testing.T) {
+	ctx := context.Background()
+
+	clusterVersion := func(failing configv1.ConditionStatus) *configv1.ClusterVersion {
+		return &configv1.ClusterVersion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "version",
+			},
+			Status: configv1.ClusterVersionStatus{
+				Conditions: []configv1.ClusterOperatorStatusCondition{
+					{
+						Type:    clusterVersionFailing,
+						Status:  failing,
+						Message: "Cluster operator etcd is degraded",
+					},
+				},
+			},
+		}
+	}
+
+	clusterOperator := func(name string, available, degraded configv1.ConditionStatus) *configv1.ClusterOperator {
+		return &configv1.ClusterOperator{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Status: configv1.ClusterOperatorStatus{
+				Conditions: []configv1.ClusterOperatorStatusCondition{
+					{
+						Type:   configv1.OperatorAvailable,
+						Status: available,
+					},
+					{
+						Type:   configv1.OperatorDegraded,
+						Status: degraded,
+					},
+				},
+			},
+		}
+	}
+
+	node := func(name string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{
+						Type:   corev1.NodeReady,
+						Status: ready,
+					},
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name    string
+		objects []runtime.Object
+		wantErr string
+	}{
+		{
+			name:    "clusterversion not found",
+			objects: []runtime.Object{},
+			wantErr: `TerminalError: clusterversions.config.openshift.io "version" not found`,
+		},
+		{
+			name: "healthy",
+			objects: []runtime.Object{
+				clusterVersion(configv1.ConditionFalse),
+				clusterOperator("etcd", configv1.ConditionTrue, configv1.ConditionFalse),
+				node("master-0", corev1.ConditionTrue),
+			},
+		},
+		{
+			name: "clusterversion failing",
+			objects: []runtime.Object{
+				clusterVersion(configv1.ConditionTrue),
+				clusterOperator("etcd", configv1.ConditionTrue, configv1.ConditionFalse),
+				node("master-0", corev1.ConditionTrue),
+			},
+			wantErr: "TransientError: ClusterVersion is failing: Cluster operator etcd is degraded",
+		},
+		{
+			name: "clusteroperator unavailable",
+			objects: []runtime.Object{
+				clusterVersion(configv1.ConditionFalse),
+				clusterOperator("etcd", configv1.ConditionFalse, configv1.ConditionFalse),
+				node("master-0", corev1.ConditionTrue),
+			},
+			wantErr: "TransientError: ClusterOperator etcd is not healthy: Available=False, Degraded=False",
+		},
+		{
+			name: "clusteroperator degraded",
+			objects: []runtime.Object{
+				clusterVersion(configv1.ConditionFalse),
+				clusterOperator("etcd", configv1.ConditionTrue, configv1.ConditionTrue),
+				node("master-0", corev1.ConditionTrue),
+			},
+			wantErr: "TransientError: ClusterOperator etcd is not healthy: Available=True, Degraded=True",
+		},
+		{
+			name: "node not ready",
+			objects: []runtime.Object{
+				clusterVersion(configv1.ConditionFalse),
+				clusterOperator("etcd", configv1.ConditionTrue, configv1.ConditionFalse),
+				node("master-0", corev1.ConditionFalse),
+			},
+			wantErr: "TransientError: node master-0 is not ready",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			controller := gomock.NewController(t)
+			_env := mock_env.NewMockInterface(controller)
+			_, log := testlog.New()
+
+			builder := fake.NewClientBuilder().WithRuntimeObjects(tt.objects...)
+			ch := clienthelper.NewWithClient(log, testclienthelper.NewHookingClient(builder.Build()))
+			tc := testtasks.NewFakeTestContext(
+				ctx, _env, log, func() time.Time { return time.Unix(100, 0) },
+				testtasks.WithClientHelper(ch),
+			)
+
+			err := EnsureClusterIsHealthyForUpgrade(tc)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestStartAutomaticPatchUpgrade(t *testing.T) {
+	ctx := context.Background()
+
+	clusterVersion := func(channel string, progressing configv1.ConditionStatus, available ...string) *configv1.ClusterVersion {
+		cv := &configv1.ClusterVersion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "version",
+			},
+			Spec: configv1.ClusterVersionSpec{
+				Channel: channel,
+			},
+			Status: configv1.ClusterVersionStatus{
+				Conditions: []configv1.ClusterOperatorStatusCondition{
+					{
+						Type:   configv1.OperatorProgressing,
+						Status: progressing,
+					},
+				},
+				History: []configv1.UpdateHistory{
+					{
+						State:   configv1.CompletedUpdate,
+						Version: "4.14.10",
+					},
+				},
+			},
+		}
+		for _, v := range available {
+			cv.Status.AvailableUpdates = append(cv.Status.AvailableUpdates, configv1.Release{
+				Version: v,
+				Image:   "quay.io/openshift-release-dev/ocp-release@sha256:" + v,
+			})
+		}
+		return cv
+	}
+
+	for _, tt := range []struct {
+		name          string
+		objects       []runtime.Object
+		graphErr      error
+		wantChannel   string
+		wantDesired   *configv1.Update
+		wantErr       string
+		wantResultMsg string
+	}{
+		{
+			name:    "clusterversion not found",
+			objects: []runtime.Object{},
+			wantErr: `TerminalError: clusterversions.config.openshift.io "version" not found`,
+		},
+		{
+			name:          "upgrade in progress",
+			objects:       []runtime.Object{clusterVersion("stable-4.14", configv1.ConditionTrue, "4.14.12")},
+			wantResultMsg: "an upgrade is already in progress",
+		},
+		{
+			name:          "no patch release available",
+			objects:       []runtime.Object{clusterVersion("stable-4.14", configv1.ConditionFalse, "4.15.2", "4.14.9")},
+			wantResultMsg: "4.14.10 is the latest available patch release",
+		},
+		{
+			name:        "upgrades to latest patch release",
+			objects:     []runtime.Object{clusterVersion("fast-4.14", configv1.ConditionFalse, "4.14.11", "4.15.2", "4.14.12")},
+			wantChannel: "fast-4.14",
+			wantDesired: &configv1.Update{
+				Version: "4.14.12",
+				Image:   "quay.io/openshift-release-dev/ocp-release@sha256:4.14.12",
+			},
+			wantResultMsg: "started upgrade from 4.14.10 to 4.14.12",
+		},
+		{
+			name:        "channel defaults to stable channel of the current version",
+			objects:     []runtime.Object{clusterVersion("", configv1.ConditionFalse, "4.14.11")},
+			wantChannel: "stable-4.14",
+			wantDesired: &configv1.Update{
+				Version: "4.14.11",
+				Image:   "quay.io/openshift-release-dev/ocp-release@sha256:4.14.11",
+			},
+			wantResultMsg: "started upgrade from 4.14.10 to 4.14.11",
+		},
+		{
+			name:    "unsupported upgrade",
+			objects: []runtime.Object{clusterVersion("stable-4.14", configv1.ConditionFalse, "4.14.12")},
+			graphErr: &cincinnati.UnsupportedUpgradeError{
+				Channel: "stable-4.14",
+				From:    "4.14.10",
+				To:      "4.14.12",
+				Reason:  "the update graph has no such edge",
+			},
+			wantChannel: "stable-4.14",
+			wantErr:     "TerminalError: upgrade from 4.14.10 to 4.14.12 is not supported in channel stable-4.14: the update graph has no such edge",
+		},
+		{
+			name:        "graph unavailable",
+			objects:     []runtime.Object{clusterVersion("stable-4.14", configv1.ConditionFalse, "4.14.12")},
+			graphErr:    errors.New("unexpected status code 503"),
+			wantChannel: "stable-4.14",
+			wantErr:     "TransientError: unexpected status code 503",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			controller := gomock.NewController(t)
+			_env := mock_env.NewMockInterface(controller)
+			_, log := testlog.New()
+
+			builder := fake.NewClientBuilder().WithRuntimeObjects(tt.objects...)
+			ch := clienthelper.NewWithClient(log, testclienthelper.NewHookingClient(builder.Build()))
+			graph := &fakeUpgradeGraph{err: tt.graphErr}
+			tc := testtasks.NewFakeTestContext(
+				ctx, _env, log, func() time.Time { return time.Unix(100, 0) },
+				testtasks.WithClientHelper(ch),
+				testtasks.WithUpgradeGraph(graph),
+			)
+
+			err := StartAutomaticPatchUpgrade(tc)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(tc.GetResultMessage()).To(Equal(tt.wantResultMsg))
+			}
+
+			g.Expect(graph.channel).To(Equal(tt.wantChannel))
+
+			if len(tt.objects) > 0 {
+				cv := &configv1.ClusterVersion{}
+				err = ch.GetOne(ctx, types.NamespacedName{Name: "version"}, cv)
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(cv.Spec.DesiredUpdate).To(Equal(tt.wantDesired))
+			}
+		})
+	}
+}
@@ -10,12 +10,14 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 
+	pkgoperator "github.com/Azure/ARO-RP/pkg/operator"
 	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
 	"github.com/Azure/ARO-RP/pkg/util/mimo"
 )
 
 // UpdateClusterOperatorFlags updates the OperatorFlags object in the ARO
-// Cluster custom resource.
+// Cluster custom resource, including the environment's Geneva logging sidecar
+// images.
 func UpdateClusterOperatorFlags(ctx context.Context) error {
 	th, err := mimo.GetTaskContext(ctx)
 	if err != nil {
@@ -29,6 +31,13 @@ func UpdateClusterOperatorFlags(ctx context.Context) error {
 		return mimo.TerminalError(err)
 	}
 
+	sidecarImages, err := th.Environment().LiveConfig().SidecarImages(ctx)
+	if err != nil {
+		return mimo.TransientError(err)
+	}
+
+	flags := pkgoperator.WithSidecarImages(props.OperatorFlags, th.Environment().ACRDomain(), sidecarImages)
+
 	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		clusterObj := &arov1alpha1.Cluster{}
 
@@ -41,7 +50,7 @@ func UpdateClusterOperatorFlags(ctx context.Context) error {
 			return mimo.TransientError(err)
 		}
 
-		clusterObj.Spec.OperatorFlags = arov1alpha1.OperatorFlags(props.OperatorFlags)
+		clusterObj.Spec.OperatorFlags = arov1alpha1.OperatorFlags(flags)
 
 		err = ch.Update(ctx, clusterObj)
 		if err != nil {
@@ -22,9 +22,11 @@ import (
 	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
 	"github.com/Azure/ARO-RP/pkg/util/clienthelper"
 	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	"github.com/Azure/ARO-RP/pkg/util/version"
 	testtasks "github.com/Azure/ARO-RP/test/mimo/tasks"
 	testclienthelper "github.com/Azure/ARO-RP/test/util/clienthelper"
 	testlog "github.com/Azure/ARO-RP/test/util/log"
+	testliveconfig "github.com/Azure/ARO-RP/test/util/testliveconfig"
 )
 
 func TestOperatorFlags(t *testing.T) {
@@ -68,8 +70,10 @@ func TestOperatorFlags(t *testing.T) {
 					},
 					Spec: arov1alpha1.ClusterSpec{
 						OperatorFlags: arov1alpha1.OperatorFlags{
-							"foo": "baz",
-							"gaz": "data",
+							"foo":                                  "baz",
+							"gaz":                                  "data",
+							"aro.genevalogging.fluentbit.pullSpec": "acrdomain/" + version.DefaultSidecarImages.Fluentbit,
+							"aro.genevalogging.mdsd.pullSpec":      "acrdomain/" + version.DefaultSidecarImages.Mdsd,
 						},
 					},
 				},
@@ -80,6 +84,8 @@ func TestOperatorFlags(t *testing.T) {
 			g := NewWithT(t)
 			controller := gomock.NewController(t)
 			_env := mock_env.NewMockInterface(controller)
+			_env.EXPECT().LiveConfig().AnyTimes().Return(testliveconfig.NewTestLiveConfig(false, false))
+			_env.EXPECT().ACRDomain().AnyTimes().Return("acrdomain")
 			_, log := testlog.New()
 
 			ocDoc := &api.OpenShiftClusterDocument{
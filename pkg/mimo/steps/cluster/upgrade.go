@@ -0,0 +1,75 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/Azure/ARO-RP/pkg/util/cincinnati"
+	"github.com/Azure/ARO-RP/pkg/util/mimo"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+// EnsureUpgradeIsSupported validates the upgrade requested in the
+// ClusterVersion's desired update against the update graph, and fails the task
+// with the reason if the graph does not offer it.
+func EnsureUpgradeIsSupported(ctx context.Context) error {
+	th, err := mimo.GetTaskContext(ctx)
+	if err != nil {
+		return mimo.TerminalError(err)
+	}
+
+	ch, err := th.ClientHelper()
+	if err != nil {
+		return mimo.TerminalError(err)
+	}
+
+	cv := &configv1.ClusterVersion{}
+
+	err = ch.GetOne(ctx, types.NamespacedName{Name: "version"}, cv)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return mimo.TerminalError(err)
+		}
+
+		return mimo.TransientError(err)
+	}
+
+	if cv.Spec.DesiredUpdate == nil || cv.Spec.DesiredUpdate.Version == "" {
+		th.SetResultMessage("no upgrade requested")
+		return nil
+	}
+
+	from, err := version.GetClusterVersion(cv)
+	if err != nil {
+		return mimo.TerminalError(err)
+	}
+
+	to, err := version.ParseVersion(cv.Spec.DesiredUpdate.Version)
+	if err != nil {
+		return mimo.TerminalError(err)
+	}
+
+	channel := cv.Spec.Channel
+	if channel == "" {
+		channel = "stable-" + to.MinorVersion()
+	}
+
+	err = th.UpgradeGraph().ValidateUpgrade(ctx, channel, from.String(), to.String())
+	var unsupported *cincinnati.UnsupportedUpgradeError
+	if errors.As(err, &unsupported) {
+		return mimo.TerminalError(err)
+	} else if err != nil {
+		return mimo.TransientError(err)
+	}
+
+	th.SetResultMessage(fmt.Sprintf("upgrade from %s to %s is supported", from, to))
+	return nil
+}
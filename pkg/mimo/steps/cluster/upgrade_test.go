@@ -0,0 +1,142 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Azure/ARO-RP/pkg/util/cincinnati"
+	"github.com/Azure/ARO-RP/pkg/util/clienthelper"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	testtasks "github.com/Azure/ARO-RP/test/mimo/tasks"
+	testclienthelper "github.com/Azure/ARO-RP/test/util/clienthelper"
+	testlog "github.com/Azure/ARO-RP/test/util/log"
+)
+
+type fakeUpgradeGraph struct {
+	channel string
+	from    string
+	to      string
+	err     error
+}
+
+func (g *fakeUpgradeGraph) ValidateUpgrade(ctx context.Context, channel, from, to string) error {
+	g.channel, g.from, g.to = channel, from, to
+	return g.err
+}
+
+func TestEnsureUpgradeIsSupported(t *testing.T) {
+	ctx := context.Background()
+
+	clusterVersion := func(channel, desired string) *configv1.ClusterVersion {
+		cv := &configv1.ClusterVersion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "version",
+			},
+			Spec: configv1.ClusterVersionSpec{
+				Channel: channel,
+			},
+			Status: configv1.ClusterVersionStatus{
+				History: []configv1.UpdateHistory{
+					{
+						State:   configv1.CompletedUpdate,
+						Version: "4.14.10",
+					},
+				},
+			},
+		}
+		if desired != "" {
+			cv.Spec.DesiredUpdate = &configv1.Update{Version: desired}
+		}
+		return cv
+	}
+
+	for _, tt := range []struct {
+		name          string
+		objects       []runtime.Object
+		graphErr      error
+		wantChannel   string
+		wantErr       string
+		wantResultMsg string
+	}{
+		{
+			name:    "clusterversion not found",
+			objects: []runtime.Object{},
+			wantErr: `TerminalError: clusterversions.config.openshift.io "version" not found`,
+		},
+		{
+			name:          "no upgrade requested",
+			objects:       []runtime.Object{clusterVersion("stable-4.14", "")},
+			wantResultMsg: "no upgrade requested",
+		},
+		{
+			name:          "supported upgrade",
+			objects:       []runtime.Object{clusterVersion("fast-4.14", "4.14.12")},
+			wantChannel:   "fast-4.14",
+			wantResultMsg: "upgrade from 4.14.10 to 4.14.12 is supported",
+		},
+		{
+			name:          "channel defaults to stable channel of the target",
+			objects:       []runtime.Object{clusterVersion("", "4.15.2")},
+			wantChannel:   "stable-4.15",
+			wantResultMsg: "upgrade from 4.14.10 to 4.15.2 is supported",
+		},
+		{
+			name:    "unsupported upgrade",
+			objects: []runtime.Object{clusterVersion("stable-4.14", "4.14.12")},
+			graphErr: &cincinnati.UnsupportedUpgradeError{
+				Channel: "stable-4.14",
+				From:    "4.14.10",
+				To:      "4.14.12",
+				Reason:  "the update graph has no such edge",
+			},
+			wantChannel: "stable-4.14",
+			wantErr:     "TerminalError: upgrade from 4.14.10 to 4.14.12 is not supported in channel stable-4.14: the update graph has no such edge",
+		},
+		{
+			name:        "graph unavailable",
+			objects:     []runtime.Object{clusterVersion("stable-4.14", "4.14.12")},
+			graphErr:    errors.New("unexpected status code 503"),
+			wantChannel: "stable-4.14",
+			wantErr:     "TransientError: unexpected status code 503",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			controller := gomock.NewController(t)
+			_env := mock_env.NewMockInterface(controller)
+			_, log := testlog.New()
+
+			builder := fake.NewClientBuilder().WithRuntimeObjects(tt.objects...)
+			ch := clienthelper.NewWithClient(log, testclienthelper.NewHookingClient(builder.Build()))
+			graph := &fakeUpgradeGraph{err: tt.graphErr}
+			tc := testtasks.NewFakeTestContext(
+				ctx, _env, log, func() time.Time { return time.Unix(100, 0) },
+				testtasks.WithClientHelper(ch),
+				testtasks.WithUpgradeGraph(graph),
+			)
+
+			err := EnsureUpgradeIsSupported(tc)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(tc.GetResultMessage()).To(Equal(tt.wantResultMsg))
+			}
+
+			g.Expect(graph.channel).To(Equal(tt.wantChannel))
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package tasks
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/mimo/steps/cluster"
+	"github.com/Azure/ARO-RP/pkg/util/mimo"
+	"github.com/Azure/ARO-RP/pkg/util/steps"
+)
+
+// AutomaticPatchUpgrade is scheduled by the actuator within the maintenance
+// windows of clusters which have opted in to automatic patch upgrades.
+func AutomaticPatchUpgrade(t mimo.TaskContext, doc *api.MaintenanceManifestDocument, oc *api.OpenShiftClusterDocument) error {
+	s := []steps.Step{
+		steps.Action(cluster.EnsureAPIServerIsUp),
+
+		steps.Action(cluster.EnsureClusterIsHealthyForUpgrade),
+		steps.Action(cluster.StartAutomaticPatchUpgrade),
+	}
+
+	return run(t, s)
+}
@@ -15,9 +15,10 @@ const DEFAULT_POLL_TIME = time.Second * 10
 const DEFAULT_TIMEOUT_DURATION = time.Minute * 20
 
 var DEFAULT_MAINTENANCE_TASKS = map[string]MaintenanceTask{
-	mimo.TLS_CERT_ROTATION_ID:     TLSCertRotation,
-	mimo.ACR_TOKEN_CHECKER_ID:     ACRTokenChecker,
-	mimo.OPERATOR_FLAGS_UPDATE_ID: UpdateOperatorFlags,
+	mimo.TLS_CERT_ROTATION_ID:       TLSCertRotation,
+	mimo.ACR_TOKEN_CHECKER_ID:       ACRTokenChecker,
+	mimo.OPERATOR_FLAGS_UPDATE_ID:   UpdateOperatorFlags,
+	mimo.AUTOMATIC_PATCH_UPGRADE_ID: AutomaticPatchUpgrade,
 }
 
 func run(t utilmimo.TaskContext, s []steps.Step) error {
@@ -0,0 +1,256 @@
+package mirror
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/containers/image/v5/types"
+	"github.com/sirupsen/logrus"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// CatalogConfig lists the OLM catalog indexes to mirror, and which operator
+// packages and channels to mirror from each.
+type CatalogConfig struct {
+	Catalogs []Catalog `json:"catalogs"`
+}
+
+// Catalog is an OLM catalog index image and the packages to mirror from it.
+type Catalog struct {
+	Index    string           `json:"index"`
+	Packages []CatalogPackage `json:"packages"`
+}
+
+// CatalogPackage is an operator package to mirror.  If no channels are
+// listed, the package's default channel is mirrored.
+type CatalogPackage struct {
+	Name     string   `json:"name"`
+	Channels []string `json:"channels,omitempty"`
+}
+
+// ReadCatalogConfig reads a CatalogConfig from a YAML file.
+func ReadCatalogConfig(path string) (*CatalogConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config *CatalogConfig
+	err = yaml.Unmarshal(b, &config)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// fbcObject holds the fields of the file-based catalog olm.package,
+// olm.channel and olm.bundle schemas which are needed to find a package's
+// images.
+type fbcObject struct {
+	Schema         string `json:"schema"`
+	Name           string `json:"name"`
+	Package        string `json:"package"`
+	DefaultChannel string `json:"defaultChannel"`
+	Entries        []struct {
+		Name string `json:"name"`
+	} `json:"entries"`
+	Image         string `json:"image"`
+	RelatedImages []struct {
+		Image string `json:"image"`
+	} `json:"relatedImages"`
+}
+
+// MirrorCatalog mirrors a catalog index image and the bundle and related
+// images of the configured packages.  The index itself is mirrored unchanged,
+// so it still lists packages which are not mirrored.  srcauth returns the
+// credentials to use for each source image.
+func MirrorCatalog(ctx context.Context, log *logrus.Entry, dstrepo string, catalog *Catalog, dstauth *types.DockerAuthConfig, srcauth func(reference string) *types.DockerAuthConfig, options Options) error {
+	log.Printf("reading catalog from %s", catalog.Index)
+	objects, err := readCatalog(ctx, catalog.Index, srcauth(catalog.Index))
+	if err != nil {
+		return err
+	}
+
+	images, err := catalogImages(objects, catalog.Packages)
+	if err != nil {
+		return err
+	}
+
+	works := []*work{
+		{
+			tag:          "index",
+			dstreference: Dest(dstrepo, catalog.Index),
+			srcreference: catalog.Index,
+			dstauth:      dstauth,
+			srcauth:      srcauth(catalog.Index),
+		},
+	}
+
+	for _, image := range images {
+		works = append(works, &work{
+			tag:          image,
+			dstreference: Dest(dstrepo, image),
+			srcreference: image,
+			dstauth:      dstauth,
+			srcauth:      srcauth(image),
+		})
+	}
+
+	log.Printf("mirroring %d image(s) with %d worker(s)", len(works), options.Workers)
+
+	return mirror(ctx, log, works, options, Copy)
+}
+
+// readCatalog returns the objects of the file-based catalog stored under
+// /configs in a catalog index image.
+func readCatalog(ctx context.Context, index string, auth *types.DockerAuthConfig) ([]fbcObject, error) {
+	files := map[string][]byte{}
+
+	err := walkLayers(ctx, index, auth, false, func(h *tar.Header, r io.Reader) error {
+		name := strings.TrimPrefix(h.Name, "./")
+		if h.Typeflag != tar.TypeReg || !strings.HasPrefix(name, "configs/") {
+			return nil
+		}
+
+		switch path.Ext(name) {
+		case ".json", ".yaml", ".yml":
+		default:
+			return nil
+		}
+
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+
+		// files in upper layers replace those in lower layers
+		files[name] = b
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("file-based catalog not found in %s", index)
+	}
+
+	var objects []fbcObject
+	for _, b := range files {
+		o, err := parseCatalog(b)
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, o...)
+	}
+
+	return objects, nil
+}
+
+// parseCatalog parses a stream of JSON or YAML file-based catalog objects.
+func parseCatalog(b []byte) ([]fbcObject, error) {
+	var objects []fbcObject
+
+	dec := kyaml.NewYAMLOrJSONDecoder(bytes.NewReader(b), 4096)
+	for {
+		var o fbcObject
+		err := dec.Decode(&o)
+		if err == io.EOF {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, o)
+	}
+}
+
+// catalogImages returns the sorted bundle and related images of the bundles in
+// the requested channels of the requested packages.
+func catalogImages(objects []fbcObject, packages []CatalogPackage) ([]string, error) {
+	defaultChannels := map[string]string{}
+	channels := map[string]map[string][]string{}
+	bundles := map[string]map[string]*fbcObject{}
+
+	for i := range objects {
+		o := &objects[i]
+
+		switch o.Schema {
+		case "olm.package":
+			defaultChannels[o.Name] = o.DefaultChannel
+
+		case "olm.channel":
+			if channels[o.Package] == nil {
+				channels[o.Package] = map[string][]string{}
+			}
+			for _, entry := range o.Entries {
+				channels[o.Package][o.Name] = append(channels[o.Package][o.Name], entry.Name)
+			}
+
+		case "olm.bundle":
+			if bundles[o.Package] == nil {
+				bundles[o.Package] = map[string]*fbcObject{}
+			}
+			bundles[o.Package][o.Name] = o
+		}
+	}
+
+	images := map[string]struct{}{}
+
+	for _, pkg := range packages {
+		defaultChannel, found := defaultChannels[pkg.Name]
+		if !found {
+			return nil, fmt.Errorf("package %q not found in catalog", pkg.Name)
+		}
+
+		wantChannels := pkg.Channels
+		if len(wantChannels) == 0 {
+			wantChannels = []string{defaultChannel}
+		}
+
+		for _, channel := range wantChannels {
+			entries, found := channels[pkg.Name][channel]
+			if !found {
+				return nil, fmt.Errorf("channel %q of package %q not found in catalog", channel, pkg.Name)
+			}
+
+			for _, entry := range entries {
+				bundle, found := bundles[pkg.Name][entry]
+				if !found {
+					return nil, fmt.Errorf("bundle %q of package %q not found in catalog", entry, pkg.Name)
+				}
+
+				if bundle.Image != "" {
+					images[bundle.Image] = struct{}{}
+				}
+				for _, relatedImage := range bundle.RelatedImages {
+					if relatedImage.Image != "" {
+						images[relatedImage.Image] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(images))
+	for image := range images {
+		sorted = append(sorted, image)
+	}
+	sort.Strings(sorted)
+
+	return sorted, nil
+}
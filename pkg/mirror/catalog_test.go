@@ -0,0 +1,96 @@
+package mirror
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"reflect"
+	"testing"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestCatalogImages(t *testing.T) {
+	jsonCatalog := []byte(`{"schema": "olm.package", "name": "logging", "defaultChannel": "stable"}
+{"schema": "olm.channel", "package": "logging", "name": "stable", "entries": [{"name": "logging.v2"}]}
+{"schema": "olm.channel", "package": "logging", "name": "candidate", "entries": [{"name": "logging.v2"}, {"name": "logging.v3"}]}
+{"schema": "olm.bundle", "package": "logging", "name": "logging.v2", "image": "registry.redhat.io/logging/bundle@sha256:2", "relatedImages": [{"name": "operator", "image": "registry.redhat.io/logging/operator@sha256:2"}]}
+{"schema": "olm.bundle", "package": "logging", "name": "logging.v3", "image": "registry.redhat.io/logging/bundle@sha256:3", "relatedImages": [{"name": "operator", "image": "registry.redhat.io/logging/operator@sha256:3"}]}
+`)
+
+	yamlCatalog := []byte(`---
+schema: olm.package
+name: mesh
+defaultChannel: stable
+---
+schema: olm.channel
+package: mesh
+name: stable
+entries:
+- name: mesh.v1
+---
+schema: olm.bundle
+package: mesh
+name: mesh.v1
+image: quay.io/mesh/bundle@sha256:1
+relatedImages:
+- image: quay.io/mesh/bundle@sha256:1
+- image: registry.redhat.io/mesh/proxy@sha256:1
+`)
+
+	var objects []fbcObject
+	for _, b := range [][]byte{jsonCatalog, yamlCatalog} {
+		o, err := parseCatalog(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		objects = append(objects, o...)
+	}
+
+	for _, tt := range []struct {
+		name       string
+		packages   []CatalogPackage
+		wantImages []string
+		wantErr    string
+	}{
+		{
+			name:     "default channels",
+			packages: []CatalogPackage{{Name: "logging"}, {Name: "mesh"}},
+			wantImages: []string{
+				"quay.io/mesh/bundle@sha256:1",
+				"registry.redhat.io/logging/bundle@sha256:2",
+				"registry.redhat.io/logging/operator@sha256:2",
+				"registry.redhat.io/mesh/proxy@sha256:1",
+			},
+		},
+		{
+			name:     "listed channels",
+			packages: []CatalogPackage{{Name: "logging", Channels: []string{"stable", "candidate"}}},
+			wantImages: []string{
+				"registry.redhat.io/logging/bundle@sha256:2",
+				"registry.redhat.io/logging/bundle@sha256:3",
+				"registry.redhat.io/logging/operator@sha256:2",
+				"registry.redhat.io/logging/operator@sha256:3",
+			},
+		},
+		{
+			name:     "missing package",
+			packages: []CatalogPackage{{Name: "missing"}},
+			wantErr:  `package "missing" not found in catalog`,
+		},
+		{
+			name:     "missing channel",
+			packages: []CatalogPackage{{Name: "mesh", Channels: []string{"fast"}}},
+			wantErr:  `channel "fast" of package "mesh" not found in catalog`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			images, err := catalogImages(objects, tt.packages)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(images, tt.wantImages) {
+				t.Error(images)
+			}
+		})
+	}
+}
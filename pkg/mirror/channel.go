@@ -0,0 +1,252 @@
+package mirror
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/containers/image/v5/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+// rxChannel matches an upgrade channel such as stable-4.14, optionally
+// qualified with the architecture of its releases, e.g. multi/stable-4.14.
+// Channels are of amd64 releases unless qualified.
+var rxChannel = regexp.MustCompile(`^(?:(amd64|arm64|multi)/)?([a-z]+-(\d+\.\d+))$`)
+
+// MirrorState records the releases mirrored from each upgrade channel, so
+// that each run only mirrors the releases which are new since the last run.
+type MirrorState struct {
+	Channels map[string]*ChannelState `json:"channels"`
+}
+
+// ChannelState records the releases mirrored from an upgrade channel.
+type ChannelState struct {
+	LastRun  time.Time      `json:"lastRun"`
+	Releases []ReleaseState `json:"releases"`
+}
+
+// ReleaseState is the result of mirroring a release.  Version,
+// OpenShiftPullspec and MultiArchPullspec have the names of the corresponding
+// properties of the admin OpenShiftVersion API, so that mirrored releases can
+// be added to it.  As in that API, they are the upstream pullspecs of the
+// release; MultiArchPullspec is set instead of OpenShiftPullspec for releases
+// of multi/ channels.
+type ReleaseState struct {
+	Version           string     `json:"version"`
+	OpenShiftPullspec string     `json:"openShiftPullspec,omitempty"`
+	MultiArchPullspec string     `json:"multiArchPullspec,omitempty"`
+	MirroredAt        *time.Time `json:"mirroredAt,omitempty"`
+	Error             string     `json:"error,omitempty"`
+}
+
+// ReadMirrorState reads a MirrorState from a JSON file, returning an empty
+// MirrorState if the file does not exist.
+func ReadMirrorState(path string) (*MirrorState, error) {
+	state := &MirrorState{}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(b, state)
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// WriteMirrorState writes a MirrorState to a JSON file.
+func WriteMirrorState(path string, state *MirrorState) error {
+	b, err := json.MarshalIndent(state, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+// parseChannel returns the architecture, name and minor version of an
+// upgrade channel
+func parseChannel(channel string) (version.Architecture, string, string, error) {
+	m := rxChannel.FindStringSubmatch(channel)
+	if m == nil {
+		return "", "", "", fmt.Errorf("invalid channel %q", channel)
+	}
+
+	arch := version.ArchitectureAMD64
+	if m[1] != "" {
+		arch = version.Architecture(m[1])
+	}
+
+	return arch, m[2], m[3], nil
+}
+
+// ChannelReleases returns the z-stream releases in an upgrade channel, such
+// as stable-4.14 or multi/stable-4.14.  Releases of earlier minor versions
+// which the channel offers upgrades from are not included.
+func ChannelReleases(channel string) ([]Node, error) {
+	arch, name, minor, err := parseChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := getGraph("https://api.openshift.com/api/upgrades_info/v1/graph?arch=" + url.QueryEscape(string(arch)) + "&channel=" + url.QueryEscape(name))
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []Node
+	for _, node := range g.Nodes {
+		vsn, err := version.ParseVersion(node.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		if vsn.MinorVersion() != minor || vsn.Suffix != "" {
+			continue
+		}
+
+		releases = append(releases, node)
+	}
+
+	return releases, nil
+}
+
+type channelMirrorer struct {
+	log     *logrus.Entry
+	dstrepo string
+	dstauth *types.DockerAuthConfig
+	srcauth *types.DockerAuthConfig
+	options Options
+
+	channelReleases func(string) ([]Node, error)
+	mirror          func(ctx context.Context, log *logrus.Entry, dstrepo, srcrelease string, dstauth, srcauth *types.DockerAuthConfig, options Options) error
+	now             func() time.Time
+}
+
+// MirrorChannels mirrors the releases in the given upgrade channels which
+// state does not record as mirrored, and records the results in state.
+// Releases which failed to mirror are retried on the next run.
+func MirrorChannels(ctx context.Context, log *logrus.Entry, dstrepo string, channels []string, state *MirrorState, dstauth, srcauth *types.DockerAuthConfig, options Options) error {
+	m := &channelMirrorer{
+		log:     log,
+		dstrepo: dstrepo,
+		dstauth: dstauth,
+		srcauth: srcauth,
+		options: options,
+
+		channelReleases: ChannelReleases,
+		mirror:          Mirror,
+		now:             time.Now,
+	}
+
+	return m.mirrorChannels(ctx, channels, state)
+}
+
+func (m *channelMirrorer) mirrorChannels(ctx context.Context, channels []string, state *MirrorState) error {
+	if state.Channels == nil {
+		state.Channels = map[string]*ChannelState{}
+	}
+
+	var errs []error
+	for _, channel := range channels {
+		err := m.mirrorChannel(ctx, channel, state)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", channel, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (m *channelMirrorer) mirrorChannel(ctx context.Context, channel string, state *MirrorState) error {
+	arch, _, _, err := parseChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	m.log.Printf("reading channel %s", channel)
+	releases, err := m.channelReleases(channel)
+	if err != nil {
+		return err
+	}
+
+	channelState := state.Channels[channel]
+	if channelState == nil {
+		channelState = &ChannelState{}
+		state.Channels[channel] = channelState
+	}
+	channelState.LastRun = m.now().UTC()
+
+	mirrored := map[string]int{}
+	for i, release := range channelState.Releases {
+		mirrored[release.Version] = i
+	}
+
+	var failed int
+	for _, release := range releases {
+		i, found := mirrored[release.Version]
+		if found && channelState.Releases[i].Error == "" {
+			continue
+		}
+
+		m.log.Printf("mirroring release %s", release.Version)
+
+		releaseState := ReleaseState{
+			Version: release.Version,
+		}
+		if arch == version.ArchitectureMulti {
+			releaseState.MultiArchPullspec = release.Payload
+		} else {
+			releaseState.OpenShiftPullspec = release.Payload
+		}
+
+		err := m.mirror(ctx, m.log, m.dstrepo, release.Payload, m.dstauth, m.srcauth, m.options)
+		if err != nil {
+			releaseState.Error = err.Error()
+			failed++
+		} else {
+			mirroredAt := m.now().UTC()
+			releaseState.MirroredAt = &mirroredAt
+		}
+
+		if found {
+			channelState.Releases[i] = releaseState
+		} else {
+			mirrored[release.Version] = len(channelState.Releases)
+			channelState.Releases = append(channelState.Releases, releaseState)
+		}
+	}
+
+	sort.Slice(channelState.Releases, func(i, j int) bool {
+		vi, erri := version.ParseVersion(channelState.Releases[i].Version)
+		vj, errj := version.ParseVersion(channelState.Releases[j].Version)
+		if erri != nil || errj != nil {
+			return channelState.Releases[i].Version < channelState.Releases[j].Version
+		}
+		return vi.Lt(vj)
+	})
+
+	if failed > 0 {
+		return fmt.Errorf("failed to mirror %d release(s)", failed)
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package mirror
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/containers/image/v5/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/util/version"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestMirrorChannels(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	earlier := now.Add(-24 * time.Hour)
+
+	channels := map[string][]Node{
+		"stable-4.14": {
+			{Version: "4.14.2", Payload: "quay.io/ocp-release@sha256:2"},
+			{Version: "4.14.1", Payload: "quay.io/ocp-release@sha256:1"},
+			{Version: "4.14.3", Payload: "quay.io/ocp-release@sha256:3"},
+		},
+		"multi/stable-4.14": {
+			{Version: "4.14.1", Payload: "quay.io/ocp-release@sha256:m1"},
+		},
+	}
+
+	for _, tt := range []struct {
+		name         string
+		channel      string
+		state        *MirrorState
+		failPayloads map[string]bool
+		wantMirrored []string
+		wantState    *MirrorState
+		wantErr      string
+	}{
+		{
+			name:         "first run mirrors every release",
+			channel:      "stable-4.14",
+			state:        &MirrorState{},
+			wantMirrored: []string{"quay.io/ocp-release@sha256:2", "quay.io/ocp-release@sha256:1", "quay.io/ocp-release@sha256:3"},
+			wantState: &MirrorState{
+				Channels: map[string]*ChannelState{
+					"stable-4.14": {
+						LastRun: now,
+						Releases: []ReleaseState{
+							{Version: "4.14.1", OpenShiftPullspec: "quay.io/ocp-release@sha256:1", MirroredAt: &now},
+							{Version: "4.14.2", OpenShiftPullspec: "quay.io/ocp-release@sha256:2", MirroredAt: &now},
+							{Version: "4.14.3", OpenShiftPullspec: "quay.io/ocp-release@sha256:3", MirroredAt: &now},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "mirrors new and previously failed releases",
+			channel: "stable-4.14",
+			state: &MirrorState{
+				Channels: map[string]*ChannelState{
+					"stable-4.14": {
+						LastRun: earlier,
+						Releases: []ReleaseState{
+							{Version: "4.14.1", OpenShiftPullspec: "quay.io/ocp-release@sha256:1", MirroredAt: &earlier},
+							{Version: "4.14.2", OpenShiftPullspec: "quay.io/ocp-release@sha256:2", Error: "failed"},
+						},
+					},
+				},
+			},
+			failPayloads: map[string]bool{"quay.io/ocp-release@sha256:3": true},
+			wantMirrored: []string{"quay.io/ocp-release@sha256:2", "quay.io/ocp-release@sha256:3"},
+			wantState: &MirrorState{
+				Channels: map[string]*ChannelState{
+					"stable-4.14": {
+						LastRun: now,
+						Releases: []ReleaseState{
+							{Version: "4.14.1", OpenShiftPullspec: "quay.io/ocp-release@sha256:1", MirroredAt: &earlier},
+							{Version: "4.14.2", OpenShiftPullspec: "quay.io/ocp-release@sha256:2", MirroredAt: &now},
+							{Version: "4.14.3", OpenShiftPullspec: "quay.io/ocp-release@sha256:3", Error: "copy failed"},
+						},
+					},
+				},
+			},
+			wantErr: "stable-4.14: failed to mirror 1 release(s)",
+		},
+		{
+			name:         "multi-architecture channel records multi-architecture pullspecs",
+			channel:      "multi/stable-4.14",
+			state:        &MirrorState{},
+			wantMirrored: []string{"quay.io/ocp-release@sha256:m1"},
+			wantState: &MirrorState{
+				Channels: map[string]*ChannelState{
+					"multi/stable-4.14": {
+						LastRun: now,
+						Releases: []ReleaseState{
+							{Version: "4.14.1", MultiArchPullspec: "quay.io/ocp-release@sha256:m1", MirroredAt: &now},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid channel",
+			channel: "multi/4.14",
+			state:   &MirrorState{},
+			wantState: &MirrorState{
+				Channels: map[string]*ChannelState{},
+			},
+			wantErr: `multi/4.14: invalid channel "multi/4.14"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var mirrored []string
+
+			m := &channelMirrorer{
+				log:     logrus.NewEntry(logrus.StandardLogger()),
+				dstrepo: "acr.io",
+				channelReleases: func(channel string) ([]Node, error) {
+					return channels[channel], nil
+				},
+				mirror: func(ctx context.Context, log *logrus.Entry, dstrepo, srcrelease string, dstauth, srcauth *types.DockerAuthConfig, options Options) error {
+					mirrored = append(mirrored, srcrelease)
+					if tt.failPayloads[srcrelease] {
+						return errors.New("copy failed")
+					}
+					return nil
+				},
+				now: func() time.Time { return now },
+			}
+
+			err := m.mirrorChannels(ctx, []string{tt.channel}, tt.state)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(mirrored, tt.wantMirrored) {
+				t.Error(mirrored)
+			}
+
+			if !reflect.DeepEqual(tt.state, tt.wantState) {
+				if channelState := tt.state.Channels[tt.channel]; channelState != nil {
+					for _, r := range channelState.Releases {
+						t.Errorf("%#v", r)
+					}
+				} else {
+					t.Error(tt.state)
+				}
+			}
+		})
+	}
+}
+
+func TestParseChannel(t *testing.T) {
+	for _, tt := range []struct {
+		channel   string
+		wantArch  version.Architecture
+		wantName  string
+		wantMinor string
+		wantErr   string
+	}{
+		{
+			channel:   "stable-4.14",
+			wantArch:  version.ArchitectureAMD64,
+			wantName:  "stable-4.14",
+			wantMinor: "4.14",
+		},
+		{
+			channel:   "multi/fast-4.15",
+			wantArch:  version.ArchitectureMulti,
+			wantName:  "fast-4.15",
+			wantMinor: "4.15",
+		},
+		{
+			channel:   "arm64/eus-4.16",
+			wantArch:  version.ArchitectureARM64,
+			wantName:  "eus-4.16",
+			wantMinor: "4.16",
+		},
+		{
+			channel: "ppc64le/stable-4.14",
+			wantErr: `invalid channel "ppc64le/stable-4.14"`,
+		},
+	} {
+		t.Run(tt.channel, func(t *testing.T) {
+			arch, name, minor, err := parseChannel(tt.channel)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if arch != tt.wantArch || name != tt.wantName || minor != tt.wantMinor {
+				t.Errorf("got %q, %q, %q", arch, name, minor)
+			}
+		})
+	}
+}
+
+func TestMirrorStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	state, err := ReadMirrorState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(state, &MirrorState{}) {
+		t.Fatal(state)
+	}
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	state.Channels = map[string]*ChannelState{
+		"stable-4.14": {
+			LastRun: now,
+			Releases: []ReleaseState{
+				{Version: "4.14.1", OpenShiftPullspec: "quay.io/ocp-release@sha256:1", MirroredAt: &now},
+			},
+		},
+	}
+
+	err = WriteMirrorState(path, state)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := ReadMirrorState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(read, state) {
+		t.Error(read)
+	}
+}
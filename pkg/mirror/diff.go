@@ -0,0 +1,249 @@
+package mirror
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/image/v5/pkg/blobinfocache/memory"
+	"github.com/containers/image/v5/types"
+	"github.com/docker/distribution/registry/api/errcode"
+	v2 "github.com/docker/distribution/registry/api/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// ImageDiff describes an image which is missing from the destination registry
+// or which differs there from its source.
+type ImageDiff struct {
+	Tag         string
+	Source      string
+	Destination string
+
+	SourceDigest string
+	// DestinationDigest is empty if the destination manifest is missing.
+	DestinationDigest string
+
+	// MissingBlobs are the config and layer blobs of the source image which
+	// are missing from the destination repository.
+	MissingBlobs []string
+}
+
+func (d *ImageDiff) String() string {
+	var sb strings.Builder
+
+	if d.DestinationDigest == "" {
+		fmt.Fprintf(&sb, "%s: manifest %s missing from %s", d.Tag, d.SourceDigest, d.Destination)
+	} else {
+		fmt.Fprintf(&sb, "%s: manifest mismatch in %s: source has %s, destination has %s", d.Tag, d.Destination, d.SourceDigest, d.DestinationDigest)
+	}
+
+	if len(d.MissingBlobs) > 0 {
+		fmt.Fprintf(&sb, "; %d blob(s) missing: %s", len(d.MissingBlobs), strings.Join(d.MissingBlobs, ", "))
+	}
+
+	return sb.String()
+}
+
+// registry reads image manifests and checks for blobs in an image registry.
+type registry interface {
+	// manifest returns the manifest of an image and its MIME type, or nil if
+	// the image does not exist.
+	manifest(ctx context.Context, reference string, auth *types.DockerAuthConfig) ([]byte, string, error)
+
+	// hasBlob returns true if the repository of the image has the blob.
+	hasBlob(ctx context.Context, reference string, auth *types.DockerAuthConfig, blob types.BlobInfo) (bool, error)
+}
+
+// Diff compares the images of a release payload with the destination registry
+// without pushing anything, and returns the images which are missing from the
+// destination or which differ from their source.
+func Diff(ctx context.Context, log *logrus.Entry, dstrepo, srcrelease string, dstauth, srcauth *types.DockerAuthConfig, options Options) ([]*ImageDiff, error) {
+	works, err := releaseWorks(ctx, log, dstrepo, srcrelease, dstauth, srcauth)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("comparing %d image(s) with %d worker(s)", len(works), options.Workers)
+
+	return diff(ctx, log, works, options, &dockerRegistry{})
+}
+
+func diff(ctx context.Context, log *logrus.Entry, works []*work, options Options, reg registry) ([]*ImageDiff, error) {
+	mu := &sync.Mutex{}
+	var diffs []*ImageDiff
+
+	failed := forEach(works, options.Workers, func(w *work) error {
+		var d *ImageDiff
+		err := withRetries(ctx, log, w, options, func() (err error) {
+			d, err = diffImage(ctx, w, reg)
+			return err
+		})
+		if err != nil {
+			log.Errorf("%s: %s", w.tag, err)
+			return err
+		}
+
+		if d != nil {
+			mu.Lock()
+			diffs = append(diffs, d)
+			mu.Unlock()
+		}
+
+		return nil
+	})
+
+	if len(failed) > 0 {
+		return nil, fmt.Errorf("failed to compare %d of %d image(s): %s", len(failed), len(works), strings.Join(failed, ", "))
+	}
+
+	return diffs, nil
+}
+
+// diffImage compares an image with its destination, and returns nil if the
+// destination has the same manifest.  Blobs are only checked when the manifest
+// differs, as a registry does not accept a manifest without its blobs.
+func diffImage(ctx context.Context, w *work, reg registry) (*ImageDiff, error) {
+	srcManifest, srcMIMEType, err := reg.manifest(ctx, w.srcreference, w.srcauth)
+	if err != nil {
+		return nil, err
+	}
+	if srcManifest == nil {
+		return nil, fmt.Errorf("source image %s not found", w.srcreference)
+	}
+
+	srcDigest, err := manifest.Digest(srcManifest)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &ImageDiff{
+		Tag:          w.tag,
+		Source:       w.srcreference,
+		Destination:  w.dstreference,
+		SourceDigest: srcDigest.String(),
+	}
+
+	dstManifest, _, err := reg.manifest(ctx, w.dstreference, w.dstauth)
+	if err != nil {
+		return nil, err
+	}
+
+	if dstManifest != nil {
+		dstDigest, err := manifest.Digest(dstManifest)
+		if err != nil {
+			return nil, err
+		}
+
+		if dstDigest == srcDigest {
+			return nil, nil
+		}
+
+		d.DestinationDigest = dstDigest.String()
+	}
+
+	// the blobs of the images of a manifest list are not compared
+	if manifest.MIMETypeIsMultiImage(srcMIMEType) {
+		return d, nil
+	}
+
+	m, err := manifest.FromBlob(srcManifest, srcMIMEType)
+	if err != nil {
+		return nil, err
+	}
+
+	blobs := []types.BlobInfo{m.ConfigInfo()}
+	for _, layer := range m.LayerInfos() {
+		blobs = append(blobs, layer.BlobInfo)
+	}
+
+	for _, blob := range blobs {
+		found, err := reg.hasBlob(ctx, w.dstreference, w.dstauth, blob)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			d.MissingBlobs = append(d.MissingBlobs, blob.Digest.String())
+		}
+	}
+
+	return d, nil
+}
+
+// dockerRegistry is a registry accessed with the docker transport.
+type dockerRegistry struct{}
+
+func (*dockerRegistry) manifest(ctx context.Context, reference string, auth *types.DockerAuthConfig) ([]byte, string, error) {
+	src, err := newImageSource(ctx, reference, auth)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+	defer src.Close()
+
+	b, mimeType, err := src.GetManifest(ctx, nil)
+	if isNotFound(err) {
+		return nil, "", nil
+	}
+
+	return b, mimeType, err
+}
+
+func (*dockerRegistry) hasBlob(ctx context.Context, reference string, auth *types.DockerAuthConfig, blob types.BlobInfo) (bool, error) {
+	src, err := newImageSource(ctx, reference, auth)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer src.Close()
+
+	// the response body is closed without being read, so the blob is not
+	// downloaded
+	rc, _, err := src.GetBlob(ctx, blob, memory.New())
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, rc.Close()
+}
+
+func newImageSource(ctx context.Context, reference string, auth *types.DockerAuthConfig) (types.ImageSource, error) {
+	ref, err := docker.ParseReference("//" + reference)
+	if err != nil {
+		return nil, err
+	}
+
+	return ref.NewImageSource(ctx, &types.SystemContext{
+		DockerAuthConfig: auth,
+	})
+}
+
+// isNotFound returns true if a registry reported that a repository, manifest
+// or blob does not exist.
+func isNotFound(err error) bool {
+	var e errcode.Error
+	if !errors.As(err, &e) {
+		return false
+	}
+
+	switch e.ErrorCode() {
+	case v2.ErrorCodeNameUnknown, v2.ErrorCodeManifestUnknown, v2.ErrorCodeBlobUnknown:
+		return true
+	}
+
+	return false
+}
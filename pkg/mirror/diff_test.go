@@ -0,0 +1,133 @@
+package mirror
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/image/v5/types"
+	"github.com/sirupsen/logrus"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+type fakeRegistry struct {
+	manifests map[string]string
+	blobs     map[string][]string
+}
+
+func (r *fakeRegistry) manifest(ctx context.Context, reference string, auth *types.DockerAuthConfig) ([]byte, string, error) {
+	m, found := r.manifests[reference]
+	if !found {
+		return nil, "", nil
+	}
+	return []byte(m), manifest.DockerV2Schema2MediaType, nil
+}
+
+func (r *fakeRegistry) hasBlob(ctx context.Context, reference string, auth *types.DockerAuthConfig, blob types.BlobInfo) (bool, error) {
+	for _, b := range r.blobs[repository(reference)] {
+		if b == blob.Digest.String() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestDiff(t *testing.T) {
+	ctx := context.Background()
+
+	digest := func(c string) string {
+		return "sha256:" + strings.Repeat(c, 64)
+	}
+
+	image := func(config string, layers ...string) string {
+		var l []string
+		for _, layer := range layers {
+			l = append(l, fmt.Sprintf(`{"mediaType": "application/vnd.docker.image.rootfs.diff.tar.gzip", "size": 1, "digest": %q}`, layer))
+		}
+		return fmt.Sprintf(`{"schemaVersion": 2, "mediaType": "application/vnd.docker.distribution.manifest.v2+json", "config": {"mediaType": "application/vnd.docker.container.image.v1+json", "size": 1, "digest": %q}, "layers": [%s]}`, config, strings.Join(l, ", "))
+	}
+
+	imageA := image(digest("1"), digest("2"), digest("3"))
+	imageB := image(digest("4"), digest("5"))
+	imageC := image(digest("6"), digest("7"))
+	imageCOld := image(digest("8"), digest("7"))
+
+	digestOf := func(m string) string {
+		d, err := manifest.Digest([]byte(m))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d.String()
+	}
+
+	works := []*work{
+		{tag: "a", srcreference: "quay.io/release/a:1", dstreference: "acr.io/release/a:1"},
+		{tag: "b", srcreference: "quay.io/release/b:1", dstreference: "acr.io/release/b:1"},
+		{tag: "c", srcreference: "quay.io/release/c:1", dstreference: "acr.io/release/c:1"},
+	}
+
+	reg := &fakeRegistry{
+		manifests: map[string]string{
+			"quay.io/release/a:1": imageA,
+			"quay.io/release/b:1": imageB,
+			"quay.io/release/c:1": imageC,
+			"acr.io/release/a:1":  imageA,
+			"acr.io/release/c:1":  imageCOld,
+		},
+		blobs: map[string][]string{
+			"acr.io/release/a": {digest("1"), digest("2"), digest("3")},
+			"acr.io/release/b": {digest("4")},
+			"acr.io/release/c": {digest("7"), digest("8")},
+		},
+	}
+
+	diffs, err := diff(ctx, logrus.NewEntry(logrus.StandardLogger()), works, Options{Workers: 1}, reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*ImageDiff{
+		{
+			Tag:          "b",
+			Source:       "quay.io/release/b:1",
+			Destination:  "acr.io/release/b:1",
+			SourceDigest: digestOf(imageB),
+			MissingBlobs: []string{digest("5")},
+		},
+		{
+			Tag:               "c",
+			Source:            "quay.io/release/c:1",
+			Destination:       "acr.io/release/c:1",
+			SourceDigest:      digestOf(imageC),
+			DestinationDigest: digestOf(imageCOld),
+			MissingBlobs:      []string{digest("6")},
+		},
+	}
+
+	if !reflect.DeepEqual(diffs, want) {
+		for _, d := range diffs {
+			t.Error(d)
+		}
+	}
+
+	wantString := fmt.Sprintf("b: manifest %s missing from acr.io/release/b:1; 1 blob(s) missing: %s", digestOf(imageB), digest("5"))
+	if diffs[0].String() != wantString {
+		t.Error(diffs[0].String())
+	}
+}
+
+func TestDiffMissingSource(t *testing.T) {
+	works := []*work{
+		{tag: "a", srcreference: "quay.io/release/a:1", dstreference: "acr.io/release/a:1"},
+	}
+
+	_, err := diff(context.Background(), logrus.NewEntry(logrus.StandardLogger()), works, Options{Workers: 1}, &fakeRegistry{})
+	utilerror.AssertErrorMessage(t, err, "failed to compare 1 of 1 image(s): a")
+}
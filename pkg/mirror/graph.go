@@ -19,13 +19,19 @@ type Node struct {
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
 
-// AddFromGraph adds all nodes whose version is of the form x.y.z (no suffix)
-// and >= min
-func AddFromGraph(min *version.Version) ([]Node, error) {
-	req, err := http.NewRequest(http.MethodGet, "https://amd64.ocp.releases.ci.openshift.org/graph", nil)
+// graph is an upgrade graph served by Cincinnati
+type graph struct {
+	Nodes []Node   `json:"nodes,omitempty"`
+	Edges [][2]int `json:"edges,omitempty"`
+}
+
+// getGraph fetches an upgrade graph.
+func getGraph(url string) (*graph, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
+	req.Header.Set("Accept", "application/json")
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -42,16 +48,25 @@ func AddFromGraph(min *version.Version) ([]Node, error) {
 		return nil, err
 	}
 
-	if mediaType != "application/vnd.redhat.cincinnati.graph+json" {
+	switch mediaType {
+	case "application/vnd.redhat.cincinnati.graph+json", "application/json":
+	default:
 		return nil, fmt.Errorf("unexpected content type %q", resp.Header.Get("Content-Type"))
 	}
 
-	var g *struct {
-		Nodes []Node   `json:"nodes,omitempty"`
-		Edges [][2]int `json:"edges,omitempty"`
+	var g *graph
+	err = json.NewDecoder(resp.Body).Decode(&g)
+	if err != nil {
+		return nil, err
 	}
 
-	err = json.NewDecoder(resp.Body).Decode(&g)
+	return g, nil
+}
+
+// AddFromGraph adds all nodes whose version is of the form x.y.z (no suffix)
+// and >= min
+func AddFromGraph(min *version.Version) ([]Node, error) {
+	g, err := getGraph("https://amd64.ocp.releases.ci.openshift.org/graph")
 	if err != nil {
 		return nil, err
 	}
@@ -10,6 +10,7 @@ import (
 	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 
@@ -19,77 +20,118 @@ import (
 	imagev1 "github.com/openshift/api/image/v1"
 )
 
-func getReleaseImageStream(ctx context.Context, reference string, auth *types.DockerAuthConfig) (*imagev1.ImageStream, error) {
+// errStopWalk is returned by a walkLayers callback to stop the walk early.
+var errStopWalk = errors.New("stop walk")
+
+// walkLayers calls fn for each file in the layers of an image, starting with
+// the top layer if reverse is set and otherwise with the bottom layer.
+func walkLayers(ctx context.Context, reference string, auth *types.DockerAuthConfig, reverse bool, fn func(*tar.Header, io.Reader) error) error {
 	systemctx := &types.SystemContext{
 		DockerAuthConfig: auth,
 	}
 
 	ref, err := docker.ParseReference("//" + reference)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	img, err := ref.NewImage(ctx, systemctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer img.Close()
 
 	src, err := ref.NewImageSource(ctx, systemctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer src.Close()
 
 	layerInfos := img.LayerInfos()
-	for i := len(layerInfos) - 1; i >= 0; i-- {
-		rc, _, err := src.GetBlob(ctx, layerInfos[i], memory.New())
+	if reverse {
+		for i, j := 0, len(layerInfos)-1; i < j; i, j = i+1, j-1 {
+			layerInfos[i], layerInfos[j] = layerInfos[j], layerInfos[i]
+		}
+	}
+
+	for _, layerInfo := range layerInfos {
+		err = walkLayer(ctx, src, layerInfo, fn)
+		if err == errStopWalk {
+			return nil
+		}
 		if err != nil {
-			return nil, err
+			return err
 		}
-		defer rc.Close()
+	}
 
-		br := bufio.NewReader(rc)
+	return nil
+}
+
+func walkLayer(ctx context.Context, src types.ImageSource, layerInfo types.BlobInfo, fn func(*tar.Header, io.Reader) error) error {
+	rc, _, err := src.GetBlob(ctx, layerInfo, memory.New())
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	br := bufio.NewReader(rc)
 
-		b, err := br.Peek(2)
+	b, err := br.Peek(2)
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader = br
+	if bytes.Equal(b, []byte("\x1f\x8b")) {
+		gr, err := gzip.NewReader(br)
 		if err != nil {
-			return nil, err
+			return err
 		}
+		defer gr.Close()
 
-		var r io.Reader = br
-		if bytes.Equal(b, []byte("\x1f\x8b")) {
-			gr, err := gzip.NewReader(br)
-			if err != nil {
-				return nil, err
-			}
-			defer gr.Close()
+		r = gr
+	}
 
-			r = gr
+	tr := tar.NewReader(r)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
 		}
 
-		tr := tar.NewReader(r)
-		for {
-			h, err := tr.Next()
-			if err == io.EOF {
-				break
-			}
+		err = fn(h, tr)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func getReleaseImageStream(ctx context.Context, reference string, auth *types.DockerAuthConfig) (*imagev1.ImageStream, error) {
+	var is *imagev1.ImageStream
+
+	err := walkLayers(ctx, reference, auth, true, func(h *tar.Header, r io.Reader) error {
+		switch h.Name {
+		case "release-manifests/image-references":
+			err := json.NewDecoder(r).Decode(&is)
 			if err != nil {
-				return nil, err
+				return err
 			}
 
-			switch h.Name {
-			case "release-manifests/image-references":
-				var is *imagev1.ImageStream
+			return errStopWalk
+		}
 
-				err = json.NewDecoder(tr).Decode(&is)
-				if err != nil {
-					return nil, err
-				}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-				return is, nil
-			}
-		}
+	if is == nil {
+		return nil, fmt.Errorf("image references not found")
 	}
 
-	return nil, fmt.Errorf("image references not found")
+	return is, nil
 }
@@ -5,14 +5,17 @@ package mirror
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/containers/image/v5/copy"
 	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/manifest"
 	"github.com/containers/image/v5/signature"
 	"github.com/containers/image/v5/types"
 	"github.com/sirupsen/logrus"
@@ -38,21 +41,56 @@ func Copy(ctx context.Context, dstreference, srcreference string, dstauth, srcau
 		return err
 	}
 
-	_, err = copy.Image(ctx, policyctx, dst, src, &copy.Options{
+	dstctx := &types.SystemContext{
+		DockerAuthConfig: dstauth,
+	}
+
+	copiedManifest, err := copy.Image(ctx, policyctx, dst, src, &copy.Options{
 		SourceCtx: &types.SystemContext{
 			DockerAuthConfig: srcauth,
 		},
-		DestinationCtx: &types.SystemContext{
-			DockerAuthConfig: dstauth,
-		},
+		DestinationCtx: dstctx,
+		// Copy every image of a manifest list, so that the images of
+		// multi-architecture release payloads can be pulled by Arm64 nodes as
+		// well as by amd64 ones, and so that a digest-pinned manifest list is
+		// mirrored to the same digest
+		ImageListSelection: copy.CopyAllImages,
 		// Images that we mirror shouldn't change, so we can use the
 		// optimisation that checks if the source and destination manifests are
 		// equal before attempting to push it (and sending no blobs because
 		// they're all already there)
 		OptimizeDestinationImageAlreadyExists: true,
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	return verifyDigest(ctx, dstctx, dst, src, copiedManifest)
+}
+
+// verifyDigest checks that the destination registry serves the manifest which
+// was copied.  If the source reference is pinned to a digest, the destination
+// must serve that digest, as clusters pull release payload images by digest.
+func verifyDigest(ctx context.Context, dstctx *types.SystemContext, dst, src types.ImageReference, copiedManifest []byte) error {
+	want, err := manifest.Digest(copiedManifest)
+	if err != nil {
+		return err
+	}
+
+	if canonical, ok := src.DockerReference().(reference.Canonical); ok {
+		want = canonical.Digest()
+	}
+
+	got, err := docker.GetDigest(ctx, dstctx, dst)
+	if err != nil {
+		return err
+	}
+
+	if got != want {
+		return fmt.Errorf("digest mismatch: destination has %s, expected %s", got, want)
+	}
+
+	return nil
 }
 
 // This will return repo and image name, preserving path
@@ -73,74 +111,217 @@ func DestLastIndex(repo, reference string) string {
 	return repo + reference[strings.LastIndex(reference, "/"):]
 }
 
-func Mirror(ctx context.Context, log *logrus.Entry, dstrepo, srcrelease string, dstauth, srcauth *types.DockerAuthConfig) error {
+// Options configures how Mirror copies the images of a release payload.
+type Options struct {
+	// Workers is the number of images which are copied concurrently.
+	Workers int
+
+	// Retries is the number of times a failed image copy is retried.
+	Retries int
+
+	// RetryInterval is the time to wait before retrying a failed image copy.
+	RetryInterval time.Duration
+
+	// ScanPolicy, if set, gates the copying of each image on the result of
+	// a vulnerability scan.
+	ScanPolicy *ScanPolicy
+
+	// ReleaseVerifier, if set, verifies the signature of each release payload
+	// before any of its images are copied.
+	ReleaseVerifier *ReleaseVerifier
+
+	// Signer, if set, signs each copied image and attaches an attestation
+	// recording its source.
+	Signer Signer
+}
+
+// DefaultOptions are the Options used by the mirror command unless
+// overridden.
+var DefaultOptions = Options{
+	Workers:       10,
+	Retries:       5,
+	RetryInterval: 10 * time.Second,
+}
+
+type work struct {
+	tag          string
+	dstreference string
+	srcreference string
+	dstauth      *types.DockerAuthConfig
+	srcauth      *types.DockerAuthConfig
+}
+
+type copyFunc func(ctx context.Context, dstreference, srcreference string, dstauth, srcauth *types.DockerAuthConfig) error
+
+func Mirror(ctx context.Context, log *logrus.Entry, dstrepo, srcrelease string, dstauth, srcauth *types.DockerAuthConfig, options Options) error {
+	if options.ReleaseVerifier != nil {
+		err := options.ReleaseVerifier.verifyRelease(ctx, log, srcrelease, srcauth)
+		if err != nil {
+			return err
+		}
+	}
+
+	works, err := releaseWorks(ctx, log, dstrepo, srcrelease, dstauth, srcauth)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("mirroring %d image(s) with %d worker(s)", len(works), options.Workers)
+
+	return mirror(ctx, log, works, options, Copy)
+}
+
+// releaseWorks returns the release payload image and the images it
+// references.
+func releaseWorks(ctx context.Context, log *logrus.Entry, dstrepo, srcrelease string, dstauth, srcauth *types.DockerAuthConfig) ([]*work, error) {
 	log.Printf("reading imagestream from %s", srcrelease)
 	is, err := getReleaseImageStream(ctx, srcrelease, srcauth)
 	if err != nil {
+		return nil, err
+	}
+
+	works := []*work{
+		{
+			tag:          "release",
+			dstreference: Dest(dstrepo, srcrelease),
+			srcreference: srcrelease,
+			dstauth:      dstauth,
+			srcauth:      srcauth,
+		},
+	}
+
+	for _, tag := range is.Spec.Tags {
+		works = append(works, &work{
+			tag:          tag.Name,
+			dstreference: Dest(dstrepo, tag.From.Name),
+			srcreference: tag.From.Name,
+			dstauth:      dstauth,
+			srcauth:      srcauth,
+		})
+	}
+
+	return works, nil
+}
+
+// mirror copies the images in works using a pool of options.Workers workers,
+// retrying each image which fails to copy up to options.Retries times.  It
+// returns an error listing the images which could not be copied.
+func mirror(ctx context.Context, log *logrus.Entry, works []*work, options Options, copyImage copyFunc) error {
+	failed := forEach(works, options.Workers, func(w *work) error {
+		log.Printf("mirroring %s", w.tag)
+
+		err := mirrorImage(ctx, log, w, options, copyImage)
+		if err != nil {
+			log.Errorf("%s: %s", w.tag, err)
+		}
 		return err
+	})
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to mirror %d of %d image(s): %s", len(failed), len(works), strings.Join(failed, ", "))
 	}
 
-	type work struct {
-		tag          string
-		dstreference string
-		srcreference string
-		dstauth      *types.DockerAuthConfig
-		srcauth      *types.DockerAuthConfig
+	return nil
+}
+
+// forEach calls fn for each of works using a pool of workers, and returns the
+// sorted tags of the works for which fn failed.
+func forEach(works []*work, workers int, fn func(*work) error) []string {
+	if workers < 1 {
+		workers = 1
 	}
 
 	ch := make(chan *work)
 	wg := &sync.WaitGroup{}
-	var errorOccurred atomic.Value
-	errorOccurred.Store(false)
 
-	for i := 0; i < 10; i++ {
+	mu := &sync.Mutex{}
+	var failed []string
+
+	for i := 0; i < workers; i++ {
 		wg.Add(1)
 		go func() {
+			defer wg.Done()
+
 			for w := range ch {
-				log.Printf("mirroring %s", w.tag)
-				var err error
-				for retry := 0; retry < 6; retry++ {
-					err = Copy(ctx, w.dstreference, w.srcreference, w.dstauth, w.srcauth)
-					if err == nil {
-						break
-					}
-					time.Sleep(10 * time.Second)
-				}
+				err := fn(w)
 				if err != nil {
-					log.Errorf("%s: %s\n", w.tag, err)
-					errorOccurred.Store(true)
+					mu.Lock()
+					failed = append(failed, w.tag)
+					mu.Unlock()
 				}
 			}
-			wg.Done()
 		}()
 	}
 
-	log.Printf("mirroring %d image(s)", len(is.Spec.Tags)+1)
-
-	ch <- &work{
-		tag:          "release",
-		dstreference: Dest(dstrepo, srcrelease),
-		srcreference: srcrelease,
-		dstauth:      dstauth,
-		srcauth:      srcauth,
+	for _, w := range works {
+		ch <- w
 	}
 
-	for _, tag := range is.Spec.Tags {
-		ch <- &work{
-			tag:          tag.Name,
-			dstreference: Dest(dstrepo, tag.From.Name),
-			srcreference: tag.From.Name,
-			dstauth:      dstauth,
-			srcauth:      srcauth,
+	close(ch)
+	wg.Wait()
+
+	sort.Strings(failed)
+
+	return failed
+}
+
+// mirrorImage copies an image, or if it fails the scan policy, copies it to
+// the quarantine repository if there is one and returns the scan failure.
+// Quarantined images are not signed.
+func mirrorImage(ctx context.Context, log *logrus.Entry, w *work, options Options, copyImage copyFunc) error {
+	if options.ScanPolicy != nil {
+		err := options.ScanPolicy.check(ctx, w.srcreference, w.srcauth)
+
+		var vulnErr *VulnerableImageError
+		if errors.As(err, &vulnErr) && options.ScanPolicy.QuarantineRepo != "" {
+			quarantined := *w
+			quarantined.dstreference = Dest(options.ScanPolicy.QuarantineRepo, w.srcreference)
+
+			log.Warnf("quarantining %s to %s", w.tag, quarantined.dstreference)
+			return errors.Join(err, copyWithRetries(ctx, log, &quarantined, options, copyImage))
+		}
+		if err != nil {
+			return err
 		}
 	}
 
-	close(ch)
-	wg.Wait()
+	err := copyWithRetries(ctx, log, w, options, copyImage)
+	if err != nil {
+		return err
+	}
 
-	if errorOccurred.Load().(bool) {
-		return fmt.Errorf("an error occurred")
+	if options.Signer != nil {
+		return signImage(ctx, log, w, options)
 	}
 
 	return nil
 }
+
+func copyWithRetries(ctx context.Context, log *logrus.Entry, w *work, options Options, copyImage copyFunc) error {
+	return withRetries(ctx, log, w, options, func() error {
+		return copyImage(ctx, w.dstreference, w.srcreference, w.dstauth, w.srcauth)
+	})
+}
+
+// withRetries calls fn until it succeeds, up to options.Retries more times.
+func withRetries(ctx context.Context, log *logrus.Entry, w *work, options Options, fn func() error) error {
+	var err error
+	for attempt := 0; attempt <= options.Retries; attempt++ {
+		if attempt > 0 {
+			log.Printf("retrying %s (attempt %d/%d): %s", w.tag, attempt, options.Retries, err)
+
+			select {
+			case <-time.After(options.RetryInterval):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		err = fn()
+		if err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
@@ -4,8 +4,18 @@ package mirror
 // Licensed under the Apache License 2.0.
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/containers/image/v5/types"
+	"github.com/sirupsen/logrus"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
 )
 
 func TestDestLastIndex(t *testing.T) {
@@ -71,3 +81,108 @@ func TestDest(t *testing.T) {
 		})
 	}
 }
+
+func TestMirror(t *testing.T) {
+	ctx := context.Background()
+
+	works := []*work{
+		{tag: "release", srcreference: "quay.io/release", dstreference: "acr.io/release"},
+		{tag: "a", srcreference: "quay.io/a", dstreference: "acr.io/a"},
+		{tag: "b", srcreference: "quay.io/b", dstreference: "acr.io/b"},
+		{tag: "c", srcreference: "quay.io/c", dstreference: "acr.io/c"},
+	}
+
+	for _, tt := range []struct {
+		name          string
+		options       Options
+		failures      map[string]int
+		wantCopies    int
+		wantMaxActive int32
+		wantErr       string
+	}{
+		{
+			name:          "copies every image",
+			options:       Options{Workers: 2},
+			wantCopies:    4,
+			wantMaxActive: 2,
+		},
+		{
+			name:          "retries failed copies",
+			options:       Options{Workers: 4, Retries: 2},
+			failures:      map[string]int{"quay.io/a": 2},
+			wantCopies:    6,
+			wantMaxActive: 4,
+		},
+		{
+			name:          "reports images which fail after all retries",
+			options:       Options{Workers: 1, Retries: 1},
+			failures:      map[string]int{"quay.io/c": 2, "quay.io/a": 5},
+			wantCopies:    6,
+			wantMaxActive: 1,
+			wantErr:       "failed to mirror 2 of 4 image(s): a, c",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var copies int
+			var active, maxActive int32
+			failures := map[string]int{}
+			for k, v := range tt.failures {
+				failures[k] = v
+			}
+
+			copyImage := func(ctx context.Context, dstreference, srcreference string, dstauth, srcauth *types.DockerAuthConfig) error {
+				n := atomic.AddInt32(&active, 1)
+				defer atomic.AddInt32(&active, -1)
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				if n > maxActive {
+					maxActive = n
+				}
+				copies++
+
+				if failures[srcreference] > 0 {
+					failures[srcreference]--
+					return errors.New("copy failed")
+				}
+
+				return nil
+			}
+
+			err := mirror(ctx, logrus.NewEntry(logrus.StandardLogger()), works, tt.options, copyImage)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if copies != tt.wantCopies {
+				t.Errorf("copies: got %d, want %d", copies, tt.wantCopies)
+			}
+
+			if maxActive > tt.wantMaxActive {
+				t.Errorf("concurrent copies: got %d, want at most %d", maxActive, tt.wantMaxActive)
+			}
+		})
+	}
+}
+
+func TestMirrorCancelledDuringRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	copyImage := func(ctx context.Context, dstreference, srcreference string, dstauth, srcauth *types.DockerAuthConfig) error {
+		cancel()
+		return errors.New("copy failed")
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- mirror(ctx, logrus.NewEntry(logrus.StandardLogger()), []*work{{tag: "a"}}, Options{Workers: 1, Retries: 1, RetryInterval: time.Hour}, copyImage)
+	}()
+
+	select {
+	case err := <-done:
+		utilerror.AssertErrorMessage(t, err, "failed to mirror 1 of 1 image(s): a")
+	case <-time.After(10 * time.Second):
+		t.Fatal("mirror did not return after the context was cancelled")
+	}
+}
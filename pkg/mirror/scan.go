@@ -0,0 +1,228 @@
+package mirror
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containers/image/v5/types"
+	"sigs.k8s.io/yaml"
+)
+
+// blockingSeverity is the severity of vulnerabilities which stop an image
+// from being mirrored
+const blockingSeverity = "CRITICAL"
+
+// Vulnerability is a vulnerability found in an image by a Scanner.
+type Vulnerability struct {
+	ID       string
+	Package  string
+	Severity string
+}
+
+// Scanner scans an image for vulnerabilities.
+type Scanner interface {
+	Scan(ctx context.Context, reference string, auth *types.DockerAuthConfig) ([]Vulnerability, error)
+}
+
+// AllowedVulnerability is an accepted risk: a vulnerability which does not
+// stop images in the given repository, or any image if Repository is empty,
+// from being mirrored until it expires.
+type AllowedVulnerability struct {
+	ID         string `json:"id"`
+	Repository string `json:"repository,omitempty"`
+	Reason     string `json:"reason"`
+	Expires    string `json:"expires,omitempty"`
+
+	expires time.Time
+}
+
+// ScanPolicy gates mirroring on the result of a vulnerability scan.  Images
+// with critical vulnerabilities which are not allowed are not mirrored; if
+// QuarantineRepo is set, they are mirrored there instead.
+type ScanPolicy struct {
+	Scanner        Scanner
+	Allowlist      []AllowedVulnerability
+	QuarantineRepo string
+
+	now func() time.Time
+}
+
+// VulnerableImageError is returned for an image with critical vulnerabilities
+// which are not allowed.
+type VulnerableImageError struct {
+	Reference       string
+	Vulnerabilities []Vulnerability
+}
+
+func (err *VulnerableImageError) Error() string {
+	ids := make([]string, 0, len(err.Vulnerabilities))
+	for _, v := range err.Vulnerabilities {
+		ids = append(ids, v.ID)
+	}
+
+	return fmt.Sprintf("%s has critical vulnerabilities: %s", err.Reference, strings.Join(ids, ", "))
+}
+
+// ReadAllowlist reads a list of AllowedVulnerabilities from a YAML file.
+// Expiry dates are in YYYY-MM-DD format.
+func ReadAllowlist(path string) ([]AllowedVulnerability, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var allowlist []AllowedVulnerability
+	err = yaml.Unmarshal(b, &allowlist)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range allowlist {
+		if allowlist[i].ID == "" || allowlist[i].Reason == "" {
+			return nil, fmt.Errorf("allowlist entry %d must have an id and a reason", i)
+		}
+
+		if allowlist[i].Expires != "" {
+			allowlist[i].expires, err = time.Parse(time.DateOnly, allowlist[i].Expires)
+			if err != nil {
+				return nil, fmt.Errorf("allowlist entry %s: invalid expiry %q", allowlist[i].ID, allowlist[i].Expires)
+			}
+		}
+	}
+
+	return allowlist, nil
+}
+
+// check scans an image and returns a *VulnerableImageError if it has critical
+// vulnerabilities which are not allowed.
+func (p *ScanPolicy) check(ctx context.Context, reference string, auth *types.DockerAuthConfig) error {
+	vulnerabilities, err := p.Scanner.Scan(ctx, reference, auth)
+	if err != nil {
+		return fmt.Errorf("scanning %s: %w", reference, err)
+	}
+
+	var blocking []Vulnerability
+	for _, v := range vulnerabilities {
+		if strings.EqualFold(v.Severity, blockingSeverity) && !p.allowed(reference, v) {
+			blocking = append(blocking, v)
+		}
+	}
+
+	if len(blocking) > 0 {
+		sort.Slice(blocking, func(i, j int) bool { return blocking[i].ID < blocking[j].ID })
+		return &VulnerableImageError{Reference: reference, Vulnerabilities: blocking}
+	}
+
+	return nil
+}
+
+func (p *ScanPolicy) allowed(reference string, v Vulnerability) bool {
+	now := time.Now
+	if p.now != nil {
+		now = p.now
+	}
+
+	for _, a := range p.Allowlist {
+		if a.ID != v.ID {
+			continue
+		}
+		if a.Repository != "" && repository(reference) != a.Repository {
+			continue
+		}
+		if !a.expires.IsZero() && !now().Before(a.expires) {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// repository strips the tag or digest from an image reference.
+func repository(reference string) string {
+	if i := strings.IndexByte(reference, '@'); i != -1 {
+		reference = reference[:i]
+	}
+	if i := strings.LastIndexByte(reference, ':'); i > strings.LastIndexByte(reference, '/') {
+		reference = reference[:i]
+	}
+
+	return reference
+}
+
+// trivyScanner scans images with the trivy command line tool.
+type trivyScanner struct {
+	run func(ctx context.Context, env []string, args ...string) ([]byte, error)
+}
+
+// NewTrivyScanner returns a Scanner which runs trivy, which must be on the
+// PATH.
+func NewTrivyScanner() Scanner {
+	return &trivyScanner{
+		run: func(ctx context.Context, env []string, args ...string) ([]byte, error) {
+			cmd := exec.CommandContext(ctx, "trivy", args...)
+			cmd.Env = append(os.Environ(), env...)
+
+			stderr := &bytes.Buffer{}
+			cmd.Stderr = stderr
+
+			b, err := cmd.Output()
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", err, stderr.String())
+			}
+
+			return b, nil
+		},
+	}
+}
+
+func (s *trivyScanner) Scan(ctx context.Context, reference string, auth *types.DockerAuthConfig) ([]Vulnerability, error) {
+	var env []string
+	if auth != nil {
+		env = append(env, "TRIVY_USERNAME="+auth.Username, "TRIVY_PASSWORD="+auth.Password)
+	}
+
+	b, err := s.run(ctx, env, "image", "--quiet", "--format", "json", "--scanners", "vuln", "--severity", blockingSeverity, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	var report struct {
+		Results []struct {
+			Vulnerabilities []struct {
+				VulnerabilityID string
+				PkgName         string
+				Severity        string
+			}
+		}
+	}
+
+	err = json.Unmarshal(b, &report)
+	if err != nil {
+		return nil, err
+	}
+
+	var vulnerabilities []Vulnerability
+	for _, result := range report.Results {
+		for _, v := range result.Vulnerabilities {
+			vulnerabilities = append(vulnerabilities, Vulnerability{
+				ID:       v.VulnerabilityID,
+				Package:  v.PkgName,
+				Severity: v.Severity,
+			})
+		}
+	}
+
+	return vulnerabilities, nil
+}
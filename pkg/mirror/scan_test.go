@@ -0,0 +1,248 @@
+package mirror
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/containers/image/v5/types"
+	"github.com/sirupsen/logrus"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+type fakeScanner map[string][]Vulnerability
+
+func (s fakeScanner) Scan(ctx context.Context, reference string, auth *types.DockerAuthConfig) ([]Vulnerability, error) {
+	if reference == "quay.io/broken" {
+		return nil, errors.New("scanner failed")
+	}
+	return s[reference], nil
+}
+
+func TestScanPolicyCheck(t *testing.T) {
+	ctx := context.Background()
+
+	scanner := fakeScanner{
+		"quay.io/release/a@sha256:1": {
+			{ID: "CVE-1", Severity: "CRITICAL"},
+			{ID: "CVE-2", Severity: "HIGH"},
+		},
+		"quay.io/release/b:latest": {
+			{ID: "CVE-3", Severity: "CRITICAL"},
+			{ID: "CVE-1", Severity: "CRITICAL"},
+		},
+	}
+
+	now := func() time.Time { return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC) }
+
+	for _, tt := range []struct {
+		name      string
+		reference string
+		allowlist []AllowedVulnerability
+		wantErr   string
+	}{
+		{
+			name:      "clean image",
+			reference: "quay.io/release/c@sha256:1",
+		},
+		{
+			name:      "critical vulnerability",
+			reference: "quay.io/release/a@sha256:1",
+			wantErr:   "quay.io/release/a@sha256:1 has critical vulnerabilities: CVE-1",
+		},
+		{
+			name:      "allowed everywhere",
+			reference: "quay.io/release/a@sha256:1",
+			allowlist: []AllowedVulnerability{{ID: "CVE-1"}},
+		},
+		{
+			name:      "allowed in another repository",
+			reference: "quay.io/release/b:latest",
+			allowlist: []AllowedVulnerability{{ID: "CVE-1", Repository: "quay.io/release/a"}},
+			wantErr:   "quay.io/release/b:latest has critical vulnerabilities: CVE-1, CVE-3",
+		},
+		{
+			name:      "allowed in this repository",
+			reference: "quay.io/release/b:latest",
+			allowlist: []AllowedVulnerability{
+				{ID: "CVE-1", Repository: "quay.io/release/b"},
+				{ID: "CVE-3", Repository: "quay.io/release/b"},
+			},
+		},
+		{
+			name:      "expired",
+			reference: "quay.io/release/a@sha256:1",
+			allowlist: []AllowedVulnerability{{ID: "CVE-1", expires: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)}},
+			wantErr:   "quay.io/release/a@sha256:1 has critical vulnerabilities: CVE-1",
+		},
+		{
+			name:      "scanner error",
+			reference: "quay.io/broken",
+			wantErr:   "scanning quay.io/broken: scanner failed",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &ScanPolicy{
+				Scanner:   scanner,
+				Allowlist: tt.allowlist,
+				now:       now,
+			}
+
+			err := p.check(ctx, tt.reference, nil)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestReadAllowlist(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		content       string
+		wantAllowlist []AllowedVulnerability
+		wantErr       string
+	}{
+		{
+			name: "valid",
+			content: `- id: CVE-1
+  repository: quay.io/release/a
+  reason: not reachable
+  expires: "2024-07-01"
+`,
+			wantAllowlist: []AllowedVulnerability{
+				{
+					ID:         "CVE-1",
+					Repository: "quay.io/release/a",
+					Reason:     "not reachable",
+					Expires:    "2024-07-01",
+					expires:    time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name:    "missing reason",
+			content: `- id: CVE-1`,
+			wantErr: "allowlist entry 0 must have an id and a reason",
+		},
+		{
+			name: "invalid expiry",
+			content: `- id: CVE-1
+  reason: not reachable
+  expires: soon
+`,
+			wantErr: `allowlist entry CVE-1: invalid expiry "soon"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "allowlist.yaml")
+			err := os.WriteFile(path, []byte(tt.content), 0o600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			allowlist, err := ReadAllowlist(path)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(allowlist, tt.wantAllowlist) {
+				t.Error(allowlist)
+			}
+		})
+	}
+}
+
+func TestMirrorScanPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	works := []*work{
+		{tag: "a", srcreference: "quay.io/release/a@sha256:1", dstreference: "acr.io/release/a@sha256:1"},
+		{tag: "b", srcreference: "quay.io/release/b@sha256:1", dstreference: "acr.io/release/b@sha256:1"},
+	}
+
+	scanner := fakeScanner{
+		"quay.io/release/b@sha256:1": {{ID: "CVE-1", Severity: "CRITICAL"}},
+	}
+
+	for _, tt := range []struct {
+		name           string
+		quarantineRepo string
+		wantCopied     []string
+	}{
+		{
+			name:       "blocks vulnerable images",
+			wantCopied: []string{"acr.io/release/a@sha256:1"},
+		},
+		{
+			name:           "quarantines vulnerable images",
+			quarantineRepo: "acr.io/quarantine",
+			wantCopied:     []string{"acr.io/quarantine/release/b@sha256:1", "acr.io/release/a@sha256:1"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			copied := map[string]struct{}{}
+
+			copyImage := func(ctx context.Context, dstreference, srcreference string, dstauth, srcauth *types.DockerAuthConfig) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				copied[dstreference] = struct{}{}
+				return nil
+			}
+
+			options := Options{
+				Workers: 2,
+				ScanPolicy: &ScanPolicy{
+					Scanner:        scanner,
+					QuarantineRepo: tt.quarantineRepo,
+				},
+			}
+
+			err := mirror(ctx, logrus.NewEntry(logrus.StandardLogger()), works, options, copyImage)
+			utilerror.AssertErrorMessage(t, err, "failed to mirror 1 of 2 image(s): b")
+
+			for _, want := range tt.wantCopied {
+				if _, found := copied[want]; !found {
+					t.Errorf("%s was not copied", want)
+				}
+			}
+			if len(copied) != len(tt.wantCopied) {
+				t.Errorf("copied %d images, want %d", len(copied), len(tt.wantCopied))
+			}
+		})
+	}
+}
+
+func TestTrivyScanner(t *testing.T) {
+	var gotEnv, gotArgs []string
+	s := &trivyScanner{
+		run: func(ctx context.Context, env []string, args ...string) ([]byte, error) {
+			gotEnv, gotArgs = env, args
+			return []byte(`{"Results": [{"Target": "rhel", "Vulnerabilities": [{"VulnerabilityID": "CVE-1", "PkgName": "openssl", "Severity": "CRITICAL"}]}, {"Target": "go"}]}`), nil
+		},
+	}
+
+	vulnerabilities, err := s.Scan(context.Background(), "quay.io/release/a@sha256:1", &types.DockerAuthConfig{Username: "user", Password: "pass"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(vulnerabilities, []Vulnerability{{ID: "CVE-1", Package: "openssl", Severity: "CRITICAL"}}) {
+		t.Error(vulnerabilities)
+	}
+
+	if !reflect.DeepEqual(gotEnv, []string{"TRIVY_USERNAME=user", "TRIVY_PASSWORD=pass"}) {
+		t.Error(gotEnv)
+	}
+
+	if gotArgs[len(gotArgs)-1] != "quay.io/release/a@sha256:1" {
+		t.Error(gotArgs)
+	}
+}
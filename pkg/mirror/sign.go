@@ -0,0 +1,290 @@
+package mirror
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/signature"
+	"github.com/containers/image/v5/types"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultReleaseSignatureStore is the store of Red Hat's signatures of
+// OpenShift release payloads.
+const DefaultReleaseSignatureStore = "https://mirror.openshift.com/pub/openshift-v4/signatures/openshift/release"
+
+// mirrorPredicateType is the predicate type of the attestation attached to
+// each mirrored image.
+const mirrorPredicateType = "https://github.com/Azure/ARO-RP/mirror/v1"
+
+// Signer signs mirrored images and attaches attestations to them.
+type Signer interface {
+	Sign(ctx context.Context, reference string, auth *types.DockerAuthConfig) error
+	Attest(ctx context.Context, reference string, auth *types.DockerAuthConfig, predicateType string, predicate []byte) error
+}
+
+// mirrorPredicate records where a mirrored image came from.
+type mirrorPredicate struct {
+	Source     string    `json:"source"`
+	MirroredAt time.Time `json:"mirroredAt"`
+}
+
+// signImage signs a mirrored image and attaches an attestation recording its
+// source.
+func signImage(ctx context.Context, log *logrus.Entry, w *work, options Options) error {
+	log.Printf("signing %s", w.tag)
+
+	err := withRetries(ctx, log, w, options, func() error {
+		return options.Signer.Sign(ctx, w.dstreference, w.dstauth)
+	})
+	if err != nil {
+		return fmt.Errorf("signing: %w", err)
+	}
+
+	predicate, err := json.Marshal(&mirrorPredicate{
+		Source:     w.srcreference,
+		MirroredAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = withRetries(ctx, log, w, options, func() error {
+		return options.Signer.Attest(ctx, w.dstreference, w.dstauth, mirrorPredicateType, predicate)
+	})
+	if err != nil {
+		return fmt.Errorf("attesting: %w", err)
+	}
+
+	return nil
+}
+
+// cosignSigner signs images with the cosign command line tool.  The
+// signatures and attestations are pushed to the image's repository.
+type cosignSigner struct {
+	key string
+	run func(ctx context.Context, env []string, args ...string) error
+}
+
+// NewCosignSigner returns a Signer which runs cosign, which must be on the
+// PATH, with the given key.  The key may be a file or a KMS URI; the
+// passphrase of a key file is read by cosign from COSIGN_PASSWORD.
+func NewCosignSigner(key string) Signer {
+	return &cosignSigner{
+		key: key,
+		run: func(ctx context.Context, env []string, args ...string) error {
+			cmd := exec.CommandContext(ctx, "cosign", args...)
+			cmd.Env = append(os.Environ(), env...)
+
+			b, err := cmd.CombinedOutput()
+			if err != nil {
+				return fmt.Errorf("%w: %s", err, string(b))
+			}
+
+			return nil
+		},
+	}
+}
+
+func (s *cosignSigner) Sign(ctx context.Context, reference string, auth *types.DockerAuthConfig) error {
+	return s.withDockerConfig(reference, auth, func(env []string) error {
+		// the transparency log is public, so signatures of internal images
+		// are not uploaded to it
+		return s.run(ctx, env, "sign", "--yes", "--tlog-upload=false", "--key", s.key, reference)
+	})
+}
+
+func (s *cosignSigner) Attest(ctx context.Context, reference string, auth *types.DockerAuthConfig, predicateType string, predicate []byte) error {
+	return s.withDockerConfig(reference, auth, func(env []string) error {
+		dir := strings.TrimPrefix(env[0], "DOCKER_CONFIG=")
+
+		path := filepath.Join(dir, "predicate.json")
+		err := os.WriteFile(path, predicate, 0o600)
+		if err != nil {
+			return err
+		}
+
+		return s.run(ctx, env, "attest", "--yes", "--tlog-upload=false", "--key", s.key, "--type", predicateType, "--predicate", path, reference)
+	})
+}
+
+// withDockerConfig writes the credentials for the image's registry to a
+// temporary docker configuration, so that they are not passed to cosign on
+// its command line, and calls fn with the environment which selects it.
+func (s *cosignSigner) withDockerConfig(reference string, auth *types.DockerAuthConfig, fn func(env []string) error) error {
+	dir, err := os.MkdirTemp("", "cosign-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	config := map[string]map[string]map[string]string{
+		"auths": {},
+	}
+	if auth != nil {
+		registry := reference[:strings.IndexByte(reference, '/')]
+		config["auths"][registry] = map[string]string{
+			"auth": base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password)),
+		}
+	}
+
+	b, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "config.json"), b, 0o600)
+	if err != nil {
+		return err
+	}
+
+	return fn([]string{"DOCKER_CONFIG=" + dir})
+}
+
+// signatureVerifier verifies a GPG signature and returns its contents.
+type signatureVerifier interface {
+	Verify(unverifiedSignature []byte) (contents []byte, keyIdentity string, err error)
+}
+
+// ReleaseVerifier verifies release payloads against their signatures in a
+// signature store, as the cluster version operator does.
+type ReleaseVerifier struct {
+	verifier signatureVerifier
+	store    string
+	client   *http.Client
+}
+
+// NewReleaseVerifier returns a ReleaseVerifier which trusts the keys in the
+// given keyring and fetches signatures from the given store.
+func NewReleaseVerifier(keyring []byte, store string) (*ReleaseVerifier, error) {
+	mech, _, err := signature.NewEphemeralGPGSigningMechanism(keyring)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReleaseVerifier{
+		verifier: mech,
+		store:    strings.TrimSuffix(store, "/"),
+		client:   http.DefaultClient,
+	}, nil
+}
+
+// Verify returns nil if the store has a valid signature for the release
+// payload with the given digest.
+func (v *ReleaseVerifier) Verify(ctx context.Context, digest string) error {
+	algorithm, hex, found := strings.Cut(digest, ":")
+	if !found {
+		return fmt.Errorf("invalid digest %q", digest)
+	}
+
+	// signatures are numbered from 1; the first missing one ends the list
+	for i := 1; ; i++ {
+		b, err := v.getSignature(ctx, fmt.Sprintf("%s/%s=%s/signature-%d", v.store, algorithm, hex, i))
+		if err != nil {
+			return err
+		}
+		if b == nil {
+			return fmt.Errorf("no valid signature found for %s", digest)
+		}
+
+		if v.verifySignature(b, digest) == nil {
+			return nil
+		}
+	}
+}
+
+// getSignature returns a signature from the store, or nil if it does not
+// exist.
+func (v *ReleaseVerifier) getSignature(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	case http.StatusNotFound, http.StatusForbidden:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected status code %d fetching %s", resp.StatusCode, url)
+	}
+}
+
+func (v *ReleaseVerifier) verifySignature(b []byte, digest string) error {
+	contents, _, err := v.verifier.Verify(b)
+	if err != nil {
+		return err
+	}
+
+	var s struct {
+		Critical struct {
+			Type  string `json:"type"`
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+		} `json:"critical"`
+	}
+
+	err = json.Unmarshal(contents, &s)
+	if err != nil {
+		return err
+	}
+
+	if s.Critical.Type != "atomic container signature" || s.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("signature is not for %s", digest)
+	}
+
+	return nil
+}
+
+// releaseDigest returns the digest of a release payload, looking it up if the
+// payload is not referenced by digest.
+func releaseDigest(ctx context.Context, srcrelease string, srcauth *types.DockerAuthConfig) (string, error) {
+	ref, err := docker.ParseReference("//" + srcrelease)
+	if err != nil {
+		return "", err
+	}
+
+	if canonical, ok := ref.DockerReference().(reference.Canonical); ok {
+		return canonical.Digest().String(), nil
+	}
+
+	digest, err := docker.GetDigest(ctx, &types.SystemContext{DockerAuthConfig: srcauth}, ref)
+	if err != nil {
+		return "", err
+	}
+
+	return digest.String(), nil
+}
+
+func (v *ReleaseVerifier) verifyRelease(ctx context.Context, log *logrus.Entry, srcrelease string, srcauth *types.DockerAuthConfig) error {
+	digest, err := releaseDigest(ctx, srcrelease, srcauth)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("verifying the signature of %s", digest)
+
+	return v.Verify(ctx, digest)
+}
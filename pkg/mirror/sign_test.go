@@ -0,0 +1,229 @@
+package mirror
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/containers/image/v5/types"
+	"github.com/sirupsen/logrus"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+type fakeSigner struct {
+	mu       sync.Mutex
+	signed   []string
+	attested []string
+	fail     map[string]bool
+}
+
+func (s *fakeSigner) Sign(ctx context.Context, reference string, auth *types.DockerAuthConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fail[reference] {
+		return errors.New("signing failed")
+	}
+	s.signed = append(s.signed, reference)
+	return nil
+}
+
+func (s *fakeSigner) Attest(ctx context.Context, reference string, auth *types.DockerAuthConfig, predicateType string, predicate []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var p mirrorPredicate
+	err := json.Unmarshal(predicate, &p)
+	if err != nil {
+		return err
+	}
+	s.attested = append(s.attested, p.Source+" "+reference)
+	return nil
+}
+
+func TestMirrorSigns(t *testing.T) {
+	works := []*work{
+		{tag: "a", srcreference: "quay.io/a", dstreference: "acr.io/a"},
+		{tag: "b", srcreference: "quay.io/b", dstreference: "acr.io/b"},
+		{tag: "c", srcreference: "quay.io/c", dstreference: "acr.io/c"},
+	}
+
+	signer := &fakeSigner{fail: map[string]bool{"acr.io/c": true}}
+
+	copyImage := func(ctx context.Context, dstreference, srcreference string, dstauth, srcauth *types.DockerAuthConfig) error {
+		if srcreference == "quay.io/b" {
+			return errors.New("copy failed")
+		}
+		return nil
+	}
+
+	err := mirror(context.Background(), logrus.NewEntry(logrus.StandardLogger()), works, Options{Workers: 2, Signer: signer}, copyImage)
+	utilerror.AssertErrorMessage(t, err, "failed to mirror 2 of 3 image(s): b, c")
+
+	sort.Strings(signer.signed)
+	if !reflect.DeepEqual(signer.signed, []string{"acr.io/a"}) {
+		t.Error(signer.signed)
+	}
+
+	if !reflect.DeepEqual(signer.attested, []string{"quay.io/a acr.io/a"}) {
+		t.Error(signer.attested)
+	}
+}
+
+type fakeVerifier struct {
+	contents map[string]string
+}
+
+func (v *fakeVerifier) Verify(unverifiedSignature []byte) ([]byte, string, error) {
+	contents, found := v.contents[string(unverifiedSignature)]
+	if !found {
+		return nil, "", errors.New("invalid signature")
+	}
+	return []byte(contents), "key", nil
+}
+
+func TestReleaseVerifierVerify(t *testing.T) {
+	const digest = "sha256:0123"
+
+	contents := func(digest string) string {
+		return `{"critical": {"type": "atomic container signature", "image": {"docker-manifest-digest": "` + digest + `"}}}`
+	}
+
+	for _, tt := range []struct {
+		name       string
+		signatures []string
+		status     int
+		wantErr    string
+	}{
+		{
+			name:       "valid signature",
+			signatures: []string{"valid"},
+		},
+		{
+			name:       "valid signature after invalid ones",
+			signatures: []string{"forged", "other", "valid"},
+		},
+		{
+			name:       "only invalid signatures",
+			signatures: []string{"forged", "other"},
+			wantErr:    "no valid signature found for sha256:0123",
+		},
+		{
+			name:    "no signatures",
+			wantErr: "no valid signature found for sha256:0123",
+		},
+		{
+			name:    "store error",
+			status:  http.StatusInternalServerError,
+			wantErr: "unexpected status code 500 fetching $SERVER/sha256=0123/signature-1",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+					return
+				}
+
+				for i, s := range tt.signatures {
+					if r.URL.Path == "/sha256=0123/signature-"+string(rune('1'+i)) {
+						w.Write([]byte(s))
+						return
+					}
+				}
+
+				w.WriteHeader(http.StatusNotFound)
+			}))
+			defer srv.Close()
+
+			v := &ReleaseVerifier{
+				verifier: &fakeVerifier{
+					contents: map[string]string{
+						"valid": contents(digest),
+						"other": contents("sha256:4567"),
+					},
+				},
+				store:  srv.URL,
+				client: srv.Client(),
+			}
+
+			err := v.Verify(context.Background(), digest)
+			utilerror.AssertErrorMessage(t, err, strings.ReplaceAll(tt.wantErr, "$SERVER", srv.URL))
+		})
+	}
+}
+
+func TestCosignSigner(t *testing.T) {
+	var calls [][]string
+	var config, predicate string
+
+	s := &cosignSigner{
+		key: "azurekms://vault.vault.azure.net/cosign",
+		run: func(ctx context.Context, env []string, args ...string) error {
+			calls = append(calls, args)
+
+			dir := strings.TrimPrefix(env[0], "DOCKER_CONFIG=")
+			b, err := os.ReadFile(filepath.Join(dir, "config.json"))
+			if err != nil {
+				return err
+			}
+			config = string(b)
+
+			if args[0] == "attest" {
+				b, err := os.ReadFile(args[len(args)-2])
+				if err != nil {
+					return err
+				}
+				predicate = string(b)
+			}
+
+			return nil
+		},
+	}
+
+	auth := &types.DockerAuthConfig{Username: "user", Password: "pass"}
+
+	err := s.Sign(context.Background(), "acr.io/release@sha256:0123", auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.Attest(context.Background(), "acr.io/release@sha256:0123", auth, mirrorPredicateType, []byte(`{"source":"quay.io/release"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(calls) != 2 {
+		t.Fatal(calls)
+	}
+
+	wantSign := []string{"sign", "--yes", "--tlog-upload=false", "--key", "azurekms://vault.vault.azure.net/cosign", "acr.io/release@sha256:0123"}
+	if !reflect.DeepEqual(calls[0], wantSign) {
+		t.Error(calls[0])
+	}
+
+	if calls[1][0] != "attest" || calls[1][6] != mirrorPredicateType {
+		t.Error(calls[1])
+	}
+
+	if config != `{"auths":{"acr.io":{"auth":"dXNlcjpwYXNz"}}}` {
+		t.Error(config)
+	}
+
+	if predicate != `{"source":"quay.io/release"}` {
+		t.Error(predicate)
+	}
+}
@@ -51,6 +51,8 @@ type Monitor struct {
 	ocpclientset  client.Client
 	hiveclientset client.Client
 
+	slo *SLOTracker
+
 	// access below only via the helper functions in cache.go
 	cache struct {
 		cos   *configv1.ClusterOperatorList
@@ -63,7 +65,7 @@ type Monitor struct {
 	wg *sync.WaitGroup
 }
 
-func NewMonitor(log *logrus.Entry, restConfig *rest.Config, oc *api.OpenShiftCluster, m metrics.Emitter, hiveRestConfig *rest.Config, hourlyRun bool, wg *sync.WaitGroup) (*Monitor, error) {
+func NewMonitor(log *logrus.Entry, restConfig *rest.Config, oc *api.OpenShiftCluster, m metrics.Emitter, hiveRestConfig *rest.Config, hourlyRun bool, slo *SLOTracker, wg *sync.WaitGroup) (*Monitor, error) {
 	r, err := azure.ParseResourceID(oc.ID)
 	if err != nil {
 		return nil, err
@@ -135,6 +137,7 @@ func NewMonitor(log *logrus.Entry, restConfig *rest.Config, oc *api.OpenShiftClu
 		m:             m,
 		ocpclientset:  ocpclientset,
 		hiveclientset: hiveclientset,
+		slo:           slo,
 		wg:            wg,
 	}, nil
 }
@@ -178,6 +181,8 @@ func (mon *Monitor) Monitor(ctx context.Context) (errs []error) {
 		errs = append(errs, err)
 		mon.emitFailureToGatherMetric(steps.FriendlyName(mon.emitAPIServerHealthzCode), err)
 	}
+	mon.emitSLOBurnRates(sliAPIServer, statusCode == http.StatusOK)
+
 	// If API is not returning 200, fallback to checking ping and short circuit the rest of the checks
 	if statusCode != http.StatusOK {
 		err := mon.emitAPIServerPingCode(ctx)
@@ -210,6 +215,7 @@ func (mon *Monitor) Monitor(ctx context.Context) (errs []error) {
 		mon.emitHiveRegistrationStatus,
 		mon.emitOperatorFlagsAndSupportBanner,
 		mon.emitMaintenanceState,
+		mon.emitIngressBurnRates,
 		mon.emitCertificateExpirationStatuses,
 		mon.emitEtcdCertificateExpiry,
 		mon.emitPrometheusAlerts, // at the end for now because it's the slowest/least reliable
@@ -233,3 +239,7 @@ func (mon *Monitor) emitFailureToGatherMetric(friendlyFuncName string, err error
 func (mon *Monitor) emitGauge(m string, value int64, dims map[string]string) {
 	emitter.EmitGauge(mon.m, m, value, mon.dims, dims)
 }
+
+func (mon *Monitor) emitFloat(m string, value float64, dims map[string]string) {
+	emitter.EmitFloat(mon.m, m, value, mon.dims, dims)
+}
@@ -45,7 +45,7 @@ func (mon *Monitor) emitClusterOperatorConditions(ctx context.Context) error {
 
 	for _, co := range cos.Items {
 		for _, c := range co.Status.Conditions {
-			if clusterOperatorConditionIsExpected(&co, &c) {
+			if ClusterOperatorConditionIsExpected(&co, &c) {
 				continue
 			}
 
@@ -70,7 +70,9 @@ func (mon *Monitor) emitClusterOperatorConditions(ctx context.Context) error {
 	return nil
 }
 
-func clusterOperatorConditionIsExpected(co *configv1.ClusterOperator, c *configv1.ClusterOperatorStatusCondition) bool {
+// ClusterOperatorConditionIsExpected returns false if the monitor would report
+// the condition as a problem.
+func ClusterOperatorConditionIsExpected(co *configv1.ClusterOperator, c *configv1.ClusterOperatorStatusCondition) bool {
 	if _, ok := clusterOperatorConditionsIgnore[clusterOperatorConditionsIgnoreStruct{
 		Name:   co.Name,
 		Type:   c.Type,
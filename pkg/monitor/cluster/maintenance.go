@@ -14,6 +14,8 @@ import (
 
 	(1) Maintenance pending
 		- We will do maintenance, so emit a maintenance pending signal
+		- This includes impactful maintenance scheduled by MIMO which
+		  has been published to the customer.
 
 	(2) Planned maintenance in progress
 		- Emit a planned maintenance in progress signal.
@@ -66,6 +68,9 @@ func getMaintenanceState(clusterProperties api.OpenShiftClusterProperties) maint
 		fallthrough
 	// For new clusters, no maintenance state has been set yet
 	default:
+		if clusterProperties.ScheduledMaintenance != nil {
+			return pending
+		}
 		return none
 	}
 }
@@ -19,6 +19,7 @@ func TestEmitMaintenanceState(t *testing.T) {
 		provisioningState api.ProvisioningState
 		maintenanceState  api.MaintenanceState
 		adminUpdateErr    string
+		scheduled         *api.ScheduledMaintenance
 		expectedState     maintenanceState
 	}{
 		{
@@ -38,6 +39,20 @@ func TestEmitMaintenanceState(t *testing.T) {
 			maintenanceState:  api.MaintenanceStatePending,
 			expectedState:     pending,
 		},
+		{
+			name:              "state pending - scheduled maintenance published",
+			provisioningState: api.ProvisioningStateSucceeded,
+			maintenanceState:  api.MaintenanceStateNone,
+			scheduled:         &api.ScheduledMaintenance{ManifestID: "manifest"},
+			expectedState:     pending,
+		},
+		{
+			name:              "state planned - scheduled maintenance in progress",
+			provisioningState: api.ProvisioningStateAdminUpdating,
+			maintenanceState:  api.MaintenanceStatePlanned,
+			scheduled:         &api.ScheduledMaintenance{ManifestID: "manifest"},
+			expectedState:     planned,
+		},
 		{
 			name:              "state unplanned",
 			provisioningState: api.ProvisioningStateAdminUpdating,
@@ -70,6 +85,7 @@ func TestEmitMaintenanceState(t *testing.T) {
 					ProvisioningState:    tt.provisioningState,
 					MaintenanceState:     tt.maintenanceState,
 					LastAdminUpdateError: tt.adminUpdateErr,
+					ScheduledMaintenance: tt.scheduled,
 				},
 			}
 			mon := &Monitor{
@@ -21,6 +21,12 @@ var nodeConditionsExpected = map[corev1.NodeConditionType]corev1.ConditionStatus
 	corev1.NodeReady:          corev1.ConditionTrue,
 }
 
+// NodeConditionIsExpected returns false if the monitor would report the
+// condition as a problem.
+func NodeConditionIsExpected(c *corev1.NodeCondition) bool {
+	return c.Status == nodeConditionsExpected[c.Type]
+}
+
 func (mon *Monitor) emitNodeConditions(ctx context.Context) error {
 	ns, err := mon.listNodes(ctx)
 	if err != nil {
@@ -33,7 +39,7 @@ func (mon *Monitor) emitNodeConditions(ctx context.Context) error {
 
 	for _, n := range ns.Items {
 		for _, c := range n.Status.Conditions {
-			if c.Status == nodeConditionsExpected[c.Type] {
+			if NodeConditionIsExpected(&c) {
 				continue
 			}
 
@@ -0,0 +1,128 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"sync"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+const (
+	sliAPIServer = "apiserver"
+	sliIngress   = "ingress"
+
+	// sloTarget is the availability objective of each SLI.  A burn rate of 1
+	// spends the error budget exactly over the SLO period.
+	sloTarget = 0.999
+)
+
+// sloWindows are the windows over which burn rates are computed, in samples.
+// The monitor samples each cluster once a minute.  Pairs of a short and a long
+// window (5m/1h, 30m/6h) allow multi-window burn rate alerts.
+var sloWindows = []struct {
+	name    string
+	samples int
+}{
+	{"5m", 5},
+	{"30m", 30},
+	{"1h", 60},
+	{"6h", 360},
+}
+
+// SLOTracker keeps the recent availability samples of a cluster across
+// monitoring runs.  A monitor worker owns one per cluster; samples are lost if
+// the cluster moves to another monitor, after which burn rates are computed
+// over the samples gathered so far.
+type SLOTracker struct {
+	mu      sync.Mutex
+	samples map[string][]bool
+}
+
+func NewSLOTracker() *SLOTracker {
+	return &SLOTracker{
+		samples: map[string][]bool{},
+	}
+}
+
+type burnRate struct {
+	window string
+	value  float64
+}
+
+// record adds a sample to an SLI and returns its burn rate over each window
+func (t *SLOTracker) record(sli string, available bool) []burnRate {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	max := sloWindows[len(sloWindows)-1].samples
+
+	samples := append(t.samples[sli], available)
+	if len(samples) > max {
+		samples = samples[len(samples)-max:]
+	}
+	t.samples[sli] = samples
+
+	burnRates := make([]burnRate, 0, len(sloWindows))
+	for _, w := range sloWindows {
+		window := samples
+		if len(window) > w.samples {
+			window = window[len(window)-w.samples:]
+		}
+
+		var unavailable int
+		for _, available := range window {
+			if !available {
+				unavailable++
+			}
+		}
+
+		burnRates = append(burnRates, burnRate{
+			window: w.name,
+			value:  float64(unavailable) / float64(len(window)) / (1 - sloTarget),
+		})
+	}
+
+	return burnRates
+}
+
+func (mon *Monitor) emitSLOBurnRates(sli string, available bool) {
+	if mon.slo == nil {
+		return
+	}
+
+	for _, br := range mon.slo.record(sli, available) {
+		mon.emitFloat("cluster.slo.burnrate", br.value, map[string]string{
+			"sli":    sli,
+			"window": br.window,
+		})
+	}
+}
+
+// emitIngressBurnRates samples ingress availability from the ingress cluster
+// operator's Available condition
+func (mon *Monitor) emitIngressBurnRates(ctx context.Context) error {
+	cos, err := mon.listClusterOperators(ctx)
+	if err != nil {
+		return err
+	}
+
+	var available bool
+	for _, co := range cos.Items {
+		if co.Name != "ingress" {
+			continue
+		}
+
+		for _, c := range co.Status.Conditions {
+			if c.Type == configv1.OperatorAvailable {
+				available = c.Status == configv1.ConditionTrue
+			}
+		}
+	}
+
+	mon.emitSLOBurnRates(sliIngress, available)
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	configfake "github.com/openshift/client-go/config/clientset/versioned/fake"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestSLOTrackerRecord(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		samples []bool
+		want    map[string]float64
+	}{
+		{
+			name:    "available",
+			samples: []bool{true, true, true},
+			want: map[string]float64{
+				"5m":  0,
+				"30m": 0,
+				"1h":  0,
+				"6h":  0,
+			},
+		},
+		{
+			name:    "partial windows",
+			samples: []bool{false, true, true, true},
+			want: map[string]float64{
+				"5m":  250,
+				"30m": 250,
+				"1h":  250,
+				"6h":  250,
+			},
+		},
+		{
+			name: "unavailable sample outside short window",
+			samples: append([]bool{false}, func() []bool {
+				s := make([]bool, 59)
+				for i := range s {
+					s[i] = true
+				}
+				return s
+			}()...),
+			want: map[string]float64{
+				"5m":  0,
+				"30m": 0,
+				"1h":  1000.0 / 60,
+				"6h":  1000.0 / 60,
+			},
+		},
+		{
+			name:    "samples beyond longest window are dropped",
+			samples: append(make([]bool, 10), make([]bool, 360)...),
+			want: map[string]float64{
+				"5m":  1000,
+				"30m": 1000,
+				"1h":  1000,
+				"6h":  1000,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewSLOTracker()
+
+			var got []burnRate
+			for _, sample := range tt.samples {
+				got = tracker.record(sliAPIServer, sample)
+			}
+
+			if len(tracker.samples[sliAPIServer]) > 360 {
+				t.Error(len(tracker.samples[sliAPIServer]))
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatal(got)
+			}
+			for _, br := range got {
+				if math.Abs(br.value-tt.want[br.window]) > 1e-6 {
+					t.Errorf("%s: got %f, want %f", br.window, br.value, tt.want[br.window])
+				}
+			}
+		})
+	}
+}
+
+func TestEmitIngressBurnRates(t *testing.T) {
+	ctx := context.Background()
+
+	configcli := configfake.NewSimpleClientset(&configv1.ClusterOperator{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ingress",
+		},
+		Status: configv1.ClusterOperatorStatus{
+			Conditions: []configv1.ClusterOperatorStatusCondition{
+				{
+					Type:   configv1.OperatorAvailable,
+					Status: configv1.ConditionFalse,
+				},
+			},
+		},
+	})
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+
+	mon := &Monitor{
+		configcli: configcli,
+		m:         m,
+		slo:       NewSLOTracker(),
+	}
+
+	for _, window := range []string{"5m", "30m", "1h", "6h"} {
+		m.EXPECT().EmitFloat("cluster.slo.burnrate", gomock.Any(), map[string]string{
+			"sli":    "ingress",
+			"window": window,
+		}).Do(func(_ string, value float64, _ map[string]string) {
+			if math.Abs(value-1000) > 1e-6 {
+				t.Error(value)
+			}
+		})
+	}
+
+	err := mon.emitIngressBurnRates(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
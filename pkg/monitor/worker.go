@@ -15,6 +15,8 @@ import (
 	"k8s.io/client-go/rest"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/hive"
 	"github.com/Azure/ARO-RP/pkg/monitor/azure/nsg"
 	"github.com/Azure/ARO-RP/pkg/monitor/cluster"
 	"github.com/Azure/ARO-RP/pkg/monitor/dimension"
@@ -85,6 +87,10 @@ func (mon *monitor) listBuckets(ctx context.Context) error {
 func (mon *monitor) changefeed(ctx context.Context, baseLog *logrus.Entry, stop <-chan struct{}) {
 	defer recover.Panic(baseLog)
 
+	// the change feed is polled continuously and tolerates briefly stale
+	// reads, so don't pay for the account's default strong consistency
+	ctx = database.WithConsistencyLevel(ctx, database.ConsistencyLevelSession)
+
 	dbOpenShiftClusters, err := mon.dbGroup.OpenShiftClusters()
 	if err != nil {
 		baseLog.Error(err)
@@ -132,10 +138,7 @@ func (mon *monitor) changefeed(ctx context.Context, baseLog *logrus.Entry, stop
 							fps == api.ProvisioningStateDeleting):
 					mon.deleteDoc(doc)
 				default:
-					// in the future we will have the shard index set on the api.OpenShiftClusterDocument
-					// but for now we simply select Hive (AKS) shard 1
-					// e.g. shard := mon.hiveShardConfigs[doc.shardIndex]
-					shard := 1
+					shard := hive.Shard(doc)
 
 					_, exists := mon.getHiveShardConfig(shard)
 					if !exists {
@@ -221,6 +224,8 @@ func (mon *monitor) worker(stop <-chan struct{}, delay time.Duration, id string)
 
 	h := time.Now().Hour()
 
+	slo := cluster.NewSLOTracker()
+
 out:
 	for {
 		mon.mu.RLock()
@@ -238,7 +243,7 @@ out:
 		// cached metrics in the remaining minutes
 
 		if sub != nil && sub.Subscription != nil && sub.Subscription.State != api.SubscriptionStateSuspended && sub.Subscription.State != api.SubscriptionStateWarned {
-			mon.workOne(context.Background(), log, v.doc, sub, newh != h, nsgMonitoringTicker)
+			mon.workOne(context.Background(), log, v.doc, sub, newh != h, nsgMonitoringTicker, slo)
 		}
 
 		select {
@@ -254,7 +259,7 @@ out:
 }
 
 // workOne checks the API server health of a cluster
-func (mon *monitor) workOne(ctx context.Context, log *logrus.Entry, doc *api.OpenShiftClusterDocument, sub *api.SubscriptionDocument, hourlyRun bool, nsgMonTicker *time.Ticker) {
+func (mon *monitor) workOne(ctx context.Context, log *logrus.Entry, doc *api.OpenShiftClusterDocument, sub *api.SubscriptionDocument, hourlyRun bool, nsgMonTicker *time.Ticker, slo *cluster.SLOTracker) {
 	ctx, cancel := context.WithTimeout(ctx, 50*time.Second)
 	defer cancel()
 
@@ -264,9 +269,7 @@ func (mon *monitor) workOne(ctx context.Context, log *logrus.Entry, doc *api.Ope
 		return
 	}
 
-	// once sharding is implemented, we will have the shard set on the api.OpenShiftClusterDocument
-	// e.g. shard := mon.hiveShardConfigs[doc.shardIndex]
-	shard := 1
+	shard := hive.Shard(doc)
 	hiveRestConfig, exists := mon.getHiveShardConfig(shard)
 	if !exists {
 		log.Warnf("no hiveShardConfigs set for shard %d", shard)
@@ -283,7 +286,7 @@ func (mon *monitor) workOne(ctx context.Context, log *logrus.Entry, doc *api.Ope
 
 	nsgMon := nsg.NewMonitor(log, doc.OpenShiftCluster, mon.env, sub.ID, sub.Subscription.Properties.TenantID, mon.clusterm, dims, &wg, nsgMonTicker.C)
 
-	c, err := cluster.NewMonitor(log, restConfig, doc.OpenShiftCluster, mon.clusterm, hiveRestConfig, hourlyRun, &wg)
+	c, err := cluster.NewMonitor(log, restConfig, doc.OpenShiftCluster, mon.clusterm, hiveRestConfig, hourlyRun, slo, &wg)
 	if err != nil {
 		log.Error(err)
 		mon.m.EmitGauge("monitor.cluster.failedworker", 1, map[string]string{
@@ -116,10 +116,22 @@ type ClusterSpec struct {
 	Banner                   Banner              `json:"banner,omitempty"`
 	ServiceSubnets           []string            `json:"serviceSubnets,omitempty"`
 
+	// SupportedVMSizes lists the VM sizes which the RP supports in addition
+	// to the VM sizes built into the operator
+	SupportedVMSizes []SupportedVMSize `json:"supportedVMSizes,omitempty"`
+
 	// OperatorFlags defines feature gates for the ARO Operator
 	OperatorFlags OperatorFlags `json:"operatorflags,omitempty"`
 }
 
+// SupportedVMSize is a VM size which nodes of the listed roles may use
+type SupportedVMSize struct {
+	VMSize    string   `json:"vmSize,omitempty"`
+	CoreCount int      `json:"coreCount,omitempty"`
+	Family    string   `json:"family,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+}
+
 // Banner defines if a Banner should be shown to the customer
 type Banner struct {
 	Content BannerContent `json:"content,omitempty"`
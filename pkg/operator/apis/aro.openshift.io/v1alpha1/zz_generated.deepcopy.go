@@ -100,6 +100,13 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SupportedVMSizes != nil {
+		in, out := &in.SupportedVMSizes, &out.SupportedVMSizes
+		*out = make([]SupportedVMSize, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.OperatorFlags != nil {
 		in, out := &in.OperatorFlags, &out.OperatorFlags
 		*out = make(OperatorFlags, len(*in))
@@ -201,3 +208,23 @@ func (in OperatorFlags) DeepCopy() OperatorFlags {
 	in.DeepCopyInto(out)
 	return *out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupportedVMSize) DeepCopyInto(out *SupportedVMSize) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupportedVMSize.
+func (in *SupportedVMSize) DeepCopy() *SupportedVMSize {
+	if in == nil {
+		return nil
+	}
+	out := new(SupportedVMSize)
+	in.DeepCopyInto(out)
+	return out
+}
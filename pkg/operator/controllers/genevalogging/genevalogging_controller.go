@@ -28,9 +28,9 @@ const (
 	ControllerName = "GenevaLogging"
 
 	// full pullspec of fluentbit image
-	controllerFluentbitPullSpec = "aro.genevalogging.fluentbit.pullSpec"
+	controllerFluentbitPullSpec = operator.GenevaLoggingFluentbitPullSpec
 	// full pullspec of mdsd image
-	controllerMDSDPullSpec = "aro.genevalogging.mdsd.pullSpec"
+	controllerMDSDPullSpec = operator.GenevaLoggingMDSDPullSpec
 )
 
 // Reconciler reconciles a Cluster object
@@ -19,6 +19,7 @@ import (
 	kruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 
+	"github.com/Azure/ARO-RP/pkg/operator"
 	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/guardrails/config"
 	"github.com/Azure/ARO-RP/pkg/util/dynamichelper"
@@ -31,11 +32,15 @@ func (r *Reconciler) getPolicyConfig(ctx context.Context, instance *arov1alpha1.
 	}
 	name := parts[0]
 
+	// per-policy flags take precedence over the cluster-wide policy flags,
+	// which are set from the cluster's guardrails profile
 	managedPath := fmt.Sprintf(controllerPolicyManagedTemplate, name)
-	managed := instance.Spec.OperatorFlags.GetWithDefault(managedPath, "false")
+	managed := instance.Spec.OperatorFlags.GetWithDefault(managedPath,
+		instance.Spec.OperatorFlags.GetWithDefault(operator.GuardrailsPoliciesManaged, "false"))
 
 	enforcementPath := fmt.Sprintf(controllerPolicyEnforcementTemplate, name)
-	enforcement := instance.Spec.OperatorFlags.GetWithDefault(enforcementPath, "dryrun")
+	enforcement := instance.Spec.OperatorFlags.GetWithDefault(enforcementPath,
+		instance.Spec.OperatorFlags.GetWithDefault(operator.GuardrailsPoliciesEnforcement, "dryrun"))
 
 	return managed, enforcement, nil
 }
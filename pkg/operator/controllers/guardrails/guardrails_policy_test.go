@@ -0,0 +1,68 @@
+package guardrails
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+)
+
+func TestGetPolicyConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		flags           arov1alpha1.OperatorFlags
+		wantManaged     string
+		wantEnforcement string
+	}{
+		{
+			name:            "defaults",
+			flags:           arov1alpha1.OperatorFlags{},
+			wantManaged:     "false",
+			wantEnforcement: "dryrun",
+		},
+		{
+			name: "cluster-wide policy flags",
+			flags: arov1alpha1.OperatorFlags{
+				operator.GuardrailsPoliciesManaged:     "true",
+				operator.GuardrailsPoliciesEnforcement: "deny",
+			},
+			wantManaged:     "true",
+			wantEnforcement: "deny",
+		},
+		{
+			name: "per-policy flags take precedence",
+			flags: arov1alpha1.OperatorFlags{
+				operator.GuardrailsPoliciesManaged:                    "true",
+				operator.GuardrailsPoliciesEnforcement:                "deny",
+				"aro.guardrails.policies.aro-deny-labels.managed":     "false",
+				"aro.guardrails.policies.aro-deny-labels.enforcement": "warn",
+			},
+			wantManaged:     "false",
+			wantEnforcement: "warn",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciler{}
+			instance := &arov1alpha1.Cluster{
+				Spec: arov1alpha1.ClusterSpec{
+					OperatorFlags: tt.flags,
+				},
+			}
+
+			managed, enforcement, err := r.getPolicyConfig(context.Background(), instance, "aro-deny-labels.yaml")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if managed != tt.wantManaged {
+				t.Errorf("got managed %q, wanted %q", managed, tt.wantManaged)
+			}
+			if enforcement != tt.wantEnforcement {
+				t.Errorf("got enforcement %q, wanted %q", enforcement, tt.wantEnforcement)
+			}
+		})
+	}
+}
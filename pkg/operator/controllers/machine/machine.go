@@ -13,9 +13,25 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/api/validate"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
 	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
 )
 
+func supportedVMSizes(instance *arov1alpha1.Cluster) []*api.SupportedVMSize {
+	vmSizes := make([]*api.SupportedVMSize, 0, len(instance.Spec.SupportedVMSizes))
+	for _, vmSize := range instance.Spec.SupportedVMSizes {
+		vmSizes = append(vmSizes, &api.SupportedVMSize{
+			Properties: api.SupportedVMSizeProperties{
+				VMSize:    api.VMSize(vmSize.VMSize),
+				CoreCount: vmSize.CoreCount,
+				Family:    vmSize.Family,
+				Roles:     vmSize.Roles,
+			},
+		})
+	}
+	return vmSizes
+}
+
 func (r *Reconciler) workerReplicas(ctx context.Context) (int, error) {
 	count := 0
 	machinesets := &machinev1beta1.MachineSetList{}
@@ -32,7 +48,7 @@ func (r *Reconciler) workerReplicas(ctx context.Context) (int, error) {
 	return count, nil
 }
 
-func (r *Reconciler) machineValid(ctx context.Context, machine *machinev1beta1.Machine, isMaster bool) (errs []error) {
+func (r *Reconciler) machineValid(ctx context.Context, machine *machinev1beta1.Machine, isMaster bool, additionalVMSizes []*api.SupportedVMSize) (errs []error) {
 	// Validate machine provider spec exists and decode it
 	if machine.Spec.ProviderSpec.Value == nil {
 		return []error{fmt.Errorf("machine %s: provider spec missing", machine.Name)}
@@ -44,7 +60,7 @@ func (r *Reconciler) machineValid(ctx context.Context, machine *machinev1beta1.M
 	}
 
 	// Validate VM size in machine provider spec
-	if !validate.VMSizeIsValid(api.VMSize(machineProviderSpec.VMSize), r.isLocalDevelopmentMode, isMaster) {
+	if !validate.VMSizeIsValid(api.VMSize(machineProviderSpec.VMSize), r.isLocalDevelopmentMode, isMaster, additionalVMSizes) {
 		errs = append(errs, fmt.Errorf("machine %s: invalid VM size '%v'", machine.Name, machineProviderSpec.VMSize))
 	}
 
@@ -65,7 +81,7 @@ func (r *Reconciler) machineValid(ctx context.Context, machine *machinev1beta1.M
 	return errs
 }
 
-func (r *Reconciler) checkMachines(ctx context.Context) (errs []error) {
+func (r *Reconciler) checkMachines(ctx context.Context, additionalVMSizes []*api.SupportedVMSize) (errs []error) {
 	actualWorkers := 0
 	actualMasters := 0
 
@@ -88,7 +104,7 @@ func (r *Reconciler) checkMachines(ctx context.Context) (errs []error) {
 			continue
 		}
 
-		errs = append(errs, r.machineValid(ctx, &machine, isMaster)...)
+		errs = append(errs, r.machineValid(ctx, &machine, isMaster, additionalVMSizes)...)
 
 		if isMaster {
 			actualMasters++
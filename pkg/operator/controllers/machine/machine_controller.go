@@ -55,6 +55,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 	}
 
 	r.log.Debug("running")
+
 	// Update cluster object's status.
 	cond := &operatorv1.OperatorCondition{
 		Type:    arov1alpha1.MachineValid,
@@ -63,7 +64,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 		Reason:  "CheckDone",
 	}
 
-	errs := r.checkMachines(ctx)
+	// the RP serves the VM sizes which it supports in addition to the
+	// built-in ones via the cluster object
+	errs := r.checkMachines(ctx, supportedVMSizes(instance))
 	if len(errs) > 0 {
 		cond.Status = operatorv1.ConditionFalse
 		cond.Reason = "CheckFailed"
@@ -70,10 +70,11 @@ func TestMachineReconciler(t *testing.T) {
 	}
 
 	tests := []struct {
-		name           string
-		request        ctrl.Request
-		objects        []client.Object
-		wantConditions []operatorv1.OperatorCondition
+		name             string
+		request          ctrl.Request
+		objects          []client.Object
+		supportedVMSizes []arov1alpha1.SupportedVMSize
+		wantConditions   []operatorv1.OperatorCondition
 	}{
 		{
 			name:    "valid",
@@ -95,6 +96,24 @@ func TestMachineReconciler(t *testing.T) {
 				Reason:  "CheckFailed",
 			}},
 		},
+		{
+			name:    "vm size supported by the RP",
+			objects: newFakeMao1("", "", "Standard_D4s_v9", ""),
+			supportedVMSizes: []arov1alpha1.SupportedVMSize{
+				{
+					VMSize:    "Standard_D4s_v9",
+					CoreCount: 4,
+					Family:    "standardDSv9Family",
+					Roles:     []string{"worker"},
+				},
+			},
+			wantConditions: []operatorv1.OperatorCondition{{
+				Type:    arov1alpha1.MachineValid,
+				Status:  operatorv1.ConditionTrue,
+				Message: "All machines valid",
+				Reason:  "CheckDone",
+			}},
+		},
 		{
 			name:    "wrong disk size",
 			objects: newFakeMao1("64", "", "", ""),
@@ -157,6 +176,7 @@ func TestMachineReconciler(t *testing.T) {
 					OperatorFlags: arov1alpha1.OperatorFlags{
 						operator.MachineEnabled: operator.FlagTrue,
 					},
+					SupportedVMSizes: tt.supportedVMSizes,
 				},
 			}
 
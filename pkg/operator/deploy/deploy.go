@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 	"text/template"
 	"time"
@@ -44,6 +45,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/util/clienthelper"
 	"github.com/Azure/ARO-RP/pkg/util/dynamichelper"
 	utilkubernetes "github.com/Azure/ARO-RP/pkg/util/kubernetes"
+	"github.com/Azure/ARO-RP/pkg/util/networkrules"
 	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
 	"github.com/Azure/ARO-RP/pkg/util/pullsecret"
 	"github.com/Azure/ARO-RP/pkg/util/ready"
@@ -56,6 +58,12 @@ var embeddedFiles embed.FS
 type Operator interface {
 	Install(context.Context) error
 	Update(context.Context) error
+	// Resources returns the operator's resources other than its secrets, for
+	// clusters where they are applied by a Hive SyncSet.
+	Resources(context.Context) ([]kruntime.Object, error)
+	// UpdateSecrets applies only the operator's secrets, which are not
+	// included in Resources.
+	UpdateSecrets(context.Context) error
 	CreateOrUpdateCredentialsRequest(context.Context) error
 	IsReady(context.Context) (bool, error)
 	Restart(context.Context, []string) error
@@ -72,6 +80,10 @@ type operator struct {
 	oc              *api.OpenShiftCluster
 	subscriptiondoc *api.SubscriptionDocument
 
+	// supportedVMSizes are the VM sizes which the RP supports in addition to
+	// the ones built into the operator
+	supportedVMSizes []*api.SupportedVMSize
+
 	arocli        aroclient.Interface
 	client        clienthelper.Interface
 	extensionscli extensionsclient.Interface
@@ -80,7 +92,7 @@ type operator struct {
 	dh            dynamichelper.Interface
 }
 
-func New(log *logrus.Entry, env env.Interface, oc *api.OpenShiftCluster, subscriptionDoc *api.SubscriptionDocument, arocli aroclient.Interface, client clienthelper.Interface, extensionscli extensionsclient.Interface, kubernetescli kubernetes.Interface, operatorcli operatorclient.Interface) (Operator, error) {
+func New(log *logrus.Entry, env env.Interface, oc *api.OpenShiftCluster, subscriptionDoc *api.SubscriptionDocument, supportedVMSizes []*api.SupportedVMSize, arocli aroclient.Interface, client clienthelper.Interface, extensionscli extensionsclient.Interface, kubernetescli kubernetes.Interface, operatorcli operatorclient.Interface) (Operator, error) {
 	restConfig, err := restconfig.RestConfig(env, oc)
 	if err != nil {
 		return nil, err
@@ -102,6 +114,8 @@ func New(log *logrus.Entry, env env.Interface, oc *api.OpenShiftCluster, subscri
 		operatorcli:     operatorcli,
 		dh:              dh,
 		subscriptiondoc: subscriptionDoc,
+
+		supportedVMSizes: supportedVMSizes,
 	}, nil
 }
 
@@ -299,12 +313,17 @@ func (o *operator) generateOperatorIdentitySecret() (*corev1.Secret, error) {
 	}, nil
 }
 
-func (o *operator) clusterObject() (*arov1alpha1.Cluster, error) {
+func (o *operator) clusterObject(ctx context.Context) (*arov1alpha1.Cluster, error) {
 	vnetID, _, err := apisubnet.Split(o.oc.Properties.MasterProfile.SubnetID)
 	if err != nil {
 		return nil, err
 	}
 
+	sidecarImages, err := o.env.LiveConfig().SidecarImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	domain := o.oc.Properties.ClusterProfile.Domain
 	if !strings.ContainsRune(domain, '.') {
 		domain += "." + o.env.Domain()
@@ -348,22 +367,27 @@ func (o *operator) clusterObject() (*arov1alpha1.Cluster, error) {
 			},
 			ServiceSubnets: serviceSubnets,
 			InternetChecker: arov1alpha1.InternetCheckerSpec{
-				URLs: []string{
-					fmt.Sprintf("https://%s/", o.env.ACRDomain()),
-					o.env.Environment().ActiveDirectoryEndpoint,
-					o.env.Environment().ResourceManagerEndpoint,
-					o.env.Environment().GenevaMonitoringEndpoint,
-				},
+				URLs: networkrules.ClusterEgressURLs(o.env.Environment(), o.env.ACRDomain()),
 			},
 
 			APIIntIP:                 o.oc.Properties.APIServerProfile.IntIP,
 			IngressIP:                ingressIP,
 			GatewayPrivateEndpointIP: o.oc.Properties.NetworkProfile.GatewayPrivateEndpointIP,
-			// Update the OperatorFlags from the version in the RP
-			OperatorFlags: arov1alpha1.OperatorFlags(o.oc.Properties.OperatorFlags),
+			// Update the OperatorFlags from the version in the RP, pointing
+			// the Geneva logging sidecars at the environment's images
+			OperatorFlags: arov1alpha1.OperatorFlags(pkgoperator.WithSidecarImages(o.oc.Properties.OperatorFlags, o.env.ACRDomain(), sidecarImages)),
 		},
 	}
 
+	for _, vmSize := range o.supportedVMSizes {
+		cluster.Spec.SupportedVMSizes = append(cluster.Spec.SupportedVMSizes, arov1alpha1.SupportedVMSize{
+			VMSize:    string(vmSize.Properties.VMSize),
+			CoreCount: vmSize.Properties.CoreCount,
+			Family:    vmSize.Properties.Family,
+			Roles:     slices.Clone(vmSize.Properties.Roles),
+		})
+	}
+
 	if o.oc.Properties.FeatureProfile.GatewayEnabled && o.oc.Properties.NetworkProfile.GatewayPrivateEndpointIP != "" {
 		cluster.Spec.GatewayDomains = append(o.env.GatewayDomains(), o.oc.Properties.ImageRegistryStorageAccountName+".blob."+o.env.Environment().StorageEndpointSuffix)
 	} else {
@@ -374,7 +398,7 @@ func (o *operator) clusterObject() (*arov1alpha1.Cluster, error) {
 }
 
 func (o *operator) SyncClusterObject(ctx context.Context) error {
-	resource, err := o.clusterObject()
+	resource, err := o.clusterObject(ctx)
 	if err != nil {
 		return err
 	}
@@ -389,7 +413,7 @@ func (o *operator) Install(ctx context.Context) error {
 
 	// If we're installing the Operator for the first time, include the Cluster
 	// object, otherwise it is updated separately
-	cluster, err := o.clusterObject()
+	cluster, err := o.clusterObject(ctx)
 	if err != nil {
 		return err
 	}
@@ -406,6 +430,49 @@ func (o *operator) Update(ctx context.Context) error {
 	return o.applyDeployment(ctx, resources)
 }
 
+func (o *operator) Resources(ctx context.Context) ([]kruntime.Object, error) {
+	resources, err := o.resources(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// prepare the full set so that the workloads are annotated with the
+	// hashes of the secrets they mount
+	err = dynamichelper.Prepare(resources)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]kruntime.Object, 0, len(resources))
+	for _, resource := range resources {
+		if _, ok := resource.(*corev1.Secret); !ok {
+			results = append(results, resource)
+		}
+	}
+
+	return results, nil
+}
+
+func (o *operator) UpdateSecrets(ctx context.Context) error {
+	resources, err := o.resources(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources {
+		if _, ok := resource.(*corev1.Secret); !ok {
+			continue
+		}
+
+		err = o.dh.Ensure(ctx, resource)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (o *operator) applyDeployment(ctx context.Context, resources []kruntime.Object) error {
 	err := dynamichelper.Prepare(resources)
 	if err != nil {
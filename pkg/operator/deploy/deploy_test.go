@@ -26,7 +26,9 @@ import (
 	pkgoperator "github.com/Azure/ARO-RP/pkg/operator"
 	"github.com/Azure/ARO-RP/pkg/util/clienthelper"
 	"github.com/Azure/ARO-RP/pkg/util/cmp"
+	mock_dynamichelper "github.com/Azure/ARO-RP/pkg/util/mocks/dynamichelper"
 	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	utiltls "github.com/Azure/ARO-RP/pkg/util/tls"
 	utilerror "github.com/Azure/ARO-RP/test/util/error"
 )
 
@@ -737,3 +739,75 @@ func TestTemplateManifests(t *testing.T) {
 		})
 	}
 }
+
+func TestResourcesAndUpdateSecrets(t *testing.T) {
+	ctx := context.Background()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	key, certs, err := utiltls.GenerateKeyAndCertificate("client", nil, nil, false, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_env := mock_env.NewMockInterface(controller)
+	_env.EXPECT().ACRDomain().AnyTimes().Return("intsvcdomain")
+	_env.EXPECT().AROOperatorImage().AnyTimes().Return("defaultaroimagefromenv")
+	_env.EXPECT().IsLocalDevelopmentMode().AnyTimes().Return(false)
+	_env.EXPECT().ClusterGenevaLoggingSecret().AnyTimes().Return(key, certs[0])
+
+	dh := mock_dynamichelper.NewMockInterface(controller)
+	dh.EXPECT().Ensure(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, objs ...kruntime.Object) {
+		for _, obj := range objs {
+			s, ok := obj.(*corev1.Secret)
+			if !ok || s.Name != pkgoperator.SecretName {
+				t.Errorf("unexpected object %T", obj)
+			}
+		}
+	}).Return(nil)
+
+	cv := &configv1.ClusterVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "version",
+		},
+		Status: configv1.ClusterVersionStatus{
+			History: []configv1.UpdateHistory{
+				{
+					State:   configv1.CompletedUpdate,
+					Version: "4.10.0",
+				},
+			},
+		},
+	}
+
+	o := &operator{
+		oc:     &api.OpenShiftCluster{},
+		env:    _env,
+		client: clienthelper.NewWithClient(logrus.NewEntry(logrus.StandardLogger()), ctrlfake.NewClientBuilder().WithObjects(cv).Build()),
+		dh:     dh,
+	}
+
+	resources, err := o.Resources(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var deployments int
+	for _, resource := range resources {
+		switch resource.(type) {
+		case *corev1.Secret:
+			t.Error("secret included in resources")
+		case *appsv1.Deployment:
+			deployments++
+		}
+	}
+	if deployments != 2 {
+		t.Errorf("found %d deployments, not 2", deployments)
+	}
+
+	err = o.UpdateSecrets(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
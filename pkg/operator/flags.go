@@ -3,6 +3,12 @@ package operator
 // Copyright (c) Microsoft Corporation.
 // Licensed under the Apache License 2.0.
 
+import (
+	"maps"
+
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
 const (
 	AlertWebhookEnabled                = "aro.alertwebhook.enabled"
 	AzureSubnetsEnabled                = "aro.azuresubnets.enabled"
@@ -14,6 +20,8 @@ const (
 	DnsmasqEnabled                     = "aro.dnsmasq.enabled"
 	RestartDnsmasqEnabled              = "aro.restartdnsmasq.enabled"
 	GenevaLoggingEnabled               = "aro.genevalogging.enabled"
+	GenevaLoggingFluentbitPullSpec     = "aro.genevalogging.fluentbit.pullSpec"
+	GenevaLoggingMDSDPullSpec          = "aro.genevalogging.mdsd.pullSpec"
 	ImageConfigEnabled                 = "aro.imageconfig.enabled"
 	IngressEnabled                     = "aro.ingress.enabled"
 	MachineEnabled                     = "aro.machine.enabled"
@@ -33,6 +41,8 @@ const (
 	MuoManaged                         = "rh.srep.muo.managed"
 	GuardrailsEnabled                  = "aro.guardrails.enabled"
 	GuardrailsDeployManaged            = "aro.guardrails.deploy.managed"
+	GuardrailsPoliciesManaged          = "aro.guardrails.policies.managed"     // default for the per-policy managed flags
+	GuardrailsPoliciesEnforcement      = "aro.guardrails.policies.enforcement" // default for the per-policy enforcement flags
 	CloudProviderConfigEnabled         = "aro.cloudproviderconfig.enabled"
 	ForceReconciliation                = "aro.forcereconciliation"
 	EtcHostsEnabled                    = "aro.etchosts.enabled" // true = enable etchosts controller
@@ -41,6 +51,16 @@ const (
 	FlagFalse                          = "false"
 )
 
+// CustomerOperatorFlags returns the subset of flags which customers may set
+// on their clusters through the preview API.
+func CustomerOperatorFlags() []string {
+	return []string{
+		AutosizedNodesEnabled,
+		BannerEnabled,
+		CheckerEnabled,
+	}
+}
+
 // DefaultOperatorFlags returns flags for new clusters
 // and ones that have not been AdminUpdated.
 func DefaultOperatorFlags() map[string]string {
@@ -80,3 +100,22 @@ func DefaultOperatorFlags() map[string]string {
 		EtcHostsManaged:                    FlagTrue,
 	}
 }
+
+// WithSidecarImages returns a copy of flags which sets the Geneva logging
+// sidecar pullspecs to the environment's sidecar images.  Pullspecs which a
+// cluster's flags already set are kept.
+func WithSidecarImages(flags map[string]string, acrDomain string, images version.SidecarImages) map[string]string {
+	out := maps.Clone(flags)
+	if out == nil {
+		out = map[string]string{}
+	}
+
+	if out[GenevaLoggingFluentbitPullSpec] == "" {
+		out[GenevaLoggingFluentbitPullSpec] = acrDomain + "/" + images.Fluentbit
+	}
+	if out[GenevaLoggingMDSDPullSpec] == "" {
+		out[GenevaLoggingMDSDPullSpec] = acrDomain + "/" + images.Mdsd
+	}
+
+	return out
+}
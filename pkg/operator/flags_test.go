@@ -0,0 +1,63 @@
+package operator
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+func TestWithSidecarImages(t *testing.T) {
+	images := version.SidecarImages{
+		Fluentbit: "fluentbit:new",
+		Mdsd:      "distroless/genevamdsd:new",
+	}
+
+	for _, tt := range []struct {
+		name  string
+		flags map[string]string
+		want  map[string]string
+	}{
+		{
+			name: "no flags",
+			want: map[string]string{
+				GenevaLoggingFluentbitPullSpec: "acrdomain/fluentbit:new",
+				GenevaLoggingMDSDPullSpec:      "acrdomain/distroless/genevamdsd:new",
+			},
+		},
+		{
+			name: "pullspecs set by the cluster are kept",
+			flags: map[string]string{
+				GenevaLoggingEnabled:      FlagTrue,
+				GenevaLoggingMDSDPullSpec: "elsewhere/genevamdsd:pinned",
+			},
+			want: map[string]string{
+				GenevaLoggingEnabled:           FlagTrue,
+				GenevaLoggingFluentbitPullSpec: "acrdomain/fluentbit:new",
+				GenevaLoggingMDSDPullSpec:      "elsewhere/genevamdsd:pinned",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var before map[string]string
+			if tt.flags != nil {
+				before = map[string]string{}
+				for k, v := range tt.flags {
+					before[k] = v
+				}
+			}
+
+			got := WithSidecarImages(tt.flags, "acrdomain", images)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(got)
+			}
+			if !reflect.DeepEqual(tt.flags, before) {
+				t.Error("flags were modified")
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/gorilla/mux"
 
+	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/portal/cluster"
 	"github.com/Azure/ARO-RP/pkg/portal/prometheus"
 )
@@ -29,6 +30,48 @@ type AdminOpenShiftCluster struct {
 	CreatedAt               string `json:"createdAt"`
 	LastModified            string `json:"lastModified"`
 	ProvisionedBy           string `json:"provisionedBy"`
+	Location                string `json:"location"`
+	LastError               string `json:"lastError,omitempty"`
+}
+
+func newAdminOpenShiftCluster(doc *api.OpenShiftClusterDocument) *AdminOpenShiftCluster {
+	ps := doc.OpenShiftCluster.Properties.ProvisioningState
+	fps := doc.OpenShiftCluster.Properties.FailedProvisioningState
+	subscription := "Unknown"
+	resourceGroup := "Unknown"
+	name := "Unknown"
+
+	if resource, err := azure.ParseResourceID(doc.OpenShiftCluster.ID); err == nil {
+		subscription = resource.SubscriptionID
+		resourceGroup = resource.ResourceGroup
+		name = resource.ResourceName
+	}
+
+	createdAt := "Unknown"
+	if !doc.OpenShiftCluster.Properties.CreatedAt.IsZero() {
+		createdAt = doc.OpenShiftCluster.Properties.CreatedAt.Format(time.RFC3339)
+	}
+
+	lastModified := "Unknown"
+	if doc.OpenShiftCluster.SystemData.LastModifiedAt != nil {
+		lastModified = doc.OpenShiftCluster.SystemData.LastModifiedAt.Format(time.RFC3339)
+	}
+
+	return &AdminOpenShiftCluster{
+		Key:                     doc.ID,
+		ResourceId:              doc.OpenShiftCluster.ID,
+		Name:                    name,
+		Subscription:            subscription,
+		ResourceGroup:           resourceGroup,
+		Version:                 doc.OpenShiftCluster.Properties.ClusterProfile.Version,
+		CreatedAt:               createdAt,
+		LastModified:            lastModified,
+		ProvisionedBy:           doc.OpenShiftCluster.Properties.ProvisionedBy,
+		ProvisioningState:       ps.String(),
+		FailedProvisioningState: fps.String(),
+		Location:                doc.OpenShiftCluster.Location,
+		LastError:               doc.OpenShiftCluster.Properties.LastAdminUpdateError,
+	}
 }
 
 func (p *portal) clusters(w http.ResponseWriter, r *http.Request) {
@@ -51,41 +94,7 @@ func (p *portal) clusters(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 
-		ps := doc.OpenShiftCluster.Properties.ProvisioningState
-		fps := doc.OpenShiftCluster.Properties.FailedProvisioningState
-		subscription := "Unknown"
-		resourceGroup := "Unknown"
-		name := "Unknown"
-
-		if resource, err := azure.ParseResourceID(doc.OpenShiftCluster.ID); err == nil {
-			subscription = resource.SubscriptionID
-			resourceGroup = resource.ResourceGroup
-			name = resource.ResourceName
-		}
-
-		createdAt := "Unknown"
-		if !doc.OpenShiftCluster.Properties.CreatedAt.IsZero() {
-			createdAt = doc.OpenShiftCluster.Properties.CreatedAt.Format(time.RFC3339)
-		}
-
-		lastModified := "Unknown"
-		if doc.OpenShiftCluster.SystemData.LastModifiedAt != nil {
-			lastModified = doc.OpenShiftCluster.SystemData.LastModifiedAt.Format(time.RFC3339)
-		}
-
-		clusters = append(clusters, &AdminOpenShiftCluster{
-			Key:                     doc.ID,
-			ResourceId:              doc.OpenShiftCluster.ID,
-			Name:                    name,
-			Subscription:            subscription,
-			ResourceGroup:           resourceGroup,
-			Version:                 doc.OpenShiftCluster.Properties.ClusterProfile.Version,
-			CreatedAt:               createdAt,
-			LastModified:            lastModified,
-			ProvisionedBy:           doc.OpenShiftCluster.Properties.ProvisionedBy,
-			ProvisioningState:       ps.String(),
-			FailedProvisioningState: fps.String(),
-		})
+		clusters = append(clusters, newAdminOpenShiftCluster(doc))
 	}
 
 	sort.SliceStable(clusters, func(i, j int) bool { return strings.Compare(clusters[i].Key, clusters[j].Key) < 0 })
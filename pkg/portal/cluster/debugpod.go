@@ -0,0 +1,318 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/websocket"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+
+	"github.com/Azure/ARO-RP/pkg/util/pointerutils"
+)
+
+const (
+	DebugPodNamespace = "default"
+
+	// DebugPodLabel is set on every debug pod created by the portal
+	DebugPodLabel = "aro.openshift.io/portal-debug"
+
+	// debugPodUserAnnotation records which SRE a debug pod belongs to
+	debugPodUserAnnotation = "aro.openshift.io/portal-debug-user"
+
+	debugPodContainer = "debug"
+
+	debugPodStartTimeout = 2 * time.Minute
+	debugPodPollInterval = 2 * time.Second
+
+	// execProtocol is the Kubernetes exec streaming protocol spoken over the
+	// websocket.  Each message is prefixed by a byte identifying its stream.
+	execProtocol     = "v4.channel.k8s.io"
+	execStreamStdin  = 0
+	execStreamStdout = 1
+	execStreamStderr = 2
+	execStreamError  = 3
+	execStreamResize = 4
+)
+
+// debugShell runs a login shell in the node's root filesystem, like
+// `oc debug node`
+var debugShell = []string{"chroot", "/host", "/bin/bash", "-l"}
+
+// Terminal is an interactive terminal session in a pod.
+type Terminal interface {
+	// Output returns the next chunk of terminal output.  It returns io.EOF
+	// once the shell has exited.
+	Output() ([]byte, error)
+	Input([]byte) error
+	Resize(width, height uint16) error
+	Close() error
+}
+
+// newDebugPod returns a privileged pod running image on the given node with
+// the node's root filesystem mounted at /host.  The pod kills itself after its
+// lifetime, so that it does not outlive the session if the portal fails to
+// delete it.
+func newDebugPod(node, username, image string, lifetime time.Duration) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "aro-portal-debug-",
+			Namespace:    DebugPodNamespace,
+			Labels: map[string]string{
+				DebugPodLabel: "true",
+			},
+			Annotations: map[string]string{
+				debugPodUserAnnotation: username,
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:                      node,
+			HostNetwork:                   true,
+			HostPID:                       true,
+			HostIPC:                       true,
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			ActiveDeadlineSeconds:         pointerutils.ToPtr(int64(lifetime / time.Second)),
+			TerminationGracePeriodSeconds: pointerutils.ToPtr(int64(0)),
+			Tolerations: []corev1.Toleration{
+				{
+					Operator: corev1.TolerationOpExists,
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:    debugPodContainer,
+					Image:   image,
+					Command: []string{"sleep", strconv.FormatInt(int64(lifetime/time.Second), 10)},
+					SecurityContext: &corev1.SecurityContext{
+						Privileged: pointerutils.ToPtr(true),
+						RunAsUser:  pointerutils.ToPtr(int64(0)),
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      "host",
+							MountPath: "/host",
+						},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "host",
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{
+							Path: "/",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *client) CreateDebugPod(ctx context.Context, node, username, image string, lifetime time.Duration) (*corev1.Pod, error) {
+	return c.fetcher.createDebugPod(ctx, node, username, image, lifetime)
+}
+
+func (c *client) DeleteDebugPod(ctx context.Context, name string) error {
+	return c.fetcher.kubernetesCli.CoreV1().Pods(DebugPodNamespace).Delete(ctx, name, metav1.DeleteOptions{
+		GracePeriodSeconds: pointerutils.ToPtr(int64(0)),
+	})
+}
+
+func (c *client) DebugTerminal(ctx context.Context, name string) (Terminal, error) {
+	return c.fetcher.execTerminal(ctx, DebugPodNamespace, name, debugPodContainer, debugShell)
+}
+
+// createDebugPod creates a debug pod on the node and waits for it to start.
+// If it does not start, it is deleted again.
+func (f *realFetcher) createDebugPod(ctx context.Context, node, username, image string, lifetime time.Duration) (*corev1.Pod, error) {
+	_, err := f.kubernetesCli.CoreV1().Nodes().Get(ctx, node, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	pod, err := f.kubernetesCli.CoreV1().Pods(DebugPodNamespace).Create(ctx, newDebugPod(node, username, image, lifetime), metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, debugPodStartTimeout)
+	defer cancel()
+
+	err = wait.PollImmediateUntil(debugPodPollInterval, func() (bool, error) {
+		pod, err = f.kubernetesCli.CoreV1().Pods(DebugPodNamespace).Get(timeoutCtx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		switch pod.Status.Phase {
+		case corev1.PodRunning:
+			return true, nil
+		case corev1.PodSucceeded, corev1.PodFailed:
+			return false, fmt.Errorf("debug pod %s exited: %s", pod.Name, pod.Status.Message)
+		}
+
+		return false, nil
+	}, timeoutCtx.Done())
+	if err != nil {
+		_ = f.kubernetesCli.CoreV1().Pods(DebugPodNamespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{})
+		return nil, err
+	}
+
+	return pod, nil
+}
+
+// execTerminal runs command with a TTY in the given container and returns a
+// terminal attached to it.  It speaks the websocket variant of the exec
+// protocol: unlike SPDY, websocket connections can be dialed through the
+// portal's proxy dialer.
+func (f *realFetcher) execTerminal(ctx context.Context, namespace, pod, container string, command []string) (Terminal, error) {
+	u, err := url.Parse(f.restConfig.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "443")
+	}
+
+	q := url.Values{
+		"container": []string{container},
+		"stdin":     []string{"true"},
+		"stdout":    []string{"true"},
+		"tty":       []string{"true"},
+		"command":   command,
+	}
+
+	execURL := &url.URL{
+		Scheme:   "wss",
+		Host:     u.Host,
+		Path:     path.Join(u.Path, "/api/v1/namespaces", namespace, "pods", pod, "exec"),
+		RawQuery: q.Encode(),
+	}
+
+	config, err := websocket.NewConfig(execURL.String(), "https://"+u.Host)
+	if err != nil {
+		return nil, err
+	}
+	config.Protocol = []string{execProtocol}
+	if f.restConfig.BearerToken != "" {
+		config.Header.Set("Authorization", "Bearer "+f.restConfig.BearerToken)
+	}
+
+	tlsConfig, err := rest.TLSConfigFor(f.restConfig)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil {
+		return nil, errors.New("kubeconfig has no TLS configuration")
+	}
+	tlsConfig = tlsConfig.Clone()
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = u.Hostname()
+	}
+
+	dial := f.restConfig.Dial
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	conn, err := dial(ctx, "tcp", host)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConn := tls.Client(conn, tlsConfig)
+	err = tlsConn.HandshakeContext(ctx)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	ws, err := websocket.NewClient(config, tlsConn)
+	if err != nil {
+		tlsConn.Close()
+		return nil, err
+	}
+
+	return &execTerminal{ws: ws}, nil
+}
+
+// execTerminal is a Terminal over an exec websocket
+type execTerminal struct {
+	ws *websocket.Conn
+}
+
+func (t *execTerminal) Output() ([]byte, error) {
+	for {
+		var b []byte
+		err := websocket.Message.Receive(t.ws, &b)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(b) == 0 {
+			continue
+		}
+
+		switch b[0] {
+		case execStreamStdout, execStreamStderr:
+			if len(b) > 1 {
+				return b[1:], nil
+			}
+
+		case execStreamError:
+			var status metav1.Status
+			err = json.Unmarshal(b[1:], &status)
+			if err != nil {
+				return nil, err
+			}
+
+			// a shell exiting non-zero (e.g. after a failed last command)
+			// is still the normal end of the session
+			if status.Status == metav1.StatusSuccess || status.Reason == "NonZeroExitCode" {
+				return nil, io.EOF
+			}
+
+			return nil, errors.New(status.Message)
+		}
+	}
+}
+
+func (t *execTerminal) Input(b []byte) error {
+	return websocket.Message.Send(t.ws, append([]byte{execStreamStdin}, b...))
+}
+
+func (t *execTerminal) Resize(width, height uint16) error {
+	b, err := json.Marshal(&struct {
+		Width  uint16
+		Height uint16
+	}{
+		Width:  width,
+		Height: height,
+	})
+	if err != nil {
+		return err
+	}
+
+	return websocket.Message.Send(t.ws, append([]byte{execStreamResize}, b...))
+}
+
+func (t *execTerminal) Close() error {
+	return t.ws.Close()
+}
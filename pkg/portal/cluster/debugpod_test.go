@@ -0,0 +1,225 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"golang.org/x/net/websocket"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	ktesting "k8s.io/client-go/testing"
+
+	testlog "github.com/Azure/ARO-RP/test/util/log"
+)
+
+func TestCreateDebugPod(t *testing.T) {
+	ctx := context.Background()
+	debugImage := "arosvc.azurecr.io/ubi8/ubi-minimal@sha256:0000000000000000000000000000000000000000000000000000000000000000"
+
+	_, log := testlog.New()
+
+	for _, tt := range []struct {
+		name    string
+		node    string
+		phase   corev1.PodPhase
+		wantErr string
+	}{
+		{
+			name:  "running",
+			node:  "master-0",
+			phase: corev1.PodRunning,
+		},
+		{
+			name:    "failed",
+			node:    "master-0",
+			phase:   corev1.PodFailed,
+			wantErr: "debug pod aro-portal-debug-1 exited: ",
+		},
+		{
+			name:    "no such node",
+			node:    "master-1",
+			wantErr: `nodes "master-1" not found`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			kubernetesCli := fake.NewSimpleClientset(&corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "master-0",
+				},
+			})
+			kubernetesCli.PrependReactor("create", "pods", func(action ktesting.Action) (bool, runtime.Object, error) {
+				pod := action.(ktesting.CreateAction).GetObject().(*corev1.Pod)
+				pod.Name = pod.GenerateName + "1"
+				pod.Status.Phase = tt.phase
+				return false, nil, nil
+			})
+
+			c := &client{
+				log: log,
+				fetcher: &realFetcher{
+					log:           log,
+					kubernetesCli: kubernetesCli,
+				},
+			}
+
+			pod, err := c.CreateDebugPod(ctx, tt.node, "user@example.com", debugImage, time.Hour)
+			if err != nil && err.Error() != tt.wantErr ||
+				err == nil && tt.wantErr != "" {
+				t.Fatal(err)
+			}
+
+			pods, err := kubernetesCli.CoreV1().Pods(DebugPodNamespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantErr != "" {
+				if len(pods.Items) != 0 {
+					t.Error(len(pods.Items))
+				}
+				return
+			}
+
+			if len(pods.Items) != 1 {
+				t.Fatal(len(pods.Items))
+			}
+			if pod.Spec.NodeName != tt.node {
+				t.Error(pod.Spec.NodeName)
+			}
+			if *pod.Spec.ActiveDeadlineSeconds != 3600 {
+				t.Error(*pod.Spec.ActiveDeadlineSeconds)
+			}
+			if pod.Annotations[debugPodUserAnnotation] != "user@example.com" {
+				t.Error(pod.Annotations)
+			}
+			if pod.Spec.Containers[0].Image != debugImage {
+				t.Error(pod.Spec.Containers[0].Image)
+			}
+		})
+	}
+}
+
+func TestExecTerminal(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		gotPath     string
+		gotQuery    map[string][]string
+		gotAuth     string
+		gotMessages [][]byte
+	)
+	done := make(chan struct{})
+
+	srv := httptest.NewTLSServer(websocket.Server{
+		Handshake: func(config *websocket.Config, r *http.Request) error {
+			gotPath = r.URL.Path
+			gotQuery = r.URL.Query()
+			gotAuth = r.Header.Get("Authorization")
+			config.Protocol = []string{execProtocol}
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			defer close(done)
+
+			_ = websocket.Message.Send(ws, append([]byte{execStreamStdout}, "sh-4.4# "...))
+
+			for i := 0; i < 2; i++ {
+				var b []byte
+				if websocket.Message.Receive(ws, &b) != nil {
+					return
+				}
+				gotMessages = append(gotMessages, b)
+			}
+
+			_ = websocket.Message.Send(ws, append([]byte{execStreamStderr}, "exit\n"...))
+
+			status, _ := json.Marshal(&metav1.Status{Status: metav1.StatusFailure, Reason: "NonZeroExitCode"})
+			_ = websocket.Message.Send(ws, append([]byte{execStreamError}, status...))
+		},
+	})
+	defer srv.Close()
+
+	f := &realFetcher{
+		restConfig: &rest.Config{
+			Host:        srv.URL,
+			BearerToken: "token",
+			TLSClientConfig: rest.TLSClientConfig{
+				CAData: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}),
+			},
+		},
+	}
+
+	term, err := f.execTerminal(ctx, "default", "aro-portal-debug-1", "debug", debugShell)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+
+	b, err := term.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "sh-4.4# " {
+		t.Error(string(b))
+	}
+
+	err = term.Input([]byte("exit\r"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = term.Resize(80, 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err = term.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "exit\n" {
+		t.Error(string(b))
+	}
+
+	_, err = term.Output()
+	if err != io.EOF {
+		t.Error(err)
+	}
+
+	<-done
+
+	if gotPath != "/api/v1/namespaces/default/pods/aro-portal-debug-1/exec" {
+		t.Error(gotPath)
+	}
+	for _, diff := range deep.Equal(gotQuery, map[string][]string{
+		"command":   {"chroot", "/host", "/bin/bash", "-l"},
+		"container": {"debug"},
+		"stdin":     {"true"},
+		"stdout":    {"true"},
+		"tty":       {"true"},
+	}) {
+		t.Error(diff)
+	}
+	if gotAuth != "Bearer token" {
+		t.Error(gotAuth)
+	}
+	for _, diff := range deep.Equal(gotMessages, [][]byte{
+		append([]byte{execStreamStdin}, "exit\r"...),
+		append([]byte{execStreamResize}, `{"Width":80,"Height":24}`...),
+	}) {
+		t.Error(diff)
+	}
+}
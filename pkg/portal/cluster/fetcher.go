@@ -5,13 +5,17 @@ package cluster
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"time"
 
 	configclient "github.com/openshift/client-go/config/clientset/versioned"
 	machineclient "github.com/openshift/client-go/machine/clientset/versioned"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/proxy"
@@ -26,6 +30,13 @@ type FetchClient interface {
 	Machines(context.Context) (*MachineListInformation, error)
 	MachineSets(context.Context) (*MachineSetListInformation, error)
 	Statistics(context.Context, *http.Client, string, time.Duration, time.Time, string) ([]Metrics, error)
+	PromQuery(ctx context.Context, httpClient *http.Client, prometheusURL, query string, r *v1.Range, t time.Time) (*PromQueryResult, error)
+	Timeline(ctx context.Context, since time.Time) ([]TimelineEvent, error)
+	PodLogs(ctx context.Context, namespace, pod, container string, follow bool, tailLines int64) (io.ReadCloser, error)
+	MustGather(ctx context.Context, w io.Writer, progress func(*MustGatherProgress) error) error
+	CreateDebugPod(ctx context.Context, node, username, image string, lifetime time.Duration) (*corev1.Pod, error)
+	DeleteDebugPod(ctx context.Context, name string) error
+	DebugTerminal(ctx context.Context, name string) (Terminal, error)
 }
 
 // client is an implementation of FetchClient. It currently contains a "fetcher"
@@ -45,6 +56,7 @@ type client struct {
 // structures. The concrete implementation of FetchClient wraps this.
 type realFetcher struct {
 	log           *logrus.Entry
+	restConfig    *rest.Config
 	configCli     configclient.Interface
 	kubernetesCli kubernetes.Interface
 	machineClient machineclient.Interface
@@ -76,6 +88,7 @@ func newRealFetcher(log *logrus.Entry, dialer proxy.Dialer, doc *api.OpenShiftCl
 
 	return &realFetcher{
 		log:           log,
+		restConfig:    restConfig,
 		configCli:     configCli,
 		kubernetesCli: kubernetesCli,
 		machineClient: machineClient,
@@ -0,0 +1,247 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	mustGatherLogTailLines  = 1000
+	mustGatherLogLimitBytes = 1024 * 1024
+
+	machineAPINamespace = "openshift-machine-api"
+)
+
+// MustGatherProgress reports how far a must-gather collection has got.
+type MustGatherProgress struct {
+	Step  string `json:"step"`
+	Done  int    `json:"done"`
+	Total int    `json:"total"`
+}
+
+func (c *client) MustGather(ctx context.Context, w io.Writer, progress func(*MustGatherProgress) error) error {
+	return c.fetcher.mustGather(ctx, w, progress)
+}
+
+// mustGatherArchive writes files into a gzipped tarball laid out like the
+// output of `oc adm must-gather`.
+type mustGatherArchive struct {
+	gz  *gzip.Writer
+	tw  *tar.Writer
+	now time.Time
+}
+
+func newMustGatherArchive(w io.Writer, now time.Time) *mustGatherArchive {
+	gz := gzip.NewWriter(w)
+
+	return &mustGatherArchive{
+		gz:  gz,
+		tw:  tar.NewWriter(gz),
+		now: now,
+	}
+}
+
+func (a *mustGatherArchive) writeFile(name string, b []byte) error {
+	err := a.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     path.Join("must-gather", name),
+		Mode:     0644,
+		Size:     int64(len(b)),
+		ModTime:  a.now,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = a.tw.Write(b)
+	return err
+}
+
+// writeJSON writes o as JSON, or if it could not be fetched, the error which
+// prevented it.  Only errors writing the archive itself are returned.
+func (a *mustGatherArchive) writeJSON(name string, o interface{}, fetchErr error) error {
+	if fetchErr != nil {
+		return a.writeFile(name+".error", []byte(fetchErr.Error()+"\n"))
+	}
+
+	b, err := json.MarshalIndent(o, "", "    ")
+	if err != nil {
+		return a.writeFile(name+".error", []byte(err.Error()+"\n"))
+	}
+
+	return a.writeFile(name, b)
+}
+
+func (a *mustGatherArchive) close() error {
+	err := a.tw.Close()
+	if err != nil {
+		return err
+	}
+
+	return a.gz.Close()
+}
+
+type mustGatherStep struct {
+	name string
+	f    func(context.Context, *mustGatherArchive) error
+}
+
+// mustGather collects the cluster-scoped state of the cluster, plus the
+// events, pods and recent pod logs of the platform namespaces, into a
+// gzipped tarball written to w.  Unlike `oc adm must-gather` it reads
+// everything through the Kubernetes API rather than running a gather pod on
+// the cluster.  Resources which cannot be fetched are recorded in the
+// archive rather than failing the collection.  progress is called before
+// each step; if it returns an error the collection is abandoned.
+func (f *realFetcher) mustGather(ctx context.Context, w io.Writer, progress func(*MustGatherProgress) error) error {
+	a := newMustGatherArchive(w, time.Now().UTC())
+
+	namespaces, err := f.kubernetesCli.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	var platformNamespaces []string
+	for _, ns := range namespaces.Items {
+		if ns.Name == "default" || strings.HasPrefix(ns.Name, "openshift") || strings.HasPrefix(ns.Name, "kube-") {
+			platformNamespaces = append(platformNamespaces, ns.Name)
+		}
+	}
+
+	steps := []mustGatherStep{
+		{
+			name: "clusterversions",
+			f: func(ctx context.Context, a *mustGatherArchive) error {
+				cvs, err := f.configCli.ConfigV1().ClusterVersions().List(ctx, metav1.ListOptions{})
+				return a.writeJSON("cluster-scoped-resources/config.openshift.io/clusterversions.json", cvs, err)
+			},
+		},
+		{
+			name: "clusteroperators",
+			f: func(ctx context.Context, a *mustGatherArchive) error {
+				cos, err := f.configCli.ConfigV1().ClusterOperators().List(ctx, metav1.ListOptions{})
+				return a.writeJSON("cluster-scoped-resources/config.openshift.io/clusteroperators.json", cos, err)
+			},
+		},
+		{
+			name: "nodes",
+			f: func(ctx context.Context, a *mustGatherArchive) error {
+				nodes, err := f.kubernetesCli.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+				return a.writeJSON("cluster-scoped-resources/core/nodes.json", nodes, err)
+			},
+		},
+		{
+			name: "machines",
+			f: func(ctx context.Context, a *mustGatherArchive) error {
+				machines, err := f.machineClient.MachineV1beta1().Machines(machineAPINamespace).List(ctx, metav1.ListOptions{})
+				err = a.writeJSON("namespaces/"+machineAPINamespace+"/machine.openshift.io/machines.json", machines, err)
+				if err != nil {
+					return err
+				}
+
+				machineSets, err := f.machineClient.MachineV1beta1().MachineSets(machineAPINamespace).List(ctx, metav1.ListOptions{})
+				return a.writeJSON("namespaces/"+machineAPINamespace+"/machine.openshift.io/machinesets.json", machineSets, err)
+			},
+		},
+	}
+
+	for _, namespace := range platformNamespaces {
+		namespace := namespace
+		steps = append(steps, mustGatherStep{
+			name: "namespaces/" + namespace,
+			f: func(ctx context.Context, a *mustGatherArchive) error {
+				return f.mustGatherNamespace(ctx, a, namespace)
+			},
+		})
+	}
+
+	for i, step := range steps {
+		err = progress(&MustGatherProgress{
+			Step:  step.name,
+			Done:  i,
+			Total: len(steps),
+		})
+		if err != nil {
+			return err
+		}
+
+		err = step.f(ctx, a)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = progress(&MustGatherProgress{
+		Done:  len(steps),
+		Total: len(steps),
+	})
+	if err != nil {
+		return err
+	}
+
+	return a.close()
+}
+
+func (f *realFetcher) mustGatherNamespace(ctx context.Context, a *mustGatherArchive, namespace string) error {
+	events, err := f.kubernetesCli.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	err = a.writeJSON("namespaces/"+namespace+"/core/events.json", events, err)
+	if err != nil {
+		return err
+	}
+
+	pods, err := f.kubernetesCli.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	err = a.writeJSON("namespaces/"+namespace+"/core/pods.json", pods, err)
+	if err != nil || pods == nil {
+		return err
+	}
+
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			dir := "namespaces/" + namespace + "/pods/" + pod.Name + "/" + status.Name + "/logs/"
+
+			err = f.mustGatherLogs(ctx, a, dir+"current.log", namespace, pod.Name, status.Name, false)
+			if err != nil {
+				return err
+			}
+
+			if status.RestartCount > 0 {
+				err = f.mustGatherLogs(ctx, a, dir+"previous.log", namespace, pod.Name, status.Name, true)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (f *realFetcher) mustGatherLogs(ctx context.Context, a *mustGatherArchive, name, namespace, pod, container string, previous bool) error {
+	tailLines := int64(mustGatherLogTailLines)
+	limitBytes := int64(mustGatherLogLimitBytes)
+
+	b, err := f.kubernetesCli.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{
+		Container:  container,
+		Previous:   previous,
+		TailLines:  &tailLines,
+		LimitBytes: &limitBytes,
+	}).DoRaw(ctx)
+	if err != nil {
+		return a.writeFile(name+".error", []byte(fmt.Sprintf("%s\n", err)))
+	}
+
+	return a.writeFile(name, b)
+}
@@ -0,0 +1,149 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/go-test/deep"
+	configv1 "github.com/openshift/api/config/v1"
+	configfake "github.com/openshift/client-go/config/clientset/versioned/fake"
+	machinefake "github.com/openshift/client-go/machine/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	testlog "github.com/Azure/ARO-RP/test/util/log"
+)
+
+func TestMustGather(t *testing.T) {
+	ctx := context.Background()
+
+	_, log := testlog.New()
+
+	newClient := func() *client {
+		return &client{
+			log: log,
+			fetcher: &realFetcher{
+				log: log,
+				configCli: configfake.NewSimpleClientset(
+					&configv1.ClusterOperator{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "dns",
+						},
+					},
+				),
+				kubernetesCli: fake.NewSimpleClientset(
+					&corev1.Namespace{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "openshift-dns",
+						},
+					},
+					&corev1.Namespace{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "customer",
+						},
+					},
+					&corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "dns-default-abcde",
+							Namespace: "openshift-dns",
+						},
+						Status: corev1.PodStatus{
+							ContainerStatuses: []corev1.ContainerStatus{
+								{
+									Name:         "dns",
+									RestartCount: 1,
+								},
+							},
+						},
+					},
+					&corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "app",
+							Namespace: "customer",
+						},
+					},
+				),
+				machineClient: machinefake.NewSimpleClientset(),
+			},
+		}
+	}
+
+	t.Run("archive", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		var progress []MustGatherProgress
+
+		err := newClient().MustGather(ctx, buf, func(p *MustGatherProgress) error {
+			progress = append(progress, *p)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, diff := range deep.Equal(progress, []MustGatherProgress{
+			{Step: "clusterversions", Done: 0, Total: 5},
+			{Step: "clusteroperators", Done: 1, Total: 5},
+			{Step: "nodes", Done: 2, Total: 5},
+			{Step: "machines", Done: 3, Total: 5},
+			{Step: "namespaces/openshift-dns", Done: 4, Total: 5},
+			{Done: 5, Total: 5},
+		}) {
+			t.Error(diff)
+		}
+
+		gz, err := gzip.NewReader(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var names []string
+		tr := tar.NewReader(gz)
+		for {
+			h, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			names = append(names, h.Name)
+		}
+
+		for _, diff := range deep.Equal(names, []string{
+			"must-gather/cluster-scoped-resources/config.openshift.io/clusterversions.json",
+			"must-gather/cluster-scoped-resources/config.openshift.io/clusteroperators.json",
+			"must-gather/cluster-scoped-resources/core/nodes.json",
+			"must-gather/namespaces/openshift-machine-api/machine.openshift.io/machines.json",
+			"must-gather/namespaces/openshift-machine-api/machine.openshift.io/machinesets.json",
+			"must-gather/namespaces/openshift-dns/core/events.json",
+			"must-gather/namespaces/openshift-dns/core/pods.json",
+			"must-gather/namespaces/openshift-dns/pods/dns-default-abcde/dns/logs/current.log",
+			"must-gather/namespaces/openshift-dns/pods/dns-default-abcde/dns/logs/previous.log",
+		}) {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("abandoned", func(t *testing.T) {
+		errGone := errors.New("client went away")
+
+		err := newClient().MustGather(ctx, io.Discard, func(p *MustGatherProgress) error {
+			if p.Step == "nodes" {
+				return errGone
+			}
+			return nil
+		})
+		if err != errGone {
+			t.Error(err)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// podLogsLimitBytes bounds how much log output a single request may read
+// when not following, so that a chatty container cannot exhaust the portal.
+const podLogsLimitBytes = 10 * 1024 * 1024
+
+func (f *realFetcher) PodLogs(ctx context.Context, namespace, pod, container string, follow bool, tailLines int64) (io.ReadCloser, error) {
+	opts := &corev1.PodLogOptions{
+		Container: container,
+		Follow:    follow,
+		TailLines: &tailLines,
+	}
+	if !follow {
+		limitBytes := int64(podLogsLimitBytes)
+		opts.LimitBytes = &limitBytes
+	}
+
+	return f.kubernetesCli.CoreV1().Pods(namespace).GetLogs(pod, opts).Stream(ctx)
+}
+
+func (c *client) PodLogs(ctx context.Context, namespace, pod, container string, follow bool, tailLines int64) (io.ReadCloser, error) {
+	return c.fetcher.PodLogs(ctx, namespace, pod, container, follow, tailLines)
+}
@@ -0,0 +1,114 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	prometheusAPI "github.com/prometheus/client_golang/api"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+const (
+	// PromQueryMaxSeries bounds the number of series returned by a query; any
+	// further series are dropped and the result is marked as truncated.
+	PromQueryMaxSeries = 500
+
+	// PromQueryMaxPoints bounds the number of points per series which a range
+	// query may request.
+	PromQueryMaxPoints = 1000
+
+	promQueryTimeout = 30 * time.Second
+)
+
+// PromQueryResult is the result of an arbitrary PromQL query.  Instant vector
+// and scalar results are returned as series with a single value.
+type PromQueryResult struct {
+	ResultType string    `json:"resulttype"`
+	Series     []Metrics `json:"series"`
+	Truncated  bool      `json:"truncated,omitempty"`
+	Warnings   []string  `json:"warnings,omitempty"`
+}
+
+func (c *client) PromQuery(ctx context.Context, httpClient *http.Client, prometheusURL, query string, r *v1.Range, t time.Time) (*PromQueryResult, error) {
+	return c.fetcher.promQuery(ctx, httpClient, prometheusURL, query, r, t)
+}
+
+// promQuery runs query as a range query if r is set, otherwise as an instant
+// query at t.
+func (f *realFetcher) promQuery(ctx context.Context, httpClient *http.Client, prometheusURL, query string, r *v1.Range, t time.Time) (*PromQueryResult, error) {
+	client, err := prometheusAPI.NewClient(prometheusAPI.Config{
+		Address:      prometheusURL,
+		RoundTripper: httpClient.Transport,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	v1api := v1.NewAPI(client)
+
+	var value model.Value
+	var warnings v1.Warnings
+	if r != nil {
+		value, warnings, err = v1api.QueryRange(ctx, query, *r, v1.WithTimeout(promQueryTimeout))
+	} else {
+		value, warnings, err = v1api.Query(ctx, query, t, v1.WithTimeout(promQueryTimeout))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PromQueryResult{
+		ResultType: value.Type().String(),
+		Warnings:   warnings,
+	}
+
+	switch value := value.(type) {
+	case model.Matrix:
+		if len(value) > PromQueryMaxSeries {
+			value = value[:PromQueryMaxSeries]
+			result.Truncated = true
+		}
+		result.Series = convertToTypeMetrics(value)
+
+	case model.Vector:
+		if len(value) > PromQueryMaxSeries {
+			value = value[:PromQueryMaxSeries]
+			result.Truncated = true
+		}
+		result.Series = make([]Metrics, 0, len(value))
+		for _, sample := range value {
+			result.Series = append(result.Series, Metrics{
+				Name: sample.Metric.String(),
+				Value: []MetricValue{
+					{
+						Timestamp: sample.Timestamp.Time().UTC(),
+						Value:     float64(sample.Value),
+					},
+				},
+			})
+		}
+
+	case *model.Scalar:
+		result.Series = []Metrics{
+			{
+				Value: []MetricValue{
+					{
+						Timestamp: value.Timestamp.Time().UTC(),
+						Value:     float64(value.Value),
+					},
+				},
+			},
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported result type %q", value.Type())
+	}
+
+	return result, nil
+}
@@ -0,0 +1,142 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	monitorcluster "github.com/Azure/ARO-RP/pkg/monitor/cluster"
+)
+
+const (
+	TimelineSourceKubernetes = "kubernetes"
+	TimelineSourceMonitor    = "monitor"
+	TimelineSourceRP         = "rp"
+
+	TimelineSeverityInfo    = "info"
+	TimelineSeverityWarning = "warning"
+	TimelineSeverityError   = "error"
+)
+
+// TimelineEvent is a single entry in a cluster's event timeline.
+type TimelineEvent struct {
+	Time     time.Time `json:"time"`
+	Source   string    `json:"source"`
+	Severity string    `json:"severity"`
+	Object   string    `json:"object"`
+	Reason   string    `json:"reason"`
+	Message  string    `json:"message"`
+}
+
+func (c *client) Timeline(ctx context.Context, since time.Time) ([]TimelineEvent, error) {
+	return c.fetcher.timeline(ctx, since)
+}
+
+// timeline returns the Kubernetes events and current monitor findings on the
+// cluster which happened since the given time.  Monitor findings are the
+// unexpected cluster operator and node conditions which the monitor reports,
+// dated by when the condition last changed.
+func (f *realFetcher) timeline(ctx context.Context, since time.Time) ([]TimelineEvent, error) {
+	var events []TimelineEvent
+
+	kubeEvents, err := f.kubernetesCli.CoreV1().Events("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range kubeEvents.Items {
+		t := kubernetesEventTime(&e)
+		if t.Before(since) {
+			continue
+		}
+
+		severity := TimelineSeverityInfo
+		if e.Type == corev1.EventTypeWarning {
+			severity = TimelineSeverityWarning
+		}
+
+		object := e.InvolvedObject.Kind + "/" + e.InvolvedObject.Name
+		if e.InvolvedObject.Namespace != "" {
+			object = e.InvolvedObject.Namespace + "/" + object
+		}
+
+		events = append(events, TimelineEvent{
+			Time:     t,
+			Source:   TimelineSourceKubernetes,
+			Severity: severity,
+			Object:   object,
+			Reason:   e.Reason,
+			Message:  e.Message,
+		})
+	}
+
+	cos, err := f.configCli.ConfigV1().ClusterOperators().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, co := range cos.Items {
+		for _, c := range co.Status.Conditions {
+			if monitorcluster.ClusterOperatorConditionIsExpected(&co, &c) || c.LastTransitionTime.Time.Before(since) {
+				continue
+			}
+
+			events = append(events, TimelineEvent{
+				Time:     c.LastTransitionTime.Time,
+				Source:   TimelineSourceMonitor,
+				Severity: TimelineSeverityError,
+				Object:   "ClusterOperator/" + co.Name,
+				Reason:   fmt.Sprintf("%s=%s", c.Type, c.Status),
+				Message:  c.Message,
+			})
+		}
+	}
+
+	nodes, err := f.kubernetesCli.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, n := range nodes.Items {
+		for _, c := range n.Status.Conditions {
+			if monitorcluster.NodeConditionIsExpected(&c) || c.LastTransitionTime.Time.Before(since) {
+				continue
+			}
+
+			events = append(events, TimelineEvent{
+				Time:     c.LastTransitionTime.Time,
+				Source:   TimelineSourceMonitor,
+				Severity: TimelineSeverityError,
+				Object:   "Node/" + n.Name,
+				Reason:   fmt.Sprintf("%s=%s", c.Type, c.Status),
+				Message:  c.Message,
+			})
+		}
+	}
+
+	return events, nil
+}
+
+// kubernetesEventTime returns the best available time of the most recent
+// occurrence of an event; which fields are set depends on the event API
+// version used by the reporting component.
+func kubernetesEventTime(e *corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case e.Series != nil && !e.Series.LastObservedTime.IsZero():
+		return e.Series.LastObservedTime.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	case !e.FirstTimestamp.IsZero():
+		return e.FirstTimestamp.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
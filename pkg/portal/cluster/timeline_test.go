@@ -0,0 +1,145 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	configv1 "github.com/openshift/api/config/v1"
+	configfake "github.com/openshift/client-go/config/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	testlog "github.com/Azure/ARO-RP/test/util/log"
+)
+
+func TestTimeline(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	since := now.Add(-time.Hour)
+
+	kubernetesCli := fake.NewSimpleClientset(
+		&corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "recent",
+				Namespace: "openshift-etcd",
+			},
+			InvolvedObject: corev1.ObjectReference{
+				Kind:      "Pod",
+				Namespace: "openshift-etcd",
+				Name:      "etcd-master-0",
+			},
+			Type:          corev1.EventTypeWarning,
+			Reason:        "BackOff",
+			Message:       "Back-off restarting failed container",
+			LastTimestamp: metav1.NewTime(now.Add(-time.Minute)),
+		},
+		&corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "old",
+				Namespace: "default",
+			},
+			InvolvedObject: corev1.ObjectReference{
+				Kind: "Node",
+				Name: "master-0",
+			},
+			Type:          corev1.EventTypeNormal,
+			Reason:        "Starting",
+			LastTimestamp: metav1.NewTime(now.Add(-2 * time.Hour)),
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "worker-0",
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{
+						Type:               corev1.NodeReady,
+						Status:             corev1.ConditionFalse,
+						LastTransitionTime: metav1.NewTime(now.Add(-2 * time.Minute)),
+						Message:            "kubelet stopped posting node status",
+					},
+					{
+						Type:               corev1.NodeMemoryPressure,
+						Status:             corev1.ConditionFalse,
+						LastTransitionTime: metav1.NewTime(now.Add(-3 * time.Minute)),
+					},
+				},
+			},
+		},
+	)
+
+	configCli := configfake.NewSimpleClientset(
+		&configv1.ClusterOperator{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "dns",
+			},
+			Status: configv1.ClusterOperatorStatus{
+				Conditions: []configv1.ClusterOperatorStatusCondition{
+					{
+						Type:               configv1.OperatorDegraded,
+						Status:             configv1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(now.Add(-4 * time.Minute)),
+						Message:            "DNS default is degraded",
+					},
+					{
+						Type:               configv1.OperatorAvailable,
+						Status:             configv1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(now.Add(-5 * time.Minute)),
+					},
+				},
+			},
+		},
+	)
+
+	_, log := testlog.New()
+
+	c := &client{
+		log: log,
+		fetcher: &realFetcher{
+			log:           log,
+			configCli:     configCli,
+			kubernetesCli: kubernetesCli,
+		},
+	}
+
+	events, err := c.Timeline(ctx, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, diff := range deep.Equal(events, []TimelineEvent{
+		{
+			Time:     now.Add(-time.Minute),
+			Source:   TimelineSourceKubernetes,
+			Severity: TimelineSeverityWarning,
+			Object:   "openshift-etcd/Pod/etcd-master-0",
+			Reason:   "BackOff",
+			Message:  "Back-off restarting failed container",
+		},
+		{
+			Time:     now.Add(-4 * time.Minute),
+			Source:   TimelineSourceMonitor,
+			Severity: TimelineSeverityError,
+			Object:   "ClusterOperator/dns",
+			Reason:   "Degraded=True",
+			Message:  "DNS default is degraded",
+		},
+		{
+			Time:     now.Add(-2 * time.Minute),
+			Source:   TimelineSourceMonitor,
+			Severity: TimelineSeverityError,
+			Object:   "Node/worker-0",
+			Reason:   "Ready=False",
+			Message:  "kubelet stopped posting node status",
+		},
+	}) {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,126 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+)
+
+const (
+	clusterSearchPageSize     = 100
+	clusterSearchMaxLastError = 256
+)
+
+var (
+	rxClusterSearchVersion  = regexp.MustCompile(`^[0-9]+(\.[0-9]+){0,2}$`)
+	rxClusterSearchLocation = regexp.MustCompile(`^[a-z0-9]+$`)
+)
+
+type ClusterSearchResult struct {
+	Clusters     []*AdminOpenShiftCluster `json:"clusters"`
+	Continuation string                   `json:"continuation,omitempty"`
+}
+
+// parseClusterSearch reads the search criteria from the query string of a
+// cluster search request
+func parseClusterSearch(r *http.Request) (*database.OpenShiftClusterSearch, error) {
+	q := r.URL.Query()
+
+	search := &database.OpenShiftClusterSearch{
+		Version:           q.Get("version"),
+		Location:          strings.ToLower(q.Get("location")),
+		ProvisioningState: api.ProvisioningState(q.Get("provisioningState")),
+		LastError:         q.Get("lastError"),
+	}
+
+	if search.Version != "" && !rxClusterSearchVersion.MatchString(search.Version) {
+		return nil, fmt.Errorf("invalid version %q", search.Version)
+	}
+
+	if search.Location != "" && !rxClusterSearchLocation.MatchString(search.Location) {
+		return nil, fmt.Errorf("invalid location %q", search.Location)
+	}
+
+	switch search.ProvisioningState {
+	case "",
+		api.ProvisioningStateCreating,
+		api.ProvisioningStateUpdating,
+		api.ProvisioningStateAdminUpdating,
+		api.ProvisioningStateCanceled,
+		api.ProvisioningStateMaintenance,
+		api.ProvisioningStateDeleting,
+		api.ProvisioningStateSucceeded,
+		api.ProvisioningStateFailed:
+	default:
+		return nil, fmt.Errorf("invalid provisioningState %q", search.ProvisioningState)
+	}
+
+	if len(search.LastError) > clusterSearchMaxLastError {
+		return nil, fmt.Errorf("lastError must be at most %d characters", clusterSearchMaxLastError)
+	}
+
+	return search, nil
+}
+
+// clusterSearch returns a page of the clusters matching the search criteria
+// in the query string.  If there are more matches, the response includes a
+// continuation token to pass back to fetch the next page.
+func (p *portal) clusterSearch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	dbOpenShiftClusters, err := p.dbGroup.OpenShiftClusters()
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	search, err := parseClusterSearch(r)
+	if err != nil {
+		p.badRequest(w, err)
+		return
+	}
+
+	i, err := dbOpenShiftClusters.Search(search, r.URL.Query().Get("continuation"))
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	docs, err := i.Next(ctx, clusterSearchPageSize)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	result := &ClusterSearchResult{
+		Clusters:     []*AdminOpenShiftCluster{},
+		Continuation: i.Continuation(),
+	}
+
+	if docs != nil {
+		for _, doc := range docs.OpenShiftClusterDocuments {
+			if doc.OpenShiftCluster == nil {
+				continue
+			}
+
+			result.Clusters = append(result.Clusters, newAdminOpenShiftCluster(doc))
+		}
+	}
+
+	b, err := json.MarshalIndent(result, "", "    ")
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
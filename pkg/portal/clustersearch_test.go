@@ -0,0 +1,170 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestClusterSearch(t *testing.T) {
+	dbOpenShiftClusters, _ := testdatabase.NewFakeOpenShiftClusters()
+
+	fixture := testdatabase.NewFixture().
+		WithOpenShiftClusters(dbOpenShiftClusters)
+
+	dbg := database.NewDBGroup().
+		WithOpenShiftClusters(dbOpenShiftClusters)
+
+	for _, c := range []struct {
+		name              string
+		location          string
+		version           string
+		provisioningState api.ProvisioningState
+		lastError         string
+	}{
+		{
+			name:              "eastus412",
+			location:          "eastus",
+			version:           "4.12.25",
+			provisioningState: api.ProvisioningStateSucceeded,
+		},
+		{
+			name:              "eastus413",
+			location:          "eastus",
+			version:           "4.13.1",
+			provisioningState: api.ProvisioningStateFailed,
+			lastError:         "Timed out waiting for etcd to become Available",
+		},
+		{
+			name:              "westeurope412",
+			location:          "westeurope",
+			version:           "4.12.9",
+			provisioningState: api.ProvisioningStateSucceeded,
+			lastError:         "etcd quorum guard is degraded",
+		},
+	} {
+		fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+			ID:  c.name,
+			Key: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroupname/providers/microsoft.redhatopenshift/openshiftclusters/" + c.name,
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID:       "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroupName/providers/microsoft.redhatopenshift/openshiftclusters/" + c.name,
+				Location: c.location,
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState: c.provisioningState,
+					ClusterProfile: api.ClusterProfile{
+						Version: c.version,
+					},
+					LastAdminUpdateError: c.lastError,
+				},
+			},
+		})
+	}
+
+	err := fixture.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &portal{
+		log:     logrus.NewEntry(logrus.StandardLogger()),
+		dbGroup: dbg,
+	}
+
+	router := mux.NewRouter()
+	p.aadAuthenticatedRoutes(router, nil, nil, nil)
+
+	for _, tt := range []struct {
+		name           string
+		query          string
+		wantStatusCode int
+		wantNames      []string
+	}{
+		{
+			name:           "no criteria",
+			wantStatusCode: http.StatusOK,
+			wantNames:      []string{"eastus412", "eastus413", "westeurope412"},
+		},
+		{
+			name:           "minor version",
+			query:          "?version=4.12",
+			wantStatusCode: http.StatusOK,
+			wantNames:      []string{"eastus412", "westeurope412"},
+		},
+		{
+			name:           "location and provisioning state",
+			query:          "?location=EastUS&provisioningState=Succeeded",
+			wantStatusCode: http.StatusOK,
+			wantNames:      []string{"eastus412"},
+		},
+		{
+			name:           "last error",
+			query:          "?lastError=ETCD",
+			wantStatusCode: http.StatusOK,
+			wantNames:      []string{"eastus413", "westeurope412"},
+		},
+		{
+			name:           "no matches",
+			query:          "?version=4.14",
+			wantStatusCode: http.StatusOK,
+			wantNames:      []string{},
+		},
+		{
+			name:           "invalid version",
+			query:          "?version=latest",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid provisioning state",
+			query:          "?provisioningState=Broken",
+			wantStatusCode: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/clusters/search"+tt.query, nil))
+
+			if w.Code != tt.wantStatusCode {
+				t.Fatal(w.Code)
+			}
+
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var result ClusterSearchResult
+			err := json.NewDecoder(w.Body).Decode(&result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			names := []string{}
+			for _, c := range result.Clusters {
+				names = append(names, c.Name)
+			}
+
+			if len(names) != len(tt.wantNames) {
+				t.Fatal(names)
+			}
+			for i := range names {
+				if names[i] != tt.wantNames[i] {
+					t.Error(names)
+				}
+			}
+
+			if result.Continuation != "" {
+				t.Error(result.Continuation)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/net/websocket"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/Azure/ARO-RP/pkg/portal/cluster"
+	"github.com/Azure/ARO-RP/pkg/portal/middleware"
+	"github.com/Azure/ARO-RP/pkg/portal/util/recorder"
+)
+
+const (
+	// debugPodLifetime bounds how long a debug pod lives, even if the portal
+	// goes away without deleting it
+	debugPodLifetime = time.Hour
+
+	debugPodDeleteTimeout = time.Minute
+
+	auditTargetResourceTypeDebug = "debug"
+)
+
+// debugTerminalStatus is a text message sent to the client during a debug
+// terminal session.  Terminal output is sent in binary messages.
+type debugTerminalStatus struct {
+	Status string `json:"status,omitempty"`
+	Pod    string `json:"pod,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// debugTerminalInput is a message sent by the client: either keystrokes or a
+// new terminal size.
+type debugTerminalInput struct {
+	Stdin  string `json:"stdin,omitempty"`
+	Resize *struct {
+		Width  uint16 `json:"width"`
+		Height uint16 `json:"height"`
+	} `json:"resize,omitempty"`
+}
+
+// debugTerminal creates an ephemeral privileged debug pod on a node and
+// attaches an interactive shell on the node to a websocket, in the manner of
+// `oc debug node`.  The pod is deleted when the session ends.  The session and
+// every line typed into it are recorded as IFx audit events.
+func (p *portal) debugTerminal(w http.ResponseWriter, r *http.Request) {
+	node := mux.Vars(r)["node"]
+	if errs := validation.IsDNS1123Subdomain(node); len(errs) > 0 {
+		p.badRequest(w, fmt.Errorf("invalid node %q: %s", node, strings.Join(errs, ", ")))
+		return
+	}
+
+	// the request context is not cancelled when a hijacked connection is
+	// closed, so the websocket handler cancels this one itself
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	fetcher, err := p.makeFetcher(ctx, r)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	username, _ := ctx.Value(middleware.ContextKeyUsername).(string)
+	apiVars := mux.Vars(r)
+	resourceID := p.getResourceID(apiVars["subscription"], apiVars["resourceGroup"], apiVars["clusterName"])
+
+	websocket.Server{
+		Handshake: checkSameOrigin,
+		Handler: func(ws *websocket.Conn) {
+			err := p.runDebugTerminal(ctx, cancel, ws, fetcher, node, username, resourceID, r.RemoteAddr)
+			if err != nil && ctx.Err() == nil {
+				p.log.Warn(err)
+				_ = websocket.JSON.Send(ws, &debugTerminalStatus{Error: err.Error()})
+			}
+		},
+	}.ServeHTTP(w, r)
+}
+
+func (p *portal) runDebugTerminal(ctx context.Context, cancel context.CancelFunc, ws *websocket.Conn, fetcher cluster.FetchClient, node, username, resourceID, remoteAddr string) error {
+	err := websocket.JSON.Send(ws, &debugTerminalStatus{Status: "creating"})
+	if err != nil {
+		return err
+	}
+
+	pod, err := fetcher.CreateDebugPod(ctx, node, username, p.debugPodImage, debugPodLifetime)
+	if err != nil {
+		return err
+	}
+
+	rec := recorder.New(p.audit.WithFields(recorder.AuditFields(p.env, username, remoteAddr, resourceID, auditTargetResourceTypeDebug, string(pod.UID))), time.Now)
+	rec.Event("Debug session start", "node "+node+", pod "+pod.Name)
+
+	input := rec.NewLineWriter("Debug input")
+
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), debugPodDeleteTimeout)
+		defer cancel()
+
+		err := fetcher.DeleteDebugPod(ctx, pod.Name)
+		if err != nil {
+			p.log.Warn(err)
+		}
+
+		input.Flush()
+		rec.Event("Debug session end", "")
+	}()
+
+	term, err := fetcher.DebugTerminal(ctx, pod.Name)
+	if err != nil {
+		return err
+	}
+	defer term.Close()
+
+	go func() {
+		<-ctx.Done()
+		term.Close()
+	}()
+
+	err = websocket.JSON.Send(ws, &debugTerminalStatus{Status: "ready", Pod: pod.Name})
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		defer cancel()
+
+		for {
+			var in debugTerminalInput
+			err := websocket.JSON.Receive(ws, &in)
+			if err != nil {
+				return
+			}
+
+			if in.Stdin != "" {
+				input.Write([]byte(in.Stdin))
+
+				err = term.Input([]byte(in.Stdin))
+				if err != nil {
+					return
+				}
+			}
+
+			if in.Resize != nil {
+				err = term.Resize(in.Resize.Width, in.Resize.Height)
+				if err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		b, err := term.Output()
+		if err == io.EOF {
+			return websocket.JSON.Send(ws, &debugTerminalStatus{Status: "exited"})
+		}
+		if err != nil {
+			return err
+		}
+
+		err = websocket.Message.Send(ws, b)
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveinternalv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// HiveClusterDeploymentInformation is the state of a cluster as seen by Hive
+type HiveClusterDeploymentInformation struct {
+	Namespace         string                 `json:"namespace"`
+	Installed         bool                   `json:"installed"`
+	PowerState        string                 `json:"powerState,omitempty"`
+	Conditions        []HiveCondition        `json:"conditions"`
+	ProvisionAttempts []HiveProvisionAttempt `json:"provisionAttempts"`
+	SyncSets          []HiveSyncSetStatus    `json:"syncSets"`
+}
+
+type HiveCondition struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason,omitempty"`
+	Message            string    `json:"message,omitempty"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+// HiveProvisionAttempt is a single ClusterProvision of the cluster.  The
+// failure reason and message are only set for failed attempts.
+type HiveProvisionAttempt struct {
+	Name           string    `json:"name"`
+	Attempt        int       `json:"attempt"`
+	Stage          string    `json:"stage"`
+	CreatedAt      time.Time `json:"createdAt"`
+	FailureReason  string    `json:"failureReason,omitempty"`
+	FailureMessage string    `json:"failureMessage,omitempty"`
+}
+
+type HiveSyncSetStatus struct {
+	Name               string    `json:"name"`
+	Kind               string    `json:"kind"`
+	Result             string    `json:"result"`
+	FailureMessage     string    `json:"failureMessage,omitempty"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+func hiveClusterDeploymentInformation(cd *hivev1.ClusterDeployment, provisions []hivev1.ClusterProvision, cs *hiveinternalv1alpha1.ClusterSync) *HiveClusterDeploymentInformation {
+	info := &HiveClusterDeploymentInformation{
+		Namespace:         cd.Namespace,
+		Installed:         cd.Spec.Installed,
+		PowerState:        string(cd.Status.PowerState),
+		Conditions:        []HiveCondition{},
+		ProvisionAttempts: []HiveProvisionAttempt{},
+		SyncSets:          []HiveSyncSetStatus{},
+	}
+
+	for _, c := range cd.Status.Conditions {
+		info.Conditions = append(info.Conditions, HiveCondition{
+			Type:               string(c.Type),
+			Status:             string(c.Status),
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastTransitionTime: c.LastTransitionTime.Time,
+		})
+	}
+
+	for _, p := range provisions {
+		attempt := HiveProvisionAttempt{
+			Name:      p.Name,
+			Attempt:   p.Spec.Attempt,
+			Stage:     string(p.Spec.Stage),
+			CreatedAt: p.CreationTimestamp.Time,
+		}
+
+		if p.Spec.Stage == hivev1.ClusterProvisionStageFailed {
+			for _, c := range p.Status.Conditions {
+				if c.Type == hivev1.ClusterProvisionFailedCondition && c.Status == corev1.ConditionTrue {
+					attempt.FailureReason = c.Reason
+					attempt.FailureMessage = c.Message
+				}
+			}
+		}
+
+		info.ProvisionAttempts = append(info.ProvisionAttempts, attempt)
+	}
+
+	if cs != nil {
+		for _, kind := range []struct {
+			name     string
+			statuses []hiveinternalv1alpha1.SyncStatus
+		}{
+			{name: "SyncSet", statuses: cs.Status.SyncSets},
+			{name: "SelectorSyncSet", statuses: cs.Status.SelectorSyncSets},
+		} {
+			for _, s := range kind.statuses {
+				info.SyncSets = append(info.SyncSets, HiveSyncSetStatus{
+					Name:               s.Name,
+					Kind:               kind.name,
+					Result:             string(s.Result),
+					FailureMessage:     s.FailureMessage,
+					LastTransitionTime: s.LastTransitionTime.Time,
+				})
+			}
+		}
+	}
+
+	return info
+}
+
+// hiveClusterDeployment returns the state of the cluster's ClusterDeployment
+// on the Hive shard: its conditions, provision attempts and SyncSet status.
+func (p *portal) hiveClusterDeployment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if p.hiveClusterManager == nil {
+		http.Error(w, "Hive is not enabled", http.StatusNotFound)
+		return
+	}
+
+	dbOpenShiftClusters, err := p.dbGroup.OpenShiftClusters()
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	apiVars := mux.Vars(r)
+	resourceID := p.getResourceID(apiVars["subscription"], apiVars["resourceGroup"], apiVars["clusterName"])
+
+	doc, err := dbOpenShiftClusters.Get(ctx, resourceID)
+	if err != nil {
+		http.Error(w, "Cluster not found", http.StatusNotFound)
+		return
+	}
+
+	if doc.OpenShiftCluster.Properties.HiveProfile.Namespace == "" {
+		http.Error(w, "Cluster is not managed by Hive", http.StatusNotFound)
+		return
+	}
+
+	cd, err := p.hiveClusterManager.GetClusterDeployment(ctx, doc)
+	if kerrors.IsNotFound(err) {
+		http.Error(w, "ClusterDeployment not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	provisions, err := p.hiveClusterManager.ListClusterProvisions(ctx, doc)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	// the ClusterSync does not exist until Hive has first tried to sync
+	cs, err := p.hiveClusterManager.GetClusterSync(ctx, doc)
+	if kerrors.IsNotFound(err) {
+		cs, err = nil, nil
+	}
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	b, err := json.MarshalIndent(hiveClusterDeploymentInformation(cd, provisions, cs), "", "    ")
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
@@ -0,0 +1,301 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/gorilla/mux"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveinternalv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	mock_hive "github.com/Azure/ARO-RP/pkg/util/mocks/hive"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestHiveClusterDeployment(t *testing.T) {
+	const (
+		hiveNamespace = "aro-00000000-0000-0000-0000-000000000000"
+		basePath      = "/api/00000000-0000-0000-0000-000000000000/resourceGroupName/"
+	)
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster",
+			Namespace: hiveNamespace,
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			Installed: true,
+		},
+		Status: hivev1.ClusterDeploymentStatus{
+			PowerState: hivev1.ClusterPowerStateRunning,
+			Conditions: []hivev1.ClusterDeploymentCondition{
+				{
+					Type:               hivev1.UnreachableCondition,
+					Status:             corev1.ConditionFalse,
+					Reason:             "ClusterReachable",
+					LastTransitionTime: metav1.NewTime(now),
+				},
+			},
+		},
+	}
+
+	provisions := []hivev1.ClusterProvision{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "cluster-1-bbbbb",
+				CreationTimestamp: metav1.NewTime(now),
+			},
+			Spec: hivev1.ClusterProvisionSpec{
+				Attempt: 1,
+				Stage:   hivev1.ClusterProvisionStageComplete,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "cluster-0-aaaaa",
+				CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+			},
+			Spec: hivev1.ClusterProvisionSpec{
+				Attempt: 0,
+				Stage:   hivev1.ClusterProvisionStageFailed,
+			},
+			Status: hivev1.ClusterProvisionStatus{
+				Conditions: []hivev1.ClusterProvisionCondition{
+					{
+						Type:    hivev1.ClusterProvisionFailedCondition,
+						Status:  corev1.ConditionTrue,
+						Reason:  "AzureQuotaExceeded",
+						Message: "quota exceeded",
+					},
+				},
+			},
+		},
+	}
+
+	cs := &hiveinternalv1alpha1.ClusterSync{
+		Status: hiveinternalv1alpha1.ClusterSyncStatus{
+			SyncSets: []hiveinternalv1alpha1.SyncStatus{
+				{
+					Name:               "aro-managed",
+					Result:             hiveinternalv1alpha1.FailureSyncSetResult,
+					FailureMessage:     "failed to apply",
+					LastTransitionTime: metav1.NewTime(now),
+				},
+			},
+			SelectorSyncSets: []hiveinternalv1alpha1.SyncStatus{
+				{
+					Name:               "aro-guardrails",
+					Result:             hiveinternalv1alpha1.SuccessSyncSetResult,
+					LastTransitionTime: metav1.NewTime(now),
+				},
+			},
+		},
+	}
+
+	notFound := kerrors.NewNotFound(schema.GroupResource{}, "cluster")
+
+	for _, tt := range []struct {
+		name           string
+		cluster        string
+		noHive         bool
+		mocks          func(*mock_hive.MockClusterManager)
+		wantStatusCode int
+		wantInfo       *HiveClusterDeploymentInformation
+	}{
+		{
+			name:    "hive cluster",
+			cluster: "hive",
+			mocks: func(hiveClusterManager *mock_hive.MockClusterManager) {
+				hiveClusterManager.EXPECT().GetClusterDeployment(gomock.Any(), gomock.Any()).Return(cd, nil)
+				hiveClusterManager.EXPECT().ListClusterProvisions(gomock.Any(), gomock.Any()).Return(provisions, nil)
+				hiveClusterManager.EXPECT().GetClusterSync(gomock.Any(), gomock.Any()).Return(cs, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantInfo: &HiveClusterDeploymentInformation{
+				Namespace:  hiveNamespace,
+				Installed:  true,
+				PowerState: "Running",
+				Conditions: []HiveCondition{
+					{
+						Type:               "Unreachable",
+						Status:             "False",
+						Reason:             "ClusterReachable",
+						LastTransitionTime: now,
+					},
+				},
+				ProvisionAttempts: []HiveProvisionAttempt{
+					{
+						Name:      "cluster-1-bbbbb",
+						Attempt:   1,
+						Stage:     "complete",
+						CreatedAt: now,
+					},
+					{
+						Name:           "cluster-0-aaaaa",
+						Attempt:        0,
+						Stage:          "failed",
+						CreatedAt:      now.Add(-time.Hour),
+						FailureReason:  "AzureQuotaExceeded",
+						FailureMessage: "quota exceeded",
+					},
+				},
+				SyncSets: []HiveSyncSetStatus{
+					{
+						Name:               "aro-managed",
+						Kind:               "SyncSet",
+						Result:             "Failure",
+						FailureMessage:     "failed to apply",
+						LastTransitionTime: now,
+					},
+					{
+						Name:               "aro-guardrails",
+						Kind:               "SelectorSyncSet",
+						Result:             "Success",
+						LastTransitionTime: now,
+					},
+				},
+			},
+		},
+		{
+			name:    "not yet synced",
+			cluster: "hive",
+			mocks: func(hiveClusterManager *mock_hive.MockClusterManager) {
+				hiveClusterManager.EXPECT().GetClusterDeployment(gomock.Any(), gomock.Any()).Return(cd, nil)
+				hiveClusterManager.EXPECT().ListClusterProvisions(gomock.Any(), gomock.Any()).Return(nil, nil)
+				hiveClusterManager.EXPECT().GetClusterSync(gomock.Any(), gomock.Any()).Return(nil, notFound)
+			},
+			wantStatusCode: http.StatusOK,
+			wantInfo: &HiveClusterDeploymentInformation{
+				Namespace:  hiveNamespace,
+				Installed:  true,
+				PowerState: "Running",
+				Conditions: []HiveCondition{
+					{
+						Type:               "Unreachable",
+						Status:             "False",
+						Reason:             "ClusterReachable",
+						LastTransitionTime: now,
+					},
+				},
+				ProvisionAttempts: []HiveProvisionAttempt{},
+				SyncSets:          []HiveSyncSetStatus{},
+			},
+		},
+		{
+			name:    "clusterdeployment missing",
+			cluster: "hive",
+			mocks: func(hiveClusterManager *mock_hive.MockClusterManager) {
+				hiveClusterManager.EXPECT().GetClusterDeployment(gomock.Any(), gomock.Any()).Return(nil, notFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:    "hive shard unreachable",
+			cluster: "hive",
+			mocks: func(hiveClusterManager *mock_hive.MockClusterManager) {
+				hiveClusterManager.EXPECT().GetClusterDeployment(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "cluster not managed by hive",
+			cluster:        "nothive",
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "no such cluster",
+			cluster:        "missing",
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "hive disabled",
+			cluster:        "hive",
+			noHive:         true,
+			wantStatusCode: http.StatusNotFound,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			dbOpenShiftClusters, _ := testdatabase.NewFakeOpenShiftClusters()
+
+			fixture := testdatabase.NewFixture().
+				WithOpenShiftClusters(dbOpenShiftClusters)
+
+			for name, namespace := range map[string]string{"hive": hiveNamespace, "nothive": ""} {
+				fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroupname/providers/microsoft.redhatopenshift/openshiftclusters/" + name,
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroupName/providers/Microsoft.RedHatOpenShift/openShiftClusters/" + name,
+						Properties: api.OpenShiftClusterProperties{
+							HiveProfile: api.HiveProfile{
+								Namespace: namespace,
+							},
+						},
+					},
+				})
+			}
+
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p := &portal{
+				log:     logrus.NewEntry(logrus.StandardLogger()),
+				dbGroup: database.NewDBGroup().WithOpenShiftClusters(dbOpenShiftClusters),
+			}
+
+			if !tt.noHive {
+				hiveClusterManager := mock_hive.NewMockClusterManager(controller)
+				if tt.mocks != nil {
+					tt.mocks(hiveClusterManager)
+				}
+				p.hiveClusterManager = hiveClusterManager
+			}
+
+			router := mux.NewRouter()
+			p.aadAuthenticatedRoutes(router, nil, nil, nil)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, basePath+tt.cluster+"/hive", nil))
+
+			if w.Code != tt.wantStatusCode {
+				t.Fatal(w.Code)
+			}
+
+			if tt.wantInfo == nil {
+				return
+			}
+
+			var info *HiveClusterDeploymentInformation
+			err = json.NewDecoder(w.Body).Decode(&info)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, diff := range deep.Equal(info, tt.wantInfo) {
+				t.Error(diff)
+			}
+		})
+	}
+}
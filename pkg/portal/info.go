@@ -10,7 +10,6 @@ import (
 	"github.com/gorilla/csrf"
 
 	"github.com/Azure/ARO-RP/pkg/portal/middleware"
-	"github.com/Azure/ARO-RP/pkg/util/stringutils"
 	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
@@ -18,18 +17,20 @@ type PortalInfo struct {
 	Location  string `json:"location"`
 	CSRFToken string `json:"csrf"`
 	Elevated  bool   `json:"elevated"`
+	Role      string `json:"role"`
 	Username  string `json:"username"`
 	RPVersion string `json:"rpversion"`
 }
 
 func (p *portal) info(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	elevated := len(stringutils.GroupsIntersect(p.elevatedGroupIDs, ctx.Value(middleware.ContextKeyGroups).([]string))) > 0
+	role := p.rbac().Role(ctx.Value(middleware.ContextKeyGroups).([]string))
 
 	resp := PortalInfo{
 		Location:  p.env.Location(),
 		CSRFToken: csrf.Token(r),
-		Elevated:  elevated,
+		Elevated:  role == middleware.RoleElevated,
+		Role:      string(role),
 		Username:  ctx.Value(middleware.ContextKeyUsername).(string),
 		RPVersion: version.GitCommit,
 	}
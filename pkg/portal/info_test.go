@@ -57,6 +57,7 @@ func TestInfo(t *testing.T) {
 				Location:  "eastus",
 				Username:  "username",
 				Elevated:  false,
+				Role:      "reader",
 				RPVersion: version.GitCommit,
 			},
 		},
@@ -69,6 +70,7 @@ func TestInfo(t *testing.T) {
 				Location:  "eastus",
 				Username:  "username",
 				Elevated:  true,
+				Role:      "elevated",
 				RPVersion: version.GitCommit,
 			},
 		},
@@ -35,6 +35,9 @@ const (
 	sessionKeyRedirectUri = "redirect_uri"
 	SessionKeyUsername    = "user_name"
 	SessionKeyGroups      = "groups"
+	// Time of the last step-up authentication in unix format
+	SessionKeyStepUpTime = "stepup_time"
+	sessionKeyStepUp     = "stepup"
 )
 
 // AAD is responsible for ensuring that we have a valid login session with AAD.
@@ -53,6 +56,7 @@ type oauther interface {
 type claims struct {
 	Groups            []string `json:"groups,omitempty"`
 	PreferredUsername string   `json:"preferred_username,omitempty"`
+	AuthContexts      []string `json:"acrs,omitempty"`
 }
 
 type aad struct {
@@ -71,6 +75,8 @@ type aad struct {
 	verifier  oidc.Verifier
 	allGroups []string
 
+	stepUpAuthContext string
+
 	sessionTimeout time.Duration
 }
 
@@ -84,6 +90,7 @@ func NewAAD(log *logrus.Entry,
 	clientKey *rsa.PrivateKey,
 	clientCerts []*x509.Certificate,
 	allGroups []string,
+	stepUpAuthContext string,
 	unauthenticatedRouter *mux.Router,
 	verifier oidc.Verifier) (*aad, error) {
 	if len(sessionKey) != 32 {
@@ -118,6 +125,8 @@ func NewAAD(log *logrus.Entry,
 		verifier:  verifier,
 		allGroups: allGroups,
 
+		stepUpAuthContext: stepUpAuthContext,
+
 		sessionTimeout: time.Hour,
 	}
 
@@ -164,6 +173,9 @@ func (a *aad) AAD(h http.Handler) http.Handler {
 		ctx := r.Context()
 		ctx = context.WithValue(ctx, ContextKeyUsername, session.Values[SessionKeyUsername])
 		ctx = context.WithValue(ctx, ContextKeyGroups, session.Values[SessionKeyGroups])
+		if stepUpTime, ok := session.Values[SessionKeyStepUpTime].(int64); ok {
+			ctx = context.WithValue(ctx, ContextKeyStepUpTime, time.Unix(stepUpTime, 0))
+		}
 		r = r.WithContext(ctx)
 
 		h.ServeHTTP(w, r)
@@ -233,13 +245,24 @@ func (a *aad) redirect(w http.ResponseWriter, r *http.Request) {
 		session.Values[sessionKeyRedirectUri] = r.URL.Query().Get(sessionKeyRedirectUri)
 	}
 
+	var opts []oauth2.AuthCodeOption
+	if r.URL.Query().Get(sessionKeyStepUp) == "true" && a.stepUpAuthContext != "" {
+		// force the user to sign in again and to satisfy the Conditional
+		// Access policy of the step-up authentication context
+		session.Values[sessionKeyStepUp] = true
+		opts = append(opts,
+			oauth2.SetAuthURLParam("prompt", "login"),
+			oauth2.SetAuthURLParam("claims", `{"id_token":{"acrs":{"essential":true,"value":"`+a.stepUpAuthContext+`"}}}`),
+		)
+	}
+
 	err = session.Save(r, w)
 	if err != nil {
 		a.internalServerError(w, err)
 		return
 	}
 
-	http.Redirect(w, r, a.oauther.AuthCodeURL(state), http.StatusTemporaryRedirect)
+	http.Redirect(w, r, a.oauther.AuthCodeURL(state, opts...), http.StatusTemporaryRedirect)
 }
 
 func (a *aad) callback(w http.ResponseWriter, r *http.Request) {
@@ -259,6 +282,9 @@ func (a *aad) callback(w http.ResponseWriter, r *http.Request) {
 
 	delete(session.Values, sessionKeyState)
 
+	stepUp, _ := session.Values[sessionKeyStepUp].(bool)
+	delete(session.Values, sessionKeyStepUp)
+
 	err = session.Save(r, w)
 	if err != nil {
 		a.internalServerError(w, err)
@@ -318,6 +344,15 @@ func (a *aad) callback(w http.ResponseWriter, r *http.Request) {
 	session.Values[SessionKeyGroups] = groupsIntersect
 	session.Values[SessionKeyExpires] = a.now().Add(a.sessionTimeout).Unix()
 
+	if stepUp {
+		if !stringutils.Contains(claims.AuthContexts, a.stepUpAuthContext) {
+			http.Error(w, "Step-up authentication context not satisfied.", http.StatusForbidden)
+			return
+		}
+
+		session.Values[SessionKeyStepUpTime] = a.now().Unix()
+	}
+
 	redirectUri := "/"
 	if v, ok := session.Values[sessionKeyRedirectUri]; ok {
 		redirectUri = v.(string)
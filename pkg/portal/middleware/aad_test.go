@@ -66,7 +66,7 @@ func (o *noopOauther) Exchange(context.Context, string, ...oauth2.AuthCodeOption
 }
 
 func TestNewAAD(t *testing.T) {
-	_, err := NewAAD(nil, nil, nil, nil, "", nil, "", nil, nil, nil, nil, nil)
+	_, err := NewAAD(nil, nil, nil, nil, "", nil, "", nil, nil, nil, "", nil, nil)
 	if err.Error() != "invalid sessionKey" {
 		t.Error(err)
 	}
@@ -152,7 +152,7 @@ func TestAAD(t *testing.T) {
 			_, audit := testlog.NewAudit()
 			_, baseLog := testlog.New()
 			_, baseAccessLog := testlog.New()
-			a, err := NewAAD(baseLog, audit, env, baseAccessLog, "", make([]byte, 32), "", nil, nil, nil, mux.NewRouter(), nil)
+			a, err := NewAAD(baseLog, audit, env, baseAccessLog, "", make([]byte, 32), "", nil, nil, nil, "c1", mux.NewRouter(), nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -255,7 +255,7 @@ func TestCheckAuthentication(t *testing.T) {
 			_, audit := testlog.NewAudit()
 			_, baseLog := testlog.New()
 			_, baseAccessLog := testlog.New()
-			a, err := NewAAD(baseLog, audit, env, baseAccessLog, "", make([]byte, 32), "", nil, nil, nil, mux.NewRouter(), nil)
+			a, err := NewAAD(baseLog, audit, env, baseAccessLog, "", make([]byte, 32), "", nil, nil, nil, "c1", mux.NewRouter(), nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -302,6 +302,7 @@ func TestLogin(t *testing.T) {
 		name           string
 		request        func(*aad) (*http.Request, error)
 		wantStatusCode int
+		wantStepUp     bool
 	}{
 		{
 			name: "authenticated",
@@ -320,6 +321,16 @@ func TestLogin(t *testing.T) {
 			},
 			wantStatusCode: http.StatusTemporaryRedirect,
 		},
+		{
+			name: "step-up",
+			request: func(a *aad) (*http.Request, error) {
+				ctx := context.Background()
+				ctx = context.WithValue(ctx, ContextKeyUsername, "user")
+				return http.NewRequestWithContext(ctx, http.MethodGet, "/login?stepup=true", nil)
+			},
+			wantStatusCode: http.StatusTemporaryRedirect,
+			wantStepUp:     true,
+		},
 		{
 			name: "invalid cookie",
 			request: func(a *aad) (*http.Request, error) {
@@ -343,7 +354,7 @@ func TestLogin(t *testing.T) {
 			_, audit := testlog.NewAudit()
 			_, baseLog := testlog.New()
 			_, baseAccessLog := testlog.New()
-			a, err := NewAAD(baseLog, audit, env, baseAccessLog, "", make([]byte, 32), "", nil, nil, nil, mux.NewRouter(), nil)
+			a, err := NewAAD(baseLog, audit, env, baseAccessLog, "", make([]byte, 32), "", nil, nil, nil, "c1", mux.NewRouter(), nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -367,7 +378,24 @@ func TestLogin(t *testing.T) {
 				return
 			}
 
-			if !strings.HasPrefix(w.Header().Get("Location"), "https://login.microsoftonline.com/common/oauth2/v2.0/authorize?client_id=&redirect_uri=https%3A%2F%2F%2Fcallback&response_type=code&scope=openid+profile&state=") {
+			location, err := url.Parse(w.Header().Get("Location"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantStepUp {
+				if location.Host+location.Path != "login.microsoftonline.com/common/oauth2/v2.0/authorize" {
+					t.Error(w.Header().Get("Location"))
+				}
+
+				if location.Query().Get("prompt") != "login" {
+					t.Error(location.Query().Get("prompt"))
+				}
+
+				if location.Query().Get("claims") != `{"id_token":{"acrs":{"essential":true,"value":"c1"}}}` {
+					t.Error(location.Query().Get("claims"))
+				}
+			} else if !strings.HasPrefix(w.Header().Get("Location"), "https://login.microsoftonline.com/common/oauth2/v2.0/authorize?client_id=&redirect_uri=https%3A%2F%2F%2Fcallback&response_type=code&scope=openid+profile&state=") {
 				t.Error(w.Header().Get("Location"))
 			}
 		})
@@ -433,7 +461,7 @@ func TestLogout(t *testing.T) {
 			_, audit := testlog.NewAudit()
 			_, baseLog := testlog.New()
 			_, baseAccessLog := testlog.New()
-			a, err := NewAAD(baseLog, audit, env, baseAccessLog, "", make([]byte, 32), "", nil, nil, nil, mux.NewRouter(), nil)
+			a, err := NewAAD(baseLog, audit, env, baseAccessLog, "", make([]byte, 32), "", nil, nil, nil, "c1", mux.NewRouter(), nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -490,14 +518,25 @@ func TestCallback(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	stepUpIDToken, err := json.Marshal(claims{
+		Groups:            groups,
+		PreferredUsername: username,
+		AuthContexts:      []string{"c1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	for _, tt := range []struct {
 		name              string
 		request           func(*aad) (*http.Request, error)
 		oauther           oauther
 		verifier          oidc.Verifier
 		wantAuthenticated bool
+		wantStepUp        bool
 		wantError         string
 		wantForbidden     bool
+		wantStepUpFailed  bool
 	}{
 		{
 			name: "success",
@@ -529,6 +568,69 @@ func TestCallback(t *testing.T) {
 			verifier:          &oidc.NoopVerifier{},
 			wantAuthenticated: true,
 		},
+		{
+			name: "success - step-up",
+			request: func(a *aad) (*http.Request, error) {
+				uuid := uuid.DefaultGenerator.Generate()
+
+				cookie, err := securecookie.EncodeMulti(SessionName, map[interface{}]interface{}{
+					sessionKeyState:  uuid,
+					sessionKeyStepUp: true,
+				}, a.store.Codecs...)
+				if err != nil {
+					return nil, err
+				}
+
+				return &http.Request{
+					URL: &url.URL{},
+					Header: http.Header{
+						"Cookie": []string{SessionName + "=" + cookie},
+					},
+					Form: url.Values{
+						"state": []string{uuid},
+					},
+				}, nil
+			},
+			oauther: &noopOauther{
+				tokenMap: map[string]interface{}{
+					"id_token": string(stepUpIDToken),
+				},
+			},
+			verifier:          &oidc.NoopVerifier{},
+			wantAuthenticated: true,
+			wantStepUp:        true,
+		},
+		{
+			name: "fail - step-up authentication context not satisfied",
+			request: func(a *aad) (*http.Request, error) {
+				uuid := uuid.DefaultGenerator.Generate()
+
+				cookie, err := securecookie.EncodeMulti(SessionName, map[interface{}]interface{}{
+					sessionKeyState:  uuid,
+					sessionKeyStepUp: true,
+				}, a.store.Codecs...)
+				if err != nil {
+					return nil, err
+				}
+
+				return &http.Request{
+					URL: &url.URL{},
+					Header: http.Header{
+						"Cookie": []string{SessionName + "=" + cookie},
+					},
+					Form: url.Values{
+						"state": []string{uuid},
+					},
+				}, nil
+			},
+			oauther: &noopOauther{
+				tokenMap: map[string]interface{}{
+					"id_token": string(idToken),
+				},
+			},
+			verifier:         &oidc.NoopVerifier{},
+			wantStepUpFailed: true,
+		},
 		{
 			name: "fail - invalid cookie",
 			request: func(a *aad) (*http.Request, error) {
@@ -753,7 +855,7 @@ func TestCallback(t *testing.T) {
 			_, audit := testlog.NewAudit()
 			_, baseLog := testlog.New()
 			_, baseAccessLog := testlog.New()
-			a, err := NewAAD(baseLog, audit, env, baseAccessLog, "", make([]byte, 32), clientID, clientkey, clientcerts, groups, mux.NewRouter(), tt.verifier)
+			a, err := NewAAD(baseLog, audit, env, baseAccessLog, "", make([]byte, 32), clientID, clientkey, clientcerts, groups, "c1", mux.NewRouter(), tt.verifier)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -799,11 +901,16 @@ func TestCallback(t *testing.T) {
 					t.Error(w.Header().Get("Location"))
 				}
 
-				for _, l := range deep.Equal(m, cookie{
+				wantCookie := cookie{
 					SessionKeyExpires:  int64(3600),
 					SessionKeyGroups:   groups,
 					SessionKeyUsername: username,
-				}) {
+				}
+				if tt.wantStepUp {
+					wantCookie[SessionKeyStepUpTime] = int64(0)
+				}
+
+				for _, l := range deep.Equal(m, wantCookie) {
 					t.Error(l)
 				}
 
@@ -819,6 +926,20 @@ func TestCallback(t *testing.T) {
 				for _, l := range deep.Equal(m, cookie{}) {
 					t.Error(l)
 				}
+
+			case tt.wantStepUpFailed:
+				if w.Code != http.StatusForbidden {
+					t.Error(w.Code)
+				}
+
+				if w.Body.String() != "Step-up authentication context not satisfied.\n" {
+					t.Error(w.Body.String())
+				}
+
+				for _, l := range deep.Equal(m, cookie{}) {
+					t.Error(l)
+				}
+
 			default:
 				if w.Code != http.StatusTemporaryRedirect {
 					t.Error(w.Code)
@@ -845,7 +966,7 @@ func TestClientAssertion(t *testing.T) {
 	_, audit := testlog.NewAudit()
 	_, baseLog := testlog.New()
 	_, baseAccessLog := testlog.New()
-	a, err := NewAAD(baseLog, audit, env, baseAccessLog, "", make([]byte, 32), clientID, clientkey, clientcerts, nil, mux.NewRouter(), nil)
+	a, err := NewAAD(baseLog, audit, env, baseAccessLog, "", make([]byte, 32), clientID, clientkey, clientcerts, nil, "c1", mux.NewRouter(), nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,80 @@
+package middleware
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/http"
+
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+// Role is a tier of portal access, granted by Entra group membership.
+type Role string
+
+const (
+	// RoleNone is held by users who are in none of the portal's groups.
+	RoleNone Role = ""
+
+	// RoleReader may view cluster information, logs and metrics and obtain a
+	// read-only kubeconfig.
+	RoleReader Role = "reader"
+
+	// RoleElevated may additionally take actions against clusters, such as
+	// SSHing to their masters.  It implies RoleReader.
+	RoleElevated Role = "elevated"
+)
+
+var roleRank = map[Role]int{
+	RoleNone:     0,
+	RoleReader:   1,
+	RoleElevated: 2,
+}
+
+// RBAC maps the groups of an authenticated user to their portal role.
+type RBAC struct {
+	readerGroupIDs   []string
+	elevatedGroupIDs []string
+}
+
+func NewRBAC(readerGroupIDs, elevatedGroupIDs []string) *RBAC {
+	return &RBAC{
+		readerGroupIDs:   readerGroupIDs,
+		elevatedGroupIDs: elevatedGroupIDs,
+	}
+}
+
+// Role returns the highest role granted by the given group memberships.
+func (rbac *RBAC) Role(groups []string) Role {
+	switch {
+	case len(stringutils.GroupsIntersect(rbac.elevatedGroupIDs, groups)) > 0:
+		return RoleElevated
+	case len(stringutils.GroupsIntersect(rbac.readerGroupIDs, groups)) > 0:
+		return RoleReader
+	default:
+		return RoleNone
+	}
+}
+
+// Has returns true if the given group memberships grant at least the given
+// role.
+func (rbac *RBAC) Has(groups []string, role Role) bool {
+	return roleRank[rbac.Role(groups)] >= roleRank[role]
+}
+
+// Require returns middleware which rejects requests from users who do not
+// hold at least the given role.  It must run after the user's groups have
+// been added to the request context.
+func (rbac *RBAC) Require(role Role) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			groups, _ := r.Context().Value(ContextKeyGroups).([]string)
+			if !rbac.Has(groups, role) {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
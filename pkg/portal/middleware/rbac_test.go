@@ -0,0 +1,79 @@
+package middleware
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRBAC(t *testing.T) {
+	rbac := NewRBAC([]string{"reader"}, []string{"elevated"})
+
+	for _, tt := range []struct {
+		name       string
+		groups     []string
+		wantRole   Role
+		wantReader int
+		wantAction int
+	}{
+		{
+			name:       "no groups",
+			wantRole:   RoleNone,
+			wantReader: http.StatusForbidden,
+			wantAction: http.StatusForbidden,
+		},
+		{
+			name:       "unrelated group",
+			groups:     []string{"other"},
+			wantRole:   RoleNone,
+			wantReader: http.StatusForbidden,
+			wantAction: http.StatusForbidden,
+		},
+		{
+			name:       "reader",
+			groups:     []string{"other", "reader"},
+			wantRole:   RoleReader,
+			wantReader: http.StatusOK,
+			wantAction: http.StatusForbidden,
+		},
+		{
+			name:       "elevated",
+			groups:     []string{"elevated"},
+			wantRole:   RoleElevated,
+			wantReader: http.StatusOK,
+			wantAction: http.StatusOK,
+		},
+		{
+			name:       "reader and elevated",
+			groups:     []string{"reader", "elevated"},
+			wantRole:   RoleElevated,
+			wantReader: http.StatusOK,
+			wantAction: http.StatusOK,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if role := rbac.Role(tt.groups); role != tt.wantRole {
+				t.Error(role)
+			}
+
+			for role, wantStatusCode := range map[Role]int{
+				RoleReader:   tt.wantReader,
+				RoleElevated: tt.wantAction,
+			} {
+				r := httptest.NewRequest(http.MethodGet, "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), ContextKeyGroups, tt.groups))
+				w := httptest.NewRecorder()
+
+				rbac.Require(role)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, r)
+
+				if w.Code != wantStatusCode {
+					t.Error(role, w.Code)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package middleware
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ActionClass groups privileged portal actions which share a step-up
+// authentication requirement.
+type ActionClass string
+
+const (
+	ActionClassSSH        ActionClass = "ssh"
+	ActionClassKubeconfig ActionClass = "kubeconfig"
+	ActionClassAdmin      ActionClass = "admin"
+)
+
+const (
+	// DefaultStepUpMaxAge is how long a step-up authentication is valid for
+	// an action class which is not otherwise configured.
+	DefaultStepUpMaxAge = 15 * time.Minute
+
+	// StepUpRequiredHeader is set on responses refused for want of a fresh
+	// step-up authentication.  Its value is the action class.
+	StepUpRequiredHeader = "X-Step-Up-Required"
+)
+
+// StepUp enforces that privileged actions are only allowed shortly after the
+// user has re-authenticated and satisfied the step-up authentication context.
+// A nil StepUp, or one without an authentication context, is disabled and
+// enforces nothing.
+type StepUp struct {
+	now         func() time.Time
+	authContext string
+	maxAge      map[ActionClass]time.Duration
+}
+
+// NewStepUp returns a StepUp requiring the given Conditional Access
+// authentication context and using the given maximum step-up ages, formatted
+// as a comma-separated list of class=duration pairs, e.g. "ssh=15m,admin=1h".
+// Classes which are not listed use DefaultStepUpMaxAge.  Step-up
+// authentication is disabled if authContext is empty.
+func NewStepUp(authContext, maxAges string) (*StepUp, error) {
+	s := &StepUp{
+		now:         time.Now,
+		authContext: authContext,
+		maxAge: map[ActionClass]time.Duration{
+			ActionClassSSH:        DefaultStepUpMaxAge,
+			ActionClassKubeconfig: DefaultStepUpMaxAge,
+			ActionClassAdmin:      DefaultStepUpMaxAge,
+		},
+	}
+
+	if maxAges == "" {
+		return s, nil
+	}
+
+	for _, pair := range strings.Split(maxAges, ",") {
+		class, age, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid step-up max age %q", pair)
+		}
+
+		if _, ok := s.maxAge[ActionClass(class)]; !ok {
+			return nil, fmt.Errorf("invalid step-up action class %q", class)
+		}
+
+		d, err := time.ParseDuration(age)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid step-up max age %q for action class %q", age, class)
+		}
+
+		s.maxAge[ActionClass(class)] = d
+	}
+
+	return s, nil
+}
+
+// Enabled returns whether step-up authentication is enforced.
+func (s *StepUp) Enabled() bool {
+	return s != nil && s.authContext != ""
+}
+
+// AuthContext returns the Conditional Access authentication context which a
+// step-up authentication must satisfy, or "" if step-up is disabled.
+func (s *StepUp) AuthContext() string {
+	if !s.Enabled() {
+		return ""
+	}
+
+	return s.authContext
+}
+
+// Require returns middleware which refuses requests unless the session's
+// last step-up authentication is recent enough for the action class.
+// Refused requests get a 401 and StepUpRequiredHeader so that the client
+// knows to send the user through /api/login?stepup=true.  If step-up is
+// disabled, the returned middleware passes all requests through.
+func (s *StepUp) Require(class ActionClass) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		if !s.Enabled() {
+			return h
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			stepUpTime, ok := r.Context().Value(ContextKeyStepUpTime).(time.Time)
+			if !ok || s.now().Sub(stepUpTime) > s.maxAge[class] {
+				w.Header().Set(StepUpRequiredHeader, string(class))
+				http.Error(w, "Step-up authentication required.", http.StatusUnauthorized)
+				return
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package middleware
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+func TestNewStepUp(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		authContext     string
+		maxAges         string
+		wantAuthContext string
+		wantMaxAge      map[ActionClass]time.Duration
+		wantErr         string
+	}{
+		{
+			name: "defaults",
+			wantMaxAge: map[ActionClass]time.Duration{
+				ActionClassSSH:        DefaultStepUpMaxAge,
+				ActionClassKubeconfig: DefaultStepUpMaxAge,
+				ActionClassAdmin:      DefaultStepUpMaxAge,
+			},
+		},
+		{
+			name:            "configured",
+			authContext:     "c2",
+			maxAges:         "ssh=5m, admin=1h",
+			wantAuthContext: "c2",
+			wantMaxAge: map[ActionClass]time.Duration{
+				ActionClassSSH:        5 * time.Minute,
+				ActionClassKubeconfig: DefaultStepUpMaxAge,
+				ActionClassAdmin:      time.Hour,
+			},
+		},
+		{
+			name:    "unknown action class",
+			maxAges: "delete=5m",
+			wantErr: `invalid step-up action class "delete"`,
+		},
+		{
+			name:    "invalid duration",
+			maxAges: "ssh=-5m",
+			wantErr: `invalid step-up max age "-5m" for action class "ssh"`,
+		},
+		{
+			name:    "missing duration",
+			maxAges: "ssh",
+			wantErr: `invalid step-up max age "ssh"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewStepUp(tt.authContext, tt.maxAges)
+			if err != nil && err.Error() != tt.wantErr ||
+				err == nil && tt.wantErr != "" {
+				t.Fatal(err)
+			}
+			if err != nil {
+				return
+			}
+
+			if s.AuthContext() != tt.wantAuthContext {
+				t.Error(s.AuthContext())
+			}
+			for _, diff := range deep.Equal(s.maxAge, tt.wantMaxAge) {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestStepUpRequire(t *testing.T) {
+	now := time.Now()
+
+	s, err := NewStepUp("c1", "ssh=5m,admin=1h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.now = func() time.Time { return now }
+
+	for _, tt := range []struct {
+		name           string
+		disabled       bool
+		stepUpTime     *time.Time
+		class          ActionClass
+		wantStatusCode int
+	}{
+		{
+			name:           "step-up disabled",
+			disabled:       true,
+			class:          ActionClassSSH,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "no step-up",
+			class:          ActionClassSSH,
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "fresh step-up",
+			stepUpTime:     &[]time.Time{now.Add(-time.Minute)}[0],
+			class:          ActionClassSSH,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "stale step-up",
+			stepUpTime:     &[]time.Time{now.Add(-10 * time.Minute)}[0],
+			class:          ActionClassSSH,
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "step-up fresh enough for another class",
+			stepUpTime:     &[]time.Time{now.Add(-10 * time.Minute)}[0],
+			class:          ActionClassAdmin,
+			wantStatusCode: http.StatusOK,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.stepUpTime != nil {
+				r = r.WithContext(context.WithValue(r.Context(), ContextKeyStepUpTime, *tt.stepUpTime))
+			}
+			w := httptest.NewRecorder()
+
+			stepUp := s
+			if tt.disabled {
+				stepUp = &StepUp{}
+			}
+
+			stepUp.Require(tt.class)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatusCode {
+				t.Error(w.Code)
+			}
+
+			wantHeader := ""
+			if tt.wantStatusCode == http.StatusUnauthorized {
+				wantHeader = string(tt.class)
+			}
+			if w.Header().Get(StepUpRequiredHeader) != wantHeader {
+				t.Error(w.Header().Get(StepUpRequiredHeader))
+			}
+		})
+	}
+}
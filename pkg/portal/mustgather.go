@@ -0,0 +1,92 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/net/websocket"
+
+	"github.com/Azure/ARO-RP/pkg/portal/cluster"
+)
+
+// mustGatherChunkSize is the size of the binary messages carrying the archive
+const mustGatherChunkSize = 64 * 1024
+
+// mustGatherMessage is a text message sent to the client during a must-gather
+// collection.  The archive itself is sent in binary messages between them.
+type mustGatherMessage struct {
+	Progress *cluster.MustGatherProgress `json:"progress,omitempty"`
+	Filename string                      `json:"filename,omitempty"`
+	Error    string                      `json:"error,omitempty"`
+}
+
+// binaryMessageWriter writes to a websocket as binary messages
+type binaryMessageWriter struct {
+	ws *websocket.Conn
+}
+
+func (w *binaryMessageWriter) Write(b []byte) (int, error) {
+	err := websocket.Message.Send(w.ws, b)
+	if err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// mustGather collects a must-gather archive from the cluster and streams it
+// to the client over a websocket, interleaved with progress messages.  The
+// last message names the archive on success or carries the error which
+// stopped the collection.
+func (p *portal) mustGather(w http.ResponseWriter, r *http.Request) {
+	// the request context is not cancelled when a hijacked connection is
+	// closed, so the websocket handler cancels this one itself
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	fetcher, err := p.makeFetcher(ctx, r)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	filename := "must-gather-" + mux.Vars(r)["clusterName"] + "-" + time.Now().UTC().Format("20060102T150405Z") + ".tar.gz"
+
+	websocket.Server{
+		Handshake: checkSameOrigin,
+		Handler: func(ws *websocket.Conn) {
+			go func() {
+				_, _ = io.Copy(io.Discard, ws)
+				cancel()
+			}()
+
+			err := streamMustGather(ctx, ws, fetcher, filename)
+			if err != nil && ctx.Err() == nil {
+				p.log.Warn(err)
+			}
+		},
+	}.ServeHTTP(w, r)
+}
+
+func streamMustGather(ctx context.Context, ws *websocket.Conn, fetcher cluster.FetchClient, filename string) error {
+	bw := bufio.NewWriterSize(&binaryMessageWriter{ws: ws}, mustGatherChunkSize)
+
+	err := fetcher.MustGather(ctx, bw, func(progress *cluster.MustGatherProgress) error {
+		return websocket.JSON.Send(ws, &mustGatherMessage{Progress: progress})
+	})
+	if err == nil {
+		err = bw.Flush()
+	}
+	if err != nil {
+		_ = websocket.JSON.Send(ws, &mustGatherMessage{Error: err.Error()})
+		return err
+	}
+
+	return websocket.JSON.Send(ws, &mustGatherMessage{Filename: filename})
+}
@@ -0,0 +1,169 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/net/websocket"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	podLogsDefaultTailLines = 500
+	podLogsMaxTailLines     = 10000
+
+	// podLogsMaxLineLength bounds the length of a single log line; longer
+	// lines are split across several messages.
+	podLogsMaxLineLength = 64 * 1024
+)
+
+type podLogsOptions struct {
+	namespace string
+	pod       string
+	container string
+	follow    bool
+	tailLines int64
+}
+
+func parsePodLogsOptions(r *http.Request) (*podLogsOptions, error) {
+	apiVars := mux.Vars(r)
+	q := r.URL.Query()
+
+	o := &podLogsOptions{
+		namespace: apiVars["namespace"],
+		pod:       apiVars["pod"],
+		container: q.Get("container"),
+		tailLines: podLogsDefaultTailLines,
+	}
+
+	if errs := validation.IsDNS1123Label(o.namespace); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid namespace %q: %s", o.namespace, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsDNS1123Subdomain(o.pod); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid pod %q: %s", o.pod, strings.Join(errs, ", "))
+	}
+	if o.container != "" {
+		if errs := validation.IsDNS1123Label(o.container); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid container %q: %s", o.container, strings.Join(errs, ", "))
+		}
+	}
+
+	if follow := q.Get("follow"); follow != "" {
+		var err error
+		o.follow, err = strconv.ParseBool(follow)
+		if err != nil {
+			return nil, fmt.Errorf("invalid follow %q", follow)
+		}
+	}
+
+	if tailLines := q.Get("tailLines"); tailLines != "" {
+		var err error
+		o.tailLines, err = strconv.ParseInt(tailLines, 10, 64)
+		if err != nil || o.tailLines < 0 || o.tailLines > podLogsMaxTailLines {
+			return nil, fmt.Errorf("invalid tailLines %q: must be between 0 and %d", tailLines, podLogsMaxTailLines)
+		}
+	}
+
+	return o, nil
+}
+
+// checkSameOrigin rejects websocket handshakes from other origins.  Browsers
+// do not apply the same-origin policy to websockets and the request carries
+// the session cookie, so without this check any site could read cluster logs
+// on behalf of a logged-in SRE.
+func checkSameOrigin(config *websocket.Config, r *http.Request) error {
+	origin, err := url.Parse(r.Header.Get("Origin"))
+	if err != nil {
+		return err
+	}
+
+	if origin.Host == "" || !strings.EqualFold(origin.Host, r.Host) {
+		return fmt.Errorf("origin %q not allowed", r.Header.Get("Origin"))
+	}
+
+	config.Origin = origin
+	return nil
+}
+
+// podLogs streams the logs of a pod container to the client over a
+// websocket, one message per line.  In follow mode the stream stays open
+// until either side goes away.
+func (p *portal) podLogs(w http.ResponseWriter, r *http.Request) {
+	o, err := parsePodLogsOptions(r)
+	if err != nil {
+		p.badRequest(w, err)
+		return
+	}
+
+	// the request context is not cancelled when a hijacked connection is
+	// closed, so the websocket handler cancels this one itself
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	fetcher, err := p.makeFetcher(ctx, r)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	// open the stream before upgrading so that errors (e.g. no such pod) can
+	// still be reported as an HTTP status
+	rc, err := fetcher.PodLogs(ctx, o.namespace, o.pod, o.container, o.follow, o.tailLines)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+	defer rc.Close()
+
+	websocket.Server{
+		Handshake: checkSameOrigin,
+		Handler: func(ws *websocket.Conn) {
+			go func() {
+				// the client never sends anything meaningful; a read error
+				// means that it has gone away
+				_, _ = io.Copy(io.Discard, ws)
+				cancel()
+			}()
+
+			err := streamLines(ws, rc)
+			if err != nil && ctx.Err() == nil {
+				p.log.Warn(err)
+			}
+		},
+	}.ServeHTTP(w, r)
+}
+
+func streamLines(ws *websocket.Conn, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 4096), podLogsMaxLineLength)
+	scanner.Split(scanLinesOrChunks)
+
+	for scanner.Scan() {
+		err := websocket.Message.Send(ws, strings.ToValidUTF8(scanner.Text(), "�"))
+		if err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// scanLinesOrChunks is bufio.ScanLines, except that it returns over-long lines
+// in chunks rather than failing the whole stream.
+func scanLinesOrChunks(data []byte, atEOF bool) (int, []byte, error) {
+	advance, token, err := bufio.ScanLines(data, atEOF)
+	if err == nil && token == nil && len(data) >= podLogsMaxLineLength {
+		return podLogsMaxLineLength, data[:podLogsMaxLineLength], nil
+	}
+	return advance, token, err
+}
@@ -0,0 +1,125 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/gorilla/mux"
+	"golang.org/x/net/websocket"
+)
+
+func TestParsePodLogsOptions(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		pod     string
+		query   string
+		want    *podLogsOptions
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			pod:  "pod-1",
+			want: &podLogsOptions{
+				namespace: "openshift-etcd",
+				pod:       "pod-1",
+				tailLines: podLogsDefaultTailLines,
+			},
+		},
+		{
+			name:  "all options",
+			pod:   "pod-1",
+			query: "?container=etcd&follow=true&tailLines=20",
+			want: &podLogsOptions{
+				namespace: "openshift-etcd",
+				pod:       "pod-1",
+				container: "etcd",
+				follow:    true,
+				tailLines: 20,
+			},
+		},
+		{
+			name:    "invalid pod",
+			pod:     "Pod_1",
+			wantErr: `invalid pod "Pod_1"`,
+		},
+		{
+			name:    "invalid follow",
+			pod:     "pod-1",
+			query:   "?follow=maybe",
+			wantErr: `invalid follow "maybe"`,
+		},
+		{
+			name:    "too many lines",
+			pod:     "pod-1",
+			query:   "?tailLines=10001",
+			wantErr: `invalid tailLines "10001": must be between 0 and 10000`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/logs"+tt.query, nil)
+			r = mux.SetURLVars(r, map[string]string{
+				"namespace": "openshift-etcd",
+				"pod":       tt.pod,
+			})
+
+			o, err := parsePodLogsOptions(r)
+			if err != nil && !strings.HasPrefix(err.Error(), tt.wantErr) ||
+				err == nil && tt.wantErr != "" {
+				t.Fatal(err)
+			}
+
+			for _, diff := range deep.Equal(o, tt.want) {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPodLogsWebsocket(t *testing.T) {
+	logs := "line 1\nline 2\n" + strings.Repeat("x", podLogsMaxLineLength+1) + "\n"
+
+	srv := httptest.NewServer(websocket.Server{
+		Handshake: checkSameOrigin,
+		Handler: func(ws *websocket.Conn) {
+			err := streamLines(ws, strings.NewReader(logs))
+			if err != nil {
+				t.Error(err)
+			}
+		},
+	})
+	defer srv.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	_, err := websocket.Dial(wsURL, "", "https://attacker.example.com")
+	if err == nil {
+		t.Error("expected cross-origin handshake to fail")
+	}
+
+	ws, err := websocket.Dial(wsURL, "", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	var got []string
+	for {
+		var msg string
+		err = websocket.Message.Receive(ws, &msg)
+		if err != nil {
+			break
+		}
+		got = append(got, msg)
+	}
+
+	want := []string{"line 1", "line 2", strings.Repeat("x", podLogsMaxLineLength), "x"}
+	for _, diff := range deep.Equal(got, want) {
+		t.Error(diff)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/env"
 	frontendmiddleware "github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/hive"
 	"github.com/Azure/ARO-RP/pkg/metrics"
 	"github.com/Azure/ARO-RP/pkg/portal/assets"
 	"github.com/Azure/ARO-RP/pkg/portal/cluster"
@@ -40,6 +41,7 @@ import (
 )
 
 type portalDBs interface {
+	database.DatabaseGroupWithAsyncOperations
 	database.DatabaseGroupWithOpenShiftClusters
 	database.DatabaseGroupWithPortal
 }
@@ -66,13 +68,24 @@ type portal struct {
 	sessionKey   []byte
 	sshKey       *rsa.PrivateKey
 
+	sshSessionRetention time.Duration
+
+	// debugPodImage is the fully qualified, digest-pinned image of node
+	// debug pods
+	debugPodImage string
+
 	groupIDs         []string
 	elevatedGroupIDs []string
 
+	stepUp *middleware.StepUp
+
 	dbGroup portalDBs
 
 	dialer proxy.Dialer
 
+	// hiveClusterManager is nil in regions without Hive
+	hiveClusterManager hive.ClusterManager
+
 	templateV2         *template.Template
 	templatePrometheus *template.Template
 
@@ -96,10 +109,14 @@ func NewPortal(env env.Core,
 	clientCerts []*x509.Certificate,
 	sessionKey []byte,
 	sshKey *rsa.PrivateKey,
+	sshSessionRetention time.Duration,
+	debugPodImage string,
 	groupIDs []string,
 	elevatedGroupIDs []string,
+	stepUp *middleware.StepUp,
 	dbGroup portalDBs,
 	dialer proxy.Dialer,
+	hiveClusterManager hive.ClusterManager,
 	m metrics.Emitter,
 ) Runnable {
 	return &portal{
@@ -120,13 +137,21 @@ func NewPortal(env env.Core,
 		sessionKey:   sessionKey,
 		sshKey:       sshKey,
 
+		sshSessionRetention: sshSessionRetention,
+
+		debugPodImage: debugPodImage,
+
 		groupIDs:         groupIDs,
 		elevatedGroupIDs: elevatedGroupIDs,
 
+		stepUp: stepUp,
+
 		dbGroup: dbGroup,
 
 		dialer: dialer,
 
+		hiveClusterManager: hiveClusterManager,
+
 		m: m,
 	}
 }
@@ -162,7 +187,7 @@ func (p *portal) setupRouter(kconfig *kubeconfig.Kubeconfig, prom *prometheus.Pr
 	allGroups := append([]string{}, p.groupIDs...)
 	allGroups = append(allGroups, p.elevatedGroupIDs...)
 
-	p.aad, err = middleware.NewAAD(p.log, p.audit, p.env, p.baseAccessLog, p.hostname, p.sessionKey, p.clientID, p.clientKey, p.clientCerts, allGroups, unauthenticatedRouter, p.verifier)
+	p.aad, err = middleware.NewAAD(p.log, p.audit, p.env, p.baseAccessLog, p.hostname, p.sessionKey, p.clientID, p.clientKey, p.clientCerts, allGroups, p.stepUp.AuthContext(), unauthenticatedRouter, p.verifier)
 	if err != nil {
 		return nil, err
 	}
@@ -171,6 +196,7 @@ func (p *portal) setupRouter(kconfig *kubeconfig.Kubeconfig, prom *prometheus.Pr
 	aadAuthenticatedRouter.Use(p.aad.AAD)
 	aadAuthenticatedRouter.Use(middleware.Log(p.env, p.audit, p.baseAccessLog))
 	aadAuthenticatedRouter.Use(p.aad.CheckAuthentication)
+	aadAuthenticatedRouter.Use(p.rbac().Require(middleware.RoleReader))
 	aadAuthenticatedRouter.Use(csrf.Protect(p.sessionKey, csrf.SameSite(csrf.SameSiteStrictMode), csrf.MaxAge(0), csrf.Path("/")))
 
 	p.aadAuthenticatedRoutes(aadAuthenticatedRouter, prom, kconfig, sshStruct)
@@ -189,7 +215,7 @@ func (p *portal) setupServices() (*kubeconfig.Kubeconfig, *prometheus.Prometheus
 		return nil, nil, nil, err
 	}
 
-	ssh, err := ssh.New(p.env, p.log, p.baseAccessLog, p.sshl, p.sshKey, p.elevatedGroupIDs, dbOpenShiftClusters, dbPortal, p.dialer)
+	ssh, err := ssh.New(p.env, p.log, p.audit, p.baseAccessLog, p.sshl, p.sshKey, p.elevatedGroupIDs, dbOpenShiftClusters, dbPortal, p.dialer, p.sshSessionRetention)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -288,8 +314,12 @@ func (p *portal) aadAuthenticatedRoutes(r *mux.Router, prom *prometheus.Promethe
 	}
 
 	r.Methods(http.MethodGet).Path("/api/clusters").HandlerFunc(p.clusters)
+	r.Methods(http.MethodGet).Path("/api/clusters/search").HandlerFunc(p.clusterSearch)
 	r.Methods(http.MethodGet).Path("/api/info").HandlerFunc(p.info)
 	r.Methods(http.MethodGet).Path("/api/regions").HandlerFunc(p.regions)
+	r.Methods(http.MethodGet).Path("/api/prometheus/queries").HandlerFunc(p.prometheusQueries)
+	r.Methods(http.MethodPost).Path("/api/prometheus/queries").HandlerFunc(p.savePrometheusQuery)
+	r.Methods(http.MethodDelete).Path("/api/prometheus/queries/{id}").HandlerFunc(p.deletePrometheusQuery)
 
 	// Cluster-specific routes
 	r.Path("/api/{subscription}/{resourceGroup}/{clusterName}/clusteroperators").HandlerFunc(p.clusterOperators)
@@ -298,6 +328,10 @@ func (p *portal) aadAuthenticatedRoutes(r *mux.Router, prom *prometheus.Promethe
 	r.Path("/api/{subscription}/{resourceGroup}/{clusterName}/machines").HandlerFunc(p.machines)
 	r.Path("/api/{subscription}/{resourceGroup}/{clusterName}/machine-sets").HandlerFunc(p.machineSets)
 	r.Path("/api/{subscription}/{resourceGroup}/{clusterName}/statistics/{statisticsType}").HandlerFunc(p.statistics)
+	r.Methods(http.MethodGet).Path("/api/{subscription}/{resourceGroup}/{clusterName}/timeline").HandlerFunc(p.timeline)
+	r.Methods(http.MethodGet).Path("/api/{subscription}/{resourceGroup}/{clusterName}/hive").HandlerFunc(p.hiveClusterDeployment)
+	r.Methods(http.MethodGet).Path("/api/{subscription}/{resourceGroup}/{clusterName}/prometheus/query").HandlerFunc(p.promQuery)
+	r.Methods(http.MethodGet).Path("/api/{subscription}/{resourceGroup}/{clusterName}/namespaces/{namespace}/pods/{pod}/logs").HandlerFunc(p.podLogs)
 	r.Path("/api/{subscription}/{resourceGroup}/{clusterName}").HandlerFunc(p.clusterInfo)
 
 	// prometheus
@@ -319,11 +353,22 @@ func (p *portal) aadAuthenticatedRoutes(r *mux.Router, prom *prometheus.Promethe
 
 	//kubeconfig
 	if kconfig != nil {
-		r.Methods(http.MethodPost).Path("/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/microsoft.redhatopenshift/openshiftclusters/{resourceName}/kubeconfig/new").HandlerFunc(kconfig.New)
+		r.Methods(http.MethodPost).Path("/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/microsoft.redhatopenshift/openshiftclusters/{resourceName}/kubeconfig/new").Handler(p.stepUp.Require(middleware.ActionClassKubeconfig)(http.HandlerFunc(kconfig.New)))
 	}
 
+	// Routes which act on clusters or expose what others did on them require
+	// the elevated role
+	elevatedRouter := r.NewRoute().Subrouter()
+	elevatedRouter.Use(p.rbac().Require(middleware.RoleElevated))
+
+	elevatedRouter.Methods(http.MethodGet).Path("/api/{subscription}/{resourceGroup}/{clusterName}/sshsessions").HandlerFunc(p.sshSessions)
+	// Routes which give access to the cluster also require a recent step-up
+	// authentication
+	elevatedRouter.Methods(http.MethodGet).Path("/api/{subscription}/{resourceGroup}/{clusterName}/mustgather").Handler(p.stepUp.Require(middleware.ActionClassAdmin)(http.HandlerFunc(p.mustGather)))
+	elevatedRouter.Methods(http.MethodGet).Path("/api/{subscription}/{resourceGroup}/{clusterName}/nodes/{node}/debug").Handler(p.stepUp.Require(middleware.ActionClassSSH)(http.HandlerFunc(p.debugTerminal)))
+
 	// ssh
-	r.Methods(http.MethodPost).Path("/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/microsoft.redhatopenshift/openshiftclusters/{resourceName}/ssh/new").HandlerFunc(sshStruct.New)
+	elevatedRouter.Methods(http.MethodPost).Path("/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/microsoft.redhatopenshift/openshiftclusters/{resourceName}/ssh/new").Handler(p.stepUp.Require(middleware.ActionClassSSH)(http.HandlerFunc(sshStruct.New)))
 
 	for _, name := range names {
 		regexp, _ := regexp.Compile(`v2/build/.*\..*`)
@@ -419,6 +464,11 @@ func (p *portal) serve(path string) func(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// rbac maps the portal's reader and elevated groups to portal roles
+func (p *portal) rbac() *middleware.RBAC {
+	return middleware.NewRBAC(p.groupIDs, p.elevatedGroupIDs)
+}
+
 func (p *portal) getResourceID(subscriptionID, resourceGroup, clusterName string) string {
 	return strings.ToLower(
 		fmt.Sprintf(
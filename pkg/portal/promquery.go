@@ -0,0 +1,274 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/portal/cluster"
+	"github.com/Azure/ARO-RP/pkg/portal/middleware"
+	"github.com/Azure/ARO-RP/pkg/portal/prometheus"
+)
+
+const (
+	promQueryMaxLength     = 4096
+	promQueryNameMaxLength = 256
+)
+
+type PrometheusQuery struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Query    string `json:"query"`
+	Username string `json:"username,omitempty"`
+}
+
+// parsePromQueryRange returns the range of a range query, or nil and the
+// evaluation time of an instant query.  A query is a range query if any of
+// start, end or step is set.
+func parsePromQueryRange(r *http.Request, now time.Time) (*v1.Range, time.Time, error) {
+	q := r.URL.Query()
+
+	if q.Get("start") == "" && q.Get("end") == "" && q.Get("step") == "" {
+		t := now
+		if q.Get("time") != "" {
+			var err error
+			t, err = time.Parse(time.RFC3339, q.Get("time"))
+			if err != nil {
+				return nil, time.Time{}, fmt.Errorf("invalid time %q", q.Get("time"))
+			}
+		}
+		return nil, t, nil
+	}
+
+	start, err := time.Parse(time.RFC3339, q.Get("start"))
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("invalid start %q", q.Get("start"))
+	}
+
+	end := now
+	if q.Get("end") != "" {
+		end, err = time.Parse(time.RFC3339, q.Get("end"))
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("invalid end %q", q.Get("end"))
+		}
+	}
+
+	step, err := time.ParseDuration(q.Get("step"))
+	if err != nil || step <= 0 {
+		return nil, time.Time{}, fmt.Errorf("invalid step %q", q.Get("step"))
+	}
+
+	if !end.After(start) {
+		return nil, time.Time{}, fmt.Errorf("end must be after start")
+	}
+
+	if end.Sub(start)/step+1 > cluster.PromQueryMaxPoints {
+		return nil, time.Time{}, fmt.Errorf("range query would return more than %d points per series, increase step", cluster.PromQueryMaxPoints)
+	}
+
+	return &v1.Range{Start: start, End: end, Step: step}, time.Time{}, nil
+}
+
+// promQuery runs an arbitrary PromQL query against the cluster's Prometheus.
+func (p *portal) promQuery(w http.ResponseWriter, r *http.Request) {
+	dbOpenShiftClusters, err := p.dbGroup.OpenShiftClusters()
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	ctx := r.Context()
+
+	query := r.URL.Query().Get("query")
+	if query == "" || len(query) > promQueryMaxLength {
+		p.badRequest(w, fmt.Errorf("invalid query"))
+		return
+	}
+
+	queryRange, t, err := parsePromQueryRange(r, time.Now())
+	if err != nil {
+		p.badRequest(w, err)
+		return
+	}
+
+	apiVars := mux.Vars(r)
+	resourceID := p.getResourceID(apiVars["subscription"], apiVars["resourceGroup"], apiVars["clusterName"])
+
+	fetcher, err := p.makeFetcher(ctx, r)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	prom := prometheus.New(p.log, dbOpenShiftClusters, p.dialer)
+	httpClient, err := prom.Cli(ctx, resourceID)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	promHost, promScheme := prom.GetPrometheusHostAndScheme()
+	result, err := fetcher.PromQuery(ctx, httpClient, promScheme+"://"+promHost, query, queryRange, t)
+	var promErr *v1.Error
+	if errors.As(err, &promErr) && promErr.Type == v1.ErrBadData {
+		// an invalid query: return Prometheus's explanation to the SRE
+		http.Error(w, promErr.Msg, http.StatusBadRequest)
+		return
+	} else if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	b, err := json.MarshalIndent(result, "", "    ")
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+// prometheusQueries lists the saved Prometheus queries, which are shared
+// between all SREs.
+func (p *portal) prometheusQueries(w http.ResponseWriter, r *http.Request) {
+	dbPortal, err := p.dbGroup.Portal()
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	docs, err := dbPortal.ListPrometheusQueries(r.Context())
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	queries := make([]*PrometheusQuery, 0, len(docs.PortalDocuments))
+	for _, doc := range docs.PortalDocuments {
+		queries = append(queries, &PrometheusQuery{
+			ID:       doc.ID,
+			Name:     doc.Portal.PrometheusQuery.Name,
+			Query:    doc.Portal.PrometheusQuery.Query,
+			Username: doc.Portal.Username,
+		})
+	}
+
+	sort.SliceStable(queries, func(i, j int) bool { return strings.ToLower(queries[i].Name) < strings.ToLower(queries[j].Name) })
+
+	b, err := json.MarshalIndent(queries, "", "    ")
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+func (p *portal) savePrometheusQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	dbPortal, err := p.dbGroup.Portal()
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	mediatype, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediatype != "application/json" {
+		http.Error(w, http.StatusText(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var query *PrometheusQuery
+	err = json.NewDecoder(r.Body).Decode(&query)
+	if err != nil {
+		p.badRequest(w, err)
+		return
+	}
+
+	if query == nil ||
+		query.Name == "" || len(query.Name) > promQueryNameMaxLength ||
+		query.Query == "" || len(query.Query) > promQueryMaxLength {
+		p.badRequest(w, fmt.Errorf("invalid query"))
+		return
+	}
+
+	doc, err := dbPortal.Create(ctx, &api.PortalDocument{
+		ID: dbPortal.NewUUID(),
+		Portal: &api.Portal{
+			Username: ctx.Value(middleware.ContextKeyUsername).(string),
+			PrometheusQuery: &api.PrometheusQuery{
+				Name:  query.Name,
+				Query: query.Query,
+			},
+		},
+	})
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	b, err := json.MarshalIndent(&PrometheusQuery{
+		ID:       doc.ID,
+		Name:     doc.Portal.PrometheusQuery.Name,
+		Query:    doc.Portal.PrometheusQuery.Query,
+		Username: doc.Portal.Username,
+	}, "", "    ")
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write(b)
+}
+
+// deletePrometheusQuery deletes a saved query.  SREs may delete their own
+// queries; elevated SREs may delete anyone's.
+func (p *portal) deletePrometheusQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	dbPortal, err := p.dbGroup.Portal()
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	id := strings.ToLower(mux.Vars(r)["id"])
+
+	doc, err := dbPortal.Get(ctx, id)
+	if err != nil || doc.Portal.PrometheusQuery == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	username, _ := ctx.Value(middleware.ContextKeyUsername).(string)
+	groups, _ := ctx.Value(middleware.ContextKeyGroups).([]string)
+	if !p.rbac().Has(groups, middleware.RoleElevated) && !strings.EqualFold(doc.Portal.Username, username) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	err = dbPortal.Delete(ctx, doc)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
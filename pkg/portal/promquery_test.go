@@ -0,0 +1,164 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/gorilla/mux"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/portal/middleware"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestParsePromQueryRange(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		name      string
+		query     string
+		wantRange *v1.Range
+		wantTime  time.Time
+		wantErr   string
+	}{
+		{
+			name:     "instant query now",
+			wantTime: now,
+		},
+		{
+			name:     "instant query at time",
+			query:    "?time=2024-01-01T11:00:00Z",
+			wantTime: now.Add(-time.Hour),
+		},
+		{
+			name:  "range query",
+			query: "?start=2024-01-01T11:00:00Z&end=2024-01-01T11:30:00Z&step=1m",
+			wantRange: &v1.Range{
+				Start: now.Add(-time.Hour),
+				End:   now.Add(-30 * time.Minute),
+				Step:  time.Minute,
+			},
+		},
+		{
+			name:  "range query until now",
+			query: "?start=2024-01-01T11:00:00Z&step=1m",
+			wantRange: &v1.Range{
+				Start: now.Add(-time.Hour),
+				End:   now,
+				Step:  time.Minute,
+			},
+		},
+		{
+			name:    "missing step",
+			query:   "?start=2024-01-01T11:00:00Z",
+			wantErr: `invalid step ""`,
+		},
+		{
+			name:    "end before start",
+			query:   "?start=2024-01-01T11:00:00Z&end=2024-01-01T10:00:00Z&step=1m",
+			wantErr: "end must be after start",
+		},
+		{
+			name:    "too many points",
+			query:   "?start=2024-01-01T00:00:00Z&step=15s",
+			wantErr: "range query would return more than 1000 points per series, increase step",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/query"+tt.query, nil)
+
+			gotRange, gotTime, err := parsePromQueryRange(r, now)
+			if err != nil && err.Error() != tt.wantErr ||
+				err == nil && tt.wantErr != "" {
+				t.Fatal(err)
+			}
+
+			for _, diff := range deep.Equal(gotRange, tt.wantRange) {
+				t.Error(diff)
+			}
+			if !gotTime.Equal(tt.wantTime) {
+				t.Error(gotTime)
+			}
+		})
+	}
+}
+
+func TestSavedPrometheusQueries(t *testing.T) {
+	dbPortal, _ := testdatabase.NewFakePortal()
+	dbg := database.NewDBGroup().WithPortal(dbPortal)
+
+	p := &portal{
+		log:              logrus.NewEntry(logrus.StandardLogger()),
+		dbGroup:          dbg,
+		elevatedGroupIDs: []string{"elevated"},
+	}
+
+	router := mux.NewRouter()
+	p.aadAuthenticatedRoutes(router, nil, nil, nil)
+
+	do := func(method, path, body, username string, groups []string) *httptest.ResponseRecorder {
+		ctx := context.WithValue(context.Background(), middleware.ContextKeyUsername, username)
+		ctx = context.WithValue(ctx, middleware.ContextKeyGroups, groups)
+
+		r := httptest.NewRequest(method, path, strings.NewReader(body)).WithContext(ctx)
+		r.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	w := do(http.MethodPost, "/api/prometheus/queries", `{"name":"etcd leader changes","query":"changes(etcd_server_leader_changes_seen_total[1h])"}`, "alice@example.com", nil)
+	if w.Code != http.StatusCreated {
+		t.Fatal(w.Code)
+	}
+
+	w = do(http.MethodPost, "/api/prometheus/queries", `{"name":"","query":"up"}`, "alice@example.com", nil)
+	if w.Code != http.StatusBadRequest {
+		t.Error(w.Code)
+	}
+
+	w = do(http.MethodGet, "/api/prometheus/queries", "", "bob@example.com", nil)
+	var queries []*PrometheusQuery
+	err := json.NewDecoder(w.Body).Decode(&queries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, diff := range deep.Equal(queries, []*PrometheusQuery{
+		{
+			ID:       "03030303-0303-0303-0303-030303030001",
+			Name:     "etcd leader changes",
+			Query:    "changes(etcd_server_leader_changes_seen_total[1h])",
+			Username: "alice@example.com",
+		},
+	}) {
+		t.Error(diff)
+	}
+
+	w = do(http.MethodDelete, "/api/prometheus/queries/"+queries[0].ID, "", "bob@example.com", nil)
+	if w.Code != http.StatusForbidden {
+		t.Error(w.Code)
+	}
+
+	w = do(http.MethodDelete, "/api/prometheus/queries/"+queries[0].ID, "", "bob@example.com", []string{"elevated"})
+	if w.Code != http.StatusNoContent {
+		t.Error(w.Code)
+	}
+
+	w = do(http.MethodDelete, "/api/prometheus/queries/"+queries[0].ID, "", "alice@example.com", nil)
+	if w.Code != http.StatusNotFound {
+		t.Error(w.Code)
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -95,7 +94,12 @@ func TestSecurity(t *testing.T) {
 		WithOpenShiftClusters(dbOpenShiftClusters).
 		WithPortal(dbPortal)
 
-	p := NewPortal(_env, portalAuditLog, portalLog, portalAccessLog, l, sshl, nil, "", serverkey, servercerts, "", nil, nil, make([]byte, 32), sshkey, nil, elevatedGroupIDs, dbg, nil, &noop.Noop{})
+	stepUp, err := middleware.NewStepUp("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewPortal(_env, portalAuditLog, portalLog, portalAccessLog, l, sshl, nil, "", serverkey, servercerts, "", nil, nil, make([]byte, 32), sshkey, 0, "", nonElevatedGroupIDs, elevatedGroupIDs, stepUp, dbg, nil, nil, &noop.Noop{})
 	go func() {
 		err := p.Run(ctx)
 		if err != nil {
@@ -109,6 +113,7 @@ func TestSecurity(t *testing.T) {
 		checkResponse                 func(*testing.T, bool, bool, *http.Response)
 		unauthenticatedWantStatusCode int
 		authenticatedWantStatusCode   int
+		readerWantStatusCode          int
 		wantAuditOperation            string
 		wantAuditTargetResources      []audit.TargetResource
 	}{
@@ -235,21 +240,8 @@ func TestSecurity(t *testing.T) {
 
 				return req, nil
 			},
-			checkResponse: func(t *testing.T, authenticated, elevated bool, resp *http.Response) {
-				if authenticated && !elevated {
-					var e struct {
-						Error string `json:"error,omitempty"`
-					}
-					err := json.NewDecoder(resp.Body).Decode(&e)
-					if err != nil {
-						t.Fatal(err)
-					}
-					if e.Error != "Elevated access is required." {
-						t.Error(e.Error)
-					}
-				}
-			},
-			wantAuditOperation: "POST /subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroupname/providers/microsoft.redhatopenshift/openshiftclusters/resourcename/ssh/new",
+			readerWantStatusCode: http.StatusForbidden,
+			wantAuditOperation:   "POST /subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroupname/providers/microsoft.redhatopenshift/openshiftclusters/resourcename/ssh/new",
 			wantAuditTargetResources: []audit.TargetResource{
 				{
 					TargetResourceType: "ssh",
@@ -302,7 +294,7 @@ func TestSecurity(t *testing.T) {
 				}
 
 				if tt2.authenticated {
-					var groups []string
+					groups := nonElevatedGroupIDs
 					if tt2.elevated {
 						groups = elevatedGroupIDs
 					}
@@ -318,6 +310,10 @@ func TestSecurity(t *testing.T) {
 				}
 				defer resp.Body.Close()
 
+				if tt2.authenticated && !tt2.elevated && tt.readerWantStatusCode != 0 {
+					tt2.wantStatusCode = tt.readerWantStatusCode
+				}
+
 				if tt2.wantStatusCode == 0 {
 					if tt2.authenticated {
 						tt2.wantStatusCode = http.StatusOK
@@ -424,9 +420,10 @@ func addAuth(req *http.Request, groups []string) error {
 	store := sessions.NewCookieStore(make([]byte, 32))
 
 	cookie, err := securecookie.EncodeMulti(middleware.SessionName, map[interface{}]interface{}{
-		middleware.SessionKeyUsername: "username",
-		middleware.SessionKeyGroups:   groups,
-		middleware.SessionKeyExpires:  time.Now().Add(time.Hour).Unix(),
+		middleware.SessionKeyUsername:   "username",
+		middleware.SessionKeyGroups:     groups,
+		middleware.SessionKeyExpires:    time.Now().Add(time.Hour).Unix(),
+		middleware.SessionKeyStepUpTime: time.Now().Unix(),
 	}, store.Codecs...)
 	if err != nil {
 		return err
@@ -189,13 +189,21 @@ func (s *SSH) newConn(ctx context.Context, clientConn net.Conn) error {
 		return err
 	}
 
+	// Don't allow unaudited sessions: if the session can't be recorded, drop
+	// the connection.
+	recorder, err := s.newSessionRecorder(ctx, portalDoc, clientConn.RemoteAddr().String())
+	if err != nil {
+		return err
+	}
+	defer recorder.end()
+
 	// Proxy channels and requests between the two connections.
-	return s.proxyConn(ctx, accessLog, keyring, upstreamConn, downstreamConn, upstreamNewChannels, downstreamNewChannels, upstreamRequests, downstreamRequests)
+	return s.proxyConn(ctx, accessLog, keyring, recorder, upstreamConn, downstreamConn, upstreamNewChannels, downstreamNewChannels, upstreamRequests, downstreamRequests)
 }
 
 // proxyConn handles incoming new channel and administrative requests.  It calls
 // newChannel to handle new channels, each on a new goroutine.
-func (s *SSH) proxyConn(ctx context.Context, accessLog *logrus.Entry, keyring agent.Agent, upstreamConn, downstreamConn cryptossh.Conn, upstreamNewChannels, downstreamNewChannels <-chan cryptossh.NewChannel, upstreamRequests, downstreamRequests <-chan *cryptossh.Request) error {
+func (s *SSH) proxyConn(ctx context.Context, accessLog *logrus.Entry, keyring agent.Agent, recorder *sessionRecorder, upstreamConn, downstreamConn cryptossh.Conn, upstreamNewChannels, downstreamNewChannels <-chan cryptossh.NewChannel, upstreamRequests, downstreamRequests <-chan *cryptossh.Request) error {
 	timer := time.NewTimer(sshTimeout)
 	defer timer.Stop()
 
@@ -220,7 +228,7 @@ func (s *SSH) proxyConn(ctx context.Context, accessLog *logrus.Entry, keyring ag
 			}
 
 			go func() {
-				_ = s.newChannel(ctx, accessLog, nc, upstreamConn, downstreamConn, firstSession)
+				_ = s.newChannel(ctx, accessLog, nc, upstreamConn, downstreamConn, firstSession, recorder.newChannelRecorder())
 			}()
 
 		case nc := <-downstreamNewChannels:
@@ -235,7 +243,7 @@ func (s *SSH) proxyConn(ctx context.Context, accessLog *logrus.Entry, keyring ag
 				}()
 			} else {
 				go func() {
-					_ = s.newChannel(ctx, accessLog, nc, downstreamConn, upstreamConn, false)
+					_ = s.newChannel(ctx, accessLog, nc, downstreamConn, upstreamConn, false, nil)
 				}()
 			}
 
@@ -276,8 +284,8 @@ func (s *SSH) handleAgent(accessLog *logrus.Entry, nc cryptossh.NewChannel, keyr
 
 // newChannel handles an incoming request to create a new channel.  If the
 // channel creation is successful, it calls proxyChannel to proxy the channel
-// between SRE and cluster.
-func (s *SSH) newChannel(ctx context.Context, accessLog *logrus.Entry, nc cryptossh.NewChannel, upstreamConn, downstreamConn cryptossh.Conn, firstSession bool) error {
+// between SRE and cluster.  recorder is set for channels opened by the SRE.
+func (s *SSH) newChannel(ctx context.Context, accessLog *logrus.Entry, nc cryptossh.NewChannel, upstreamConn, downstreamConn cryptossh.Conn, firstSession bool, recorder *channelRecorder) error {
 	defer recover.Panic(s.log)
 
 	ch2, rs2, err := downstreamConn.OpenChannel(nc.ChannelType(), nc.ExtraData())
@@ -307,7 +315,7 @@ func (s *SSH) newChannel(ctx context.Context, accessLog *logrus.Entry, nc crypto
 		go s.keepAliveConn(ctx, ch1)
 	}
 
-	return s.proxyChannel(ch1, ch2, rs1, rs2)
+	return s.proxyChannel(ch1, ch2, rs1, rs2, recorder)
 }
 
 func (s *SSH) proxyGlobalRequest(r *cryptossh.Request, c cryptossh.Conn) error {
@@ -328,7 +336,7 @@ func (s *SSH) proxyRequest(r *cryptossh.Request, ch cryptossh.Channel) error {
 	return r.Reply(ok, nil)
 }
 
-func (s *SSH) proxyChannel(ch1, ch2 cryptossh.Channel, rs1, rs2 <-chan *cryptossh.Request) error {
+func (s *SSH) proxyChannel(ch1, ch2 cryptossh.Channel, rs1, rs2 <-chan *cryptossh.Request, recorder *channelRecorder) error {
 	g := errgroup.Group{}
 
 	g.Go(func() error {
@@ -348,7 +356,12 @@ func (s *SSH) proxyChannel(ch1, ch2 cryptossh.Channel, rs1, rs2 <-chan *cryptoss
 		defer func() {
 			_ = ch2.CloseWrite()
 		}()
-		_, err := io.Copy(ch2, ch1)
+		var w io.Writer = ch2
+		if recorder != nil {
+			w = io.MultiWriter(ch2, recorder)
+			defer recorder.flush()
+		}
+		_, err := io.Copy(w, ch1)
 		if err != nil {
 			return err
 		}
@@ -359,6 +372,9 @@ func (s *SSH) proxyChannel(ch1, ch2 cryptossh.Channel, rs1, rs2 <-chan *cryptoss
 		defer recover.Panic(s.log)
 
 		for r := range rs1 {
+			if recorder != nil {
+				recorder.request(r)
+			}
 			err := s.proxyRequest(r, ch2)
 			if err != nil {
 				break
@@ -8,12 +8,15 @@ import (
 	"context"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-test/deep"
 	"github.com/gorilla/mux"
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
@@ -23,6 +26,9 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/log/audit"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
 	mock_proxy "github.com/Azure/ARO-RP/pkg/util/mocks/proxy"
 	utiltls "github.com/Azure/ARO-RP/pkg/util/tls"
 	testdatabase "github.com/Azure/ARO-RP/test/database"
@@ -143,6 +149,8 @@ func TestProxy(t *testing.T) {
 	resourceName := "cluster"
 	resourceID := "/subscriptions/" + subscriptionID + "/resourcegroups/" + resourceGroup + "/providers/microsoft.redhatopenshift/openshiftclusters/" + resourceName
 	apiServerPrivateEndpointIP := "1.2.3.4"
+	sessionID := "03030303-0303-0303-0303-030303030001"
+	now := time.Unix(1000, 0)
 
 	hostKey, _, err := utiltls.GenerateKeyAndCertificate("proxy", nil, nil, false, false)
 	if err != nil {
@@ -196,6 +204,7 @@ func TestProxy(t *testing.T) {
 		mocks          func(*mock_proxy.MockDialer)
 		wantErrPrefix  string
 		wantLogs       []map[string]types.GomegaMatcher
+		wantAudit      []string
 	}
 
 	for _, tt := range []*test{
@@ -210,12 +219,26 @@ func TestProxy(t *testing.T) {
 				fixture.AddOpenShiftClusterDocuments(openShiftClusterDocument)
 				portalDocument = goodPortalDocument(tt.password)
 				portalDocument.Portal.SSH.Authenticated = true
-				checker.AddPortalDocuments(portalDocument)
+				checker.AddPortalDocuments(portalDocument, &api.PortalDocument{
+					ID:  sessionID,
+					TTL: 3600,
+					Portal: &api.Portal{
+						ID:       resourceID,
+						Username: username,
+						SSHSession: &api.SSHSession{
+							Master:     1,
+							RemoteAddr: "bufferedpipe",
+							StartTime:  now.Unix(),
+							EndTime:    now.Unix(),
+						},
+					},
+				})
 				checker.AddOpenShiftClusterDocuments(openShiftClusterDocument)
 			},
 			mocks: func(dialer *mock_proxy.MockDialer) {
 				dialer.EXPECT().DialContext(gomock.Any(), "tcp", apiServerPrivateEndpointIP+":2201").Return(l.DialContext(ctx, "", ""))
 			},
+			wantAudit: []string{"SSH session start", "SSH session end"},
 			wantLogs: []map[string]types.GomegaMatcher{
 				{
 					"level":       gomega.Equal(logrus.InfoLevel),
@@ -411,12 +434,19 @@ func TestProxy(t *testing.T) {
 				tt.mocks(dialer)
 			}
 
+			env := mock_env.NewMockCore(ctrl)
+			env.EXPECT().Environment().AnyTimes().Return(&azureclient.PublicCloud)
+			env.EXPECT().Hostname().AnyTimes().Return("portal")
+			env.EXPECT().Location().AnyTimes().Return("eastus")
+
 			hook, log := testlog.New()
+			auditHook, auditLog := testlog.NewAudit()
 
-			s, err := New(nil, nil, log, nil, hostKey, nil, dbOpenShiftClusters, dbPortal, dialer)
+			s, err := New(env, nil, auditLog, log, nil, hostKey, nil, dbOpenShiftClusters, dbPortal, dialer, time.Hour)
 			if err != nil {
 				t.Fatal(err)
 			}
+			s.now = func() time.Time { return now }
 
 			r := mux.NewRouter()
 			r.Methods(http.MethodPost).Path("/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/microsoft.redhatopenshift/openshiftclusters/{resourceName}/ssh/new").HandlerFunc(s.New)
@@ -451,6 +481,22 @@ func TestProxy(t *testing.T) {
 			if err != nil {
 				t.Error(err)
 			}
+
+			var gotAudit []string
+			for _, entry := range auditHook.AllEntries() {
+				var payload audit.Payload
+				err = json.Unmarshal([]byte(entry.Data[audit.MetadataPayload].(string)), &payload)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if payload.CallerIdentities[0].CallerIdentityValue != username {
+					t.Error(payload.CallerIdentities)
+				}
+				gotAudit = append(gotAudit, payload.OperationName)
+			}
+			for _, diff := range deep.Equal(gotAudit, tt.wantAudit) {
+				t.Error(diff)
+			}
 		})
 	}
 }
@@ -0,0 +1,136 @@
+package ssh
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	cryptossh "golang.org/x/crypto/ssh"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/portal/util/recorder"
+)
+
+const (
+	// DefaultSessionRetention is how long the metadata of an SSH session is
+	// kept in the database after the session starts.
+	DefaultSessionRetention = 90 * 24 * time.Hour
+
+	auditTargetResourceType = "ssh"
+)
+
+// sessionRecorder keeps the audit trail of a single SRE->cluster SSH
+// connection.  Its metadata is stored in the Portal database so that it can be
+// looked up later; the commands run during the session are emitted as IFx
+// audit events, which are shipped to Geneva with the rest of the portal's audit
+// log.
+type sessionRecorder struct {
+	*recorder.Recorder
+
+	s  *SSH
+	id string
+}
+
+func (s *SSH) newSessionRecorder(ctx context.Context, portalDoc *api.PortalDocument, remoteAddr string) (*sessionRecorder, error) {
+	resourceID := strings.ToLower(portalDoc.Portal.ID)
+
+	doc, err := s.dbPortal.Create(ctx, &api.PortalDocument{
+		ID:  s.dbPortal.NewUUID(),
+		TTL: int(s.sessionRetention / time.Second),
+		Portal: &api.Portal{
+			Username: portalDoc.Portal.Username,
+			ID:       resourceID,
+			SSHSession: &api.SSHSession{
+				Master:     portalDoc.Portal.SSH.Master,
+				RemoteAddr: remoteAddr,
+				StartTime:  s.now().Unix(),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := &sessionRecorder{
+		Recorder: recorder.New(s.audit.WithFields(recorder.AuditFields(s.env, portalDoc.Portal.Username, remoteAddr, resourceID, auditTargetResourceType, doc.ID)), s.now),
+		s:        s,
+		id:       doc.ID,
+	}
+
+	r.Event("SSH session start", "")
+
+	return r, nil
+}
+
+// end records the end of the session.  It is called once the connection has
+// closed, so it does not use the connection's context.
+func (r *sessionRecorder) end() {
+	commands := r.Commands()
+
+	r.Event("SSH session end", "")
+
+	_, err := r.s.dbPortal.Patch(context.Background(), r.id, func(doc *api.PortalDocument) error {
+		doc.Portal.SSHSession.EndTime = r.s.now().Unix()
+		doc.Portal.SSHSession.Commands = commands
+		return nil
+	})
+	if err != nil {
+		r.s.log.Warn(err)
+	}
+}
+
+// channelRecorder records the commands run on a single SRE-initiated session
+// channel: the command line of exec and subsystem requests, and the lines
+// typed into an interactive shell.
+type channelRecorder struct {
+	session *sessionRecorder
+	shell   atomic.Bool
+	input   *recorder.LineWriter
+}
+
+func (r *sessionRecorder) newChannelRecorder() *channelRecorder {
+	return &channelRecorder{
+		session: r,
+		input:   r.NewLineWriter("SSH input"),
+	}
+}
+
+// request records a channel request sent by the SRE.
+func (c *channelRecorder) request(req *cryptossh.Request) {
+	switch req.Type {
+	case "exec":
+		var payload struct{ Command string }
+		if cryptossh.Unmarshal(req.Payload, &payload) == nil {
+			c.session.Command("SSH exec", payload.Command)
+		}
+
+	case "subsystem":
+		var payload struct{ Name string }
+		if cryptossh.Unmarshal(req.Payload, &payload) == nil {
+			c.session.Command("SSH subsystem", payload.Name)
+		}
+
+	case "shell":
+		c.shell.Store(true)
+		c.session.Event("SSH shell", "")
+	}
+}
+
+// Write records interactive input sent by the SRE.  Input on non-shell
+// channels (e.g. the stdin of an exec request) is not recorded.
+func (c *channelRecorder) Write(b []byte) (int, error) {
+	if !c.shell.Load() {
+		return len(b), nil
+	}
+
+	return c.input.Write(b)
+}
+
+// flush records any buffered partial line.
+func (c *channelRecorder) flush() {
+	c.input.Flush()
+}
@@ -0,0 +1,95 @@
+package ssh
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	cryptossh "golang.org/x/crypto/ssh"
+
+	"github.com/Azure/ARO-RP/pkg/portal/util/recorder"
+	"github.com/Azure/ARO-RP/pkg/util/log/audit"
+	testlog "github.com/Azure/ARO-RP/test/util/log"
+)
+
+func TestChannelRecorder(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		requests []*cryptossh.Request
+		input    []string
+		want     []audit.Result
+	}{
+		{
+			name: "exec",
+			requests: []*cryptossh.Request{
+				{Type: "exec", Payload: cryptossh.Marshal(struct{ Command string }{"journalctl -u kubelet"})},
+			},
+			input: []string{"stdin is not recorded\n"},
+			want: []audit.Result{
+				{ResultType: audit.ResultTypeSuccess, ResultDescription: "journalctl -u kubelet"},
+			},
+		},
+		{
+			name: "subsystem",
+			requests: []*cryptossh.Request{
+				{Type: "subsystem", Payload: cryptossh.Marshal(struct{ Name string }{"sftp"})},
+			},
+			want: []audit.Result{
+				{ResultType: audit.ResultTypeSuccess, ResultDescription: "sftp"},
+			},
+		},
+		{
+			name: "interactive shell",
+			requests: []*cryptossh.Request{
+				{Type: "pty-req"},
+				{Type: "shell"},
+			},
+			input: []string{"sudo -i\r", "crictl pss\x7f", "\r", "\x1b[A", "exit"},
+			want: []audit.Result{
+				{ResultType: audit.ResultTypeSuccess},
+				{ResultType: audit.ResultTypeSuccess, ResultDescription: "sudo -i"},
+				{ResultType: audit.ResultTypeSuccess, ResultDescription: "crictl ps"},
+				{ResultType: audit.ResultTypeSuccess, ResultDescription: "[Aexit"},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			hook, log := testlog.NewAudit()
+
+			r := &sessionRecorder{
+				Recorder: recorder.New(log, time.Now),
+				s:        &SSH{now: time.Now},
+			}
+
+			c := r.newChannelRecorder()
+			for _, req := range tt.requests {
+				c.request(req)
+			}
+			for _, input := range tt.input {
+				_, err := c.Write([]byte(input))
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			c.flush()
+
+			var got []audit.Result
+			for _, entry := range hook.AllEntries() {
+				var payload audit.Payload
+				err := json.Unmarshal([]byte(entry.Data[audit.MetadataPayload].(string)), &payload)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, payload.Result)
+			}
+
+			for _, diff := range deep.Equal(got, tt.want) {
+				t.Error(diff)
+			}
+		})
+	}
+}
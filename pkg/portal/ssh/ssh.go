@@ -35,6 +35,7 @@ const (
 type SSH struct {
 	env           env.Core
 	log           *logrus.Entry
+	audit         *logrus.Entry
 	baseAccessLog *logrus.Entry
 	l             net.Listener
 
@@ -48,10 +49,14 @@ type SSH struct {
 	baseServerConfig *cryptossh.ServerConfig
 
 	hostPubKey cryptossh.PublicKey
+
+	sessionRetention time.Duration
+	now              func() time.Time
 }
 
 func New(env env.Core,
 	log *logrus.Entry,
+	audit *logrus.Entry,
 	baseAccessLog *logrus.Entry,
 	l net.Listener,
 	hostKey *rsa.PrivateKey,
@@ -59,6 +64,7 @@ func New(env env.Core,
 	dbOpenShiftClusters database.OpenShiftClusters,
 	dbPortal database.Portal,
 	dialer proxy.Dialer,
+	sessionRetention time.Duration,
 ) (*SSH, error) {
 	hostPubKey, err := cryptossh.NewPublicKey(&hostKey.PublicKey)
 	if err != nil {
@@ -68,6 +74,7 @@ func New(env env.Core,
 	s := &SSH{
 		env:           env,
 		log:           log,
+		audit:         audit,
 		baseAccessLog: baseAccessLog,
 		l:             l,
 
@@ -81,6 +88,9 @@ func New(env env.Core,
 		baseServerConfig: &cryptossh.ServerConfig{},
 
 		hostPubKey: hostPubKey,
+
+		sessionRetention: sessionRetention,
+		now:              time.Now,
 	}
 
 	signer, err := cryptossh.NewSignerFromSigner(hostKey)
@@ -149,7 +149,7 @@ func TestNew(t *testing.T) {
 			env := mock_env.NewMockCore(ctrl)
 			env.EXPECT().IsLocalDevelopmentMode().AnyTimes().Return(false)
 
-			s, err := New(env, logrus.NewEntry(logrus.StandardLogger()), nil, nil, hostKey, elevatedGroupIDs, nil, dbPortal, nil)
+			s, err := New(env, logrus.NewEntry(logrus.StandardLogger()), nil, nil, nil, hostKey, elevatedGroupIDs, nil, dbPortal, nil, 0)
 			if err != nil {
 				t.Fatal(err)
 			}
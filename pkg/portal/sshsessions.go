@@ -0,0 +1,82 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Azure/ARO-RP/pkg/api/validate"
+)
+
+type SSHSession struct {
+	ID         string `json:"id"`
+	Username   string `json:"username"`
+	Hostname   string `json:"hostname"`
+	RemoteAddr string `json:"remoteAddr"`
+	StartTime  string `json:"startTime"`
+	EndTime    string `json:"endTime,omitempty"`
+	Commands   int    `json:"commands"`
+}
+
+// sshSessions returns the metadata of the recorded SSH sessions to a cluster,
+// most recent first.  The commands run in each session are in the audit log,
+// correlated by session ID.  It is only routed for elevated users.
+func (p *portal) sshSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	dbPortal, err := p.dbGroup.Portal()
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	apiVars := mux.Vars(r)
+	resourceID := p.getResourceID(apiVars["subscription"], apiVars["resourceGroup"], apiVars["clusterName"])
+	if !validate.RxClusterID.MatchString(resourceID) {
+		p.badRequest(w, fmt.Errorf("invalid resource ID"))
+		return
+	}
+
+	docs, err := dbPortal.ListSSHSessions(ctx, resourceID)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	sessions := make([]*SSHSession, 0, len(docs.PortalDocuments))
+	for _, doc := range docs.PortalDocuments {
+		session := doc.Portal.SSHSession
+
+		s := &SSHSession{
+			ID:         doc.ID,
+			Username:   doc.Portal.Username,
+			Hostname:   fmt.Sprintf("master-%d", session.Master),
+			RemoteAddr: session.RemoteAddr,
+			StartTime:  time.Unix(session.StartTime, 0).UTC().Format(time.RFC3339),
+			Commands:   session.Commands,
+		}
+		if session.EndTime != 0 {
+			s.EndTime = time.Unix(session.EndTime, 0).UTC().Format(time.RFC3339)
+		}
+
+		sessions = append(sessions, s)
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].StartTime > sessions[j].StartTime })
+
+	b, err := json.MarshalIndent(sessions, "", "    ")
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
@@ -0,0 +1,129 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/portal/cluster"
+)
+
+const (
+	timelineDefaultSince = 24 * time.Hour
+	timelineMaxSince     = 7 * 24 * time.Hour
+	timelineMaxEvents    = 1000
+)
+
+// asyncOperationEvents returns the start and, if finished, the end of each of
+// the RP's asynchronous operations on the cluster.
+func asyncOperationEvents(docs *api.AsyncOperationDocuments, clusterName string, since time.Time) []cluster.TimelineEvent {
+	var events []cluster.TimelineEvent
+
+	object := "OpenShiftCluster/" + clusterName
+
+	for _, doc := range docs.AsyncOperationDocuments {
+		op := doc.AsyncOperation
+		if op == nil {
+			continue
+		}
+
+		if !op.StartTime.Before(since) {
+			events = append(events, cluster.TimelineEvent{
+				Time:     op.StartTime,
+				Source:   cluster.TimelineSourceRP,
+				Severity: cluster.TimelineSeverityInfo,
+				Object:   object,
+				Reason:   string(op.InitialProvisioningState),
+				Message:  fmt.Sprintf("%s operation started", op.InitialProvisioningState),
+			})
+		}
+
+		if op.EndTime != nil && !op.EndTime.Before(since) {
+			e := cluster.TimelineEvent{
+				Time:     *op.EndTime,
+				Source:   cluster.TimelineSourceRP,
+				Severity: cluster.TimelineSeverityInfo,
+				Object:   object,
+				Reason:   string(op.ProvisioningState),
+				Message:  fmt.Sprintf("%s operation finished: %s", op.InitialProvisioningState, op.ProvisioningState),
+			}
+			if op.ProvisioningState == api.ProvisioningStateFailed {
+				e.Severity = cluster.TimelineSeverityError
+				if op.Error != nil {
+					e.Message = fmt.Sprintf("%s operation failed: %s: %s", op.InitialProvisioningState, op.Error.Code, op.Error.Message)
+				}
+			}
+			events = append(events, e)
+		}
+	}
+
+	return events
+}
+
+// timeline returns a single chronological timeline of the cluster's
+// Kubernetes events, monitor findings and RP operations, most recent first.
+func (p *portal) timeline(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	dbAsyncOperations, err := p.dbGroup.AsyncOperations()
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	duration := timelineDefaultSince
+	if s := r.URL.Query().Get("since"); s != "" {
+		duration, err = time.ParseDuration(s)
+		if err != nil || duration <= 0 || duration > timelineMaxSince {
+			p.badRequest(w, fmt.Errorf("invalid since %q", s))
+			return
+		}
+	}
+	since := time.Now().Add(-duration)
+
+	apiVars := mux.Vars(r)
+	resourceID := p.getResourceID(apiVars["subscription"], apiVars["resourceGroup"], apiVars["clusterName"])
+
+	fetcher, err := p.makeFetcher(ctx, r)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	events, err := fetcher.Timeline(ctx, since)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	docs, err := dbAsyncOperations.ListByClusterKey(ctx, resourceID)
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	events = append(events, asyncOperationEvents(docs, apiVars["clusterName"], since)...)
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.After(events[j].Time) })
+
+	if len(events) > timelineMaxEvents {
+		events = events[:timelineMaxEvents]
+	}
+
+	b, err := json.MarshalIndent(events, "", "    ")
+	if err != nil {
+		p.internalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
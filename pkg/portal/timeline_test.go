@@ -0,0 +1,84 @@
+package portal
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/portal/cluster"
+)
+
+func TestAsyncOperationEvents(t *testing.T) {
+	now := time.Now().UTC()
+	since := now.Add(-time.Hour)
+	ended := now.Add(-10 * time.Minute)
+	oldEnded := now.Add(-2 * time.Hour)
+
+	docs := &api.AsyncOperationDocuments{
+		AsyncOperationDocuments: []*api.AsyncOperationDocument{
+			{
+				AsyncOperation: &api.AsyncOperation{
+					InitialProvisioningState: api.ProvisioningStateUpdating,
+					ProvisioningState:        api.ProvisioningStateFailed,
+					StartTime:                now.Add(-30 * time.Minute),
+					EndTime:                  &ended,
+					Error: &api.CloudErrorBody{
+						Code:    api.CloudErrorCodeInternalServerError,
+						Message: "Internal server error.",
+					},
+				},
+			},
+			{
+				AsyncOperation: &api.AsyncOperation{
+					InitialProvisioningState: api.ProvisioningStateAdminUpdating,
+					ProvisioningState:        api.ProvisioningStateAdminUpdating,
+					StartTime:                now.Add(-time.Minute),
+				},
+			},
+			{
+				AsyncOperation: &api.AsyncOperation{
+					InitialProvisioningState: api.ProvisioningStateCreating,
+					ProvisioningState:        api.ProvisioningStateSucceeded,
+					StartTime:                now.Add(-3 * time.Hour),
+					EndTime:                  &oldEnded,
+				},
+			},
+		},
+	}
+
+	events := asyncOperationEvents(docs, "cluster", since)
+
+	for _, diff := range deep.Equal(events, []cluster.TimelineEvent{
+		{
+			Time:     now.Add(-30 * time.Minute),
+			Source:   cluster.TimelineSourceRP,
+			Severity: cluster.TimelineSeverityInfo,
+			Object:   "OpenShiftCluster/cluster",
+			Reason:   "Updating",
+			Message:  "Updating operation started",
+		},
+		{
+			Time:     ended,
+			Source:   cluster.TimelineSourceRP,
+			Severity: cluster.TimelineSeverityError,
+			Object:   "OpenShiftCluster/cluster",
+			Reason:   "Failed",
+			Message:  "Updating operation failed: InternalServerError: Internal server error.",
+		},
+		{
+			Time:     now.Add(-time.Minute),
+			Source:   cluster.TimelineSourceRP,
+			Severity: cluster.TimelineSeverityInfo,
+			Object:   "OpenShiftCluster/cluster",
+			Reason:   "AdminUpdating",
+			Message:  "AdminUpdating operation started",
+		},
+	}) {
+		t.Error(diff)
+	}
+}
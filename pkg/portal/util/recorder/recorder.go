@@ -0,0 +1,157 @@
+package recorder
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/log/audit"
+)
+
+// maxLineLength bounds the length of a single recorded line of interactive
+// input.
+const maxLineLength = 4096
+
+// Recorder keeps the audit trail of a single interactive SRE session, such as
+// an SSH connection or a node debug terminal.  Its events are emitted as IFx
+// audit events, which are shipped to Geneva with the rest of the portal's
+// audit log.
+type Recorder struct {
+	audit *logrus.Entry
+	now   func() time.Time
+
+	mu       sync.Mutex
+	commands int
+}
+
+// AuditFields returns the audit fields which attribute the events of a
+// session to the SRE username connecting from remoteAddr, acting on
+// resourceID.  correlationID ties the events of the session together.
+func AuditFields(env env.Core, username, remoteAddr, resourceID, resourceType, correlationID string) logrus.Fields {
+	return logrus.Fields{
+		audit.MetadataAdminOperation: true,
+		audit.MetadataLogKind:        audit.IFXAuditLogKind,
+		audit.MetadataSource:         audit.SourceAdminPortal,
+		audit.EnvKeyAppID:            audit.SourceAdminPortal,
+		audit.EnvKeyCloudRole:        audit.CloudRoleRP,
+		audit.EnvKeyCorrelationID:    correlationID,
+		audit.EnvKeyEnvironment:      env.Environment().Name,
+		audit.EnvKeyHostname:         env.Hostname(),
+		audit.EnvKeyLocation:         env.Location(),
+		audit.PayloadKeyCategory:     audit.CategoryResourceManagement,
+		audit.PayloadKeyCallerIdentities: []audit.CallerIdentity{
+			{
+				CallerIdentityType:  audit.CallerIdentityTypeUPN,
+				CallerIdentityValue: username,
+				CallerIPAddress:     remoteAddr,
+			},
+		},
+		audit.PayloadKeyTargetResources: []audit.TargetResource{
+			{
+				TargetResourceName: resourceID,
+				TargetResourceType: resourceType,
+			},
+		},
+	}
+}
+
+// New returns a Recorder which emits events to auditLog, which should carry
+// the session's AuditFields.
+func New(auditLog *logrus.Entry, now func() time.Time) *Recorder {
+	return &Recorder{
+		audit: auditLog,
+		now:   now,
+	}
+}
+
+// Event records an event in the session.
+func (r *Recorder) Event(operation, description string) {
+	r.audit.WithFields(logrus.Fields{
+		audit.MetadataCreatedTime:     r.now().UTC().Format(time.RFC3339),
+		audit.PayloadKeyOperationName: operation,
+		audit.PayloadKeyResult: audit.Result{
+			ResultType:        audit.ResultTypeSuccess,
+			ResultDescription: description,
+		},
+	}).Info(audit.DefaultLogMessage)
+}
+
+// Command records a command run in the session.
+func (r *Recorder) Command(operation, command string) {
+	r.mu.Lock()
+	r.commands++
+	r.mu.Unlock()
+
+	r.Event(operation, command)
+}
+
+// Commands returns the number of commands recorded so far.
+func (r *Recorder) Commands() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.commands
+}
+
+// LineWriter records interactive input typed by the SRE as commands, line by
+// line.  Input is recorded as sent by the client, so line editing other than
+// backspace is not reflected.
+type LineWriter struct {
+	r         *Recorder
+	operation string
+
+	mu   sync.Mutex
+	line []byte
+}
+
+// NewLineWriter returns a LineWriter which records each line of input as a
+// command with the given operation name.
+func (r *Recorder) NewLineWriter(operation string) *LineWriter {
+	return &LineWriter{
+		r:         r,
+		operation: operation,
+	}
+}
+
+func (w *LineWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, ch := range b {
+		switch {
+		case ch == '\r' || ch == '\n':
+			w.flushLocked()
+		case ch == 0x7f || ch == '\b':
+			if len(w.line) > 0 {
+				w.line = w.line[:len(w.line)-1]
+			}
+		case ch < 0x20:
+			// drop other control characters, e.g. escape sequences
+		case len(w.line) < maxLineLength:
+			w.line = append(w.line, ch)
+		}
+	}
+
+	return len(b), nil
+}
+
+// Flush records any buffered partial line.
+func (w *LineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.flushLocked()
+}
+
+func (w *LineWriter) flushLocked() {
+	if len(w.line) > 0 {
+		w.r.Command(w.operation, strings.ToValidUTF8(string(w.line), "?"))
+		w.line = w.line[:0]
+	}
+}
@@ -0,0 +1,48 @@
+package recorder
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"github.com/Azure/ARO-RP/pkg/util/log/audit"
+	testlog "github.com/Azure/ARO-RP/test/util/log"
+)
+
+func TestLineWriter(t *testing.T) {
+	hook, log := testlog.NewAudit()
+
+	r := New(log, time.Now)
+	w := r.NewLineWriter("input")
+
+	for _, input := range []string{"chroot /host\r", "crictl pss\x7f", "\r", "\x1b[A", "exit"} {
+		_, err := w.Write([]byte(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Flush()
+
+	var got []string
+	for _, entry := range hook.AllEntries() {
+		var payload audit.Payload
+		err := json.Unmarshal([]byte(entry.Data[audit.MetadataPayload].(string)), &payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, payload.Result.ResultDescription)
+	}
+
+	for _, diff := range deep.Equal(got, []string{"chroot /host", "crictl ps", "[Aexit"}) {
+		t.Error(diff)
+	}
+
+	if r.Commands() != 3 {
+		t.Error(r.Commands())
+	}
+}
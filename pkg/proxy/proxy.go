@@ -104,7 +104,9 @@ func (s Server) proxyHandler(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	Proxy(s.Log, w, r, 0)
+	Proxy(s.Log, w, r, func(network, address string) (net.Conn, error) {
+		return utilnet.Dial(network, address, 0)
+	})
 }
 
 // validateProxyRequest checks that the request is valid. If not, it writes the
@@ -130,11 +132,11 @@ func (s Server) validateProxyRequest(w http.ResponseWriter, r *http.Request) err
 }
 
 // Proxy takes an HTTP/1.x CONNECT Request and ResponseWriter from the Golang
-// HTTP stack and uses Hijack() to get the underlying Connection (c1).  It dials
-// a second Connection (c2) to the requested end Host and then copies data in
-// both directions (c1->c2 and c2->c1).
-func Proxy(log *logrus.Entry, w http.ResponseWriter, r *http.Request, sz int) {
-	c2, err := utilnet.Dial("tcp", r.Host, sz)
+// HTTP stack and uses Hijack() to get the underlying Connection (c1).  It uses
+// dial to dial a second Connection (c2) to the requested end Host and then
+// copies data in both directions (c1->c2 and c2->c1).
+func Proxy(log *logrus.Entry, w http.ResponseWriter, r *http.Request, dial func(network, address string) (net.Conn, error)) {
+	c2, err := dial("tcp", r.Host)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
@@ -168,6 +168,8 @@ func (g *generator) generateExamples(outputDir string, s *Swagger) error {
 						body = g.exampleOpenShiftClusterCredentialsResponse()
 					case "#/definitions/OpenShiftClusterAdminKubeconfig":
 						body = g.exampleOpenShiftClusterAdminKubeconfigResponse()
+					case "#/definitions/FederatedIdentityCredentialList":
+						body = g.exampleFederatedIdentityCredentialListResponse()
 					case "#/definitions/OpenShiftClusterList":
 						body = g.exampleOpenShiftClusterListResponse()
 					case "#/definitions/OperationList":
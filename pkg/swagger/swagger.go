@@ -93,13 +93,24 @@ func Run(api, outputDir string) error {
 		},
 	}
 
+	listCredentialsParameters := g.populateParameters(3, "OpenShiftCluster", "OpenShift cluster")
+	if g.execCredentials {
+		listCredentialsParameters = append(listCredentialsParameters, Parameter{
+			Name:        "format",
+			In:          "query",
+			Description: "The format of the credentials to return.  If set to exec, a kubeconfig which obtains short-lived Microsoft Entra tokens through kubelogin is returned instead of the kubeadmin password.",
+			Type:        "string",
+			Enum:        []interface{}{"exec"},
+		})
+	}
+
 	s.Paths["/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.RedHatOpenShift/openShiftClusters/{resourceName}/listCredentials"] = &PathItem{
 		Post: &Operation{
 			Tags:        []string{"OpenShiftClusters"},
 			Summary:     "Lists credentials of an OpenShift cluster with the specified subscription, resource group and resource name.",
 			Description: "The operation returns the credentials.",
 			OperationID: "OpenShiftClusters_ListCredentials",
-			Parameters:  g.populateParameters(3, "OpenShiftCluster", "OpenShift cluster"),
+			Parameters:  listCredentialsParameters,
 			Responses:   g.populateResponses("OpenShiftClusterCredentials", false, http.StatusOK),
 		},
 	}
@@ -117,6 +128,19 @@ func Run(api, outputDir string) error {
 		}
 	}
 
+	if g.federatedIdentityCredentials {
+		s.Paths["/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.RedHatOpenShift/openShiftClusters/{resourceName}/federatedIdentityCredentials"] = &PathItem{
+			Get: &Operation{
+				Tags:        []string{"OpenShiftClusters"},
+				Summary:     "Lists the federated identity credentials of an OpenShift cluster with the specified subscription, resource group and resource name.",
+				Description: "The operation returns the federated identity credentials created on the cluster's platform workload identities.",
+				OperationID: "OpenShiftClusters_ListFederatedIdentityCredentials",
+				Parameters:  g.populateParameters(3, "OpenShiftCluster", "OpenShift cluster"),
+				Responses:   g.populateResponses("FederatedIdentityCredentialList", false, http.StatusOK),
+			},
+		}
+	}
+
 	if g.installVersionList {
 		s.Paths["/subscriptions/{subscriptionId}/providers/Microsoft.RedHatOpenShift/locations/{location}/openshiftversions"] = &PathItem{
 			Get: &Operation{
@@ -171,6 +195,10 @@ func Run(api, outputDir string) error {
 		names = append(names, "PlatformWorkloadIdentityRoleSetList")
 	}
 
+	if g.federatedIdentityCredentials {
+		names = append(names, "FederatedIdentityCredentialList")
+	}
+
 	if g.clusterManager {
 		// This needs to be the top level struct
 		// in most cases, the "list" struct (a collection of resources)
@@ -0,0 +1,44 @@
+package armauthorization
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v3"
+)
+
+// DenyAssignmentsClient is a minimal interface for azure DenyAssignmentsClient
+type DenyAssignmentsClient interface {
+	ListForScope(ctx context.Context, scope string, options *armauthorization.DenyAssignmentsClientListForScopeOptions) ([]*armauthorization.DenyAssignment, error)
+}
+
+type denyAssignmentsClient struct {
+	*armauthorization.DenyAssignmentsClient
+}
+
+var _ DenyAssignmentsClient = &denyAssignmentsClient{}
+
+// NewDenyAssignmentsClient creates a new DenyAssignmentsClient
+func NewDenyAssignmentsClient(subscriptionID string, credential azcore.TokenCredential, options *arm.ClientOptions) (DenyAssignmentsClient, error) {
+	client, err := armauthorization.NewDenyAssignmentsClient(subscriptionID, credential, options)
+	return &denyAssignmentsClient{
+		DenyAssignmentsClient: client,
+	}, err
+}
+
+func (c *denyAssignmentsClient) ListForScope(ctx context.Context, scope string, options *armauthorization.DenyAssignmentsClientListForScopeOptions) (result []*armauthorization.DenyAssignment, err error) {
+	pager := c.DenyAssignmentsClient.NewListForScopePager(scope, options)
+
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, page.Value...)
+	}
+	return result, nil
+}
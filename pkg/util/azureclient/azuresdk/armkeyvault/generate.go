@@ -0,0 +1,8 @@
+package armkeyvault
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+//go:generate rm -rf ../../../../../pkg/util/mocks/azureclient/azuresdk/$GOPACKAGE
+//go:generate mockgen -source ./keys.go -destination=../../../mocks/azureclient/azuresdk/$GOPACKAGE/keys.go github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/$GOPACKAGE KeysClient
+//go:generate goimports -local=github.com/Azure/ARO-RP -e -w ../../../mocks/azureclient/azuresdk/$GOPACKAGE/keys.go
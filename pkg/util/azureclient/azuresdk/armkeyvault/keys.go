@@ -0,0 +1,33 @@
+package armkeyvault
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/keyvault/armkeyvault"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/azcore"
+)
+
+// KeysClient is a minimal interface for azure KeysClient
+type KeysClient interface {
+	Get(ctx context.Context, resourceGroupName string, vaultName string, keyName string, options *armkeyvault.KeysClientGetOptions) (armkeyvault.KeysClientGetResponse, error)
+	GetVersion(ctx context.Context, resourceGroupName string, vaultName string, keyName string, keyVersion string, options *armkeyvault.KeysClientGetVersionOptions) (armkeyvault.KeysClientGetVersionResponse, error)
+}
+
+type keysClient struct {
+	*armkeyvault.KeysClient
+}
+
+var _ KeysClient = &keysClient{}
+
+// NewKeysClient creates a new KeysClient
+func NewKeysClient(subscriptionID string, credential azcore.TokenCredential, options *arm.ClientOptions) (KeysClient, error) {
+	client, err := armkeyvault.NewKeysClient(subscriptionID, credential, options)
+	return &keysClient{
+		KeysClient: client,
+	}, err
+}
@@ -4,14 +4,18 @@ package azureclient
 // Licensed under the Apache License 2.0.
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"strings"
+	"sync"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/msi-dataplane/pkg/dataplane"
@@ -40,6 +44,13 @@ type AROEnvironment struct {
 	KeyVaultScope          string
 	MicrosoftGraphScope    string
 	CosmosDBDNSSuffixScope string
+	// MsiDataplaneCloudName is the cloud name passed to the MSI dataplane
+	// client, or empty if the MSI dataplane is not available in the cloud
+	MsiDataplaneCloudName string
+	// ARMMetadataEndpoint serves the ARM authentication metadata used to
+	// authorize inbound ARM requests.  It defaults to port 24582 of the
+	// resource manager endpoint.
+	ARMMetadataEndpoint string
 }
 
 // AzureRbacPDPEnvironment contains cloud specific instance of Authz RBAC PDP Remote Server
@@ -69,6 +80,8 @@ var (
 		KeyVaultScope:          azure.PublicCloud.ResourceIdentifiers.KeyVault + "/.default",
 		MicrosoftGraphScope:    azure.PublicCloud.MicrosoftGraphEndpoint + "/.default",
 		CosmosDBDNSSuffixScope: azure.PublicCloud.CosmosDBDNSSuffix + "/.default",
+		MsiDataplaneCloudName:  dataplane.AzurePublicCloud,
+		ARMMetadataEndpoint:    "https://admin.management.azure.com",
 	}
 
 	// USGovernmentCloud contains additional ARO information for the US Gov cloud environment.
@@ -92,17 +105,106 @@ var (
 		KeyVaultScope:          azure.USGovernmentCloud.ResourceIdentifiers.KeyVault + "/.default",
 		MicrosoftGraphScope:    azure.USGovernmentCloud.MicrosoftGraphEndpoint + "/.default",
 		CosmosDBDNSSuffixScope: azure.USGovernmentCloud.CosmosDBDNSSuffix + "/.default",
+		MsiDataplaneCloudName:  dataplane.AzureUSGovCloud,
 	}
 )
 
+// EnvironmentsFilePath is the environment variable naming a JSON file of
+// additional AROEnvironments, such as sovereign or air-gapped clouds, which
+// are registered the first time an environment is looked up.
+const EnvironmentsFilePath = "ARO_ENVIRONMENTS_FILEPATH"
+
+var (
+	environmentsMu       sync.RWMutex
+	environments         = map[string]AROEnvironment{}
+	environmentsFileOnce sync.Once
+	environmentsFileErr  error
+)
+
+func init() {
+	for _, e := range []AROEnvironment{PublicCloud, USGovernmentCloud} {
+		if err := RegisterEnvironment(e); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterEnvironment adds an AROEnvironment to the environments which can be
+// looked up by name.  The Microsoft identity platform scopes default to those
+// of the environment's endpoints if they are not set.
+func RegisterEnvironment(e AROEnvironment) error {
+	if e.Name == "" {
+		return errors.New("cloud environment has no name")
+	}
+
+	if e.ResourceManagerScope == "" {
+		e.ResourceManagerScope = e.ResourceManagerEndpoint + "/.default"
+	}
+	if e.KeyVaultScope == "" {
+		e.KeyVaultScope = e.ResourceIdentifiers.KeyVault + "/.default"
+	}
+	if e.MicrosoftGraphScope == "" {
+		e.MicrosoftGraphScope = e.MicrosoftGraphEndpoint + "/.default"
+	}
+	if e.CosmosDBDNSSuffixScope == "" {
+		e.CosmosDBDNSSuffixScope = e.CosmosDBDNSSuffix + "/.default"
+	}
+
+	environmentsMu.Lock()
+	defer environmentsMu.Unlock()
+
+	name := strings.ToUpper(e.Name)
+	if _, found := environments[name]; found {
+		return fmt.Errorf("cloud environment %q is already registered", e.Name)
+	}
+
+	environments[name] = e
+
+	return nil
+}
+
+// RegisterEnvironmentsFromFile registers the AROEnvironments in a JSON file
+// containing a list of them.
+func RegisterEnvironmentsFromFile(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var envs []AROEnvironment
+	err = json.Unmarshal(b, &envs)
+	if err != nil {
+		return fmt.Errorf("could not parse cloud environments in %s: %w", path, err)
+	}
+
+	for _, e := range envs {
+		err = RegisterEnvironment(e)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // EnvironmentFromName returns the AROEnvironment corresponding to the common name specified.
 func EnvironmentFromName(name string) (AROEnvironment, error) {
-	switch strings.ToUpper(name) {
-	case "AZUREPUBLICCLOUD":
-		return PublicCloud, nil
-	case "AZUREUSGOVERNMENTCLOUD":
-		return USGovernmentCloud, nil
+	environmentsFileOnce.Do(func() {
+		if path := os.Getenv(EnvironmentsFilePath); path != "" {
+			environmentsFileErr = RegisterEnvironmentsFromFile(path)
+		}
+	})
+	if environmentsFileErr != nil {
+		return AROEnvironment{}, environmentsFileErr
 	}
+
+	environmentsMu.RLock()
+	defer environmentsMu.RUnlock()
+
+	if e, found := environments[strings.ToUpper(name)]; found {
+		return e, nil
+	}
+
 	return AROEnvironment{}, fmt.Errorf("cloud environment %q is unsupported by ARO", name)
 }
 
@@ -117,6 +219,8 @@ func (e *AROEnvironment) ArmClientOptions() *arm.ClientOptions {
 			Transport: &http.Client{
 				Transport: customRoundTripper,
 			},
+			PerCallPolicies:  []policy.Policy{attemptsPolicy{}},
+			PerRetryPolicies: []policy.Policy{throttlingPolicy{}},
 		},
 	}
 }
@@ -179,22 +283,12 @@ func (e *AROEnvironment) NewGraphServiceClient(tokenCredential azcore.TokenCrede
 }
 
 // CloudNameForMsiDataplane returns the cloud name to be passed in when instantiating
-// an MSI dataplane client or an error if it encounters an issue getting the correct
-// cloud name. This function might seem a little strange, but it's necessary because
-// the cloud names stored in the AROEnvironments are in all-caps, whereas the ones
-// defined as constants in the dataplane module are in camel case.
+// an MSI dataplane client or an error if the MSI dataplane is not available in
+// the cloud.
 func (e *AROEnvironment) CloudNameForMsiDataplane() (string, error) {
-	cloud := ""
-	switch strings.ToUpper(e.Name) {
-	case dataplane.AzurePublicCloud:
-		cloud = dataplane.AzurePublicCloud
-	case dataplane.AzureUSGovCloud:
-		cloud = dataplane.AzureUSGovCloud
-	}
-
-	if cloud == "" {
+	if e.MsiDataplaneCloudName == "" {
 		return "", errors.New("could not determine which Azure Cloud to use to instantiate MSI dataplane client")
 	}
 
-	return cloud, nil
+	return e.MsiDataplaneCloudName, nil
 }
@@ -4,9 +4,12 @@ package azureclient
 // Licensed under the Apache License 2.0.
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/msi-dataplane/pkg/dataplane"
 
 	utilerror "github.com/Azure/ARO-RP/test/util/error"
 )
@@ -38,3 +41,77 @@ func TestEnvironmentFromName(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterEnvironmentsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "environments.json")
+	err := os.WriteFile(path, []byte(`[
+	{
+		"name": "AzureAirGappedCloud",
+		"resourceManagerEndpoint": "https://management.airgapped.example",
+		"containerRegistryDNSSuffix": "azurecr.airgapped.example",
+		"ActualCloudName": "AzureAirGapped",
+		"MsiDataplaneCloudName": "AZUREAIRGAPPEDCLOUD"
+	}
+]`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = RegisterEnvironmentsFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := EnvironmentFromName("AZUREAIRGAPPEDCLOUD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.ActualCloudName != "AzureAirGapped" ||
+		e.ContainerRegistryDNSSuffix != "azurecr.airgapped.example" ||
+		e.ResourceManagerScope != "https://management.airgapped.example/.default" {
+		t.Error(e)
+	}
+
+	cloud, err := e.CloudNameForMsiDataplane()
+	if err != nil || cloud != "AZUREAIRGAPPEDCLOUD" {
+		t.Error(cloud, err)
+	}
+
+	err = RegisterEnvironmentsFromFile(path)
+	utilerror.AssertErrorMessage(t, err, `cloud environment "AzureAirGappedCloud" is already registered`)
+}
+
+func TestCloudNameForMsiDataplane(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		env     AROEnvironment
+		want    string
+		wantErr string
+	}{
+		{
+			name: "public cloud",
+			env:  PublicCloud,
+			want: dataplane.AzurePublicCloud,
+		},
+		{
+			name: "US government cloud",
+			env:  USGovernmentCloud,
+			want: dataplane.AzureUSGovCloud,
+		},
+		{
+			name:    "MSI dataplane not available",
+			env:     AROEnvironment{Environment: azure.Environment{Name: "AzureAirGappedCloud"}},
+			wantErr: "could not determine which Azure Cloud to use to instantiate MSI dataplane client",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.env.CloudNameForMsiDataplane()
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
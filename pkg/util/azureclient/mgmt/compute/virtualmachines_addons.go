@@ -22,6 +22,7 @@ type VirtualMachinesClientAddons interface {
 	StopAndWait(ctx context.Context, resourceGroupName string, VMName string, deallocateVM bool) error
 	List(ctx context.Context, resourceGroupName string) (result []mgmtcompute.VirtualMachine, err error)
 	GetSerialConsoleForVM(ctx context.Context, resourceGroupName string, VMName string, target io.Writer) error
+	GetScreenshotForVM(ctx context.Context, resourceGroupName string, VMName string, target io.Writer) error
 }
 
 func (c *virtualMachinesClient) CreateOrUpdateAndWait(ctx context.Context, resourceGroupName string, VMName string, parameters mgmtcompute.VirtualMachine) error {
@@ -102,28 +103,42 @@ func (c *virtualMachinesClient) List(ctx context.Context, resourceGroupName stri
 
 // retrieveBootDiagnosticsData returns the boot diagnostics data for the given
 // VM by RG and VMName.
-func (c *virtualMachinesClient) retrieveBootDiagnosticsData(ctx context.Context, resourceGroupName string, VMName string) (serialConsoleURI string, err error) {
-	resp, err := c.VirtualMachinesClient.RetrieveBootDiagnosticsData(ctx, resourceGroupName, VMName, to.Int32Ptr(60))
-	if err != nil {
-		return "", err
-	}
+func (c *virtualMachinesClient) retrieveBootDiagnosticsData(ctx context.Context, resourceGroupName string, VMName string) (mgmtcompute.RetrieveBootDiagnosticsDataResult, error) {
+	return c.VirtualMachinesClient.RetrieveBootDiagnosticsData(ctx, resourceGroupName, VMName, to.Int32Ptr(60))
+}
 
-	if resp.SerialConsoleLogBlobURI == nil {
-		return "", fmt.Errorf("no available serial console URI")
+// GetSerialConsoleForVM writes the serial console log blob to target, or
+// returns an error if it cannot be retrieved.
+func (c *virtualMachinesClient) GetSerialConsoleForVM(ctx context.Context, resourceGroupName string, vmName string, target io.Writer) error {
+	resp, err := c.retrieveBootDiagnosticsData(ctx, resourceGroupName, vmName)
+	if err == nil && resp.SerialConsoleLogBlobURI == nil {
+		err = fmt.Errorf("no available serial console URI")
+	}
+	if err != nil {
+		return fmt.Errorf("failure getting boot diagnostics URI Azure: %w", err)
 	}
 
-	return *resp.SerialConsoleLogBlobURI, nil
+	return downloadBootDiagnosticsBlob(ctx, *resp.SerialConsoleLogBlobURI, target)
 }
 
-// GetSerialConsoleForVM will return the serial console log blob as an
-// io.ReadCloser, or an error if it cannot be retrieved.
-func (c *virtualMachinesClient) GetSerialConsoleForVM(ctx context.Context, resourceGroupName string, vmName string, target io.Writer) error {
-	serialConsoleLogBlobURI, err := c.retrieveBootDiagnosticsData(ctx, resourceGroupName, vmName)
+// GetScreenshotForVM writes the console screenshot blob (a bitmap image) to
+// target, or returns an error if it cannot be retrieved.
+func (c *virtualMachinesClient) GetScreenshotForVM(ctx context.Context, resourceGroupName string, vmName string, target io.Writer) error {
+	resp, err := c.retrieveBootDiagnosticsData(ctx, resourceGroupName, vmName)
+	if err == nil && resp.ConsoleScreenshotBlobURI == nil {
+		err = fmt.Errorf("no available console screenshot URI")
+	}
 	if err != nil {
 		return fmt.Errorf("failure getting boot diagnostics URI Azure: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, serialConsoleLogBlobURI, nil)
+	return downloadBootDiagnosticsBlob(ctx, *resp.ConsoleScreenshotBlobURI, target)
+}
+
+// downloadBootDiagnosticsBlob copies the boot diagnostics blob at the given
+// SAS URI to target.
+func downloadBootDiagnosticsBlob(ctx context.Context, blobURI string, target io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, blobURI, nil)
 	if err != nil {
 		return err
 	}
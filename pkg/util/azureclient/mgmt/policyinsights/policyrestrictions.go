@@ -0,0 +1,35 @@
+package policyinsights
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	mgmtpolicyinsights "github.com/Azure/azure-sdk-for-go/services/preview/policyinsights/mgmt/2020-07-01-preview/policyinsights"
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+)
+
+// PolicyRestrictionsClient is a minimal interface for azure PolicyRestrictionsClient
+type PolicyRestrictionsClient interface {
+	CheckAtSubscriptionScope(ctx context.Context, subscriptionID string, parameters mgmtpolicyinsights.CheckRestrictionsRequest) (mgmtpolicyinsights.CheckRestrictionsResult, error)
+}
+
+type policyRestrictionsClient struct {
+	mgmtpolicyinsights.PolicyRestrictionsClient
+}
+
+var _ PolicyRestrictionsClient = &policyRestrictionsClient{}
+
+// NewPolicyRestrictionsClient creates a new PolicyRestrictionsClient
+func NewPolicyRestrictionsClient(environment *azureclient.AROEnvironment, subscriptionID string, authorizer autorest.Authorizer) PolicyRestrictionsClient {
+	client := mgmtpolicyinsights.NewPolicyRestrictionsClientWithBaseURI(environment.ResourceManagerEndpoint, subscriptionID)
+	client.Authorizer = authorizer
+	client.Sender = azureclient.DecorateSenderWithLogging(client.Sender)
+
+	return &policyRestrictionsClient{
+		PolicyRestrictionsClient: client,
+	}
+}
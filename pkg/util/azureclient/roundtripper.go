@@ -39,8 +39,15 @@ func (crt *customRoundTripper) RoundTrip(req *http.Request) (*http.Response, err
 	correlationData := api.GetCorrelationDataFromCtx(req.Context())
 	if correlationData == nil {
 		correlationData = api.CreateCorrelationDataFromReq(req)
-	} else if correlationData.CorrelationID != "" {
-		req.Header.Set(correlationIdHeader, correlationData.CorrelationID)
+	} else {
+		if correlationData.CorrelationID != "" {
+			req.Header.Set(correlationIdHeader, correlationData.CorrelationID)
+		}
+
+		// the correlation data in the context may be shared by concurrent
+		// requests and persisted with the operation, so update a copy
+		cd := *correlationData
+		correlationData = &cd
 	}
 
 	requestTime := time.Now()
@@ -4,6 +4,7 @@ package azureclient
 // Licensed under the Apache License 2.0.
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -158,3 +159,43 @@ func TestUpdateCorrelationDataAndEnrichLogWithResponse(t *testing.T) {
 		})
 	}
 }
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestCustomRoundTripperPropagatesCorrelationID(t *testing.T) {
+	requestTime := time.Now().Add(-time.Hour)
+	correlationData := &api.CorrelationData{
+		CorrelationID: "correlation-id",
+		RequestTime:   requestTime,
+	}
+
+	rt := NewCustomRoundTripper(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if got := req.Header.Get(correlationIdHeader); got != "correlation-id" {
+			t.Error(got)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{correlationIdHeader: []string{"other-id"}},
+			Body:       http.NoBody,
+		}, nil
+	}))
+
+	req, err := http.NewRequestWithContext(api.CtxWithCorrelationData(context.Background(), correlationData), http.MethodGet, "https://management.azure.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = rt.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if correlationData.CorrelationID != "correlation-id" || !correlationData.RequestTime.Equal(requestTime) {
+		t.Errorf("correlation data in the context was modified: %#v", correlationData)
+	}
+}
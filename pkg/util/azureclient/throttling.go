@@ -0,0 +1,133 @@
+package azureclient
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+
+	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+)
+
+const rateLimitRemainingHeaderPrefix = "x-ms-ratelimit-remaining-"
+
+// rateLimitHeaders are the ARM headers which report the remaining requests
+// before the caller is throttled
+var rateLimitHeaders = []string{
+	rateLimitRemainingHeaderPrefix + "subscription-reads",
+	rateLimitRemainingHeaderPrefix + "subscription-writes",
+	rateLimitRemainingHeaderPrefix + "subscription-deletes",
+	rateLimitRemainingHeaderPrefix + "tenant-reads",
+	rateLimitRemainingHeaderPrefix + "tenant-writes",
+	rateLimitRemainingHeaderPrefix + "tenant-deletes",
+}
+
+var (
+	throttlingMetricsMu sync.RWMutex
+	throttlingMetrics   metrics.Emitter = &noop.Noop{}
+)
+
+// RegisterThrottlingMetrics registers the emitter to which Azure SDK clients
+// created with ArmClientOptions report the remaining ARM rate limits, retries,
+// and throttled requests.  Until it is called, nothing is reported.
+func RegisterThrottlingMetrics(m metrics.Emitter) {
+	throttlingMetricsMu.Lock()
+	defer throttlingMetricsMu.Unlock()
+
+	throttlingMetrics = m
+}
+
+func getThrottlingMetrics() metrics.Emitter {
+	throttlingMetricsMu.RLock()
+	defer throttlingMetricsMu.RUnlock()
+
+	return throttlingMetrics
+}
+
+type attempts struct {
+	n int32
+}
+
+// attemptsPolicy runs once per call, before the retry policy, so that each try
+// of the call shares its attempt counter
+type attemptsPolicy struct{}
+
+func (attemptsPolicy) Do(req *policy.Request) (*http.Response, error) {
+	req.SetOperationValue(&attempts{})
+	return req.Next()
+}
+
+// throttlingPolicy runs once per try of a call and reports retries, throttled
+// responses and the remaining ARM rate limits, dimensioned by client
+type throttlingPolicy struct{}
+
+func (throttlingPolicy) Do(req *policy.Request) (*http.Response, error) {
+	m := getThrottlingMetrics()
+	client := clientName(req.Raw().URL.Path)
+
+	var a *attempts
+	if req.OperationValue(&a) && atomic.AddInt32(&a.n, 1) > 1 {
+		m.EmitGauge("client.arm.retries", 1, map[string]string{
+			"client": client,
+		})
+	}
+
+	resp, err := req.Next()
+	if err != nil {
+		return resp, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		m.EmitGauge("client.arm.throttled", 1, map[string]string{
+			"client": client,
+			"code":   strconv.Itoa(resp.StatusCode),
+		})
+	}
+
+	for _, header := range rateLimitHeaders {
+		remaining, err := strconv.ParseInt(resp.Header.Get(header), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		m.EmitGauge("client.arm.ratelimit.remaining", remaining, map[string]string{
+			"client": client,
+			"limit":  strings.TrimPrefix(header, rateLimitRemainingHeaderPrefix),
+		})
+	}
+
+	return resp, err
+}
+
+// clientName returns the resource type which an ARM request path is for, e.g.
+// microsoft.network/virtualnetworks/subnets, so that metrics are dimensioned by
+// client and not by resource
+func clientName(path string) string {
+	path = "/" + strings.ToLower(strings.Trim(path, "/")) + "/"
+
+	i := strings.LastIndex(path, "/providers/")
+	if i == -1 || i+len("/providers/") == len(path) {
+		if strings.Contains(path, "/resourcegroups/") {
+			return "microsoft.resources/resourcegroups"
+		}
+		return "microsoft.resources/subscriptions"
+	}
+
+	segments := strings.Split(strings.TrimSuffix(path[i+len("/providers/"):], "/"), "/")
+
+	// the namespace is followed by alternating resource types and names
+	parts := []string{segments[0]}
+	for j := 1; j < len(segments); j += 2 {
+		parts = append(parts, segments[j])
+	}
+
+	return strings.Join(parts, "/")
+}
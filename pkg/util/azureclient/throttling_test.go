@@ -0,0 +1,110 @@
+package azureclient
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+type fakeTransport []int
+
+func (f *fakeTransport) Do(req *http.Request) (*http.Response, error) {
+	resp := &http.Response{
+		StatusCode: (*f)[0],
+		Header:     http.Header{},
+		Body:       http.NoBody,
+		Request:    req,
+	}
+	resp.Header.Set("x-ms-ratelimit-remaining-subscription-reads", "11999")
+	*f = (*f)[1:]
+
+	return resp, nil
+}
+
+func TestThrottlingPolicy(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+	RegisterThrottlingMetrics(m)
+	defer RegisterThrottlingMetrics(&noop.Noop{})
+
+	client := map[string]string{"client": "microsoft.compute/virtualmachines"}
+	remaining := map[string]string{"client": "microsoft.compute/virtualmachines", "limit": "subscription-reads"}
+
+	gomock.InOrder(
+		m.EXPECT().EmitGauge("client.arm.throttled", int64(1), map[string]string{"client": "microsoft.compute/virtualmachines", "code": "429"}),
+		m.EXPECT().EmitGauge("client.arm.ratelimit.remaining", int64(11999), remaining),
+		m.EXPECT().EmitGauge("client.arm.retries", int64(1), client),
+		m.EXPECT().EmitGauge("client.arm.ratelimit.remaining", int64(11999), remaining),
+	)
+
+	pipeline := runtime.NewPipeline("test", "v1", runtime.PipelineOptions{}, &policy.ClientOptions{
+		Transport: &fakeTransport{http.StatusTooManyRequests, http.StatusOK},
+		Retry: policy.RetryOptions{
+			RetryDelay: time.Millisecond,
+		},
+		PerCallPolicies:  []policy.Policy{attemptsPolicy{}},
+		PerRetryPolicies: []policy.Policy{throttlingPolicy{}},
+	})
+
+	req, err := runtime.NewRequest(context.Background(), http.MethodGet, "https://management.azure.com/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := pipeline.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Error(resp.StatusCode)
+	}
+}
+
+func TestClientName(t *testing.T) {
+	for _, tt := range []struct {
+		path string
+		want string
+	}{
+		{
+			path: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/subnet",
+			want: "microsoft.network/virtualnetworks/subnets",
+		},
+		{
+			path: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines",
+			want: "microsoft.compute/virtualmachines",
+		},
+		{
+			path: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/privateEndpoints/pe/providers/Microsoft.Authorization/roleAssignments/ra",
+			want: "microsoft.authorization/roleassignments",
+		},
+		{
+			path: "/subscriptions/sub/resourcegroups/rg",
+			want: "microsoft.resources/resourcegroups",
+		},
+		{
+			path: "/subscriptions/sub/providers",
+			want: "microsoft.resources/subscriptions",
+		},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			got := clientName(tt.path)
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package cincinnati
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultURL is the OpenShift update graph service.  Environments which can
+// not reach it set CINCINNATI_URL to a mirror.
+const DefaultURL = "https://api.openshift.com/api/upgrades_info/v1/graph"
+
+const defaultTTL = time.Hour
+
+type node struct {
+	Version string `json:"version,omitempty"`
+}
+
+// graph is an upgrade graph served by Cincinnati
+type graph struct {
+	Nodes []node   `json:"nodes,omitempty"`
+	Edges [][2]int `json:"edges,omitempty"`
+}
+
+// UnsupportedUpgradeError is returned when the update graph does not offer an
+// upgrade.  Its message explains why and is suitable to show to the user.
+type UnsupportedUpgradeError struct {
+	Channel string
+	From    string
+	To      string
+	Reason  string
+}
+
+func (e *UnsupportedUpgradeError) Error() string {
+	return fmt.Sprintf("upgrade from %s to %s is not supported in channel %s: %s", e.From, e.To, e.Channel, e.Reason)
+}
+
+type Client interface {
+	// ValidateUpgrade returns an *UnsupportedUpgradeError if the graph of the
+	// given channel has no edge from the source to the target version
+	ValidateUpgrade(ctx context.Context, channel, from, to string) error
+}
+
+type cachedGraph struct {
+	g       *graph
+	expires time.Time
+}
+
+type client struct {
+	url string
+	ttl time.Duration
+	cli *http.Client
+	now func() time.Time
+
+	mu     sync.Mutex
+	graphs map[string]cachedGraph
+}
+
+// NewClient returns a Client which caches the graph of each channel for an
+// hour.  If baseURL is empty, CINCINNATI_URL or else DefaultURL is used.
+func NewClient(baseURL string) Client {
+	if baseURL == "" {
+		baseURL = os.Getenv("CINCINNATI_URL")
+	}
+	if baseURL == "" {
+		baseURL = DefaultURL
+	}
+
+	return &client{
+		url:    baseURL,
+		ttl:    defaultTTL,
+		cli:    http.DefaultClient,
+		now:    time.Now,
+		graphs: map[string]cachedGraph{},
+	}
+}
+
+func (c *client) ValidateUpgrade(ctx context.Context, channel, from, to string) error {
+	if from == to {
+		return nil
+	}
+
+	g, err := c.graph(ctx, channel)
+	if err != nil {
+		return err
+	}
+
+	unsupported := func(reason string) error {
+		return &UnsupportedUpgradeError{
+			Channel: channel,
+			From:    from,
+			To:      to,
+			Reason:  reason,
+		}
+	}
+
+	src, dst := -1, -1
+	for i, n := range g.Nodes {
+		switch n.Version {
+		case from:
+			src = i
+		case to:
+			dst = i
+		}
+	}
+
+	if src == -1 {
+		return unsupported(fmt.Sprintf("version %s is not in the channel", from))
+	}
+	if dst == -1 {
+		return unsupported(fmt.Sprintf("version %s is not in the channel", to))
+	}
+
+	for _, e := range g.Edges {
+		if e[0] == src && e[1] == dst {
+			return nil
+		}
+	}
+
+	return unsupported("the update graph has no such edge")
+}
+
+// graph returns the cached graph of a channel, fetching it if it is missing or
+// has expired
+func (c *client) graph(ctx context.Context, channel string) (*graph, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, found := c.graphs[channel]; found && c.now().Before(cached.expires) {
+		return cached.g, nil
+	}
+
+	g, err := c.getGraph(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	c.graphs[channel] = cachedGraph{
+		g:       g,
+		expires: c.now().Add(c.ttl),
+	}
+
+	return g, nil
+}
+
+// getGraph fetches the upgrade graph of a channel
+func (c *client) getGraph(ctx context.Context, channel string) (*graph, error) {
+	u, err := url.Parse(c.url)
+	if err != nil {
+		return nil, err
+	}
+
+	q := u.Query()
+	q.Set("arch", "amd64")
+	q.Set("channel", channel)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+
+	switch mediaType {
+	case "application/vnd.redhat.cincinnati.graph+json", "application/json":
+	default:
+		return nil, fmt.Errorf("unexpected content type %q", resp.Header.Get("Content-Type"))
+	}
+
+	var g *graph
+	err = json.NewDecoder(resp.Body).Decode(&g)
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
@@ -0,0 +1,126 @@
+package cincinnati
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateUpgrade(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name            string
+		from            string
+		to              string
+		wantUnsupported bool
+		wantErr         string
+	}{
+		{
+			name: "supported edge",
+			from: "4.14.10",
+			to:   "4.14.12",
+		},
+		{
+			name: "same version",
+			from: "4.14.99",
+			to:   "4.14.99",
+		},
+		{
+			name:            "no edge",
+			from:            "4.14.12",
+			to:              "4.14.10",
+			wantUnsupported: true,
+			wantErr:         "upgrade from 4.14.12 to 4.14.10 is not supported in channel stable-4.14: the update graph has no such edge",
+		},
+		{
+			name:            "unknown source",
+			from:            "4.13.1",
+			to:              "4.14.12",
+			wantUnsupported: true,
+			wantErr:         "upgrade from 4.13.1 to 4.14.12 is not supported in channel stable-4.14: version 4.13.1 is not in the channel",
+		},
+		{
+			name:            "unknown target",
+			from:            "4.14.10",
+			to:              "4.15.0",
+			wantUnsupported: true,
+			wantErr:         "upgrade from 4.14.10 to 4.15.0 is not supported in channel stable-4.14: version 4.15.0 is not in the channel",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("channel") != "stable-4.14" {
+					t.Error(r.URL.Query().Get("channel"))
+				}
+				w.Header().Set("Content-Type", "application/vnd.redhat.cincinnati.graph+json")
+				_, _ = w.Write([]byte(`{"nodes":[{"version":"4.14.10"},{"version":"4.14.11"},{"version":"4.14.12"}],"edges":[[0,1],[0,2],[1,2]]}`))
+			}))
+			defer s.Close()
+
+			c := NewClient(s.URL)
+
+			err := c.ValidateUpgrade(ctx, "stable-4.14", tt.from, tt.to)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			var unsupported *UnsupportedUpgradeError
+			if errors.As(err, &unsupported) != tt.wantUnsupported {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestGraphCache(t *testing.T) {
+	ctx := context.Background()
+
+	var requests int
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"nodes":[{"version":"4.14.10"},{"version":"4.14.11"}],"edges":[[0,1]]}`))
+	}))
+	defer s.Close()
+
+	now := time.Now()
+	c := NewClient(s.URL).(*client)
+	c.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		err := c.ValidateUpgrade(ctx, "stable-4.14", "4.14.10", "4.14.11")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests != 1 {
+		t.Fatal(requests)
+	}
+
+	now = now.Add(defaultTTL)
+
+	err := c.ValidateUpgrade(ctx, "stable-4.14", "4.14.10", "4.14.11")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Fatal(requests)
+	}
+}
+
+func TestGetGraphError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer s.Close()
+
+	err := NewClient(s.URL).ValidateUpgrade(context.Background(), "stable-4.14", "4.14.10", "4.14.11")
+	utilerror.AssertErrorMessage(t, err, "unexpected status code 503")
+}
@@ -9,12 +9,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"reflect"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/sirupsen/logrus"
 
 	"github.com/Azure/ARO-RP/pkg/util/instancemetadata"
@@ -104,9 +102,9 @@ func (a *arm) refreshOnce() error {
 	now := a.now()
 
 	// ARM <-> RP Authentication endpoint is not consistent.  Check ARM wiki for up-to-date metadata endpoints
-	endpoint := strings.TrimSuffix(a.im.Environment().ResourceManagerEndpoint, "/") + ":24582"
-	if reflect.DeepEqual(a.im.Environment().Environment, azure.PublicCloud) {
-		endpoint = "https://admin.management.azure.com"
+	endpoint := a.im.Environment().ARMMetadataEndpoint
+	if endpoint == "" {
+		endpoint = strings.TrimSuffix(a.im.Environment().ResourceManagerEndpoint, "/") + ":24582"
 	}
 
 	req, err := http.NewRequest(http.MethodGet, endpoint+"/metadata/authentication?api-version=2015-01-01", nil)
@@ -13,6 +13,9 @@ const (
 	standardDisk          = "StandardSSD_LRS"
 	premiumDisk           = "Premium_LRS"
 	premiumDiskCapability = "PremiumIO"
+
+	hyperVGenerationsCapability         = "HyperVGenerations"
+	confidentialComputingTypeCapability = "ConfidentialComputingType"
 )
 
 // Zones returns zone information for the resource SKU
@@ -41,6 +44,43 @@ func HasCapability(sku *mgmtcompute.ResourceSku, capabilityName string) bool {
 	return false
 }
 
+// SupportsHyperVGeneration checks whether given resource SKU supports the
+// Hyper-V generation (e.g. "V2") listed in its HyperVGenerations capability
+func SupportsHyperVGeneration(sku *mgmtcompute.ResourceSku, generation string) bool {
+	if sku.Capabilities == nil {
+		return false
+	}
+
+	for _, c := range *sku.Capabilities {
+		if *c.Name == hyperVGenerationsCapability {
+			for _, g := range strings.Split(*c.Value, ",") {
+				if strings.EqualFold(strings.TrimSpace(g), generation) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	return false
+}
+
+// SupportsConfidentialComputing checks whether given resource SKU can run as
+// a confidential VM, i.e. it lists a ConfidentialComputingType such as "SNP"
+func SupportsConfidentialComputing(sku *mgmtcompute.ResourceSku) bool {
+	if sku.Capabilities == nil {
+		return false
+	}
+
+	for _, c := range *sku.Capabilities {
+		if *c.Name == confidentialComputingTypeCapability {
+			return c.Value != nil && *c.Value != ""
+		}
+	}
+
+	return false
+}
+
 // IsRestricted checks whether given resource SKU is restricted in a given location
 func IsRestricted(skus map[string]*mgmtcompute.ResourceSku, location, VMSize string) bool {
 	for _, restriction := range *skus[VMSize].Restrictions {
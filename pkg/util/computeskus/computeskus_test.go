@@ -103,6 +103,86 @@ func TestHasCapability(t *testing.T) {
 	}
 }
 
+func TestSupportsHyperVGeneration(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		sku        *mgmtcompute.ResourceSku
+		wantResult bool
+	}{
+		{
+			name: "sku supports generation 1 and 2",
+			sku: &mgmtcompute.ResourceSku{
+				Capabilities: &([]mgmtcompute.ResourceSkuCapabilities{
+					{Name: to.StringPtr("HyperVGenerations"), Value: to.StringPtr("V1,V2")},
+				}),
+			},
+			wantResult: true,
+		},
+		{
+			name: "sku supports generation 1 only",
+			sku: &mgmtcompute.ResourceSku{
+				Capabilities: &([]mgmtcompute.ResourceSkuCapabilities{
+					{Name: to.StringPtr("HyperVGenerations"), Value: to.StringPtr("V1")},
+				}),
+			},
+		},
+		{
+			name: "sku does not list its generations",
+			sku: &mgmtcompute.ResourceSku{
+				Capabilities: &([]mgmtcompute.ResourceSkuCapabilities{}),
+			},
+		},
+		{
+			name: "capabilities info missing",
+			sku:  &mgmtcompute.ResourceSku{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := SupportsHyperVGeneration(tt.sku, "V2")
+
+			if result != tt.wantResult {
+				t.Error(result)
+			}
+		})
+	}
+}
+
+func TestSupportsConfidentialComputing(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		sku        *mgmtcompute.ResourceSku
+		wantResult bool
+	}{
+		{
+			name: "sku supports confidential computing",
+			sku: &mgmtcompute.ResourceSku{
+				Capabilities: &([]mgmtcompute.ResourceSkuCapabilities{
+					{Name: to.StringPtr("ConfidentialComputingType"), Value: to.StringPtr("SNP")},
+				}),
+			},
+			wantResult: true,
+		},
+		{
+			name: "sku does not support confidential computing",
+			sku: &mgmtcompute.ResourceSku{
+				Capabilities: &([]mgmtcompute.ResourceSkuCapabilities{}),
+			},
+		},
+		{
+			name: "capabilities info missing",
+			sku:  &mgmtcompute.ResourceSku{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := SupportsConfidentialComputing(tt.sku)
+
+			if result != tt.wantResult {
+				t.Error(result)
+			}
+		})
+	}
+}
+
 func TestFilterVmSizes(t *testing.T) {
 	for _, tt := range []struct {
 		name             string
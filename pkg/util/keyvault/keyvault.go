@@ -42,6 +42,7 @@ type Manager interface {
 	GetCertificateSecret(context.Context, string) (*rsa.PrivateKey, []*x509.Certificate, error)
 	GetSecret(context.Context, string) (azkeyvault.SecretBundle, error)
 	GetSecrets(context.Context) ([]azkeyvault.SecretItem, error)
+	RenewCertificate(context.Context, string) error
 	SetCertificateIssuer(ctx context.Context, issuerName string, parameter azkeyvault.CertificateIssuerSetParameters) (result azkeyvault.IssuerBundle, err error)
 	SetSecret(context.Context, string, azkeyvault.SecretSetParameters) error
 	UpdateCertificatePolicy(context.Context, string, azkeyvault.CertificatePolicy) error
@@ -216,6 +217,25 @@ func (m *manager) GetSecrets(ctx context.Context) ([]azkeyvault.SecretItem, erro
 	return m.kv.GetSecrets(ctx, m.keyvaultURI, nil)
 }
 
+// RenewCertificate creates a new version of an existing certificate using the
+// certificate's current policy.
+func (m *manager) RenewCertificate(ctx context.Context, certificateName string) error {
+	policy, err := m.kv.GetCertificatePolicy(ctx, m.keyvaultURI, certificateName)
+	if err != nil {
+		return err
+	}
+
+	op, err := m.kv.CreateCertificate(ctx, m.keyvaultURI, certificateName, azkeyvault.CertificateCreateParameters{
+		CertificatePolicy: &policy,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = checkOperation(&op)
+	return err
+}
+
 func (m *manager) SetCertificateIssuer(ctx context.Context, issuerName string, parameter azkeyvault.CertificateIssuerSetParameters) (azkeyvault.IssuerBundle, error) {
 	return m.kv.SetCertificateIssuer(ctx, m.keyvaultURI, issuerName, parameter)
 }
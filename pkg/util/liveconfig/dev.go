@@ -71,3 +71,23 @@ func (p *dev) AdoptByHive(ctx context.Context) (bool, error) {
 	}
 	return false, nil
 }
+
+func (p *dev) DeprovisionViaHive(ctx context.Context) (bool, error) {
+	deprovision := os.Getenv(hiveDeprovisionEnvVar)
+	if deprovision != "" {
+		return true, nil
+	}
+	return false, nil
+}
+
+func (p *dev) OperatorViaHive(ctx context.Context) (bool, error) {
+	operator := os.Getenv(hiveOperatorEnvVar)
+	if operator != "" {
+		return true, nil
+	}
+	return false, nil
+}
+
+func (p *dev) HiveShardWeights(ctx context.Context) (map[int]int, error) {
+	return parseHiveShardWeights(os.Getenv(hiveShardWeightsEnvVar))
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
@@ -117,3 +118,56 @@ func (p *prod) AdoptByHive(ctx context.Context) (bool, error) {
 	}
 	return false, nil
 }
+
+func (p *prod) DeprovisionViaHive(ctx context.Context) (bool, error) {
+	// TODO: Replace with RP Live Service Config (KeyVault)
+	deprovision := os.Getenv(hiveDeprovisionEnvVar)
+	if deprovision != "" {
+		return true, nil
+	}
+	return false, nil
+}
+
+func (p *prod) OperatorViaHive(ctx context.Context) (bool, error) {
+	// TODO: Replace with RP Live Service Config (KeyVault)
+	operator := os.Getenv(hiveOperatorEnvVar)
+	if operator != "" {
+		return true, nil
+	}
+	return false, nil
+}
+
+func (p *prod) HiveShardWeights(ctx context.Context) (map[int]int, error) {
+	// TODO: Replace with RP Live Service Config (KeyVault)
+	return parseHiveShardWeights(os.Getenv(hiveShardWeightsEnvVar))
+}
+
+// parseHiveShardWeights parses a comma separated list of shard:weight pairs,
+// e.g. "1:1,2:2".  If no weights are configured, shard 1 is the only shard.
+func parseHiveShardWeights(s string) (map[int]int, error) {
+	if s == "" {
+		return map[int]int{1: 1}, nil
+	}
+
+	weights := map[int]int{}
+	for _, pair := range strings.Split(s, ",") {
+		shard, weight, found := strings.Cut(strings.TrimSpace(pair), ":")
+		if !found {
+			return nil, fmt.Errorf("invalid hive shard weight %q", pair)
+		}
+
+		shardNumber, err := strconv.Atoi(shard)
+		if err != nil || shardNumber < 1 {
+			return nil, fmt.Errorf("invalid hive shard %q", shard)
+		}
+
+		weightNumber, err := strconv.Atoi(weight)
+		if err != nil || weightNumber < 0 {
+			return nil, fmt.Errorf("invalid weight %q for hive shard %d", weight, shardNumber)
+		}
+
+		weights[shardNumber] = weightNumber
+	}
+
+	return weights, nil
+}
@@ -8,6 +8,7 @@ import (
 	"embed"
 	"errors"
 	"net/http"
+	"reflect"
 	"testing"
 
 	azfake "github.com/Azure/azure-sdk-for-go/sdk/azcore/fake"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/util/azureclient"
 	utilcontainerservice "github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/armcontainerservice"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
 )
 
 //go:embed testdata
@@ -125,7 +127,7 @@ func TestProdHiveAdmin(t *testing.T) {
 		t.Fatal(errors.New("invalid number of credentials returned"))
 	}
 
-	lc := NewProd("eastus", mcc)
+	lc := NewProd("eastus", mcc, nil)
 
 	restConfig, err := lc.HiveRestConfig(ctx, 1)
 	if err != nil {
@@ -155,3 +157,46 @@ func TestProdHiveAdmin(t *testing.T) {
 		t.Error("Invalid admin BearerToken returned for test 2")
 	}
 }
+
+func TestParseHiveShardWeights(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		s       string
+		want    map[int]int
+		wantErr string
+	}{
+		{
+			name: "default",
+			want: map[int]int{1: 1},
+		},
+		{
+			name: "several shards",
+			s:    "1:0, 2:1,3:2",
+			want: map[int]int{1: 0, 2: 1, 3: 2},
+		},
+		{
+			name:    "missing weight",
+			s:       "1",
+			wantErr: `invalid hive shard weight "1"`,
+		},
+		{
+			name:    "invalid shard",
+			s:       "0:1",
+			wantErr: `invalid hive shard "0"`,
+		},
+		{
+			name:    "invalid weight",
+			s:       "1:-1",
+			wantErr: `invalid weight "-1" for hive shard 1`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseHiveShardWeights(tt.s)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(got)
+			}
+		})
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"k8s.io/client-go/rest"
 
 	utilcontainerservice "github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/armcontainerservice"
+	"github.com/Azure/ARO-RP/pkg/util/keyvault"
+	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
 const (
@@ -17,15 +19,29 @@ const (
 	hiveInstallerEnableEnvVar = "ARO_INSTALL_VIA_HIVE"
 	hiveDefaultPullSpecEnvVar = "ARO_HIVE_DEFAULT_INSTALLER_PULLSPEC"
 	hiveAdoptEnableEnvVar     = "ARO_ADOPT_BY_HIVE"
+	hiveDeprovisionEnvVar     = "ARO_DEPROVISION_VIA_HIVE"
+	hiveOperatorEnvVar        = "ARO_OPERATOR_VIA_HIVE"
+	hiveShardWeightsEnvVar    = "ARO_HIVE_SHARD_WEIGHTS"
 )
 
 type Manager interface {
 	HiveRestConfig(context.Context, int) (*rest.Config, error)
 	InstallViaHive(context.Context) (bool, error)
 	AdoptByHive(context.Context) (bool, error)
+	DeprovisionViaHive(context.Context) (bool, error)
+	OperatorViaHive(context.Context) (bool, error)
+	// HiveShardWeights returns the weight of each Hive shard in the region.
+	// New clusters are spread across shards in proportion to their weights,
+	// and shards with a zero weight are not given new clusters.
+	HiveShardWeights(context.Context) (map[int]int, error)
 
 	// Allows overriding the default installer pullspec for Prod, if the OpenShiftVersions database is not populated
 	DefaultInstallerPullSpecOverride(context.Context) string
+
+	// SidecarImages returns the Geneva sidecar images of the environment,
+	// configured in the SidecarImagesSecretName secret of the service key
+	// vault, so that they can be bumped without an RP release
+	SidecarImages(context.Context) (version.SidecarImages, error)
 }
 
 type dev struct {
@@ -34,13 +50,18 @@ type dev struct {
 
 	hiveCredentialsMutex sync.RWMutex
 	cachedCredentials    map[int]*rest.Config
+
+	sidecarImages *sidecarImages
 }
 
-func NewDev(location string, managedClustersClient utilcontainerservice.ManagedClustersClient) Manager {
+// NewDev returns a development Manager.  serviceKeyvault may be nil, in
+// which case the default sidecar images are used.
+func NewDev(location string, managedClustersClient utilcontainerservice.ManagedClustersClient, serviceKeyvault keyvault.Manager) Manager {
 	return &dev{location: location,
 		managedClustersClient: managedClustersClient,
 		cachedCredentials:     make(map[int]*rest.Config),
 		hiveCredentialsMutex:  sync.RWMutex{},
+		sidecarImages:         newSidecarImages(serviceKeyvault),
 	}
 }
 
@@ -50,13 +71,18 @@ type prod struct {
 
 	hiveCredentialsMutex sync.RWMutex
 	cachedCredentials    map[int]*rest.Config
+
+	sidecarImages *sidecarImages
 }
 
-func NewProd(location string, managedClustersClient utilcontainerservice.ManagedClustersClient) Manager {
+// NewProd returns a production Manager.  serviceKeyvault may be nil, in
+// which case the default sidecar images are used.
+func NewProd(location string, managedClustersClient utilcontainerservice.ManagedClustersClient, serviceKeyvault keyvault.Manager) Manager {
 	return &prod{
 		location:              location,
 		managedClustersClient: managedClustersClient,
 		cachedCredentials:     make(map[int]*rest.Config),
 		hiveCredentialsMutex:  sync.RWMutex{},
+		sidecarImages:         newSidecarImages(serviceKeyvault),
 	}
 }
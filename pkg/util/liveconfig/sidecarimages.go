@@ -0,0 +1,88 @@
+package liveconfig
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureerrors"
+	"github.com/Azure/ARO-RP/pkg/util/keyvault"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+const (
+	// SidecarImagesSecretName is the service key vault secret holding the
+	// JSON-encoded version.SidecarImages of the environment
+	SidecarImagesSecretName = "rp-sidecar-images"
+
+	sidecarImagesTTL = 5 * time.Minute
+)
+
+// sidecarImages caches the sidecar images configured in the service key
+// vault.  Images which are not configured take their defaults.
+type sidecarImages struct {
+	kv  keyvault.Manager
+	now func() time.Time
+
+	mu      sync.Mutex
+	images  *version.SidecarImages
+	expires time.Time
+}
+
+func newSidecarImages(kv keyvault.Manager) *sidecarImages {
+	return &sidecarImages{
+		kv:  kv,
+		now: time.Now,
+	}
+}
+
+// get returns the configured sidecar images.  If the key vault cannot be
+// read, the last images read are returned.
+func (s *sidecarImages) get(ctx context.Context) (version.SidecarImages, error) {
+	if s.kv == nil {
+		return version.DefaultSidecarImages, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.images != nil && s.now().Before(s.expires) {
+		return *s.images, nil
+	}
+
+	var images version.SidecarImages
+
+	bundle, err := s.kv.GetSecret(ctx, SidecarImagesSecretName)
+	switch {
+	case azureerrors.IsNotFoundError(err):
+	case err != nil:
+		if s.images != nil {
+			return *s.images, nil
+		}
+		return version.SidecarImages{}, err
+	case bundle.Value != nil:
+		err = json.Unmarshal([]byte(*bundle.Value), &images)
+		if err != nil {
+			return version.SidecarImages{}, err
+		}
+	}
+
+	images = images.WithDefaults()
+
+	s.images = &images
+	s.expires = s.now().Add(sidecarImagesTTL)
+
+	return images, nil
+}
+
+func (d *dev) SidecarImages(ctx context.Context) (version.SidecarImages, error) {
+	return d.sidecarImages.get(ctx)
+}
+
+func (p *prod) SidecarImages(ctx context.Context) (version.SidecarImages, error) {
+	return p.sidecarImages.get(ctx)
+}
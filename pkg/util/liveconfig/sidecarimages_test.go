@@ -0,0 +1,130 @@
+package liveconfig
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	azkeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"go.uber.org/mock/gomock"
+
+	mock_keyvault "github.com/Azure/ARO-RP/pkg/util/mocks/keyvault"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestSidecarImages(t *testing.T) {
+	ctx := context.Background()
+
+	notFound := autorest.DetailedError{StatusCode: http.StatusNotFound}
+	secret := func(value string) azkeyvault.SecretBundle {
+		return azkeyvault.SecretBundle{Value: to.StringPtr(value)}
+	}
+
+	bumped := version.DefaultSidecarImages
+	bumped.Mdsd = "distroless/genevamdsd:bumped"
+
+	for _, tt := range []struct {
+		name    string
+		mocks   func(*mock_keyvault.MockManager)
+		expire  bool
+		want    version.SidecarImages
+		wantErr string
+	}{
+		{
+			name: "defaults if the secret does not exist",
+			mocks: func(kv *mock_keyvault.MockManager) {
+				kv.EXPECT().GetSecret(gomock.Any(), SidecarImagesSecretName).Return(azkeyvault.SecretBundle{}, notFound)
+			},
+			want: version.DefaultSidecarImages,
+		},
+		{
+			name: "configured images override the defaults",
+			mocks: func(kv *mock_keyvault.MockManager) {
+				kv.EXPECT().GetSecret(gomock.Any(), SidecarImagesSecretName).Return(secret(`{"mdsd": "distroless/genevamdsd:bumped"}`), nil)
+			},
+			want: bumped,
+		},
+		{
+			name: "images are cached",
+			mocks: func(kv *mock_keyvault.MockManager) {
+				kv.EXPECT().GetSecret(gomock.Any(), SidecarImagesSecretName).Return(secret(`{"mdsd": "distroless/genevamdsd:bumped"}`), nil).Times(1)
+			},
+			want: bumped,
+		},
+		{
+			name: "expired images are refreshed",
+			mocks: func(kv *mock_keyvault.MockManager) {
+				gomock.InOrder(
+					kv.EXPECT().GetSecret(gomock.Any(), SidecarImagesSecretName).Return(azkeyvault.SecretBundle{}, notFound),
+					kv.EXPECT().GetSecret(gomock.Any(), SidecarImagesSecretName).Return(secret(`{"mdsd": "distroless/genevamdsd:bumped"}`), nil),
+				)
+			},
+			expire: true,
+			want:   bumped,
+		},
+		{
+			name: "last images are kept if the key vault cannot be read",
+			mocks: func(kv *mock_keyvault.MockManager) {
+				gomock.InOrder(
+					kv.EXPECT().GetSecret(gomock.Any(), SidecarImagesSecretName).Return(secret(`{"mdsd": "distroless/genevamdsd:bumped"}`), nil),
+					kv.EXPECT().GetSecret(gomock.Any(), SidecarImagesSecretName).Return(azkeyvault.SecretBundle{}, errors.New("failed")),
+				)
+			},
+			expire: true,
+			want:   bumped,
+		},
+		{
+			name: "invalid secret",
+			mocks: func(kv *mock_keyvault.MockManager) {
+				kv.EXPECT().GetSecret(gomock.Any(), SidecarImagesSecretName).Return(secret(`not json`), nil).Times(2)
+			},
+			wantErr: "invalid character 'o' in literal null (expecting 'u')",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			kv := mock_keyvault.NewMockManager(controller)
+			tt.mocks(kv)
+
+			now := time.Now()
+			s := newSidecarImages(kv)
+			s.now = func() time.Time { return now }
+
+			var got version.SidecarImages
+			var err error
+			for i := 0; i < 2; i++ {
+				got, err = s.get(ctx)
+				if tt.expire {
+					now = now.Add(sidecarImagesTTL)
+				}
+			}
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestSidecarImagesWithoutKeyvault(t *testing.T) {
+	got, err := newSidecarImages(nil).get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, version.DefaultSidecarImages) {
+		t.Error(got)
+	}
+}
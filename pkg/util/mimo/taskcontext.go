@@ -13,6 +13,7 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/cincinnati"
 	"github.com/Azure/ARO-RP/pkg/util/clienthelper"
 )
 
@@ -23,6 +24,7 @@ type TaskContext interface {
 	ClientHelper() (clienthelper.Interface, error)
 	Log() *logrus.Entry
 	LocalFpAuthorizer() (autorest.Authorizer, error)
+	UpgradeGraph() cincinnati.Client
 
 	// OpenShiftCluster
 	GetClusterUUID() string
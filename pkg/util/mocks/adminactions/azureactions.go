@@ -143,6 +143,20 @@ func (mr *MockAzureActionsMockRecorder) VMResize(ctx, vmName, vmSize any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VMResize", reflect.TypeOf((*MockAzureActions)(nil).VMResize), ctx, vmName, vmSize)
 }
 
+// VMScreenshot mocks base method.
+func (m *MockAzureActions) VMScreenshot(ctx context.Context, log *logrus.Entry, vmName string, target io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VMScreenshot", ctx, log, vmName, target)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VMScreenshot indicates an expected call of VMScreenshot.
+func (mr *MockAzureActionsMockRecorder) VMScreenshot(ctx, log, vmName, target any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VMScreenshot", reflect.TypeOf((*MockAzureActions)(nil).VMScreenshot), ctx, log, vmName, target)
+}
+
 // VMSerialConsole mocks base method.
 func (m *MockAzureActions) VMSerialConsole(ctx context.Context, log *logrus.Entry, vmName string, target io.Writer) error {
 	m.ctrl.T.Helper()
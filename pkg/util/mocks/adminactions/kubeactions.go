@@ -201,3 +201,31 @@ func (mr *MockKubeActionsMockRecorder) ResolveGVR(groupKind, optionalVersion any
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveGVR", reflect.TypeOf((*MockKubeActions)(nil).ResolveGVR), groupKind, optionalVersion)
 }
+
+// ResumeWorkerRollout mocks base method.
+func (m *MockKubeActions) ResumeWorkerRollout(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeWorkerRollout", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeWorkerRollout indicates an expected call of ResumeWorkerRollout.
+func (mr *MockKubeActionsMockRecorder) ResumeWorkerRollout(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeWorkerRollout", reflect.TypeOf((*MockKubeActions)(nil).ResumeWorkerRollout), ctx)
+}
+
+// UpgradeControlPlane mocks base method.
+func (m *MockKubeActions) UpgradeControlPlane(ctx context.Context, version string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeControlPlane", ctx, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpgradeControlPlane indicates an expected call of UpgradeControlPlane.
+func (mr *MockKubeActionsMockRecorder) UpgradeControlPlane(ctx, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeControlPlane", reflect.TypeOf((*MockKubeActions)(nil).UpgradeControlPlane), ctx, version)
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/armauthorization (interfaces: RoleDefinitionsClient)
+// Source: github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/armauthorization (interfaces: DenyAssignmentsClient,RoleDefinitionsClient)
 //
 // Generated by this command:
 //
-//	mockgen -destination=../../../../util/mocks/azureclient/azuresdk/armauthorization/armauthorization.go github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/armauthorization RoleDefinitionsClient
+//	mockgen -destination=../../../../util/mocks/azureclient/azuresdk/armauthorization/armauthorization.go github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/armauthorization DenyAssignmentsClient,RoleDefinitionsClient
 //
 
 // Package mock_armauthorization is a generated GoMock package.
@@ -17,6 +17,44 @@ import (
 	gomock "go.uber.org/mock/gomock"
 )
 
+// MockDenyAssignmentsClient is a mock of DenyAssignmentsClient interface.
+type MockDenyAssignmentsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockDenyAssignmentsClientMockRecorder
+}
+
+// MockDenyAssignmentsClientMockRecorder is the mock recorder for MockDenyAssignmentsClient.
+type MockDenyAssignmentsClientMockRecorder struct {
+	mock *MockDenyAssignmentsClient
+}
+
+// NewMockDenyAssignmentsClient creates a new mock instance.
+func NewMockDenyAssignmentsClient(ctrl *gomock.Controller) *MockDenyAssignmentsClient {
+	mock := &MockDenyAssignmentsClient{ctrl: ctrl}
+	mock.recorder = &MockDenyAssignmentsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDenyAssignmentsClient) EXPECT() *MockDenyAssignmentsClientMockRecorder {
+	return m.recorder
+}
+
+// ListForScope mocks base method.
+func (m *MockDenyAssignmentsClient) ListForScope(arg0 context.Context, arg1 string, arg2 *armauthorization.DenyAssignmentsClientListForScopeOptions) ([]*armauthorization.DenyAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListForScope", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*armauthorization.DenyAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListForScope indicates an expected call of ListForScope.
+func (mr *MockDenyAssignmentsClientMockRecorder) ListForScope(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForScope", reflect.TypeOf((*MockDenyAssignmentsClient)(nil).ListForScope), arg0, arg1, arg2)
+}
+
 // MockRoleDefinitionsClient is a mock of RoleDefinitionsClient interface.
 type MockRoleDefinitionsClient struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./keys.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./keys.go -destination=../../../mocks/azureclient/azuresdk/armkeyvault/keys.go github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/armkeyvault KeysClient
+//
+
+// Package mock_armkeyvault is a generated GoMock package.
+package mock_armkeyvault
+
+import (
+	context "context"
+	reflect "reflect"
+
+	armkeyvault "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/keyvault/armkeyvault"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockKeysClient is a mock of KeysClient interface.
+type MockKeysClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeysClientMockRecorder
+}
+
+// MockKeysClientMockRecorder is the mock recorder for MockKeysClient.
+type MockKeysClientMockRecorder struct {
+	mock *MockKeysClient
+}
+
+// NewMockKeysClient creates a new mock instance.
+func NewMockKeysClient(ctrl *gomock.Controller) *MockKeysClient {
+	mock := &MockKeysClient{ctrl: ctrl}
+	mock.recorder = &MockKeysClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeysClient) EXPECT() *MockKeysClientMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockKeysClient) Get(ctx context.Context, resourceGroupName, vaultName, keyName string, options *armkeyvault.KeysClientGetOptions) (armkeyvault.KeysClientGetResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, resourceGroupName, vaultName, keyName, options)
+	ret0, _ := ret[0].(armkeyvault.KeysClientGetResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockKeysClientMockRecorder) Get(ctx, resourceGroupName, vaultName, keyName, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockKeysClient)(nil).Get), ctx, resourceGroupName, vaultName, keyName, options)
+}
+
+// GetVersion mocks base method.
+func (m *MockKeysClient) GetVersion(ctx context.Context, resourceGroupName, vaultName, keyName, keyVersion string, options *armkeyvault.KeysClientGetVersionOptions) (armkeyvault.KeysClientGetVersionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersion", ctx, resourceGroupName, vaultName, keyName, keyVersion, options)
+	ret0, _ := ret[0].(armkeyvault.KeysClientGetVersionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersion indicates an expected call of GetVersion.
+func (mr *MockKeysClientMockRecorder) GetVersion(ctx, resourceGroupName, vaultName, keyName, keyVersion, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockKeysClient)(nil).GetVersion), ctx, resourceGroupName, vaultName, keyName, keyVersion, options)
+}
@@ -174,6 +174,20 @@ func (mr *MockVirtualMachinesClientMockRecorder) Get(arg0, arg1, arg2, arg3 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockVirtualMachinesClient)(nil).Get), arg0, arg1, arg2, arg3)
 }
 
+// GetScreenshotForVM mocks base method.
+func (m *MockVirtualMachinesClient) GetScreenshotForVM(arg0 context.Context, arg1, arg2 string, arg3 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScreenshotForVM", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetScreenshotForVM indicates an expected call of GetScreenshotForVM.
+func (mr *MockVirtualMachinesClientMockRecorder) GetScreenshotForVM(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScreenshotForVM", reflect.TypeOf((*MockVirtualMachinesClient)(nil).GetScreenshotForVM), arg0, arg1, arg2, arg3)
+}
+
 // GetSerialConsoleForVM mocks base method.
 func (m *MockVirtualMachinesClient) GetSerialConsoleForVM(arg0 context.Context, arg1, arg2 string, arg3 io.Writer) error {
 	m.ctrl.T.Helper()
@@ -402,6 +416,20 @@ func (mr *MockVirtualMachineScaleSetsClientMockRecorder) List(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockVirtualMachineScaleSetsClient)(nil).List), arg0, arg1)
 }
 
+// UpdateAndWait mocks base method.
+func (m *MockVirtualMachineScaleSetsClient) UpdateAndWait(arg0 context.Context, arg1, arg2 string, arg3 compute.VirtualMachineScaleSetUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAndWait", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAndWait indicates an expected call of UpdateAndWait.
+func (mr *MockVirtualMachineScaleSetsClientMockRecorder) UpdateAndWait(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAndWait", reflect.TypeOf((*MockVirtualMachineScaleSetsClient)(nil).UpdateAndWait), arg0, arg1, arg2, arg3)
+}
+
 // MockDiskEncryptionSetsClient is a mock of DiskEncryptionSetsClient interface.
 type MockDiskEncryptionSetsClient struct {
 	ctrl     *gomock.Controller
@@ -112,6 +112,21 @@ func (mr *MockDeploymentsClientMockRecorder) Wait(arg0, arg1, arg2 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockDeploymentsClient)(nil).Wait), arg0, arg1, arg2)
 }
 
+// WhatIfAndWait mocks base method.
+func (m *MockDeploymentsClient) WhatIfAndWait(arg0 context.Context, arg1, arg2 string, arg3 features.DeploymentWhatIf) (features.WhatIfOperationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WhatIfAndWait", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(features.WhatIfOperationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WhatIfAndWait indicates an expected call of WhatIfAndWait.
+func (mr *MockDeploymentsClientMockRecorder) WhatIfAndWait(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WhatIfAndWait", reflect.TypeOf((*MockDeploymentsClient)(nil).WhatIfAndWait), arg0, arg1, arg2, arg3)
+}
+
 // MockProvidersClient is a mock of ProvidersClient interface.
 type MockProvidersClient struct {
 	ctrl     *gomock.Controller
@@ -96,6 +96,53 @@ func (mr *MockDynamicMockRecorder) ValidateClusterUserAssignedIdentity(ctx, plat
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateClusterUserAssignedIdentity", reflect.TypeOf((*MockDynamic)(nil).ValidateClusterUserAssignedIdentity), ctx, platformIdentities, roleDefinitions)
 }
 
+// ValidateConnectedNetworks mocks base method.
+func (m *MockDynamic) ValidateConnectedNetworks(ctx context.Context, subnets []dynamic.Subnet, additionalCIDRs ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, subnets}
+	for _, a := range additionalCIDRs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ValidateConnectedNetworks", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateConnectedNetworks indicates an expected call of ValidateConnectedNetworks.
+func (mr *MockDynamicMockRecorder) ValidateConnectedNetworks(ctx, subnets any, additionalCIDRs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, subnets}, additionalCIDRs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateConnectedNetworks", reflect.TypeOf((*MockDynamic)(nil).ValidateConnectedNetworks), varargs...)
+}
+
+// ValidateCustomDomain mocks base method.
+func (m *MockDynamic) ValidateCustomDomain(ctx context.Context, oc *api.OpenShiftCluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateCustomDomain", ctx, oc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateCustomDomain indicates an expected call of ValidateCustomDomain.
+func (mr *MockDynamicMockRecorder) ValidateCustomDomain(ctx, oc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCustomDomain", reflect.TypeOf((*MockDynamic)(nil).ValidateCustomDomain), ctx, oc)
+}
+
+// ValidateDenyAssignmentsAndPolicies mocks base method.
+func (m *MockDynamic) ValidateDenyAssignmentsAndPolicies(ctx context.Context, oc *api.OpenShiftCluster, subnets []dynamic.Subnet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateDenyAssignmentsAndPolicies", ctx, oc, subnets)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateDenyAssignmentsAndPolicies indicates an expected call of ValidateDenyAssignmentsAndPolicies.
+func (mr *MockDynamicMockRecorder) ValidateDenyAssignmentsAndPolicies(ctx, oc, subnets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateDenyAssignmentsAndPolicies", reflect.TypeOf((*MockDynamic)(nil).ValidateDenyAssignmentsAndPolicies), ctx, oc, subnets)
+}
+
 // ValidateDiskEncryptionSets mocks base method.
 func (m *MockDynamic) ValidateDiskEncryptionSets(ctx context.Context, oc *api.OpenShiftCluster) error {
 	m.ctrl.T.Helper()
@@ -110,6 +157,20 @@ func (mr *MockDynamicMockRecorder) ValidateDiskEncryptionSets(ctx, oc any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateDiskEncryptionSets", reflect.TypeOf((*MockDynamic)(nil).ValidateDiskEncryptionSets), ctx, oc)
 }
 
+// ValidateEgress mocks base method.
+func (m *MockDynamic) ValidateEgress(ctx context.Context, oc *api.OpenShiftCluster, subnets []dynamic.Subnet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateEgress", ctx, oc, subnets)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateEgress indicates an expected call of ValidateEgress.
+func (mr *MockDynamicMockRecorder) ValidateEgress(ctx, oc, subnets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateEgress", reflect.TypeOf((*MockDynamic)(nil).ValidateEgress), ctx, oc, subnets)
+}
+
 // ValidateEncryptionAtHost mocks base method.
 func (m *MockDynamic) ValidateEncryptionAtHost(ctx context.Context, oc *api.OpenShiftCluster) error {
 	m.ctrl.T.Helper()
@@ -138,6 +199,20 @@ func (mr *MockDynamicMockRecorder) ValidateLoadBalancerProfile(ctx, oc any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateLoadBalancerProfile", reflect.TypeOf((*MockDynamic)(nil).ValidateLoadBalancerProfile), ctx, oc)
 }
 
+// ValidateMasterNetworkInterfaces mocks base method.
+func (m *MockDynamic) ValidateMasterNetworkInterfaces(ctx context.Context, oc *api.OpenShiftCluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateMasterNetworkInterfaces", ctx, oc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateMasterNetworkInterfaces indicates an expected call of ValidateMasterNetworkInterfaces.
+func (mr *MockDynamicMockRecorder) ValidateMasterNetworkInterfaces(ctx, oc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateMasterNetworkInterfaces", reflect.TypeOf((*MockDynamic)(nil).ValidateMasterNetworkInterfaces), ctx, oc)
+}
+
 // ValidatePlatformWorkloadIdentityProfile mocks base method.
 func (m *MockDynamic) ValidatePlatformWorkloadIdentityProfile(ctx context.Context, oc *api.OpenShiftCluster, platformWorkloadIdentityRolesByRoleName map[string]api.PlatformWorkloadIdentityRole, roleDefinitions armauthorization.RoleDefinitionsClient, clusterMsiFederatedIdentityCredentials armmsi.FederatedIdentityCredentialsClient) error {
 	m.ctrl.T.Helper()
@@ -180,6 +255,20 @@ func (mr *MockDynamicMockRecorder) ValidateServicePrincipal(ctx, spTokenCredenti
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateServicePrincipal", reflect.TypeOf((*MockDynamic)(nil).ValidateServicePrincipal), ctx, spTokenCredential)
 }
 
+// ValidateSharedSubnets mocks base method.
+func (m *MockDynamic) ValidateSharedSubnets(ctx context.Context, oc *api.OpenShiftCluster, subnets []dynamic.Subnet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateSharedSubnets", ctx, oc, subnets)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateSharedSubnets indicates an expected call of ValidateSharedSubnets.
+func (mr *MockDynamicMockRecorder) ValidateSharedSubnets(ctx, oc, subnets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateSharedSubnets", reflect.TypeOf((*MockDynamic)(nil).ValidateSharedSubnets), ctx, oc, subnets)
+}
+
 // ValidateSubnets mocks base method.
 func (m *MockDynamic) ValidateSubnets(ctx context.Context, oc *api.OpenShiftCluster, subnets []dynamic.Subnet) error {
 	m.ctrl.T.Helper()
@@ -44,15 +44,15 @@ func (m *MockQuotaValidator) EXPECT() *MockQuotaValidatorMockRecorder {
 }
 
 // ValidateQuota mocks base method.
-func (m *MockQuotaValidator) ValidateQuota(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string, oc *api.OpenShiftCluster) error {
+func (m *MockQuotaValidator) ValidateQuota(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string, oc *api.OpenShiftCluster, additionalVMSizes []*api.SupportedVMSize) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateQuota", ctx, azEnv, environment, subscriptionID, tenantID, oc)
+	ret := m.ctrl.Call(m, "ValidateQuota", ctx, azEnv, environment, subscriptionID, tenantID, oc, additionalVMSizes)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ValidateQuota indicates an expected call of ValidateQuota.
-func (mr *MockQuotaValidatorMockRecorder) ValidateQuota(ctx, azEnv, environment, subscriptionID, tenantID, oc any) *gomock.Call {
+func (mr *MockQuotaValidatorMockRecorder) ValidateQuota(ctx, azEnv, environment, subscriptionID, tenantID, oc, additionalVMSizes any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateQuota", reflect.TypeOf((*MockQuotaValidator)(nil).ValidateQuota), ctx, azEnv, environment, subscriptionID, tenantID, oc)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateQuota", reflect.TypeOf((*MockQuotaValidator)(nil).ValidateQuota), ctx, azEnv, environment, subscriptionID, tenantID, oc, additionalVMSizes)
 }
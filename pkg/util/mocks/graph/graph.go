@@ -70,3 +70,18 @@ func (mr *MockManagerMockRecorder) LoadPersisted(arg0, arg1, arg2 any) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadPersisted", reflect.TypeOf((*MockManager)(nil).LoadPersisted), arg0, arg1, arg2)
 }
+
+// RotateEncryption mocks base method.
+func (m *MockManager) RotateEncryption(arg0 context.Context, arg1, arg2, arg3 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateEncryption", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateEncryption indicates an expected call of RotateEncryption.
+func (mr *MockManagerMockRecorder) RotateEncryption(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateEncryption", reflect.TypeOf((*MockManager)(nil).RotateEncryption), arg0, arg1, arg2, arg3)
+}
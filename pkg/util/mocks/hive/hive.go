@@ -14,11 +14,13 @@ import (
 	reflect "reflect"
 
 	v1 "github.com/openshift/hive/apis/hive/v1"
+	v1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
 	gomock "go.uber.org/mock/gomock"
 	v10 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 
 	api "github.com/Azure/ARO-RP/pkg/api"
+	hive "github.com/Azure/ARO-RP/pkg/hive"
 )
 
 // MockClusterManager is a mock of ClusterManager interface.
@@ -87,6 +89,34 @@ func (mr *MockClusterManagerMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockClusterManager)(nil).Delete), arg0, arg1)
 }
 
+// Deprovision mocks base method.
+func (m *MockClusterManager) Deprovision(arg0 context.Context, arg1 *api.OpenShiftClusterDocument) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deprovision", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deprovision indicates an expected call of Deprovision.
+func (mr *MockClusterManagerMockRecorder) Deprovision(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deprovision", reflect.TypeOf((*MockClusterManager)(nil).Deprovision), arg0, arg1)
+}
+
+// EnsureSyncSet mocks base method.
+func (m *MockClusterManager) EnsureSyncSet(arg0 context.Context, arg1 *api.OpenShiftClusterDocument, arg2 string, arg3 []runtime.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureSyncSet", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureSyncSet indicates an expected call of EnsureSyncSet.
+func (mr *MockClusterManagerMockRecorder) EnsureSyncSet(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureSyncSet", reflect.TypeOf((*MockClusterManager)(nil).EnsureSyncSet), arg0, arg1, arg2, arg3)
+}
+
 // GetClusterDeployment mocks base method.
 func (m *MockClusterManager) GetClusterDeployment(arg0 context.Context, arg1 *api.OpenShiftClusterDocument) (*v1.ClusterDeployment, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +132,36 @@ func (mr *MockClusterManagerMockRecorder) GetClusterDeployment(arg0, arg1 any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterDeployment", reflect.TypeOf((*MockClusterManager)(nil).GetClusterDeployment), arg0, arg1)
 }
 
+// GetClusterSync mocks base method.
+func (m *MockClusterManager) GetClusterSync(arg0 context.Context, arg1 *api.OpenShiftClusterDocument) (*v1alpha1.ClusterSync, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClusterSync", arg0, arg1)
+	ret0, _ := ret[0].(*v1alpha1.ClusterSync)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClusterSync indicates an expected call of GetClusterSync.
+func (mr *MockClusterManagerMockRecorder) GetClusterSync(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterSync", reflect.TypeOf((*MockClusterManager)(nil).GetClusterSync), arg0, arg1)
+}
+
+// GetProvisionLogs mocks base method.
+func (m *MockClusterManager) GetProvisionLogs(arg0 context.Context, arg1 *api.OpenShiftClusterDocument) (*hive.ProvisionLogs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProvisionLogs", arg0, arg1)
+	ret0, _ := ret[0].(*hive.ProvisionLogs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProvisionLogs indicates an expected call of GetProvisionLogs.
+func (mr *MockClusterManagerMockRecorder) GetProvisionLogs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProvisionLogs", reflect.TypeOf((*MockClusterManager)(nil).GetProvisionLogs), arg0, arg1)
+}
+
 // Install mocks base method.
 func (m *MockClusterManager) Install(arg0 context.Context, arg1 *api.SubscriptionDocument, arg2 *api.OpenShiftClusterDocument, arg3 *api.OpenShiftVersion, arg4 map[string]runtime.Object) error {
 	m.ctrl.T.Helper()
@@ -131,6 +191,21 @@ func (mr *MockClusterManagerMockRecorder) IsClusterDeploymentReady(arg0, arg1 an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsClusterDeploymentReady", reflect.TypeOf((*MockClusterManager)(nil).IsClusterDeploymentReady), arg0, arg1)
 }
 
+// IsClusterDeprovisionComplete mocks base method.
+func (m *MockClusterManager) IsClusterDeprovisionComplete(arg0 context.Context, arg1 *api.OpenShiftClusterDocument) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsClusterDeprovisionComplete", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsClusterDeprovisionComplete indicates an expected call of IsClusterDeprovisionComplete.
+func (mr *MockClusterManagerMockRecorder) IsClusterDeprovisionComplete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsClusterDeprovisionComplete", reflect.TypeOf((*MockClusterManager)(nil).IsClusterDeprovisionComplete), arg0, arg1)
+}
+
 // IsClusterInstallationComplete mocks base method.
 func (m *MockClusterManager) IsClusterInstallationComplete(arg0 context.Context, arg1 *api.OpenShiftClusterDocument) (bool, error) {
 	m.ctrl.T.Helper()
@@ -146,6 +221,21 @@ func (mr *MockClusterManagerMockRecorder) IsClusterInstallationComplete(arg0, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsClusterInstallationComplete", reflect.TypeOf((*MockClusterManager)(nil).IsClusterInstallationComplete), arg0, arg1)
 }
 
+// ListClusterProvisions mocks base method.
+func (m *MockClusterManager) ListClusterProvisions(arg0 context.Context, arg1 *api.OpenShiftClusterDocument) ([]v1.ClusterProvision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClusterProvisions", arg0, arg1)
+	ret0, _ := ret[0].([]v1.ClusterProvision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClusterProvisions indicates an expected call of ListClusterProvisions.
+func (mr *MockClusterManagerMockRecorder) ListClusterProvisions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClusterProvisions", reflect.TypeOf((*MockClusterManager)(nil).ListClusterProvisions), arg0, arg1)
+}
+
 // ResetCorrelationData mocks base method.
 func (m *MockClusterManager) ResetCorrelationData(arg0 context.Context, arg1 *api.OpenShiftClusterDocument) error {
 	m.ctrl.T.Helper()
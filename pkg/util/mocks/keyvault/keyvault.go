@@ -178,6 +178,20 @@ func (mr *MockManagerMockRecorder) GetSecrets(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecrets", reflect.TypeOf((*MockManager)(nil).GetSecrets), arg0)
 }
 
+// RenewCertificate mocks base method.
+func (m *MockManager) RenewCertificate(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewCertificate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenewCertificate indicates an expected call of RenewCertificate.
+func (mr *MockManagerMockRecorder) RenewCertificate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewCertificate", reflect.TypeOf((*MockManager)(nil).RenewCertificate), arg0, arg1)
+}
+
 // SetCertificateIssuer mocks base method.
 func (m *MockManager) SetCertificateIssuer(arg0 context.Context, arg1 string, arg2 keyvault0.CertificateIssuerSetParameters) (keyvault0.IssuerBundle, error) {
 	m.ctrl.T.Helper()
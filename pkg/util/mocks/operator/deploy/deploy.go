@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
 // MockOperator is a mock of Operator interface.
@@ -125,6 +126,21 @@ func (mr *MockOperatorMockRecorder) RenewMDSDCertificate(arg0 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewMDSDCertificate", reflect.TypeOf((*MockOperator)(nil).RenewMDSDCertificate), arg0)
 }
 
+// Resources mocks base method.
+func (m *MockOperator) Resources(arg0 context.Context) ([]runtime.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resources", arg0)
+	ret0, _ := ret[0].([]runtime.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resources indicates an expected call of Resources.
+func (mr *MockOperatorMockRecorder) Resources(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resources", reflect.TypeOf((*MockOperator)(nil).Resources), arg0)
+}
+
 // Restart mocks base method.
 func (m *MockOperator) Restart(arg0 context.Context, arg1 []string) error {
 	m.ctrl.T.Helper()
@@ -180,3 +196,17 @@ func (mr *MockOperatorMockRecorder) Update(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOperator)(nil).Update), arg0)
 }
+
+// UpdateSecrets mocks base method.
+func (m *MockOperator) UpdateSecrets(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecrets", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSecrets indicates an expected call of UpdateSecrets.
+func (mr *MockOperatorMockRecorder) UpdateSecrets(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecrets", reflect.TypeOf((*MockOperator)(nil).UpdateSecrets), arg0)
+}
@@ -9,6 +9,7 @@ import (
 	"net"
 	"strings"
 	"syscall"
+	"time"
 
 	mgmtprivatedns "github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	"github.com/Azure/go-autorest/autorest/azure"
@@ -56,7 +57,15 @@ func Listen(network, address string, sz int) (net.Listener, error) {
 // Dial returns a dialled connection with its send and receive buffer sizes set.
 // If sz <= 0, we leave the default size.
 func Dial(network, address string, sz int) (net.Conn, error) {
+	return DialKeepAlive(network, address, sz, 0)
+}
+
+// DialKeepAlive is like Dial, but also sets the TCP keep-alive period of the
+// connection.  If keepAlive is 0, the default period is used; if it is
+// negative, keep-alives are disabled.
+func DialKeepAlive(network, address string, sz int, keepAlive time.Duration) (net.Conn, error) {
 	return (&net.Dialer{
+		KeepAlive: keepAlive,
 		Control: func(network, address string, rc syscall.RawConn) error {
 			if sz <= 0 {
 				return nil
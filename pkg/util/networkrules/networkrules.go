@@ -0,0 +1,124 @@
+package networkrules
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+)
+
+// Rule is a TCP network security rule required by ARO.  The RP infrastructure
+// NSGs and the cluster NSG are built from the rules below, and the operator's
+// egress checks from ClusterEgressURLs, so that they cannot drift apart.
+type Rule struct {
+	Name      string
+	Direction mgmtnetwork.SecurityRuleDirection
+	Access    mgmtnetwork.SecurityRuleAccess
+	Priority  int32
+
+	// Source is an address prefix or service tag
+	Source string
+	Port   string
+}
+
+var (
+	// RPInboundARM allows ARM to call the RP frontend
+	RPInboundARM = Rule{
+		Name:      "rp_in_arm",
+		Direction: mgmtnetwork.SecurityRuleDirectionInbound,
+		Access:    mgmtnetwork.SecurityRuleAccessAllow,
+		Priority:  120,
+		Source:    "AzureResourceManager",
+		Port:      "443",
+	}
+
+	// RPInboundGeneva allows Geneva Actions to call the RP admin API
+	RPInboundGeneva = Rule{
+		Name:      "rp_in_geneva",
+		Direction: mgmtnetwork.SecurityRuleDirectionInbound,
+		Access:    mgmtnetwork.SecurityRuleAccessAllow,
+		Priority:  130,
+		Source:    "GenevaActions",
+		Port:      "443",
+	}
+
+	// RPInboundPortal allows the configured source address prefixes to reach
+	// the SRE portal
+	RPInboundPortal = Rule{
+		Name:      "portal_in",
+		Direction: mgmtnetwork.SecurityRuleDirectionInbound,
+		Access:    mgmtnetwork.SecurityRuleAccessAllow,
+		Priority:  142,
+		Port:      "444",
+	}
+
+	// RPInboundSSH allows SSH to the RP VMs in development
+	RPInboundSSH = Rule{
+		Name:      "ssh_in",
+		Direction: mgmtnetwork.SecurityRuleDirectionInbound,
+		Access:    mgmtnetwork.SecurityRuleAccessAllow,
+		Priority:  125,
+		Source:    "*",
+		Port:      "22",
+	}
+
+	// RPDenyInboundGateway stops the gateway subnet from reaching the RP
+	RPDenyInboundGateway = Rule{
+		Name:      "deny_in_gateway",
+		Direction: mgmtnetwork.SecurityRuleDirectionInbound,
+		Access:    mgmtnetwork.SecurityRuleAccessDeny,
+		Priority:  145,
+		Source:    "10.0.8.0/24",
+		Port:      "*",
+	}
+
+	// ClusterInboundAPIServer allows clients to reach the API server of a
+	// cluster with a public API server
+	ClusterInboundAPIServer = Rule{
+		Name:      "apiserver_in",
+		Direction: mgmtnetwork.SecurityRuleDirectionInbound,
+		Access:    mgmtnetwork.SecurityRuleAccessAllow,
+		Priority:  120,
+		Source:    "*",
+		Port:      "6443",
+	}
+)
+
+// SecurityRulePropertiesFormat returns the properties of the NSG security rule
+// which implements r.
+func (r Rule) SecurityRulePropertiesFormat() *mgmtnetwork.SecurityRulePropertiesFormat {
+	return &mgmtnetwork.SecurityRulePropertiesFormat{
+		Protocol:                 mgmtnetwork.SecurityRuleProtocolTCP,
+		SourcePortRange:          to.StringPtr("*"),
+		DestinationPortRange:     to.StringPtr(r.Port),
+		SourceAddressPrefix:      to.StringPtr(r.Source),
+		DestinationAddressPrefix: to.StringPtr("*"),
+		Access:                   r.Access,
+		Priority:                 to.Int32Ptr(r.Priority),
+		Direction:                r.Direction,
+	}
+}
+
+// SecurityRule returns the NSG security rule which implements r.
+func (r Rule) SecurityRule() mgmtnetwork.SecurityRule {
+	return mgmtnetwork.SecurityRule{
+		SecurityRulePropertiesFormat: r.SecurityRulePropertiesFormat(),
+		Name:                         to.StringPtr(r.Name),
+	}
+}
+
+// ClusterEgressURLs returns the endpoints which cluster nodes must be able to
+// reach over HTTPS.
+func ClusterEgressURLs(environment *azureclient.AROEnvironment, acrDomain string) []string {
+	return []string{
+		fmt.Sprintf("https://%s/", acrDomain),
+		environment.ActiveDirectoryEndpoint,
+		environment.ResourceManagerEndpoint,
+		environment.GenevaMonitoringEndpoint,
+	}
+}
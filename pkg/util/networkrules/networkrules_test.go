@@ -0,0 +1,72 @@
+package networkrules
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"reflect"
+	"testing"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+)
+
+func TestSecurityRule(t *testing.T) {
+	want := mgmtnetwork.SecurityRule{
+		SecurityRulePropertiesFormat: &mgmtnetwork.SecurityRulePropertiesFormat{
+			Protocol:                 mgmtnetwork.SecurityRuleProtocolTCP,
+			SourcePortRange:          to.StringPtr("*"),
+			DestinationPortRange:     to.StringPtr("6443"),
+			SourceAddressPrefix:      to.StringPtr("*"),
+			DestinationAddressPrefix: to.StringPtr("*"),
+			Access:                   mgmtnetwork.SecurityRuleAccessAllow,
+			Priority:                 to.Int32Ptr(120),
+			Direction:                mgmtnetwork.SecurityRuleDirectionInbound,
+		},
+		Name: to.StringPtr("apiserver_in"),
+	}
+
+	got := ClusterInboundAPIServer.SecurityRule()
+	if !reflect.DeepEqual(got, want) {
+		t.Error(got)
+	}
+}
+
+func TestRulesAreUnique(t *testing.T) {
+	names := map[string]bool{}
+	priorities := map[int32]string{}
+
+	for _, r := range []Rule{
+		RPInboundARM,
+		RPInboundGeneva,
+		RPInboundPortal,
+		RPInboundSSH,
+		RPDenyInboundGateway,
+	} {
+		if names[r.Name] {
+			t.Errorf("duplicate rule name %s", r.Name)
+		}
+		names[r.Name] = true
+
+		if other, found := priorities[r.Priority]; found {
+			t.Errorf("rules %s and %s have the same priority %d", r.Name, other, r.Priority)
+		}
+		priorities[r.Priority] = r.Name
+	}
+}
+
+func TestClusterEgressURLs(t *testing.T) {
+	want := []string{
+		"https://arointsvc.azurecr.io/",
+		"https://login.microsoftonline.com/",
+		"https://management.azure.com/",
+		"https://gcs.prod.monitoring.core.windows.net/",
+	}
+
+	got := ClusterEgressURLs(&azureclient.PublicCloud, "arointsvc.azurecr.io")
+	if !reflect.DeepEqual(got, want) {
+		t.Error(got)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	securityv1 "github.com/openshift/api/security/v1"
 	cloudcredentialv1 "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveinternalv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
 	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
@@ -51,6 +52,7 @@ func init() {
 	utilruntime.Must(operatorv1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(cloudcredentialv1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(hivev1.AddToScheme(scheme.Scheme))
+	utilruntime.Must(hiveinternalv1alpha1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(imageregistryv1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(templatesv1.AddToScheme(scheme.Scheme))
 }
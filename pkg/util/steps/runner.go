@@ -14,6 +14,9 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/util/azureerrors"
@@ -41,6 +44,8 @@ func shortName(fullName string) string {
 	return fullName
 }
 
+const tracerName = "github.com/Azure/ARO-RP/pkg/util/steps"
+
 // Step is the interface for steps that Runner can execute.
 type Step interface {
 	run(ctx context.Context, log *logrus.Entry) error
@@ -48,6 +53,24 @@ type Step interface {
 	metricsName() string
 }
 
+// runWithSpan runs a step within an OpenTelemetry span
+func runWithSpan(ctx context.Context, log *logrus.Entry, step Step) error {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, step.metricsName())
+	defer span.End()
+
+	if correlationData := api.GetCorrelationDataFromCtx(ctx); correlationData != nil {
+		span.SetAttributes(attribute.String("aro.correlation_id", correlationData.CorrelationID))
+	}
+
+	err := step.run(ctx, log)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
+
 // Run executes the provided steps in order until one fails or all steps
 // are completed. Errors from failed steps are returned directly.
 // time cost for each step run will be recorded for metrics usage
@@ -57,7 +80,7 @@ func Run(ctx context.Context, log *logrus.Entry, pollInterval time.Duration, ste
 		log.Infof("running step %s", step)
 
 		startTime := time.Now()
-		err := step.run(ctx, log)
+		err := runWithSpan(ctx, log, step)
 
 		if err != nil {
 			if azureerrors.IsUnauthorizedClientError(err) ||
@@ -25,35 +25,76 @@ const (
 
 var GitCommit = "unknown"
 
+// Architecture is the architecture of an OpenShift release payload, as named
+// by the OpenShift update service
+type Architecture string
+
+const (
+	ArchitectureAMD64 Architecture = "amd64"
+	ArchitectureARM64 Architecture = "arm64"
+
+	// ArchitectureMulti release payloads contain the images of every
+	// architecture, and are needed by clusters with Arm64 workers
+	ArchitectureMulti Architecture = "multi"
+)
+
 type Stream struct {
 	Version  *Version `json:"version"`
 	PullSpec string   `json:"-"`
 }
 
-// Install stream data for production and INT has moved to RP-Config.
-// This default is left here ONLY for use by local development mode,
-// until we can come up with a better solution.
+// DefaultInstallStream only seeds the OpenShiftVersions database of a new
+// environment.  From then on, the OpenShiftVersion documents, managed via the
+// admin API, decide which versions can be installed in which regions.
 var DefaultInstallStream = Stream{
 	Version:  NewVersion(4, 13, 40),
 	PullSpec: "quay.io/openshift-release-dev/ocp-release@sha256:c1f69e6137bc9cda2c6da56bafbc7ea969900acb5e5c349b1ebb2103b10b424f",
 }
 
-// FluentbitImage contains the location of the Fluentbit container image
-func FluentbitImage(acrDomain string) string {
+// SidecarImages are the Geneva sidecar images, relative to the ACR domain.
+// Environments override them at runtime via liveconfig; the defaults below
+// bootstrap new environments.
+type SidecarImages struct {
+	Fluentbit string `json:"fluentbit,omitempty"`
+	Mdm       string `json:"mdm,omitempty"`
+	Mdsd      string `json:"mdsd,omitempty"`
+}
+
+var DefaultSidecarImages = SidecarImages{
 	// https://github.com/microsoft/azurelinux/releases
-	return acrDomain + "/fluentbit:1.9.10-cm20240628@sha256:dbf5304bd98cd51c72e4ae0ee8511d3bbcdbcb7e72e65e7d1495a50d7ac33b8d"
+	Fluentbit: "fluentbit:1.9.10-cm20240628@sha256:dbf5304bd98cd51c72e4ae0ee8511d3bbcdbcb7e72e65e7d1495a50d7ac33b8d",
+	// https://eng.ms/docs/products/geneva/collect/references/linuxcontainers
+	Mdm:  "distroless/genevamdm:2.2024.626.1539-d1a6e7-20240715t0935@sha256:372fbc981bbfdf2b9a9d0ffdca2c51ed389b291a3bcff0401e9afb0c01605823",
+	Mdsd: "distroless/genevamdsd:mariner_20240711.1@sha256:86d73d9df70aca71c54bbfbdf6402a2cc1ddd9cbbebf3d6a0319de5950b10382",
+}
+
+// WithDefaults returns the images with any unset image replaced by its default
+func (s SidecarImages) WithDefaults() SidecarImages {
+	if s.Fluentbit == "" {
+		s.Fluentbit = DefaultSidecarImages.Fluentbit
+	}
+	if s.Mdm == "" {
+		s.Mdm = DefaultSidecarImages.Mdm
+	}
+	if s.Mdsd == "" {
+		s.Mdsd = DefaultSidecarImages.Mdsd
+	}
+	return s
+}
+
+// FluentbitImage contains the location of the default Fluentbit container image
+func FluentbitImage(acrDomain string) string {
+	return acrDomain + "/" + DefaultSidecarImages.Fluentbit
 }
 
-// MdmImage contains the location of the MDM container image
-// https://eng.ms/docs/products/geneva/collect/references/linuxcontainers
+// MdmImage contains the location of the default MDM container image
 func MdmImage(acrDomain string) string {
-	return acrDomain + "/distroless/genevamdm:2.2024.626.1539-d1a6e7-20240715t0935@sha256:372fbc981bbfdf2b9a9d0ffdca2c51ed389b291a3bcff0401e9afb0c01605823"
+	return acrDomain + "/" + DefaultSidecarImages.Mdm
 }
 
-// MdsdImage contains the location of the MDSD container image
-// https://eng.ms/docs/products/geneva/collect/references/linuxcontainers
+// MdsdImage contains the location of the default MDSD container image
 func MdsdImage(acrDomain string) string {
-	return acrDomain + "/distroless/genevamdsd:mariner_20240711.1@sha256:86d73d9df70aca71c54bbfbdf6402a2cc1ddd9cbbebf3d6a0319de5950b10382"
+	return acrDomain + "/" + DefaultSidecarImages.Mdsd
 }
 
 // MUOImage contains the location of the Managed Upgrade Operator container image
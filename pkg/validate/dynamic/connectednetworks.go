@@ -0,0 +1,196 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	sdknetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+	"github.com/Azure/go-autorest/autorest/azure"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	apisubnet "github.com/Azure/ARO-RP/pkg/api/util/subnet"
+)
+
+var errMsgCIDROverlapsConnectedNetwork = "The provided CIDR '%s' overlaps with '%s' of %s."
+
+type connectedRange struct {
+	cidr   *net.IPNet
+	source string
+}
+
+// ValidateConnectedNetworks checks that the machine subnets and the
+// additional CIDRs (pod and service CIDRs) do not overlap with networks which
+// are reachable from the cluster vnet: the address spaces of peered vnets and
+// the prefixes of routes sending traffic to a virtual network gateway (VPN or
+// ExpressRoute).  Such overlaps are otherwise silent: the cluster installs, but
+// traffic to the remote network is misrouted.
+//
+// Prefixes learned over BGP are only visible through the effective routes of a
+// NIC, which does not exist before the cluster is installed, so they are not
+// checked here.
+func (dv *dynamic) ValidateConnectedNetworks(ctx context.Context, subnets []Subnet, additionalCIDRs ...string) error {
+	dv.log.Print("ValidateConnectedNetworks")
+
+	subnets = uniqueSubnetSlice(subnets)
+
+	var clusterCIDRs []string
+	var connected []connectedRange
+	seenVnets := map[string]bool{}
+	seenRouteTables := map[string]bool{}
+
+	for _, s := range subnets {
+		vnetID, _, err := apisubnet.Split(s.ID)
+		if err != nil {
+			return err
+		}
+
+		vnetr, err := azure.ParseResourceID(vnetID)
+		if err != nil {
+			return err
+		}
+
+		vnet, err := dv.virtualNetworks.Get(ctx, vnetr.ResourceGroup, vnetr.ResourceName, nil)
+		if err != nil {
+			return err
+		}
+
+		subnet, err := findSubnet(&vnet.VirtualNetwork, s.ID)
+		if err != nil {
+			return err
+		}
+
+		if subnet.Properties.AddressPrefix == nil {
+			for _, address := range subnet.Properties.AddressPrefixes {
+				clusterCIDRs = append(clusterCIDRs, *address)
+			}
+		} else {
+			clusterCIDRs = append(clusterCIDRs, *subnet.Properties.AddressPrefix)
+		}
+
+		if !seenVnets[strings.ToLower(vnetID)] {
+			seenVnets[strings.ToLower(vnetID)] = true
+			connected = append(connected, peeredRanges(&vnet.VirtualNetwork)...)
+		}
+
+		if subnet.Properties.RouteTable == nil || subnet.Properties.RouteTable.ID == nil ||
+			seenRouteTables[strings.ToLower(*subnet.Properties.RouteTable.ID)] {
+			continue
+		}
+		seenRouteTables[strings.ToLower(*subnet.Properties.RouteTable.ID)] = true
+
+		ranges, err := dv.gatewayRoutedRanges(ctx, *subnet.Properties.RouteTable.ID)
+		if err != nil {
+			return err
+		}
+		connected = append(connected, ranges...)
+	}
+
+	clusterCIDRs = append(clusterCIDRs, additionalCIDRs...)
+
+	for _, c := range clusterCIDRs {
+		_, clusterCIDR, err := net.ParseCIDR(c)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range connected {
+			if clusterCIDR.Contains(r.cidr.IP) || r.cidr.Contains(clusterCIDR.IP) {
+				return api.NewCloudError(
+					http.StatusBadRequest,
+					api.CloudErrorCodeInvalidLinkedVNet,
+					"",
+					errMsgCIDROverlapsConnectedNetwork,
+					c,
+					r.cidr,
+					r.source,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// peeredRanges returns the address spaces of the vnets connected to vnet
+func peeredRanges(vnet *sdknetwork.VirtualNetwork) []connectedRange {
+	if vnet.Properties == nil {
+		return nil
+	}
+
+	var ranges []connectedRange
+	for _, peering := range vnet.Properties.VirtualNetworkPeerings {
+		if peering == nil || peering.Properties == nil || peering.Properties.RemoteAddressSpace == nil ||
+			peering.Properties.PeeringState == nil || *peering.Properties.PeeringState != sdknetwork.VirtualNetworkPeeringStateConnected {
+			continue
+		}
+
+		source := "a peered virtual network"
+		if peering.Properties.RemoteVirtualNetwork != nil && peering.Properties.RemoteVirtualNetwork.ID != nil {
+			source = fmt.Sprintf("peered virtual network '%s'", *peering.Properties.RemoteVirtualNetwork.ID)
+		}
+
+		for _, prefix := range peering.Properties.RemoteAddressSpace.AddressPrefixes {
+			if prefix == nil {
+				continue
+			}
+
+			_, cidr, err := net.ParseCIDR(*prefix)
+			if err != nil {
+				continue
+			}
+
+			ranges = append(ranges, connectedRange{cidr: cidr, source: source})
+		}
+	}
+
+	return ranges
+}
+
+// gatewayRoutedRanges returns the prefixes of the routes of a route table which
+// send traffic to a virtual network gateway
+func (dv *dynamic) gatewayRoutedRanges(ctx context.Context, rtID string) ([]connectedRange, error) {
+	rtr, err := azure.ParseResourceID(rtID)
+	if err != nil {
+		return nil, err
+	}
+
+	rt, err := dv.routeTables.Get(ctx, rtr.ResourceGroup, rtr.ResourceName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if rt.Properties == nil {
+		return nil, nil
+	}
+
+	var ranges []connectedRange
+	for _, route := range rt.Properties.Routes {
+		if route == nil || route.Name == nil || route.Properties == nil ||
+			route.Properties.AddressPrefix == nil ||
+			route.Properties.NextHopType == nil || *route.Properties.NextHopType != sdknetwork.RouteNextHopTypeVirtualNetworkGateway {
+			continue
+		}
+
+		// ignore service tags and the default route
+		_, cidr, err := net.ParseCIDR(*route.Properties.AddressPrefix)
+		if err != nil {
+			continue
+		}
+		if ones, _ := cidr.Mask.Size(); ones == 0 {
+			continue
+		}
+
+		ranges = append(ranges, connectedRange{
+			cidr:   cidr,
+			source: fmt.Sprintf("route '%s' of route table '%s'", *route.Name, rtID),
+		})
+	}
+
+	return ranges, nil
+}
@@ -0,0 +1,168 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sdknetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+
+	mock_armnetwork "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/azuresdk/armnetwork"
+	"github.com/Azure/ARO-RP/pkg/util/pointerutils"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateConnectedNetworks(t *testing.T) {
+	ctx := context.Background()
+
+	hubVnetID := "/subscriptions/" + subscriptionID + "/resourceGroups/hub/providers/Microsoft.Network/virtualNetworks/hub"
+
+	peering := func(state sdknetwork.VirtualNetworkPeeringState, prefixes ...string) *sdknetwork.VirtualNetworkPeering {
+		p := &sdknetwork.VirtualNetworkPeering{
+			Properties: &sdknetwork.VirtualNetworkPeeringPropertiesFormat{
+				PeeringState: pointerutils.ToPtr(state),
+				RemoteVirtualNetwork: &sdknetwork.SubResource{
+					ID: &hubVnetID,
+				},
+				RemoteAddressSpace: &sdknetwork.AddressSpace{},
+			},
+		}
+		for _, prefix := range prefixes {
+			p.Properties.RemoteAddressSpace.AddressPrefixes = append(p.Properties.RemoteAddressSpace.AddressPrefixes, pointerutils.ToPtr(prefix))
+		}
+		return p
+	}
+
+	route := func(name, prefix string, nextHopType sdknetwork.RouteNextHopType) *sdknetwork.Route {
+		return &sdknetwork.Route{
+			Name: pointerutils.ToPtr(name),
+			Properties: &sdknetwork.RoutePropertiesFormat{
+				AddressPrefix: pointerutils.ToPtr(prefix),
+				NextHopType:   pointerutils.ToPtr(nextHopType),
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name          string
+		peerings      []*sdknetwork.VirtualNetworkPeering
+		routes        []*sdknetwork.Route
+		routeTableErr error
+		wantErr       string
+	}{
+		{
+			name: "pass: no connected networks",
+		},
+		{
+			name:     "pass: peered vnet does not overlap",
+			peerings: []*sdknetwork.VirtualNetworkPeering{peering(sdknetwork.VirtualNetworkPeeringStateConnected, "10.100.0.0/16")},
+			routes: []*sdknetwork.Route{
+				route("onprem", "192.168.0.0/16", sdknetwork.RouteNextHopTypeVirtualNetworkGateway),
+				route("default", "0.0.0.0/0", sdknetwork.RouteNextHopTypeVirtualNetworkGateway),
+			},
+		},
+		{
+			name:     "pass: disconnected peering is ignored",
+			peerings: []*sdknetwork.VirtualNetworkPeering{peering(sdknetwork.VirtualNetworkPeeringStateDisconnected, "10.128.0.0/16")},
+		},
+		{
+			name: "pass: routes not via a gateway are ignored",
+			routes: []*sdknetwork.Route{
+				route("firewall", "172.30.0.0/16", sdknetwork.RouteNextHopTypeVirtualAppliance),
+			},
+		},
+		{
+			name:     "fail: pod CIDR overlaps with peered vnet",
+			peerings: []*sdknetwork.VirtualNetworkPeering{peering(sdknetwork.VirtualNetworkPeeringStateConnected, "10.100.0.0/16", "10.129.0.0/16")},
+			wantErr:  "400: InvalidLinkedVNet: : The provided CIDR '10.128.0.0/14' overlaps with '10.129.0.0/16' of peered virtual network '" + hubVnetID + "'.",
+		},
+		{
+			name: "fail: service CIDR overlaps with on-premises route",
+			routes: []*sdknetwork.Route{
+				route("onprem", "172.16.0.0/12", sdknetwork.RouteNextHopTypeVirtualNetworkGateway),
+			},
+			wantErr: "400: InvalidLinkedVNet: : The provided CIDR '172.30.0.0/16' overlaps with '172.16.0.0/12' of route 'onprem' of route table '" + masterRtID + "'.",
+		},
+		{
+			name: "fail: master subnet overlaps with on-premises route",
+			routes: []*sdknetwork.Route{
+				route("onprem", "10.0.0.0/8", sdknetwork.RouteNextHopTypeVirtualNetworkGateway),
+			},
+			wantErr: "400: InvalidLinkedVNet: : The provided CIDR '10.0.0.0/24' overlaps with '10.0.0.0/8' of route 'onprem' of route table '" + masterRtID + "'.",
+		},
+		{
+			name:          "fail: route table get error",
+			routeTableErr: errors.New("failed to get route table"),
+			wantErr:       "failed to get route table",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			vnetClient := mock_armnetwork.NewMockVirtualNetworksClient(controller)
+			routeTablesClient := mock_armnetwork.NewMockRouteTablesClient(controller)
+
+			vnetClient.EXPECT().
+				Get(gomock.Any(), resourceGroupName, vnetName, nil).
+				AnyTimes().
+				Return(sdknetwork.VirtualNetworksClientGetResponse{
+					VirtualNetwork: sdknetwork.VirtualNetwork{
+						ID: &vnetID,
+						Properties: &sdknetwork.VirtualNetworkPropertiesFormat{
+							Subnets: []*sdknetwork.Subnet{
+								{
+									ID: &masterSubnet,
+									Properties: &sdknetwork.SubnetPropertiesFormat{
+										AddressPrefix: pointerutils.ToPtr("10.0.0.0/24"),
+										RouteTable: &sdknetwork.RouteTable{
+											ID: &masterRtID,
+										},
+									},
+								},
+								{
+									ID: &workerSubnet,
+									Properties: &sdknetwork.SubnetPropertiesFormat{
+										AddressPrefix: pointerutils.ToPtr("10.0.1.0/24"),
+										RouteTable: &sdknetwork.RouteTable{
+											ID: &masterRtID,
+										},
+									},
+								},
+							},
+							VirtualNetworkPeerings: tt.peerings,
+						},
+					},
+				}, nil)
+
+			// the route table is shared between the subnets and only fetched once
+			routeTablesClient.EXPECT().
+				Get(gomock.Any(), resourceGroupName, "masterRt", nil).
+				Return(sdknetwork.RouteTablesClientGetResponse{
+					RouteTable: sdknetwork.RouteTable{
+						ID: &masterRtID,
+						Properties: &sdknetwork.RouteTablePropertiesFormat{
+							Routes: tt.routes,
+						},
+					},
+				}, tt.routeTableErr)
+
+			dv := &dynamic{
+				log:             logrus.NewEntry(logrus.StandardLogger()),
+				virtualNetworks: vnetClient,
+				routeTables:     routeTablesClient,
+			}
+
+			err := dv.ValidateConnectedNetworks(ctx, []Subnet{
+				{ID: masterSubnet, Path: masterSubnetPath},
+				{ID: workerSubnet, Path: workerSubnetPath},
+			}, "10.128.0.0/14", "172.30.0.0/16")
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
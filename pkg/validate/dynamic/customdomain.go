@@ -0,0 +1,98 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/dns"
+)
+
+var (
+	errMsgCustomDomainNotDelegated  = "The provided custom domain '%s' is invalid: no name servers were found for it or any of its parent zones. Delegate the domain to a DNS zone before creating the cluster."
+	errMsgCustomDomainNotResolvable = "The provided custom domain '%s' is invalid: '%s' could not be resolved in zone '%s': %s. Make sure the name servers of the zone are reachable so that the api and *.apps records can be created after installation."
+)
+
+// ValidateCustomDomain checks, when a cluster is created with a custom domain,
+// that the domain is delegated and that the names of the records the customer
+// must create after installation can be looked up.  A name which does not yet
+// exist is fine: only lookups which fail outright are reported.
+func (dv *dynamic) ValidateCustomDomain(ctx context.Context, oc *api.OpenShiftCluster) error {
+	dv.log.Print("ValidateCustomDomain")
+
+	if oc.Properties.ProvisioningState != api.ProvisioningStateCreating {
+		return nil
+	}
+
+	domain := strings.TrimSuffix(strings.ToLower(oc.Properties.ClusterProfile.Domain), ".")
+	if !strings.ContainsRune(domain, '.') || dns.IsManagedDomain(domain) {
+		return nil
+	}
+
+	// walk up from the domain to find the zone it is delegated to, stopping
+	// short of the top level domain
+	var zone string
+	for d := domain; strings.ContainsRune(d, '.'); d = d[strings.IndexRune(d, '.')+1:] {
+		ns, err := dv.resolveNS(ctx, d)
+		if err == nil && len(ns) > 0 {
+			zone = d
+			break
+		}
+
+		var dnsErr *net.DNSError
+		if err != nil && !(errors.As(err, &dnsErr) && dnsErr.IsNotFound) {
+			// don't fail the create because of a transient resolver error
+			dv.log.Warnf("could not look up name servers for %s: %s", d, err)
+			return nil
+		}
+	}
+
+	if zone == "" {
+		return api.NewCloudError(
+			http.StatusBadRequest,
+			api.CloudErrorCodeInvalidParameter,
+			"properties.clusterProfile.domain",
+			errMsgCustomDomainNotDelegated,
+			domain,
+		)
+	}
+
+	for _, host := range []string{
+		"api." + domain,
+		"console-openshift-console.apps." + domain,
+	} {
+		_, err := dv.resolveIP(ctx, host)
+
+		var dnsErr *net.DNSError
+		if err == nil || (errors.As(err, &dnsErr) && (dnsErr.IsNotFound || dnsErr.IsTimeout)) {
+			continue
+		}
+
+		return api.NewCloudError(
+			http.StatusBadRequest,
+			api.CloudErrorCodeInvalidParameter,
+			"properties.clusterProfile.domain",
+			errMsgCustomDomainNotResolvable,
+			domain,
+			host,
+			zone,
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (dv *dynamic) resolveNS(ctx context.Context, name string) ([]*net.NS, error) {
+	if dv.lookupNS != nil {
+		return dv.lookupNS(ctx, name)
+	}
+
+	return net.DefaultResolver.LookupNS(ctx, name)
+}
@@ -0,0 +1,125 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateCustomDomain(t *testing.T) {
+	ctx := context.Background()
+
+	notFound := func(name string) error {
+		return &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+
+	for _, tt := range []struct {
+		name              string
+		provisioningState api.ProvisioningState
+		domain            string
+		zones             map[string]bool
+		lookupIPErr       map[string]error
+		nsErr             error
+		wantErr           string
+	}{
+		{
+			name:              "pass: not creating",
+			provisioningState: api.ProvisioningStateUpdating,
+			domain:            "cluster.contoso.com",
+		},
+		{
+			name:              "pass: managed domain label",
+			provisioningState: api.ProvisioningStateCreating,
+			domain:            "cluster",
+		},
+		{
+			name:              "pass: managed domain",
+			provisioningState: api.ProvisioningStateCreating,
+			domain:            "cluster.location.aroapp.io",
+		},
+		{
+			name:              "pass: domain delegated to a parent zone",
+			provisioningState: api.ProvisioningStateCreating,
+			domain:            "cluster.contoso.com",
+			zones:             map[string]bool{"contoso.com": true},
+		},
+		{
+			name:              "pass: domain delegated to its own zone",
+			provisioningState: api.ProvisioningStateCreating,
+			domain:            "Cluster.Contoso.com.",
+			zones:             map[string]bool{"cluster.contoso.com": true},
+		},
+		{
+			name:              "pass: transient name server lookup error",
+			provisioningState: api.ProvisioningStateCreating,
+			domain:            "cluster.contoso.com",
+			nsErr:             &net.DNSError{Err: "i/o timeout", IsTimeout: true},
+		},
+		{
+			name:              "pass: record lookup times out",
+			provisioningState: api.ProvisioningStateCreating,
+			domain:            "cluster.contoso.com",
+			zones:             map[string]bool{"contoso.com": true},
+			lookupIPErr: map[string]error{
+				"api.cluster.contoso.com": &net.DNSError{Err: "i/o timeout", IsTimeout: true},
+			},
+		},
+		{
+			name:              "fail: domain not delegated",
+			provisioningState: api.ProvisioningStateCreating,
+			domain:            "cluster.contoso.com",
+			wantErr:           "400: InvalidParameter: properties.clusterProfile.domain: The provided custom domain 'cluster.contoso.com' is invalid: no name servers were found for it or any of its parent zones. Delegate the domain to a DNS zone before creating the cluster.",
+		},
+		{
+			name:              "fail: apps records can't be resolved",
+			provisioningState: api.ProvisioningStateCreating,
+			domain:            "cluster.contoso.com",
+			zones:             map[string]bool{"contoso.com": true},
+			lookupIPErr: map[string]error{
+				"console-openshift-console.apps.cluster.contoso.com": &net.DNSError{Err: "server misbehaving", Name: "console-openshift-console.apps.cluster.contoso.com", IsTemporary: true},
+			},
+			wantErr: "400: InvalidParameter: properties.clusterProfile.domain: The provided custom domain 'cluster.contoso.com' is invalid: 'console-openshift-console.apps.cluster.contoso.com' could not be resolved in zone 'contoso.com': lookup console-openshift-console.apps.cluster.contoso.com: server misbehaving. Make sure the name servers of the zone are reachable so that the api and *.apps records can be created after installation.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dv := &dynamic{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				lookupNS: func(_ context.Context, name string) ([]*net.NS, error) {
+					if tt.nsErr != nil {
+						return nil, tt.nsErr
+					}
+					if tt.zones[name] {
+						return []*net.NS{{Host: "ns1.contoso.com."}}, nil
+					}
+					return nil, notFound(name)
+				},
+				lookupIP: func(_ context.Context, host string) ([]net.IP, error) {
+					if err, ok := tt.lookupIPErr[host]; ok {
+						return nil, err
+					}
+					return nil, notFound(host)
+				},
+			}
+
+			oc := &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState: tt.provisioningState,
+					ClusterProfile: api.ClusterProfile{
+						Domain: tt.domain,
+					},
+				},
+			}
+
+			err := dv.ValidateCustomDomain(ctx, oc)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
@@ -5,10 +5,16 @@ package dynamic
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	sdkkeyvault "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/keyvault/armkeyvault"
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -57,7 +63,12 @@ func (dv *dynamic) ValidateDiskEncryptionSets(ctx context.Context, oc *api.OpenS
 			return err
 		}
 
-		err = dv.validateDiskEncryptionSetLocation(ctx, &r, oc.Location, paths[i])
+		des, err := dv.validateDiskEncryptionSetLocation(ctx, &r, oc.Location, paths[i])
+		if err != nil {
+			return err
+		}
+
+		err = dv.validateDiskEncryptionSetKey(ctx, des, &r, paths[i])
 		if err != nil {
 			return err
 		}
@@ -92,20 +103,104 @@ func (dv *dynamic) validateDiskEncryptionSetPermissions(ctx context.Context, des
 	return err
 }
 
-func (dv *dynamic) validateDiskEncryptionSetLocation(ctx context.Context, desr *azure.Resource, location, path string) error {
+func (dv *dynamic) validateDiskEncryptionSetLocation(ctx context.Context, desr *azure.Resource, location, path string) (*mgmtcompute.DiskEncryptionSet, error) {
 	dv.log.Print("validateDiskEncryptionSetLocation")
 
 	des, err := dv.diskEncryptionSets.Get(ctx, desr.ResourceGroup, desr.ResourceName)
 	if err != nil {
 		if detailedErr, ok := err.(autorest.DetailedError); ok &&
 			detailedErr.StatusCode == http.StatusNotFound {
-			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedDiskEncryptionSet, path, "The disk encryption set '%s' could not be found.", desr.String())
+			return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedDiskEncryptionSet, path, "The disk encryption set '%s' could not be found.", desr.String())
 		}
-		return err
+		return nil, err
 	}
 
 	if !strings.EqualFold(*des.Location, location) {
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedDiskEncryptionSet, "", "The disk encryption set location '%s' must match the cluster location '%s'.", *des.Location, location)
+		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedDiskEncryptionSet, "", "The disk encryption set location '%s' must match the cluster location '%s'.", *des.Location, location)
+	}
+
+	return &des, nil
+}
+
+// validateDiskEncryptionSetKey checks that the key vault key backing the disk
+// encryption set is usable: otherwise the first disk creation fails half way
+// through the install.  The key is read through ARM, so if we are not allowed
+// to read it we skip the check rather than fail.
+func (dv *dynamic) validateDiskEncryptionSetKey(ctx context.Context, des *mgmtcompute.DiskEncryptionSet, desr *azure.Resource, path string) error {
+	dv.log.Print("validateDiskEncryptionSetKey")
+
+	if des.EncryptionSetProperties == nil || des.ActiveKey == nil ||
+		des.ActiveKey.KeyURL == nil || des.ActiveKey.SourceVault == nil || des.ActiveKey.SourceVault.ID == nil {
+		return nil
+	}
+
+	vaultr, err := azure.ParseResourceID(*des.ActiveKey.SourceVault.ID)
+	if err != nil {
+		return err
+	}
+
+	// our keys client is scoped to the cluster subscription
+	if !strings.EqualFold(vaultr.SubscriptionID, desr.SubscriptionID) {
+		dv.log.Infof("skipping validation of key %s in another subscription", *des.ActiveKey.KeyURL)
+		return nil
+	}
+
+	// the key URL is https://<vault>.vault.azure.net/keys/<name>[/<version>]
+	u, err := url.Parse(*des.ActiveKey.KeyURL)
+	if err != nil {
+		return err
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 2 || segments[0] != "keys" {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedDiskEncryptionSet, path, "The disk encryption set '%s' has an invalid key URL '%s'.", desr.String(), *des.ActiveKey.KeyURL)
+	}
+
+	var attributes *sdkkeyvault.KeyAttributes
+	if len(segments) > 2 && segments[2] != "" {
+		var key sdkkeyvault.KeysClientGetVersionResponse
+		key, err = dv.keys.GetVersion(ctx, vaultr.ResourceGroup, vaultr.ResourceName, segments[1], segments[2], nil)
+		if key.Properties != nil {
+			attributes = key.Properties.Attributes
+		}
+	} else {
+		var key sdkkeyvault.KeysClientGetResponse
+		key, err = dv.keys.Get(ctx, vaultr.ResourceGroup, vaultr.ResourceName, segments[1], nil)
+		if key.Properties != nil {
+			attributes = key.Properties.Attributes
+		}
+	}
+
+	var responseErr *azcore.ResponseError
+	if errors.As(err, &responseErr) {
+		switch responseErr.StatusCode {
+		case http.StatusNotFound:
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedDiskEncryptionSet, path, "The key '%s' of disk encryption set '%s' could not be found.", *des.ActiveKey.KeyURL, desr.String())
+		case http.StatusForbidden, http.StatusUnauthorized:
+			dv.log.Warnf("skipping validation of key %s: %s", *des.ActiveKey.KeyURL, err)
+			return nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if attributes == nil {
+		return nil
+	}
+
+	now := time.Now()
+
+	if attributes.Enabled != nil && !*attributes.Enabled {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedDiskEncryptionSet, path, "The key '%s' of disk encryption set '%s' is disabled.", *des.ActiveKey.KeyURL, desr.String())
+	}
+
+	if attributes.Expires != nil && now.After(time.Unix(*attributes.Expires, 0)) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedDiskEncryptionSet, path, "The key '%s' of disk encryption set '%s' expired on %s.", *des.ActiveKey.KeyURL, desr.String(), time.Unix(*attributes.Expires, 0).UTC().Format(time.RFC3339))
+	}
+
+	if attributes.NotBefore != nil && now.Before(time.Unix(*attributes.NotBefore, 0)) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedDiskEncryptionSet, path, "The key '%s' of disk encryption set '%s' is not valid before %s.", *des.ActiveKey.KeyURL, desr.String(), time.Unix(*attributes.NotBefore, 0).UTC().Format(time.RFC3339))
 	}
 
 	return nil
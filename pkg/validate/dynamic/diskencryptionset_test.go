@@ -9,7 +9,10 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	sdkkeyvault "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/keyvault/armkeyvault"
 	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
 	"github.com/Azure/checkaccess-v2-go-sdk/client"
 	"github.com/Azure/go-autorest/autorest"
@@ -20,6 +23,7 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	mock_armkeyvault "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/azuresdk/armkeyvault"
 	mock_azcore "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/azuresdk/azcore"
 	mock_compute "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/compute"
 	mock_checkaccess "github.com/Azure/ARO-RP/pkg/util/mocks/checkaccess"
@@ -463,6 +467,154 @@ func TestValidateDiskEncryptionSets(t *testing.T) {
 	}
 }
 
+func TestValidateDiskEncryptionSetKey(t *testing.T) {
+	ctx := context.Background()
+
+	desID := "/subscriptions/0000000-0000-0000-0000-000000000000/resourceGroups/fakeRG/providers/Microsoft.Compute/diskEncryptionSets/fakeDES1"
+	vaultID := "/subscriptions/0000000-0000-0000-0000-000000000000/resourceGroups/vaultRG/providers/Microsoft.KeyVault/vaults/fakeVault"
+	otherVaultID := "/subscriptions/1111111-1111-1111-1111-111111111111/resourceGroups/vaultRG/providers/Microsoft.KeyVault/vaults/fakeVault"
+	versionedKeyURL := "https://fakevault.vault.azure.net/keys/fakeKey/0123456789"
+	versionlessKeyURL := "https://fakevault.vault.azure.net/keys/fakeKey"
+	path := "properties.masterProfile.diskEncryptionSetId"
+
+	desr, err := azure.ParseResourceID(desID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	des := func(vaultID, keyURL string) *mgmtcompute.DiskEncryptionSet {
+		return &mgmtcompute.DiskEncryptionSet{
+			EncryptionSetProperties: &mgmtcompute.EncryptionSetProperties{
+				ActiveKey: &mgmtcompute.KeyVaultAndKeyReference{
+					SourceVault: &mgmtcompute.SourceVault{ID: to.StringPtr(vaultID)},
+					KeyURL:      to.StringPtr(keyURL),
+				},
+			},
+		}
+	}
+
+	attributes := func(enabled bool, expires, notBefore time.Time) *sdkkeyvault.KeyProperties {
+		a := &sdkkeyvault.KeyAttributes{Enabled: to.BoolPtr(enabled)}
+		if !expires.IsZero() {
+			a.Expires = to.Int64Ptr(expires.Unix())
+		}
+		if !notBefore.IsZero() {
+			a.NotBefore = to.Int64Ptr(notBefore.Unix())
+		}
+		return &sdkkeyvault.KeyProperties{Attributes: a}
+	}
+
+	expired := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	future := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	for _, tt := range []struct {
+		name    string
+		des     *mgmtcompute.DiskEncryptionSet
+		mocks   func(*mock_armkeyvault.MockKeysClient)
+		wantErr string
+	}{
+		{
+			name: "pass: no active key",
+			des:  &mgmtcompute.DiskEncryptionSet{EncryptionSetProperties: &mgmtcompute.EncryptionSetProperties{}},
+		},
+		{
+			name: "pass: key vault in another subscription",
+			des:  des(otherVaultID, versionedKeyURL),
+		},
+		{
+			name: "pass: versioned key enabled and valid",
+			des:  des(vaultID, versionedKeyURL),
+			mocks: func(keys *mock_armkeyvault.MockKeysClient) {
+				keys.EXPECT().
+					GetVersion(gomock.Any(), "vaultRG", "fakeVault", "fakeKey", "0123456789", nil).
+					Return(sdkkeyvault.KeysClientGetVersionResponse{Key: sdkkeyvault.Key{Properties: attributes(true, future, time.Time{})}}, nil)
+			},
+		},
+		{
+			name: "pass: no permission to read the key",
+			des:  des(vaultID, versionedKeyURL),
+			mocks: func(keys *mock_armkeyvault.MockKeysClient) {
+				keys.EXPECT().
+					GetVersion(gomock.Any(), "vaultRG", "fakeVault", "fakeKey", "0123456789", nil).
+					Return(sdkkeyvault.KeysClientGetVersionResponse{}, &azcore.ResponseError{StatusCode: http.StatusForbidden})
+			},
+		},
+		{
+			name: "fail: versionless key disabled",
+			des:  des(vaultID, versionlessKeyURL),
+			mocks: func(keys *mock_armkeyvault.MockKeysClient) {
+				keys.EXPECT().
+					Get(gomock.Any(), "vaultRG", "fakeVault", "fakeKey", nil).
+					Return(sdkkeyvault.KeysClientGetResponse{Key: sdkkeyvault.Key{Properties: attributes(false, time.Time{}, time.Time{})}}, nil)
+			},
+			wantErr: "400: InvalidLinkedDiskEncryptionSet: " + path + ": The key '" + versionlessKeyURL + "' of disk encryption set '" + desID + "' is disabled.",
+		},
+		{
+			name: "fail: key expired",
+			des:  des(vaultID, versionedKeyURL),
+			mocks: func(keys *mock_armkeyvault.MockKeysClient) {
+				keys.EXPECT().
+					GetVersion(gomock.Any(), "vaultRG", "fakeVault", "fakeKey", "0123456789", nil).
+					Return(sdkkeyvault.KeysClientGetVersionResponse{Key: sdkkeyvault.Key{Properties: attributes(true, expired, time.Time{})}}, nil)
+			},
+			wantErr: "400: InvalidLinkedDiskEncryptionSet: " + path + ": The key '" + versionedKeyURL + "' of disk encryption set '" + desID + "' expired on 2020-01-01T00:00:00Z.",
+		},
+		{
+			name: "fail: key not yet valid",
+			des:  des(vaultID, versionedKeyURL),
+			mocks: func(keys *mock_armkeyvault.MockKeysClient) {
+				keys.EXPECT().
+					GetVersion(gomock.Any(), "vaultRG", "fakeVault", "fakeKey", "0123456789", nil).
+					Return(sdkkeyvault.KeysClientGetVersionResponse{Key: sdkkeyvault.Key{Properties: attributes(true, time.Time{}, future)}}, nil)
+			},
+			wantErr: "400: InvalidLinkedDiskEncryptionSet: " + path + ": The key '" + versionedKeyURL + "' of disk encryption set '" + desID + "' is not valid before " + future.UTC().Format(time.RFC3339) + ".",
+		},
+		{
+			name: "fail: key not found",
+			des:  des(vaultID, versionedKeyURL),
+			mocks: func(keys *mock_armkeyvault.MockKeysClient) {
+				keys.EXPECT().
+					GetVersion(gomock.Any(), "vaultRG", "fakeVault", "fakeKey", "0123456789", nil).
+					Return(sdkkeyvault.KeysClientGetVersionResponse{}, &azcore.ResponseError{StatusCode: http.StatusNotFound})
+			},
+			wantErr: "400: InvalidLinkedDiskEncryptionSet: " + path + ": The key '" + versionedKeyURL + "' of disk encryption set '" + desID + "' could not be found.",
+		},
+		{
+			name:    "fail: invalid key URL",
+			des:     des(vaultID, "https://fakevault.vault.azure.net/secrets/fakeKey"),
+			wantErr: "400: InvalidLinkedDiskEncryptionSet: " + path + ": The disk encryption set '" + desID + "' has an invalid key URL 'https://fakevault.vault.azure.net/secrets/fakeKey'.",
+		},
+		{
+			name: "fail: other error",
+			des:  des(vaultID, versionedKeyURL),
+			mocks: func(keys *mock_armkeyvault.MockKeysClient) {
+				keys.EXPECT().
+					GetVersion(gomock.Any(), "vaultRG", "fakeVault", "fakeKey", "0123456789", nil).
+					Return(sdkkeyvault.KeysClientGetVersionResponse{}, errors.New("fakeerr"))
+			},
+			wantErr: "fakeerr",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			keysClient := mock_armkeyvault.NewMockKeysClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(keysClient)
+			}
+
+			dv := &dynamic{
+				log:  logrus.NewEntry(logrus.StandardLogger()),
+				keys: keysClient,
+			}
+
+			err := dv.validateDiskEncryptionSetKey(ctx, tt.des, &desr, path)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
 var (
 	invalidDiskEncryptionAuthorizationDecisionsReadNotAllowed = &client.AuthorizationDecisionResponse{
 		Value: []client.AuthorizationDecision{
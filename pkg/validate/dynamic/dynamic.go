@@ -26,10 +26,12 @@ import (
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/armauthorization"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/armkeyvault"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/armmsi"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/azuresdk/armnetwork"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/policyinsights"
 	"github.com/Azure/ARO-RP/pkg/util/stringutils"
 	"github.com/Azure/ARO-RP/pkg/util/token"
 )
@@ -76,11 +78,17 @@ type Dynamic interface {
 	ServicePrincipalValidator
 
 	ValidateVnet(ctx context.Context, location string, subnets []Subnet, additionalCIDRs ...string) error
+	ValidateConnectedNetworks(ctx context.Context, subnets []Subnet, additionalCIDRs ...string) error
 	ValidateSubnets(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error
 	ValidateDiskEncryptionSets(ctx context.Context, oc *api.OpenShiftCluster) error
 	ValidateEncryptionAtHost(ctx context.Context, oc *api.OpenShiftCluster) error
+	ValidateEgress(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error
+	ValidateCustomDomain(ctx context.Context, oc *api.OpenShiftCluster) error
+	ValidateDenyAssignmentsAndPolicies(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error
+	ValidateSharedSubnets(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error
 	ValidateLoadBalancerProfile(ctx context.Context, oc *api.OpenShiftCluster) error
 	ValidatePreConfiguredNSGs(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error
+	ValidateMasterNetworkInterfaces(ctx context.Context, oc *api.OpenShiftCluster) error
 	ValidateClusterUserAssignedIdentity(ctx context.Context, platformIdentities map[string]api.PlatformWorkloadIdentity, roleDefinitions armauthorization.RoleDefinitionsClient) error
 	ValidatePlatformWorkloadIdentityProfile(
 		ctx context.Context,
@@ -103,11 +111,21 @@ type dynamic struct {
 	platformIdentitiesActionsMap map[string][]string
 
 	virtualNetworks                       virtualNetworksGetClient
+	routeTables                           armnetwork.RouteTablesClient
+	securityGroups                        armnetwork.SecurityGroupsClient
+	interfaces                            armnetwork.InterfacesClient
 	diskEncryptionSets                    compute.DiskEncryptionSetsClient
+	keys                                  armkeyvault.KeysClient
+	denyAssignments                       armauthorization.DenyAssignmentsClient
+	policyRestrictions                    policyinsights.PolicyRestrictionsClient
 	resourceSkusClient                    compute.ResourceSkusClient
 	spNetworkUsage                        armnetwork.UsagesClient
 	loadBalancerBackendAddressPoolsClient network.LoadBalancerBackendAddressPoolsClient
 	pdpClient                             client.RemotePDPClient
+
+	// lookupIP and lookupNS are overridden in tests
+	lookupIP func(ctx context.Context, host string) ([]net.IP, error)
+	lookupNS func(ctx context.Context, name string) ([]*net.NS, error)
 }
 
 type AuthorizerType string
@@ -142,6 +160,31 @@ func NewValidator(
 		return nil, err
 	}
 
+	routeTablesClient, err := armnetwork.NewRouteTablesClient(subscriptionID, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	securityGroupsClient, err := armnetwork.NewSecurityGroupsClient(subscriptionID, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	interfacesClient, err := armnetwork.NewInterfacesClient(subscriptionID, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	keysClient, err := armkeyvault.NewKeysClient(subscriptionID, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	denyAssignmentsClient, err := armauthorization.NewDenyAssignmentsClient(subscriptionID, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
 	return &dynamic{
 		log:                        log,
 		appID:                      appID,
@@ -152,7 +195,13 @@ func NewValidator(
 
 		spNetworkUsage:                        usagesClient,
 		virtualNetworks:                       newVirtualNetworksCache(virtualNetworksClient),
+		routeTables:                           routeTablesClient,
+		securityGroups:                        securityGroupsClient,
+		interfaces:                            interfacesClient,
 		diskEncryptionSets:                    compute.NewDiskEncryptionSetsClient(azEnv, subscriptionID, authorizer),
+		keys:                                  keysClient,
+		denyAssignments:                       denyAssignmentsClient,
+		policyRestrictions:                    policyinsights.NewPolicyRestrictionsClient(azEnv, subscriptionID, authorizer),
 		resourceSkusClient:                    compute.NewResourceSkusClient(azEnv, subscriptionID, authorizer),
 		pdpClient:                             pdpClient,
 		loadBalancerBackendAddressPoolsClient: network.NewLoadBalancerBackendAddressPoolsClient(azEnv, subscriptionID, authorizer),
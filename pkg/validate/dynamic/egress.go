@@ -0,0 +1,201 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	sdknetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+	"github.com/Azure/go-autorest/autorest/azure"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	apisubnet "github.com/Azure/ARO-RP/pkg/api/util/subnet"
+)
+
+var errMsgEgressBlocked = "The route table '%s' of the provided subnet '%s' drops traffic to destinations required by the cluster: %s."
+
+type egressDestination struct {
+	host string
+	ip   net.IP
+}
+
+// ValidateEgress checks, when the cluster uses user defined routing, that the
+// route tables attached to the cluster subnets do not drop traffic to the
+// endpoints the cluster needs during install (ARM, ACR and the gateway).
+// Routes towards virtual appliances cannot be assessed from here, so only
+// routes with a next hop of None are reported as blocking.
+func (dv *dynamic) ValidateEgress(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error {
+	dv.log.Print("ValidateEgress")
+
+	if oc.Properties.NetworkProfile.OutboundType != api.OutboundTypeUserDefinedRouting {
+		return nil
+	}
+
+	// with the gateway enabled, egress to the required endpoints goes via the
+	// gateway private endpoint rather than the subnet routes
+	if oc.Properties.FeatureProfile.GatewayEnabled {
+		return nil
+	}
+
+	destinations := dv.egressDestinations(ctx)
+	if len(destinations) == 0 {
+		return nil
+	}
+
+	for _, s := range uniqueSubnetSlice(subnets) {
+		err := dv.validateSubnetEgress(ctx, s, destinations)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// egressDestinations resolves the hosts the cluster must reach. Hosts which
+// cannot be resolved are logged and skipped: DNS is validated elsewhere.
+func (dv *dynamic) egressDestinations(ctx context.Context) []egressDestination {
+	hosts := []string{}
+	if u, err := url.Parse(dv.azEnv.ResourceManagerEndpoint); err == nil && u.Hostname() != "" {
+		hosts = append(hosts, u.Hostname())
+	}
+	hosts = append(hosts, dv.env.ACRDomain())
+	hosts = append(hosts, dv.env.GatewayDomains()...)
+
+	seen := map[string]bool{}
+	destinations := []egressDestination{}
+	for _, host := range hosts {
+		host = strings.ToLower(host)
+		if host == "" || seen[host] {
+			continue
+		}
+		seen[host] = true
+
+		ips, err := dv.resolveIP(ctx, host)
+		if err != nil {
+			dv.log.Warnf("could not resolve %s: %s", host, err)
+			continue
+		}
+
+		for _, ip := range ips {
+			destinations = append(destinations, egressDestination{host: host, ip: ip})
+		}
+	}
+
+	return destinations
+}
+
+func (dv *dynamic) validateSubnetEgress(ctx context.Context, s Subnet, destinations []egressDestination) error {
+	vnetID, _, err := apisubnet.Split(s.ID)
+	if err != nil {
+		return err
+	}
+
+	vnetr, err := azure.ParseResourceID(vnetID)
+	if err != nil {
+		return err
+	}
+
+	vnet, err := dv.virtualNetworks.Get(ctx, vnetr.ResourceGroup, vnetr.ResourceName, nil)
+	if err != nil {
+		return err
+	}
+
+	rtID, err := getRouteTableID(&vnet.VirtualNetwork, s.ID)
+	if err != nil || rtID == "" { // error or no route table: system routes apply
+		return err
+	}
+
+	rtr, err := azure.ParseResourceID(rtID)
+	if err != nil {
+		return err
+	}
+
+	rt, err := dv.routeTables.Get(ctx, rtr.ResourceGroup, rtr.ResourceName, nil)
+	if err != nil {
+		return err
+	}
+
+	var routes []*sdknetwork.Route
+	if rt.Properties != nil {
+		routes = rt.Properties.Routes
+	}
+
+	blocked := []string{}
+	blockedHosts := map[string]bool{}
+	for _, d := range destinations {
+		if blockedHosts[d.host] {
+			continue
+		}
+
+		route := effectiveRoute(routes, d.ip)
+		if route == nil {
+			continue
+		}
+
+		switch *route.Properties.NextHopType {
+		case sdknetwork.RouteNextHopTypeNone:
+			blockedHosts[d.host] = true
+			blocked = append(blocked, fmt.Sprintf("'%s' (%s) via route '%s' (%s)", d.host, d.ip, *route.Name, *route.Properties.AddressPrefix))
+		case sdknetwork.RouteNextHopTypeVirtualAppliance, sdknetwork.RouteNextHopTypeVirtualNetworkGateway:
+			dv.log.Infof("egress to %s (%s) from subnet %s is routed via %s and cannot be assessed", d.host, d.ip, s.ID, *route.Properties.NextHopType)
+		}
+	}
+
+	if len(blocked) > 0 {
+		return api.NewCloudError(
+			http.StatusBadRequest,
+			api.CloudErrorCodeInvalidLinkedRouteTable,
+			s.Path,
+			errMsgEgressBlocked,
+			rtID,
+			s.ID,
+			strings.Join(blocked, ", "),
+		)
+	}
+
+	return nil
+}
+
+// effectiveRoute returns the user defined route with the longest prefix
+// matching ip, or nil if the system routes apply. Routes using service tags as
+// address prefix are ignored.
+func effectiveRoute(routes []*sdknetwork.Route, ip net.IP) *sdknetwork.Route {
+	var match *sdknetwork.Route
+	matchOnes := -1
+
+	for _, route := range routes {
+		if route == nil || route.Name == nil || route.Properties == nil ||
+			route.Properties.AddressPrefix == nil ||
+			route.Properties.NextHopType == nil {
+			continue
+		}
+
+		_, ipnet, err := net.ParseCIDR(*route.Properties.AddressPrefix)
+		if err != nil {
+			continue
+		}
+
+		ones, _ := ipnet.Mask.Size()
+		if ipnet.Contains(ip) && ones > matchOnes {
+			match = route
+			matchOnes = ones
+		}
+	}
+
+	return match
+}
+
+func (dv *dynamic) resolveIP(ctx context.Context, host string) ([]net.IP, error) {
+	if dv.lookupIP != nil {
+		return dv.lookupIP(ctx, host)
+	}
+
+	return net.DefaultResolver.LookupIP(ctx, "ip4", host)
+}
@@ -0,0 +1,206 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	sdknetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	mock_armnetwork "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/azuresdk/armnetwork"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	"github.com/Azure/ARO-RP/pkg/util/pointerutils"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateEgress(t *testing.T) {
+	ctx := context.Background()
+
+	resolved := map[string][]net.IP{
+		"management.azure.com": {net.ParseIP("20.0.0.1")},
+		"arosvc.azurecr.io":    {net.ParseIP("20.0.1.1")},
+		"gateway.example.com":  {net.ParseIP("10.1.0.4")},
+	}
+
+	route := func(name, prefix string, nextHopType sdknetwork.RouteNextHopType) *sdknetwork.Route {
+		return &sdknetwork.Route{
+			Name: pointerutils.ToPtr(name),
+			Properties: &sdknetwork.RoutePropertiesFormat{
+				AddressPrefix: pointerutils.ToPtr(prefix),
+				NextHopType:   pointerutils.ToPtr(nextHopType),
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name           string
+		outboundType   api.OutboundType
+		gatewayEnabled bool
+		lookupIP       func(context.Context, string) ([]net.IP, error)
+		noRouteTable   bool
+		routes         []*sdknetwork.Route
+		routeTableErr  error
+		wantErr        string
+	}{
+		{
+			name:         "pass: outbound type is Loadbalancer",
+			outboundType: api.OutboundTypeLoadbalancer,
+		},
+		{
+			name:           "pass: gateway is enabled",
+			outboundType:   api.OutboundTypeUserDefinedRouting,
+			gatewayEnabled: true,
+		},
+		{
+			name:         "pass: no route table attached",
+			outboundType: api.OutboundTypeUserDefinedRouting,
+			noRouteTable: true,
+		},
+		{
+			name:         "pass: default route via virtual appliance",
+			outboundType: api.OutboundTypeUserDefinedRouting,
+			routes: []*sdknetwork.Route{
+				route("default", "0.0.0.0/0", sdknetwork.RouteNextHopTypeVirtualAppliance),
+			},
+		},
+		{
+			name:         "pass: more specific route overrides a blackhole",
+			outboundType: api.OutboundTypeUserDefinedRouting,
+			routes: []*sdknetwork.Route{
+				route("blackhole", "0.0.0.0/0", sdknetwork.RouteNextHopTypeNone),
+				route("azure", "20.0.0.0/16", sdknetwork.RouteNextHopTypeInternet),
+				route("gateway", "10.1.0.0/16", sdknetwork.RouteNextHopTypeVirtualNetworkGateway),
+			},
+		},
+		{
+			name:         "pass: service tag routes are ignored",
+			outboundType: api.OutboundTypeUserDefinedRouting,
+			routes: []*sdknetwork.Route{
+				route("arm", "AzureResourceManager", sdknetwork.RouteNextHopTypeNone),
+			},
+		},
+		{
+			name:         "pass: unresolvable destinations are skipped",
+			outboundType: api.OutboundTypeUserDefinedRouting,
+			lookupIP: func(context.Context, string) ([]net.IP, error) {
+				return nil, errors.New("no such host")
+			},
+		},
+		{
+			name:         "fail: default route drops all traffic",
+			outboundType: api.OutboundTypeUserDefinedRouting,
+			routes: []*sdknetwork.Route{
+				route("blackhole", "0.0.0.0/0", sdknetwork.RouteNextHopTypeNone),
+			},
+			wantErr: "400: InvalidLinkedRouteTable: " + masterSubnetPath + ": The route table '" + masterRtID + "' of the provided subnet '" + masterSubnet + "' drops traffic to destinations required by the cluster: " +
+				"'management.azure.com' (20.0.0.1) via route 'blackhole' (0.0.0.0/0), " +
+				"'arosvc.azurecr.io' (20.0.1.1) via route 'blackhole' (0.0.0.0/0), " +
+				"'gateway.example.com' (10.1.0.4) via route 'blackhole' (0.0.0.0/0).",
+		},
+		{
+			name:         "fail: specific route drops traffic to ACR",
+			outboundType: api.OutboundTypeUserDefinedRouting,
+			routes: []*sdknetwork.Route{
+				route("default", "0.0.0.0/0", sdknetwork.RouteNextHopTypeInternet),
+				route("acr", "20.0.1.0/24", sdknetwork.RouteNextHopTypeNone),
+			},
+			wantErr: "400: InvalidLinkedRouteTable: " + masterSubnetPath + ": The route table '" + masterRtID + "' of the provided subnet '" + masterSubnet + "' drops traffic to destinations required by the cluster: " +
+				"'arosvc.azurecr.io' (20.0.1.1) via route 'acr' (20.0.1.0/24).",
+		},
+		{
+			name:          "fail: route table get error",
+			outboundType:  api.OutboundTypeUserDefinedRouting,
+			routeTableErr: errors.New("failed to get route table"),
+			wantErr:       "failed to get route table",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			env := mock_env.NewMockInterface(controller)
+			env.EXPECT().ACRDomain().AnyTimes().Return("arosvc.azurecr.io")
+			env.EXPECT().GatewayDomains().AnyTimes().Return([]string{"management.azure.com", "gateway.example.com"})
+
+			vnetClient := mock_armnetwork.NewMockVirtualNetworksClient(controller)
+			routeTablesClient := mock_armnetwork.NewMockRouteTablesClient(controller)
+
+			subnet := &sdknetwork.Subnet{
+				ID: &masterSubnet,
+				Properties: &sdknetwork.SubnetPropertiesFormat{
+					RouteTable: &sdknetwork.RouteTable{
+						ID: &masterRtID,
+					},
+				},
+			}
+			if tt.noRouteTable {
+				subnet.Properties.RouteTable = nil
+			}
+
+			vnetClient.EXPECT().
+				Get(gomock.Any(), resourceGroupName, vnetName, nil).
+				AnyTimes().
+				Return(sdknetwork.VirtualNetworksClientGetResponse{
+					VirtualNetwork: sdknetwork.VirtualNetwork{
+						ID: &vnetID,
+						Properties: &sdknetwork.VirtualNetworkPropertiesFormat{
+							Subnets: []*sdknetwork.Subnet{subnet},
+						},
+					},
+				}, nil)
+
+			routeTablesClient.EXPECT().
+				Get(gomock.Any(), resourceGroupName, "masterRt", nil).
+				AnyTimes().
+				Return(sdknetwork.RouteTablesClientGetResponse{
+					RouteTable: sdknetwork.RouteTable{
+						ID: &masterRtID,
+						Properties: &sdknetwork.RouteTablePropertiesFormat{
+							Routes: tt.routes,
+						},
+					},
+				}, tt.routeTableErr)
+
+			lookupIP := tt.lookupIP
+			if lookupIP == nil {
+				lookupIP = func(_ context.Context, host string) ([]net.IP, error) {
+					return resolved[host], nil
+				}
+			}
+
+			dv := &dynamic{
+				log:             logrus.NewEntry(logrus.StandardLogger()),
+				env:             env,
+				azEnv:           &azureclient.PublicCloud,
+				virtualNetworks: vnetClient,
+				routeTables:     routeTablesClient,
+				lookupIP:        lookupIP,
+			}
+
+			oc := &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					NetworkProfile: api.NetworkProfile{
+						OutboundType: tt.outboundType,
+					},
+					FeatureProfile: api.FeatureProfile{
+						GatewayEnabled: tt.gatewayEnabled,
+					},
+				},
+			}
+
+			err := dv.ValidateEgress(ctx, oc, []Subnet{
+				{ID: masterSubnet, Path: masterSubnetPath},
+				{ID: masterSubnet, Path: masterSubnetPath},
+			})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
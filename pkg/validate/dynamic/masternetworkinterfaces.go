@@ -0,0 +1,137 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	sdknetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/apparentlymart/go-cidr/cidr"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+var (
+	errMsgMasterIPNotInSubnet   = "The provided master IP address '%s' is invalid: must be in master subnet '%s'."
+	errMsgMasterIPReserved      = "The provided master IP address '%s' is invalid: it is reserved by Azure in master subnet '%s'."
+	errMsgMasterNICNotFound     = "The provided master network interface '%s' could not be found."
+	errMsgMasterNICNotInSubnet  = "The provided master network interface '%s' is invalid: must be in master subnet '%s'."
+	errMsgMasterNICAttachedToVM = "The provided master network interface '%s' is invalid: it is already attached to virtual machine '%s'."
+)
+
+// ValidateMasterNetworkInterfaces checks that statically assigned master IPs
+// are usable addresses in the master subnet, and that pre-created master NICs
+// are in the master subnet and not attached to a virtual machine.
+func (dv *dynamic) ValidateMasterNetworkInterfaces(ctx context.Context, oc *api.OpenShiftCluster) error {
+	dv.log.Print("ValidateMasterNetworkInterfaces")
+
+	mp := &oc.Properties.MasterProfile
+	if oc.Properties.ProvisioningState != api.ProvisioningStateCreating ||
+		len(mp.IPAddresses) == 0 && len(mp.NetworkInterfaceIDs) == 0 {
+		return nil
+	}
+
+	if len(mp.IPAddresses) > 0 {
+		subnetByID, err := dv.createSubnetMapByID(ctx, []Subnet{{ID: mp.SubnetID, Path: "properties.masterProfile.subnetId"}})
+		if err != nil {
+			return err
+		}
+
+		return validateMasterIPAddresses(subnetByID[mp.SubnetID], mp)
+	}
+
+	for i, id := range mp.NetworkInterfaceIDs {
+		err := dv.validateMasterNetworkInterface(ctx, id, mp.SubnetID, fmt.Sprintf("properties.masterProfile.networkInterfaceIds[%d]", i))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateMasterIPAddresses(subnet *sdknetwork.Subnet, mp *api.MasterProfile) error {
+	var prefixes []string
+	if subnet.Properties != nil {
+		if subnet.Properties.AddressPrefix != nil {
+			prefixes = append(prefixes, *subnet.Properties.AddressPrefix)
+		}
+		for _, prefix := range subnet.Properties.AddressPrefixes {
+			prefixes = append(prefixes, *prefix)
+		}
+	}
+
+	for i, s := range mp.IPAddresses {
+		path := fmt.Sprintf("properties.masterProfile.ipAddresses[%d]", i)
+		ip := net.ParseIP(s)
+
+		var subnetCIDR *net.IPNet
+		for _, prefix := range prefixes {
+			_, c, err := net.ParseCIDR(prefix)
+			if err != nil {
+				return err
+			}
+			if c.Contains(ip) {
+				subnetCIDR = c
+				break
+			}
+		}
+		if subnetCIDR == nil {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, errMsgMasterIPNotInSubnet, s, mp.SubnetID)
+		}
+
+		// first four addresses and the broadcast address are reserved:
+		// https://docs.microsoft.com/en-us/azure/virtual-network/private-ip-addresses#allocation-method
+		bottom, top := cidr.AddressRange(subnetCIDR)
+		reserved := map[string]struct{}{top.String(): {}}
+		for j, r := 0, bottom; j < 4; j, r = j+1, cidr.Inc(r) {
+			reserved[r.String()] = struct{}{}
+		}
+		if _, ok := reserved[ip.String()]; ok {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, errMsgMasterIPReserved, s, mp.SubnetID)
+		}
+	}
+
+	return nil
+}
+
+func (dv *dynamic) validateMasterNetworkInterface(ctx context.Context, id, subnetID, path string) error {
+	r, err := azure.ParseResourceID(id)
+	if err != nil {
+		return err
+	}
+
+	nic, err := dv.interfaces.Get(ctx, r.ResourceGroup, r.ResourceName, nil)
+	var responseErr *azcore.ResponseError
+	if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusNotFound {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, errMsgMasterNICNotFound, id)
+	}
+	if err != nil {
+		return err
+	}
+
+	if nic.Properties == nil || len(nic.Properties.IPConfigurations) == 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, errMsgMasterNICNotInSubnet, id, subnetID)
+	}
+
+	if nic.Properties.VirtualMachine != nil && nic.Properties.VirtualMachine.ID != nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, errMsgMasterNICAttachedToVM, id, *nic.Properties.VirtualMachine.ID)
+	}
+
+	for _, ipc := range nic.Properties.IPConfigurations {
+		if ipc.Properties == nil || ipc.Properties.Subnet == nil || ipc.Properties.Subnet.ID == nil ||
+			!strings.EqualFold(*ipc.Properties.Subnet.ID, subnetID) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, errMsgMasterNICNotInSubnet, id, subnetID)
+		}
+	}
+
+	return nil
+}
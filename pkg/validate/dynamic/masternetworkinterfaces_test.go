@@ -0,0 +1,204 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	sdknetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_armnetwork "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/azuresdk/armnetwork"
+	"github.com/Azure/ARO-RP/pkg/util/pointerutils"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateMasterNetworkInterfaces(t *testing.T) {
+	ctx := context.Background()
+
+	nicID := func(name string) string {
+		return resourceGroupID + "/providers/Microsoft.Network/networkInterfaces/" + name
+	}
+
+	nic := func(subnetID string) sdknetwork.InterfacesClientGetResponse {
+		return sdknetwork.InterfacesClientGetResponse{
+			Interface: sdknetwork.Interface{
+				Properties: &sdknetwork.InterfacePropertiesFormat{
+					IPConfigurations: []*sdknetwork.InterfaceIPConfiguration{
+						{
+							Properties: &sdknetwork.InterfaceIPConfigurationPropertiesFormat{
+								Subnet: &sdknetwork.Subnet{
+									ID: pointerutils.ToPtr(subnetID),
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name      string
+		modifyOC  func(*api.OpenShiftCluster)
+		vnetMocks func(*mock_armnetwork.MockVirtualNetworksClient)
+		nicMocks  func(*mock_armnetwork.MockInterfacesClient)
+		wantErr   string
+	}{
+		{
+			name: "pass: no static IPs or pre-created NICs",
+		},
+		{
+			name: "pass: cluster is not being created",
+			modifyOC: func(oc *api.OpenShiftCluster) {
+				oc.Properties.ProvisioningState = api.ProvisioningStateUpdating
+				oc.Properties.MasterProfile.IPAddresses = []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}
+			},
+		},
+		{
+			name: "pass: static IPs in the master subnet",
+			modifyOC: func(oc *api.OpenShiftCluster) {
+				oc.Properties.MasterProfile.IPAddresses = []string{"10.0.0.10", "10.0.0.11", "10.0.0.12"}
+			},
+			vnetMocks: func(vnetClient *mock_armnetwork.MockVirtualNetworksClient) {
+				vnetClient.EXPECT().
+					Get(gomock.Any(), resourceGroupName, vnetName, nil).
+					Return(masterVnet("10.0.0.0/24"), nil)
+			},
+		},
+		{
+			name: "fail: static IP outside the master subnet",
+			modifyOC: func(oc *api.OpenShiftCluster) {
+				oc.Properties.MasterProfile.IPAddresses = []string{"10.0.0.10", "10.0.1.11", "10.0.0.12"}
+			},
+			vnetMocks: func(vnetClient *mock_armnetwork.MockVirtualNetworksClient) {
+				vnetClient.EXPECT().
+					Get(gomock.Any(), resourceGroupName, vnetName, nil).
+					Return(masterVnet("10.0.0.0/24"), nil)
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.ipAddresses[1]: The provided master IP address '10.0.1.11' is invalid: must be in master subnet '" + masterSubnet + "'.",
+		},
+		{
+			name: "fail: static IP reserved by Azure",
+			modifyOC: func(oc *api.OpenShiftCluster) {
+				oc.Properties.MasterProfile.IPAddresses = []string{"10.0.0.3", "10.0.0.11", "10.0.0.12"}
+			},
+			vnetMocks: func(vnetClient *mock_armnetwork.MockVirtualNetworksClient) {
+				vnetClient.EXPECT().
+					Get(gomock.Any(), resourceGroupName, vnetName, nil).
+					Return(masterVnet("10.0.0.0/24"), nil)
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.ipAddresses[0]: The provided master IP address '10.0.0.3' is invalid: it is reserved by Azure in master subnet '" + masterSubnet + "'.",
+		},
+		{
+			name: "pass: pre-created NICs in the master subnet",
+			modifyOC: func(oc *api.OpenShiftCluster) {
+				oc.Properties.MasterProfile.NetworkInterfaceIDs = []string{nicID("master0"), nicID("master1"), nicID("master2")}
+			},
+			nicMocks: func(nicClient *mock_armnetwork.MockInterfacesClient) {
+				for _, name := range []string{"master0", "master1", "master2"} {
+					nicClient.EXPECT().
+						Get(gomock.Any(), resourceGroupName, name, nil).
+						Return(nic(masterSubnet), nil)
+				}
+			},
+		},
+		{
+			name: "fail: pre-created NIC not found",
+			modifyOC: func(oc *api.OpenShiftCluster) {
+				oc.Properties.MasterProfile.NetworkInterfaceIDs = []string{nicID("master0"), nicID("master1"), nicID("master2")}
+			},
+			nicMocks: func(nicClient *mock_armnetwork.MockInterfacesClient) {
+				nicClient.EXPECT().
+					Get(gomock.Any(), resourceGroupName, "master0", nil).
+					Return(sdknetwork.InterfacesClientGetResponse{}, &azcore.ResponseError{StatusCode: http.StatusNotFound})
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.networkInterfaceIds[0]: The provided master network interface '" + nicID("master0") + "' could not be found.",
+		},
+		{
+			name: "fail: pre-created NIC in another subnet",
+			modifyOC: func(oc *api.OpenShiftCluster) {
+				oc.Properties.MasterProfile.NetworkInterfaceIDs = []string{nicID("master0"), nicID("master1"), nicID("master2")}
+			},
+			nicMocks: func(nicClient *mock_armnetwork.MockInterfacesClient) {
+				nicClient.EXPECT().
+					Get(gomock.Any(), resourceGroupName, "master0", nil).
+					Return(nic(workerSubnet), nil)
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.networkInterfaceIds[0]: The provided master network interface '" + nicID("master0") + "' is invalid: must be in master subnet '" + masterSubnet + "'.",
+		},
+		{
+			name: "fail: pre-created NIC attached to a VM",
+			modifyOC: func(oc *api.OpenShiftCluster) {
+				oc.Properties.MasterProfile.NetworkInterfaceIDs = []string{nicID("master0"), nicID("master1"), nicID("master2")}
+			},
+			nicMocks: func(nicClient *mock_armnetwork.MockInterfacesClient) {
+				attached := nic(masterSubnet)
+				attached.Properties.VirtualMachine = &sdknetwork.SubResource{ID: pointerutils.ToPtr("vm-id")}
+				nicClient.EXPECT().
+					Get(gomock.Any(), resourceGroupName, "master0", nil).
+					Return(attached, nil)
+			},
+			wantErr: "400: InvalidParameter: properties.masterProfile.networkInterfaceIds[0]: The provided master network interface '" + nicID("master0") + "' is invalid: it is already attached to virtual machine 'vm-id'.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			oc := &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState: api.ProvisioningStateCreating,
+					MasterProfile: api.MasterProfile{
+						SubnetID: masterSubnet,
+					},
+				},
+			}
+			if tt.modifyOC != nil {
+				tt.modifyOC(oc)
+			}
+
+			vnetClient := mock_armnetwork.NewMockVirtualNetworksClient(controller)
+			if tt.vnetMocks != nil {
+				tt.vnetMocks(vnetClient)
+			}
+			nicClient := mock_armnetwork.NewMockInterfacesClient(controller)
+			if tt.nicMocks != nil {
+				tt.nicMocks(nicClient)
+			}
+
+			dv := &dynamic{
+				log:             logrus.NewEntry(logrus.StandardLogger()),
+				virtualNetworks: vnetClient,
+				interfaces:      nicClient,
+			}
+
+			err := dv.ValidateMasterNetworkInterfaces(ctx, oc)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func masterVnet(addressPrefix string) sdknetwork.VirtualNetworksClientGetResponse {
+	return sdknetwork.VirtualNetworksClientGetResponse{
+		VirtualNetwork: sdknetwork.VirtualNetwork{
+			ID: &vnetID,
+			Properties: &sdknetwork.VirtualNetworkPropertiesFormat{
+				Subnets: []*sdknetwork.Subnet{
+					{
+						ID: &masterSubnet,
+						Properties: &sdknetwork.SubnetPropertiesFormat{
+							AddressPrefix: pointerutils.ToPtr(addressPrefix),
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,255 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	sdkauthorization "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v3"
+	mgmtpolicyinsights "github.com/Azure/azure-sdk-for-go/services/preview/policyinsights/mgmt/2020-07-01-preview/policyinsights"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	apisubnet "github.com/Azure/ARO-RP/pkg/api/util/subnet"
+	"github.com/Azure/ARO-RP/pkg/util/pointerutils"
+)
+
+// vnetRequiredActions are the actions performed on the customer vnet resource
+// groups during install
+var vnetRequiredActions = []string{
+	"Microsoft.Network/virtualNetworks/subnets/join/action",
+	"Microsoft.Network/virtualNetworks/subnets/write",
+}
+
+// ValidateDenyAssignmentsAndPolicies checks for deny assignments and Azure
+// Policy assignments which would block the resources created during install,
+// and returns all of those found as details of a single error.
+//
+// The cluster resource group does not exist yet, so deny assignments and
+// policies are evaluated at the scope of the subscription it will be created
+// in.  Policy assignments are evaluated with the checkPolicyRestrictions API,
+// which only reports policies with a deny effect, for example disallowed
+// resource types or locations and required tags.
+func (dv *dynamic) ValidateDenyAssignmentsAndPolicies(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error {
+	dv.log.Print("ValidateDenyAssignmentsAndPolicies")
+
+	r, err := azure.ParseResourceID(oc.ID)
+	if err != nil {
+		return err
+	}
+	subscriptionScope := "/subscriptions/" + r.SubscriptionID
+
+	details := []api.CloudErrorBody{}
+
+	vnetScopes := []string{}
+	seen := map[string]bool{}
+	for _, s := range uniqueSubnetSlice(subnets) {
+		vnetID, _, err := apisubnet.Split(s.ID)
+		if err != nil {
+			return err
+		}
+
+		vnetr, err := azure.ParseResourceID(vnetID)
+		if err != nil {
+			return err
+		}
+
+		scope := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", vnetr.SubscriptionID, vnetr.ResourceGroup)
+		if !seen[strings.ToLower(scope)] {
+			seen[strings.ToLower(scope)] = true
+			vnetScopes = append(vnetScopes, scope)
+		}
+	}
+
+	for _, scope := range vnetScopes {
+		d, err := dv.blockingDenyAssignments(ctx, scope, vnetRequiredActions)
+		if err != nil {
+			return err
+		}
+		details = append(details, d...)
+	}
+
+	resourceTypes := clusterResourceTypes(oc)
+
+	actions := make([]string, 0, len(resourceTypes))
+	for _, t := range resourceTypes {
+		actions = append(actions, t+"/write")
+	}
+
+	d, err := dv.blockingDenyAssignments(ctx, subscriptionScope, actions)
+	if err != nil {
+		return err
+	}
+	details = append(details, d...)
+
+	d, err = dv.blockingPolicies(ctx, r.SubscriptionID, oc.Location, resourceTypes)
+	if err != nil {
+		return err
+	}
+	details = append(details, d...)
+
+	if len(details) > 0 {
+		return &api.CloudError{
+			StatusCode: http.StatusBadRequest,
+			CloudErrorBody: &api.CloudErrorBody{
+				Code:    api.CloudErrorCodeRequestDisallowedByPolicy,
+				Message: "The cluster cannot be installed because resources it creates are blocked by deny assignments or policy assignments.",
+				Details: details,
+			},
+		}
+	}
+
+	return nil
+}
+
+// clusterResourceTypes returns the types of the resources created in the
+// cluster resource group during install
+func clusterResourceTypes(oc *api.OpenShiftCluster) []string {
+	resourceTypes := []string{
+		"Microsoft.Resources/subscriptions/resourceGroups",
+		"Microsoft.Compute/virtualMachines",
+		"Microsoft.Network/networkInterfaces",
+		"Microsoft.Network/loadBalancers",
+		"Microsoft.Storage/storageAccounts",
+	}
+
+	needsPublicIP := oc.Properties.NetworkProfile.OutboundType != api.OutboundTypeUserDefinedRouting ||
+		oc.Properties.APIServerProfile.Visibility == api.VisibilityPublic
+	for _, ip := range oc.Properties.IngressProfiles {
+		needsPublicIP = needsPublicIP || ip.Visibility == api.VisibilityPublic
+	}
+	if needsPublicIP {
+		resourceTypes = append(resourceTypes, "Microsoft.Network/publicIPAddresses")
+	}
+
+	return resourceTypes
+}
+
+func (dv *dynamic) blockingDenyAssignments(ctx context.Context, scope string, actions []string) ([]api.CloudErrorBody, error) {
+	das, err := dv.denyAssignments.ListForScope(ctx, scope, &sdkauthorization.DenyAssignmentsClientListForScopeOptions{
+		Filter: pointerutils.ToPtr("atScope()"),
+	})
+	var responseErr *azcore.ResponseError
+	if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusForbidden {
+		dv.log.Warnf("skipping deny assignment validation at %s: %s", scope, err)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	details := []api.CloudErrorBody{}
+	for _, da := range das {
+		if da == nil || da.ID == nil || da.Properties == nil {
+			continue
+		}
+
+		// a deny assignment which does not apply to child scopes only
+		// affects the scope it is assigned at
+		if da.Properties.DoNotApplyToChildScopes != nil && *da.Properties.DoNotApplyToChildScopes &&
+			(da.Properties.Scope == nil || !strings.EqualFold(*da.Properties.Scope, scope)) {
+			continue
+		}
+
+		if action, ok := deniedAction(da.Properties.Permissions, actions); ok {
+			details = append(details, api.CloudErrorBody{
+				Code:    api.CloudErrorCodeRequestDisallowedByDenyAssignment,
+				Target:  *da.ID,
+				Message: fmt.Sprintf("The deny assignment '%s' denies the action '%s' at scope '%s'.", *da.ID, action, scope),
+			})
+		}
+	}
+
+	return details, nil
+}
+
+// deniedAction returns the first of actions which is denied by permissions
+func deniedAction(permissions []*sdkauthorization.DenyAssignmentPermission, actions []string) (string, bool) {
+	for _, action := range actions {
+		for _, p := range permissions {
+			if p == nil || !matchesAnyAction(p.Actions, action) || matchesAnyAction(p.NotActions, action) {
+				continue
+			}
+			return action, true
+		}
+	}
+
+	return "", false
+}
+
+func matchesAnyAction(patterns []*string, action string) bool {
+	for _, pattern := range patterns {
+		if pattern == nil {
+			continue
+		}
+
+		rx := "(?i)^" + strings.ReplaceAll(regexp.QuoteMeta(*pattern), `\*`, ".*") + "$"
+		if ok, _ := regexp.MatchString(rx, action); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (dv *dynamic) blockingPolicies(ctx context.Context, subscriptionID, location string, resourceTypes []string) ([]api.CloudErrorBody, error) {
+	details := []api.CloudErrorBody{}
+	seen := map[string]bool{}
+
+	for _, t := range resourceTypes {
+		result, err := dv.policyRestrictions.CheckAtSubscriptionScope(ctx, subscriptionID, mgmtpolicyinsights.CheckRestrictionsRequest{
+			ResourceDetails: &mgmtpolicyinsights.CheckRestrictionsResourceDetails{
+				ResourceContent: map[string]interface{}{
+					"type":     t,
+					"location": location,
+				},
+			},
+		})
+		if detailedErr, ok := err.(autorest.DetailedError); ok && detailedErr.StatusCode == http.StatusForbidden {
+			dv.log.Warnf("skipping policy validation in subscription %s: %s", subscriptionID, err)
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if result.ContentEvaluationResult == nil || result.ContentEvaluationResult.PolicyEvaluations == nil {
+			continue
+		}
+
+		for _, evaluation := range *result.ContentEvaluationResult.PolicyEvaluations {
+			if evaluation.EvaluationResult == nil || !strings.EqualFold(*evaluation.EvaluationResult, "NonCompliant") ||
+				evaluation.PolicyInfo == nil || evaluation.PolicyInfo.PolicyAssignmentID == nil {
+				continue
+			}
+
+			assignmentID := *evaluation.PolicyInfo.PolicyAssignmentID
+			key := strings.ToLower(assignmentID + "|" + t)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			message := fmt.Sprintf("The policy assignment '%s' disallows resources of type '%s' in location '%s'.", assignmentID, t, location)
+			if evaluation.PolicyInfo.PolicyDefinitionID != nil {
+				message = fmt.Sprintf("The policy assignment '%s' (policy definition '%s') disallows resources of type '%s' in location '%s'.", assignmentID, *evaluation.PolicyInfo.PolicyDefinitionID, t, location)
+			}
+
+			details = append(details, api.CloudErrorBody{
+				Code:    api.CloudErrorCodeRequestDisallowedByPolicy,
+				Target:  assignmentID,
+				Message: message,
+			})
+		}
+	}
+
+	return details, nil
+}
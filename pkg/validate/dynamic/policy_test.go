@@ -0,0 +1,194 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	sdkauthorization "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v3"
+	mgmtpolicyinsights "github.com/Azure/azure-sdk-for-go/services/preview/policyinsights/mgmt/2020-07-01-preview/policyinsights"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_armauthorization "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/azuresdk/armauthorization"
+	mock_policyinsights "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/policyinsights"
+	"github.com/Azure/ARO-RP/pkg/util/pointerutils"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateDenyAssignmentsAndPolicies(t *testing.T) {
+	ctx := context.Background()
+
+	subscriptionScope := "/subscriptions/" + subscriptionID
+	daID := "/providers/Microsoft.Authorization/denyAssignments/deny"
+	policyAssignmentID := subscriptionScope + "/providers/Microsoft.Authorization/policyAssignments/no-public-ips"
+	policyDefinitionID := "/providers/Microsoft.Authorization/policyDefinitions/no-public-ips"
+
+	denyAssignment := func(scope string, doNotApplyToChildScopes bool, actions []string, notActions []string) *sdkauthorization.DenyAssignment {
+		permission := &sdkauthorization.DenyAssignmentPermission{}
+		for _, a := range actions {
+			permission.Actions = append(permission.Actions, pointerutils.ToPtr(a))
+		}
+		for _, a := range notActions {
+			permission.NotActions = append(permission.NotActions, pointerutils.ToPtr(a))
+		}
+		return &sdkauthorization.DenyAssignment{
+			ID: pointerutils.ToPtr(daID),
+			Properties: &sdkauthorization.DenyAssignmentProperties{
+				Scope:                   pointerutils.ToPtr(scope),
+				DoNotApplyToChildScopes: pointerutils.ToPtr(doNotApplyToChildScopes),
+				Permissions:             []*sdkauthorization.DenyAssignmentPermission{permission},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name                    string
+		outboundType            api.OutboundType
+		vnetDenyAssignments     []*sdkauthorization.DenyAssignment
+		vnetDenyAssignmentsErr  error
+		subDenyAssignments      []*sdkauthorization.DenyAssignment
+		nonCompliantTypes       map[string]bool
+		withoutDefinition       bool
+		policyErr               error
+		wantPublicIPPolicyCheck bool
+		wantErr                 string
+	}{
+		{
+			name:                    "pass: nothing blocks the install",
+			wantPublicIPPolicyCheck: true,
+		},
+		{
+			name:                    "pass: deny assignment excludes the required actions",
+			vnetDenyAssignments:     []*sdkauthorization.DenyAssignment{denyAssignment(resourceGroupID, false, []string{"*"}, []string{"Microsoft.Network/virtualNetworks/subnets/*"})},
+			wantPublicIPPolicyCheck: true,
+		},
+		{
+			name:                    "pass: deny assignment on a parent scope does not apply to child scopes",
+			vnetDenyAssignments:     []*sdkauthorization.DenyAssignment{denyAssignment(subscriptionScope, true, []string{"*"}, nil)},
+			wantPublicIPPolicyCheck: true,
+		},
+		{
+			name:         "pass: public IP addresses are not checked for private UDR clusters",
+			outboundType: api.OutboundTypeUserDefinedRouting,
+		},
+		{
+			name: "pass: insufficient permissions skip the checks",
+			vnetDenyAssignmentsErr: &azcore.ResponseError{
+				StatusCode: http.StatusForbidden,
+			},
+			policyErr: autorest.DetailedError{
+				StatusCode: http.StatusForbidden,
+			},
+		},
+		{
+			name:                    "fail: deny assignment blocks subnet write",
+			vnetDenyAssignments:     []*sdkauthorization.DenyAssignment{denyAssignment(resourceGroupID, false, []string{"microsoft.network/virtualNetworks/subnets/write"}, nil)},
+			wantPublicIPPolicyCheck: true,
+			wantErr:                 "400: RequestDisallowedByPolicy: : The cluster cannot be installed because resources it creates are blocked by deny assignments or policy assignments. Details: RequestDisallowedByDenyAssignment: " + daID + ": The deny assignment '" + daID + "' denies the action 'Microsoft.Network/virtualNetworks/subnets/write' at scope '" + resourceGroupID + "'.",
+		},
+		{
+			name:                    "fail: deny assignment and policy block cluster resources",
+			subDenyAssignments:      []*sdkauthorization.DenyAssignment{denyAssignment(subscriptionScope, false, []string{"Microsoft.Compute/*"}, nil)},
+			nonCompliantTypes:       map[string]bool{"Microsoft.Network/publicIPAddresses": true},
+			wantPublicIPPolicyCheck: true,
+			wantErr:                 "400: RequestDisallowedByPolicy: : The cluster cannot be installed because resources it creates are blocked by deny assignments or policy assignments. Details: RequestDisallowedByDenyAssignment: " + daID + ": The deny assignment '" + daID + "' denies the action 'Microsoft.Compute/virtualMachines/write' at scope '" + subscriptionScope + "'., RequestDisallowedByPolicy: " + policyAssignmentID + ": The policy assignment '" + policyAssignmentID + "' (policy definition '" + policyDefinitionID + "') disallows resources of type 'Microsoft.Network/publicIPAddresses' in location 'eastus'.",
+		},
+		{
+			name:                    "fail: policy without a definition",
+			nonCompliantTypes:       map[string]bool{"Microsoft.Storage/storageAccounts": true},
+			withoutDefinition:       true,
+			wantPublicIPPolicyCheck: true,
+			wantErr:                 "400: RequestDisallowedByPolicy: : The cluster cannot be installed because resources it creates are blocked by deny assignments or policy assignments. Details: RequestDisallowedByPolicy: " + policyAssignmentID + ": The policy assignment '" + policyAssignmentID + "' disallows resources of type 'Microsoft.Storage/storageAccounts' in location 'eastus'.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			denyAssignmentsClient := mock_armauthorization.NewMockDenyAssignmentsClient(controller)
+			policyRestrictionsClient := mock_policyinsights.NewMockPolicyRestrictionsClient(controller)
+
+			denyAssignmentsClient.EXPECT().
+				ListForScope(gomock.Any(), resourceGroupID, gomock.Any()).
+				Return(tt.vnetDenyAssignments, tt.vnetDenyAssignmentsErr)
+			denyAssignmentsClient.EXPECT().
+				ListForScope(gomock.Any(), subscriptionScope, gomock.Any()).
+				Return(tt.subDenyAssignments, nil)
+
+			checkedTypes := map[string]bool{}
+			policyRestrictionsClient.EXPECT().
+				CheckAtSubscriptionScope(gomock.Any(), subscriptionID, gomock.Any()).
+				AnyTimes().
+				DoAndReturn(func(_ context.Context, _ string, parameters mgmtpolicyinsights.CheckRestrictionsRequest) (mgmtpolicyinsights.CheckRestrictionsResult, error) {
+					if tt.policyErr != nil {
+						return mgmtpolicyinsights.CheckRestrictionsResult{}, tt.policyErr
+					}
+
+					resourceType := parameters.ResourceDetails.ResourceContent.(map[string]interface{})["type"].(string)
+					checkedTypes[resourceType] = true
+
+					evaluationResult := "Compliant"
+					if tt.nonCompliantTypes[resourceType] {
+						evaluationResult = "NonCompliant"
+					}
+
+					policyInfo := &mgmtpolicyinsights.PolicyReference{
+						PolicyAssignmentID: &policyAssignmentID,
+					}
+					if !tt.withoutDefinition {
+						policyInfo.PolicyDefinitionID = &policyDefinitionID
+					}
+
+					return mgmtpolicyinsights.CheckRestrictionsResult{
+						ContentEvaluationResult: &mgmtpolicyinsights.CheckRestrictionsResultContentEvaluationResult{
+							PolicyEvaluations: &[]mgmtpolicyinsights.PolicyEvaluationResult{
+								{
+									PolicyInfo:       policyInfo,
+									EvaluationResult: &evaluationResult,
+								},
+							},
+						},
+					}, nil
+				})
+
+			dv := &dynamic{
+				log:                logrus.NewEntry(logrus.StandardLogger()),
+				denyAssignments:    denyAssignmentsClient,
+				policyRestrictions: policyRestrictionsClient,
+			}
+
+			oc := &api.OpenShiftCluster{
+				ID:       clusterID,
+				Location: "eastus",
+				Properties: api.OpenShiftClusterProperties{
+					NetworkProfile: api.NetworkProfile{
+						OutboundType: tt.outboundType,
+					},
+					APIServerProfile: api.APIServerProfile{
+						Visibility: api.VisibilityPrivate,
+					},
+					IngressProfiles: []api.IngressProfile{
+						{Visibility: api.VisibilityPrivate},
+					},
+				},
+			}
+
+			err := dv.ValidateDenyAssignmentsAndPolicies(ctx, oc, []Subnet{
+				{ID: masterSubnet, Path: masterSubnetPath},
+				{ID: workerSubnet, Path: workerSubnetPath},
+			})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if tt.policyErr == nil && checkedTypes["Microsoft.Network/publicIPAddresses"] != tt.wantPublicIPPolicyCheck {
+				t.Errorf("public IP addresses checked: got %v, want %v", checkedTypes["Microsoft.Network/publicIPAddresses"], tt.wantPublicIPPolicyCheck)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	azcorearm "github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	sdknetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+	"github.com/Azure/go-autorest/autorest/azure"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	apisubnet "github.com/Azure/ARO-RP/pkg/api/util/subnet"
+	"github.com/Azure/ARO-RP/pkg/env"
+)
+
+var (
+	errMsgSubnetUsedByCluster    = "The provided subnet '%s' is invalid: it is used by the cluster in resource group '%s'."
+	errMsgSubnetNSGShared        = "The provided subnet '%s' is invalid: its network security group '%s' is also attached to subnet '%s', which is used by the cluster in resource group '%s'."
+	errMsgSubnetRouteTableShared = "The provided subnet '%s' is invalid: its route table '%s' is also attached to subnet '%s', which is used by the cluster in resource group '%s'."
+)
+
+// clusterNICRegex matches the names of the network interfaces of the master
+// and worker machines of a cluster
+var clusterNICRegex = regexp.MustCompile(`(?i)^.+-(master\d+|worker-.+)-nic$`)
+
+// ValidateSharedSubnets checks that the subnets of a new cluster are not used
+// by another ARO cluster, and that the network security groups and route
+// tables attached to them are not attached to the subnets of another ARO
+// cluster.  Clusters sharing these resources reconcile them against each
+// other.  Conflicts fail validation when the BlockSharedSubnets feature is set,
+// and are only logged as a warning otherwise.
+func (dv *dynamic) ValidateSharedSubnets(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error {
+	dv.log.Print("ValidateSharedSubnets")
+
+	if oc.Properties.ProvisioningState != api.ProvisioningStateCreating {
+		return nil
+	}
+
+	err := dv.validateSharedSubnets(ctx, oc, uniqueSubnetSlice(subnets))
+	var cloudErr *api.CloudError
+	if errors.As(err, &cloudErr) && !dv.env.FeatureIsSet(env.FeatureBlockSharedSubnets) {
+		dv.log.Warn(err)
+		return nil
+	}
+
+	return err
+}
+
+func (dv *dynamic) validateSharedSubnets(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error {
+	subnetByID, err := dv.createSubnetMapByID(ctx, subnets)
+	if err != nil {
+		return err
+	}
+
+	seenNSGs := map[string]bool{}
+	seenRouteTables := map[string]bool{}
+
+	for _, s := range subnets {
+		ss := subnetByID[s.ID]
+		if ss.Properties == nil {
+			continue
+		}
+
+		rg := otherClusterResourceGroup(oc, ss)
+		if rg != "" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedVNet, s.Path, errMsgSubnetUsedByCluster, s.ID, rg)
+		}
+
+		if subnetHasNSGAttached(ss) && !seenNSGs[strings.ToLower(*ss.Properties.NetworkSecurityGroup.ID)] {
+			nsgID := *ss.Properties.NetworkSecurityGroup.ID
+			seenNSGs[strings.ToLower(nsgID)] = true
+
+			attached, err := dv.nsgSubnets(ctx, nsgID)
+			if err != nil {
+				return err
+			}
+
+			otherID, rg, err := dv.findOtherClusterSubnet(ctx, oc, attached, subnetByID)
+			if err != nil {
+				return err
+			}
+			if rg != "" {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedVNet, s.Path, errMsgSubnetNSGShared, s.ID, nsgID, otherID, rg)
+			}
+		}
+
+		if ss.Properties.RouteTable != nil && ss.Properties.RouteTable.ID != nil && !seenRouteTables[strings.ToLower(*ss.Properties.RouteTable.ID)] {
+			rtID := *ss.Properties.RouteTable.ID
+			seenRouteTables[strings.ToLower(rtID)] = true
+
+			attached, err := dv.routeTableSubnets(ctx, rtID)
+			if err != nil {
+				return err
+			}
+
+			otherID, rg, err := dv.findOtherClusterSubnet(ctx, oc, attached, subnetByID)
+			if err != nil {
+				return err
+			}
+			if rg != "" {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedRouteTable, s.Path, errMsgSubnetRouteTableShared, s.ID, rtID, otherID, rg)
+			}
+		}
+	}
+
+	return nil
+}
+
+// nsgSubnets returns the subnets network security group nsgID is attached to
+func (dv *dynamic) nsgSubnets(ctx context.Context, nsgID string) ([]*sdknetwork.Subnet, error) {
+	nsgr, err := azure.ParseResourceID(nsgID)
+	if err != nil {
+		return nil, err
+	}
+
+	nsg, err := dv.securityGroups.Get(ctx, nsgr.ResourceGroup, nsgr.ResourceName, nil)
+	var responseErr *azcore.ResponseError
+	if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusForbidden {
+		dv.log.Warnf("skipping shared network security group validation of %s: %s", nsgID, err)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if nsg.Properties == nil {
+		return nil, nil
+	}
+
+	return nsg.Properties.Subnets, nil
+}
+
+// routeTableSubnets returns the subnets route table rtID is attached to
+func (dv *dynamic) routeTableSubnets(ctx context.Context, rtID string) ([]*sdknetwork.Subnet, error) {
+	rtr, err := azure.ParseResourceID(rtID)
+	if err != nil {
+		return nil, err
+	}
+
+	rt, err := dv.routeTables.Get(ctx, rtr.ResourceGroup, rtr.ResourceName, nil)
+	var responseErr *azcore.ResponseError
+	if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusForbidden {
+		dv.log.Warnf("skipping shared route table validation of %s: %s", rtID, err)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if rt.Properties == nil {
+		return nil, nil
+	}
+
+	return rt.Properties.Subnets, nil
+}
+
+// findOtherClusterSubnet returns the first subnet of attached, other than the
+// requested subnets, which is used by a cluster other than oc, and the
+// resource group of that cluster
+func (dv *dynamic) findOtherClusterSubnet(ctx context.Context, oc *api.OpenShiftCluster, attached []*sdknetwork.Subnet, subnetByID map[string]*sdknetwork.Subnet) (string, string, error) {
+	for _, a := range attached {
+		if a == nil || a.ID == nil || isRequestedSubnet(*a.ID, subnetByID) {
+			continue
+		}
+
+		vnetID, _, err := apisubnet.Split(*a.ID)
+		if err != nil {
+			return "", "", err
+		}
+
+		vnetr, err := azure.ParseResourceID(vnetID)
+		if err != nil {
+			return "", "", err
+		}
+
+		vnet, err := dv.virtualNetworks.Get(ctx, vnetr.ResourceGroup, vnetr.ResourceName, nil)
+		var responseErr *azcore.ResponseError
+		if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusForbidden {
+			dv.log.Warnf("skipping shared subnet validation of %s: %s", *a.ID, err)
+			continue
+		}
+		if err != nil {
+			return "", "", err
+		}
+
+		other, err := findSubnet(&vnet.VirtualNetwork, *a.ID)
+		if err != nil {
+			return "", "", err
+		}
+
+		if other.Properties == nil {
+			continue
+		}
+
+		rg := otherClusterResourceGroup(oc, other)
+		if rg != "" {
+			return *a.ID, rg, nil
+		}
+	}
+
+	return "", "", nil
+}
+
+// otherClusterResourceGroup returns the resource group of a cluster other than
+// oc whose machines are attached to subnet, or "" if there is none
+func otherClusterResourceGroup(oc *api.OpenShiftCluster, subnet *sdknetwork.Subnet) string {
+	for _, ipc := range subnet.Properties.IPConfigurations {
+		if ipc == nil || ipc.ID == nil {
+			continue
+		}
+
+		// IP configurations are child resources of network interfaces:
+		// .../networkInterfaces/<nic>/ipConfigurations/<name>
+		r, err := azcorearm.ParseResourceID(*ipc.ID)
+		if err != nil || r.Parent == nil || !strings.EqualFold(r.Parent.ResourceType.Type, "networkInterfaces") {
+			continue
+		}
+
+		if clusterNICRegex.MatchString(r.Parent.Name) &&
+			!strings.EqualFold("/subscriptions/"+r.SubscriptionID+"/resourceGroups/"+r.ResourceGroupName, oc.Properties.ClusterProfile.ResourceGroupID) {
+			return r.ResourceGroupName
+		}
+	}
+
+	return ""
+}
+
+func isRequestedSubnet(subnetID string, subnetByID map[string]*sdknetwork.Subnet) bool {
+	for id := range subnetByID {
+		if strings.EqualFold(id, subnetID) {
+			return true
+		}
+	}
+	return false
+}
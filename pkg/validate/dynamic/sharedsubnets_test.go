@@ -0,0 +1,225 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	sdknetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/env"
+	mock_armnetwork "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/azuresdk/armnetwork"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	"github.com/Azure/ARO-RP/pkg/util/pointerutils"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateSharedSubnets(t *testing.T) {
+	ctx := context.Background()
+
+	otherClusterRG := "aro-other"
+	otherVnetID := "/subscriptions/" + subscriptionID + "/resourceGroups/otherGroup/providers/Microsoft.Network/virtualNetworks/otherVnet"
+	otherSubnet := otherVnetID + "/subnets/otherSubnet"
+	nsgID := resourceGroupID + "/providers/Microsoft.Network/networkSecurityGroups/customer-nsg"
+
+	ipConfiguration := func(resourceGroup, nic string) *sdknetwork.IPConfiguration {
+		return &sdknetwork.IPConfiguration{
+			ID: pointerutils.ToPtr("/subscriptions/" + subscriptionID + "/resourceGroups/" + resourceGroup + "/providers/Microsoft.Network/networkInterfaces/" + nic + "/ipConfigurations/pipConfig"),
+		}
+	}
+	otherClusterIPConfigurations := []*sdknetwork.IPConfiguration{
+		ipConfiguration(otherClusterRG, "other-abcde-master0-nic"),
+		ipConfiguration(otherClusterRG, "other-abcde-worker-eastus1-fghij-nic"),
+	}
+
+	for _, tt := range []struct {
+		name                   string
+		provisioningState      api.ProvisioningState
+		block                  bool
+		masterIPConfigurations []*sdknetwork.IPConfiguration
+		nsgSubnets             []*sdknetwork.Subnet
+		nsgErr                 error
+		routeTableSubnets      []*sdknetwork.Subnet
+		wantErr                string
+	}{
+		{
+			name:              "pass: existing clusters are not validated",
+			provisioningState: api.ProvisioningStateUpdating,
+		},
+		{
+			name:              "pass: subnets are not shared",
+			provisioningState: api.ProvisioningStateCreating,
+			block:             true,
+			nsgSubnets: []*sdknetwork.Subnet{
+				{ID: &masterSubnet},
+				{ID: &workerSubnet},
+			},
+			routeTableSubnets: []*sdknetwork.Subnet{
+				{ID: &masterSubnet},
+			},
+		},
+		{
+			name:                   "pass: other resources in the subnet",
+			provisioningState:      api.ProvisioningStateCreating,
+			block:                  true,
+			masterIPConfigurations: []*sdknetwork.IPConfiguration{ipConfiguration("jumpbox", "jumpbox-nic")},
+		},
+		{
+			name:                   "pass: shared subnet is only logged without the feature",
+			provisioningState:      api.ProvisioningStateCreating,
+			masterIPConfigurations: otherClusterIPConfigurations,
+		},
+		{
+			name:              "pass: network security group not readable",
+			provisioningState: api.ProvisioningStateCreating,
+			block:             true,
+			nsgErr: &azcore.ResponseError{
+				StatusCode: http.StatusForbidden,
+			},
+		},
+		{
+			name:                   "fail: subnet used by another cluster",
+			provisioningState:      api.ProvisioningStateCreating,
+			block:                  true,
+			masterIPConfigurations: otherClusterIPConfigurations,
+			wantErr:                "400: InvalidLinkedVNet: properties.masterProfile.subnetId: The provided subnet '" + masterSubnet + "' is invalid: it is used by the cluster in resource group '" + otherClusterRG + "'.",
+		},
+		{
+			name:              "fail: network security group attached to a subnet of another cluster",
+			provisioningState: api.ProvisioningStateCreating,
+			block:             true,
+			nsgSubnets: []*sdknetwork.Subnet{
+				{ID: &masterSubnet},
+				{ID: &otherSubnet},
+			},
+			wantErr: "400: InvalidLinkedVNet: properties.masterProfile.subnetId: The provided subnet '" + masterSubnet + "' is invalid: its network security group '" + nsgID + "' is also attached to subnet '" + otherSubnet + "', which is used by the cluster in resource group '" + otherClusterRG + "'.",
+		},
+		{
+			name:              "fail: route table attached to a subnet of another cluster",
+			provisioningState: api.ProvisioningStateCreating,
+			block:             true,
+			routeTableSubnets: []*sdknetwork.Subnet{
+				{ID: &otherSubnet},
+			},
+			wantErr: "400: InvalidLinkedRouteTable: properties.masterProfile.subnetId: The provided subnet '" + masterSubnet + "' is invalid: its route table '" + masterRtID + "' is also attached to subnet '" + otherSubnet + "', which is used by the cluster in resource group '" + otherClusterRG + "'.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			_env := mock_env.NewMockInterface(controller)
+			vnetClient := mock_armnetwork.NewMockVirtualNetworksClient(controller)
+			securityGroupsClient := mock_armnetwork.NewMockSecurityGroupsClient(controller)
+			routeTablesClient := mock_armnetwork.NewMockRouteTablesClient(controller)
+
+			_env.EXPECT().FeatureIsSet(env.FeatureBlockSharedSubnets).AnyTimes().Return(tt.block)
+
+			vnetClient.EXPECT().
+				Get(gomock.Any(), resourceGroupName, vnetName, nil).
+				AnyTimes().
+				Return(sdknetwork.VirtualNetworksClientGetResponse{
+					VirtualNetwork: sdknetwork.VirtualNetwork{
+						ID: &vnetID,
+						Properties: &sdknetwork.VirtualNetworkPropertiesFormat{
+							Subnets: []*sdknetwork.Subnet{
+								{
+									ID: &masterSubnet,
+									Properties: &sdknetwork.SubnetPropertiesFormat{
+										IPConfigurations: tt.masterIPConfigurations,
+										NetworkSecurityGroup: &sdknetwork.SecurityGroup{
+											ID: &nsgID,
+										},
+										RouteTable: &sdknetwork.RouteTable{
+											ID: &masterRtID,
+										},
+									},
+								},
+								{
+									ID: &workerSubnet,
+									Properties: &sdknetwork.SubnetPropertiesFormat{
+										NetworkSecurityGroup: &sdknetwork.SecurityGroup{
+											ID: &nsgID,
+										},
+									},
+								},
+							},
+						},
+					},
+				}, nil)
+			vnetClient.EXPECT().
+				Get(gomock.Any(), "otherGroup", "otherVnet", nil).
+				AnyTimes().
+				Return(sdknetwork.VirtualNetworksClientGetResponse{
+					VirtualNetwork: sdknetwork.VirtualNetwork{
+						ID: &otherVnetID,
+						Properties: &sdknetwork.VirtualNetworkPropertiesFormat{
+							Subnets: []*sdknetwork.Subnet{
+								{
+									ID: &otherSubnet,
+									Properties: &sdknetwork.SubnetPropertiesFormat{
+										IPConfigurations: otherClusterIPConfigurations,
+									},
+								},
+							},
+						},
+					},
+				}, nil)
+
+			// the network security group is shared between the subnets and
+			// only fetched once
+			securityGroupsClient.EXPECT().
+				Get(gomock.Any(), resourceGroupName, "customer-nsg", nil).
+				MaxTimes(1).
+				Return(sdknetwork.SecurityGroupsClientGetResponse{
+					SecurityGroup: sdknetwork.SecurityGroup{
+						ID: &nsgID,
+						Properties: &sdknetwork.SecurityGroupPropertiesFormat{
+							Subnets: tt.nsgSubnets,
+						},
+					},
+				}, tt.nsgErr)
+			routeTablesClient.EXPECT().
+				Get(gomock.Any(), resourceGroupName, "masterRt", nil).
+				MaxTimes(1).
+				Return(sdknetwork.RouteTablesClientGetResponse{
+					RouteTable: sdknetwork.RouteTable{
+						ID: &masterRtID,
+						Properties: &sdknetwork.RouteTablePropertiesFormat{
+							Subnets: tt.routeTableSubnets,
+						},
+					},
+				}, nil)
+
+			dv := &dynamic{
+				log:             logrus.NewEntry(logrus.StandardLogger()),
+				env:             _env,
+				virtualNetworks: vnetClient,
+				securityGroups:  securityGroupsClient,
+				routeTables:     routeTablesClient,
+			}
+
+			oc := &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState: tt.provisioningState,
+					ClusterProfile: api.ClusterProfile{
+						ResourceGroupID: "/subscriptions/" + subscriptionID + "/resourceGroups/aro-cluster",
+					},
+				},
+			}
+
+			err := dv.ValidateSharedSubnets(ctx, oc, []Subnet{
+				{ID: masterSubnet, Path: masterSubnetPath},
+				{ID: workerSubnet, Path: workerSubnetPath},
+			})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
@@ -257,6 +257,11 @@ func (dv *openShiftClusterDynamicValidator) Dynamic(ctx context.Context) error {
 		return err
 	}
 
+	err = spDynamic.ValidateMasterNetworkInterfaces(ctx, dv.oc)
+	if err != nil {
+		return err
+	}
+
 	err = ensureAccessTokenClaims(ctx, fpClientCred, scopes)
 	if err != nil {
 		return err
@@ -289,6 +294,16 @@ func (dv *openShiftClusterDynamicValidator) Dynamic(ctx context.Context) error {
 		return err
 	}
 
+	err = fpDynamic.ValidateConnectedNetworks(
+		ctx,
+		subnets,
+		dv.oc.Properties.NetworkProfile.PodCIDR,
+		dv.oc.Properties.NetworkProfile.ServiceCIDR,
+	)
+	if err != nil {
+		return err
+	}
+
 	err = fpDynamic.ValidateDiskEncryptionSets(ctx, dv.oc)
 	if err != nil {
 		return err
@@ -304,5 +319,25 @@ func (dv *openShiftClusterDynamicValidator) Dynamic(ctx context.Context) error {
 		return err
 	}
 
+	err = fpDynamic.ValidateEgress(ctx, dv.oc, subnets)
+	if err != nil {
+		return err
+	}
+
+	err = fpDynamic.ValidateCustomDomain(ctx, dv.oc)
+	if err != nil {
+		return err
+	}
+
+	err = fpDynamic.ValidateDenyAssignmentsAndPolicies(ctx, dv.oc, subnets)
+	if err != nil {
+		return err
+	}
+
+	err = fpDynamic.ValidateSharedSubnets(ctx, dv.oc, subnets)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -175,7 +175,13 @@ export interface IClusterDetail {
 }
 
 function App() {
-  const [data, updateData] = useState({ location: "", csrf: "", elevated: false, username: "" })
+  const [data, updateData] = useState({
+    location: "",
+    csrf: "",
+    elevated: false,
+    role: "",
+    username: "",
+  })
   const [regions, setRegions] = useState<any>([])
   const [error, setError] = useState<Response | null>(null)
   const [isOpen, { setTrue: openPanel, setFalse: dismissPanel }] = useBoolean(false)
@@ -292,6 +298,11 @@ function App() {
                 <Icon iconName={"Admin"}></Icon>
               </TooltipHost>
             </Stack.Item>
+            <Stack.Item hidden={data.role !== "reader"}>
+              <TooltipHost content={`Read-only User`}>
+                <Icon iconName={"ReadingMode"}></Icon>
+              </TooltipHost>
+            </Stack.Item>
             <Stack.Item>
               <IconButton
                 iconProps={{ iconName: "SignOut" }}
@@ -322,7 +333,7 @@ function App() {
             </Routes>
           </Stack.Item>
         </Stack>
-        <SSHModal csrfToken={csrfRef} ref={sshRef} />
+        <SSHModal csrfToken={csrfRef} elevated={data.elevated} ref={sshRef} />
       </Stack>
     </>
   )
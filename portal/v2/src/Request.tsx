@@ -3,7 +3,12 @@ import { IClusterCoordinates } from "./App"
 import { convertTimeToHours } from "./ClusterDetailListComponents/Statistics/GraphOptionsComponent"
 
 const OnError = (err: Response): Response => {
-  if (err.status === 403) {
+  if (err.status === 401 && err.headers.has("X-Step-Up-Required")) {
+    // privileged actions need a recent step-up authentication
+    document.location.href =
+      "/api/login?stepup=true&redirect_uri=" + encodeURIComponent(document.location.pathname)
+    return err
+  } else if (err.status === 403) {
     var href = "/api/login"
     if (document.location.pathname !== "/") {
       href += "?redirect_uri=" + document.location.pathname
@@ -34,6 +39,30 @@ export const fetchClusters = async (): Promise<Response> => {
   return doFetch("/api/clusters")
 }
 
+export interface IClusterSearch {
+  version?: string
+  location?: string
+  provisioningState?: string
+  lastError?: string
+}
+
+export const searchClusters = async (
+  search: IClusterSearch,
+  continuation?: string
+): Promise<Response> => {
+  const params = new URLSearchParams()
+  Object.entries(search).forEach(([key, value]) => {
+    if (value) {
+      params.append(key, value)
+    }
+  })
+  if (continuation) {
+    params.append("continuation", continuation)
+  }
+
+  return doFetch("/api/clusters/search?" + params.toString())
+}
+
 export const fetchClusterInfo = async (cluster: IClusterCoordinates): Promise<Response> => {
   return doFetch(urlJoin("/", "api", cluster.subscription, cluster.resourceGroup, cluster.name))
 }
@@ -73,6 +102,35 @@ export const fetchClusterOperators = async (cluster: IClusterCoordinates): Promi
   )
 }
 
+export const fetchSSHSessions = async (cluster: IClusterCoordinates): Promise<Response> => {
+  return doFetch(
+    urlJoin("/", "api", cluster.subscription, cluster.resourceGroup, cluster.name, "sshsessions")
+  )
+}
+
+export const fetchTimeline = async (
+  cluster: IClusterCoordinates,
+  since?: string
+): Promise<Response> => {
+  const url = urlJoin(
+    "/",
+    "api",
+    cluster.subscription,
+    cluster.resourceGroup,
+    cluster.name,
+    "timeline"
+  )
+  return doFetch(since ? url + "?" + new URLSearchParams({ since }).toString() : url)
+}
+
+export const fetchHiveClusterDeployment = async (
+  cluster: IClusterCoordinates
+): Promise<Response> => {
+  return doFetch(
+    urlJoin("/", "api", cluster.subscription, cluster.resourceGroup, cluster.name, "hive")
+  )
+}
+
 export const fetchRegions = async (): Promise<Response> => {
   return doFetch("/api/regions")
 }
@@ -140,3 +198,202 @@ export const fetchStatistics = async (
 
   return doFetch(url)
 }
+
+export const streamPodLogs = (
+  cluster: IClusterCoordinates,
+  namespace: string,
+  pod: string,
+  container: string,
+  follow: boolean,
+  tailLines: number,
+  onLine: (line: string) => void
+): WebSocket => {
+  const url = new URL(
+    urlJoin(
+      "/",
+      "api",
+      cluster.subscription,
+      cluster.resourceGroup,
+      cluster.name,
+      "namespaces",
+      namespace,
+      "pods",
+      pod,
+      "logs"
+    ),
+    window.location.href
+  )
+  url.protocol = url.protocol === "https:" ? "wss:" : "ws:"
+  if (container) {
+    url.searchParams.append("container", container)
+  }
+  url.searchParams.append("follow", String(follow))
+  url.searchParams.append("tailLines", String(tailLines))
+
+  const ws = new WebSocket(url)
+  ws.onmessage = (event: MessageEvent) => onLine(event.data)
+  return ws
+}
+
+export interface IMustGatherProgress {
+  step: string
+  done: number
+  total: number
+}
+
+export interface IMustGatherArchive {
+  filename: string
+  archive: Blob
+}
+
+// downloadMustGather collects a must-gather archive from the cluster,
+// reporting progress as it goes, and resolves with the archive once the
+// collection has finished.
+export const downloadMustGather = (
+  cluster: IClusterCoordinates,
+  onProgress: (progress: IMustGatherProgress, bytes: number) => void
+): Promise<IMustGatherArchive> => {
+  const url = new URL(
+    urlJoin("/", "api", cluster.subscription, cluster.resourceGroup, cluster.name, "mustgather"),
+    window.location.href
+  )
+  url.protocol = url.protocol === "https:" ? "wss:" : "ws:"
+
+  return new Promise((resolve, reject) => {
+    const chunks: Blob[] = []
+    let bytes = 0
+    let finished = false
+
+    const ws = new WebSocket(url)
+    ws.binaryType = "blob"
+    ws.onmessage = (event: MessageEvent) => {
+      if (event.data instanceof Blob) {
+        chunks.push(event.data)
+        bytes += event.data.size
+        return
+      }
+
+      const message = JSON.parse(event.data)
+      if (message.progress) {
+        onProgress(message.progress, bytes)
+      } else if (message.filename) {
+        finished = true
+        ws.close()
+        resolve({
+          filename: message.filename,
+          archive: new Blob(chunks, { type: "application/gzip" }),
+        })
+      } else if (message.error) {
+        finished = true
+        ws.close()
+        reject(new Error(message.error))
+      }
+    }
+    ws.onclose = () => {
+      if (!finished) {
+        reject(new Error("must-gather connection closed"))
+      }
+    }
+  })
+}
+
+export interface IDebugTerminalStatus {
+  status?: "creating" | "ready" | "exited"
+  pod?: string
+  error?: string
+}
+
+// openDebugTerminal starts a shell on a node in an ephemeral debug pod.  The
+// returned socket accepts JSON messages carrying either {stdin} or {resize:
+// {width, height}}; terminal output is passed to onOutput.
+export const openDebugTerminal = (
+  cluster: IClusterCoordinates,
+  node: string,
+  onStatus: (status: IDebugTerminalStatus) => void,
+  onOutput: (output: ArrayBuffer) => void
+): WebSocket => {
+  const url = new URL(
+    urlJoin(
+      "/",
+      "api",
+      cluster.subscription,
+      cluster.resourceGroup,
+      cluster.name,
+      "nodes",
+      node,
+      "debug"
+    ),
+    window.location.href
+  )
+  url.protocol = url.protocol === "https:" ? "wss:" : "ws:"
+
+  const ws = new WebSocket(url)
+  ws.binaryType = "arraybuffer"
+  ws.onmessage = (event: MessageEvent) => {
+    if (event.data instanceof ArrayBuffer) {
+      onOutput(event.data)
+    } else {
+      onStatus(JSON.parse(event.data))
+    }
+  }
+  return ws
+}
+
+export const fetchPromQuery = async (
+  cluster: IClusterCoordinates,
+  query: string,
+  start?: Date,
+  end?: Date,
+  step?: string
+): Promise<Response> => {
+  const url = new URL(
+    urlJoin(
+      "/",
+      "api",
+      cluster.subscription,
+      cluster.resourceGroup,
+      cluster.name,
+      "prometheus",
+      "query"
+    ),
+    window.location.href
+  )
+  url.searchParams.append("query", query)
+  if (start && step) {
+    url.searchParams.append("start", start.toJSON())
+    url.searchParams.append("step", step)
+    if (end) {
+      url.searchParams.append("end", end.toJSON())
+    }
+  }
+
+  return doFetch(url)
+}
+
+export const fetchPrometheusQueries = async (): Promise<Response> => {
+  return doFetch(urlJoin("/", "api", "prometheus", "queries"))
+}
+
+export const SavePrometheusQuery = async (
+  name: string,
+  query: string,
+  csrfToken: string
+): Promise<Response> => {
+  return doFetch(urlJoin("/", "api", "prometheus", "queries"), {
+    method: "POST",
+    body: JSON.stringify({ name: name, query: query }),
+    headers: {
+      "Content-Type": "application/json",
+      "X-CSRF-Token": csrfToken,
+    },
+  })
+}
+
+export const DeletePrometheusQuery = async (id: string, csrfToken: string): Promise<Response> => {
+  return doFetch(urlJoin("/", "api", "prometheus", "queries", id), {
+    method: "DELETE",
+    headers: {
+      "X-CSRF-Token": csrfToken,
+    },
+  })
+}
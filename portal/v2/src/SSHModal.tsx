@@ -35,6 +35,7 @@ const machineOptions = [
 
 type SSHModalProps = {
   csrfToken: MutableRefObject<string>
+  elevated: boolean
 }
 
 const theme = getTheme()
@@ -89,7 +90,7 @@ const iconButtonStyles = {
 const sshDocs: string =
   "https://msazure.visualstudio.com/AzureRedHatOpenShift/_wiki/wikis/ARO.wiki/136823/ARO-SRE-portal?anchor=ssh-(elevated)"
 
-export const SSHModal = forwardRef<any, SSHModalProps>(({ csrfToken }, ref) => {
+export const SSHModal = forwardRef<any, SSHModalProps>(({ csrfToken, elevated }, ref) => {
   const [isPopupVisible, { setTrue: showPopup, setFalse: hidePopup }] = useBoolean(false)
   const titleId = useId("title")
   const [update, { setTrue: requestSSH, setFalse: sshRequested }] = useBoolean(false)
@@ -149,6 +150,14 @@ export const SSHModal = forwardRef<any, SSHModalProps>(({ csrfToken }, ref) => {
     )
   }
 
+  const notElevatedBar = (): any => {
+    return (
+      <MessageBar messageBarType={MessageBarType.warning} isMultiline={true}>
+        SSH access requires the elevated role. Your account has read-only access to this portal.
+      </MessageBar>
+    )
+  }
+
   const selectionField = (): any => {
     return (
       <Stack tokens={{ childrenGap: 15 }}>
@@ -235,7 +244,7 @@ export const SSHModal = forwardRef<any, SSHModalProps>(({ csrfToken }, ref) => {
                 <a href={sshDocs}>SSH docs</a>.
               </p>
               {error && errorBar()}
-              {data ? dataResult() : selectionField()}
+              {!elevated ? notElevatedBar() : data ? dataResult() : selectionField()}
             </div>
           </Popup>
         </Layer>
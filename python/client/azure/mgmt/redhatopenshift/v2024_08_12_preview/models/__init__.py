@@ -26,6 +26,7 @@ try:
     from ._models_py3 import MachinePool
     from ._models_py3 import MachinePoolList
     from ._models_py3 import MachinePoolUpdate
+    from ._models_py3 import MaintenanceWindow
     from ._models_py3 import ManagedOutboundIPs
     from ._models_py3 import ManagedServiceIdentity
     from ._models_py3 import MasterProfile
@@ -58,6 +59,7 @@ try:
     from ._models_py3 import SyncSetUpdate
     from ._models_py3 import SystemData
     from ._models_py3 import TrackedResource
+    from ._models_py3 import UpgradeProfile
     from ._models_py3 import UserAssignedIdentity
     from ._models_py3 import WorkerProfile
 except (SyntaxError, ImportError):
@@ -72,6 +74,7 @@ except (SyntaxError, ImportError):
     from ._models import MachinePool  # type: ignore
     from ._models import MachinePoolList  # type: ignore
     from ._models import MachinePoolUpdate  # type: ignore
+    from ._models import MaintenanceWindow  # type: ignore
     from ._models import ManagedOutboundIPs  # type: ignore
     from ._models import ManagedServiceIdentity  # type: ignore
     from ._models import MasterProfile  # type: ignore
@@ -104,6 +107,7 @@ except (SyntaxError, ImportError):
     from ._models import SyncSetUpdate  # type: ignore
     from ._models import SystemData  # type: ignore
     from ._models import TrackedResource  # type: ignore
+    from ._models import UpgradeProfile  # type: ignore
     from ._models import UserAssignedIdentity  # type: ignore
     from ._models import WorkerProfile  # type: ignore
 
@@ -115,6 +119,7 @@ from ._azure_red_hat_open_shift_client_enums import (
     OutboundType,
     PreconfiguredNSG,
     ProvisioningState,
+    UpgradePolicy,
     Visibility,
 )
 
@@ -130,6 +135,7 @@ __all__ = [
     'MachinePool',
     'MachinePoolList',
     'MachinePoolUpdate',
+    'MaintenanceWindow',
     'ManagedOutboundIPs',
     'ManagedServiceIdentity',
     'MasterProfile',
@@ -162,6 +168,7 @@ __all__ = [
     'SyncSetUpdate',
     'SystemData',
     'TrackedResource',
+    'UpgradeProfile',
     'UserAssignedIdentity',
     'WorkerProfile',
     'CreatedByType',
@@ -171,5 +178,6 @@ __all__ = [
     'OutboundType',
     'PreconfiguredNSG',
     'ProvisioningState',
+    'UpgradePolicy',
     'Visibility',
 ]
@@ -78,6 +78,13 @@ class ProvisioningState(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
     SUCCEEDED = "Succeeded"
     UPDATING = "Updating"
 
+class UpgradePolicy(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+    """UpgradePolicy represents how the cluster is upgraded.
+    """
+
+    AUTOMATIC_PATCH = "AutomaticPatch"
+    MANUAL = "Manual"
+
 class Visibility(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
     """Visibility represents visibility.
     """
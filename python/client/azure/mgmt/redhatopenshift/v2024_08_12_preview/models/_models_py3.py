@@ -551,6 +551,49 @@ class MachinePoolUpdate(msrest.serialization.Model):
         self.resources = resources
 
 
+class MaintenanceWindow(msrest.serialization.Model):
+    """MaintenanceWindow represents a weekly window, in UTC, in which automatic upgrades may start.
+
+    :ivar day_of_week: The day of the week on which the window starts, e.g. Saturday.
+    :vartype day_of_week: str
+    :ivar start_hour: The hour, in UTC, at which the window starts.  Allowed values are in the range
+     of 0 - 23.
+    :vartype start_hour: int
+    :ivar duration_hours: The duration of the window in hours.  Allowed values are in the range of 1
+     - 24.
+    :vartype duration_hours: int
+    """
+
+    _attribute_map = {
+        'day_of_week': {'key': 'dayOfWeek', 'type': 'str'},
+        'start_hour': {'key': 'startHour', 'type': 'int'},
+        'duration_hours': {'key': 'durationHours', 'type': 'int'},
+    }
+
+    def __init__(
+        self,
+        *,
+        day_of_week: Optional[str] = None,
+        start_hour: Optional[int] = None,
+        duration_hours: Optional[int] = None,
+        **kwargs
+    ):
+        """
+        :keyword day_of_week: The day of the week on which the window starts, e.g. Saturday.
+        :paramtype day_of_week: str
+        :keyword start_hour: The hour, in UTC, at which the window starts.  Allowed values are in the
+         range of 0 - 23.
+        :paramtype start_hour: int
+        :keyword duration_hours: The duration of the window in hours.  Allowed values are in the range
+         of 1 - 24.
+        :paramtype duration_hours: int
+        """
+        super(MaintenanceWindow, self).__init__(**kwargs)
+        self.day_of_week = day_of_week
+        self.start_hour = start_hour
+        self.duration_hours = duration_hours
+
+
 class ManagedOutboundIPs(msrest.serialization.Model):
     """ManagedOutboundIPs represents the desired managed outbound IPs for the cluster public load balancer.
 
@@ -876,6 +919,8 @@ class OpenShiftCluster(TrackedResource):
     :ivar ingress_profiles: The cluster ingress profiles.
     :vartype ingress_profiles:
      list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.IngressProfile]
+    :ivar upgrade_profile: The cluster upgrade profile.
+    :vartype upgrade_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.UpgradeProfile
     """
 
     _validation = {
@@ -906,6 +951,7 @@ class OpenShiftCluster(TrackedResource):
         'worker_profiles_status': {'key': 'properties.workerProfilesStatus', 'type': '[WorkerProfile]'},
         'apiserver_profile': {'key': 'properties.apiserverProfile', 'type': 'APIServerProfile'},
         'ingress_profiles': {'key': 'properties.ingressProfiles', 'type': '[IngressProfile]'},
+        'upgrade_profile': {'key': 'properties.upgradeProfile', 'type': 'UpgradeProfile'},
     }
 
     def __init__(
@@ -924,6 +970,7 @@ class OpenShiftCluster(TrackedResource):
         worker_profiles: Optional[List["WorkerProfile"]] = None,
         apiserver_profile: Optional["APIServerProfile"] = None,
         ingress_profiles: Optional[List["IngressProfile"]] = None,
+        upgrade_profile: Optional["UpgradeProfile"] = None,
         **kwargs
     ):
         """
@@ -965,6 +1012,8 @@ class OpenShiftCluster(TrackedResource):
         :keyword ingress_profiles: The cluster ingress profiles.
         :paramtype ingress_profiles:
          list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.IngressProfile]
+        :keyword upgrade_profile: The cluster upgrade profile.
+        :paramtype upgrade_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.UpgradeProfile
         """
         super(OpenShiftCluster, self).__init__(tags=tags, location=location, **kwargs)
         self.identity = identity
@@ -979,6 +1028,7 @@ class OpenShiftCluster(TrackedResource):
         self.worker_profiles_status = None
         self.apiserver_profile = apiserver_profile
         self.ingress_profiles = ingress_profiles
+        self.upgrade_profile = upgrade_profile
 
 
 class OpenShiftClusterAdminKubeconfig(msrest.serialization.Model):
@@ -1111,6 +1161,8 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
     :ivar ingress_profiles: The cluster ingress profiles.
     :vartype ingress_profiles:
      list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.IngressProfile]
+    :ivar upgrade_profile: The cluster upgrade profile.
+    :vartype upgrade_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.UpgradeProfile
     """
 
     _validation = {
@@ -1131,6 +1183,7 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
         'worker_profiles_status': {'key': 'properties.workerProfilesStatus', 'type': '[WorkerProfile]'},
         'apiserver_profile': {'key': 'properties.apiserverProfile', 'type': 'APIServerProfile'},
         'ingress_profiles': {'key': 'properties.ingressProfiles', 'type': '[IngressProfile]'},
+        'upgrade_profile': {'key': 'properties.upgradeProfile', 'type': 'UpgradeProfile'},
     }
 
     def __init__(
@@ -1148,6 +1201,7 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
         worker_profiles: Optional[List["WorkerProfile"]] = None,
         apiserver_profile: Optional["APIServerProfile"] = None,
         ingress_profiles: Optional[List["IngressProfile"]] = None,
+        upgrade_profile: Optional["UpgradeProfile"] = None,
         **kwargs
     ):
         """
@@ -1187,6 +1241,8 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
         :keyword ingress_profiles: The cluster ingress profiles.
         :paramtype ingress_profiles:
          list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.IngressProfile]
+        :keyword upgrade_profile: The cluster upgrade profile.
+        :paramtype upgrade_profile: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.UpgradeProfile
         """
         super(OpenShiftClusterUpdate, self).__init__(**kwargs)
         self.tags = tags
@@ -1202,6 +1258,7 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
         self.worker_profiles_status = None
         self.apiserver_profile = apiserver_profile
         self.ingress_profiles = ingress_profiles
+        self.upgrade_profile = upgrade_profile
 
 
 class OpenShiftVersion(ProxyResource):
@@ -1222,6 +1279,14 @@ class OpenShiftVersion(ProxyResource):
     :vartype system_data: ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.SystemData
     :ivar version: Version represents the version to create the cluster at.
     :vartype version: str
+    :ivar ga_date: The date the version became generally available, in YYYY-MM-DD format.
+    :vartype ga_date: str
+    :ivar end_of_support_date: The date after which the version is no longer supported, in
+     YYYY-MM-DD format.
+    :vartype end_of_support_date: str
+    :ivar end_of_life_date: The date after which the version no longer receives any fixes, in
+     YYYY-MM-DD format.
+    :vartype end_of_life_date: str
     """
 
     _validation = {
@@ -1229,6 +1294,9 @@ class OpenShiftVersion(ProxyResource):
         'name': {'readonly': True},
         'type': {'readonly': True},
         'system_data': {'readonly': True},
+        'ga_date': {'readonly': True},
+        'end_of_support_date': {'readonly': True},
+        'end_of_life_date': {'readonly': True},
     }
 
     _attribute_map = {
@@ -1237,6 +1305,9 @@ class OpenShiftVersion(ProxyResource):
         'type': {'key': 'type', 'type': 'str'},
         'system_data': {'key': 'systemData', 'type': 'SystemData'},
         'version': {'key': 'properties.version', 'type': 'str'},
+        'ga_date': {'key': 'properties.gaDate', 'type': 'str'},
+        'end_of_support_date': {'key': 'properties.endOfSupportDate', 'type': 'str'},
+        'end_of_life_date': {'key': 'properties.endOfLifeDate', 'type': 'str'},
     }
 
     def __init__(
@@ -1251,6 +1322,9 @@ class OpenShiftVersion(ProxyResource):
         """
         super(OpenShiftVersion, self).__init__(**kwargs)
         self.version = version
+        self.ga_date = None
+        self.end_of_support_date = None
+        self.end_of_life_date = None
 
 
 class OpenShiftVersionList(msrest.serialization.Model):
@@ -1990,6 +2064,55 @@ class SystemData(msrest.serialization.Model):
         self.last_modified_at = last_modified_at
 
 
+class UpgradeProfile(msrest.serialization.Model):
+    """UpgradeProfile represents the upgrade policy of a cluster.
+
+    Variables are only populated by the server, and will be ignored when sending a request.
+
+    :ivar policy: The upgrade policy.  AutomaticPatch clusters are upgraded to the latest patch
+     release of their minor version within their maintenance windows. Possible values include:
+     "AutomaticPatch", "Manual".
+    :vartype policy: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.UpgradePolicy
+    :ivar maintenance_windows: The weekly windows in which automatic upgrades may start.
+    :vartype maintenance_windows:
+     list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MaintenanceWindow]
+    :ivar automatic_upgrades_paused_reason: The reason automatic upgrades are paused, e.g. because
+     an automatic upgrade failed.
+    :vartype automatic_upgrades_paused_reason: str
+    """
+
+    _validation = {
+        'automatic_upgrades_paused_reason': {'readonly': True},
+    }
+
+    _attribute_map = {
+        'policy': {'key': 'policy', 'type': 'str'},
+        'maintenance_windows': {'key': 'maintenanceWindows', 'type': '[MaintenanceWindow]'},
+        'automatic_upgrades_paused_reason': {'key': 'automaticUpgradesPausedReason', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        policy: Optional[Union[str, "UpgradePolicy"]] = None,
+        maintenance_windows: Optional[List["MaintenanceWindow"]] = None,
+        **kwargs
+    ):
+        """
+        :keyword policy: The upgrade policy.  AutomaticPatch clusters are upgraded to the latest patch
+         release of their minor version within their maintenance windows. Possible values include:
+         "AutomaticPatch", "Manual".
+        :paramtype policy: str or ~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.UpgradePolicy
+        :keyword maintenance_windows: The weekly windows in which automatic upgrades may start.
+        :paramtype maintenance_windows:
+         list[~azure.mgmt.redhatopenshift.v2024_08_12_preview.models.MaintenanceWindow]
+        """
+        super(UpgradeProfile, self).__init__(**kwargs)
+        self.policy = policy
+        self.maintenance_windows = maintenance_windows
+        self.automatic_upgrades_paused_reason = None
+
+
 class UserAssignedIdentity(msrest.serialization.Model):
     """User assigned identity properties.
 
@@ -0,0 +1,34 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+)
+
+func injectAsyncOperations(c *cosmosdb.FakeAsyncOperationDocumentClient) {
+	c.SetQueryHandler(database.AsyncOperationsForClusterQuery, fakeAsyncOperationsForClusterQuery)
+}
+
+func fakeAsyncOperationsForClusterQuery(client cosmosdb.AsyncOperationDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.AsyncOperationDocumentRawIterator {
+	input, err := client.ListAll(context.Background(), nil)
+	if err != nil {
+		return cosmosdb.NewFakeAsyncOperationDocumentErroringRawIterator(err)
+	}
+
+	key := query.Parameters[0].Value
+
+	var results []*api.AsyncOperationDocument
+	for _, r := range input.AsyncOperationDocuments {
+		if r.OpenShiftClusterKey == key {
+			results = append(results, r)
+		}
+	}
+
+	return cosmosdb.NewFakeAsyncOperationDocumentIterator(results, 0)
+}
@@ -26,6 +26,7 @@ type Checker struct {
 	gatewayDocuments                         []*api.GatewayDocument
 	openShiftVersionDocuments                []*api.OpenShiftVersionDocument
 	platformWorkloadIdentityRoleSetDocuments []*api.PlatformWorkloadIdentityRoleSetDocument
+	supportedVMSizeDocuments                 []*api.SupportedVMSizeDocument
 	validationResult                         []*api.ValidationResult
 	maintenanceManifestDocuments             []*api.MaintenanceManifestDocument
 }
@@ -43,6 +44,7 @@ func (f *Checker) Clear() {
 	f.gatewayDocuments = []*api.GatewayDocument{}
 	f.openShiftVersionDocuments = []*api.OpenShiftVersionDocument{}
 	f.platformWorkloadIdentityRoleSetDocuments = []*api.PlatformWorkloadIdentityRoleSetDocument{}
+	f.supportedVMSizeDocuments = []*api.SupportedVMSizeDocument{}
 	f.validationResult = []*api.ValidationResult{}
 	f.maintenanceManifestDocuments = []*api.MaintenanceManifestDocument{}
 }
@@ -135,6 +137,17 @@ func (f *Checker) AddPlatformWorkloadIdentityRoleSetDocuments(docs ...*api.Platf
 	}
 }
 
+func (f *Checker) AddSupportedVMSizeDocuments(docs ...*api.SupportedVMSizeDocument) {
+	for _, doc := range docs {
+		docCopy, err := deepCopy(doc)
+		if err != nil {
+			panic(err)
+		}
+
+		f.supportedVMSizeDocuments = append(f.supportedVMSizeDocuments, docCopy.(*api.SupportedVMSizeDocument))
+	}
+}
+
 func (f *Checker) AddValidationResult(docs ...*api.ValidationResult) {
 	for _, doc := range docs {
 		docCopy, err := deepCopy(doc)
@@ -256,6 +269,7 @@ func (f *Checker) CheckPortals(portals *cosmosdb.FakePortalDocumentClient) (errs
 	if err != nil {
 		return []error{err}
 	}
+	sort.Slice(all.PortalDocuments, func(i, j int) bool { return all.PortalDocuments[i].ID < all.PortalDocuments[j].ID })
 
 	if len(f.portalDocuments) != 0 && len(all.PortalDocuments) == len(f.portalDocuments) {
 		diff := deep.Equal(all.PortalDocuments, f.portalDocuments)
@@ -335,6 +349,28 @@ func (f *Checker) CheckPlatformWorkloadIdentityRoleSets(roleSets *cosmosdb.FakeP
 	return errs
 }
 
+func (f *Checker) CheckSupportedVMSizes(vmSizes *cosmosdb.FakeSupportedVMSizeDocumentClient) (errs []error) {
+	ctx := context.Background()
+
+	all, err := vmSizes.ListAll(ctx, nil)
+	if err != nil {
+		return []error{err}
+	}
+
+	sort.Slice(all.SupportedVMSizeDocuments, func(i, j int) bool { return all.SupportedVMSizeDocuments[i].ID < all.SupportedVMSizeDocuments[j].ID })
+
+	if len(f.supportedVMSizeDocuments) != 0 && len(all.SupportedVMSizeDocuments) == len(f.supportedVMSizeDocuments) {
+		diff := deep.Equal(all.SupportedVMSizeDocuments, f.supportedVMSizeDocuments)
+		for _, i := range diff {
+			errs = append(errs, errors.New(i))
+		}
+	} else if len(all.SupportedVMSizeDocuments) != 0 || len(f.supportedVMSizeDocuments) != 0 {
+		errs = append(errs, fmt.Errorf("supported VM sizes length different, %d vs %d", len(all.SupportedVMSizeDocuments), len(f.supportedVMSizeDocuments)))
+	}
+
+	return errs
+}
+
 func (f *Checker) CheckMaintenanceManifests(client *cosmosdb.FakeMaintenanceManifestDocumentClient) (errs []error) {
 	ctx := context.Background()
 
@@ -20,6 +20,7 @@ type Fixture struct {
 	gatewayDocuments                         []*api.GatewayDocument
 	openShiftVersionDocuments                []*api.OpenShiftVersionDocument
 	platformWorkloadIdentityRoleSetDocuments []*api.PlatformWorkloadIdentityRoleSetDocument
+	supportedVMSizeDocuments                 []*api.SupportedVMSizeDocument
 	clusterManagerConfigurationDocuments     []*api.ClusterManagerConfigurationDocument
 	maintenanceManifestDocuments             []*api.MaintenanceManifestDocument
 
@@ -31,11 +32,13 @@ type Fixture struct {
 	gatewayDatabase                          database.Gateway
 	openShiftVersionsDatabase                database.OpenShiftVersions
 	platformWorkloadIdentityRoleSetsDatabase database.PlatformWorkloadIdentityRoleSets
+	supportedVMSizesDatabase                 database.SupportedVMSizes
 	clusterManagerConfigurationsDatabase     database.ClusterManagerConfigurations
 	maintenanceManifestsDatabase             database.MaintenanceManifests
 
 	openShiftVersionsUUID                uuid.Generator
 	platformWorkloadIdentityRoleSetsUUID uuid.Generator
+	supportedVMSizesUUID                 uuid.Generator
 }
 
 func NewFixture() *Fixture {
@@ -52,6 +55,7 @@ func (f *Fixture) Clear() {
 	f.openShiftVersionDocuments = []*api.OpenShiftVersionDocument{}
 	f.clusterManagerConfigurationDocuments = []*api.ClusterManagerConfigurationDocument{}
 	f.platformWorkloadIdentityRoleSetDocuments = []*api.PlatformWorkloadIdentityRoleSetDocument{}
+	f.supportedVMSizeDocuments = []*api.SupportedVMSizeDocument{}
 	f.maintenanceManifestDocuments = []*api.MaintenanceManifestDocument{}
 }
 
@@ -102,6 +106,12 @@ func (f *Fixture) WithPlatformWorkloadIdentityRoleSets(db database.PlatformWorkl
 	return f
 }
 
+func (f *Fixture) WithSupportedVMSizes(db database.SupportedVMSizes, uuid uuid.Generator) *Fixture {
+	f.supportedVMSizesDatabase = db
+	f.supportedVMSizesUUID = uuid
+	return f
+}
+
 func (f *Fixture) WithMaintenanceManifests(db database.MaintenanceManifests) *Fixture {
 	f.maintenanceManifestsDatabase = db
 	return f
@@ -195,6 +205,17 @@ func (f *Fixture) AddPlatformWorkloadIdentityRoleSetDocuments(docs ...*api.Platf
 	}
 }
 
+func (f *Fixture) AddSupportedVMSizeDocuments(docs ...*api.SupportedVMSizeDocument) {
+	for _, doc := range docs {
+		docCopy, err := deepCopy(doc)
+		if err != nil {
+			panic(err)
+		}
+
+		f.supportedVMSizeDocuments = append(f.supportedVMSizeDocuments, docCopy.(*api.SupportedVMSizeDocument))
+	}
+}
+
 func (f *Fixture) AddClusterManagerConfigurationDocuments(docs ...*api.ClusterManagerConfigurationDocument) {
 	for _, doc := range docs {
 		docCopy, err := deepCopy(doc)
@@ -285,6 +306,16 @@ func (f *Fixture) Create() error {
 		}
 	}
 
+	for _, i := range f.supportedVMSizeDocuments {
+		if i.ID == "" {
+			i.ID = f.supportedVMSizesDatabase.NewUUID()
+		}
+		_, err := f.supportedVMSizesDatabase.Create(ctx, i)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, i := range f.clusterManagerConfigurationDocuments {
 		if i.ID == "" {
 			i.ID = f.clusterManagerConfigurationsDatabase.NewUUID()
@@ -29,7 +29,14 @@ func NewFakeOpenShiftClusters() (db database.OpenShiftClusters, client *cosmosdb
 	coll := &fakeCollectionClient{}
 	client = cosmosdb.NewFakeOpenShiftClusterDocumentClient(jsonHandle)
 	injectOpenShiftClusters(client)
-	db = database.NewOpenShiftClustersWithProvidedClient(client, coll, "", uuid)
+	lookupClient := cosmosdb.NewFakeClusterLookupDocumentClient(jsonHandle)
+	db = database.NewOpenShiftClustersWithProvidedClient(client, lookupClient, coll, "", uuid)
+	return db, client
+}
+
+func NewFakeOpenShiftClusterTombstones() (db database.OpenShiftClusterTombstones, client *cosmosdb.FakeOpenShiftClusterDocumentClient) {
+	client = cosmosdb.NewFakeOpenShiftClusterDocumentClient(jsonHandle)
+	db = database.NewOpenShiftClusterTombstonesWithProvidedClient(client, database.DefaultOpenShiftClusterTombstoneRetention)
 	return db, client
 }
 
@@ -50,6 +57,7 @@ func NewFakeBilling() (db database.Billing, client *cosmosdb.FakeBillingDocument
 func NewFakeAsyncOperations() (db database.AsyncOperations, client *cosmosdb.FakeAsyncOperationDocumentClient) {
 	uuid := deterministicuuid.NewTestUUIDGenerator(deterministicuuid.ASYNCOPERATIONS)
 	client = cosmosdb.NewFakeAsyncOperationDocumentClient(jsonHandle)
+	injectAsyncOperations(client)
 	db = database.NewAsyncOperationsWithProvidedClient(client, uuid)
 	return db, client
 }
@@ -57,6 +65,7 @@ func NewFakeAsyncOperations() (db database.AsyncOperations, client *cosmosdb.Fak
 func NewFakePortal() (db database.Portal, client *cosmosdb.FakePortalDocumentClient) {
 	uuid := deterministicuuid.NewTestUUIDGenerator(deterministicuuid.PORTAL)
 	client = cosmosdb.NewFakePortalDocumentClient(jsonHandle)
+	injectPortal(client)
 	db = database.NewPortalWithProvidedClient(client, uuid)
 	return db, client
 }
@@ -80,6 +89,12 @@ func NewFakePlatformWorkloadIdentityRoleSets(uuid uuid.Generator) (db database.P
 	return db, client
 }
 
+func NewFakeSupportedVMSizes(uuid uuid.Generator) (db database.SupportedVMSizes, client *cosmosdb.FakeSupportedVMSizeDocumentClient) {
+	client = cosmosdb.NewFakeSupportedVMSizeDocumentClient(jsonHandle)
+	db = database.NewSupportedVMSizesWithProvidedClient(client, uuid)
+	return db, client
+}
+
 func NewFakeClusterManager() (db database.ClusterManagerConfigurations, client *cosmosdb.FakeClusterManagerConfigurationDocumentClient) {
 	uuid := deterministicuuid.NewTestUUIDGenerator(deterministicuuid.CLUSTERMANAGER)
 	client = cosmosdb.NewFakeClusterManagerConfigurationDocumentClient(jsonHandle)
@@ -173,6 +173,65 @@ func fakeOpenShiftClustersOnlyResourceID(client cosmosdb.OpenShiftClusterDocumen
 	return cosmosdb.NewFakeOpenShiftClusterDocumentIterator(newDocs, startingIndex)
 }
 
+func fakeOpenShiftClustersSearchQuery(client cosmosdb.OpenShiftClusterDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.OpenShiftClusterDocumentRawIterator {
+	startingIndex, err := fakeOpenShiftClustersGetContinuation(options)
+	if err != nil {
+		return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+	}
+
+	docs, err := fakeOpenShiftClustersGetAllDocuments(client)
+	if err != nil {
+		return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+	}
+
+	params := map[string]string{}
+	for _, p := range query.Parameters {
+		params[p.Name] = p.Value
+	}
+
+	var results []*api.OpenShiftClusterDocument
+	for _, r := range docs {
+		props := r.OpenShiftCluster.Properties
+
+		if params["@version"] != "" && !strings.HasPrefix(props.ClusterProfile.Version, params["@version"]) ||
+			params["@location"] != "" && r.OpenShiftCluster.Location != params["@location"] ||
+			params["@provisioningState"] != "" && string(props.ProvisioningState) != params["@provisioningState"] ||
+			params["@lastError"] != "" && !strings.Contains(strings.ToLower(props.LastAdminUpdateError), strings.ToLower(params["@lastError"])) {
+			continue
+		}
+
+		results = append(results, r)
+	}
+
+	return cosmosdb.NewFakeOpenShiftClusterDocumentIterator(results, startingIndex)
+}
+
+func fakeOpenShiftClustersOutdatedQuery(client cosmosdb.OpenShiftClusterDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.OpenShiftClusterDocumentRawIterator {
+	startingIndex, err := fakeOpenShiftClustersGetContinuation(options)
+	if err != nil {
+		return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+	}
+
+	docs, err := fakeOpenShiftClustersGetAllDocuments(client)
+	if err != nil {
+		return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+	}
+
+	schemaVersion, err := strconv.Atoi(query.Parameters[0].Value)
+	if err != nil {
+		return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+	}
+
+	var results []*api.OpenShiftClusterDocument
+	for _, r := range docs {
+		if r.SchemaVersion < schemaVersion {
+			results = append(results, r)
+		}
+	}
+
+	return cosmosdb.NewFakeOpenShiftClusterDocumentIterator(results, startingIndex)
+}
+
 func injectOpenShiftClusters(c *cosmosdb.FakeOpenShiftClusterDocumentClient) {
 	c.SetQueryHandler(database.OpenShiftClustersDequeueQuery, fakeOpenShiftClustersDequeueQuery)
 	c.SetQueryHandler(database.OpenShiftClustersQueueLengthQuery, fakeOpenShiftClustersQueueLengthQuery)
@@ -181,6 +240,8 @@ func injectOpenShiftClusters(c *cosmosdb.FakeOpenShiftClusterDocumentClient) {
 	c.SetQueryHandler(database.OpenshiftClustersResourceGroupQuery, fakeOpenshiftClustersMatchQuery)
 	c.SetQueryHandler(database.OpenshiftClustersPrefixQuery, fakeOpenshiftClustersPrefixQuery)
 	c.SetQueryHandler(database.OpenshiftClustersClusterResourceIDOnlyQuery, fakeOpenShiftClustersOnlyResourceID)
+	c.SetQueryHandler(database.OpenShiftClustersSearchQuery, fakeOpenShiftClustersSearchQuery)
+	c.SetQueryHandler(database.OpenShiftClustersOutdatedQuery, fakeOpenShiftClustersOutdatedQuery)
 
 	c.SetTriggerHandler("renewLease", fakeOpenShiftClustersRenewLeaseTrigger)
 
@@ -0,0 +1,51 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+)
+
+func injectPortal(c *cosmosdb.FakePortalDocumentClient) {
+	c.SetQueryHandler(database.PortalSSHSessionsQuery, fakePortalSSHSessionsQuery)
+	c.SetQueryHandler(database.PortalPrometheusQueriesQuery, fakePortalPrometheusQueriesQuery)
+}
+
+func fakePortalSSHSessionsQuery(client cosmosdb.PortalDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.PortalDocumentRawIterator {
+	input, err := client.ListAll(context.Background(), nil)
+	if err != nil {
+		return cosmosdb.NewFakePortalDocumentErroringRawIterator(err)
+	}
+
+	resourceID := query.Parameters[0].Value
+
+	var results []*api.PortalDocument
+	for _, r := range input.PortalDocuments {
+		if r.Portal.ID == resourceID && r.Portal.SSHSession != nil {
+			results = append(results, r)
+		}
+	}
+
+	return cosmosdb.NewFakePortalDocumentIterator(results, 0)
+}
+
+func fakePortalPrometheusQueriesQuery(client cosmosdb.PortalDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.PortalDocumentRawIterator {
+	input, err := client.ListAll(context.Background(), nil)
+	if err != nil {
+		return cosmosdb.NewFakePortalDocumentErroringRawIterator(err)
+	}
+
+	var results []*api.PortalDocument
+	for _, r := range input.PortalDocuments {
+		if r.Portal.PrometheusQuery != nil {
+			results = append(results, r)
+		}
+	}
+
+	return cosmosdb.NewFakePortalDocumentIterator(results, 0)
+}
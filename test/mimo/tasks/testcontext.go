@@ -13,6 +13,7 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/cincinnati"
 	"github.com/Azure/ARO-RP/pkg/util/clienthelper"
 )
 
@@ -23,6 +24,8 @@ type fakeTestContext struct {
 	ch  clienthelper.Interface
 	log *logrus.Entry
 
+	upgradeGraph cincinnati.Client
+
 	clusterUUID       string
 	clusterResourceID string
 	properties        api.OpenShiftClusterProperties
@@ -38,6 +41,12 @@ func WithClientHelper(ch clienthelper.Interface) Option {
 	}
 }
 
+func WithUpgradeGraph(g cincinnati.Client) Option {
+	return func(ftc *fakeTestContext) {
+		ftc.upgradeGraph = g
+	}
+}
+
 func WithOpenShiftClusterDocument(oc *api.OpenShiftClusterDocument) Option {
 	return func(ftc *fakeTestContext) {
 		ftc.clusterUUID = oc.ID
@@ -75,6 +84,13 @@ func (t *fakeTestContext) GetOpenshiftClusterDocument() *api.OpenShiftClusterDoc
 	return myCD
 }
 
+func (t *fakeTestContext) UpgradeGraph() cincinnati.Client {
+	if t.upgradeGraph == nil {
+		panic("didn't set up upgrade graph in test")
+	}
+	return t.upgradeGraph
+}
+
 // handle
 
 func (t *fakeTestContext) Environment() env.Interface {
@@ -10,6 +10,7 @@ import (
 	"k8s.io/client-go/rest"
 
 	"github.com/Azure/ARO-RP/pkg/util/liveconfig"
+	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
 type testLiveConfig struct {
@@ -32,6 +33,18 @@ func (t *testLiveConfig) AdoptByHive(ctx context.Context) (bool, error) {
 	return t.adoptByHive, nil
 }
 
+func (t *testLiveConfig) DeprovisionViaHive(ctx context.Context) (bool, error) {
+	return false, nil
+}
+
+func (t *testLiveConfig) OperatorViaHive(ctx context.Context) (bool, error) {
+	return false, nil
+}
+
+func (t *testLiveConfig) HiveShardWeights(ctx context.Context) (map[int]int, error) {
+	return map[int]int{1: 1}, nil
+}
+
 func (t *testLiveConfig) DefaultInstallerPullSpecOverride(ctx context.Context) string {
 	if t.installViaHive {
 		return "example/pull:spec"
@@ -39,6 +52,10 @@ func (t *testLiveConfig) DefaultInstallerPullSpecOverride(ctx context.Context) s
 	return ""
 }
 
+func (t *testLiveConfig) SidecarImages(ctx context.Context) (version.SidecarImages, error) {
+	return version.DefaultSidecarImages, nil
+}
+
 func NewTestLiveConfig(adoptByHive, installViaHive bool) liveconfig.Manager {
 	return &testLiveConfig{
 		adoptByHive:    adoptByHive,